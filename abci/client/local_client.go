@@ -352,5 +352,7 @@ func (app *localClient) callback(req *types.Request, res *types.Response) *ReqRe
 func newLocalReqRes(req *types.Request, res *types.Response) *ReqRes {
 	reqRes := NewReqRes(req)
 	reqRes.Response = res
+	// the request is already answered, so waiting for it must not block
+	reqRes.Done()
 	return reqRes
 }
@@ -0,0 +1,608 @@
+package abcicli
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+const (
+	defaultReconnectMinBackoff = 100 * time.Millisecond
+	defaultReconnectMaxBackoff = 10 * time.Second
+)
+
+// ReconnectOption sets an optional parameter on the reconnecting client.
+type ReconnectOption func(*reconnectClient)
+
+// ReconnectWithBackoff sets the delays before the first attempt to reconnect
+// to the application, doubled after every failed attempt up to max.
+func ReconnectWithBackoff(min, max time.Duration) ReconnectOption {
+	return func(cli *reconnectClient) {
+		cli.minBackoff = min
+		cli.maxBackoff = max
+	}
+}
+
+// ReconnectWithMaxAttempts stops the client with an error after n consecutive
+// failed attempts to reconnect to the application. 0, the default, retries
+// indefinitely.
+func ReconnectWithMaxAttempts(n int) ReconnectOption {
+	return func(cli *reconnectClient) { cli.maxAttempts = n }
+}
+
+// ReconnectWithHook calls hook after every attempt to reconnect to the
+// application, with the error of the attempt, if any.
+func ReconnectWithHook(hook func(err error)) ReconnectOption {
+	return func(cli *reconnectClient) { cli.hook = hook }
+}
+
+// reconnectClient is a Client which, when the connection to the application is
+// lost, reconnects to it with an exponential backoff and replays the requests
+// in flight.
+//
+// Since the application may have processed the requests in flight before the
+// connection was lost, it must only be used for the connections whose requests
+// are safe to replay, i.e. the query and mempool connections. A request is
+// replayed at most once, in case it is the one which crashed the application.
+type reconnectClient struct {
+	service.BaseService
+
+	newClient   func() (Client, error)
+	mustConnect bool
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+	hook        func(error)
+
+	// sendMtx serializes the requests sent to the application, so that the
+	// replayed requests are sent before the new ones.
+	sendMtx cmtsync.Mutex
+
+	mtx    cmtsync.Mutex
+	client Client
+	// closed once client is connected, and replaced when it is disconnected
+	connected chan struct{}
+	err       error
+	resCb     Callback
+	// the asynchronous requests which were not answered yet
+	pending *list.List
+}
+
+var _ Client = (*reconnectClient)(nil)
+
+// pendingReqRes is an asynchronous request which was not answered yet.
+type pendingReqRes struct {
+	reqRes   *ReqRes
+	send     func(Client) *ReqRes
+	replayed bool
+	done     bool
+}
+
+// NewReconnectingClient returns a Client connected to the application with
+// the clients returned by newClient, which must fail to start if they can't
+// connect. When the connection is lost, the client reconnects and replays the
+// requests in flight, so it must only be used for the connections whose
+// requests are safe to replay: the query and mempool connections. If
+// mustConnect is true, the client returns an error upon start if it fails to
+// connect.
+func NewReconnectingClient(newClient func() (Client, error), mustConnect bool, options ...ReconnectOption) Client {
+	cli := &reconnectClient{
+		newClient:   newClient,
+		mustConnect: mustConnect,
+		minBackoff:  defaultReconnectMinBackoff,
+		maxBackoff:  defaultReconnectMaxBackoff,
+		connected:   make(chan struct{}),
+		pending:     list.New(),
+	}
+	cli.BaseService = *service.NewBaseService(nil, "reconnectClient", cli)
+	for _, option := range options {
+		option(cli)
+	}
+	return cli
+}
+
+// OnStart implements Service by connecting to the application.
+func (cli *reconnectClient) OnStart() error {
+	backoff := cli.minBackoff
+	for {
+		c, err := cli.connect()
+		if err == nil {
+			cli.reconnected(c)
+			go cli.reconnectRoutine(c)
+			return nil
+		}
+		if cli.mustConnect {
+			return err
+		}
+		cli.Logger.Error("Failed to connect to the application, retrying", "in", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-cli.Quit():
+			return errors.New("client stopped")
+		}
+		backoff = cli.nextBackoff(backoff)
+	}
+}
+
+// OnStop implements Service by stopping the client of the current connection,
+// and releasing the requests which were not answered.
+func (cli *reconnectClient) OnStop() {
+	cli.mtx.Lock()
+	c := cli.client
+	var pending []*pendingReqRes
+	for e := cli.pending.Front(); e != nil; e = e.Next() {
+		pending = append(pending, e.Value.(*pendingReqRes))
+	}
+	cli.pending.Init()
+	cli.mtx.Unlock()
+
+	if c != nil && c.IsRunning() {
+		if err := c.Stop(); err != nil {
+			cli.Logger.Error("Error stopping the ABCI client", "err", err)
+		}
+	}
+	for _, p := range pending {
+		p.reqRes.Done()
+	}
+}
+
+// Error returns an error if the client was stopped abruptly.
+func (cli *reconnectClient) Error() error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.err
+}
+
+// SetResponseCallback sets a callback, which will be executed for each
+// non-error & non-empty response from the server, including the replayed ones.
+func (cli *reconnectClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	cli.resCb = resCb
+	c := cli.client
+	cli.mtx.Unlock()
+	if c != nil {
+		c.SetResponseCallback(filterEmptyResponses(resCb))
+	}
+}
+
+func (cli *reconnectClient) connect() (Client, error) {
+	c, err := cli.newClient()
+	if err != nil {
+		return nil, err
+	}
+	c.SetLogger(cli.Logger)
+	cli.mtx.Lock()
+	if cli.resCb != nil {
+		c.SetResponseCallback(filterEmptyResponses(cli.resCb))
+	}
+	cli.mtx.Unlock()
+	if err := c.Start(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// reconnectRoutine reconnects to the application once the connection of c is
+// lost.
+func (cli *reconnectClient) reconnectRoutine(c Client) {
+	for {
+		select {
+		case <-c.Quit():
+		case <-cli.Quit():
+			return
+		}
+		cli.disconnected(c)
+		cli.Logger.Error("Lost the connection to the application, reconnecting", "err", c.Error())
+
+		backoff := cli.minBackoff
+		for attempt := 1; ; attempt++ {
+			select {
+			case <-time.After(backoff):
+			case <-cli.Quit():
+				return
+			}
+			newC, err := cli.connect()
+			if cli.hook != nil {
+				cli.hook(err)
+			}
+			if err == nil {
+				cli.Logger.Info("Reconnected to the application", "attempts", attempt)
+				c = newC
+				cli.reconnected(c)
+				break
+			}
+			if cli.maxAttempts > 0 && attempt >= cli.maxAttempts {
+				cli.stopForError(fmt.Errorf("failed to reconnect to the application after %d attempts: %w",
+					attempt, err))
+				return
+			}
+			cli.Logger.Error("Failed to reconnect to the application", "attempt", attempt, "err", err)
+			backoff = cli.nextBackoff(backoff)
+		}
+	}
+}
+
+func (cli *reconnectClient) nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > cli.maxBackoff {
+		backoff = cli.maxBackoff
+	}
+	return backoff
+}
+
+// disconnected marks the connection of c as lost, if not already.
+func (cli *reconnectClient) disconnected(c Client) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if cli.client == c && isClosed(cli.connected) {
+		cli.connected = make(chan struct{})
+	}
+}
+
+// reconnected makes c the client of the connection, and replays the requests
+// which were not answered before the connection was lost.
+func (cli *reconnectClient) reconnected(c Client) {
+	cli.sendMtx.Lock()
+	defer cli.sendMtx.Unlock()
+
+	cli.mtx.Lock()
+	cli.client = c
+	close(cli.connected)
+	var replayed, dropped []*list.Element
+	for e := cli.pending.Front(); e != nil; e = e.Next() {
+		p := e.Value.(*pendingReqRes)
+		if p.replayed {
+			dropped = append(dropped, e)
+			continue
+		}
+		p.replayed = true
+		replayed = append(replayed, e)
+	}
+	for _, e := range dropped {
+		e.Value.(*pendingReqRes).done = true
+		cli.pending.Remove(e)
+	}
+	cli.mtx.Unlock()
+
+	// like in the other clients, the requests which are not answered are
+	// released without response
+	for _, e := range dropped {
+		e.Value.(*pendingReqRes).reqRes.Done()
+	}
+	if len(replayed) > 0 {
+		cli.Logger.Info("Replaying the requests in flight", "requests", len(replayed))
+	}
+	for _, e := range replayed {
+		cli.send(c, e)
+	}
+}
+
+// connectedClient returns the client of the connection, waiting until it is
+// connected.
+func (cli *reconnectClient) connectedClient() (Client, error) {
+	cli.mtx.Lock()
+	connected := cli.connected
+	cli.mtx.Unlock()
+
+	select {
+	case <-connected:
+	case <-cli.Quit():
+		if err := cli.Error(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("client stopped")
+	}
+
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.client, nil
+}
+
+// sync makes a synchronous call, and replays it once reconnected if the
+// connection was lost during the call.
+func (cli *reconnectClient) sync(call func(Client) error) error {
+	replayed := false
+	for {
+		c, err := cli.connectedClient()
+		if err != nil {
+			return err
+		}
+		err = call(c)
+		if err == nil || c.IsRunning() || replayed {
+			return err
+		}
+		cli.disconnected(c)
+		cli.Logger.Info("Replaying a request after the connection was lost", "err", err)
+		replayed = true
+	}
+}
+
+// async sends an asynchronous request, or queues it to be sent once
+// reconnected if the connection is lost.
+func (cli *reconnectClient) async(req *types.Request, send func(Client) *ReqRes) *ReqRes {
+	p := &pendingReqRes{reqRes: NewReqRes(req), send: send}
+
+	cli.sendMtx.Lock()
+	defer cli.sendMtx.Unlock()
+
+	cli.mtx.Lock()
+	c := cli.client
+	connected := isClosed(cli.connected)
+	e := cli.pending.PushBack(p)
+	cli.mtx.Unlock()
+
+	if connected && c.IsRunning() {
+		cli.send(c, e)
+	}
+	return p.reqRes
+}
+
+func (cli *reconnectClient) send(c Client, e *list.Element) {
+	p := e.Value.(*pendingReqRes)
+	p.send(c).SetCallback(func(res *types.Response) {
+		// the requests whose connection was lost are replayed
+		if isEmptyResponse(res) {
+			return
+		}
+		cli.mtx.Lock()
+		if p.done {
+			cli.mtx.Unlock()
+			return
+		}
+		p.done = true
+		cli.pending.Remove(e)
+		cli.mtx.Unlock()
+
+		p.reqRes.Response = res
+		p.reqRes.Done()
+		p.reqRes.InvokeCallback()
+	})
+}
+
+func (cli *reconnectClient) stopForError(err error) {
+	cli.mtx.Lock()
+	if cli.err == nil {
+		cli.err = err
+	}
+	cli.mtx.Unlock()
+
+	cli.Logger.Error("Stopping the reconnecting ABCI client", "err", err)
+	if err := cli.Stop(); err != nil {
+		cli.Logger.Error("Error stopping the reconnecting ABCI client", "err", err)
+	}
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEmptyResponse reports whether res carries no response, like the ones of
+// the calls of the gRPC client which failed.
+func isEmptyResponse(res *types.Response) bool {
+	if res == nil || res.Value == nil {
+		return true
+	}
+	v := reflect.ValueOf(res.Value).Elem()
+	return v.NumField() > 0 && v.Field(0).Kind() == reflect.Ptr && v.Field(0).IsNil()
+}
+
+func filterEmptyResponses(resCb Callback) Callback {
+	return func(req *types.Request, res *types.Response) {
+		if !isEmptyResponse(res) {
+			resCb(req, res)
+		}
+	}
+}
+
+//----------------------------------------
+
+func (cli *reconnectClient) FlushAsync() *ReqRes {
+	return cli.async(types.ToRequestFlush(), func(c Client) *ReqRes { return c.FlushAsync() })
+}
+
+func (cli *reconnectClient) EchoAsync(msg string) *ReqRes {
+	return cli.async(types.ToRequestEcho(msg), func(c Client) *ReqRes { return c.EchoAsync(msg) })
+}
+
+func (cli *reconnectClient) InfoAsync(req types.RequestInfo) *ReqRes {
+	return cli.async(types.ToRequestInfo(req), func(c Client) *ReqRes { return c.InfoAsync(req) })
+}
+
+func (cli *reconnectClient) DeliverTxAsync(req types.RequestDeliverTx) *ReqRes {
+	return cli.async(types.ToRequestDeliverTx(req), func(c Client) *ReqRes { return c.DeliverTxAsync(req) })
+}
+
+func (cli *reconnectClient) CheckTxAsync(req types.RequestCheckTx) *ReqRes {
+	return cli.async(types.ToRequestCheckTx(req), func(c Client) *ReqRes { return c.CheckTxAsync(req) })
+}
+
+func (cli *reconnectClient) QueryAsync(req types.RequestQuery) *ReqRes {
+	return cli.async(types.ToRequestQuery(req), func(c Client) *ReqRes { return c.QueryAsync(req) })
+}
+
+func (cli *reconnectClient) CommitAsync() *ReqRes {
+	return cli.async(types.ToRequestCommit(), func(c Client) *ReqRes { return c.CommitAsync() })
+}
+
+func (cli *reconnectClient) InitChainAsync(req types.RequestInitChain) *ReqRes {
+	return cli.async(types.ToRequestInitChain(req), func(c Client) *ReqRes { return c.InitChainAsync(req) })
+}
+
+func (cli *reconnectClient) PrepareProposalAsync(req types.RequestPrepareProposal) *ReqRes {
+	return cli.async(types.ToRequestPrepareProposal(req), func(c Client) *ReqRes { return c.PrepareProposalAsync(req) })
+}
+
+func (cli *reconnectClient) ProcessProposalAsync(req types.RequestProcessProposal) *ReqRes {
+	return cli.async(types.ToRequestProcessProposal(req), func(c Client) *ReqRes { return c.ProcessProposalAsync(req) })
+}
+
+func (cli *reconnectClient) BeginBlockAsync(req types.RequestBeginBlock) *ReqRes {
+	return cli.async(types.ToRequestBeginBlock(req), func(c Client) *ReqRes { return c.BeginBlockAsync(req) })
+}
+
+func (cli *reconnectClient) EndBlockAsync(req types.RequestEndBlock) *ReqRes {
+	return cli.async(types.ToRequestEndBlock(req), func(c Client) *ReqRes { return c.EndBlockAsync(req) })
+}
+
+func (cli *reconnectClient) ListSnapshotsAsync(req types.RequestListSnapshots) *ReqRes {
+	return cli.async(types.ToRequestListSnapshots(req), func(c Client) *ReqRes { return c.ListSnapshotsAsync(req) })
+}
+
+func (cli *reconnectClient) OfferSnapshotAsync(req types.RequestOfferSnapshot) *ReqRes {
+	return cli.async(types.ToRequestOfferSnapshot(req), func(c Client) *ReqRes { return c.OfferSnapshotAsync(req) })
+}
+
+func (cli *reconnectClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) *ReqRes {
+	return cli.async(types.ToRequestLoadSnapshotChunk(req), func(c Client) *ReqRes {
+		return c.LoadSnapshotChunkAsync(req)
+	})
+}
+
+func (cli *reconnectClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) *ReqRes {
+	return cli.async(types.ToRequestApplySnapshotChunk(req), func(c Client) *ReqRes {
+		return c.ApplySnapshotChunkAsync(req)
+	})
+}
+
+//----------------------------------------
+
+func (cli *reconnectClient) FlushSync() error {
+	return cli.sync(func(c Client) error { return c.FlushSync() })
+}
+
+func (cli *reconnectClient) EchoSync(msg string) (res *types.ResponseEcho, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.EchoSync(msg)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) InfoSync(req types.RequestInfo) (res *types.ResponseInfo, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.InfoSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) DeliverTxSync(req types.RequestDeliverTx) (res *types.ResponseDeliverTx, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.DeliverTxSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) CheckTxSync(req types.RequestCheckTx) (res *types.ResponseCheckTx, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.CheckTxSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) QuerySync(req types.RequestQuery) (res *types.ResponseQuery, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.QuerySync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) CommitSync() (res *types.ResponseCommit, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.CommitSync()
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) InitChainSync(req types.RequestInitChain) (res *types.ResponseInitChain, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.InitChainSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) PrepareProposalSync(
+	req types.RequestPrepareProposal) (res *types.ResponsePrepareProposal, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.PrepareProposalSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) ProcessProposalSync(
+	req types.RequestProcessProposal) (res *types.ResponseProcessProposal, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.ProcessProposalSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) BeginBlockSync(req types.RequestBeginBlock) (res *types.ResponseBeginBlock, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.BeginBlockSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) EndBlockSync(req types.RequestEndBlock) (res *types.ResponseEndBlock, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.EndBlockSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) ListSnapshotsSync(
+	req types.RequestListSnapshots) (res *types.ResponseListSnapshots, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.ListSnapshotsSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) OfferSnapshotSync(
+	req types.RequestOfferSnapshot) (res *types.ResponseOfferSnapshot, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.OfferSnapshotSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) LoadSnapshotChunkSync(
+	req types.RequestLoadSnapshotChunk) (res *types.ResponseLoadSnapshotChunk, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.LoadSnapshotChunkSync(req)
+		return err
+	})
+	return res, err
+}
+
+func (cli *reconnectClient) ApplySnapshotChunkSync(
+	req types.RequestApplySnapshotChunk) (res *types.ResponseApplySnapshotChunk, err error) {
+	err = cli.sync(func(c Client) (err error) {
+		res, err = c.ApplySnapshotChunkSync(req)
+		return err
+	})
+	return res, err
+}
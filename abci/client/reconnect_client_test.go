@@ -0,0 +1,104 @@
+package abcicli_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/server"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+func TestReconnectingClient(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", 20000+cmtrand.Int32()%10000)
+	s := startServer(t, addr)
+
+	reconnects := make(chan error, 10)
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) { return abcicli.NewSocketClient(addr, true), nil },
+		true,
+		abcicli.ReconnectWithBackoff(10*time.Millisecond, 50*time.Millisecond),
+		abcicli.ReconnectWithHook(func(err error) { reconnects <- err }),
+	)
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	res, err := c.EchoSync("before")
+	require.NoError(t, err)
+	assert.Equal(t, "before", res.Message)
+
+	// the application restarts
+	require.NoError(t, s.Stop())
+	require.Eventually(t, func() bool { return len(reconnects) > 0 }, time.Second, 5*time.Millisecond)
+	require.Error(t, <-reconnects)
+
+	// the requests sent while disconnected are sent once reconnected
+	reqRes := c.CheckTxAsync(types.RequestCheckTx{Tx: []byte("tx")})
+	echo := make(chan *types.ResponseEcho, 1)
+	go func() {
+		res, err := c.EchoSync("after")
+		assert.NoError(t, err)
+		echo <- res
+	}()
+
+	s = startServer(t, addr)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	select {
+	case res := <-echo:
+		assert.Equal(t, "after", res.Message)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "No response arrived")
+	}
+	require.NoError(t, c.FlushSync())
+	reqRes.Wait()
+	require.NotNil(t, reqRes.Response.GetCheckTx())
+	assert.Equal(t, types.CodeTypeOK, reqRes.Response.GetCheckTx().Code)
+	assert.NoError(t, c.Error())
+}
+
+func TestReconnectingClientMaxAttempts(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", 20000+cmtrand.Int32()%10000)
+	s := startServer(t, addr)
+
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) { return abcicli.NewSocketClient(addr, true), nil },
+		true,
+		abcicli.ReconnectWithBackoff(10*time.Millisecond, 10*time.Millisecond),
+		abcicli.ReconnectWithMaxAttempts(2),
+	)
+	require.NoError(t, c.Start())
+
+	require.NoError(t, s.Stop())
+	select {
+	case <-c.Quit():
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "The client did not stop")
+	}
+	require.Error(t, c.Error())
+
+	_, err := c.EchoSync("stopped")
+	require.Error(t, err)
+}
+
+func startServer(t *testing.T, addr string) service.Service {
+	t.Helper()
+	s, err := server.NewServer(addr, "socket", types.NewBaseApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	return s
+}
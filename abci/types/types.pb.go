@@ -611,6 +611,11 @@ type RequestInitChain struct {
 	Validators      []ValidatorUpdate       `protobuf:"bytes,4,rep,name=validators,proto3" json:"validators"`
 	AppStateBytes   []byte                  `protobuf:"bytes,5,opt,name=app_state_bytes,json=appStateBytes,proto3" json:"app_state_bytes,omitempty"`
 	InitialHeight   int64                   `protobuf:"varint,6,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
+	// Set when the app_state is sent in several InitChain requests, each with
+	// a chunk of it in app_state_bytes: the number of chunks, and the index of
+	// the chunk of this request.
+	AppStateChunks     uint32 `protobuf:"varint,7,opt,name=app_state_chunks,json=appStateChunks,proto3" json:"app_state_chunks,omitempty"`
+	AppStateChunkIndex uint32 `protobuf:"varint,8,opt,name=app_state_chunk_index,json=appStateChunkIndex,proto3" json:"app_state_chunk_index,omitempty"`
 }
 
 func (m *RequestInitChain) Reset()         { *m = RequestInitChain{} }
@@ -688,6 +693,20 @@ func (m *RequestInitChain) GetInitialHeight() int64 {
 	return 0
 }
 
+func (m *RequestInitChain) GetAppStateChunks() uint32 {
+	if m != nil {
+		return m.AppStateChunks
+	}
+	return 0
+}
+
+func (m *RequestInitChain) GetAppStateChunkIndex() uint32 {
+	if m != nil {
+		return m.AppStateChunkIndex
+	}
+	return 0
+}
+
 type RequestQuery struct {
 	Data   []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -1420,7 +1439,6 @@ func (m *RequestProcessProposal) GetProposerAddress() []byte {
 
 type Response struct {
 	// Types that are valid to be assigned to Value:
-	//
 	//	*Response_Exception
 	//	*Response_Echo
 	//	*Response_Flush
@@ -2122,6 +2140,19 @@ type ResponseCheckTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// priority is used by the mempool to order transactions when
+	// mempool.prioritized is enabled. Higher values are reaped first.
+	Priority int64 `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	// sender and sequence identify the position of the transaction in the
+	// sender's lane when mempool.sender_lanes is enabled.
+	Sender   string `protobuf:"bytes,13,opt,name=sender,proto3" json:"sender,omitempty"`
+	Sequence uint64 `protobuf:"varint,14,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// fee is the fee paid by the transaction, which the mempool compares to the
+	// fee per byte floors of the peers, see mempool.gossip_min_fee_per_byte.
+	Fee int64 `protobuf:"varint,15,opt,name=fee,proto3" json:"fee,omitempty"`
+	// lane is the priority lane of the transaction, reaped first into the block
+	// space reserved for the lane, see mempool.priority_lanes.
+	Lane string `protobuf:"bytes,16,opt,name=lane,proto3" json:"lane,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
@@ -2213,6 +2244,41 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetLane() string {
+	if m != nil {
+		return m.Lane
+	}
+	return ""
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -3435,195 +3501,199 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2995 bytes of a gzipped FileDescriptorProto
+	// 3071 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x3b, 0x73, 0x23, 0xc7,
-	0xf1, 0xc7, 0xfb, 0xd1, 0x78, 0x2d, 0xe7, 0x28, 0x0a, 0x07, 0x9d, 0x48, 0x6a, 0x55, 0x92, 0xee,
-	0x4e, 0x12, 0xa9, 0x3f, 0xf5, 0x3f, 0x3d, 0x4a, 0x96, 0x2d, 0x00, 0x87, 0x33, 0x78, 0xa4, 0x48,
-	0x7a, 0x09, 0x9e, 0x4a, 0x7e, 0xdc, 0x6a, 0x01, 0x0c, 0x89, 0xd5, 0x01, 0xbb, 0xab, 0xdd, 0x01,
-	0x05, 0x2a, 0xb4, 0xca, 0x55, 0x2e, 0x95, 0x03, 0x85, 0x4a, 0x14, 0x38, 0x70, 0xe2, 0x4f, 0xe0,
-	0xc8, 0x91, 0x03, 0x05, 0x0e, 0x14, 0x38, 0x70, 0x24, 0xbb, 0xa4, 0xcc, 0x5f, 0xc0, 0x81, 0x03,
-	0xbb, 0xe6, 0xb1, 0x2f, 0x00, 0x4b, 0x80, 0x92, 0xcb, 0x55, 0x2e, 0x67, 0x33, 0xbd, 0xdd, 0x3d,
-	0x33, 0x3d, 0x33, 0xdd, 0xfd, 0xeb, 0x1d, 0x78, 0x82, 0x60, 0xa3, 0x8f, 0xed, 0x91, 0x6e, 0x90,
-	0x6d, 0xad, 0xdb, 0xd3, 0xb7, 0xc9, 0x85, 0x85, 0x9d, 0x2d, 0xcb, 0x36, 0x89, 0x89, 0x2a, 0xfe,
-	0xc7, 0x2d, 0xfa, 0xb1, 0xf6, 0x64, 0x80, 0xbb, 0x67, 0x5f, 0x58, 0xc4, 0xdc, 0xb6, 0x6c, 0xd3,
-	0x3c, 0xe5, 0xfc, 0xb5, 0x1b, 0x81, 0xcf, 0x4c, 0x4f, 0x50, 0x5b, 0xe8, 0xab, 0x10, 0x7e, 0x84,
-	0x2f, 0xdc, 0xaf, 0x4f, 0xce, 0xc8, 0x5a, 0x9a, 0xad, 0x8d, 0xdc, 0xcf, 0x1b, 0x67, 0xa6, 0x79,
-	0x36, 0xc4, 0xdb, 0xac, 0xd7, 0x1d, 0x9f, 0x6e, 0x13, 0x7d, 0x84, 0x1d, 0xa2, 0x8d, 0x2c, 0xc1,
-	0xb0, 0x7a, 0x66, 0x9e, 0x99, 0xac, 0xb9, 0x4d, 0x5b, 0x9c, 0x2a, 0xff, 0x33, 0x07, 0x59, 0x05,
-	0x7f, 0x30, 0xc6, 0x0e, 0x41, 0x3b, 0x90, 0xc2, 0xbd, 0x81, 0x59, 0x8d, 0x6f, 0xc6, 0x6f, 0x16,
-	0x76, 0x6e, 0x6c, 0x4d, 0x2d, 0x6e, 0x4b, 0xf0, 0xb5, 0x7a, 0x03, 0xb3, 0x1d, 0x53, 0x18, 0x2f,
-	0xba, 0x03, 0xe9, 0xd3, 0xe1, 0xd8, 0x19, 0x54, 0x13, 0x4c, 0xe8, 0xc9, 0x28, 0xa1, 0x7b, 0x94,
-	0xa9, 0x1d, 0x53, 0x38, 0x37, 0x1d, 0x4a, 0x37, 0x4e, 0xcd, 0x6a, 0xf2, 0xf2, 0xa1, 0x76, 0x8d,
-	0x53, 0x36, 0x14, 0xe5, 0x45, 0x0d, 0x00, 0xdd, 0xd0, 0x89, 0xda, 0x1b, 0x68, 0xba, 0x51, 0x4d,
-	0x33, 0xc9, 0xa7, 0xa2, 0x25, 0x75, 0xd2, 0xa4, 0x8c, 0xed, 0x98, 0x92, 0xd7, 0xdd, 0x0e, 0x9d,
-	0xee, 0x07, 0x63, 0x6c, 0x5f, 0x54, 0x33, 0x97, 0x4f, 0xf7, 0x47, 0x94, 0x89, 0x4e, 0x97, 0x71,
-	0xa3, 0x16, 0x14, 0xba, 0xf8, 0x4c, 0x37, 0xd4, 0xee, 0xd0, 0xec, 0x3d, 0xaa, 0x66, 0x99, 0xb0,
-	0x1c, 0x25, 0xdc, 0xa0, 0xac, 0x0d, 0xca, 0xd9, 0x8e, 0x29, 0xd0, 0xf5, 0x7a, 0xe8, 0x7b, 0x90,
-	0xeb, 0x0d, 0x70, 0xef, 0x91, 0x4a, 0x26, 0xd5, 0x1c, 0xd3, 0xb1, 0x11, 0xa5, 0xa3, 0x49, 0xf9,
-	0x3a, 0x93, 0x76, 0x4c, 0xc9, 0xf6, 0x78, 0x93, 0xae, 0xbf, 0x8f, 0x87, 0xfa, 0x39, 0xb6, 0xa9,
-	0x7c, 0xfe, 0xf2, 0xf5, 0xdf, 0xe5, 0x9c, 0x4c, 0x43, 0xbe, 0xef, 0x76, 0xd0, 0x0f, 0x20, 0x8f,
-	0x8d, 0xbe, 0x58, 0x06, 0x30, 0x15, 0x9b, 0x91, 0xfb, 0x6c, 0xf4, 0xdd, 0x45, 0xe4, 0xb0, 0x68,
-	0xa3, 0xd7, 0x20, 0xd3, 0x33, 0x47, 0x23, 0x9d, 0x54, 0x0b, 0x4c, 0x7a, 0x3d, 0x72, 0x01, 0x8c,
-	0xab, 0x1d, 0x53, 0x04, 0x3f, 0x3a, 0x80, 0xf2, 0x50, 0x77, 0x88, 0xea, 0x18, 0x9a, 0xe5, 0x0c,
-	0x4c, 0xe2, 0x54, 0x8b, 0x4c, 0xc3, 0x33, 0x51, 0x1a, 0xf6, 0x75, 0x87, 0x1c, 0xbb, 0xcc, 0xed,
-	0x98, 0x52, 0x1a, 0x06, 0x09, 0x54, 0x9f, 0x79, 0x7a, 0x8a, 0x6d, 0x4f, 0x61, 0xb5, 0x74, 0xb9,
-	0xbe, 0x43, 0xca, 0xed, 0xca, 0x53, 0x7d, 0x66, 0x90, 0x80, 0x7e, 0x02, 0xd7, 0x86, 0xa6, 0xd6,
-	0xf7, 0xd4, 0xa9, 0xbd, 0xc1, 0xd8, 0x78, 0x54, 0x2d, 0x33, 0xa5, 0xb7, 0x22, 0x27, 0x69, 0x6a,
-	0x7d, 0x57, 0x45, 0x93, 0x0a, 0xb4, 0x63, 0xca, 0xca, 0x70, 0x9a, 0x88, 0x1e, 0xc2, 0xaa, 0x66,
-	0x59, 0xc3, 0x8b, 0x69, 0xed, 0x15, 0xa6, 0xfd, 0x76, 0x94, 0xf6, 0x3a, 0x95, 0x99, 0x56, 0x8f,
-	0xb4, 0x19, 0x2a, 0xea, 0x80, 0x64, 0xd9, 0xd8, 0xd2, 0x6c, 0xac, 0x5a, 0xb6, 0x69, 0x99, 0x8e,
-	0x36, 0xac, 0x4a, 0x4c, 0xf7, 0x73, 0x51, 0xba, 0x8f, 0x38, 0xff, 0x91, 0x60, 0x6f, 0xc7, 0x94,
-	0x8a, 0x15, 0x26, 0x71, 0xad, 0x66, 0x0f, 0x3b, 0x8e, 0xaf, 0x75, 0x65, 0x91, 0x56, 0xc6, 0x1f,
-	0xd6, 0x1a, 0x22, 0x35, 0xb2, 0x90, 0x3e, 0xd7, 0x86, 0x63, 0x7c, 0x3f, 0x95, 0x4b, 0x49, 0x69,
-	0xf9, 0x39, 0x28, 0x04, 0x1c, 0x0b, 0xaa, 0x42, 0x76, 0x84, 0x1d, 0x47, 0x3b, 0xc3, 0xcc, 0x0f,
-	0xe5, 0x15, 0xb7, 0x2b, 0x97, 0xa1, 0x18, 0x74, 0x26, 0xf2, 0xa7, 0x71, 0x4f, 0x92, 0xfa, 0x09,
-	0x2a, 0x79, 0x8e, 0x6d, 0x47, 0x37, 0x0d, 0x57, 0x52, 0x74, 0xd1, 0xd3, 0x50, 0x62, 0x27, 0x5e,
-	0x75, 0xbf, 0x53, 0x67, 0x95, 0x52, 0x8a, 0x8c, 0xf8, 0x40, 0x30, 0x6d, 0x40, 0xc1, 0xda, 0xb1,
-	0x3c, 0x96, 0x24, 0x63, 0x01, 0x6b, 0xc7, 0x72, 0x19, 0x9e, 0x82, 0x22, 0x5d, 0xa9, 0xc7, 0x91,
-	0x62, 0x83, 0x14, 0x28, 0x4d, 0xb0, 0xc8, 0x7f, 0x4c, 0x80, 0x34, 0xed, 0x80, 0xd0, 0x6b, 0x90,
-	0xa2, 0xbe, 0x58, 0xb8, 0xd5, 0xda, 0x16, 0x77, 0xd4, 0x5b, 0xae, 0xa3, 0xde, 0xea, 0xb8, 0x8e,
-	0xba, 0x91, 0xfb, 0xe2, 0xab, 0x8d, 0xd8, 0xa7, 0x7f, 0xd9, 0x88, 0x2b, 0x4c, 0x02, 0x5d, 0xa7,
-	0xfe, 0x42, 0xd3, 0x0d, 0x55, 0xef, 0xb3, 0x29, 0xe7, 0xa9, 0x33, 0xd0, 0x74, 0x63, 0xb7, 0x8f,
-	0xf6, 0x41, 0xea, 0x99, 0x86, 0x83, 0x0d, 0x67, 0xec, 0xa8, 0x3c, 0x10, 0x08, 0x67, 0x1a, 0x72,
-	0x09, 0x3c, 0xbc, 0x34, 0x5d, 0xce, 0x23, 0xc6, 0xa8, 0x54, 0x7a, 0x61, 0x02, 0xba, 0x07, 0x70,
-	0xae, 0x0d, 0xf5, 0xbe, 0x46, 0x4c, 0xdb, 0xa9, 0xa6, 0x36, 0x93, 0x73, 0xfd, 0xc2, 0x03, 0x97,
-	0xe5, 0xc4, 0xea, 0x6b, 0x04, 0x37, 0x52, 0x74, 0xba, 0x4a, 0x40, 0x12, 0x3d, 0x0b, 0x15, 0xcd,
-	0xb2, 0x54, 0x87, 0x68, 0x04, 0xab, 0xdd, 0x0b, 0x82, 0x1d, 0xe6, 0xa7, 0x8b, 0x4a, 0x49, 0xb3,
-	0xac, 0x63, 0x4a, 0x6d, 0x50, 0x22, 0x7a, 0x06, 0xca, 0xd4, 0x27, 0xeb, 0xda, 0x50, 0x1d, 0x60,
-	0xfd, 0x6c, 0x40, 0x98, 0x3f, 0x4e, 0x2a, 0x25, 0x41, 0x6d, 0x33, 0xa2, 0xdc, 0xf7, 0x76, 0x9c,
-	0xf9, 0x63, 0x84, 0x20, 0xd5, 0xd7, 0x88, 0xc6, 0x2c, 0x59, 0x54, 0x58, 0x9b, 0xd2, 0x2c, 0x8d,
-	0x0c, 0x84, 0x7d, 0x58, 0x1b, 0xad, 0x41, 0x46, 0xa8, 0x4d, 0x32, 0xb5, 0xa2, 0x87, 0x56, 0x21,
-	0x6d, 0xd9, 0xe6, 0x39, 0x66, 0x5b, 0x97, 0x53, 0x78, 0x47, 0xfe, 0x38, 0x01, 0x2b, 0x33, 0x9e,
-	0x9b, 0xea, 0x1d, 0x68, 0xce, 0xc0, 0x1d, 0x8b, 0xb6, 0xd1, 0x2b, 0x54, 0xaf, 0xd6, 0xc7, 0xb6,
-	0x88, 0x76, 0xd5, 0x59, 0x53, 0xb7, 0xd9, 0x77, 0x61, 0x1a, 0xc1, 0x8d, 0xf6, 0x40, 0x1a, 0x6a,
-	0x0e, 0x51, 0xb9, 0x27, 0x54, 0x03, 0x91, 0xef, 0x89, 0x19, 0x23, 0x73, 0xbf, 0x49, 0x0f, 0xb4,
-	0x50, 0x52, 0xa6, 0xa2, 0x3e, 0x15, 0x9d, 0xc0, 0x6a, 0xf7, 0xe2, 0x23, 0xcd, 0x20, 0xba, 0x81,
-	0xd5, 0x99, 0x5d, 0x9b, 0x0d, 0xa5, 0x6f, 0xeb, 0x4e, 0x17, 0x0f, 0xb4, 0x73, 0xdd, 0x74, 0xa7,
-	0x75, 0xcd, 0x93, 0xf7, 0x76, 0xd4, 0x91, 0x15, 0x28, 0x87, 0x43, 0x0f, 0x2a, 0x43, 0x82, 0x4c,
-	0xc4, 0xfa, 0x13, 0x64, 0x82, 0x5e, 0x82, 0x14, 0x5d, 0x23, 0x5b, 0x7b, 0x79, 0xce, 0x40, 0x42,
-	0xae, 0x73, 0x61, 0x61, 0x85, 0x71, 0xca, 0xb2, 0x77, 0x1b, 0xbc, 0x70, 0x34, 0xad, 0x55, 0xbe,
-	0x05, 0x95, 0xa9, 0x78, 0x13, 0xd8, 0xbe, 0x78, 0x70, 0xfb, 0xe4, 0x0a, 0x94, 0x42, 0xc1, 0x45,
-	0x5e, 0x83, 0xd5, 0x79, 0xb1, 0x42, 0x1e, 0x78, 0xf4, 0x90, 0xcf, 0x47, 0x77, 0x20, 0xe7, 0x05,
-	0x0b, 0x7e, 0x1b, 0xaf, 0xcf, 0xac, 0xc2, 0x65, 0x56, 0x3c, 0x56, 0x7a, 0x0d, 0xe9, 0xa9, 0x66,
-	0xc7, 0x21, 0xc1, 0x26, 0x9e, 0xd5, 0x2c, 0xab, 0xad, 0x39, 0x03, 0xf9, 0x3d, 0xa8, 0x46, 0x05,
-	0x82, 0xa9, 0x65, 0xa4, 0xbc, 0x53, 0xb8, 0x06, 0x99, 0x53, 0xd3, 0x1e, 0x69, 0x84, 0x29, 0x2b,
-	0x29, 0xa2, 0x47, 0x4f, 0x27, 0x0f, 0x0a, 0x49, 0x46, 0xe6, 0x1d, 0x59, 0x85, 0xeb, 0x91, 0xc1,
-	0x80, 0x8a, 0xe8, 0x46, 0x1f, 0x73, 0x7b, 0x96, 0x14, 0xde, 0xf1, 0x15, 0xf1, 0xc9, 0xf2, 0x0e,
-	0x1d, 0xd6, 0x61, 0x6b, 0x65, 0xfa, 0xf3, 0x8a, 0xe8, 0xc9, 0x9f, 0x25, 0x61, 0x6d, 0x7e, 0x48,
-	0x40, 0x9b, 0x50, 0x1c, 0x69, 0x13, 0x95, 0x4c, 0xc4, 0x5d, 0xe6, 0xdb, 0x01, 0x23, 0x6d, 0xd2,
-	0x99, 0xf0, 0x8b, 0x2c, 0x41, 0x92, 0x4c, 0x9c, 0x6a, 0x62, 0x33, 0x79, 0xb3, 0xa8, 0xd0, 0x26,
-	0x3a, 0x81, 0x95, 0xa1, 0xd9, 0xd3, 0x86, 0x6a, 0xe0, 0xc4, 0x8b, 0xc3, 0xfe, 0xf4, 0x8c, 0xb1,
-	0x5b, 0x13, 0x46, 0xe9, 0xcf, 0x1c, 0xfa, 0x0a, 0xd3, 0xb1, 0xef, 0x9d, 0x7c, 0x74, 0x17, 0x0a,
-	0x23, 0xff, 0x20, 0x5f, 0xe1, 0xb0, 0x07, 0xc5, 0x02, 0x5b, 0x92, 0x0e, 0x39, 0x06, 0xd7, 0x45,
-	0x67, 0xae, 0xec, 0xa2, 0x5f, 0x82, 0x55, 0x03, 0x4f, 0x48, 0xe0, 0x22, 0xf2, 0x73, 0x92, 0x65,
-	0xa6, 0x47, 0xf4, 0x9b, 0x7f, 0xc9, 0xe8, 0x91, 0x41, 0xb7, 0x58, 0x50, 0xb5, 0x4c, 0x07, 0xdb,
-	0xaa, 0xd6, 0xef, 0xdb, 0xd8, 0x71, 0x58, 0x32, 0x58, 0x64, 0x91, 0x92, 0xd1, 0xeb, 0x9c, 0x2c,
-	0xff, 0x32, 0xb8, 0x35, 0xa1, 0x20, 0xea, 0x1a, 0x3e, 0xee, 0x1b, 0xfe, 0x18, 0x56, 0x85, 0x7c,
-	0x3f, 0x64, 0xfb, 0xc4, 0xb2, 0x8e, 0x06, 0xb9, 0xe2, 0xd1, 0x66, 0x4f, 0x7e, 0x3b, 0xb3, 0xbb,
-	0xbe, 0x34, 0x15, 0xf0, 0xa5, 0xff, 0x65, 0x5b, 0xf1, 0xa7, 0x3c, 0xe4, 0x14, 0xec, 0x58, 0x34,
-	0x70, 0xa2, 0x06, 0xe4, 0xf1, 0xa4, 0x87, 0x2d, 0xe2, 0xe6, 0x1a, 0xf3, 0xc1, 0x00, 0xe7, 0x6e,
-	0xb9, 0x9c, 0x34, 0x13, 0xf7, 0xc4, 0xd0, 0xcb, 0x02, 0x6c, 0x45, 0xe3, 0x26, 0x21, 0x1e, 0x44,
-	0x5b, 0xaf, 0xb8, 0x68, 0x2b, 0x19, 0x99, 0x7c, 0x73, 0xa9, 0x29, 0xb8, 0xf5, 0xb2, 0x80, 0x5b,
-	0xa9, 0x05, 0x83, 0x85, 0xf0, 0x56, 0x33, 0x84, 0xb7, 0x32, 0x0b, 0x96, 0x19, 0x01, 0xb8, 0x5e,
-	0x71, 0x01, 0x57, 0x76, 0xc1, 0x8c, 0xa7, 0x10, 0xd7, 0xbd, 0x30, 0xe2, 0xca, 0x45, 0x38, 0x10,
-	0x57, 0x3a, 0x12, 0x72, 0xbd, 0x19, 0x80, 0x5c, 0xf9, 0x48, 0xbc, 0xc3, 0x95, 0xcc, 0xc1, 0x5c,
-	0xcd, 0x10, 0xe6, 0x82, 0x05, 0x36, 0x88, 0x00, 0x5d, 0x6f, 0x05, 0x41, 0x57, 0x21, 0x12, 0xb7,
-	0x89, 0xfd, 0x9e, 0x87, 0xba, 0x5e, 0xf7, 0x50, 0x57, 0x31, 0x12, 0x36, 0x8a, 0x35, 0x4c, 0xc3,
-	0xae, 0xc3, 0x19, 0xd8, 0xc5, 0x61, 0xd2, 0xb3, 0x91, 0x2a, 0x16, 0xe0, 0xae, 0xc3, 0x19, 0xdc,
-	0x55, 0x5e, 0xa0, 0x70, 0x01, 0xf0, 0xfa, 0xe9, 0x7c, 0xe0, 0x15, 0x0d, 0x8d, 0xc4, 0x34, 0x97,
-	0x43, 0x5e, 0x6a, 0x04, 0xf2, 0xe2, 0xe8, 0xe8, 0xf9, 0x48, 0xf5, 0x4b, 0x43, 0xaf, 0x93, 0x39,
-	0xd0, 0x8b, 0x83, 0xa4, 0x9b, 0x91, 0xca, 0x97, 0xc0, 0x5e, 0x27, 0x73, 0xb0, 0x17, 0x5a, 0xa8,
-	0xf6, 0x2a, 0xe0, 0x2b, 0x2d, 0x65, 0xe4, 0x5b, 0x34, 0xf5, 0x9d, 0xf2, 0x53, 0x34, 0x7f, 0xc0,
-	0xb6, 0x6d, 0xda, 0x02, 0x46, 0xf1, 0x8e, 0x7c, 0x93, 0x26, 0xe3, 0xbe, 0x4f, 0xba, 0x04, 0xa8,
-	0xb1, 0x3c, 0x2d, 0xe0, 0x87, 0xe4, 0xdf, 0xc5, 0x7d, 0x59, 0x96, 0xc3, 0x06, 0x13, 0xf9, 0xbc,
-	0x48, 0xe4, 0x03, 0xf0, 0x2d, 0x11, 0x86, 0x6f, 0x1b, 0x50, 0xa0, 0xf9, 0xd7, 0x14, 0x32, 0xd3,
-	0x2c, 0x0f, 0x99, 0xdd, 0x86, 0x15, 0x16, 0xf1, 0x38, 0xc8, 0x13, 0x61, 0x25, 0xc5, 0xc2, 0x4a,
-	0x85, 0x7e, 0xe0, 0x17, 0x8a, 0xc7, 0x97, 0x17, 0xe1, 0x5a, 0x80, 0xd7, 0xcb, 0xeb, 0x38, 0x4c,
-	0x91, 0x3c, 0xee, 0xba, 0x48, 0xf0, 0xfe, 0x10, 0xf7, 0x2d, 0xe4, 0x43, 0xba, 0x79, 0xe8, 0x2b,
-	0xfe, 0x6f, 0x42, 0x5f, 0x89, 0x6f, 0x8d, 0xbe, 0x82, 0x79, 0x6a, 0x32, 0x9c, 0xa7, 0xfe, 0x3d,
-	0xee, 0xef, 0x89, 0x87, 0xa5, 0x7a, 0x66, 0x1f, 0x8b, 0xcc, 0x91, 0xb5, 0x69, 0x52, 0x31, 0x34,
-	0xcf, 0x44, 0x7e, 0x48, 0x9b, 0x94, 0xcb, 0x0b, 0x1c, 0x79, 0x11, 0x17, 0xbc, 0xa4, 0x93, 0x07,
-	0x6e, 0x91, 0x74, 0x4a, 0x90, 0x7c, 0x84, 0x79, 0x5d, 0xad, 0xa8, 0xd0, 0x26, 0xe5, 0x63, 0x47,
-	0x4d, 0x04, 0x60, 0xde, 0x41, 0xaf, 0x41, 0x9e, 0x55, 0x44, 0x55, 0xd3, 0x72, 0x84, 0x5b, 0x0f,
-	0xe5, 0x26, 0xbc, 0xf0, 0xb9, 0x75, 0x44, 0x79, 0x0e, 0x2d, 0x47, 0xc9, 0x59, 0xa2, 0x15, 0xc8,
-	0x18, 0xf2, 0xa1, 0x8c, 0xe1, 0x06, 0xe4, 0xe9, 0xec, 0x1d, 0x4b, 0xeb, 0x61, 0xe6, 0xa2, 0xf3,
-	0x8a, 0x4f, 0x90, 0x1f, 0x02, 0x9a, 0x0d, 0x12, 0xa8, 0x0d, 0x19, 0x7c, 0x8e, 0x0d, 0xc2, 0x33,
-	0xa8, 0xc2, 0xce, 0xda, 0x6c, 0x6a, 0x4a, 0x3f, 0x37, 0xaa, 0xd4, 0xc8, 0x7f, 0xfb, 0x6a, 0x43,
-	0xe2, 0xdc, 0x2f, 0x98, 0x23, 0x9d, 0xe0, 0x91, 0x45, 0x2e, 0x14, 0x21, 0x2f, 0xff, 0x36, 0x41,
-	0x01, 0x4c, 0x28, 0x80, 0xcc, 0xb5, 0xad, 0x7b, 0xe4, 0x13, 0x01, 0xec, 0xba, 0x9c, 0xbd, 0xd7,
-	0x01, 0xce, 0x34, 0x47, 0xfd, 0x50, 0x33, 0x08, 0xee, 0x0b, 0xa3, 0x07, 0x28, 0xa8, 0x06, 0x39,
-	0xda, 0x1b, 0x3b, 0xb8, 0x2f, 0x60, 0xb4, 0xd7, 0x0f, 0xac, 0x33, 0xfb, 0xdd, 0xd6, 0x19, 0xb6,
-	0x72, 0x6e, 0xca, 0xca, 0xf7, 0x53, 0xb9, 0xbc, 0x54, 0x74, 0x21, 0x05, 0xdd, 0x33, 0xdd, 0xb4,
-	0x75, 0x72, 0xa1, 0x94, 0x46, 0x78, 0x64, 0x99, 0xe6, 0x50, 0xe5, 0x3e, 0xe4, 0x17, 0x09, 0xff,
-	0x36, 0xf9, 0x90, 0xf0, 0x7f, 0xce, 0x5c, 0xf2, 0xaf, 0x58, 0x9d, 0x28, 0x1c, 0xf0, 0xd1, 0x31,
-	0xac, 0x78, 0x97, 0x59, 0x1d, 0xb3, 0x4b, 0xee, 0x1e, 0xcf, 0x65, 0xbd, 0x81, 0x74, 0x1e, 0x26,
-	0x3b, 0xe8, 0x5d, 0x78, 0x7c, 0xca, 0x53, 0x79, 0xaa, 0x13, 0xcb, 0x3a, 0xac, 0xc7, 0xc2, 0x0e,
-	0xcb, 0x55, 0xed, 0x1b, 0x2b, 0xf9, 0x1d, 0xef, 0xd0, 0x2e, 0x94, 0xc3, 0xf9, 0xcb, 0xdc, 0xed,
-	0x7f, 0x1a, 0x4a, 0x36, 0x26, 0x9a, 0x6e, 0xa8, 0xa1, 0xe2, 0x4e, 0x91, 0x13, 0x45, 0xc9, 0xe8,
-	0x08, 0x1e, 0x9b, 0x9b, 0xc7, 0xa0, 0x57, 0x21, 0xef, 0xa7, 0x40, 0xdc, 0xaa, 0x97, 0x80, 0x7f,
-	0x9f, 0x57, 0xfe, 0x7d, 0xdc, 0x57, 0x19, 0x2e, 0x27, 0xb4, 0x20, 0x63, 0x63, 0x67, 0x3c, 0xe4,
-	0x00, 0xbf, 0xbc, 0xf3, 0xe2, 0x72, 0x19, 0x10, 0xa5, 0x8e, 0x87, 0x44, 0x11, 0xc2, 0xf2, 0x43,
-	0xc8, 0x70, 0x0a, 0x2a, 0x40, 0xf6, 0xe4, 0x60, 0xef, 0xe0, 0xf0, 0x9d, 0x03, 0x29, 0x86, 0x00,
-	0x32, 0xf5, 0x66, 0xb3, 0x75, 0xd4, 0x91, 0xe2, 0x28, 0x0f, 0xe9, 0x7a, 0xe3, 0x50, 0xe9, 0x48,
-	0x09, 0x4a, 0x56, 0x5a, 0xf7, 0x5b, 0xcd, 0x8e, 0x94, 0x44, 0x2b, 0x50, 0xe2, 0x6d, 0xf5, 0xde,
-	0xa1, 0xf2, 0x76, 0xbd, 0x23, 0xa5, 0x02, 0xa4, 0xe3, 0xd6, 0xc1, 0xdd, 0x96, 0x22, 0xa5, 0xe5,
-	0xff, 0x83, 0xeb, 0x91, 0x39, 0x93, 0x5f, 0x2b, 0x88, 0x07, 0x6a, 0x05, 0xf2, 0x67, 0x09, 0xa8,
-	0x45, 0x27, 0x42, 0xe8, 0xfe, 0xd4, 0xc2, 0x77, 0xae, 0x90, 0x45, 0x4d, 0xad, 0x1e, 0x3d, 0x03,
-	0x65, 0x1b, 0x9f, 0x62, 0xd2, 0x1b, 0xf0, 0xc4, 0x8c, 0x07, 0xc0, 0x92, 0x52, 0x12, 0x54, 0x26,
-	0xe4, 0x70, 0xb6, 0xf7, 0x71, 0x8f, 0xa8, 0xdc, 0xc7, 0xf0, 0x43, 0x97, 0xa7, 0x6c, 0x94, 0x7a,
-	0xcc, 0x89, 0xf2, 0x7b, 0x57, 0xb2, 0x65, 0x1e, 0xd2, 0x4a, 0xab, 0xa3, 0xbc, 0x2b, 0x25, 0x11,
-	0x82, 0x32, 0x6b, 0xaa, 0xc7, 0x07, 0xf5, 0xa3, 0xe3, 0xf6, 0x21, 0xb5, 0xe5, 0x35, 0xa8, 0xb8,
-	0xb6, 0x74, 0x89, 0x69, 0xf9, 0x79, 0x78, 0x3c, 0x22, 0x8b, 0x9b, 0xc5, 0xe4, 0xf2, 0xaf, 0xe3,
-	0x41, 0xee, 0x30, 0x82, 0x3f, 0x84, 0x8c, 0x43, 0x34, 0x32, 0x76, 0x84, 0x11, 0x5f, 0x5d, 0x36,
-	0xad, 0xdb, 0x72, 0x1b, 0xc7, 0x4c, 0x5c, 0x11, 0x6a, 0xe4, 0x3b, 0x50, 0x0e, 0x7f, 0x89, 0xb6,
-	0x81, 0x7f, 0x88, 0x12, 0xf2, 0xbb, 0x00, 0x81, 0xea, 0xe2, 0x2a, 0xa4, 0x6d, 0x73, 0x6c, 0xf4,
-	0xd9, 0xa4, 0xd2, 0x0a, 0xef, 0xa0, 0x3b, 0x90, 0x3e, 0x37, 0xb9, 0xcf, 0x98, 0x7f, 0x71, 0x1e,
-	0x98, 0x04, 0x07, 0x4a, 0x09, 0x9c, 0x5b, 0xd6, 0x01, 0xcd, 0x56, 0x78, 0x22, 0x86, 0x78, 0x33,
-	0x3c, 0xc4, 0x53, 0x91, 0xb5, 0xa2, 0xf9, 0x43, 0x7d, 0x04, 0x69, 0xe6, 0x6d, 0xa8, 0xe7, 0x60,
-	0x55, 0x4a, 0x91, 0x5a, 0xd2, 0x36, 0xfa, 0x19, 0x80, 0x46, 0x88, 0xad, 0x77, 0xc7, 0xfe, 0x00,
-	0x1b, 0xf3, 0xbd, 0x55, 0xdd, 0xe5, 0x6b, 0xdc, 0x10, 0x6e, 0x6b, 0xd5, 0x17, 0x0d, 0xb8, 0xae,
-	0x80, 0x42, 0xf9, 0x00, 0xca, 0x61, 0x59, 0x37, 0x19, 0xe2, 0x73, 0x08, 0x27, 0x43, 0x3c, 0xb7,
-	0x15, 0xc9, 0x90, 0x97, 0x4a, 0x25, 0x79, 0x41, 0x9a, 0x75, 0xe4, 0x4f, 0xe2, 0x90, 0xeb, 0x4c,
-	0xc4, 0x39, 0x8e, 0x28, 0x86, 0xfa, 0xa2, 0x89, 0x60, 0xe9, 0x8f, 0x57, 0x57, 0x93, 0x5e, 0xcd,
-	0xf6, 0x2d, 0xef, 0xa6, 0xa6, 0x96, 0xc5, 0xae, 0x6e, 0xed, 0x5a, 0x78, 0xa7, 0x37, 0x20, 0xef,
-	0xc5, 0x1a, 0x9a, 0xa3, 0xbb, 0x75, 0x92, 0xb8, 0x48, 0x30, 0x79, 0x97, 0x95, 0xd6, 0xcd, 0x0f,
-	0x45, 0x71, 0x31, 0xa9, 0xf0, 0x8e, 0xdc, 0x87, 0xca, 0x54, 0xa0, 0x42, 0x6f, 0x40, 0xd6, 0x1a,
-	0x77, 0x55, 0xd7, 0x3c, 0x53, 0xd5, 0x24, 0x37, 0xfb, 0x1b, 0x77, 0x87, 0x7a, 0x6f, 0x0f, 0x5f,
-	0xb8, 0x93, 0xb1, 0xc6, 0xdd, 0x3d, 0x6e, 0x45, 0x3e, 0x4a, 0x22, 0x38, 0xca, 0x39, 0xe4, 0xdc,
-	0x43, 0x81, 0xbe, 0x0f, 0x79, 0x2f, 0x06, 0x7a, 0x7f, 0x5c, 0x22, 0x83, 0xa7, 0x50, 0xef, 0x8b,
-	0x50, 0x28, 0xe1, 0xe8, 0x67, 0x86, 0x5b, 0x43, 0xe3, 0x98, 0x3d, 0xc1, 0x76, 0xa7, 0xc2, 0x3f,
-	0xec, 0xbb, 0x10, 0x41, 0xfe, 0x4d, 0x1c, 0xa4, 0xe9, 0x53, 0xf9, 0x9f, 0x9c, 0x00, 0x75, 0x8a,
-	0xf4, 0xf4, 0xab, 0x98, 0x4e, 0xc2, 0xc3, 0x46, 0x45, 0xa5, 0x44, 0xa9, 0x2d, 0x97, 0x28, 0x7f,
-	0x9c, 0x80, 0x42, 0xa0, 0x42, 0x87, 0xfe, 0x3f, 0x70, 0x45, 0xca, 0x73, 0x72, 0x8b, 0x00, 0xaf,
-	0x5f, 0xcc, 0x0f, 0x2f, 0x2c, 0x71, 0xf5, 0x85, 0x45, 0xfd, 0x94, 0x71, 0x0b, 0x7e, 0xa9, 0x2b,
-	0x17, 0xfc, 0x5e, 0x00, 0x44, 0x4c, 0xa2, 0x0d, 0xd5, 0x73, 0x93, 0xe8, 0xc6, 0x99, 0xca, 0x8f,
-	0x06, 0xcf, 0xf8, 0x24, 0xf6, 0xe5, 0x01, 0xfb, 0x70, 0xc4, 0x4e, 0xc9, 0xcf, 0xe3, 0x90, 0xf3,
-	0x42, 0xf7, 0x55, 0x6b, 0xf3, 0x6b, 0x90, 0x11, 0xd1, 0x89, 0x17, 0xe7, 0x45, 0x6f, 0x6e, 0x65,
-	0xb3, 0x06, 0xb9, 0x11, 0x26, 0x1a, 0xcb, 0x5f, 0x38, 0xac, 0xf4, 0xfa, 0xb7, 0x5f, 0x87, 0x42,
-	0xe0, 0x37, 0x09, 0xf5, 0x13, 0x07, 0xad, 0x77, 0xa4, 0x58, 0x2d, 0xfb, 0xc9, 0xe7, 0x9b, 0xc9,
-	0x03, 0xfc, 0x21, 0xbd, 0x61, 0x4a, 0xab, 0xd9, 0x6e, 0x35, 0xf7, 0xa4, 0x78, 0xad, 0xf0, 0xc9,
-	0xe7, 0x9b, 0x59, 0x05, 0xb3, 0x62, 0xd4, 0xed, 0x3d, 0xa8, 0x4c, 0x6d, 0x4c, 0xd8, 0xbf, 0x23,
-	0x28, 0xdf, 0x3d, 0x39, 0xda, 0xdf, 0x6d, 0xd6, 0x3b, 0x2d, 0xf5, 0xc1, 0x61, 0xa7, 0x25, 0xc5,
-	0xd1, 0xe3, 0x70, 0x6d, 0x7f, 0xf7, 0x87, 0xed, 0x8e, 0xda, 0xdc, 0xdf, 0x6d, 0x1d, 0x74, 0xd4,
-	0x7a, 0xa7, 0x53, 0x6f, 0xee, 0x49, 0x89, 0x9d, 0x7f, 0x00, 0x54, 0xea, 0x8d, 0xe6, 0x2e, 0x8d,
-	0xcf, 0x7a, 0x4f, 0x63, 0xb0, 0xbf, 0x09, 0x29, 0x06, 0xec, 0x2f, 0x7d, 0xf8, 0x51, 0xbb, 0xbc,
-	0x52, 0x89, 0xee, 0x41, 0x9a, 0x61, 0x7e, 0x74, 0xf9, 0x4b, 0x90, 0xda, 0x82, 0xd2, 0x25, 0x9d,
-	0x0c, 0xbb, 0x4e, 0x97, 0x3e, 0x0d, 0xa9, 0x5d, 0x5e, 0xc9, 0x44, 0x0a, 0xe4, 0x7d, 0x94, 0xb1,
-	0xf8, 0xa9, 0x44, 0x6d, 0x09, 0xef, 0x88, 0xf6, 0x21, 0xeb, 0xc2, 0xbc, 0x45, 0x8f, 0x37, 0x6a,
-	0x0b, 0x4b, 0x8d, 0xd4, 0x5c, 0x1c, 0x8e, 0x5f, 0xfe, 0x12, 0xa5, 0xb6, 0xa0, 0x6e, 0x8a, 0x76,
-	0x21, 0x23, 0x32, 0xe7, 0x05, 0x0f, 0x32, 0x6a, 0x8b, 0x4a, 0x87, 0xd4, 0x68, 0x7e, 0xa1, 0x63,
-	0xf1, 0xfb, 0x9a, 0xda, 0x12, 0x25, 0x61, 0x74, 0x02, 0x10, 0x00, 0xdf, 0x4b, 0x3c, 0x9c, 0xa9,
-	0x2d, 0x53, 0xea, 0x45, 0x87, 0x90, 0xf3, 0xd0, 0xd3, 0xc2, 0x67, 0x2c, 0xb5, 0xc5, 0x35, 0x57,
-	0xf4, 0x10, 0x4a, 0x61, 0xd4, 0xb0, 0xdc, 0xe3, 0x94, 0xda, 0x92, 0xc5, 0x54, 0xaa, 0x3f, 0x0c,
-	0x21, 0x96, 0x7b, 0xac, 0x52, 0x5b, 0xb2, 0xb6, 0x8a, 0xde, 0x87, 0x95, 0xd9, 0x14, 0x7f, 0xf9,
-	0xb7, 0x2b, 0xb5, 0x2b, 0x54, 0x5b, 0xd1, 0x08, 0xd0, 0x1c, 0x68, 0x70, 0x85, 0xa7, 0x2c, 0xb5,
-	0xab, 0x14, 0x5f, 0x51, 0x1f, 0x2a, 0xd3, 0xf9, 0xf6, 0xb2, 0x4f, 0x5b, 0x6a, 0x4b, 0x17, 0x62,
-	0xf9, 0x28, 0xe1, 0x3c, 0x7d, 0xd9, 0xa7, 0x2e, 0xb5, 0xa5, 0xeb, 0xb2, 0x8d, 0xfa, 0x17, 0x5f,
-	0xaf, 0xc7, 0xbf, 0xfc, 0x7a, 0x3d, 0xfe, 0xd7, 0xaf, 0xd7, 0xe3, 0x9f, 0x7e, 0xb3, 0x1e, 0xfb,
-	0xf2, 0x9b, 0xf5, 0xd8, 0x9f, 0xbf, 0x59, 0x8f, 0xfd, 0xf8, 0xb9, 0x33, 0x9d, 0x0c, 0xc6, 0xdd,
-	0xad, 0x9e, 0x39, 0xda, 0xee, 0x99, 0x23, 0x4c, 0xba, 0xa7, 0xc4, 0x6f, 0xf8, 0xef, 0x0f, 0xbb,
-	0x19, 0x16, 0x1f, 0x5f, 0xfe, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff, 0xad, 0xbb, 0x1b, 0xa2, 0x9f,
-	0x28, 0x00, 0x00,
+	0xf1, 0xc7, 0xfb, 0xd1, 0x78, 0x2d, 0xe7, 0x78, 0x27, 0x1c, 0x74, 0x22, 0x4f, 0xab, 0x92, 0x74,
+	0x77, 0x92, 0x48, 0x89, 0xfa, 0xeb, 0x55, 0xfa, 0xcb, 0x16, 0x81, 0xc3, 0x19, 0x3c, 0x52, 0x24,
+	0x3d, 0x04, 0x4f, 0x25, 0x3f, 0x6e, 0xb5, 0x00, 0x86, 0xc4, 0xea, 0x80, 0xdd, 0xd5, 0xee, 0x82,
+	0x02, 0x15, 0xda, 0xe5, 0x2a, 0x97, 0xca, 0xe5, 0x52, 0xa8, 0x44, 0x81, 0x03, 0x07, 0xfe, 0x06,
+	0x8e, 0x1c, 0x39, 0x50, 0xa8, 0xc0, 0x81, 0x23, 0xd9, 0x25, 0x65, 0xfe, 0x02, 0x0e, 0x1c, 0xd8,
+	0x35, 0xaf, 0xc5, 0x2e, 0x80, 0x25, 0x40, 0xc9, 0xe5, 0x2a, 0x97, 0xb3, 0x99, 0xde, 0xee, 0x9e,
+	0x99, 0x9e, 0x99, 0xee, 0xfe, 0xf5, 0x0e, 0x3c, 0xee, 0x11, 0xb3, 0x47, 0x9c, 0xa1, 0x61, 0x7a,
+	0x9b, 0x7a, 0xa7, 0x6b, 0x6c, 0x7a, 0xe7, 0x36, 0x71, 0x37, 0x6c, 0xc7, 0xf2, 0x2c, 0x54, 0x99,
+	0x7c, 0xdc, 0xa0, 0x1f, 0x6b, 0x4f, 0x04, 0xb8, 0xbb, 0xce, 0xb9, 0xed, 0x59, 0x9b, 0xb6, 0x63,
+	0x59, 0x27, 0x9c, 0xbf, 0x76, 0x23, 0xf0, 0x99, 0xe9, 0x09, 0x6a, 0xab, 0xdd, 0x98, 0x15, 0x7e,
+	0x44, 0xce, 0xe5, 0xd7, 0x27, 0x66, 0x64, 0x6d, 0xdd, 0xd1, 0x87, 0xf2, 0xf3, 0xfa, 0xa9, 0x65,
+	0x9d, 0x0e, 0xc8, 0x26, 0xeb, 0x75, 0x46, 0x27, 0x9b, 0x9e, 0x31, 0x24, 0xae, 0xa7, 0x0f, 0x6d,
+	0xc1, 0xb0, 0x7a, 0x6a, 0x9d, 0x5a, 0xac, 0xb9, 0x49, 0x5b, 0x9c, 0xaa, 0xfe, 0x33, 0x07, 0x59,
+	0x4c, 0x3e, 0x1c, 0x11, 0xd7, 0x43, 0x5b, 0x90, 0x22, 0xdd, 0xbe, 0x55, 0x8d, 0xdf, 0x8c, 0xdf,
+	0x2a, 0x6c, 0xdd, 0xd8, 0x98, 0x5a, 0xdc, 0x86, 0xe0, 0x6b, 0x76, 0xfb, 0x56, 0x2b, 0x86, 0x19,
+	0x2f, 0x7a, 0x05, 0xd2, 0x27, 0x83, 0x91, 0xdb, 0xaf, 0x26, 0x98, 0xd0, 0x13, 0x51, 0x42, 0xf7,
+	0x28, 0x53, 0x2b, 0x86, 0x39, 0x37, 0x1d, 0xca, 0x30, 0x4f, 0xac, 0x6a, 0xf2, 0xe2, 0xa1, 0x76,
+	0xcc, 0x13, 0x36, 0x14, 0xe5, 0x45, 0x75, 0x00, 0xc3, 0x34, 0x3c, 0xad, 0xdb, 0xd7, 0x0d, 0xb3,
+	0x9a, 0x66, 0x92, 0x4f, 0x46, 0x4b, 0x1a, 0x5e, 0x83, 0x32, 0xb6, 0x62, 0x38, 0x6f, 0xc8, 0x0e,
+	0x9d, 0xee, 0x87, 0x23, 0xe2, 0x9c, 0x57, 0x33, 0x17, 0x4f, 0xf7, 0x87, 0x94, 0x89, 0x4e, 0x97,
+	0x71, 0xa3, 0x26, 0x14, 0x3a, 0xe4, 0xd4, 0x30, 0xb5, 0xce, 0xc0, 0xea, 0x3e, 0xaa, 0x66, 0x99,
+	0xb0, 0x1a, 0x25, 0x5c, 0xa7, 0xac, 0x75, 0xca, 0xd9, 0x8a, 0x61, 0xe8, 0xf8, 0x3d, 0xf4, 0xff,
+	0x90, 0xeb, 0xf6, 0x49, 0xf7, 0x91, 0xe6, 0x8d, 0xab, 0x39, 0xa6, 0x63, 0x3d, 0x4a, 0x47, 0x83,
+	0xf2, 0xb5, 0xc7, 0xad, 0x18, 0xce, 0x76, 0x79, 0x93, 0xae, 0xbf, 0x47, 0x06, 0xc6, 0x19, 0x71,
+	0xa8, 0x7c, 0xfe, 0xe2, 0xf5, 0xdf, 0xe5, 0x9c, 0x4c, 0x43, 0xbe, 0x27, 0x3b, 0xe8, 0xfb, 0x90,
+	0x27, 0x66, 0x4f, 0x2c, 0x03, 0x98, 0x8a, 0x9b, 0x91, 0xfb, 0x6c, 0xf6, 0xe4, 0x22, 0x72, 0x44,
+	0xb4, 0xd1, 0xeb, 0x90, 0xe9, 0x5a, 0xc3, 0xa1, 0xe1, 0x55, 0x0b, 0x4c, 0x7a, 0x2d, 0x72, 0x01,
+	0x8c, 0xab, 0x15, 0xc3, 0x82, 0x1f, 0xed, 0x43, 0x79, 0x60, 0xb8, 0x9e, 0xe6, 0x9a, 0xba, 0xed,
+	0xf6, 0x2d, 0xcf, 0xad, 0x16, 0x99, 0x86, 0xa7, 0xa3, 0x34, 0xec, 0x19, 0xae, 0x77, 0x24, 0x99,
+	0x5b, 0x31, 0x5c, 0x1a, 0x04, 0x09, 0x54, 0x9f, 0x75, 0x72, 0x42, 0x1c, 0x5f, 0x61, 0xb5, 0x74,
+	0xb1, 0xbe, 0x03, 0xca, 0x2d, 0xe5, 0xa9, 0x3e, 0x2b, 0x48, 0x40, 0x3f, 0x86, 0x2b, 0x03, 0x4b,
+	0xef, 0xf9, 0xea, 0xb4, 0x6e, 0x7f, 0x64, 0x3e, 0xaa, 0x96, 0x99, 0xd2, 0xdb, 0x91, 0x93, 0xb4,
+	0xf4, 0x9e, 0x54, 0xd1, 0xa0, 0x02, 0xad, 0x18, 0x5e, 0x19, 0x4c, 0x13, 0xd1, 0x43, 0x58, 0xd5,
+	0x6d, 0x7b, 0x70, 0x3e, 0xad, 0xbd, 0xc2, 0xb4, 0xdf, 0x89, 0xd2, 0xbe, 0x4d, 0x65, 0xa6, 0xd5,
+	0x23, 0x7d, 0x86, 0x8a, 0xda, 0xa0, 0xd8, 0x0e, 0xb1, 0x75, 0x87, 0x68, 0xb6, 0x63, 0xd9, 0x96,
+	0xab, 0x0f, 0xaa, 0x0a, 0xd3, 0xfd, 0x6c, 0x94, 0xee, 0x43, 0xce, 0x7f, 0x28, 0xd8, 0x5b, 0x31,
+	0x5c, 0xb1, 0xc3, 0x24, 0xae, 0xd5, 0xea, 0x12, 0xd7, 0x9d, 0x68, 0x5d, 0x59, 0xa4, 0x95, 0xf1,
+	0x87, 0xb5, 0x86, 0x48, 0xf5, 0x2c, 0xa4, 0xcf, 0xf4, 0xc1, 0x88, 0xdc, 0x4f, 0xe5, 0x52, 0x4a,
+	0x5a, 0x7d, 0x16, 0x0a, 0x01, 0xc7, 0x82, 0xaa, 0x90, 0x1d, 0x12, 0xd7, 0xd5, 0x4f, 0x09, 0xf3,
+	0x43, 0x79, 0x2c, 0xbb, 0x6a, 0x19, 0x8a, 0x41, 0x67, 0xa2, 0x7e, 0x1a, 0x87, 0x42, 0xc0, 0x4f,
+	0x50, 0xc9, 0x33, 0xe2, 0xb8, 0x86, 0x65, 0x4a, 0x49, 0xd1, 0x45, 0x4f, 0x41, 0x89, 0x9d, 0x78,
+	0x4d, 0x7e, 0xa7, 0xce, 0x2a, 0x85, 0x8b, 0x8c, 0xf8, 0x40, 0x30, 0xad, 0x43, 0xc1, 0xde, 0xb2,
+	0x7d, 0x96, 0x24, 0x63, 0x01, 0x7b, 0xcb, 0x96, 0x0c, 0x4f, 0x42, 0x91, 0xae, 0xd4, 0xe7, 0x48,
+	0xb1, 0x41, 0x0a, 0x94, 0x26, 0x58, 0xd4, 0xdf, 0x25, 0x41, 0x99, 0x76, 0x40, 0xe8, 0x75, 0x48,
+	0x51, 0x5f, 0x2c, 0xdc, 0x6a, 0x6d, 0x83, 0x3b, 0xea, 0x0d, 0xe9, 0xa8, 0x37, 0xda, 0xd2, 0x51,
+	0xd7, 0x73, 0x5f, 0x7c, 0xb5, 0x1e, 0xfb, 0xf4, 0x2f, 0xeb, 0x71, 0xcc, 0x24, 0xd0, 0x75, 0xea,
+	0x2f, 0x74, 0xc3, 0xd4, 0x8c, 0x1e, 0x9b, 0x72, 0x9e, 0x3a, 0x03, 0xdd, 0x30, 0x77, 0x7a, 0x68,
+	0x0f, 0x94, 0xae, 0x65, 0xba, 0xc4, 0x74, 0x47, 0xae, 0xc6, 0x03, 0x41, 0x35, 0x39, 0xeb, 0x12,
+	0x78, 0x78, 0x69, 0x48, 0xce, 0x43, 0xc6, 0x88, 0x2b, 0xdd, 0x30, 0x01, 0xdd, 0x03, 0x38, 0xd3,
+	0x07, 0x46, 0x4f, 0xf7, 0x2c, 0xc7, 0xad, 0xa6, 0x6e, 0x26, 0xe7, 0xfa, 0x85, 0x07, 0x92, 0xe5,
+	0xd8, 0xee, 0xe9, 0x1e, 0xa9, 0xa7, 0xe8, 0x74, 0x71, 0x40, 0x12, 0x3d, 0x03, 0x15, 0xdd, 0xb6,
+	0x35, 0xd7, 0xd3, 0x3d, 0xa2, 0x75, 0xce, 0x3d, 0xe2, 0x32, 0x3f, 0x5d, 0xc4, 0x25, 0xdd, 0xb6,
+	0x8f, 0x28, 0xb5, 0x4e, 0x89, 0xe8, 0x69, 0x28, 0x53, 0x9f, 0x6c, 0xe8, 0x03, 0xad, 0x4f, 0x8c,
+	0xd3, 0xbe, 0xc7, 0xfc, 0x71, 0x12, 0x97, 0x04, 0xb5, 0xc5, 0x88, 0xe8, 0x16, 0x28, 0x13, 0x75,
+	0xec, 0xc2, 0xb8, 0xcc, 0xf7, 0x96, 0x70, 0x59, 0xea, 0x63, 0xc7, 0xdf, 0x45, 0x2f, 0xc1, 0xd5,
+	0x29, 0x4e, 0xcd, 0x30, 0x7b, 0x84, 0xbb, 0xd9, 0x12, 0x46, 0x21, 0xf6, 0x1d, 0xfa, 0x45, 0xed,
+	0x41, 0x31, 0xe8, 0xec, 0x11, 0x82, 0x54, 0x4f, 0xf7, 0x74, 0xb6, 0x4d, 0x45, 0xcc, 0xda, 0x94,
+	0x66, 0xeb, 0x5e, 0x5f, 0x18, 0x9f, 0xb5, 0xd1, 0x35, 0xc8, 0x88, 0x39, 0x27, 0xd9, 0x9c, 0x45,
+	0x0f, 0xad, 0x42, 0xda, 0x76, 0xac, 0x33, 0xc2, 0xce, 0x45, 0x0e, 0xf3, 0x8e, 0xfa, 0xf3, 0x04,
+	0xac, 0xcc, 0x84, 0x05, 0xaa, 0xb7, 0xaf, 0xbb, 0x7d, 0x39, 0x16, 0x6d, 0xa3, 0x57, 0xa9, 0x5e,
+	0xbd, 0x47, 0x1c, 0x11, 0x4a, 0xab, 0xb3, 0xfb, 0xd8, 0x62, 0xdf, 0x85, 0xdd, 0x05, 0x37, 0xda,
+	0x05, 0x65, 0xa0, 0xbb, 0x9e, 0xc6, 0xdd, 0xac, 0x16, 0x08, 0xab, 0x8f, 0xcf, 0xec, 0x20, 0x77,
+	0xca, 0xf4, 0xb6, 0x08, 0x25, 0x65, 0x2a, 0x3a, 0xa1, 0xa2, 0x63, 0x58, 0xed, 0x9c, 0x7f, 0xac,
+	0x9b, 0x9e, 0x61, 0x12, 0x6d, 0xe6, 0x48, 0xcc, 0xc6, 0xe9, 0x77, 0x0c, 0xb7, 0x43, 0xfa, 0xfa,
+	0x99, 0x61, 0xc9, 0x69, 0x5d, 0xf1, 0xe5, 0xfd, 0xe3, 0xe2, 0xaa, 0x18, 0xca, 0xe1, 0xb8, 0x86,
+	0xca, 0x90, 0xf0, 0xc6, 0x62, 0xfd, 0x09, 0x6f, 0x8c, 0x5e, 0x84, 0x14, 0x5d, 0x23, 0x5b, 0x7b,
+	0x79, 0xce, 0x40, 0x42, 0xae, 0x7d, 0x6e, 0x13, 0xcc, 0x38, 0x55, 0x15, 0x94, 0xe9, 0x58, 0x37,
+	0xad, 0x55, 0xbd, 0x0d, 0x95, 0xa9, 0x60, 0x16, 0xd8, 0xbe, 0x78, 0x70, 0xfb, 0xd4, 0x0a, 0x94,
+	0x42, 0x91, 0x4b, 0xbd, 0x06, 0xab, 0xf3, 0x02, 0x91, 0xda, 0x87, 0xd5, 0x79, 0x01, 0x05, 0xbd,
+	0x02, 0x39, 0x3f, 0x12, 0xf1, 0xab, 0x7e, 0x7d, 0x66, 0x15, 0x92, 0x19, 0xfb, 0xac, 0xf4, 0x8e,
+	0xd3, 0x93, 0xcb, 0x8e, 0x43, 0x82, 0x4d, 0x3c, 0xab, 0xdb, 0x76, 0x4b, 0x77, 0xfb, 0xea, 0xfb,
+	0x50, 0x8d, 0x8a, 0x32, 0x53, 0xcb, 0x48, 0xf9, 0xa7, 0xf0, 0x1a, 0x64, 0x4e, 0x2c, 0x67, 0xa8,
+	0x7b, 0x4c, 0x59, 0x09, 0x8b, 0x1e, 0x3d, 0x9d, 0x3c, 0xe2, 0x24, 0x19, 0x99, 0x77, 0x54, 0x0d,
+	0xae, 0x47, 0x46, 0x1a, 0x2a, 0xc2, 0xef, 0x50, 0x9c, 0x8b, 0xb0, 0xce, 0x44, 0x11, 0x9f, 0x2c,
+	0xef, 0xd0, 0x61, 0x5d, 0xb6, 0x56, 0xa6, 0x3f, 0x8f, 0x45, 0x4f, 0xfd, 0x2c, 0x09, 0xd7, 0xe6,
+	0xc7, 0x1b, 0x74, 0x13, 0x8a, 0x43, 0x7d, 0xac, 0x79, 0x63, 0xe1, 0x28, 0xf8, 0x76, 0xc0, 0x50,
+	0x1f, 0xb7, 0xc7, 0xdc, 0x4b, 0x28, 0x90, 0xf4, 0xc6, 0x6e, 0x35, 0x71, 0x33, 0x79, 0xab, 0x88,
+	0x69, 0x13, 0x1d, 0xc3, 0xca, 0xc0, 0xea, 0xea, 0x03, 0x2d, 0x70, 0xe2, 0xc5, 0x61, 0x7f, 0x6a,
+	0xc6, 0xd8, 0xcd, 0x31, 0xa3, 0xf4, 0x66, 0x0e, 0x7d, 0x85, 0xe9, 0xd8, 0xf3, 0x4f, 0x3e, 0xba,
+	0x0b, 0x85, 0xe1, 0xe4, 0x20, 0x5f, 0xe2, 0xb0, 0x07, 0xc5, 0x02, 0x5b, 0x92, 0x0e, 0x39, 0x06,
+	0xe9, 0xff, 0x33, 0x97, 0xf6, 0xff, 0x2f, 0xc2, 0xaa, 0x49, 0xc6, 0x5e, 0xe0, 0x22, 0xf2, 0x73,
+	0x92, 0x65, 0xa6, 0x47, 0xf4, 0xdb, 0xe4, 0x92, 0xd1, 0x23, 0x83, 0x6e, 0xb3, 0x88, 0x6d, 0x5b,
+	0x2e, 0x71, 0x34, 0xbd, 0xd7, 0x73, 0x88, 0xeb, 0x32, 0x17, 0x58, 0xc4, 0x15, 0x49, 0xdf, 0xe6,
+	0x64, 0xf5, 0x97, 0xc1, 0xad, 0x09, 0x45, 0x68, 0x69, 0xf8, 0xf8, 0xc4, 0xf0, 0x47, 0xb0, 0x2a,
+	0xe4, 0x7b, 0x21, 0xdb, 0x27, 0x96, 0x75, 0x34, 0x48, 0x8a, 0x47, 0x9b, 0x3d, 0xf9, 0xed, 0xcc,
+	0x2e, 0x7d, 0x69, 0x2a, 0xe0, 0x4b, 0xff, 0xcb, 0xb6, 0xe2, 0x4f, 0x79, 0xc8, 0x61, 0xe2, 0xda,
+	0x96, 0xe9, 0x12, 0x54, 0x87, 0x3c, 0x19, 0x77, 0x89, 0xed, 0xc9, 0x44, 0x66, 0x3e, 0xd2, 0xe0,
+	0xdc, 0x4d, 0xc9, 0x49, 0xd3, 0x7c, 0x5f, 0x0c, 0xbd, 0x2c, 0x90, 0x5c, 0x34, 0x28, 0x13, 0xe2,
+	0x41, 0x28, 0xf7, 0xaa, 0x84, 0x72, 0xc9, 0xc8, 0xcc, 0x9e, 0x4b, 0x4d, 0x61, 0xb9, 0x97, 0x05,
+	0x96, 0x4b, 0x2d, 0x18, 0x2c, 0x04, 0xe6, 0x1a, 0x21, 0x30, 0x97, 0x59, 0xb0, 0xcc, 0x08, 0x34,
+	0xf7, 0xaa, 0x44, 0x73, 0xd9, 0x05, 0x33, 0x9e, 0x82, 0x73, 0xf7, 0xc2, 0x70, 0x2e, 0x17, 0xe1,
+	0x40, 0xa4, 0x74, 0x24, 0x9e, 0x7b, 0x2b, 0x80, 0xe7, 0xf2, 0x91, 0x60, 0x8a, 0x2b, 0x99, 0x03,
+	0xe8, 0x1a, 0x21, 0x40, 0x07, 0x0b, 0x6c, 0x10, 0x81, 0xe8, 0xde, 0x0e, 0x22, 0xba, 0x42, 0x24,
+	0x28, 0x14, 0xfb, 0x3d, 0x0f, 0xd2, 0xbd, 0xe1, 0x43, 0xba, 0x62, 0x24, 0x26, 0x15, 0x6b, 0x98,
+	0xc6, 0x74, 0x07, 0x33, 0x98, 0x8e, 0x63, 0xb0, 0x67, 0x22, 0x55, 0x2c, 0x00, 0x75, 0x07, 0x33,
+	0xa0, 0xae, 0xbc, 0x40, 0xe1, 0x02, 0x54, 0xf7, 0x93, 0xf9, 0xa8, 0x2e, 0x1a, 0x77, 0x89, 0x69,
+	0x2e, 0x07, 0xeb, 0xb4, 0x08, 0x58, 0xc7, 0xa1, 0xd7, 0x73, 0x91, 0xea, 0x97, 0xc6, 0x75, 0xc7,
+	0x73, 0x70, 0x1d, 0x47, 0x60, 0xb7, 0x22, 0x95, 0x2f, 0x01, 0xec, 0x8e, 0xe7, 0x00, 0x3b, 0xb4,
+	0x50, 0xed, 0x65, 0x90, 0x5d, 0x5a, 0xc9, 0xa8, 0xb7, 0x61, 0x45, 0x0a, 0xfb, 0x7e, 0x8a, 0xe6,
+	0x0f, 0xc4, 0x71, 0x2c, 0x47, 0x60, 0x34, 0xde, 0x51, 0x6f, 0x41, 0xd1, 0x67, 0xbd, 0x18, 0x05,
+	0xb2, 0x3c, 0x2d, 0xe0, 0x87, 0xd4, 0xdf, 0xc7, 0xa1, 0x18, 0x74, 0x31, 0xa1, 0x44, 0x3e, 0x2f,
+	0x12, 0xf9, 0x00, 0x36, 0x4c, 0x84, 0xb1, 0xe1, 0x3a, 0x14, 0x68, 0xfe, 0x35, 0x05, 0xfb, 0x74,
+	0xdb, 0x87, 0x7d, 0x77, 0x60, 0x85, 0x45, 0x3c, 0x8e, 0x20, 0x45, 0x58, 0x49, 0xb1, 0xb0, 0x52,
+	0xa1, 0x1f, 0xf8, 0x85, 0x62, 0x64, 0xf4, 0x02, 0x5c, 0x09, 0xf0, 0xfa, 0x79, 0x1d, 0xc7, 0x40,
+	0x8a, 0xcf, 0xbd, 0x2d, 0x12, 0xbc, 0x3f, 0xc6, 0x61, 0x65, 0xc6, 0xc5, 0xcd, 0x85, 0x76, 0xf1,
+	0x7f, 0x13, 0xb4, 0x4b, 0x7c, 0x6b, 0x68, 0x17, 0xcc, 0x53, 0x93, 0xe1, 0x3c, 0xf5, 0xef, 0x71,
+	0x28, 0x85, 0x3c, 0x2d, 0xdd, 0x82, 0xae, 0xd5, 0x23, 0x22, 0x73, 0x64, 0x6d, 0x9a, 0x54, 0x0c,
+	0xac, 0x53, 0x91, 0x1f, 0xd2, 0x26, 0xe5, 0xf2, 0x03, 0x47, 0x5e, 0xc4, 0x05, 0x3f, 0xe9, 0xe4,
+	0x81, 0x9b, 0x77, 0xa8, 0xec, 0x23, 0xc2, 0x8b, 0x76, 0x45, 0x4c, 0x9b, 0x68, 0x55, 0x1c, 0x35,
+	0x11, 0x80, 0x79, 0x07, 0xbd, 0x0e, 0x79, 0x56, 0x6e, 0xd5, 0x2c, 0xdb, 0xad, 0xe6, 0x66, 0x73,
+	0x13, 0x5e, 0x55, 0xdd, 0x38, 0xa4, 0x3c, 0x07, 0xb6, 0x8b, 0x73, 0xb6, 0x68, 0x05, 0x32, 0x86,
+	0x7c, 0x28, 0x63, 0xb8, 0x01, 0x79, 0x3a, 0x7b, 0xd7, 0xd6, 0xbb, 0x84, 0xb9, 0xe8, 0x3c, 0x9e,
+	0x10, 0xd4, 0x87, 0x80, 0x66, 0x83, 0x04, 0x6a, 0x41, 0x86, 0x9c, 0x11, 0xd3, 0xe3, 0x19, 0x54,
+	0x61, 0xeb, 0xda, 0x6c, 0x6a, 0x4a, 0x3f, 0xd7, 0xab, 0xd4, 0xc8, 0x7f, 0xfb, 0x6a, 0x5d, 0xe1,
+	0xdc, 0xcf, 0x5b, 0x43, 0xc3, 0x23, 0x43, 0xdb, 0x3b, 0xc7, 0x42, 0x5e, 0xfd, 0x75, 0x12, 0x2a,
+	0x72, 0x00, 0x89, 0x9c, 0xe6, 0xd9, 0x56, 0x1e, 0xf9, 0x44, 0x00, 0xbb, 0x2e, 0x67, 0xef, 0x35,
+	0x80, 0x53, 0xdd, 0xd5, 0x3e, 0xd2, 0x4d, 0x8f, 0xf4, 0x84, 0xd1, 0x03, 0x14, 0x54, 0x83, 0x1c,
+	0xed, 0x8d, 0x5c, 0xd2, 0x13, 0x18, 0xdd, 0xef, 0x07, 0xd6, 0x99, 0xfd, 0x6e, 0xeb, 0x0c, 0x5b,
+	0x39, 0x37, 0x65, 0x65, 0x3a, 0x07, 0xdb, 0x31, 0x2c, 0xc7, 0xf0, 0xce, 0x59, 0x88, 0x4a, 0x62,
+	0xbf, 0x1f, 0x00, 0x1e, 0xa5, 0x20, 0xf0, 0xa0, 0x32, 0x2e, 0x4d, 0x6e, 0xcd, 0x2e, 0x61, 0x21,
+	0x24, 0x85, 0xfd, 0x3e, 0xb5, 0xcc, 0x09, 0x21, 0x2c, 0x06, 0x24, 0x31, 0x6d, 0x52, 0xcb, 0x0c,
+	0x74, 0x93, 0x30, 0xbf, 0x9d, 0xc7, 0xac, 0x7d, 0x3f, 0x95, 0xcb, 0x2b, 0x45, 0x5c, 0x1a, 0x92,
+	0xa1, 0x6d, 0x59, 0x03, 0x8d, 0xfb, 0xa9, 0x5f, 0x24, 0x60, 0x65, 0x26, 0x20, 0xff, 0xef, 0x6d,
+	0x89, 0xfa, 0xab, 0x04, 0x28, 0xd2, 0x0e, 0x3e, 0xb4, 0x3e, 0x82, 0x15, 0xdf, 0x61, 0x68, 0x23,
+	0xe6, 0x48, 0xe4, 0x15, 0x58, 0xd6, 0xe3, 0x28, 0x67, 0x61, 0xb2, 0x8b, 0xde, 0x83, 0xc7, 0xa6,
+	0xbc, 0xa1, 0xaf, 0x3a, 0xb1, 0xac, 0x53, 0xbc, 0x1a, 0x76, 0x8a, 0x52, 0xf5, 0xc4, 0x58, 0xc9,
+	0xef, 0x78, 0x4f, 0x77, 0xa0, 0x2c, 0xad, 0x21, 0xb0, 0xcd, 0xbc, 0xed, 0x7f, 0x0a, 0x4a, 0x0e,
+	0xf1, 0x68, 0x3d, 0x2f, 0x54, 0x40, 0x2a, 0x72, 0x22, 0x0f, 0x21, 0xea, 0x21, 0x5c, 0x9d, 0x9b,
+	0x2b, 0xa1, 0xd7, 0x20, 0x3f, 0x49, 0xb3, 0xb8, 0x55, 0x2f, 0x28, 0x30, 0x4c, 0x78, 0xd5, 0x3f,
+	0xc4, 0xe1, 0xea, 0xdc, 0x6c, 0x09, 0x35, 0x21, 0xe3, 0x10, 0x77, 0x34, 0xe0, 0x45, 0x84, 0xf2,
+	0xd6, 0x0b, 0xcb, 0x65, 0x59, 0x94, 0x3a, 0x1a, 0x78, 0x58, 0x08, 0xab, 0x0f, 0x21, 0xc3, 0x29,
+	0xa8, 0x00, 0xd9, 0xe3, 0xfd, 0xdd, 0xfd, 0x83, 0x77, 0xf7, 0x95, 0x18, 0x02, 0xc8, 0x6c, 0x37,
+	0x1a, 0xcd, 0xc3, 0xb6, 0x12, 0x47, 0x79, 0x48, 0x6f, 0xd7, 0x0f, 0x70, 0x5b, 0x49, 0x50, 0x32,
+	0x6e, 0xde, 0x6f, 0x36, 0xda, 0x4a, 0x12, 0xad, 0x40, 0x89, 0xb7, 0xb5, 0x7b, 0x07, 0xf8, 0x9d,
+	0xed, 0xb6, 0x92, 0x0a, 0x90, 0x8e, 0x9a, 0xfb, 0x77, 0x9b, 0x58, 0x49, 0xab, 0x2f, 0xc1, 0x75,
+	0x39, 0x8f, 0xd9, 0x42, 0x88, 0x5f, 0x8f, 0x88, 0x07, 0xea, 0x11, 0xea, 0x67, 0x09, 0xa8, 0x45,
+	0x27, 0x5b, 0xe8, 0xfe, 0xd4, 0xc2, 0xb7, 0x2e, 0x91, 0xa9, 0x4d, 0xad, 0x9e, 0xd6, 0x32, 0x1d,
+	0x72, 0x42, 0xbc, 0x6e, 0x5f, 0x96, 0x28, 0x69, 0x90, 0x2d, 0xe1, 0x92, 0xa0, 0x8a, 0x0a, 0x25,
+	0x63, 0xfb, 0x80, 0x74, 0x3d, 0x8d, 0x7b, 0x28, 0x7e, 0xe8, 0xf2, 0xb8, 0xc4, 0xa9, 0x47, 0x9c,
+	0xa8, 0xbe, 0x7f, 0x29, 0x5b, 0xe6, 0x21, 0x8d, 0x9b, 0x6d, 0xfc, 0x9e, 0x92, 0x44, 0x08, 0xca,
+	0xac, 0xa9, 0x1d, 0xed, 0x6f, 0x1f, 0x1e, 0xb5, 0x0e, 0xa8, 0x2d, 0xaf, 0x40, 0x45, 0xda, 0x52,
+	0x12, 0xd3, 0xea, 0x73, 0xf0, 0x58, 0x44, 0xa6, 0x38, 0x8b, 0xfb, 0xd5, 0xdf, 0xc4, 0x83, 0xdc,
+	0xe1, 0x2a, 0xc1, 0x01, 0x64, 0x5c, 0x4f, 0xf7, 0x46, 0xae, 0x30, 0xe2, 0x6b, 0xcb, 0xa6, 0x8e,
+	0x1b, 0xb2, 0x71, 0xc4, 0xc4, 0xb1, 0x50, 0xa3, 0xbe, 0x02, 0xe5, 0xf0, 0x97, 0x68, 0x1b, 0x4c,
+	0x0e, 0x51, 0x42, 0x7d, 0x0f, 0x20, 0x50, 0xc1, 0x5c, 0x85, 0xb4, 0x63, 0x8d, 0xcc, 0x1e, 0x9b,
+	0x54, 0x1a, 0xf3, 0x0e, 0xfd, 0xef, 0x77, 0x66, 0x71, 0x9f, 0x31, 0xff, 0xe2, 0x3c, 0xb0, 0x3c,
+	0x12, 0x28, 0x57, 0x70, 0x6e, 0xd5, 0x00, 0x34, 0x5b, 0x45, 0x8a, 0x18, 0xe2, 0xad, 0xf0, 0x10,
+	0x4f, 0x46, 0xd6, 0xa3, 0xe6, 0x0f, 0xf5, 0x31, 0xa4, 0x99, 0xb7, 0xa1, 0x9e, 0x83, 0x55, 0x42,
+	0x45, 0xfa, 0x4a, 0xdb, 0xe8, 0xa7, 0x00, 0xba, 0xe7, 0x39, 0x46, 0x67, 0x34, 0x19, 0x60, 0x7d,
+	0xbe, 0xb7, 0xda, 0x96, 0x7c, 0xf5, 0x1b, 0xc2, 0x6d, 0xad, 0x4e, 0x44, 0x03, 0xae, 0x2b, 0xa0,
+	0x50, 0xdd, 0x87, 0x72, 0x58, 0x56, 0x26, 0x5c, 0x7c, 0x0e, 0xe1, 0x84, 0x8b, 0xe7, 0xcf, 0xbc,
+	0x33, 0x49, 0xd7, 0x92, 0xbc, 0xe8, 0xcd, 0x3a, 0xea, 0x27, 0x71, 0xc8, 0xb5, 0xc7, 0xe2, 0x1c,
+	0x47, 0x14, 0x5c, 0x27, 0xa2, 0x89, 0x60, 0x79, 0x91, 0x57, 0x70, 0x93, 0x7e, 0x5d, 0xf8, 0x6d,
+	0xff, 0xa6, 0xa6, 0x96, 0xc5, 0xc7, 0xb2, 0x3e, 0x2e, 0xbc, 0xd3, 0x9b, 0x90, 0xf7, 0x63, 0x0d,
+	0xc5, 0x01, 0xb2, 0x16, 0x13, 0x17, 0x49, 0x2c, 0xef, 0xd2, 0xe9, 0xd8, 0xd6, 0x47, 0xa2, 0x80,
+	0x99, 0xc4, 0xbc, 0xa3, 0xf6, 0xa0, 0x32, 0x15, 0xa8, 0xd0, 0x9b, 0x90, 0xb5, 0x47, 0x1d, 0x4d,
+	0x9a, 0x67, 0xaa, 0x62, 0x25, 0x33, 0xcc, 0x51, 0x67, 0x60, 0x74, 0x77, 0xc9, 0xb9, 0x9c, 0x8c,
+	0x3d, 0xea, 0xec, 0x72, 0x2b, 0xf2, 0x51, 0x12, 0xc1, 0x51, 0xce, 0x20, 0x27, 0x0f, 0x05, 0xfa,
+	0x1e, 0xe4, 0xfd, 0x18, 0xe8, 0xff, 0x32, 0x8a, 0x0c, 0x9e, 0x42, 0xfd, 0x44, 0x84, 0xc2, 0x15,
+	0xd7, 0x38, 0x35, 0x65, 0x9d, 0x8e, 0xd7, 0x05, 0x12, 0x6c, 0x77, 0x2a, 0xfc, 0xc3, 0x9e, 0x84,
+	0x21, 0xea, 0x6f, 0xe3, 0xa0, 0x4c, 0x9f, 0xca, 0xff, 0xe4, 0x04, 0xa8, 0x53, 0xa4, 0xa7, 0x5f,
+	0x23, 0x74, 0x12, 0x3e, 0xfe, 0x2a, 0xe2, 0x12, 0xa5, 0x36, 0x25, 0x91, 0xfe, 0x44, 0x29, 0x04,
+	0xaa, 0x80, 0xe8, 0xff, 0x02, 0x57, 0xa4, 0x3c, 0x27, 0xb7, 0x08, 0xf0, 0x4e, 0x7e, 0x18, 0x84,
+	0x17, 0x96, 0xb8, 0xfc, 0xc2, 0xa2, 0x7e, 0xfc, 0xc8, 0xa2, 0x62, 0xea, 0xd2, 0x45, 0xc5, 0xe7,
+	0x01, 0x79, 0x96, 0xa7, 0x0f, 0xb4, 0x33, 0xcb, 0x33, 0xcc, 0x53, 0x8d, 0x1f, 0x0d, 0x9e, 0xf1,
+	0x29, 0xec, 0xcb, 0x03, 0xf6, 0xe1, 0x90, 0x9d, 0x92, 0x9f, 0xc5, 0x21, 0xe7, 0x87, 0xee, 0xcb,
+	0xd6, 0xff, 0xaf, 0x41, 0x46, 0x44, 0x27, 0xfe, 0x03, 0x40, 0xf4, 0xe6, 0x56, 0x4f, 0x6b, 0x90,
+	0x1b, 0x12, 0x4f, 0x67, 0xf9, 0x0b, 0x87, 0xae, 0x7e, 0xff, 0xce, 0x1b, 0x50, 0x08, 0xfc, 0x8a,
+	0xa1, 0x7e, 0x62, 0xbf, 0xf9, 0xae, 0x12, 0xab, 0x65, 0x3f, 0xf9, 0xfc, 0x66, 0x72, 0x9f, 0x7c,
+	0x44, 0x6f, 0x18, 0x6e, 0x36, 0x5a, 0xcd, 0xc6, 0xae, 0x12, 0xaf, 0x15, 0x3e, 0xf9, 0xfc, 0x66,
+	0x16, 0x13, 0x56, 0xf0, 0xba, 0xb3, 0x0b, 0x95, 0xa9, 0x8d, 0x09, 0xfb, 0x77, 0x04, 0xe5, 0xbb,
+	0xc7, 0x87, 0x7b, 0x3b, 0x8d, 0xed, 0x76, 0x53, 0x7b, 0x70, 0xd0, 0x6e, 0x2a, 0x71, 0xf4, 0x18,
+	0x5c, 0xd9, 0xdb, 0xf9, 0x41, 0xab, 0xad, 0x35, 0xf6, 0x76, 0x9a, 0xfb, 0x6d, 0x6d, 0xbb, 0xdd,
+	0xde, 0x6e, 0xec, 0x2a, 0x89, 0xad, 0x7f, 0x00, 0x54, 0xb6, 0xeb, 0x8d, 0x1d, 0x1a, 0x9f, 0x8d,
+	0xae, 0xce, 0x4a, 0x0b, 0x0d, 0x48, 0xb1, 0xe2, 0xc1, 0x85, 0x2f, 0x57, 0x6a, 0x17, 0x57, 0x43,
+	0xd1, 0x3d, 0x48, 0xb3, 0xba, 0x02, 0xba, 0xf8, 0x29, 0x4b, 0x6d, 0x41, 0x79, 0x94, 0x4e, 0x86,
+	0x5d, 0xa7, 0x0b, 0xdf, 0xb6, 0xd4, 0x2e, 0xae, 0x96, 0x22, 0x0c, 0xf9, 0x09, 0xca, 0x58, 0xfc,
+	0xd6, 0xa3, 0xb6, 0x84, 0x77, 0x44, 0x7b, 0x90, 0x95, 0x50, 0x72, 0xd1, 0xeb, 0x93, 0xda, 0xc2,
+	0x72, 0x26, 0x35, 0x17, 0x87, 0xfc, 0x17, 0x3f, 0xa5, 0xa9, 0x2d, 0xa8, 0xcd, 0xa2, 0x1d, 0xc8,
+	0x88, 0xcc, 0x79, 0xc1, 0x8b, 0x92, 0xda, 0xa2, 0xf2, 0x24, 0x35, 0xda, 0xa4, 0x98, 0xb2, 0xf8,
+	0x81, 0x50, 0x6d, 0x89, 0xb2, 0x33, 0x3a, 0x06, 0x08, 0x00, 0xfc, 0x25, 0x5e, 0xfe, 0xd4, 0x96,
+	0x29, 0x27, 0xa3, 0x03, 0xc8, 0xf9, 0xe8, 0x69, 0xe1, 0x3b, 0x9c, 0xda, 0xe2, 0xba, 0x2e, 0x7a,
+	0x08, 0xa5, 0x30, 0x6a, 0x58, 0xee, 0x75, 0x4d, 0x6d, 0xc9, 0x82, 0x2d, 0xd5, 0x1f, 0x86, 0x10,
+	0xcb, 0xbd, 0xb6, 0xa9, 0x2d, 0x59, 0xbf, 0x45, 0x1f, 0xc0, 0xca, 0x6c, 0x8a, 0xbf, 0xfc, 0xe3,
+	0x9b, 0xda, 0x25, 0x2a, 0xba, 0x68, 0x08, 0x68, 0x0e, 0x34, 0xb8, 0xc4, 0x5b, 0x9c, 0xda, 0x65,
+	0x0a, 0xbc, 0xa8, 0x07, 0x95, 0xe9, 0x7c, 0x7b, 0xd9, 0xb7, 0x39, 0xb5, 0xa5, 0x8b, 0xbd, 0x7c,
+	0x94, 0x70, 0x9e, 0xbe, 0xec, 0x5b, 0x9d, 0xda, 0xd2, 0xb5, 0xdf, 0xfa, 0xf6, 0x17, 0x5f, 0xaf,
+	0xc5, 0xbf, 0xfc, 0x7a, 0x2d, 0xfe, 0xd7, 0xaf, 0xd7, 0xe2, 0x9f, 0x7e, 0xb3, 0x16, 0xfb, 0xf2,
+	0x9b, 0xb5, 0xd8, 0x9f, 0xbf, 0x59, 0x8b, 0xfd, 0xe8, 0xd9, 0x53, 0xc3, 0xeb, 0x8f, 0x3a, 0x1b,
+	0x5d, 0x6b, 0xb8, 0xd9, 0xb5, 0x86, 0xc4, 0xeb, 0x9c, 0x78, 0x93, 0xc6, 0xe4, 0x01, 0x65, 0x27,
+	0xc3, 0xe2, 0xe3, 0xcb, 0xff, 0x1a, 0x00, 0xb2, 0xc5, 0xd4, 0x64, 0x60, 0x29, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4738,6 +4808,16 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppStateChunkIndex != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateChunkIndex))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.AppStateChunks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateChunks))
+		i--
+		dAtA[i] = 0x38
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -6079,6 +6159,37 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Lane) > 0 {
+		i -= len(m.Lane)
+		copy(dAtA[i:], m.Lane)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Lane)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Fee != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Fee))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x70
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x60
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -7315,6 +7426,12 @@ func (m *RequestInitChain) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	if m.AppStateChunks != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateChunks))
+	}
+	if m.AppStateChunkIndex != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateChunkIndex))
+	}
 	return n
 }
 
@@ -7952,6 +8069,23 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
+	if m.Fee != 0 {
+		n += 1 + sovTypes(uint64(m.Fee))
+	}
+	l = len(m.Lane)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -9451,6 +9585,44 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateChunks", wireType)
+			}
+			m.AppStateChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateChunkIndex", wireType)
+			}
+			m.AppStateChunkIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateChunkIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12960,6 +13132,127 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fee", wireType)
+			}
+			m.Fee = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Fee |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lane", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lane = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
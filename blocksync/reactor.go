@@ -1,8 +1,10 @@
 package blocksync
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/cometbft/cometbft/libs/log"
@@ -17,6 +19,14 @@ const (
 	// BlocksyncChannel is a channel for blocks and status updates (`BlockStore` height)
 	BlocksyncChannel = byte(0x40)
 
+	// BlocksyncChannelVersion is the highest version of the messages of
+	// BlocksyncChannel the reactor supports.
+	BlocksyncChannelVersion = p2p.DefaultChannelVersion
+
+	// peerChannelVersionKey is the key of the version of the messages of
+	// BlocksyncChannel negotiated with a peer in AddPeer.
+	peerChannelVersionKey = "BlocksyncReactor.channelVersion"
+
 	trySyncIntervalMS = 10
 
 	// stop syncing when last block's time is
@@ -33,6 +43,9 @@ type consensusReactor interface {
 	// for when we switch from blocksync reactor and block sync to
 	// the consensus machine
 	SwitchToConsensus(state sm.State, skipWAL bool)
+	// for when the block sync halts upon a block committed by +2/3 of the
+	// validators with a different app hash than ours
+	HaltOnAppHashMismatch(block *types.Block, commit *types.Commit, err error)
 }
 
 type peerError struct {
@@ -56,6 +69,9 @@ type Reactor struct {
 	pool      *BlockPool
 	blockSync bool
 
+	// atomic, Block.MaxBytes of the consensus params of the latest state
+	maxBlockBytes int64
+
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
 
@@ -83,14 +99,15 @@ func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockS
 	pool := NewBlockPool(startHeight, requestsCh, errorsCh)
 
 	bcR := &Reactor{
-		initialState: state,
-		blockExec:    blockExec,
-		store:        store,
-		pool:         pool,
-		blockSync:    blockSync,
-		requestsCh:   requestsCh,
-		errorsCh:     errorsCh,
-		metrics:      metrics,
+		initialState:  state,
+		blockExec:     blockExec,
+		store:         store,
+		pool:          pool,
+		blockSync:     blockSync,
+		maxBlockBytes: state.ConsensusParams.Block.MaxBytes,
+		requestsCh:    requestsCh,
+		errorsCh:      errorsCh,
+		metrics:       metrics,
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("Reactor", bcR)
 	return bcR
@@ -118,6 +135,7 @@ func (bcR *Reactor) OnStart() error {
 func (bcR *Reactor) SwitchToBlockSync(state sm.State) error {
 	bcR.blockSync = true
 	bcR.initialState = state
+	atomic.StoreInt64(&bcR.maxBlockBytes, state.ConsensusParams.Block.MaxBytes)
 
 	bcR.pool.height = state.LastBlockHeight + 1
 	err := bcR.pool.Start()
@@ -141,18 +159,37 @@ func (bcR *Reactor) OnStop() {
 func (bcR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
 		{
-			ID:                  BlocksyncChannel,
-			Priority:            5,
-			SendQueueCapacity:   1000,
-			RecvBufferCapacity:  50 * 4096,
-			RecvMessageCapacity: MaxMsgSize,
-			MessageType:         &bcproto.Message{},
+			ID:                      BlocksyncChannel,
+			Priority:                5,
+			SendQueueCapacity:       1000,
+			RecvBufferCapacity:      50 * 4096,
+			RecvMessageCapacity:     MaxMsgSize,
+			RecvMessageCapacityFunc: bcR.maxRecvMsgSize,
+			MessageType:             &bcproto.Message{},
+			Version:                 BlocksyncChannelVersion,
 		},
 	}
 }
 
-// AddPeer implements Reactor by sending our state to peer.
+// maxRecvMsgSize returns the maximum size of the messages received from
+// peers. While syncing, the blocks ahead may be made under larger consensus
+// params than the ones of our state, so it's MaxMsgSize. Otherwise only the
+// blocks requested before the switch to consensus are received, bounded by
+// the consensus params.
+func (bcR *Reactor) maxRecvMsgSize() int {
+	if bcR.pool.IsRunning() {
+		return MaxMsgSize
+	}
+	return int(atomic.LoadInt64(&bcR.maxBlockBytes)) + BlockResponseMessagePrefixSize + BlockResponseMessageFieldKeySize
+}
+
+// AddPeer implements Reactor by negotiating the version of the messages with
+// the peer and sending our state to it.
 func (bcR *Reactor) AddPeer(peer p2p.Peer) {
+	version := p2p.NegotiateChannelVersion(peer, BlocksyncChannel, BlocksyncChannelVersion)
+	peer.Set(peerChannelVersionKey, version)
+	bcR.Logger.Debug("Negotiated the channel version", "peer", peer.ID(), "version", version)
+
 	peer.Send(p2p.Envelope{
 		ChannelID: BlocksyncChannel,
 		Message: &bcproto.StatusResponse{
@@ -363,6 +400,22 @@ FOR_LOOP:
 			if err == nil {
 				// validate the block before we persist it
 				err = bcR.blockExec.ValidateBlock(state, first)
+				var mismatch sm.ErrAppHashMismatch
+				if errors.As(err, &mismatch) {
+					// +2/3 of the validators committed the block: our app hash
+					// diverged from the one of the network, not the peers.
+					// Halt, as the consensus does, instead of banning them.
+					err = bcR.blockExec.WriteDiagnostics(state, first, err)
+					bcR.Logger.Error("The app hash diverged from the one committed by +2/3 of the validators; "+
+						"stopping the block sync", "height", first.Height, "err", err)
+					if conR, ok := bcR.Switch.Reactor("CONSENSUS").(consensusReactor); ok {
+						conR.HaltOnAppHashMismatch(first, second.LastCommit, err)
+					}
+					if err := bcR.pool.Stop(); err != nil {
+						bcR.Logger.Error("Error stopping pool", "err", err)
+					}
+					break FOR_LOOP
+				}
 			}
 
 			if err != nil {
@@ -387,6 +440,7 @@ FOR_LOOP:
 			bcR.pool.PopRequest()
 
 			// TODO: batch saves so we dont persist to disk every block
+			unlockCommit := bcR.blockExec.LockCommit()
 			bcR.store.SaveBlock(first, firstParts, second.LastCommit)
 
 			// TODO: same thing for app - but we would need a way to
@@ -396,8 +450,18 @@ FOR_LOOP:
 				// TODO This is bad, are we zombie?
 				panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
 			}
+			unlockCommit()
+			atomic.StoreInt64(&bcR.maxBlockBytes, state.ConsensusParams.Block.MaxBytes)
 			blocksSynced++
 
+			if bcR.blockExec.IsHalted() {
+				bcR.Logger.Info("The node halted; stopping the block sync", "height", first.Height)
+				if err := bcR.pool.Stop(); err != nil {
+					bcR.Logger.Error("Error stopping pool", "err", err)
+				}
+				break FOR_LOOP
+			}
+
 			if blocksSynced%100 == 0 {
 				lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
 				bcR.Logger.Info("Block Sync Rate", "height", bcR.pool.height,
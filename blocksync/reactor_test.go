@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/cometbft/cometbft/libs/log"
 	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
 	"github.com/cometbft/cometbft/p2p"
+	p2pmock "github.com/cometbft/cometbft/p2p/mock"
 	"github.com/cometbft/cometbft/proxy"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/store"
@@ -59,12 +61,25 @@ func newReactor(
 	genDoc *types.GenesisDoc,
 	privVals []types.PrivValidator,
 	maxBlockHeight int64,
+) ReactorPair {
+	return newReactorWithApp(t, logger, genDoc, privVals, maxBlockHeight, &testApp{})
+}
+
+// newReactorWithApp is newReactor with the given app and options of the block
+// executor.
+func newReactorWithApp(
+	t *testing.T,
+	logger log.Logger,
+	genDoc *types.GenesisDoc,
+	privVals []types.PrivValidator,
+	maxBlockHeight int64,
+	app abci.Application,
+	options ...sm.BlockExecutorOption,
 ) ReactorPair {
 	if len(privVals) != 1 {
 		panic("only support one validator")
 	}
 
-	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
 	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
 	err := proxyApp.Start()
@@ -105,7 +120,7 @@ func newReactor(
 		DiscardABCIResponses: false,
 	})
 	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
-		mp, sm.EmptyEvidencePool{}, blockStore)
+		mp, sm.EmptyEvidencePool{}, blockStore, options...)
 	if err = stateStore.Save(state); err != nil {
 		panic(err)
 	}
@@ -208,6 +223,61 @@ func TestNoBlockResponse(t *testing.T) {
 	}
 }
 
+func TestReactorMaxRecvMsgSize(t *testing.T) {
+	config = test.ResetTestRoot("blocksync_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	reactorPair := newReactor(t, log.TestingLogger(), genDoc, privVals, 0)
+	defer func() {
+		err := reactorPair.app.Stop()
+		require.NoError(t, err)
+	}()
+	bcR := reactorPair.reactor
+	chDesc := bcR.GetChannels()[0]
+
+	// bounded by the consensus params unless syncing
+	assert.Equal(t,
+		int(genDoc.ConsensusParams.Block.MaxBytes)+BlockResponseMessagePrefixSize+BlockResponseMessageFieldKeySize,
+		chDesc.MaxRecvMessageSize())
+
+	require.NoError(t, bcR.pool.Start())
+	assert.Equal(t, MaxMsgSize, chDesc.MaxRecvMessageSize())
+	require.NoError(t, bcR.pool.Stop())
+}
+
+// versionedPeer advertises a version of BlocksyncChannel.
+type versionedPeer struct {
+	*p2pmock.Peer
+	version uint32
+}
+
+func (p *versionedPeer) NodeInfo() p2p.NodeInfo {
+	ni := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	ni.SetChannelVersion(BlocksyncChannel, p.version)
+	return ni
+}
+
+func TestReactorNegotiatesChannelVersion(t *testing.T) {
+	config = test.ResetTestRoot("blocksync_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	reactorPair := newReactor(t, log.TestingLogger(), genDoc, privVals, 0)
+	defer func() {
+		err := reactorPair.app.Stop()
+		require.NoError(t, err)
+	}()
+	bcR := reactorPair.reactor
+	assert.Equal(t, BlocksyncChannelVersion, bcR.GetChannels()[0].Version)
+
+	for _, version := range []uint32{0, p2p.DefaultChannelVersion, BlocksyncChannelVersion + 1} {
+		peer := &versionedPeer{Peer: p2pmock.NewPeer(nil), version: version}
+		bcR.AddPeer(peer)
+		assert.Equal(t, BlocksyncChannelVersion, peer.Get(peerChannelVersionKey), "advertised version %d", version)
+	}
+}
+
 // NOTE: This is too hard to test without
 // an easy way to add test peer to switch
 // or without significant refactoring of the module.
@@ -296,8 +366,50 @@ func TestBadBlockStopsPeer(t *testing.T) {
 	assert.True(t, lastReactorPair.reactor.Switch.Peers().Size() < len(reactorPairs)-1)
 }
 
+func TestReactorHaltsOnAppHashMismatch(t *testing.T) {
+	config = test.ResetTestRoot("blocksync_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+	diagnosticsDir := t.TempDir()
+
+	reactorPairs := []ReactorPair{
+		newReactor(t, log.TestingLogger(), genDoc, privVals, 10),
+		// the app of the syncing node computes another app hash
+		newReactorWithApp(t, log.TestingLogger(), genDoc, privVals, 0, &testApp{appHash: []byte{1}},
+			sm.BlockExecutorWithDiagnosticsDir(diagnosticsDir)),
+	}
+
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKSYNC", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, r := range reactorPairs {
+			err := r.reactor.Stop()
+			require.NoError(t, err)
+			err = r.app.Stop()
+			require.NoError(t, err)
+		}
+	}()
+
+	// the block 2 holds the app hash computed after the block 1
+	require.Eventually(t, func() bool { return !reactorPairs[1].reactor.pool.IsRunning() },
+		10*time.Second, 50*time.Millisecond)
+	assert.EqualValues(t, 1, reactorPairs[1].reactor.store.Height())
+	// the peer isn't to blame
+	assert.Equal(t, 1, reactorPairs[1].reactor.Switch.Peers().Size())
+
+	entries, err := os.ReadDir(diagnosticsDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasPrefix(entries[0].Name(), "2-"), entries[0].Name())
+}
+
 type testApp struct {
 	abci.BaseApplication
+
+	appHash []byte
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -323,7 +435,7 @@ func (app *testApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 }
 
 func (app *testApp) Commit() abci.ResponseCommit {
-	return abci.ResponseCommit{}
+	return abci.ResponseCommit{Data: app.appHash}
 }
 
 func (app *testApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQuery) {
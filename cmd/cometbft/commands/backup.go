@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+)
+
+var (
+	backupRPCAddr string
+	backupAPIKey  string
+)
+
+// backupPollInterval is how often the status of the backup is polled.
+const backupPollInterval = time.Second
+
+// BackupCmd backs up the data directory of a running node.
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the data directory of a running node",
+	Long: `Back up the data directory of a running node, with its /backup RPC route, into a new
+directory of rpc.backup_dir, and wait for the backup to complete. The node pauses the
+commits of blocks while its databases are copied, so that the backup is consistent at a
+height boundary, and resumes. The validator key is not backed up. The route requires an
+admin API key (see rpc.api_keys_file).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr := backupRPCAddr
+		if addr == "" {
+			addr = config.RPC.ListenAddress
+		}
+		httpClient, err := rpcclient.DefaultHTTPClient(addr)
+		if err != nil {
+			return err
+		}
+		if backupAPIKey != "" {
+			httpClient.Transport = apiKeyTransport{key: backupAPIKey, next: httpClient.Transport}
+		}
+		client, err := rpcclient.NewWithHTTPClient(addr, httpClient)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		backup := new(ctypes.ResultBackup)
+		if _, err := client.Call(ctx, "backup", map[string]interface{}{}, backup); err != nil {
+			return fmt.Errorf("failed to start the backup: %w", err)
+		}
+		fmt.Printf("Backing up height %d into %s\n", backup.Height, backup.Dir)
+
+		ticker := time.NewTicker(backupPollInterval)
+		defer ticker.Stop()
+		for !backup.Done {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+			if _, err := client.Call(ctx, "backup_status", map[string]interface{}{}, backup); err != nil {
+				return fmt.Errorf("failed to get the status of the backup: %w", err)
+			}
+		}
+		if backup.Error != "" {
+			return errors.New(backup.Error)
+		}
+		fmt.Printf("Backed up height %d into %s\n", backup.Height, backup.Dir)
+		return nil
+	},
+}
+
+func init() {
+	BackupCmd.Flags().StringVar(&backupRPCAddr, "rpc-laddr", "",
+		"the RPC address of the node, rpc.laddr if empty")
+	BackupCmd.Flags().StringVar(&backupAPIKey, "api-key", "",
+		"an admin API key of the node")
+}
+
+// apiKeyTransport presents an API key in the requests it sends.
+type apiKeyTransport struct {
+	key  string
+	next http.RoundTripper
+}
+
+func (t apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(rpcserver.APIKeyHeader, t.key)
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(req)
+}
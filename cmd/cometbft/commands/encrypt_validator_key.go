@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/privval"
+)
+
+// EncryptValidatorKeyCmd encrypts the private validator key file with a
+// passphrase.
+var EncryptValidatorKeyCmd = &cobra.Command{
+	Use:   "encrypt-validator-key",
+	Short: "Encrypt this node's private validator key with a passphrase",
+	Long: `Encrypt the private validator key file with a passphrase (argon2id and
+AES-256-GCM), in place. The passphrase is read from priv_validator_key_passphrase_file,
+the CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, the
+priv_validator_key_passphrase systemd credential, or a prompt. The node then
+reads it the same way at startup.`,
+	RunE: encryptValidatorKey,
+}
+
+func encryptValidatorKey(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !cmtos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+
+	pv := privval.LoadFilePVEmptyStateWithPassphrase(keyFilePath, "",
+		privval.KeyPassphrase(config.PrivValidatorKeyPassphraseFile()))
+	if pv.Key.IsEncrypted() {
+		return errors.New("the private validator key is already encrypted")
+	}
+	passphrase, err := privval.NewKeyPassphrase(config.PrivValidatorKeyPassphraseFile())()
+	if err != nil {
+		return err
+	}
+	if err := pv.Key.Encrypt(passphrase); err != nil {
+		return err
+	}
+	pv.Key.Save()
+
+	logger.Info("Encrypted the private validator key", "keyFile", keyFilePath)
+	return nil
+}
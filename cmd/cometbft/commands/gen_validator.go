@@ -5,6 +5,10 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/privval"
 )
@@ -16,15 +20,45 @@ var GenValidatorCmd = &cobra.Command{
 	Aliases: []string{"gen_validator"},
 	Short:   "Generate new validator keypair",
 	PreRun:  deprecateSnakeCase,
-	Run:     genValidator,
+	RunE:    genValidator,
 }
 
-func genValidator(cmd *cobra.Command, args []string) {
-	pv := privval.GenFilePV("", "")
+// keyType is the type of the validator keys generated by the gen-validator,
+// init and testnet commands.
+var keyType string
+
+const keyTypeUsage = "type of the validator key: ed25519 | secp256k1 | bls12_381"
+
+func init() {
+	GenValidatorCmd.Flags().StringVar(&keyType, "key-type", ed25519.KeyType, keyTypeUsage)
+}
+
+// genPrivKey generates a new validator private key of the given type.
+func genPrivKey(keyType string) (crypto.PrivKey, error) {
+	switch keyType {
+	case ed25519.KeyType:
+		return ed25519.GenPrivKey(), nil
+	case secp256k1.KeyType:
+		return secp256k1.GenPrivKey(), nil
+	case bls12381.KeyType:
+		return bls12381.GenPrivKey(), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+func genValidator(cmd *cobra.Command, args []string) error {
+	privKey, err := genPrivKey(keyType)
+	if err != nil {
+		return err
+	}
+
+	pv := privval.NewFilePV(privKey, "", "")
 	jsbz, err := cmtjson.Marshal(pv)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	fmt.Printf(`%v
 `, string(jsbz))
+	return nil
 }
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// GenesisCmd contains the subcommands working on genesis files.
+var GenesisCmd = &cobra.Command{
+	Use:   "genesis",
+	Short: "Validate, hash and chunk genesis files",
+}
+
+var genesisValidateCmd = &cobra.Command{
+	Use:   "validate [genesis-file]",
+	Short: "Validate a genesis file, the one of the node by default",
+	Long: `Validate a genesis file, the one of the node by default: its fields, its consensus
+params and its validator set, as the node does on startup. The app_state is not
+validated, since only the application knows its format.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genDoc, err := loadGenesisDoc(args)
+		if err != nil {
+			return err
+		}
+		// duplicate validators and the total voting power are checked when
+		// building the set
+		vals := make([]*types.Validator, len(genDoc.Validators))
+		for i, v := range genDoc.Validators {
+			vals[i] = types.NewValidator(v.PubKey, v.Power)
+		}
+		if err := new(types.ValidatorSet).UpdateWithChangeSet(vals); err != nil {
+			return fmt.Errorf("invalid validator set: %w", err)
+		}
+		fmt.Println("The genesis file is valid")
+		return nil
+	},
+}
+
+var genesisHashCmd = &cobra.Command{
+	Use:   "hash [genesis-file]",
+	Short: "Print the hash of a genesis file, the one of the node by default",
+	Long: `Print the hash of a genesis file, the one of the node by default. The hash
+depends on the content of the genesis document only, not on the formatting of the
+file, nor on its app_state being split into chunks, so the nodes of a network can
+check that they were given the same genesis.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genDoc, err := loadGenesisDoc(args)
+		if err != nil {
+			return err
+		}
+		hash, err := genDoc.Hash()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%X\n", hash)
+		return nil
+	},
+}
+
+// chunkSize is the maximum size of the app_state chunks written by genesis
+// shard.
+var chunkSize int
+
+var genesisShardCmd = &cobra.Command{
+	Use:   "shard <genesis-file> <output-file>",
+	Short: "Split the app_state of a genesis file into chunks",
+	Long: `Write the genesis document of genesis-file to output-file, with its app_state split
+into files of at most --chunk-size bytes next to it, listed with their hashes in
+app_state_chunks. The node reads the chunks into a single buffer on startup, instead
+of decoding a large app_state from JSON, which takes several times its size in
+memory. The chunks must be copied along with the genesis file.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genDoc, err := types.GenesisDocFromFile(args[0])
+		if err != nil {
+			return err
+		}
+		if err := genDoc.SaveAsChunks(args[1], chunkSize); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s with %d bytes of app_state in chunks of %d bytes\n", args[1], len(genDoc.AppState), chunkSize)
+		return nil
+	},
+}
+
+var genesisMergeCmd = &cobra.Command{
+	Use:   "merge <genesis-file> <output-file>",
+	Short: "Merge the app_state chunks of a genesis file back into it",
+	Long: `Write the genesis document of genesis-file, whose app_state is split into chunks,
+to output-file as a single file.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genDoc, err := types.GenesisDocFromFile(args[0])
+		if err != nil {
+			return err
+		}
+		if err := genDoc.SaveAs(args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", args[1])
+		return nil
+	},
+}
+
+func init() {
+	genesisShardCmd.Flags().IntVar(&chunkSize, "chunk-size", 64*1024*1024, "maximum size of the app_state chunks, in bytes")
+
+	GenesisCmd.AddCommand(genesisValidateCmd)
+	GenesisCmd.AddCommand(genesisHashCmd)
+	GenesisCmd.AddCommand(genesisShardCmd)
+	GenesisCmd.AddCommand(genesisMergeCmd)
+}
+
+// loadGenesisDoc loads the genesis file given in args, or the one of the node.
+func loadGenesisDoc(args []string) (*types.GenesisDoc, error) {
+	file := config.GenesisFile()
+	if len(args) > 0 {
+		file = args[0]
+	}
+	return types.GenesisDocFromFile(file)
+}
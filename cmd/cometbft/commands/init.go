@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/libs/os"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/p2p"
@@ -21,6 +22,10 @@ var InitFilesCmd = &cobra.Command{
 	RunE:  initFiles,
 }
 
+func init() {
+	InitFilesCmd.Flags().StringVar(&keyType, "key-type", ed25519.KeyType, keyTypeUsage)
+}
+
 func initFiles(cmd *cobra.Command, args []string) error {
 	return initFilesWithConfig(config)
 }
@@ -31,11 +36,16 @@ func initFilesWithConfig(config *cfg.Config) error {
 	privValStateFile := config.PrivValidatorStateFile()
 	var pv *privval.FilePV
 	if cmtos.FileExists(privValKeyFile) {
-		pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+		pv = privval.LoadFilePVWithPassphrase(privValKeyFile, privValStateFile,
+			privval.KeyPassphrase(config.PrivValidatorKeyPassphraseFile()))
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
-		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
+		privKey, err := genPrivKey(keyType)
+		if err != nil {
+			return err
+		}
+		pv = privval.NewFilePV(privKey, privValKeyFile, privValStateFile)
 		pv.Save()
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
@@ -65,6 +75,8 @@ func initFilesWithConfig(config *cfg.Config) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		// Allow the key type of the generated validator.
+		genDoc.ConsensusParams.Validator.PubKeyTypes = []string{pubKey.Type()}
 		genDoc.Validators = []types.GenesisValidator{{
 			Address: pubKey.Address(),
 			PubKey:  pubKey,
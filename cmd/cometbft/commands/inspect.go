@@ -22,7 +22,9 @@ var InspectCmd = &cobra.Command{
 	Short: "Run an inspect server for investigating CometBFT state",
 	Long: `
 	inspect runs a subset of CometBFT's RPC endpoints that are useful for debugging
-	issues with CometBFT.
+	issues with CometBFT, such as /block, /commit, /validators and /tx_search,
+	against the data directory of a stopped node, without starting consensus.
+	The databases are opened read-only: inspect never modifies them.
 
 	When the CometBFT detects inconsistent state, it will crash the
 	CometBFT process. CometBFT will not start up while in this inconsistent state.
@@ -39,7 +41,7 @@ func init() {
 			config.RPC.ListenAddress, "RPC listenener address. Port required")
 	InspectCmd.Flags().
 		String("db-backend",
-			config.DBBackend, "database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb")
+			config.DBBackend, "database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb")
 	InspectCmd.Flags().
 		String("db-dir", config.DBPath, "database directory")
 }
@@ -55,14 +57,29 @@ func runInspect(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
-	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
+	if err := applyInspectFlags(cmd, config); err != nil {
+		return err
+	}
+
+	// The databases are opened read-only, and can't be opened while the node runs.
+	dbProvider := inspect.ReadOnlyDBProvider(cfg.DefaultDBProvider)
+	blockStoreDB, err := dbProvider(&cfg.DBContext{ID: "blockstore", Config: config})
 	if err != nil {
 		return err
 	}
-	blockStore := store.NewBlockStore(blockStoreDB)
+	var options []store.BlockStoreOption
+	if config.Storage.ColdStorageAfter > 0 {
+		coldDB, err := dbProvider(&cfg.DBContext{ID: cfg.ColdBlockStoreDBID, Config: config})
+		if err != nil {
+			blockStoreDB.Close()
+			return err
+		}
+		options = append(options, store.WithColdStore(coldDB))
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, options...)
 	defer blockStore.Close()
 
-	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
+	stateDB, err := dbProvider(&cfg.DBContext{ID: "state", Config: config})
 	if err != nil {
 		return err
 	}
@@ -73,11 +90,11 @@ func runInspect(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	txIndexer, blockIndexer, err := block.IndexerFromConfig(config, cfg.DefaultDBProvider, genDoc.ChainID)
+	txIndexer, blockIndexer, err := block.IndexerFromConfig(config, dbProvider, genDoc.ChainID)
 	if err != nil {
 		return err
 	}
-	ins := inspect.New(config.RPC, blockStore, stateStore, txIndexer, blockIndexer, logger)
+	ins := inspect.New(config.RPC, blockStore, stateStore, txIndexer, blockIndexer, logger.With("module", "inspect"))
 
 	logger.Info("starting inspect server")
 	if err := ins.Run(ctx); err != nil {
@@ -85,3 +102,24 @@ func runInspect(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// applyInspectFlags overrides the database backend and directory of the
+// configuration with the --db-backend and --db-dir flags, if set. Unlike the
+// flags of the other commands, their names don't match the configuration keys.
+func applyInspectFlags(cmd *cobra.Command, config *cfg.Config) error {
+	if cmd.Flags().Changed("db-backend") {
+		backend, err := cmd.Flags().GetString("db-backend")
+		if err != nil {
+			return err
+		}
+		config.DBBackend = backend
+	}
+	if cmd.Flags().Changed("db-dir") {
+		dir, err := cmd.Flags().GetString("db-dir")
+		if err != nil {
+			return err
+		}
+		config.DBPath = dir
+	}
+	return nil
+}
@@ -40,6 +40,12 @@ need a primary RPC address, a trusted hash and height and witness RPC addresses
 (if not using sequential verification). To restart the node, thereafter
 only the chainID is required.
 
+Queries are verified as well: /abci_query values are verified with their
+Merkle proofs against the verified app hash, and the transactions and blocks
+returned by /tx, /tx_search and /block_search against the verified headers.
+With --update-period, the light client is kept updated in the background, so
+that the proxy can serve as a long-running query gateway.
+
 When /abci_query is called, the Merkle key path format is:
 
 	/{store name}/{key}
@@ -67,7 +73,8 @@ var (
 	trustedHash    []byte
 	trustLevelStr  string
 
-	verbose bool
+	verbose      bool
+	updatePeriod time.Duration
 
 	primaryKey   = []byte("primary")
 	witnessesKey = []byte("witnesses")
@@ -95,6 +102,10 @@ func init() {
 	LightCmd.Flags().StringVar(&trustLevelStr, "trust-level", "1/3",
 		"trust level. Must be between 1/3 and 3/3",
 	)
+	LightCmd.Flags().DurationVar(&updatePeriod, "update-period", 0,
+		"update the light client to the latest header in the background with this period. "+
+			"If 0, the light client is only updated on demand",
+	)
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
@@ -212,7 +223,8 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		cfg.WriteTimeout = config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
-	p, err := lproxy.NewProxy(c, listenAddr, primaryAddr, cfg, logger, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
+	p, err := lproxy.NewProxy(c, listenAddr, primaryAddr, cfg, logger,
+		lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()), lrpc.UpdatePeriod(updatePeriod))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+)
+
+var (
+	migrateDBFrom string
+	migrateDBTo   string
+)
+
+// migrateDBBatchSize is the number of keys written at once to the converted
+// databases.
+var migrateDBBatchSize = 10000
+
+// migratedDBs are the databases of the data directory which are converted.
+var migratedDBs = []string{"blockstore", "state", "evidence", "tx_index"}
+
+func init() {
+	MigrateDBCmd.Flags().StringVar(&migrateDBFrom, "from", string(dbm.GoLevelDBBackend),
+		"database backend of the existing databases")
+	MigrateDBCmd.Flags().StringVar(&migrateDBTo, "to", string(dbm.PebbleDBBackend),
+		"database backend to convert the databases to")
+}
+
+// MigrateDBCmd converts the databases of the node to another database
+// backend.
+var MigrateDBCmd = &cobra.Command{
+	Use:   "experimental-migrate-db",
+	Short: "convert the databases to another database backend",
+	Long: `
+Convert the blockstore, state, evidence and tx_index databases of the data
+directory from a database backend (goleveldb by default) to another one
+(pebbledb by default). The binary must be built with the tags of both backends.
+
+Every database is copied to a database of the new backend before any of them
+is replaced, so that an interrupted conversion leaves the data directory
+untouched. The original databases are kept with the .<backend>.bak suffix, and
+can be removed once the node runs with the new backend.
+
+The node must be stopped, and db_backend set to the new backend in config.toml
+afterward.
+`,
+	Example: `
+	cometbft experimental-migrate-db
+	cometbft experimental-migrate-db --from goleveldb --to pebbledb
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := dbm.BackendType(migrateDBFrom), dbm.BackendType(migrateDBTo)
+		if err := migrateDBs(config.DBDir(), from, to); err != nil {
+			return fmt.Errorf("failed to migrate the databases: %w", err)
+		}
+		fmt.Printf("Converted the databases to %s: set db_backend = %q in config.toml\n", to, to)
+		return nil
+	},
+}
+
+// migrateDBs converts the databases of dbDir from a backend to another one.
+// The converted databases are written to a temporary directory, and replace
+// the original ones once all of them are converted.
+func migrateDBs(dbDir string, from, to dbm.BackendType) error {
+	if from == to {
+		return errors.New("the databases are already of this backend")
+	}
+	if to == dbm.MemDBBackend {
+		return errors.New("the databases can't be converted to memdb")
+	}
+
+	tmpDir := filepath.Join(dbDir, "migrate-"+string(to))
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, name := range migratedDBs {
+		if !cmtos.FileExists(dbPath(dbDir, name)) {
+			logger.Info("Skipping missing database", "db", name)
+			continue
+		}
+		n, err := migrateDB(name, dbDir, tmpDir, from, to)
+		if err != nil {
+			return fmt.Errorf("converting %s: %w", name, err)
+		}
+		logger.Info("Converted database", "db", name, "keys", n)
+		names = append(names, name)
+	}
+
+	for _, name := range names {
+		backup := dbPath(dbDir, name) + "." + string(from) + ".bak"
+		if err := os.Rename(dbPath(dbDir, name), backup); err != nil {
+			return err
+		}
+		if err := os.Rename(dbPath(tmpDir, name), dbPath(dbDir, name)); err != nil {
+			return err
+		}
+		logger.Info("Replaced database", "db", name, "backup", backup)
+	}
+	return os.RemoveAll(tmpDir)
+}
+
+// migrateDB copies the database of the given name from the backend from in
+// srcDir to the backend to in dstDir, and returns the number of keys copied.
+func migrateDB(name, srcDir, dstDir string, from, to dbm.BackendType) (int, error) {
+	src, err := dbm.NewDB(name, from, srcDir)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+	dst, err := dbm.NewDB(name, to, dstDir)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	return copyDB(src, dst)
+}
+
+// copyDB copies all the keys of src to dst, and returns their number.
+func copyDB(src, dst dbm.DB) (int, error) {
+	it, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	n := 0
+	batch := dst.NewBatch()
+	defer func() { batch.Close() }()
+	for ; it.Valid(); it.Next() {
+		// the iterated keys and values can't be retained by every backend
+		key := append([]byte{}, it.Key()...)
+		value := append([]byte{}, it.Value()...)
+		if err := batch.Set(key, value); err != nil {
+			return 0, err
+		}
+		n++
+
+		if n%migrateDBBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func dbPath(dir, name string) string {
+	return filepath.Join(dir, name+".db")
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+func TestCopyDB(t *testing.T) {
+	defer func(size int) { migrateDBBatchSize = size }(migrateDBBatchSize)
+	migrateDBBatchSize = 3
+
+	src, dst := dbm.NewMemDB(), dbm.NewMemDB()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, src.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+
+	n, err := copyDB(src, dst)
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+	for i := 0; i < 10; i++ {
+		value, err := dst.Get([]byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("value%d", i), string(value))
+	}
+}
+
+func TestMigrateDBs(t *testing.T) {
+	dir := t.TempDir()
+	require.Error(t, migrateDBs(dir, dbm.GoLevelDBBackend, dbm.GoLevelDBBackend))
+	require.Error(t, migrateDBs(dir, dbm.GoLevelDBBackend, dbm.MemDBBackend))
+
+	probe, err := dbm.NewDB("probe", dbm.PebbleDBBackend, t.TempDir())
+	if err != nil {
+		t.Skip("pebbledb is not available, build with the pebbledb tag")
+	}
+	require.NoError(t, probe.Close())
+
+	for _, name := range []string{"blockstore", "state"} {
+		db, err := dbm.NewDB(name, dbm.GoLevelDBBackend, dir)
+		require.NoError(t, err)
+		require.NoError(t, db.Set([]byte("key"), []byte(name)))
+		require.NoError(t, db.Close())
+	}
+
+	require.NoError(t, migrateDBs(dir, dbm.GoLevelDBBackend, dbm.PebbleDBBackend))
+
+	for _, name := range []string{"blockstore", "state"} {
+		assert.DirExists(t, dbPath(dir, name)+".goleveldb.bak")
+		db, err := dbm.NewDB(name, dbm.PebbleDBBackend, dir)
+		require.NoError(t, err)
+		value, err := db.Get([]byte("key"))
+		require.NoError(t, err)
+		assert.Equal(t, name, string(value))
+		require.NoError(t, db.Close())
+	}
+	assert.NoDirExists(t, dbPath(dir, "evidence"))
+	assert.NoDirExists(t, dir+"/migrate-pebbledb")
+}
@@ -114,6 +114,9 @@ func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, t
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := es.Migrate(); err != nil {
+			return nil, nil, err
+		}
 		return es.BlockIndexer(), es.TxIndexer(), nil
 	case "kv":
 		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
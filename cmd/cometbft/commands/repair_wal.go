@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/consensus"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+)
+
+// RepairWALCmd truncates a torn tail of the consensus WAL, left by a crash
+// while a message was being written.
+var RepairWALCmd = &cobra.Command{
+	Use:   "repair-wal",
+	Short: "Truncate the consensus WAL after its last valid message",
+	Long: `
+Truncate the head of the consensus WAL after its last valid message. This
+removes a torn or corrupted tail left by a crash while a message was being
+written. A copy of the original file is kept with the .CORRUPTED suffix.
+
+The node must be stopped.
+`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		config, err = ParseConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		return repairWAL(config.Consensus.WalFile())
+	},
+}
+
+func repairWAL(walFile string) error {
+	backup := walFile + ".CORRUPTED"
+	if err := cmtos.CopyFile(walFile, backup); err != nil {
+		return fmt.Errorf("failed to back up WAL file: %w", err)
+	}
+
+	removed, err := consensus.TruncateWALTail(walFile)
+	if err != nil {
+		return fmt.Errorf("failed to repair WAL file: %w", err)
+	}
+
+	if removed == 0 {
+		logger.Info("WAL file is intact", "file", walFile)
+		return nil
+	}
+	logger.Info("Truncated WAL file", "file", walFile, "removed_bytes", removed, "backup", backup)
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/spf13/cobra"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proxy"
+)
+
+var (
+	replayABCIStartHeight int64
+	replayABCIEndHeight   int64
+)
+
+func init() {
+	ReplayABCICmd.Flags().String("proxy_app", config.ProxyApp,
+		"address of the candidate application the records are replayed against")
+	ReplayABCICmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | grpc)")
+	ReplayABCICmd.Flags().String("abci_client.record_dir", config.ABCIClient.RecordDir,
+		"directory of the ABCI records")
+	ReplayABCICmd.Flags().Int64Var(&replayABCIStartHeight, "start-height", 0,
+		"the height to start the replay from, the lowest recorded height if 0")
+	ReplayABCICmd.Flags().Int64Var(&replayABCIEndHeight, "end-height", 0,
+		"the height to end the replay at, the highest recorded height if 0")
+}
+
+// ReplayABCICmd replays the recorded ABCI requests against an application, and
+// reports the responses which differ from the recorded ones.
+var ReplayABCICmd = &cobra.Command{
+	Use:   "replay-abci",
+	Short: "replay the recorded ABCI requests against an application, and diff its responses",
+	Long: `
+replay-abci sends the requests of the consensus connection recorded by a node
+running with [abci_client] record = true to the application at --proxy_app, in
+order, and reports the responses which differ from the recorded ones.
+
+It allows to validate, before a coordinated upgrade, that a new version of the
+application produces the same responses, hence the same app hashes, as the
+running one. The candidate application must start from the state of the
+application before the first replayed height, e.g. from a copy of its data
+taken at that height, or from genesis if the first replayed height is the
+initial height.
+	`,
+	Example: `
+	cometbft replay-abci --proxy_app tcp://127.0.0.1:36658
+	cometbft replay-abci --proxy_app tcp://127.0.0.1:36658 --start-height 100 --end-height 200
+	`,
+	RunE: runReplayABCI,
+}
+
+func runReplayABCI(cmd *cobra.Command, args []string) error {
+	dir := config.ABCIClient.RecordDirPath()
+	heights, err := proxy.RecordedHeights(dir)
+	if err != nil {
+		return fmt.Errorf("listing the ABCI records: %w", err)
+	}
+	if len(heights) == 0 {
+		return fmt.Errorf("no ABCI records found in %s", dir)
+	}
+	from, to := replayABCIStartHeight, replayABCIEndHeight
+	if from == 0 {
+		from = heights[0]
+	}
+	if to == 0 {
+		to = heights[len(heights)-1]
+	}
+	if from > to {
+		return errors.New("the start height must not be greater than the end height")
+	}
+
+	client, err := abcicli.NewClient(config.ProxyApp, config.ABCI, true)
+	if err != nil {
+		return fmt.Errorf("creating the ABCI client: %w", err)
+	}
+	client.SetLogger(logger.With("module", "abci-client"))
+	if err := client.Start(); err != nil {
+		return fmt.Errorf("connecting to the application: %w", err)
+	}
+	defer func() {
+		if err := client.Stop(); err != nil {
+			logger.Error("Error stopping the ABCI client", "err", err)
+		}
+	}()
+
+	replayed, mismatched := 0, 0
+	for _, height := range heights {
+		if height < from || height > to {
+			continue
+		}
+		records, err := proxy.ReadRecords(dir, height)
+		if err != nil {
+			return err
+		}
+		mismatches, err := proxy.ReplayRecords(client, records)
+		if err != nil {
+			return fmt.Errorf("replaying height %d: %w", height, err)
+		}
+		replayed++
+		for _, m := range mismatches {
+			mismatched++
+			fmt.Printf("Height %d, request %d (%T): the responses differ\n", height, m.Index, m.Request.Value)
+			fmt.Printf("  recorded: %s\n", responseJSON(m.Recorded))
+			fmt.Printf("  replayed: %s\n", responseJSON(m.Replayed))
+		}
+	}
+
+	fmt.Printf("Replayed %d heights, from %d to %d\n", replayed, from, to)
+	if mismatched > 0 {
+		return fmt.Errorf("%d responses differ from the recorded ones", mismatched)
+	}
+	fmt.Println("All the responses match the recorded ones")
+	return nil
+}
+
+func responseJSON(res *abci.Response) string {
+	s, err := new(jsonpb.Marshaler).MarshalToString(res)
+	if err != nil {
+		return res.String()
+	}
+	return s
+}
@@ -64,6 +64,7 @@ func resetAllCmd(cmd *cobra.Command, args []string) (err error) {
 		config.P2P.AddrBookFile(),
 		config.PrivValidatorKeyFile(),
 		config.PrivValidatorStateFile(),
+		config.PrivValidatorKeyPassphraseFile(),
 		logger,
 	)
 }
@@ -76,12 +77,16 @@ func resetPrivValidator(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
+	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(),
+		config.PrivValidatorKeyPassphraseFile(), logger)
 	return nil
 }
 
 // resetAll removes address book files plus all data, and resets the privValdiator data.
-func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) error {
+func resetAll(
+	dbDir, addrBookFile, privValKeyFile, privValStateFile, privValPassphraseFile string,
+	logger log.Logger,
+) error {
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
 	} else {
@@ -99,7 +104,7 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	}
 
 	// recreate the dbDir since the privVal state needs to live there
-	resetFilePV(privValKeyFile, privValStateFile, logger)
+	resetFilePV(privValKeyFile, privValStateFile, privValPassphraseFile, logger)
 	return nil
 }
 
@@ -157,9 +162,12 @@ func resetState(dbDir string, logger log.Logger) error {
 	return nil
 }
 
-func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
+// resetFilePV resets the state of the private validator, the passphrase of an
+// encrypted key being read as with privval.KeyPassphrase(privValPassphraseFile).
+func resetFilePV(privValKeyFile, privValStateFile, privValPassphraseFile string, logger log.Logger) {
 	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
+		pv := privval.LoadFilePVEmptyStateWithPassphrase(privValKeyFile, privValStateFile,
+			privval.KeyPassphrase(privValPassphraseFile))
 		pv.Reset()
 		logger.Info(
 			"Reset private validator file to genesis state",
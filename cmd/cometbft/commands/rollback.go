@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -21,8 +23,8 @@ func init() {
 }
 
 var RollbackStateCmd = &cobra.Command{
-	Use:   "rollback",
-	Short: "rollback CometBFT state by one height",
+	Use:   "rollback [heights]",
+	Short: "rollback CometBFT state by one height, or more with --hard",
 	Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when CometBFT has persisted an incorrect app hash and is thus unable to make
@@ -31,9 +33,37 @@ The application should also roll back to height n - 1. If the --hard flag is not
 no blocks will be removed so upon restarting CometBFT the transactions in block n will be 
 re-executed against the application. Using --hard will also remove block n. This can
 be done multiple times.
+
+With --hard, the number of heights to roll back can be given, e.g. 'rollback --hard 5'
+to recover from an application failure detected late: the blocks, states and ABCI
+responses above height n - 5 are removed. The application should also roll back
+to this height. The heights rolled back must be within the evidence window of the
+consensus params, and their blocks must not have been pruned.
 `,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		height, hash, err := RollbackState(config, removeBlock)
+		heights := int64(1)
+		if len(args) > 0 {
+			var err error
+			heights, err = strconv.ParseInt(args[0], 10, 64)
+			if err != nil || heights < 1 {
+				return fmt.Errorf("invalid number of heights %q", args[0])
+			}
+		}
+		if heights > 1 && !removeBlock {
+			return errors.New("rolling back more than one height requires --hard")
+		}
+
+		var (
+			height int64
+			hash   []byte
+			err    error
+		)
+		if heights > 1 {
+			height, hash, err = RollbackStateHeights(config, heights)
+		} else {
+			height, hash, err = RollbackState(config, removeBlock)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to rollback state: %w", err)
 		}
@@ -66,6 +96,27 @@ func RollbackState(config *cfg.Config, removeBlock bool) (int64, []byte, error)
 	return state.Rollback(blockStore, stateStore, removeBlock)
 }
 
+// RollbackStateHeights rolls back the state, the blocks and the ABCI responses
+// of the given number of heights, from the latest state height n to height
+// n - heights. Returns the latest state height and app hash alongside an error
+// if there was one.
+func RollbackStateHeights(config *cfg.Config, heights int64) (int64, []byte, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return -1, nil, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	st, err := stateStore.Load()
+	if err != nil {
+		return -1, nil, err
+	}
+	return state.RollbackTo(blockStore, stateStore, st.LastBlockHeight-heights)
+}
+
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
 	dbType := dbm.BackendType(config.DBBackend)
 
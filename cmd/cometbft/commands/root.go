@@ -83,16 +83,23 @@ var RootCmd = &cobra.Command{
 			logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
 		}
 
-		logger, err = cmtflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel)
+		// the log level can be changed upon a reload of the configuration
+		next := logger
+		reloadable, err := log.NewReloadableLogger(config.LogLevel, func(level string) (log.Logger, error) {
+			logger, err := cmtflags.ParseLogLevel(level, next, cfg.DefaultLogLevel)
+			if err != nil {
+				return nil, err
+			}
+			if viper.GetBool(cli.TraceFlag) {
+				logger = log.NewTracingLogger(logger)
+			}
+			return logger, nil
+		})
 		if err != nil {
 			return err
 		}
 
-		if viper.GetBool(cli.TraceFlag) {
-			logger = log.NewTracingLogger(logger)
-		}
-
-		logger = logger.With("module", "main")
+		logger = reloadable.With("module", "main")
 		return nil
 	},
 }
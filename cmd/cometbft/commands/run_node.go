@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	cfg "github.com/cometbft/cometbft/config"
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -24,6 +25,12 @@ func AddNodeFlags(cmd *cobra.Command) {
 	// bind flags
 	cmd.Flags().String("moniker", config.Moniker, "node name")
 
+	// halt flags
+	cmd.Flags().Int64("halt-height", config.HaltHeight,
+		"stop the node after committing the block of this height (0 to disable)")
+	cmd.Flags().Int64("halt-time", config.HaltTime,
+		"stop the node after committing the first block whose time is not before this UNIX time, in seconds (0 to disable)")
+
 	// priv val flags
 	cmd.Flags().String(
 		"priv_validator_laddr",
@@ -68,7 +75,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String("p2p.bootstrap_peers", config.P2P.BootstrapPeers, "comma-delimited ID@host:port peers to be added to the addressbook on startup")
 	cmd.Flags().String("p2p.unconditional_peer_ids",
 		config.P2P.UnconditionalPeerIDs, "comma-delimited IDs of unconditional peers")
-	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPNP port forwarding")
+	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPnP/NAT-PMP port forwarding")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "enable/disable seed mode")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "comma-delimited private peer IDs")
@@ -87,7 +94,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"db_backend",
 		config.DBBackend,
-		"database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb")
+		"database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb")
 	cmd.Flags().String(
 		"db_dir",
 		config.DBPath,
@@ -105,6 +112,9 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 			if err := checkGenesisHash(config); err != nil {
 				return err
 			}
+			if err := applyHaltFlags(cmd, config); err != nil {
+				return err
+			}
 
 			n, err := nodeProvider(config, logger)
 			if err != nil {
@@ -117,6 +127,15 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 
 			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
 
+			// Reload the configuration upon receiving SIGHUP, along with the
+			// command-line flags and environment variables overriding it.
+			n.SetConfigReader(func() (*cfg.Config, error) { return reloadConfig(cmd) })
+			cmtos.TrapHangupSignal(logger, func() {
+				if _, err := n.ReloadConfigFile(); err != nil {
+					logger.Error("unable to reload the configuration", "error", err)
+				}
+			})
+
 			// Stop upon receiving SIGTERM or CTRL-C.
 			cmtos.TrapSignal(logger, func() {
 				if n.IsRunning() {
@@ -126,8 +145,9 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 				}
 			})
 
-			// Run forever.
-			select {}
+			// Run until the node stops, e.g. after the halt height.
+			<-n.Quit()
+			return nil
 		},
 	}
 
@@ -135,6 +155,36 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 	return cmd
 }
 
+// reloadConfig reads the configuration file again, and returns the
+// configuration it sets, overridden by the command-line flags and environment
+// variables as when the node started.
+func reloadConfig(cmd *cobra.Command) (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading the config file: %w", err)
+	}
+	return ParseConfig(cmd)
+}
+
+// applyHaltFlags overrides the halt height and time of the configuration with
+// the --halt-height and --halt-time flags, if set.
+func applyHaltFlags(cmd *cobra.Command, config *cfg.Config) error {
+	if cmd.Flags().Changed("halt-height") {
+		height, err := cmd.Flags().GetInt64("halt-height")
+		if err != nil {
+			return err
+		}
+		config.HaltHeight = height
+	}
+	if cmd.Flags().Changed("halt-time") {
+		haltTime, err := cmd.Flags().GetInt64("halt-time")
+		if err != nil {
+			return err
+		}
+		config.HaltTime = haltTime
+	}
+	return config.ValidateBasic()
+}
+
 func checkGenesisHash(config *cfg.Config) error {
 	if len(genesisHash) == 0 || config.Genesis == "" {
 		return nil
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/seed"
+	"github.com/cometbft/cometbft/types"
+)
+
+// SeedCmd runs a standalone seed node.
+var SeedCmd = &cobra.Command{
+	Use:   "seed [chainID]",
+	Short: "Run a standalone seed node, crawling the network for peers",
+	Long: `Run a standalone seed node.
+
+The seed only crawls the network of the chain with the peer exchange protocol,
+keeping the addresses of the peers it finds in its address book, and hands
+them out to the nodes connecting to it, at most --max-addrs-per-group of each
+network (/16 for IPv4, /32 for IPv6) at once. It needs neither the state and
+block stores nor an application: the chain ID is taken from the genesis file
+if not given, and only the [p2p] and [rpc] sections of the configuration are
+used. The /crawl_status and /health endpoints are served on rpc.laddr.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSeed,
+}
+
+func init() {
+	SeedCmd.Flags().String("p2p.laddr", config.P2P.ListenAddress, "node listen address")
+	SeedCmd.Flags().String("p2p.external-address", config.P2P.ExternalAddress,
+		"ip:port address to advertise to peers for them to dial")
+	SeedCmd.Flags().String("p2p.seeds", config.P2P.Seeds, "comma-delimited ID@host:port seed nodes")
+	SeedCmd.Flags().String("p2p.persistent_peers", config.P2P.PersistentPeers,
+		"comma-delimited ID@host:port persistent peers")
+	SeedCmd.Flags().String("p2p.bootstrap_peers", config.P2P.BootstrapPeers,
+		"comma-delimited ID@host:port peers to be added to the addressbook on startup")
+	SeedCmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address, empty to disable the RPC")
+	SeedCmd.Flags().Int("max-addrs-per-group", seed.DefaultMaxAddrsPerGroup,
+		"maximum number of addresses of a network group handed out at once, 0 for no limit")
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	var chainID string
+	if len(args) > 0 {
+		chainID = args[0]
+	} else {
+		if !cmtos.FileExists(config.GenesisFile()) {
+			return errors.New("chain ID required: no genesis file to take it from")
+		}
+		genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return err
+		}
+		chainID = genDoc.ChainID
+	}
+
+	seedConfig := seed.ConfigFromNodeConfig(config, chainID)
+	maxAddrsPerGroup, err := cmd.Flags().GetInt("max-addrs-per-group")
+	if err != nil {
+		return err
+	}
+	seedConfig.MaxAddrsPerGroup = maxAddrsPerGroup
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
+	}
+	s, err := seed.New(seedConfig, nodeKey, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-c
+		cancel()
+	}()
+
+	return s.Run(ctx)
+}
@@ -25,7 +25,8 @@ func showValidator(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 	}
 
-	pv := privval.LoadFilePV(keyFilePath, config.PrivValidatorStateFile())
+	pv := privval.LoadFilePVWithPassphrase(keyFilePath, config.PrivValidatorStateFile(),
+		privval.KeyPassphrase(config.PrivValidatorKeyPassphraseFile()))
 
 	pubKey, err := pv.GetPubKey()
 	if err != nil {
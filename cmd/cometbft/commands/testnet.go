@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/libs/bytes"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/p2p"
@@ -74,6 +75,7 @@ func init() {
 		"P2P Port")
 	TestnetFilesCmd.Flags().BoolVar(&randomMonikers, "random-monikers", false,
 		"randomize the moniker for each generated node")
+	TestnetFilesCmd.Flags().StringVar(&keyType, "key-type", ed25519.KeyType, keyTypeUsage)
 }
 
 // TestnetFilesCmd allows initialisation of files for a CometBFT testnet.
@@ -142,7 +144,8 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 
 		pvKeyFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorKey)
 		pvStateFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorState)
-		pv := privval.LoadFilePV(pvKeyFile, pvStateFile)
+		pv := privval.LoadFilePVWithPassphrase(pvKeyFile, pvStateFile,
+			privval.KeyPassphrase(config.PrivValidatorKeyPassphraseFile()))
 
 		pubKey, err := pv.GetPubKey()
 		if err != nil {
@@ -185,6 +188,7 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		InitialHeight:   initialHeight,
 		Validators:      genVals,
 	}
+	genDoc.ConsensusParams.Validator.PubKeyTypes = []string{keyType}
 
 	// Write genesis file.
 	for i := 0; i < nValidators+nNonValidators; i++ {
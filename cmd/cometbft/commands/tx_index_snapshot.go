@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/state/txindex/kv"
+)
+
+var snapshotDir string
+
+// TxIndexSnapshotCmd contains the subcommands working on the snapshots of the
+// kv tx index.
+var TxIndexSnapshotCmd = &cobra.Command{
+	Use:   "tx-index-snapshot",
+	Short: "Export and import snapshots of the kv tx index",
+	Long: `Export and import snapshots of the kv tx index, which also holds the block index. A
+snapshot is content-addressed: it is split into chunks identified by their hash, and is
+identified by the hash of its height and chunks. The snapshots of the directory set by
+tx_index_snapshot_dir are served to peers over state sync, and a node restores the one set by
+tx_index_snapshot_hash after its state sync, instead of reindexing the blocks.`,
+}
+
+var txIndexSnapshotExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the kv tx index into a snapshot",
+	Long: `Export the kv tx index into a snapshot, at the last height of the node, and print its
+hash. The node must be stopped.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer blockStore.Close()
+		defer stateStore.Close()
+		state, err := stateStore.Load()
+		if err != nil {
+			return err
+		}
+
+		txIndex, closer, err := loadKVTxIndex()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		s, err := txIndex.ExportSnapshot(txIndexSnapshotDir(), state.LastBlockHeight)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Exported the snapshot of height %d with %d chunks, hash %X\n",
+			s.Height, len(s.ChunkHashes), s.Hash)
+		return nil
+	},
+}
+
+var txIndexSnapshotImportCmd = &cobra.Command{
+	Use:   "import [hash]",
+	Short: "Import a snapshot into the kv tx index, which must be empty",
+	Long: `Import the snapshot with the given hash into the kv tx index, which must be empty, from
+the snapshot directory. Its chunks are verified against the hash. The node must be stopped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hash, err := hex.DecodeString(args[0])
+		if err != nil || len(hash) == 0 {
+			return fmt.Errorf("invalid snapshot hash %q", args[0])
+		}
+
+		txIndex, closer, err := loadKVTxIndex()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		s, err := txIndex.ImportSnapshot(txIndexSnapshotDir(), hash)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported the snapshot of height %d\n", s.Height)
+		return nil
+	},
+}
+
+func init() {
+	TxIndexSnapshotCmd.PersistentFlags().StringVar(&snapshotDir, "dir", "",
+		"directory of the snapshots, tx_index_snapshot_dir by default")
+
+	TxIndexSnapshotCmd.AddCommand(txIndexSnapshotExportCmd)
+	TxIndexSnapshotCmd.AddCommand(txIndexSnapshotImportCmd)
+}
+
+func txIndexSnapshotDir() string {
+	if snapshotDir != "" {
+		return snapshotDir
+	}
+	return config.StateSync.TxIndexSnapshotDir()
+}
+
+// loadKVTxIndex opens the database of the kv tx index of the node.
+func loadKVTxIndex() (*kv.TxIndex, dbm.DB, error) {
+	if strings.ToLower(config.TxIndex.Indexer) != "kv" {
+		return nil, nil, errors.New("snapshots are only supported by the kv tx indexer")
+	}
+	store, err := dbm.NewDB("tx_index", dbm.BackendType(config.DBBackend), config.DBDir())
+	if err != nil {
+		return nil, nil, err
+	}
+	return kv.NewTxIndex(store), store, nil
+}
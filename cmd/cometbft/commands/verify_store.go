@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/config"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+var (
+	verifyStartHeight int64
+	verifyEndHeight   int64
+	verifyRepairFrom  []string
+)
+
+func init() {
+	VerifyStoreCmd.Flags().Int64Var(&verifyStartHeight, "start-height", 0,
+		"the height to start the verification from, the base height if 0")
+	VerifyStoreCmd.Flags().Int64Var(&verifyEndHeight, "end-height", 0,
+		"the height to end the verification at, the latest height if 0")
+	VerifyStoreCmd.Flags().StringSliceVar(&verifyRepairFrom, "repair-from", nil,
+		"comma-separated RPC addresses of live nodes to fetch the corrupted blocks from")
+	VerifyStoreCmd.Flags().
+		String("db-backend",
+			config.DBBackend, "database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb")
+	VerifyStoreCmd.Flags().
+		String("db-dir", config.DBPath, "database directory")
+
+	InspectCmd.AddCommand(VerifyStoreCmd)
+}
+
+// VerifyStoreCmd checks the integrity of the block store.
+var VerifyStoreCmd = &cobra.Command{
+	Use:   "verify-store",
+	Short: "verify the integrity of the blocks of the block store",
+	Long: `
+verify-store walks the block store, from the base height to the latest height by
+default, recomputes the hash of every block, and verifies its commit against the
+validator set of the state store. The ranges of heights whose blocks are
+corrupted are reported. The commits of the heights whose validator set was
+pruned can't be verified.
+
+With --repair-from, the corrupted blocks are fetched, along with their commits,
+from the RPC endpoints of live nodes of the network, verified against the stored
+validator sets, and replace the corrupted ones.
+
+The node must be stopped.
+	`,
+	Example: `
+	cometbft inspect verify-store
+	cometbft inspect verify-store --start-height 100 --end-height 200
+	cometbft inspect verify-store --repair-from tcp://10.0.0.2:26657,tcp://10.0.0.3:26657
+	`,
+	RunE: runVerifyStore,
+}
+
+func runVerifyStore(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-c
+		cancel()
+	}()
+
+	blockStoreDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return err
+	}
+	var options []store.BlockStoreOption
+	if config.Storage.ColdStorageAfter > 0 {
+		coldDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: cfg.ColdBlockStoreDBID, Config: config})
+		if err != nil {
+			blockStoreDB.Close()
+			return err
+		}
+		options = append(options, store.WithColdStore(coldDB))
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, options...)
+	defer blockStore.Close()
+
+	stateDB, err := cfg.DefaultDBProvider(&cfg.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return err
+	}
+	stateStore := state.NewStore(stateDB, state.StoreOptions{DiscardABCIResponses: false})
+	defer stateStore.Close()
+
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+
+	if blockStore.Height() == 0 {
+		return errors.New("the block store is empty")
+	}
+	from, to := verifyStartHeight, verifyEndHeight
+	if from == 0 {
+		from = blockStore.Base()
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+
+	logger.Info("Verifying the block store", "from", from, "to", to)
+	res, err := blockStore.Verify(ctx, stateStore, genDoc.ChainID, from, to)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Verified %d heights, %d heights without validator set\n", res.Verified, res.Unverified)
+	if len(res.Corrupted) == 0 {
+		fmt.Println("No corrupted blocks found")
+		return nil
+	}
+	corrupted := int64(0)
+	for _, r := range res.Corrupted {
+		fmt.Printf("Corrupted heights %d to %d: %v\n", r.From, r.To, r.Err)
+		corrupted += r.To - r.From + 1
+	}
+
+	if len(verifyRepairFrom) == 0 {
+		return fmt.Errorf("found %d corrupted heights", corrupted)
+	}
+	repaired, err := repairBlocks(ctx, blockStore, stateStore, genDoc.ChainID, res.Corrupted, verifyRepairFrom)
+	fmt.Printf("Repaired %d of %d corrupted heights\n", repaired, corrupted)
+	if err != nil {
+		return err
+	}
+	if repaired < corrupted {
+		return fmt.Errorf("%d corrupted heights could not be repaired", corrupted-repaired)
+	}
+	return nil
+}
+
+// repairBlocks fetches the blocks of the corrupted ranges from the RPC
+// endpoints of live nodes, and replaces the stored blocks by the first fetched
+// ones which are verified. It returns the number of repaired heights.
+func repairBlocks(
+	ctx context.Context,
+	blockStore *store.BlockStore,
+	stateStore state.Store,
+	chainID string,
+	corrupted []store.CorruptedRange,
+	addrs []string,
+) (int64, error) {
+	clients := make([]*rpchttp.HTTP, len(addrs))
+	for i, addr := range addrs {
+		client, err := rpchttp.New(addr, "/websocket")
+		if err != nil {
+			return 0, fmt.Errorf("creating the RPC client of %s: %w", addr, err)
+		}
+		clients[i] = client
+	}
+
+	repaired := int64(0)
+	for _, r := range corrupted {
+		for height := r.From; height <= r.To; height++ {
+			if err := ctx.Err(); err != nil {
+				return repaired, err
+			}
+			if repairBlock(ctx, blockStore, stateStore, chainID, height, clients, addrs) {
+				repaired++
+			}
+		}
+	}
+	return repaired, nil
+}
+
+// repairBlock replaces the block of the given height by the first verified
+// block fetched from the clients, and reports whether it did.
+func repairBlock(
+	ctx context.Context,
+	blockStore *store.BlockStore,
+	stateStore state.Store,
+	chainID string,
+	height int64,
+	clients []*rpchttp.HTTP,
+	addrs []string,
+) bool {
+	for i, client := range clients {
+		block, err := client.Block(ctx, &height)
+		if err != nil {
+			logger.Error("Failed to fetch the block", "height", height, "from", addrs[i], "err", err)
+			continue
+		}
+		commit, err := client.Commit(ctx, &height)
+		if err != nil {
+			logger.Error("Failed to fetch the commit", "height", height, "from", addrs[i], "err", err)
+			continue
+		}
+		if err := blockStore.RepairBlock(stateStore, chainID, block.Block, commit.Commit); err != nil {
+			logger.Error("Failed to repair the block", "height", height, "from", addrs[i], "err", err)
+			continue
+		}
+		if _, err := blockStore.VerifyBlock(stateStore, chainID, height); err != nil {
+			logger.Error("Repaired block is still corrupted", "height", height, "err", err)
+			return false
+		}
+		logger.Info("Repaired block", "height", height, "from", addrs[i])
+		return true
+	}
+	return false
+}
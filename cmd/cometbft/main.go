@@ -20,17 +20,25 @@ func main() {
 		cmd.LightCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
+		cmd.ReplayABCICmd,
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ResetStateCmd,
 		cmd.ShowValidatorCmd,
+		cmd.EncryptValidatorKeyCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
 		cmd.RollbackStateCmd,
+		cmd.GenesisCmd,
 		cmd.CompactGoLevelDBCmd,
+		cmd.MigrateDBCmd,
 		cmd.InspectCmd,
+		cmd.RepairWALCmd,
+		cmd.SeedCmd,
+		cmd.TxIndexSnapshotCmd,
+		cmd.BackupCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
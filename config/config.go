@@ -5,11 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cometbft/cometbft/store/remotedb"
 	"github.com/cometbft/cometbft/version"
 )
 
@@ -24,6 +28,19 @@ const (
 	// LogFormatJSON is a format for json output
 	LogFormatJSON = "json"
 
+	// P2PTransportTCP is the transport multiplexing the channels over TCP
+	// connections (MConnection)
+	P2PTransportTCP = "tcp"
+	// P2PTransportQUIC is the transport mapping the channels to QUIC streams
+	P2PTransportQUIC = "quic"
+
+	// DefaultChannelWeights gives the consensus block part (0x21) and vote
+	// (0x22) channels precedence over the mempool (0x30, priority 5) and
+	// blocksync (0x40, priority 5) channels under contention, while keeping
+	// a share of the send rate for the consensus state (0x20) and vote set
+	// bits (0x23) channels, which the peers need to know what to gossip.
+	DefaultChannelWeights = "0x20:50,0x21:100,0x22:100,0x23:25"
+
 	// DefaultLogLevel defines a default log level as INFO.
 	DefaultLogLevel = "info"
 
@@ -75,6 +92,7 @@ type Config struct {
 	StateSync       *StateSyncConfig       `mapstructure:"statesync"`
 	BlockSync       *BlockSyncConfig       `mapstructure:"blocksync"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
+	ABCIClient      *ABCIClientConfig      `mapstructure:"abci_client"`
 	Storage         *StorageConfig         `mapstructure:"storage"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx_index"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
@@ -90,6 +108,7 @@ func DefaultConfig() *Config {
 		StateSync:       DefaultStateSyncConfig(),
 		BlockSync:       DefaultBlockSyncConfig(),
 		Consensus:       DefaultConsensusConfig(),
+		ABCIClient:      DefaultABCIClientConfig(),
 		Storage:         DefaultStorageConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
@@ -106,6 +125,7 @@ func TestConfig() *Config {
 		StateSync:       TestStateSyncConfig(),
 		BlockSync:       TestBlockSyncConfig(),
 		Consensus:       TestConsensusConfig(),
+		ABCIClient:      TestABCIClientConfig(),
 		Storage:         TestStorageConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
@@ -119,6 +139,8 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.ABCIClient.RootDir = root
+	cfg.StateSync.RootDir = root
 	return cfg
 }
 
@@ -146,9 +168,20 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.ABCIClient.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [abci_client] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if err := cfg.Storage.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [storage] section: %w", err)
+	}
+	if cfg.Storage.ColdStorageDir != "" &&
+		filepath.Clean(rootify(cfg.Storage.ColdStorageDir, cfg.RootDir)) == filepath.Clean(cfg.DBDir()) {
+		// the cold store would be the block store itself
+		return errors.New("error in [storage] section: cold_storage_dir can't be the data directory")
+	}
 	return nil
 }
 
@@ -179,7 +212,7 @@ type BaseConfig struct { //nolint: maligned
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
-	// Database backend: goleveldb | cleveldb | boltdb | rocksdb
+	// Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb
 	// * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 	//   - pure go
 	//   - stable
@@ -198,6 +231,12 @@ type BaseConfig struct { //nolint: maligned
 	// * badgerdb (uses github.com/dgraph-io/badger)
 	//   - EXPERIMENTAL
 	//   - use badgerdb build tag (go build -tags badgerdb)
+	// * pebbledb (uses github.com/cockroachdb/pebble)
+	//   - EXPERIMENTAL
+	//   - pure go
+	//   - use pebbledb build tag (go build -tags pebbledb)
+	//   - existing goleveldb databases can be converted with the
+	//     experimental-migrate-db command
 	DBBackend string `mapstructure:"db_backend"`
 
 	// Database directory
@@ -212,12 +251,23 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis_file"`
 
+	// If not 0, the app_state of the genesis file is sent to the app in chunks
+	// of at most this many bytes, in consecutive InitChain requests. The app
+	// must support it.
+	InitChainChunkSize int `mapstructure:"init_chain_chunk_size"`
+
 	// Path to the JSON file containing the private key to use as a validator in the consensus protocol
 	PrivValidatorKey string `mapstructure:"priv_validator_key_file"`
 
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
+	// Path to the file containing the passphrase of an encrypted private
+	// validator key. If empty, the passphrase is read from the
+	// CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, the
+	// priv_validator_key_passphrase systemd credential, or a prompt.
+	PrivValidatorKeyPassphrase string `mapstructure:"priv_validator_key_passphrase_file"`
+
 	// TCP or UNIX socket address for CometBFT to listen on for
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
@@ -231,6 +281,19 @@ type BaseConfig struct { //nolint: maligned
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
+
+	// If not 0, the node stops after committing the block of this height
+	HaltHeight int64 `mapstructure:"halt_height"`
+
+	// If not 0, the node stops after committing the first block whose time is
+	// not before this UNIX time, in seconds
+	HaltTime int64 `mapstructure:"halt_time"`
+
+	// Directory where a diagnostics bundle is written when a block fails to
+	// be applied, e.g. upon an app hash mismatch: the block, the ABCI
+	// responses, the states before and after, and the validator set. Empty
+	// disables the bundles.
+	DiagnosticsDir string `mapstructure:"diagnostics_dir"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a CometBFT node
@@ -249,6 +312,7 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers:        false,
 		DBBackend:          "goleveldb",
 		DBPath:             DefaultDataDir,
+		DiagnosticsDir:     filepath.Join(DefaultDataDir, "diagnostics"),
 	}
 }
 
@@ -270,6 +334,15 @@ func (cfg BaseConfig) PrivValidatorKeyFile() string {
 	return rootify(cfg.PrivValidatorKey, cfg.RootDir)
 }
 
+// PrivValidatorKeyPassphraseFile returns the full path to the file containing
+// the passphrase of an encrypted private validator key, or "" if unset.
+func (cfg BaseConfig) PrivValidatorKeyPassphraseFile() string {
+	if cfg.PrivValidatorKeyPassphrase == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorKeyPassphrase, cfg.RootDir)
+}
+
 // PrivValidatorFile returns the full path to the priv_validator_state.json file
 func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
@@ -285,6 +358,20 @@ func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
 }
 
+// DiagnosticsDirPath returns the full path to the directory of the
+// diagnostics bundles.
+func (cfg BaseConfig) DiagnosticsDirPath() string {
+	return rootify(cfg.DiagnosticsDir, cfg.RootDir)
+}
+
+// HaltTimestamp returns the halt time, or the zero time if unset.
+func (cfg BaseConfig) HaltTimestamp() time.Time {
+	if cfg.HaltTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(cfg.HaltTime, 0).UTC()
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
@@ -299,6 +386,15 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.InitChainChunkSize < 0 {
+		return errors.New("init_chain_chunk_size can't be negative")
+	}
+	if cfg.HaltHeight < 0 {
+		return errors.New("halt_height can't be negative")
+	}
+	if cfg.HaltTime < 0 {
+		return errors.New("halt_time can't be negative")
+	}
 	return nil
 }
 
@@ -324,8 +420,22 @@ type RPCConfig struct {
 	// A list of non simple headers the client is allowed to use with cross-domain requests.
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
+	// A list of headers of the responses the client is allowed to read with
+	// cross-domain requests.
+	CORSExposedHeaders []string `mapstructure:"cors_exposed_headers"`
+
+	// Whether cross-domain requests may include credentials (cookies, HTTP
+	// authentication and client certificates).
+	CORSAllowCredentials bool `mapstructure:"cors_allow_credentials"`
+
+	// How long the result of a preflight request may be cached by the client.
+	// 0 - the client's default.
+	CORSMaxAge time.Duration `mapstructure:"cors_max_age"`
+
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server only supports /broadcast_tx_commit, /broadcast_evidence,
+	// serving light blocks to light clients, streaming the blocks and the tx
+	// results (StreamAPI), and serving the block results (BlockResultsService)
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -380,6 +490,30 @@ type RPCConfig struct {
 	// predictability in subscription behavior.
 	CloseOnSlowClient bool `mapstructure:"experimental_close_on_slow_client"`
 
+	// The number of the last published events kept in memory, so that a
+	// WebSocket client that reconnects can resume its subscription with
+	// /subscribe?resume=N and receive the events published after sequence
+	// number N.
+	// 0 - subscriptions can't be resumed.
+	EventReplayBufferSize int `mapstructure:"event_replay_buffer_size"`
+
+	// Record the events of the given types in a log on disk (the eventlog
+	// database), which clients read with /events, e.g. to recover the events
+	// published while they were down. Unlike the replay buffer, the log
+	// survives the restarts of the node.
+	EventLog bool `mapstructure:"event_log"`
+
+	// The types of the events recorded in the event log.
+	EventLogTypes []string `mapstructure:"event_log_types"`
+
+	// The number of the latest events kept in the event log.
+	// 0 - all the events are kept.
+	EventLogRetainEvents int64 `mapstructure:"event_log_retain_events"`
+
+	// Minimum number of peers of a node ready to serve requests, as reported
+	// by /health?ready=true.
+	ReadinessMinPeers int `mapstructure:"readiness_min_peers"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
@@ -392,6 +526,47 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// The algorithms, in order of preference, the responses are compressed
+	// with if the client accepts one of them: "zstd" and/or "gzip".
+	// WebSocket messages are never compressed.
+	Compression []string `mapstructure:"compression"`
+
+	// Serve HTTP/2 over cleartext TCP (h2c), besides HTTP/1.1. HTTP/2 is
+	// always served over TLS.
+	H2C bool `mapstructure:"h2c"`
+
+	// Maximum number of results a /tx_search or /block_search query may
+	// match, beyond which the query fails rather than being paginated.
+	// 0 - unlimited.
+	MaxSearchResults int `mapstructure:"max_search_results"`
+
+	// Maximum number of index entries a /tx_search or /block_search query may
+	// scan, beyond which the query fails, bounding its cost. Only applies to
+	// the "kv" indexer.
+	// 0 - unlimited.
+	MaxSearchScannedEntries int `mapstructure:"max_search_scanned_entries"`
+
+	// Maximum number of requests of a JSON-RPC batch executed concurrently.
+	// The responses are returned in the order of the requests regardless.
+	// 0 or 1 - the requests are executed sequentially.
+	MaxBatchConcurrency int `mapstructure:"max_batch_concurrency"`
+
+	// Rate limits of the requests of each client, identified by its API key or
+	// else its IP address, per route class, in the form "class:rps:burst".
+	// The routes are of the "default" class, except for /tx_search and
+	// /block_search ("search") and the /broadcast_* routes ("broadcast").
+	// The routes of a class without a limit are not rate limited.
+	RateLimits []string `mapstructure:"rate_limits"`
+
+	// The path to a JSON file of API keys, which clients present in the
+	// X-Api-Key header to be rate limited on their own, or not at all:
+	// [{"key": "...", "name": "indexer", "unlimited": true}]
+	// Only the clients with an "admin": true key may call the /reload_config
+	// route.
+	// Might be either absolute path or path related to CometBFT's config directory.
+	// Requests with an API key which is not in the file are rejected.
+	APIKeysFile string `mapstructure:"api_keys_file"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to CometBFT's config directory.
 	//
@@ -413,6 +588,17 @@ type RPCConfig struct {
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	// FIXME: This should be moved under the instrumentation section
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
+
+	// Directory where the debug_snapshot route writes the bundles of profiles
+	// and store statistics it captures, relative to the home directory if not
+	// absolute. The route requires an admin API key.
+	DebugSnapshotDir string `mapstructure:"debug_snapshot_dir"`
+
+	// Directory where the backup route writes the consistent copies of the
+	// data directory it takes, relative to the home directory if not
+	// absolute. The route requires an admin API key. Empty disables the
+	// backups.
+	BackupDir string `mapstructure:"backup_dir"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
@@ -422,6 +608,9 @@ func DefaultRPCConfig() *RPCConfig {
 		CORSAllowedOrigins:     []string{},
 		CORSAllowedMethods:     []string{http.MethodHead, http.MethodGet, http.MethodPost},
 		CORSAllowedHeaders:     []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
+		CORSExposedHeaders:     []string{},
+		CORSAllowCredentials:   false,
+		CORSMaxAge:             0,
 		GRPCListenAddress:      "",
 		GRPCMaxOpenConnections: 900,
 
@@ -433,12 +622,34 @@ func DefaultRPCConfig() *RPCConfig {
 		SubscriptionBufferSize:    defaultSubscriptionBufferSize,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
+		EventReplayBufferSize:     1000,
+		ReadinessMinPeers:         1,
+
+		EventLogTypes: []string{
+			"NewBlock", "NewBlockHeader", "Tx", "ValidatorSetUpdates", "ValidatorSetChange",
+			"NewEvidence",
+		},
+		EventLogRetainEvents: 100000,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		Compression: []string{},
+		H2C:         false,
+
+		MaxSearchResults:        10000,
+		MaxSearchScannedEntries: 1000000,
+
+		MaxBatchConcurrency: 4,
+
+		RateLimits:  []string{},
+		APIKeysFile: "",
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		DebugSnapshotDir: filepath.Join(DefaultDataDir, "debug"),
+		BackupDir:        "backups",
 	}
 }
 
@@ -478,6 +689,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 			cfg.SubscriptionBufferSize,
 		)
 	}
+	if cfg.EventReplayBufferSize < 0 {
+		return errors.New("event_replay_buffer_size can't be negative")
+	}
+	if cfg.EventLogRetainEvents < 0 {
+		return errors.New("event_log_retain_events can't be negative")
+	}
+	if cfg.ReadinessMinPeers < 0 {
+		return errors.New("readiness_min_peers can't be negative")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout_broadcast_tx_commit can't be negative")
 	}
@@ -487,6 +707,28 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	for _, algorithm := range cfg.Compression {
+		if algorithm != "gzip" && algorithm != "zstd" {
+			return fmt.Errorf("unsupported compression algorithm %q, must be \"gzip\" or \"zstd\"", algorithm)
+		}
+	}
+	if cfg.CORSMaxAge < 0 {
+		return errors.New("cors_max_age can't be negative")
+	}
+	if cfg.MaxSearchResults < 0 {
+		return errors.New("max_search_results can't be negative")
+	}
+	if cfg.MaxSearchScannedEntries < 0 {
+		return errors.New("max_search_scanned_entries can't be negative")
+	}
+	if cfg.MaxBatchConcurrency < 0 {
+		return errors.New("max_batch_concurrency can't be negative")
+	}
+	for _, s := range cfg.RateLimits {
+		if _, err := ParseRPCRateLimit(s); err != nil {
+			return fmt.Errorf("wrong rate_limits: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -515,6 +757,51 @@ func (cfg RPCConfig) CertFile() string {
 	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
 }
 
+// APIKeysPath returns the path to the API keys file, or an empty string if
+// there is none.
+func (cfg RPCConfig) APIKeysPath() string {
+	path := cfg.APIKeysFile
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+// DebugSnapshotDirPath returns the full path to the directory of the debug
+// snapshots.
+func (cfg RPCConfig) DebugSnapshotDirPath() string {
+	return rootify(cfg.DebugSnapshotDir, cfg.RootDir)
+}
+
+// BackupDirPath returns the full path to the directory of the backups.
+func (cfg RPCConfig) BackupDirPath() string {
+	return rootify(cfg.BackupDir, cfg.RootDir)
+}
+
+// RPCRateLimit is a rate limit of the requests to the RPC routes of a class.
+type RPCRateLimit struct {
+	Class string
+	RPS   float64
+	Burst int
+}
+
+// ParseRPCRateLimit parses a rate limit in the form "class:rps:burst".
+func ParseRPCRateLimit(s string) (RPCRateLimit, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return RPCRateLimit{}, fmt.Errorf("rate limit %q is not of the form class:rps:burst", s)
+	}
+	rps, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || rps <= 0 {
+		return RPCRateLimit{}, fmt.Errorf("rate limit %q must have a positive rps", s)
+	}
+	burst, err := strconv.Atoi(parts[2])
+	if err != nil || burst <= 0 {
+		return RPCRateLimit{}, fmt.Errorf("rate limit %q must have a positive burst", s)
+	}
+	return RPCRateLimit{Class: parts[0], RPS: rps, Burst: burst}, nil
+}
+
 func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
@@ -529,6 +816,9 @@ type P2PConfig struct { //nolint: maligned
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Transport used to connect to peers, "tcp" or "quic"
+	Transport string `mapstructure:"transport"`
+
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external_address"`
 
@@ -544,7 +834,8 @@ type P2PConfig struct { //nolint: maligned
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent_peers"`
 
-	// UPNP port forwarding
+	// UPnP/NAT-PMP port forwarding: map the listen port on the NAT gateway
+	// and, if ExternalAddress is empty, advertise the mapped address to peers
 	UPNP bool `mapstructure:"upnp"`
 
 	// Path to address book
@@ -560,6 +851,22 @@ type P2PConfig struct { //nolint: maligned
 	// Maximum number of outbound peers to connect to, excluding persistent peers
 	MaxNumOutboundPeers int `mapstructure:"max_num_outbound_peers"`
 
+	// Minimum number of outbound peers to connect to. If set, the target
+	// number of outbound peers is adjusted between it and
+	// MaxNumOutboundPeers: it is lowered while the bandwidth used exceeds
+	// BandwidthBudget, and raised while the quorum prevote delay exceeds
+	// VoteLatencyTarget (or, without a target, while the bandwidth used is
+	// under 3/4 of the budget). 0 keeps MaxNumOutboundPeers outbound peers.
+	MinNumOutboundPeers int `mapstructure:"min_num_outbound_peers"`
+
+	// Bytes per second sent and received from all the peers above which the
+	// number of outbound peers is lowered. 0 means unlimited.
+	BandwidthBudget int64 `mapstructure:"bandwidth_budget"`
+
+	// Quorum prevote delay above which the number of outbound peers is
+	// raised, and under half of which it is lowered. 0 disables it.
+	VoteLatencyTarget time.Duration `mapstructure:"vote_latency_target"`
+
 	// List of node IDs, to which a connection will be (re)established ignoring any existing limits
 	UnconditionalPeerIDs string `mapstructure:"unconditional_peer_ids"`
 
@@ -578,6 +885,18 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Comma separated list of channel weights, as "<channel ID>:<weight>",
+	// overriding the priorities of the channels set by the reactors. Under
+	// contention, each channel gets a share of the send rate proportional to
+	// its weight. Only applies to the "tcp" transport.
+	ChannelWeights string `mapstructure:"channel_weights"`
+
+	// Maximum number of bytes queued to be sent to a peer on all the channels,
+	// beyond which the messages are rejected or dropped, or the peer is
+	// disconnected, depending on the channel. 0 means unlimited. Only applies
+	// to the "tcp" transport.
+	MaxSendQueueBytes int64 `mapstructure:"max_send_queue_bytes"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -591,6 +910,31 @@ type P2PConfig struct { //nolint: maligned
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private_peer_ids"`
 
+	// Comma separated list of the sentry nodes of a validator, as
+	// "<ID>@<host>:<port>". If set, the node only connects to them: they are
+	// added to the persistent and unconditional peers, the peers with other
+	// IDs are rejected, PEX is disabled and the address book isn't strict.
+	SentryNodes string `mapstructure:"sentry_nodes"`
+
+	// Comma separated list of the validators behind a sentry node, as
+	// "<ID>@<host>:<port>". If set, they are added to the persistent,
+	// unconditional and private peers, so that their addresses aren't
+	// gossiped, and the address book isn't strict.
+	ValidatorNodes string `mapstructure:"validator_nodes"`
+
+	// Comma separated list of IDs of the peers to connect to with mutual TLS,
+	// e.g. the validator behind a sentry, whose connections without it are
+	// rejected. Only applies to the "tcp" transport.
+	TLSPeerIDs string `mapstructure:"tls_peer_ids"`
+
+	// The paths to the PEM encoded CA certificate verifying the certificates
+	// of the peers, and to the certificate and key of the node, for the mutual
+	// TLS connections. The certificates must carry the ID of their node as DNS
+	// name. Relative paths are relative to the config directory.
+	TLSCAFile   string `mapstructure:"tls_ca_file"`
+	TLSCertFile string `mapstructure:"tls_cert_file"`
+	TLSKeyFile  string `mapstructure:"tls_key_file"`
+
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
@@ -610,6 +954,7 @@ type P2PConfig struct { //nolint: maligned
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
+		Transport:                    P2PTransportTCP,
 		ExternalAddress:              "",
 		UPNP:                         false,
 		AddrBook:                     defaultAddrBookPath,
@@ -621,6 +966,8 @@ func DefaultP2PConfig() *P2PConfig {
 		MaxPacketMsgPayloadSize:      1024,    // 1 kB
 		SendRate:                     5120000, // 5 mB/s
 		RecvRate:                     5120000, // 5 mB/s
+		ChannelWeights:               DefaultChannelWeights,
+		MaxSendQueueBytes:            67108864, // 64 MB
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
@@ -646,15 +993,146 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// CAFile returns the full path to the CA certificate of the mutual TLS
+// connections.
+func (cfg *P2PConfig) CAFile() string {
+	return cfg.configFile(cfg.TLSCAFile)
+}
+
+// CertFile returns the full path to the certificate of the mutual TLS
+// connections.
+func (cfg *P2PConfig) CertFile() string {
+	return cfg.configFile(cfg.TLSCertFile)
+}
+
+// KeyFile returns the full path to the key of the mutual TLS connections.
+func (cfg *P2PConfig) KeyFile() string {
+	return cfg.configFile(cfg.TLSKeyFile)
+}
+
+func (cfg *P2PConfig) configFile(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
+// SentryNodeIDs returns the IDs of the sentry nodes in SentryNodes.
+func (cfg *P2PConfig) SentryNodeIDs() []string {
+	return peerListIDs(cfg.SentryNodes)
+}
+
+// ApplySentryMode sets the P2P settings implied by SentryNodes, on a
+// validator, or ValidatorNodes, on a sentry node, on top of the ones set
+// explicitly. Applying it several times has no further effect.
+func (cfg *P2PConfig) ApplySentryMode() {
+	if cfg.SentryNodes != "" {
+		cfg.PexReactor = false
+		cfg.AddrBookStrict = false
+		cfg.PersistentPeers = mergePeerLists(cfg.PersistentPeers, splitPeerList(cfg.SentryNodes))
+		cfg.UnconditionalPeerIDs = mergePeerLists(cfg.UnconditionalPeerIDs, peerListIDs(cfg.SentryNodes))
+	}
+	if cfg.ValidatorNodes != "" {
+		ids := peerListIDs(cfg.ValidatorNodes)
+		cfg.AddrBookStrict = false
+		cfg.PersistentPeers = mergePeerLists(cfg.PersistentPeers, splitPeerList(cfg.ValidatorNodes))
+		cfg.UnconditionalPeerIDs = mergePeerLists(cfg.UnconditionalPeerIDs, ids)
+		cfg.PrivatePeerIDs = mergePeerLists(cfg.PrivatePeerIDs, ids)
+	}
+}
+
+func splitPeerList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func peerListIDs(list string) []string {
+	var ids []string
+	for _, addr := range splitPeerList(list) {
+		id, _, _ := strings.Cut(addr, "@")
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// mergePeerLists adds the items missing from the comma separated list.
+func mergePeerLists(list string, items []string) string {
+	merged := splitPeerList(list)
+	for _, item := range items {
+		found := false
+		for _, existing := range merged {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return strings.Join(merged, ",")
+}
+
+// ChannelWeightsMap parses ChannelWeights and returns the weights by channel
+// ID.
+func (cfg *P2PConfig) ChannelWeightsMap() (map[byte]int, error) {
+	weights := make(map[byte]int)
+	for _, item := range strings.Split(cfg.ChannelWeights, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		idStr, weightStr, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid channel weight %q, expected <channel ID>:<weight>", item)
+		}
+		id, err := strconv.ParseUint(strings.TrimSpace(idStr), 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel ID in %q: %w", item, err)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(weightStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight in %q: %w", item, err)
+		}
+		if weight <= 0 {
+			return nil, fmt.Errorf("weight of channel %#x must be positive", id)
+		}
+		weights[byte(id)] = weight
+	}
+	return weights, nil
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case P2PTransportTCP, P2PTransportQUIC:
+	default:
+		return fmt.Errorf("unknown transport %q, expected %q or %q", cfg.Transport, P2PTransportTCP, P2PTransportQUIC)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return errors.New("max_num_inbound_peers can't be negative")
 	}
 	if cfg.MaxNumOutboundPeers < 0 {
 		return errors.New("max_num_outbound_peers can't be negative")
 	}
+	if cfg.MinNumOutboundPeers < 0 {
+		return errors.New("min_num_outbound_peers can't be negative")
+	}
+	if cfg.MinNumOutboundPeers > cfg.MaxNumOutboundPeers {
+		return errors.New("min_num_outbound_peers can't be greater than max_num_outbound_peers")
+	}
+	if cfg.BandwidthBudget < 0 {
+		return errors.New("bandwidth_budget can't be negative")
+	}
+	if cfg.VoteLatencyTarget < 0 {
+		return errors.New("vote_latency_target can't be negative")
+	}
 	if cfg.FlushThrottleTimeout < 0 {
 		return errors.New("flush_throttle_timeout can't be negative")
 	}
@@ -670,6 +1148,36 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if _, err := cfg.ChannelWeightsMap(); err != nil {
+		return fmt.Errorf("channel_weights: %w", err)
+	}
+	if cfg.MaxSendQueueBytes < 0 {
+		return errors.New("max_send_queue_bytes can't be negative")
+	}
+	if cfg.TLSPeerIDs != "" {
+		if cfg.Transport != P2PTransportTCP {
+			return fmt.Errorf("tls_peer_ids is only supported by the %q transport", P2PTransportTCP)
+		}
+		if cfg.TLSCAFile == "" || cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			return errors.New("tls_ca_file, tls_cert_file and tls_key_file are required with tls_peer_ids")
+		}
+	}
+	if cfg.SentryNodes != "" && cfg.ValidatorNodes != "" {
+		return errors.New("sentry_nodes and validator_nodes can't both be set")
+	}
+	if cfg.SentryNodes != "" && cfg.SeedMode {
+		return errors.New("sentry_nodes can't be set in seed mode")
+	}
+	for _, list := range []struct{ name, value string }{
+		{"sentry_nodes", cfg.SentryNodes},
+		{"validator_nodes", cfg.ValidatorNodes},
+	} {
+		for _, addr := range splitPeerList(list.value) {
+			if id, hostPort, ok := strings.Cut(addr, "@"); !ok || id == "" || hostPort == "" {
+				return fmt.Errorf("%s: invalid address %q, expected <ID>@<host>:<port>", list.name, addr)
+			}
+		}
+	}
 	return nil
 }
 
@@ -713,16 +1221,59 @@ type MempoolConfig struct {
 	// mempool may become invalid. If this does not apply to your application,
 	// you can disable rechecking.
 	Recheck bool `mapstructure:"recheck"`
+	// RecheckConcurrency (default: 0) defines the number of additional ABCI
+	// connections used to recheck transactions in parallel after a block is
+	// committed. If 0, transactions are rechecked sequentially over the
+	// mempool connection.
+	RecheckConcurrency int `mapstructure:"recheck_concurrency"`
+	// CheckTxConcurrency (default: 0) defines the number of additional ABCI
+	// connections used to check new transactions in parallel. Transactions
+	// are sharded across the connections by sender, or by hash when the
+	// sender is unknown, so transactions from a given peer are checked in the
+	// order they were received. They wait for the transactions rechecked
+	// over the mempool connection, and are rejected if the recheck doesn't
+	// complete within a second. If 0, transactions are checked over the
+	// mempool connection.
+	CheckTxConcurrency int `mapstructure:"check_tx_concurrency"`
 	// Broadcast (default: true) defines whether the mempool should relay
 	// transactions to other peers. Setting this to false will stop the mempool
 	// from relaying transactions to other peers until they are included in a
 	// block. In other words, if Broadcast is disabled, only the peer you send
 	// the tx to will see it until it is included in a block.
 	Broadcast bool `mapstructure:"broadcast"`
+	// PushPullGossip (default: false) defines whether transactions are
+	// announced to peers by key first (HaveTx), and only sent in full when a
+	// peer requests them (WantTx). Peers that do not support this protocol
+	// keep receiving full transactions.
+	PushPullGossip bool `mapstructure:"push_pull_gossip"`
+	// Prioritized (default: false) defines whether transactions are reaped
+	// for a proposal in order of the priority returned by the application in
+	// ResponseCheckTx, instead of in the order in which they were received.
+	// Transactions with equal priority are reaped in the order they were
+	// received.
+	Prioritized bool `mapstructure:"prioritized"`
+	// SenderLanes (default: false) enables per-sender lanes, using the sender
+	// and sequence returned by the application in ResponseCheckTx. The
+	// transactions of a sender are reaped in sequence order, and a
+	// transaction replaces a pending one with the same sender and sequence
+	// if it has a strictly higher priority.
+	SenderLanes bool `mapstructure:"sender_lanes"`
+	// PriorityLanes (default: "") is a comma separated list of priority
+	// lanes, <lane>:<percent>, e.g. "oracle:10,ibc:20". The transactions
+	// assigned to a lane by the application in ResponseCheckTx are reaped
+	// first, into the percentage of the block space, in bytes and gas,
+	// reserved for their lane, and the rest of the block is then filled with
+	// the other transactions, so that time-sensitive transactions aren't
+	// starved by spam. The lane of a transaction is updated on recheck. With
+	// SenderLanes, a transaction is only reaped into its lane along with the
+	// previous ones of its sender. The percentages can't add up to more than
+	// 100.
+	PriorityLanes string `mapstructure:"priority_lanes"`
 	// WalPath (default: "") configures the location of the Write Ahead Log
-	// (WAL) for the mempool. The WAL is disabled by default. To enable, set
-	// WalPath to where you want the WAL to be written (e.g.
-	// "data/mempool.wal").
+	// (WAL) for the mempool, recording the pending transactions, which are
+	// checked again and restored when the node restarts, e.g. after a crash.
+	// The WAL is disabled by default. To enable, set WalPath to where you want
+	// the WAL to be written (e.g. "data/mempool.wal").
 	WalPath string `mapstructure:"wal_dir"`
 	// Maximum number of transactions in the mempool
 	Size int `mapstructure:"size"`
@@ -736,6 +1287,13 @@ type MempoolConfig struct {
 	// Set to true if it's not possible for any invalid transaction to become
 	// valid again in the future.
 	KeepInvalidTxsInCache bool `mapstructure:"keep-invalid-txs-in-cache"`
+	// CheckTxCacheSize (default: 0) is the size of the cache of CheckTx
+	// results, in transactions. A transaction checked again before the next
+	// block, e.g. a failed one resubmitted, gets its cached result instead of
+	// being checked by the application. The cache is disabled if 0, which
+	// applications whose CheckTx results depend on the other transactions
+	// checked, e.g. on the sequence of their sender, should keep.
+	CheckTxCacheSize int `mapstructure:"checktx_cache_size"`
 	// Maximum size of a single transaction
 	// NOTE: the max size of a tx transmitted over the network is {max_tx_bytes}.
 	MaxTxBytes int `mapstructure:"max_tx_bytes"`
@@ -743,6 +1301,27 @@ type MempoolConfig struct {
 	// Including space needed by encoding (one varint per transaction).
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max_batch_bytes"`
+	// GasAdmissionFactor (default: 0, disabled), if greater than 0, rejects
+	// the new transactions, before they are checked by the application, while
+	// the total gas wanted by the transactions of the mempool exceeds
+	// GasAdmissionFactor times the average gas wanted by the last
+	// GasAdmissionBlocks blocks, to stop the mempool from growing without
+	// bounds during spam. The limit doesn't apply while the recent blocks
+	// are empty.
+	GasAdmissionFactor float64 `mapstructure:"gas_admission_factor"`
+	// GasAdmissionBlocks (default: 20) is the number of the latest blocks
+	// whose gas is averaged by the gas admission control.
+	GasAdmissionBlocks int `mapstructure:"gas_admission_blocks"`
+	// GossipMinFeePerByte (default: 0, disabled), if greater than 0, is
+	// advertised to the peers upon connection, which then don't gossip the
+	// transactions paying a lower fee per byte, according to the fee returned
+	// by the application in ResponseCheckTx, to save the bandwidth of the
+	// transactions this node wouldn't accept.
+	GossipMinFeePerByte int64 `mapstructure:"gossip_min_fee_per_byte"`
+	// GossipMaxTxBytes (default: 0, disabled), if greater than 0, is
+	// advertised to the peers upon connection, which then don't gossip the
+	// larger transactions.
+	GossipMaxTxBytes int `mapstructure:"gossip_max_tx_bytes"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool
@@ -757,6 +1336,8 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:   10000,
 		MaxTxBytes:  1024 * 1024, // 1MB
+
+		GasAdmissionBlocks: 20,
 	}
 }
 
@@ -777,6 +1358,43 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 	return cfg.WalPath != ""
 }
 
+// PriorityLanesMap parses PriorityLanes and returns the percentage of the
+// block space reserved for each lane.
+func (cfg *MempoolConfig) PriorityLanesMap() (map[string]int, error) {
+	lanes := make(map[string]int)
+	total := 0
+	for _, item := range strings.Split(cfg.PriorityLanes, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		lane, percentStr, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid priority lane %q, expected <lane>:<percent>", item)
+		}
+		lane = strings.TrimSpace(lane)
+		if lane == "" {
+			return nil, fmt.Errorf("empty lane in %q", item)
+		}
+		if _, ok := lanes[lane]; ok {
+			return nil, fmt.Errorf("duplicate lane %q", lane)
+		}
+		percent, err := strconv.Atoi(strings.TrimSpace(percentStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid percentage in %q: %w", item, err)
+		}
+		if percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("percentage of lane %q must be between 1 and 100", lane)
+		}
+		total += percent
+		lanes[lane] = percent
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("the percentages of the priority lanes add up to %d, more than 100", total)
+	}
+	return lanes, nil
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
@@ -789,9 +1407,33 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.CacheSize < 0 {
 		return errors.New("cache_size can't be negative")
 	}
+	if cfg.CheckTxCacheSize < 0 {
+		return errors.New("checktx_cache_size can't be negative")
+	}
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max_tx_bytes can't be negative")
 	}
+	if cfg.RecheckConcurrency < 0 {
+		return errors.New("recheck_concurrency can't be negative")
+	}
+	if cfg.CheckTxConcurrency < 0 {
+		return errors.New("check_tx_concurrency can't be negative")
+	}
+	if cfg.GasAdmissionFactor < 0 {
+		return errors.New("gas_admission_factor can't be negative")
+	}
+	if cfg.GasAdmissionBlocks <= 0 {
+		return errors.New("gas_admission_blocks must be positive")
+	}
+	if cfg.GossipMinFeePerByte < 0 {
+		return errors.New("gossip_min_fee_per_byte can't be negative")
+	}
+	if cfg.GossipMaxTxBytes < 0 {
+		return errors.New("gossip_max_tx_bytes can't be negative")
+	}
+	if _, err := cfg.PriorityLanesMap(); err != nil {
+		return fmt.Errorf("priority_lanes: %w", err)
+	}
 	return nil
 }
 
@@ -800,6 +1442,8 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 
 // StateSyncConfig defines the configuration for the CometBFT state sync service
 type StateSyncConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	Enable              bool          `mapstructure:"enable"`
 	TempDir             string        `mapstructure:"temp_dir"`
 	RPCServers          []string      `mapstructure:"rpc_servers"`
@@ -809,6 +1453,31 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+	// Maximum number of chunk requests in flight to a single peer.
+	MaxInflightChunksPerPeer int32 `mapstructure:"max_inflight_chunks_per_peer"`
+
+	// HTTPS URLs of snapshot mirrors, serving the snapshots when no peer can.
+	SnapshotMirrors []string `mapstructure:"snapshot_mirrors"`
+
+	// Serve light snapshots, built from the block store instead of the ABCI
+	// application, every LightSnapshotInterval heights. 0 disables them.
+	LightSnapshotInterval int64 `mapstructure:"light_snapshot_interval"`
+	// Number of blocks, ending at the snapshot height, in a light snapshot.
+	LightSnapshotBlocks int64 `mapstructure:"light_snapshot_blocks"`
+
+	// Ask the ABCI application to take a snapshot every SnapshotInterval
+	// heights. 0 leaves the scheduling to the application.
+	SnapshotInterval int64 `mapstructure:"snapshot_interval"`
+	// Number of the most recent snapshot heights the application keeps, the
+	// others being deleted. 0 keeps all of them.
+	SnapshotKeepRecent int64 `mapstructure:"snapshot_keep_recent"`
+
+	// Directory of the snapshots of the kv tx index, which are served to
+	// peers.
+	TxIndexSnapshotPath string `mapstructure:"tx_index_snapshot_dir"`
+	// Hash of the snapshot of the kv tx index to restore after a state sync,
+	// instead of starting with an empty tx index. Empty disables it.
+	TxIndexSnapshotHash string `mapstructure:"tx_index_snapshot_hash"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -820,13 +1489,37 @@ func (cfg *StateSyncConfig) TrustHashBytes() []byte {
 	return bytes
 }
 
+// TxIndexSnapshotDir returns the full path to the directory of the snapshots
+// of the tx index.
+func (cfg *StateSyncConfig) TxIndexSnapshotDir() string {
+	return rootify(cfg.TxIndexSnapshotPath, cfg.RootDir)
+}
+
+// TxIndexSnapshotHashBytes returns the hash of the snapshot of the tx index to
+// restore, or nil if none.
+func (cfg *StateSyncConfig) TxIndexSnapshotHashBytes() []byte {
+	// validated in ValidateBasic, so we can safely panic here
+	bytes, err := hex.DecodeString(cfg.TxIndexSnapshotHash)
+	if err != nil {
+		panic(err)
+	}
+	if len(bytes) == 0 {
+		return nil
+	}
+	return bytes
+}
+
 // DefaultStateSyncConfig returns a default configuration for the state sync service
 func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
-		TrustPeriod:         168 * time.Hour,
-		DiscoveryTime:       15 * time.Second,
-		ChunkRequestTimeout: 10 * time.Second,
-		ChunkFetchers:       4,
+		TrustPeriod:              168 * time.Hour,
+		DiscoveryTime:            15 * time.Second,
+		ChunkRequestTimeout:      10 * time.Second,
+		ChunkFetchers:            4,
+		MaxInflightChunksPerPeer: 2,
+		LightSnapshotBlocks:      100,
+		SnapshotKeepRecent:       2,
+		TxIndexSnapshotPath:      filepath.Join(DefaultDataDir, "tx_index_snapshots"),
 	}
 }
 
@@ -880,6 +1573,37 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		if cfg.ChunkFetchers <= 0 {
 			return errors.New("chunk_fetchers is required")
 		}
+
+		if cfg.MaxInflightChunksPerPeer <= 0 {
+			return errors.New("max_inflight_chunks_per_peer is required")
+		}
+
+		for _, mirror := range cfg.SnapshotMirrors {
+			u, err := url.Parse(mirror)
+			if err != nil || u.Scheme != "https" || u.Host == "" {
+				return fmt.Errorf("invalid snapshot_mirrors entry %q: must be an HTTPS URL", mirror)
+			}
+		}
+	}
+
+	if cfg.LightSnapshotInterval < 0 {
+		return errors.New("light_snapshot_interval can't be negative")
+	}
+
+	if cfg.LightSnapshotInterval > 0 && cfg.LightSnapshotBlocks <= 0 {
+		return errors.New("light_snapshot_blocks must be positive when light snapshots are enabled")
+	}
+
+	if cfg.SnapshotInterval < 0 {
+		return errors.New("snapshot_interval can't be negative")
+	}
+
+	if cfg.SnapshotKeepRecent < 0 {
+		return errors.New("snapshot_keep_recent can't be negative")
+	}
+
+	if _, err := hex.DecodeString(cfg.TxIndexSnapshotHash); err != nil {
+		return fmt.Errorf("invalid tx_index_snapshot_hash: %w", err)
 	}
 
 	return nil
@@ -927,6 +1651,17 @@ type ConsensusConfig struct {
 	WalPath string `mapstructure:"wal_file"`
 	walFile string // overrides WalPath if set
 
+	// Compress the messages written to the WAL with zstd
+	WalCompression bool `mapstructure:"wal_compression"`
+	// Size of a WAL segment, in bytes, after which a new segment is started
+	WalSegmentSize int64 `mapstructure:"wal_segment_size"`
+	// Maximum total size of the WAL segments, in bytes. The oldest segments
+	// are removed when it is exceeded.
+	WalMaxSize int64 `mapstructure:"wal_max_size"`
+	// How often the WAL is flushed and synced to disk in the background.
+	// Messages signed by this node are always synced before being sent.
+	WalFlushInterval time.Duration `mapstructure:"wal_flush_interval"`
+
 	// How long we wait for a proposal block before prevoting nil
 	TimeoutPropose time.Duration `mapstructure:"timeout_propose"`
 	// How much timeout_propose increases with each round
@@ -948,6 +1683,22 @@ type ConsensusConfig struct {
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip_timeout_commit"`
 
+	// Execute a proposed block against the app as soon as we prevote for it,
+	// instead of waiting for it to be committed. The results are discarded if
+	// another block is committed, so the app must support executing a height
+	// more than once.
+	OptimisticExecution bool `mapstructure:"optimistic_execution"`
+
+	// Report, for every proposal of the node, how many transactions reaped
+	// from the mempool the application removed or reordered in
+	// PrepareProposal, and how many it added, in the metrics and a debug log.
+	PrepareProposalDiagnostics bool `mapstructure:"prepare_proposal_diagnostics"`
+
+	// Record when each block part and vote of the last heights was first
+	// received, and from which peer, returned by the
+	// /unsafe_propagation_trace RPC route.
+	TracePropagation bool `mapstructure:"trace_propagation"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
@@ -956,13 +1707,33 @@ type ConsensusConfig struct {
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer_gossip_sleep_duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
+	// Maximum number of votes gossiped to a peer in a single message. Batching
+	// is disabled if it is 0 or 1.
+	VoteBatchSize int `mapstructure:"vote_batch_size"`
+	// How long to wait for more votes before sending an incomplete batch
+	VoteBatchFlushInterval time.Duration `mapstructure:"vote_batch_flush_interval"`
+
+	// Ratio of parity parts to block parts the proposer erasure codes its
+	// blocks with, so that peers can reconstruct a block from any of its parts
+	// and parity parts. Disabled if it is 0.
+	BlockPartParityRatio float64 `mapstructure:"block_part_parity_ratio"`
+
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+	// RPC servers whose last DoubleSignCheckHeight commits are checked for
+	// signatures of the node's key before starting, in addition to the ones
+	// of the local block store, which misses the blocks signed by another
+	// instance of the node, e.g. restored from the same backup.
+	DoubleSignCheckRPCServers []string `mapstructure:"double_sign_check_rpc_servers"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(DefaultDataDir, "cs.wal", "wal"),
+		WalCompression:              false,
+		WalSegmentSize:              10 * 1024 * 1024,   // 10MB
+		WalMaxSize:                  1024 * 1024 * 1024, // 1GB
+		WalFlushInterval:            2 * time.Second,
 		TimeoutPropose:              3000 * time.Millisecond,
 		TimeoutProposeDelta:         500 * time.Millisecond,
 		TimeoutPrevote:              1000 * time.Millisecond,
@@ -975,6 +1746,9 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		CreateEmptyBlocksInterval:   0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
+		VoteBatchSize:               0,
+		VoteBatchFlushInterval:      5 * time.Millisecond,
+		BlockPartParityRatio:        0,
 		DoubleSignCheckHeight:       int64(0),
 	}
 }
@@ -1042,6 +1816,12 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
 }
 
+// AppHashDivergenceFile returns the full path to the file recording the app
+// hash divergence upon which the consensus halted.
+func (cfg *ConsensusConfig) AppHashDivergenceFile() string {
+	return rootify(filepath.Join(DefaultDataDir, "app_hash_divergence.json"), cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
@@ -1075,12 +1855,154 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.PeerQueryMaj23SleepDuration < 0 {
 		return errors.New("peer_query_maj23_sleep_duration can't be negative")
 	}
+	if cfg.VoteBatchSize < 0 {
+		return errors.New("vote_batch_size can't be negative")
+	}
+	if cfg.VoteBatchFlushInterval < 0 {
+		return errors.New("vote_batch_flush_interval can't be negative")
+	}
+	if cfg.BlockPartParityRatio < 0 || cfg.BlockPartParityRatio > 1 {
+		return errors.New("block_part_parity_ratio must be between 0 and 1")
+	}
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	for _, server := range cfg.DoubleSignCheckRPCServers {
+		if server == "" {
+			return errors.New("found empty double_sign_check_rpc_servers entry")
+		}
+	}
+	if cfg.WalSegmentSize < 0 {
+		return errors.New("wal_segment_size can't be negative")
+	}
+	if cfg.WalMaxSize < 0 {
+		return errors.New("wal_max_size can't be negative")
+	}
+	if cfg.WalFlushInterval < 0 {
+		return errors.New("wal_flush_interval can't be negative")
+	}
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+// ABCIClientConfig
+
+// ABCIClientConfig defines the configuration of the connections to the ABCI
+// application.
+type ABCIClientConfig struct {
+	RootDir string `mapstructure:"home"`
+
+	// Deadlines of the calls to the application, per ABCI method. A call which
+	// exceeds its deadline fails. 0 disables the deadline. The asynchronous
+	// CheckTx calls of the mempool, including the rechecks, are not failed
+	// but count towards MaxTimeouts when they exceed CheckTxTimeout.
+	PrepareProposalTimeout time.Duration `mapstructure:"prepare_proposal_timeout"`
+	ProcessProposalTimeout time.Duration `mapstructure:"process_proposal_timeout"`
+	BeginBlockTimeout      time.Duration `mapstructure:"begin_block_timeout"`
+	EndBlockTimeout        time.Duration `mapstructure:"end_block_timeout"`
+	CommitTimeout          time.Duration `mapstructure:"commit_timeout"`
+	CheckTxTimeout         time.Duration `mapstructure:"check_tx_timeout"`
+	// Deadline of the Info, Echo and Query calls.
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+	// Deadline of the state sync snapshot calls.
+	SnapshotTimeout time.Duration `mapstructure:"snapshot_timeout"`
+
+	// The node is stopped after MaxTimeouts consecutive calls of a connection
+	// exceeded their deadline, since the application is likely wedged. A
+	// single timeout of the consensus connection stops the node, since the
+	// block being executed can't be committed anymore.
+	MaxTimeouts int `mapstructure:"max_timeouts"`
+
+	// Reconnect the query and mempool connections to a remote application
+	// when they are lost, e.g. when the application restarts, instead of
+	// stopping the node. Their requests in flight are replayed once
+	// reconnected. The consensus and snapshot connections always stop the
+	// node when lost.
+	Reconnect bool `mapstructure:"reconnect"`
+	// Delay before the first attempt to reconnect, doubled after every failed
+	// attempt up to ReconnectMaxBackoff.
+	ReconnectMinBackoff time.Duration `mapstructure:"reconnect_min_backoff"`
+	ReconnectMaxBackoff time.Duration `mapstructure:"reconnect_max_backoff"`
+	// The node is stopped after MaxReconnectAttempts consecutive failed
+	// attempts to reconnect. 0 retries indefinitely.
+	MaxReconnectAttempts int `mapstructure:"max_reconnect_attempts"`
+
+	// Record the requests of the consensus connection, and the responses of
+	// the application, in a file per height in RecordDir, to be replayed
+	// against another version of the application with the replay-abci
+	// command.
+	Record    bool   `mapstructure:"record"`
+	RecordDir string `mapstructure:"record_dir"`
+}
+
+// DefaultABCIClientConfig returns a default configuration of the connections
+// to the ABCI application.
+func DefaultABCIClientConfig() *ABCIClientConfig {
+	return &ABCIClientConfig{
+		PrepareProposalTimeout: 0,
+		ProcessProposalTimeout: 0,
+		BeginBlockTimeout:      0,
+		EndBlockTimeout:        0,
+		CommitTimeout:          0,
+		CheckTxTimeout:         0,
+		QueryTimeout:           0,
+		SnapshotTimeout:        0,
+		MaxTimeouts:            3,
+		Reconnect:              true,
+		ReconnectMinBackoff:    100 * time.Millisecond,
+		ReconnectMaxBackoff:    10 * time.Second,
+		MaxReconnectAttempts:   0,
+		Record:                 false,
+		RecordDir:              filepath.Join(DefaultDataDir, "abci_records"),
+	}
+}
+
+// TestABCIClientConfig returns a configuration of the connections to the ABCI
+// application for testing.
+func TestABCIClientConfig() *ABCIClientConfig {
+	return DefaultABCIClientConfig()
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *ABCIClientConfig) ValidateBasic() error {
+	for name, timeout := range map[string]time.Duration{
+		"prepare_proposal_timeout": cfg.PrepareProposalTimeout,
+		"process_proposal_timeout": cfg.ProcessProposalTimeout,
+		"begin_block_timeout":      cfg.BeginBlockTimeout,
+		"end_block_timeout":        cfg.EndBlockTimeout,
+		"commit_timeout":           cfg.CommitTimeout,
+		"check_tx_timeout":         cfg.CheckTxTimeout,
+		"query_timeout":            cfg.QueryTimeout,
+		"snapshot_timeout":         cfg.SnapshotTimeout,
+	} {
+		if timeout < 0 {
+			return fmt.Errorf("%s can't be negative", name)
+		}
+	}
+	if cfg.MaxTimeouts <= 0 {
+		return errors.New("max_timeouts must be positive")
+	}
+	if cfg.ReconnectMinBackoff <= 0 {
+		return errors.New("reconnect_min_backoff must be positive")
+	}
+	if cfg.ReconnectMaxBackoff < cfg.ReconnectMinBackoff {
+		return errors.New("reconnect_max_backoff can't be less than reconnect_min_backoff")
+	}
+	if cfg.MaxReconnectAttempts < 0 {
+		return errors.New("max_reconnect_attempts can't be negative")
+	}
+	if cfg.Record && cfg.RecordDir == "" {
+		return errors.New("record_dir can't be empty when recording")
+	}
+	return nil
+}
+
+// RecordDirPath returns the full path to the directory of the ABCI records.
+func (cfg *ABCIClientConfig) RecordDirPath() string {
+	return rootify(cfg.RecordDir, cfg.RootDir)
+}
+
 //-----------------------------------------------------------------------------
 // StorageConfig
 
@@ -1091,6 +2013,84 @@ type StorageConfig struct {
 	// required for `/block_results` RPC queries, and to reindex events in the
 	// command-line tool.
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
+
+	// If greater than 0, the blocks, and the states, below the last
+	// MinRetainBlocks blocks are pruned in the background. Blocks can also be
+	// pruned below the retain height set with the set_block_retain_height RPC
+	// endpoint: the highest of the two retain heights applies.
+	MinRetainBlocks int64 `mapstructure:"min_retain_blocks"`
+
+	// If greater than 0, the validator sets and consensus params below the last
+	// MinRetainHistory heights are pruned in the background, even if their
+	// blocks are retained, except the ones needed to verify evidence.
+	MinRetainHistory int64 `mapstructure:"min_retain_history"`
+
+	// If greater than 0, the validator sets and consensus params of the heights
+	// multiple of HistoryKeepEvery are not pruned along with the history.
+	HistoryKeepEvery int64 `mapstructure:"history_keep_every"`
+
+	// If greater than 0, the results of the blocks below the last
+	// MinRetainResults blocks are pruned in the background, independently of
+	// the blocks. They can also be pruned below the retain height set with the
+	// set_block_results_retain_height RPC endpoint: the highest of the two
+	// retain heights applies. Otherwise, they are pruned along with the blocks.
+	MinRetainResults int64 `mapstructure:"min_retain_results"`
+
+	// How often a batch of blocks is pruned.
+	PruningInterval time.Duration `mapstructure:"pruning_interval"`
+
+	// The maximum number of blocks pruned every PruningInterval.
+	PruningBatchSize int64 `mapstructure:"pruning_batch_size"`
+
+	// Once the pruning caught up with the retain height, the databases are
+	// compacted, to reclaim the disk space, if at least CompactionBlocks blocks
+	// were pruned since the last compaction. Only goleveldb, pebbledb, cleveldb
+	// and rocksdb are compacted.
+	// 0 disables the compaction.
+	CompactionBlocks int64 `mapstructure:"compaction_blocks"`
+
+	// The address of a storage service serving the state store over gRPC,
+	// instead of the local database, e.g. for nodes without a disk. Empty to
+	// keep the state store local.
+	RemoteStateStoreAddr string `mapstructure:"remote_state_store_addr"`
+
+	// If true, the block store is also served by the storage service at
+	// RemoteStateStoreAddr.
+	RemoteBlockStore bool `mapstructure:"remote_block_store"`
+
+	// If true, the blocks stored with an entry per part by the previous
+	// versions are migrated in the background to a single compressed blob per
+	// block, the layout of the new blocks.
+	MigrateBlockParts bool `mapstructure:"migrate_block_parts"`
+
+	// If greater than 0, the blocks older than ColdStorageAfter heights are
+	// moved in the background from the block store to a cold store, on
+	// cheaper and slower storage, from which they are still served, keeping
+	// the block store small on archive nodes. 0 disables the cold store.
+	ColdStorageAfter int64 `mapstructure:"cold_storage_after"`
+
+	// The directory of the cold store database, relative to the home
+	// directory if not absolute. It can't be the data directory.
+	ColdStorageDir string `mapstructure:"cold_storage_dir"`
+
+	// The address of a storage service serving the cold store over gRPC,
+	// e.g. backed by an object storage, instead of the cold store database.
+	ColdStorageAddr string `mapstructure:"cold_storage_addr"`
+
+	// The namespace of the node on the storage services at
+	// RemoteStateStoreAddr and ColdStorageAddr, which only the node can
+	// access: the common name of the certificate of the node.
+	RemoteStoreNamespace string `mapstructure:"remote_store_namespace"`
+
+	// The CA of the storage services, and the certificate and key of the
+	// node, authenticating the connections to them. Might be either absolute
+	// paths or paths relative to CometBFT's config directory.
+	RemoteStoreTLSCAFile   string `mapstructure:"remote_store_tls_ca_file"`
+	RemoteStoreTLSCertFile string `mapstructure:"remote_store_tls_cert_file"`
+	RemoteStoreTLSKeyFile  string `mapstructure:"remote_store_tls_key_file"`
+
+	// The maximum duration of a call to the storage services.
+	RemoteStoreTimeout time.Duration `mapstructure:"remote_store_timeout"`
 }
 
 // DefaultStorageConfig returns the default configuration options relating to
@@ -1098,15 +2098,71 @@ type StorageConfig struct {
 func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
 		DiscardABCIResponses: false,
+		MinRetainBlocks:      0,
+		MinRetainHistory:     0,
+		HistoryKeepEvery:     0,
+		MinRetainResults:     0,
+		PruningInterval:      10 * time.Second,
+		PruningBatchSize:     1000,
+		CompactionBlocks:     10000,
+		RemoteStoreTimeout:   10 * time.Second,
 	}
 }
 
 // TestStorageConfig returns storage configuration that can be used for
 // testing.
 func TestStorageConfig() *StorageConfig {
-	return &StorageConfig{
-		DiscardABCIResponses: false,
+	cfg := DefaultStorageConfig()
+	cfg.PruningInterval = 100 * time.Millisecond
+	return cfg
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StorageConfig) ValidateBasic() error {
+	if cfg.MinRetainBlocks < 0 {
+		return errors.New("min_retain_blocks can't be negative")
+	}
+	if cfg.MinRetainHistory < 0 {
+		return errors.New("min_retain_history can't be negative")
+	}
+	if cfg.HistoryKeepEvery < 0 {
+		return errors.New("history_keep_every can't be negative")
+	}
+	if cfg.MinRetainResults < 0 {
+		return errors.New("min_retain_results can't be negative")
+	}
+	if cfg.PruningInterval <= 0 {
+		return errors.New("pruning_interval must be positive")
+	}
+	if cfg.PruningBatchSize <= 0 {
+		return errors.New("pruning_batch_size must be positive")
+	}
+	if cfg.CompactionBlocks < 0 {
+		return errors.New("compaction_blocks can't be negative")
 	}
+	if cfg.RemoteBlockStore && cfg.RemoteStateStoreAddr == "" {
+		return errors.New("remote_block_store requires remote_state_store_addr")
+	}
+	if cfg.ColdStorageAfter < 0 {
+		return errors.New("cold_storage_after can't be negative")
+	}
+	if cfg.ColdStorageAfter > 0 && cfg.ColdStorageDir == "" && cfg.ColdStorageAddr == "" {
+		return errors.New("cold_storage_after requires cold_storage_dir or cold_storage_addr")
+	}
+	if cfg.RemoteStateStoreAddr != "" || cfg.ColdStorageAddr != "" {
+		if err := remotedb.ValidateNamespace(cfg.RemoteStoreNamespace); err != nil {
+			return fmt.Errorf("invalid remote_store_namespace: %w", err)
+		}
+		if cfg.RemoteStoreTLSCAFile == "" || cfg.RemoteStoreTLSCertFile == "" || cfg.RemoteStoreTLSKeyFile == "" {
+			return errors.New("the remote stores require remote_store_tls_ca_file, " +
+				"remote_store_tls_cert_file and remote_store_tls_key_file")
+		}
+		if cfg.RemoteStoreTimeout <= 0 {
+			return errors.New("remote_store_timeout must be positive")
+		}
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -1135,6 +2191,11 @@ type TxIndexConfig struct {
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// If true, the "psql" indexer indexes in the background, when the node
+	// starts, the heights of the block store the database is missing. This
+	// requires the ABCI responses to be persisted (see DiscardABCIResponses).
+	PsqlBackfill bool `mapstructure:"psql-backfill"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1170,6 +2231,19 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// When true, an OpenTelemetry span is recorded for every call to the ABCI
+	// application, and exported to the OTLP collector at TracingOTLPEndpoint.
+	Tracing bool `mapstructure:"tracing"`
+
+	// Address of the OTLP gRPC collector the spans are exported to.
+	TracingOTLPEndpoint string `mapstructure:"tracing_otlp_endpoint"`
+
+	// When true, the spans are exported without TLS.
+	TracingOTLPInsecure bool `mapstructure:"tracing_otlp_insecure"`
+
+	// Fraction of the spans which are recorded, between 0 and 1.
+	TracingSampleRate float64 `mapstructure:"tracing_sample_rate"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -1180,6 +2254,10 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "cometbft",
+		Tracing:              false,
+		TracingOTLPEndpoint:  "localhost:4317",
+		TracingOTLPInsecure:  false,
+		TracingSampleRate:    1,
 	}
 }
 
@@ -1195,6 +2273,12 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.Tracing && cfg.TracingOTLPEndpoint == "" {
+		return errors.New("tracing_otlp_endpoint can't be empty when tracing is enabled")
+	}
+	if cfg.TracingSampleRate < 0 || cfg.TracingSampleRate > 1 {
+		return errors.New("tracing_sample_rate must be between 0 and 1")
+	}
 	return nil
 }
 
@@ -39,6 +39,17 @@ func TestConfigValidateBasic(t *testing.T) {
 	// tamper with timeout_propose
 	cfg.Consensus.TimeoutPropose = -10 * time.Second
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.Consensus.TimeoutPropose = time.Second
+
+	// the cold store can't be the block store
+	cfg.SetRoot("/home/user")
+	cfg.Storage.ColdStorageAfter = 1000
+	cfg.Storage.ColdStorageDir = "data/"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Storage.ColdStorageDir = "/home/user/data"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Storage.ColdStorageDir = "data/cold"
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestTLSConfiguration(t *testing.T) {
@@ -64,6 +75,18 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the halt height and time
+	cfg = config.TestBaseConfig()
+	cfg.HaltHeight = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = config.TestBaseConfig()
+	cfg.HaltTime = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = config.TestBaseConfig()
+	cfg.InitChainChunkSize = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -78,6 +101,12 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"CORSMaxAge",
+		"MaxSearchResults",
+		"MaxSearchScannedEntries",
+		"MaxBatchConcurrency",
+		"EventReplayBufferSize",
+		"ReadinessMinPeers",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -87,6 +116,21 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestRPCConfigRateLimits(t *testing.T) {
+	cfg := config.TestRPCConfig()
+	cfg.RateLimits = []string{"default:20:50", "search:0.5:5"}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	limit, err := config.ParseRPCRateLimit("search:0.5:5")
+	require.NoError(t, err)
+	assert.Equal(t, config.RPCRateLimit{Class: "search", RPS: 0.5, Burst: 5}, limit)
+
+	for _, s := range []string{"default", ":1:1", "default:0:1", "default:1:0", "default:x:1"} {
+		cfg.RateLimits = []string{s}
+		assert.Error(t, cfg.ValidateBasic(), s)
+	}
+}
+
 func TestP2PConfigValidateBasic(t *testing.T) {
 	cfg := config.TestP2PConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -94,10 +138,14 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 	fieldsToTest := []string{
 		"MaxNumInboundPeers",
 		"MaxNumOutboundPeers",
+		"MinNumOutboundPeers",
+		"BandwidthBudget",
+		"VoteLatencyTarget",
 		"FlushThrottleTimeout",
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"MaxSendQueueBytes",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -105,6 +153,72 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.MinNumOutboundPeers = cfg.MaxNumOutboundPeers + 1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MinNumOutboundPeers = 0
+
+	cfg.Transport = config.P2PTransportQUIC
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Transport = "udp"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigChannelWeights(t *testing.T) {
+	cfg := config.TestP2PConfig()
+	weights, err := cfg.ChannelWeightsMap()
+	require.NoError(t, err)
+	assert.Equal(t, map[byte]int{0x20: 50, 0x21: 100, 0x22: 100, 0x23: 25}, weights)
+
+	cfg.ChannelWeights = " 0x30:1, 64:2 ,"
+	weights, err = cfg.ChannelWeightsMap()
+	require.NoError(t, err)
+	assert.Equal(t, map[byte]int{0x30: 1, 0x40: 2}, weights)
+
+	cfg.ChannelWeights = ""
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, invalid := range []string{"0x30", "0x100:1", "0x30:x", "0x30:0", "0x30:-1"} {
+		cfg.ChannelWeights = invalid
+		assert.Error(t, cfg.ValidateBasic(), invalid)
+	}
+}
+
+func TestP2PConfigSentryMode(t *testing.T) {
+	// a validator behind sentry nodes
+	cfg := config.TestP2PConfig()
+	cfg.PersistentPeers = "ccc@10.0.0.3:26656"
+	cfg.SentryNodes = "aaa@10.0.0.1:26656, bbb@10.0.0.2:26656"
+	require.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, []string{"aaa", "bbb"}, cfg.SentryNodeIDs())
+	cfg.ApplySentryMode()
+	cfg.ApplySentryMode()
+	assert.False(t, cfg.PexReactor)
+	assert.False(t, cfg.AddrBookStrict)
+	assert.Equal(t, "ccc@10.0.0.3:26656,aaa@10.0.0.1:26656,bbb@10.0.0.2:26656", cfg.PersistentPeers)
+	assert.Equal(t, "aaa,bbb", cfg.UnconditionalPeerIDs)
+	assert.Empty(t, cfg.PrivatePeerIDs)
+
+	// a sentry node
+	cfg = config.TestP2PConfig()
+	cfg.PrivatePeerIDs = "ccc"
+	cfg.ValidatorNodes = "aaa@10.0.0.1:26656"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.ApplySentryMode()
+	assert.True(t, cfg.PexReactor)
+	assert.False(t, cfg.AddrBookStrict)
+	assert.Equal(t, "aaa@10.0.0.1:26656", cfg.PersistentPeers)
+	assert.Equal(t, "aaa", cfg.UnconditionalPeerIDs)
+	assert.Equal(t, "ccc,aaa", cfg.PrivatePeerIDs)
+
+	cfg.SentryNodes = "bbb@10.0.0.2:26656"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ValidatorNodes = ""
+	cfg.SeedMode = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SeedMode = false
+	cfg.SentryNodes = "10.0.0.2:26656"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -115,7 +229,10 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"Size",
 		"MaxTxsBytes",
 		"CacheSize",
+		"CheckTxCacheSize",
 		"MaxTxBytes",
+		"GossipMinFeePerByte",
+		"GossipMaxTxBytes",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -123,11 +240,64 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.GasAdmissionFactor = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GasAdmissionFactor = 1.5
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.GasAdmissionBlocks = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestMempoolConfigPriorityLanes(t *testing.T) {
+	cfg := config.TestMempoolConfig()
+	lanes, err := cfg.PriorityLanesMap()
+	require.NoError(t, err)
+	assert.Empty(t, lanes)
+
+	cfg.PriorityLanes = " oracle:10, ibc:90 ,"
+	lanes, err = cfg.PriorityLanesMap()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"oracle": 10, "ibc": 90}, lanes)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, invalid := range []string{"oracle", ":10", "oracle:x", "oracle:0", "oracle:101", "oracle:10,oracle:20", "oracle:60,ibc:50"} {
+		cfg.PriorityLanes = invalid
+		assert.Error(t, cfg.ValidateBasic(), invalid)
+	}
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := config.TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.LightSnapshotInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LightSnapshotInterval = 0
+
+	cfg.SnapshotInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SnapshotInterval = 0
+	cfg.SnapshotKeepRecent = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SnapshotKeepRecent = 2
+
+	cfg.Enable = true
+	cfg.RPCServers = []string{"a:26657", "b:26657"}
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0A"
+	cfg.ChunkRequestTimeout = 10 * time.Second
+	cfg.SnapshotMirrors = []string{"https://snapshots.example.com/chain"}
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.SnapshotMirrors = []string{"snapshots.example.com"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SnapshotMirrors = []string{"http://snapshots.example.com/chain"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Enable = false
+
+	cfg.LightSnapshotInterval = 100
+	cfg.LightSnapshotBlocks = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestBlockSyncConfigValidateBasic(t *testing.T) {
@@ -167,6 +337,7 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *config.ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"DoubleSignCheckRPCServers empty":      {func(c *config.ConsensusConfig) { c.DoubleSignCheckRPCServers = []string{""} }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -184,6 +355,48 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestABCIClientConfigValidateBasic(t *testing.T) {
+	cfg := config.TestABCIClientConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	fieldsToTest := []string{
+		"PrepareProposalTimeout",
+		"ProcessProposalTimeout",
+		"BeginBlockTimeout",
+		"EndBlockTimeout",
+		"CommitTimeout",
+		"CheckTxTimeout",
+		"QueryTimeout",
+		"SnapshotTimeout",
+	}
+
+	for _, fieldName := range fieldsToTest {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(-1)
+		assert.Error(t, cfg.ValidateBasic(), fieldName)
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(int64(time.Second))
+		assert.NoError(t, cfg.ValidateBasic(), fieldName)
+	}
+
+	cfg.MaxTimeouts = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxTimeouts = 3
+
+	cfg.ReconnectMinBackoff = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ReconnectMinBackoff = time.Minute
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ReconnectMinBackoff = time.Second
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.MaxReconnectAttempts = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxReconnectAttempts = 0
+
+	cfg.Record = true
+	cfg.RecordDir = ""
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := config.TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -191,4 +404,49 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxOpenConnections = 3
+
+	cfg.Tracing = true
+	cfg.TracingOTLPEndpoint = ""
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TracingOTLPEndpoint = "localhost:4317"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.TracingSampleRate = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestStorageConfigValidateBasic(t *testing.T) {
+	cfg := config.DefaultStorageConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.RemoteBlockStore = true
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.RemoteStateStoreAddr = "tcp://127.0.0.1:26670"
+	// the remote stores require a namespace and TLS
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RemoteStoreNamespace = "node0"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RemoteStoreTLSCAFile = "ca.pem"
+	cfg.RemoteStoreTLSCertFile = "cert.pem"
+	cfg.RemoteStoreTLSKeyFile = "key.pem"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.RemoteStoreNamespace = "../node1"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RemoteStoreNamespace = "node0"
+	cfg.RemoteStoreTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RemoteStoreTimeout = time.Second
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.ColdStorageAfter = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ColdStorageAfter = 1000
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ColdStorageDir = "data/cold"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ColdStorageDir = ""
+	cfg.ColdStorageAddr = "tcp://127.0.0.1:26671"
+	assert.NoError(t, cfg.ValidateBasic())
 }
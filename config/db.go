@@ -2,16 +2,23 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 
 	dbm "github.com/cometbft/cometbft-db"
 
 	"github.com/cometbft/cometbft/libs/log"
+	cmtnet "github.com/cometbft/cometbft/libs/net"
 	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/store/remotedb"
 )
 
 // ServiceProvider takes a config and a logger and returns a ready to go Node.
 type ServiceProvider func(context.Context, *Config, log.Logger) (service.Service, error)
 
+// ColdBlockStoreDBID is the ID of the cold store of the block store.
+const ColdBlockStoreDBID = "blockstore_cold"
+
 // DBContext specifies config information for loading a new DB.
 type DBContext struct {
 	ID     string
@@ -22,9 +29,47 @@ type DBContext struct {
 type DBProvider func(*DBContext) (dbm.DB, error)
 
 // DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the Config.
+// specified in the Config, or served by the storage service at
+// Storage.RemoteStateStoreAddr for the state store, and for the block store if
+// Storage.RemoteBlockStore is set. The cold store of the block store, with the
+// ID "blockstore_cold", is the database in Storage.ColdStorageDir, or served
+// by the storage service at Storage.ColdStorageAddr.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
+	if storage := ctx.Config.Storage; storage != nil && storage.RemoteStateStoreAddr != "" {
+		if ctx.ID == "state" || (ctx.ID == "blockstore" && storage.RemoteBlockStore) {
+			return newRemoteDB(ctx, storage.RemoteStateStoreAddr)
+		}
+	}
+
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
 
+	if storage := ctx.Config.Storage; storage != nil && ctx.ID == ColdBlockStoreDBID {
+		if storage.ColdStorageAddr != "" {
+			return newRemoteDB(ctx, storage.ColdStorageAddr)
+		}
+		return dbm.NewDB("blockstore", dbType, rootify(storage.ColdStorageDir, ctx.Config.RootDir))
+	}
+
 	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
 }
+
+// newRemoteDB returns the database of the node served by the storage service
+// at addr.
+func newRemoteDB(ctx *DBContext, addr string) (dbm.DB, error) {
+	storage := ctx.Config.Storage
+	configFile := func(path string) string {
+		if filepath.IsAbs(path) {
+			return path
+		}
+		return rootify(filepath.Join(DefaultConfigDir, path), ctx.Config.RootDir)
+	}
+	tlsConfig, err := cmtnet.LoadTLSConfig(
+		configFile(storage.RemoteStoreTLSCAFile),
+		configFile(storage.RemoteStoreTLSCertFile),
+		configFile(storage.RemoteStoreTLSKeyFile),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS configuration of the remote stores: %w", err)
+	}
+	return remotedb.NewDB(addr, storage.RemoteStoreNamespace, ctx.ID, tlsConfig, storage.RemoteStoreTimeout)
+}
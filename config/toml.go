@@ -89,7 +89,7 @@ proxy_app = "{{ .BaseConfig.ProxyApp }}"
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
-# Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb
+# Database backend: goleveldb | cleveldb | boltdb | rocksdb | badgerdb | pebbledb
 # * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 #   - pure go
 #   - stable
@@ -108,12 +108,20 @@ moniker = "{{ .BaseConfig.Moniker }}"
 # * badgerdb (uses github.com/dgraph-io/badger)
 #   - EXPERIMENTAL
 #   - use badgerdb build tag (go build -tags badgerdb)
+# * pebbledb (uses github.com/cockroachdb/pebble)
+#   - EXPERIMENTAL
+#   - pure go
+#   - use pebbledb build tag (go build -tags pebbledb)
+#   - existing goleveldb databases can be converted with the
+#     experimental-migrate-db command
 db_backend = "{{ .BaseConfig.DBBackend }}"
 
 # Database directory
 db_dir = "{{ js .BaseConfig.DBPath }}"
 
-# Output level for logging, including package level options
+# Output level for logging, including package level options, e.g.
+# "consensus:debug,p2p:error,*:info". The levels can be changed at runtime
+# with the /set_log_level RPC route
 log_level = "{{ .BaseConfig.LogLevel }}"
 
 # Output format: 'plain' (colored text) or 'json'
@@ -124,12 +132,23 @@ log_format = "{{ .BaseConfig.LogFormat }}"
 # Path to the JSON file containing the initial validator set and other meta data
 genesis_file = "{{ js .BaseConfig.Genesis }}"
 
+# If not 0, the app_state of the genesis file is sent to the app in chunks of at
+# most this many bytes, in consecutive InitChain requests, instead of a single
+# one. The app must support it: see the InitChain method of the ABCI spec.
+init_chain_chunk_size = {{ .BaseConfig.InitChainChunkSize }}
+
 # Path to the JSON file containing the private key to use as a validator in the consensus protocol
 priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
+# Path to the file containing the passphrase of the private validator key, if
+# encrypted (see the encrypt-validator-key command). If empty, the passphrase
+# is read from the CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, the
+# priv_validator_key_passphrase systemd credential, or a prompt
+priv_validator_key_passphrase_file = "{{ js .BaseConfig.PrivValidatorKeyPassphrase }}"
+
 # TCP or UNIX socket address for CometBFT to listen on for
 # connections from an external PrivValidator process
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
@@ -144,6 +163,20 @@ abci = "{{ .BaseConfig.ABCI }}"
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
 
+# If not 0, the node stops after committing the block of this height,
+# e.g. for a coordinated upgrade. The node refuses to start if this height
+# is already committed: unset it to resume.
+halt_height = {{ .BaseConfig.HaltHeight }}
+
+# If not 0, the node stops after committing the first block whose time is
+# not before this UNIX time, in seconds.
+halt_time = {{ .BaseConfig.HaltTime }}
+
+# Directory where a diagnostics bundle is written when a block fails to be
+# applied, e.g. upon an app hash mismatch: the block, the ABCI responses, the
+# states before and after, and the validator set. Empty disables the bundles.
+diagnostics_dir = "{{ js .BaseConfig.DiagnosticsDir }}"
+
 
 #######################################################################
 ###                 Advanced Configuration Options                  ###
@@ -168,8 +201,22 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 # A list of non simple headers the client is allowed to use with cross-domain requests
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
+# A list of headers of the responses the client is allowed to read with
+# cross-domain requests
+cors_exposed_headers = [{{ range .RPC.CORSExposedHeaders }}{{ printf "%q, " . }}{{end}}]
+
+# Whether cross-domain requests may include credentials (cookies, HTTP
+# authentication and client certificates)
+cors_allow_credentials = {{ .RPC.CORSAllowCredentials }}
+
+# How long the result of a preflight request may be cached by the client
+# 0 - the client's default
+cors_max_age = "{{ .RPC.CORSMaxAge }}"
+
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# NOTE: This server only supports /broadcast_tx_commit, /broadcast_evidence,
+# serving light blocks to light clients, streaming the blocks and the tx
+# results (StreamAPI), and serving the block results (BlockResultsService)
 grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -230,6 +277,30 @@ experimental_websocket_write_buffer_size = {{ .RPC.WebSocketWriteBufferSize }}
 # predictability in subscription behavior.
 experimental_close_on_slow_client = {{ .RPC.CloseOnSlowClient }}
 
+# The number of the last published events kept in memory, so that a
+# WebSocket client that reconnects can resume its subscription with
+# /subscribe?resume=N and receive the events published after sequence number N.
+# 0 - subscriptions can't be resumed.
+event_replay_buffer_size = {{ .RPC.EventReplayBufferSize }}
+
+# Record the events of the given types in a log on disk (the eventlog
+# database), which clients read with /events, e.g. to recover the events
+# published while they were down. Unlike the replay buffer, the log survives
+# the restarts of the node. The consumers store their offsets with
+# /commit_event_log_offset, which requires an admin API key.
+event_log = {{ .RPC.EventLog }}
+
+# The types of the events recorded in the event log.
+event_log_types = [{{ range .RPC.EventLogTypes }}{{ printf "%q, " . }}{{end}}]
+
+# The number of the latest events kept in the event log.
+# 0 - all the events are kept.
+event_log_retain_events = {{ .RPC.EventLogRetainEvents }}
+
+# Minimum number of peers of a node ready to serve requests, as reported by
+# /health?ready=true.
+readiness_min_peers = {{ .RPC.ReadinessMinPeers }}
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
@@ -242,6 +313,49 @@ max_body_bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# The algorithms, in order of preference, the responses are compressed with if
+# the client accepts one of them: "zstd" and/or "gzip", e.g. '["zstd", "gzip"]'.
+# WebSocket messages are never compressed.
+compression = [{{ range .RPC.Compression }}{{ printf "%q, " . }}{{end}}]
+
+# Serve HTTP/2 over cleartext TCP (h2c), besides HTTP/1.1. HTTP/2 is always
+# served over TLS.
+h2c = {{ .RPC.H2C }}
+
+# Maximum number of results a /tx_search or /block_search query may match,
+# beyond which the query fails rather than being paginated.
+# 0 - unlimited.
+max_search_results = {{ .RPC.MaxSearchResults }}
+
+# Maximum number of index entries a /tx_search or /block_search query may
+# scan, beyond which the query fails, bounding its cost. Only applies to the
+# "kv" indexer.
+# 0 - unlimited.
+max_search_scanned_entries = {{ .RPC.MaxSearchScannedEntries }}
+
+# Maximum number of requests of a JSON-RPC batch executed concurrently.
+# The responses are returned in the order of the requests regardless.
+# 0 or 1 - the requests are executed sequentially.
+max_batch_concurrency = {{ .RPC.MaxBatchConcurrency }}
+
+# Rate limits of the requests of each client, identified by its API key or
+# else its IP address, per route class, in the form "class:rps:burst", e.g.
+# '["default:20:50", "search:1:5", "broadcast:5:10"]'.
+# The routes are of the "default" class, except for /tx_search and
+# /block_search ("search") and the /broadcast_* routes ("broadcast").
+# The routes of a class without a limit are not rate limited.
+rate_limits = [{{ range .RPC.RateLimits }}{{ printf "%q, " . }}{{end}}]
+
+# The path to a JSON file of API keys, which clients present in the
+# X-Api-Key header to be rate limited on their own, or not at all:
+# [{"key": "...", "name": "indexer", "unlimited": true}]
+# Only the clients with an "admin": true key may call the /reload_config,
+# /debug_snapshot, /backup, /backup_status, /log_level and /set_log_level
+# routes.
+# Might be either absolute path or path related to CometBFT's config directory.
+# Requests with an API key which is not in the file are rejected.
+api_keys_file = "{{ .RPC.APIKeysFile }}"
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to CometBFT's config directory.
 # If the certificate is signed by a certificate authority,
@@ -260,6 +374,18 @@ tls_key_file = "{{ .RPC.TLSKeyFile }}"
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
+# Directory where the debug_snapshot route writes the bundles of heap,
+# goroutine and mutex profiles and store statistics it captures, relative to
+# the home directory if not absolute. The route requires an admin API key (see
+# api_keys_file), and the 10 latest bundles are kept.
+debug_snapshot_dir = "{{ js .RPC.DebugSnapshotDir }}"
+
+# Directory where the backup route writes the consistent copies of the data
+# directory it takes while the node runs, relative to the home directory if not
+# absolute. The route requires an admin API key (see api_keys_file). Empty
+# disables the backups.
+backup_dir = "{{ js .RPC.BackupDir }}"
+
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -268,6 +394,15 @@ pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
+# Transport used to connect to peers:
+#
+#   1) "tcp" - (default) the channels are multiplexed over a TCP connection
+#   2) "quic" - each channel is mapped to its own stream of a QUIC connection,
+#      listening on UDP at the port of laddr. Messages of a channel are not
+#      delayed by the packet losses of the others, and reconnections to known
+#      peers use 0-RTT session resumption. All peers must use the same transport.
+transport = "{{ .P2P.Transport }}"
+
 # Address to advertise to peers for them to dial
 # If empty, will use the same port as the laddr,
 # and will introspect on the listener or use UPnP
@@ -286,7 +421,8 @@ bootstrap_peers = "{{ .P2P.BootstrapPeers }}"
 # Comma separated list of nodes to keep persistent connections to
 persistent_peers = "{{ .P2P.PersistentPeers }}"
 
-# UPNP port forwarding
+# UPnP/NAT-PMP port forwarding: map the listen port on the NAT gateway and,
+# if external_address is empty, advertise the mapped address to peers
 upnp = {{ .P2P.UPNP }}
 
 # Path to address book
@@ -302,6 +438,22 @@ max_num_inbound_peers = {{ .P2P.MaxNumInboundPeers }}
 # Maximum number of outbound peers to connect to, excluding persistent peers
 max_num_outbound_peers = {{ .P2P.MaxNumOutboundPeers }}
 
+# Minimum number of outbound peers to connect to. If set, the target number of
+# outbound peers is adjusted between it and max_num_outbound_peers: it is
+# lowered while the bandwidth used exceeds bandwidth_budget, and raised while
+# the quorum prevote delay exceeds vote_latency_target (or, without a target,
+# while the bandwidth used is under 3/4 of the budget). 0 keeps
+# max_num_outbound_peers outbound peers.
+min_num_outbound_peers = {{ .P2P.MinNumOutboundPeers }}
+
+# Bytes per second sent and received from all the peers above which the number
+# of outbound peers is lowered. 0 means unlimited.
+bandwidth_budget = {{ .P2P.BandwidthBudget }}
+
+# Quorum prevote delay above which the number of outbound peers is raised, and
+# under half of which it is lowered. 0 disables it.
+vote_latency_target = "{{ .P2P.VoteLatencyTarget }}"
+
 # List of node IDs, to which a connection will be (re)established ignoring any existing limits
 unconditional_peer_ids = "{{ .P2P.UnconditionalPeerIDs }}"
 
@@ -320,6 +472,18 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Comma separated list of channel weights, as "<channel ID>:<weight>",
+# overriding the priorities of the channels set by the reactors. Under
+# contention, each channel gets a share of the send rate proportional to its
+# weight. Only applies to the "tcp" transport.
+channel_weights = "{{ .P2P.ChannelWeights }}"
+
+# Maximum number of bytes queued to be sent to a peer on all the channels,
+# beyond which the messages are rejected or dropped, or the peer is
+# disconnected, depending on the channel. 0 means unlimited. Only applies to
+# the "tcp" transport.
+max_send_queue_bytes = {{ .P2P.MaxSendQueueBytes }}
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -332,6 +496,31 @@ seed_mode = {{ .P2P.SeedMode }}
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 
+# Comma separated list of the sentry nodes of a validator, as
+# "<ID>@<host>:<port>". If set, the node only connects to them: they are
+# added to the persistent and unconditional peers, the peers with other IDs
+# are rejected, PEX is disabled and the address book isn't strict.
+sentry_nodes = "{{ .P2P.SentryNodes }}"
+
+# Comma separated list of the validators behind a sentry node, as
+# "<ID>@<host>:<port>". If set, they are added to the persistent,
+# unconditional and private peers, so that their addresses aren't gossiped,
+# and the address book isn't strict.
+validator_nodes = "{{ .P2P.ValidatorNodes }}"
+
+# Comma separated list of IDs of the peers to connect to with mutual TLS,
+# e.g. the validator behind a sentry, whose connections without it are
+# rejected. Only applies to the "tcp" transport.
+tls_peer_ids = "{{ .P2P.TLSPeerIDs }}"
+
+# The paths to the PEM encoded CA certificate verifying the certificates of
+# the peers, and to the certificate and key of the node, for the mutual TLS
+# connections. The certificates must carry the ID of their node as DNS name.
+# Relative paths are relative to the config directory.
+tls_ca_file = "{{ .P2P.TLSCAFile }}"
+tls_cert_file = "{{ .P2P.TLSCertFile }}"
+tls_key_file = "{{ .P2P.TLSKeyFile }}"
+
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
@@ -351,6 +540,21 @@ dial_timeout = "{{ .P2P.DialTimeout }}"
 # you can disable rechecking.
 recheck = {{ .Mempool.Recheck }}
 
+# RecheckConcurrency (default: 0) defines the number of additional ABCI
+# connections used to recheck transactions in parallel after a block is
+# committed. If 0, transactions are rechecked sequentially over the mempool
+# connection.
+recheck_concurrency = {{ .Mempool.RecheckConcurrency }}
+
+# CheckTxConcurrency (default: 0) defines the number of additional ABCI
+# connections used to check new transactions in parallel. Transactions are
+# sharded across the connections by sender, or by hash when the sender is
+# unknown, so transactions from a given peer are checked in the order they
+# were received. They wait for the transactions rechecked over the mempool
+# connection, and are rejected if the recheck doesn't complete within a second.
+# If 0, transactions are checked over the mempool connection.
+check_tx_concurrency = {{ .Mempool.CheckTxConcurrency }}
+
 # Broadcast (default: true) defines whether the mempool should relay
 # transactions to other peers. Setting this to false will stop the mempool
 # from relaying transactions to other peers until they are included in a
@@ -358,10 +562,40 @@ recheck = {{ .Mempool.Recheck }}
 # the tx to will see it until it is included in a block.
 broadcast = {{ .Mempool.Broadcast }}
 
+# PushPullGossip (default: false) defines whether transactions are announced
+# to peers by key first (HaveTx), and only sent in full when a peer requests
+# them (WantTx). Peers that do not support this protocol keep receiving full
+# transactions.
+push_pull_gossip = {{ .Mempool.PushPullGossip }}
+
+# Prioritized (default: false) defines whether transactions are reaped for a
+# proposal in order of the priority returned by the application in
+# ResponseCheckTx, instead of in the order in which they were received.
+# Transactions with equal priority are reaped in the order they were received.
+prioritized = {{ .Mempool.Prioritized }}
+
+# SenderLanes (default: false) enables per-sender lanes, using the sender and
+# sequence returned by the application in ResponseCheckTx. The transactions of
+# a sender are reaped in sequence order, and a transaction replaces a pending
+# one with the same sender and sequence if it has a strictly higher priority.
+sender_lanes = {{ .Mempool.SenderLanes }}
+
+# PriorityLanes (default: "") is a comma separated list of priority lanes,
+# <lane>:<percent>, e.g. "oracle:10,ibc:20". The transactions assigned to a
+# lane by the application in ResponseCheckTx are reaped first, into the
+# percentage of the block space, in bytes and gas, reserved for their lane, and
+# the rest of the block is then filled with the other transactions, so that
+# time-sensitive transactions aren't starved by spam. The lane of a
+# transaction is updated on recheck. With sender_lanes, a transaction is only
+# reaped into its lane along with the previous ones of its sender. The
+# percentages can't add up to more than 100.
+priority_lanes = "{{ .Mempool.PriorityLanes }}"
+
 # WalPath (default: "") configures the location of the Write Ahead Log
-# (WAL) for the mempool. The WAL is disabled by default. To enable, set
-# WalPath to where you want the WAL to be written (e.g.
-# "data/mempool.wal").
+# (WAL) for the mempool, recording the pending transactions, which are
+# checked again and restored when the node restarts, e.g. after a crash.
+# The WAL is disabled by default. To enable, set WalPath to where you want
+# the WAL to be written (e.g. "data/mempool.wal").
 wal_dir = "{{ js .Mempool.WalPath }}"
 
 # Maximum number of transactions in the mempool
@@ -380,6 +614,13 @@ cache_size = {{ .Mempool.CacheSize }}
 # again in the future.
 keep-invalid-txs-in-cache = {{ .Mempool.KeepInvalidTxsInCache }}
 
+# Size of the cache of CheckTx results, in transactions (default: 0, disabled).
+# A transaction checked again before the next block, e.g. a failed one
+# resubmitted, gets its cached result instead of being checked by the
+# application. Keep it disabled if the result of CheckTx depends on the other
+# transactions checked, e.g. on the sequence of their sender.
+checktx_cache_size = {{ .Mempool.CheckTxCacheSize }}
+
 # Maximum size of a single transaction.
 # NOTE: the max size of a tx transmitted over the network is {max_tx_bytes}.
 max_tx_bytes = {{ .Mempool.MaxTxBytes }}
@@ -389,6 +630,28 @@ max_tx_bytes = {{ .Mempool.MaxTxBytes }}
 # XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 max_batch_bytes = {{ .Mempool.MaxBatchBytes }}
 
+# If greater than 0, the new transactions are rejected, before they are checked
+# by the application, while the total gas wanted by the transactions of the
+# mempool exceeds gas_admission_factor times the average gas wanted by the
+# last gas_admission_blocks blocks, to stop the mempool from growing without
+# bounds during spam. The limit doesn't apply while the recent blocks are empty.
+# 0 disables it.
+gas_admission_factor = {{ .Mempool.GasAdmissionFactor }}
+
+# Number of the latest blocks whose gas is averaged by the gas admission
+# control.
+gas_admission_blocks = {{ .Mempool.GasAdmissionBlocks }}
+
+# If greater than 0, advertised to the peers upon connection, which then don't
+# gossip the transactions paying a lower fee per byte, according to the fee
+# returned by the application in ResponseCheckTx, to save the bandwidth of the
+# transactions this node wouldn't accept. 0 disables it.
+gossip_min_fee_per_byte = {{ .Mempool.GossipMinFeePerByte }}
+
+# If greater than 0, advertised to the peers upon connection, which then don't
+# gossip the larger transactions. 0 disables it.
+gossip_max_tx_bytes = {{ .Mempool.GossipMaxTxBytes }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -411,6 +674,13 @@ trust_height = {{ .StateSync.TrustHeight }}
 trust_hash = "{{ .StateSync.TrustHash }}"
 trust_period = "{{ .StateSync.TrustPeriod }}"
 
+# HTTPS URLs (comma-separated) of snapshot mirrors, e.g. buckets or CDNs, serving snapshots in
+# addition to peers. The chunks are fetched from the peers first, and from the mirrors only when no
+# peer serving the snapshot can take the request. A mirror serves a JSON manifest listing its snapshots and the SHA-256 hashes of
+# their chunks at <url>/manifest.json, and the chunks at <url>/<height>/<format>/<index>. Chunks
+# are verified against the manifest, and the restored state against the light client.
+snapshot_mirrors = "{{ StringsJoin .StateSync.SnapshotMirrors "," }}"
+
 # Time to spend discovering snapshots before initiating a restore.
 discovery_time = "{{ .StateSync.DiscoveryTime }}"
 
@@ -425,6 +695,39 @@ chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk fetchers to run (default: 1).
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
+# The maximum number of chunk requests in flight to a single peer. Chunks are requested in
+# parallel from the peers with the highest measured throughput, and requested again from another
+# peer when they take much longer than usual.
+max_inflight_chunks_per_peer = {{ .StateSync.MaxInflightChunksPerPeer }}
+
+# Serve light snapshots every light_snapshot_interval heights (0 disables them). A light snapshot
+# is built from the block store instead of the ABCI application and contains the last
+# light_snapshot_blocks blocks up to the snapshot height. It allows bootstrapping nodes of chains
+# whose application does not take snapshots: the restoring node verifies the blocks against the
+# light client and only requires its application to already be at the snapshot height, e.g.
+# restored from a backup of the application data.
+light_snapshot_interval = {{ .StateSync.LightSnapshotInterval }}
+light_snapshot_blocks = {{ .StateSync.LightSnapshotBlocks }}
+
+# Ask the ABCI application to take a snapshot every snapshot_interval heights, once the block at
+# that height is committed (0 leaves the scheduling to the application), and to delete its
+# snapshots but those of the snapshot_keep_recent most recent heights (0 keeps all of them). The
+# requests are the ABCI queries /snapshot/create/<height> and /snapshot/delete/<height>/<format>,
+# which the application must handle.
+snapshot_interval = {{ .StateSync.SnapshotInterval }}
+snapshot_keep_recent = {{ .StateSync.SnapshotKeepRecent }}
+
+# Directory of the snapshots of the kv tx index, exported with the "tx-index-snapshot export"
+# command, which are served to peers. A snapshot is identified by its hash, printed by the export.
+tx_index_snapshot_dir = "{{ js .StateSync.TxIndexSnapshotPath }}"
+
+# Hash of a snapshot of the kv tx index to fetch from peers and restore after a state sync, so that
+# the transactions before the snapshot height can be searched without reindexing the blocks. The
+# chunks of the snapshot are verified against it, so it must be obtained from a trusted source. The
+# snapshot must be at the state sync height, and the node doesn't start block sync if it can't be
+# restored. Empty starts with an empty tx index.
+tx_index_snapshot_hash = "{{ .StateSync.TxIndexSnapshotHash }}"
+
 #######################################################
 ###       Block Sync Configuration Options          ###
 #######################################################
@@ -445,6 +748,24 @@ version = "{{ .BlockSync.Version }}"
 
 wal_file = "{{ js .Consensus.WalPath }}"
 
+# Compress the messages written to the WAL with zstd. WAL files written with
+# and without compression can always be read back.
+wal_compression = {{ .Consensus.WalCompression }}
+
+# Size of a WAL segment, in bytes, after which a new segment is started
+wal_segment_size = {{ .Consensus.WalSegmentSize }}
+
+# Maximum total size of the WAL segments, in bytes. The oldest segments are
+# removed when it is exceeded.
+wal_max_size = {{ .Consensus.WalMaxSize }}
+
+# How often the WAL is flushed and synced to disk in the background. Messages
+# signed by this node are always synced before being sent.
+wal_flush_interval = "{{ .Consensus.WalFlushInterval }}"
+
+# The timeouts below are only used if the corresponding timeout is not set in
+# the consensus params of the chain.
+
 # How long we wait for a proposal block before prevoting nil
 timeout_propose = "{{ .Consensus.TimeoutPropose }}"
 # How much timeout_propose increases with each round
@@ -468,9 +789,33 @@ timeout_commit = "{{ .Consensus.TimeoutCommit }}"
 # So, validators should stop the state machine, wait for some blocks, and then restart the state machine to avoid panic.
 double_sign_check_height = {{ .Consensus.DoubleSignCheckHeight }}
 
+# Comma separated list of RPC servers whose {double_sign_check_height} last commits are also checked
+# for signatures of the node's consensus key before starting, since the local block store misses the
+# blocks signed by another instance of the node, e.g. restored from the same backup. The node refuses
+# to start if one of them holds such a signature, or if none of them can be queried.
+double_sign_check_rpc_servers = "{{ StringsJoin .Consensus.DoubleSignCheckRPCServers "," }}"
+
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip_timeout_commit = {{ .Consensus.SkipTimeoutCommit }}
 
+# Execute a proposed block against the app as soon as we prevote for it,
+# instead of waiting for it to be committed. The results are discarded if
+# another block is committed. Only enable this if the app discards any
+# uncommitted state when BeginBlock is called again for the same height.
+optimistic_execution = {{ .Consensus.OptimisticExecution }}
+
+# Report, for every proposal of the node, how many transactions reaped from the
+# mempool the application removed or reordered in PrepareProposal, how many it
+# added, and their total size and gas, in the metrics and a debug log, to help
+# tuning the block building logic of the application.
+prepare_proposal_diagnostics = {{ .Consensus.PrepareProposalDiagnostics }}
+
+# Record when each block part and vote of the last 20 heights was first
+# received, and from which peer, to diagnose why the rounds time out on some
+# validators. The timeline of a round is returned by the
+# /unsafe_propagation_trace RPC route.
+trace_propagation = {{ .Consensus.TracePropagation }}
+
 # EmptyBlocks mode and possible interval between empty blocks
 create_empty_blocks = {{ .Consensus.CreateEmptyBlocks }}
 create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
@@ -479,6 +824,76 @@ create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Maximum number of votes gossiped to a peer in a single message, reducing the
+# per-message overhead on large validator sets. Set to 0 or 1 to disable.
+# NOTE: peers running a version without vote batching disconnect upon receiving
+# a batch, so only enable it once all the peers support it.
+vote_batch_size = {{ .Consensus.VoteBatchSize }}
+
+# How long to wait for more votes before sending an incomplete batch
+vote_batch_flush_interval = "{{ .Consensus.VoteBatchFlushInterval }}"
+
+# Ratio of parity parts to block parts the node erasure codes its proposals
+# with: peers reconstruct the block from any of its parts and parity parts, and
+# the proposer sends each of them to a single peer first, instead of every part
+# to every peer. Set to 0 to disable.
+# NOTE: peers running a version without parity parts disconnect upon receiving
+# one, so only enable it once all the peers support it.
+block_part_parity_ratio = {{ .Consensus.BlockPartParityRatio }}
+
+#######################################################
+###        ABCI Client Configuration Options        ###
+#######################################################
+[abci_client]
+
+# Deadlines of the calls to the application, per ABCI method. A call which
+# exceeds its deadline fails, instead of blocking the node indefinitely when
+# the application is wedged. "0s" disables the deadline. The asynchronous
+# CheckTx calls of the mempool, including the rechecks, are not failed but
+# count towards max_timeouts when they exceed check_tx_timeout.
+prepare_proposal_timeout = "{{ .ABCIClient.PrepareProposalTimeout }}"
+process_proposal_timeout = "{{ .ABCIClient.ProcessProposalTimeout }}"
+begin_block_timeout = "{{ .ABCIClient.BeginBlockTimeout }}"
+end_block_timeout = "{{ .ABCIClient.EndBlockTimeout }}"
+commit_timeout = "{{ .ABCIClient.CommitTimeout }}"
+check_tx_timeout = "{{ .ABCIClient.CheckTxTimeout }}"
+
+# Deadline of the Info, Echo and Query calls
+query_timeout = "{{ .ABCIClient.QueryTimeout }}"
+
+# Deadline of the state sync snapshot calls
+snapshot_timeout = "{{ .ABCIClient.SnapshotTimeout }}"
+
+# The node is stopped, with an error, once this many consecutive calls of a
+# connection to the application exceeded their deadline. A single timeout of
+# the consensus connection stops the node, since the block being executed
+# can't be committed anymore.
+max_timeouts = {{ .ABCIClient.MaxTimeouts }}
+
+# If true, the query and mempool connections to a remote application are
+# reconnected when lost, e.g. when the application restarts, and their
+# requests in flight are replayed once. The consensus and snapshot connections
+# are not reconnected, since their requests can't be safely replayed: losing
+# them still stops the node.
+reconnect = {{ .ABCIClient.Reconnect }}
+
+# Delay before the first attempt to reconnect, doubled after every failed
+# attempt up to reconnect_max_backoff
+reconnect_min_backoff = "{{ .ABCIClient.ReconnectMinBackoff }}"
+reconnect_max_backoff = "{{ .ABCIClient.ReconnectMaxBackoff }}"
+
+# The node is stopped, with an error, after this many consecutive failed
+# attempts to reconnect. 0 retries indefinitely.
+max_reconnect_attempts = {{ .ABCIClient.MaxReconnectAttempts }}
+
+# If true, the requests of the consensus connection, and the responses of the
+# application, are recorded in a file per height in record_dir. The records can
+# be replayed against another version of the application with the
+# "cometbft replay-abci" command, to find its non-deterministic responses
+# before upgrading.
+record = {{ .ABCIClient.Record }}
+record_dir = "{{ js .ABCIClient.RecordDir }}"
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
@@ -490,6 +905,84 @@ peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # reindex events in the command-line tool.
 discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 
+# If greater than 0, the blocks, and the states, below the last
+# min_retain_blocks blocks are pruned in the background. Blocks can also be
+# pruned below the retain height set with the set_block_retain_height RPC
+# endpoint (unsafe): the highest of the two retain heights applies.
+min_retain_blocks = {{ .Storage.MinRetainBlocks }}
+
+# If greater than 0, the validator sets and consensus params below the last
+# min_retain_history heights are pruned in the background, even if their blocks
+# are retained, except the ones needed to verify evidence. The first time, the
+# history saved before is pruned all at once.
+min_retain_history = {{ .Storage.MinRetainHistory }}
+
+# If greater than 0, the validator sets and consensus params of the heights
+# multiple of history_keep_every are not pruned along with the history, so that
+# they can still be queried.
+history_keep_every = {{ .Storage.HistoryKeepEvery }}
+
+# If greater than 0, the results of the blocks (their ABCI responses) below the
+# last min_retain_results blocks are pruned in the background, independently of
+# the blocks. They can also be pruned below the retain height set with the
+# set_block_results_retain_height RPC endpoint (unsafe): the highest of the two
+# retain heights applies. Otherwise, they are pruned along with the blocks.
+min_retain_results = {{ .Storage.MinRetainResults }}
+
+# How often a batch of at most pruning_batch_size blocks is pruned.
+pruning_interval = "{{ .Storage.PruningInterval }}"
+pruning_batch_size = {{ .Storage.PruningBatchSize }}
+
+# Once the pruning caught up with the retain height, the databases are
+# compacted, to reclaim the disk space, if at least compaction_blocks blocks
+# were pruned since the last compaction. Only goleveldb, pebbledb, cleveldb
+# and rocksdb are compacted.
+# 0 disables the compaction.
+compaction_blocks = {{ .Storage.CompactionBlocks }}
+
+# The address of a storage service serving the state store over gRPC, instead
+# of the local database, e.g. for nodes without a disk. Empty to keep the state
+# store local.
+remote_state_store_addr = "{{ .Storage.RemoteStateStoreAddr }}"
+
+# If true, the block store is also served by the storage service at
+# remote_state_store_addr.
+remote_block_store = {{ .Storage.RemoteBlockStore }}
+
+# If true, the blocks stored with an entry per part by the previous versions
+# are migrated in the background to a single compressed blob per block, the
+# layout of the new blocks. The blocks remain readable during the migration.
+migrate_block_parts = {{ .Storage.MigrateBlockParts }}
+
+# If greater than 0, the blocks older than cold_storage_after heights are moved
+# in the background from the block store to a cold store, on cheaper and slower
+# storage, from which they are still served, keeping the block store small on
+# archive nodes. 0 disables the cold store.
+cold_storage_after = {{ .Storage.ColdStorageAfter }}
+
+# The directory of the cold store database, relative to the home directory if
+# not absolute. It can't be the data directory.
+cold_storage_dir = "{{ .Storage.ColdStorageDir }}"
+
+# The address of a storage service serving the cold store over gRPC, e.g.
+# backed by an object storage, instead of the cold store database.
+cold_storage_addr = "{{ .Storage.ColdStorageAddr }}"
+
+# The namespace of the node on the storage services of remote_state_store_addr
+# and cold_storage_addr, which only the node can access: it must be the common
+# name of the certificate of the node.
+remote_store_namespace = "{{ .Storage.RemoteStoreNamespace }}"
+
+# The CA of the storage services, and the certificate and key of the node,
+# authenticating the connections to them with mutual TLS. Might be either
+# absolute paths or paths relative to CometBFT's config directory.
+remote_store_tls_ca_file = "{{ .Storage.RemoteStoreTLSCAFile }}"
+remote_store_tls_cert_file = "{{ .Storage.RemoteStoreTLSCertFile }}"
+remote_store_tls_key_file = "{{ .Storage.RemoteStoreTLSKeyFile }}"
+
+# The maximum duration of a call to the storage services.
+remote_store_timeout = "{{ .Storage.RemoteStoreTimeout }}"
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -512,6 +1005,12 @@ indexer = "{{ .TxIndex.Indexer }}"
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# If true, the "psql" indexer indexes in the background, when the node starts,
+# the heights of the block store the database is missing. The database schema
+# is always migrated when the node starts.
+# This requires the ABCI responses to be persisted (see discard_abci_responses).
+psql-backfill = {{ .TxIndex.PsqlBackfill }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -533,4 +1032,18 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+# When true, an OpenTelemetry span is recorded for every call to the ABCI
+# application, with the height and round of the block, and exported to the
+# OTLP collector at tracing_otlp_endpoint.
+tracing = {{ .Instrumentation.Tracing }}
+
+# Address of the OTLP gRPC collector the spans are exported to
+tracing_otlp_endpoint = "{{ .Instrumentation.TracingOTLPEndpoint }}"
+
+# If true, the spans are exported without TLS
+tracing_otlp_insecure = {{ .Instrumentation.TracingOTLPInsecure }}
+
+# Fraction of the spans which are recorded, between 0 and 1
+tracing_sample_rate = {{ .Instrumentation.TracingSampleRate }}
 `
@@ -0,0 +1,23 @@
+package consensus
+
+import "time"
+
+// ByzantineBehavior makes the reactor of a validator misbehave, for the test
+// harnesses, e.g. the e2e tests, to check that the other nodes create evidence
+// of the misbehavior and that the network keeps committing blocks. It only
+// takes effect in the binaries built with the byzantine build tag, see
+// ByzantineHooksEnabled and Reactor.SetByzantineBehavior.
+type ByzantineBehavior struct {
+	// EquivocateVotes makes the validator sign, along with each of its
+	// prevotes and precommits, a conflicting vote for another block ID, and
+	// broadcast it to its peers, which report it as duplicate vote evidence.
+	EquivocateVotes bool
+	// WithholdBlockParts makes the node stop gossiping the parts of the
+	// blocks of the current height, including the ones of its own proposals.
+	// The parts of the committed blocks are still sent to the peers catching
+	// up.
+	WithholdBlockParts bool
+	// ProposalDelay delays the gossiping of the proposals of the validator,
+	// from their timestamp.
+	ProposalDelay time.Duration
+}
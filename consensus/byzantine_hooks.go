@@ -0,0 +1,127 @@
+//go:build byzantine
+// +build byzantine
+
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+	cmtcons "github.com/cometbft/cometbft/proto/tendermint/consensus"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// ByzantineHooksEnabled is true in the binaries built with the byzantine build
+// tag, in which the reactor can be made to misbehave with
+// Reactor.SetByzantineBehavior.
+const ByzantineHooksEnabled = true
+
+// byzantineHooks is the state of the misbehavior of the reactor. It is set
+// before the reactor starts, so it is read without a lock.
+type byzantineHooks struct {
+	behavior ByzantineBehavior
+	address  types.Address
+	privKey  crypto.PrivKey // to sign the conflicting votes
+	chainID  string
+}
+
+// SetByzantineBehavior makes the reactor misbehave, see ByzantineBehavior. It
+// must be called before the reactor starts, and the node must be a validator
+// with a local private key (privval.FilePV or types.MockPV) to equivocate,
+// since the conflicting votes are not signed by the private validator, which
+// would refuse to.
+func (conR *Reactor) SetByzantineBehavior(behavior ByzantineBehavior) error {
+	if conR.IsRunning() {
+		return errors.New("the byzantine behavior must be set before the reactor starts")
+	}
+	pv := conR.conS.privValidator
+	if pv == nil {
+		return errors.New("only a validator can be byzantine")
+	}
+	pubKey, err := pv.GetPubKey()
+	if err != nil {
+		return fmt.Errorf("can't get the pubkey of the validator: %w", err)
+	}
+
+	hooks := byzantineHooks{
+		behavior: behavior,
+		address:  pubKey.Address(),
+		chainID:  conR.conS.GetState().ChainID,
+	}
+	if behavior.EquivocateVotes {
+		switch pv := pv.(type) {
+		case *privval.FilePV:
+			hooks.privKey = pv.Key.PrivKey
+		case types.MockPV:
+			hooks.privKey = pv.PrivKey
+		default:
+			return fmt.Errorf("can't equivocate with a private validator of type %T", pv)
+		}
+	}
+	conR.byzantine = hooks
+	return nil
+}
+
+// withholdBlockParts tells whether to stop gossiping the block parts of the
+// current height.
+func (conR *Reactor) withholdBlockParts() bool {
+	return conR.byzantine.behavior.WithholdBlockParts
+}
+
+// delayProposal tells whether to hold back the proposal of the round state,
+// which is only done if it is ours.
+func (conR *Reactor) delayProposal(rs *cstypes.RoundState) bool {
+	delay := conR.byzantine.behavior.ProposalDelay
+	if delay <= 0 || !bytes.Equal(rs.Validators.GetProposer().Address, conR.byzantine.address) {
+		return false
+	}
+	return cmttime.Now().Before(rs.Proposal.Timestamp.Add(delay))
+}
+
+// equivocate broadcasts a vote conflicting with the given one, if it is a vote
+// of ours.
+func (conR *Reactor) equivocate(vote *types.Vote) {
+	if !conR.byzantine.behavior.EquivocateVotes || !bytes.Equal(vote.ValidatorAddress, conR.byzantine.address) {
+		return
+	}
+	conflicting, err := conR.conflictingVote(vote)
+	if err != nil {
+		conR.Logger.Error("Failed to sign a conflicting vote", "vote", vote, "err", err)
+		return
+	}
+	conR.Logger.Info("Equivocating", "vote", vote, "conflicting", conflicting)
+	conR.Switch.Broadcast(p2p.Envelope{
+		ChannelID: VoteChannel,
+		Message:   &cmtcons.Vote{Vote: conflicting.ToProto()},
+	})
+}
+
+// conflictingVote returns a vote of the same validator at the same height,
+// round and step as the given one, for another block ID: nil if the vote is
+// for a block, or a made-up block otherwise.
+func (conR *Reactor) conflictingVote(vote *types.Vote) (*types.Vote, error) {
+	conflicting := vote.Copy()
+	if vote.BlockID.IsZero() {
+		hash := tmhash.Sum(types.VoteSignBytes(conR.byzantine.chainID, vote.ToProto()))
+		conflicting.BlockID = types.BlockID{
+			Hash:          hash,
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: hash},
+		}
+	} else {
+		conflicting.BlockID = types.BlockID{}
+	}
+
+	sig, err := conR.byzantine.privKey.Sign(types.VoteSignBytes(conR.byzantine.chainID, conflicting.ToProto()))
+	if err != nil {
+		return nil, err
+	}
+	conflicting.Signature = sig
+	return conflicting, nil
+}
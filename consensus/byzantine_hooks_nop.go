@@ -0,0 +1,29 @@
+//go:build !byzantine
+// +build !byzantine
+
+package consensus
+
+import (
+	"errors"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ByzantineHooksEnabled is false in the binaries built without the byzantine
+// build tag, in which the reactor can't misbehave.
+const ByzantineHooksEnabled = false
+
+type byzantineHooks struct{}
+
+// SetByzantineBehavior returns an error, since the binary isn't built with the
+// byzantine tag.
+func (conR *Reactor) SetByzantineBehavior(ByzantineBehavior) error {
+	return errors.New("byzantine behaviors require a binary built with the byzantine tag")
+}
+
+func (conR *Reactor) withholdBlockParts() bool { return false }
+
+func (conR *Reactor) delayProposal(*cstypes.RoundState) bool { return false }
+
+func (conR *Reactor) equivocate(*types.Vote) {}
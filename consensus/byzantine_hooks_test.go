@@ -0,0 +1,50 @@
+//go:build byzantine
+// +build byzantine
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestReactorByzantineConflictingVote(t *testing.T) {
+	cs, _ := randState(1)
+	conR := NewReactor(cs, false)
+	require.NoError(t, conR.SetByzantineBehavior(ByzantineBehavior{EquivocateVotes: true}))
+
+	hash := cmtrand.Bytes(32)
+	header := types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(32)}
+	for _, msgType := range []cmtproto.SignedMsgType{cmtproto.PrevoteType, cmtproto.PrecommitType} {
+		// one vote for a block, and one for nil
+		for _, blockID := range []types.BlockID{{Hash: hash, PartSetHeader: header}, {}} {
+			vote, err := cs.signVote(msgType, blockID.Hash, blockID.PartSetHeader)
+			require.NoError(t, err)
+
+			conflicting, err := conR.conflictingVote(vote)
+			require.NoError(t, err)
+			assert.NotEqual(t, vote.BlockID, conflicting.BlockID)
+			require.NoError(t, conflicting.ValidateBasic())
+
+			votes := types.NewVoteSet(cs.state.ChainID, vote.Height, vote.Round, msgType, cs.Validators)
+			added, err := votes.AddVote(vote)
+			require.NoError(t, err)
+			require.True(t, added)
+			_, err = votes.AddVote(conflicting)
+			assert.IsType(t, &types.ErrVoteConflictingVotes{}, err)
+		}
+	}
+}
+
+func TestReactorByzantineRequiresValidator(t *testing.T) {
+	cs, _ := randState(1)
+	cs.privValidator = nil
+	conR := NewReactor(cs, false)
+	assert.Error(t, conR.SetByzantineBehavior(ByzantineBehavior{WithholdBlockParts: true}))
+}
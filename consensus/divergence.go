@@ -0,0 +1,129 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/libs/tempfile"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// errAppHashDiverged is returned when signing once the app hash diverged.
+var errAppHashDiverged = errors.New("the app hash diverged from the one committed by +2/3 of the validators")
+
+// AppHashDivergence is a block committed by +2/3 of the validators whose app
+// hash disagrees with the one computed by the app of this node, upon which the
+// consensus halted.
+type AppHashDivergence struct {
+	Height  int64         `json:"height"`
+	Round   int32         `json:"round"`
+	BlockID types.BlockID `json:"block_id"`
+	// app hash computed by the app after the previous block
+	AppHash cmtbytes.HexBytes `json:"app_hash"`
+	// app hash of the committed block
+	BlockAppHash cmtbytes.HexBytes `json:"block_app_hash"`
+	// directory of the diagnostics bundle, if base.diagnostics_dir is set
+	DiagnosticsDir string    `json:"diagnostics_dir,omitempty"`
+	Time           time.Time `json:"time"`
+}
+
+// GetAppHashDivergence returns the divergence upon which the consensus halted,
+// or nil if it didn't.
+func (cs *State) GetAppHashDivergence() *AppHashDivergence {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.divergence
+}
+
+// divergeAppHash halts the consensus upon a block committed by +2/3 of the
+// validators with a different app hash than ours, instead of panicking, so
+// that the node keeps serving the RPC, and a process supervisor doesn't
+// restart it in a loop. Nothing is signed from then on.
+func (cs *State) divergeAppHash(blockID types.BlockID, block *types.Block, mismatch sm.ErrAppHashMismatch) {
+	err := cs.blockExec.WriteDiagnostics(cs.state, block, mismatch)
+	cs.haltOnDivergence(cs.CommitRound, blockID, block, err)
+}
+
+// haltOnDivergence halts the consensus upon the block committed by +2/3 of the
+// validators in the given round, whose app hash mismatches ours with err, an
+// sm.ErrAppHashMismatch possibly wrapped in an sm.ErrApplyBlockDiagnostics.
+// The divergence is saved, so that the consensus is still halted once the
+// node restarts, until the file is removed. It must be called with mtx held.
+func (cs *State) haltOnDivergence(round int32, blockID types.BlockID, block *types.Block, err error) {
+	var mismatch sm.ErrAppHashMismatch
+	errors.As(err, &mismatch)
+	divergence := &AppHashDivergence{
+		Height:       block.Height,
+		Round:        round,
+		BlockID:      blockID,
+		AppHash:      mismatch.Expected,
+		BlockAppHash: mismatch.Got,
+		Time:         cs.now(),
+	}
+	var diagErr sm.ErrApplyBlockDiagnostics
+	if errors.As(err, &diagErr) {
+		divergence.DiagnosticsDir = diagErr.Dir
+	}
+	cs.Logger.Error("CONSENSUS HALTED: +2/3 committed a block with a different app hash than ours; "+
+		"the node keeps serving the RPC, see /app_hash_divergence, but no longer signs",
+		"height", block.Height,
+		"hash", blockID.Hash,
+		"app_hash", divergence.AppHash,
+		"block_app_hash", divergence.BlockAppHash,
+		"diagnostics_dir", divergence.DiagnosticsDir)
+	if err := saveAppHashDivergence(cs.config.AppHashDivergenceFile(), divergence); err != nil {
+		cs.Logger.Error("Failed to save the app hash divergence", "err", err)
+	}
+	cs.divergence = divergence
+}
+
+// saveAppHashDivergence writes the divergence into file.
+func saveAppHashDivergence(file string, divergence *AppHashDivergence) error {
+	bz, err := cmtjson.MarshalIndent(divergence, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := cmtos.EnsureDir(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(file, bz, 0o600)
+}
+
+// loadAppHashDivergence reads the divergence saved into file, if any.
+func loadAppHashDivergence(file string) (*AppHashDivergence, error) {
+	bz, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	divergence := new(AppHashDivergence)
+	if err := cmtjson.Unmarshal(bz, divergence); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	return divergence, nil
+}
+
+// discardMsgs drops the messages of the peers, the internal ones, and the
+// timeouts, so that the reactor doesn't block on a full queue, until the
+// consensus is stopped.
+func (cs *State) discardMsgs() {
+	for {
+		select {
+		case <-cs.peerMsgQueue:
+		case <-cs.internalMsgQueue:
+		case <-cs.timeoutTicker.Chan():
+		case <-cs.Quit():
+			return
+		}
+	}
+}
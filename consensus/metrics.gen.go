@@ -132,6 +132,22 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: stdprometheus.ExponentialBucketsRange(0.1, 100, 8),
 		}, append(labels, "step")).With(labelsAndValues...),
+		HeightStepDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "height_step_duration_seconds",
+			Help:      "Histogram of the time spent in the propose, prevote, precommit and commit steps over all the rounds of a height, labeled by whether this node proposed the committed block.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 100, 12),
+		}, append(labels, "step", "proposer")).With(labelsAndValues...),
+		CommitToProposalSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "commit_to_proposal_seconds",
+			Help:      "Histogram of the time between the commit of a block and the proposal for the next height, labeled by whether this node made the proposal.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 100, 12),
+		}, append(labels, "proposer")).With(labelsAndValues...),
 		BlockGossipPartsReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -174,6 +190,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "late_votes",
 			Help:      "LateVotes stores the number of votes that were received by this node that correspond to earlier heights and rounds than this node is currently in.",
 		}, append(labels, "vote_type")).With(labelsAndValues...),
+		TimeoutsScheduled: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "timeouts_scheduled",
+			Help:      "Number of timeouts scheduled, by step. A timeout that is replaced by the one of a later step before it fires is never fired.",
+		}, append(labels, "step")).With(labelsAndValues...),
+		TimeoutsFired: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "timeouts_fired",
+			Help:      "Number of timeouts fired, by step.",
+		}, append(labels, "step")).With(labelsAndValues...),
 	}
 }
 
@@ -198,6 +226,8 @@ func NopMetrics() *Metrics {
 		CommittedHeight:           discard.NewGauge(),
 		BlockParts:                discard.NewCounter(),
 		StepDurationSeconds:       discard.NewHistogram(),
+		HeightStepDurationSeconds: discard.NewHistogram(),
+		CommitToProposalSeconds:   discard.NewHistogram(),
 		BlockGossipPartsReceived:  discard.NewCounter(),
 		QuorumPrevoteDelay:        discard.NewGauge(),
 		FullPrevoteDelay:          discard.NewGauge(),
@@ -205,5 +235,7 @@ func NopMetrics() *Metrics {
 		ProposalCreateCount:       discard.NewCounter(),
 		RoundVotingPowerPercent:   discard.NewGauge(),
 		LateVotes:                 discard.NewCounter(),
+		TimeoutsScheduled:         discard.NewCounter(),
+		TimeoutsFired:             discard.NewCounter(),
 	}
 }
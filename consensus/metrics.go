@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -69,6 +70,17 @@ type Metrics struct {
 	StepDurationSeconds metrics.Histogram `metrics_labels:"step" metrics_buckettype:"exprange" metrics_bucketsizes:"0.1, 100, 8"`
 	stepStart           time.Time
 
+	// Histogram of the time spent in the propose, prevote, precommit and commit
+	// steps over all the rounds of a height, labeled by whether this node
+	// proposed the committed block.
+	HeightStepDurationSeconds metrics.Histogram `metrics_labels:"step, proposer" metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 100, 12"`
+	heightStepSeconds         map[string]float64
+
+	// Histogram of the time between the commit of a block and the proposal
+	// for the next height, labeled by whether this node made the proposal.
+	CommitToProposalSeconds metrics.Histogram `metrics_labels:"proposer" metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 100, 12"`
+	commitTime              time.Time
+
 	// Number of block parts received by the node, separated by whether the part
 	// was relevant to the block the node is trying to gather or not.
 	BlockGossipPartsReceived metrics.Counter `metrics_labels:"matches_current"`
@@ -110,6 +122,12 @@ type Metrics struct {
 	// correspond to earlier heights and rounds than this node is currently
 	// in.
 	LateVotes metrics.Counter `metrics_labels:"vote_type"`
+
+	// Number of timeouts scheduled, by step. A timeout that is replaced by
+	// the one of a later step before it fires is never fired.
+	TimeoutsScheduled metrics.Counter `metrics_labels:"step"`
+	// Number of timeouts fired, by step.
+	TimeoutsFired metrics.Counter `metrics_labels:"step"`
 }
 
 // RecordConsMetrics uses for recording the block related metrics during fast-sync.
@@ -128,6 +146,16 @@ func (m *Metrics) MarkProposalProcessed(accepted bool) {
 	m.ProposalReceiveCount.With("status", status).Add(1)
 }
 
+// MarkTimeoutScheduled records a timeout scheduled for the step.
+func (m *Metrics) MarkTimeoutScheduled(s cstypes.RoundStepType) {
+	m.TimeoutsScheduled.With("step", strings.TrimPrefix(s.String(), "RoundStep")).Add(1)
+}
+
+// MarkTimeoutFired records a timeout fired for the step.
+func (m *Metrics) MarkTimeoutFired(s cstypes.RoundStepType) {
+	m.TimeoutsFired.With("step", strings.TrimPrefix(s.String(), "RoundStep")).Add(1)
+}
+
 func (m *Metrics) MarkVoteReceived(vt cmtproto.SignedMsgType, power, totalPower int64) {
 	p := float64(power) / float64(totalPower)
 	n := strings.ToLower(strings.TrimPrefix(vt.String(), "SIGNED_MSG_TYPE_"))
@@ -150,6 +178,52 @@ func (m *Metrics) MarkStep(s cstypes.RoundStepType) {
 		stepTime := time.Since(m.stepStart).Seconds()
 		stepName := strings.TrimPrefix(s.String(), "RoundStep")
 		m.StepDurationSeconds.With("step", stepName).Observe(stepTime)
+		if step := heightStep(s); step != "" {
+			if m.heightStepSeconds == nil {
+				m.heightStepSeconds = make(map[string]float64, len(heightSteps))
+			}
+			m.heightStepSeconds[step] += stepTime
+		}
 	}
 	m.stepStart = time.Now()
 }
+
+// heightSteps are the steps whose durations are summed over a height.
+var heightSteps = []string{"propose", "prevote", "precommit", "commit"}
+
+// heightStep returns the step of heightSteps s is part of, or "".
+func heightStep(s cstypes.RoundStepType) string {
+	switch s {
+	case cstypes.RoundStepPropose:
+		return "propose"
+	case cstypes.RoundStepPrevote, cstypes.RoundStepPrevoteWait:
+		return "prevote"
+	case cstypes.RoundStepPrecommit, cstypes.RoundStepPrecommitWait:
+		return "precommit"
+	case cstypes.RoundStepCommit:
+		return "commit"
+	default:
+		return ""
+	}
+}
+
+// MarkHeight records the time spent in each step of the height just committed,
+// once the commit step is over, and starts the wait for the next proposal.
+func (m *Metrics) MarkHeight(proposer bool) {
+	label := strconv.FormatBool(proposer)
+	for _, step := range heightSteps {
+		m.HeightStepDurationSeconds.With("step", step, "proposer", label).Observe(m.heightStepSeconds[step])
+		delete(m.heightStepSeconds, step)
+	}
+	m.commitTime = time.Now()
+}
+
+// MarkProposal records the time since the last commit when the first proposal
+// of the next height is received.
+func (m *Metrics) MarkProposal(proposer bool) {
+	if m.commitTime.IsZero() {
+		return
+	}
+	m.CommitToProposalSeconds.With("proposer", strconv.FormatBool(proposer)).Observe(time.Since(m.commitTime).Seconds())
+	m.commitTime = time.Time{}
+}
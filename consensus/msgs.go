@@ -70,12 +70,34 @@ func MsgToProto(msg Message) (proto.Message, error) {
 			Part:   *parts,
 		}
 
+	case *BlockParityPartMessage:
+		part, err := msg.Part.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		pb = &cmtcons.BlockParityPart{
+			Height:       msg.Height,
+			Round:        msg.Round,
+			ParityHeader: msg.ParityHeader.ToProto(),
+			LastPartSize: msg.LastPartSize,
+			Part:         *part,
+		}
+
 	case *VoteMessage:
 		vote := msg.Vote.ToProto()
 		pb = &cmtcons.Vote{
 			Vote: vote,
 		}
 
+	case *VoteBatchMessage:
+		votes := make([]*cmtproto.Vote, len(msg.Votes))
+		for i, vote := range msg.Votes {
+			votes[i] = vote.ToProto()
+		}
+		pb = &cmtcons.VoteBatch{
+			Votes: votes,
+		}
+
 	case *HasVoteMessage:
 		pb = &cmtcons.HasVote{
 			Height: msg.Height,
@@ -110,6 +132,16 @@ func MsgToProto(msg Message) (proto.Message, error) {
 
 		pb = vsb
 
+	case *CommitRequestMessage:
+		pb = &cmtcons.CommitRequest{
+			Height: msg.Height,
+		}
+
+	case *CommitResponseMessage:
+		pb = &cmtcons.CommitResponse{
+			Commit: msg.Commit.ToProto(),
+		}
+
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -181,6 +213,22 @@ func MsgFromProto(p proto.Message) (Message, error) {
 			Round:  msg.Round,
 			Part:   parts,
 		}
+	case *cmtcons.BlockParityPart:
+		parityHeader, err := types.PartSetHeaderFromProto(&msg.ParityHeader)
+		if err != nil {
+			return nil, fmt.Errorf("parityHeader: %w", err)
+		}
+		part, err := types.PartFromProto(&msg.Part)
+		if err != nil {
+			return nil, fmt.Errorf("blockparitypart msg to proto error: %w", err)
+		}
+		pb = &BlockParityPartMessage{
+			Height:       msg.Height,
+			Round:        msg.Round,
+			ParityHeader: *parityHeader,
+			LastPartSize: msg.LastPartSize,
+			Part:         part,
+		}
 	case *cmtcons.Vote:
 		vote, err := types.VoteFromProto(msg.Vote)
 		if err != nil {
@@ -190,6 +238,19 @@ func MsgFromProto(p proto.Message) (Message, error) {
 		pb = &VoteMessage{
 			Vote: vote,
 		}
+	case *cmtcons.VoteBatch:
+		votes := make([]*types.Vote, len(msg.Votes))
+		for i, pbVote := range msg.Votes {
+			vote, err := types.VoteFromProto(pbVote)
+			if err != nil {
+				return nil, fmt.Errorf("vote batch msg to proto error: %w", err)
+			}
+			votes[i] = vote
+		}
+
+		pb = &VoteBatchMessage{
+			Votes: votes,
+		}
 	case *cmtcons.HasVote:
 		pb = &HasVoteMessage{
 			Height: msg.Height,
@@ -223,6 +284,18 @@ func MsgFromProto(p proto.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *cmtcons.CommitRequest:
+		pb = &CommitRequestMessage{
+			Height: msg.Height,
+		}
+	case *cmtcons.CommitResponse:
+		commit, err := types.CommitFromProto(msg.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("commitResponse msg to proto error: %w", err)
+		}
+		pb = &CommitResponseMessage{
+			Commit: commit,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -25,6 +25,11 @@ func TestMsgToProto(t *testing.T) {
 		Hash:  cmtrand.Bytes(32),
 	}
 	pbPsh := psh.ToProto()
+	parityPsh := types.PartSetHeader{
+		Total: 2,
+		Hash:  cmtrand.Bytes(32),
+	}
+	pbParityPsh := parityPsh.ToProto()
 	bi := types.BlockID{
 		Hash:          cmtrand.Bytes(32),
 		PartSetHeader: psh,
@@ -68,6 +73,14 @@ func TestMsgToProto(t *testing.T) {
 	require.NoError(t, err)
 	pbVote := vote.ToProto()
 
+	commit := &types.Commit{
+		Height:     1,
+		Round:      0,
+		BlockID:    bi,
+		Signatures: []types.CommitSig{vote.CommitSig()},
+	}
+	pbCommit := commit.ToProto()
+
 	testsCases := []struct {
 		testName string
 		msg      Message
@@ -115,6 +128,21 @@ func TestMsgToProto(t *testing.T) {
 			Part:   *pbParts,
 		},
 
+			false},
+		{"successful BlockParityPartMessage", &BlockParityPartMessage{
+			Height:       100,
+			Round:        1,
+			ParityHeader: parityPsh,
+			LastPartSize: 10,
+			Part:         &parts,
+		}, &cmtcons.BlockParityPart{
+			Height:       100,
+			Round:        1,
+			ParityHeader: pbParityPsh,
+			LastPartSize: 10,
+			Part:         *pbParts,
+		},
+
 			false},
 		{"successful ProposalPOLMessage", &ProposalPOLMessage{
 			Height:           1,
@@ -139,6 +167,13 @@ func TestMsgToProto(t *testing.T) {
 			Vote: pbVote,
 		},
 
+			false},
+		{"successful VoteBatchMessage", &VoteBatchMessage{
+			Votes: []*types.Vote{vote, vote},
+		}, &cmtcons.VoteBatch{
+			Votes: []*cmtproto.Vote{pbVote, pbVote},
+		},
+
 			false},
 		{"successful VoteSetMaj23", &VoteSetMaj23Message{
 			Height:  1,
@@ -167,6 +202,20 @@ func TestMsgToProto(t *testing.T) {
 			Votes:   *pbBits,
 		},
 
+			false},
+		{"successful CommitRequest", &CommitRequestMessage{
+			Height: 1,
+		}, &cmtcons.CommitRequest{
+			Height: 1,
+		},
+
+			false},
+		{"successful CommitResponse", &CommitResponseMessage{
+			Commit: commit,
+		}, &cmtcons.CommitResponse{
+			Commit: pbCommit,
+		},
+
 			false},
 		{"failure", nil, &cmtcons.Message{}, true},
 	}
@@ -193,6 +242,63 @@ func TestMsgToProto(t *testing.T) {
 	}
 }
 
+func TestMsgFromProtoPooled(t *testing.T) {
+	pv := types.NewMockPV()
+	pk, err := pv.GetPubKey()
+	require.NoError(t, err)
+	val := types.NewValidator(pk, 100)
+	valSet := &types.ValidatorSet{Proposer: val, Validators: []*types.Validator{val}}
+	blockID := types.BlockID{
+		Hash:          cmtrand.Bytes(32),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(32)},
+	}
+	parts := types.NewPartSetFromData(cmtrand.Bytes(100), types.BlockPartSizeBytes)
+
+	msgs := make([]Message, 0, 4)
+	for i := 0; i < 2; i++ {
+		vote, err := types.MakeVote(int64(i+1), blockID, valSet, pv, "chainID", time.Now())
+		require.NoError(t, err)
+		msgs = append(msgs,
+			&VoteMessage{vote},
+			&BlockPartMessage{Height: int64(i + 1), Round: 0, Part: parts.GetPart(0)},
+			&HasVoteMessage{Height: int64(i + 1), Round: 0, Type: cmtproto.PrevoteType, Index: 0},
+		)
+	}
+
+	// the messages decoded from the pooled ones are left intact once these
+	// are released and reused
+	pool := &cmtcons.Message{}
+	expected := make([]Message, 0, len(msgs))
+	decoded := make([]Message, 0, len(msgs))
+	for _, msg := range msgs {
+		pb, err := MsgToProto(msg)
+		require.NoError(t, err)
+		bz, err := proto.Marshal(pb.(p2p.Wrapper).Wrap())
+		require.NoError(t, err)
+
+		var pbMsg cmtcons.Message
+		require.NoError(t, proto.Unmarshal(bz, &pbMsg))
+		inner, err := pbMsg.Unwrap()
+		require.NoError(t, err)
+		emsg, err := MsgFromProto(inner)
+		require.NoError(t, err)
+		expected = append(expected, emsg)
+
+		pooled, err := pool.UnmarshalPooled(bz)
+		require.NoError(t, err)
+		inner, err = pooled.(p2p.Unwrapper).Unwrap()
+		require.NoError(t, err)
+		dmsg, err := MsgFromProto(inner)
+		require.NoError(t, err)
+		pool.Release(pooled)
+		decoded = append(decoded, dmsg)
+	}
+	assert.Equal(t, expected, decoded)
+
+	_, err = pool.UnmarshalPooled([]byte{0x32, 0x05, 0x01})
+	assert.Error(t, err)
+}
+
 func TestWALMsgProto(t *testing.T) {
 
 	parts := types.Part{
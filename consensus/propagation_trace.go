@@ -0,0 +1,188 @@
+package consensus
+
+import (
+	"sort"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// propagationTraceHeights is the number of heights kept in the propagation
+// trace.
+const propagationTraceHeights = 20
+
+// PropagationTrace is the timeline of the propagation of the block parts and
+// votes of a round to this node: when each of them was first received, and
+// from which peer.
+type PropagationTrace struct {
+	Height     int64              `json:"height"`
+	Round      int32              `json:"round"`
+	BlockParts []BlockPartArrival `json:"block_parts"`
+	Prevotes   []VoteReceipt      `json:"prevotes"`
+	Precommits []VoteReceipt      `json:"precommits"`
+}
+
+// BlockPartArrival is the first receipt of a block part.
+type BlockPartArrival struct {
+	Index  uint32    `json:"index"`
+	PeerID p2p.ID    `json:"peer_id"`
+	Time   time.Time `json:"time"`
+}
+
+// VoteReceipt is the first receipt of the vote of a validator.
+type VoteReceipt struct {
+	ValidatorAddress types.Address `json:"validator_address"`
+	ValidatorIndex   int32         `json:"validator_index"`
+	PeerID           p2p.ID        `json:"peer_id"`
+	Time             time.Time     `json:"time"`
+}
+
+// propagationTracer records when the block parts and votes of the last heights
+// were first received, and from which peer. It has its own lock so that it can
+// be called from the reactor without blocking the state machine.
+type propagationTracer struct {
+	mtx    cmtsync.Mutex
+	rounds map[propagationRound]*propagationRecord
+	// the recorded heights, oldest first
+	heights []int64
+}
+
+type propagationRound struct {
+	height int64
+	round  int32
+}
+
+type propagationRecord struct {
+	parts      map[uint32]BlockPartArrival
+	prevotes   map[int32]VoteReceipt // by validator index
+	precommits map[int32]VoteReceipt // by validator index
+}
+
+func newPropagationTracer() *propagationTracer {
+	return &propagationTracer{rounds: make(map[propagationRound]*propagationRecord)}
+}
+
+// recordBlockPart records the receipt of a block part from the peer, unless
+// it was already received.
+func (t *propagationTracer) recordBlockPart(height int64, round int32, index uint32, peerID p2p.ID, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	r := t.round(height, round)
+	if r == nil {
+		return
+	}
+	if _, ok := r.parts[index]; !ok {
+		r.parts[index] = BlockPartArrival{Index: index, PeerID: peerID, Time: now}
+	}
+}
+
+// recordVote records the receipt of a vote from the peer, unless a vote of
+// the same type of the validator was already received in the round.
+func (t *propagationTracer) recordVote(vote *types.Vote, peerID p2p.ID, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	r := t.round(vote.Height, vote.Round)
+	if r == nil {
+		return
+	}
+	receipts := r.prevotes
+	if vote.Type == cmtproto.PrecommitType {
+		receipts = r.precommits
+	}
+	if _, ok := receipts[vote.ValidatorIndex]; !ok {
+		receipts[vote.ValidatorIndex] = VoteReceipt{
+			ValidatorAddress: vote.ValidatorAddress,
+			ValidatorIndex:   vote.ValidatorIndex,
+			PeerID:           peerID,
+			Time:             now,
+		}
+	}
+}
+
+// round returns the record of the round, creating it if needed, or nil if the
+// height is older than the recorded ones. Recording a new height drops the
+// oldest one if needed.
+func (t *propagationTracer) round(height int64, round int32) *propagationRecord {
+	key := propagationRound{height, round}
+	if r, ok := t.rounds[key]; ok {
+		return r
+	}
+
+	i := sort.Search(len(t.heights), func(i int) bool { return t.heights[i] >= height })
+	if i == len(t.heights) || t.heights[i] != height {
+		if i == 0 && len(t.heights) == propagationTraceHeights {
+			return nil
+		}
+		t.heights = append(t.heights, 0)
+		copy(t.heights[i+1:], t.heights[i:])
+		t.heights[i] = height
+		if len(t.heights) > propagationTraceHeights {
+			t.dropHeight(t.heights[0])
+			t.heights = t.heights[1:]
+		}
+	}
+
+	r := &propagationRecord{
+		parts:      make(map[uint32]BlockPartArrival),
+		prevotes:   make(map[int32]VoteReceipt),
+		precommits: make(map[int32]VoteReceipt),
+	}
+	t.rounds[key] = r
+	return r
+}
+
+func (t *propagationTracer) dropHeight(height int64) {
+	for key := range t.rounds {
+		if key.height == height {
+			delete(t.rounds, key)
+		}
+	}
+}
+
+// trace returns the propagation trace of the round, with the block parts and
+// votes in the order they were received, or false if the height is not
+// recorded.
+func (t *propagationTracer) trace(height int64, round int32) (PropagationTrace, bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	i := sort.Search(len(t.heights), func(i int) bool { return t.heights[i] >= height })
+	if i == len(t.heights) || t.heights[i] != height {
+		return PropagationTrace{}, false
+	}
+
+	res := PropagationTrace{
+		Height:     height,
+		Round:      round,
+		BlockParts: make([]BlockPartArrival, 0),
+		Prevotes:   make([]VoteReceipt, 0),
+		Precommits: make([]VoteReceipt, 0),
+	}
+	r, ok := t.rounds[propagationRound{height, round}]
+	if !ok {
+		return res, true
+	}
+	for _, part := range r.parts {
+		res.BlockParts = append(res.BlockParts, part)
+	}
+	sort.Slice(res.BlockParts, func(i, j int) bool {
+		return res.BlockParts[i].Time.Before(res.BlockParts[j].Time)
+	})
+	res.Prevotes = sortedReceipts(r.prevotes)
+	res.Precommits = sortedReceipts(r.precommits)
+	return res, true
+}
+
+func sortedReceipts(receipts map[int32]VoteReceipt) []VoteReceipt {
+	res := make([]VoteReceipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		res = append(res, receipt)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time) })
+	return res
+}
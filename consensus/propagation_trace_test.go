@@ -0,0 +1,90 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/p2p"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestPropagationTracer(t *testing.T) {
+	tracer := newPropagationTracer()
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	vote := func(height int64, idx int32, voteType cmtproto.SignedMsgType) *types.Vote {
+		return &types.Vote{Height: height, Round: 0, Type: voteType, ValidatorIndex: idx}
+	}
+	peerA, peerB := p2p.ID("a"), p2p.ID("b")
+
+	tracer.recordBlockPart(1, 0, 1, peerA, at(20))
+	tracer.recordBlockPart(1, 0, 0, peerB, at(10))
+	tracer.recordBlockPart(1, 0, 0, peerA, at(15)) // already received, ignored
+	tracer.recordVote(vote(1, 1, cmtproto.PrevoteType), peerB, at(30))
+	tracer.recordVote(vote(1, 0, cmtproto.PrevoteType), peerA, at(25))
+	tracer.recordVote(vote(1, 0, cmtproto.PrevoteType), peerB, at(26)) // already received, ignored
+	tracer.recordVote(vote(1, 0, cmtproto.PrecommitType), peerB, at(40))
+
+	trace, ok := tracer.trace(1, 0)
+	require.True(t, ok)
+	assert.Equal(t, []BlockPartArrival{
+		{Index: 0, PeerID: peerB, Time: at(10)},
+		{Index: 1, PeerID: peerA, Time: at(20)},
+	}, trace.BlockParts)
+	assert.Equal(t, []VoteReceipt{
+		{ValidatorIndex: 0, PeerID: peerA, Time: at(25)},
+		{ValidatorIndex: 1, PeerID: peerB, Time: at(30)},
+	}, trace.Prevotes)
+	assert.Equal(t, []VoteReceipt{{ValidatorIndex: 0, PeerID: peerB, Time: at(40)}}, trace.Precommits)
+
+	// a round without any receipt
+	trace, ok = tracer.trace(1, 1)
+	require.True(t, ok)
+	assert.Empty(t, trace.BlockParts)
+	_, ok = tracer.trace(2, 0)
+	assert.False(t, ok)
+
+	// only the last heights are kept
+	for h := int64(2); h <= propagationTraceHeights+1; h++ {
+		tracer.recordVote(vote(h, 0, cmtproto.PrevoteType), peerA, at(int(h)*100))
+	}
+	_, ok = tracer.trace(1, 0)
+	assert.False(t, ok)
+	_, ok = tracer.trace(propagationTraceHeights+1, 0)
+	assert.True(t, ok)
+	// the heights older than the kept ones are not recorded
+	tracer.recordBlockPart(1, 0, 0, peerA, at(5000))
+	_, ok = tracer.trace(1, 0)
+	assert.False(t, ok)
+	assert.Len(t, tracer.heights, propagationTraceHeights)
+}
+
+func TestTracedRound(t *testing.T) {
+	vals, _ := types.RandValidatorSet(1, 10)
+	rs := &cstypes.RoundState{
+		Height:     10,
+		Round:      2,
+		LastCommit: types.NewVoteSet("test", 9, 1, cmtproto.PrecommitType, vals),
+	}
+
+	assert.True(t, tracedRound(rs, 9, 1))
+	assert.False(t, tracedRound(rs, 9, 0))
+	assert.True(t, tracedRound(rs, 10, 0))
+	assert.True(t, tracedRound(rs, 10, 3))
+	assert.False(t, tracedRound(rs, 10, 4))
+	assert.False(t, tracedRound(rs, 10, -1))
+	assert.True(t, tracedRound(rs, 11, 3))
+	assert.False(t, tracedRound(rs, 12, 0))
+	assert.False(t, tracedRound(rs, 8, 1))
+
+	// the part indices are bounded by the total of the proposal block parts
+	assert.EqualValues(t, types.MaxBlockPartsCount, maxTracedParts(rs, 10, 2))
+	rs.ProposalBlockParts = types.NewPartSetFromHeader(types.PartSetHeader{Total: 3, Hash: []byte("hash")})
+	assert.EqualValues(t, 3, maxTracedParts(rs, 10, 2))
+	assert.EqualValues(t, types.MaxBlockPartsCount, maxTracedParts(rs, 10, 3))
+}
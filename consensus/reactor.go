@@ -9,6 +9,7 @@ import (
 
 	cstypes "github.com/cometbft/cometbft/consensus/types"
 	"github.com/cometbft/cometbft/libs/bits"
+	"github.com/cometbft/cometbft/libs/erasure"
 	cmtevents "github.com/cometbft/cometbft/libs/events"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -27,12 +28,29 @@ const (
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
 
+	// DataChannelVersion is the highest version of the messages of
+	// DataChannel the reactor supports.
+	DataChannelVersion = uint32(2)
+
+	// catchupCommitVersion is the version of DataChannel adding CommitRequest
+	// and CommitResponse, used by nodes lagging behind a peer to pull the
+	// commit of their height in one message. They are only sent to the peers
+	// supporting it.
+	catchupCommitVersion = uint32(2)
+
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
+	// How long the peers are left to relay the dispersed parts of our
+	// proposal block to each other, before we gossip the missing parts.
+	blockPartDispersalGracePeriod = time.Second
+
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
 )
 
+// The consensus messages are decoded into pooled ones by the peers.
+var _ p2p.PooledUnmarshaler = &cmtcons.Message{}
+
 //-----------------------------------------------------------------------------
 
 // Reactor defines a reactor for the consensus service.
@@ -46,6 +64,8 @@ type Reactor struct {
 	eventBus *types.EventBus
 	rs       *cstypes.RoundState
 
+	byzantine byzantineHooks
+
 	Metrics *Metrics
 }
 
@@ -80,6 +100,12 @@ func (conR *Reactor) OnStart() error {
 	conR.subscribeToBroadcastEvents()
 	go conR.updateRoundStateRoutine()
 
+	// the switch adjusts the number of outbound peers from the latency of
+	// the votes
+	if conR.Switch != nil {
+		conR.conS.reportVoteLatency = conR.Switch.ReportVoteLatency
+	}
+
 	if !conR.WaitSync() {
 		err := conR.conS.Start()
 		if err != nil {
@@ -135,6 +161,16 @@ conR:
 	}
 }
 
+// HaltOnAppHashMismatch halts the consensus, as upon committing the block,
+// when the block sync is given a block committed by +2/3 of the validators
+// with commit, whose app hash mismatches ours with err, an
+// sm.ErrAppHashMismatch. The consensus is then not switched to.
+func (conR *Reactor) HaltOnAppHashMismatch(block *types.Block, commit *types.Commit, err error) {
+	conR.conS.mtx.Lock()
+	defer conR.conS.mtx.Unlock()
+	conR.conS.haltOnDivergence(commit.Round, commit.BlockID, block, err)
+}
+
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
@@ -145,6 +181,8 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   100,
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
+			// a peer too slow to keep up with consensus is of no use
+			DropPolicy: p2p.Disconnect,
 		},
 		{
 			ID: DataChannel, // maybe split between gossiping current block and catchup stuff
@@ -154,6 +192,8 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvBufferCapacity:  50 * 4096,
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
+			DropPolicy:          p2p.Disconnect,
+			Version:             DataChannelVersion,
 		},
 		{
 			ID:                  VoteChannel,
@@ -162,6 +202,7 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvBufferCapacity:  100 * 100,
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
+			DropPolicy:          p2p.Disconnect,
 		},
 		{
 			ID:                  VoteSetBitsChannel,
@@ -177,6 +218,9 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 // InitPeer implements Reactor by creating a state for the peer.
 func (conR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
 	peerState := NewPeerState(peer).SetLogger(conR.Logger)
+	if cfg := conR.conS.config; cfg.VoteBatchSize > 1 {
+		peerState.voteBatch = newVoteBatch(cfg.VoteBatchSize, cfg.VoteBatchFlushInterval)
+	}
 	peer.Set(types.PeerStateKey, peerState)
 	return peer
 }
@@ -321,8 +365,16 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 			ps.ApplyProposalPOLMessage(msg)
 		case *BlockPartMessage:
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
+			conR.tracePropagation(msg, e.Src.ID())
 			conR.Metrics.BlockParts.With("peer_id", string(e.Src.ID())).Add(1)
 			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+		case *BlockParityPartMessage:
+			ps.SetHasProposalBlockParityPart(msg.Height, msg.Round, int(msg.Part.Index), int(msg.ParityHeader.Total))
+			conR.conS.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
+		case *CommitRequestMessage:
+			conR.respondCommitRequest(e.Src, msg.Height)
+		case *CommitResponseMessage:
+			conR.handleCommitResponse(ps, e.Src.ID(), msg.Commit)
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -341,9 +393,27 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 			ps.EnsureVoteBitArrays(height, valSize)
 			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
+			conR.tracePropagation(msg, e.Src.ID())
 
 			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
 
+		case *VoteBatchMessage:
+			cs := conR.conS
+			cs.mtx.RLock()
+			height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+			cs.mtx.RUnlock()
+			ps.EnsureVoteBitArrays(height, valSize)
+			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+
+			// the votes are handled by the state machine one by one, as if
+			// they had been received separately
+			for _, vote := range msg.Votes {
+				ps.SetHasVote(vote)
+				voteMsg := &VoteMessage{vote}
+				conR.tracePropagation(voteMsg, e.Src.ID())
+				cs.peerMsgQueue <- msgInfo{voteMsg, e.Src.ID()}
+			}
+
 		default:
 			// don't punish (leave room for soft upgrades)
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
@@ -422,6 +492,7 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventVote,
 		func(data cmtevents.EventData) {
 			conR.broadcastHasVoteMessage(data.(*types.Vote))
+			conR.equivocate(data.(*types.Vote))
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events", "err", err)
 	}
@@ -526,6 +597,60 @@ func (conR *Reactor) updateRoundStateRoutine() {
 	}
 }
 
+// tracePropagation records the receipt of the block part or vote from the
+// peer, if the propagation is traced. Only the messages of the rounds next to
+// the current one are recorded, with the part and validator indices within
+// their bounds, so that the peers can't evict the trace of the recent heights
+// or grow it without bound.
+func (conR *Reactor) tracePropagation(msg Message, peerID p2p.ID) {
+	tracer := conR.conS.propagationTracer
+	if tracer == nil {
+		return
+	}
+	now := cmttime.Now()
+	rs := conR.getRoundState()
+	switch msg := msg.(type) {
+	case *BlockPartMessage:
+		if tracedRound(rs, msg.Height, msg.Round) && msg.Part.Index < maxTracedParts(rs, msg.Height, msg.Round) {
+			tracer.recordBlockPart(msg.Height, msg.Round, msg.Part.Index, peerID, now)
+		}
+	case *VoteMessage:
+		vote := msg.Vote
+		vals := rs.Validators
+		if vote.Height == rs.Height-1 {
+			vals = rs.LastValidators
+		}
+		if tracedRound(rs, vote.Height, vote.Round) && vals != nil &&
+			vote.ValidatorIndex >= 0 && int(vote.ValidatorIndex) < vals.Size() {
+			tracer.recordVote(vote, peerID, now)
+		}
+	}
+}
+
+// tracedRound returns true if the messages of the round are recorded in the
+// propagation trace: those of the round of the last commit, and of the rounds
+// of the current and next heights up to the one after the current round.
+func tracedRound(rs *cstypes.RoundState, height int64, round int32) bool {
+	switch height {
+	case rs.Height - 1:
+		return rs.LastCommit != nil && round == rs.LastCommit.GetRound()
+	case rs.Height, rs.Height + 1:
+		return round >= 0 && round <= rs.Round+1
+	default:
+		return false
+	}
+}
+
+// maxTracedParts returns the bound of the indices of the block parts of the
+// round recorded in the propagation trace: the total of the proposal block
+// parts, if known.
+func maxTracedParts(rs *cstypes.RoundState, height int64, round int32) uint32 {
+	if height == rs.Height && round == rs.Round && rs.ProposalBlockParts != nil {
+		return rs.ProposalBlockParts.Total()
+	}
+	return types.MaxBlockPartsCount
+}
+
 func (conR *Reactor) getRoundState() *cstypes.RoundState {
 	conR.mtx.RLock()
 	defer conR.mtx.RUnlock()
@@ -545,7 +670,21 @@ OUTER_LOOP:
 		prs := ps.GetRoundState()
 
 		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) && !conR.withholdBlockParts() &&
+			!conR.relayingDispersedParts(rs) {
+			// Disperse the parts and parity parts of our proposal block, each
+			// to a single peer.
+			if rs.ProposalBlockParityParts.IsDispersing() {
+				if !rs.ProposalBlockParts.IsComplete() {
+					// our own parts are still being added
+					time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+					continue OUTER_LOOP
+				}
+				if index, ok := rs.ProposalBlockParityParts.ClaimDispersal(); ok {
+					conR.sendDispersedPart(logger, rs, prs, ps, peer, index)
+				}
+				continue OUTER_LOOP
+			}
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				parts, err := part.ToProto()
@@ -565,6 +704,10 @@ OUTER_LOOP:
 				}
 				continue OUTER_LOOP
 			}
+			// Or the parity parts of the missing ones
+			if !prs.ProposalBlockParts.IsFull() && conR.sendParityPart(logger, rs, prs, ps, peer) {
+				continue OUTER_LOOP
+			}
 		}
 
 		// If the peer is on a previous height that we have, help catch up.
@@ -604,6 +747,10 @@ OUTER_LOOP:
 
 		// Send Proposal && ProposalPOL BitArray?
 		if rs.Proposal != nil && !prs.Proposal {
+			if conR.delayProposal(rs) {
+				time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+				continue OUTER_LOOP
+			}
 			// Proposal: share the proposal metadata with peer.
 			{
 				logger.Debug("Sending proposal", "height", prs.Height, "round", prs.Round)
@@ -639,6 +786,91 @@ OUTER_LOOP:
 	}
 }
 
+// relayingDispersedParts tells whether the peers are left to relay the
+// dispersed parts and parity parts of our proposal block to each other, before
+// we gossip the missing parts.
+func (conR *Reactor) relayingDispersedParts(rs *cstypes.RoundState) bool {
+	dispersedAt := rs.ProposalBlockParityParts.DispersedAt()
+	return !dispersedAt.IsZero() && time.Since(dispersedAt) < blockPartDispersalGracePeriod
+}
+
+// sendDispersedPart sends the part, or the parity part past the parts, of the
+// given index to the peer, unless it has it.
+func (conR *Reactor) sendDispersedPart(logger log.Logger, rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer, index int) {
+
+	total := int(rs.ProposalBlockParts.Total())
+	if index >= total {
+		conR.sendBlockParityPart(logger, rs, prs, ps, peer, index-total)
+		return
+	}
+	if prs.ProposalBlockParts.GetIndex(index) {
+		return
+	}
+	part, err := rs.ProposalBlockParts.GetPart(index).ToProto()
+	if err != nil {
+		panic(err)
+	}
+	logger.Debug("Dispersing block part", "height", prs.Height, "round", prs.Round, "index", index)
+	if peer.Send(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message: &cmtcons.BlockPart{
+			Height: rs.Height,
+			Round:  rs.Round,
+			Part:   *part,
+		},
+	}) {
+		ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
+	}
+}
+
+// sendParityPart sends a parity part of the proposal block the peer doesn't
+// have, and returns whether there was one.
+func (conR *Reactor) sendParityPart(logger log.Logger, rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) bool {
+
+	if rs.ProposalBlockParityParts == nil {
+		return false
+	}
+	has := rs.ProposalBlockParityParts.BitArray()
+	if prs.ProposalBlockParityParts != nil && prs.ProposalBlockParityParts.Size() == has.Size() {
+		has = has.Sub(prs.ProposalBlockParityParts.Copy())
+	}
+	index, ok := has.PickRandom()
+	if !ok {
+		return false
+	}
+	conR.sendBlockParityPart(logger, rs, prs, ps, peer, index)
+	return true
+}
+
+func (conR *Reactor) sendBlockParityPart(logger log.Logger, rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer, index int) {
+
+	parity := rs.ProposalBlockParityParts
+	if prs.ProposalBlockParityParts != nil && prs.ProposalBlockParityParts.GetIndex(index) {
+		return
+	}
+	header := parity.Header()
+	part, err := parity.GetPart(index).ToProto()
+	if err != nil {
+		panic(err)
+	}
+	logger.Debug("Sending block parity part", "height", prs.Height, "round", prs.Round, "index", index)
+	if peer.Send(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message: &cmtcons.BlockParityPart{
+			Height:       rs.Height,
+			Round:        rs.Round,
+			ParityHeader: header.ToProto(),
+			LastPartSize: parity.LastPartSize(),
+			Part:         *part,
+		},
+	}) {
+		ps.SetHasProposalBlockParityPart(prs.Height, prs.Round, index, int(parity.Total()))
+	}
+}
+
 func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundState,
 	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) {
 
@@ -739,7 +971,7 @@ OUTER_LOOP:
 		if blockStoreBase > 0 && prs.Height != 0 && rs.Height >= prs.Height+2 && prs.Height >= blockStoreBase {
 			// Load the block commit for prs.Height,
 			// which contains precommit signatures for prs.Height.
-			if commit := conR.conS.blockStore.LoadBlockCommit(prs.Height); commit != nil {
+			if commit := loadVotesCommit(conR.conS.blockStore, prs.Height); commit != nil {
 				if ps.PickSendVote(commit) {
 					logger.Debug("Picked Catchup commit to send", "height", prs.Height)
 					continue OUTER_LOOP
@@ -747,6 +979,25 @@ OUTER_LOOP:
 			}
 		}
 
+		// If peer is ahead of us, pull the commit of our height from it.
+		conR.requestCatchupCommit(peer, ps, rs, prs)
+
+		// Nothing more to pick, send the pending votes once the flush
+		// interval has elapsed.
+		if batch := ps.voteBatch; batch != nil && batch.size() > 0 {
+			if wait := batch.untilFlush(); wait > 0 {
+				if wait > conR.conS.config.PeerGossipSleepDuration {
+					wait = conR.conS.config.PeerGossipSleepDuration
+				}
+				time.Sleep(wait)
+				continue OUTER_LOOP
+			}
+			if batch.flush(peer) {
+				continue OUTER_LOOP
+			}
+			logger.Debug("Sending vote batch failed")
+		}
+
 		if sleeping == 0 {
 			// We sent nothing. Sleep...
 			sleeping = 1
@@ -822,6 +1073,87 @@ func (conR *Reactor) gossipVotesForHeight(
 	return false
 }
 
+// requestCatchupCommit asks the peer for the commit of our height if the peer
+// is already past it, so that we don't have to wait for its precommits to be
+// gossiped one by one, if the peer supports catchupCommitVersion. Requests are
+// retried at most every PeerQueryMaj23SleepDuration.
+func (conR *Reactor) requestCatchupCommit(
+	peer p2p.Peer,
+	ps *PeerState,
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+) {
+	if prs.Height <= rs.Height || rs.Step >= cstypes.RoundStepCommit {
+		return
+	}
+	if p2p.NegotiateChannelVersion(peer, DataChannel, DataChannelVersion) < catchupCommitVersion {
+		return
+	}
+	if ps.commitRequestHeight == rs.Height &&
+		time.Since(ps.commitRequestTime) < conR.conS.config.PeerQueryMaj23SleepDuration {
+		return
+	}
+	if peer.TrySend(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message:   &cmtcons.CommitRequest{Height: rs.Height},
+	}) {
+		ps.commitRequestHeight, ps.commitRequestTime = rs.Height, time.Now()
+	}
+}
+
+// respondCommitRequest sends the commit of the height to the peer, if we have
+// it.
+func (conR *Reactor) respondCommitRequest(peer p2p.Peer, height int64) {
+	blockStore := conR.conS.blockStore
+	if height < blockStore.Base() || height > blockStore.Height() {
+		return
+	}
+	commit := loadVotesCommit(blockStore, height)
+	if commit == nil {
+		return
+	}
+	peer.TrySend(p2p.Envelope{
+		ChannelID: DataChannel,
+		Message:   &cmtcons.CommitResponse{Commit: commit.ToProto()},
+	})
+}
+
+// loadVotesCommit loads a commit of the height whose precommits can be sent one
+// by one: the block commit, or the seen commit if the block commit is missing or
+// aggregated. It returns nil if neither is available with their signatures.
+func loadVotesCommit(blockStore sm.BlockStore, height int64) *types.Commit {
+	if commit := blockStore.LoadBlockCommit(height); commit != nil && !commit.IsAggregated() {
+		return commit
+	}
+	if commit := blockStore.LoadSeenCommit(height); commit != nil && !commit.IsAggregated() {
+		return commit
+	}
+	return nil
+}
+
+// handleCommitResponse passes the precommits of the commit to the state
+// machine, as if they had been received one by one. Commits for another height
+// than ours are ignored.
+func (conR *Reactor) handleCommitResponse(ps *PeerState, peerID p2p.ID, commit *types.Commit) {
+	cs := conR.conS
+	cs.mtx.RLock()
+	height, valSize := cs.Height, cs.Validators.Size()
+	cs.mtx.RUnlock()
+	if commit.Height != height || len(commit.Signatures) != valSize || commit.IsAggregated() {
+		return
+	}
+	ps.EnsureVoteBitArrays(height, valSize)
+
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+		vote := commit.GetVote(int32(idx))
+		ps.SetHasVote(vote)
+		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}
+	}
+}
+
 // NOTE: `queryMaj23Routine` has a simple crude design since it only comes
 // into play for liveness when there's a signature DDoS attack happening.
 func (conR *Reactor) queryMaj23Routine(peer p2p.Peer, ps *PeerState) {
@@ -1010,6 +1342,15 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// votes picked but not yet sent to the peer, nil if batching is disabled.
+	// Only used by the routine gossiping votes.
+	voteBatch *voteBatch
+
+	// last commit requested from the peer. Only used by the routine gossiping
+	// votes.
+	commitRequestHeight int64
+	commitRequestTime   time.Time
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1122,10 +1463,38 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
+// SetHasProposalBlockParityPart sets the given parity part, of the given
+// number of parity parts, as known for the peer.
+func (ps *PeerState) SetHasProposalBlockParityPart(height int64, round int32, index, total int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.PRS.Height != height || ps.PRS.Round != round {
+		return
+	}
+
+	if ps.PRS.ProposalBlockParityParts == nil || ps.PRS.ProposalBlockParityParts.Size() != total {
+		ps.PRS.ProposalBlockParityParts = bits.NewBitArray(total)
+	}
+	ps.PRS.ProposalBlockParityParts.SetIndex(index, true)
+}
+
 // PickSendVote picks a vote and sends it to the peer.
 // Returns true if vote was sent.
+//
+// If vote batching is enabled, the vote is queued instead and only sent once
+// the batch is full or flushed by the routine gossiping votes.
 func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
 	if vote, ok := ps.PickVoteToSend(votes); ok {
+		if ps.voteBatch != nil {
+			ps.logger.Debug("Queueing vote message", "ps", ps, "vote", vote)
+			ps.voteBatch.add(vote)
+			ps.SetHasVote(vote)
+			if ps.voteBatch.full() {
+				return ps.voteBatch.flush(ps.peer)
+			}
+			return true
+		}
 		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
 		if ps.peer.Send(p2p.Envelope{
 			ChannelID: VoteChannel,
@@ -1364,6 +1733,7 @@ func (ps *PeerState) ApplyNewRoundStepMessage(msg *NewRoundStepMessage) {
 		ps.PRS.Proposal = false
 		ps.PRS.ProposalBlockPartSetHeader = types.PartSetHeader{}
 		ps.PRS.ProposalBlockParts = nil
+		ps.PRS.ProposalBlockParityParts = nil
 		ps.PRS.ProposalPOLRound = -1
 		ps.PRS.ProposalPOL = nil
 		// We'll update the BitArray capacity later.
@@ -1407,6 +1777,7 @@ func (ps *PeerState) ApplyNewValidBlockMessage(msg *NewValidBlockMessage) {
 
 	ps.PRS.ProposalBlockPartSetHeader = msg.BlockPartSetHeader
 	ps.PRS.ProposalBlockParts = msg.BlockParts
+	ps.PRS.ProposalBlockParityParts = nil
 }
 
 // ApplyProposalPOLMessage updates the peer state for the new proposal POL.
@@ -1493,10 +1864,14 @@ func init() {
 	cmtjson.RegisterType(&ProposalMessage{}, "tendermint/Proposal")
 	cmtjson.RegisterType(&ProposalPOLMessage{}, "tendermint/ProposalPOL")
 	cmtjson.RegisterType(&BlockPartMessage{}, "tendermint/BlockPart")
+	cmtjson.RegisterType(&BlockParityPartMessage{}, "tendermint/BlockParityPart")
 	cmtjson.RegisterType(&VoteMessage{}, "tendermint/Vote")
+	cmtjson.RegisterType(&VoteBatchMessage{}, "tendermint/VoteBatch")
 	cmtjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	cmtjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	cmtjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	cmtjson.RegisterType(&CommitRequestMessage{}, "tendermint/CommitRequest")
+	cmtjson.RegisterType(&CommitResponseMessage{}, "tendermint/CommitResponse")
 }
 
 //-------------------------------------
@@ -1680,6 +2055,49 @@ func (m *BlockPartMessage) String() string {
 
 //-------------------------------------
 
+// BlockParityPartMessage is sent when gossipping a parity part of the erasure
+// coding of the parts of the proposed block (see types.ParityPartSet).
+type BlockParityPartMessage struct {
+	Height       int64
+	Round        int32
+	ParityHeader types.PartSetHeader
+	LastPartSize uint32
+	Part         *types.Part
+}
+
+// ValidateBasic performs basic validation.
+func (m *BlockParityPartMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.ParityHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong ParityHeader: %v", err)
+	}
+	if m.ParityHeader.Total == 0 || m.ParityHeader.Total >= erasure.MaxShards {
+		return fmt.Errorf("invalid ParityHeader.Total %d", m.ParityHeader.Total)
+	}
+	if m.LastPartSize == 0 || m.LastPartSize > types.BlockPartSizeBytes {
+		return fmt.Errorf("invalid LastPartSize %d", m.LastPartSize)
+	}
+	if err := m.Part.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Part: %v", err)
+	}
+	if m.Part.Index >= m.ParityHeader.Total {
+		return fmt.Errorf("part index %d out of range", m.Part.Index)
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *BlockParityPartMessage) String() string {
+	return fmt.Sprintf("[BlockParityPart H:%v R:%v P:%v]", m.Height, m.Round, m.Part)
+}
+
+//-------------------------------------
+
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
@@ -1697,6 +2115,34 @@ func (m *VoteMessage) String() string {
 
 //-------------------------------------
 
+// VoteBatchMessage is sent to gossip several votes at once.
+type VoteBatchMessage struct {
+	Votes []*types.Vote
+}
+
+// ValidateBasic performs basic validation.
+func (m *VoteBatchMessage) ValidateBasic() error {
+	if len(m.Votes) == 0 {
+		return errors.New("empty vote batch")
+	}
+	if len(m.Votes) > maxVoteBatchSize {
+		return fmt.Errorf("vote batch too big: %d votes, max %d", len(m.Votes), maxVoteBatchSize)
+	}
+	for i, vote := range m.Votes {
+		if err := vote.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid vote #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *VoteBatchMessage) String() string {
+	return fmt.Sprintf("[VoteBatch %d votes]", len(m.Votes))
+}
+
+//-------------------------------------
+
 // HasVoteMessage is sent to indicate that a particular vote has been received.
 type HasVoteMessage struct {
 	Height int64
@@ -1794,3 +2240,48 @@ func (m *VoteSetBitsMessage) String() string {
 }
 
 //-------------------------------------
+
+// CommitRequestMessage is sent by a node lagging behind a peer to get the
+// commit of its current height.
+type CommitRequestMessage struct {
+	Height int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *CommitRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive Height")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CommitRequestMessage) String() string {
+	return fmt.Sprintf("[CommitRequest H:%v]", m.Height)
+}
+
+//-------------------------------------
+
+// CommitResponseMessage is sent in response to a CommitRequestMessage.
+type CommitResponseMessage struct {
+	Commit *types.Commit
+}
+
+// ValidateBasic performs basic validation.
+func (m *CommitResponseMessage) ValidateBasic() error {
+	if m.Commit == nil {
+		return errors.New("nil Commit")
+	}
+	if m.Commit.Height <= 0 {
+		return errors.New("non-positive Height")
+	}
+	if len(m.Commit.Signatures) > types.MaxVotesCount {
+		return fmt.Errorf("too many signatures: %d, max: %d", len(m.Commit.Signatures), types.MaxVotesCount)
+	}
+	return m.Commit.ValidateBasic()
+}
+
+// String returns a string representation.
+func (m *CommitResponseMessage) String() string {
+	return fmt.Sprintf("[CommitResponse %v]", m.Commit)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/cometbft/cometbft/libs/bits"
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
@@ -120,6 +121,43 @@ func TestReactorBasic(t *testing.T) {
 	}, css)
 }
 
+// Ensure a testnet gossiping votes in batches makes blocks
+func TestReactorVoteBatching(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newKVStore,
+		func(c *cfg.Config) {
+			c.Consensus.VoteBatchSize = 2
+			c.Consensus.VoteBatchFlushInterval = time.Millisecond
+		})
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	// wait till everyone makes the first two blocks
+	for i := 0; i < 2; i++ {
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+}
+
+// Ensure a testnet erasure coding the block parts makes blocks
+func TestReactorBlockPartParity(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newKVStore,
+		func(c *cfg.Config) {
+			c.Consensus.BlockPartParityRatio = 0.5
+		})
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	// wait till everyone makes the first two blocks
+	for i := 0; i < 2; i++ {
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+}
+
 // Ensure we can process blocks with evidence
 func TestReactorWithEvidence(t *testing.T) {
 	nValidators := 4
@@ -271,6 +309,79 @@ func TestReactorReceiveDoesNotPanicIfAddPeerHasntBeenCalledYet(t *testing.T) {
 	})
 }
 
+// commitPeer records the messages sent to it.
+type commitPeer struct {
+	*p2pmock.Peer
+	sent []p2p.Envelope
+	// version of DataChannel advertised by the peer
+	dataChannelVersion uint32
+}
+
+func (p *commitPeer) NodeInfo() p2p.NodeInfo {
+	ni := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	ni.SetChannelVersion(DataChannel, p.dataChannelVersion)
+	return ni
+}
+
+func (p *commitPeer) TrySend(e p2p.Envelope) bool {
+	p.sent = append(p.sent, e)
+	return true
+}
+
+// Ensure a node serves the commits it has and passes the precommits of a
+// commit received for its height to the state machine
+func TestReactorCatchupCommit(t *testing.T) {
+	cs, vss := randState(4)
+	height, round := cs.Height, cs.Round
+	conR := NewReactor(cs, false)
+	conR.SetLogger(log.TestingLogger())
+
+	peer := &commitPeer{Peer: p2pmock.NewPeer(nil)}
+	conR.InitPeer(peer)
+	ps := peer.Get(types.PeerStateKey).(*PeerState)
+
+	// nothing to serve yet
+	conR.respondCommitRequest(peer, height)
+	require.Empty(t, peer.sent)
+
+	// the commit is only requested from the peers supporting it
+	rs := cs.GetRoundState()
+	prs := &cstypes.PeerRoundState{Height: height + 1}
+	conR.requestCatchupCommit(peer, ps, rs, prs)
+	require.Empty(t, peer.sent)
+	peer.dataChannelVersion = DataChannelVersion
+	conR.requestCatchupCommit(peer, ps, rs, prs)
+	require.Len(t, peer.sent, 1)
+	assert.Equal(t, DataChannel, peer.sent[0].ChannelID)
+	assert.Equal(t, &cmtcons.CommitRequest{Height: height}, peer.sent[0].Message)
+
+	blockID := types.BlockID{Hash: cmtrand.Bytes(32), PartSetHeader: types.PartSetHeader{Total: 1, Hash: cmtrand.Bytes(32)}}
+	voteSet := types.NewVoteSet(cs.state.ChainID, height, round, cmtproto.PrecommitType, cs.Validators)
+	for _, vote := range signVotes(cmtproto.PrecommitType, blockID.Hash, blockID.PartSetHeader, vss[1:]...) {
+		added, err := voteSet.AddVote(vote)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	commit := voteSet.MakeCommit()
+
+	// a commit for another height is ignored
+	otherCommit := *commit
+	otherCommit.Height = height + 1
+	conR.handleCommitResponse(ps, peer.ID(), &otherCommit)
+	require.Empty(t, cs.peerMsgQueue)
+
+	conR.handleCommitResponse(ps, peer.ID(), commit)
+	require.Len(t, cs.peerMsgQueue, 3)
+	for i := 0; i < 3; i++ {
+		mi := <-cs.peerMsgQueue
+		vote := mi.Msg.(*VoteMessage).Vote
+		assert.Equal(t, cmtproto.PrecommitType, vote.Type)
+		assert.Equal(t, height, vote.Height)
+		assert.Equal(t, blockID, vote.BlockID)
+		assert.Equal(t, peer.ID(), mi.PeerID)
+	}
+}
+
 func TestReactorReceivePanicsIfInitPeerHasntBeenCalledYet(t *testing.T) {
 	N := 1
 	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newKVStore)
@@ -956,6 +1067,45 @@ func TestVoteSetMaj23MessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestVoteBatchMessageValidateBasic(t *testing.T) {
+	vote := &types.Vote{
+		ValidatorAddress: make([]byte, 20),
+		ValidatorIndex:   1,
+		Height:           1,
+		Round:            0,
+		Type:             cmtproto.PrevoteType,
+		Timestamp:        time.Now(),
+		BlockID:          types.BlockID{},
+		Signature:        []byte{1},
+	}
+	invalidVote := *vote
+	invalidVote.Height = -1
+
+	tooMany := make([]*types.Vote, maxVoteBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = vote
+	}
+
+	testCases := []struct {
+		testName  string
+		votes     []*types.Vote
+		expectErr bool
+	}{
+		{"Valid Batch", []*types.Vote{vote, vote}, false},
+		{"Empty Batch", nil, true},
+		{"Too Many Votes", tooMany, true},
+		{"Invalid Vote", []*types.Vote{vote, &invalidVote}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			msg := &VoteBatchMessage{Votes: tc.votes}
+			assert.Equal(t, tc.expectErr, msg.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestVoteSetBitsMessageValidateBasic(t *testing.T) {
 	testCases := []struct {
 		malleateFn func(*VoteSetBitsMessage)
@@ -205,6 +205,10 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// maximum size of the app_state sent in each InitChain request, 0 to
+	// send it in a single request
+	initChainChunkSize int
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -232,6 +236,14 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetInitChainChunkSize sets the maximum size of the app_state sent in each
+// InitChain request. A larger app_state is split into chunks sent in several
+// requests, see initChain. If not called, or if size is 0, the app_state is
+// sent in a single request.
+func (h *Handshaker) SetInitChainChunkSize(size int) {
+	h.initChainChunkSize = size
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -316,7 +328,7 @@ func (h *Handshaker) ReplayBlocks(
 			Validators:      nextVals,
 			AppStateBytes:   h.genDoc.AppState,
 		}
-		res, err := proxyApp.Consensus().InitChainSync(req)
+		res, err := h.initChain(proxyApp.Consensus(), req)
 		if err != nil {
 			return nil, err
 		}
@@ -434,6 +446,41 @@ func (h *Handshaker) ReplayBlocks(
 		appBlockHeight, storeBlockHeight, stateBlockHeight))
 }
 
+// initChain sends the InitChain request to the app. If the app_state is larger
+// than the chunk size, it is split into chunks sent in consecutive requests,
+// which only differ by their AppStateBytes and AppStateChunkIndex, and carry
+// the number of chunks in AppStateChunks. The app must return an empty
+// response to all the requests but the last one, whose response is the one of
+// the whole InitChain.
+func (h *Handshaker) initChain(
+	proxyApp proxy.AppConnConsensus,
+	req abci.RequestInitChain,
+) (*abci.ResponseInitChain, error) {
+	appState := req.AppStateBytes
+	size := h.initChainChunkSize
+	if size <= 0 || len(appState) <= size {
+		return proxyApp.InitChainSync(req)
+	}
+
+	chunks := (len(appState) + size - 1) / size
+	h.logger.Info("Sending the app_state to InitChain in chunks", "size", len(appState), "chunks", chunks)
+	req.AppStateChunks = uint32(chunks)
+	for i := 0; i < chunks-1; i++ {
+		req.AppStateBytes = appState[i*size : (i+1)*size]
+		req.AppStateChunkIndex = uint32(i)
+		res, err := proxyApp.InitChainSync(req)
+		if err != nil {
+			return nil, err
+		}
+		if res.Size() != 0 {
+			return nil, fmt.Errorf("got a non-empty InitChain response for app_state chunk %d of %d", i, chunks)
+		}
+	}
+	req.AppStateBytes = appState[(chunks-1)*size:]
+	req.AppStateChunkIndex = uint32(chunks - 1)
+	return proxyApp.InitChainSync(req)
+}
+
 func (h *Handshaker) replayBlocks(
 	state sm.State,
 	proxyApp proxy.AppConns,
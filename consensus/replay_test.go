@@ -1201,6 +1201,7 @@ func (bs *mockBlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	return bs.chain[int64(len(bs.chain))-1]
 }
 func (bs *mockBlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta { return nil }
+func (bs *mockBlockStore) LoadBlockHeightByHash(hash []byte) int64          { return 0 }
 func (bs *mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	block := bs.chain[height-1]
 	bps, err := block.MakePartSet(types.BlockPartSizeBytes)
@@ -1294,3 +1295,64 @@ func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitC
 		Validators: ica.vals,
 	}
 }
+
+func TestHandshakeSendsAppStateInChunks(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	defer os.RemoveAll(config.RootDir)
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	genDoc, err := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+	genDoc.AppState = []byte(`{"accounts":["foo","bar"]}`)
+
+	for _, tc := range []struct {
+		name      string
+		chunkSize int
+		chunks    int
+	}{
+		{"disabled", 0, 1},
+		{"larger than the app_state", 100, 1},
+		{"chunked", 10, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			app := &chunkedInitChainApp{}
+			stateDB, state, store := stateAndStore(t, config, pubKey, 0x0)
+			stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), proxy.NopMetrics())
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			handshaker := NewHandshaker(stateStore, state, store, genDoc)
+			handshaker.SetInitChainChunkSize(tc.chunkSize)
+			require.NoError(t, handshaker.Handshake(proxyApp))
+
+			assert.Len(t, app.requests, tc.chunks)
+			assert.EqualValues(t, genDoc.AppState, app.appState)
+			state, err = stateStore.Load()
+			require.NoError(t, err)
+			assert.EqualValues(t, app.appState, state.AppHash)
+		})
+	}
+}
+
+// gathers the app_state chunks on InitChain, and returns the app_state as app
+// hash
+type chunkedInitChainApp struct {
+	abci.BaseApplication
+	requests []abci.RequestInitChain
+	appState []byte
+}
+
+func (app *chunkedInitChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	app.requests = append(app.requests, req)
+	app.appState = append(app.appState, req.AppStateBytes...)
+	if req.AppStateChunks > 0 && req.AppStateChunkIndex < req.AppStateChunks-1 {
+		return abci.ResponseInitChain{}
+	}
+	return abci.ResponseInitChain{AppHash: app.appState}
+}
@@ -0,0 +1,290 @@
+package consensus
+
+import (
+	"sort"
+	"time"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// roundHistoryHeights is the number of heights kept in the round history.
+const roundHistoryHeights = 100
+
+// HeightRoundHistory is the timing of the rounds of a committed height, as
+// observed by this node.
+type HeightRoundHistory struct {
+	Height int64          `json:"height"`
+	Rounds []RoundHistory `json:"rounds"`
+}
+
+// RoundHistory is the timing of a round.
+type RoundHistory struct {
+	Round     int32         `json:"round"`
+	Proposer  types.Address `json:"proposer"`
+	StartTime time.Time     `json:"start_time"`
+	// Steps taken by this node in the round, in order, with the time spent
+	// in each of them.
+	Steps      []StepHistory `json:"steps"`
+	Prevotes   VoteArrivals  `json:"prevotes"`
+	Precommits VoteArrivals  `json:"precommits"`
+	// Validators whose prevote or precommit was received after +2/3 of the
+	// voting power had voted, or not at all.
+	LateValidators []types.Address `json:"late_validators"`
+}
+
+// StepHistory is the time spent in a step of a round.
+type StepHistory struct {
+	Step     string        `json:"step"`
+	Duration time.Duration `json:"duration"`
+}
+
+// VoteArrivals is the distribution of the arrival times of the votes of one
+// type in a round. Delays are relative to the start of the round and negative
+// for votes received before this node entered the round.
+type VoteArrivals struct {
+	// Delay after which +2/3 of the voting power had voted, zero if it was
+	// never reached.
+	QuorumDelay time.Duration `json:"quorum_delay"`
+	Votes       []VoteArrival `json:"votes"`
+}
+
+// VoteArrival is the arrival time of a single vote.
+type VoteArrival struct {
+	ValidatorAddress types.Address `json:"validator_address"`
+	Delay            time.Duration `json:"delay"`
+	Late             bool          `json:"late"`
+}
+
+// roundHistory records the timing of the rounds of the last heights. It has
+// its own lock so that it can be read without blocking the state machine.
+type roundHistory struct {
+	mtx      cmtsync.Mutex
+	heights  []*heightRecord // oldest first, the last one is the current height
+	lastStep *stepRecord
+}
+
+type heightRecord struct {
+	height     int64
+	validators []*types.Validator
+	totalPower int64
+	rounds     map[int32]*roundRecord
+}
+
+type roundRecord struct {
+	proposer   types.Address
+	startTime  time.Time
+	steps      []*stepRecord
+	prevotes   map[int32]time.Time // by validator index
+	precommits map[int32]time.Time // by validator index
+}
+
+type stepRecord struct {
+	step     cstypes.RoundStepType
+	start    time.Time
+	duration time.Duration
+}
+
+func newRoundHistory() *roundHistory {
+	return &roundHistory{}
+}
+
+// newHeight starts recording a new height, dropping the oldest one if needed.
+func (h *roundHistory) newHeight(height int64, vals *types.ValidatorSet) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if n := len(h.heights); n > 0 && h.heights[n-1].height == height {
+		return
+	}
+	if len(h.heights) == roundHistoryHeights {
+		h.heights = h.heights[1:]
+	}
+	hr := &heightRecord{
+		height: height,
+		rounds: make(map[int32]*roundRecord),
+	}
+	if vals != nil {
+		hr.validators = vals.Copy().Validators
+		hr.totalPower = vals.TotalVotingPower()
+	}
+	h.heights = append(h.heights, hr)
+}
+
+// recordStep records that the node entered the step of the round at the
+// given time, ending the previous step.
+func (h *roundHistory) recordStep(height int64, round int32, step cstypes.RoundStepType, now time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	r := h.round(height, round, now)
+	if r == nil {
+		return
+	}
+	if n := len(r.steps); n > 0 && r.steps[n-1].step == step {
+		return
+	}
+	if h.lastStep != nil {
+		h.lastStep.duration = now.Sub(h.lastStep.start)
+	}
+	if step == cstypes.RoundStepNewRound {
+		r.startTime = now
+	}
+	h.lastStep = &stepRecord{step: step, start: now}
+	r.steps = append(r.steps, h.lastStep)
+}
+
+// recordProposer records the proposer of the round.
+func (h *roundHistory) recordProposer(height int64, round int32, proposer types.Address, now time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if r := h.round(height, round, now); r != nil {
+		r.proposer = proposer
+	}
+}
+
+// recordVote records the arrival time of the vote. Only the first vote of
+// each validator is recorded.
+func (h *roundHistory) recordVote(vote *types.Vote, now time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	r := h.round(vote.Height, vote.Round, now)
+	if r == nil {
+		return
+	}
+	arrivals := r.prevotes
+	if vote.Type == cmtproto.PrecommitType {
+		arrivals = r.precommits
+	}
+	if _, ok := arrivals[vote.ValidatorIndex]; !ok {
+		arrivals[vote.ValidatorIndex] = now
+	}
+}
+
+// round returns the record of the round, creating it if needed, or nil if the
+// height is not recorded.
+func (h *roundHistory) round(height int64, round int32, now time.Time) *roundRecord {
+	for i := len(h.heights) - 1; i >= 0; i-- {
+		hr := h.heights[i]
+		if hr.height != height {
+			continue
+		}
+		r, ok := hr.rounds[round]
+		if !ok {
+			r = &roundRecord{
+				startTime:  now,
+				prevotes:   make(map[int32]time.Time),
+				precommits: make(map[int32]time.Time),
+			}
+			hr.rounds[round] = r
+		}
+		return r
+	}
+	return nil
+}
+
+// history returns the round history of the last committed heights, at most
+// limit of them, the most recent first.
+func (h *roundHistory) history(limit int) []HeightRoundHistory {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	res := make([]HeightRoundHistory, 0, limit)
+	// the last height is still in progress
+	for i := len(h.heights) - 2; i >= 0 && len(res) < limit; i-- {
+		res = append(res, h.heights[i].history())
+	}
+	return res
+}
+
+func (hr *heightRecord) history() HeightRoundHistory {
+	rounds := make([]int32, 0, len(hr.rounds))
+	for round := range hr.rounds {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+
+	res := HeightRoundHistory{
+		Height: hr.height,
+		Rounds: make([]RoundHistory, 0, len(rounds)),
+	}
+	for _, round := range rounds {
+		r := hr.rounds[round]
+		rh := RoundHistory{
+			Round:     round,
+			Proposer:  r.proposer,
+			StartTime: r.startTime,
+			Steps:     make([]StepHistory, len(r.steps)),
+		}
+		for i, s := range r.steps {
+			rh.Steps[i] = StepHistory{Step: s.step.String(), Duration: s.duration}
+		}
+
+		late := make(map[int32]bool)
+		rh.Prevotes = hr.voteArrivals(r.startTime, r.prevotes, late)
+		rh.Precommits = hr.voteArrivals(r.startTime, r.precommits, late)
+		for idx, val := range hr.validators {
+			if late[int32(idx)] {
+				rh.LateValidators = append(rh.LateValidators, val.Address)
+			}
+		}
+		res.Rounds = append(res.Rounds, rh)
+	}
+	return res
+}
+
+// voteArrivals returns the arrival times of the votes in order. Validators
+// that voted after +2/3 of the voting power, or did not vote although +2/3
+// did, are added to late.
+func (hr *heightRecord) voteArrivals(
+	startTime time.Time,
+	arrivals map[int32]time.Time,
+	late map[int32]bool,
+) VoteArrivals {
+	indexes := make([]int32, 0, len(arrivals))
+	for idx := range arrivals {
+		if int(idx) < len(hr.validators) {
+			indexes = append(indexes, idx)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return arrivals[indexes[i]].Before(arrivals[indexes[j]])
+	})
+
+	var (
+		res      VoteArrivals
+		power    int64
+		quorumAt time.Time
+	)
+	for _, idx := range indexes {
+		at := arrivals[idx]
+		isLate := !quorumAt.IsZero() && at.After(quorumAt)
+		if isLate {
+			late[idx] = true
+		}
+		res.Votes = append(res.Votes, VoteArrival{
+			ValidatorAddress: hr.validators[idx].Address,
+			Delay:            at.Sub(startTime),
+			Late:             isLate,
+		})
+
+		power += hr.validators[idx].VotingPower
+		if quorumAt.IsZero() && power*3 > hr.totalPower*2 {
+			quorumAt = at
+			res.QuorumDelay = at.Sub(startTime)
+		}
+	}
+
+	if !quorumAt.IsZero() {
+		for idx := range hr.validators {
+			if _, ok := arrivals[int32(idx)]; !ok {
+				late[int32(idx)] = true
+			}
+		}
+	}
+	return res
+}
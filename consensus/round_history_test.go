@@ -0,0 +1,76 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestRoundHistory(t *testing.T) {
+	vals, _ := types.RandValidatorSet(4, 10)
+	h := newRoundHistory()
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	vote := func(idx int32, voteType cmtproto.SignedMsgType) *types.Vote {
+		return &types.Vote{Height: 1, Round: 0, Type: voteType, ValidatorIndex: idx}
+	}
+
+	h.newHeight(1, vals)
+	h.recordStep(1, 0, cstypes.RoundStepNewHeight, at(0))
+	h.recordStep(1, 0, cstypes.RoundStepNewRound, at(10))
+	h.recordProposer(1, 0, vals.Validators[0].Address, at(10))
+	h.recordStep(1, 0, cstypes.RoundStepPropose, at(10))
+	h.recordStep(1, 0, cstypes.RoundStepPrevote, at(30))
+	h.recordVote(vote(0, cmtproto.PrevoteType), at(30))
+	h.recordVote(vote(1, cmtproto.PrevoteType), at(35))
+	h.recordVote(vote(2, cmtproto.PrevoteType), at(40))
+	h.recordVote(vote(3, cmtproto.PrevoteType), at(90))
+	h.recordVote(vote(3, cmtproto.PrevoteType), at(20)) // duplicate, ignored
+	h.recordStep(1, 0, cstypes.RoundStepPrecommit, at(40))
+	h.recordVote(vote(0, cmtproto.PrecommitType), at(45))
+	h.recordVote(vote(1, cmtproto.PrecommitType), at(50))
+	h.recordVote(vote(2, cmtproto.PrecommitType), at(55))
+	h.recordStep(1, 0, cstypes.RoundStepCommit, at(55))
+
+	// the current height is not returned
+	assert.Empty(t, h.history(10))
+
+	h.newHeight(2, vals)
+	h.recordStep(2, 0, cstypes.RoundStepNewHeight, at(60))
+
+	history := h.history(10)
+	require.Len(t, history, 1)
+	require.EqualValues(t, 1, history[0].Height)
+	require.Len(t, history[0].Rounds, 1)
+
+	round := history[0].Rounds[0]
+	assert.Equal(t, vals.Validators[0].Address, round.Proposer)
+	assert.Equal(t, at(10), round.StartTime)
+	assert.Equal(t, []StepHistory{
+		{Step: "RoundStepNewHeight", Duration: 10 * time.Millisecond},
+		{Step: "RoundStepNewRound", Duration: 0},
+		{Step: "RoundStepPropose", Duration: 20 * time.Millisecond},
+		{Step: "RoundStepPrevote", Duration: 10 * time.Millisecond},
+		{Step: "RoundStepPrecommit", Duration: 15 * time.Millisecond},
+		{Step: "RoundStepCommit", Duration: 5 * time.Millisecond},
+	}, round.Steps)
+
+	assert.Equal(t, 30*time.Millisecond, round.Prevotes.QuorumDelay)
+	require.Len(t, round.Prevotes.Votes, 4)
+	assert.Equal(t, VoteArrival{
+		ValidatorAddress: vals.Validators[3].Address,
+		Delay:            80 * time.Millisecond,
+		Late:             true,
+	}, round.Prevotes.Votes[3])
+	assert.Equal(t, 45*time.Millisecond, round.Precommits.QuorumDelay)
+	require.Len(t, round.Precommits.Votes, 3)
+
+	// late prevote and missing precommit
+	assert.Equal(t, []types.Address{vals.Validators[3].Address}, round.LateValidators)
+}
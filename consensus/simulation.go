@@ -0,0 +1,528 @@
+package consensus
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/proxy"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+// simulationGenesisTime is the genesis time of the simulated chains, at which
+// the virtual clock starts.
+var simulationGenesisTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SimulationConfig is the configuration of a Simulation.
+type SimulationConfig struct {
+	// Validators is the number of validators, with the same voting power.
+	Validators int
+	// Seed seeds the randomness of the simulation: the keys of the validators
+	// and the latencies of the messages.
+	Seed int64
+	// MinLatency and MaxLatency bound the latency of the messages, drawn
+	// uniformly between them.
+	MinLatency, MaxLatency time.Duration
+	// GossipInterval is the interval at which each node sends again its
+	// messages of the current height to the other nodes, and the blocks and
+	// commits of the heights they are missing, like the gossip routines of
+	// the reactor. 100ms if 0.
+	GossipInterval time.Duration
+	// Rules drop or delay the messages, see DeliveryRule.
+	Rules []DeliveryRule
+
+	// Consensus is the config of the consensus of the nodes,
+	// cfg.TestConsensusConfig() if nil.
+	Consensus *cfg.ConsensusConfig
+	// App returns the app of the i-th node, a kvstore app if nil. The apps
+	// must be deterministic for the simulation to be.
+	App func(i int) abci.Application
+	// Options returns the options of the State of the i-th node, if not nil,
+	// e.g. to test a change of the protocol on some of the nodes.
+	Options func(i int) []StateOption
+	// Logger is the logger of the nodes, which discards the logs if nil.
+	Logger log.Logger
+}
+
+// DeliveryRule drops or delays the messages sent by a node to another during
+// a time window of a simulation. All the rules matching a message apply: it is
+// dropped if one of them drops it, and delayed by the sum of their delays
+// otherwise.
+type DeliveryRule struct {
+	// From and To are the indexes of the sending and receiving nodes, or -1
+	// for all of them.
+	From, To int
+	// Start and End bound the time window during which the rule applies to
+	// the messages sent, from the start of the simulation. The rule applies
+	// until the end of the simulation if End is 0.
+	Start, End time.Duration
+	// Match restricts the rule to the messages for which it returns true, if
+	// not nil.
+	Match func(msg Message) bool
+
+	// Drop drops the messages.
+	Drop bool
+	// Delay is added to the latency of the messages.
+	Delay time.Duration
+}
+
+func (r DeliveryRule) matches(from, to int, elapsed time.Duration, msg Message) bool {
+	return (r.From < 0 || r.From == from) &&
+		(r.To < 0 || r.To == to) &&
+		elapsed >= r.Start && (r.End == 0 || elapsed < r.End) &&
+		(r.Match == nil || r.Match(msg))
+}
+
+// Simulation runs the consensus state machines of several validators in a
+// single goroutine, with a virtual clock, and delivers their messages to each
+// other after a random latency, according to the delivery rules. Given the
+// same config, a simulation makes the same decisions at the same virtual
+// times, so it can test the changes of the protocol, e.g. on a fork, without
+// running a network.
+//
+// While running, a simulation checks that the nodes never commit different
+// blocks at the same height, and that they all decide up to the given height
+// in time.
+type Simulation struct {
+	config SimulationConfig
+	nodes  []*simNode
+	rng    *rand.Rand
+
+	now    time.Time
+	events simEventQueue
+	seq    uint64 // sequence number of the last event, to order the events deterministically
+
+	// the blocks committed at each height
+	decisions map[int64]types.BlockID
+}
+
+type simNode struct {
+	index      int
+	peerID     p2p.ID
+	cs         *State
+	blockStore *store.BlockStore
+	proxyApp   proxy.AppConns
+	eventBus   *types.EventBus
+	ticker     *simTicker
+
+	// the messages of the node, of the current height once pruned by gossip,
+	// which sends them again
+	sent []Message
+	// the last height checked against the decisions
+	checkedHeight int64
+}
+
+// NewSimulation creates the nodes of a simulation.
+func NewSimulation(config SimulationConfig) (*Simulation, error) {
+	if config.Validators <= 0 {
+		return nil, errors.New("a simulation needs at least one validator")
+	}
+	if config.MinLatency < 0 || config.MaxLatency < config.MinLatency {
+		return nil, fmt.Errorf("invalid latency range [%v, %v]", config.MinLatency, config.MaxLatency)
+	}
+	if config.GossipInterval == 0 {
+		config.GossipInterval = 100 * time.Millisecond
+	}
+	if config.Consensus == nil {
+		config.Consensus = cfg.TestConsensusConfig()
+	}
+	if config.App == nil {
+		config.App = func(int) abci.Application { return kvstore.NewApplication() }
+	}
+	if config.Logger == nil {
+		config.Logger = log.NewNopLogger()
+	}
+
+	s := &Simulation{
+		config:    config,
+		rng:       rand.New(rand.NewSource(config.Seed)), //nolint:gosec
+		now:       simulationGenesisTime,
+		decisions: make(map[int64]types.BlockID),
+	}
+
+	pvs := make([]types.PrivValidator, config.Validators)
+	genDoc := &types.GenesisDoc{
+		ChainID:         "simulation",
+		GenesisTime:     simulationGenesisTime,
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators:      make([]types.GenesisValidator, config.Validators),
+	}
+	for i := range pvs {
+		privKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("%d/%d", config.Seed, i)))
+		pvs[i] = types.NewMockPVWithParams(privKey, false, false)
+		genDoc.Validators[i] = types.GenesisValidator{PubKey: privKey.PubKey(), Power: 10}
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	for i, pv := range pvs {
+		node, err := s.newNode(i, genDoc, pv)
+		if node != nil {
+			s.nodes = append(s.nodes, node)
+		}
+		if err != nil {
+			s.Stop()
+			return nil, fmt.Errorf("failed to create node %d: %w", i, err)
+		}
+	}
+	for _, node := range s.nodes {
+		node.cs.scheduleRound0(&node.cs.RoundState)
+		s.schedule(s.now.Add(config.GossipInterval), simEvent{node: node.index, gossip: true})
+	}
+	return s, nil
+}
+
+// newNode creates the i-th node. The node is returned even on error once its
+// services are started, to be stopped.
+func (s *Simulation) newNode(i int, genDoc *types.GenesisDoc, pv types.PrivValidator) (*simNode, error) {
+	logger := s.config.Logger.With("validator", i)
+	node := &simNode{
+		index:      i,
+		peerID:     p2p.ID(fmt.Sprintf("node%d", i)),
+		blockStore: store.NewBlockStore(dbm.NewMemDB()),
+		proxyApp:   proxy.NewAppConns(proxy.NewLocalClientCreator(s.config.App(i)), proxy.NopMetrics()),
+		eventBus:   types.NewEventBus(),
+	}
+	node.proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := node.proxyApp.Start(); err != nil {
+		return node, err
+	}
+	node.eventBus.SetLogger(logger.With("module", "events"))
+	if err := node.eventBus.Start(); err != nil {
+		return node, err
+	}
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return node, err
+	}
+	if err := stateStore.Save(state); err != nil {
+		return node, err
+	}
+	handshaker := NewHandshaker(stateStore, state, node.blockStore, genDoc)
+	handshaker.SetLogger(logger)
+	if err := handshaker.Handshake(node.proxyApp); err != nil {
+		return node, err
+	}
+	if state, err = stateStore.Load(); err != nil {
+		return node, err
+	}
+
+	mempool := emptyMempool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, node.proxyApp.Consensus(), mempool, sm.EmptyEvidencePool{}, node.blockStore)
+	options := []StateOption{func(cs *State) { cs.now = func() time.Time { return s.now } }}
+	if s.config.Options != nil {
+		options = append(options, s.config.Options(i)...)
+	}
+	node.cs = NewState(s.config.Consensus, state, blockExec, node.blockStore, mempool, sm.EmptyEvidencePool{}, options...)
+	node.ticker = &simTicker{sim: s, node: i}
+	node.cs.SetTimeoutTicker(node.ticker)
+	node.cs.SetLogger(logger)
+	node.cs.SetEventBus(node.eventBus)
+	node.cs.SetPrivValidator(pv)
+	return node, nil
+}
+
+// Stop stops the apps of the nodes.
+func (s *Simulation) Stop() {
+	for _, node := range s.nodes {
+		_ = node.eventBus.Stop()
+		_ = node.proxyApp.Stop()
+	}
+}
+
+// Now returns the virtual time of the simulation.
+func (s *Simulation) Now() time.Time {
+	return s.now
+}
+
+// States returns the consensus states of the nodes, e.g. to check the blocks
+// they committed.
+func (s *Simulation) States() []*State {
+	states := make([]*State, len(s.nodes))
+	for i, node := range s.nodes {
+		states[i] = node.cs
+	}
+	return states
+}
+
+// Run runs the simulation until all the nodes committed the given height, and
+// returns an error if two nodes committed different blocks at the same height,
+// or if a node didn't commit the height within timeout of virtual time.
+func (s *Simulation) Run(height int64, timeout time.Duration) error {
+	deadline := s.now.Add(timeout)
+	for !s.decided(height) {
+		if s.events.Len() == 0 || s.events[0].time.After(deadline) {
+			for _, node := range s.nodes {
+				if h := node.blockStore.Height(); h < height {
+					return fmt.Errorf("node %d only committed up to height %d after %v", node.index, h, timeout)
+				}
+			}
+		}
+		ev := heap.Pop(&s.events).(*simEvent)
+		if ev.canceled {
+			continue
+		}
+		s.now = ev.time
+		if err := s.process(ev); err != nil {
+			return err
+		}
+		if err := s.checkDecisions(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Simulation) decided(height int64) bool {
+	for _, node := range s.nodes {
+		if node.blockStore.Height() < height {
+			return false
+		}
+	}
+	return true
+}
+
+// process processes the event, and then all the messages of the nodes to
+// themselves, sending them to the other nodes.
+func (s *Simulation) process(ev *simEvent) (err error) {
+	node := s.nodes[ev.node]
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("consensus failure of node %d: %v", node.index, r)
+		}
+	}()
+
+	switch {
+	case ev.gossip:
+		s.gossip(node)
+		s.schedule(s.now.Add(s.config.GossipInterval), simEvent{node: node.index, gossip: true})
+	case ev.msg != nil:
+		node.cs.handleMsg(msgInfo{ev.msg, s.nodes[ev.from].peerID})
+	default:
+		node.ticker.fired(ev)
+		node.cs.handleTimeout(ev.timeout, node.cs.RoundState)
+	}
+
+	for sent := true; sent; {
+		sent = false
+		for _, node := range s.nodes {
+			for len(node.cs.internalMsgQueue) > 0 {
+				mi := <-node.cs.internalMsgQueue
+				node.cs.handleMsg(mi)
+				s.broadcast(node, mi.Msg)
+				sent = true
+			}
+			for len(node.cs.statsMsgQueue) > 0 {
+				<-node.cs.statsMsgQueue
+			}
+		}
+	}
+	return nil
+}
+
+// broadcast sends a message of the node to all the other nodes.
+func (s *Simulation) broadcast(node *simNode, msg Message) {
+	node.sent = append(node.sent, msg)
+	for _, peer := range s.nodes {
+		if peer != node {
+			s.send(node, peer, msg)
+		}
+	}
+}
+
+// gossip sends again the messages of the current height of the node to the
+// other nodes, and to the ones which are behind, the block and commit of their
+// height.
+func (s *Simulation) gossip(node *simNode) {
+	sent := node.sent[:0]
+	for _, msg := range node.sent {
+		if messageHeight(msg) == node.cs.Height {
+			sent = append(sent, msg)
+		}
+	}
+	node.sent = sent
+
+	for _, peer := range s.nodes {
+		if peer == node {
+			continue
+		}
+		for _, msg := range node.sent {
+			s.send(node, peer, msg)
+		}
+		if height := peer.cs.Height; height < node.cs.Height {
+			s.sendBlock(node, peer, height)
+		}
+	}
+}
+
+// sendBlock sends the precommits of the commit of the block of the height,
+// and the parts of the block, like the reactor does to the peers catching up.
+func (s *Simulation) sendBlock(node, peer *simNode, height int64) {
+	commit := node.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		commit = node.blockStore.LoadSeenCommit(height)
+	}
+	meta := node.blockStore.LoadBlockMeta(height)
+	if commit == nil || meta == nil {
+		return
+	}
+	for i, sig := range commit.Signatures {
+		if !sig.Absent() {
+			s.send(node, peer, &VoteMessage{commit.GetVote(int32(i))})
+		}
+	}
+	for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+		s.send(node, peer, &BlockPartMessage{Height: height, Round: commit.Round, Part: node.blockStore.LoadBlockPart(height, i)})
+	}
+}
+
+// send schedules the delivery of the message to the peer, unless a rule drops
+// it.
+func (s *Simulation) send(node, peer *simNode, msg Message) {
+	latency := s.config.MinLatency
+	if d := s.config.MaxLatency - s.config.MinLatency; d > 0 {
+		latency += time.Duration(s.rng.Int63n(int64(d) + 1))
+	}
+	elapsed := s.now.Sub(simulationGenesisTime)
+	for _, rule := range s.config.Rules {
+		if !rule.matches(node.index, peer.index, elapsed, msg) {
+			continue
+		}
+		if rule.Drop {
+			return
+		}
+		latency += rule.Delay
+	}
+	s.schedule(s.now.Add(latency), simEvent{node: peer.index, from: node.index, msg: msg})
+}
+
+// checkDecisions checks the blocks committed by the nodes since the last
+// check against the ones committed by the other nodes.
+func (s *Simulation) checkDecisions() error {
+	for _, node := range s.nodes {
+		for ; node.checkedHeight < node.blockStore.Height(); node.checkedHeight++ {
+			height := node.checkedHeight + 1
+			blockID := node.blockStore.LoadBlockMeta(height).BlockID
+			decision, ok := s.decisions[height]
+			if !ok {
+				s.decisions[height] = blockID
+				continue
+			}
+			if !decision.Equals(blockID) {
+				return fmt.Errorf("node %d committed block %v at height %d, but another node committed %v",
+					node.index, blockID, height, decision)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Simulation) schedule(t time.Time, ev simEvent) *simEvent {
+	s.seq++
+	ev.time, ev.seq = t, s.seq
+	heap.Push(&s.events, &ev)
+	return &ev
+}
+
+func messageHeight(msg Message) int64 {
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		return msg.Proposal.Height
+	case *BlockPartMessage:
+		return msg.Height
+	case *VoteMessage:
+		return msg.Vote.Height
+	}
+	return 0
+}
+
+// simEvent is the delivery of a message, a timeout or a gossip round of a
+// node.
+type simEvent struct {
+	time time.Time
+	seq  uint64
+	node int
+
+	// delivery
+	from int
+	msg  Message
+
+	// timeout
+	timeout  timeoutInfo
+	canceled bool
+
+	gossip bool
+}
+
+// simEventQueue implements heap.Interface, ordering the events by time and
+// then by the order they were scheduled.
+type simEventQueue []*simEvent
+
+func (q simEventQueue) Len() int { return len(q) }
+
+func (q simEventQueue) Less(i, j int) bool {
+	if !q[i].time.Equal(q[j].time) {
+		return q[i].time.Before(q[j].time)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q simEventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *simEventQueue) Push(x interface{}) { *q = append(*q, x.(*simEvent)) }
+
+func (q *simEventQueue) Pop() interface{} {
+	old := *q
+	ev := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ev
+}
+
+// simTicker implements TimeoutTicker on the virtual clock of a simulation. Like
+// timeoutTicker, it only schedules the timeouts for a greater height, round or
+// step than the last one, which replace it.
+type simTicker struct {
+	sim     *Simulation
+	node    int
+	last    timeoutInfo
+	pending *simEvent
+}
+
+var _ TimeoutTicker = (*simTicker)(nil)
+
+func (t *simTicker) Start() error             { return nil }
+func (t *simTicker) Stop() error              { return nil }
+func (t *simTicker) Chan() <-chan timeoutInfo { return nil }
+func (t *simTicker) SetLogger(log.Logger)     {}
+
+func (t *simTicker) ScheduleTimeout(ti timeoutInfo) {
+	if !ti.supersedes(t.last) {
+		return
+	}
+	if t.pending != nil {
+		t.pending.canceled = true
+	}
+	t.last = ti
+	t.pending = t.sim.schedule(t.sim.now.Add(ti.Duration), simEvent{node: t.node, timeout: ti})
+}
+
+func (t *simTicker) fired(ev *simEvent) {
+	if t.pending == ev {
+		t.pending = nil
+	}
+}
@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+func runSimulation(t *testing.T, config SimulationConfig, height int64, timeout time.Duration) (*Simulation, error) {
+	s, err := NewSimulation(config)
+	require.NoError(t, err)
+	t.Cleanup(s.Stop)
+	return s, s.Run(height, timeout)
+}
+
+func TestSimulationDecides(t *testing.T) {
+	s, err := runSimulation(t, SimulationConfig{
+		Validators: 4,
+		Seed:       1,
+		MinLatency: 5 * time.Millisecond,
+		MaxLatency: 50 * time.Millisecond,
+	}, 5, time.Minute)
+	require.NoError(t, err)
+
+	for _, cs := range s.States() {
+		assert.GreaterOrEqual(t, cs.blockStore.Height(), int64(5))
+	}
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	config := SimulationConfig{
+		Validators: 4,
+		Seed:       2,
+		MinLatency: time.Millisecond,
+		MaxLatency: 100 * time.Millisecond,
+	}
+	s1, err := runSimulation(t, config, 5, time.Minute)
+	require.NoError(t, err)
+	s2, err := runSimulation(t, config, 5, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, s1.Now(), s2.Now())
+	for h := int64(1); h <= 5; h++ {
+		assert.Equal(t,
+			s1.States()[0].blockStore.LoadBlockMeta(h).BlockID,
+			s2.States()[0].blockStore.LoadBlockMeta(h).BlockID,
+			"height %d", h)
+	}
+}
+
+func TestSimulationRecoversFromPartition(t *testing.T) {
+	// the nodes are split in two halves without a quorum for 5s, and the
+	// proposals to node 3 are dropped for 10s
+	partition := func(from, to int) DeliveryRule {
+		return DeliveryRule{From: from, To: to, End: 5 * time.Second, Drop: true}
+	}
+	_, err := runSimulation(t, SimulationConfig{
+		Validators: 4,
+		Seed:       3,
+		MinLatency: 5 * time.Millisecond,
+		MaxLatency: 20 * time.Millisecond,
+		Rules: []DeliveryRule{
+			partition(0, 2), partition(0, 3), partition(1, 2), partition(1, 3),
+			partition(2, 0), partition(2, 1), partition(3, 0), partition(3, 1),
+			{From: -1, To: 3, End: 10 * time.Second, Drop: true, Match: func(msg Message) bool {
+				_, ok := msg.(*ProposalMessage)
+				return ok
+			}},
+		},
+	}, 5, time.Minute)
+	require.NoError(t, err)
+}
+
+func TestSimulationDetectsNoDecision(t *testing.T) {
+	// the precommits are all dropped, so no block is committed
+	_, err := runSimulation(t, SimulationConfig{
+		Validators: 4,
+		Seed:       4,
+		Rules: []DeliveryRule{{From: -1, To: -1, Drop: true, Match: func(msg Message) bool {
+			vote, ok := msg.(*VoteMessage)
+			return ok && vote.Vote.Type == cmtproto.PrecommitType
+		}}},
+	}, 1, 10*time.Second)
+	require.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	cfg "github.com/cometbft/cometbft/config"
 	cstypes "github.com/cometbft/cometbft/consensus/types"
 	"github.com/cometbft/cometbft/crypto"
+	auto "github.com/cometbft/cometbft/libs/autofile"
 	cmtevents "github.com/cometbft/cometbft/libs/events"
 	"github.com/cometbft/cometbft/libs/fail"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -25,6 +26,7 @@ import (
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/types"
 	cmttime "github.com/cometbft/cometbft/types/time"
@@ -99,6 +101,9 @@ type State struct {
 	mtx cmtsync.RWMutex
 	cstypes.RoundState
 	state sm.State // State until height-1.
+	// aggregated seen commit of height-1, e.g. after block sync, from which
+	// LastCommit can't be reconstructed, to propose a block with
+	lastAggregatedCommit *types.Commit
 	// privValidator pubkey, memoized for the duration of one block
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
@@ -140,6 +145,28 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// called with the quorum prevote delay of the committed rounds, set
+	// before starting, see Reactor.OnStart
+	reportVoteLatency func(time.Duration)
+
+	// timing of the rounds of the last heights
+	roundHistory *roundHistory
+
+	// receipts of the block parts and votes of the last heights, nil unless
+	// the propagation is traced
+	propagationTracer *propagationTracer
+
+	// records the round in the spans of the calls to the application, nil if
+	// not traced
+	abciTracer *proxy.Tracer
+
+	// the clock, cmttime.Now except in simulations
+	now func() time.Time
+
+	// the block committed by +2/3 with a different app hash than ours, upon
+	// which the consensus halted, nil unless it did
+	divergence *AppHashDivergence
 }
 
 // StateOption sets an optional parameter on the State.
@@ -170,6 +197,12 @@ func NewState(
 		evpool:           evpool,
 		evsw:             cmtevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		roundHistory:     newRoundHistory(),
+		now:              cmttime.Now,
+	}
+
+	if config.TracePropagation {
+		cs.propagationTracer = newPropagationTracer()
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -177,6 +210,23 @@ func NewState(
 	cs.doPrevote = cs.defaultDoPrevote
 	cs.setProposal = cs.defaultSetProposal
 
+	cs.BaseService = *service.NewBaseService(nil, "State", cs)
+	// the options apply to the initial state too
+	for _, option := range options {
+		option(cs)
+	}
+
+	// The consensus stays halted upon an app hash divergence saved before a
+	// restart, unless the block was applied since, e.g. once the application
+	// state was repaired and the node state synced.
+	divergence, err := loadAppHashDivergence(config.AppHashDivergenceFile())
+	if err != nil {
+		panic(fmt.Sprintf("failed to load the app hash divergence: %v", err))
+	}
+	if divergence != nil && divergence.Height > state.LastBlockHeight {
+		cs.divergence = divergence
+	}
+
 	// We have no votes, so reconstruct LastCommit from SeenCommit.
 	if state.LastBlockHeight > 0 {
 		cs.reconstructLastCommit(state)
@@ -186,11 +236,6 @@ func NewState(
 
 	// NOTE: we do not call scheduleRound0 yet, we do that upon Start()
 
-	cs.BaseService = *service.NewBaseService(nil, "State", cs)
-	for _, option := range options {
-		option(cs)
-	}
-
 	return cs
 }
 
@@ -211,6 +256,12 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateABCITracer sets the tracer of the calls to the application, which
+// records the round being decided in their spans.
+func StateABCITracer(tracer *proxy.Tracer) StateOption {
+	return func(cs *State) { cs.abciTracer = tracer }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -254,6 +305,26 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cmtjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// GetRoundHistoryJSON returns a json of the round history of the last
+// committed heights, at most limit of them, the most recent first.
+func (cs *State) GetRoundHistoryJSON(limit int) ([]byte, error) {
+	return cmtjson.Marshal(cs.roundHistory.history(limit))
+}
+
+// GetPropagationTraceJSON returns a json of the propagation trace of the
+// round, if consensus.trace_propagation is set and the height is one of the
+// last ones.
+func (cs *State) GetPropagationTraceJSON(height int64, round int32) ([]byte, error) {
+	if cs.propagationTracer == nil {
+		return nil, errors.New("the propagation is not traced, see consensus.trace_propagation")
+	}
+	trace, ok := cs.propagationTracer.trace(height, round)
+	if !ok {
+		return nil, fmt.Errorf("height %d is not traced, only the last %d heights are", height, propagationTraceHeights)
+	}
+	return cmtjson.Marshal(trace)
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -272,6 +343,7 @@ func (cs *State) SetPrivValidator(priv types.PrivValidator) {
 	if err := cs.updatePrivValidatorPubKey(); err != nil {
 		cs.Logger.Error("failed to get private validator pubkey", "err", err)
 	}
+	cs.updatePrivValidatorSignBytesVersion()
 }
 
 // SetTimeoutTicker sets the local timer. It may be useful to overwrite for
@@ -297,6 +369,12 @@ func (cs *State) LoadCommit(height int64) *types.Commit {
 // OnStart loads the latest state via the WAL, and starts the timeout and
 // receive routines.
 func (cs *State) OnStart() error {
+	if cs.divergence != nil {
+		cs.Logger.Error("CONSENSUS HALTED: the app hash diverged before the restart; "+
+			"repair the application state and remove the file to resume",
+			"height", cs.divergence.Height, "file", cs.config.AppHashDivergenceFile())
+	}
+
 	// We may set the WAL in testing before calling Start, so only OpenWAL if its
 	// still the nilWAL.
 	if _, ok := cs.wal.(nilWAL); ok {
@@ -431,13 +509,25 @@ func (cs *State) Wait() {
 // OpenWAL opens a file to log all consensus messages and timeouts for
 // deterministic accountability.
 func (cs *State) OpenWAL(walFile string) (WAL, error) {
-	wal, err := NewWAL(walFile)
+	var groupOptions []func(*auto.Group)
+	if cs.config.WalSegmentSize > 0 {
+		groupOptions = append(groupOptions, auto.GroupHeadSizeLimit(cs.config.WalSegmentSize))
+	}
+	if cs.config.WalMaxSize > 0 {
+		groupOptions = append(groupOptions, auto.GroupTotalSizeLimit(cs.config.WalMaxSize))
+	}
+
+	wal, err := NewWAL(walFile, groupOptions...)
 	if err != nil {
 		cs.Logger.Error("failed to open WAL", "file", walFile, "err", err)
 		return nil, err
 	}
 
 	wal.SetLogger(cs.Logger.With("wal", walFile))
+	wal.SetCompression(cs.config.WalCompression)
+	if cs.config.WalFlushInterval > 0 {
+		wal.SetFlushInterval(cs.config.WalFlushInterval)
+	}
 
 	if err := wal.Start(); err != nil {
 		cs.Logger.Error("failed to start WAL", "err", err)
@@ -530,6 +620,7 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 		if cs.Step != step {
 			cs.metrics.MarkStep(cs.Step)
 		}
+		cs.roundHistory.recordStep(cs.Height, round, step, cs.now())
 	}
 	cs.Round = round
 	cs.Step = step
@@ -537,14 +628,57 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
-	// cs.Logger.Info("scheduleRound0", "now", cmttime.Now(), "startTime", cs.StartTime)
-	sleepDuration := rs.StartTime.Sub(cmttime.Now())
+	// cs.Logger.Info("scheduleRound0", "now", cs.now(), "startTime", cs.StartTime)
+	sleepDuration := rs.StartTime.Sub(cs.now())
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
 // Attempt to schedule a timeout (by sending timeoutInfo on the tickChan)
 func (cs *State) scheduleTimeout(duration time.Duration, height int64, round int32, step cstypes.RoundStepType) {
 	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
+	cs.metrics.MarkTimeoutScheduled(step)
+}
+
+// The timeouts set in the consensus params take precedence over the ones from
+// the local config, so that they can be changed uniformly across the network.
+
+// proposeTimeout returns the amount of time to wait for a proposal in the
+// given round.
+func (cs *State) proposeTimeout(round int32) time.Duration {
+	tp := cs.state.ConsensusParams.Timeout
+	if tp.Propose == 0 {
+		return cs.config.Propose(round)
+	}
+	return tp.Propose + tp.ProposeDelta*time.Duration(round)
+}
+
+// prevoteTimeout returns the amount of time to wait for straggler prevotes
+// after receiving any +2/3 prevotes in the given round.
+func (cs *State) prevoteTimeout(round int32) time.Duration {
+	tp := cs.state.ConsensusParams.Timeout
+	if tp.Prevote == 0 {
+		return cs.config.Prevote(round)
+	}
+	return tp.Prevote + tp.PrevoteDelta*time.Duration(round)
+}
+
+// precommitTimeout returns the amount of time to wait for straggler
+// precommits after receiving any +2/3 precommits in the given round.
+func (cs *State) precommitTimeout(round int32) time.Duration {
+	tp := cs.state.ConsensusParams.Timeout
+	if tp.Precommit == 0 {
+		return cs.config.Precommit(round)
+	}
+	return tp.Precommit + tp.PrecommitDelta*time.Duration(round)
+}
+
+// commitTime returns the time at which to start the next height after
+// committing a block at t, given the timeout params of the next height.
+func (cs *State) commitTime(tp types.TimeoutParams, t time.Time) time.Time {
+	if tp.Commit == 0 {
+		return cs.config.Commit(t)
+	}
+	return t.Add(tp.Commit)
 }
 
 // send a msg into the receiveRoutine regarding our own proposal, block part, or vote
@@ -572,6 +706,16 @@ func (cs *State) reconstructLastCommit(state sm.State) {
 		))
 	}
 
+	// The votes of an aggregated commit, e.g. saved by block sync, have no
+	// signatures: LastCommit is left empty, to be filled with the precommits we
+	// may still receive, and the commit is kept as is to propose a block.
+	if seenCommit.IsAggregated() {
+		cs.LastCommit = types.NewVoteSet(state.ChainID, seenCommit.Height, seenCommit.Round,
+			cmtproto.PrecommitType, state.LastValidators)
+		cs.lastAggregatedCommit = seenCommit
+		return
+	}
+
 	lastPrecommits := types.CommitToVoteSet(state.ChainID, seenCommit, state.LastValidators)
 	if !lastPrecommits.HasTwoThirdsMajority() {
 		panic("failed to reconstruct last commit; does not have +2/3 maj")
@@ -655,6 +799,7 @@ func (cs *State) updateToState(state sm.State) {
 
 	// RoundState fields
 	cs.updateHeight(height)
+	cs.roundHistory.newHeight(height, validators)
 	cs.updateRoundStep(0, cstypes.RoundStepNewHeight)
 
 	if cs.CommitTime.IsZero() {
@@ -663,15 +808,17 @@ func (cs *State) updateToState(state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(cmttime.Now())
+		cs.StartTime = cs.commitTime(state.ConsensusParams.Timeout, cs.now())
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = cs.commitTime(state.ConsensusParams.Timeout, cs.CommitTime)
 	}
 
 	cs.Validators = validators
 	cs.Proposal = nil
+	cs.ProposalReceiveTime = time.Time{}
 	cs.ProposalBlock = nil
 	cs.ProposalBlockParts = nil
+	cs.ProposalBlockParityParts = nil
 	cs.LockedRound = -1
 	cs.LockedBlock = nil
 	cs.LockedBlockParts = nil
@@ -684,6 +831,7 @@ func (cs *State) updateToState(state sm.State) {
 	cs.TriggeredTimeoutPrecommit = false
 
 	cs.state = state
+	cs.updatePrivValidatorSignBytesVersion()
 
 	// Finally, broadcast RoundState
 	cs.newStep()
@@ -754,6 +902,20 @@ func (cs *State) receiveRoutine(maxSteps int) {
 			}
 		}
 
+		// once the node halted, nothing is processed until the consensus is
+		// stopped
+		if cs.blockExec.IsHalted() {
+			<-cs.Quit()
+			onExit(cs)
+			return
+		}
+		// once the app hash diverged, the node keeps running to serve the RPC
+		if cs.divergence != nil {
+			cs.discardMsgs()
+			onExit(cs)
+			return
+		}
+
 		rs := cs.RoundState
 		var mi msgInfo
 
@@ -791,6 +953,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 			cs.handleMsg(mi)
 
 		case ti := <-cs.timeoutTicker.Chan(): // tockChan:
+			cs.metrics.MarkTimeoutFired(ti.Step)
 			if err := cs.wal.Write(ti); err != nil {
 				cs.Logger.Error("failed writing to WAL", "err", err)
 			}
@@ -858,6 +1021,19 @@ func (cs *State) handleMsg(mi msgInfo) {
 			err = nil
 		}
 
+	case *BlockParityPartMessage:
+		// like a block part, once the parts can be reconstructed
+		added, err = cs.addProposalBlockParityPart(msg, peerID)
+		cs.mtx.Unlock()
+
+		cs.mtx.Lock()
+		if added && cs.ProposalBlockParts.IsComplete() {
+			cs.handleCompleteProposal(msg.Height)
+		}
+		if err != nil && msg.Round != cs.Round {
+			err = nil
+		}
+
 	case *VoteMessage:
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
@@ -965,7 +1141,7 @@ func (cs *State) handleTxsAvailable() {
 		}
 
 		// +1ms to ensure RoundStepNewRound timeout always happens after RoundStepNewHeight
-		timeoutCommit := cs.StartTime.Sub(cmttime.Now()) + 1*time.Millisecond
+		timeoutCommit := cs.StartTime.Sub(cs.now()) + 1*time.Millisecond
 		cs.scheduleTimeout(timeoutCommit, cs.Height, 0, cstypes.RoundStepNewRound)
 
 	case cstypes.RoundStepNewRound: // after timeoutCommit
@@ -996,7 +1172,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 		return
 	}
 
-	if now := cmttime.Now(); cs.StartTime.After(now) {
+	if now := cs.now(); cs.StartTime.After(now) {
 		logger.Debug("need to set a buffer and log message here for sanity", "start_time", cs.StartTime, "now", now)
 	}
 
@@ -1014,6 +1190,10 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	// but we fire an event, so update the round step first
 	cs.updateRoundStep(round, cstypes.RoundStepNewRound)
 	cs.Validators = validators
+	cs.abciTracer.SetRound(height, round)
+	if !cs.replayMode {
+		cs.roundHistory.recordProposer(height, round, validators.GetProposer().Address, cs.now())
+	}
 	if round == 0 {
 		// We've already reset these upon new height,
 		// and meanwhile we might have received a proposal
@@ -1021,8 +1201,10 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	} else {
 		logger.Debug("resetting proposal info")
 		cs.Proposal = nil
+		cs.ProposalReceiveTime = time.Time{}
 		cs.ProposalBlock = nil
 		cs.ProposalBlockParts = nil
+		cs.ProposalBlockParityParts = nil
 	}
 
 	cs.Votes.SetRound(cmtmath.SafeAddInt32(round, 1)) // also track next round (round+1) to allow round-skipping
@@ -1095,7 +1277,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.proposeTimeout(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1132,7 +1314,17 @@ func (cs *State) isProposer(address []byte) bool {
 	return bytes.Equal(cs.Validators.GetProposer().Address, address)
 }
 
+// isPrivValidator reports whether address is the one of our private validator.
+func (cs *State) isPrivValidator(address []byte) bool {
+	return cs.privValidatorPubKey != nil && bytes.Equal(cs.privValidatorPubKey.Address(), address)
+}
+
 func (cs *State) defaultDecideProposal(height int64, round int32) {
+	if cs.divergence != nil {
+		cs.Logger.Error("not proposing", "height", height, "round", round, "err", errAppHashDiverged)
+		return
+	}
+
 	var block *types.Block
 	var blockParts *types.PartSet
 
@@ -1167,6 +1359,11 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 	// Make proposal
 	propBlockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
+	proposal.Timestamp = cs.now()
+	if cs.state.ConsensusParams.Feature.PbtsEnabled(height) {
+		// with proposer-based timestamps, the proposal carries the block time
+		proposal.Timestamp = block.Time
+	}
 	p := proposal.ToProto()
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, p); err == nil {
 		proposal.Signature = p.Signature
@@ -1179,6 +1376,17 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 			cs.sendInternalMessage(msgInfo{&BlockPartMessage{cs.Height, cs.Round, part}, ""})
 		}
 
+		// the parity parts are only gossiped, and don't need to go through
+		// the WAL
+		if parityTotal := types.NumParityParts(blockParts.Total(), cs.config.BlockPartParityRatio); parityTotal > 0 {
+			parity, err := types.NewParityPartSet(blockParts, parityTotal)
+			if err != nil {
+				cs.Logger.Error("failed to make the parity parts of the proposal block", "err", err)
+			} else {
+				cs.ProposalBlockParityParts = parity
+			}
+		}
+
 		cs.Logger.Debug("signed proposal", "height", height, "round", round, "proposal", proposal)
 	} else if !cs.replayMode {
 		cs.Logger.Error("propose step; failed signing proposal", "height", height, "round", round, "err", err)
@@ -1224,6 +1432,9 @@ func (cs *State) createProposalBlock() (*types.Block, error) {
 		// Make the commit from LastCommit
 		commit = cs.LastCommit.MakeCommit()
 
+	case cs.lastAggregatedCommit != nil && cs.lastAggregatedCommit.Height == cs.Height-1:
+		commit = cs.lastAggregatedCommit
+
 	default: // This shouldn't happen.
 		return nil, errors.New("propose step; cannot propose anything without commit for the previous block")
 	}
@@ -1280,6 +1491,9 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 	if cs.LockedBlock != nil {
 		logger.Debug("prevote step; already locked on a block; prevoting locked block")
 		cs.signAddVote(cmtproto.PrevoteType, cs.LockedBlock.Hash(), cs.LockedBlockParts.Header())
+		if cs.config.OptimisticExecution {
+			cs.blockExec.ExecuteBlockOptimistically(cs.state, cs.LockedBlock)
+		}
 		return
 	}
 
@@ -1300,6 +1514,23 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// With proposer-based timestamps, the proposal must carry the block time,
+	// and a new block must have been proposed in a timely manner.
+	if cs.state.ConsensusParams.Feature.PbtsEnabled(height) {
+		if !cs.Proposal.Timestamp.Equal(cs.ProposalBlock.Time) {
+			logger.Debug("prevote step: proposal timestamp does not match block time; prevoting nil",
+				"proposal_time", cs.Proposal.Timestamp, "block_time", cs.ProposalBlock.Time)
+			cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+		if cs.Proposal.POLRound == -1 && !cs.proposalIsTimely() {
+			logger.Debug("prevote step: proposal is not timely; prevoting nil",
+				"proposal_time", cs.Proposal.Timestamp, "receive_time", cs.ProposalReceiveTime)
+			cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+	}
+
 	/*
 		Before prevoting on the block received from the proposer for the current round and height,
 		we request the Application, via `ProcessProposal` ABCI call, to confirm that the block is
@@ -1331,6 +1562,23 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
 	logger.Debug("prevote step: ProposalBlock is valid")
 	cs.signAddVote(cmtproto.PrevoteType, cs.ProposalBlock.Hash(), cs.ProposalBlockParts.Header())
+
+	// Start executing the block while voting, so that its results are ready
+	// if it gets committed.
+	if cs.config.OptimisticExecution {
+		cs.blockExec.ExecuteBlockOptimistically(cs.state, cs.ProposalBlock)
+	}
+}
+
+// proposalIsTimely returns true if the proposal of the current round was
+// received within the bounds set by the synchrony params, i.e. if
+//
+//	timestamp - precision <= receiveTime <= timestamp + messageDelay + precision
+func (cs *State) proposalIsTimely() bool {
+	sp := cs.state.ConsensusParams.Synchrony.InRound(cs.Proposal.Round)
+	lower := cs.Proposal.Timestamp.Add(-sp.Precision)
+	upper := cs.Proposal.Timestamp.Add(sp.MessageDelay + sp.Precision)
+	return !cs.ProposalReceiveTime.Before(lower) && !cs.ProposalReceiveTime.After(upper)
 }
 
 // Enter: any +2/3 prevotes at next round.
@@ -1361,7 +1609,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.prevoteTimeout(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1455,7 +1703,27 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 		// Validate the block.
 		if err := cs.blockExec.ValidateBlock(cs.state, cs.ProposalBlock); err != nil {
-			panic(fmt.Sprintf("precommit step; +2/3 prevoted for an invalid block: %v", err))
+			var mismatch sm.ErrAppHashMismatch
+			if !errors.As(err, &mismatch) {
+				panic(fmt.Sprintf("precommit step; +2/3 prevoted for an invalid block: %v", err))
+			}
+
+			// Our app hash most likely diverged from the one of the network:
+			// unlock and precommit nil, as for a block we do not have, and
+			// halt if +2/3 commit the block, see finalizeCommit.
+			logger.Error("precommit step; +2/3 prevoted for a block with a different app hash; voting nil",
+				"err", err)
+
+			cs.LockedRound = -1
+			cs.LockedBlock = nil
+			cs.LockedBlockParts = nil
+
+			if err := cs.eventBus.PublishEventUnlock(cs.RoundStateEvent()); err != nil {
+				logger.Error("failed publishing event unlock", "err", err)
+			}
+
+			cs.signAddVote(cmtproto.PrecommitType, nil, types.PartSetHeader{})
+			return
 		}
 
 		cs.LockedRound = round
@@ -1482,6 +1750,7 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 	if !cs.ProposalBlockParts.HasHeader(blockID.PartSetHeader) {
 		cs.ProposalBlock = nil
 		cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+		cs.ProposalBlockParityParts = nil
 	}
 
 	if err := cs.eventBus.PublishEventUnlock(cs.RoundStateEvent()); err != nil {
@@ -1520,7 +1789,7 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.precommitTimeout(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
@@ -1542,7 +1811,7 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 		// keep cs.Round the same, commitRound points to the right Precommits set.
 		cs.updateRoundStep(cs.Round, cstypes.RoundStepCommit)
 		cs.CommitRound = commitRound
-		cs.CommitTime = cmttime.Now()
+		cs.CommitTime = cs.now()
 		cs.newStep()
 
 		// Maybe finalize immediately.
@@ -1576,6 +1845,7 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 			// Set up ProposalBlockParts and keep waiting.
 			cs.ProposalBlock = nil
 			cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+			cs.ProposalBlockParityParts = nil
 
 			if err := cs.eventBus.PublishEventValidBlock(cs.RoundStateEvent()); err != nil {
 				logger.Error("failed publishing valid block", "err", err)
@@ -1642,6 +1912,11 @@ func (cs *State) finalizeCommit(height int64) {
 	}
 
 	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
+		var mismatch sm.ErrAppHashMismatch
+		if errors.As(err, &mismatch) {
+			cs.divergeAppHash(blockID, block, mismatch)
+			return
+		}
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 
@@ -1655,6 +1930,12 @@ func (cs *State) finalizeCommit(height int64) {
 
 	fail.Fail() // XXX
 
+	// The block is saved and applied in one go, so that the stores are only
+	// backed up at a height boundary, see PauseCommits. The lock is released
+	// if the commit panics, so that the backups don't hang.
+	unlockCommit := cs.blockExec.LockCommit()
+	defer unlockCommit()
+
 	// Save to blockStore.
 	if cs.blockStore.Height() < block.Height {
 		// NOTE: the seenCommit is local justification to commit this block,
@@ -1708,6 +1989,7 @@ func (cs *State) finalizeCommit(height int64) {
 	if err != nil {
 		panic(fmt.Sprintf("failed to apply block; error %v", err))
 	}
+	unlockCommit()
 
 	fail.Fail() // XXX
 
@@ -1716,6 +1998,9 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
+	if !cs.replayMode {
+		cs.metrics.MarkHeight(cs.isPrivValidator(block.ProposerAddress))
+	}
 
 	fail.Fail() // XXX
 
@@ -1724,6 +2009,11 @@ func (cs *State) finalizeCommit(height int64) {
 		logger.Error("failed to get private validator pubkey", "err", err)
 	}
 
+	if cs.blockExec.IsHalted() {
+		logger.Info("the node halted; not starting the next height")
+		return
+	}
+
 	// cs.StartTime is already set.
 	// Schedule Round0 to start soon.
 	cs.scheduleRound0(&cs.RoundState)
@@ -1852,6 +2142,10 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	proposal.Signature = p.Signature
 	cs.Proposal = proposal
+	cs.ProposalReceiveTime = cs.now()
+	if !cs.replayMode {
+		cs.metrics.MarkProposal(cs.isPrivValidator(cs.Validators.GetProposer().Address))
+	}
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -1907,32 +2201,80 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		)
 	}
 	if added && cs.ProposalBlockParts.IsComplete() {
-		bz, err := io.ReadAll(cs.ProposalBlockParts.GetReader())
-		if err != nil {
-			return added, err
-		}
+		return cs.completeProposalBlock()
+	}
+	return added, nil
+}
 
-		var pbb = new(cmtproto.Block)
-		err = proto.Unmarshal(bz, pbb)
-		if err != nil {
-			return added, err
-		}
+// completeProposalBlock decodes the proposal block from its complete parts.
+func (cs *State) completeProposalBlock() (bool, error) {
+	bz, err := io.ReadAll(cs.ProposalBlockParts.GetReader())
+	if err != nil {
+		return true, err
+	}
 
-		block, err := types.BlockFromProto(pbb)
-		if err != nil {
-			return added, err
-		}
+	var pbb = new(cmtproto.Block)
+	err = proto.Unmarshal(bz, pbb)
+	if err != nil {
+		return true, err
+	}
+
+	block, err := types.BlockFromProto(pbb)
+	if err != nil {
+		return true, err
+	}
+
+	cs.ProposalBlock = block
 
-		cs.ProposalBlock = block
+	// NOTE: it's possible to receive complete proposal blocks for future rounds without having the proposal
+	cs.Logger.Info("received complete proposal block", "height", cs.ProposalBlock.Height, "hash", cs.ProposalBlock.Hash())
 
-		// NOTE: it's possible to receive complete proposal blocks for future rounds without having the proposal
-		cs.Logger.Info("received complete proposal block", "height", cs.ProposalBlock.Height, "hash", cs.ProposalBlock.Hash())
+	if err := cs.eventBus.PublishEventCompleteProposal(cs.CompleteProposalEvent()); err != nil {
+		cs.Logger.Error("failed publishing event complete proposal", "err", err)
+	}
+	return true, nil
+}
 
-		if err := cs.eventBus.PublishEventCompleteProposal(cs.CompleteProposalEvent()); err != nil {
-			cs.Logger.Error("failed publishing event complete proposal", "err", err)
+// addProposalBlockParityPart adds a parity part of the proposal block parts,
+// and reconstructs the missing parts once there are enough parts and parity
+// parts, returning whether it did.
+func (cs *State) addProposalBlockParityPart(msg *BlockParityPartMessage, peerID p2p.ID) (bool, error) {
+	// Like block parts, parity parts are matched by the part set, not the
+	// round.
+	if cs.Height != msg.Height || cs.ProposalBlockParts == nil || cs.ProposalBlockParts.IsComplete() {
+		return false, nil
+	}
+	if cs.ProposalBlockParityParts == nil {
+		parity, err := types.NewParityPartSetFromHeader(msg.ParityHeader, cs.ProposalBlockParts.Total(), msg.LastPartSize)
+		if err != nil {
+			return false, err
 		}
+		cs.ProposalBlockParityParts = parity
+	} else if !cs.ProposalBlockParityParts.HasHeader(msg.ParityHeader) {
+		cs.Logger.Debug("received a parity part of other parity parts", "height", msg.Height,
+			"round", msg.Round, "peer", peerID)
+		return false, nil
 	}
-	return added, nil
+
+	added, err := cs.ProposalBlockParityParts.AddPart(msg.Part)
+	if !added || err != nil {
+		return false, err
+	}
+	reconstructed, err := cs.ProposalBlockParityParts.Reconstruct(cs.ProposalBlockParts)
+	if errors.Is(err, types.ErrParityPartSetInvalid) {
+		// the parity parts don't match the block, the parts are still
+		// gossiped
+		cs.Logger.Info("parity parts don't match the proposal block, discarding them",
+			"height", msg.Height, "round", msg.Round, "peer", peerID)
+		cs.ProposalBlockParityParts = nil
+		return false, nil
+	}
+	if !reconstructed || err != nil {
+		return false, err
+	}
+	cs.Logger.Debug("reconstructed the proposal block parts from the parity parts",
+		"height", msg.Height, "parity_parts", cs.ProposalBlockParityParts.Count())
+	return cs.completeProposalBlock()
 }
 
 func (cs *State) handleCompleteProposal(blockHeight int64) {
@@ -2045,6 +2387,9 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		if !added {
 			return
 		}
+		if !cs.replayMode {
+			cs.roundHistory.recordVote(vote, cs.now())
+		}
 
 		cs.Logger.Debug("added vote to last precommits", "last_commit", cs.LastCommit.StringShort())
 		if err := cs.eventBus.PublishEventVote(types.EventDataVote{Vote: vote}); err != nil {
@@ -2076,6 +2421,9 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		// Either duplicate, or error upon cs.Votes.AddByIndex()
 		return
 	}
+	if !cs.replayMode {
+		cs.roundHistory.recordVote(vote, cs.now())
+	}
 	if vote.Round == cs.Round {
 		vals := cs.state.Validators
 		_, val := vals.GetByIndex(vote.ValidatorIndex)
@@ -2137,6 +2485,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 
 				if !cs.ProposalBlockParts.HasHeader(blockID.PartSetHeader) {
 					cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+					cs.ProposalBlockParityParts = nil
 				}
 
 				cs.evsw.FireEvent(types.EventValidBlock, &cs.RoundState)
@@ -2208,6 +2557,10 @@ func (cs *State) signVote(
 	hash []byte,
 	header types.PartSetHeader,
 ) (*types.Vote, error) {
+	if cs.divergence != nil {
+		return nil, errAppHashDiverged
+	}
+
 	// Flush the WAL. Otherwise, we may not recompute the same vote to sign,
 	// and the privValidator will refuse to sign anything.
 	if err := cs.wal.FlushAndSync(); err != nil {
@@ -2240,7 +2593,7 @@ func (cs *State) signVote(
 }
 
 func (cs *State) voteTime() time.Time {
-	now := cmttime.Now()
+	now := cs.now()
 	minVoteTime := now
 	// Minimum time increment between blocks
 	const timeIota = time.Millisecond
@@ -2305,6 +2658,14 @@ func (cs *State) updatePrivValidatorPubKey() error {
 	return nil
 }
 
+// updatePrivValidatorSignBytesVersion tells the private validator, if it signs
+// out of process, the version of the sign bytes of the current height.
+func (cs *State) updatePrivValidatorSignBytesVersion() {
+	if pv, ok := cs.privValidator.(types.SignBytesVersionSetter); ok {
+		pv.SetSignBytesVersion(cs.state.ConsensusParams.Version.SignBytesVersion())
+	}
+}
+
 // look back to check existence of the node's consensus votes before joining consensus
 func (cs *State) checkDoubleSigningRisk(height int64) error {
 	if cs.privValidator != nil && cs.privValidatorPubKey != nil && cs.config.DoubleSignCheckHeight > 0 && height > 0 {
@@ -2347,7 +2708,11 @@ func (cs *State) calculatePrevoteMessageDelayMetrics() {
 		_, val := cs.Validators.GetByAddress(v.ValidatorAddress)
 		votingPowerSeen += val.VotingPower
 		if votingPowerSeen >= cs.Validators.TotalVotingPower()*2/3+1 {
-			cs.metrics.QuorumPrevoteDelay.With("proposer_address", cs.Validators.GetProposer().Address.String()).Set(v.Timestamp.Sub(cs.Proposal.Timestamp).Seconds())
+			delay := v.Timestamp.Sub(cs.Proposal.Timestamp)
+			cs.metrics.QuorumPrevoteDelay.With("proposer_address", cs.Validators.GetProposer().Address.String()).Set(delay.Seconds())
+			if cs.reportVoteLatency != nil && delay > 0 {
+				cs.reportVoteLatency(delay)
+			}
 			break
 		}
 	}
@@ -16,6 +16,7 @@ import (
 	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
 	cstypes "github.com/cometbft/cometbft/consensus/types"
 	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/internal/test"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
@@ -185,6 +186,82 @@ func TestStateEnterProposeYesPrivValidator(t *testing.T) {
 	ensureNoNewTimeout(timeoutCh, cs.config.TimeoutPropose.Nanoseconds())
 }
 
+// timeouts set in the consensus params override the local config
+func TestStateTimeoutsFromConsensusParams(t *testing.T) {
+	cs, _ := randState(1)
+	round := int32(2)
+	now := time.Now()
+
+	// without timeout params, the local config is used
+	assert.Equal(t, cs.config.Propose(round), cs.proposeTimeout(round))
+	assert.Equal(t, cs.config.Prevote(round), cs.prevoteTimeout(round))
+	assert.Equal(t, cs.config.Precommit(round), cs.precommitTimeout(round))
+	assert.Equal(t, cs.config.Commit(now), cs.commitTime(cs.state.ConsensusParams.Timeout, now))
+
+	cs.state.ConsensusParams.Timeout = types.TimeoutParams{
+		Propose:      5 * time.Second,
+		ProposeDelta: time.Second,
+		Precommit:    2 * time.Second,
+		Commit:       3 * time.Second,
+	}
+	assert.Equal(t, 7*time.Second, cs.proposeTimeout(round))
+	assert.Equal(t, cs.config.Prevote(round), cs.prevoteTimeout(round))
+	assert.Equal(t, 2*time.Second, cs.precommitTimeout(round))
+	assert.Equal(t, now.Add(3*time.Second), cs.commitTime(cs.state.ConsensusParams.Timeout, now))
+}
+
+func TestStateProposalIsTimely(t *testing.T) {
+	cs, _ := randState(1)
+	cs.state.ConsensusParams.Synchrony = types.SynchronyParams{
+		Precision:    time.Second,
+		MessageDelay: 2 * time.Second,
+	}
+	proposalTime := time.Now()
+
+	testCases := []struct {
+		name        string
+		round       int32
+		receiveTime time.Time
+		timely      bool
+	}{
+		{"received at proposal time", 0, proposalTime, true},
+		{"received within precision before", 0, proposalTime.Add(-time.Second), true},
+		{"received too early", 0, proposalTime.Add(-2 * time.Second), false},
+		{"received within message delay", 0, proposalTime.Add(3 * time.Second), true},
+		{"received too late", 0, proposalTime.Add(4 * time.Second), false},
+		// the message delay grows with the round
+		{"received late in a later round", 5, proposalTime.Add(4 * time.Second), true},
+	}
+
+	for _, tc := range testCases {
+		cs.Proposal = &types.Proposal{Round: tc.round, Timestamp: proposalTime}
+		cs.ProposalReceiveTime = tc.receiveTime
+		assert.Equal(t, tc.timely, cs.proposalIsTimely(), tc.name)
+	}
+}
+
+// a single validator with proposer-based timestamps commits blocks carrying
+// the time of the proposal
+func TestStatePBTSCommitsBlocks(t *testing.T) {
+	cs, _ := randState(1)
+	cs.state.ConsensusParams.Feature.PbtsEnableHeight = cs.state.InitialHeight
+	cs.state.ConsensusParams.Synchrony = types.DefaultSynchronyParams()
+	height, round := cs.Height, cs.Round
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	propCh := subscribe(cs.eventBus, types.EventQueryCompleteProposal)
+
+	startTestRound(cs, height, round)
+
+	for h := height; h < height+2; h++ {
+		ensureNewProposal(propCh, h, 0)
+		msg := <-newBlockCh
+		block := msg.Data().(types.EventDataNewBlock).Block
+		require.Equal(t, h, block.Height)
+		assert.False(t, block.Time.After(time.Now()))
+	}
+}
+
 func TestStateBadProposal(t *testing.T) {
 	cs1, vss := randState(2)
 	height, round := cs1.Height, cs1.Round
@@ -251,6 +328,127 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, cmtproto.PrecommitType, propBlock.Hash(), bps2.Header(), vs2)
 }
 
+// the consensus halts, instead of panicking, once +2/3 commit a block with a
+// different app hash than ours
+func TestStateAppHashDivergence(t *testing.T) {
+	cs1, vss := randState(4)
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height, round := cs1.Height, cs1.Round
+
+	partSize := types.BlockPartSizeBytes
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	pv1, err := cs1.privValidator.GetPubKey()
+	require.NoError(t, err)
+	voteCh := subscribeToVoter(cs1, pv1.Address())
+
+	propBlock, err := cs1.createProposalBlock()
+	require.NoError(t, err)
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	// the network computed a different app hash
+	appHash := make([]byte, 32)
+	copy(appHash, propBlock.AppHash)
+	appHash[0]++
+	propBlock.AppHash = appHash
+	propBlockParts, err := propBlock.MakePartSet(partSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(cs1.state.ChainID, p))
+	proposal.Signature = p.Signature
+
+	require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureProposal(proposalCh, height, round, blockID)
+
+	// we prevote nil
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+
+	// +2/3 prevote the block, we precommit nil
+	signAddVotes(cs1, cmtproto.PrevoteType, blockID.Hash, blockID.PartSetHeader, vs2, vs3, vs4)
+	ensurePrecommit(voteCh, height, round)
+	validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
+	assert.Nil(t, cs1.GetAppHashDivergence())
+
+	// +2/3 precommit the block, the consensus halts
+	signAddVotes(cs1, cmtproto.PrecommitType, blockID.Hash, blockID.PartSetHeader, vs2, vs3, vs4)
+	ensureNoNewEventOnChannel(newRoundCh)
+
+	divergence := cs1.GetAppHashDivergence()
+	require.NotNil(t, divergence)
+	assert.Equal(t, height, divergence.Height)
+	assert.Equal(t, round, divergence.Round)
+	assert.Equal(t, blockID, divergence.BlockID)
+	assert.EqualValues(t, appHash, divergence.BlockAppHash)
+	assert.Equal(t, height-1, cs1.GetLastHeight())
+	assert.Zero(t, cs1.blockStore.Height())
+
+	// nothing is signed anymore
+	_, err = cs1.signVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+	assert.ErrorIs(t, err, errAppHashDiverged)
+
+	// the consensus is still halted once the node restarts
+	config := test.ResetTestRoot("consensus_state_test")
+	config.SetRoot(cs1.config.RootDir)
+	cs2 := newStateWithConfig(config, cs1.GetState(), cs1.privValidator, kvstore.NewApplication())
+	require.NotNil(t, cs2.GetAppHashDivergence())
+	assert.Equal(t, divergence.BlockID, cs2.GetAppHashDivergence().BlockID)
+	_, err = cs2.signVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+	assert.ErrorIs(t, err, errAppHashDiverged)
+}
+
+// a proposal block is reconstructed from its parity parts
+func TestStateProposalBlockParityParts(t *testing.T) {
+	cs1, vss := randState(2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	propBlock, err := cs1.createProposalBlock()
+	require.NoError(t, err)
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	propBlockParts, err := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	parity, err := types.NewParityPartSet(propBlockParts, propBlockParts.Total())
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID)
+	p := proposal.ToProto()
+	require.NoError(t, vs2.SignProposal(cs1.state.ChainID, p))
+	proposal.Signature = p.Signature
+
+	// only send the parity parts
+	require.NoError(t, cs1.SetProposal(proposal, "some peer"))
+	for i := 0; i < int(parity.Total()); i++ {
+		cs1.peerMsgQueue <- msgInfo{&BlockParityPartMessage{
+			Height:       height,
+			Round:        round,
+			ParityHeader: parity.Header(),
+			LastPartSize: parity.LastPartSize(),
+			Part:         parity.GetPart(i),
+		}, "some peer"}
+	}
+
+	startTestRound(cs1, height, round)
+	ensureProposal(proposalCh, height, round, blockID)
+	rs := cs1.GetRoundState()
+	assert.Equal(t, propBlock.Hash(), rs.ProposalBlock.Hash())
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	cs1, vss := randState(2)
 	cs1.state.ConsensusParams.Block.MaxBytes = 2000
@@ -23,15 +23,15 @@ type TimeoutTicker interface {
 	SetLogger(log.Logger)
 }
 
-// timeoutTicker wraps time.Timer,
-// scheduling timeouts only for greater height/round/step
-// than what it's already seen.
-// Timeouts are scheduled along the tickChan,
-// and fired on the tockChan.
+// timeoutTicker schedules the timeouts on a single timer, armed only while a
+// timeout is pending, so that it doesn't wake up when there's nothing to time
+// out. The pending timeout is keyed by its height/round/step: a timeout is only
+// scheduled for a greater height/round/step than the last one, which it
+// replaces. Timeouts are scheduled along the tickChan, and fired on the
+// tockChan.
 type timeoutTicker struct {
 	service.BaseService
 
-	timer    *time.Timer
 	tickChan chan timeoutInfo // for scheduling timeouts
 	tockChan chan timeoutInfo // for notifying about them
 }
@@ -39,12 +39,10 @@ type timeoutTicker struct {
 // NewTimeoutTicker returns a new TimeoutTicker.
 func NewTimeoutTicker() TimeoutTicker {
 	tt := &timeoutTicker{
-		timer:    time.NewTimer(0),
 		tickChan: make(chan timeoutInfo, tickTockBufferSize),
 		tockChan: make(chan timeoutInfo, tickTockBufferSize),
 	}
 	tt.BaseService = *service.NewBaseService(nil, "TimeoutTicker", tt)
-	tt.stopTimer() // don't want to fire until the first scheduled timeout
 	return tt
 }
 
@@ -56,12 +54,6 @@ func (t *timeoutTicker) OnStart() error {
 	return nil
 }
 
-// OnStop implements service.Service. It stops the timeout routine.
-func (t *timeoutTicker) OnStop() {
-	t.BaseService.OnStop()
-	t.stopTimer()
-}
-
 // Chan returns a channel on which timeouts are sent.
 func (t *timeoutTicker) Chan() <-chan timeoutInfo {
 	return t.tockChan
@@ -76,57 +68,81 @@ func (t *timeoutTicker) ScheduleTimeout(ti timeoutInfo) {
 
 //-------------------------------------------------------------
 
-// stop the timer and drain if necessary
-func (t *timeoutTicker) stopTimer() {
-	// Stop() returns false if it was already fired or was stopped
-	if !t.timer.Stop() {
-		select {
-		case <-t.timer.C:
-		default:
-			t.Logger.Debug("Timer already stopped")
-		}
+// supersedes tells whether the timeout is for a greater height/round/step
+// than the last one scheduled, and replaces it.
+func (ti timeoutInfo) supersedes(last timeoutInfo) bool {
+	switch {
+	case ti.Height != last.Height:
+		return ti.Height > last.Height
+	case ti.Round != last.Round:
+		return ti.Round > last.Round
+	default:
+		return last.Step == 0 || ti.Step > last.Step
 	}
 }
 
-// send on tickChan to start a new timer.
-// timers are interupted and replaced by new ticks from later steps
-// timeouts of 0 on the tickChan will be immediately relayed to the tockChan
+// timeoutRoutine receives the timeouts on the tickChan and arms the timer
+// for them, replacing the pending timeout. Timeouts of non-positive
+// durations, and the ones the timer fires, are queued to be sent on the
+// tockChan without blocking the routine, and in order, which the replay of
+// the WAL relies on.
 func (t *timeoutTicker) timeoutRoutine() {
 	t.Logger.Debug("Starting timeout routine")
-	var ti timeoutInfo
+	var (
+		ti     timeoutInfo
+		timer  *time.Timer
+		timerC <-chan time.Time // nil while no timeout is pending
+		fired  []timeoutInfo    // not sent on the tockChan yet
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
 	for {
+		var (
+			tockChan chan<- timeoutInfo // nil while no timeout has fired
+			next     timeoutInfo
+		)
+		if len(fired) > 0 {
+			tockChan, next = t.tockChan, fired[0]
+		}
+
 		select {
 		case newti := <-t.tickChan:
 			t.Logger.Debug("Received tick", "old_ti", ti, "new_ti", newti)
 
 			// ignore tickers for old height/round/step
-			if newti.Height < ti.Height {
+			if !newti.supersedes(ti) {
 				continue
-			} else if newti.Height == ti.Height {
-				if newti.Round < ti.Round {
-					continue
-				} else if newti.Round == ti.Round {
-					if ti.Step > 0 && newti.Step <= ti.Step {
-						continue
-					}
-				}
 			}
 
-			// stop the last timer
-			t.stopTimer()
+			// disarm the timer of the replaced timeout, draining it if it
+			// already fired
+			if timerC != nil && !timer.Stop() {
+				<-timer.C
+			}
+			timerC = nil
 
-			// update timeoutInfo and reset timer
-			// NOTE time.Timer allows duration to be non-positive
 			ti = newti
-			t.timer.Reset(ti.Duration)
+			if ti.Duration <= 0 {
+				fired = append(fired, ti)
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(ti.Duration)
+			} else {
+				timer.Reset(ti.Duration)
+			}
+			timerC = timer.C
 			t.Logger.Debug("Scheduled timeout", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
-		case <-t.timer.C:
+		case <-timerC:
+			timerC = nil
 			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
-			// go routine here guarantees timeoutRoutine doesn't block.
-			// Determinism comes from playback in the receiveRoutine.
-			// We can eliminate it by merging the timeoutRoutine into receiveRoutine
-			//  and managing the timeouts ourselves with a millisecond ticker
-			go func(toi timeoutInfo) { t.tockChan <- toi }(ti)
+			fired = append(fired, ti)
+		case tockChan <- next:
+			fired = fired[1:]
 		case <-t.Quit():
 			return
 		}
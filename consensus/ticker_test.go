@@ -0,0 +1,68 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/cometbft/cometbft/consensus/types"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestTimeoutTicker(t *testing.T) {
+	ticker := NewTimeoutTicker()
+	ticker.SetLogger(log.TestingLogger())
+	require.NoError(t, ticker.Start())
+	t.Cleanup(func() {
+		if err := ticker.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	expectTimeout := func(want timeoutInfo) {
+		t.Helper()
+		select {
+		case ti := <-ticker.Chan():
+			assert.Equal(t, want, ti)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout %v not fired", &want)
+		}
+	}
+	expectNoTimeout := func() {
+		t.Helper()
+		select {
+		case ti := <-ticker.Chan():
+			t.Fatalf("unexpected timeout %v", &ti)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	// non-positive durations fire right away, in order
+	propose := timeoutInfo{0, 1, 0, cstypes.RoundStepPropose}
+	prevote := timeoutInfo{-time.Second, 1, 0, cstypes.RoundStepPrevoteWait}
+	ticker.ScheduleTimeout(propose)
+	ticker.ScheduleTimeout(prevote)
+	expectTimeout(propose)
+	expectTimeout(prevote)
+
+	// timeouts for an earlier or the same height/round/step are ignored
+	ticker.ScheduleTimeout(timeoutInfo{0, 1, 0, cstypes.RoundStepPropose})
+	ticker.ScheduleTimeout(timeoutInfo{0, 1, 0, cstypes.RoundStepPrevoteWait})
+	expectNoTimeout()
+
+	// a pending timeout is replaced by the one of a later round
+	ticker.ScheduleTimeout(timeoutInfo{time.Hour, 1, 1, cstypes.RoundStepPropose})
+	precommit := timeoutInfo{10 * time.Millisecond, 1, 1, cstypes.RoundStepPrecommitWait}
+	ticker.ScheduleTimeout(precommit)
+	expectTimeout(precommit)
+	expectNoTimeout()
+
+	// and by the one of a later height
+	ticker.ScheduleTimeout(timeoutInfo{time.Hour, 1, 2, cstypes.RoundStepPropose})
+	newHeight := timeoutInfo{10 * time.Millisecond, 2, 0, cstypes.RoundStepNewHeight}
+	ticker.ScheduleTimeout(newHeight)
+	expectTimeout(newHeight)
+	expectNoTimeout()
+}
@@ -0,0 +1,94 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/p2p"
+	cmtcons "github.com/cometbft/cometbft/proto/tendermint/consensus"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// maxVoteBatchSize is the maximum number of votes in a VoteBatch message,
+// which keeps batches well below maxMsgSize.
+const maxVoteBatchSize = 1000
+
+// voteBatch accumulates the votes picked to be gossiped to a peer, so that
+// they are sent in a single VoteBatch message instead of one message each.
+// It is only used by the routine gossiping votes to the peer and is not safe
+// for concurrent use.
+type voteBatch struct {
+	maxSize       int
+	flushInterval time.Duration
+
+	votes   []*types.Vote
+	firstAt time.Time // when the oldest pending vote was added
+}
+
+func newVoteBatch(maxSize int, flushInterval time.Duration) *voteBatch {
+	return &voteBatch{
+		maxSize:       cmtmath.MinInt(maxSize, maxVoteBatchSize),
+		flushInterval: flushInterval,
+	}
+}
+
+// add queues the vote to be sent on the next flush.
+func (b *voteBatch) add(vote *types.Vote) {
+	if len(b.votes) == 0 {
+		b.firstAt = time.Now()
+	}
+	b.votes = append(b.votes, vote)
+}
+
+// size returns the number of pending votes.
+func (b *voteBatch) size() int {
+	return len(b.votes)
+}
+
+// full returns true if the pending votes fill a whole batch.
+func (b *voteBatch) full() bool {
+	return len(b.votes) >= b.maxSize
+}
+
+// untilFlush returns how long to wait for more votes before flushing the
+// pending ones, or 0 if they should be flushed now.
+func (b *voteBatch) untilFlush() time.Duration {
+	if b.full() {
+		return 0
+	}
+	if wait := b.flushInterval - time.Since(b.firstAt); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// flush sends the pending votes to the peer, in messages of at most maxSize
+// votes. A single vote is sent as a regular Vote message. Votes that could
+// not be sent are kept for the next flush. Returns true if all the votes were
+// sent.
+func (b *voteBatch) flush(peer p2p.Peer) bool {
+	for len(b.votes) > 0 {
+		n := cmtmath.MinInt(len(b.votes), b.maxSize)
+
+		var msg proto.Message
+		if n == 1 {
+			msg = &cmtcons.Vote{Vote: b.votes[0].ToProto()}
+		} else {
+			votes := make([]*cmtproto.Vote, n)
+			for i, vote := range b.votes[:n] {
+				votes[i] = vote.ToProto()
+			}
+			msg = &cmtcons.VoteBatch{Votes: votes}
+		}
+
+		if !peer.Send(p2p.Envelope{ChannelID: VoteChannel, Message: msg}) {
+			return false
+		}
+		b.votes = b.votes[n:]
+	}
+	b.votes = nil
+	return true
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/cosmos/gogoproto/proto"
+	"github.com/klauspost/compress/zstd"
 
 	auto "github.com/cometbft/cometbft/libs/autofile"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
@@ -26,6 +28,17 @@ const (
 
 	// how often the WAL should be sync'd during period sync'ing
 	walDefaultFlushInterval = 2 * time.Second
+
+	// walCompressedFlag is set in the length field of a record whose value is
+	// zstd-compressed. Lengths never reach this bit (see maxMsgSizeBytes).
+	walCompressedFlag = uint32(1) << 31
+)
+
+var (
+	// zstd encoders and decoders are safe for concurrent use through
+	// EncodeAll and DecodeAll.
+	walZstdEncoder, _ = zstd.NewWriter(nil)
+	walZstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxMsgSizeBytes))
 )
 
 //--------------------------------------------------------
@@ -112,6 +125,12 @@ func (wal *BaseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetCompression enables or disables zstd compression of the messages
+// written to the WAL. Messages written before are read back regardless.
+func (wal *BaseWAL) SetCompression(enabled bool) {
+	wal.enc.SetCompression(enabled)
+}
+
 func (wal *BaseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -285,13 +304,22 @@ func (wal *BaseWAL) SearchForEndHeight(
 // A WALEncoder writes custom-encoded WAL messages to an output stream.
 //
 // Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value
+//
+// If compression is enabled, the value is zstd-compressed and the highest bit
+// of the length is set. The CRC sum is computed over the stored value.
 type WALEncoder struct {
-	wr io.Writer
+	wr       io.Writer
+	compress bool
 }
 
 // NewWALEncoder returns a new encoder that writes to wr.
 func NewWALEncoder(wr io.Writer) *WALEncoder {
-	return &WALEncoder{wr}
+	return &WALEncoder{wr: wr}
+}
+
+// SetCompression enables or disables zstd compression of the encoded values.
+func (enc *WALEncoder) SetCompression(enabled bool) {
+	enc.compress = enabled
 }
 
 // Encode writes the custom encoding of v to the stream. It returns an error if
@@ -312,16 +340,24 @@ func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
 		panic(fmt.Errorf("encode timed wall message failure: %w", err))
 	}
 
-	crc := crc32.Checksum(data, crc32c)
 	length := uint32(len(data))
 	if length > maxMsgSizeBytes {
 		return fmt.Errorf("msg is too big: %d bytes, max: %d bytes", length, maxMsgSizeBytes)
 	}
+
+	lengthField := length
+	if enc.compress {
+		data = walZstdEncoder.EncodeAll(data, nil)
+		length = uint32(len(data))
+		lengthField = length | walCompressedFlag
+	}
+
+	crc := crc32.Checksum(data, crc32c)
 	totalLength := 8 + int(length)
 
 	msg := make([]byte, totalLength)
 	binary.BigEndian.PutUint32(msg[0:4], crc)
-	binary.BigEndian.PutUint32(msg[4:8], length)
+	binary.BigEndian.PutUint32(msg[4:8], lengthField)
 	copy(msg[8:], data)
 
 	_, err = enc.wr.Write(msg)
@@ -380,6 +416,8 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %v", err)}
 	}
 	length := binary.BigEndian.Uint32(b)
+	compressed := length&walCompressedFlag != 0
+	length &^= walCompressedFlag
 
 	if length > maxMsgSizeBytes {
 		return nil, DataCorruptionError{fmt.Errorf(
@@ -400,6 +438,13 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)}
 	}
 
+	if compressed {
+		data, err = walZstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, DataCorruptionError{fmt.Errorf("failed to decompress data: %v", err)}
+		}
+	}
+
 	var res = new(cmtcons.TimedWALMessage)
 	err = proto.Unmarshal(data, res)
 	if err != nil {
@@ -418,6 +463,55 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	return tMsgWal, err
 }
 
+// TruncateWALTail truncates the given WAL file (usually the head of the
+// group) after its last valid message, discarding a torn or corrupted tail
+// left by a crash. It returns the number of bytes removed.
+func TruncateWALTail(walFile string) (int64, error) {
+	f, err := os.OpenFile(walFile, os.O_RDWR, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var (
+		cr     = &countingReader{rd: f}
+		dec    = NewWALDecoder(cr)
+		offset int64
+	)
+	for {
+		_, err := dec.Decode()
+		if errors.Is(err, io.EOF) && cr.n == offset {
+			// the file ends cleanly after the last message
+			return 0, nil
+		}
+		if err != nil {
+			break
+		}
+		offset = cr.n
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if err := f.Truncate(offset); err != nil {
+		return 0, err
+	}
+	return info.Size() - offset, f.Sync()
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.rd.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
 type nilWAL struct{}
 
 var _ WAL = nilWAL{}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"crypto/rand"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -107,6 +108,66 @@ func TestWALEncoderDecoder(t *testing.T) {
 	}
 }
 
+func TestWALEncoderDecoderCompressed(t *testing.T) {
+	now := cmttime.Now()
+	msgs := []TimedWALMessage{
+		{Time: now, Msg: EndHeightMessage{0}},
+		{Time: now, Msg: timeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: types.RoundStepPropose}},
+		{Time: now, Msg: cmttypes.EventDataRoundState{Height: 1, Round: 1, Step: ""}},
+	}
+
+	b := new(bytes.Buffer)
+	enc := NewWALEncoder(b)
+	for i, msg := range msgs {
+		msg := msg
+		// compressed and uncompressed messages can be interleaved
+		enc.SetCompression(i%2 == 0)
+		require.NoError(t, enc.Encode(&msg))
+	}
+
+	dec := NewWALDecoder(b)
+	for _, msg := range msgs {
+		decoded, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, msg.Time.UTC(), decoded.Time)
+		assert.Equal(t, msg.Msg, decoded.Msg)
+	}
+	_, err := dec.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestTruncateWALTail(t *testing.T) {
+	walFile := filepath.Join(t.TempDir(), "wal")
+	f, err := os.Create(walFile)
+	require.NoError(t, err)
+
+	enc := NewWALEncoder(f)
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, enc.Encode(&TimedWALMessage{Time: cmttime.Now(), Msg: EndHeightMessage{h}}))
+	}
+	info, err := f.Stat()
+	require.NoError(t, err)
+	validSize := info.Size()
+
+	// an intact file is left untouched
+	removed, err := TruncateWALTail(walFile)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	// simulate a crash in the middle of a write
+	_, err = f.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x00})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	removed, err = TruncateWALTail(walFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, removed)
+
+	info, err = os.Stat(walFile)
+	require.NoError(t, err)
+	assert.Equal(t, validSize, info.Size())
+}
+
 func TestWALWrite(t *testing.T) {
 	walDir, err := os.MkdirTemp("", "wal")
 	require.NoError(t, err)
@@ -175,6 +236,32 @@ func TestWALSearchForEndHeight(t *testing.T) {
 	assert.Equal(t, rs.Height, h+1, "wrong height")
 }
 
+func TestTraceWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6)
+	require.NoError(t, err)
+	walFile := tempWALWithData(walBody)
+
+	trace, err := TraceWAL(walFile, 3, 4)
+	require.NoError(t, err)
+	require.Len(t, trace.Heights, 2)
+
+	for i, ht := range trace.Heights {
+		assert.Equal(t, int64(3+i), ht.Height)
+		assert.True(t, ht.Completed, "expected height %d to be completed", ht.Height)
+		assert.NotEmpty(t, ht.Steps, "expected step transitions at height %d", ht.Height)
+		assert.NotEmpty(t, ht.Proposals, "expected a proposal at height %d", ht.Height)
+		assert.NotEmpty(t, ht.Votes, "expected votes at height %d", ht.Height)
+		for _, vote := range ht.Votes {
+			assert.True(t, cmttypes.IsVoteTypeValid(vote.Type))
+		}
+	}
+
+	_, err = TraceWAL(walFile, 4, 3)
+	assert.Error(t, err)
+	_, err = TraceWAL(walFile+".missing", 1, 1)
+	assert.Error(t, err)
+}
+
 func TestWALPeriodicSync(t *testing.T) {
 	walDir, err := os.MkdirTemp("", "wal")
 	require.NoError(t, err)
@@ -0,0 +1,203 @@
+package consensus
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cometbft/cometbft/p2p"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+// WALTrace is a structured trace of the consensus WAL over a range of heights.
+// It is built by TraceWAL and is meant to help diagnosing why consensus took
+// the rounds it took at a given height.
+type WALTrace struct {
+	MinHeight int64            `json:"min_height"`
+	MaxHeight int64            `json:"max_height"`
+	Heights   []WALHeightTrace `json:"heights"`
+}
+
+// WALHeightTrace is the trace of a single height. Completed is true if the
+// WAL contains the EndHeightMessage for the height, i.e. a block was
+// committed.
+type WALHeightTrace struct {
+	Height    int64              `json:"height"`
+	Completed bool               `json:"completed"`
+	Steps     []WALTraceStep     `json:"steps"`
+	Timeouts  []WALTraceTimeout  `json:"timeouts"`
+	Proposals []WALTraceProposal `json:"proposals"`
+	Votes     []WALTraceVote     `json:"votes"`
+}
+
+// WALTraceStep is a transition of the state machine to a new round step.
+type WALTraceStep struct {
+	Time  time.Time `json:"time"`
+	Round int32     `json:"round"`
+	Step  string    `json:"step"`
+}
+
+// WALTraceTimeout is a timeout that fired and was processed by the state
+// machine.
+type WALTraceTimeout struct {
+	Time     time.Time     `json:"time"`
+	Round    int32         `json:"round"`
+	Step     string        `json:"step"`
+	Duration time.Duration `json:"duration"`
+}
+
+// WALTraceProposal is a proposal received by the state machine. PeerID is
+// empty if the proposal was created by this node.
+type WALTraceProposal struct {
+	Time     time.Time     `json:"time"`
+	Round    int32         `json:"round"`
+	POLRound int32         `json:"pol_round"`
+	BlockID  types.BlockID `json:"block_id"`
+	PeerID   p2p.ID        `json:"peer_id"`
+}
+
+// WALTraceVote is a vote received by the state machine. PeerID is empty if
+// the vote was cast by this node.
+type WALTraceVote struct {
+	Time             time.Time              `json:"time"`
+	Type             cmtproto.SignedMsgType `json:"type"`
+	Round            int32                  `json:"round"`
+	ValidatorIndex   int32                  `json:"validator_index"`
+	ValidatorAddress types.Address          `json:"validator_address"`
+	BlockID          types.BlockID          `json:"block_id"`
+	PeerID           p2p.ID                 `json:"peer_id"`
+}
+
+// TraceWAL reads the consensus WAL at walFile and returns the step
+// transitions, timeouts, proposals and votes recorded for the heights in
+// [minHeight, maxHeight]. The WAL is only read, so it is safe to call
+// TraceWAL while the node is running.
+//
+// Reading stops at the first corrupted entry, which for a running node is
+// usually a partially flushed record at the end of the WAL.
+func TraceWAL(walFile string, minHeight, maxHeight int64) (*WALTrace, error) {
+	if minHeight < 1 {
+		return nil, fmt.Errorf("min height must be positive, got %d", minHeight)
+	}
+	if maxHeight < minHeight {
+		return nil, fmt.Errorf("max height %d is less than min height %d", maxHeight, minHeight)
+	}
+
+	if _, err := os.Stat(walFile); err != nil {
+		return nil, err
+	}
+	wal, err := NewWAL(walFile)
+	if err != nil {
+		return nil, err
+	}
+	group := wal.Group()
+	defer group.Close()
+
+	// Skip everything before the requested range, if possible.
+	rd, found, err := wal.SearchForEndHeight(minHeight-1, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		rd, err = group.NewReader(group.MinIndex())
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer rd.Close()
+
+	trace := &WALTrace{MinHeight: minHeight, MaxHeight: maxHeight}
+	heights := make(map[int64]*WALHeightTrace)
+	heightTrace := func(height int64) *WALHeightTrace {
+		if height < minHeight || height > maxHeight {
+			return nil
+		}
+		ht, ok := heights[height]
+		if !ok {
+			ht = &WALHeightTrace{Height: height}
+			heights[height] = ht
+		}
+		return ht
+	}
+
+	dec := NewWALDecoder(rd)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF || IsDataCorruptionError(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			if ht := heightTrace(m.Height); ht != nil {
+				ht.Completed = true
+			}
+			if m.Height >= maxHeight {
+				break
+			}
+			continue
+		}
+		traceWALMessage(msg, heightTrace)
+	}
+
+	for h := minHeight; h <= maxHeight; h++ {
+		if ht, ok := heights[h]; ok {
+			trace.Heights = append(trace.Heights, *ht)
+		}
+	}
+	return trace, nil
+}
+
+// traceWALMessage records msg in the trace of the height it belongs to.
+// Messages of heights outside the requested range are ignored.
+func traceWALMessage(msg *TimedWALMessage, heightTrace func(int64) *WALHeightTrace) {
+	switch m := msg.Msg.(type) {
+	case types.EventDataRoundState:
+		if ht := heightTrace(m.Height); ht != nil {
+			ht.Steps = append(ht.Steps, WALTraceStep{
+				Time:  msg.Time,
+				Round: m.Round,
+				Step:  m.Step,
+			})
+		}
+
+	case timeoutInfo:
+		if ht := heightTrace(m.Height); ht != nil {
+			ht.Timeouts = append(ht.Timeouts, WALTraceTimeout{
+				Time:     msg.Time,
+				Round:    m.Round,
+				Step:     m.Step.String(),
+				Duration: m.Duration,
+			})
+		}
+
+	case msgInfo:
+		switch cm := m.Msg.(type) {
+		case *ProposalMessage:
+			if ht := heightTrace(cm.Proposal.Height); ht != nil {
+				ht.Proposals = append(ht.Proposals, WALTraceProposal{
+					Time:     msg.Time,
+					Round:    cm.Proposal.Round,
+					POLRound: cm.Proposal.POLRound,
+					BlockID:  cm.Proposal.BlockID,
+					PeerID:   m.PeerID,
+				})
+			}
+		case *VoteMessage:
+			if ht := heightTrace(cm.Vote.Height); ht != nil {
+				ht.Votes = append(ht.Votes, WALTraceVote{
+					Time:             msg.Time,
+					Type:             cm.Vote.Type,
+					Round:            cm.Vote.Round,
+					ValidatorIndex:   cm.Vote.ValidatorIndex,
+					ValidatorAddress: cm.Vote.ValidatorAddress,
+					BlockID:          cm.Vote.BlockID,
+					PeerID:           m.PeerID,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package bls12381
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	bls "github.com/cloudflare/circl/ecc/bls12381"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+//-------------------------------------
+
+const (
+	PrivKeyName = "tendermint/PrivKeyBls12_381"
+	PubKeyName  = "tendermint/PubKeyBls12_381"
+
+	KeyType = "bls12_381"
+
+	// PrivKeySize is the size, in bytes, of private keys, big-endian scalars.
+	PrivKeySize = bls.ScalarSize
+	// PubKeySize is the size, in bytes, of public keys, compressed points of
+	// G1.
+	PubKeySize = bls.G1SizeCompressed
+	// SignatureSize is the size, in bytes, of signatures, compressed points
+	// of G2.
+	SignatureSize = bls.G2SizeCompressed
+)
+
+// dst is the domain separation tag of the signatures: the minimal-pubkey-size
+// ciphersuite with message augmentation of the IETF BLS signature draft. The
+// public key of the signer is prepended to every message it signs, so that
+// the messages of an aggregate signature are all distinct, which prevents
+// rogue key attacks without proofs of possession of the keys.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+
+func init() {
+	cmtjson.RegisterType(PubKey{}, PubKeyName)
+	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+}
+
+var _ crypto.PrivKey = PrivKey{}
+
+// PrivKey implements crypto.PrivKey.
+type PrivKey []byte
+
+// Bytes returns the private key as a big-endian scalar.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign signs the message, augmented with the public key, the signature being a
+// compressed point of G2.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+	var pk bls.G1
+	pk.ScalarMult(sk, bls.G1Generator())
+	var sig bls.G2
+	sig.Hash(augment(pk.BytesCompressed(), msg), dst)
+	sig.ScalarMult(sk, &sig)
+	return sig.BytesCompressed(), nil
+}
+
+// PubKey returns the public key, a compressed point of G1.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	sk, err := privKey.scalar()
+	if err != nil {
+		panic(err)
+	}
+	var pk bls.G1
+	pk.ScalarMult(sk, bls.G1Generator())
+	return PubKey(pk.BytesCompressed())
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBls, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBls[:]) == 1
+	}
+	return false
+}
+
+func (privKey PrivKey) Type() string {
+	return KeyType
+}
+
+func (privKey PrivKey) scalar() (*bls.Scalar, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, fmt.Errorf("invalid private key size %d", len(privKey))
+	}
+	sk := new(bls.Scalar)
+	if err := sk.UnmarshalBinary(privKey); err != nil {
+		return nil, err
+	}
+	if sk.IsZero() == 1 {
+		return nil, errors.New("the private key is zero")
+	}
+	return sk, nil
+}
+
+// GenPrivKey generates a new BLS12-381 private key.
+// It uses OS randomness to generate the private key.
+func GenPrivKey() PrivKey {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKey {
+	sk := new(bls.Scalar)
+	for sk.IsZero() == 1 {
+		if err := sk.Random(rand); err != nil {
+			panic(err)
+		}
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+// GenPrivKeyFromSecret hashes the secret with SHA256, and uses the hash,
+// reduced modulo the order of the groups, as the private key.
+//
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	h := sha256.Sum256(secret)
+	sk := new(bls.Scalar)
+	sk.SetBytes(h[:])
+	if sk.IsZero() == 1 {
+		sk.SetOne()
+	}
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return PrivKey(bz)
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey. It is a compressed point of G1.
+type PubKey []byte
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey))
+}
+
+// Bytes returns the compressed point.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+// VerifySignature verifies the signature of the message.
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	return VerifyAggregateSignature([]PubKey{pubKey}, [][]byte{msg}, sig)
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBls12_381{%X}", []byte(pubKey))
+}
+
+func (pubKey PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBls, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBls[:])
+	}
+	return false
+}
+
+// point returns the public key as a point of G1, rejecting the identity.
+func (pubKey PubKey) point() (*bls.G1, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, fmt.Errorf("invalid public key size %d", len(pubKey))
+	}
+	pk := new(bls.G1)
+	if err := pk.SetBytes(pubKey); err != nil {
+		return nil, err
+	}
+	if pk.IsIdentity() {
+		return nil, errors.New("the public key is the identity")
+	}
+	return pk, nil
+}
+
+//-------------------------------------
+
+// AggregateSignatures aggregates the signatures into one, of the same size.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	var agg bls.G2
+	agg.SetIdentity()
+	for i, sig := range sigs {
+		var p bls.G2
+		if len(sig) != SignatureSize {
+			return nil, fmt.Errorf("invalid size %d of signature %d", len(sig), i)
+		}
+		if err := p.SetBytes(sig); err != nil {
+			return nil, fmt.Errorf("invalid signature %d: %w", i, err)
+		}
+		agg.Add(&agg, &p)
+	}
+	return agg.BytesCompressed(), nil
+}
+
+// VerifyAggregateSignature verifies the aggregate of the signatures of the
+// messages, msgs[i] being signed with pubKeys[i]. The public keys must be
+// distinct.
+func VerifyAggregateSignature(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) || len(sig) != SignatureSize {
+		return false
+	}
+	seen := make(map[string]struct{}, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		if _, ok := seen[string(pubKey)]; ok {
+			return false
+		}
+		seen[string(pubKey)] = struct{}{}
+	}
+	var s bls.G2
+	if err := s.SetBytes(sig); err != nil {
+		return false
+	}
+
+	// e(G1, sig) == prod e(pk_i, H(m_i))
+	ps := make([]*bls.G1, 0, len(pubKeys)+1)
+	qs := make([]*bls.G2, 0, len(pubKeys)+1)
+	signs := make([]int, 0, len(pubKeys)+1)
+	ps = append(ps, bls.G1Generator())
+	qs = append(qs, &s)
+	signs = append(signs, -1)
+	for i, pubKey := range pubKeys {
+		pk, err := pubKey.point()
+		if err != nil {
+			return false
+		}
+		h := new(bls.G2)
+		h.Hash(augment(pubKey, msgs[i]), dst)
+		ps = append(ps, pk)
+		qs = append(qs, h)
+		signs = append(signs, 1)
+	}
+	return bls.ProdPairFrac(ps, qs, signs).IsIdentity()
+}
+
+// augment returns the message signed for msg with the public key pubKey.
+func augment(pubKey []byte, msg []byte) []byte {
+	augmented := make([]byte, 0, len(pubKey)+len(msg))
+	augmented = append(augmented, pubKey...)
+	return append(augmented, msg...)
+}
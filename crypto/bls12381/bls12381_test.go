@@ -0,0 +1,99 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+)
+
+func TestSignAndValidateBls12381(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.Nil(t, err)
+	require.Len(t, sig, bls12381.SignatureSize)
+
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature(crypto.CRandBytes(128), sig))
+	assert.False(t, bls12381.GenPrivKey().PubKey().VerifySignature(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	secret := []byte("secret")
+	privKey := bls12381.GenPrivKeyFromSecret(secret)
+	assert.Len(t, privKey.Bytes(), bls12381.PrivKeySize)
+	assert.True(t, privKey.Equals(bls12381.GenPrivKeyFromSecret(secret)))
+	assert.False(t, privKey.Equals(bls12381.GenPrivKeyFromSecret([]byte("other"))))
+	assert.True(t, privKey.PubKey().Equals(bls12381.GenPrivKeyFromSecret(secret).PubKey()))
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	const n = 4
+	pubKeys := make([]bls12381.PubKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey := bls12381.GenPrivKey()
+		pubKeys[i] = privKey.PubKey().(bls12381.PubKey)
+		msgs[i] = crypto.CRandBytes(32)
+		sig, err := privKey.Sign(msgs[i])
+		require.NoError(t, err)
+		sigs[i] = sig
+	}
+
+	agg, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	require.Len(t, agg, bls12381.SignatureSize)
+	assert.True(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, agg))
+
+	// A missing signer, or a different message, fails the verification.
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys[1:], msgs[1:], agg))
+	msgs[0] = crypto.CRandBytes(32)
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, agg))
+
+	// The messages are augmented with the public keys, so the signatures of a
+	// same message by different keys can be aggregated, but not those of a
+	// same key twice.
+	privKey := bls12381.GenPrivKey()
+	other := bls12381.GenPrivKey()
+	msg := crypto.CRandBytes(32)
+	sig0, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	sig1, err := other.Sign(msg)
+	require.NoError(t, err)
+	agg, err = bls12381.AggregateSignatures([][]byte{sig0, sig1})
+	require.NoError(t, err)
+	assert.True(t, bls12381.VerifyAggregateSignature(
+		[]bls12381.PubKey{privKey.PubKey().(bls12381.PubKey), other.PubKey().(bls12381.PubKey)},
+		[][]byte{msg, msg}, agg))
+	agg, err = bls12381.AggregateSignatures([][]byte{sig0, sig0})
+	require.NoError(t, err)
+	assert.False(t, bls12381.VerifyAggregateSignature(
+		[]bls12381.PubKey{privKey.PubKey().(bls12381.PubKey), privKey.PubKey().(bls12381.PubKey)},
+		[][]byte{msg, msg}, agg))
+
+	_, err = bls12381.AggregateSignatures(nil)
+	assert.Error(t, err)
+	_, err = bls12381.AggregateSignatures([][]byte{{0x01}})
+	assert.Error(t, err)
+}
+
+func TestInvalidPubKey(t *testing.T) {
+	msg := []byte("message")
+	sig, err := bls12381.GenPrivKey().Sign(msg)
+	require.NoError(t, err)
+
+	assert.False(t, bls12381.PubKey{}.VerifySignature(msg, sig))
+	assert.False(t, bls12381.PubKey(make([]byte, bls12381.PubKeySize)).VerifySignature(msg, sig))
+}
@@ -0,0 +1,170 @@
+package evidence
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/types"
+)
+
+// AttackType is the type of attack a conflicting header is the product of.
+type AttackType uint8
+
+const (
+	// LunaticAttack is an attack where the conflicting header was not correctly
+	// derived from the state of the chain, i.e. some of its validators, consensus
+	// params, app or results hashes differ from the trusted header.
+	LunaticAttack AttackType = iota + 1
+	// EquivocationAttack is an attack where validators signed both the conflicting
+	// and the trusted header in the same round.
+	EquivocationAttack
+	// AmnesiaAttack is an attack where validators signed the conflicting and the
+	// trusted header in different rounds, without a valid unlock. The malicious
+	// validators can't be identified.
+	AmnesiaAttack
+)
+
+func (a AttackType) String() string {
+	switch a {
+	case LunaticAttack:
+		return "lunatic"
+	case EquivocationAttack:
+		return "equivocation"
+	case AmnesiaAttack:
+		return "amnesia"
+	default:
+		return fmt.Sprintf("unknown attack %d", a)
+	}
+}
+
+// ClassifyAttack returns the type of attack the conflicting light block is the
+// product of, given the trusted signed header at the same height, or the
+// latest trusted one if the conflicting block is higher (forward lunatic
+// attack).
+func ClassifyAttack(conflicting *types.LightBlock, trusted *types.SignedHeader) AttackType {
+	ev := &types.LightClientAttackEvidence{ConflictingBlock: conflicting}
+	switch {
+	case conflicting.Height != trusted.Height, ev.ConflictingHeaderIsInvalid(trusted.Header):
+		return LunaticAttack
+	case conflicting.Commit.Round == trusted.Commit.Round:
+		return EquivocationAttack
+	default:
+		return AmnesiaAttack
+	}
+}
+
+// NewLightClientAttackEvidence forms the evidence of the attack the conflicting
+// light block is the product of, given the trusted signed header and the
+// common height, time and validators from which the conflicting block was
+// derived. For equivocation and amnesia attacks, the common height is the
+// height of the conflicting block, for lunatic attacks a lower height whose
+// validators signed the conflicting block with at least 1/3 of their voting
+// power.
+func NewLightClientAttackEvidence(
+	conflicting *types.LightBlock,
+	trusted *types.SignedHeader,
+	commonHeight int64,
+	commonTime time.Time,
+	commonVals *types.ValidatorSet,
+) *types.LightClientAttackEvidence {
+	ev := &types.LightClientAttackEvidence{
+		ConflictingBlock: conflicting,
+		CommonHeight:     commonHeight,
+		TotalVotingPower: commonVals.TotalVotingPower(),
+		Timestamp:        commonTime,
+	}
+	ev.ByzantineValidators = ev.GetByzantineValidators(commonVals, trusted)
+	return ev
+}
+
+// ReportConflictingHeader takes a light block conflicting with the chain of the
+// node, e.g. received from a peer during block sync, classifies the attack it
+// is the product of and forms the evidence against it, adding it to the pool
+// to be gossiped and committed.
+//
+// The conflicting light block is verified as part of the evidence.
+func (evpool *Pool) ReportConflictingHeader(conflicting *types.LightBlock) error {
+	if conflicting == nil || conflicting.SignedHeader == nil || conflicting.ValidatorSet == nil {
+		return errors.New("incomplete conflicting light block")
+	}
+
+	// The trusted header is the one of the node at the same height, or the latest
+	// one for forward lunatic attacks.
+	trusted, err := getSignedHeader(evpool.blockStore, conflicting.Height)
+	if err != nil {
+		latestHeight := evpool.blockStore.Height()
+		if conflicting.Height <= latestHeight {
+			return err
+		}
+		trusted, err = getSignedHeader(evpool.blockStore, latestHeight)
+		if err != nil {
+			return err
+		}
+	}
+	if err := conflicting.ValidateBasic(trusted.ChainID); err != nil {
+		return fmt.Errorf("invalid conflicting light block: %w", err)
+	}
+	if bytes.Equal(trusted.Hash(), conflicting.Hash()) {
+		return errors.New("light block does not conflict with the trusted header")
+	}
+
+	attack := ClassifyAttack(conflicting, trusted)
+	commonHeight := conflicting.Height
+	if attack == LunaticAttack {
+		commonHeight, err = evpool.findCommonHeight(conflicting, trusted)
+		if err != nil {
+			return err
+		}
+	}
+	commonMeta := evpool.blockStore.LoadBlockMeta(commonHeight)
+	if commonMeta == nil {
+		return fmt.Errorf("don't have header #%d", commonHeight)
+	}
+	commonVals, err := evpool.stateDB.LoadValidators(commonHeight)
+	if err != nil {
+		return err
+	}
+
+	ev := NewLightClientAttackEvidence(conflicting, trusted, commonHeight, commonMeta.Header.Time, commonVals)
+	evpool.logger.Info("Formed evidence from conflicting header", "attack", attack, "evidence", ev)
+	return evpool.AddEvidence(ev)
+}
+
+// findCommonHeight returns the highest height below the conflicting block, and
+// not above the trusted header, whose validators signed the conflicting block
+// with at least 1/3 of their voting power. Heights of expired evidence are not
+// considered.
+func (evpool *Pool) findCommonHeight(conflicting *types.LightBlock, trusted *types.SignedHeader) (int64, error) {
+	state := evpool.State()
+	minHeight := state.LastBlockHeight - state.ConsensusParams.Evidence.MaxAgeNumBlocks
+	if minHeight < 1 {
+		minHeight = 1
+	}
+	height := conflicting.Height - 1
+	if height > trusted.Height {
+		height = trusted.Height
+	}
+
+	var failedHash []byte
+	for ; height >= minHeight; height-- {
+		vals, err := evpool.stateDB.LoadValidators(height)
+		if err != nil {
+			break
+		}
+		// Skip validator sets which already failed to verify the conflicting commit.
+		hash := vals.Hash()
+		if bytes.Equal(hash, failedHash) {
+			continue
+		}
+		err = vals.VerifyCommitLightTrusting(trusted.ChainID, conflicting.Commit, light.DefaultTrustLevel)
+		if err != nil {
+			failedHash = hash
+			continue
+		}
+		return height, nil
+	}
+	return 0, errors.New("no common height whose validators signed the conflicting block")
+}
@@ -0,0 +1,138 @@
+package evidence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/evidence"
+	"github.com/cometbft/cometbft/evidence/mocks"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sm "github.com/cometbft/cometbft/state"
+	smmocks "github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestReportConflictingHeader_Lunatic(t *testing.T) {
+	const (
+		height       int64 = 10
+		commonHeight int64 = 4
+		totalVals          = 10
+		byzVals            = 4
+	)
+	attackTime := defaultEvidenceTime.Add(1 * time.Hour)
+	expected, trusted, common := makeLunaticEvidence(
+		t, height, commonHeight, totalVals, byzVals, totalVals-byzVals, defaultEvidenceTime, attackTime)
+	assert.Equal(t, evidence.LunaticAttack, evidence.ClassifyAttack(expected.ConflictingBlock, trusted.SignedHeader))
+
+	state := sm.State{
+		LastBlockTime:   defaultEvidenceTime.Add(2 * time.Hour),
+		LastBlockHeight: height + 1,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("Load").Return(state, nil)
+	// the validators between the common height and the conflicting block didn't sign it
+	for h := commonHeight + 1; h < height; h++ {
+		stateStore.On("LoadValidators", h).Return(trusted.ValidatorSet, nil)
+	}
+	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: *trusted.Header})
+	blockStore.On("LoadBlockCommit", commonHeight).Return(common.Commit)
+	blockStore.On("LoadBlockCommit", height).Return(trusted.Commit)
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	require.NoError(t, pool.ReportConflictingHeader(expected.ConflictingBlock))
+
+	pendingEvs, _ := pool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	require.Len(t, pendingEvs, 1)
+	ev := pendingEvs[0].(*types.LightClientAttackEvidence)
+	assert.Equal(t, expected.Hash(), ev.Hash())
+	assert.Equal(t, commonHeight, ev.CommonHeight)
+	assert.Equal(t, defaultEvidenceTime, ev.Timestamp)
+	assert.Equal(t, common.ValidatorSet.TotalVotingPower(), ev.TotalVotingPower)
+	assert.ElementsMatch(t, expected.ByzantineValidators, ev.ByzantineValidators)
+}
+
+func TestReportConflictingHeader_EquivocationAndAmnesia(t *testing.T) {
+	testCases := []struct {
+		name          string
+		round         int32
+		attack        evidence.AttackType
+		byzantineVals int
+	}{
+		// validators signed both headers in the same round
+		{"equivocation", 1, evidence.EquivocationAttack, 4},
+		// validators signed the headers in different rounds, they can't be identified
+		{"amnesia", 0, evidence.AmnesiaAttack, 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			conflictingVals, conflictingPrivVals := types.RandValidatorSet(5, 10)
+			conflictingHeader := makeHeaderRandom(10)
+			conflictingHeader.ValidatorsHash = conflictingVals.Hash()
+			trustedHeader := makeHeaderRandom(10)
+			trustedHeader.ValidatorsHash = conflictingHeader.ValidatorsHash
+			trustedHeader.NextValidatorsHash = conflictingHeader.NextValidatorsHash
+			trustedHeader.ConsensusHash = conflictingHeader.ConsensusHash
+			trustedHeader.AppHash = conflictingHeader.AppHash
+			trustedHeader.LastResultsHash = conflictingHeader.LastResultsHash
+
+			blockID := makeBlockID(conflictingHeader.Hash(), 1000, []byte("partshash"))
+			voteSet := types.NewVoteSet(evidenceChainID, 10, tc.round, cmtproto.SignedMsgType(2), conflictingVals)
+			commit, err := types.MakeCommit(blockID, 10, tc.round, voteSet, conflictingPrivVals[:4], defaultEvidenceTime)
+			require.NoError(t, err)
+			conflicting := &types.LightBlock{
+				SignedHeader: &types.SignedHeader{Header: conflictingHeader, Commit: commit},
+				ValidatorSet: conflictingVals,
+			}
+
+			trustedBlockID := makeBlockID(trustedHeader.Hash(), 1000, []byte("partshash"))
+			trustedVoteSet := types.NewVoteSet(evidenceChainID, 10, 1, cmtproto.SignedMsgType(2), conflictingVals)
+			trustedCommit, err := types.MakeCommit(
+				trustedBlockID, 10, 1, trustedVoteSet, conflictingPrivVals, defaultEvidenceTime)
+			require.NoError(t, err)
+			trusted := &types.SignedHeader{Header: trustedHeader, Commit: trustedCommit}
+			assert.Equal(t, tc.attack, evidence.ClassifyAttack(conflicting, trusted))
+
+			state := sm.State{
+				LastBlockTime:   defaultEvidenceTime.Add(1 * time.Minute),
+				LastBlockHeight: 11,
+				ConsensusParams: *types.DefaultConsensusParams(),
+			}
+			stateStore := &smmocks.Store{}
+			stateStore.On("LoadValidators", int64(10)).Return(conflictingVals, nil)
+			stateStore.On("Load").Return(state, nil)
+			blockStore := &mocks.BlockStore{}
+			blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: *trustedHeader})
+			blockStore.On("LoadBlockCommit", int64(10)).Return(trustedCommit)
+			pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+			require.NoError(t, err)
+			pool.SetLogger(log.TestingLogger())
+
+			// the trusted header doesn't conflict with itself
+			assert.Error(t, pool.ReportConflictingHeader(&types.LightBlock{
+				SignedHeader: trusted,
+				ValidatorSet: conflictingVals,
+			}))
+
+			require.NoError(t, pool.ReportConflictingHeader(conflicting))
+			pendingEvs, _ := pool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+			require.Len(t, pendingEvs, 1)
+			ev := pendingEvs[0].(*types.LightClientAttackEvidence)
+			assert.EqualValues(t, 10, ev.CommonHeight)
+			assert.Equal(t, trustedHeader.Time, ev.Timestamp)
+			assert.Len(t, ev.ByzantineValidators, tc.byzantineVals)
+		})
+	}
+}
@@ -29,6 +29,17 @@ There are two buckets that evidence can be stored in: Pending & Committed.
 
 All evidence is proto encoded to disk.
 
+# Conflicting Headers
+
+Besides the evidence it receives, the pool can form light client attack evidence from a header
+conflicting with the chain of the node, e.g. received from a peer during block sync, with
+`ReportConflictingHeader`. The attack is classified against the header of the node at the same height
+(or its latest header for forward lunatic attacks): a lunatic attack if the conflicting header was not
+correctly derived from the state, an equivocation if both headers were committed in the same round and
+amnesia otherwise. For lunatic attacks, the common height is the highest one whose validators signed
+the conflicting header with at least 1/3 of their voting power. The evidence is then verified and added
+to the pool like any other.
+
 # Proposing
 
 When a new block is being proposed (in state/execution.go#CreateProposalBlock),
@@ -0,0 +1,239 @@
+package evidence
+
+import (
+	"bytes"
+	"fmt"
+
+	gogotypes "github.com/cosmos/gogoproto/types"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// DuplicateVoteType is the type of DuplicateVoteEvidence in filters.
+	DuplicateVoteType = "duplicate_vote"
+	// LightClientAttackType is the type of LightClientAttackEvidence in filters.
+	LightClientAttackType = "light_client_attack"
+
+	// compactionInterval is the number of heights between two compactions of the
+	// expired committed evidence.
+	compactionInterval = 1000
+)
+
+// Filter filters the pending evidence listed by ListPendingEvidence. Zero
+// values match any evidence.
+type Filter struct {
+	MinHeight        int64
+	MaxHeight        int64
+	ValidatorAddress crypto.Address
+	Type             string
+}
+
+func (f Filter) matches(ev types.Evidence) bool {
+	switch {
+	case f.MinHeight > 0 && ev.Height() < f.MinHeight:
+		return false
+	case f.MaxHeight > 0 && ev.Height() > f.MaxHeight:
+		return false
+	case f.Type != "" && f.Type != evidenceType(ev):
+		return false
+	}
+	if f.ValidatorAddress != nil {
+		for _, addr := range evidenceValidators(ev) {
+			if bytes.Equal(addr, f.ValidatorAddress) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ListPendingEvidence returns the pending evidence matching the filter, from
+// oldest to newest, skipping the first skip ones and returning at most limit
+// of them, along with the total number of evidence matching the filter.
+// Evidence is looked up by validator address using a secondary index.
+func (evpool *Pool) ListPendingEvidence(filter Filter, skip, limit int) ([]types.Evidence, int, error) {
+	var (
+		prefix = []byte{baseKeyPending}
+		start  = prefix
+	)
+	if filter.ValidatorAddress != nil {
+		prefix = keyValidatorPrefix(filter.ValidatorAddress)
+		start = prefix
+	}
+	if filter.MinHeight > 0 {
+		start = append(append([]byte{}, prefix...), bE(filter.MinHeight)...)
+	}
+
+	iter, err := evpool.evidenceStore.Iterator(start, prefixEnd(prefix))
+	if err != nil {
+		return nil, 0, fmt.Errorf("database error: %v", err)
+	}
+	defer iter.Close()
+
+	var (
+		evidence []types.Evidence
+		total    int
+	)
+	for ; iter.Valid(); iter.Next() {
+		evBytes := iter.Value()
+		if filter.ValidatorAddress != nil {
+			// the index points to the pending evidence
+			evBytes, err = evpool.evidenceStore.Get(iter.Value())
+			if err != nil {
+				return nil, 0, err
+			}
+			if evBytes == nil {
+				continue
+			}
+		}
+		ev, err := bytesToEv(evBytes)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.MaxHeight > 0 && ev.Height() > filter.MaxHeight {
+			break
+		}
+		if !filter.matches(ev) {
+			continue
+		}
+		if total >= skip && len(evidence) < limit {
+			evidence = append(evidence, ev)
+		}
+		total++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, 0, err
+	}
+	return evidence, total, nil
+}
+
+// indexPendingEvidence indexes the pending evidence by the addresses of its
+// validators.
+func (evpool *Pool) indexPendingEvidence(batch dbm.Batch, ev types.Evidence) error {
+	for _, addr := range evidenceValidators(ev) {
+		if err := batch.Set(keyValidator(addr, ev), keyPending(ev)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexPendingEvidence removes the pending evidence from the index of
+// validator addresses.
+func (evpool *Pool) unindexPendingEvidence(batch dbm.Batch, ev types.Evidence) error {
+	for _, addr := range evidenceValidators(ev) {
+		if err := batch.Delete(keyValidator(addr, ev)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeExpiredCommittedEvidence removes the committed evidence which has
+// expired, and can thus no longer be proposed, from the evidence store. It is
+// run every compactionInterval heights.
+func (evpool *Pool) removeExpiredCommittedEvidence() {
+	iter, err := dbm.IteratePrefix(evpool.evidenceStore, []byte{baseKeyCommitted})
+	if err != nil {
+		evpool.logger.Error("Unable to iterate over committed evidence", "err", err)
+		return
+	}
+	defer iter.Close()
+
+	batch := evpool.evidenceStore.NewBatch()
+	defer batch.Close()
+	var (
+		state   = evpool.State()
+		removed = 0
+	)
+	for ; iter.Valid(); iter.Next() {
+		var h gogotypes.Int64Value
+		if err := h.Unmarshal(iter.Value()); err != nil {
+			evpool.logger.Error("Unable to decode committed evidence height", "err", err)
+			continue
+		}
+		// committed evidence is ordered by height
+		if state.LastBlockHeight-h.Value <= state.ConsensusParams.Evidence.MaxAgeNumBlocks {
+			break
+		}
+		// evidence whose block was pruned can't be verified anymore
+		if meta := evpool.blockStore.LoadBlockMeta(h.Value); meta != nil && !evpool.isExpired(h.Value, meta.Header.Time) {
+			break
+		}
+		if err := batch.Delete(iter.Key()); err != nil {
+			evpool.logger.Error("Unable to delete committed evidence", "err", err)
+			return
+		}
+		removed++
+	}
+	if err := iter.Error(); err != nil {
+		evpool.logger.Error("Unable to iterate over committed evidence", "err", err)
+		return
+	}
+	if removed == 0 {
+		return
+	}
+	if err := batch.WriteSync(); err != nil {
+		evpool.logger.Error("Unable to delete expired committed evidence", "err", err)
+		return
+	}
+	evpool.logger.Debug("Removed expired committed evidence", "count", removed)
+}
+
+// evidenceType returns the type of the evidence used in filters.
+func evidenceType(ev types.Evidence) string {
+	switch ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		return DuplicateVoteType
+	case *types.LightClientAttackEvidence:
+		return LightClientAttackType
+	default:
+		return ""
+	}
+}
+
+// evidenceValidators returns the addresses of the validators the evidence is
+// against.
+func evidenceValidators(ev types.Evidence) []crypto.Address {
+	switch ev := ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		if ev.VoteA == nil {
+			return nil
+		}
+		return []crypto.Address{ev.VoteA.ValidatorAddress}
+	case *types.LightClientAttackEvidence:
+		addrs := make([]crypto.Address, 0, len(ev.ByzantineValidators))
+		for _, val := range ev.ByzantineValidators {
+			addrs = append(addrs, val.Address)
+		}
+		return addrs
+	default:
+		return nil
+	}
+}
+
+func keyValidatorPrefix(addr crypto.Address) []byte {
+	return append([]byte{baseKeyValidator}, addr...)
+}
+
+func keyValidator(addr crypto.Address, ev types.Evidence) []byte {
+	return append(keyValidatorPrefix(addr), keySuffix(ev)...)
+}
+
+// prefixEnd returns the end of the range of keys with the prefix, or nil if
+// there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package evidence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/evidence"
+	"github.com/cometbft/cometbft/evidence/mocks"
+	"github.com/cometbft/cometbft/libs/log"
+	smmocks "github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestListPendingEvidence(t *testing.T) {
+	var (
+		height     = int64(10)
+		stateStore = &smmocks.Store{}
+		evidenceDB = dbm.NewMemDB()
+		blockStore = &mocks.BlockStore{}
+	)
+
+	valSet, privVals := types.RandValidatorSet(2, 10)
+
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
+		&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}},
+	)
+	stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(valSet, nil)
+	stateStore.On("Load").Return(createState(height, valSet), nil)
+
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	// evidence against the first validator at heights 1 to 5 and against the
+	// second one at heights 3 to 4
+	var evList []types.Evidence
+	for h := int64(1); h <= 5; h++ {
+		ev := makeDuplicateVoteEvidence(t, valSet, privVals[0], h)
+		require.NoError(t, pool.AddEvidence(ev))
+		evList = append(evList, ev)
+	}
+	for h := int64(3); h <= 4; h++ {
+		ev := makeDuplicateVoteEvidence(t, valSet, privVals[1], h)
+		require.NoError(t, pool.AddEvidence(ev))
+		evList = append(evList, ev)
+	}
+	addr0, err := privVals[0].GetPubKey()
+	require.NoError(t, err)
+	addr1, err := privVals[1].GetPubKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		filter evidence.Filter
+		skip   int
+		limit  int
+		total  int
+		expEv  []types.Evidence
+	}{
+		{"all", evidence.Filter{}, 0, 100, 7, nil},
+		{"height range", evidence.Filter{MinHeight: 2, MaxHeight: 3}, 0, 100, 3, nil},
+		{"validator", evidence.Filter{ValidatorAddress: addr1.Address()}, 0, 100, 2, evList[5:]},
+		{"validator and height range", evidence.Filter{ValidatorAddress: addr0.Address(), MinHeight: 4},
+			0, 100, 2, evList[3:5]},
+		{"paginated", evidence.Filter{ValidatorAddress: addr0.Address()}, 1, 2, 5, evList[1:3]},
+		{"duplicate vote", evidence.Filter{Type: evidence.DuplicateVoteType}, 0, 100, 7, nil},
+		{"light client attack", evidence.Filter{Type: evidence.LightClientAttackType}, 0, 100, 0, nil},
+		{"unknown validator", evidence.Filter{ValidatorAddress: types.NewMockPV().PrivKey.PubKey().Address()},
+			0, 100, 0, nil},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			evs, total, err := pool.ListPendingEvidence(tc.filter, tc.skip, tc.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tc.total, total)
+			if tc.expEv != nil {
+				assert.Equal(t, tc.expEv, evs)
+			} else {
+				assert.Len(t, evs, total)
+			}
+		})
+	}
+
+	// the index is maintained when the evidence is committed
+	state := pool.State()
+	state.LastBlockHeight++
+	pool.Update(state, types.EvidenceList{evList[5]})
+	evs, total, err := pool.ListPendingEvidence(evidence.Filter{ValidatorAddress: addr1.Address()}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, evList[6:], evs)
+
+	// and rebuilt when the pool is restarted
+	pool, err = evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	_, total, err = pool.ListPendingEvidence(evidence.Filter{ValidatorAddress: addr0.Address()}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+}
+
+func TestCompactExpiredCommittedEvidence(t *testing.T) {
+	var (
+		height     = int64(21)
+		val        = types.NewMockPV()
+		evidenceDB = dbm.NewMemDB()
+		stateStore = initializeValidatorState(val, height)
+	)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore, err := initializeBlockStore(dbm.NewMemDB(), state, val.PrivKey.PubKey().Address())
+	require.NoError(t, err)
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	ev, err := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime.Add(21*time.Minute),
+		val, evidenceChainID)
+	require.NoError(t, err)
+	require.NoError(t, pool.AddEvidence(ev))
+
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
+	pool.Update(state, types.EvidenceList{ev})
+	assert.Equal(t, 1, countKeys(t, evidenceDB, 0x00), "committed evidence should be kept until it expires")
+
+	// the evidence has expired by the next compaction
+	state.LastBlockHeight = height + 1001
+	state.LastBlockTime = defaultEvidenceTime.Add(72 * time.Hour)
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 100
+	state.ConsensusParams.Evidence.MaxAgeDuration = time.Hour
+	pool.Update(state, nil)
+	assert.Zero(t, countKeys(t, evidenceDB, 0x00), "expired committed evidence should be removed")
+}
+
+func makeDuplicateVoteEvidence(
+	t *testing.T, valSet *types.ValidatorSet, val types.PrivValidator, height int64,
+) *types.DuplicateVoteEvidence {
+	t.Helper()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	valIdx, _ := valSet.GetByAddress(pubKey.Address())
+	voteA := makeVote(t, val, evidenceChainID, valIdx, height, 0, 2, makeBlockID([]byte("blockhash"), 1000,
+		[]byte("partshash")), defaultEvidenceTime)
+	voteB := makeVote(t, val, evidenceChainID, valIdx, height, 0, 2, makeBlockID([]byte("blockhash2"), 1000,
+		[]byte("partshash")), defaultEvidenceTime)
+	ev, err := types.NewDuplicateVoteEvidence(voteA, voteB, defaultEvidenceTime, valSet)
+	require.NoError(t, err)
+	return ev
+}
+
+func countKeys(t *testing.T, db dbm.DB, prefix byte) int {
+	t.Helper()
+	iter, err := dbm.IteratePrefix(db, []byte{prefix})
+	require.NoError(t, err)
+	defer iter.Close()
+	count := 0
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+	return count
+}
@@ -23,6 +23,7 @@ import (
 const (
 	baseKeyCommitted = byte(0x00)
 	baseKeyPending   = byte(0x01)
+	baseKeyValidator = byte(0x02) // index of pending evidence by validator address
 )
 
 // Pool maintains a pool of valid evidence to be broadcasted and committed
@@ -48,6 +49,9 @@ type Pool struct {
 
 	pruningHeight int64
 	pruningTime   time.Time
+
+	// height at which to next remove the expired committed evidence
+	compactionHeight int64
 }
 
 // NewPool creates an evidence pool. If using an existing evidence store,
@@ -76,8 +80,17 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		return nil, err
 	}
 	atomic.StoreUint32(&pool.evidenceSize, uint32(len(evList)))
+	// index the pending evidence, which may have been added before the index existed
+	batch := evidenceDB.NewBatch()
+	defer batch.Close()
 	for _, ev := range evList {
 		pool.evidenceList.PushBack(ev)
+		if err := pool.indexPendingEvidence(batch, ev); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return nil, fmt.Errorf("cannot index pending evidence: %w", err)
 	}
 
 	return pool, nil
@@ -128,6 +141,12 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 		state.LastBlockTime.After(evpool.pruningTime) {
 		evpool.pruningHeight, evpool.pruningTime = evpool.removeExpiredPendingEvidence()
 	}
+
+	// periodically remove the committed evidence which has expired
+	if state.LastBlockHeight >= evpool.compactionHeight {
+		evpool.removeExpiredCommittedEvidence()
+		evpool.compactionHeight = state.LastBlockHeight + compactionInterval
+	}
 }
 
 // AddEvidence checks the evidence is valid and adds it to the pool.
@@ -305,8 +324,15 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 
 	key := keyPending(ev)
 
-	err = evpool.evidenceStore.Set(key, evBytes)
-	if err != nil {
+	batch := evpool.evidenceStore.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(key, evBytes); err != nil {
+		return fmt.Errorf("can't persist evidence: %w", err)
+	}
+	if err := evpool.indexPendingEvidence(batch, ev); err != nil {
+		return fmt.Errorf("can't index evidence: %w", err)
+	}
+	if err := batch.Write(); err != nil {
 		return fmt.Errorf("can't persist evidence: %w", err)
 	}
 	atomic.AddUint32(&evpool.evidenceSize, 1)
@@ -315,7 +341,16 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 
 func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 	key := keyPending(evidence)
-	if err := evpool.evidenceStore.Delete(key); err != nil {
+	batch := evpool.evidenceStore.NewBatch()
+	defer batch.Close()
+	err := batch.Delete(key)
+	if err == nil {
+		err = evpool.unindexPendingEvidence(batch, evidence)
+	}
+	if err == nil {
+		err = batch.Write()
+	}
+	if err != nil {
 		evpool.logger.Error("Unable to delete pending evidence", "err", err)
 	} else {
 		atomic.AddUint32(&evpool.evidenceSize, ^uint32(0))
@@ -51,39 +51,54 @@ type Inspector struct {
 //
 //nolint:lll
 func New(cfg *config.RPCConfig, bs state.BlockStore, ss state.Store, txidx txindex.TxIndexer, blkidx indexer.BlockIndexer, lg log.Logger) *Inspector {
-	routes := rpc.Routes(*cfg, ss, bs, txidx, blkidx, logger)
-	eb := types.NewEventBus()
-	eb.SetLogger(logger.With("module", "events"))
+	routes := rpc.Routes(*cfg, ss, bs, txidx, blkidx, lg)
 	return &Inspector{
 		routes: routes,
 		config: cfg,
-		logger: logger,
+		logger: lg,
 		ss:     ss,
 		bs:     bs,
 	}
 }
 
 // NewFromConfig constructs an Inspector using the values defined in the passed in config.
+// The databases of the data directory are opened read-only, and can't be opened while the
+// node runs.
 func NewFromConfig(cfg *config.Config) (*Inspector, error) {
-	bsDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	dbProvider := ReadOnlyDBProvider(config.DefaultDBProvider)
+	bsDB, err := dbProvider(&config.DBContext{ID: "blockstore", Config: cfg})
 	if err != nil {
 		return nil, err
 	}
-	bs := store.NewBlockStore(bsDB)
-	sDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
+	var options []store.BlockStoreOption
+	if cfg.Storage.ColdStorageAfter > 0 {
+		coldDB, err := dbProvider(&config.DBContext{ID: config.ColdBlockStoreDBID, Config: cfg})
+		if err != nil {
+			bsDB.Close()
+			return nil, err
+		}
+		options = append(options, store.WithColdStore(coldDB))
+	}
+	bs := store.NewBlockStore(bsDB, options...)
+	sDB, err := dbProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
+		bs.Close()
 		return nil, err
 	}
+	ss := state.NewStore(sDB, state.StoreOptions{})
 	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
 	if err != nil {
+		bs.Close()
+		ss.Close()
 		return nil, err
 	}
-	txidx, blkidx, err := block.IndexerFromConfig(cfg, config.DefaultDBProvider, genDoc.ChainID)
+	txidx, blkidx, err := block.IndexerFromConfig(cfg, dbProvider, genDoc.ChainID)
 	if err != nil {
+		bs.Close()
+		ss.Close()
 		return nil, err
 	}
 	lg := logger.With("module", "inspect")
-	ss := state.NewStore(sDB, state.StoreOptions{})
 	return New(cfg.RPC, bs, ss, txidx, blkidx, lg), nil
 }
 
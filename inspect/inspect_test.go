@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	dbm "github.com/cometbft/cometbft-db"
+
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/inspect"
@@ -39,6 +41,47 @@ func TestInspectConstructor(t *testing.T) {
 
 }
 
+func TestInspectNodeRunning(t *testing.T) {
+	cfg := test.ResetTestRoot("test")
+	defer func() { _ = os.RemoveAll(cfg.RootDir) }()
+	cfg.DBBackend = "goleveldb"
+
+	// the node holds the lock of its databases while it runs
+	db, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = inspect.NewFromConfig(cfg)
+	require.ErrorContains(t, err, "is the node stopped?")
+}
+
+func TestReadOnlyDBProvider(t *testing.T) {
+	memDB := dbm.NewMemDB()
+	require.NoError(t, memDB.Set([]byte("key"), []byte("value")))
+	provider := inspect.ReadOnlyDBProvider(func(*config.DBContext) (dbm.DB, error) {
+		return memDB, nil
+	})
+	db, err := provider(&config.DBContext{ID: "test"})
+	require.NoError(t, err)
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	require.ErrorIs(t, db.Set([]byte("key"), []byte("other")), inspect.ErrReadOnly)
+	require.ErrorIs(t, db.SetSync([]byte("key"), []byte("other")), inspect.ErrReadOnly)
+	require.ErrorIs(t, db.Delete([]byte("key")), inspect.ErrReadOnly)
+	require.ErrorIs(t, db.DeleteSync([]byte("key")), inspect.ErrReadOnly)
+	batch := db.NewBatch()
+	require.ErrorIs(t, batch.Set([]byte("key"), []byte("other")), inspect.ErrReadOnly)
+	require.ErrorIs(t, batch.WriteSync(), inspect.ErrReadOnly)
+	require.NoError(t, batch.Close())
+
+	value, err = memDB.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
+
 func TestInspectRun(t *testing.T) {
 	cfg := test.ResetTestRoot("test")
 	t.Cleanup(leaktest.Check(t))
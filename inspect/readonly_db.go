@@ -0,0 +1,52 @@
+package inspect
+
+import (
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+// ErrReadOnly is returned when writing to a database opened by the Inspector.
+var ErrReadOnly = errors.New("the database is opened read-only")
+
+// ReadOnlyDBProvider returns a DBProvider opening the databases with provider
+// and refusing any write to them, so that the Inspector never modifies the data
+// directory of the node.
+func ReadOnlyDBProvider(provider config.DBProvider) config.DBProvider {
+	return func(ctx *config.DBContext) (dbm.DB, error) {
+		db, err := provider(ctx)
+		if err != nil {
+			// The databases can't be opened while the node runs.
+			return nil, fmt.Errorf("could not open the %s database, is the node stopped? %w", ctx.ID, err)
+		}
+		return readOnlyDB{DB: db}, nil
+	}
+}
+
+// readOnlyDB wraps a database, refusing the writes.
+type readOnlyDB struct {
+	dbm.DB
+}
+
+// ReadOnly reports that the database refuses the writes, e.g. for the tx
+// indexer not to update its older indexes.
+func (readOnlyDB) ReadOnly() bool { return true }
+
+func (readOnlyDB) Set([]byte, []byte) error     { return ErrReadOnly }
+func (readOnlyDB) SetSync([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyDB) Delete([]byte) error          { return ErrReadOnly }
+func (readOnlyDB) DeleteSync([]byte) error      { return ErrReadOnly }
+func (readOnlyDB) Compact([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyDB) NewBatch() dbm.Batch          { return readOnlyBatch{} }
+
+// readOnlyBatch is a batch refusing the writes.
+type readOnlyBatch struct{}
+
+func (readOnlyBatch) Set([]byte, []byte) error { return ErrReadOnly }
+func (readOnlyBatch) Delete([]byte) error      { return ErrReadOnly }
+func (readOnlyBatch) Write() error             { return ErrReadOnly }
+func (readOnlyBatch) WriteSync() error         { return ErrReadOnly }
+func (readOnlyBatch) Close() error             { return nil }
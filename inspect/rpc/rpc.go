@@ -36,18 +36,19 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, txidx txin
 		Logger:           logger,
 	}
 	return core.RoutesMap{
-		"blockchain":       server.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight"),
-		"consensus_params": server.NewRPCFunc(env.ConsensusParams, "height"),
-		"block":            server.NewRPCFunc(env.Block, "height"),
-		"block_by_hash":    server.NewRPCFunc(env.BlockByHash, "hash"),
-		"block_results":    server.NewRPCFunc(env.BlockResults, "height"),
-		"commit":           server.NewRPCFunc(env.Commit, "height"),
-		"header":           server.NewRPCFunc(env.Header, "height"),
-		"header_by_hash":   server.NewRPCFunc(env.HeaderByHash, "hash"),
-		"validators":       server.NewRPCFunc(env.Validators, "height,page,per_page"),
-		"tx":               server.NewRPCFunc(env.Tx, "hash,prove"),
-		"tx_search":        server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
+		"blockchain":            server.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight"),
+		"consensus_params":      server.NewRPCFunc(env.ConsensusParams, "height"),
+		"block":                 server.NewRPCFunc(env.Block, "height"),
+		"block_by_hash":         server.NewRPCFunc(env.BlockByHash, "hash"),
+		"block_results":         server.NewRPCFunc(env.BlockResults, "height"),
+		"block_results_by_hash": server.NewRPCFunc(env.BlockResultsByHash, "hash"),
+		"commit":                server.NewRPCFunc(env.Commit, "height"),
+		"header":                server.NewRPCFunc(env.Header, "height"),
+		"header_by_hash":        server.NewRPCFunc(env.HeaderByHash, "hash"),
+		"validators":            server.NewRPCFunc(env.Validators, "height,page,per_page,time"),
+		"tx":                    server.NewRPCFunc(env.Tx, "hash,prove"),
+		"tx_search":             server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by,cursor"),
+		"block_search":          server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,cursor"),
 	}
 }
 
@@ -72,9 +73,12 @@ func Handler(rpcConfig *config.RPCConfig, routes core.RoutesMap, logger log.Logg
 
 func addCORSHandler(rpcConfig *config.RPCConfig, h http.Handler) http.Handler {
 	corsMiddleware := cors.New(cors.Options{
-		AllowedOrigins: rpcConfig.CORSAllowedOrigins,
-		AllowedMethods: rpcConfig.CORSAllowedMethods,
-		AllowedHeaders: rpcConfig.CORSAllowedHeaders,
+		AllowedOrigins:   rpcConfig.CORSAllowedOrigins,
+		AllowedMethods:   rpcConfig.CORSAllowedMethods,
+		AllowedHeaders:   rpcConfig.CORSAllowedHeaders,
+		ExposedHeaders:   rpcConfig.CORSExposedHeaders,
+		AllowCredentials: rpcConfig.CORSAllowCredentials,
+		MaxAge:           int(rpcConfig.CORSMaxAge.Seconds()),
 	})
 	h = corsMiddleware.Handler(h)
 	return h
@@ -118,6 +122,8 @@ func serverRPCConfig(r *config.RPCConfig) *server.Config {
 	cfg := server.DefaultConfig()
 	cfg.MaxBodyBytes = r.MaxBodyBytes
 	cfg.MaxHeaderBytes = r.MaxHeaderBytes
+	cfg.Compression = r.Compression
+	cfg.H2C = r.H2C
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -90,3 +91,32 @@ func MakeHeader(t *testing.T, h *types.Header) *types.Header {
 
 	return h
 }
+
+// MakeLightBlocks returns signed headers of the chain with the chain ID, and
+// the validator sets, at the given heights, e.g. for a mock light provider.
+func MakeLightBlocks(
+	t *testing.T,
+	chainID string,
+	heights ...int64,
+) (map[int64]*types.SignedHeader, map[int64]*types.ValidatorSet) {
+	t.Helper()
+	valSet, privVals := ValidatorSet(context.Background(), t, 2, 10)
+	headers := make(map[int64]*types.SignedHeader, len(heights))
+	vals := make(map[int64]*types.ValidatorSet, len(heights))
+	for _, height := range heights {
+		header := MakeHeader(t, &types.Header{
+			ChainID:            chainID,
+			Height:             height,
+			Time:               time.Now(),
+			ValidatorsHash:     valSet.Hash(),
+			NextValidatorsHash: valSet.Hash(),
+			ProposerAddress:    valSet.Proposer.Address,
+		})
+		commit, err := MakeCommit(MakeBlockIDWithHash(header.Hash()), height, 0, valSet, privVals,
+			chainID, time.Now())
+		require.NoError(t, err)
+		headers[height] = &types.SignedHeader{Header: header, Commit: commit}
+		vals[height] = valSet
+	}
+	return headers, vals
+}
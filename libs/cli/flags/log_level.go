@@ -88,3 +88,63 @@ func ParseLogLevel(lvl string, logger log.Logger, defaultLogLevelValue string) (
 
 	return log.NewFilter(logger, options...), nil
 }
+
+// MergeLogLevels returns the complex log level lvl, with the module:level pairs
+// of override replacing the ones of the same modules, and the modules not in
+// lvl added at the end. A simple one word level in override replaces the
+// level of all the other modules.
+//
+// Example:
+//
+//	MergeLogLevels("consensus:debug,*:info", "p2p:error") // "consensus:debug,*:info,p2p:error"
+func MergeLogLevels(lvl, override string) (string, error) {
+	pairs, err := parseLevelPairs(lvl)
+	if err != nil {
+		return "", err
+	}
+	overrides, err := parseLevelPairs(override)
+	if err != nil {
+		return "", err
+	}
+	for _, o := range overrides {
+		replaced := false
+		for i := range pairs {
+			if pairs[i][0] == o[0] {
+				pairs[i][1] = o[1]
+				replaced = true
+			}
+		}
+		if !replaced {
+			pairs = append(pairs, o)
+		}
+	}
+
+	list := make([]string, len(pairs))
+	for i, p := range pairs {
+		list[i] = p[0] + ":" + p[1]
+	}
+	return strings.Join(list, ","), nil
+}
+
+// parseLevelPairs parses the module:level pairs of a complex log level.
+func parseLevelPairs(lvl string) ([][2]string, error) {
+	if lvl == "" {
+		return nil, errors.New("empty log level")
+	}
+	if !strings.Contains(lvl, ":") {
+		lvl = defaultLogLevelKey + ":" + lvl
+	}
+
+	var pairs [][2]string
+	for _, item := range strings.Split(lvl, ",") {
+		moduleAndLevel := strings.Split(item, ":")
+		if len(moduleAndLevel) != 2 || moduleAndLevel[0] == "" {
+			return nil, fmt.Errorf("expected list in a form of \"module:level\" pairs, given pair %s, list %s", item, lvl)
+		}
+		if _, err := log.AllowLevel(moduleAndLevel[1]); err != nil {
+			return nil, fmt.Errorf("invalid log level (pair %s, list %s): %w", item, lvl, err)
+		}
+		pairs = append(pairs, [2]string{moduleAndLevel[0], moduleAndLevel[1]})
+	}
+	return pairs, nil
+}
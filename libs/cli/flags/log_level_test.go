@@ -92,3 +92,31 @@ func TestParseLogLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeLogLevels(t *testing.T) {
+	testCases := []struct {
+		lvl, override, expected string
+	}{
+		{"info", "consensus:debug", "*:info,consensus:debug"},
+		{"consensus:debug,*:info", "p2p:error", "consensus:debug,*:info,p2p:error"},
+		{"consensus:debug,*:info", "consensus:error,mempool:none", "consensus:error,*:info,mempool:none"},
+		{"consensus:debug,*:info", "error", "consensus:debug,*:error"},
+		{"mempool:error", "*:debug", "mempool:error,*:debug"},
+	}
+	for _, tc := range testCases {
+		have, err := cmtflags.MergeLogLevels(tc.lvl, tc.override)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != tc.expected {
+			t.Errorf("\nwant '%s'\nhave '%s'\nlevel '%s', override '%s'", tc.expected, have, tc.lvl, tc.override)
+		}
+	}
+
+	incorrectOverrides := []string{"", "some", "p2p:some", ":debug", "p2p:debug,"}
+	for _, override := range incorrectOverrides {
+		if _, err := cmtflags.MergeLogLevels("info", override); err == nil {
+			t.Fatalf("Expected %s to produce error", override)
+		}
+	}
+}
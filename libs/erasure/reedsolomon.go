@@ -0,0 +1,260 @@
+// Package erasure implements a systematic Reed-Solomon erasure code over
+// GF(2^8): k data shards are extended with m parity shards, and any k of the
+// k+m shards are enough to reconstruct the data shards.
+package erasure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum number of data and parity shards of a code.
+const MaxShards = 256
+
+var (
+	// ErrTooFewShards is returned when reconstructing from less shards than
+	// the data shards.
+	ErrTooFewShards = errors.New("too few shards to reconstruct the data")
+	// ErrShardSize is returned when the shards don't have the same size.
+	ErrShardSize = errors.New("shards must have the same size")
+)
+
+// The arithmetic of GF(2^8), with the polynomial x^8+x^4+x^3+x^2+1.
+var (
+	gfExp [2 * 255]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfExp[i+255] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[255-gfLog[a]]
+}
+
+// gfPow returns a^n, with 0^0 = 1.
+func gfPow(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]*n%255]
+}
+
+// mulAdd adds c*in to out.
+func mulAdd(c byte, in, out []byte) {
+	if c == 0 {
+		return
+	}
+	logC := gfLog[c]
+	for i, b := range in {
+		if b != 0 {
+			out[i] ^= gfExp[logC+gfLog[b]]
+		}
+	}
+}
+
+type matrix [][]byte
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for r := range m {
+		m[r] = make([]byte, cols)
+	}
+	return m
+}
+
+func (m matrix) mul(o matrix) matrix {
+	res := newMatrix(len(m), len(o[0]))
+	for r := range m {
+		for c := range o[0] {
+			var v byte
+			for i := range o {
+				v ^= gfMul(m[r][i], o[i][c])
+			}
+			res[r][c] = v
+		}
+	}
+	return res
+}
+
+// invert returns the inverse of the square matrix, by Gauss-Jordan
+// elimination.
+func (m matrix) invert() (matrix, error) {
+	n := len(m)
+	work := newMatrix(n, 2*n)
+	for r := range m {
+		copy(work[r], m[r])
+		work[r][n+r] = 1
+	}
+	for c := 0; c < n; c++ {
+		pivot := c
+		for pivot < n && work[pivot][c] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("singular matrix")
+		}
+		work[c], work[pivot] = work[pivot], work[c]
+		if inv := gfInv(work[c][c]); inv != 1 {
+			for i := range work[c] {
+				work[c][i] = gfMul(work[c][i], inv)
+			}
+		}
+		for r := 0; r < n; r++ {
+			if r != c && work[r][c] != 0 {
+				f := work[r][c]
+				for i := range work[r] {
+					work[r][i] ^= gfMul(f, work[c][i])
+				}
+			}
+		}
+	}
+	inv := newMatrix(n, n)
+	for r := range inv {
+		copy(inv[r], work[r][n:])
+	}
+	return inv, nil
+}
+
+// Code is a Reed-Solomon code of a number of data and parity shards.
+type Code struct {
+	dataShards   int
+	parityShards int
+	// encoding is the (data+parity)xdata encoding matrix, whose top is the
+	// identity, so that the data shards are kept as they are.
+	encoding matrix
+}
+
+// New returns the code of the given numbers of data and parity shards, at
+// most MaxShards in total.
+func New(dataShards, parityShards int) (*Code, error) {
+	if dataShards <= 0 || parityShards <= 0 {
+		return nil, fmt.Errorf("invalid number of shards %d+%d", dataShards, parityShards)
+	}
+	if dataShards+parityShards > MaxShards {
+		return nil, fmt.Errorf("%d+%d shards, more than the maximum of %d", dataShards, parityShards, MaxShards)
+	}
+
+	// Any dataShards rows of a Vandermonde matrix are independent, which
+	// stays true once it's multiplied by the inverse of its top.
+	total := dataShards + parityShards
+	vandermonde := newMatrix(total, dataShards)
+	for r := range vandermonde {
+		for c := range vandermonde[r] {
+			vandermonde[r][c] = gfPow(byte(r), c)
+		}
+	}
+	topInv, err := vandermonde[:dataShards].invert()
+	if err != nil {
+		return nil, err
+	}
+	return &Code{
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		encoding:     vandermonde.mul(topInv),
+	}, nil
+}
+
+// DataShards returns the number of data shards of the code.
+func (c *Code) DataShards() int { return c.dataShards }
+
+// ParityShards returns the number of parity shards of the code.
+func (c *Code) ParityShards() int { return c.parityShards }
+
+// Encode returns the parity shards of the data shards, which must have the
+// same size.
+func (c *Code) Encode(data [][]byte) ([][]byte, error) {
+	if len(data) != c.dataShards {
+		return nil, fmt.Errorf("expected %d data shards, got %d", c.dataShards, len(data))
+	}
+	size := len(data[0])
+	for _, shard := range data {
+		if len(shard) != size {
+			return nil, ErrShardSize
+		}
+	}
+
+	parity := make([][]byte, c.parityShards)
+	for i := range parity {
+		parity[i] = make([]byte, size)
+		for j, shard := range data {
+			mulAdd(c.encoding[c.dataShards+i][j], shard, parity[i])
+		}
+	}
+	return parity, nil
+}
+
+// ReconstructData reconstructs the missing data shards, which are nil, from
+// the shards present, data shards first and then parity shards. At least as
+// many shards as the data shards must be present, with the same size.
+func (c *Code) ReconstructData(shards [][]byte) error {
+	if len(shards) != c.dataShards+c.parityShards {
+		return fmt.Errorf("expected %d shards, got %d", c.dataShards+c.parityShards, len(shards))
+	}
+	missing := false
+	for _, shard := range shards[:c.dataShards] {
+		missing = missing || shard == nil
+	}
+	if !missing {
+		return nil
+	}
+
+	// Solve the data from the rows of the encoding matrix of the first
+	// dataShards shards present.
+	size := -1
+	rows := make(matrix, 0, c.dataShards)
+	present := make([][]byte, 0, c.dataShards)
+	for i, shard := range shards {
+		if shard == nil {
+			continue
+		}
+		if size == -1 {
+			size = len(shard)
+		} else if len(shard) != size {
+			return ErrShardSize
+		}
+		rows = append(rows, c.encoding[i])
+		present = append(present, shard)
+		if len(rows) == c.dataShards {
+			break
+		}
+	}
+	if len(rows) < c.dataShards {
+		return ErrTooFewShards
+	}
+	decoding, err := rows.invert()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < c.dataShards; i++ {
+		if shards[i] != nil {
+			continue
+		}
+		shard := make([]byte, size)
+		for j, in := range present {
+			mulAdd(decoding[i][j], in, shard)
+		}
+		shards[i] = shard
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package erasure
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode(t *testing.T) {
+	_, err := New(0, 1)
+	require.Error(t, err)
+	_, err = New(200, 57)
+	require.Error(t, err)
+
+	for _, tc := range []struct{ data, parity int }{{1, 1}, {4, 2}, {10, 10}, {128, 128}} {
+		code, err := New(tc.data, tc.parity)
+		require.NoError(t, err)
+
+		data := make([][]byte, tc.data)
+		for i := range data {
+			data[i] = make([]byte, 100)
+			rand.Read(data[i]) //nolint:gosec
+		}
+		parity, err := code.Encode(data)
+		require.NoError(t, err)
+		require.Len(t, parity, tc.parity)
+
+		// any data shards present are enough
+		for attempt := 0; attempt < 10; attempt++ {
+			shards := append(append([][]byte{}, data...), parity...)
+			for _, i := range rand.Perm(len(shards))[:tc.parity] {
+				shards[i] = nil
+			}
+			require.NoError(t, code.ReconstructData(shards))
+			assert.Equal(t, data, shards[:tc.data])
+		}
+
+		// but not less
+		shards := append(append([][]byte{}, data...), parity...)
+		for _, i := range rand.Perm(len(shards))[:tc.parity+1] {
+			shards[i] = nil
+		}
+		assert.ErrorIs(t, code.ReconstructData(shards), ErrTooFewShards)
+	}
+}
+
+func TestCodeShardSize(t *testing.T) {
+	code, err := New(2, 1)
+	require.NoError(t, err)
+	_, err = code.Encode([][]byte{make([]byte, 2), make([]byte, 3)})
+	assert.ErrorIs(t, err, ErrShardSize)
+	err = code.ReconstructData([][]byte{nil, make([]byte, 2), make([]byte, 3)})
+	assert.ErrorIs(t, err, ErrShardSize)
+}
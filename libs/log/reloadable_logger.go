@@ -0,0 +1,93 @@
+package log
+
+import (
+	"sync/atomic"
+)
+
+// LevelSetter is implemented by the loggers whose level can be changed at
+// runtime.
+type LevelSetter interface {
+	SetLevel(level string) error
+}
+
+// ReloadableLogger is a Logger whose level, along with the one of the loggers
+// derived from it with With, can be changed at runtime with SetLevel, e.g.
+// upon a reload of the configuration.
+type ReloadableLogger struct {
+	root    *reloadableRoot
+	keyvals []interface{}
+	// the logger of the current level with keyvals
+	cached atomic.Pointer[cachedLogger]
+}
+
+var _ LevelSetter = (*ReloadableLogger)(nil)
+
+type reloadableRoot struct {
+	newLogger func(level string) (Logger, error)
+	current   atomic.Pointer[leveledLogger]
+}
+
+// leveledLogger is the logger of a level, replaced upon every SetLevel.
+type leveledLogger struct {
+	Logger
+}
+
+type cachedLogger struct {
+	root   *leveledLogger
+	logger Logger
+}
+
+// NewReloadableLogger returns a ReloadableLogger of the given level, where
+// newLogger returns the logger of a level, e.g. filtering the logs below it.
+func NewReloadableLogger(level string, newLogger func(level string) (Logger, error)) (*ReloadableLogger, error) {
+	l := &ReloadableLogger{root: &reloadableRoot{newLogger: newLogger}}
+	if err := l.SetLevel(level); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetLevel changes the level of the logger, and of all the loggers derived
+// from the same ReloadableLogger.
+func (l *ReloadableLogger) SetLevel(level string) error {
+	logger, err := l.root.newLogger(level)
+	if err != nil {
+		return err
+	}
+	l.root.current.Store(&leveledLogger{logger})
+	return nil
+}
+
+func (l *ReloadableLogger) logger() Logger {
+	root := l.root.current.Load()
+	if c := l.cached.Load(); c != nil && c.root == root {
+		return c.logger
+	}
+	var logger Logger = root
+	if len(l.keyvals) > 0 {
+		logger = root.With(l.keyvals...)
+	}
+	l.cached.Store(&cachedLogger{root: root, logger: logger})
+	return logger
+}
+
+func (l *ReloadableLogger) Debug(msg string, keyvals ...interface{}) {
+	l.logger().Debug(msg, keyvals...)
+}
+
+func (l *ReloadableLogger) Info(msg string, keyvals ...interface{}) {
+	l.logger().Info(msg, keyvals...)
+}
+
+func (l *ReloadableLogger) Error(msg string, keyvals ...interface{}) {
+	l.logger().Error(msg, keyvals...)
+}
+
+// With implements Logger by returning a ReloadableLogger following the level
+// of l.
+func (l *ReloadableLogger) With(keyvals ...interface{}) Logger {
+	kvs := make([]interface{}, 0, len(l.keyvals)+len(keyvals))
+	kvs = append(kvs, l.keyvals...)
+	kvs = append(kvs, keyvals...)
+	return &ReloadableLogger{root: l.root, keyvals: kvs}
+}
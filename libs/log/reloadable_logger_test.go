@@ -0,0 +1,55 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestReloadableLogger(t *testing.T) {
+	var buf bytes.Buffer
+	next := log.NewTMJSONLoggerNoTS(&buf)
+	newLogger := func(level string) (log.Logger, error) {
+		switch level {
+		case "info":
+			return log.NewFilter(next, log.AllowInfo()), nil
+		case "debug":
+			return log.NewFilter(next, log.AllowDebug()), nil
+		case "crypto:debug":
+			return log.NewFilter(next, log.AllowInfo(), log.AllowDebugWith("module", "crypto")), nil
+		}
+		return nil, errors.New("unknown level")
+	}
+
+	logger, err := log.NewReloadableLogger("info", newLogger)
+	require.NoError(t, err)
+	crypto := logger.With("module", "crypto")
+	logDebug := func() string {
+		buf.Reset()
+		logger.Debug("root")
+		crypto.Debug("crypto")
+		return strings.TrimSpace(buf.String())
+	}
+
+	assert.Empty(t, logDebug())
+
+	require.NoError(t, logger.SetLevel("debug"))
+	assert.Equal(t, strings.Join([]string{
+		`{"_msg":"root","level":"debug"}`,
+		`{"_msg":"crypto","level":"debug","module":"crypto"}`,
+	}, "\n"), logDebug())
+
+	// the levels by module apply to the derived loggers
+	require.NoError(t, crypto.(log.LevelSetter).SetLevel("crypto:debug"))
+	assert.Equal(t, `{"_msg":"crypto","level":"debug","module":"crypto"}`, logDebug())
+
+	// the level is unchanged upon an error
+	require.Error(t, logger.SetLevel("verbose"))
+	assert.Equal(t, `{"_msg":"crypto","level":"debug","module":"crypto"}`, logDebug())
+}
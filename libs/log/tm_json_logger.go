@@ -1,24 +1,138 @@
 package log
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io"
+	"reflect"
+	"strings"
 
 	kitlog "github.com/go-kit/log"
 )
 
+const tsKey = "ts"
+
 // NewTMJSONLogger returns a Logger that encodes keyvals to the Writer as a
 // single JSON object. Each log event produces no more than one call to
 // w.Write. The passed Writer must be safe for concurrent use by multiple
 // goroutines if the returned Logger will be used concurrently.
+//
+// The objects have the following fields, sorted by name:
+//
+//   - "_msg", the message;
+//   - "level", "debug", "info" or "error";
+//   - "module", the module logging the event, e.g. "consensus", if any;
+//   - "ts", the time of the event in UTC, in RFC 3339 format;
+//   - the keyvals of the event, where the values which are errors or
+//     fmt.Stringers are encoded as strings, byte slices as uppercase hex
+//     strings, like the TM format logger does, and the other ones with
+//     encoding/json.
+//
+// The keyvals named like "_msg", "level" and "ts" are prefixed with "_", so
+// that they don't replace the fields above.
 func NewTMJSONLogger(w io.Writer) Logger {
-	logger := kitlog.NewJSONLogger(w)
-	logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC)
+	logger := newJSONLogger(w)
+	logger = kitlog.With(logger, tsKey, kitlog.DefaultTimestampUTC)
 	return &tmLogger{logger}
 }
 
 // NewTMJSONLoggerNoTS is the same as NewTMJSONLogger, but without the
 // timestamp.
 func NewTMJSONLoggerNoTS(w io.Writer) Logger {
-	logger := kitlog.NewJSONLogger(w)
-	return &tmLogger{logger}
+	return &tmLogger{newJSONLogger(w)}
+}
+
+type jsonLogger struct {
+	io.Writer
+}
+
+func newJSONLogger(w io.Writer) kitlog.Logger {
+	return &jsonLogger{w}
+}
+
+func (l *jsonLogger) Log(keyvals ...interface{}) error {
+	m := make(map[string]interface{}, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := jsonKey(keyvals[i])
+		var v interface{} = kitlog.ErrMissingValue
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		// The first fields with a reserved name are the ones set by the logger.
+		if isReservedKey(key) {
+			for _, ok := m[key]; ok; _, ok = m[key] {
+				key = "_" + key
+			}
+		}
+		m[key] = jsonValue(v)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	_, err := l.Writer.Write(buf.Bytes())
+	return err
+}
+
+func isReservedKey(key string) bool {
+	return key == tsKey || key == "level" || key == msgKey
+}
+
+func jsonKey(k interface{}) string {
+	switch x := k.(type) {
+	case string:
+		return x
+	case fmt.Stringer:
+		return safeString(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func jsonValue(v interface{}) interface{} {
+	// json.Marshaler and encoding.TextMarshaler take priority over
+	// err.Error() and v.String().
+	switch x := v.(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return v
+	case error:
+		return safeError(x)
+	case fmt.Stringer:
+		return safeString(x)
+	case []byte:
+		return strings.ToUpper(hex.EncodeToString(x))
+	}
+	return v
+}
+
+func safeString(str fmt.Stringer) (s string) {
+	defer func() {
+		if panicVal := recover(); panicVal != nil {
+			if v := reflect.ValueOf(str); v.Kind() == reflect.Ptr && v.IsNil() {
+				s = "NULL"
+			} else {
+				s = fmt.Sprintf("PANIC in String method: %v", panicVal)
+			}
+		}
+	}()
+	return str.String()
+}
+
+func safeError(err error) (s interface{}) {
+	defer func() {
+		if panicVal := recover(); panicVal != nil {
+			if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+				s = nil
+			} else {
+				s = fmt.Sprintf("PANIC in Error method: %v", panicVal)
+			}
+		}
+	}()
+	return err.Error()
 }
@@ -0,0 +1,55 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+type stringer struct{ s string }
+
+func (s *stringer) String() string { return s.s }
+
+func TestTMJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewTMJSONLoggerNoTS(&buf).With("module", "consensus")
+
+	logger.Info("Committed block",
+		"height", 42,
+		"hash", []byte{0xde, 0xad, 0xbe, 0xef},
+		"err", errors.New("failure"),
+		"peer", &stringer{"peer1"},
+		"nil", (*stringer)(nil),
+		"level", "mine",
+		"_msg", "mine")
+	assert.Equal(t,
+		`{"__msg":"mine","_level":"mine","_msg":"Committed block","err":"failure",`+
+			`"hash":"DEADBEEF","height":42,"level":"info","module":"consensus","nil":"NULL","peer":"peer1"}`,
+		strings.TrimSpace(buf.String()))
+
+	buf.Reset()
+	logger.With("module", "p2p").Error("Stopping peer", "missing")
+	assert.Equal(t,
+		`{"_msg":"Stopping peer","level":"error","missing":"(MISSING)","module":"p2p"}`,
+		strings.TrimSpace(buf.String()))
+}
+
+func TestTMJSONLoggerTimestamp(t *testing.T) {
+	var buf bytes.Buffer
+	log.NewTMJSONLogger(&buf).Info("foo", "ts", "mine")
+
+	var event map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+	assert.Equal(t, "mine", event["_ts"])
+	ts, err := time.Parse(time.RFC3339Nano, event["ts"])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ts, time.Minute)
+}
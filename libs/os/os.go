@@ -31,6 +31,19 @@ func TrapSignal(logger logger, cb func()) {
 	}()
 }
 
+// TrapHangupSignal catches the SIGHUP and executes cb function, every time it
+// is received, e.g. to reload the configuration.
+func TrapHangupSignal(logger logger, cb func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for sig := range c {
+			logger.Info("signal trapped", "msg", log.NewLazySprintf("captured %v", sig))
+			cb()
+		}
+	}()
+}
+
 // Kill the running process by sending itself SIGTERM.
 func Kill() error {
 	p, err := os.FindProcess(os.Getpid())
@@ -0,0 +1,73 @@
+// Package protojson encodes the protobuf messages of CometBFT in the canonical
+// proto3 JSON form: lowerCamelCase field names, base64 bytes, 64-bit integers
+// as strings, enums as their names and timestamps in RFC 3339, unlike the
+// encoding of libs/json, which follows the Go types with snake_case names.
+//
+// See https://protobuf.dev/programming-guides/proto3/#json.
+package protojson
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+var (
+	marshaler   = &jsonpb.Marshaler{}
+	unmarshaler = &jsonpb.Unmarshaler{}
+)
+
+// Marshal returns the canonical proto3 JSON encoding of the message, which
+// omits the fields of default value.
+func Marshal(msg proto.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := marshaler.Marshal(&buf, msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalIndent is like Marshal, with each field on its own line, indented
+// by the given indent.
+func MarshalIndent(msg proto.Message, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	m := *marshaler
+	m.Indent = indent
+	if err := m.Marshal(&buf, msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the proto3 JSON encoding of the message, with either the
+// lowerCamelCase or the original names of the fields. Unknown fields are
+// rejected.
+func Unmarshal(bz []byte, msg proto.Message) error {
+	return unmarshaler.Unmarshal(bytes.NewReader(bz), msg)
+}
+
+// Message wraps a protobuf message to implement json.Marshaler and
+// json.Unmarshaler with its canonical proto3 JSON encoding, e.g. to embed it
+// in a Go type encoded with encoding/json or libs/json.
+type Message struct {
+	proto.Message
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Message) MarshalJSON() ([]byte, error) {
+	if m.Message == nil {
+		return []byte("null"), nil
+	}
+	return Marshal(m.Message)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The message must be set to the
+// message to decode into.
+func (m *Message) UnmarshalJSON(bz []byte) error {
+	if m.Message == nil {
+		return errors.New("protojson: unmarshal into a nil message")
+	}
+	return Unmarshal(bz, m.Message)
+}
@@ -0,0 +1,75 @@
+package protojson
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+func TestMarshal(t *testing.T) {
+	vote := cmtproto.Vote{
+		Type:   cmtproto.PrevoteType,
+		Height: 10,
+		Round:  1,
+		BlockID: cmtproto.BlockID{
+			Hash:          []byte{1, 2, 3},
+			PartSetHeader: cmtproto.PartSetHeader{Total: 2, Hash: []byte{4, 5}},
+		},
+		Timestamp:        time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC),
+		ValidatorAddress: []byte{6},
+	}
+
+	bz, err := Marshal(&vote)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "SIGNED_MSG_TYPE_PREVOTE",
+		"height": "10",
+		"round": 1,
+		"blockId": {"hash": "AQID", "partSetHeader": {"total": 2, "hash": "BAU="}},
+		"timestamp": "2023-01-02T03:04:05.000000006Z",
+		"validatorAddress": "Bg=="
+	}`, string(bz))
+
+	var decoded cmtproto.Vote
+	require.NoError(t, Unmarshal(bz, &decoded))
+	assert.Equal(t, vote, decoded)
+
+	// the original field names are accepted too
+	decoded = cmtproto.Vote{}
+	require.NoError(t, Unmarshal([]byte(`{"height": "10", "validator_address": "Bg=="}`), &decoded))
+	assert.EqualValues(t, 10, decoded.Height)
+	assert.Equal(t, []byte{6}, decoded.ValidatorAddress)
+
+	// but not unknown fields
+	assert.Error(t, Unmarshal([]byte(`{"unknown": 1}`), &decoded))
+
+	indented, err := MarshalIndent(&vote, "  ")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(bz), string(indented))
+}
+
+func TestMessage(t *testing.T) {
+	type result struct {
+		Header Message `json:"header"`
+	}
+	header := cmtproto.Header{ChainID: "test-chain", Height: 3}
+
+	bz, err := json.Marshal(result{Header: Message{&header}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"header": {"version": {}, "chainId": "test-chain", "height": "3",
+		"time": "0001-01-01T00:00:00Z", "lastBlockId": {"partSetHeader": {}}}}`, string(bz))
+
+	decoded := result{Header: Message{&cmtproto.Header{}}}
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	assert.Equal(t, &header, decoded.Header.Message)
+
+	bz, err = json.Marshal(result{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"header": null}`, string(bz))
+	assert.Error(t, json.Unmarshal([]byte(`{"header": {}}`), &result{}))
+}
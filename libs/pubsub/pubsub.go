@@ -59,6 +59,11 @@ var (
 	// ErrAlreadySubscribed is returned when a client tries to subscribe twice or
 	// more using the same query.
 	ErrAlreadySubscribed = errors.New("already subscribed")
+
+	// ErrReplayUnavailable is returned when a client tries to resume a
+	// subscription after a sequence number whose following messages are no
+	// longer (or not yet) in the replay buffer.
+	ErrReplayUnavailable = errors.New("messages to resume from are no longer available")
 )
 
 // Query defines an interface for a query to be used for subscribing. A query
@@ -80,6 +85,10 @@ type cmd struct {
 	subscription *Subscription
 	clientID     string
 
+	// subscribe from a sequence number
+	after *uint64
+	errc  chan error
+
 	// publish
 	msg    interface{}
 	events map[string][]string
@@ -93,6 +102,11 @@ type Server struct {
 	cmds    chan cmd
 	cmdsCap int
 
+	// number of the last published messages kept to resume subscriptions
+	replayCap int
+	// sequence number before the one of the first published message
+	initialSeq uint64
+
 	// check if we have subscription before
 	// subscribing or unsubscribing
 	mtx           cmtsync.RWMutex
@@ -133,6 +147,29 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// ReplayCapacity allows you to specify the number of the last published
+// messages the server keeps in memory, so that a client can resume a
+// subscription with SubscribeFrom after a brief disconnect without missing
+// any message. If not set, subscriptions can't be resumed.
+func ReplayCapacity(cap int) Option {
+	return func(s *Server) {
+		if cap > 0 {
+			s.replayCap = cap
+		}
+	}
+}
+
+// InitialSequence sets the sequence number before the one of the first
+// published message, 0 by default. Seeding it with a value greater than the
+// last sequence number of the previous run, e.g. the start time, keeps a
+// client resuming a subscription of the previous run from getting the messages
+// of the new one with the same sequence numbers.
+func InitialSequence(seq uint64) Option {
+	return func(s *Server) {
+		s.initialSeq = seq
+	}
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -159,17 +196,47 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, outCap, nil)
+}
+
+// SubscribeFrom does the same as Subscribe, except that the messages published
+// after the one with the given sequence number, and matching the query, are
+// sent to the subscription before any newly published message.
+//
+// ErrReplayUnavailable is returned if some of these messages are no longer in
+// the replay buffer (see ReplayCapacity), and ErrOutOfCapacity if they don't
+// fit in the subscription's Out channel.
+func (s *Server) SubscribeFrom(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	after uint64,
+	outCapacity ...int) (*Subscription, error) {
+	outCap := 1
+	if len(outCapacity) > 0 {
+		if outCapacity[0] <= 0 {
+			panic("Negative or zero capacity")
+		}
+		outCap = outCapacity[0]
+	}
+
+	return s.subscribe(ctx, clientID, query, outCap, &after)
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, 0, nil)
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	after *uint64,
+) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -181,8 +248,22 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 	}
 
 	subscription := NewSubscription(outCapacity)
+	c := cmd{op: sub, clientID: clientID, query: query, subscription: subscription, after: after}
+	if after != nil {
+		c.errc = make(chan error, 1)
+	}
 	select {
-	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
+	case s.cmds <- c:
+		if c.errc != nil {
+			select {
+			case err := <-c.errc:
+				if err != nil {
+					return nil, err
+				}
+			case <-s.Quit():
+				return nil, errors.New("service is shutting down")
+			}
+		}
 		s.mtx.Lock()
 		if _, ok = s.subscriptions[clientID]; !ok {
 			s.subscriptions[clientID] = make(map[string]struct{})
@@ -295,6 +376,12 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+
+	// sequence number of the last published message
+	seq uint64
+	// ring buffer of the last published messages, oldest at replayStart
+	replay      []Message
+	replayStart int
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -309,6 +396,8 @@ func (s *Server) OnStart() error {
 	go s.loop(state{
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		seq:           s.initialSeq,
+		replay:        make([]Message, 0, s.replayCap),
 	})
 	return nil
 }
@@ -332,9 +421,19 @@ loop:
 			state.removeAll(nil)
 			break loop
 		case sub:
+			if cmd.after != nil {
+				err := state.resume(*cmd.after, cmd.query, cmd.subscription)
+				cmd.errc <- err
+				if err != nil {
+					continue
+				}
+			}
 			state.add(cmd.clientID, cmd.query, cmd.subscription)
 		case pub:
-			if err := state.send(cmd.msg, cmd.events); err != nil {
+			state.seq++
+			msg := Message{data: cmd.msg, events: cmd.events, seq: state.seq}
+			state.record(msg)
+			if err := state.send(msg); err != nil {
 				s.Logger.Error("Error querying for events", "err", err)
 			}
 		}
@@ -403,11 +502,56 @@ func (state *state) removeAll(reason error) {
 	}
 }
 
-func (state *state) send(msg interface{}, events map[string][]string) error {
+// record adds the message to the replay buffer, evicting the oldest one if
+// the buffer is full.
+func (state *state) record(msg Message) {
+	if cap(state.replay) == 0 {
+		return
+	}
+	if len(state.replay) < cap(state.replay) {
+		state.replay = append(state.replay, msg)
+		return
+	}
+	state.replay[state.replayStart] = msg
+	state.replayStart = (state.replayStart + 1) % len(state.replay)
+}
+
+// resume sends the buffered messages published after the given sequence
+// number and matching the query to the subscription.
+func (state *state) resume(after uint64, q Query, subscription *Subscription) error {
+	if after > state.seq {
+		return ErrReplayUnavailable
+	}
+	missed := state.seq - after
+	if missed > uint64(len(state.replay)) {
+		return ErrReplayUnavailable
+	}
+
+	var msgs []Message
+	for i := len(state.replay) - int(missed); i < len(state.replay); i++ {
+		msg := state.replay[(state.replayStart+i)%len(state.replay)]
+		match, err := q.Matches(msg.events)
+		if err != nil {
+			return fmt.Errorf("failed to match against query %s: %w", q.String(), err)
+		}
+		if match {
+			msgs = append(msgs, msg)
+		}
+	}
+	if len(msgs) > cap(subscription.out) {
+		return ErrOutOfCapacity
+	}
+	for _, msg := range msgs {
+		subscription.out <- msg
+	}
+	return nil
+}
+
+func (state *state) send(msg Message) error {
 	for qStr, clientSubscriptions := range state.subscriptions {
 		q := state.queries[qStr].q
 
-		match, err := q.Matches(events)
+		match, err := q.Matches(msg.events)
 		if err != nil {
 			return fmt.Errorf("failed to match against query %s: %w", q.String(), err)
 		}
@@ -416,11 +560,11 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 			for clientID, subscription := range clientSubscriptions {
 				if cap(subscription.out) == 0 {
 					// block on unbuffered channel
-					subscription.out <- NewMessage(msg, events)
+					subscription.out <- msg
 				} else {
 					// don't block on buffered channels
 					select {
-					case subscription.out <- NewMessage(msg, events):
+					case subscription.out <- msg:
 					default:
 						state.remove(clientID, qStr, ErrOutOfCapacity)
 					}
@@ -377,6 +377,102 @@ func TestUnsubscribeAll(t *testing.T) {
 	assertCancelled(t, subscription2, pubsub.ErrUnsubscribed)
 }
 
+func TestSubscribeFrom(t *testing.T) {
+	s := pubsub.NewServer(pubsub.ReplayCapacity(3))
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.Subscribe(ctx, clientID, query.All, 10)
+	require.NoError(t, err)
+	for _, msg := range []string{"Hulk", "Thor", "Loki", "Odin"} {
+		err = s.Publish(ctx, msg)
+		require.NoError(t, err)
+	}
+	for i, msg := range []string{"Hulk", "Thor", "Loki", "Odin"} {
+		select {
+		case m := <-subscription.Out():
+			assert.Equal(t, msg, m.Data())
+			assert.EqualValues(t, i+1, m.Sequence())
+		case <-time.After(1 * time.Second):
+			t.Fatalf("Expected to receive %v", msg)
+		}
+	}
+	err = s.Unsubscribe(ctx, clientID, query.All)
+	require.NoError(t, err)
+
+	// Loki and Odin are replayed before the newly published messages.
+	subscription, err = s.SubscribeFrom(ctx, clientID, query.All, 2, 10)
+	require.NoError(t, err)
+	err = s.Publish(ctx, "Freya")
+	require.NoError(t, err)
+	for i, msg := range []string{"Loki", "Odin", "Freya"} {
+		select {
+		case m := <-subscription.Out():
+			assert.Equal(t, msg, m.Data())
+			assert.EqualValues(t, i+3, m.Sequence())
+		case <-time.After(1 * time.Second):
+			t.Fatalf("Expected to receive %v", msg)
+		}
+	}
+
+	// Thor was evicted from the replay buffer.
+	_, err = s.SubscribeFrom(ctx, "other-client", query.All, 1, 10)
+	assert.Equal(t, pubsub.ErrReplayUnavailable, err)
+	assert.Equal(t, 0, s.NumClientSubscriptions("other-client"))
+
+	// Sequence numbers which have not been published yet.
+	_, err = s.SubscribeFrom(ctx, "other-client", query.All, 6, 10)
+	assert.Equal(t, pubsub.ErrReplayUnavailable, err)
+
+	// The replayed messages don't fit in the subscription.
+	_, err = s.SubscribeFrom(ctx, "other-client", query.All, 2, 1)
+	assert.Equal(t, pubsub.ErrOutOfCapacity, err)
+
+	// Only the messages matching the query are replayed.
+	subscription, err = s.SubscribeFrom(ctx, "other-client", query.MustCompile("tm.events.type='NewBlock'"), 2, 1)
+	require.NoError(t, err)
+	assert.Zero(t, len(subscription.Out()))
+}
+
+// A server started with an initial sequence number greater than the last one
+// of a previous run doesn't resume from the sequence numbers of that run.
+func TestInitialSequence(t *testing.T) {
+	s := pubsub.NewServer(pubsub.ReplayCapacity(3), pubsub.InitialSequence(100))
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.Subscribe(ctx, clientID, query.All, 10)
+	require.NoError(t, err)
+	err = s.Publish(ctx, "Hulk")
+	require.NoError(t, err)
+	select {
+	case m := <-subscription.Out():
+		assert.EqualValues(t, 101, m.Sequence())
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected to receive Hulk")
+	}
+
+	// sequence numbers of the previous run
+	_, err = s.SubscribeFrom(ctx, "other-client", query.All, 1, 10)
+	assert.Equal(t, pubsub.ErrReplayUnavailable, err)
+	_, err = s.SubscribeFrom(ctx, "other-client", query.All, 100, 10)
+	assert.NoError(t, err)
+}
+
 func TestBufferCapacity(t *testing.T) {
 	s := pubsub.NewServer(pubsub.BufferCapacity(2))
 	s.SetLogger(log.TestingLogger())
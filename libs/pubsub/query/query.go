@@ -125,7 +125,8 @@ func (q *Query) matchesEvents(events []types.Event) bool {
 // the event has the designated type, contains an attribute with the given
 // name, and the match function returns true for the attribute value.
 type condition struct {
-	tag   string // e.g., "tx.hash"
+	tag   string   // e.g., "tx.hash"
+	segs  []string // segments of a tag with wildcards, e.g., ["transfer", "*"]
 	match func(s string) bool
 }
 
@@ -133,6 +134,9 @@ type condition struct {
 // condition tag, and reports whether the event type strictly equals the
 // condition tag.
 func (c condition) findAttr(event types.Event) ([]string, bool) {
+	if c.segs != nil {
+		return c.findWildcardAttr(event)
+	}
 	if !strings.HasPrefix(c.tag, event.Type) {
 		return nil, false // type does not match tag
 	} else if len(c.tag) == len(event.Type) {
@@ -148,6 +152,48 @@ func (c condition) findAttr(event types.Event) ([]string, bool) {
 	return vals, false
 }
 
+// findWildcardAttr is findAttr for a condition tag with wildcards, which
+// matches the names with the same number of segments, each wildcard matching
+// any segment.
+func (c condition) findWildcardAttr(event types.Event) ([]string, bool) {
+	if matchSegments(c.segs, event.Type) {
+		return nil, true
+	}
+	// The attribute names have at least one more segment than the event type.
+	typeSegs := strings.Count(event.Type, ".") + 1
+	if typeSegs >= len(c.segs) || !matchSegments(c.segs[:typeSegs], event.Type) {
+		return nil, false
+	}
+	var vals []string
+	for _, attr := range event.Attributes {
+		if matchSegments(c.segs[typeSegs:], attr.Key) {
+			vals = append(vals, attr.Value)
+		}
+	}
+	return vals, false
+}
+
+// matchSegments reports whether the dot-separated segments of name match segs,
+// without allocating.
+func matchSegments(segs []string, name string) bool {
+	for i, seg := range segs {
+		part := name
+		if i < len(segs)-1 {
+			j := strings.IndexByte(name, '.')
+			if j < 0 {
+				return false
+			}
+			part, name = name[:j], name[j+1:]
+		} else if strings.IndexByte(name, '.') >= 0 {
+			return false
+		}
+		if seg != syntax.Wildcard && seg != part {
+			return false
+		}
+	}
+	return true
+}
+
 // matchesAny reports whether c matches at least one of the given events.
 func (c condition) matchesAny(events []types.Event) bool {
 	for _, event := range events {
@@ -182,6 +228,9 @@ func (c condition) matchesEvent(event types.Event) bool {
 
 func compileCondition(cond syntax.Condition) (condition, error) {
 	out := condition{tag: cond.Tag}
+	if cond.HasWildcard() {
+		out.segs = strings.Split(cond.Tag, ".")
+	}
 
 	// Handle existence checks separately to simplify the logic below for
 	// comparisons that take arguments.
@@ -241,6 +290,13 @@ var opTypeMap = map[syntax.Token]map[syntax.Token]func(interface{}) func(string)
 			}
 		},
 	},
+	syntax.TStartsWith: {
+		syntax.TString: func(v interface{}) func(string) bool {
+			return func(s string) bool {
+				return strings.HasPrefix(s, v.(string))
+			}
+		},
+	},
 	syntax.TEq: {
 		syntax.TString: func(v interface{}) func(string) bool {
 			return func(s string) bool { return s == v.(string) }
@@ -284,6 +284,39 @@ func TestCompiledMatches(t *testing.T) {
 		{`slash.reason EXISTS`,
 			newTestEvents(`transfer|recipient=cosmos1gu6y2a0ffteesyeyeesk23082c6998xyzmt9mz|sender=cosmos1crje20aj4gxdtyct7z3knxqry2jqt2fuaey6u5`),
 			false},
+		{`transfer.* EXISTS`,
+			newTestEvents(`transfer|recipient=AddrA|sender=AddrB`),
+			true},
+		{`transfer.* EXISTS`,
+			newTestEvents(`slash|reason=missing_signature|power=6000`),
+			false},
+		{`transfer.* = 'AddrB'`,
+			newTestEvents(`transfer|recipient=AddrA|sender=AddrB`),
+			true},
+		{`*.power > 1000`,
+			newTestEvents(`transfer|recipient=AddrA`, `slash|reason=missing_signature|power=6000`),
+			true},
+		{`*.power > 1000`,
+			newTestEvents(`slash|reason=missing_signature|power=500`),
+			false},
+		{`abci.*.name = 'Igor'`,
+			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
+			true},
+		{`abci.* = 'Igor'`,
+			newTestEvents(`abci|owner.name=Igor|owner.name=Ivan`),
+			false},
+		{`* EXISTS`,
+			newTestEvents(`slash|reason=missing_signature`),
+			true},
+		{`transfer.recipient STARTS_WITH 'cosmos1gu'`,
+			newTestEvents(`transfer|recipient=cosmos1gu6y2a0ffteesyeyeesk23082c6998xyzmt9mz`),
+			true},
+		{`transfer.recipient STARTS_WITH 'cosmos1crje'`,
+			newTestEvents(`transfer|recipient=cosmos1gu6y2a0ffteesyeyeesk23082c6998xyzmt9mz|sender=cosmos1crje20aj4gxdtyct7z3knxqry2jqt2fuaey6u5`),
+			false},
+		{`transfer.* STARTS_WITH 'cosmos1crje'`,
+			newTestEvents(`transfer|recipient=cosmos1gu6y2a0ffteesyeyeesk23082c6998xyzmt9mz|sender=cosmos1crje20aj4gxdtyct7z3knxqry2jqt2fuaey6u5`),
+			true},
 
 		// Test cases based on the OpenAPI examples.
 		{`tm.event = 'Tx' AND rewards.withdraw.address = 'AddrA'`,
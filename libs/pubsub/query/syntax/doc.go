@@ -8,16 +8,19 @@
 //	query      = conditions EOF
 //	conditions = condition {"AND" condition}
 //	condition  = tag comparison
-//	comparison = equal / order / contains / "EXISTS"
+//	comparison = equal / order / range / contains / prefix / "EXISTS"
 //	equal      = "=" (date / number / time / value)
 //	order      = cmp (date / number / time)
+//	range      = "BETWEEN" (date "AND" date / number "AND" number / time "AND" time)
 //	contains   = "CONTAINS" value
+//	prefix     = "STARTS_WITH" value
 //	cmp        = "<" / "<=" / ">" / ">="
 //
 // The lexical terms are defined here using RE2 regular expression notation:
 //
-//	// The name of an event attribute (type.value)
-//	tag    = #'\w+(\.\w+)*'
+//	// The name of an event attribute (type.value), whose segments may be
+//	// wildcards (transfer.*, *.amount)
+//	tag    = #'(\w+|\*)(\.(\w+|\*))*'
 //
 //	// A datestamp (YYYY-MM-DD)
 //	date   = #'DATE \d{4}-\d{2}-\d{2}'
@@ -30,4 +33,13 @@
 //
 //	// A quoted literal string value ('a b c')
 //	value  = #'\'[^\']*\''
+//
+// The bounds of a range are inclusive: "x BETWEEN 1 AND 5" is equivalent to,
+// and parsed as, "x >= 1 AND x <= 5".
+//
+// A wildcard segment of a tag matches exactly one segment of the name of an
+// attribute: "transfer.* EXISTS" matches the events of type transfer with any
+// attribute, and "*.amount > 10" the amount attribute of the events of any
+// single-segment type. "x STARTS_WITH 'abc'" matches the values of x beginning
+// with abc.
 package syntax
@@ -27,6 +27,10 @@ func (q Query) String() string {
 	return strings.Join(ss, " AND ")
 }
 
+// Wildcard is the tag segment matching any one segment of an event type or
+// attribute name, e.g. transfer.* or *.amount.
+const Wildcard = "*"
+
 // A Condition is a single conditional expression, consisting of a tag, a
 // comparison operator, and an optional argument. The type of the argument
 // depends on the operator.
@@ -46,6 +50,11 @@ func (c Condition) String() string {
 	return s
 }
 
+// HasWildcard reports whether the tag of c contains a wildcard segment.
+func (c Condition) HasWildcard() bool {
+	return strings.Contains(c.Tag, Wildcard)
+}
+
 // An Arg is the argument of a comparison operator.
 type Arg struct {
 	Type Token
@@ -122,33 +131,37 @@ func NewParser(r io.Reader) *Parser {
 
 // Parse parses the complete input and returns the resulting query.
 func (p *Parser) Parse() (Query, error) {
-	cond, err := p.parseCond()
+	conds, err := p.parseCond()
 	if err != nil {
 		return nil, err
 	}
-	conds := []Condition{cond}
 	for p.scanner.Next() != io.EOF {
 		if tok := p.scanner.Token(); tok != TAnd {
 			return nil, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), tok, TAnd)
 		}
-		cond, err := p.parseCond()
+		more, err := p.parseCond()
 		if err != nil {
 			return nil, err
 		}
-		conds = append(conds, cond)
+		conds = append(conds, more...)
 	}
 	return conds, nil
 }
 
-// parseCond parses a conditional expression: tag OP value.
-func (p *Parser) parseCond() (Condition, error) {
+// parseCond parses a conditional expression: tag OP value. A range
+// expression, tag BETWEEN low AND high, is parsed as the two conditions
+// tag >= low and tag <= high.
+func (p *Parser) parseCond() ([]Condition, error) {
 	var cond Condition
 	if err := p.require(TTag); err != nil {
-		return cond, err
+		return nil, err
 	}
 	cond.Tag = p.scanner.Text()
-	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists); err != nil {
-		return cond, err
+	if err := checkTag(cond.Tag); err != nil {
+		return nil, fmt.Errorf("offset %d: %w", p.scanner.Pos(), err)
+	}
+	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TStartsWith, TExists, TBetween); err != nil {
+		return nil, err
 	}
 	cond.Op = p.scanner.Token()
 	cond.opText = p.scanner.Text()
@@ -159,19 +172,51 @@ func (p *Parser) parseCond() (Condition, error) {
 		err = p.require(TNumber, TTime, TDate)
 	case TEq:
 		err = p.require(TNumber, TTime, TDate, TString)
-	case TContains:
+	case TContains, TStartsWith:
 		err = p.require(TString)
 	case TExists:
 		// no argument
-		return cond, nil
+		return []Condition{cond}, nil
+	case TBetween:
+		return p.parseBetween(cond.Tag)
 	default:
-		return cond, fmt.Errorf("offset %d: unexpected operator %v", p.scanner.Pos(), cond.Op)
+		return nil, fmt.Errorf("offset %d: unexpected operator %v", p.scanner.Pos(), cond.Op)
 	}
 	if err != nil {
-		return cond, err
+		return nil, err
 	}
 	cond.Arg = &Arg{Type: p.scanner.Token(), text: p.scanner.Text()}
-	return cond, nil
+	return []Condition{cond}, nil
+}
+
+// parseBetween parses the bounds of a range expression: low AND high.
+func (p *Parser) parseBetween(tag string) ([]Condition, error) {
+	if err := p.require(TNumber, TTime, TDate); err != nil {
+		return nil, err
+	}
+	low := &Arg{Type: p.scanner.Token(), text: p.scanner.Text()}
+	if err := p.require(TAnd); err != nil {
+		return nil, err
+	}
+	if err := p.require(low.Type); err != nil {
+		return nil, err
+	}
+	high := &Arg{Type: p.scanner.Token(), text: p.scanner.Text()}
+	return []Condition{
+		{Tag: tag, Op: TGeq, Arg: low, opText: ">="},
+		{Tag: tag, Op: TLeq, Arg: high, opText: "<="},
+	}, nil
+}
+
+// checkTag reports an error if a segment of tag contains a wildcard along with
+// other characters.
+func checkTag(tag string) error {
+	for _, seg := range strings.Split(tag, ".") {
+		if seg != Wildcard && strings.Contains(seg, Wildcard) {
+			return fmt.Errorf("invalid wildcard in tag %q, it must be a whole segment", tag)
+		}
+	}
+	return nil
 }
 
 // require advances the scanner and requires that the resulting token is one of
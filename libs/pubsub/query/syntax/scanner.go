@@ -30,23 +30,28 @@ const (
 	TGeq             // operator: >=
 
 	// Do not reorder these values without updating the scanner code.
+
+	TBetween    // operator: BETWEEN
+	TStartsWith // operator: STARTS_WITH
 )
 
 var tString = [...]string{
-	TInvalid:  "invalid token",
-	TTag:      "tag",
-	TString:   "string",
-	TNumber:   "number",
-	TTime:     "timestamp",
-	TDate:     "datestamp",
-	TAnd:      "AND operator",
-	TContains: "CONTAINS operator",
-	TExists:   "EXISTS operator",
-	TEq:       "= operator",
-	TLt:       "< operator",
-	TLeq:      "<= operator",
-	TGt:       "> operator",
-	TGeq:      ">= operator",
+	TInvalid:    "invalid token",
+	TTag:        "tag",
+	TString:     "string",
+	TNumber:     "number",
+	TTime:       "timestamp",
+	TDate:       "datestamp",
+	TAnd:        "AND operator",
+	TContains:   "CONTAINS operator",
+	TExists:     "EXISTS operator",
+	TEq:         "= operator",
+	TLt:         "< operator",
+	TLeq:        "<= operator",
+	TGt:         "> operator",
+	TGeq:        ">= operator",
+	TBetween:    "BETWEEN operator",
+	TStartsWith: "STARTS_WITH operator",
 }
 
 func (t Token) String() string {
@@ -228,6 +233,10 @@ func (s *Scanner) scanTagLike(first rune) error {
 		s.tok = TExists
 	case "CONTAINS":
 		s.tok = TContains
+	case "BETWEEN":
+		s.tok = TBetween
+	case "STARTS_WITH":
+		s.tok = TStartsWith
 	default:
 		s.tok = TTag
 	}
@@ -302,7 +311,7 @@ func (s *Scanner) invalid(ch rune) error {
 func isDigit(r rune) bool { return '0' <= r && r <= '9' }
 
 func isTagRune(r rune) bool {
-	return r == '.' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	return r == '.' || r == '_' || r == '*' || unicode.IsLetter(r) || unicode.IsDigit(r)
 }
 
 func isTimeRune(r rune) bool {
@@ -25,6 +25,7 @@ func TestScanner(t *testing.T) {
 
 		// Tags
 		{`foo foo.bar`, []syntax.Token{syntax.TTag, syntax.TTag}},
+		{`foo.* *.bar`, []syntax.Token{syntax.TTag, syntax.TTag}},
 
 		// Strings (values)
 		{` '' x 'x' 'x y'`, []syntax.Token{syntax.TString, syntax.TTag, syntax.TString, syntax.TString}},
@@ -39,6 +40,8 @@ func TestScanner(t *testing.T) {
 		{`x AND y`, []syntax.Token{syntax.TTag, syntax.TAnd, syntax.TTag}},
 		{`x.y CONTAINS 'z'`, []syntax.Token{syntax.TTag, syntax.TContains, syntax.TString}},
 		{`foo EXISTS`, []syntax.Token{syntax.TTag, syntax.TExists}},
+		{`x.y STARTS_WITH 'z'`, []syntax.Token{syntax.TTag, syntax.TStartsWith, syntax.TString}},
+		{`x BETWEEN 1 AND 5`, []syntax.Token{syntax.TTag, syntax.TBetween, syntax.TNumber, syntax.TAnd, syntax.TNumber}},
 		{`and AND`, []syntax.Token{syntax.TTag, syntax.TAnd}},
 
 		// Timestamp
@@ -128,6 +131,15 @@ func TestParseValid(t *testing.T) {
 		{"AND tm.events.type='NewBlock' ", false},
 
 		{"abci.account.name CONTAINS 'Igor'", true},
+		{"abci.account.name STARTS_WITH 'Ig'", true},
+		{"abci.account.name STARTS_WITH 10", false},
+		{"abci.account.name STARTS_WITH", false},
+
+		{"transfer.* EXISTS", true},
+		{"*.amount > 10", true},
+		{"transfer.*.name = 'Igor'", true},
+		{"transfer.recipient* EXISTS", false},
+		{"transfer.*x = 'Igor'", false},
 
 		{"tx.date > DATE 2013-05-03", true},
 		{"tx.date < DATE 2013-05-03", true},
@@ -160,6 +172,15 @@ func TestParseValid(t *testing.T) {
 		{"account.balance >>= 400", false},
 		{"account.balance=33.22.1", false},
 
+		{"account.balance BETWEEN 100 AND 200", true},
+		{"account.balance BETWEEN 100 AND 200 AND slashing.amount EXISTS", true},
+		{"tx.date BETWEEN DATE 2013-05-03 AND DATE 2013-06-03", true},
+		{"tx.date BETWEEN TIME 2013-05-03T14:45:00Z AND TIME 2013-06-03T14:45:00Z", true},
+		{"tx.date BETWEEN DATE 2013-05-03 AND 100", false},
+		{"account.balance BETWEEN 100", false},
+		{"account.balance BETWEEN 100 200", false},
+		{"account.balance BETWEEN 'a' AND 'b'", false},
+
 		{"slashing.amount EXISTS", true},
 		{"slashing.amount EXISTS AND account.balance=100", true},
 		{"account.balance=100 AND slashing.amount EXISTS", true},
@@ -188,3 +209,17 @@ func TestParseValid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseBetween(t *testing.T) {
+	q, err := syntax.Parse("account.balance BETWEEN 100 AND 200 AND tx.height = 5")
+	if err != nil {
+		t.Fatalf("Parse: unexpected error: %v", err)
+	}
+	want := "account.balance >= 100 AND account.balance <= 200 AND tx.height = 5"
+	if got := q.String(); got != want {
+		t.Errorf("Wrong query:\ngot:  %s\nwant: %s", got, want)
+	}
+	if len(q) != 3 || q[0].Op != syntax.TGeq || q[1].Op != syntax.TLeq {
+		t.Errorf("Wrong conditions: %+v", q)
+	}
+}
@@ -74,10 +74,11 @@ func (s *Subscription) cancel(err error) {
 type Message struct {
 	data   interface{}
 	events map[string][]string
+	seq    uint64
 }
 
 func NewMessage(data interface{}, events map[string][]string) Message {
-	return Message{data, events}
+	return Message{data: data, events: events}
 }
 
 // Data returns an original data published.
@@ -89,3 +90,10 @@ func (msg Message) Data() interface{} {
 func (msg Message) Events() map[string][]string {
 	return msg.events
 }
+
+// Sequence returns the sequence number the server assigned to the message
+// when it was published. Sequence numbers start at 1 and increase by one with
+// each published message, whether or not it matched the client's query.
+func (msg Message) Sequence() uint64 {
+	return msg.seq
+}
@@ -555,6 +555,99 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 	return c.verifyLightBlock(ctx, l, now)
 }
 
+// VerifyHeaderRange verifies the light blocks from height from to height to,
+// both included, and returns them in ascending order.
+//
+// The light block at height to is verified like in VerifyLightBlockAtHeight,
+// using bisection and cross-checking it with the witnesses. The blocks below
+// it are then verified backwards through the hash chain of their headers.
+// They are fetched in parallel from the primary and the witnesses, each
+// provider being queried sequentially, and any block failing verification is
+// fetched again from the primary. Light blocks already in the trusted store
+// are not fetched again. Only the light block at height to is saved to the
+// trusted store.
+func (c *Client) VerifyHeaderRange(ctx context.Context, from, to int64, now time.Time) ([]*types.LightBlock, error) {
+	if from <= 0 || from > to {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+
+	top, err := c.VerifyLightBlockAtHeight(ctx, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*types.LightBlock, to-from+1)
+	blocks[len(blocks)-1] = top
+	if from == to {
+		return blocks, nil
+	}
+
+	// Fetch the missing light blocks, splitting them among the providers.
+	var missing []int64
+	for height := from; height < to; height++ {
+		if l, err := c.trustedStore.LightBlock(height); err == nil {
+			blocks[height-from] = l
+		} else {
+			missing = append(missing, height)
+		}
+	}
+	c.providerMutex.Lock()
+	providers := append([]provider.Provider{c.primary}, c.witnesses...)
+	c.providerMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func(i int, p provider.Provider) {
+			defer wg.Done()
+			for j := i; j < len(missing); j += len(providers) {
+				l, err := p.LightBlock(ctx, missing[j])
+				if err != nil {
+					c.logger.Debug("Failed to fetch light block of range", "height", missing[j], "err", err)
+					continue
+				}
+				// Each height is only written by one goroutine.
+				blocks[missing[j]-from] = l
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	// Verify the hash chain down from the verified light block.
+	for height := to - 1; height >= from; height-- {
+		verified := blocks[height-from+1]
+		l := blocks[height-from]
+		if err := c.verifyRangeLightBlock(l, height, verified); err != nil {
+			c.logger.Info("Refetching light block of range from primary", "height", height, "err", err)
+			l, err = c.lightBlockFromPrimary(ctx, height)
+			if err != nil {
+				return nil, ErrVerificationFailed{From: verified.Height, To: height, Reason: err}
+			}
+			if err := c.verifyRangeLightBlock(l, height, verified); err != nil {
+				return nil, ErrVerificationFailed{From: verified.Height, To: height, Reason: err}
+			}
+			blocks[height-from] = l
+		}
+	}
+
+	return blocks, nil
+}
+
+// verifyRangeLightBlock verifies the light block at the given height against
+// the verified light block after it.
+func (c *Client) verifyRangeLightBlock(l *types.LightBlock, height int64, verified *types.LightBlock) error {
+	if l == nil {
+		return provider.ErrLightBlockNotFound
+	}
+	if l.Height != height {
+		return ErrInvalidHeader{fmt.Errorf("expected light block at height %d, got %d", height, l.Height)}
+	}
+	if err := l.ValidateBasic(c.chainID); err != nil {
+		return ErrInvalidHeader{err}
+	}
+	return VerifyBackwards(l.Header, verified.Header)
+}
+
 func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.LightBlock, now time.Time) error {
 	c.logger.Info("VerifyHeader", "height", newLightBlock.Height, "hash", newLightBlock.Hash())
 
@@ -405,6 +405,54 @@ func TestClientLargeBisectionVerification(t *testing.T) {
 	assert.Equal(t, h, h2)
 }
 
+func TestClient_VerifyHeaderRange(t *testing.T) {
+	_, headers, vals := genMockNode(chainID, 30, 3, 0, bTime)
+	primary := mockp.New(chainID, headers, vals)
+	trustedLightBlock, err := primary.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: trustedLightBlock.Height,
+			Hash:   trustedLightBlock.Hash(),
+		},
+		primary,
+		[]provider.Provider{mockp.New(chainID, headers, vals), mockp.New(chainID, headers, vals)},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.SkippingVerification(light.DefaultTrustLevel),
+	)
+	require.NoError(t, err)
+
+	blocks, err := c.VerifyHeaderRange(ctx, 10, 25, bTime.Add(1*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, blocks, 16)
+	for i, l := range blocks {
+		expected, err := primary.LightBlock(ctx, int64(10+i))
+		require.NoError(t, err)
+		assert.Equal(t, expected.Hash(), l.Hash())
+	}
+
+	// the top block is saved, the intermediate ones are not
+	_, err = c.TrustedLightBlock(25)
+	assert.NoError(t, err)
+	_, err = c.TrustedLightBlock(20)
+	assert.Error(t, err)
+
+	// a single block
+	blocks, err = c.VerifyHeaderRange(ctx, 25, 25, bTime.Add(1*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.EqualValues(t, 25, blocks[0].Height)
+
+	// invalid ranges
+	_, err = c.VerifyHeaderRange(ctx, 0, 5, bTime.Add(1*time.Hour))
+	assert.Error(t, err)
+	_, err = c.VerifyHeaderRange(ctx, 6, 5, bTime.Add(1*time.Hour))
+	assert.Error(t, err)
+}
+
 func TestClientBisectionBetweenTrustedHeaders(t *testing.T) {
 	c, err := light.NewClient(
 		ctx,
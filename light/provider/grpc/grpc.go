@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cmtnet "github.com/cometbft/cometbft/libs/net"
+	"github.com/cometbft/cometbft/light/provider"
+	coregrpc "github.com/cometbft/cometbft/rpc/grpc"
+	"github.com/cometbft/cometbft/types"
+)
+
+// timeout is used for all requests.
+const timeout = 5 * time.Second
+
+// blockAPI provider uses the gRPC BlockAPI of a node to obtain the necessary
+// information, and its BroadcastAPI to report evidence.
+type blockAPI struct {
+	chainID   string
+	remote    string
+	blocks    coregrpc.BlockAPIClient
+	broadcast coregrpc.BroadcastAPIClient
+}
+
+// New creates a gRPC provider connecting to the gRPC server of a node
+// (rpc.grpc_laddr), e.g. "tcp://127.0.0.1:26658".
+func New(chainID, remote string) (provider.Provider, error) {
+	//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+	conn, err := grpc.Dial(remote, grpc.WithInsecure(), grpc.WithContextDialer(
+		func(ctx context.Context, addr string) (net.Conn, error) {
+			return cmtnet.Connect(addr)
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockAPI{
+		chainID:   chainID,
+		remote:    remote,
+		blocks:    coregrpc.NewBlockAPIClient(conn),
+		broadcast: coregrpc.NewBroadcastAPIClient(conn),
+	}, nil
+}
+
+// NewWithClient allows you to provide custom clients.
+func NewWithClient(
+	chainID string,
+	blocks coregrpc.BlockAPIClient,
+	broadcast coregrpc.BroadcastAPIClient,
+) provider.Provider {
+	return &blockAPI{
+		chainID:   chainID,
+		blocks:    blocks,
+		broadcast: broadcast,
+	}
+}
+
+// ChainID returns a chainID this provider was configured with.
+func (p *blockAPI) ChainID() string {
+	return p.chainID
+}
+
+func (p *blockAPI) String() string {
+	return fmt.Sprintf("grpc{%s}", p.remote)
+}
+
+// LightBlock fetches a LightBlock at the given height and checks the
+// chainID matches.
+func (p *blockAPI) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("expected height >= 0, got height %d", height)}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	res, err := p.blocks.LightBlock(ctx, &coregrpc.RequestLightBlock{Height: height})
+	if err != nil {
+		return nil, providerError(err)
+	}
+	if res.LightBlock == nil {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("no light block in response")}
+	}
+
+	lb, err := types.LightBlockFromProto(res.LightBlock)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence calls the BroadcastEvidence method of the BroadcastAPI.
+func (p *blockAPI) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	pb, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err = p.broadcast.BroadcastEvidence(ctx, &coregrpc.RequestBroadcastEvidence{Evidence: pb})
+	return err
+}
+
+// providerError maps the status code of a gRPC error to the provider errors.
+func providerError(err error) error {
+	switch status.Code(err) {
+	case codes.OutOfRange:
+		return provider.ErrHeightTooHigh
+	case codes.NotFound:
+		return provider.ErrLightBlockNotFound
+	case codes.DeadlineExceeded, codes.Unavailable:
+		return provider.ErrNoResponse
+	default:
+		return err
+	}
+}
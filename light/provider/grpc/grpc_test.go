@@ -0,0 +1,94 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/light/provider"
+	grpcprovider "github.com/cometbft/cometbft/light/provider/grpc"
+	mockp "github.com/cometbft/cometbft/light/provider/mock"
+	coregrpc "github.com/cometbft/cometbft/rpc/grpc"
+	"github.com/cometbft/cometbft/types"
+)
+
+const chainID = test.DefaultTestChainID
+
+// server serves the light blocks of a mock provider over gRPC.
+type server struct {
+	coregrpc.BroadcastAPIServer
+	mock *mockp.Mock
+}
+
+func (s *server) LightBlock(ctx context.Context, req *coregrpc.RequestLightBlock) (*coregrpc.ResponseLightBlock, error) {
+	lb, err := s.mock.LightBlock(ctx, req.Height)
+	switch {
+	case errors.Is(err, provider.ErrHeightTooHigh):
+		return nil, status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, provider.ErrLightBlockNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	pb, err := lb.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &coregrpc.ResponseLightBlock{LightBlock: pb}, nil
+}
+
+func (s *server) BroadcastEvidence(
+	ctx context.Context,
+	req *coregrpc.RequestBroadcastEvidence,
+) (*coregrpc.ResponseBroadcastEvidence, error) {
+	ev, err := types.EvidenceFromProto(req.Evidence)
+	if err != nil {
+		return nil, err
+	}
+	return &coregrpc.ResponseBroadcastEvidence{Hash: ev.Hash()}, s.mock.ReportEvidence(ctx, ev)
+}
+
+func TestProvider(t *testing.T) {
+	headers, vals := test.MakeLightBlocks(t, chainID, 1, 3)
+	srv := &server{mock: mockp.New(chainID, headers, vals)}
+	grpcServer := grpc.NewServer()
+	coregrpc.RegisterBlockAPIServer(grpcServer, srv)
+	coregrpc.RegisterBroadcastAPIServer(grpcServer, srv)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = grpcServer.Serve(ln) }()
+	t.Cleanup(grpcServer.Stop)
+
+	p, err := grpcprovider.New(chainID, "tcp://"+ln.Addr().String())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	lb, err := p.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), lb.Hash())
+	assert.Equal(t, vals[1].Hash(), lb.ValidatorSet.Hash())
+
+	// latest
+	lb, err = p.LightBlock(ctx, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lb.Height)
+
+	_, err = p.LightBlock(ctx, 2)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+	_, err = p.LightBlock(ctx, 4)
+	assert.Equal(t, provider.ErrHeightTooHigh, err)
+
+	ev, err := types.NewMockDuplicateVoteEvidence(1, time.Now(), chainID)
+	require.NoError(t, err)
+	require.NoError(t, p.ReportEvidence(ctx, ev))
+	assert.True(t, srv.mock.HasEvidence(ev))
+}
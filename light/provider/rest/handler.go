@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/light/provider"
+	"github.com/cometbft/cometbft/types"
+)
+
+// NewHandler returns an HTTP handler serving the light blocks of the provider
+// and accepting evidence for it, as expected by the REST provider.
+func NewHandler(p provider.Provider) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(lightBlocksPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var height int64
+		if path := strings.TrimPrefix(r.URL.Path, lightBlocksPath); path != latest {
+			var err error
+			height, err = strconv.ParseInt(path, 10, 64)
+			if err != nil || height <= 0 {
+				http.Error(w, "invalid height", http.StatusBadRequest)
+				return
+			}
+		}
+
+		lb, err := p.LightBlock(r.Context(), height)
+		switch {
+		case errors.Is(err, provider.ErrLightBlockNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, provider.ErrHeightTooHigh):
+			http.Error(w, err.Error(), http.StatusTooEarly)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		bz, err := cmtjson.Marshal(lb)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bz)
+	})
+	mux.HandleFunc(evidencePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		bz, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var ev types.Evidence
+		if err := cmtjson.Unmarshal(bz, &ev); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := p.ReportEvidence(r.Context(), ev); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	})
+	return mux
+}
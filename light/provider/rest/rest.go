@@ -0,0 +1,165 @@
+// Package rest implements a light client provider fetching light blocks from
+// a plain REST endpoint, e.g. exposed by a gateway or a cache in front of
+// nodes, and a handler serving the light blocks of any provider at such an
+// endpoint.
+//
+// The endpoint serves:
+//
+//	GET  <url>/light_blocks/<height>  the light block at the height, as JSON
+//	GET  <url>/light_blocks/latest    the latest light block, as JSON
+//	POST <url>/evidence               reports the evidence in the JSON body
+//
+// A missing light block is reported with 404 Not Found, and a height above the
+// latest light block with 425 Too Early.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/light/provider"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// timeout is used for all requests.
+	timeout = 5 * time.Second
+	// maxBodySize is the maximum size of a light block or evidence.
+	maxBodySize = 16 << 20
+
+	lightBlocksPath = "/light_blocks/"
+	evidencePath    = "/evidence"
+	latest          = "latest"
+)
+
+// rest provider uses a REST endpoint to obtain the necessary information.
+type rest struct {
+	chainID string
+	url     string
+	client  *http.Client
+}
+
+// New creates a REST provider for the endpoint at the given URL. If no scheme
+// is provided in the URL, http will be used by default.
+func New(chainID, url string) (provider.Provider, error) {
+	return NewWithClient(chainID, url, &http.Client{Timeout: timeout})
+}
+
+// NewWithClient allows you to provide a custom HTTP client.
+func NewWithClient(chainID, url string, client *http.Client) (provider.Provider, error) {
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	if _, err := http.NewRequest(http.MethodGet, url, nil); err != nil {
+		return nil, err
+	}
+	return &rest{
+		chainID: chainID,
+		url:     strings.TrimSuffix(url, "/"),
+		client:  client,
+	}, nil
+}
+
+// ChainID returns a chainID this provider was configured with.
+func (p *rest) ChainID() string {
+	return p.chainID
+}
+
+func (p *rest) String() string {
+	return fmt.Sprintf("rest{%s}", p.url)
+}
+
+// LightBlock fetches a LightBlock at the given height and checks the
+// chainID matches.
+func (p *rest) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("expected height >= 0, got height %d", height)}
+	}
+	path := latest
+	if height > 0 {
+		path = strconv.FormatInt(height, 10)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+lightBlocksPath+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	lb := new(types.LightBlock)
+	if err := cmtjson.Unmarshal(bz, lb); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if lb.SignedHeader == nil || lb.ValidatorSet == nil {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("incomplete light block")}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence posts the evidence to the evidence endpoint.
+func (p *rest) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	bz, err := cmtjson.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url+evidencePath, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = p.do(req)
+	return err
+}
+
+// do sends the request and returns the response body, mapping the status
+// codes to the provider errors.
+func (p *rest) do(req *http.Request) ([]byte, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, provider.ErrNoResponse
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, provider.ErrLightBlockNotFound
+	case http.StatusTooEarly:
+		return nil, provider.ErrHeightTooHigh
+	default:
+		return nil, fmt.Errorf("%s: unexpected status %s", req.URL, resp.Status)
+	}
+
+	bz, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > maxBodySize {
+		return nil, errors.New("response is too large")
+	}
+	return bz, nil
+}
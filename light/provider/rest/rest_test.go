@@ -0,0 +1,58 @@
+package rest_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/light/provider"
+	mockp "github.com/cometbft/cometbft/light/provider/mock"
+	"github.com/cometbft/cometbft/light/provider/rest"
+	"github.com/cometbft/cometbft/types"
+)
+
+const chainID = test.DefaultTestChainID
+
+func TestProvider(t *testing.T) {
+	headers, vals := test.MakeLightBlocks(t, chainID, 1, 3)
+	mock := mockp.New(chainID, headers, vals)
+	srv := httptest.NewServer(rest.NewHandler(mock))
+	t.Cleanup(srv.Close)
+
+	p, err := rest.New(chainID, srv.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	lb, err := p.LightBlock(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), lb.Hash())
+	assert.Equal(t, vals[1].Hash(), lb.ValidatorSet.Hash())
+
+	// latest
+	lb, err = p.LightBlock(ctx, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lb.Height)
+
+	_, err = p.LightBlock(ctx, 2)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+	_, err = p.LightBlock(ctx, 4)
+	assert.Equal(t, provider.ErrHeightTooHigh, err)
+	_, err = p.LightBlock(ctx, -1)
+	assert.Error(t, err)
+
+	// light blocks of another chain are rejected
+	other, err := rest.New("other-chain", srv.URL)
+	require.NoError(t, err)
+	_, err = other.LightBlock(ctx, 1)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	ev, err := types.NewMockDuplicateVoteEvidence(1, time.Now(), chainID)
+	require.NoError(t, err)
+	require.NoError(t, p.ReportEvidence(ctx, ev))
+	assert.True(t, mock.HasEvidence(ev))
+}
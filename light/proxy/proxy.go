@@ -37,10 +37,13 @@ func NewProxy(
 		return nil, fmt.Errorf("failed to create http client for %s: %w", providerAddr, err)
 	}
 
+	client := lrpc.NewClient(rpcClient, lightClient, opts...)
+	client.SetLogger(logger)
+
 	return &Proxy{
 		Addr:   listenAddr,
 		Config: config,
-		Client: lrpc.NewClient(rpcClient, lightClient, opts...),
+		Client: client,
 		Logger: logger,
 	}, nil
 }
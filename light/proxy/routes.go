@@ -1,6 +1,9 @@
 package proxy
 
 import (
+	"errors"
+	"time"
+
 	"github.com/cometbft/cometbft/libs/bytes"
 	lrpc "github.com/cometbft/cometbft/light/rpc"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
@@ -18,27 +21,36 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
 
 		// info API
-		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
-		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
-		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
-		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", rpcserver.Cacheable()),
-		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", rpcserver.Cacheable()),
-		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", rpcserver.Cacheable()),
-		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height", rpcserver.Cacheable("height")),
-		"header":               rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
-		"header_by_hash":       rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable()),
-		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height")),
-		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height")),
-		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
-		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
-		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by"),
-		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
-		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
-		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
-		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
-		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
-		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"health":                  rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
+		"status":                  rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
+		"net_info":                rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
+		"blockchain":              rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", rpcserver.Cacheable()),
+		"genesis":                 rpcserver.NewRPCFunc(makeGenesisFunc(c), "", rpcserver.Cacheable()),
+		"genesis_chunked":         rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", rpcserver.Cacheable()),
+		"block":                   rpcserver.NewRPCFunc(makeBlockFunc(c), "height", rpcserver.Cacheable("height")),
+		"header":                  rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
+		"header_by_hash":          rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash", rpcserver.Cacheable()),
+		"block_by_hash":           rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", rpcserver.Cacheable()),
+		"block_results":           rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", rpcserver.Cacheable("height")),
+		"block_results_by_hash":   rpcserver.NewRPCFunc(makeBlockResultsByHashFunc(c), "hash", rpcserver.Cacheable()),
+		"commit":                  rpcserver.NewRPCFunc(makeCommitFunc(c), "height", rpcserver.Cacheable("height")),
+		"tx":                      rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
+		"tx_search":               rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
+		"block_search":            rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by"),
+		"validators":              rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page,time", rpcserver.Cacheable("height")),
+		"dump_consensus_state":    rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
+		"consensus_state":         rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
+		"consensus_round_history": rpcserver.NewRPCFunc(makeConsensusRoundHistoryFunc(c), "limit"),
+		"consensus_params":        rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", rpcserver.Cacheable("height")),
+		"app_hash_divergence":     rpcserver.NewRPCFunc(makeAppHashDivergenceFunc(c), ""),
+		"unconfirmed_txs":         rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
+		"num_unconfirmed_txs":     rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"tx_status":               rpcserver.NewRPCFunc(makeTxStatusFunc(c), "hash"),
+
+		// event log API
+		"events":                  rpcserver.NewRPCFunc(makeEventsFunc(c), "after,query,limit"),
+		"event_log_offset":        rpcserver.NewRPCFunc(makeEventLogOffsetFunc(c), "consumer"),
+		"commit_event_log_offset": rpcserver.NewRPCFunc(makeCommitEventLogOffsetFunc(c), "consumer,sequence"),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
@@ -51,6 +63,8 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
+		"evidence": rpcserver.NewRPCFunc(makeEvidenceFunc(c),
+			"min_height,max_height,validator_address,type,page,per_page"),
 	}
 }
 
@@ -142,6 +156,14 @@ func makeBlockResultsFunc(c *lrpc.Client) rpcBlockResultsFunc {
 	}
 }
 
+type rpcBlockResultsByHashFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlockResults, error)
+
+func makeBlockResultsByHashFunc(c *lrpc.Client) rpcBlockResultsByHashFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlockResults, error) {
+		return c.BlockResultsByHash(ctx.Context(), hash)
+	}
+}
+
 type rpcCommitFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommit, error)
 
 func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
@@ -199,10 +221,16 @@ func makeBlockSearchFunc(c *lrpc.Client) rpcBlockSearchFunc {
 }
 
 type rpcValidatorsFunc func(ctx *rpctypes.Context, height *int64,
-	page, perPage *int) (*ctypes.ResultValidators, error)
+	page, perPage *int, timePtr *int64) (*ctypes.ResultValidators, error)
 
 func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
-	return func(ctx *rpctypes.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
+	return func(ctx *rpctypes.Context, height *int64, page, perPage *int, timePtr *int64) (*ctypes.ResultValidators, error) {
+		if timePtr != nil {
+			if height != nil {
+				return nil, errors.New("only one of height and time can be provided")
+			}
+			return c.ValidatorsByTime(ctx.Context(), time.Unix(*timePtr, 0), page, perPage)
+		}
 		return c.Validators(ctx.Context(), height, page, perPage)
 	}
 }
@@ -223,6 +251,25 @@ func makeConsensusStateFunc(c *lrpc.Client) rpcConsensusStateFunc {
 	}
 }
 
+type rpcConsensusRoundHistoryFunc func(
+	ctx *rpctypes.Context,
+	limit *int,
+) (*ctypes.ResultConsensusRoundHistory, error)
+
+func makeConsensusRoundHistoryFunc(c *lrpc.Client) rpcConsensusRoundHistoryFunc {
+	return func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultConsensusRoundHistory, error) {
+		return c.ConsensusRoundHistory(ctx.Context(), limit)
+	}
+}
+
+type rpcAppHashDivergenceFunc func(ctx *rpctypes.Context) (*ctypes.ResultAppHashDivergence, error)
+
+func makeAppHashDivergenceFunc(c *lrpc.Client) rpcAppHashDivergenceFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultAppHashDivergence, error) {
+		return c.AppHashDivergence(ctx.Context())
+	}
+}
+
 type rpcConsensusParamsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultConsensusParams, error)
 
 func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
@@ -247,6 +294,47 @@ func makeNumUnconfirmedTxsFunc(c *lrpc.Client) rpcNumUnconfirmedTxsFunc {
 	}
 }
 
+type rpcTxStatusFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error)
+
+func makeTxStatusFunc(c *lrpc.Client) rpcTxStatusFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+		return c.TxStatus(ctx.Context(), hash)
+	}
+}
+
+type rpcEventsFunc func(
+	ctx *rpctypes.Context,
+	after uint64,
+	query string,
+	limit *int,
+) (*ctypes.ResultEvents, error)
+
+func makeEventsFunc(c *lrpc.Client) rpcEventsFunc {
+	return func(ctx *rpctypes.Context, after uint64, query string, limit *int) (*ctypes.ResultEvents, error) {
+		return c.Events(ctx.Context(), after, query, limit)
+	}
+}
+
+type rpcEventLogOffsetFunc func(ctx *rpctypes.Context, consumer string) (*ctypes.ResultEventLogOffset, error)
+
+func makeEventLogOffsetFunc(c *lrpc.Client) rpcEventLogOffsetFunc {
+	return func(ctx *rpctypes.Context, consumer string) (*ctypes.ResultEventLogOffset, error) {
+		return c.EventLogOffset(ctx.Context(), consumer)
+	}
+}
+
+type rpcCommitEventLogOffsetFunc func(
+	ctx *rpctypes.Context,
+	consumer string,
+	sequence uint64,
+) (*ctypes.ResultEventLogOffset, error)
+
+func makeCommitEventLogOffsetFunc(c *lrpc.Client) rpcCommitEventLogOffsetFunc {
+	return func(ctx *rpctypes.Context, consumer string, sequence uint64) (*ctypes.ResultEventLogOffset, error) {
+		return c.CommitEventLogOffset(ctx.Context(), consumer, sequence)
+	}
+}
+
 type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
 
 func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
@@ -300,3 +388,13 @@ func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
 		return c.BroadcastEvidence(ctx.Context(), ev)
 	}
 }
+
+type rpcEvidenceFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64, validatorAddress []byte,
+	evType string, page, perPage *int) (*ctypes.ResultEvidence, error)
+
+func makeEvidenceFunc(c *lrpc.Client) rpcEvidenceFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64, validatorAddress []byte,
+		evType string, page, perPage *int) (*ctypes.ResultEvidence, error) {
+		return c.Evidence(ctx.Context(), minHeight, maxHeight, validatorAddress, evType, page, perPage)
+	}
+}
@@ -50,6 +50,9 @@ type Client struct {
 	// proof runtime used to verify values returned by ABCIQuery
 	prt       *merkle.ProofRuntime
 	keyPathFn KeyPathFunc
+
+	// if positive, the light client is updated in the background
+	updatePeriod time.Duration
 }
 
 var _ rpcclient.Client = (*Client)(nil)
@@ -66,6 +69,16 @@ func KeyPathFn(fn KeyPathFunc) Option {
 	}
 }
 
+// UpdatePeriod option can be used to keep the light client updated to the
+// latest header in the background, every period, instead of only on demand.
+// This runs the proxy as a daemon, so that queries for recent heights don't
+// wait for the light client to catch up.
+func UpdatePeriod(period time.Duration) Option {
+	return func(c *Client) {
+		c.updatePeriod = period
+	}
+}
+
 // DefaultMerkleKeyPathFn creates a function used to generate merkle key paths
 // from a path string and a key. This is the default used by the cosmos SDK.
 // This merkle key paths are required when verifying /abci_query calls
@@ -103,11 +116,35 @@ func NewClient(next rpcclient.Client, lc LightClient, opts ...Option) *Client {
 
 func (c *Client) OnStart() error {
 	if !c.next.IsRunning() {
-		return c.next.Start()
+		if err := c.next.Start(); err != nil {
+			return err
+		}
+	}
+	if c.updatePeriod > 0 {
+		go c.updateRoutine()
 	}
 	return nil
 }
 
+// updateRoutine updates the light client every update period until the client
+// is stopped.
+func (c *Client) updateRoutine() {
+	ticker := time.NewTicker(c.updatePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), c.updatePeriod)
+			if _, err := c.lc.Update(ctx, time.Now()); err != nil {
+				c.Logger.Error("Failed to update light client", "err", err)
+			}
+			cancel()
+		case <-c.Quit():
+			return
+		}
+	}
+}
+
 func (c *Client) OnStop() {
 	if c.next.IsRunning() {
 		if err := c.next.Stop(); err != nil {
@@ -155,6 +192,9 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data cmt
 	if resp.Height <= 0 {
 		return nil, errNegOrZeroHeight
 	}
+	if opts.Height != 0 && resp.Height != opts.Height {
+		return nil, fmt.Errorf("response height %d does not match requested height %d", resp.Height, opts.Height)
+	}
 
 	// Update the light client if we're behind.
 	// NOTE: AppHash for height H is in header H+1.
@@ -211,6 +251,10 @@ func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirm
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Client) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	return c.next.TxStatus(ctx, hash)
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
@@ -227,6 +271,31 @@ func (c *Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusSta
 	return c.next.ConsensusState(ctx)
 }
 
+// Events calls rpcclient#Events. The events are not verified.
+func (c *Client) Events(ctx context.Context, after uint64, query string, limit *int) (*ctypes.ResultEvents, error) {
+	return c.next.Events(ctx, after, query, limit)
+}
+
+func (c *Client) EventLogOffset(ctx context.Context, consumer string) (*ctypes.ResultEventLogOffset, error) {
+	return c.next.EventLogOffset(ctx, consumer)
+}
+
+func (c *Client) CommitEventLogOffset(
+	ctx context.Context,
+	consumer string,
+	sequence uint64,
+) (*ctypes.ResultEventLogOffset, error) {
+	return c.next.CommitEventLogOffset(ctx, consumer, sequence)
+}
+
+func (c *Client) ConsensusRoundHistory(ctx context.Context, limit *int) (*ctypes.ResultConsensusRoundHistory, error) {
+	return c.next.ConsensusRoundHistory(ctx, limit)
+}
+
+func (c *Client) AppHashDivergence(ctx context.Context) (*ctypes.ResultAppHashDivergence, error) {
+	return c.next.AppHashDivergence(ctx)
+}
+
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	res, err := c.next.ConsensusParams(ctx, height)
 	if err != nil {
@@ -315,31 +384,9 @@ func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock,
 	if err != nil {
 		return nil, err
 	}
-
-	// Validate res.
-	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
-			bmH, bH)
-	}
-
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
-	if err != nil {
+	if err := c.verifyBlock(ctx, res); err != nil {
 		return nil, err
 	}
-
-	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
-			bH, tH)
-	}
-
 	return res, nil
 }
 
@@ -349,32 +396,42 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 	if err != nil {
 		return nil, err
 	}
+	if err := c.verifyBlock(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+// verifyBlock verifies the block against the trusted header at its height.
+func (c *Client) verifyBlock(ctx context.Context, res *ctypes.ResultBlock) error {
 	// Validate res.
+	if res.Block == nil {
+		return errors.New("no block in response")
+	}
 	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
+		return fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verify block.
 	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+		return fmt.Errorf("block header %X does not match with trusted header %X",
 			bH, tH)
 	}
 
-	return res, nil
+	return nil
 }
 
 // BlockResults returns the block results for the given height. If no height is
@@ -398,16 +455,53 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 		return nil, err
 	}
 
+	if err := c.verifyBlockResults(ctx, h, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// BlockResultsByHash calls rpcclient#BlockResultsByHash and verifies the
+// results like BlockResults. The results of the latest block can't be proven
+// until the next block is committed.
+func (c *Client) BlockResultsByHash(ctx context.Context, hash cmtbytes.HexBytes) (*ctypes.ResultBlockResults, error) {
+	res, err := c.next.BlockResultsByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyBlockResults(ctx, res.Height, res); err != nil {
+		return nil, err
+	}
+
+	// The results are verified against the header of the height they claim,
+	// which must be the one with the hash.
+	lb, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(lb.Header.Hash(), hash) {
+		return nil, fmt.Errorf("block results at height %d are not of block %X, trusted hash is %X",
+			res.Height, hash, lb.Header.Hash())
+	}
+
+	return res, nil
+}
+
+// verifyBlockResults verifies the block results of the height against the
+// LastResultsHash of the trusted header of the next height.
+func (c *Client) verifyBlockResults(ctx context.Context, height int64, res *ctypes.ResultBlockResults) error {
 	// Validate res.
 	if res.Height <= 0 {
-		return nil, errNegOrZeroHeight
+		return errNegOrZeroHeight
 	}
 
 	// Update the light client if we're behind.
-	nextHeight := h + 1
+	nextHeight := height + 1
 	trustedBlock, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// proto-encode BeginBlock events
@@ -415,7 +509,7 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 		Events: res.BeginBlockEvents,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Build a Merkle tree of proto-encoded DeliverTx results and get a hash.
@@ -426,7 +520,7 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 		Events: res.EndBlockEvents,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Build a Merkle tree out of the above 3 binary slices.
@@ -434,11 +528,11 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 
 	// Verify block results.
 	if !bytes.Equal(rH, trustedBlock.LastResultsHash) {
-		return nil, fmt.Errorf("last results %X does not match with trusted last results %X",
+		return fmt.Errorf("last results %X does not match with trusted last results %X",
 			rH, trustedBlock.LastResultsHash)
 	}
 
-	return res, nil
+	return nil
 }
 
 // Header fetches and verifies the header directly via the light client
@@ -489,29 +583,26 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
-// Tx calls rpcclient#Tx method and then verifies the proof if such was
-// requested.
+// Tx calls rpcclient#Tx method and then verifies the proof. The proof is
+// always requested, so that the transaction is verified even if prove is false.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	res, err := c.next.Tx(ctx, hash, prove)
-	if err != nil || !prove {
-		return res, err
+	res, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
 	}
-
-	// Validate res.
-	if res.Height <= 0 {
-		return nil, errNegOrZeroHeight
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, fmt.Errorf("tx hash %X does not match requested hash %X", res.Hash, hash)
 	}
-
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
-	if err != nil {
+	if err := c.verifyTx(ctx, res); err != nil {
 		return nil, err
 	}
-
-	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
+	return res, nil
 }
 
+// TxSearch calls rpcclient#TxSearch method and then verifies the proofs of
+// all the transactions found. The proofs are always requested, so that the
+// transactions are verified even if prove is false. Note that the completeness
+// of the results can't be verified.
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -519,16 +610,60 @@ func (c *Client) TxSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	res, err := c.next.TxSearch(ctx, query, true, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range res.Txs {
+		if err := c.verifyTx(ctx, tx); err != nil {
+			return nil, fmt.Errorf("tx %X: %w", tx.Hash, err)
+		}
+	}
+	return res, nil
 }
 
+// verifyTx verifies the transaction and its proof against the trusted header
+// at its height.
+func (c *Client) verifyTx(ctx context.Context, res *ctypes.ResultTx) error {
+	// Validate res.
+	if res.Height <= 0 {
+		return errNegOrZeroHeight
+	}
+	if !bytes.Equal(res.Tx, res.Proof.Data) {
+		return errors.New("tx does not match with its proof")
+	}
+	if tH := res.Tx.Hash(); !bytes.Equal(res.Hash, tH) {
+		return fmt.Errorf("tx hash %X does not match with tx %X", res.Hash, tH)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return err
+	}
+
+	// Validate the proof.
+	return res.Proof.Validate(l.DataHash)
+}
+
+// BlockSearch calls rpcclient#BlockSearch method and then verifies all the
+// blocks found. Note that the completeness of the results can't be verified.
 func (c *Client) BlockSearch(
 	ctx context.Context,
 	query string,
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	res, err := c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range res.Blocks {
+		if err := c.verifyBlock(ctx, block); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
 }
 
 // Validators fetches and verifies validators.
@@ -562,10 +697,52 @@ func (c *Client) Validators(
 		Total:       totalCount}, nil
 }
 
+// ValidatorsByTime fetches the height of the validator set as of the given
+// time from the primary, and verifies the validators at that height. The
+// verified block at that height must not be after t, and the next one, if it
+// can be verified, must be after t.
+func (c *Client) ValidatorsByTime(
+	ctx context.Context,
+	t time.Time,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultValidators, error) {
+	res, err := c.next.ValidatorsByTime(ctx, t, pagePtr, perPagePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	height := res.BlockHeight
+	l, err := c.updateLightClientIfNeededTo(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	if l.Time.After(t) {
+		return nil, fmt.Errorf("block %d is after %v", height, t)
+	}
+	nextHeight := height + 1
+	if nl, err := c.updateLightClientIfNeededTo(ctx, &nextHeight); err == nil && !nl.Time.After(t) {
+		return nil, fmt.Errorf("block %d is not after %v", nextHeight, t)
+	}
+
+	return c.Validators(ctx, &height, pagePtr, perPagePtr)
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+// Evidence calls rpcclient#Evidence. The pending evidence isn't part of any
+// block yet, so it can't be verified.
+func (c *Client) Evidence(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+	validatorAddress []byte,
+	evType string,
+	page, perPage *int,
+) (*ctypes.ResultEvidence, error) {
+	return c.next.Evidence(ctx, minHeight, maxHeight, validatorAddress, evType, page, perPage)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
@@ -0,0 +1,96 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	lrpc "github.com/cometbft/cometbft/light/rpc"
+	lcmock "github.com/cometbft/cometbft/light/rpc/mocks"
+	rpcmock "github.com/cometbft/cometbft/rpc/client/mocks"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxSearch(t *testing.T) {
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+	lb := &types.LightBlock{SignedHeader: &types.SignedHeader{
+		Header: &types.Header{Height: 5, DataHash: txs.Hash()},
+	}}
+	resultTx := func(i int) *ctypes.ResultTx {
+		return &ctypes.ResultTx{Hash: txs[i].Hash(), Height: 5, Index: uint32(i), Tx: txs[i], Proof: txs.Proof(i)}
+	}
+
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(5), mock.Anything).Return(lb, nil)
+
+	testCases := []struct {
+		name   string
+		tx     *ctypes.ResultTx
+		expErr bool
+	}{
+		{"valid", resultTx(1), false},
+		{"tx not matching its proof", func() *ctypes.ResultTx {
+			res := resultTx(1)
+			res.Tx = types.Tx("d")
+			res.Hash = res.Tx.Hash()
+			return res
+		}(), true},
+		{"hash not matching the tx", func() *ctypes.ResultTx {
+			res := resultTx(1)
+			res.Hash = txs[0].Hash()
+			return res
+		}(), true},
+		{"invalid proof", func() *ctypes.ResultTx {
+			res := resultTx(1)
+			res.Proof.Proof.Index = 0
+			return res
+		}(), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			next := &rpcmock.Client{}
+			// proofs are always requested
+			next.On("TxSearch", mock.Anything, "tx.height=5", true, mock.Anything, mock.Anything, "").
+				Return(&ctypes.ResultTxSearch{Txs: []*ctypes.ResultTx{resultTx(0), tc.tx}, TotalCount: 2}, nil)
+			c := lrpc.NewClient(next, lc)
+
+			res, err := c.TxSearch(context.Background(), "tx.height=5", false, nil, nil, "")
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, res.Txs, 2)
+		})
+	}
+}
+
+func TestClient_UpdatePeriod(t *testing.T) {
+	updated := make(chan struct{}, 1)
+	lc := &lcmock.LightClient{}
+	lc.On("Update", mock.Anything, mock.Anything).Return(&types.LightBlock{}, nil).Run(func(mock.Arguments) {
+		select {
+		case updated <- struct{}{}:
+		default:
+		}
+	})
+	next := &rpcmock.Client{}
+	next.On("IsRunning").Return(true)
+	next.On("Stop").Return(nil)
+
+	c := lrpc.NewClient(next, lc, lrpc.UpdatePeriod(10*time.Millisecond))
+	require.NoError(t, c.Start())
+	t.Cleanup(func() { _ = c.Stop() })
+
+	select {
+	case <-updated:
+	case <-time.After(time.Second):
+		t.Fatal("light client was not updated in the background")
+	}
+}
@@ -15,7 +15,7 @@ func BenchmarkReap(b *testing.B) {
 	mp, cleanup := newMempoolWithApp(cc)
 	defer cleanup()
 
-	mp.config.Size = 100000
+	mp.SetLimits(100000, mp.config.MaxTxsBytes)
 
 	size := 10000
 	for i := 0; i < size; i++ {
@@ -37,7 +37,7 @@ func BenchmarkCheckTx(b *testing.B) {
 	mp, cleanup := newMempoolWithApp(cc)
 	defer cleanup()
 
-	mp.config.Size = 1000000
+	mp.SetLimits(1000000, mp.config.MaxTxsBytes)
 
 	b.ResetTimer()
 
@@ -59,7 +59,7 @@ func BenchmarkParallelCheckTx(b *testing.B) {
 	mp, cleanup := newMempoolWithApp(cc)
 	defer cleanup()
 
-	mp.config.Size = 100000000
+	mp.SetLimits(100000000, mp.config.MaxTxsBytes)
 
 	var txcnt uint64
 	next := func() uint64 {
@@ -84,7 +84,7 @@ func BenchmarkCheckDuplicateTx(b *testing.B) {
 	mp, cleanup := newMempoolWithApp(cc)
 	defer cleanup()
 
-	mp.config.Size = 1000000
+	mp.SetLimits(1000000, mp.config.MaxTxsBytes)
 
 	for i := 0; i < b.N; i++ {
 		tx := make([]byte, 8)
@@ -0,0 +1,94 @@
+package mempool
+
+import (
+	"container/list"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// checkTxResultCache is a thread-safe LRU cache of the responses of the
+// application to the first check of transactions, keyed by transaction hash,
+// so that the application doesn't check the same transaction again while its
+// result can't have changed. It is reset when a block is committed, and when
+// the version of the application changes.
+type checkTxResultCache struct {
+	mtx        cmtsync.Mutex
+	size       int
+	appVersion uint64
+	cacheMap   map[types.TxKey]*list.Element
+	list       *list.List
+}
+
+type checkTxResult struct {
+	key types.TxKey
+	res *abci.ResponseCheckTx
+}
+
+func newCheckTxResultCache(cacheSize int) *checkTxResultCache {
+	return &checkTxResultCache{
+		size:     cacheSize,
+		cacheMap: make(map[types.TxKey]*list.Element, cacheSize),
+		list:     list.New(),
+	}
+}
+
+// Reset removes all the results from the cache.
+func (c *checkTxResultCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.reset()
+}
+
+func (c *checkTxResultCache) reset() {
+	c.cacheMap = make(map[types.TxKey]*list.Element, c.size)
+	c.list.Init()
+}
+
+// SetAppVersion resets the cache if the version of the application changed.
+func (c *checkTxResultCache) SetAppVersion(version uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if version != c.appVersion {
+		c.appVersion = version
+		c.reset()
+	}
+}
+
+// Get returns the cached result of the transaction with the given key, if
+// any.
+func (c *checkTxResultCache) Get(key types.TxKey) (*abci.ResponseCheckTx, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.cacheMap[key]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToBack(e)
+	return e.Value.(*checkTxResult).res, true
+}
+
+// Add caches the result of the transaction with the given key, evicting the
+// least recently used result if the cache is full.
+func (c *checkTxResultCache) Add(key types.TxKey, res *abci.ResponseCheckTx) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		e.Value.(*checkTxResult).res = res
+		c.list.MoveToBack(e)
+		return
+	}
+
+	if c.list.Len() >= c.size {
+		if front := c.list.Front(); front != nil {
+			delete(c.cacheMap, front.Value.(*checkTxResult).key)
+			c.list.Remove(front)
+		}
+	}
+	c.cacheMap[key] = c.list.PushBack(&checkTxResult{key: key, res: res})
+}
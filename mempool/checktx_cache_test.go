@@ -0,0 +1,45 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestCheckTxResultCacheEviction(t *testing.T) {
+	cache := newCheckTxResultCache(2)
+	a, b, c := types.Tx("a").Key(), types.Tx("b").Key(), types.Tx("c").Key()
+
+	cache.Add(a, &abci.ResponseCheckTx{Code: 1})
+	cache.Add(b, &abci.ResponseCheckTx{Code: 2})
+	// a is used, so b is the least recently used result
+	res, ok := cache.Get(a)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, res.Code)
+	cache.Add(c, &abci.ResponseCheckTx{Code: 3})
+
+	_, ok = cache.Get(b)
+	assert.False(t, ok)
+	_, ok = cache.Get(a)
+	assert.True(t, ok)
+	_, ok = cache.Get(c)
+	assert.True(t, ok)
+}
+
+func TestCheckTxResultCacheAppVersion(t *testing.T) {
+	cache := newCheckTxResultCache(10)
+	key := types.Tx("a").Key()
+
+	cache.Add(key, &abci.ResponseCheckTx{Code: 1})
+	cache.SetAppVersion(0)
+	_, ok := cache.Get(key)
+	assert.True(t, ok, "the same version must not invalidate the results")
+
+	cache.SetAppVersion(1)
+	_, ok = cache.Get(key)
+	assert.False(t, ok)
+}
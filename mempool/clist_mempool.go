@@ -2,9 +2,13 @@ package mempool
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
@@ -17,6 +21,10 @@ import (
 	"github.com/cometbft/cometbft/types"
 )
 
+// How long a tx checked over one of the additional CheckTx connections waits
+// for the recheck of the txs of the mempool to complete.
+const recheckWaitTimeout = time.Second
+
 // CListMempool is an ordered in-memory pool for transactions before they are
 // proposed in a consensus round. Transaction validity is checked using the
 // CheckTx abci message before the transaction is added to the pool. The
@@ -24,8 +32,16 @@ import (
 // be efficiently accessed by multiple concurrent readers.
 type CListMempool struct {
 	// Atomic integers
-	height   int64 // the last block Update()'d to
-	txsBytes int64 // total size of mempool, in bytes
+	height      int64 // the last block Update()'d to
+	txsBytes    int64 // total size of mempool, in bytes
+	maxTxs      int64 // maximum number of txs, config.Size unless changed by SetLimits
+	maxTxsBytes int64 // maximum total size of the txs, in bytes
+	// size of the largest tx a block holds, 0 for no bound, e.g. until
+	// SetMaxBlockTxBytes is called
+	maxBlockTxBytes int64
+	txsGas          int64 // total gas wanted by the txs of the mempool
+	// maximum total gas of the txs admitted by CheckTx, 0 if no limit
+	maxTxsGas int64
 
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
@@ -47,15 +63,53 @@ type CListMempool struct {
 	// serial (ie. by abci responses which are called in serial).
 	recheckCursor *clist.CElement // next expected response
 	recheckEnd    *clist.CElement // re-checking stops here
+	recheckStart  time.Time       // when the current recheck started
+	// Closed when the recheck started by recheckTxs completes, nil if none
+	// was started. Protected by updateMtx.
+	recheckDone chan struct{}
+
+	// Additional connections used to recheck txs in parallel. If empty, txs
+	// are rechecked over proxyAppConn.
+	recheckConns []proxy.AppConnMempool
+	// Closed to cancel the parallel recheck in progress, if any, when the next
+	// block is committed. Protected by updateMtx.
+	recheckQuit chan struct{}
+
+	// Additional connections used to check new txs in parallel, sharded by
+	// sender. If empty, txs are checked over proxyAppConn.
+	checkTxConns []proxy.AppConnMempool
+	// Serializes the handling of the CheckTx responses, which arrive
+	// concurrently when checkTxConns is not empty.
+	checkTxMtx cmtsync.Mutex
 
 	// Map for quick access to txs to record sender in CheckTx.
 	// txsMap: txKey -> CElement
 	txsMap sync.Map
 
+	// Index of txs by sender and sequence, used when config.SenderLanes is
+	// enabled.
+	lanes *senderLanes
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache TxCache
 
+	// Cache of the CheckTx results, nil if config.CheckTxCacheSize is 0.
+	resultCache *checkTxResultCache
+
+	// Write-ahead log of the pending txs, nil until InitWAL is called.
+	wal *txWAL
+
+	// Recently evicted txs, nil if config.CacheSize is 0.
+	evicted *evictedTxCache
+
+	// Gas wanted by the latest blocks, nil if config.GasAdmissionFactor is 0.
+	blockGas *blockGasWindow
+
+	// Percentage of the block space reserved for each priority lane, parsed
+	// from config.PriorityLanes.
+	priorityLanes map[string]int
+
 	logger  log.Logger
 	metrics *Metrics
 }
@@ -66,21 +120,24 @@ var _ Mempool = &CListMempool{}
 type CListMempoolOption func(*CListMempool)
 
 // NewCListMempool returns a new mempool with the given configuration and
-// connection to an application.
+// connection to an application. The configuration must be valid, see
+// MempoolConfig.ValidateBasic.
 func NewCListMempool(
 	cfg *config.MempoolConfig,
 	proxyAppConn proxy.AppConnMempool,
 	height int64,
 	options ...CListMempoolOption,
 ) *CListMempool {
-
 	mp := &CListMempool{
 		config:        cfg,
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
 		height:        height,
+		maxTxs:        int64(cfg.Size),
+		maxTxsBytes:   cfg.MaxTxsBytes,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		lanes:         newSenderLanes(),
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
 	}
@@ -90,6 +147,20 @@ func NewCListMempool(
 	} else {
 		mp.cache = NopTxCache{}
 	}
+	if cfg.CacheSize > 0 {
+		mp.evicted = newEvictedTxCache(cfg.CacheSize)
+	}
+	if cfg.CheckTxCacheSize > 0 {
+		mp.resultCache = newCheckTxResultCache(cfg.CheckTxCacheSize)
+	}
+	if cfg.GasAdmissionFactor > 0 {
+		mp.blockGas = newBlockGasWindow(cfg.GasAdmissionBlocks)
+	}
+	priorityLanes, err := cfg.PriorityLanesMap()
+	if err != nil {
+		panic(fmt.Sprintf("invalid priority lanes, the config must be validated first: %v", err))
+	}
+	mp.priorityLanes = priorityLanes
 
 	proxyAppConn.SetResponseCallback(mp.globalCb)
 
@@ -124,6 +195,25 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithRecheckConns sets the connections used to recheck txs in parallel after
+// a block is committed.
+func WithRecheckConns(conns ...proxy.AppConnMempool) CListMempoolOption {
+	return func(mem *CListMempool) { mem.recheckConns = conns }
+}
+
+// WithCheckTxConns sets the connections used, along with the mempool
+// connection, to check new txs in parallel.
+func WithCheckTxConns(conns ...proxy.AppConnMempool) CListMempoolOption {
+	return func(mem *CListMempool) {
+		for _, conn := range conns {
+			// Txs are never rechecked over these connections, so their
+			// responses are all handled by the request specific callbacks.
+			conn.SetResponseCallback(func(*abci.Request, *abci.Response) {})
+		}
+		mem.checkTxConns = conns
+	}
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
@@ -151,7 +241,15 @@ func (mem *CListMempool) SizeBytes() int64 {
 
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
-	return mem.proxyAppConn.FlushSync()
+	if err := mem.proxyAppConn.FlushSync(); err != nil {
+		return err
+	}
+	for _, conn := range mem.checkTxConns {
+		if err := conn.FlushSync(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // XXX: Unsafe! Calling Flush may leave mempool in inconsistent state.
@@ -160,7 +258,11 @@ func (mem *CListMempool) Flush() {
 	defer mem.updateMtx.RUnlock()
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+	_ = atomic.SwapInt64(&mem.txsGas, 0)
 	mem.cache.Reset()
+	if mem.resultCache != nil {
+		mem.resultCache.Reset()
+	}
 
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		mem.txs.Remove(e)
@@ -171,6 +273,59 @@ func (mem *CListMempool) Flush() {
 		mem.txsMap.Delete(key)
 		return true
 	})
+
+	mem.lanes.Reset()
+	if mem.evicted != nil {
+		mem.evicted.Reset()
+	}
+
+	if mem.wal != nil {
+		if err := mem.wal.Compact(nil); err != nil {
+			mem.logger.Error("Failed to reset the WAL", "err", err)
+		}
+	}
+}
+
+// InitWAL opens the write-ahead log of the pending transactions in
+// config.WalDir, and checks the transactions it records again, to restore the
+// pending transactions of the node before it crashed or was stopped. It must
+// be called once, after the connection to the application is ready.
+func (mem *CListMempool) InitWAL() error {
+	wal, pending, err := openTxWAL(mem.config.WalDir())
+	if err != nil {
+		return fmt.Errorf("failed to open the mempool WAL: %w", err)
+	}
+	mem.wal = wal
+
+	for _, tx := range pending {
+		if err := mem.CheckTx(tx, nil, TxInfo{SenderID: UnknownPeerID}); err != nil {
+			mem.logger.Debug("Failed to restore a tx from the WAL", "tx", tx.Hash(), "err", err)
+		}
+	}
+
+	// The WAL keeps recording all the pending txs until they are checked
+	// again, and is then compacted to the ones accepted, so that a crash in
+	// between loses none of them.
+	if err := mem.FlushAppConn(); err != nil {
+		return fmt.Errorf("failed to check the txs of the mempool WAL: %w", err)
+	}
+	mem.Lock()
+	defer mem.Unlock()
+	if err := mem.wal.Compact(mem.pendingTxs()); err != nil {
+		return err
+	}
+	mem.logger.Info("Restored the pending txs from the WAL", "txs", len(pending), "accepted", mem.Size())
+	return nil
+}
+
+// CloseWAL closes the write-ahead log of the pending transactions.
+func (mem *CListMempool) CloseWAL() {
+	if mem.wal == nil {
+		return
+	}
+	if err := mem.wal.Close(); err != nil {
+		mem.logger.Error("Failed to close the WAL", "err", err)
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -215,6 +370,11 @@ func (mem *CListMempool) CheckTx(
 		return err
 	}
 
+	if err := mem.isGasFull(); err != nil {
+		mem.metrics.GasAdmissionRejectedTxs.Add(1)
+		return err
+	}
+
 	if txSize > mem.config.MaxTxBytes {
 		return ErrTxTooLarge{
 			Max:    mem.config.MaxTxBytes,
@@ -230,8 +390,10 @@ func (mem *CListMempool) CheckTx(
 		}
 	}
 
-	// NOTE: proxyAppConn may error if tx buffer is full
-	if err := mem.proxyAppConn.Error(); err != nil {
+	conn := mem.checkTxConn(tx, txInfo.SenderID)
+
+	// NOTE: the connection may error if tx buffer is full
+	if err := conn.Error(); err != nil {
 		return err
 	}
 
@@ -250,12 +412,100 @@ func (mem *CListMempool) CheckTx(
 		return ErrTxInCache
 	}
 
-	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
+	if mem.resultCache != nil {
+		if res, ok := mem.resultCache.Get(tx.Key()); ok {
+			if handled, err := mem.checkTxFromCache(tx, txInfo, res, cb); handled {
+				mem.metrics.CheckTxCacheHits.Add(1)
+				return err
+			}
+		}
+		mem.metrics.CheckTxCacheMisses.Add(1)
+	}
+
+	if conn != mem.proxyAppConn && mem.recheckDone != nil {
+		// The responses over the other connections are not ordered after the
+		// rechecks, which must complete first.
+		if err := mem.waitRecheck(); err != nil {
+			mem.cache.Remove(tx)
+			return err
+		}
+	}
+	reqRes := conn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
 	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
 
 	return nil
 }
 
+// waitRecheck waits for the recheck started by recheckTxs to complete. The
+// wait is bounded by recheckWaitTimeout, so that CheckTx, and Update waiting
+// for the lock, don't hang if the mempool connection fails or doesn't answer.
+//
+// The caller must hold the read lock.
+func (mem *CListMempool) waitRecheck() error {
+	timer := time.NewTimer(recheckWaitTimeout)
+	defer timer.Stop()
+	select {
+	case <-mem.recheckDone:
+		return nil
+	case <-timer.C:
+		if err := mem.proxyAppConn.Error(); err != nil {
+			return err
+		}
+		return ErrRecheckPending
+	}
+}
+
+// checkTxConn returns the connection that checks tx. The txs of a peer are all
+// checked over the same connection, hence in the order they were received,
+// while the txs of an unknown sender, e.g. from the RPC, are spread by hash.
+func (mem *CListMempool) checkTxConn(tx types.Tx, senderID uint16) proxy.AppConnMempool {
+	if len(mem.checkTxConns) == 0 {
+		return mem.proxyAppConn
+	}
+	numConns := uint64(len(mem.checkTxConns) + 1)
+	var shard uint64
+	if senderID != UnknownPeerID {
+		shard = uint64(senderID) % numConns
+	} else {
+		key := tx.Key()
+		shard = binary.BigEndian.Uint64(key[:8]) % numConns
+	}
+	if shard == 0 {
+		return mem.proxyAppConn
+	}
+	return mem.checkTxConns[shard-1]
+}
+
+// checkTxFromCache handles a transaction with a cached CheckTx result, and
+// returns false if the application must check it anyway: the application
+// checks a valid transaction again to update its state, unless the
+// transaction is still in the mempool, in which case the new sender is
+// recorded. An invalid transaction gets its cached result.
+func (mem *CListMempool) checkTxFromCache(
+	tx types.Tx,
+	txInfo TxInfo,
+	res *abci.ResponseCheckTx,
+	cb func(*abci.Response),
+) (bool, error) {
+	if res.Code == abci.CodeTypeOK {
+		memTx, ok := mem.getTx(tx.Key())
+		if !ok {
+			return false, nil
+		}
+		memTx.senders.LoadOrStore(txInfo.SenderID, true)
+		return true, ErrTxInCache
+	}
+
+	mem.logger.Debug("rejected bad transaction from the CheckTx cache", "tx", tx.Hash(), "res", res)
+	if !mem.config.KeepInvalidTxsInCache {
+		mem.cache.Remove(tx)
+	}
+	if cb != nil {
+		cb(abci.ToResponseCheckTx(*res))
+	}
+	return true, nil
+}
+
 // Global callback that will be called after every ABCI response.
 // Having a single global callback avoids needing to set a callback for each request.
 // However, processing the checkTx response requires the peerID (so we can track which txs we heard from who),
@@ -298,7 +548,9 @@ func (mem *CListMempool) reqResCb(
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
+		mem.checkTxMtx.Lock()
 		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
+		mem.checkTxMtx.Unlock()
 
 		// update metrics
 		mem.metrics.Size.Set(float64(mem.Size()))
@@ -310,12 +562,37 @@ func (mem *CListMempool) reqResCb(
 	}
 }
 
+// getTx returns the mempool transaction with the given key, if any.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) getTx(txKey types.TxKey) (*mempoolTx, bool) {
+	e, ok := mem.txsMap.Load(txKey)
+	if !ok {
+		return nil, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx), true
+}
+
 // Called from:
 //   - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
+	if mem.wal != nil {
+		if err := mem.wal.Add(memTx.tx); err != nil {
+			mem.logger.Error("Failed to write the tx to the WAL", "tx", memTx.tx.Hash(), "err", err)
+		}
+	}
+	if mem.evicted != nil {
+		mem.evicted.Remove(memTx.tx.Key())
+	}
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(memTx.tx.Key(), e)
+	if mem.config.SenderLanes {
+		mem.lanes.Add(e)
+	}
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	if memTx.gasWanted > 0 {
+		atomic.AddInt64(&mem.txsGas, memTx.gasWanted)
+	}
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
 }
 
@@ -326,19 +603,52 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(tx.Key())
+	mem.lanes.Remove(elem)
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	if memTx := elem.Value.(*mempoolTx); memTx.gasWanted > 0 {
+		atomic.AddInt64(&mem.txsGas, -memTx.gasWanted)
+	}
+	if mem.wal != nil {
+		if err := mem.wal.Remove(tx.Key()); err != nil {
+			mem.logger.Error("Failed to write the tx removal to the WAL", "tx", tx.Hash(), "err", err)
+		}
+	}
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
 }
 
+// evict records a tx dropped without being committed.
+func (mem *CListMempool) evict(tx types.Tx, reason EvictionReason) {
+	if mem.evicted != nil {
+		mem.evicted.Add(tx.Key(), EvictedTx{Height: mem.height, Reason: reason})
+	}
+}
+
+// HasTx reports whether the transaction with the given key is in the mempool.
+func (mem *CListMempool) HasTx(txKey types.TxKey) bool {
+	_, ok := mem.txsMap.Load(txKey)
+	return ok
+}
+
+// EvictedTx returns why and when the transaction with the given key was
+// dropped by the mempool without being committed, if it is among the
+// config.CacheSize last ones dropped.
+func (mem *CListMempool) EvictedTx(txKey types.TxKey) (EvictedTx, bool) {
+	if mem.evicted == nil {
+		return EvictedTx{}, false
+	}
+	return mem.evicted.Get(txKey)
+}
+
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
 func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	if e, ok := mem.txsMap.Load(txKey); ok {
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
 			mem.removeTx(memTx.tx, e.(*clist.CElement), false)
+			mem.evict(memTx.tx, EvictionReasonRemoved)
 			return nil
 		}
 		return errors.New("transaction not found")
@@ -346,24 +656,97 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	return errors.New("invalid transaction found")
 }
 
+// TxGasWanted returns the gas wanted by a transaction of the mempool, as
+// returned by CheckTx, and whether it is in the mempool.
+func (mem *CListMempool) TxGasWanted(txKey types.TxKey) (int64, bool) {
+	e, ok := mem.txsMap.Load(txKey)
+	if !ok {
+		return 0, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).gasWanted, true
+}
+
+// SetAppVersion sets the version of the application, invalidating the cached
+// CheckTx results if it changed.
+func (mem *CListMempool) SetAppVersion(version uint64) {
+	if mem.resultCache != nil {
+		mem.resultCache.SetAppVersion(version)
+	}
+}
+
+// SetMaxBlockTxBytes sets the size of the largest transaction a block holds
+// under the current consensus params, which bounds the size of the
+// transactions received from peers, along with config.MaxTxBytes.
+func (mem *CListMempool) SetMaxBlockTxBytes(maxBytes int64) {
+	atomic.StoreInt64(&mem.maxBlockTxBytes, maxBytes)
+}
+
+// maxRecvTxBytes returns the size of the largest transaction accepted from
+// peers.
+func (mem *CListMempool) maxRecvTxBytes() int {
+	maxBytes := int64(mem.config.MaxTxBytes)
+	if maxBlockTxBytes := atomic.LoadInt64(&mem.maxBlockTxBytes); maxBlockTxBytes > 0 && maxBlockTxBytes < maxBytes {
+		maxBytes = maxBlockTxBytes
+	}
+	return int(maxBytes)
+}
+
+// SetLimits changes the maximum number of transactions of the mempool, and
+// their maximum total size in bytes, e.g. upon a reload of the configuration.
+// The transactions already in the mempool are kept if above the new limits.
+func (mem *CListMempool) SetLimits(maxTxs int, maxTxsBytes int64) {
+	atomic.StoreInt64(&mem.maxTxs, int64(maxTxs))
+	atomic.StoreInt64(&mem.maxTxsBytes, maxTxsBytes)
+}
+
 func (mem *CListMempool) isFull(txSize int) error {
+	return mem.isFullReplacing(txSize, nil)
+}
+
+// isFullReplacing is isFull for a tx replacing the given pending tx, if not
+// nil, whose space is freed by the replacement.
+func (mem *CListMempool) isFullReplacing(txSize int, replaced *mempoolTx) error {
 	var (
-		memSize  = mem.Size()
-		txsBytes = mem.SizeBytes()
+		memSize     = mem.Size()
+		txsBytes    = mem.SizeBytes()
+		maxTxs      = int(atomic.LoadInt64(&mem.maxTxs))
+		maxTxsBytes = atomic.LoadInt64(&mem.maxTxsBytes)
 	)
+	if replaced != nil {
+		memSize--
+		txsBytes -= int64(len(replaced.tx))
+	}
 
-	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+	if memSize >= maxTxs || int64(txSize)+txsBytes > maxTxsBytes {
 		return ErrMempoolIsFull{
 			NumTxs:      memSize,
-			MaxTxs:      mem.config.Size,
+			MaxTxs:      maxTxs,
 			TxsBytes:    txsBytes,
-			MaxTxsBytes: mem.config.MaxTxsBytes,
+			MaxTxsBytes: maxTxsBytes,
 		}
 	}
 
 	return nil
 }
 
+// SizeGas returns the total gas wanted by the transactions of the mempool.
+func (mem *CListMempool) SizeGas() int64 {
+	return atomic.LoadInt64(&mem.txsGas)
+}
+
+// isGasFull returns an error if the total gas of the mempool exceeds the gas
+// admission limit, see config.GasAdmissionFactor.
+func (mem *CListMempool) isGasFull() error {
+	maxTxsGas := atomic.LoadInt64(&mem.maxTxsGas)
+	if maxTxsGas <= 0 {
+		return nil
+	}
+	if txsGas := mem.SizeGas(); txsGas >= maxTxsGas {
+		return ErrMempoolGasIsFull{TxsGas: txsGas, MaxTxsGas: maxTxsGas}
+	}
+	return nil
+}
+
 // callback, which is called after the app checked the tx for the first time.
 //
 // The case where the app checks the tx for the second and subsequent times is
@@ -376,25 +759,75 @@ func (mem *CListMempool) resCbFirstTime(
 ) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
+		if mem.resultCache != nil {
+			mem.resultCache.Add(types.Tx(tx).Key(), r.CheckTx)
+		}
+
 		var postCheckErr error
 		if mem.postCheck != nil {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
+			// A tx with the same sender and sequence as a pending tx replaces it
+			// only if it has a strictly higher priority.
+			var (
+				replaced     *mempoolTx
+				replacedElem *clist.CElement
+			)
+			if mem.config.SenderLanes && r.CheckTx.Sender != "" {
+				if e, ok := mem.lanes.Get(r.CheckTx.Sender, r.CheckTx.Sequence); ok {
+					pending := e.Value.(*mempoolTx)
+					if r.CheckTx.Priority <= pending.Priority() {
+						mem.cache.Remove(tx)
+						mem.logger.Debug(
+							"rejected transaction with the same sender and sequence as a pending transaction",
+							"tx", types.Tx(tx).Hash(),
+							"pending", pending.tx.Hash(),
+							"sender", r.CheckTx.Sender,
+							"sequence", r.CheckTx.Sequence,
+						)
+						mem.metrics.RejectedTxs.Add(1)
+						mem.evict(tx, EvictionReasonRejected)
+						return
+					}
+					replaced, replacedElem = pending, e
+				}
+			}
+
 			// Check mempool isn't full again to reduce the chance of exceeding the
-			// limits.
-			if err := mem.isFull(len(tx)); err != nil {
+			// limits, before removing the replaced tx, so that it stays pending if
+			// the new one is rejected.
+			if err := mem.isFullReplacing(len(tx), replaced); err != nil {
 				// remove from cache (mempool might have a space later)
 				mem.cache.Remove(tx)
 				mem.logger.Error(err.Error())
+				mem.evict(tx, EvictionReasonRejected)
 				return
 			}
 
+			if replaced != nil {
+				mem.removeTx(replaced.tx, replacedElem, false)
+				mem.evict(replaced.tx, EvictionReasonReplaced)
+				mem.logger.Debug(
+					"replaced pending transaction",
+					"tx", types.Tx(tx).Hash(),
+					"replaced", replaced.tx.Hash(),
+					"sender", r.CheckTx.Sender,
+					"sequence", r.CheckTx.Sequence,
+				)
+				mem.metrics.EvictedTxs.Add(1)
+			}
+
 			memTx := &mempoolTx{
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
+				priority:  r.CheckTx.Priority,
+				sender:    r.CheckTx.Sender,
+				sequence:  r.CheckTx.Sequence,
+				fee:       r.CheckTx.Fee,
 				tx:        tx,
 			}
+			memTx.lane.Store(r.CheckTx.Lane)
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Debug(
@@ -458,6 +891,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 				// matching the one we received from the ABCI application.
 				// Return without processing any tx.
 				mem.recheckCursor = nil
+				close(mem.recheckDone)
 				return
 			}
 
@@ -465,19 +899,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			memTx = mem.recheckCursor.Value.(*mempoolTx)
 		}
 
-		var postCheckErr error
-		if mem.postCheck != nil {
-			postCheckErr = mem.postCheck(tx, r.CheckTx)
-		}
-
-		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Good, nothing to do.
-		} else {
-			// Tx became invalidated due to newly committed block.
-			mem.logger.Debug("tx is no longer valid", "tx", types.Tx(tx).Hash(), "res", r, "err", postCheckErr)
-			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
-		}
+		mem.handleRecheckResult(mem.recheckCursor, r.CheckTx)
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
 		} else {
@@ -486,6 +908,8 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 		if mem.recheckCursor == nil {
 			// Done!
 			mem.logger.Debug("done rechecking txs")
+			close(mem.recheckDone)
+			mem.metrics.RecheckDurationSeconds.Observe(time.Since(mem.recheckStart).Seconds())
 
 			// incase the recheck removed all txs
 			if mem.Size() > 0 {
@@ -497,6 +921,30 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	}
 }
 
+// handleRecheckResult removes the tx held by e from the mempool if it is no
+// longer valid according to res.
+func (mem *CListMempool) handleRecheckResult(e *clist.CElement, res *abci.ResponseCheckTx) {
+	memTx := e.Value.(*mempoolTx)
+
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(memTx.tx, res)
+	}
+
+	if (res.Code == abci.CodeTypeOK) && postCheckErr == nil {
+		// Good, the application may have changed the priority and the lane of
+		// the tx.
+		atomic.StoreInt64(&memTx.priority, res.Priority)
+		memTx.lane.Store(res.Lane)
+	} else {
+		// Tx became invalidated due to newly committed block.
+		mem.logger.Debug("tx is no longer valid", "tx", memTx.tx.Hash(), "res", res, "err", postCheckErr)
+		// NOTE: we remove tx from the cache because it might be good later
+		mem.removeTx(memTx.tx, e, !mem.config.KeepInvalidTxsInCache)
+		mem.evict(memTx.tx, EvictionReasonRecheck)
+	}
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsAvailable() <-chan struct{} {
 	return mem.txsAvailable
@@ -521,38 +969,46 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
-	var (
-		totalGas    int64
-		runningSize int64
-	)
-
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
-		txs = append(txs, memTx.tx)
+	memTxs := mem.reapOrder()
+
+	// The transactions of the priority lanes take the block space reserved
+	// for their lane first.
+	reserved, runningSize, totalGas := reapPriorityLanes(memTxs, mem.priorityLanes, mem.config.SenderLanes,
+		maxBytes, maxGas)
+
+	full := false
+	txs := make([]types.Tx, 0, len(memTxs))
+	for _, memTx := range memTxs {
+		if _, ok := reserved[memTx]; ok {
+			txs = append(txs, memTx.tx)
+			continue
+		}
+		if full {
+			continue
+		}
 
 		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
 
-		// Check total size requirement
-		if maxBytes > -1 && runningSize+dataSize > maxBytes {
-			return txs[:len(txs)-1]
+		// Check total size and gas requirements.
+		// If maxGas is negative, skip the gas check.
+		// Since totalGas <= maxGas, which
+		// must be non-negative, it follows that this won't overflow.
+		if (maxBytes > -1 && runningSize+dataSize > maxBytes) ||
+			(maxGas > -1 && totalGas+memTx.gasWanted > maxGas) {
+			if len(reserved) == 0 {
+				break
+			}
+			// the reserved txs coming next in the order still make it
+			full = true
+			continue
 		}
 
 		runningSize += dataSize
-
-		// Check total gas requirement.
-		// If maxGas is negative, skip this check.
-		// Since newTotalGas < masGas, which
-		// must be non-negative, it follows that this won't overflow.
-		newTotalGas := totalGas + memTx.gasWanted
-		if maxGas > -1 && newTotalGas > maxGas {
-			return txs[:len(txs)-1]
-		}
-		totalGas = newTotalGas
+		totalGas += memTx.gasWanted
+		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
@@ -566,14 +1022,42 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 		max = mem.txs.Len()
 	}
 
-	txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	memTxs := mem.reapOrder()
+	txs := make([]types.Tx, 0, cmtmath.MinInt(len(memTxs), max))
+	for _, memTx := range memTxs {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
 
+// reapOrder returns the transactions in the order in which they should be
+// reaped. This is the order in which they were added to the mempool, unless
+// the mempool is prioritized, in which case transactions are sorted by
+// descending priority and ties are broken by the order in which they were
+// added. With sender lanes enabled, the transactions of each sender are
+// additionally reaped in ascending sequence order.
+func (mem *CListMempool) reapOrder() []*mempoolTx {
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+
+	if mem.config.Prioritized {
+		sort.SliceStable(memTxs, func(i, j int) bool {
+			return memTxs[i].Priority() > memTxs[j].Priority()
+		})
+	}
+
+	if mem.config.SenderLanes {
+		orderBySequence(memTxs)
+	}
+
+	return memTxs
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
@@ -593,6 +1077,17 @@ func (mem *CListMempool) Update(
 		mem.postCheck = postCheck
 	}
 
+	// The block may change the result of CheckTx for any transaction.
+	if mem.resultCache != nil {
+		mem.resultCache.Reset()
+	}
+
+	if mem.blockGas != nil {
+		mem.blockGas.Add(blockGasWanted(deliverTxResponses))
+		atomic.StoreInt64(&mem.maxTxsGas,
+			gasAdmissionLimit(mem.config.GasAdmissionFactor, mem.blockGas.Average()))
+	}
+
 	for i, tx := range txs {
 		if deliverTxResponses[i].Code == abci.CodeTypeOK {
 			// Add valid committed tx to the cache (if missing).
@@ -602,6 +1097,10 @@ func (mem *CListMempool) Update(
 			mem.cache.Remove(tx)
 		}
 
+		if mem.evicted != nil {
+			mem.evicted.Remove(tx.Key())
+		}
+
 		// Remove committed tx from the mempool.
 		//
 		// Note an evil proposer can drop valid txs!
@@ -617,15 +1116,24 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	if mem.wal != nil {
+		mem.syncWAL()
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
-		if mem.config.Recheck {
+		if mem.config.Recheck && len(mem.recheckConns) > 0 {
+			mem.logger.Debug("recheck txs in parallel", "numtxs", mem.Size(), "height", height,
+				"conns", len(mem.recheckConns))
+			mem.recheckTxsParallel()
+		} else if mem.config.Recheck {
 			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
 			mem.recheckTxs()
 			// At this point, mem.txs are being rechecked.
 			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
 			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
+			// The new txs checked over checkTxConns wait for it in CheckTx.
 		} else {
 			mem.notifyTxsAvailable()
 		}
@@ -637,6 +1145,32 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// syncWAL flushes the WAL to disk, compacting it first if it grew too large.
+//
+// Called from Update (lock held).
+func (mem *CListMempool) syncWAL() {
+	var err error
+	if mem.wal.NeedsCompaction(mem.Size(), mem.SizeBytes()) {
+		err = mem.wal.Compact(mem.pendingTxs())
+	} else {
+		err = mem.wal.Sync()
+	}
+	if err != nil {
+		mem.logger.Error("Failed to sync the WAL", "err", err)
+	}
+}
+
+// pendingTxs returns the txs of the mempool, in order.
+//
+// The caller must hold the lock.
+func (mem *CListMempool) pendingTxs() types.Txs {
+	pending := make(types.Txs, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		pending = append(pending, e.Value.(*mempoolTx).tx)
+	}
+	return pending
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
@@ -644,6 +1178,8 @@ func (mem *CListMempool) recheckTxs() {
 
 	mem.recheckCursor = mem.txs.Front()
 	mem.recheckEnd = mem.txs.Back()
+	mem.recheckStart = time.Now()
+	mem.recheckDone = make(chan struct{})
 
 	// Push txs to proxyAppConn
 	// NOTE: globalCb may be called concurrently.
@@ -658,14 +1194,105 @@ func (mem *CListMempool) recheckTxs() {
 	mem.proxyAppConn.FlushAsync()
 }
 
+// recheckTxsParallel rechecks all txs in the background, distributing them
+// among the recheck connections, cancelling the recheck of the previous block
+// if still in progress. As with recheckTxs, CheckTx and the reaping aren't
+// blocked while the txs are rechecked.
+//
+// Lock() must be held by the caller during execution.
+func (mem *CListMempool) recheckTxsParallel() {
+	if mem.recheckQuit != nil {
+		close(mem.recheckQuit)
+	}
+	quit := make(chan struct{})
+	mem.recheckQuit = quit
+
+	elems := make([]*clist.CElement, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		elems = append(elems, e)
+	}
+	go mem.recheckTxsParallelRoutine(elems, quit)
+}
+
+// recheckTxsParallelRoutine rechecks the txs held by elems over the recheck
+// connections, then removes the ones which became invalid with the lock held,
+// unless quit was closed in the meantime.
+func (mem *CListMempool) recheckTxsParallelRoutine(elems []*clist.CElement, quit <-chan struct{}) {
+	start := time.Now()
+
+	results := make([]*abci.ResponseCheckTx, len(elems))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for _, conn := range mem.recheckConns {
+		wg.Add(1)
+		go func(conn proxy.AppConnMempool) {
+			defer wg.Done()
+			for i := range jobs {
+				res, err := conn.CheckTxSync(abci.RequestCheckTx{
+					Tx:   elems[i].Value.(*mempoolTx).tx,
+					Type: abci.CheckTxType_Recheck,
+				})
+				if err != nil {
+					mem.logger.Error("error rechecking tx", "err", err)
+					continue
+				}
+				results[i] = res
+			}
+		}(conn)
+	}
+dispatch:
+	for i := range elems {
+		select {
+		case jobs <- i:
+		case <-quit:
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	mem.Lock()
+	defer mem.Unlock()
+	select {
+	case <-quit:
+		// the txs are rechecked again after the next block
+		return
+	default:
+	}
+
+	// Results are applied serially, in mempool order.
+	for i, e := range elems {
+		if results[i] == nil || e.Removed() {
+			// The tx could not be rechecked, keep it, or was committed.
+			continue
+		}
+		mem.handleRecheckResult(e, results[i])
+	}
+
+	mem.metrics.RecheckTimes.Add(float64(len(elems)))
+	mem.metrics.RecheckDurationSeconds.Observe(time.Since(start).Seconds())
+	mem.logger.Debug("done rechecking txs", "duration", time.Since(start))
+
+	if mem.Size() > 0 {
+		mem.notifyTxsAvailable()
+	}
+}
+
 //--------------------------------------------------------------------------------
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
+	priority  int64    // priority reported by the app in the last CheckTx
+	sender    string   // sender reported by the app, used for sender lanes
+	sequence  uint64   // sequence of this tx in the sender's lane
+	fee       int64    // fee reported by the app, compared to the peers' gossip filters
 	tx        types.Tx //
 
+	// priority lane reported by the app in the last CheckTx, a string
+	lane atomic.Value
+
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders sync.Map
@@ -675,3 +1302,14 @@ type mempoolTx struct {
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// Priority returns the priority for this transaction
+func (memTx *mempoolTx) Priority() int64 {
+	return atomic.LoadInt64(&memTx.priority)
+}
+
+// Lane returns the priority lane of this transaction, if any
+func (memTx *mempoolTx) Lane() string {
+	lane, _ := memTx.lane.Load().(string)
+	return lane
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	mrand "math/rand"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -167,6 +168,368 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+// priorityApp sets the priority of a transaction to the value of its first
+// byte.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: 1, Priority: int64(req.Tx[0])}
+}
+
+func TestReapPrioritized(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.Prioritized = true
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	txs := []types.Tx{{1, 0}, {3, 0}, {2, 0}, {3, 1}, {1, 1}}
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// Higher priorities come first, ties are broken by arrival order.
+	expected := types.Txs{{3, 0}, {3, 1}, {2, 0}, {1, 0}, {1, 1}}
+	assert.Equal(t, expected, mp.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, expected[:2], mp.ReapMaxBytesMaxGas(-1, 2))
+	assert.Equal(t, expected, mp.ReapMaxTxs(-1))
+
+	// Without prioritization, transactions are reaped in arrival order.
+	mp.config.Prioritized = false
+	assert.Equal(t, types.Txs(txs), mp.ReapMaxBytesMaxGas(-1, -1))
+}
+
+// laneApp reads the sender, sequence and priority of a transaction from its
+// first three bytes.
+type laneApp struct {
+	abci.BaseApplication
+}
+
+func (laneApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{
+		Code:      abci.CodeTypeOK,
+		GasWanted: 1,
+		Sender:    string(req.Tx[:1]),
+		Sequence:  uint64(req.Tx[1]),
+		Priority:  int64(req.Tx[2]),
+	}
+}
+
+func TestReapSenderLanes(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(laneApp{})
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.Prioritized = true
+	conf.Mempool.SenderLanes = true
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	// Sender a submits its txs out of order, with the later sequence having a
+	// higher priority.
+	txs := []types.Tx{{'a', 2, 5}, {'b', 1, 3}, {'a', 1, 1}}
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+	assert.Equal(t, types.Txs{{'a', 1, 1}, {'b', 1, 3}, {'a', 2, 5}}, mp.ReapMaxTxs(-1))
+
+	// A tx with the same sender and sequence but a lower or equal priority is
+	// rejected.
+	require.NoError(t, mp.CheckTx(types.Tx{'a', 1, 1, 0}, nil, TxInfo{}))
+	assert.Equal(t, 3, mp.Size())
+
+	// A tx with a higher priority replaces the pending one.
+	require.NoError(t, mp.CheckTx(types.Tx{'b', 1, 4}, nil, TxInfo{}))
+	assert.Equal(t, 3, mp.Size())
+	assert.Equal(t, types.Txs{{'a', 1, 1}, {'b', 1, 4}, {'a', 2, 5}}, mp.ReapMaxTxs(-1))
+
+	// A replacement rejected as the mempool is full keeps the pending tx.
+	mp.SetLimits(3, 9)
+	tx := types.Tx{'a', 2, 6, 0, 0}
+	res := laneApp{}.CheckTx(abci.RequestCheckTx{Tx: tx})
+	mp.resCbFirstTime(tx, 0, "", abci.ToResponseCheckTx(res))
+	assert.Equal(t, types.Txs{{'a', 1, 1}, {'b', 1, 4}, {'a', 2, 5}}, mp.ReapMaxTxs(-1))
+	_, ok := mp.lanes.Get("a", 2)
+	assert.True(t, ok)
+	// but one fitting in the space freed by the replaced tx is accepted
+	tx = types.Tx{'a', 2, 6}
+	res = laneApp{}.CheckTx(abci.RequestCheckTx{Tx: tx})
+	mp.resCbFirstTime(tx, 0, "", abci.ToResponseCheckTx(res))
+	assert.Equal(t, types.Txs{{'a', 1, 1}, {'b', 1, 4}, {'a', 2, 6}}, mp.ReapMaxTxs(-1))
+
+	// Committed txs are removed from their lane.
+	err := mp.Update(1, types.Txs{{'b', 1, 4}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	_, ok = mp.lanes.Get("b", 1)
+	assert.False(t, ok)
+}
+
+// priorityLaneApp assigns the transactions starting with 'o' to the oracle
+// lane, and the ones starting with 'p' too once rechecked. The transactions of
+// three bytes have the sender and the sequence of their last two bytes.
+type priorityLaneApp struct {
+	abci.BaseApplication
+}
+
+func (priorityLaneApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: 1}
+	if req.Tx[0] == 'o' || (req.Tx[0] == 'p' && req.Type == abci.CheckTxType_Recheck) {
+		res.Lane = "oracle"
+	}
+	if len(req.Tx) == 3 {
+		res.Sender = string(req.Tx[1:2])
+		res.Sequence = uint64(req.Tx[2])
+	}
+	return res
+}
+
+func TestReapPriorityLanes(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityLaneApp{})
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.PriorityLanes = "oracle:20"
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	// the oracle txs arrive after the spam
+	var spam, oracle types.Txs
+	for i := byte(0); i < 10; i++ {
+		spam = append(spam, types.Tx{'s', i})
+	}
+	for i := byte(0); i < 3; i++ {
+		oracle = append(oracle, types.Tx{'o', i})
+	}
+	for _, tx := range append(spam, oracle...) {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// 20% of the gas is reserved for the oracle txs, reaped in order
+	assert.Equal(t, types.Txs{spam[0], spam[1], spam[2], spam[3], oracle[0]}, mp.ReapMaxBytesMaxGas(-1, 5))
+	assert.Equal(t, append(spam[:8:8], oracle[:2]...), mp.ReapMaxBytesMaxGas(-1, 10))
+
+	// and 20% of the bytes, 4 bytes per tx
+	assert.Equal(t, types.Txs{spam[0], spam[1], spam[2], spam[3], oracle[0]}, mp.ReapMaxBytesMaxGas(20, -1))
+
+	assert.Equal(t, append(spam[:10:10], oracle...), mp.ReapMaxBytesMaxGas(-1, -1))
+
+	// without lanes, the oracle txs are starved
+	mp.priorityLanes = nil
+	assert.Equal(t, spam[:5], mp.ReapMaxBytesMaxGas(-1, 5))
+}
+
+func TestReapPriorityLanesRecheck(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityLaneApp{})
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.PriorityLanes = "oracle:20"
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	txs := types.Txs{{'s', 0}, {'s', 1}, {'s', 2}, {'s', 3}, {'s', 4}, {'p', 0}}
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+	assert.Equal(t, txs[:5], mp.ReapMaxBytesMaxGas(-1, 5))
+
+	// the app moves the tx to the oracle lane on recheck
+	mp.Lock()
+	require.NoError(t, mp.Update(1, nil, nil, nil, nil))
+	mp.Unlock()
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(append(txs[:4:4], txs[5]), mp.ReapMaxBytesMaxGas(-1, 5))
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReapPriorityLanesSenderLanes(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityLaneApp{})
+	conf := test.ResetTestRoot("mempool_test")
+	conf.Mempool.PriorityLanes = "oracle:20"
+	conf.Mempool.SenderLanes = true
+	mp, cleanup := newMempoolWithAppAndConfig(cc, conf)
+	defer cleanup()
+
+	// the oracle tx of a comes after a spam one
+	txs := types.Txs{{'s', 'c', 1}, {'s', 'c', 2}, {'s', 'c', 3}, {'s', 'c', 4}, {'s', 'a', 1}, {'o', 'a', 2}, {'o', 'b', 1}}
+	for _, tx := range txs {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// the oracle tx of a isn't reaped without the previous one
+	assert.Equal(t, types.Txs{txs[0], txs[1], txs[2], txs[3], txs[6]}, mp.ReapMaxBytesMaxGas(-1, 5))
+	assert.Equal(t, txs, mp.ReapMaxBytesMaxGas(-1, -1))
+}
+
+// recheckApp invalidates transactions with an odd first byte on recheck.
+type recheckApp struct {
+	abci.BaseApplication
+}
+
+func (recheckApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if req.Type == abci.CheckTxType_Recheck && req.Tx[0]%2 == 1 {
+		return abci.ResponseCheckTx{Code: 1}
+	}
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: 1}
+}
+
+func TestMempoolParallelRecheck(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(recheckApp{})
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	var conns []proxy.AppConnMempool
+	for i := 0; i < 3; i++ {
+		client, err := cc.NewABCIClient()
+		require.NoError(t, err)
+		require.NoError(t, client.Start())
+		t.Cleanup(func() {
+			if err := client.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		conns = append(conns, proxy.NewAppConnMempool(client, proxy.NopMetrics()))
+	}
+	WithRecheckConns(conns...)(mp)
+
+	var txs types.Txs
+	for i := byte(0); i < 20; i++ {
+		tx := types.Tx{i}
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+		if i%2 == 0 {
+			txs = append(txs, tx)
+		}
+	}
+
+	mp.Lock()
+	err := mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+
+	// Invalid txs are removed in the background, without holding the lock.
+	require.Eventually(t, func() bool {
+		mp.Lock()
+		defer mp.Unlock()
+		return mp.Size() == len(txs)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, txs, mp.ReapMaxTxs(-1))
+}
+
+// checkTxRecorderApp records the txs it checks.
+type checkTxRecorderApp struct {
+	abci.BaseApplication
+
+	mtx sync.Mutex
+	txs types.Txs
+}
+
+func (app *checkTxRecorderApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.txs = append(app.txs, req.Tx)
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: 1}
+}
+
+func TestMempoolCheckTxConns(t *testing.T) {
+	apps := []*checkTxRecorderApp{{}, {}, {}}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(apps[0]))
+	defer cleanup()
+
+	var conns []proxy.AppConnMempool
+	for _, app := range apps[1:] {
+		client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+		require.NoError(t, err)
+		require.NoError(t, client.Start())
+		t.Cleanup(func() {
+			if err := client.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		conns = append(conns, proxy.NewAppConnMempool(client, proxy.NopMetrics()))
+	}
+	WithCheckTxConns(conns...)(mp)
+
+	// The txs of each peer are checked over a single connection, in order.
+	for i := byte(0); i < 10; i++ {
+		for peerID := uint16(1); peerID <= 3; peerID++ {
+			tx := types.Tx{byte(peerID), i}
+			require.NoError(t, mp.CheckTx(tx, nil, TxInfo{SenderID: peerID}))
+		}
+	}
+	// The txs of unknown senders are spread by hash.
+	for i := byte(0); i < 30; i++ {
+		require.NoError(t, mp.CheckTx(types.Tx{0, i}, nil, TxInfo{SenderID: UnknownPeerID}))
+	}
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, 60, mp.Size())
+
+	for peerID := byte(1); peerID <= 3; peerID++ {
+		var peerTxs types.Txs
+		for i := byte(0); i < 10; i++ {
+			peerTxs = append(peerTxs, types.Tx{peerID, i})
+		}
+		app := apps[int(peerID)%len(apps)]
+		var checked types.Txs
+		for _, tx := range app.txs {
+			if tx[0] == peerID {
+				checked = append(checked, tx)
+			}
+		}
+		assert.Equal(t, peerTxs, checked)
+	}
+	for _, app := range apps {
+		assert.Greater(t, len(app.txs), 10)
+	}
+
+	// The new txs are checked once the remaining txs are rechecked.
+	mp.Lock()
+	err := mp.Update(1, types.Txs{{1, 0}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	<-mp.recheckDone
+	for peerID := uint16(1); peerID <= 3; peerID++ {
+		require.NoError(t, mp.CheckTx(types.Tx{byte(peerID), 10}, nil, TxInfo{SenderID: peerID}))
+	}
+	require.NoError(t, mp.FlushAppConn())
+	assert.Equal(t, 62, mp.Size())
+
+	// A recheck which doesn't complete doesn't block the new txs, which can
+	// be checked again afterwards.
+	mp.Lock()
+	mp.recheckDone = make(chan struct{})
+	mp.Unlock()
+	tx := types.Tx{1, 11}
+	assert.Equal(t, ErrRecheckPending, mp.CheckTx(tx, nil, TxInfo{SenderID: 1}))
+	close(mp.recheckDone)
+	require.NoError(t, mp.CheckTx(tx, nil, TxInfo{SenderID: 1}))
+}
+
+func TestMempoolEvictedTxs(t *testing.T) {
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(recheckApp{}))
+	defer cleanup()
+
+	valid, invalid, committed := types.Tx{2}, types.Tx{3}, types.Tx{4}
+	for _, tx := range []types.Tx{valid, invalid, committed} {
+		require.NoError(t, mp.CheckTx(tx, nil, TxInfo{}))
+	}
+	require.NoError(t, mp.RemoveTxByKey(committed.Key()))
+	evicted, ok := mp.EvictedTx(committed.Key())
+	require.True(t, ok)
+	assert.Equal(t, EvictedTx{Height: 0, Reason: EvictionReasonRemoved}, evicted)
+
+	// the invalid tx is evicted when rechecked, and the committed one isn't
+	// evicted anymore
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{committed}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+
+	evicted, ok = mp.EvictedTx(invalid.Key())
+	require.True(t, ok)
+	assert.Equal(t, EvictedTx{Height: 1, Reason: EvictionReasonRecheck}, evicted)
+	assert.False(t, mp.HasTx(invalid.Key()))
+	_, ok = mp.EvictedTx(committed.Key())
+	assert.False(t, ok)
+	_, ok = mp.EvictedTx(valid.Key())
+	assert.False(t, ok)
+	assert.True(t, mp.HasTx(valid.Key()))
+}
+
 func TestMempoolFilters(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -294,6 +657,70 @@ func TestMempoolUpdateDoesNotPanicWhenApplicationMissedTx(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+// countingApp rejects the transactions starting with an odd byte, and counts
+// the checks.
+type countingApp struct {
+	abci.BaseApplication
+	checks int
+}
+
+func (app *countingApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.checks++
+	if req.Tx[0]%2 == 1 {
+		return abci.ResponseCheckTx{Code: 1}
+	}
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+}
+
+func TestMempoolCheckTxCache(t *testing.T) {
+	app := &countingApp{}
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.CacheSize = 0 // so duplicates are not filtered out before
+	cfg.Mempool.CheckTxCacheSize = 10
+	mp, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(app), cfg)
+	defer cleanup()
+
+	checkTx := func(tx types.Tx) (*abci.ResponseCheckTx, error) {
+		var res *abci.ResponseCheckTx
+		err := mp.CheckTx(tx, func(r *abci.Response) { res = r.GetCheckTx() }, TxInfo{})
+		return res, err
+	}
+
+	// A resubmitted invalid tx gets the cached result.
+	bad := types.Tx{1}
+	res, err := checkTx(bad)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Code)
+	res, err = checkTx(bad)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Code)
+	assert.Equal(t, 1, app.checks)
+
+	// A duplicate of a tx in the mempool is filtered out.
+	good := types.Tx{2}
+	_, err = checkTx(good)
+	require.NoError(t, err)
+	_, err = checkTx(good)
+	assert.Equal(t, ErrTxInCache, err)
+	assert.Equal(t, 2, app.checks)
+	assert.Equal(t, 1, mp.Size())
+
+	// The results are invalidated by a block.
+	mp.Lock()
+	require.NoError(t, mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+	checks := app.checks // including the recheck of good
+	_, err = checkTx(bad)
+	require.NoError(t, err)
+	assert.Equal(t, checks+1, app.checks)
+
+	// The results are invalidated by a new app version.
+	mp.SetAppVersion(1)
+	_, err = checkTx(bad)
+	require.NoError(t, err)
+	assert.Equal(t, checks+2, app.checks)
+}
+
 func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -638,6 +1065,72 @@ func TestMempoolTxsBytes(t *testing.T) {
 
 }
 
+func TestMempoolSetLimits(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	mp.SetLimits(1, mp.config.MaxTxsBytes)
+	require.NoError(t, mp.CheckTx([]byte{0x01}, nil, TxInfo{}))
+	err := mp.CheckTx([]byte{0x02}, nil, TxInfo{})
+	if assert.Error(t, err) {
+		assert.Equal(t, ErrMempoolIsFull{NumTxs: 1, MaxTxs: 1, TxsBytes: 1, MaxTxsBytes: mp.config.MaxTxsBytes}, err)
+	}
+
+	mp.SetLimits(2, 2)
+	require.NoError(t, mp.CheckTx([]byte{0x02}, nil, TxInfo{}))
+	assert.Equal(t, 2, mp.Size())
+
+	// the txs above the new limits are kept
+	mp.SetLimits(1, 1)
+	assert.Equal(t, 2, mp.Size())
+	assert.IsType(t, ErrMempoolIsFull{}, mp.CheckTx([]byte{0x03}, nil, TxInfo{}))
+}
+
+func TestMempoolGasAdmission(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.GasAdmissionFactor = 2
+	cfg.Mempool.GasAdmissionBlocks = 2
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	update := func(height int64, txs types.Txs, gasWanted int64) {
+		mp.Lock()
+		defer mp.Unlock()
+		responses := make([]*abci.ResponseDeliverTx, len(txs))
+		for i := range txs {
+			responses[i] = &abci.ResponseDeliverTx{Code: abci.CodeTypeOK, GasWanted: gasWanted}
+		}
+		require.NoError(t, mp.Update(height, txs, responses, nil, nil))
+	}
+
+	// no limit until a block is committed
+	txs := checkTxs(t, mp, 3, UnknownPeerID)
+	assert.EqualValues(t, 3, mp.SizeGas())
+
+	// the average block gas is 1, the limit 2
+	update(1, txs[:1], 1)
+	assert.EqualValues(t, 2, mp.SizeGas())
+	err := mp.CheckTx([]byte{0x01, 0x02}, nil, TxInfo{})
+	assert.Equal(t, ErrMempoolGasIsFull{TxsGas: 2, MaxTxsGas: 2}, err)
+
+	// no limit while the recent blocks are empty
+	update(2, nil, 0)
+	require.NoError(t, mp.CheckTx([]byte{0x01, 0x02}, nil, TxInfo{}))
+	assert.EqualValues(t, 3, mp.SizeGas())
+
+	// the average block gas is 2, the limit 4
+	update(3, txs[1:3], 2)
+	assert.EqualValues(t, 1, mp.SizeGas())
+	require.NoError(t, mp.CheckTx([]byte{0x01, 0x03}, nil, TxInfo{}))
+	require.NoError(t, mp.CheckTx([]byte{0x01, 0x04}, nil, TxInfo{}))
+	require.NoError(t, mp.CheckTx([]byte{0x01, 0x05}, nil, TxInfo{}))
+	assert.IsType(t, ErrMempoolGasIsFull{}, mp.CheckTx([]byte{0x01, 0x06}, nil, TxInfo{}))
+}
+
 // This will non-deterministically catch some concurrency failures like
 // https://github.com/tendermint/tendermint/issues/3509
 // TODO: all of the tests should probably also run using the remote proxy app
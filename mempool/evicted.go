@@ -0,0 +1,112 @@
+package mempool
+
+import (
+	"container/list"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// EvictionReason is why a transaction was dropped by the mempool.
+type EvictionReason string
+
+const (
+	// EvictionReasonReplaced is for a transaction replaced by one with the
+	// same sender and sequence, and a higher priority.
+	EvictionReasonReplaced EvictionReason = "replaced"
+	// EvictionReasonRejected is for a transaction which passed CheckTx but
+	// wasn't added to the mempool, because it was full, or because a
+	// transaction with the same sender and sequence was pending with a higher
+	// priority.
+	EvictionReasonRejected EvictionReason = "rejected"
+	// EvictionReasonRecheck is for a transaction which became invalid when it
+	// was rechecked after a block.
+	EvictionReasonRecheck EvictionReason = "recheck"
+	// EvictionReasonRemoved is for a transaction removed by RemoveTxByKey.
+	EvictionReasonRemoved EvictionReason = "removed"
+)
+
+// EvictedTx is a transaction which the mempool dropped without it being
+// committed.
+type EvictedTx struct {
+	// Height is the height of the last block committed when the transaction
+	// was dropped.
+	Height int64
+	Reason EvictionReason
+}
+
+// evictedTxCache is a thread-safe LRU cache of the transactions evicted from
+// the mempool, keyed by transaction hash.
+type evictedTxCache struct {
+	mtx      cmtsync.Mutex
+	size     int
+	cacheMap map[types.TxKey]*list.Element
+	list     *list.List
+}
+
+type evictedTxEntry struct {
+	key types.TxKey
+	tx  EvictedTx
+}
+
+func newEvictedTxCache(cacheSize int) *evictedTxCache {
+	return &evictedTxCache{
+		size:     cacheSize,
+		cacheMap: make(map[types.TxKey]*list.Element, cacheSize),
+		list:     list.New(),
+	}
+}
+
+// Add records an evicted transaction, forgetting the least recently evicted
+// one if the cache is full.
+func (c *evictedTxCache) Add(key types.TxKey, tx EvictedTx) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		e.Value.(*evictedTxEntry).tx = tx
+		c.list.MoveToBack(e)
+		return
+	}
+
+	if c.list.Len() >= c.size {
+		if front := c.list.Front(); front != nil {
+			delete(c.cacheMap, front.Value.(*evictedTxEntry).key)
+			c.list.Remove(front)
+		}
+	}
+	c.cacheMap[key] = c.list.PushBack(&evictedTxEntry{key: key, tx: tx})
+}
+
+// Get returns the eviction of the transaction with the given key, if any.
+func (c *evictedTxCache) Get(key types.TxKey) (EvictedTx, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.cacheMap[key]
+	if !ok {
+		return EvictedTx{}, false
+	}
+	return e.Value.(*evictedTxEntry).tx, true
+}
+
+// Remove forgets the eviction of the transaction with the given key, e.g.
+// once it is added to the mempool again, or committed.
+func (c *evictedTxCache) Remove(key types.TxKey) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		delete(c.cacheMap, key)
+		c.list.Remove(e)
+	}
+}
+
+// Reset forgets all the evictions.
+func (c *evictedTxCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.cacheMap = make(map[types.TxKey]*list.Element, c.size)
+	c.list.Init()
+}
@@ -0,0 +1,78 @@
+package mempool
+
+import (
+	"math"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// blockGasWindow holds the gas wanted by the latest blocks, whose average
+// bounds the total gas of the mempool when config.GasAdmissionFactor is set.
+// It isn't thread-safe: it is only updated by Update, with the lock held.
+type blockGasWindow struct {
+	gas   []int64 // ring buffer of the gas of the latest blocks
+	next  int     // index of the next block in gas
+	count int     // number of blocks in gas
+	total int64   // sum of gas
+}
+
+func newBlockGasWindow(size int) *blockGasWindow {
+	return &blockGasWindow{gas: make([]int64, size)}
+}
+
+// Add records the gas wanted by a new block, forgetting the oldest block if
+// the window is full.
+func (w *blockGasWindow) Add(gas int64) {
+	if w.count == len(w.gas) {
+		w.total -= w.gas[w.next]
+	} else {
+		w.count++
+	}
+	w.gas[w.next] = gas
+	w.total += gas
+	w.next = (w.next + 1) % len(w.gas)
+}
+
+// Average returns the average gas wanted by the blocks of the window, or 0 if
+// none.
+func (w *blockGasWindow) Average() int64 {
+	if w.count == 0 {
+		return 0
+	}
+	return w.total / int64(w.count)
+}
+
+// blockGasWanted returns the total gas wanted by the transactions of a block,
+// ignoring negative values.
+func blockGasWanted(deliverTxResponses []*abci.ResponseDeliverTx) int64 {
+	total := int64(0)
+	for _, res := range deliverTxResponses {
+		if res.GasWanted > 0 {
+			total = saturatingAdd(total, res.GasWanted)
+		}
+	}
+	return total
+}
+
+// gasAdmissionLimit returns the maximum total gas of the mempool, factor times
+// average, or 0, no limit, if the average is 0.
+func gasAdmissionLimit(factor float64, average int64) int64 {
+	if average == 0 {
+		return 0
+	}
+	limit := factor * float64(average)
+	switch {
+	case limit >= math.MaxInt64:
+		return math.MaxInt64
+	case limit < 1:
+		return 1
+	}
+	return int64(limit)
+}
+
+func saturatingAdd(a, b int64) int64 {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
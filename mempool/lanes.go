@@ -0,0 +1,111 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/cometbft/cometbft/libs/clist"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// senderLanes indexes the transactions in the mempool by sender and sequence,
+// as reported by the application in ResponseCheckTx. It is used to replace a
+// pending transaction by a later one with the same sender and sequence, and to
+// reap the transactions of a sender in sequence order.
+type senderLanes struct {
+	mtx   cmtsync.Mutex
+	lanes map[string]map[uint64]*clist.CElement // sender -> sequence -> element
+}
+
+func newSenderLanes() *senderLanes {
+	return &senderLanes{
+		lanes: make(map[string]map[uint64]*clist.CElement),
+	}
+}
+
+// Get returns the element holding the transaction of the given sender with
+// the given sequence, if any.
+func (sl *senderLanes) Get(sender string, sequence uint64) (*clist.CElement, bool) {
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+
+	e, ok := sl.lanes[sender][sequence]
+	return e, ok
+}
+
+// Add adds the element to the lane of the transaction's sender. Transactions
+// without a sender are ignored.
+func (sl *senderLanes) Add(e *clist.CElement) {
+	memTx := e.Value.(*mempoolTx)
+	if memTx.sender == "" {
+		return
+	}
+
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+
+	lane, ok := sl.lanes[memTx.sender]
+	if !ok {
+		lane = make(map[uint64]*clist.CElement)
+		sl.lanes[memTx.sender] = lane
+	}
+	lane[memTx.sequence] = e
+}
+
+// Remove removes the element from the lane of the transaction's sender, if it
+// is still the one stored for its sequence.
+func (sl *senderLanes) Remove(e *clist.CElement) {
+	memTx := e.Value.(*mempoolTx)
+	if memTx.sender == "" {
+		return
+	}
+
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+
+	lane := sl.lanes[memTx.sender]
+	if lane[memTx.sequence] != e {
+		return
+	}
+	delete(lane, memTx.sequence)
+	if len(lane) == 0 {
+		delete(sl.lanes, memTx.sender)
+	}
+}
+
+// Reset removes all transactions from the lanes.
+func (sl *senderLanes) Reset() {
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+
+	sl.lanes = make(map[string]map[uint64]*clist.CElement)
+}
+
+// orderBySequence reorders memTxs in place so that the transactions of each
+// sender appear in ascending sequence order. Every transaction keeps the
+// position of one of its sender's transactions, so the relative order between
+// senders (e.g. by priority) is preserved.
+func orderBySequence(memTxs []*mempoolTx) {
+	positions := make(map[string][]int)
+	for i, memTx := range memTxs {
+		if memTx.sender == "" {
+			continue
+		}
+		positions[memTx.sender] = append(positions[memTx.sender], i)
+	}
+
+	for _, idxs := range positions {
+		if len(idxs) < 2 {
+			continue
+		}
+		laneTxs := make([]*mempoolTx, len(idxs))
+		for i, idx := range idxs {
+			laneTxs[i] = memTxs[idx]
+		}
+		sort.SliceStable(laneTxs, func(i, j int) bool {
+			return laneTxs[i].sequence < laneTxs[j].sequence
+		})
+		for i, idx := range idxs {
+			memTxs[idx] = laneTxs[i]
+		}
+	}
+}
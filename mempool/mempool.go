@@ -13,6 +13,10 @@ import (
 const (
 	MempoolChannel = byte(0x30)
 
+	// MempoolHashChannel is used to announce (HaveTx) and request (WantTx)
+	// transactions by key when push/pull gossip is enabled.
+	MempoolHashChannel = byte(0x31)
+
 	// PeerCatchupSleepIntervalMS defines how much time to sleep if a peer is behind
 	PeerCatchupSleepIntervalMS = 100
 
@@ -44,6 +48,10 @@ type Mempool interface {
 	//
 	// If both maxes are negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
+	//
+	// With priority lanes (see config.MempoolConfig.PriorityLanes), the
+	// transactions of each lane are reaped first, into the share of maxBytes
+	// and maxGas reserved for the lane.
 	ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs
 
 	// ReapMaxTxs reaps up to max transactions from the mempool. If max is
@@ -98,6 +106,13 @@ type Mempool interface {
 
 	// SizeBytes returns the total size of all txs in the mempool.
 	SizeBytes() int64
+
+	// InitWAL opens the write-ahead log of the pending transactions, and
+	// restores the transactions it records.
+	InitWAL() error
+
+	// CloseWAL closes the write-ahead log of the pending transactions.
+	CloseWAL()
 }
 
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
@@ -147,6 +162,11 @@ func PostCheckMaxGas(maxGas int64) PostCheckFunc {
 // ErrTxInCache is returned to the client if we saw tx earlier
 var ErrTxInCache = errors.New("tx already exists in cache")
 
+// ErrRecheckPending is returned when a tx to be checked over one of the
+// additional CheckTx connections timed out waiting for the recheck of the txs
+// of the mempool to complete.
+var ErrRecheckPending = errors.New("txs of the mempool still being rechecked")
+
 // TxKey is the fixed length array key used as an index.
 type TxKey [sha256.Size]byte
 
@@ -180,6 +200,22 @@ func (e ErrMempoolIsFull) Error() string {
 	)
 }
 
+// ErrMempoolGasIsFull defines an error where a transaction is rejected
+// because the total gas wanted by the transactions of the mempool exceeds the
+// gas admission limit, a multiple of the average gas of the recent blocks.
+type ErrMempoolGasIsFull struct {
+	TxsGas    int64
+	MaxTxsGas int64
+}
+
+func (e ErrMempoolGasIsFull) Error() string {
+	return fmt.Sprintf(
+		"mempool is full: total txs gas %d (max: %d)",
+		e.TxsGas,
+		e.MaxTxsGas,
+	)
+}
+
 // ErrPreCheck defines an error where a transaction fails a pre-check.
 type ErrPreCheck struct {
 	Reason error
@@ -46,22 +46,52 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "evicted_txs",
 			Help:      "Number of evicted transactions.",
 		}, labels).With(labelsAndValues...),
+		CheckTxCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "check_tx_cache_hits",
+			Help:      "Number of transactions whose CheckTx result was found in the CheckTx result cache, so they weren't checked by the application again.",
+		}, labels).With(labelsAndValues...),
+		CheckTxCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "check_tx_cache_misses",
+			Help:      "Number of transactions checked by the application because their CheckTx result wasn't in the CheckTx result cache.",
+		}, labels).With(labelsAndValues...),
+		GasAdmissionRejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gas_admission_rejected_txs",
+			Help:      "Number of transactions rejected before CheckTx because the total gas of the mempool exceeded the gas admission limit.",
+		}, labels).With(labelsAndValues...),
 		RecheckTimes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		RecheckDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "recheck_duration_seconds",
+			Help:      "Time spent rechecking all the transactions in the mempool after a block was committed.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.001, 10, 10),
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RejectedTxs:  discard.NewCounter(),
-		EvictedTxs:   discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:                    discard.NewGauge(),
+		TxSizeBytes:             discard.NewHistogram(),
+		FailedTxs:               discard.NewCounter(),
+		RejectedTxs:             discard.NewCounter(),
+		EvictedTxs:              discard.NewCounter(),
+		CheckTxCacheHits:        discard.NewCounter(),
+		CheckTxCacheMisses:      discard.NewCounter(),
+		GasAdmissionRejectedTxs: discard.NewCounter(),
+		RecheckTimes:            discard.NewCounter(),
+		RecheckDurationSeconds:  discard.NewHistogram(),
 	}
 }
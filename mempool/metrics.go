@@ -38,6 +38,22 @@ type Metrics struct {
 	//metrics:Number of evicted transactions.
 	EvictedTxs metrics.Counter
 
+	// Number of transactions whose CheckTx result was found in the CheckTx
+	// result cache, so they weren't checked by the application again.
+	CheckTxCacheHits metrics.Counter
+
+	// Number of transactions checked by the application because their
+	// CheckTx result wasn't in the CheckTx result cache.
+	CheckTxCacheMisses metrics.Counter
+
+	// Number of transactions rejected before CheckTx because the total gas
+	// of the mempool exceeded the gas admission limit.
+	GasAdmissionRejectedTxs metrics.Counter
+
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+
+	// Time spent rechecking all the transactions in the mempool after a block
+	// was committed.
+	RecheckDurationSeconds metrics.Histogram `metrics_buckettype:"exprange" metrics_bucketsizes:"0.001, 10, 10"`
 }
@@ -30,6 +30,11 @@ func (_m *Mempool) CheckTx(tx types.Tx, callback func(*abcitypes.Response), txIn
 	return r0
 }
 
+// CloseWAL provides a mock function with given fields:
+func (_m *Mempool) CloseWAL() {
+	_m.Called()
+}
+
 // EnableTxsAvailable provides a mock function with given fields:
 func (_m *Mempool) EnableTxsAvailable() {
 	_m.Called()
@@ -54,6 +59,20 @@ func (_m *Mempool) FlushAppConn() error {
 	return r0
 }
 
+// InitWAL provides a mock function with given fields:
+func (_m *Mempool) InitWAL() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Lock provides a mock function with given fields:
 func (_m *Mempool) Lock() {
 	_m.Called()
@@ -0,0 +1,85 @@
+package mempool
+
+import (
+	"github.com/cometbft/cometbft/types"
+)
+
+// laneSpace is the block space reserved for a priority lane, and the part of
+// it taken by the transactions reaped so far.
+type laneSpace struct {
+	maxBytes int64 // -1 if no limit
+	maxGas   int64 // -1 if no limit
+	bytes    int64
+	gas      int64
+	full     bool
+}
+
+// reapPriorityLanes returns the transactions of memTxs, given in reap order,
+// assigned to one of the priority lanes by the application, which fit in the
+// percentage of maxBytes and maxGas reserved for their lane, along with their
+// total size and gas. As for the whole block, the transactions of a lane are
+// reaped until the first one which doesn't fit. With sender lanes, a
+// transaction is only reaped along with all the previous ones of its sender,
+// so that the sequences of the senders have no gaps.
+func reapPriorityLanes(
+	memTxs []*mempoolTx,
+	lanes map[string]int,
+	senderLanes bool,
+	maxBytes, maxGas int64,
+) (map[*mempoolTx]struct{}, int64, int64) {
+	if len(lanes) == 0 {
+		return nil, 0, 0
+	}
+	spaces := make(map[string]*laneSpace, len(lanes))
+	for lane, percent := range lanes {
+		spaces[lane] = &laneSpace{
+			maxBytes: reservedSpace(maxBytes, percent),
+			maxGas:   reservedSpace(maxGas, percent),
+		}
+	}
+
+	var (
+		reaped     = make(map[*mempoolTx]struct{})
+		totalBytes int64
+		totalGas   int64
+		// senders with a transaction not reaped
+		skipped = make(map[string]struct{})
+	)
+	for _, memTx := range memTxs {
+		if senderLanes && memTx.sender != "" {
+			if _, ok := skipped[memTx.sender]; ok {
+				continue
+			}
+			// the next transactions of the sender are skipped too, unless
+			// this one is reaped
+			skipped[memTx.sender] = struct{}{}
+		}
+		space, ok := spaces[memTx.Lane()]
+		if !ok || space.full {
+			continue
+		}
+		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
+		if (space.maxBytes > -1 && space.bytes+dataSize > space.maxBytes) ||
+			(space.maxGas > -1 && space.gas+memTx.gasWanted > space.maxGas) {
+			space.full = true
+			continue
+		}
+		delete(skipped, memTx.sender)
+		space.bytes += dataSize
+		space.gas += memTx.gasWanted
+		totalBytes += dataSize
+		totalGas += memTx.gasWanted
+		reaped[memTx] = struct{}{}
+	}
+	return reaped, totalBytes, totalGas
+}
+
+// reservedSpace returns percent percents of max, or -1, no limit, if max is
+// negative.
+func reservedSpace(max int64, percent int) int64 {
+	if max < 0 {
+		return -1
+	}
+	// max*percent/100 without overflowing
+	return max/100*int64(percent) + max%100*int64(percent)/100
+}
@@ -5,14 +5,41 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cosmos/gogoproto/proto"
+
 	cfg "github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/libs/clist"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/p2p"
 	protomem "github.com/cometbft/cometbft/proto/tendermint/mempool"
 	"github.com/cometbft/cometbft/types"
 )
 
+const (
+	// WantTxTimeout is how long the reactor waits for a peer to send a
+	// transaction requested with WantTx before requesting it from another peer
+	// that announced it.
+	WantTxTimeout = 2 * time.Second
+
+	// maxTxAnnouncers is the number of peers announcing a tx being requested
+	// which are recorded to request it from if the requested peer doesn't send
+	// it.
+	maxTxAnnouncers = 8
+
+	// PushPullQueueSize is the number of WantTx requests and requested
+	// transactions that can be queued for a peer before new ones are dropped.
+	PushPullQueueSize = 1000
+
+	// MempoolChannelVersion is the highest version of the messages of
+	// MempoolChannel the reactor supports.
+	MempoolChannelVersion = uint32(2)
+
+	// gossipFilterVersion is the version of MempoolChannel adding
+	// GossipFilter.
+	gossipFilterVersion = uint32(2)
+)
+
 // Reactor handles mempool tx broadcasting amongst peers.
 // It maintains a map from peer ID to counter, to prevent gossiping txs to the
 // peers you received it from.
@@ -21,6 +48,22 @@ type Reactor struct {
 	config  *cfg.MempoolConfig
 	mempool *CListMempool
 	ids     *mempoolIDs
+
+	// Txs requested with WantTx and not received yet, used to avoid
+	// requesting the same tx from several peers announcing it, and to request
+	// it from the next one if it isn't received within WantTxTimeout.
+	wantedMtx cmtsync.Mutex
+	wanted    map[types.TxKey]*wantedTx
+
+	// Messages sent in response to HaveTx and WantTx, per peer. They are sent
+	// from a separate routine so that Receive never blocks on a peer's send
+	// queue.
+	queuesMtx cmtsync.Mutex
+	queues    map[p2p.ID]chan p2p.Envelope
+
+	// Gossip filters sent by the peers, applied to the txs gossiped to them.
+	filtersMtx cmtsync.RWMutex
+	filters    map[p2p.ID]gossipFilter
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
@@ -29,6 +72,9 @@ func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 		config:  config,
 		mempool: mempool,
 		ids:     newMempoolIDs(),
+		wanted:  make(map[types.TxKey]*wantedTx),
+		queues:  make(map[p2p.ID]chan p2p.Envelope),
+		filters: make(map[p2p.ID]gossipFilter),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	return memR
@@ -51,32 +97,78 @@ func (memR *Reactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
 	}
+	if memR.config.PushPullGossip {
+		go memR.wantTxRoutine()
+	}
 	return nil
 }
 
 // GetChannels implements Reactor by returning the list of channels for this
 // reactor.
 func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
-	largestTx := make([]byte, memR.config.MaxTxBytes)
-	batchMsg := protomem.Message{
-		Sum: &protomem.Message_Txs{
-			Txs: &protomem.Txs{Txs: [][]byte{largestTx}},
+	chs := []*p2p.ChannelDescriptor{
+		{
+			ID:                  MempoolChannel,
+			Priority:            5,
+			RecvMessageCapacity: txsMsgSize(memR.config.MaxTxBytes),
+			// txs too large for a block are rejected before being decoded
+			RecvMessageCapacityFunc: func() int {
+				return txsMsgSize(memR.mempool.maxRecvTxBytes())
+			},
+			MessageType: &protomem.Message{},
+			Version:     MempoolChannelVersion,
 		},
 	}
 
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  MempoolChannel,
+	if memR.config.PushPullGossip {
+		keyMsg := protomem.Message{
+			Sum: &protomem.Message_HaveTx{
+				HaveTx: &protomem.HaveTx{TxKey: make([]byte, types.TxKeySize)},
+			},
+		}
+		chs = append(chs, &p2p.ChannelDescriptor{
+			ID:                  MempoolHashChannel,
 			Priority:            5,
-			RecvMessageCapacity: batchMsg.Size(),
+			SendQueueCapacity:   100,
+			RecvMessageCapacity: keyMsg.Size(),
 			MessageType:         &protomem.Message{},
-		},
+		})
 	}
+
+	return chs
+}
+
+// txsMsgSize returns the size of a Txs message of a transaction of the given
+// size.
+func txsMsgSize(txBytes int) int {
+	txsSize := 1 + proto.SizeVarint(uint64(txBytes)) + txBytes
+	return 1 + proto.SizeVarint(uint64(txsSize)) + txsSize
 }
 
 // AddPeer implements Reactor.
 // It starts a broadcast routine ensuring all txs are forwarded to the given peer.
 func (memR *Reactor) AddPeer(peer p2p.Peer) {
+	if memR.config.PushPullGossip && peerHasChannel(peer, MempoolHashChannel) {
+		queue := make(chan p2p.Envelope, PushPullQueueSize)
+		memR.queuesMtx.Lock()
+		memR.queues[peer.ID()] = queue
+		memR.queuesMtx.Unlock()
+		go memR.sendQueueRoutine(peer, queue)
+	}
+	filter := gossipFilter{
+		minFeePerByte: memR.config.GossipMinFeePerByte,
+		maxTxBytes:    int64(memR.config.GossipMaxTxBytes),
+	}
+	if filter != (gossipFilter{}) &&
+		p2p.NegotiateChannelVersion(peer, MempoolChannel, MempoolChannelVersion) >= gossipFilterVersion {
+		peer.Send(p2p.Envelope{
+			ChannelID: MempoolChannel,
+			Message: &protomem.GossipFilter{
+				MinFeePerByte: filter.minFeePerByte,
+				MaxTxBytes:    filter.maxTxBytes,
+			},
+		})
+	}
 	if memR.config.Broadcast {
 		go memR.broadcastTxRoutine(peer)
 	}
@@ -85,7 +177,13 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
-	// broadcast routine checks if peer is gone and returns
+	memR.queuesMtx.Lock()
+	delete(memR.queues, peer.ID())
+	memR.queuesMtx.Unlock()
+	memR.filtersMtx.Lock()
+	delete(memR.filters, peer.ID())
+	memR.filtersMtx.Unlock()
+	// broadcast and send queue routines check if peer is gone and return
 }
 
 // Receive implements Reactor.
@@ -107,6 +205,7 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 		var err error
 		for _, tx := range protoTxs {
 			ntx := types.Tx(tx)
+			memR.removeWanted(ntx.Key())
 			err = memR.mempool.CheckTx(ntx, nil, txInfo)
 			if errors.Is(err, ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", ntx.String())
@@ -114,6 +213,28 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 				memR.Logger.Info("Could not check tx", "tx", ntx.String(), "err", err)
 			}
 		}
+	case *protomem.HaveTx:
+		txKey, err := txKeyFromBytes(msg.TxKey)
+		if err != nil {
+			memR.Switch.StopPeerForError(e.Src, err)
+			return
+		}
+		memR.handleHaveTx(e.Src, txKey)
+	case *protomem.WantTx:
+		txKey, err := txKeyFromBytes(msg.TxKey)
+		if err != nil {
+			memR.Switch.StopPeerForError(e.Src, err)
+			return
+		}
+		memR.handleWantTx(e.Src, txKey)
+	case *protomem.GossipFilter:
+		if msg.MinFeePerByte < 0 || msg.MaxTxBytes < 0 {
+			memR.Switch.StopPeerForError(e.Src, fmt.Errorf("invalid gossip filter: %v", msg))
+			return
+		}
+		memR.filtersMtx.Lock()
+		memR.filters[e.Src.ID()] = gossipFilter{minFeePerByte: msg.MinFeePerByte, maxTxBytes: msg.MaxTxBytes}
+		memR.filtersMtx.Unlock()
 	default:
 		memR.Logger.Error("unknown message type", "src", e.Src, "chId", e.ChannelID, "msg", e.Message)
 		memR.Switch.StopPeerForError(e.Src, fmt.Errorf("mempool cannot handle message of type: %T", e.Message))
@@ -131,6 +252,7 @@ type PeerState interface {
 // Send new mempool txs to peer.
 func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	peerID := memR.ids.GetForPeer(peer)
+	pushPull := memR.config.PushPullGossip && peerHasChannel(peer, MempoolHashChannel)
 	var next *clist.CElement
 
 	for {
@@ -176,11 +298,20 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// NOTE: Transaction batching was disabled due to
 		// https://github.com/tendermint/tendermint/issues/5796
 
-		if _, ok := memTx.senders.Load(peerID); !ok {
-			success := peer.Send(p2p.Envelope{
+		if _, ok := memTx.senders.Load(peerID); !ok && memR.gossipFilter(peer).allows(memTx) {
+			envelope := p2p.Envelope{
 				ChannelID: MempoolChannel,
 				Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
-			})
+			}
+			if pushPull {
+				// Announce the tx; the peer requests it if it does not have it.
+				txKey := memTx.tx.Key()
+				envelope = p2p.Envelope{
+					ChannelID: MempoolHashChannel,
+					Message:   &protomem.HaveTx{TxKey: txKey[:]},
+				}
+			}
+			success := peer.Send(envelope)
 			if !success {
 				time.Sleep(PeerCatchupSleepIntervalMS * time.Millisecond)
 				continue
@@ -199,6 +330,207 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// gossipFilter returns the gossip filter sent by the peer, if any.
+func (memR *Reactor) gossipFilter(peer p2p.Peer) gossipFilter {
+	memR.filtersMtx.RLock()
+	defer memR.filtersMtx.RUnlock()
+	return memR.filters[peer.ID()]
+}
+
+// handleHaveTx requests the announced tx from the peer, unless we already have
+// it or have already requested it from another peer recently, in which case
+// the peer is recorded to request the tx from if the other peer doesn't send
+// it.
+func (memR *Reactor) handleHaveTx(src p2p.Peer, txKey types.TxKey) {
+	if memTx, ok := memR.mempool.getTx(txKey); ok {
+		// Record the peer as a sender so we don't announce the tx back to it.
+		memTx.senders.LoadOrStore(memR.ids.GetForPeer(src), true)
+		return
+	}
+	if memR.mempool.cache.HasKey(txKey) {
+		return
+	}
+	if !memR.markWanted(txKey, src) {
+		return
+	}
+
+	if !memR.requestTx(src, txKey) {
+		memR.removeWanted(txKey)
+	}
+}
+
+// requestTx sends WantTx for the tx to the peer, and returns false if the
+// peer's queue is full.
+func (memR *Reactor) requestTx(peer p2p.Peer, txKey types.TxKey) bool {
+	return memR.enqueue(peer, p2p.Envelope{
+		ChannelID: MempoolHashChannel,
+		Message:   &protomem.WantTx{TxKey: txKey[:]},
+	})
+}
+
+// handleWantTx sends the requested tx to the peer, if it is in the mempool.
+func (memR *Reactor) handleWantTx(src p2p.Peer, txKey types.TxKey) {
+	memTx, ok := memR.mempool.getTx(txKey)
+	if !ok {
+		memR.Logger.Debug("peer wants a tx that is not in the mempool", "src", src, "tx", fmt.Sprintf("%X", txKey))
+		return
+	}
+	if !memR.enqueue(src, p2p.Envelope{
+		ChannelID: MempoolChannel,
+		Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
+	}) {
+		memR.Logger.Debug("dropping requested tx, peer queue is full", "src", src, "tx", fmt.Sprintf("%X", txKey))
+	}
+}
+
+// enqueue adds the envelope to the peer's send queue. It returns false if the
+// peer does not use push/pull gossip or its queue is full.
+func (memR *Reactor) enqueue(peer p2p.Peer, e p2p.Envelope) bool {
+	memR.queuesMtx.Lock()
+	queue, ok := memR.queues[peer.ID()]
+	memR.queuesMtx.Unlock()
+	if !ok {
+		return false
+	}
+
+	select {
+	case queue <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendQueueRoutine sends the messages queued for the peer.
+func (memR *Reactor) sendQueueRoutine(peer p2p.Peer, queue <-chan p2p.Envelope) {
+	for {
+		select {
+		case e := <-queue:
+			peer.Send(e)
+		case <-peer.Quit():
+			return
+		case <-memR.Quit():
+			return
+		}
+	}
+}
+
+// wantedTx is a tx requested with WantTx and not received yet.
+type wantedTx struct {
+	requestedAt time.Time
+	// peers which announced the tx after it was requested, to request it
+	// from, in order, if it isn't received within WantTxTimeout
+	announcers []p2p.Peer
+}
+
+// markWanted records that the tx is being requested from src, and returns
+// false if it was already requested less than WantTxTimeout ago, recording src
+// as an announcer of the tx instead.
+func (memR *Reactor) markWanted(txKey types.TxKey, src p2p.Peer) bool {
+	memR.wantedMtx.Lock()
+	defer memR.wantedMtx.Unlock()
+
+	now := time.Now()
+	if w, ok := memR.wanted[txKey]; ok && now.Sub(w.requestedAt) < WantTxTimeout {
+		if len(w.announcers) < maxTxAnnouncers {
+			w.announcers = append(w.announcers, src)
+		}
+		return false
+	}
+
+	// Forget requests that were never answered, so the map stays bounded.
+	if len(memR.wanted) >= memR.config.Size {
+		for key, w := range memR.wanted {
+			if now.Sub(w.requestedAt) >= WantTxTimeout {
+				delete(memR.wanted, key)
+			}
+		}
+	}
+
+	memR.wanted[txKey] = &wantedTx{requestedAt: now}
+	return true
+}
+
+func (memR *Reactor) removeWanted(txKey types.TxKey) {
+	memR.wantedMtx.Lock()
+	defer memR.wantedMtx.Unlock()
+
+	delete(memR.wanted, txKey)
+}
+
+// wantTxRoutine requests the txs not received within WantTxTimeout from the
+// next peer which announced them, so that a slow or malicious peer announcing
+// a tx first can't keep us from getting it.
+func (memR *Reactor) wantTxRoutine() {
+	ticker := time.NewTicker(WantTxTimeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			memR.retryWantedTxs(time.Now())
+		case <-memR.Quit():
+			return
+		}
+	}
+}
+
+// retryWantedTxs requests the txs requested before now-WantTxTimeout from the
+// next peer which announced them and is still connected, and forgets the ones
+// with none left.
+func (memR *Reactor) retryWantedTxs(now time.Time) {
+	memR.wantedMtx.Lock()
+	defer memR.wantedMtx.Unlock()
+
+	for txKey, w := range memR.wanted {
+		if now.Sub(w.requestedAt) < WantTxTimeout {
+			continue
+		}
+		requested := false
+		for len(w.announcers) > 0 && !requested {
+			peer := w.announcers[0]
+			w.announcers = w.announcers[1:]
+			requested = peer.IsRunning() && memR.requestTx(peer, txKey)
+		}
+		if !requested {
+			delete(memR.wanted, txKey)
+			continue
+		}
+		w.requestedAt = now
+	}
+}
+
+func txKeyFromBytes(bz []byte) (types.TxKey, error) {
+	var txKey types.TxKey
+	if len(bz) != types.TxKeySize {
+		return txKey, fmt.Errorf("invalid tx key size: expected %d, got %d", types.TxKeySize, len(bz))
+	}
+	copy(txKey[:], bz)
+	return txKey, nil
+}
+
+// gossipFilter is the filter a peer applies to the txs it receives by gossip,
+// see protomem.GossipFilter. The zero value allows all the txs.
+type gossipFilter struct {
+	minFeePerByte int64
+	maxTxBytes    int64
+}
+
+// allows returns true if the tx passes the filter.
+func (f gossipFilter) allows(memTx *mempoolTx) bool {
+	txBytes := int64(len(memTx.tx))
+	if f.maxTxBytes > 0 && txBytes > f.maxTxBytes {
+		return false
+	}
+	return f.minFeePerByte == 0 || (txBytes > 0 && memTx.fee/txBytes >= f.minFeePerByte)
+}
+
+// peerHasChannel returns true if the peer advertised the given channel in its
+// NodeInfo.
+func peerHasChannel(peer p2p.Peer, chID byte) bool {
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(chID)
+}
+
 // TxsMessage is a Message containing transactions.
 type TxsMessage struct {
 	Txs []types.Tx
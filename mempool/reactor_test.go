@@ -64,6 +64,81 @@ func TestReactorBroadcastTxsMessage(t *testing.T) {
 	waitForTxsOnReactors(t, txs, reactors)
 }
 
+// Same as TestReactorBroadcastTxsMessage, but txs are announced by key and
+// only sent when requested.
+func TestReactorBroadcastTxsMessagePushPull(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.PushPullGossip = true
+	const N = 2
+	reactors := makeAndConnectReactors(config, N)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+			require.True(t, peerHasChannel(peer, MempoolHashChannel))
+		}
+	}
+
+	txs := checkTxs(t, reactors[0].mempool, numTxs, UnknownPeerID)
+	waitForTxsOnReactors(t, txs, reactors)
+}
+
+// The txs filtered out by the gossip filter of a peer aren't gossiped to it.
+func TestReactorGossipFilter(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.GossipMaxTxBytes = 10
+	const N = 2
+	reactors := makeAndConnectReactors(config, N)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+			assert.Equal(t, MempoolChannelVersion,
+				p2p.NegotiateChannelVersion(peer, MempoolChannel, MempoolChannelVersion))
+			require.Eventually(t, func() bool {
+				return r.gossipFilter(peer) == gossipFilter{maxTxBytes: 10}
+			}, time.Second, 10*time.Millisecond)
+		}
+	}
+
+	small, large := types.Tx("small"), types.Tx("a large transaction")
+	require.NoError(t, reactors[0].mempool.CheckTx(large, nil, TxInfo{}))
+	require.NoError(t, reactors[0].mempool.CheckTx(small, nil, TxInfo{}))
+	waitForTxsOnReactor(t, types.Txs{small}, reactors[1], 1)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, reactors[1].mempool.Size())
+}
+
+func TestGossipFilterAllows(t *testing.T) {
+	memTx := &mempoolTx{tx: make(types.Tx, 100), fee: 250}
+	testCases := []struct {
+		filter gossipFilter
+		allows bool
+	}{
+		{gossipFilter{}, true},
+		{gossipFilter{maxTxBytes: 100}, true},
+		{gossipFilter{maxTxBytes: 99}, false},
+		{gossipFilter{minFeePerByte: 2}, true},
+		{gossipFilter{minFeePerByte: 3}, false},
+		{gossipFilter{minFeePerByte: 2, maxTxBytes: 99}, false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.allows, tc.filter.allows(memTx), tc.filter)
+	}
+}
+
 // regression test for https://github.com/tendermint/tendermint/issues/5408
 func TestReactorConcurrency(t *testing.T) {
 	config := cfg.TestConfig()
@@ -183,6 +258,71 @@ func TestReactor_MaxTxBytes(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestReactorRecvMessageCapacity(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	reactor := NewReactor(cfg.TestConfig().Mempool, mp)
+
+	maxTxsMsgSize := func(txBytes int) int {
+		msg := memproto.Message{
+			Sum: &memproto.Message_Txs{Txs: &memproto.Txs{Txs: [][]byte{make([]byte, txBytes)}}},
+		}
+		return msg.Size()
+	}
+	chDesc := reactor.GetChannels()[0]
+	assert.Equal(t, maxTxsMsgSize(mp.config.MaxTxBytes), chDesc.RecvMessageCapacity)
+	assert.Equal(t, chDesc.RecvMessageCapacity, chDesc.MaxRecvMessageSize())
+
+	// the txs are bounded by the consensus params
+	mp.SetMaxBlockTxBytes(1000)
+	assert.Equal(t, maxTxsMsgSize(1000), chDesc.MaxRecvMessageSize())
+	mp.SetMaxBlockTxBytes(int64(mp.config.MaxTxBytes) + 1)
+	assert.Equal(t, chDesc.RecvMessageCapacity, chDesc.MaxRecvMessageSize())
+}
+
+// A tx not received within WantTxTimeout is requested from the next peer which
+// announced it.
+func TestReactorWantTxFromNextAnnouncer(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	reactor := NewReactor(cfg.TestConfig().Mempool, mp)
+
+	peers := make([]*mock.Peer, 3)
+	queues := make([]chan p2p.Envelope, 3)
+	for i := range peers {
+		peers[i] = mock.NewPeer(nil)
+		queues[i] = make(chan p2p.Envelope, 1)
+		reactor.queues[peers[i].ID()] = queues[i]
+	}
+	require.NoError(t, peers[1].Stop())
+
+	txKey := types.Tx("tx").Key()
+	for _, peer := range peers {
+		reactor.handleHaveTx(peer, txKey)
+	}
+	require.Len(t, queues[0], 1)
+	require.Empty(t, queues[2])
+
+	now := time.Now()
+	reactor.retryWantedTxs(now)
+	assert.Empty(t, queues[2])
+
+	// the stopped peer is skipped
+	reactor.retryWantedTxs(now.Add(WantTxTimeout))
+	assert.Empty(t, queues[1])
+	require.Len(t, queues[2], 1)
+	e := <-queues[2]
+	assert.Equal(t, &memproto.WantTx{TxKey: txKey[:]}, e.Message)
+
+	// no announcer is left
+	reactor.retryWantedTxs(now.Add(2 * WantTxTimeout))
+	assert.NotContains(t, reactor.wanted, txKey)
+}
+
 func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
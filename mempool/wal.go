@@ -0,0 +1,220 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/libs/tempfile"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// walFile is the name of the file of the WAL, in config.WalDir.
+	walFile = "wal"
+
+	// The records of the WAL add a transaction to the pending set, or remove
+	// it by key.
+	walRecordAdd    = byte(1)
+	walRecordRemove = byte(2)
+
+	// walRecordHeaderSize is the size of the header of a record: the crc and
+	// the length of the data, which is the type of the record and its
+	// payload.
+	walRecordHeaderSize = 8
+
+	// walMinCompactSize is the size under which the WAL isn't compacted.
+	walMinCompactSize = 1024 * 1024 // 1MB
+)
+
+var walCrc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// txWAL is the write-ahead log of the transactions pending in the mempool,
+// so that they are restored after a crash or a restart. It records the
+// transactions added to the mempool and the keys of the ones removed, and is
+// compacted to the pending transactions once it is more than twice their
+// size.
+type txWAL struct {
+	mtx  cmtsync.Mutex
+	path string
+	file *os.File
+	size int64 // of the file
+}
+
+// openTxWAL opens the WAL in the given directory, and returns the pending
+// transactions it records, in the order they were added. A truncated or
+// corrupted record, e.g. written during a crash, and the ones after it are
+// ignored. The WAL is then compacted to the pending transactions, which it
+// keeps recording until the caller compacts it again, e.g. once they have
+// been checked again.
+func openTxWAL(dir string) (*txWAL, types.Txs, error) {
+	if err := cmtos.EnsureDir(dir, 0o700); err != nil {
+		return nil, nil, err
+	}
+	w := &txWAL{path: filepath.Join(dir, walFile)}
+
+	pending, err := w.read()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := w.compact(pending); err != nil {
+		return nil, nil, err
+	}
+	return w, pending, nil
+}
+
+// read returns the pending transactions recorded in the WAL file.
+func (w *txWAL) read() (types.Txs, error) {
+	f, err := os.Open(w.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		r       = bufio.NewReader(f)
+		keys    []types.TxKey
+		pending = make(map[types.TxKey]types.Tx)
+	)
+	for {
+		data, err := readWALRecord(r)
+		if err != nil {
+			break // io.EOF, or a truncated or corrupted record
+		}
+		switch data[0] {
+		case walRecordAdd:
+			tx := types.Tx(data[1:])
+			if _, ok := pending[tx.Key()]; !ok {
+				keys = append(keys, tx.Key())
+			}
+			pending[tx.Key()] = tx
+		case walRecordRemove:
+			var key types.TxKey
+			copy(key[:], data[1:])
+			delete(pending, key)
+		}
+	}
+
+	txs := make(types.Txs, 0, len(pending))
+	for _, key := range keys {
+		if tx, ok := pending[key]; ok {
+			txs = append(txs, tx)
+			delete(pending, key) // in case it was added twice
+		}
+	}
+	return txs, nil
+}
+
+func readWALRecord(r io.Reader) ([]byte, error) {
+	var header [walRecordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	crc := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length == 0 || length > types.MaxBlockSizeBytes+1 {
+		return nil, fmt.Errorf("invalid record length %d", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	if crc32.Checksum(data, walCrc32c) != crc {
+		return nil, errors.New("checksums do not match")
+	}
+	return data, nil
+}
+
+func encodeWALRecord(recordType byte, payload []byte) []byte {
+	record := make([]byte, walRecordHeaderSize+1+len(payload))
+	record[walRecordHeaderSize] = recordType
+	copy(record[walRecordHeaderSize+1:], payload)
+	data := record[walRecordHeaderSize:]
+	binary.BigEndian.PutUint32(record[0:4], crc32.Checksum(data, walCrc32c))
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(data)))
+	return record
+}
+
+// Add records a transaction added to the mempool.
+func (w *txWAL) Add(tx types.Tx) error {
+	return w.write(encodeWALRecord(walRecordAdd, tx))
+}
+
+// Remove records the removal of a transaction from the mempool.
+func (w *txWAL) Remove(key types.TxKey) error {
+	return w.write(encodeWALRecord(walRecordRemove, key[:]))
+}
+
+func (w *txWAL) write(record []byte) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	n, err := w.file.Write(record)
+	w.size += int64(n)
+	return err
+}
+
+// Sync flushes the WAL to disk.
+func (w *txWAL) Sync() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.file.Sync()
+}
+
+// NeedsCompaction tells whether the WAL is more than twice the size of the
+// records of the pending transactions, of the given number and total size.
+func (w *txWAL) NeedsCompaction(numTxs int, txsBytes int64) bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	live := txsBytes + int64(numTxs)*(walRecordHeaderSize+1)
+	return w.size > walMinCompactSize && w.size > 2*live
+}
+
+// Compact replaces the WAL with the records of the given pending
+// transactions.
+func (w *txWAL) Compact(pending types.Txs) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.compact(pending)
+}
+
+func (w *txWAL) compact(pending types.Txs) error {
+	var data []byte
+	for _, tx := range pending {
+		data = append(data, encodeWALRecord(walRecordAdd, tx)...)
+	}
+	if err := tempfile.WriteFileAtomic(w.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write the mempool WAL: %w", err)
+	}
+
+	if w.file != nil {
+		w.file.Close()
+	}
+	file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.size = int64(len(data))
+	return nil
+}
+
+// Close closes the WAL.
+func (w *txWAL) Close() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.file.Close()
+}
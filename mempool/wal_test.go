@@ -0,0 +1,100 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxWALRestoresPendingTxs(t *testing.T) {
+	dir := t.TempDir()
+	wal, pending, err := openTxWAL(dir)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	a, b, c := types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")
+	require.NoError(t, wal.Add(a))
+	require.NoError(t, wal.Add(b))
+	require.NoError(t, wal.Add(c))
+	require.NoError(t, wal.Remove(b.Key()))
+	require.NoError(t, wal.Close())
+
+	// a record truncated by a crash is ignored
+	f, err := os.OpenFile(filepath.Join(dir, walFile), os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write(encodeWALRecord(walRecordAdd, types.Tx("d=4"))[:10])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	wal, pending, err = openTxWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{a, c}, pending)
+	require.NoError(t, wal.Close())
+
+	// the WAL keeps the pending txs once read, until it is compacted again
+	wal, pending, err = openTxWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{a, c}, pending)
+	require.NoError(t, wal.Compact(types.Txs{c}))
+	require.NoError(t, wal.Close())
+
+	wal, pending, err = openTxWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{c}, pending)
+	require.NoError(t, wal.Close())
+}
+
+func TestTxWALCompaction(t *testing.T) {
+	wal, _, err := openTxWAL(t.TempDir())
+	require.NoError(t, err)
+	defer wal.Close()
+
+	tx := make(types.Tx, 1024)
+	for i := 0; i < 2*walMinCompactSize/len(tx); i++ {
+		tx[0], tx[1] = byte(i), byte(i>>8)
+		require.NoError(t, wal.Add(tx))
+		require.NoError(t, wal.Remove(tx.Key()))
+	}
+	assert.True(t, wal.NeedsCompaction(0, 0))
+	assert.False(t, wal.NeedsCompaction(2*walMinCompactSize/len(tx), 2*walMinCompactSize))
+
+	require.NoError(t, wal.Compact(types.Txs{tx}))
+	assert.False(t, wal.NeedsCompaction(1, int64(len(tx))))
+}
+
+func TestMempoolWALRestoresTxsOnRestart(t *testing.T) {
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.WalPath = "data/mempool.wal"
+	cc := proxy.NewLocalClientCreator(kvstore.NewApplication())
+
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+	require.NoError(t, mp.InitWAL())
+	txs := checkTxs(t, mp, 10, UnknownPeerID)
+
+	// the first txs are committed
+	mp.Lock()
+	require.NoError(t, mp.Update(1, txs[:4], abciResponses(4, abci.CodeTypeOK), nil, nil))
+	mp.Unlock()
+	mp.CloseWAL()
+
+	restarted, _ := newMempoolWithAppAndConfig(cc, cfg)
+	require.NoError(t, restarted.InitWAL())
+	assert.Equal(t, txs[4:], restarted.ReapMaxTxs(-1))
+	restarted.CloseWAL()
+
+	// the restored txs are still recorded for the next restart
+	restarted, _ = newMempoolWithAppAndConfig(cc, cfg)
+	require.NoError(t, restarted.InitWAL())
+	defer restarted.CloseWAL()
+	assert.Equal(t, txs[4:], restarted.ReapMaxTxs(-1))
+}
@@ -0,0 +1,319 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+const (
+	// txIndexDBID is the ID of the database of the tx index and the block
+	// index.
+	txIndexDBID = "tx_index"
+
+	// backupBatchSize is the number of entries written in a batch to the
+	// databases of a backup.
+	backupBatchSize = 1000
+
+	backupTimeFormat = "20060102T150405Z"
+)
+
+// dbRecorder records the databases opened by a DBProvider, so that the
+// backups copy them.
+type dbRecorder struct {
+	provider cfg.DBProvider
+
+	mtx cmtsync.Mutex
+	dbs map[string]dbm.DB // by ID
+}
+
+func newDBRecorder(provider cfg.DBProvider) *dbRecorder {
+	return &dbRecorder{provider: provider, dbs: make(map[string]dbm.DB)}
+}
+
+// open implements cfg.DBProvider.
+func (r *dbRecorder) open(ctx *cfg.DBContext) (dbm.DB, error) {
+	db, err := r.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mtx.Lock()
+	r.dbs[ctx.ID] = db
+	r.mtx.Unlock()
+	return db, nil
+}
+
+func (r *dbRecorder) all() map[string]dbm.DB {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	dbs := make(map[string]dbm.DB, len(r.dbs))
+	for id, db := range r.dbs {
+		dbs[id] = db
+	}
+	return dbs
+}
+
+// Backup starts a backup of the data directory of the node, into a new
+// directory of rpc.backup_dir named after the height and the current time. It
+// waits for the block being committed, if any, and pauses the commits, the
+// pruning and the indexing while the databases are snapshotted and the other
+// files copied, so that they are consistent, at a height boundary. The
+// snapshots are then streamed into new databases of the same backend while the
+// node goes on. The validator key and the backups are not copied. The backup
+// goes on in the background, see BackupStatus.
+func (n *Node) Backup() (*ctypes.ResultBackup, error) {
+	if n.config.RPC.BackupDir == "" {
+		return nil, errors.New("backups are disabled")
+	}
+	if n.config.DBBackend == string(dbm.MemDBBackend) {
+		return nil, errors.New("backups are not available with the memdb backend")
+	}
+
+	n.backupMtx.Lock()
+	defer n.backupMtx.Unlock()
+	if n.backup != nil && !n.backup.Done {
+		return nil, fmt.Errorf("the backup into %s is in progress", n.backup.Dir)
+	}
+
+	resumePruning := n.pruner.Pause()
+	resumeCommits := n.blockExec.PauseCommits()
+	height := n.blockStore.Height()
+	resumeIndexing := func() {}
+	if _, ok := n.dbs.all()[txIndexDBID]; ok {
+		resumeIndexing = n.indexerService.Pause(height)
+	}
+	resume := func() {
+		resumeIndexing()
+		resumeCommits()
+		resumePruning()
+	}
+
+	now := time.Now().UTC()
+	dir := filepath.Join(n.config.RPC.BackupDirPath(),
+		fmt.Sprintf("%d-%s", height, now.Format(backupTimeFormat)))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		resume()
+		return nil, fmt.Errorf("failed to create the backup directory: %w", err)
+	}
+
+	n.backup = &ctypes.ResultBackup{Height: height, Dir: dir, StartTime: now}
+	backup := *n.backup
+	n.Logger.Info("Backing up the data directory", "height", height, "dir", dir)
+	go func() {
+		snapshots, err := n.checkpointDataDir(dir)
+		resume()
+		for id, snapshot := range snapshots {
+			if err == nil {
+				if err = copyDB(snapshot.entries, id, dbm.BackendType(n.config.DBBackend), dir); err != nil {
+					err = fmt.Errorf("failed to copy the %s database: %w", id, err)
+				}
+			}
+			snapshot.release()
+		}
+
+		n.backupMtx.Lock()
+		defer n.backupMtx.Unlock()
+		n.backup.Done = true
+		if err != nil {
+			n.backup.Error = err.Error()
+			n.Logger.Error("Failed to back up the data directory", "dir", dir, "err", err)
+			return
+		}
+		n.Logger.Info("Backed up the data directory", "height", height, "dir", dir,
+			"duration", time.Since(now))
+	}()
+	return &backup, nil
+}
+
+// BackupStatus returns the last backup started by Backup, or nil if none.
+func (n *Node) BackupStatus() *ctypes.ResultBackup {
+	n.backupMtx.Lock()
+	defer n.backupMtx.Unlock()
+	if n.backup == nil {
+		return nil
+	}
+	backup := *n.backup
+	return &backup
+}
+
+// checkpointDataDir snapshots the databases of the data directory, and copies
+// its files into dir. The databases which can't be snapshotted are copied. The
+// snapshots returned, by database ID, must be released.
+func (n *Node) checkpointDataDir(dir string) (map[string]dbSnapshot, error) {
+	backend := dbm.BackendType(n.config.DBBackend)
+	dataDir := n.config.DBDir()
+	snapshots := make(map[string]dbSnapshot)
+
+	// the files which are not copied as files
+	skipped := map[string]bool{
+		n.config.PrivValidatorKeyFile():     true,
+		n.config.RPC.BackupDirPath():        true,
+		n.config.RPC.DebugSnapshotDirPath(): true,
+		n.config.DiagnosticsDirPath():       true,
+	}
+	for id, db := range n.dbs.all() {
+		skipped[filepath.Join(dataDir, id+".db")] = true
+		snapshot, ok, err := snapshotDB(db)
+		if err != nil {
+			releaseSnapshots(snapshots)
+			return nil, fmt.Errorf("failed to snapshot the %s database: %w", id, err)
+		}
+		if ok {
+			snapshots[id] = snapshot
+			continue
+		}
+		if err := copyDB(dbEntriesOf(db), id, backend, dir); err != nil {
+			releaseSnapshots(snapshots)
+			return nil, fmt.Errorf("failed to copy the %s database: %w", id, err)
+		}
+	}
+
+	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skipped[path] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dataDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0o700)
+		case d.Type().IsRegular():
+			return copyFile(path, target)
+		default: // sockets, symbolic links
+			return nil
+		}
+	})
+	if err != nil {
+		releaseSnapshots(snapshots)
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// dbEntries calls fn with each entry of a database, in order, until it
+// returns an error.
+type dbEntries func(fn func(key, value []byte) error) error
+
+// dbSnapshot is a consistent view of a database at the time it was taken.
+type dbSnapshot struct {
+	entries dbEntries
+	release func()
+}
+
+// snapshotDB returns a snapshot of db, if its backend supports it.
+func snapshotDB(db dbm.DB) (dbSnapshot, bool, error) {
+	levelDB, ok := db.(*dbm.GoLevelDB)
+	if !ok {
+		return dbSnapshot{}, false, nil
+	}
+	snapshot, err := levelDB.DB().GetSnapshot()
+	if err != nil {
+		return dbSnapshot{}, false, err
+	}
+	entries := func(fn func(key, value []byte) error) error {
+		itr := snapshot.NewIterator(nil, nil)
+		defer itr.Release()
+		for itr.Next() {
+			if err := fn(itr.Key(), itr.Value()); err != nil {
+				return err
+			}
+		}
+		return itr.Error()
+	}
+	return dbSnapshot{entries: entries, release: snapshot.Release}, true, nil
+}
+
+func releaseSnapshots(snapshots map[string]dbSnapshot) {
+	for _, snapshot := range snapshots {
+		snapshot.release()
+	}
+}
+
+// dbEntriesOf returns the entries of db, read with an iterator.
+func dbEntriesOf(db dbm.DB) dbEntries {
+	return func(fn func(key, value []byte) error) error {
+		itr, err := db.Iterator(nil, nil)
+		if err != nil {
+			return err
+		}
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			if err := fn(itr.Key(), itr.Value()); err != nil {
+				return err
+			}
+		}
+		return itr.Error()
+	}
+}
+
+// copyDB streams the entries into a new database with the given name and
+// backend, in dir.
+func copyDB(entries dbEntries, name string, backend dbm.BackendType, dir string) error {
+	dst, err := dbm.NewDB(name, backend, dir)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	batch := dst.NewBatch()
+	defer func() { batch.Close() }()
+	n := 0
+	err = entries(func(key, value []byte) error {
+		if err := batch.Set(key, value); err != nil {
+			return err
+		}
+		n++
+		if n%backupBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -35,5 +35,25 @@ To replace the built-in p2p.Reactor, use the CustomReactors option:
 	)
 
 The list of existing reactors can be found in CustomReactors documentation.
+
+# Running several nodes in one process
+
+To run a testnet in the same process, e.g. in integration tests, use a Network,
+whose nodes are connected over an in-memory network instead of TCP:
+
+	network, err := NewNetwork([]NetworkNode{
+		{Config: config0, ClientCreator: proxy.NewLocalClientCreator(app0)},
+		{Config: config1, ClientCreator: proxy.NewLocalClientCreator(app1)},
+	}, logger)
+	if err != nil {
+		return err
+	}
+	if err := network.Start(); err != nil {
+		return err
+	}
+	defer network.Stop()
+
+Each node has its own home directory, with its keys and the genesis file of the
+network.
 */
 package node
@@ -0,0 +1,143 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/proxy"
+)
+
+// networkP2PPort is the P2P port of the nodes of a Network.
+const networkP2PPort = 26656
+
+// MemoryNetwork makes the node listen and dial its peers on the in-memory
+// network instead of TCP, to run several nodes in the same process. See
+// Network, which sets it up for a testnet.
+func MemoryNetwork(network *p2p.MemoryNetwork) Option {
+	return func(n *Node) {
+		mt, ok := n.transport.(*p2p.MultiplexTransport)
+		if !ok {
+			n.Logger.Error("The in-memory network is only supported by the tcp transport", "transport", n.config.P2P.Transport)
+			return
+		}
+		p2p.MultiplexTransportMemoryNetwork(network)(mt)
+	}
+}
+
+// NetworkNode is a node to run in a Network.
+type NetworkNode struct {
+	// Config is the configuration of the node, with its own home directory,
+	// holding its keys and genesis file, and its own databases. The P2P
+	// settings are set by NewNetwork, but the other listen addresses (RPC,
+	// Prometheus, pprof) must be unique in the process, or empty.
+	Config *cfg.Config
+	// ClientCreator connects the node to its app, e.g. a
+	// proxy.NewLocalClientCreator to run the app in the same process.
+	ClientCreator proxy.ClientCreator
+	// Options are passed to NewNode.
+	Options []Option
+}
+
+// Network runs several isolated nodes in the same process, connected to each
+// other over an in-memory network instead of TCP, e.g. for the integration
+// tests which would otherwise run a testnet with docker-compose.
+type Network struct {
+	network *p2p.MemoryNetwork
+	nodes   []*Node
+}
+
+// NewNetwork creates the nodes of a network. Each node listens on its own
+// address of the in-memory network, and has all the other nodes as persistent
+// peers, so the P2P listen address, external address and persistent peers of
+// their configs are overwritten. The address book is made non-strict, since
+// the addresses are private.
+func NewNetwork(nodes []NetworkNode, logger log.Logger) (*Network, error) {
+	nodeKeys := make([]*p2p.NodeKey, len(nodes))
+	peers := make([]string, len(nodes))
+	for i, node := range nodes {
+		nodeKey, err := p2p.LoadOrGenNodeKey(node.Config.NodeKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load or gen node key %s: %w", node.Config.NodeKeyFile(), err)
+		}
+		nodeKeys[i] = nodeKey
+		peers[i] = p2p.IDAddressString(nodeKey.ID(), networkP2PAddress(i))
+	}
+
+	network := &Network{
+		network: p2p.NewMemoryNetwork(),
+		nodes:   make([]*Node, len(nodes)),
+	}
+	for i, node := range nodes {
+		config := node.Config
+		config.P2P.ListenAddress = "tcp://" + networkP2PAddress(i)
+		config.P2P.ExternalAddress = ""
+		config.P2P.PersistentPeers = strings.Join(append(append([]string{}, peers[:i]...), peers[i+1:]...), ",")
+		config.P2P.AddrBookStrict = false
+
+		options := append([]Option{MemoryNetwork(network.network)}, node.Options...)
+		n, err := NewNode(config,
+			privval.LoadOrGenFilePVWithPassphrase(
+				config.PrivValidatorKeyFile(),
+				config.PrivValidatorStateFile(),
+				privval.KeyPassphrase(config.PrivValidatorKeyPassphraseFile()),
+			),
+			nodeKeys[i],
+			node.ClientCreator,
+			DefaultGenesisDocProviderFunc(config),
+			cfg.DefaultDBProvider,
+			DefaultMetricsProvider(config.Instrumentation),
+			logger.With("node", i),
+			options...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create node %d: %w", i, err)
+		}
+		network.nodes[i] = n
+	}
+	return network, nil
+}
+
+// networkP2PAddress returns the P2P address of the i-th node of a network.
+func networkP2PAddress(i int) string {
+	ip := net.IPv4(10, byte((i+1)>>16), byte((i+1)>>8), byte(i+1))
+	return net.JoinHostPort(ip.String(), fmt.Sprint(networkP2PPort))
+}
+
+// Nodes returns the nodes of the network, in the order they were given to
+// NewNetwork.
+func (nw *Network) Nodes() []*Node {
+	return nw.nodes
+}
+
+// Start starts all the nodes. If one fails to start, the ones already started
+// are stopped.
+func (nw *Network) Start() error {
+	for i, n := range nw.nodes {
+		if err := n.Start(); err != nil {
+			for _, started := range nw.nodes[:i] {
+				_ = started.Stop()
+			}
+			return fmt.Errorf("failed to start node %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Stop stops all the running nodes, and returns the first error.
+func (nw *Network) Stop() error {
+	var firstErr error
+	for i, n := range nw.nodes {
+		if !n.IsRunning() {
+			continue
+		}
+		if err := n.Stop(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to stop node %d: %w", i, err)
+		}
+	}
+	return firstErr
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"path/filepath"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	bc "github.com/cometbft/cometbft/blocksync"
 	cfg "github.com/cometbft/cometbft/config"
@@ -19,14 +21,18 @@ import (
 	"github.com/cometbft/cometbft/libs/log"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
+	"github.com/cometbft/cometbft/p2p/upnp"
 	"github.com/cometbft/cometbft/proxy"
 	rpccore "github.com/cometbft/cometbft/rpc/core"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	grpccore "github.com/cometbft/cometbft/rpc/grpc"
 	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/eventlog"
 	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/state/txindex/null"
@@ -50,17 +56,20 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
-	sw          *p2p.Switch  // p2p connections
-	addrBook    pex.AddrBook // known peers
+	transport   p2pTransport
+	portMapping *upnp.PortMapping // P2P port mapped on the NAT gateway
+	sw          *p2p.Switch       // p2p connections
+	addrBook    pex.AddrBook      // known peers
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
 
 	// services
 	eventBus          *types.EventBus // pub/sub for services
+	eventLog          *eventlog.Log   // nil unless the event log is enabled
 	stateStore        sm.Store
 	blockStore        *store.BlockStore // store the blockchain to disk
+	blockExec         *sm.BlockExecutor // executes the blocks, and halts the node
 	bcReactor         p2p.Reactor       // for block-syncing
 	mempool           mempl.Mempool
 	stateSync         bool                    // whether the node should state sync on startup
@@ -72,11 +81,28 @@ type Node struct {
 	evidencePool      *evidence.Pool          // tracking evidence
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
+	rpcRateLimiter    *rpcserver.RateLimiter
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	pruner            *sm.Pruner
+	partsMigrator     *store.BlockPartsMigrator    // nil unless migrate_block_parts is set
+	coldMover         *store.ColdStorageMover      // nil unless cold_storage_after is set
+	dbs               *dbRecorder                  // the databases opened by the node
+	snapshotScheduler *statesync.SnapshotScheduler // nil unless snapshot_interval is set
 	prometheusSrv     *http.Server
 	pprofSrv          *http.Server
+	tracerProvider    *sdktrace.TracerProvider
+
+	// the configuration applied by the last reload
+	reloadMtx  cmtsync.Mutex
+	liveConfig *cfg.Config
+	readConfig func() (*cfg.Config, error) // nil to read the configuration file
+	logLevel   string                      // log_level, with the changes made with SetLogLevel
+
+	// the last backup, see Backup
+	backupMtx cmtsync.Mutex
+	backup    *ctypes.ResultBackup
 }
 
 // Option sets a parameter for the node.
@@ -113,6 +139,8 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 						ni.Channels = append(ni.Channels, chDesc.ID)
 						n.transport.AddChannel(chDesc.ID)
 					}
+					ni.SetChannelVersion(chDesc.ID, chDesc.Version)
+					n.transport.SetChannelVersion(chDesc.ID, chDesc.Version)
 				}
 				n.nodeInfo = ni
 			} else {
@@ -144,6 +172,20 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option,
 ) (*Node, error) {
+	// Set the P2P settings of a validator behind sentry nodes, or of a
+	// sentry node.
+	if config.P2P.SentryNodes != "" || config.P2P.ValidatorNodes != "" {
+		config.P2P.ApplySentryMode()
+		logger.Info("Sentry mode",
+			"sentry_nodes", config.P2P.SentryNodes,
+			"validator_nodes", config.P2P.ValidatorNodes,
+			"pex", config.P2P.PexReactor)
+	}
+
+	// record the databases, for the backups to copy them
+	dbs := newDBRecorder(dbProvider)
+	dbProvider = dbs.open
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
@@ -160,8 +202,17 @@ func NewNode(config *cfg.Config,
 
 	csMetrics, p2pMetrics, memplMetrics, smMetrics, abciMetrics, bsMetrics, ssMetrics := metricsProvider(genDoc.ChainID)
 
+	tracerProvider, err := createTracerProvider(config, genDoc.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	var abciTracer *proxy.Tracer
+	if tracerProvider != nil {
+		abciTracer = proxy.NewTracer(tracerProvider)
+	}
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger, abciMetrics)
+	proxyApp, err := createAndStartProxyAppConns(config, clientCreator, logger, abciMetrics, abciTracer)
 	if err != nil {
 		return nil, err
 	}
@@ -170,13 +221,18 @@ func NewNode(config *cfg.Config,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(config, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+		genDoc.ChainID, dbProvider, blockStore, stateStore, eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	eventLog, err := createAndStartEventLog(config, dbProvider, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +263,8 @@ func NewNode(config *cfg.Config,
 	// and replays any blocks as necessary to sync CometBFT with the app.
 	consensusLogger := logger.With("module", "consensus")
 	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp,
+			config.InitChainChunkSize, consensusLogger); err != nil {
 			return nil, err
 		}
 
@@ -236,6 +293,13 @@ func NewNode(config *cfg.Config,
 	}
 
 	// make block executor for consensus and blocksync reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{sm.BlockExecutorWithMetrics(smMetrics)}
+	if config.Consensus.PrepareProposalDiagnostics {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithPrepareProposalDiagnostics())
+	}
+	if config.DiagnosticsDir != "" {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithDiagnosticsDir(config.DiagnosticsDirPath()))
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
 		logger.With("module", "state"),
@@ -243,9 +307,30 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evidencePool,
 		blockStore,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
+	)
+	if err := blockExec.SetHalt(config.HaltHeight, config.HaltTimestamp()); err != nil {
+		return nil, fmt.Errorf("could not set the halt height: %w", err)
+	}
+
+	pruner := sm.NewPruner(
+		stateStore,
+		blockStore,
+		logger.With("module", "pruner"),
+		prunerOptions(config.Storage)...,
 	)
 
+	var partsMigrator *store.BlockPartsMigrator
+	if config.Storage.MigrateBlockParts {
+		partsMigrator = store.NewBlockPartsMigrator(blockStore, logger.With("module", "store"))
+	}
+
+	var coldMover *store.ColdStorageMover
+	if config.Storage.ColdStorageAfter > 0 {
+		coldMover = store.NewColdStorageMover(blockStore, config.Storage.ColdStorageAfter,
+			logger.With("module", "store"))
+	}
+
 	// Make BlocksyncReactor. Don't start block sync if we're doing a state sync first.
 	bcReactor, err := createBlocksyncReactor(config, state, blockExec, blockStore, blockSync && !stateSync, logger, bsMetrics)
 	if err != nil {
@@ -255,7 +340,7 @@ func NewNode(config *cfg.Config,
 	// Make ConsensusReactor
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || blockSync, eventBus, consensusLogger,
+		privValidator, csMetrics, abciTracer, stateSync || blockSync, eventBus, consensusLogger,
 	)
 
 	// Set up state sync reactor, and schedule a sync if requested.
@@ -268,16 +353,39 @@ func NewNode(config *cfg.Config,
 		proxyApp.Query(),
 		config.StateSync.TempDir,
 		ssMetrics,
+		statesync.WithBlockStore(blockStore),
+		statesync.WithProgressFile(filepath.Join(config.DBDir(), "statesync_progress.json")),
+		statesync.WithTxIndexSnapshots(config.StateSync.TxIndexSnapshotDir()),
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
-	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
+	// Schedule the snapshots of the application, if enabled.
+	var snapshotScheduler *statesync.SnapshotScheduler
+	if config.StateSync.SnapshotInterval > 0 {
+		snapshotScheduler = statesync.NewSnapshotScheduler(
+			proxyApp.Query(),
+			proxyApp.Snapshot(),
+			eventBus,
+			config.StateSync.SnapshotInterval,
+			config.StateSync.SnapshotKeepRecent,
+		)
+		snapshotScheduler.SetLogger(logger.With("module", "statesync"))
+	}
+
+	// Map the P2P port on the NAT gateway, which may set the external address.
+	portMapping := createPortMapping(config, logger.With("module", "p2p"))
+
+	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state,
+		mempoolReactor, bcReactor, stateSyncReactor, consensusReactor, evidenceReactor)
 	if err != nil {
 		return nil, err
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
@@ -333,30 +441,41 @@ func NewNode(config *cfg.Config,
 
 	node := &Node{
 		config:        config,
+		liveConfig:    config,
+		logLevel:      config.LogLevel,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
-
-		stateStore:       stateStore,
-		blockStore:       blockStore,
-		bcReactor:        bcReactor,
-		mempool:          mempool,
-		consensusState:   consensusState,
-		consensusReactor: consensusReactor,
-		stateSyncReactor: stateSyncReactor,
-		stateSync:        stateSync,
-		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
-		evidencePool:     evidencePool,
-		proxyApp:         proxyApp,
-		txIndexer:        txIndexer,
-		indexerService:   indexerService,
-		blockIndexer:     blockIndexer,
-		eventBus:         eventBus,
+		transport:   transport,
+		portMapping: portMapping,
+		sw:          sw,
+		addrBook:    addrBook,
+		nodeInfo:    nodeInfo,
+		nodeKey:     nodeKey,
+
+		stateStore:        stateStore,
+		blockStore:        blockStore,
+		blockExec:         blockExec,
+		bcReactor:         bcReactor,
+		mempool:           mempool,
+		consensusState:    consensusState,
+		consensusReactor:  consensusReactor,
+		stateSyncReactor:  stateSyncReactor,
+		stateSync:         stateSync,
+		stateSyncGenesis:  state, // Shouldn't be necessary, but need a way to pass the genesis state
+		evidencePool:      evidencePool,
+		proxyApp:          proxyApp,
+		txIndexer:         txIndexer,
+		indexerService:    indexerService,
+		blockIndexer:      blockIndexer,
+		pruner:            pruner,
+		partsMigrator:     partsMigrator,
+		coldMover:         coldMover,
+		dbs:               dbs,
+		snapshotScheduler: snapshotScheduler,
+		tracerProvider:    tracerProvider,
+		eventBus:          eventBus,
+		eventLog:          eventLog,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -376,6 +495,19 @@ func (n *Node) OnStart() error {
 		time.Sleep(genTime.Sub(now))
 	}
 
+	// Refuse to start if the consensus key is signing on another node.
+	if n.config.Consensus.DoubleSignCheckHeight > 0 && len(n.config.Consensus.DoubleSignCheckRPCServers) > 0 {
+		pubKey, err := n.privValidator.GetPubKey()
+		if err != nil {
+			return fmt.Errorf("can't get pubkey: %w", err)
+		}
+		err = checkDoubleSigningRiskRemote(n.config.Consensus.DoubleSignCheckRPCServers,
+			n.config.Consensus.DoubleSignCheckHeight, pubKey.Address(), n.Logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	// run pprof server if it is enabled
 	if n.config.RPC.IsPprofEnabled() {
 		n.pprofSrv = n.startPprofServer()
@@ -386,6 +518,13 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer()
 	}
 
+	// Restore the pending txs of the mempool before accepting new ones.
+	if n.config.Mempool.WalEnabled() {
+		if err := n.mempool.InitWAL(); err != nil {
+			return err
+		}
+	}
+
 	// Start the RPC server before the P2P server
 	// so we can eg. receive txs for the first block
 	if n.config.RPC.ListenAddress != "" {
@@ -396,6 +535,28 @@ func (n *Node) OnStart() error {
 		n.rpcListeners = listeners
 	}
 
+	if err := n.pruner.Start(); err != nil {
+		return err
+	}
+
+	if n.partsMigrator != nil {
+		if err := n.partsMigrator.Start(); err != nil {
+			return err
+		}
+	}
+
+	if n.coldMover != nil {
+		if err := n.coldMover.Start(); err != nil {
+			return err
+		}
+	}
+
+	if n.snapshotScheduler != nil {
+		if err := n.snapshotScheduler.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
 	if err != nil {
@@ -407,6 +568,13 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	// Keep the P2P port mapped on the NAT gateway.
+	if n.portMapping != nil {
+		if err := n.portMapping.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -426,15 +594,32 @@ func (n *Node) OnStart() error {
 			return fmt.Errorf("this blocksync reactor does not support switching from state sync")
 		}
 		err := startStateSync(n.stateSyncReactor, bcR, n.consensusReactor, n.stateSyncProvider,
-			n.config.StateSync, n.stateStore, n.blockStore, n.stateSyncGenesis)
+			n.config.StateSync, n.stateStore, n.blockStore, n.txIndexer, n.stateSyncGenesis)
 		if err != nil {
 			return fmt.Errorf("failed to start state sync: %w", err)
 		}
 	}
 
+	// Stop the node once it halted.
+	go n.stopOnHalt()
+
 	return nil
 }
 
+// stopOnHalt stops the node once the block executor halted, after committing
+// the halt height or time.
+func (n *Node) stopOnHalt() {
+	select {
+	case <-n.blockExec.Halted():
+	case <-n.Quit():
+		return
+	}
+	n.Logger.Info("The node halted; stopping")
+	if err := n.Stop(); err != nil {
+		n.Logger.Error("Error stopping the node after the halt", "err", err)
+	}
+}
+
 // OnStop stops the Node. It implements service.Service.
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
@@ -442,12 +627,35 @@ func (n *Node) OnStop() {
 	n.Logger.Info("Stopping Node")
 
 	// first stop the non-reactor services
+	if n.snapshotScheduler != nil {
+		if err := n.snapshotScheduler.Stop(); err != nil {
+			n.Logger.Error("Error closing snapshotScheduler", "err", err)
+		}
+	}
 	if err := n.eventBus.Stop(); err != nil {
 		n.Logger.Error("Error closing eventBus", "err", err)
 	}
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if n.eventLog != nil {
+		if err := n.eventLog.Stop(); err != nil {
+			n.Logger.Error("Error closing eventLog", "err", err)
+		}
+	}
+	if err := n.pruner.Stop(); err != nil {
+		n.Logger.Error("Error closing pruner", "err", err)
+	}
+	if n.partsMigrator != nil {
+		if err := n.partsMigrator.Stop(); err != nil {
+			n.Logger.Error("Error closing partsMigrator", "err", err)
+		}
+	}
+	if n.coldMover != nil {
+		if err := n.coldMover.Stop(); err != nil {
+			n.Logger.Error("Error closing coldMover", "err", err)
+		}
+	}
 
 	// now stop the reactors
 	if err := n.sw.Stop(); err != nil {
@@ -458,6 +666,12 @@ func (n *Node) OnStop() {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
 
+	if n.portMapping != nil && n.portMapping.IsRunning() {
+		if err := n.portMapping.Stop(); err != nil {
+			n.Logger.Error("Error removing port mapping", "err", err)
+		}
+	}
+
 	n.isListening = false
 
 	// finally stop the listeners / external services
@@ -468,6 +682,10 @@ func (n *Node) OnStop() {
 		}
 	}
 
+	if n.config.Mempool.WalEnabled() {
+		n.mempool.CloseWAL()
+	}
+
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
@@ -485,6 +703,11 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Pprof HTTP server Shutdown", "err", err)
 		}
 	}
+	if n.tracerProvider != nil {
+		if err := n.tracerProvider.Shutdown(context.Background()); err != nil {
+			n.Logger.Error("Error flushing the spans", "err", err)
+		}
+	}
 	if n.blockStore != nil {
 		if err := n.blockStore.Close(); err != nil {
 			n.Logger.Error("problem closing blockstore", "err", err)
@@ -513,6 +736,7 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		AddrBook:       n.addrBook,
 		PubKey:         pubKey,
 
 		GenDoc:           n.genesisDoc,
@@ -520,7 +744,15 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		BlockIndexer:     n.blockIndexer,
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
+		EventLog:         n.eventLog,
 		Mempool:          n.mempool,
+		Pruner:           n.pruner,
+		ConfigReloader:   n,
+		LogLevelSetter:   n,
+		Halter:           n.blockExec,
+		Backuper:         n,
+
+		ConsensusWALFile: n.config.Consensus.WalFile(),
 
 		Logger: n.Logger.With("module", "rpc"),
 
@@ -549,6 +781,8 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.Compression = n.config.RPC.Compression
+	config.H2C = n.config.RPC.H2C
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -556,6 +790,14 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	rateLimiter, err := createRPCRateLimiter(n.config.RPC)
+	if err != nil {
+		return nil, err
+	}
+	n.reloadMtx.Lock()
+	n.rpcRateLimiter = rateLimiter
+	n.reloadMtx.Unlock()
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -573,8 +815,11 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
 		)
 		wm.SetLogger(wmLogger)
+		wm.SetRateLimiter(rateLimiter)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger,
+			rpcserver.BatchConcurrency(n.config.RPC.MaxBatchConcurrency),
+			rpcserver.RateLimited(rateLimiter))
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config.MaxOpenConnections,
@@ -586,9 +831,12 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		var rootHandler http.Handler = mux
 		if n.config.RPC.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
-				AllowedMethods: n.config.RPC.CORSAllowedMethods,
-				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
+				AllowedOrigins:   n.config.RPC.CORSAllowedOrigins,
+				AllowedMethods:   n.config.RPC.CORSAllowedMethods,
+				AllowedHeaders:   n.config.RPC.CORSAllowedHeaders,
+				ExposedHeaders:   n.config.RPC.CORSExposedHeaders,
+				AllowCredentials: n.config.RPC.CORSAllowCredentials,
+				MaxAge:           int(n.config.RPC.CORSMaxAge.Seconds()),
 			})
 			rootHandler = corsMiddleware.Handler(mux)
 		}
@@ -743,6 +991,7 @@ func makeNodeInfo(
 	txIndexer txindex.TxIndexer,
 	genDoc *types.GenesisDoc,
 	state sm.State,
+	reactors ...p2p.Reactor,
 ) (p2p.DefaultNodeInfo, error) {
 	txIndexerStatus := "on"
 	if _, ok := txIndexer.(*null.TxIndex); ok {
@@ -772,10 +1021,24 @@ func makeNodeInfo(
 		},
 	}
 
+	if config.Mempool.PushPullGossip {
+		nodeInfo.Channels = append(nodeInfo.Channels, mempl.MempoolHashChannel)
+	}
+
 	if config.P2P.PexReactor {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	// Advertise the versions of the messages of the channels supported by the
+	// reactors, so that they can negotiate them with each peer.
+	for _, reactor := range reactors {
+		for _, chDesc := range reactor.GetChannels() {
+			if nodeInfo.HasChannel(chDesc.ID) {
+				nodeInfo.SetChannelVersion(chDesc.ID, chDesc.Version)
+			}
+		}
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
@@ -2,10 +2,14 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
 	"syscall"
 	"testing"
 	"time"
@@ -17,6 +21,7 @@ import (
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	cfg "github.com/cometbft/cometbft/config"
+	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/internal/test"
@@ -28,7 +33,10 @@ import (
 	p2pmock "github.com/cometbft/cometbft/p2p/mock"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	sm "github.com/cometbft/cometbft/state"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
 	"github.com/cometbft/cometbft/store"
 	"github.com/cometbft/cometbft/types"
 	cmttime "github.com/cometbft/cometbft/types/time"
@@ -77,6 +85,207 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeReloadConfig(t *testing.T) {
+	config := test.ResetTestRoot("node_reload_config_test")
+	defer os.RemoveAll(config.RootDir)
+
+	var levels []string
+	logger, err := log.NewReloadableLogger(config.LogLevel, func(level string) (log.Logger, error) {
+		levels = append(levels, level)
+		return log.TestingLogger(), nil
+	})
+	require.NoError(t, err)
+	n, err := DefaultNewNode(config, logger)
+	require.NoError(t, err)
+	n.rpcRateLimiter, err = createRPCRateLimiter(config.RPC)
+	require.NoError(t, err)
+
+	// nothing changed
+	configFile := filepath.Join(config.RootDir, cfg.DefaultConfigDir, cfg.DefaultConfigFileName)
+	cfg.WriteConfigFile(configFile, config)
+	changed, err := n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	rpcConfig, p2pConfig, mempoolConfig := *config.RPC, *config.P2P, *config.Mempool
+	storageConfig, consensusConfig := *config.Storage, *config.Consensus
+	reloaded := *config
+	reloaded.LogLevel = "debug"
+	reloaded.RPC = &rpcConfig
+	reloaded.RPC.RateLimits = []string{"default:10:20"}
+	reloaded.P2P = &p2pConfig
+	reloaded.P2P.UnconditionalPeerIDs = "9e4f6d5f4bde8d0d6f5b6f0a2d0ff9c2b0f3c1e5"
+	reloaded.Mempool = &mempoolConfig
+	reloaded.Mempool.Size = 10
+	reloaded.Storage = &storageConfig
+	reloaded.Storage.MinRetainBlocks = 100
+	reloaded.Consensus = &consensusConfig
+	reloaded.Consensus.TimeoutCommit = time.Hour // not reloaded
+	cfg.WriteConfigFile(configFile, &reloaded)
+	changed, err = n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"log_level",
+		"rpc.rate_limits",
+		"p2p.unconditional_peer_ids",
+		"mempool.size",
+		"storage.min_retain_blocks",
+	}, changed)
+	assert.Equal(t, []string{config.LogLevel, "debug"}, levels)
+	assert.EqualValues(t, 101, n.pruner.PruningRetainHeight(200))
+
+	// the API keys are reported as changed only if the content of their file
+	// changed
+	keysFile := filepath.Join(config.RootDir, "api_keys.json")
+	require.NoError(t, os.WriteFile(keysFile, []byte(`[{"key":"k1","name":"a"}]`), 0o600))
+	reloaded.RPC.APIKeysFile = keysFile
+	cfg.WriteConfigFile(configFile, &reloaded)
+	changed, err = n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rpc.api_keys_file"}, changed)
+	changed, err = n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	require.NoError(t, os.WriteFile(keysFile, []byte(`[{"key":"k2","name":"a"}]`), 0o600))
+	changed, err = n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rpc.api_keys_file"}, changed)
+
+	// the configuration can be read by another reader, e.g. applying the
+	// command-line flags again
+	flagged := reloaded
+	flagged.LogLevel = "error"
+	n.SetConfigReader(func() (*cfg.Config, error) { return &flagged, nil })
+	changed, err = n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log_level"}, changed)
+	n.SetConfigReader(nil)
+
+	// an invalid configuration is not applied
+	reloaded.Mempool.Size = -1
+	cfg.WriteConfigFile(configFile, &reloaded)
+	_, err = n.ReloadConfigFile()
+	require.Error(t, err)
+}
+
+func TestNodeSetLogLevel(t *testing.T) {
+	config := test.ResetTestRoot("node_set_log_level_test")
+	defer os.RemoveAll(config.RootDir)
+	config.LogLevel = "info"
+
+	var levels []string
+	logger, err := log.NewReloadableLogger(config.LogLevel, func(level string) (log.Logger, error) {
+		levels = append(levels, level)
+		return log.TestingLogger(), nil
+	})
+	require.NoError(t, err)
+	n, err := DefaultNewNode(config, logger)
+	require.NoError(t, err)
+
+	level, err := n.SetLogLevel("consensus:debug,p2p:error")
+	require.NoError(t, err)
+	assert.Equal(t, "*:info,consensus:debug,p2p:error", level)
+	level, err = n.SetLogLevel("p2p:info")
+	require.NoError(t, err)
+	assert.Equal(t, "*:info,consensus:debug,p2p:info", level)
+	assert.Equal(t, level, n.LogLevel())
+
+	_, err = n.SetLogLevel("p2p:verbose")
+	require.Error(t, err)
+	assert.Equal(t, level, n.LogLevel())
+
+	// a change of log_level in the configuration file replaces the overrides
+	configFile := filepath.Join(config.RootDir, cfg.DefaultConfigDir, cfg.DefaultConfigFileName)
+	reloaded := *config
+	reloaded.LogLevel = "error"
+	cfg.WriteConfigFile(configFile, &reloaded)
+	_, err = n.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, "error", n.LogLevel())
+	assert.Equal(t, []string{"info", "*:info,consensus:debug,p2p:error", "*:info,consensus:debug,p2p:info", "error"}, levels)
+}
+
+func TestNodeHalt(t *testing.T) {
+	config := test.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+	config.HaltHeight = 2
+	config.DBBackend = "goleveldb"
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	err = n.Start()
+	require.NoError(t, err)
+
+	// the node stops by itself after committing the halt height
+	select {
+	case <-n.Quit():
+	case <-time.After(20 * time.Second):
+		t.Fatal("timed out waiting for the node to halt")
+	}
+	assert.EqualValues(t, 2, n.blockStore.Height())
+
+	// the node refuses to start again with the same halt height
+	_, err = DefaultNewNode(config, log.TestingLogger())
+	assert.Error(t, err)
+}
+
+func TestNodeBackup(t *testing.T) {
+	config := test.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = "goleveldb"
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	assert.Nil(t, n.BackupStatus())
+	require.Eventually(t, func() bool { return n.blockStore.Height() >= 2 }, 20*time.Second, 50*time.Millisecond)
+
+	backup, err := n.Backup()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return n.BackupStatus().Done }, 20*time.Second, 50*time.Millisecond)
+	backup = n.BackupStatus()
+	require.Empty(t, backup.Error)
+	assert.Equal(t, config.RPC.BackupDirPath(), filepath.Dir(backup.Dir))
+
+	// the node goes on after the backup
+	require.Eventually(t, func() bool { return n.blockStore.Height() > backup.Height }, 20*time.Second, 50*time.Millisecond)
+
+	// the stores of the backup are at the same height
+	blockDB, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, backup.Dir)
+	require.NoError(t, err)
+	blockStore := store.NewBlockStore(blockDB)
+	defer blockStore.Close()
+	assert.Equal(t, backup.Height, blockStore.Height())
+	require.NotNil(t, blockStore.LoadBlock(backup.Height))
+
+	stateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, backup.Dir)
+	require.NoError(t, err)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	defer stateStore.Close()
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, backup.Height, state.LastBlockHeight)
+
+	// the block at the height of the backup is indexed
+	txIndexDB, err := dbm.NewDB("tx_index", dbm.GoLevelDBBackend, backup.Dir)
+	require.NoError(t, err)
+	defer txIndexDB.Close()
+	indexed, err := blockidxkv.New(dbm.NewPrefixDB(txIndexDB, []byte("block_events"))).Has(backup.Height)
+	require.NoError(t, err)
+	assert.True(t, indexed)
+
+	// the validator state is backed up, but not the validator key
+	_, err = os.Stat(filepath.Join(backup.Dir, filepath.Base(config.PrivValidatorStateFile())))
+	require.NoError(t, err)
+	entries, err := os.ReadDir(backup.Dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotEqual(t, filepath.Base(config.PrivValidatorKeyFile()), entry.Name())
+	}
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -491,3 +700,150 @@ func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	}
 	return s, stateDB, privVals
 }
+
+func TestLoadStateFromDBOrGenesisDocProvider(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	genDoc := &types.GenesisDoc{
+		ChainID:     "test-chain",
+		GenesisTime: cmttime.Now(),
+		AppState:    []byte(`{"accounts": []}`),
+	}
+	provider := func() (*types.GenesisDoc, error) { return genDoc, nil }
+
+	state, loaded, err := LoadStateFromDBOrGenesisDocProvider(stateDB, provider)
+	require.NoError(t, err)
+	assert.Equal(t, "test-chain", state.ChainID)
+	assert.Equal(t, genDoc, loaded)
+
+	// only the hash of the genesis doc is stored
+	has, err := stateDB.Has(genesisDocKey)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// the same genesis doc, formatted differently
+	genDoc.AppState = []byte(`{"accounts":[]}`)
+	_, _, err = LoadStateFromDBOrGenesisDocProvider(stateDB, provider)
+	require.NoError(t, err)
+
+	genDoc.AppState = []byte(`{"accounts":["a"]}`)
+	_, _, err = LoadStateFromDBOrGenesisDocProvider(stateDB, provider)
+	require.ErrorContains(t, err, "the genesis file must not change")
+}
+
+func TestNetwork(t *testing.T) {
+	const numNodes = 4
+	nodes := make([]NetworkNode, numNodes)
+	validators := make([]types.GenesisValidator, numNodes)
+	for i := range nodes {
+		config := test.ResetTestRoot("node_network_test")
+		t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+		config.RPC.ListenAddress = ""
+		config.RPC.GRPCListenAddress = ""
+
+		// ResetTestRoot writes the same validator key for all the nodes
+		pv := privval.GenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+		pv.Save()
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		validators[i] = types.GenesisValidator{Address: pubKey.Address(), PubKey: pubKey, Power: 10}
+		nodes[i] = NetworkNode{Config: config, ClientCreator: proxy.NewLocalClientCreator(kvstore.NewApplication())}
+	}
+	genDoc := &types.GenesisDoc{
+		ChainID:         "network_test",
+		GenesisTime:     cmttime.Now(),
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators:      validators,
+	}
+	for _, node := range nodes {
+		require.NoError(t, genDoc.SaveAs(node.Config.GenesisFile()))
+	}
+
+	network, err := NewNetwork(nodes, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, network.Start())
+	t.Cleanup(func() { assert.NoError(t, network.Stop()) })
+
+	// all the nodes are connected to each other and commit blocks
+	require.Eventually(t, func() bool {
+		for _, n := range network.Nodes() {
+			if n.Switch().Peers().Size() < numNodes-1 || n.blockStore.Height() < 3 {
+				return false
+			}
+		}
+		return true
+	}, 30*time.Second, 100*time.Millisecond)
+}
+
+// commitsServer returns an RPC server whose commit at the signedAt height has a
+// signature of the validator with the given address, with the given flag.
+func commitsServer(
+	t *testing.T,
+	latestHeight, signedAt int64,
+	flag types.BlockIDFlag,
+	valAddr types.Address,
+) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var res interface{}
+		switch req.Method {
+		case "status":
+			res = &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: latestHeight}}
+		case "commit":
+			var params struct {
+				Height string `json:"height"`
+			}
+			require.NoError(t, json.Unmarshal(req.Params, &params))
+			height, err := strconv.ParseInt(params.Height, 10, 64)
+			require.NoError(t, err)
+			sig := types.CommitSig{BlockIDFlag: types.BlockIDFlagAbsent}
+			if height == signedAt {
+				sig = types.CommitSig{BlockIDFlag: flag, ValidatorAddress: valAddr}
+			}
+			header := &types.Header{Height: height}
+			commit := &types.Commit{Height: height, Signatures: []types.CommitSig{sig}}
+			res = ctypes.NewResultCommit(header, commit, true)
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(rpctypes.NewRPCSuccessResponse(req.ID, res)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCheckDoubleSigningRiskRemote(t *testing.T) {
+	valAddr := ed25519.GenPrivKey().PubKey().Address()
+	logger := log.TestingLogger()
+
+	// The key signed 5 blocks ago on another node.
+	signed := commitsServer(t, 20, 15, types.BlockIDFlagCommit, valAddr)
+	notSigned := commitsServer(t, 20, 0, types.BlockIDFlagCommit, valAddr)
+
+	err := checkDoubleSigningRiskRemote([]string{signed.URL}, 10, valAddr, logger)
+	assert.ErrorIs(t, err, cs.ErrSignatureFoundInPastBlocks)
+	// A precommit for nil is a signature too.
+	signedNil := commitsServer(t, 20, 15, types.BlockIDFlagNil, valAddr)
+	err = checkDoubleSigningRiskRemote([]string{signedNil.URL}, 10, valAddr, logger)
+	assert.ErrorIs(t, err, cs.ErrSignatureFoundInPastBlocks)
+	err = checkDoubleSigningRiskRemote([]string{notSigned.URL, signed.URL}, 10, valAddr, logger)
+	assert.ErrorIs(t, err, cs.ErrSignatureFoundInPastBlocks)
+
+	// The signatures older than the checked heights are ignored.
+	err = checkDoubleSigningRiskRemote([]string{signed.URL}, 5, valAddr, logger)
+	assert.NoError(t, err)
+	err = checkDoubleSigningRiskRemote([]string{notSigned.URL}, 10, valAddr, logger)
+	assert.NoError(t, err)
+
+	// Unreachable servers are skipped, as long as one is checked.
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	err = checkDoubleSigningRiskRemote([]string{unreachable.URL, notSigned.URL}, 10, valAddr, logger)
+	assert.NoError(t, err)
+	err = checkDoubleSigningRiskRemote([]string{unreachable.URL}, 10, valAddr, logger)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, cs.ErrSignatureFoundInPastBlocks)
+}
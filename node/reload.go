@@ -0,0 +1,235 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtflags "github.com/cometbft/cometbft/libs/cli/flags"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// mempoolWithLimits is implemented by the mempools whose limits can be changed
+// at runtime.
+type mempoolWithLimits interface {
+	SetLimits(maxTxs int, maxTxsBytes int64)
+}
+
+// ReloadConfigFile reads the configuration file of the node again, and
+// applies the changes of the settings which can be changed without restarting
+// the node (see ReloadConfig). The configuration is read with the reader set
+// by SetConfigReader, if any, or else along with the environment variables
+// only, without the settings given with command-line flags.
+func (n *Node) ReloadConfigFile() ([]string, error) {
+	n.reloadMtx.Lock()
+	read := n.readConfig
+	n.reloadMtx.Unlock()
+	if read == nil {
+		read = func() (*cfg.Config, error) { return readConfigFile(n.config.RootDir) }
+	}
+
+	config, err := read()
+	if err != nil {
+		return nil, err
+	}
+	return n.ReloadConfig(config)
+}
+
+// SetConfigReader sets the function reading the configuration again upon a
+// reload, e.g. to apply the command-line flags the node was started with
+// again.
+func (n *Node) SetConfigReader(read func() (*cfg.Config, error)) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	n.readConfig = read
+}
+
+// ReloadConfig applies the changes of the settings of config which can be
+// changed without restarting the node, and returns the names of the changed
+// settings:
+//
+//   - log_level, if the logger of the node is a log.LevelSetter, replacing the
+//     changes made with SetLogLevel;
+//   - rpc.rate_limits, and the API keys of rpc.api_keys_file, read again;
+//   - p2p.persistent_peers, whose new peers are dialed, and
+//     p2p.unconditional_peer_ids and p2p.private_peer_ids, whose new peers are
+//     added to the current ones;
+//   - mempool.size and mempool.max_txs_bytes;
+//   - the pruning settings of the storage section.
+//
+// The changes of the other settings are ignored until the node is restarted.
+func (n *Node) ReloadConfig(config *cfg.Config) ([]string, error) {
+	if err := config.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in config file: %w", err)
+	}
+
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	live := n.liveConfig
+
+	var changed []string
+	if config.LogLevel != live.LogLevel {
+		if setter, ok := n.Logger.(log.LevelSetter); ok {
+			if err := setter.SetLevel(config.LogLevel); err != nil {
+				return changed, fmt.Errorf("setting the log level: %w", err)
+			}
+			n.logLevel = config.LogLevel
+			changed = append(changed, "log_level")
+		} else {
+			n.Logger.Error("The log level of the node can't be changed without restarting it")
+		}
+	}
+
+	if n.rpcRateLimiter != nil {
+		// the API keys file is read again, since its content may have changed
+		limits, keys, err := rpcRateLimits(config.RPC)
+		if err != nil {
+			return changed, err
+		}
+		ratesChanged := !slices.Equal(config.RPC.RateLimits, live.RPC.RateLimits)
+		keysChanged := !n.rpcRateLimiter.HasAPIKeys(keys)
+		if ratesChanged || keysChanged {
+			n.rpcRateLimiter.SetLimits(limits, keys)
+		}
+		if ratesChanged {
+			changed = append(changed, "rpc.rate_limits")
+		}
+		if keysChanged {
+			changed = append(changed, "rpc.api_keys_file")
+		}
+	}
+
+	if config.P2P.PersistentPeers != live.P2P.PersistentPeers {
+		peers := splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " ")
+		if err := n.sw.AddPersistentPeers(peers); err != nil {
+			return changed, fmt.Errorf("could not add persistent peers: %w", err)
+		}
+		if n.IsRunning() {
+			if err := n.sw.DialPeersAsync(newItems(peers, splitAndTrimEmpty(live.P2P.PersistentPeers, ",", " "))); err != nil {
+				return changed, fmt.Errorf("could not dial peers: %w", err)
+			}
+		}
+		changed = append(changed, "p2p.persistent_peers")
+	}
+	if config.P2P.UnconditionalPeerIDs != live.P2P.UnconditionalPeerIDs {
+		if err := n.sw.AddUnconditionalPeerIDs(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " ")); err != nil {
+			return changed, fmt.Errorf("could not add unconditional peer ids: %w", err)
+		}
+		changed = append(changed, "p2p.unconditional_peer_ids")
+	}
+	if config.P2P.PrivatePeerIDs != live.P2P.PrivatePeerIDs {
+		if err := n.sw.AddPrivatePeerIDs(splitAndTrimEmpty(config.P2P.PrivatePeerIDs, ",", " ")); err != nil {
+			return changed, fmt.Errorf("could not add private peer ids: %w", err)
+		}
+		changed = append(changed, "p2p.private_peer_ids")
+	}
+
+	if config.Mempool.Size != live.Mempool.Size || config.Mempool.MaxTxsBytes != live.Mempool.MaxTxsBytes {
+		if mempool, ok := n.mempool.(mempoolWithLimits); ok {
+			mempool.SetLimits(config.Mempool.Size, config.Mempool.MaxTxsBytes)
+			if config.Mempool.Size != live.Mempool.Size {
+				changed = append(changed, "mempool.size")
+			}
+			if config.Mempool.MaxTxsBytes != live.Mempool.MaxTxsBytes {
+				changed = append(changed, "mempool.max_txs_bytes")
+			}
+		}
+	}
+
+	pruningChanged := false
+	for _, setting := range []struct {
+		name    string
+		changed bool
+	}{
+		{"storage.min_retain_blocks", config.Storage.MinRetainBlocks != live.Storage.MinRetainBlocks},
+		{"storage.min_retain_history", config.Storage.MinRetainHistory != live.Storage.MinRetainHistory},
+		{"storage.history_keep_every", config.Storage.HistoryKeepEvery != live.Storage.HistoryKeepEvery},
+		{"storage.pruning_interval", config.Storage.PruningInterval != live.Storage.PruningInterval},
+		{"storage.pruning_batch_size", config.Storage.PruningBatchSize != live.Storage.PruningBatchSize},
+		{"storage.compaction_blocks", config.Storage.CompactionBlocks != live.Storage.CompactionBlocks},
+	} {
+		if setting.changed {
+			changed = append(changed, setting.name)
+			pruningChanged = true
+		}
+	}
+	if pruningChanged {
+		n.pruner.SetOptions(prunerOptions(config.Storage)...)
+	}
+
+	n.liveConfig = config
+	n.Logger.Info("Reloaded the configuration", "changed", changed)
+	return changed, nil
+}
+
+// LogLevel returns the current log level of the node.
+func (n *Node) LogLevel() string {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	return n.logLevel
+}
+
+// SetLogLevel changes the levels of the modules given in level, a list of
+// module:level pairs like log_level, or of all the other modules if level is a
+// simple one word level, and returns the new log level of the node. The
+// changes last until the node is restarted, or log_level is changed in the
+// configuration file and reloaded.
+func (n *Node) SetLogLevel(level string) (string, error) {
+	setter, ok := n.Logger.(log.LevelSetter)
+	if !ok {
+		return "", errors.New("the log level of the node can't be changed without restarting it")
+	}
+
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	merged, err := cmtflags.MergeLogLevels(n.logLevel, level)
+	if err != nil {
+		return "", err
+	}
+	if err := setter.SetLevel(merged); err != nil {
+		return "", fmt.Errorf("setting the log level: %w", err)
+	}
+	n.logLevel = merged
+	n.Logger.Info("Changed the log level", "log_level", merged)
+	return merged, nil
+}
+
+// readConfigFile reads the configuration file of the node at rootDir, along
+// with the environment variables overriding it.
+func readConfigFile(rootDir string) (*cfg.Config, error) {
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(rootDir, cfg.DefaultConfigDir, cfg.DefaultConfigFileName))
+	v.SetEnvPrefix("CMT")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading the config file: %w", err)
+	}
+
+	config := cfg.DefaultConfig()
+	if err := v.Unmarshal(config); err != nil {
+		return nil, fmt.Errorf("error in config file: %w", err)
+	}
+	config.SetRoot(rootDir)
+	return config, nil
+}
+
+// newItems returns the items of s which are not in old.
+func newItems(s, old []string) []string {
+	oldSet := make(map[string]struct{}, len(old))
+	for _, item := range old {
+		oldSet[item] = struct{}{}
+	}
+	var items []string
+	for _, item := range s {
+		if _, ok := oldSet[item]; !ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -6,11 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
 	_ "net/http/pprof" //nolint: gosec // securely exposed on separate, optional port
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -23,16 +30,23 @@ import (
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtnet "github.com/cometbft/cometbft/libs/net"
 	"github.com/cometbft/cometbft/light"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
+	"github.com/cometbft/cometbft/p2p/upnp"
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cometbft/cometbft/proxy"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
 	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/eventlog"
 	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/indexer/block"
+	"github.com/cometbft/cometbft/state/indexer/sink/psql"
 	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/kv"
 	"github.com/cometbft/cometbft/store"
 	"github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
@@ -40,7 +54,13 @@ import (
 	_ "github.com/lib/pq" // provide the psql db driver
 )
 
-const readHeaderTimeout = 10 * time.Second
+const (
+	readHeaderTimeout = 10 * time.Second
+
+	// doubleSignCheckTimeout is how long the commits of an RPC server are
+	// checked for signatures of the node's key before giving up.
+	doubleSignCheckTimeout = 30 * time.Second
+)
 
 // GenesisDocProvider returns a GenesisDoc.
 // It allows the GenesisDoc to be pulled from sources other than the
@@ -68,7 +88,11 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	}
 
 	return NewNode(config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		privval.LoadOrGenFilePVWithPassphrase(
+			config.PrivValidatorKeyFile(),
+			config.PrivValidatorStateFile(),
+			privval.KeyPassphrase(config.PrivValidatorKeyPassphraseFile()),
+		),
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
@@ -110,7 +134,16 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (blockStore *store.B
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	var options []store.BlockStoreOption
+	if config.Storage.ColdStorageAfter > 0 {
+		var coldDB dbm.DB
+		coldDB, err = dbProvider(&cfg.DBContext{ID: cfg.ColdBlockStoreDBID, Config: config})
+		if err != nil {
+			return
+		}
+		options = append(options, store.WithColdStore(coldDB))
+	}
+	blockStore = store.NewBlockStore(blockStoreDB, options...)
 
 	stateDB, err = dbProvider(&cfg.DBContext{ID: "state", Config: config})
 	if err != nil {
@@ -120,8 +153,74 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (blockStore *store.B
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger, metrics *proxy.Metrics) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator, metrics)
+// createTracerProvider returns the provider of the OpenTelemetry spans
+// exported to the configured OTLP collector, or nil if tracing is disabled.
+func createTracerProvider(config *cfg.Config, chainID string) (*sdktrace.TracerProvider, error) {
+	if !config.Instrumentation.Tracing {
+		return nil, nil
+	}
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Instrumentation.TracingOTLPEndpoint)}
+	if config.Instrumentation.TracingOTLPInsecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the OTLP exporter: %w", err)
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(config.Instrumentation.Namespace),
+		semconv.ServiceVersion(version.TMCoreSemVer),
+		attribute.String("chain_id", chainID),
+	)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Instrumentation.TracingSampleRate))),
+	), nil
+}
+
+func createAndStartProxyAppConns(
+	config *cfg.Config,
+	clientCreator proxy.ClientCreator,
+	logger log.Logger,
+	metrics *proxy.Metrics,
+	tracer *proxy.Tracer,
+) (proxy.AppConns, error) {
+	var options []proxy.MultiAppConnOption
+	if config.Mempool.Recheck && config.Mempool.RecheckConcurrency > 0 {
+		options = append(options, proxy.WithMempoolRecheckConns(config.Mempool.RecheckConcurrency))
+	}
+	if config.Mempool.CheckTxConcurrency > 0 {
+		options = append(options, proxy.WithMempoolCheckTxConns(config.Mempool.CheckTxConcurrency))
+	}
+	options = append(options, proxy.WithTimeouts(proxy.Timeouts{
+		PrepareProposal: config.ABCIClient.PrepareProposalTimeout,
+		ProcessProposal: config.ABCIClient.ProcessProposalTimeout,
+		BeginBlock:      config.ABCIClient.BeginBlockTimeout,
+		EndBlock:        config.ABCIClient.EndBlockTimeout,
+		Commit:          config.ABCIClient.CommitTimeout,
+		CheckTx:         config.ABCIClient.CheckTxTimeout,
+		Query:           config.ABCIClient.QueryTimeout,
+		Snapshot:        config.ABCIClient.SnapshotTimeout,
+	}, config.ABCIClient.MaxTimeouts))
+	if config.ABCIClient.Reconnect {
+		options = append(options, proxy.WithReconnect(
+			config.ABCIClient.ReconnectMinBackoff,
+			config.ABCIClient.ReconnectMaxBackoff,
+			config.ABCIClient.MaxReconnectAttempts,
+		))
+	}
+	if tracer != nil {
+		options = append(options, proxy.WithTracer(tracer))
+	}
+	if config.ABCIClient.Record {
+		recorder, err := proxy.NewRecorder(config.ABCIClient.RecordDirPath(), logger.With("module", "abci-recorder"))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, proxy.WithRecorder(recorder))
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, metrics, options...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -129,8 +228,8 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+func createAndStartEventBus(config *cfg.Config, logger log.Logger) (*types.EventBus, error) {
+	eventBus := types.NewEventBusWithReplayCapacity(config.RPC.EventReplayBufferSize)
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -138,10 +237,37 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
+// createAndStartEventLog returns nil if the event log is disabled.
+func createAndStartEventLog(
+	config *cfg.Config,
+	dbProvider cfg.DBProvider,
+	eventBus *types.EventBus,
+	logger log.Logger,
+) (*eventlog.Log, error) {
+	if !config.RPC.EventLog {
+		return nil, nil
+	}
+	db, err := dbProvider(&cfg.DBContext{ID: "eventlog", Config: config})
+	if err != nil {
+		return nil, err
+	}
+	eventLog, err := eventlog.NewLog(db, eventBus, config.RPC.EventLogTypes, uint64(config.RPC.EventLogRetainEvents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the event log: %w", err)
+	}
+	eventLog.SetLogger(logger.With("module", "eventlog"))
+	if err := eventLog.Start(); err != nil {
+		return nil, err
+	}
+	return eventLog, nil
+}
+
 func createAndStartIndexerService(
 	config *cfg.Config,
 	chainID string,
 	dbProvider cfg.DBProvider,
+	blockStore *store.BlockStore,
+	stateStore sm.Store,
 	eventBus *types.EventBus,
 	logger log.Logger,
 ) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
@@ -154,6 +280,17 @@ func createAndStartIndexerService(
 		return nil, nil, nil, err
 	}
 
+	// The heights to backfill must be found before the indexer service starts
+	// indexing new blocks.
+	var backfill []psql.HeightRange
+	es, isPsql := blockIndexer.(psql.BackportBlockIndexer)
+	if isPsql && config.TxIndex.PsqlBackfill {
+		backfill, err = es.EventSink().MissingHeights(blockStore)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
@@ -161,9 +298,64 @@ func createAndStartIndexerService(
 		return nil, nil, nil, err
 	}
 
+	if len(backfill) > 0 {
+		go backfillEventSink(indexerService, es.EventSink(), backfill, blockStore, stateStore)
+	}
+	if txIndex, ok := txIndexer.(*kv.TxIndex); ok {
+		go indexNumericValues(indexerService, txIndex)
+	}
+
 	return indexerService, txIndexer, blockIndexer, nil
 }
 
+// backfillEventSink indexes the given heights in the psql event sink until
+// they are all indexed or the indexer service stops.
+func backfillEventSink(
+	indexerService *txindex.IndexerService,
+	es *psql.EventSink,
+	ranges []psql.HeightRange,
+	blockStore *store.BlockStore,
+	stateStore sm.Store,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-indexerService.Quit():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	indexerService.Logger.Info("Backfilling the psql event sink", "ranges", ranges)
+	if err := es.Backfill(ctx, ranges, blockStore, stateStore); err != nil {
+		if ctx.Err() == nil {
+			indexerService.Logger.Error("Failed to backfill the psql event sink", "err", err)
+		}
+		return
+	}
+	indexerService.Logger.Info("Backfilled the psql event sink")
+}
+
+// indexNumericValues adds the values of the events indexed by older versions
+// to the numeric index of the kv indexer, until they are all indexed or the
+// indexer service stops. The range queries scan the events in the meantime.
+func indexNumericValues(indexerService *txindex.IndexerService, txIndex *kv.TxIndex) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-indexerService.Quit():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := txIndex.IndexNumericValues(ctx); err != nil && ctx.Err() == nil {
+		indexerService.Logger.Error("Failed to index the numeric values of the tx index", "err", err)
+	}
+}
+
 func doHandshake(
 	stateStore sm.Store,
 	state sm.State,
@@ -171,11 +363,13 @@ func doHandshake(
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
+	initChainChunkSize int,
 	consensusLogger log.Logger,
 ) error {
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetInitChainChunkSize(initChainChunkSize)
 	if err := handshaker.Handshake(proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
@@ -232,9 +426,12 @@ func createMempoolAndMempoolReactor(
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
+		mempl.WithRecheckConns(proxyApp.MempoolRecheck()...),
+		mempl.WithCheckTxConns(proxyApp.MempoolCheckTx()...),
 	)
 
 	mp.SetLogger(logger)
+	mp.SetMaxBlockTxBytes(sm.MaxBlockTxBytes(state))
 
 	reactor := mempl.NewReactor(
 		config.Mempool,
@@ -294,6 +491,7 @@ func createConsensusReactor(config *cfg.Config,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
+	abciTracer *proxy.Tracer,
 	waitSync bool,
 	eventBus *types.EventBus,
 	consensusLogger log.Logger,
@@ -306,6 +504,7 @@ func createConsensusReactor(config *cfg.Config,
 		mempool,
 		evidencePool,
 		cs.StateMetrics(csMetrics),
+		cs.StateABCITracer(abciTracer),
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
@@ -319,18 +518,27 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// p2pTransport is the transport of the switch, which the node starts and
+// stops.
+type p2pTransport interface {
+	p2p.Transport
+	Listen(p2p.NetAddress) error
+	Close() error
+	AddChannel(chID byte)
+	SetChannelVersion(chID byte, version uint32)
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
 ) (
-	*p2p.MultiplexTransport,
+	p2pTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
-		mConnConfig = p2p.MConnConfig(config.P2P)
-		transport   = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
 		connFilters = []p2p.ConnFilterFunc{}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
@@ -379,13 +587,90 @@ func createTransport(
 		)
 	}
 
-	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
+	// Only accept the sentry nodes as peers of a validator behind them.
+	if sentryIDs := config.P2P.SentryNodeIDs(); len(sentryIDs) > 0 {
+		peerFilters = append(peerFilters, p2p.PeerIDFilter(sentryIDs))
+	}
 
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		transport, err := p2p.NewQUICTransport(
+			nodeInfo,
+			*nodeKey,
+			p2p.QUICTransportConnFilters(connFilters...),
+			p2p.QUICTransportMaxIncomingConnections(max),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create QUIC transport: %w", err)
+		}
+		return transport, peerFilters, nil
+	}
+
+	mConnConfig := p2p.MConnConfig(config.P2P)
+	transport := p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
+	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	if config.P2P.TLSPeerIDs != "" {
+		tlsConfig, err := p2p.LoadTLSConfig(config.P2P.CAFile(), config.P2P.CertFile(), config.P2P.KeyFile())
+		if err != nil {
+			return nil, nil, err
+		}
+		var ids []p2p.ID
+		for _, id := range splitAndTrimEmpty(config.P2P.TLSPeerIDs, ",", " ") {
+			ids = append(ids, p2p.ID(id))
+		}
+		p2p.MultiplexTransportTLS(tlsConfig, ids)(transport)
+	}
+
+	return transport, peerFilters, nil
+}
+
+// createPortMapping maps the P2P listen port on the NAT gateway found with
+// UPnP or NAT-PMP, if enabled, and sets the external address to the mapped
+// one unless it is configured, so that peers can dial the node behind the
+// gateway. The node runs without the mapping if it fails.
+func createPortMapping(config *cfg.Config, logger log.Logger) *upnp.PortMapping {
+	if !config.P2P.UPNP {
+		return nil
+	}
+
+	_, laddr := cmtnet.ProtocolAndAddress(config.P2P.ListenAddress)
+	_, portStr, err := net.SplitHostPort(laddr)
+	if err != nil {
+		logger.Error("Failed to map the P2P port", "laddr", config.P2P.ListenAddress, "err", err)
+		return nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		logger.Error("Failed to map the P2P port", "laddr", config.P2P.ListenAddress, "err", err)
+		return nil
+	}
+	protocol := "tcp"
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		protocol = "udp"
+	}
+
+	nat, err := upnp.DiscoverNAT()
+	if err != nil {
+		logger.Error("Failed to map the P2P port", "err", err)
+		return nil
+	}
+	portMapping, err := upnp.NewPortMapping(nat, protocol, port)
+	if err != nil {
+		logger.Error("Failed to map the P2P port", "err", err)
+		return nil
+	}
+	portMapping.SetLogger(logger)
+
+	logger.Info("Mapped the P2P port on the NAT gateway", "port", port,
+		"external_address", portMapping.ExternalAddress())
+	if config.P2P.ExternalAddress == "" {
+		config.P2P.ExternalAddress = portMapping.ExternalAddress()
+	}
+	return portMapping
 }
 
 func createSwitch(config *cfg.Config,
@@ -471,7 +756,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 // startStateSync starts an asynchronous state sync process, then switches to block sync mode.
 func startStateSync(ssR *statesync.Reactor, bcR blockSyncReactor, conR *cs.Reactor,
 	stateProvider statesync.StateProvider, config *cfg.StateSyncConfig,
-	stateStore sm.Store, blockStore *store.BlockStore, state sm.State,
+	stateStore sm.Store, blockStore *store.BlockStore, txIndexer txindex.TxIndexer, state sm.State,
 ) error {
 	ssR.Logger.Info("Starting state sync")
 
@@ -509,6 +794,22 @@ func startStateSync(ssR *statesync.Reactor, bcR blockSyncReactor, conR *cs.React
 			return
 		}
 
+		// The tx index snapshot is restored before block sync indexes the
+		// blocks after the state sync height. Block sync isn't started if it
+		// fails, like when the state sync fails, since the transactions before
+		// the state sync height couldn't be searched.
+		if hash := config.TxIndexSnapshotHashBytes(); hash != nil {
+			txIndex, ok := txIndexer.(*kv.TxIndex)
+			if !ok {
+				ssR.Logger.Error("Can't restore the tx index snapshot, the tx indexer isn't kv")
+				return
+			}
+			if err := ssR.RestoreTxIndex(txIndex, hash, state.LastBlockHeight); err != nil {
+				ssR.Logger.Error("Failed to restore the tx index snapshot, not starting block sync", "err", err)
+				return
+			}
+		}
+
 		err = bcR.SwitchToBlockSync(state)
 		if err != nil {
 			ssR.Logger.Error("Failed to switch to block sync", "err", err)
@@ -520,11 +821,19 @@ func startStateSync(ssR *statesync.Reactor, bcR blockSyncReactor, conR *cs.React
 
 //------------------------------------------------------------------------------
 
-var genesisDocKey = []byte("genesisDoc")
+var (
+	// the genesis doc was stored in full by the previous versions
+	genesisDocKey     = []byte("genesisDoc")
+	genesisDocHashKey = []byte("genesisDocHash")
+)
 
 // LoadStateFromDBOrGenesisDocProvider attempts to load the state from the
 // database, or creates one using the given genesisDocProvider. On success this also
 // returns the genesis doc loaded through the given provider.
+//
+// Only the hash of the genesis doc is stored in the database, to check that it
+// doesn't change, so the genesis doc is loaded through the provider on every
+// start.
 func LoadStateFromDBOrGenesisDocProvider(
 	stateDB dbm.DB,
 	genesisDocProvider GenesisDocProvider,
@@ -541,9 +850,9 @@ func LoadStateFromDBOrGenesisDocProvider(
 		if err != nil {
 			return sm.State{}, nil, fmt.Errorf("error in genesis doc: %w", err)
 		}
-		// save genesis doc to prevent a certain class of user errors (e.g. when it
-		// was changed, accidentally or not). Also good for audit trail.
-		if err := saveGenesisDoc(stateDB, genDoc); err != nil {
+		// check the genesis doc against the stored hash to prevent a certain
+		// class of user errors (e.g. when it was changed, accidentally or not).
+		if err := checkGenesisDocHash(stateDB, genDoc); err != nil {
 			return sm.State{}, nil, err
 		}
 	}
@@ -574,16 +883,24 @@ func loadGenesisDoc(db dbm.DB) (*types.GenesisDoc, error) {
 	return genDoc, nil
 }
 
-// panics if failed to marshal the given genesis document
-func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) error {
-	b, err := cmtjson.Marshal(genDoc)
+// checkGenesisDocHash checks that the hash of the genesis doc is the one
+// stored in the database, or stores it if there is none.
+func checkGenesisDocHash(db dbm.DB, genDoc *types.GenesisDoc) error {
+	hash, err := genDoc.Hash()
 	if err != nil {
-		return fmt.Errorf("failed to save genesis doc due to marshaling error: %w", err)
+		return fmt.Errorf("failed to hash genesis doc: %w", err)
 	}
-	if err := db.SetSync(genesisDocKey, b); err != nil {
+	storedHash, err := db.Get(genesisDocHashKey)
+	if err != nil {
 		return err
 	}
-
+	if len(storedHash) == 0 {
+		return db.SetSync(genesisDocHashKey, hash)
+	}
+	if !bytes.Equal(hash, storedHash) {
+		return fmt.Errorf("genesis doc hash %X doesn't match the one the node was started with, %X; "+
+			"the genesis file must not change", hash, storedHash)
+	}
 	return nil
 }
 
@@ -637,3 +954,115 @@ func splitAndTrimEmpty(s, sep, cutset string) []string {
 	}
 	return nonEmptyStrings
 }
+
+// createRPCRateLimiter returns the rate limiter of the RPC server, enforcing
+// the rate limits and recognizing the API keys of the configuration, which can
+// be changed upon a reload of the configuration.
+func createRPCRateLimiter(config *cfg.RPCConfig) (*rpcserver.RateLimiter, error) {
+	limits, keys, err := rpcRateLimits(config)
+	if err != nil {
+		return nil, err
+	}
+	return rpcserver.NewRateLimiter(limits, keys), nil
+}
+
+// rpcRateLimits returns the rate limits of the RPC routes, by route class, and
+// the API keys read from the API keys file.
+func rpcRateLimits(config *cfg.RPCConfig) (map[string]rpcserver.RateLimit, []rpcserver.APIKey, error) {
+	limits := make(map[string]rpcserver.RateLimit, len(config.RateLimits))
+	for _, s := range config.RateLimits {
+		limit, err := cfg.ParseRPCRateLimit(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		limits[limit.Class] = rpcserver.RateLimit{RPS: limit.RPS, Burst: limit.Burst}
+	}
+
+	var keys []rpcserver.APIKey
+	if path := config.APIKeysPath(); path != "" {
+		var err error
+		keys, err = rpcserver.LoadAPIKeys(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return limits, keys, nil
+}
+
+// prunerOptions returns the options of the pruner set by the storage
+// configuration.
+func prunerOptions(config *cfg.StorageConfig) []sm.PrunerOption {
+	return []sm.PrunerOption{
+		sm.PrunerWithMinRetainBlocks(config.MinRetainBlocks),
+		sm.PrunerWithHistory(config.MinRetainHistory, config.HistoryKeepEvery),
+		sm.PrunerWithMinRetainResults(config.MinRetainResults),
+		sm.PrunerWithInterval(config.PruningInterval),
+		sm.PrunerWithBatchSize(config.PruningBatchSize),
+		sm.PrunerWithCompaction(config.CompactionBlocks),
+	}
+}
+
+// checkDoubleSigningRiskRemote looks back at the last checkHeight commits of
+// the RPC servers for a signature of the validator with the given address,
+// made by another instance of the node, e.g. restored from the same backup,
+// which the local block store misses. The servers that can't be queried are
+// skipped, but at least one of them must be.
+func checkDoubleSigningRiskRemote(
+	servers []string,
+	checkHeight int64,
+	valAddr crypto.Address,
+	logger log.Logger,
+) error {
+	checked := 0
+	for _, server := range servers {
+		err := checkDoubleSigningRiskOn(server, checkHeight, valAddr)
+		if errors.Is(err, cs.ErrSignatureFoundInPastBlocks) {
+			return err
+		}
+		if err != nil {
+			logger.Error("Failed to check the commits of the RPC server for signatures of the node",
+				"server", server, "err", err)
+			continue
+		}
+		checked++
+	}
+	if checked == 0 {
+		return errors.New("failed to check the commits of any RPC server for signatures of the node")
+	}
+	return nil
+}
+
+// checkDoubleSigningRiskOn looks back at the last checkHeight commits of the
+// RPC server for a signature of the validator with the given address, for the
+// block or for nil.
+func checkDoubleSigningRiskOn(server string, checkHeight int64, valAddr crypto.Address) error {
+	if !strings.Contains(server, "://") {
+		server = "http://" + server
+	}
+	c, err := rpchttp.New(server, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doubleSignCheckTimeout)
+	defer cancel()
+
+	status, err := c.Status(ctx)
+	if err != nil {
+		return err
+	}
+	latestHeight := status.SyncInfo.LatestBlockHeight
+	for height := latestHeight; height > 0 && height > latestHeight-checkHeight; height-- {
+		h := height
+		commit, err := c.Commit(ctx, &h)
+		if err != nil {
+			return err
+		}
+		for _, sig := range commit.Commit.Signatures {
+			if sig.BlockIDFlag != types.BlockIDFlagAbsent && bytes.Equal(sig.ValidatorAddress, valAddr) {
+				return fmt.Errorf("%w: at height %d on %s", cs.ErrSignatureFoundInPastBlocks, height, server)
+			}
+		}
+	}
+	return nil
+}
@@ -43,6 +43,7 @@ const (
 	defaultSendRate            = int64(512000) // 500KB/s
 	defaultRecvRate            = int64(512000) // 500KB/s
 	defaultSendTimeout         = 10 * time.Second
+	defaultMaxSendQueueBytes   = 67108864 // 64MB
 	defaultPingInterval        = 60 * time.Second
 	defaultPongTimeout         = 45 * time.Second
 )
@@ -110,6 +111,8 @@ type MConnection struct {
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
 	pongTimeoutCh chan bool // true - timeout, false - peer sent pong
+	pingSent      time.Time // when the last ping was sent, zero once ponged
+	rtt           int64     // atomic, round-trip time of the last ping in ns
 
 	chStatsTimer *time.Ticker // update channel stats periodically
 
@@ -134,6 +137,14 @@ type MConnConfig struct {
 
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+
+	// Weights of the channels by ID, overriding the priorities of their
+	// descriptors
+	ChannelWeights map[byte]int `mapstructure:"channel_weights"`
+
+	// Maximum number of bytes queued on all the channels, beyond which the
+	// queues are considered full (0 - unlimited)
+	MaxSendQueueBytes int64 `mapstructure:"max_send_queue_bytes"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -145,6 +156,7 @@ func DefaultMConnConfig() MConnConfig {
 		FlushThrottle:           defaultFlushThrottle,
 		PingInterval:            defaultPingInterval,
 		PongTimeout:             defaultPongTimeout,
+		MaxSendQueueBytes:       defaultMaxSendQueueBytes,
 	}
 }
 
@@ -194,7 +206,11 @@ func NewMConnectionWithConfig(
 	var channels = []*Channel{}
 
 	for _, desc := range chDescs {
-		channel := newChannel(mconn, *desc)
+		desc := *desc
+		if weight, ok := config.ChannelWeights[desc.ID]; ok {
+			desc.Priority = weight
+		}
+		channel := newChannel(mconn, desc)
 		channelsIdx[channel.desc.ID] = channel
 		channels = append(channels, channel)
 	}
@@ -347,7 +363,18 @@ func (c *MConnection) stopForError(r interface{}) {
 	}
 }
 
-// Queues a message to be sent to channel.
+// queuedBytes returns the number of bytes queued on all the channels.
+func (c *MConnection) queuedBytes() int64 {
+	var n int64
+	for _, channel := range c.channels {
+		n += atomic.LoadInt64(&channel.sendQueueBytes)
+	}
+	return n
+}
+
+// Queues a message to be sent to channel. If the queue of the channel is full,
+// it waits up to defaultSendTimeout for room in the queue, unless the drop
+// policy of the channel makes room, and disconnects if it is Disconnect.
 func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 	if !c.IsRunning() {
 		return false
@@ -376,7 +403,9 @@ func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 }
 
 // Queues a message to be sent to channel.
-// Nonblocking, returns true if successful.
+// Nonblocking, returns true if successful. It fails if the queue of the channel
+// is full, unless the drop policy of the channel makes room, even if it is
+// Disconnect.
 func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
 	if !c.IsRunning() {
 		return false
@@ -446,6 +475,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(_n)
+			c.pingSent = time.Now()
 			c.Logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -460,6 +490,10 @@ FOR_LOOP:
 				err = errors.New("pong timeout")
 			} else {
 				c.stopPongTimer()
+				if !c.pingSent.IsZero() {
+					atomic.StoreInt64(&c.rtt, int64(time.Since(c.pingSent)))
+					c.pingSent = time.Time{}
+				}
 			}
 		case <-c.pong:
 			c.Logger.Debug("Send Pong")
@@ -519,21 +553,7 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
-	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *Channel
-	for _, channel := range c.channels {
-		// If nothing to send, skip this channel
-		if !channel.isSendPending() {
-			continue
-		}
-		// Get ratio, and keep track of lowest ratio.
-		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
-		if ratio < leastRatio {
-			leastRatio = ratio
-			leastChannel = channel
-		}
-	}
+	leastChannel := c.nextSendChannel()
 
 	// Nothing to send?
 	if leastChannel == nil {
@@ -553,6 +573,29 @@ func (c *MConnection) sendPacketMsg() bool {
 	return false
 }
 
+// nextSendChannel returns the channel with pending messages whose
+// recentlySent/priority is the least, the one with the highest priority on
+// ties, so that the bandwidth is shared between the channels in proportion to
+// their priority. It returns nil if no channel has a message to send.
+func (c *MConnection) nextSendChannel() *Channel {
+	var leastRatio float32
+	var leastChannel *Channel
+	for _, channel := range c.channels {
+		// If nothing to send, skip this channel
+		if !channel.isSendPending() {
+			continue
+		}
+		// Get ratio, and keep track of lowest ratio.
+		ratio := float32(atomic.LoadInt64(&channel.recentlySent)) / float32(channel.desc.Priority)
+		if leastChannel == nil || ratio < leastRatio ||
+			(ratio == leastRatio && channel.desc.Priority > leastChannel.desc.Priority) {
+			leastRatio = ratio
+			leastChannel = channel
+		}
+	}
+	return leastChannel
+}
+
 // recvRoutine reads PacketMsgs and reconstructs the message using the channels' "recving" buffer.
 // After a whole message has been assembled, it's pushed to onReceive().
 // Blocks depending on how the connection is throttled.
@@ -685,6 +728,7 @@ func (c *MConnection) maxPacketMsgSize() int {
 
 type ConnectionStatus struct {
 	Duration    time.Duration
+	RTT         time.Duration // of the last ping, 0 until the first pong
 	SendMonitor flow.Status
 	RecvMonitor flow.Status
 	Channels    []ChannelStatus
@@ -694,6 +738,8 @@ type ChannelStatus struct {
 	ID                byte
 	SendQueueCapacity int
 	SendQueueSize     int
+	SendQueueBytes    int
+	NumDropped        int64 // by the DropOldest policy
 	Priority          int
 	RecentlySent      int64
 }
@@ -701,6 +747,7 @@ type ChannelStatus struct {
 func (c *MConnection) Status() ConnectionStatus {
 	var status ConnectionStatus
 	status.Duration = time.Since(c.created)
+	status.RTT = time.Duration(atomic.LoadInt64(&c.rtt))
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
 	status.Channels = make([]ChannelStatus, len(c.channels))
@@ -709,6 +756,8 @@ func (c *MConnection) Status() ConnectionStatus {
 			ID:                channel.desc.ID,
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
+			SendQueueBytes:    int(atomic.LoadInt64(&channel.sendQueueBytes)),
+			NumDropped:        atomic.LoadInt64(&channel.numDropped),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
 		}
@@ -724,7 +773,46 @@ type ChannelDescriptor struct {
 	SendQueueCapacity   int
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
-	MessageType         proto.Message
+	// If set, returns the current maximum size of a received message, at
+	// most RecvMessageCapacity, for the channels whose messages are bounded
+	// by the consensus params, which change at runtime.
+	RecvMessageCapacityFunc func() int
+	MessageType             proto.Message
+	// What to do when the send queue is full
+	DropPolicy DropPolicy
+	// Highest version of the messages of the channel the reactor supports,
+	// advertised to the peers in the handshake. 0 is the same as 1, the
+	// version of the nodes which don't advertise it. See
+	// p2p.NegotiateChannelVersion.
+	Version uint32
+}
+
+// DropPolicy is what a channel does with a message when its send queue is
+// full.
+type DropPolicy uint8
+
+const (
+	// RejectNewest fails to queue the message, once the wait for room in the
+	// queue, if any, times out. It's the default.
+	RejectNewest DropPolicy = iota
+	// DropOldest drops the oldest messages queued to make room for the
+	// message, for gossip where newer messages are worth more than old ones.
+	DropOldest
+	// Disconnect rejects the message like RejectNewest, but stops the
+	// connection rather than dropping the message silently, for messages
+	// the peer can't do without. Messages sent with TrySend are only
+	// rejected.
+	Disconnect
+)
+
+// ErrSendQueueFull is the error the connection stops with when the send queue
+// of a channel with the Disconnect drop policy is full.
+type ErrSendQueueFull struct {
+	ChID byte
+}
+
+func (e ErrSendQueueFull) Error() string {
+	return fmt.Sprintf("send queue of channel %#x is full", e.ChID)
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -741,6 +829,17 @@ func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
 	return
 }
 
+// MaxRecvMessageSize returns the maximum size of a message received on the
+// channel.
+func (chDesc ChannelDescriptor) MaxRecvMessageSize() int {
+	if chDesc.RecvMessageCapacityFunc != nil {
+		if capacity := chDesc.RecvMessageCapacityFunc(); capacity < chDesc.RecvMessageCapacity {
+			return capacity
+		}
+	}
+	return chDesc.RecvMessageCapacity
+}
+
 // TODO: lowercase.
 // NOTE: not goroutine-safe.
 type Channel struct {
@@ -752,6 +851,11 @@ type Channel struct {
 	sending       []byte
 	recentlySent  int64 // exponential moving average
 
+	// atomic, bytes queued or being sent, not yet written to the connection
+	sendQueueBytes int64
+	// atomic, messages dropped by the DropOldest policy
+	numDropped int64
+
 	maxPacketMsgPayloadSize int
 
 	Logger log.Logger
@@ -779,22 +883,84 @@ func (ch *Channel) SetLogger(l log.Logger) {
 // Goroutine-safe
 // Times out (and returns false) after defaultSendTimeout
 func (ch *Channel) sendBytes(bytes []byte) bool {
-	select {
-	case ch.sendQueue <- bytes:
-		atomic.AddInt32(&ch.sendQueueSize, 1)
+	if ch.queue(bytes, defaultSendTimeout) {
 		return true
-	case <-time.After(defaultSendTimeout):
-		return false
 	}
+	if ch.desc.DropPolicy == Disconnect && ch.conn.IsRunning() {
+		// not to stop the peer from the goroutine of a reactor
+		go ch.conn.stopForError(ErrSendQueueFull{ChID: ch.desc.ID})
+	}
+	return false
 }
 
 // Queues message to send to this channel.
 // Nonblocking, returns true if successful.
 // Goroutine-safe
 func (ch *Channel) trySendBytes(bytes []byte) bool {
+	return ch.queue(bytes, 0)
+}
+
+// queue queues the bytes if there is room in the queue and within the
+// maximum number of bytes queued on the connection, dropping the oldest
+// messages to make room with the DropOldest policy, or else waiting up to the
+// timeout for room in the queue. It returns false if the bytes were not
+// queued.
+// Goroutine-safe
+func (ch *Channel) queue(bytes []byte, timeout time.Duration) bool {
+	n := int64(len(bytes))
+	if max := ch.conn.config.MaxSendQueueBytes; max > 0 {
+		if ch.desc.DropPolicy == DropOldest {
+			// not to drop messages in vain, if the channel can't make room
+			if ch.conn.queuedBytes()-atomic.LoadInt64(&ch.sendQueueBytes)+n > max {
+				return false
+			}
+			for ch.conn.queuedBytes()+n > max {
+				if !ch.dropOldest() {
+					return false
+				}
+			}
+		} else if ch.conn.queuedBytes()+n > max {
+			return false
+		}
+	}
+
+	// counted before queueing as the bytes may be sent right away
+	atomic.AddInt64(&ch.sendQueueBytes, n)
+	for {
+		select {
+		case ch.sendQueue <- bytes:
+			atomic.AddInt32(&ch.sendQueueSize, 1)
+			return true
+		default:
+		}
+		if ch.desc.DropPolicy != DropOldest {
+			break
+		}
+		// if the queue was emptied meanwhile, there is room now
+		ch.dropOldest()
+	}
+
+	if timeout > 0 {
+		select {
+		case ch.sendQueue <- bytes:
+			atomic.AddInt32(&ch.sendQueueSize, 1)
+			return true
+		case <-time.After(timeout):
+		}
+	}
+	atomic.AddInt64(&ch.sendQueueBytes, -n)
+	return false
+}
+
+// dropOldest drops the oldest message queued, returning false if there is
+// none.
+// Goroutine-safe
+func (ch *Channel) dropOldest() bool {
 	select {
-	case ch.sendQueue <- bytes:
-		atomic.AddInt32(&ch.sendQueueSize, 1)
+	case bytes := <-ch.sendQueue:
+		atomic.AddInt32(&ch.sendQueueSize, -1)
+		atomic.AddInt64(&ch.sendQueueBytes, -int64(len(bytes)))
+		atomic.AddInt64(&ch.numDropped, 1)
 		return true
 	default:
 		return false
@@ -817,10 +983,12 @@ func (ch *Channel) canSend() bool {
 // Goroutine-safe
 func (ch *Channel) isSendPending() bool {
 	if len(ch.sending) == 0 {
-		if len(ch.sendQueue) == 0 {
+		// messages may be dropped from the queue concurrently
+		select {
+		case ch.sending = <-ch.sendQueue:
+		default:
 			return false
 		}
-		ch.sending = <-ch.sendQueue
 	}
 	return true
 }
@@ -831,6 +999,7 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 	packet := tmp2p.PacketMsg{ChannelID: int32(ch.desc.ID)}
 	maxSize := ch.maxPacketMsgPayloadSize
 	packet.Data = ch.sending[:cmtmath.MinInt(maxSize, len(ch.sending))]
+	atomic.AddInt64(&ch.sendQueueBytes, -int64(len(packet.Data)))
 	if len(ch.sending) <= maxSize {
 		packet.EOF = true
 		ch.sending = nil
@@ -856,7 +1025,7 @@ func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 // Not goroutine-safe
 func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	ch.Logger.Debug("Read PacketMsg", "conn", ch.conn, "packet", packet)
-	var recvCap, recvReceived = ch.desc.RecvMessageCapacity, len(ch.recving) + len(packet.Data)
+	var recvCap, recvReceived = ch.desc.MaxRecvMessageSize(), len(ch.recving) + len(packet.Data)
 	if recvCap < recvReceived {
 		return nil, fmt.Errorf("received message exceeds available capacity: %v < %v", recvCap, recvReceived)
 	}
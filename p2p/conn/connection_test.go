@@ -163,6 +163,73 @@ func TestMConnectionStatus(t *testing.T) {
 	assert.Zero(t, status.Channels[0].SendQueueSize)
 }
 
+func TestMConnectionStatusRTTAndQueuedBytes(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn := createTestMConnection(client)
+	err := mconn.Start()
+	require.Nil(t, err)
+	defer mconn.Stop() //nolint:errcheck // ignore for tests
+
+	assert.True(t, mconn.Send(0x01, []byte("hello")))
+	assert.Eventually(t, func() bool {
+		return mconn.Status().Channels[0].SendQueueBytes == 0
+	}, time.Second, 10*time.Millisecond, "message not dequeued")
+	assert.Zero(t, mconn.Status().RTT)
+
+	// reply to the ping after some delay
+	protoReader := protoio.NewDelimitedReader(server, mconn.config.MaxPacketMsgPayloadSize+64)
+	protoWriter := protoio.NewDelimitedWriter(server)
+	for {
+		var pkt tmp2p.Packet
+		_, err = protoReader.ReadMsg(&pkt)
+		require.NoError(t, err)
+		if _, ok := pkt.Sum.(*tmp2p.Packet_PacketPing); ok {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	_, err = protoWriter.WriteMsg(mustWrapPacket(&tmp2p.PacketPong{}))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return mconn.Status().RTT >= 10*time.Millisecond
+	}, time.Second, 5*time.Millisecond, "RTT not measured")
+	assert.Less(t, mconn.Status().RTT, mconn.config.PongTimeout)
+}
+
+func TestMConnectionChannelWeights(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	cfg := DefaultMConnConfig()
+	cfg.ChannelWeights = map[byte]int{0x02: 10}
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1}, {ID: 0x02, Priority: 1}}
+	mconn := NewMConnectionWithConfig(client, chDescs, nil, nil, cfg)
+	low, high := mconn.channelsIdx[0x01], mconn.channelsIdx[0x02]
+	assert.Equal(t, 10, high.desc.Priority)
+	assert.Equal(t, 1, chDescs[1].Priority, "descriptor should not be modified")
+
+	assert.Nil(t, mconn.nextSendChannel())
+	require.True(t, low.trySendBytes([]byte("low")))
+	require.True(t, high.trySendBytes([]byte("high")))
+
+	// the highest weight wins ties
+	assert.Equal(t, high, mconn.nextSendChannel())
+
+	// the channel having sent the least relative to its weight is next
+	high.recentlySent = 500
+	low.recentlySent = 100
+	assert.Equal(t, high, mconn.nextSendChannel())
+	high.recentlySent = 1500
+	assert.Equal(t, low, mconn.nextSendChannel())
+
+	assert.Equal(t, 10, mconn.Status().Channels[1].Priority)
+}
+
 func TestMConnectionPongTimeoutResultsInError(t *testing.T) {
 	server, client := net.Pipe()
 	defer server.Close()
@@ -564,6 +631,90 @@ func TestMConnectionTrySend(t *testing.T) {
 	assert.Equal(t, "TrySend", <-resultCh)
 }
 
+func TestMConnectionDropOldest(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, SendQueueCapacity: 2, DropPolicy: DropOldest}}
+	mconn := NewMConnectionWithConfig(client, chDescs, nil, nil, DefaultMConnConfig())
+	ch := mconn.channelsIdx[0x01]
+
+	// not started, so nothing is sent
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		assert.True(t, ch.trySendBytes([]byte(msg)))
+	}
+	status := mconn.Status().Channels[0]
+	assert.EqualValues(t, 2, status.SendQueueSize)
+	assert.EqualValues(t, 2, status.SendQueueBytes)
+	assert.EqualValues(t, 2, status.NumDropped)
+
+	// the newest messages are kept
+	assert.Equal(t, []byte("c"), <-ch.sendQueue)
+	assert.Equal(t, []byte("d"), <-ch.sendQueue)
+}
+
+func TestMConnectionMaxSendQueueBytes(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	cfg := DefaultMConnConfig()
+	cfg.MaxSendQueueBytes = 10
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, DropPolicy: DropOldest},
+	}
+	mconn := NewMConnectionWithConfig(client, chDescs, nil, nil, cfg)
+	reject, drop := mconn.channelsIdx[0x01], mconn.channelsIdx[0x02]
+
+	assert.True(t, reject.trySendBytes([]byte("12345")))
+	assert.True(t, drop.trySendBytes([]byte("1234")))
+	// the limit is shared by the channels
+	assert.False(t, reject.trySendBytes([]byte("12")))
+	// the oldest messages of the channel are dropped to make room
+	assert.True(t, drop.trySendBytes([]byte("12")))
+	assert.True(t, drop.trySendBytes([]byte("123")))
+	assert.False(t, drop.trySendBytes([]byte("123456")), "larger than the room the channel can make")
+
+	status := mconn.Status()
+	assert.EqualValues(t, 5, status.Channels[0].SendQueueBytes)
+	assert.EqualValues(t, 5, status.Channels[1].SendQueueBytes)
+	assert.EqualValues(t, 1, status.Channels[1].NumDropped)
+}
+
+func TestMConnectionDisconnectWhenSendQueueFull(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	errCh := make(chan interface{}, 1)
+	onError := func(r interface{}) {
+		errCh <- r
+	}
+	cfg := DefaultMConnConfig()
+	cfg.MaxSendQueueBytes = 10
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, SendQueueCapacity: 10, DropPolicy: Disconnect}}
+	mconn := NewMConnectionWithConfig(client, chDescs, nil, onError, cfg)
+	mconn.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn.Start())
+	defer mconn.Stop() //nolint:errcheck // ignore for tests
+
+	// the server doesn't read, so the bytes stay queued
+	assert.True(t, mconn.Send(0x01, []byte("1234567890")))
+	// TrySend only fails
+	assert.False(t, mconn.TrySend(0x01, []byte("1")))
+	assert.True(t, mconn.IsRunning())
+
+	assert.False(t, mconn.Send(0x01, []byte("1")))
+	select {
+	case r := <-errCh:
+		assert.Equal(t, ErrSendQueueFull{ChID: 0x01}, r)
+	case <-time.After(time.Second):
+		t.Fatal("connection not stopped")
+	}
+}
+
 //nolint:lll //ignore line length for tests
 func TestConnVectors(t *testing.T) {
 
@@ -631,3 +782,31 @@ func stopAll(t *testing.T, stoppers ...stopper) func() {
 		}
 	}
 }
+
+func TestChannelRecvMessageCapacityFunc(t *testing.T) {
+	capacity := 10
+	mconn := createTestMConnection(nil)
+	ch := newChannel(mconn, ChannelDescriptor{
+		ID:                      0x01,
+		Priority:                1,
+		RecvMessageCapacity:     20,
+		RecvMessageCapacityFunc: func() int { return capacity },
+	})
+	ch.SetLogger(log.TestingLogger())
+
+	msgBytes, err := ch.recvPacketMsg(tmp2p.PacketMsg{ChannelID: 0x01, EOF: true, Data: make([]byte, 10)})
+	require.NoError(t, err)
+	assert.Len(t, msgBytes, 10)
+
+	// the messages above the current capacity are rejected, before the
+	// last packet is received
+	_, err = ch.recvPacketMsg(tmp2p.PacketMsg{ChannelID: 0x01, EOF: false, Data: make([]byte, 11)})
+	require.Error(t, err)
+
+	// and it's never above RecvMessageCapacity
+	capacity = 30
+	_, err = ch.recvPacketMsg(tmp2p.PacketMsg{ChannelID: 0x01, EOF: true, Data: make([]byte, 20)})
+	require.NoError(t, err)
+	_, err = ch.recvPacketMsg(tmp2p.PacketMsg{ChannelID: 0x01, EOF: true, Data: make([]byte, 21)})
+	require.Error(t, err)
+}
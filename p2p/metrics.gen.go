@@ -38,6 +38,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_pending_send_bytes",
 			Help:      "Pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		ChannelSendQueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "channel_send_queue_size",
+			Help:      "Number of messages queued to be sent to a given peer on a channel.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -56,17 +62,95 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		SendQueueFullDisconnectsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "send_queue_full_disconnects_total",
+			Help:      "Number of peers disconnected as the send queue of a channel which can't drop messages was full.",
+		}, append(labels, "chID")).With(labelsAndValues...),
+		OutboundPeersTarget: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "outbound_peers_target",
+			Help:      "Target number of outbound peers.",
+		}, labels).With(labelsAndValues...),
+		OutboundPeersTargetChangesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "outbound_peers_target_changes_total",
+			Help:      "Number of changes of the target number of outbound peers, by reason: bandwidth or latency.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		BandwidthUsage: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "bandwidth_usage",
+			Help:      "Bytes per second sent and received from all the peers.",
+		}, labels).With(labelsAndValues...),
+		VoteLatencySeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "vote_latency_seconds",
+			Help:      "Moving average of the quorum prevote delay, in seconds.",
+		}, labels).With(labelsAndValues...),
+		PeerRTTSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_rtt_seconds",
+			Help:      "Round-trip time of the last ping to a given peer, in seconds.",
+		}, append(labels, "short_peer_id")).With(labelsAndValues...),
+		ChannelSendQueueBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "channel_send_queue_bytes",
+			Help:      "Number of bytes queued to be sent to a given peer on a channel.",
+		}, append(labels, "short_peer_id", "chID")).With(labelsAndValues...),
+		PeerDroppedEnvelopesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_dropped_envelopes_total",
+			Help:      "Number of messages to a given peer on a channel dropped as they could not be queued, usually because the send queue was full.",
+		}, append(labels, "short_peer_id", "chID")).With(labelsAndValues...),
+		ChannelSendQueueDropsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "channel_send_queue_drops_total",
+			Help:      "Number of messages queued to be sent to a given peer on a channel dropped to make room for newer ones.",
+		}, append(labels, "short_peer_id", "chID")).With(labelsAndValues...),
+		PeerMessageReceiveBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_message_receive_bytes_total",
+			Help:      "Number of bytes of each message type received from a given peer.",
+		}, append(labels, "short_peer_id", "chID", "message_type")).With(labelsAndValues...),
+		PeerMessageSendBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_message_send_bytes_total",
+			Help:      "Number of bytes of each message type sent to a given peer.",
+		}, append(labels, "short_peer_id", "chID", "message_type")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                    discard.NewGauge(),
-		PeerReceiveBytesTotal:    discard.NewCounter(),
-		PeerSendBytesTotal:       discard.NewCounter(),
-		PeerPendingSendBytes:     discard.NewGauge(),
-		NumTxs:                   discard.NewGauge(),
-		MessageReceiveBytesTotal: discard.NewCounter(),
-		MessageSendBytesTotal:    discard.NewCounter(),
+		Peers:                           discard.NewGauge(),
+		PeerReceiveBytesTotal:           discard.NewCounter(),
+		PeerSendBytesTotal:              discard.NewCounter(),
+		PeerPendingSendBytes:            discard.NewGauge(),
+		ChannelSendQueueSize:            discard.NewGauge(),
+		NumTxs:                          discard.NewGauge(),
+		MessageReceiveBytesTotal:        discard.NewCounter(),
+		MessageSendBytesTotal:           discard.NewCounter(),
+		SendQueueFullDisconnectsTotal:   discard.NewCounter(),
+		OutboundPeersTarget:             discard.NewGauge(),
+		OutboundPeersTargetChangesTotal: discard.NewCounter(),
+		BandwidthUsage:                  discard.NewGauge(),
+		VoteLatencySeconds:              discard.NewGauge(),
+		PeerRTTSeconds:                  discard.NewGauge(),
+		ChannelSendQueueBytes:           discard.NewGauge(),
+		PeerDroppedEnvelopesTotal:       discard.NewCounter(),
+		ChannelSendQueueDropsTotal:      discard.NewCounter(),
+		PeerMessageReceiveBytesTotal:    discard.NewCounter(),
+		PeerMessageSendBytesTotal:       discard.NewCounter(),
 	}
 }
@@ -13,6 +13,10 @@ const (
 	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
 	// package.
 	MetricsSubsystem = "p2p"
+
+	// metricsPeerIDLength is the number of characters of the peer IDs kept in
+	// the short_peer_id label, enough to tell the peers apart.
+	metricsPeerIDLength = 8
 )
 
 var (
@@ -34,12 +38,56 @@ type Metrics struct {
 	PeerSendBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge `metrics_labels:"peer_id"`
+	// Number of messages queued to be sent to a given peer on a channel.
+	ChannelSendQueueSize metrics.Gauge `metrics_labels:"peer_id,chID"`
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge `metrics_labels:"peer_id"`
 	// Number of bytes of each message type received.
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Number of peers disconnected as the send queue of a channel which can't
+	// drop messages was full.
+	SendQueueFullDisconnectsTotal metrics.Counter `metrics_labels:"chID"`
+
+	// The metrics below are set by the controller of the number of outbound
+	// peers, if min_num_outbound_peers is set.
+
+	// Target number of outbound peers.
+	OutboundPeersTarget metrics.Gauge
+	// Number of changes of the target number of outbound peers, by reason:
+	// bandwidth or latency.
+	OutboundPeersTargetChangesTotal metrics.Counter `metrics_labels:"reason"`
+	// Bytes per second sent and received from all the peers.
+	BandwidthUsage metrics.Gauge
+	// Moving average of the quorum prevote delay, in seconds.
+	VoteLatencySeconds metrics.Gauge `metrics_name:"vote_latency_seconds"`
+
+	// The detailed metrics below label the peers with the first 8 characters
+	// of their ID.
+
+	// Round-trip time of the last ping to a given peer, in seconds.
+	PeerRTTSeconds metrics.Gauge `metrics_labels:"short_peer_id" metrics_name:"peer_rtt_seconds"`
+	// Number of bytes queued to be sent to a given peer on a channel.
+	ChannelSendQueueBytes metrics.Gauge `metrics_labels:"short_peer_id,chID"`
+	// Number of messages to a given peer on a channel dropped as they could not
+	// be queued, usually because the send queue was full.
+	PeerDroppedEnvelopesTotal metrics.Counter `metrics_labels:"short_peer_id,chID"`
+	// Number of messages queued to be sent to a given peer on a channel dropped
+	// to make room for newer ones.
+	ChannelSendQueueDropsTotal metrics.Counter `metrics_labels:"short_peer_id,chID"`
+	// Number of bytes of each message type received from a given peer.
+	PeerMessageReceiveBytesTotal metrics.Counter `metrics_labels:"short_peer_id,chID,message_type"`
+	// Number of bytes of each message type sent to a given peer.
+	PeerMessageSendBytesTotal metrics.Counter `metrics_labels:"short_peer_id,chID,message_type"`
+}
+
+// shortPeerID returns the value of the short_peer_id label of the peer.
+func shortPeerID(id ID) string {
+	if len(id) > metricsPeerIDLength {
+		return string(id[:metricsPeerIDLength])
+	}
+	return string(id)
 }
 
 type metricsLabelCache struct {
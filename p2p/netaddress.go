@@ -63,6 +63,17 @@ func NewNetAddress(id ID, addr net.Addr) *NetAddress {
 	return na
 }
 
+// newNetAddressFromConn returns a new NetAddress using the provided ID and
+// remote address of a connection, which is a UDP address for QUIC connections.
+func newNetAddressFromConn(id ID, addr net.Addr) *NetAddress {
+	if udpAddr, ok := addr.(*net.UDPAddr); ok {
+		na := NewNetAddressIPPort(udpAddr.IP, uint16(udpAddr.Port))
+		na.ID = id
+		return na
+	}
+	return NewNetAddress(id, addr)
+}
+
 // NewNetAddressString returns a new NetAddress using the provided address in
 // the form of "ID@IP:Port".
 // Also resolves the host if host is not an IP.
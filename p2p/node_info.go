@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
@@ -15,6 +16,11 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+
+	// DefaultChannelVersion is the version of the messages of a channel
+	// whose version a node doesn't advertise, e.g. since it predates the
+	// channel versions.
+	DefaultChannelVersion = uint32(1)
 )
 
 // Max size of the NodeInfo struct
@@ -93,6 +99,18 @@ type DefaultNodeInfo struct {
 	// ASCIIText fields
 	Moniker string               `json:"moniker"` // arbitrary moniker
 	Other   DefaultNodeInfoOther `json:"other"`   // other application specific data
+
+	// Highest versions of the messages of the channels this node supports,
+	// for the channels with a version above DefaultChannelVersion.
+	ChannelVersions []ChannelVersion `json:"channel_versions"`
+}
+
+// ChannelVersion is the highest version of the messages of a channel that a
+// node supports, so that the reactors can exchange newer messages with the
+// peers supporting them, and older ones with the others.
+type ChannelVersion struct {
+	ChannelID byte   `json:"channel_id"`
+	Version   uint32 `json:"version"`
 }
 
 // DefaultNodeInfoOther is the misc. applcation specific data
@@ -151,6 +169,23 @@ func (info DefaultNodeInfo) Validate() error {
 		channels[ch] = struct{}{}
 	}
 
+	// Validate ChannelVersions - ensure they are of known channels, without
+	// duplicates.
+	versions := make(map[byte]struct{})
+	for _, cv := range info.ChannelVersions {
+		if _, ok := channels[cv.ChannelID]; !ok {
+			return fmt.Errorf("info.ChannelVersions contains unknown channel id %v", cv.ChannelID)
+		}
+		if _, ok := versions[cv.ChannelID]; ok {
+			return fmt.Errorf("info.ChannelVersions contains duplicate channel id %v", cv.ChannelID)
+		}
+		if cv.Version < DefaultChannelVersion {
+			return fmt.Errorf("info.ChannelVersions contains invalid version %v for channel id %v",
+				cv.Version, cv.ChannelID)
+		}
+		versions[cv.ChannelID] = struct{}{}
+	}
+
 	// Validate Moniker.
 	if !cmtstrings.IsASCIIText(info.Moniker) || cmtstrings.ASCIITrim(info.Moniker) == "" {
 		return fmt.Errorf("info.Moniker must be valid non-empty ASCII text without tabs, but got %v", info.Moniker)
@@ -227,6 +262,51 @@ func (info DefaultNodeInfo) HasChannel(chID byte) bool {
 	return bytes.Contains(info.Channels, []byte{chID})
 }
 
+// ChannelVersion returns the highest version of the messages of the channel
+// the node supports, which is DefaultChannelVersion if it doesn't advertise
+// one.
+func (info DefaultNodeInfo) ChannelVersion(chID byte) uint32 {
+	for _, cv := range info.ChannelVersions {
+		if cv.ChannelID == chID {
+			return cv.Version
+		}
+	}
+	return DefaultChannelVersion
+}
+
+// SetChannelVersion advertises the highest version of the messages of the
+// channel the node supports. Versions up to DefaultChannelVersion aren't
+// advertised, to keep the handshake compatible with older nodes.
+func (info *DefaultNodeInfo) SetChannelVersion(chID byte, version uint32) {
+	versions := info.ChannelVersions[:0:0]
+	for _, cv := range info.ChannelVersions {
+		if cv.ChannelID != chID {
+			versions = append(versions, cv)
+		}
+	}
+	if version > DefaultChannelVersion {
+		versions = append(versions, ChannelVersion{ChannelID: chID, Version: version})
+	}
+	info.ChannelVersions = versions
+}
+
+// NegotiateChannelVersion returns the version of the messages to exchange with
+// the peer on the channel: the highest one supported by both, given the
+// highest one we support.
+func NegotiateChannelVersion(peer Peer, chID byte, maxVersion uint32) uint32 {
+	version := DefaultChannelVersion
+	if ni, ok := peer.NodeInfo().(DefaultNodeInfo); ok {
+		version = ni.ChannelVersion(chID)
+	}
+	if maxVersion < version {
+		version = maxVersion
+	}
+	if version < DefaultChannelVersion {
+		version = DefaultChannelVersion
+	}
+	return version
+}
+
 func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 
 	dni := new(tmp2p.DefaultNodeInfo)
@@ -246,6 +326,12 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
+	for _, cv := range info.ChannelVersions {
+		dni.ChannelVersions = append(dni.ChannelVersions, tmp2p.ChannelVersion{
+			ChannelID: uint32(cv.ChannelID),
+			Version:   cv.Version,
+		})
+	}
 
 	return dni
 }
@@ -271,6 +357,15 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 			RPCAddress: pb.Other.RPCAddress,
 		},
 	}
+	for _, cv := range pb.ChannelVersions {
+		if cv.ChannelID > math.MaxUint8 {
+			return DefaultNodeInfo{}, fmt.Errorf("invalid channel id %v", cv.ChannelID)
+		}
+		dni.ChannelVersions = append(dni.ChannelVersions, ChannelVersion{
+			ChannelID: byte(cv.ChannelID),
+			Version:   cv.Version,
+		})
+	}
 
 	return dni, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
 )
@@ -39,6 +40,20 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Duplicate Channel", func(ni *DefaultNodeInfo) { ni.Channels = dupChannels }, true},
 		{"Good Channels", func(ni *DefaultNodeInfo) { ni.Channels = ni.Channels[:5] }, false},
 
+		{"Unknown Channel Version", func(ni *DefaultNodeInfo) {
+			ni.Channels = ni.Channels[:5]
+			ni.ChannelVersions = []ChannelVersion{{ChannelID: 5, Version: 2}}
+		}, true},
+		{"Duplicate Channel Version", func(ni *DefaultNodeInfo) {
+			ni.ChannelVersions = []ChannelVersion{{ChannelID: 1, Version: 2}, {ChannelID: 1, Version: 3}}
+		}, true},
+		{"Invalid Channel Version", func(ni *DefaultNodeInfo) {
+			ni.ChannelVersions = []ChannelVersion{{ChannelID: 1, Version: 0}}
+		}, true},
+		{"Good Channel Versions", func(ni *DefaultNodeInfo) {
+			ni.ChannelVersions = []ChannelVersion{{ChannelID: 1, Version: 2}, {ChannelID: 2, Version: 1}}
+		}, false},
+
 		{"Invalid NetAddress", func(ni *DefaultNodeInfo) { ni.ListenAddr = "not-an-address" }, true},
 		{"Good NetAddress", func(ni *DefaultNodeInfo) { ni.ListenAddr = "0.0.0.0:26656" }, false},
 
@@ -126,3 +141,32 @@ func TestNodeInfoCompatible(t *testing.T) {
 		assert.Error(t, ni1.CompatibleWith(ni))
 	}
 }
+
+func TestNodeInfoChannelVersions(t *testing.T) {
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	ni := testNodeInfo(nodeKey.ID(), "testing").(DefaultNodeInfo)
+	ni.Channels = []byte{0x01, 0x02, 0x03}
+
+	ni.SetChannelVersion(0x01, 2)
+	ni.SetChannelVersion(0x02, 3)
+	ni.SetChannelVersion(0x03, 1) // the default version isn't advertised
+	ni.SetChannelVersion(0x02, 4)
+	require.NoError(t, ni.Validate())
+	assert.Equal(t, []ChannelVersion{{ChannelID: 0x01, Version: 2}, {ChannelID: 0x02, Version: 4}}, ni.ChannelVersions)
+	assert.EqualValues(t, 2, ni.ChannelVersion(0x01))
+	assert.EqualValues(t, 4, ni.ChannelVersion(0x02))
+	assert.Equal(t, DefaultChannelVersion, ni.ChannelVersion(0x03))
+
+	pbni, err := DefaultNodeInfoFromToProto(ni.ToProto())
+	require.NoError(t, err)
+	assert.Equal(t, ni, pbni)
+
+	// the version is the highest one supported by both nodes, and the
+	// default one with the peers which don't advertise it
+	p := &peer{nodeInfo: ni}
+	assert.EqualValues(t, 2, NegotiateChannelVersion(p, 0x01, 3))
+	assert.EqualValues(t, 3, NegotiateChannelVersion(p, 0x02, 3))
+	assert.Equal(t, DefaultChannelVersion, NegotiateChannelVersion(p, 0x03, 3))
+	assert.Equal(t, DefaultChannelVersion, NegotiateChannelVersion(p, 0x01, 0))
+	assert.Equal(t, DefaultChannelVersion, NegotiateChannelVersion(&peer{nodeInfo: DefaultNodeInfo{}}, 0x01, 3))
+}
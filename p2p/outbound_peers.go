@@ -0,0 +1,175 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/cometbft/cometbft/config"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+const (
+	// outboundPeersControlInterval is the interval at which the target number
+	// of outbound peers is adjusted.
+	outboundPeersControlInterval = 30 * time.Second
+
+	// voteLatencyEWMAWeight is the weight of a new vote latency in its EWMA.
+	voteLatencyEWMAWeight = 0.2
+
+	// The reasons of the changes of the target number of outbound peers.
+	outboundPeersReasonBandwidth = "bandwidth"
+	outboundPeersReasonLatency   = "latency"
+)
+
+// outboundPeersController adjusts the target number of outbound peers within
+// [min, max], from the bandwidth used and the latency of the votes, instead
+// of keeping a fixed number. It's moved by one peer at a time:
+//   - down while the bandwidth used exceeds the budget;
+//   - else, with a vote latency target, up while the latency exceeds it and
+//     the bandwidth used is under 3/4 of the budget, and down while the
+//     latency is under half of it;
+//   - else, without a latency target, up while the bandwidth used is under
+//     3/4 of the budget.
+type outboundPeersController struct {
+	mtx cmtsync.Mutex
+
+	minPeers          int
+	maxPeers          int
+	bandwidthBudget   int64         // bytes/s, 0 for unlimited
+	voteLatencyTarget time.Duration // 0 for none
+
+	target      int
+	voteLatency time.Duration // EWMA, 0 until reported
+}
+
+// newOutboundPeersController returns the controller of the number of
+// outbound peers, or nil if the config sets a fixed number.
+func newOutboundPeersController(cfg *config.P2PConfig) *outboundPeersController {
+	if cfg.MinNumOutboundPeers <= 0 || cfg.MinNumOutboundPeers >= cfg.MaxNumOutboundPeers {
+		return nil
+	}
+	return &outboundPeersController{
+		minPeers:          cfg.MinNumOutboundPeers,
+		maxPeers:          cfg.MaxNumOutboundPeers,
+		bandwidthBudget:   cfg.BandwidthBudget,
+		voteLatencyTarget: cfg.VoteLatencyTarget,
+		target:            cfg.MaxNumOutboundPeers,
+	}
+}
+
+// Target returns the target number of outbound peers.
+func (c *outboundPeersController) Target() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.target
+}
+
+// ReportVoteLatency records the latency of the votes of a round.
+func (c *outboundPeersController) ReportVoteLatency(latency time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.voteLatency == 0 {
+		c.voteLatency = latency
+		return
+	}
+	c.voteLatency = time.Duration(voteLatencyEWMAWeight*float64(latency) +
+		(1-voteLatencyEWMAWeight)*float64(c.voteLatency))
+}
+
+// VoteLatency returns the EWMA of the reported vote latencies.
+func (c *outboundPeersController) VoteLatency() time.Duration {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.voteLatency
+}
+
+// adjust adjusts the target from the bandwidth used, in bytes/s, and returns
+// the change of the target (-1, 0 or 1) and its reason.
+func (c *outboundPeersController) adjust(bandwidth int64) (int, string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	headroom := c.bandwidthBudget == 0 || bandwidth < c.bandwidthBudget*3/4
+	delta, reason := 0, ""
+	switch {
+	case c.bandwidthBudget > 0 && bandwidth > c.bandwidthBudget:
+		delta, reason = -1, outboundPeersReasonBandwidth
+	case c.voteLatencyTarget > 0:
+		switch {
+		case c.voteLatency == 0:
+		case c.voteLatency > c.voteLatencyTarget && headroom:
+			delta, reason = 1, outboundPeersReasonLatency
+		case c.voteLatency < c.voteLatencyTarget/2:
+			delta, reason = -1, outboundPeersReasonLatency
+		}
+	case headroom:
+		delta, reason = 1, outboundPeersReasonBandwidth
+	}
+
+	target := c.target + delta
+	if target < c.minPeers || target > c.maxPeers {
+		return 0, ""
+	}
+	c.target = target
+	return delta, reason
+}
+
+// outboundPeersRoutine adjusts the target number of outbound peers
+// periodically, and stops an outbound peer when it's lowered below the
+// number of outbound peers.
+func (sw *Switch) outboundPeersRoutine() {
+	ticker := time.NewTicker(outboundPeersControlInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			sw.adjustOutboundPeers()
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
+func (sw *Switch) adjustOutboundPeers() {
+	var bandwidth int64
+	for _, peer := range sw.peers.List() {
+		status := peer.Status()
+		bandwidth += status.SendMonitor.CurRate + status.RecvMonitor.CurRate
+	}
+
+	delta, reason := sw.outboundPeers.adjust(bandwidth)
+	target := sw.outboundPeers.Target()
+	sw.metrics.BandwidthUsage.Set(float64(bandwidth))
+	sw.metrics.VoteLatencySeconds.Set(sw.outboundPeers.VoteLatency().Seconds())
+	sw.metrics.OutboundPeersTarget.Set(float64(target))
+	if delta == 0 {
+		return
+	}
+	sw.metrics.OutboundPeersTargetChangesTotal.With("reason", reason).Add(1)
+	sw.Logger.Info("Adjusted the target number of outbound peers",
+		"target", target, "reason", reason, "bandwidth", bandwidth,
+		"vote_latency", sw.outboundPeers.VoteLatency())
+
+	if delta > 0 {
+		return
+	}
+	if outbound, _, _ := sw.NumPeers(); outbound <= target {
+		return
+	}
+	for _, peer := range sw.peers.List() {
+		if peer.IsOutbound() && !peer.IsPersistent() && !sw.IsPeerUnconditional(peer.ID()) {
+			sw.Logger.Info("Stopping an outbound peer above the target", "peer", peer.ID())
+			sw.StopPeerGracefully(peer)
+			return
+		}
+	}
+}
+
+// ReportVoteLatency records the latency of the votes of a round, e.g. the
+// delay of the quorum of prevotes, to adjust the number of outbound peers.
+func (sw *Switch) ReportVoteLatency(latency time.Duration) {
+	if sw.outboundPeers != nil {
+		sw.outboundPeers.ReportVoteLatency(latency)
+	}
+}
@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/config"
+)
+
+func testOutboundPeersController(t *testing.T, budget int64, latencyTarget time.Duration) *outboundPeersController {
+	cfg := config.TestP2PConfig()
+	cfg.MinNumOutboundPeers = 2
+	cfg.MaxNumOutboundPeers = 4
+	cfg.BandwidthBudget = budget
+	cfg.VoteLatencyTarget = latencyTarget
+	c := newOutboundPeersController(cfg)
+	require.NotNil(t, c)
+	return c
+}
+
+func TestOutboundPeersControllerDisabled(t *testing.T) {
+	cfg := config.TestP2PConfig()
+	assert.Nil(t, newOutboundPeersController(cfg))
+	cfg.MinNumOutboundPeers = cfg.MaxNumOutboundPeers
+	assert.Nil(t, newOutboundPeersController(cfg))
+
+	sw := NewSwitch(cfg, nil)
+	assert.Equal(t, cfg.MaxNumOutboundPeers, sw.MaxNumOutboundPeers())
+	sw.ReportVoteLatency(time.Second)
+}
+
+func TestOutboundPeersControllerBandwidth(t *testing.T) {
+	c := testOutboundPeersController(t, 1000, 0)
+	assert.Equal(t, 4, c.Target())
+
+	// down to the minimum while over budget
+	for _, want := range []int{3, 2, 2} {
+		c.adjust(1500)
+		assert.Equal(t, want, c.Target())
+	}
+	// held between 3/4 of the budget and the budget
+	delta, _ := c.adjust(900)
+	assert.Zero(t, delta)
+	// up to the maximum while under 3/4 of the budget
+	for _, want := range []int{3, 4, 4} {
+		c.adjust(500)
+		assert.Equal(t, want, c.Target())
+	}
+}
+
+func TestOutboundPeersControllerLatency(t *testing.T) {
+	c := testOutboundPeersController(t, 1000, time.Second)
+
+	// held until a latency is reported
+	delta, _ := c.adjust(0)
+	assert.Zero(t, delta)
+
+	c.ReportVoteLatency(100 * time.Millisecond)
+	delta, reason := c.adjust(0)
+	assert.Equal(t, -1, delta)
+	assert.Equal(t, outboundPeersReasonLatency, reason)
+	assert.Equal(t, 3, c.Target())
+
+	// the latency is a moving average
+	c.ReportVoteLatency(10 * time.Second)
+	assert.Equal(t, 2080*time.Millisecond, c.VoteLatency())
+	delta, reason = c.adjust(0)
+	assert.Equal(t, 1, delta)
+	assert.Equal(t, outboundPeersReasonLatency, reason)
+	assert.Equal(t, 4, c.Target())
+
+	// not raised without bandwidth headroom, and lowered over budget
+	c.adjust(1500)
+	assert.Equal(t, 3, c.Target())
+	delta, _ = c.adjust(900)
+	assert.Zero(t, delta)
+}
+
+func TestSwitchOutboundPeersTarget(t *testing.T) {
+	cfg := config.TestP2PConfig()
+	cfg.MinNumOutboundPeers = 2
+	cfg.MaxNumOutboundPeers = 4
+	cfg.BandwidthBudget = 1
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	assert.Equal(t, 4, sw.MaxNumOutboundPeers())
+
+	// without peers, the bandwidth used is under the budget
+	sw.adjustOutboundPeers()
+	assert.Equal(t, 4, sw.MaxNumOutboundPeers())
+}
@@ -290,6 +290,16 @@ func (p *peer) send(chID byte, msg proto.Message, sendFunc func(byte, []byte) bo
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
 		p.metrics.MessageSendBytesTotal.With("message_type", metricLabelValue).Add(float64(len(msgBytes)))
+		p.metrics.PeerMessageSendBytesTotal.With(
+			"short_peer_id", shortPeerID(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+			"message_type", metricLabelValue,
+		).Add(float64(len(msgBytes)))
+	} else {
+		p.metrics.PeerDroppedEnvelopesTotal.With(
+			"short_peer_id", shortPeerID(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		).Add(1)
 	}
 	return res
 }
@@ -368,16 +378,36 @@ func PeerMetrics(metrics *Metrics) PeerOption {
 }
 
 func (p *peer) metricsReporter() {
+	// the number of messages dropped by channel, as last reported
+	dropped := make(map[byte]int64)
 	for {
 		select {
 		case <-p.metricsTicker.C:
 			status := p.mconn.Status()
 			var sendQueueSize float64
 			for _, chStatus := range status.Channels {
+				if n := chStatus.NumDropped - dropped[chStatus.ID]; n > 0 {
+					p.metrics.ChannelSendQueueDropsTotal.With(
+						"short_peer_id", shortPeerID(p.ID()),
+						"chID", fmt.Sprintf("%#x", chStatus.ID),
+					).Add(float64(n))
+					dropped[chStatus.ID] = chStatus.NumDropped
+				}
 				sendQueueSize += float64(chStatus.SendQueueSize)
+				p.metrics.ChannelSendQueueSize.With(
+					"peer_id", string(p.ID()),
+					"chID", fmt.Sprintf("%#x", chStatus.ID),
+				).Set(float64(chStatus.SendQueueSize))
+				p.metrics.ChannelSendQueueBytes.With(
+					"short_peer_id", shortPeerID(p.ID()),
+					"chID", fmt.Sprintf("%#x", chStatus.ID),
+				).Set(float64(chStatus.SendQueueBytes))
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			if status.RTT > 0 {
+				p.metrics.PeerRTTSeconds.With("short_peer_id", shortPeerID(p.ID())).Set(status.RTT.Seconds())
+			}
 		case <-p.Quit():
 			return
 		}
@@ -398,38 +428,13 @@ func createMConnection(
 ) *cmtconn.MConnection {
 
 	onReceive := func(chID byte, msgBytes []byte) {
-		reactor := reactorsByCh[chID]
-		if reactor == nil {
-			// Note that its ok to panic here as it's caught in the conn._recover,
-			// which does onPeerError.
-			panic(fmt.Sprintf("Unknown channel %X", chID))
-		}
-		mt := msgTypeByChID[chID]
-		msg := proto.Clone(mt)
-		err := proto.Unmarshal(msgBytes, msg)
-		if err != nil {
-			panic(fmt.Errorf("unmarshaling message: %s into type: %s", err, reflect.TypeOf(mt)))
-		}
-		labels := []string{
-			"peer_id", string(p.ID()),
-			"chID", fmt.Sprintf("%#x", chID),
-		}
-		if w, ok := msg.(Unwrapper); ok {
-			msg, err = w.Unwrap()
-			if err != nil {
-				panic(fmt.Errorf("unwrapping message: %s", err))
-			}
-		}
-		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
-		p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
-		reactor.Receive(Envelope{
-			ChannelID: chID,
-			Src:       p,
-			Message:   msg,
-		})
+		receiveEnvelope(p, chID, msgBytes, reactorsByCh, msgTypeByChID, p.metrics, p.mlc)
 	}
 
 	onError := func(r interface{}) {
+		if err, ok := r.(cmtconn.ErrSendQueueFull); ok {
+			p.metrics.SendQueueFullDisconnectsTotal.With("chID", fmt.Sprintf("%#x", err.ChID)).Add(1)
+		}
 		onPeerError(p, r)
 	}
 
@@ -441,3 +446,62 @@ func createMConnection(
 		config,
 	)
 }
+
+// receiveEnvelope unmarshals the message received from the peer on the channel
+// and passes it to the reactor of the channel. It panics if the message is
+// invalid.
+func receiveEnvelope(
+	p Peer,
+	chID byte,
+	msgBytes []byte,
+	reactorsByCh map[byte]Reactor,
+	msgTypeByChID map[byte]proto.Message,
+	metrics *Metrics,
+	mlc *metricsLabelCache,
+) {
+	reactor := reactorsByCh[chID]
+	if reactor == nil {
+		// Note that its ok to panic here as it's caught by the connection
+		// (e.g. conn._recover), which does onPeerError.
+		panic(fmt.Sprintf("Unknown channel %X", chID))
+	}
+	mt := msgTypeByChID[chID]
+	var (
+		msg proto.Message
+		err error
+	)
+	if pool, ok := mt.(PooledUnmarshaler); ok {
+		msg, err = pool.UnmarshalPooled(msgBytes)
+		if err == nil {
+			defer pool.Release(msg)
+		}
+	} else {
+		msg = proto.Clone(mt)
+		err = proto.Unmarshal(msgBytes, msg)
+	}
+	if err != nil {
+		panic(fmt.Errorf("unmarshaling message: %s into type: %s", err, reflect.TypeOf(mt)))
+	}
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", chID),
+	}
+	if w, ok := msg.(Unwrapper); ok {
+		msg, err = w.Unwrap()
+		if err != nil {
+			panic(fmt.Errorf("unwrapping message: %s", err))
+		}
+	}
+	metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	metrics.MessageReceiveBytesTotal.With("message_type", mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
+	metrics.PeerMessageReceiveBytesTotal.With(
+		"short_peer_id", shortPeerID(p.ID()),
+		"chID", fmt.Sprintf("%#x", chID),
+		"message_type", mlc.ValueToMetricLabel(msg),
+	).Add(float64(len(msgBytes)))
+	reactor.Receive(Envelope{
+		ChannelID: chID,
+		Src:       p,
+		Message:   msg,
+	})
+}
@@ -0,0 +1,557 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/libs/cmap"
+	flow "github.com/cometbft/cometbft/libs/flowrate"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtconn "github.com/cometbft/cometbft/p2p/conn"
+)
+
+const (
+	quicSendTimeout = 10 * time.Second
+
+	// same as MConnection
+	quicFlowSampleRate    = 100 * time.Millisecond
+	quicFlowWindowSize    = 5 * time.Second
+	quicRecentlySentDecay = 0.8
+)
+
+// quicChannel is a channel of a QUIC peer, sending its messages on its own
+// unidirectional stream.
+type quicChannel struct {
+	desc         cmtconn.ChannelDescriptor
+	sendQueue    chan []byte
+	recentlySent int64 // atomic
+	// atomic, bytes queued, not yet written to the stream
+	sendQueueBytes int64
+	// atomic, messages dropped by the DropOldest policy
+	numDropped int64
+}
+
+// quicPeer implements Peer over a QUIC connection, each channel being mapped
+// to a unidirectional stream in each direction. The messages are written to
+// the streams prefixed by their length.
+type quicPeer struct {
+	service.BaseService
+
+	peerConn
+	conn    quic.Connection
+	created time.Time
+
+	// peer's node info and the channel it knows about
+	// channels = nodeInfo.Channels
+	// cached to avoid copying nodeInfo in hasChannel
+	nodeInfo NodeInfo
+	channels []byte
+
+	chans         map[byte]*quicChannel
+	reactorsByCh  map[byte]Reactor
+	msgTypeByChID map[byte]proto.Message
+	onPeerError   func(Peer, interface{})
+	errorOnce     sync.Once
+
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+
+	// closed to flush the send queues and close the send streams
+	flushc   chan struct{}
+	sendDone sync.WaitGroup
+
+	// User data
+	Data *cmap.CMap
+
+	metrics       *Metrics
+	metricsTicker *time.Ticker
+	mlc           *metricsLabelCache
+
+	// When removal of a peer fails, we set this flag
+	removalAttemptFailed bool
+}
+
+var _ Peer = (*quicPeer)(nil)
+
+type quicPeerOption func(*quicPeer)
+
+func quicPeerMetrics(metrics *Metrics) quicPeerOption {
+	return func(p *quicPeer) {
+		p.metrics = metrics
+	}
+}
+
+func newQUICPeer(
+	pc peerConn,
+	conn quic.Connection,
+	nodeInfo NodeInfo,
+	reactorsByCh map[byte]Reactor,
+	msgTypeByChID map[byte]proto.Message,
+	chDescs []*cmtconn.ChannelDescriptor,
+	onPeerError func(Peer, interface{}),
+	mlc *metricsLabelCache,
+	options ...quicPeerOption,
+) *quicPeer {
+	p := &quicPeer{
+		peerConn:      pc,
+		conn:          conn,
+		created:       time.Now(),
+		nodeInfo:      nodeInfo,
+		channels:      nodeInfo.(DefaultNodeInfo).Channels,
+		chans:         make(map[byte]*quicChannel, len(chDescs)),
+		reactorsByCh:  reactorsByCh,
+		msgTypeByChID: msgTypeByChID,
+		onPeerError:   onPeerError,
+		sendMonitor:   flow.New(quicFlowSampleRate, quicFlowWindowSize),
+		recvMonitor:   flow.New(quicFlowSampleRate, quicFlowWindowSize),
+		flushc:        make(chan struct{}),
+		Data:          cmap.NewCMap(),
+		metricsTicker: time.NewTicker(metricsTickerDuration),
+		metrics:       NopMetrics(),
+		mlc:           mlc,
+	}
+	for _, desc := range chDescs {
+		filled := desc.FillDefaults()
+		p.chans[desc.ID] = &quicChannel{
+			desc:      filled,
+			sendQueue: make(chan []byte, filled.SendQueueCapacity),
+		}
+	}
+	p.BaseService = *service.NewBaseService(nil, "Peer", p)
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// String representation.
+func (p *quicPeer) String() string {
+	if p.outbound {
+		return fmt.Sprintf("Peer{QUIC %v %v out}", p.RemoteAddr(), p.ID())
+	}
+
+	return fmt.Sprintf("Peer{QUIC %v %v in}", p.RemoteAddr(), p.ID())
+}
+
+//---------------------------------------------------
+// Implements service.Service
+
+// OnStart implements BaseService.
+func (p *quicPeer) OnStart() error {
+	if err := p.BaseService.OnStart(); err != nil {
+		return err
+	}
+
+	for _, ch := range p.chans {
+		p.sendDone.Add(1)
+		go p.sendRoutine(ch)
+	}
+	go p.acceptRoutine()
+	go p.metricsReporter()
+	return nil
+}
+
+// FlushStop mimics OnStop but additionally ensures that the messages of all
+// successful .Send() calls are written to their stream before closing the
+// connection.
+// NOTE: it is not safe to call this method more than once.
+func (p *quicPeer) FlushStop() {
+	p.metricsTicker.Stop()
+	p.BaseService.OnStop()
+	close(p.flushc)
+	p.sendDone.Wait()
+	_ = p.conn.CloseWithError(0, "")
+}
+
+// OnStop implements BaseService.
+func (p *quicPeer) OnStop() {
+	p.metricsTicker.Stop()
+	p.BaseService.OnStop()
+	if err := p.conn.CloseWithError(0, ""); err != nil {
+		p.Logger.Debug("Error while stopping peer", "err", err)
+	}
+}
+
+//---------------------------------------------------
+// Implements Peer
+
+// ID returns the peer's ID - the hex encoded hash of its pubkey.
+func (p *quicPeer) ID() ID {
+	return p.nodeInfo.ID()
+}
+
+// IsOutbound returns true if the connection is outbound, false otherwise.
+func (p *quicPeer) IsOutbound() bool {
+	return p.peerConn.outbound
+}
+
+// IsPersistent returns true if the peer is persitent, false otherwise.
+func (p *quicPeer) IsPersistent() bool {
+	return p.peerConn.persistent
+}
+
+// NodeInfo returns a copy of the peer's NodeInfo.
+func (p *quicPeer) NodeInfo() NodeInfo {
+	return p.nodeInfo
+}
+
+// SocketAddr returns the address of the socket.
+// For outbound peers, it's the address dialed (after DNS resolution).
+// For inbound peers, it's the address returned by the underlying connection
+// (not what's reported in the peer's NodeInfo).
+func (p *quicPeer) SocketAddr() *NetAddress {
+	return p.peerConn.socketAddr
+}
+
+// RemoteAddr returns peer's remote network address.
+func (p *quicPeer) RemoteAddr() net.Addr {
+	return p.conn.RemoteAddr()
+}
+
+// CloseConn closes the connection. Used for cleaning up in cases where the peer
+// had not been started at all.
+func (p *quicPeer) CloseConn() error {
+	return p.conn.CloseWithError(0, "")
+}
+
+// Status returns the peer's ConnectionStatus.
+func (p *quicPeer) Status() cmtconn.ConnectionStatus {
+	status := cmtconn.ConnectionStatus{
+		Duration:    time.Since(p.created),
+		SendMonitor: p.sendMonitor.Status(),
+		RecvMonitor: p.recvMonitor.Status(),
+		Channels:    make([]cmtconn.ChannelStatus, 0, len(p.chans)),
+	}
+	for id, ch := range p.chans {
+		status.Channels = append(status.Channels, cmtconn.ChannelStatus{
+			ID:                id,
+			SendQueueCapacity: cap(ch.sendQueue),
+			SendQueueSize:     len(ch.sendQueue),
+			SendQueueBytes:    int(atomic.LoadInt64(&ch.sendQueueBytes)),
+			NumDropped:        atomic.LoadInt64(&ch.numDropped),
+			Priority:          ch.desc.Priority,
+			RecentlySent:      atomic.LoadInt64(&ch.recentlySent),
+		})
+	}
+	return status
+}
+
+// Send msg bytes to the channel identified by chID byte. Returns false if the
+// send queue is full after a timeout, applying the drop policy of the channel
+// like MConnection.
+func (p *quicPeer) Send(e Envelope) bool {
+	return p.send(e.ChannelID, e.Message, quicSendTimeout)
+}
+
+// TrySend msg bytes to the channel identified by chID byte. Immediately returns
+// false if the send queue is full, unless the drop policy of the channel makes
+// room.
+func (p *quicPeer) TrySend(e Envelope) bool {
+	return p.send(e.ChannelID, e.Message, 0)
+}
+
+func (p *quicPeer) send(chID byte, msg proto.Message, timeout time.Duration) bool {
+	if !p.IsRunning() {
+		return false
+	} else if !p.hasChannel(chID) {
+		return false
+	}
+	ch, ok := p.chans[chID]
+	if !ok {
+		p.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	metricLabelValue := p.mlc.ValueToMetricLabel(msg)
+	if w, ok := msg.(Wrapper); ok {
+		msg = w.Wrap()
+	}
+	msgBytes, err := proto.Marshal(msg)
+	if err != nil {
+		p.Logger.Error("marshaling message to send", "error", err)
+		return false
+	}
+	res := ch.queue(msgBytes, timeout, p.Quit())
+	if !res && timeout > 0 && ch.desc.DropPolicy == cmtconn.Disconnect && p.IsRunning() {
+		p.metrics.SendQueueFullDisconnectsTotal.With("chID", fmt.Sprintf("%#x", chID)).Add(1)
+		// not to stop the peer from the goroutine of a reactor
+		go p.stopForError(cmtconn.ErrSendQueueFull{ChID: chID})
+	}
+	if res {
+		labels := []string{
+			"peer_id", string(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		}
+		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		p.metrics.MessageSendBytesTotal.With("message_type", metricLabelValue).Add(float64(len(msgBytes)))
+		p.metrics.PeerMessageSendBytesTotal.With(
+			"short_peer_id", shortPeerID(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+			"message_type", metricLabelValue,
+		).Add(float64(len(msgBytes)))
+	} else {
+		p.metrics.PeerDroppedEnvelopesTotal.With(
+			"short_peer_id", shortPeerID(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		).Add(1)
+	}
+	return res
+}
+
+// queue queues the message if there is room in the queue, dropping the oldest
+// messages to make room with the DropOldest policy, or else waiting up to the
+// timeout for room in the queue. It returns false if the message was not
+// queued.
+func (ch *quicChannel) queue(msgBytes []byte, timeout time.Duration, quit <-chan struct{}) bool {
+	n := int64(len(msgBytes))
+	// counted before queueing as the message may be written right away
+	atomic.AddInt64(&ch.sendQueueBytes, n)
+	for {
+		select {
+		case ch.sendQueue <- msgBytes:
+			return true
+		default:
+		}
+		if ch.desc.DropPolicy != cmtconn.DropOldest {
+			break
+		}
+		// if the queue was emptied meanwhile, there is room now
+		select {
+		case dropped := <-ch.sendQueue:
+			atomic.AddInt64(&ch.sendQueueBytes, -int64(len(dropped)))
+			atomic.AddInt64(&ch.numDropped, 1)
+		default:
+		}
+	}
+
+	if timeout > 0 {
+		select {
+		case ch.sendQueue <- msgBytes:
+			return true
+		case <-time.After(timeout):
+		case <-quit:
+		}
+	}
+	atomic.AddInt64(&ch.sendQueueBytes, -n)
+	return false
+}
+
+// Get the data for a given key.
+func (p *quicPeer) Get(key string) interface{} {
+	return p.Data.Get(key)
+}
+
+// Set sets the data for the given key.
+func (p *quicPeer) Set(key string, data interface{}) {
+	p.Data.Set(key, data)
+}
+
+// hasChannel returns true if the peer reported
+// knowing about the given chID.
+func (p *quicPeer) hasChannel(chID byte) bool {
+	for _, ch := range p.channels {
+		if ch == chID {
+			return true
+		}
+	}
+	p.Logger.Debug(
+		"Unknown channel for peer",
+		"channel",
+		chID,
+		"channels",
+		p.channels,
+	)
+	return false
+}
+
+func (p *quicPeer) SetRemovalFailed() {
+	p.removalAttemptFailed = true
+}
+
+func (p *quicPeer) GetRemovalFailed() bool {
+	return p.removalAttemptFailed
+}
+
+//---------------------------------------------------
+
+// sendRoutine writes the messages queued on the channel to its stream, which
+// is opened along with the first message.
+func (p *quicPeer) sendRoutine(ch *quicChannel) {
+	defer p.sendDone.Done()
+
+	var (
+		stream quic.SendStream
+		lenBuf [binary.MaxVarintLen64]byte
+	)
+	write := func(msgBytes []byte) error {
+		defer atomic.AddInt64(&ch.sendQueueBytes, -int64(len(msgBytes)))
+		if stream == nil {
+			var err error
+			stream, err = p.conn.OpenUniStreamSync(p.conn.Context())
+			if err != nil {
+				return err
+			}
+			if _, err := stream.Write([]byte{ch.desc.ID}); err != nil {
+				return err
+			}
+		}
+		n := binary.PutUvarint(lenBuf[:], uint64(len(msgBytes)))
+		if _, err := stream.Write(lenBuf[:n]); err != nil {
+			return err
+		}
+		if _, err := stream.Write(msgBytes); err != nil {
+			return err
+		}
+		p.sendMonitor.Update(n + len(msgBytes))
+		atomic.AddInt64(&ch.recentlySent, int64(n+len(msgBytes)))
+		return nil
+	}
+
+	for {
+		select {
+		case msgBytes := <-ch.sendQueue:
+			if err := write(msgBytes); err != nil {
+				p.stopForError(err)
+				return
+			}
+		case <-p.flushc:
+			// write the remaining messages and close the stream
+			for {
+				select {
+				case msgBytes := <-ch.sendQueue:
+					if err := write(msgBytes); err != nil {
+						return
+					}
+				default:
+					if stream != nil {
+						_ = stream.Close()
+					}
+					return
+				}
+			}
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// acceptRoutine accepts the streams opened by the peer, one per channel.
+func (p *quicPeer) acceptRoutine() {
+	for {
+		stream, err := p.conn.AcceptUniStream(p.conn.Context())
+		if err != nil {
+			p.stopForError(err)
+			return
+		}
+		go p.recvRoutine(stream)
+	}
+}
+
+// recvRoutine reads the messages of a channel from its stream and passes them
+// to the reactor of the channel.
+func (p *quicPeer) recvRoutine(stream quic.ReceiveStream) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.Logger.Error("Peer panicked", "err", r)
+			p.stopForError(r)
+		}
+	}()
+
+	var (
+		r      = bufio.NewReader(stream)
+		lenBuf [binary.MaxVarintLen64]byte
+	)
+	chID, err := r.ReadByte()
+	if err != nil {
+		p.stopForError(err)
+		return
+	}
+	ch, ok := p.chans[chID]
+	if !ok {
+		p.stopForError(fmt.Errorf("unknown channel %X", chID))
+		return
+	}
+
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			p.stopForError(err)
+			return
+		}
+		if recvCap := ch.desc.MaxRecvMessageSize(); size > uint64(recvCap) {
+			p.stopForError(fmt.Errorf("received message exceeds available capacity: %v < %v",
+				recvCap, size))
+			return
+		}
+		msgBytes := make([]byte, size)
+		if _, err := io.ReadFull(r, msgBytes); err != nil {
+			p.stopForError(err)
+			return
+		}
+		p.recvMonitor.Update(binary.PutUvarint(lenBuf[:], size) + len(msgBytes))
+		receiveEnvelope(p, chID, msgBytes, p.reactorsByCh, p.msgTypeByChID, p.metrics, p.mlc)
+	}
+}
+
+// stopForError reports the error to the switch, which stops the peer, unless
+// the peer is already stopping.
+func (p *quicPeer) stopForError(r interface{}) {
+	if !p.IsRunning() {
+		return
+	}
+	if err, ok := r.(error); ok && errors.Is(err, context.Canceled) {
+		return
+	}
+	p.errorOnce.Do(func() {
+		if p.onPeerError != nil {
+			p.onPeerError(p, r)
+		}
+	})
+}
+
+func (p *quicPeer) metricsReporter() {
+	// the number of messages dropped by channel, as last reported
+	dropped := make(map[byte]int64)
+	for {
+		select {
+		case <-p.metricsTicker.C:
+			var sendQueueSize float64
+			for id, ch := range p.chans {
+				if n := atomic.LoadInt64(&ch.numDropped) - dropped[id]; n > 0 {
+					p.metrics.ChannelSendQueueDropsTotal.With(
+						"short_peer_id", shortPeerID(p.ID()),
+						"chID", fmt.Sprintf("%#x", id),
+					).Add(float64(n))
+					dropped[id] += n
+				}
+				sendQueueSize += float64(len(ch.sendQueue))
+				p.metrics.ChannelSendQueueSize.With(
+					"peer_id", string(p.ID()),
+					"chID", fmt.Sprintf("%#x", id),
+				).Set(float64(len(ch.sendQueue)))
+				p.metrics.ChannelSendQueueBytes.With(
+					"short_peer_id", shortPeerID(p.ID()),
+					"chID", fmt.Sprintf("%#x", id),
+				).Set(float64(atomic.LoadInt64(&ch.sendQueueBytes)))
+				// decay the number of bytes recently sent
+				sent := atomic.LoadInt64(&ch.recentlySent)
+				atomic.StoreInt64(&ch.recentlySent, int64(float64(sent)*quicRecentlySentDecay))
+			}
+
+			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+		case <-p.Quit():
+			return
+		}
+	}
+}
@@ -64,6 +64,10 @@ type AddrBook interface {
 	MarkGood(p2p.ID)
 	MarkAttempt(*p2p.NetAddress)
 	MarkBad(*p2p.NetAddress, time.Duration) // Move peer to bad peers list
+	// Record the latency of a successful dial, and the last consensus height
+	// of the peer, in its reputation
+	MarkLatency(p2p.ID, time.Duration)
+	MarkHeight(p2p.ID, int64)
 	// Add bad peers back to addrBook
 	ReinstateBadPeers()
 
@@ -79,6 +83,12 @@ type AddrBook interface {
 
 	// Persist to disk
 	Save()
+	// Wait for the last save after the book is stopped
+	Wait()
+
+	// Export the addresses, and import exported ones
+	Export() *AddrBookExport
+	Import(*AddrBookExport) (int, error)
 }
 
 var _ AddrBook = (*addrBook)(nil)
@@ -270,6 +280,9 @@ func (a *addrBook) Empty() bool {
 // The address is picked randomly from an old or new bucket according
 // to the biasTowardsNewAddrs argument, which must be between [0, 100] (or else is truncated to that range)
 // and determines how biased we are to pick an address from a new bucket.
+// Within the bucket, the addresses are picked with a probability
+// proportional to their reputation, to bias dialing toward the historically
+// healthy peers.
 // PickAddress returns nil if the AddrBook is empty or if we try to pick
 // from an empty bucket.
 func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
@@ -309,15 +322,27 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 			bucket = a.bucketsNew[a.rand.Intn(len(a.bucketsNew))]
 		}
 	}
-	// pick a random index and loop over the map to return that index
-	randIndex := a.rand.Intn(len(bucket))
+	// pick a random address weighted by reputation, looping over the map
+	var (
+		now     = time.Now()
+		weights = make([]float64, 0, len(bucket))
+		addrs   = make([]*p2p.NetAddress, 0, len(bucket))
+		total   float64
+	)
 	for _, ka := range bucket {
-		if randIndex == 0 {
-			return ka.Addr
+		weight := ka.reputation(now)
+		weights = append(weights, weight)
+		addrs = append(addrs, ka.Addr)
+		total += weight
+	}
+	r := a.rand.Float64() * total
+	for i, weight := range weights {
+		if r < weight {
+			return addrs[i]
 		}
-		randIndex--
+		r -= weight
 	}
-	return nil
+	return addrs[len(addrs)-1]
 }
 
 // MarkGood implements AddrBook - it marks the peer as good and
@@ -350,6 +375,32 @@ func (a *addrBook) MarkAttempt(addr *p2p.NetAddress) {
 	ka.markAttempt()
 }
 
+// MarkLatency implements AddrBook - it records the latency of a successful
+// dial to the peer.
+func (a *addrBook) MarkLatency(id p2p.ID, latency time.Duration) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.markLatency(latency)
+}
+
+// MarkHeight implements AddrBook - it records the last consensus height of
+// the peer.
+func (a *addrBook) MarkHeight(id p2p.ID, height int64) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.markHeight(height)
+}
+
 // MarkBad implements AddrBook. Kicks address out from book, places
 // the address in the badPeers pool.
 func (a *addrBook) MarkBad(addr *p2p.NetAddress, banTime time.Duration) {
@@ -92,6 +92,105 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookSaveLoadReputation(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	addrSrc := randNetAddressPairs(t, 1)[0]
+	require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	book.MarkAttempt(addrSrc.addr)
+	book.MarkGood(addrSrc.addr.ID)
+	book.MarkLatency(addrSrc.addr.ID, 100*time.Millisecond)
+	book.MarkHeight(addrSrc.addr.ID, 10)
+	book.MarkHeight(addrSrc.addr.ID, 5)
+	book.Save()
+
+	data, err := os.ReadFile(fname)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), fmt.Sprintf(`"version": %d`, addrBookFormatVersion))
+
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	ka := book.(*addrBook).addrLookup[addrSrc.addr.ID]
+	require.NotNil(t, ka)
+	assert.InDelta(t, 1, ka.Successes, 0.01)
+	assert.InDelta(t, 1, ka.Failures, 0.01)
+	assert.Equal(t, 100*time.Millisecond, ka.LatencyEWMA)
+	assert.EqualValues(t, 10, ka.LastGoodHeight)
+
+	// the files of version 1 are loaded without reputation, and the newer
+	// ones are rejected
+	legacy := fmt.Sprintf(`{"key":"abc","addrs":[{"addr":{"id":"%s","ip":"%s","port":%d},`+
+		`"src":{"id":"%s","ip":"%s","port":%d},"buckets":[0],"bucket_type":1}]}`,
+		addrSrc.addr.ID, addrSrc.addr.IP, addrSrc.addr.Port, addrSrc.src.ID, addrSrc.src.IP, addrSrc.src.Port)
+	require.NoError(t, os.WriteFile(fname, []byte(legacy), 0o600))
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	ka = book.(*addrBook).addrLookup[addrSrc.addr.ID]
+	require.NotNil(t, ka)
+	assert.Zero(t, ka.Successes)
+	assert.InDelta(t, 0.5, ka.reputation(time.Now()), 0.001)
+
+	require.NoError(t, os.WriteFile(fname, []byte(`{"version":3,"key":"abc","addrs":[]}`), 0o600))
+	assert.Panics(t, func() {
+		book.(*addrBook).loadFromFile(fname)
+	})
+}
+
+func TestKnownAddressReputation(t *testing.T) {
+	now := time.Now()
+	ka := newKnownAddress(randIPv4Address(t), randIPv4Address(t))
+	assert.InDelta(t, 0.5, ka.reputation(now), 0.001)
+
+	for i := 0; i < 3; i++ {
+		ka.markGood()
+	}
+	ka.markAttempt()
+	assert.InDelta(t, 4.0/6, ka.reputation(now), 0.01)
+
+	// the counters are halved every half-life, so the reputation goes back
+	// toward 0.5
+	assert.InDelta(t, 2.5/4, ka.reputation(now.Add(reputationHalfLife)), 0.01)
+	assert.InDelta(t, 0.5, ka.reputation(now.Add(100*reputationHalfLife)), 0.001)
+
+	// a high latency lowers the reputation
+	ka.markLatency(reputationLatencyRef)
+	assert.InDelta(t, 2.0/6, ka.reputation(now), 0.01)
+	ka.markLatency(0)
+	assert.Equal(t, time.Duration(float64(reputationLatencyRef)*(1-latencyEWMAWeight)), ka.LatencyEWMA)
+}
+
+func TestAddrBookPickAddressReputation(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+
+	// two addresses in the same bucket, one of which failed many times
+	healthy := newKnownAddress(randIPv4Address(t), randIPv4Address(t))
+	unhealthy := newKnownAddress(randIPv4Address(t), randIPv4Address(t))
+	for i := 0; i < 20; i++ {
+		healthy.markGood()
+		unhealthy.markAttempt()
+	}
+	require.NoError(t, book.addToNewBucket(healthy, 0))
+	require.NoError(t, book.addToNewBucket(unhealthy, 0))
+
+	picked := make(map[p2p.ID]int)
+	for i := 0; i < 1000; i++ {
+		addr := book.PickAddress(100)
+		require.NotNil(t, addr)
+		picked[addr.ID]++
+	}
+	assert.Greater(t, picked[healthy.ID()], 900)
+	assert.Positive(t, picked[unhealthy.ID()])
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -643,6 +742,60 @@ func TestAddrBookAddDoesNotOverwriteOldIP(t *testing.T) {
 	}
 }
 
+func TestAddrBookExportImport(t *testing.T) {
+	book, fname := createAddrBookWithMOldAndNNewAddrs(t, 3, 5)
+	defer deleteTempFile(fname)
+
+	export := book.Export()
+	assert.Equal(t, AddrBookExportVersion, export.Version)
+	require.Len(t, export.Addrs, 8)
+	for i, entry := range export.Addrs {
+		// the good addresses score the highest and come first
+		assert.Equal(t, i < 3, entry.Good)
+		assert.Equal(t, book.IsGood(entry.Addr), entry.Good)
+		if entry.Good {
+			assert.Equal(t, 100, entry.Score)
+			assert.False(t, entry.LastSuccess.IsZero())
+		} else {
+			assert.Equal(t, 50, entry.Score)
+		}
+	}
+
+	fname2 := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname2)
+	book2 := NewAddrBook(fname2, true)
+	book2.SetLogger(log.TestingLogger())
+
+	n, err := book2.Import(export)
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, 8, book2.Size())
+	for _, entry := range export.Addrs {
+		assert.True(t, book2.HasAddress(entry.Addr))
+		assert.Equal(t, entry.Good, book2.IsGood(entry.Addr))
+	}
+	export2 := book2.Export()
+	assert.Equal(t, export.Addrs[0].LastSuccess.Unix(), export2.Addrs[0].LastSuccess.Unix())
+
+	// the known addresses are skipped
+	n, err = book2.Import(export)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// nothing is imported if an address is invalid
+	book3 := NewAddrBook(fname2, true)
+	book3.SetLogger(log.TestingLogger())
+	invalid, err := p2p.NewNetAddressString("b0dd378c3fbc4c156cd6d302a799f0d2e4227201@127.0.0.1:26656")
+	require.NoError(t, err)
+	export.Addrs = append(export.Addrs, AddrBookEntry{Addr: invalid})
+	_, err = book3.Import(export)
+	assert.Error(t, err)
+	assert.Zero(t, book3.Size())
+
+	_, err = book3.Import(&AddrBookExport{Version: AddrBookExportVersion + 1})
+	assert.Error(t, err)
+}
+
 func TestAddrBookGroupKey(t *testing.T) {
 	// non-strict routability
 	testCases := []struct {
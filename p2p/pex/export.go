@@ -0,0 +1,176 @@
+package pex
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cometbft/cometbft/p2p"
+)
+
+/* Exporting & Importing */
+
+// AddrBookExportVersion is the version of the format of the exported address
+// books, to be bumped on incompatible changes.
+const AddrBookExportVersion = 1
+
+// AddrBookExport is a portable copy of an address book, to be imported into
+// the address book of another node.
+type AddrBookExport struct {
+	Version int             `json:"version"`
+	Addrs   []AddrBookEntry `json:"addrs"`
+}
+
+// AddrBookEntry is an address exported from an address book, along with what
+// the book knows about it.
+type AddrBookEntry struct {
+	Addr *p2p.NetAddress `json:"addr"`
+	// Address the address was learned from, Addr itself if empty
+	Src *p2p.NetAddress `json:"src,omitempty"`
+	// Whether a connection to the address succeeded (the address is old)
+	Good bool `json:"good"`
+	// Viability of the address, from 0 (bad) to 100, see knownAddress.score
+	Score       int       `json:"score"`
+	Attempts    int32     `json:"attempts"`
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success"`
+}
+
+// Export implements AddrBook - it returns the addresses of the book, the best
+// scoring first.
+func (a *addrBook) Export() *AddrBookExport {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	addrs := make([]AddrBookEntry, 0, len(a.addrLookup))
+	for _, ka := range a.addrLookup {
+		addrs = append(addrs, AddrBookEntry{
+			Addr:        ka.Addr,
+			Src:         ka.Src,
+			Good:        ka.isOld(),
+			Score:       ka.score(),
+			Attempts:    ka.Attempts,
+			LastAttempt: ka.LastAttempt,
+			LastSuccess: ka.LastSuccess,
+		})
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		if addrs[i].Score != addrs[j].Score {
+			return addrs[i].Score > addrs[j].Score
+		}
+		return addrs[i].Addr.ID < addrs[j].Addr.ID
+	})
+
+	return &AddrBookExport{
+		Version: AddrBookExportVersion,
+		Addrs:   addrs,
+	}
+}
+
+// Import implements AddrBook - it adds the exported addresses unknown to the
+// book, the good ones to the old buckets, keeping their attempts and last
+// attempt (defaulting to now) and success times. The addresses, and the
+// buckets they go to, are all checked before the book is changed, so that
+// either all the addresses are imported or, if any is invalid, none is. As when
+// adding addresses, the imported ones may evict others from full buckets. It
+// returns the number of addresses added.
+func (a *addrBook) Import(export *AddrBookExport) (int, error) {
+	if export == nil {
+		return 0, errors.New("nil address book export")
+	}
+	if export.Version != AddrBookExportVersion {
+		return 0, fmt.Errorf("unsupported address book export version %d, expected %d",
+			export.Version, AddrBookExportVersion)
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	type importedAddr struct {
+		ka     *knownAddress
+		bucket int
+		good   bool
+	}
+	var (
+		imported = make([]importedAddr, 0, len(export.Addrs))
+		seen     = make(map[p2p.ID]struct{}, len(export.Addrs))
+	)
+	for i, entry := range export.Addrs {
+		src := entry.Src
+		if src == nil {
+			src = entry.Addr
+		}
+		if err := a.checkImportedAddress(entry.Addr, src); err != nil {
+			return 0, fmt.Errorf("address #%d: %w", i, err)
+		}
+		if _, ok := seen[entry.Addr.ID]; ok {
+			continue
+		}
+		seen[entry.Addr.ID] = struct{}{}
+		if _, ok := a.addrLookup[entry.Addr.ID]; ok {
+			continue
+		}
+
+		bucket, err := a.calcNewBucket(entry.Addr, src)
+		if err != nil {
+			return 0, fmt.Errorf("address #%d: %w", i, err)
+		}
+		if entry.Good {
+			// moveToOld can't fail past this check
+			if _, err := a.calcOldBucket(entry.Addr); err != nil {
+				return 0, fmt.Errorf("address #%d: %w", i, err)
+			}
+		}
+		ka := newKnownAddress(entry.Addr, src)
+		ka.Attempts = entry.Attempts
+		if !entry.LastAttempt.IsZero() {
+			ka.LastAttempt = entry.LastAttempt
+		}
+		ka.LastSuccess = entry.LastSuccess
+		imported = append(imported, importedAddr{ka: ka, bucket: bucket, good: entry.Good})
+	}
+
+	// Neither can fail for the new addresses, whose buckets were checked.
+	for _, addr := range imported {
+		if err := a.addToNewBucket(addr.ka, addr.bucket); err != nil {
+			a.Logger.Error("Error adding imported address to new bucket", "addr", addr.ka.Addr, "err", err)
+			continue
+		}
+		if addr.good {
+			if err := a.moveToOld(addr.ka); err != nil {
+				a.Logger.Error("Error moving imported address to old", "addr", addr.ka.Addr, "err", err)
+			}
+		}
+	}
+	a.Logger.Info("Imported addresses", "imported", len(imported), "total", len(export.Addrs))
+
+	return len(imported), nil
+}
+
+// checkImportedAddress returns an error if the address can't be added to the
+// book, like addAddress.
+func (a *addrBook) checkImportedAddress(addr, src *p2p.NetAddress) error {
+	if addr == nil {
+		return ErrAddrBookNilAddr{addr, src}
+	}
+	if err := addr.Valid(); err != nil {
+		return ErrAddrBookInvalidAddr{Addr: addr, AddrErr: err}
+	}
+	if _, ok := a.badPeers[addr.ID]; ok {
+		return ErrAddressBanned{addr}
+	}
+	if _, ok := a.privateIDs[addr.ID]; ok {
+		return ErrAddrBookPrivate{addr}
+	}
+	if _, ok := a.privateIDs[src.ID]; ok {
+		return ErrAddrBookPrivateSrc{src}
+	}
+	if _, ok := a.ourAddrs[addr.String()]; ok {
+		return ErrAddrBookSelf{addr}
+	}
+	if a.routabilityStrict && !addr.Routable() {
+		return ErrAddrBookNonRoutable{addr}
+	}
+	return nil
+}
@@ -10,9 +10,15 @@ import (
 
 /* Loading & Saving */
 
+// addrBookFormatVersion is the version of the format of the address book
+// file. The files without a version are of version 1, without the reputation
+// of the addresses, which starts from scratch when they are loaded.
+const addrBookFormatVersion = 2
+
 type addrBookJSON struct {
-	Key   string          `json:"key"`
-	Addrs []*knownAddress `json:"addrs"`
+	Version int             `json:"version"`
+	Key     string          `json:"key"`
+	Addrs   []*knownAddress `json:"addrs"`
 }
 
 func (a *addrBook) saveToFile(filePath string) {
@@ -26,8 +32,9 @@ func (a *addrBook) saveToFile(filePath string) {
 		addrs = append(addrs, ka)
 	}
 	aJSON := &addrBookJSON{
-		Key:   a.key,
-		Addrs: addrs,
+		Version: addrBookFormatVersion,
+		Key:     a.key,
+		Addrs:   addrs,
 	}
 
 	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
@@ -62,6 +69,10 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 	if err != nil {
 		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
 	}
+	if aJSON.Version > addrBookFormatVersion {
+		panic(fmt.Sprintf("Error reading file %s: unsupported version %d, expected at most %d",
+			filePath, aJSON.Version, addrBookFormatVersion))
+	}
 
 	// Restore all the fields...
 	// Restore the key
@@ -1,6 +1,7 @@
 package pex
 
 import (
+	"math"
 	"time"
 
 	"github.com/cometbft/cometbft/p2p"
@@ -17,6 +18,14 @@ type knownAddress struct {
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
 	LastBanTime time.Time       `json:"last_ban_time"`
+
+	// Reputation of the address, see reputation. The counters are decayed
+	// since LastDecay, with a half-life of reputationHalfLife.
+	Successes      float64       `json:"successes"`
+	Failures       float64       `json:"failures"`
+	LastDecay      time.Time     `json:"last_decay"`
+	LatencyEWMA    time.Duration `json:"latency_ewma"`     // of the successful dials
+	LastGoodHeight int64         `json:"last_good_height"` // last consensus height of the peer
 }
 
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
@@ -46,6 +55,8 @@ func (ka *knownAddress) markAttempt() {
 	now := time.Now()
 	ka.LastAttempt = now
 	ka.Attempts++
+	ka.decay(now)
+	ka.Failures++
 }
 
 func (ka *knownAddress) markGood() {
@@ -53,6 +64,52 @@ func (ka *knownAddress) markGood() {
 	ka.LastAttempt = now
 	ka.Attempts = 0
 	ka.LastSuccess = now
+	ka.decay(now)
+	ka.Successes++
+}
+
+func (ka *knownAddress) markLatency(latency time.Duration) {
+	if ka.LatencyEWMA == 0 {
+		ka.LatencyEWMA = latency
+		return
+	}
+	ka.LatencyEWMA = time.Duration(latencyEWMAWeight*float64(latency) +
+		(1-latencyEWMAWeight)*float64(ka.LatencyEWMA))
+}
+
+func (ka *knownAddress) markHeight(height int64) {
+	if height > ka.LastGoodHeight {
+		ka.LastGoodHeight = height
+	}
+}
+
+// decayFactor returns the factor by which the counters decayed since
+// LastDecay.
+func (ka *knownAddress) decayFactor(now time.Time) float64 {
+	if ka.LastDecay.IsZero() || !now.After(ka.LastDecay) {
+		return 1
+	}
+	return math.Pow(0.5, float64(now.Sub(ka.LastDecay))/float64(reputationHalfLife))
+}
+
+// decay applies the decay of the counters since LastDecay.
+func (ka *knownAddress) decay(now time.Time) {
+	f := ka.decayFactor(now)
+	ka.Successes *= f
+	ka.Failures *= f
+	ka.LastDecay = now
+}
+
+// reputation rates how healthy the peer at the address has been recently,
+// from 0 to 1: the ratio of the successes among the (decayed) successes and
+// failures, starting at 0.5 without any, lowered by a high dial latency.
+func (ka *knownAddress) reputation(now time.Time) float64 {
+	f := ka.decayFactor(now)
+	reputation := (f*ka.Successes + 1) / (f*(ka.Successes+ka.Failures) + 2)
+	if ka.LatencyEWMA > 0 {
+		reputation *= float64(reputationLatencyRef) / float64(reputationLatencyRef+ka.LatencyEWMA)
+	}
+	return reputation
 }
 
 func (ka *knownAddress) ban(banTime time.Duration) {
@@ -137,3 +194,21 @@ func (ka *knownAddress) isBad() bool {
 
 	return false
 }
+
+// score rates the viability of the address from 0 to 100: the addresses a
+// connection succeeded to (old) rank above the new ones, each failed attempt
+// since the last success lowers the score, and bad addresses score 0.
+func (ka *knownAddress) score() int {
+	if ka.isBad() {
+		return 0
+	}
+	score := 50
+	if ka.isOld() {
+		score = 100
+	}
+	score -= 10 * int(ka.Attempts)
+	if score < 1 {
+		score = 1
+	}
+	return score
+}
@@ -52,4 +52,14 @@ const (
 	// max addresses returned by GetSelection
 	// NOTE: this must match "maxMsgSize"
 	maxGetSelection = 250
+
+	// time after which the success and failure counters of an address are
+	// halved, so that its reputation reflects its recent behavior.
+	reputationHalfLife = 24 * time.Hour
+
+	// weight of a new dial latency in the latency EWMA of an address.
+	latencyEWMAWeight = 0.2
+
+	// dial latency at which the reputation of an address is halved.
+	reputationLatencyRef = time.Second
 )
@@ -13,6 +13,7 @@ import (
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/conn"
 	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
+	"github.com/cometbft/cometbft/types"
 )
 
 type Peer = p2p.Peer
@@ -95,7 +96,9 @@ type Reactor struct {
 	attemptsToDial sync.Map // address (string) -> {number of attempts (int), last time dialed (time.Time)}
 
 	// seed/crawled mode fields
+	crawlMtx       sync.Mutex // guards crawlPeerInfos and lastCrawl
 	crawlPeerInfos map[p2p.ID]crawlPeerInfo
+	lastCrawl      time.Time
 }
 
 func (r *Reactor) minReceiveRequestInterval() time.Duration {
@@ -120,6 +123,11 @@ type ReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// Maximum number of addresses of a network group (the /16 for IPv4, the
+	// /32 for IPv6) a seed hands out at once, so that the peers of the nodes
+	// are spread over networks. 0 means no limit.
+	MaxAddrsPerGroup int
 }
 
 type _attemptsToDial struct {
@@ -220,6 +228,22 @@ func (r *Reactor) RemovePeer(p Peer, reason interface{}) {
 	id := string(p.ID())
 	r.requestsSent.Delete(id)
 	r.lastReceivedRequests.Delete(id)
+	r.markPeerHeight(p)
+}
+
+// peerHeight is the state of a peer kept by the consensus reactor.
+type peerHeight interface {
+	GetHeight() int64
+}
+
+// markPeerHeight records the consensus height of the peer, if any, in the
+// address book.
+func (r *Reactor) markPeerHeight(p Peer) {
+	if ps, ok := p.Get(types.PeerStateKey).(peerHeight); ok {
+		if height := ps.GetHeight(); height > 0 {
+			r.book.MarkHeight(p.ID(), height)
+		}
+	}
 }
 
 func (r *Reactor) logErrAddrBook(err error) {
@@ -259,7 +283,11 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 			r.lastReceivedRequests.Set(id, time.Now())
 
 			// Send addrs and disconnect
-			r.SendAddrs(e.Src, r.book.GetSelectionWithBias(biasToSelectNewPeers))
+			addrs := r.book.GetSelectionWithBias(biasToSelectNewPeers)
+			if r.config.MaxAddrsPerGroup > 0 {
+				addrs = limitAddrsPerGroup(addrs, r.config.MaxAddrsPerGroup)
+			}
+			r.SendAddrs(e.Src, addrs)
 			go func() {
 				// In a go-routine so it doesn't block .Receive.
 				e.Src.FlushStop()
@@ -463,6 +491,10 @@ func (r *Reactor) ensurePeers() {
 		"numToDial", numToDial,
 	)
 
+	for _, peer := range r.Switch.Peers().List() {
+		r.markPeerHeight(peer)
+	}
+
 	if numToDial <= 0 {
 		return
 	}
@@ -561,6 +593,7 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 		}
 	}
 
+	start := time.Now()
 	err := r.Switch.DialPeerWithAddress(addr)
 	if err != nil {
 		if _, ok := err.(p2p.ErrCurrentlyDialingOrExistingAddress); ok {
@@ -578,6 +611,8 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 		return fmt.Errorf("dialing failed (attempts: %d): %w", attempts+1, err)
 	}
 
+	r.book.MarkLatency(addr.ID, time.Since(start))
+
 	// cleanup any history
 	r.attemptsToDial.Delete(addr.DialString())
 	return nil
@@ -691,15 +726,54 @@ type crawlPeerInfo struct {
 	LastCrawled time.Time `json:"last_crawled"`
 }
 
+// CrawlStatus is the progress of the crawling of the network by a seed.
+type CrawlStatus struct {
+	// Number of addresses in the address book
+	BookSize int `json:"book_size"`
+	// Number of peers connected at the moment
+	NumPeers int `json:"num_peers"`
+	// Number of addresses crawled in the last 24 hours
+	NumCrawled int `json:"num_crawled"`
+	// Number of network groups (the /16 for IPv4, the /32 for IPv6) of the
+	// addresses crawled
+	NumCrawledGroups int `json:"num_crawled_groups"`
+	// The last time the crawler ran, zero if it didn't yet
+	LastCrawl time.Time `json:"last_crawl"`
+}
+
+// CrawlStatus returns the progress of the crawling, when in seed mode.
+func (r *Reactor) CrawlStatus() CrawlStatus {
+	r.crawlMtx.Lock()
+	defer r.crawlMtx.Unlock()
+
+	groups := make(map[string]struct{})
+	for _, info := range r.crawlPeerInfos {
+		groups[groupKeyFor(info.Addr, false)] = struct{}{}
+	}
+	return CrawlStatus{
+		BookSize:         r.book.Size(),
+		NumPeers:         r.Switch.Peers().Size(),
+		NumCrawled:       len(r.crawlPeerInfos),
+		NumCrawledGroups: len(groups),
+		LastCrawl:        r.lastCrawl,
+	}
+}
+
 // crawlPeers will crawl the network looking for new peer addresses.
 func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 	now := time.Now()
 
+	r.crawlMtx.Lock()
+	r.lastCrawl = now
+	r.crawlMtx.Unlock()
+
 	for _, addr := range addrs {
+		r.crawlMtx.Lock()
 		peerInfo, ok := r.crawlPeerInfos[addr.ID]
 
 		// Do not attempt to connect with peers we recently crawled.
 		if ok && now.Sub(peerInfo.LastCrawled) < minTimeBetweenCrawls {
+			r.crawlMtx.Unlock()
 			continue
 		}
 
@@ -708,6 +782,7 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 			Addr:        addr,
 			LastCrawled: now,
 		}
+		r.crawlMtx.Unlock()
 
 		err := r.dialPeer(addr)
 		if err != nil {
@@ -728,6 +803,9 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 }
 
 func (r *Reactor) cleanupCrawlPeerInfos() {
+	r.crawlMtx.Lock()
+	defer r.crawlMtx.Unlock()
+
 	for id, info := range r.crawlPeerInfos {
 		// If we did not crawl a peer for 24 hours, it means the peer was removed
 		// from the addrbook => remove
@@ -741,6 +819,24 @@ func (r *Reactor) cleanupCrawlPeerInfos() {
 	}
 }
 
+// limitAddrsPerGroup returns the addresses, keeping at most max of each
+// network group.
+func limitAddrsPerGroup(addrs []*p2p.NetAddress, max int) []*p2p.NetAddress {
+	var (
+		limited  = make([]*p2p.NetAddress, 0, len(addrs))
+		perGroup = make(map[string]int)
+	)
+	for _, addr := range addrs {
+		group := groupKeyFor(addr, false)
+		if perGroup[group] >= max {
+			continue
+		}
+		perGroup[group]++
+		limited = append(limited, addr)
+	}
+	return limited
+}
+
 // attemptDisconnects checks if we've been with each peer long enough to disconnect
 func (r *Reactor) attemptDisconnects() {
 	for _, peer := range r.Switch.Peers().List() {
@@ -3,6 +3,7 @@ package pex
 import (
 	"encoding/hex"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -330,6 +331,48 @@ func TestPEXReactorSeedMode(t *testing.T) {
 	assert.Equal(t, 0, sw.Peers().Size())
 }
 
+func TestPEXReactorCrawlStatus(t *testing.T) {
+	// directory to store address books
+	dir, err := os.MkdirTemp("", "pex_reactor")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	pexR, book := createReactor(&ReactorConfig{SeedMode: true})
+	defer teardownReactor(book)
+
+	sw := createSwitchAndAddReactors(pexR)
+	sw.SetAddrBook(book)
+	require.NoError(t, sw.Start())
+	defer sw.Stop() //nolint:errcheck // ignore for tests
+
+	assert.Equal(t, CrawlStatus{}, pexR.CrawlStatus())
+
+	peerSwitch := testCreateDefaultPeer(dir, 1)
+	require.NoError(t, peerSwitch.Start())
+	defer peerSwitch.Stop() //nolint:errcheck // ignore for tests
+
+	pexR.crawlPeers([]*p2p.NetAddress{peerSwitch.NetAddress()})
+	status := pexR.CrawlStatus()
+	assert.Equal(t, 1, status.NumPeers)
+	assert.Equal(t, 1, status.NumCrawled)
+	assert.Equal(t, 1, status.NumCrawledGroups)
+	assert.False(t, status.LastCrawl.IsZero())
+}
+
+func TestLimitAddrsPerGroup(t *testing.T) {
+	addrs := []*p2p.NetAddress{
+		p2p.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 26656),
+		p2p.NewNetAddressIPPort(net.ParseIP("1.2.5.6"), 26656),
+		p2p.NewNetAddressIPPort(net.ParseIP("1.2.7.8"), 26656),
+		p2p.NewNetAddressIPPort(net.ParseIP("5.6.7.8"), 26656),
+		p2p.NewNetAddressIPPort(net.ParseIP("2001:db8::1"), 26656),
+		p2p.NewNetAddressIPPort(net.ParseIP("2001:db8:1::1"), 26656),
+	}
+	assert.Equal(t, []*p2p.NetAddress{addrs[0], addrs[1], addrs[3], addrs[4], addrs[5]}, limitAddrsPerGroup(addrs, 2))
+	assert.Equal(t, []*p2p.NetAddress{addrs[0], addrs[3], addrs[4]}, limitAddrsPerGroup(addrs, 1))
+	assert.Equal(t, addrs, limitAddrsPerGroup(addrs, 3))
+}
+
 func TestPEXReactorDoesNotDisconnectFromPersistentPeerInSeedMode(t *testing.T) {
 	// directory to store address books
 	dir, err := os.MkdirTemp("", "pex_reactor")
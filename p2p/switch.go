@@ -39,6 +39,9 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.SendRate = cfg.SendRate
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
+	// the weights are checked by the config's ValidateBasic
+	mConfig.ChannelWeights, _ = cfg.ChannelWeightsMap()
+	mConfig.MaxSendQueueBytes = cfg.MaxSendQueueBytes
 	return mConfig
 }
 
@@ -61,6 +64,21 @@ type AddrBook interface {
 // fully setup.
 type PeerFilterFunc func(IPeerSet, Peer) error
 
+// PeerIDFilter rejects the peers whose ID isn't one of the given ones, e.g.
+// on a validator only connected to its sentry nodes.
+func PeerIDFilter(ids []string) PeerFilterFunc {
+	allowed := make(map[ID]struct{}, len(ids))
+	for _, id := range ids {
+		allowed[ID(id)] = struct{}{}
+	}
+	return func(_ IPeerSet, p Peer) error {
+		if _, ok := allowed[p.ID()]; !ok {
+			return fmt.Errorf("peer %v isn't allowed", p.ID())
+		}
+		return nil
+	}
+}
+
 //-----------------------------------------------------------------------------
 
 // Switch handles peer connections and exposes an API to receive incoming messages
@@ -92,6 +110,8 @@ type Switch struct {
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
+	outboundPeers *outboundPeersController // nil for a fixed number
+
 	metrics *Metrics
 	mlc     *metricsLabelCache
 }
@@ -132,6 +152,8 @@ func NewSwitch(
 	// Ensure we have a completely undeterministic PRNG.
 	sw.rng = rand.NewRand()
 
+	sw.outboundPeers = newOutboundPeersController(cfg)
+
 	sw.BaseService = *service.NewBaseService(nil, "P2P Switch", sw)
 
 	for _, option := range options {
@@ -240,6 +262,10 @@ func (sw *Switch) OnStart() error {
 	// Start accepting Peers.
 	go sw.acceptRoutine()
 
+	if sw.outboundPeers != nil {
+		go sw.outboundPeersRoutine()
+	}
+
 	return nil
 }
 
@@ -316,8 +342,12 @@ func (sw *Switch) IsPeerUnconditional(id ID) bool {
 	return ok
 }
 
-// MaxNumOutboundPeers returns a maximum number of outbound peers.
+// MaxNumOutboundPeers returns a maximum number of outbound peers: the target
+// number of outbound peers if it's adjusted, see min_num_outbound_peers.
 func (sw *Switch) MaxNumOutboundPeers() int {
+	if sw.outboundPeers != nil {
+		return sw.outboundPeers.Target()
+	}
 	return sw.config.MaxNumOutboundPeers
 }
 
@@ -280,6 +280,13 @@ func TestSwitchPeerFilter(t *testing.T) {
 	}
 }
 
+func TestPeerIDFilter(t *testing.T) {
+	allowed, other := newMockPeer(nil), newMockPeer(nil)
+	filter := PeerIDFilter([]string{string(allowed.ID())})
+	assert.NoError(t, filter(NewPeerSet(), allowed))
+	assert.Error(t, filter(NewPeerSet(), other))
+}
+
 func TestSwitchPeerFilterTimeout(t *testing.T) {
 	var (
 		filters = []PeerFilterFunc{
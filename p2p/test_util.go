@@ -7,6 +7,7 @@ import (
 
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/cmap"
 	"github.com/cometbft/cometbft/libs/log"
 	cmtnet "github.com/cometbft/cometbft/libs/net"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
@@ -42,6 +43,7 @@ func CreateRandomPeer(outbound bool) Peer {
 		nodeInfo: mockNodeInfo{netAddr},
 		mconn:    &conn.MConnection{},
 		metrics:  NopMetrics(),
+		Data:     cmap.NewCMap(),
 	}
 	p.SetLogger(log.TestingLogger().With("peer", addr))
 	return p
@@ -209,6 +211,9 @@ func MakeSwitch(
 	for ch := range sw.reactorsByCh {
 		ni.Channels = append(ni.Channels, ch)
 	}
+	for _, chDesc := range sw.chDescs {
+		ni.SetChannelVersion(chDesc.ID, chDesc.Version)
+	}
 	nodeInfo = ni
 
 	// TODO: We need to setup reactors ahead of time so the NodeInfo is properly
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"time"
@@ -134,6 +135,12 @@ func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.maxIncomingConnections = n }
 }
 
+// MultiplexTransportMemoryNetwork makes the transport listen and dial on the
+// in-memory network instead of TCP, to run several nodes in the same process.
+func MultiplexTransportMemoryNetwork(network *MemoryNetwork) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.memoryNetwork = network }
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
 // multiplexed peers.
 type MultiplexTransport struct {
@@ -155,6 +162,15 @@ type MultiplexTransport struct {
 	nodeKey          NodeKey
 	resolver         IPResolver
 
+	// mutual TLS config and IDs of the peers required to use it, see
+	// MultiplexTransportTLS
+	tlsConfig  *tls.Config
+	tlsPeerIDs map[ID]struct{}
+
+	// in-memory network used instead of TCP, if not nil, see
+	// MultiplexTransportMemoryNetwork
+	memoryNetwork *MemoryNetwork
+
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
 	// with sane defaults.
@@ -213,7 +229,15 @@ func (mt *MultiplexTransport) Dial(
 	addr NetAddress,
 	cfg peerConfig,
 ) (Peer, error) {
-	c, err := addr.DialTimeout(mt.dialTimeout)
+	var (
+		c   net.Conn
+		err error
+	)
+	if mt.memoryNetwork != nil {
+		c, err = mt.memoryNetwork.Dial(mt.netAddr.IP, addr, mt.dialTimeout)
+	} else {
+		c, err = addr.DialTimeout(mt.dialTimeout)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +272,15 @@ func (mt *MultiplexTransport) Close() error {
 
 // Listen implements transportLifecycle.
 func (mt *MultiplexTransport) Listen(addr NetAddress) error {
-	ln, err := net.Listen("tcp", addr.DialString())
+	var (
+		ln  net.Listener
+		err error
+	)
+	if mt.memoryNetwork != nil {
+		ln, err = mt.memoryNetwork.Listen(addr)
+	} else {
+		ln, err = net.Listen("tcp", addr.DialString())
+	}
 	if err != nil {
 		return err
 	}
@@ -278,6 +310,16 @@ func (mt *MultiplexTransport) AddChannel(chID byte) {
 	}
 }
 
+// SetChannelVersion advertises the highest version of the messages of a
+// channel supported by the node, in the nodeInfo.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else it won't be updated
+func (mt *MultiplexTransport) SetChannelVersion(chID byte, version uint32) {
+	if ni, ok := mt.nodeInfo.(DefaultNodeInfo); ok {
+		ni.SetChannelVersion(chID, version)
+		mt.nodeInfo = ni
+	}
+}
+
 func (mt *MultiplexTransport) acceptPeers() {
 	for {
 		c, err := mt.listener.Accept()
@@ -413,7 +455,16 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	sc, tlsConn, err := mt.upgradeTLS(c, dialedAddr)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("tls handshake failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	secretConn, err = upgradeSecretConn(sc, mt.handshakeTimeout, mt.nodeKey.PrivKey)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -439,6 +490,15 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	if err := mt.verifyTLS(tlsConn, connID); err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			id:            connID,
+			err:           err,
+			isAuthFailure: true,
+		}
+	}
+
 	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
@@ -448,8 +508,18 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	if err := verifyNodeInfo(c, connID, nodeInfo, mt.nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return secretConn, nodeInfo, nil
+}
+
+// verifyNodeInfo checks the NodeInfo received during the handshake on the
+// connection authenticated with connID.
+func verifyNodeInfo(c net.Conn, connID ID, nodeInfo, ourNodeInfo NodeInfo) error {
 	if err := nodeInfo.Validate(); err != nil {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn:              c,
 			err:               err,
 			isNodeInfoInvalid: true,
@@ -458,7 +528,7 @@ func (mt *MultiplexTransport) upgrade(
 
 	// Ensure connection key matches self reported key.
 	if connID != nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn: c,
 			id:   connID,
 			err: fmt.Errorf(
@@ -471,17 +541,17 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
-		return nil, nil, ErrRejected{
-			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
+	if ourNodeInfo.ID() == nodeInfo.ID() {
+		return ErrRejected{
+			addr:   *newNetAddressFromConn(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
 			id:     nodeInfo.ID(),
 			isSelf: true,
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, nil, ErrRejected{
+	if err := ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
+		return ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
@@ -489,7 +559,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	return secretConn, nodeInfo, nil
+	return nil
 }
 
 func (mt *MultiplexTransport) wrapPeer(
@@ -0,0 +1,132 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// memoryEphemeralPorts is the first port given to the dialing end of the
+// connections of a MemoryNetwork, like the ephemeral ports of TCP.
+const memoryEphemeralPorts = 32768
+
+// MemoryNetwork is an in-memory network, on which the transports of the nodes
+// running in the same process listen and dial each other instead of using TCP,
+// see MultiplexTransportMemoryNetwork. The connections are synchronous pipes,
+// with the addresses of the listening and dialing transports, so that the
+// transports filter and identify them like TCP connections.
+type MemoryNetwork struct {
+	mtx       cmtsync.Mutex
+	listeners map[string]*memoryListener
+	nextPort  int
+}
+
+// NewMemoryNetwork returns an empty in-memory network.
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{
+		listeners: make(map[string]*memoryListener),
+		nextPort:  memoryEphemeralPorts,
+	}
+}
+
+// Listen returns a listener accepting the connections dialed to the address
+// on the network.
+func (mn *MemoryNetwork) Listen(addr NetAddress) (net.Listener, error) {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+
+	key := addr.DialString()
+	if _, ok := mn.listeners[key]; ok {
+		return nil, fmt.Errorf("address %s already in use on the memory network", key)
+	}
+	ln := &memoryListener{
+		network: mn,
+		key:     key,
+		addr:    &net.TCPAddr{IP: addr.IP, Port: int(addr.Port)},
+		connc:   make(chan net.Conn),
+		closec:  make(chan struct{}),
+	}
+	mn.listeners[key] = ln
+	return ln, nil
+}
+
+// Dial connects to the listener of the address on the network, from the given
+// IP, waiting at most timeout for the listener to accept the connection.
+func (mn *MemoryNetwork) Dial(from net.IP, addr NetAddress, timeout time.Duration) (net.Conn, error) {
+	mn.mtx.Lock()
+	ln, ok := mn.listeners[addr.DialString()]
+	port := mn.nextPort
+	mn.nextPort++
+	mn.mtx.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("dial %s: connection refused", addr.DialString())
+	}
+
+	localAddr := &net.TCPAddr{IP: from, Port: port}
+	c1, c2 := net.Pipe()
+	select {
+	case ln.connc <- &memoryConn{Conn: c2, local: ln.addr, remote: localAddr}:
+		return &memoryConn{Conn: c1, local: localAddr, remote: ln.addr}, nil
+	case <-ln.closec:
+		return nil, fmt.Errorf("dial %s: connection refused", addr.DialString())
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("dial %s: i/o timeout", addr.DialString())
+	}
+}
+
+func (mn *MemoryNetwork) removeListener(ln *memoryListener) {
+	mn.mtx.Lock()
+	defer mn.mtx.Unlock()
+
+	if mn.listeners[ln.key] == ln {
+		delete(mn.listeners, ln.key)
+	}
+}
+
+// memoryListener implements net.Listener for MemoryNetwork.
+type memoryListener struct {
+	network   *MemoryNetwork
+	key       string // address on the network
+	addr      *net.TCPAddr
+	connc     chan net.Conn
+	closec    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*memoryListener)(nil)
+
+func (ln *memoryListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-ln.connc:
+		return c, nil
+	case <-ln.closec:
+		return nil, net.ErrClosed
+	}
+}
+
+func (ln *memoryListener) Close() error {
+	err := net.ErrClosed
+	ln.closeOnce.Do(func() {
+		close(ln.closec)
+		ln.network.removeListener(ln)
+		err = nil
+	})
+	return err
+}
+
+func (ln *memoryListener) Addr() net.Addr {
+	return ln.addr
+}
+
+// memoryConn is a net.Pipe connection with the addresses of its ends on the
+// MemoryNetwork.
+type memoryConn struct {
+	net.Conn
+	local, remote net.Addr
+}
+
+func (c *memoryConn) LocalAddr() net.Addr  { return c.local }
+func (c *memoryConn) RemoteAddr() net.Addr { return c.remote }
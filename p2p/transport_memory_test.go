@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func testSetupMemoryTransport(t *testing.T, network *MemoryNetwork, name, addr string) *MultiplexTransport {
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	mt := newMultiplexTransport(testNodeInfo(id, name), NodeKey{PrivKey: pv})
+	MultiplexTransportMemoryNetwork(network)(mt)
+
+	netAddr, err := NewNetAddressString(IDAddressString(id, addr))
+	require.NoError(t, err)
+	require.NoError(t, mt.Listen(*netAddr))
+	t.Cleanup(func() { _ = mt.Close() })
+	return mt
+}
+
+func TestTransportMemoryNetwork(t *testing.T) {
+	network := NewMemoryNetwork()
+	listener := testSetupMemoryTransport(t, network, "listener", "10.0.0.1:26656")
+	dialer := testSetupMemoryTransport(t, network, "dialer", "10.0.0.2:26656")
+
+	type acceptResult struct {
+		p   Peer
+		err error
+	}
+	acceptc := make(chan acceptResult)
+	go func() {
+		p, err := listener.Accept(peerConfig{})
+		acceptc <- acceptResult{p, err}
+	}()
+
+	p, err := dialer.Dial(listener.NetAddress(), peerConfig{})
+	require.NoError(t, err)
+	defer dialer.Cleanup(p)
+	assert.Equal(t, listener.nodeKey.ID(), p.ID())
+	assert.Equal(t, "10.0.0.1:26656", p.RemoteAddr().String())
+
+	res := <-acceptc
+	require.NoError(t, res.err)
+	defer listener.Cleanup(res.p)
+	assert.Equal(t, dialer.nodeKey.ID(), res.p.ID())
+	assert.Equal(t, "10.0.0.2", res.p.RemoteIP().String())
+
+	// The address isn't on the network anymore once the listener is closed.
+	require.NoError(t, listener.listener.Close())
+	_, err = network.Dial(nil, listener.NetAddress(), time.Second)
+	assert.Error(t, err)
+}
+
+func TestTransportMemoryNetworkDialUnknownAddress(t *testing.T) {
+	network := NewMemoryNetwork()
+	dialer := testSetupMemoryTransport(t, network, "dialer", "10.0.0.2:26656")
+
+	addr, err := NewNetAddressString(IDAddressString(PubKeyToID(ed25519.GenPrivKey().PubKey()), "10.0.0.3:26656"))
+	require.NoError(t, err)
+	_, err = dialer.Dial(*addr, peerConfig{})
+	assert.Error(t, err)
+
+	// The address of a listener can't be reused.
+	_, err = network.Listen(dialer.NetAddress())
+	assert.Error(t, err)
+}
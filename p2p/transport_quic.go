@@ -0,0 +1,612 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+const (
+	// quicALPN is the application protocol negotiated by the QUIC connections.
+	quicALPN = "cometbft-p2p"
+
+	defaultQUICKeepAlivePeriod  = 15 * time.Second
+	defaultQUICSessionCacheSize = 1024
+
+	// maxQUICStreams is the maximum number of streams a peer can open, one per
+	// channel.
+	maxQUICStreams = 256
+)
+
+// quicAccept is the container to carry the upgraded connection and NodeInfo
+// from an asynchronously running routine to the Accept method.
+type quicAccept struct {
+	netAddr  *NetAddress
+	conn     *quicConn
+	nodeInfo NodeInfo
+	err      error
+}
+
+// QUICTransportOption sets an optional parameter on the QUICTransport.
+type QUICTransportOption func(*QUICTransport)
+
+// QUICTransportConnFilters sets the filters for rejection new connections.
+func QUICTransportConnFilters(filters ...ConnFilterFunc) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.connFilters = filters }
+}
+
+// QUICTransportMaxIncomingConnections sets the maximum number of
+// simultaneous connections (incoming). Default: 0 (unlimited)
+func QUICTransportMaxIncomingConnections(n int) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.maxIncomingConnections = n }
+}
+
+// QUICTransport accepts and dials QUIC connections and upgrades them to peers
+// whose channels are each mapped to their own stream, so that the messages of
+// a channel are not delayed by the packet losses of the others.
+//
+// The connections are encrypted with TLS 1.3, using a certificate signed with
+// the node key, and reconnections to known peers use 0-RTT session resumption.
+type QUICTransport struct {
+	netAddr                NetAddress
+	transport              *quic.Transport
+	listener               *quic.EarlyListener
+	maxIncomingConnections int // see MaxIncomingConnections
+	numIncomingConnections int32
+
+	acceptc chan quicAccept
+	closec  chan struct{}
+
+	// Lookup table for duplicate ip and id checks.
+	conns       ConnSet
+	connFilters []ConnFilterFunc
+
+	dialTimeout      time.Duration
+	filterTimeout    time.Duration
+	handshakeTimeout time.Duration
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	resolver         IPResolver
+
+	tlsConfig    *tls.Config
+	sessionCache tls.ClientSessionCache
+	quicConfig   *quic.Config
+}
+
+// Test QUICTransport for interface completeness.
+var _ Transport = (*QUICTransport)(nil)
+var _ transportLifecycle = (*QUICTransport)(nil)
+
+// NewQUICTransport returns a QUIC transport. The node key must be an ed25519
+// key, as it signs the certificate of the node.
+func NewQUICTransport(
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	options ...QUICTransportOption,
+) (*QUICTransport, error) {
+	tlsConfig, err := quicTLSConfig(nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	qt := &QUICTransport{
+		acceptc:          make(chan quicAccept),
+		closec:           make(chan struct{}),
+		dialTimeout:      defaultDialTimeout,
+		filterTimeout:    defaultFilterTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		conns:            NewConnSet(),
+		resolver:         net.DefaultResolver,
+		tlsConfig:        tlsConfig,
+		sessionCache:     tls.NewLRUClientSessionCache(defaultQUICSessionCacheSize),
+		quicConfig: &quic.Config{
+			HandshakeIdleTimeout:  defaultHandshakeTimeout,
+			KeepAlivePeriod:       defaultQUICKeepAlivePeriod,
+			MaxIncomingStreams:    1, // the control stream
+			MaxIncomingUniStreams: maxQUICStreams,
+			Allow0RTT:             true,
+		},
+	}
+	for _, option := range options {
+		option(qt)
+	}
+
+	return qt, nil
+}
+
+// NetAddress implements Transport.
+func (qt *QUICTransport) NetAddress() NetAddress {
+	return qt.netAddr
+}
+
+// Accept implements Transport.
+func (qt *QUICTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	// This case should never have any side-effectful/blocking operations to
+	// ensure that quality peers are ready to be used.
+	case a := <-qt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return qt.wrapPeer(a.conn, a.nodeInfo, cfg, a.netAddr), nil
+	case <-qt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (qt *QUICTransport) Dial(
+	addr NetAddress,
+	cfg peerConfig,
+) (Peer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), qt.dialTimeout)
+	defer cancel()
+
+	udpAddr := &net.UDPAddr{IP: addr.IP, Port: int(addr.Port)}
+	tlsConfig := qt.tlsConfig.Clone()
+	// the sessions used for 0-RTT are cached by server name
+	tlsConfig.ServerName = string(addr.ID)
+	tlsConfig.ClientSessionCache = qt.sessionCache
+
+	var (
+		conn quic.EarlyConnection
+		err  error
+	)
+	if qt.transport != nil {
+		// dial from the port we listen on
+		conn, err = qt.transport.DialEarly(ctx, udpAddr, tlsConfig, qt.quicConfig)
+	} else {
+		conn, err = quic.DialAddrEarly(ctx, udpAddr.String(), tlsConfig, qt.quicConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &quicConn{conn: conn}
+
+	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
+	if err := qt.filterConn(c); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := qt.upgrade(c, &addr)
+	if errors.Is(err, quic.Err0RTTRejected) {
+		// The peer didn't accept to resume the session, so the handshake has to
+		// be done again on the connection, after the TLS handshake.
+		c.conn = conn.NextConnection()
+		nodeInfo, err = qt.upgrade(c, &addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return qt.wrapPeer(c, nodeInfo, cfg, &addr), nil
+}
+
+// Close implements transportLifecycle.
+func (qt *QUICTransport) Close() error {
+	close(qt.closec)
+
+	if qt.listener != nil {
+		if err := qt.listener.Close(); err != nil {
+			return err
+		}
+	}
+	if qt.transport != nil {
+		return qt.transport.Close()
+	}
+
+	return nil
+}
+
+// Listen implements transportLifecycle. It listens for QUIC connections on
+// UDP at the port of the address.
+func (qt *QUICTransport) Listen(addr NetAddress) error {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr.DialString())
+	if err != nil {
+		return err
+	}
+	udpConn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return err
+	}
+
+	transport := &quic.Transport{Conn: udpConn}
+	ln, err := transport.ListenEarly(qt.tlsConfig, qt.quicConfig)
+	if err != nil {
+		_ = udpConn.Close()
+		return err
+	}
+
+	qt.netAddr = addr
+	qt.transport = transport
+	qt.listener = ln
+
+	go qt.acceptPeers()
+
+	return nil
+}
+
+// AddChannel registers a channel to nodeInfo.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else channels won't be updated
+func (qt *QUICTransport) AddChannel(chID byte) {
+	if ni, ok := qt.nodeInfo.(DefaultNodeInfo); ok {
+		if !ni.HasChannel(chID) {
+			ni.Channels = append(ni.Channels, chID)
+		}
+		qt.nodeInfo = ni
+	}
+}
+
+// SetChannelVersion advertises the highest version of the messages of a
+// channel supported by the node, in the nodeInfo.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else it won't be updated
+func (qt *QUICTransport) SetChannelVersion(chID byte, version uint32) {
+	if ni, ok := qt.nodeInfo.(DefaultNodeInfo); ok {
+		ni.SetChannelVersion(chID, version)
+		qt.nodeInfo = ni
+	}
+}
+
+func (qt *QUICTransport) acceptPeers() {
+	for {
+		conn, err := qt.listener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case _, ok := <-qt.closec:
+				if !ok {
+					return
+				}
+			default:
+				// Transport is not closed
+			}
+
+			qt.acceptc <- quicAccept{err: err}
+			return
+		}
+
+		if qt.maxIncomingConnections > 0 &&
+			atomic.LoadInt32(&qt.numIncomingConnections) >= int32(qt.maxIncomingConnections) {
+			_ = conn.CloseWithError(0, "too many connections")
+			continue
+		}
+		atomic.AddInt32(&qt.numIncomingConnections, 1)
+		go func() {
+			<-conn.Context().Done()
+			atomic.AddInt32(&qt.numIncomingConnections, -1)
+		}()
+
+		// Connection upgrade and filtering should be asynchronous to avoid
+		// Head-of-line blocking.
+		go func(c *quicConn) {
+			defer func() {
+				if r := recover(); r != nil {
+					err := ErrRejected{
+						conn:          c,
+						err:           fmt.Errorf("recovered from panic: %v", r),
+						isAuthFailure: true,
+					}
+					select {
+					case qt.acceptc <- quicAccept{err: err}:
+					case <-qt.closec:
+						// Give up if the transport was closed.
+						_ = c.Close()
+						return
+					}
+				}
+			}()
+
+			var (
+				nodeInfo NodeInfo
+				netAddr  *NetAddress
+			)
+
+			err := qt.filterConn(c)
+			if err == nil {
+				nodeInfo, err = qt.upgrade(c, nil)
+				if err == nil {
+					netAddr = newNetAddressFromConn(nodeInfo.ID(), c.RemoteAddr())
+				}
+			}
+
+			select {
+			case qt.acceptc <- quicAccept{netAddr, c, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-qt.closec:
+				// Give up if the transport was closed.
+				_ = c.Close()
+				return
+			}
+		}(&quicConn{conn: conn})
+	}
+}
+
+// Cleanup removes the given address from the connections set and
+// closes the connection.
+func (qt *QUICTransport) Cleanup(p Peer) {
+	qt.conns.RemoveAddr(p.RemoteAddr())
+	_ = p.CloseConn()
+}
+
+func (qt *QUICTransport) cleanup(c net.Conn) error {
+	qt.conns.Remove(c)
+
+	return c.Close()
+}
+
+func (qt *QUICTransport) filterConn(c net.Conn) (err error) {
+	defer func() {
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
+
+	// Reject if connection is already present.
+	if qt.conns.Has(c) {
+		return ErrRejected{conn: c, isDuplicate: true}
+	}
+
+	// Resolve ips for incoming conn.
+	ips, err := resolveIPs(qt.resolver, c)
+	if err != nil {
+		return err
+	}
+
+	errc := make(chan error, len(qt.connFilters))
+
+	for _, f := range qt.connFilters {
+		go func(f ConnFilterFunc, c net.Conn, ips []net.IP, errc chan<- error) {
+			errc <- f(qt.conns, c, ips)
+		}(f, c, ips, errc)
+	}
+
+	for i := 0; i < cap(errc); i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return ErrRejected{conn: c, err: err, isFiltered: true}
+			}
+		case <-time.After(qt.filterTimeout):
+			return ErrFilterTimeout{}
+		}
+	}
+
+	qt.conns.Set(c, ips)
+
+	return nil
+}
+
+// upgrade opens the control stream of the connection, on which the NodeInfo
+// are exchanged, and authenticates the peer. If 0-RTT was rejected by the
+// peer, quic.Err0RTTRejected is returned and the connection is left open.
+func (qt *QUICTransport) upgrade(
+	c *quicConn,
+	dialedAddr *NetAddress,
+) (nodeInfo NodeInfo, err error) {
+	defer func() {
+		if err != nil && !errors.Is(err, quic.Err0RTTRejected) {
+			_ = qt.cleanup(c)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), qt.handshakeTimeout)
+	defer cancel()
+
+	// The dialer opens the control stream. Its NodeInfo is sent along with the
+	// TLS handshake when 0-RTT is used.
+	if dialedAddr != nil {
+		c.stream, err = c.conn.OpenStreamSync(ctx)
+	} else {
+		c.stream, err = c.conn.AcceptStream(ctx)
+	}
+	if errors.Is(err, quic.Err0RTTRejected) {
+		return nil, err
+	} else if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("control stream failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+
+	nodeInfo, err = handshake(c, qt.handshakeTimeout, qt.nodeInfo)
+	if errors.Is(err, quic.Err0RTTRejected) {
+		return nil, err
+	} else if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+
+	// The identity of the peer is only known once the TLS handshake completed.
+	connID, err := waitPeerID(ctx, c.conn)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("tls handshake failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+
+	// For outgoing conns, ensure connection key matches dialed key.
+	if dialedAddr != nil {
+		if dialedID := dialedAddr.ID; connID != dialedID {
+			return nil, ErrRejected{
+				conn: c,
+				id:   connID,
+				err: fmt.Errorf(
+					"conn.ID (%v) dialed ID (%v) mismatch",
+					connID,
+					dialedID,
+				),
+				isAuthFailure: true,
+			}
+		}
+	}
+
+	if err := verifyNodeInfo(c, connID, nodeInfo, qt.nodeInfo); err != nil {
+		return nil, err
+	}
+
+	return nodeInfo, nil
+}
+
+func (qt *QUICTransport) wrapPeer(
+	c *quicConn,
+	ni NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+) Peer {
+
+	persistent := false
+	if cfg.isPersistent != nil {
+		if cfg.outbound {
+			persistent = cfg.isPersistent(socketAddr)
+		} else {
+			selfReportedAddr, err := ni.NetAddress()
+			if err == nil {
+				persistent = cfg.isPersistent(selfReportedAddr)
+			}
+		}
+	}
+
+	peerConn := newPeerConn(
+		cfg.outbound,
+		persistent,
+		c,
+		socketAddr,
+	)
+
+	return newQUICPeer(
+		peerConn,
+		c.conn,
+		ni,
+		cfg.reactorsByCh,
+		cfg.msgTypeByChID,
+		cfg.chDescs,
+		cfg.onPeerError,
+		cfg.mlc,
+		quicPeerMetrics(cfg.metrics),
+	)
+}
+
+// quicConn is a QUIC connection along with its control stream. It implements
+// net.Conn, reading from and writing to the control stream, so it can be used
+// by the connection filters and the handshake.
+type quicConn struct {
+	conn   quic.Connection
+	stream quic.Stream
+}
+
+var _ net.Conn = (*quicConn)(nil)
+
+func (c *quicConn) Read(b []byte) (int, error)         { return c.stream.Read(b) }
+func (c *quicConn) Write(b []byte) (int, error)        { return c.stream.Write(b) }
+func (c *quicConn) LocalAddr() net.Addr                { return c.conn.LocalAddr() }
+func (c *quicConn) RemoteAddr() net.Addr               { return c.conn.RemoteAddr() }
+func (c *quicConn) SetDeadline(t time.Time) error      { return c.stream.SetDeadline(t) }
+func (c *quicConn) SetReadDeadline(t time.Time) error  { return c.stream.SetReadDeadline(t) }
+func (c *quicConn) SetWriteDeadline(t time.Time) error { return c.stream.SetWriteDeadline(t) }
+
+// Close closes the whole connection.
+func (c *quicConn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
+
+// waitPeerID waits for the TLS handshake of the connection to complete and
+// returns the ID of the peer, derived from the key of its certificate.
+func waitPeerID(ctx context.Context, conn quic.Connection) (ID, error) {
+	if early, ok := conn.(quic.EarlyConnection); ok {
+		select {
+		case <-early.HandshakeComplete():
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	certs := conn.ConnectionState().TLS.PeerCertificates
+	if len(certs) == 0 {
+		return "", errors.New("no peer certificate")
+	}
+	pubKey, ok := certs[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("unsupported peer key type %T", certs[0].PublicKey)
+	}
+
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
+
+// quicTLSConfig returns the TLS configuration of the QUIC connections, with a
+// self-signed certificate of the node key. The peers are authenticated by the
+// key of their certificate, as the IDs are derived from the node keys.
+func quicTLSConfig(nodeKey NodeKey) (*tls.Config, error) {
+	privKey, ok := nodeKey.PrivKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("the QUIC transport requires an ed25519 node key, got %s", nodeKey.PrivKey.Type())
+	}
+	stdPrivKey := stded25519.PrivateKey(privKey)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: string(nodeKey.ID())},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(100, 0, 0),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, stdPrivKey.Public(), stdPrivKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate: %w", err)
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{certDER},
+			PrivateKey:  stdPrivKey,
+		}},
+		ClientAuth: tls.RequireAnyClientCert,
+		// The certificates are self-signed, they are verified below and the ID
+		// of the peer checked once the handshake completed.
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: verifyQUICCertificate,
+		NextProtos:            []string{quicALPN},
+	}, nil
+}
+
+// verifyQUICCertificate checks the peer presented a single certificate for an
+// ed25519 key, signed by this key.
+func verifyQUICCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected a single certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return fmt.Errorf("unsupported certificate key type %T", cert.PublicKey)
+	}
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
@@ -0,0 +1,152 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p/conn"
+	tmp2p "github.com/cometbft/cometbft/proto/tendermint/p2p"
+)
+
+const testCh2 = 0x02
+
+func testSetupQUICTransport(t *testing.T, name string) *QUICTransport {
+	t.Helper()
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	nodeInfo := testNodeInfo(nodeKey.ID(), name).(DefaultNodeInfo)
+	nodeInfo.Channels = []byte{testCh, testCh2}
+
+	qt, err := NewQUICTransport(nodeInfo, nodeKey)
+	require.NoError(t, err)
+
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, qt.Listen(*addr))
+	t.Cleanup(func() { _ = qt.Close() })
+
+	return qt
+}
+
+func testQUICPeerConfig(reactor Reactor) peerConfig {
+	chDescs := []*conn.ChannelDescriptor{
+		{ID: testCh, Priority: 1},
+		{ID: testCh2, Priority: 1},
+	}
+	return peerConfig{
+		chDescs:       chDescs,
+		onPeerError:   func(Peer, interface{}) {},
+		reactorsByCh:  map[byte]Reactor{testCh: reactor, testCh2: reactor},
+		msgTypeByChID: map[byte]proto.Message{testCh: &tmp2p.Message{}, testCh2: &tmp2p.Message{}},
+		metrics:       NopMetrics(),
+		mlc:           newMetricsLabelCache(),
+	}
+}
+
+func testQUICListenAddr(qt *QUICTransport) NetAddress {
+	return *newNetAddressFromConn(qt.nodeKey.ID(), qt.listener.Addr())
+}
+
+// testQUICDialAccept dials the server from the client and returns the peers
+// on both sides, started.
+func testQUICDialAccept(t *testing.T, client, server *QUICTransport, reactor Reactor) (Peer, Peer) {
+	t.Helper()
+	acceptc := make(chan Peer)
+	go func() {
+		p, err := server.Accept(testQUICPeerConfig(reactor))
+		assert.NoError(t, err)
+		acceptc <- p
+	}()
+
+	out, err := client.Dial(testQUICListenAddr(server), testQUICPeerConfig(reactor))
+	require.NoError(t, err)
+	in := <-acceptc
+	require.NotNil(t, in)
+
+	for _, p := range []Peer{out, in} {
+		p.SetLogger(log.TestingLogger())
+		require.NoError(t, p.Start())
+		p := p
+		t.Cleanup(func() { _ = p.Stop() })
+	}
+	return out, in
+}
+
+func TestQUICTransportDialAccept(t *testing.T) {
+	var (
+		client  = testSetupQUICTransport(t, "client")
+		server  = testSetupQUICTransport(t, "server")
+		reactor = NewTestReactor(nil, true)
+	)
+
+	out, in := testQUICDialAccept(t, client, server, reactor)
+	assert.True(t, out.IsOutbound())
+	assert.False(t, in.IsOutbound())
+	assert.Equal(t, server.nodeKey.ID(), out.ID())
+	assert.Equal(t, client.nodeKey.ID(), in.ID())
+
+	// the messages of each channel are sent on their own stream
+	msg := &tmp2p.Message{Sum: &tmp2p.Message_PexRequest{PexRequest: &tmp2p.PexRequest{}}}
+	for i := 0; i < 3; i++ {
+		require.True(t, out.Send(Envelope{ChannelID: testCh, Message: msg}))
+		require.True(t, in.Send(Envelope{ChannelID: testCh2, Message: msg}))
+	}
+	assert.Eventually(t, func() bool {
+		return len(reactor.getMsgs(testCh)) == 3 && len(reactor.getMsgs(testCh2)) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, m := range reactor.getMsgs(testCh) {
+		assert.Equal(t, msg.GetPexRequest(), m.Contents)
+	}
+	assert.Len(t, out.Status().Channels, 2)
+}
+
+func TestQUICTransport0RTTReconnect(t *testing.T) {
+	var (
+		client  = testSetupQUICTransport(t, "client")
+		server  = testSetupQUICTransport(t, "server")
+		reactor = NewTestReactor(nil, false)
+	)
+
+	out, in := testQUICDialAccept(t, client, server, reactor)
+	assert.False(t, out.(*quicPeer).conn.ConnectionState().Used0RTT)
+	// wait for the session ticket
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, out.Stop())
+	require.NoError(t, in.Stop())
+	client.Cleanup(out)
+	server.Cleanup(in)
+
+	out, _ = testQUICDialAccept(t, client, server, reactor)
+	assert.True(t, out.(*quicPeer).conn.ConnectionState().Used0RTT)
+	assert.Equal(t, server.nodeKey.ID(), out.ID())
+}
+
+func TestQUICTransportDialRejectWrongID(t *testing.T) {
+	var (
+		client = testSetupQUICTransport(t, "client")
+		server = testSetupQUICTransport(t, "server")
+	)
+
+	go func() {
+		_, _ = server.Accept(testQUICPeerConfig(NewTestReactor(nil, false)))
+	}()
+
+	addr := testQUICListenAddr(server)
+	addr.ID = PubKeyToID(ed25519.GenPrivKey().PubKey())
+	_, err := client.Dial(addr, testQUICPeerConfig(NewTestReactor(nil, false)))
+	require.Error(t, err)
+	e, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %v", err)
+	assert.True(t, e.IsAuthFailure())
+}
+
+func TestQUICTransportRequiresEd25519Key(t *testing.T) {
+	_, err := NewQUICTransport(emptyNodeInfo(), NodeKey{PrivKey: secp256k1.GenPrivKey()})
+	assert.Error(t, err)
+}
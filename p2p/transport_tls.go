@@ -0,0 +1,118 @@
+package p2p
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	cmtnet "github.com/cometbft/cometbft/libs/net"
+)
+
+// The first byte of a TLS connection, the type of the record carrying the
+// ClientHello, which can't start a secret connection: its first message, the
+// length-prefixed ephemeral key, always starts with its length, 0x22.
+const tlsRecordTypeHandshake = 0x16
+
+// MultiplexTransportTLS makes the transport connect to the peers with the IDs
+// with mutual TLS, beneath the secret connection, and reject their connections
+// without it. The certificates must be signed by the CA of the config and
+// carry the ID of the node as DNS name. Connections with TLS are accepted from
+// any other peer presenting such a certificate.
+func MultiplexTransportTLS(config *tls.Config, peerIDs []ID) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) {
+		mt.tlsConfig = config
+		mt.tlsPeerIDs = make(map[ID]struct{}, len(peerIDs))
+		for _, id := range peerIDs {
+			mt.tlsPeerIDs[id] = struct{}{}
+		}
+	}
+}
+
+// LoadTLSConfig returns the mutual TLS config for MultiplexTransportTLS, with
+// the certificate and key of the node and the CA certificate used to verify
+// the certificates of the peers, all PEM encoded.
+func LoadTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config, err := cmtnet.LoadTLSConfig(caFile, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
+
+// upgradeTLS runs the TLS handshake on the connection when dialing a peer
+// required to use TLS, or accepting a connection starting with a TLS record.
+// It returns the connection to run the secret connection on, and the TLS
+// connection if any.
+func (mt *MultiplexTransport) upgradeTLS(c net.Conn, dialedAddr *NetAddress) (net.Conn, *tls.Conn, error) {
+	if mt.tlsConfig == nil {
+		return c, nil, nil
+	}
+
+	if err := c.SetDeadline(time.Now().Add(mt.handshakeTimeout)); err != nil {
+		return nil, nil, err
+	}
+
+	var tlsConn *tls.Conn
+	if dialedAddr != nil {
+		if _, ok := mt.tlsPeerIDs[dialedAddr.ID]; !ok {
+			return c, nil, nil
+		}
+		config := mt.tlsConfig.Clone()
+		config.ServerName = string(dialedAddr.ID)
+		tlsConn = tls.Client(c, config)
+	} else {
+		first := make([]byte, 1)
+		if _, err := c.Read(first); err != nil {
+			return nil, nil, err
+		}
+		c = &prefixedConn{Conn: c, prefix: first}
+		if first[0] != tlsRecordTypeHandshake {
+			return c, nil, nil
+		}
+		tlsConn = tls.Server(c, mt.tlsConfig)
+	}
+
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, nil, err
+	}
+	return tlsConn, tlsConn, nil
+}
+
+// verifyTLS checks that the peer authenticated with connID used TLS if
+// required, with a certificate for connID.
+func (mt *MultiplexTransport) verifyTLS(tlsConn *tls.Conn, connID ID) error {
+	if tlsConn == nil {
+		if _, ok := mt.tlsPeerIDs[connID]; ok {
+			return errors.New("peer is required to connect with TLS")
+		}
+		return nil
+	}
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("no TLS certificate")
+	}
+	if err := certs[0].VerifyHostname(string(connID)); err != nil {
+		return fmt.Errorf("TLS certificate is not for the peer: %w", err)
+	}
+	return nil
+}
+
+// prefixedConn is a connection whose first bytes were read, returning them
+// first.
+type prefixedConn struct {
+	net.Conn
+	prefix []byte
+}
+
+func (c *prefixedConn) Read(b []byte) (int, error) {
+	if len(c.prefix) > 0 {
+		n := copy(b, c.prefix)
+		c.prefix = c.prefix[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmttest "github.com/cometbft/cometbft/libs/test"
+)
+
+// testTLSConfig issues a certificate of the CA for the ID and returns the TLS
+// config with it.
+func testTLSConfig(t *testing.T, ca *cmttest.TLSCA, id ID) *tls.Config {
+	certFile, keyFile := ca.IssueCert(t, string(id))
+	config, err := LoadTLSConfig(ca.File, certFile, keyFile)
+	require.NoError(t, err)
+	return config
+}
+
+// testSetupTLSTransports returns a transport to listen with and one to dial
+// with, each with its own key, to be configured before testTLSDialAccept.
+func testSetupTLSTransports() (listener, dialer *MultiplexTransport) {
+	pv := ed25519.GenPrivKey()
+	listener = newMultiplexTransport(testNodeInfo(PubKeyToID(pv.PubKey()), "listener"), NodeKey{PrivKey: pv})
+	pv = ed25519.GenPrivKey()
+	dialer = newMultiplexTransport(testNodeInfo(PubKeyToID(pv.PubKey()), "dialer"), NodeKey{PrivKey: pv})
+	return listener, dialer
+}
+
+func testTLSDialAccept(t *testing.T, listener, dialer *MultiplexTransport) (dialErr, acceptErr error) {
+	addr, err := NewNetAddressString(IDAddressString(listener.nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, listener.Listen(*addr))
+	t.Cleanup(func() { _ = listener.Close() })
+
+	errc := make(chan error)
+	go func() {
+		_, err := listener.Accept(peerConfig{})
+		errc <- err
+	}()
+
+	addr = NewNetAddress(listener.nodeKey.ID(), listener.listener.Addr())
+	p, dialErr := dialer.Dial(*addr, peerConfig{})
+	if dialErr == nil {
+		defer dialer.Cleanup(p)
+	}
+	return dialErr, <-errc
+}
+
+func TestTransportTLS(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	listener, dialer := testSetupTLSTransports()
+	listenerID, dialerID := listener.nodeKey.ID(), dialer.nodeKey.ID()
+	MultiplexTransportTLS(testTLSConfig(t, ca, listenerID), []ID{dialerID})(listener)
+	MultiplexTransportTLS(testTLSConfig(t, ca, dialerID), []ID{listenerID})(dialer)
+
+	dialErr, acceptErr := testTLSDialAccept(t, listener, dialer)
+	require.NoError(t, dialErr)
+	require.NoError(t, acceptErr)
+}
+
+func TestTransportTLSAcceptsOtherPeersWithoutTLS(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	listener, dialer := testSetupTLSTransports()
+	otherID := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	MultiplexTransportTLS(testTLSConfig(t, ca, listener.nodeKey.ID()), []ID{otherID})(listener)
+
+	dialErr, acceptErr := testTLSDialAccept(t, listener, dialer)
+	require.NoError(t, dialErr)
+	require.NoError(t, acceptErr)
+}
+
+func TestTransportTLSRejectsPeerWithoutTLS(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	listener, dialer := testSetupTLSTransports()
+	MultiplexTransportTLS(testTLSConfig(t, ca, listener.nodeKey.ID()), []ID{dialer.nodeKey.ID()})(listener)
+
+	_, acceptErr := testTLSDialAccept(t, listener, dialer)
+	require.Error(t, acceptErr)
+	e, ok := acceptErr.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %v", acceptErr)
+	assert.True(t, e.IsAuthFailure())
+}
+
+func TestTransportTLSRejectsCertificateOfOtherPeer(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	listener, dialer := testSetupTLSTransports()
+	listenerID, dialerID := listener.nodeKey.ID(), dialer.nodeKey.ID()
+	otherID := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	MultiplexTransportTLS(testTLSConfig(t, ca, listenerID), []ID{dialerID})(listener)
+	MultiplexTransportTLS(testTLSConfig(t, ca, otherID), []ID{listenerID})(dialer)
+
+	_, acceptErr := testTLSDialAccept(t, listener, dialer)
+	require.Error(t, acceptErr)
+	e, ok := acceptErr.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %v", acceptErr)
+	assert.True(t, e.IsAuthFailure())
+}
+
+func TestTransportTLSRejectsCertificateOfOtherCA(t *testing.T) {
+	listener, dialer := testSetupTLSTransports()
+	listenerID, dialerID := listener.nodeKey.ID(), dialer.nodeKey.ID()
+	MultiplexTransportTLS(testTLSConfig(t, cmttest.NewTLSCA(t), listenerID), []ID{dialerID})(listener)
+	MultiplexTransportTLS(testTLSConfig(t, cmttest.NewTLSCA(t), dialerID), []ID{listenerID})(dialer)
+
+	dialErr, acceptErr := testTLSDialAccept(t, listener, dialer)
+	assert.Error(t, dialErr)
+	assert.Error(t, acceptErr)
+}
@@ -9,6 +9,15 @@ import (
 
 type ChannelDescriptor = conn.ChannelDescriptor
 type ConnectionStatus = conn.ConnectionStatus
+type DropPolicy = conn.DropPolicy
+
+// Policies of the channels for the messages sent to a peer whose send queue is
+// full, see conn.DropPolicy.
+const (
+	RejectNewest = conn.RejectNewest
+	DropOldest   = conn.DropOldest
+	Disconnect   = conn.Disconnect
+)
 
 // Envelope contains a message with sender routing info.
 type Envelope struct {
@@ -35,6 +44,21 @@ type Wrapper interface {
 	Wrap() proto.Message
 }
 
+// PooledUnmarshaler is a Protobuf message type whose inbound messages are
+// decoded into messages taken from a pool, and returned to it once the reactor
+// received them, sparing the allocations of the messages received at a high
+// rate. The reactors of the channels of such a type must not retain the
+// received messages, nor the messages they hold, once Receive returns.
+type PooledUnmarshaler interface {
+	proto.Message
+
+	// UnmarshalPooled decodes the bytes into a message taken from the pool.
+	UnmarshalPooled(bz []byte) (proto.Message, error)
+	// Release resets a message returned by UnmarshalPooled and returns it to
+	// the pool.
+	Release(msg proto.Message)
+}
+
 var (
 	_ Wrapper = &tmp2p.PexRequest{}
 	_ Wrapper = &tmp2p.PexAddrs{}
@@ -0,0 +1,119 @@
+package upnp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/service"
+)
+
+const (
+	portMappingDescription = "CometBFT"
+
+	// the lease of the mappings, renewed before it expires
+	portMappingLifetime      = 20 * time.Minute
+	portMappingRenewInterval = 15 * time.Minute
+)
+
+// DiscoverNAT looks for a UPnP gateway, falling back to a NAT-PMP one.
+func DiscoverNAT() (NAT, error) {
+	nat, err := Discover()
+	if err == nil {
+		return nat, nil
+	}
+	nat, pmpErr := DiscoverNATPMP()
+	if pmpErr != nil {
+		return nil, fmt.Errorf("no UPnP (%v) or NAT-PMP (%v) gateway found", err, pmpErr)
+	}
+	return nat, nil
+}
+
+// PortMapping keeps a port of the host mapped on the NAT gateway, renewing
+// the mapping before its lease expires once started, and deletes the mapping
+// when stopped.
+type PortMapping struct {
+	service.BaseService
+
+	nat          NAT
+	protocol     string
+	internalPort int
+	externalPort int
+	externalIP   net.IP
+	// lease of the mapping in seconds, 0 if the mapping is permanent
+	lifetime int
+}
+
+// NewPortMapping maps the port of the host on the NAT gateway, to the same
+// external port if the gateway allows it, for the protocol, "tcp" or "udp".
+func NewPortMapping(nat NAT, protocol string, port int) (*PortMapping, error) {
+	externalIP, err := nat.GetExternalAddress()
+	if err != nil {
+		return nil, fmt.Errorf("external address error: %w", err)
+	}
+
+	m := &PortMapping{
+		nat:          nat,
+		protocol:     protocol,
+		internalPort: port,
+		externalIP:   externalIP,
+		lifetime:     int(portMappingLifetime.Seconds()),
+	}
+	m.externalPort, err = nat.AddPortMapping(protocol, port, port, portMappingDescription, m.lifetime)
+	if err != nil {
+		// some UPnP gateways only support permanent mappings
+		m.lifetime = 0
+		m.externalPort, err = nat.AddPortMapping(protocol, port, port, portMappingDescription, m.lifetime)
+		if err != nil {
+			return nil, fmt.Errorf("port mapping error: %w", err)
+		}
+	}
+	m.BaseService = *service.NewBaseService(nil, "PortMapping", m)
+
+	return m, nil
+}
+
+// ExternalAddress returns the address on the gateway mapped to the port of
+// the host, as host:port.
+func (m *PortMapping) ExternalAddress() string {
+	return net.JoinHostPort(m.externalIP.String(), strconv.Itoa(m.externalPort))
+}
+
+// OnStart implements service.Service by renewing the mapping periodically.
+func (m *PortMapping) OnStart() error {
+	if m.lifetime > 0 {
+		go m.renewRoutine()
+	}
+	return nil
+}
+
+// OnStop implements service.Service by deleting the mapping.
+func (m *PortMapping) OnStop() {
+	if err := m.nat.DeletePortMapping(m.protocol, m.externalPort, m.internalPort); err != nil {
+		m.Logger.Error("Failed to delete port mapping", "err", err)
+	}
+}
+
+func (m *PortMapping) renewRoutine() {
+	ticker := time.NewTicker(portMappingRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			port, err := m.nat.AddPortMapping(m.protocol, m.externalPort, m.internalPort,
+				portMappingDescription, m.lifetime)
+			if err != nil {
+				m.Logger.Error("Failed to renew port mapping", "err", err)
+				continue
+			}
+			if port != m.externalPort {
+				m.Logger.Error("External port of the mapping changed, peers may not reach us",
+					"old", m.externalPort, "new", port)
+			}
+		case <-m.Quit():
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package upnp
+
+// NAT-PMP client, as specified by RFC 6886.
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	natPMPPort    = 5351
+	natPMPVersion = 0
+
+	natPMPOpExternalAddress = 0
+	natPMPOpMapUDP          = 1
+	natPMPOpMapTCP          = 2
+
+	// the initial timeout of a request, doubled on each retry
+	natPMPInitialTimeout = 250 * time.Millisecond
+	natPMPMaxTries       = 4
+)
+
+type natPMP struct {
+	gateway *net.UDPAddr
+}
+
+var _ NAT = (*natPMP)(nil)
+
+// DiscoverNATPMP looks for a NAT-PMP gateway, trying the first address of
+// each private IPv4 network the host is on, as the address of the gateway
+// can't be obtained portably.
+func DiscoverNATPMP() (NAT, error) {
+	for _, gw := range potentialGateways() {
+		nat := &natPMP{gateway: &net.UDPAddr{IP: gw, Port: natPMPPort}}
+		if _, err := nat.GetExternalAddress(); err == nil {
+			return nat, nil
+		}
+	}
+	return nil, errors.New("nat-pmp gateway discovery failed")
+}
+
+func potentialGateways() (gws []net.IP) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			v4 := ipnet.IP.To4()
+			if v4 == nil || !v4.IsPrivate() {
+				continue
+			}
+			gw := make(net.IP, net.IPv4len)
+			copy(gw, v4.Mask(ipnet.Mask))
+			gw[3] |= 1
+			gws = append(gws, gw)
+		}
+	}
+	return gws
+}
+
+// GetExternalAddress returns the external IP of the gateway.
+func (n *natPMP) GetExternalAddress() (addr net.IP, err error) {
+	res, err := n.request([]byte{natPMPVersion, natPMPOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(res[8], res[9], res[10], res[11]), nil
+}
+
+// AddPortMapping maps the external port to the internal port for timeout
+// seconds. The gateway may map another external port, which is returned.
+func (n *natPMP) AddPortMapping(
+	protocol string,
+	externalPort,
+	internalPort int,
+	description string,
+	timeout int) (mappedExternalPort int, err error) {
+	res, err := n.mapPort(protocol, externalPort, internalPort, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(res[10:12])), nil
+}
+
+// DeletePortMapping removes the mapping of the internal port.
+func (n *natPMP) DeletePortMapping(protocol string, externalPort, internalPort int) (err error) {
+	// a mapping is deleted by requesting it with a lifetime and an external
+	// port of 0
+	_, err = n.mapPort(protocol, 0, internalPort, 0)
+	return err
+}
+
+func (n *natPMP) mapPort(protocol string, externalPort, internalPort, lifetime int) ([]byte, error) {
+	var op byte
+	switch protocol {
+	case "udp", "UDP":
+		op = natPMPOpMapUDP
+	case "tcp", "TCP":
+		op = natPMPOpMapTCP
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+	msg := make([]byte, 12)
+	msg[0] = natPMPVersion
+	msg[1] = op
+	binary.BigEndian.PutUint16(msg[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(msg[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(msg[8:12], uint32(lifetime))
+	return n.request(msg, 16)
+}
+
+// request sends msg to the gateway, retrying with an increasing timeout, and
+// returns its response of resSize bytes once checked.
+func (n *natPMP) request(msg []byte, resSize int) ([]byte, error) {
+	conn, err := net.DialUDP("udp4", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res := make([]byte, 16)
+	timeout := natPMPInitialTimeout
+	for i := 0; i < natPMPMaxTries; i++ {
+		if _, err = conn.Write(msg); err != nil {
+			return nil, err
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		timeout *= 2
+
+		var size int
+		size, err = conn.Read(res)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return nil, err
+		}
+		switch {
+		case size < resSize:
+			return nil, fmt.Errorf("response too short: %d < %d bytes", size, resSize)
+		case res[0] != natPMPVersion:
+			return nil, fmt.Errorf("unsupported version %d", res[0])
+		case res[1] != msg[1]|0x80:
+			return nil, fmt.Errorf("unexpected opcode %d", res[1])
+		}
+		if code := binary.BigEndian.Uint16(res[2:4]); code != 0 {
+			return nil, fmt.Errorf("request failed with result code %d", code)
+		}
+		return res[:resSize], nil
+	}
+	return nil, fmt.Errorf("no response from %v: %w", n.gateway, err)
+}
@@ -0,0 +1,126 @@
+package upnp
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// testNATPMPGateway is a NAT-PMP gateway mapping the ports to
+// externalPortOffset + the internal port.
+type testNATPMPGateway struct {
+	conn       *net.UDPConn
+	externalIP net.IP
+
+	mtx      sync.Mutex
+	mappings map[int]uint32 // lifetime by internal port
+}
+
+const externalPortOffset = 10000
+
+func newTestNATPMPGateway(t *testing.T) *testNATPMPGateway {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	gw := &testNATPMPGateway{
+		conn:       conn,
+		externalIP: net.IPv4(203, 0, 113, 7).To4(),
+		mappings:   make(map[int]uint32),
+	}
+	go gw.serve()
+	return gw
+}
+
+func (gw *testNATPMPGateway) serve() {
+	buf := make([]byte, 16)
+	for {
+		n, addr, err := gw.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		var res []byte
+		switch {
+		case n == 2 && buf[1] == natPMPOpExternalAddress:
+			res = make([]byte, 12)
+			copy(res[8:], gw.externalIP)
+		case n == 12 && (buf[1] == natPMPOpMapTCP || buf[1] == natPMPOpMapUDP):
+			internalPort := int(binary.BigEndian.Uint16(buf[4:6]))
+			lifetime := binary.BigEndian.Uint32(buf[8:12])
+			gw.mtx.Lock()
+			if lifetime == 0 {
+				delete(gw.mappings, internalPort)
+			} else {
+				gw.mappings[internalPort] = lifetime
+			}
+			gw.mtx.Unlock()
+			res = make([]byte, 16)
+			binary.BigEndian.PutUint16(res[8:10], uint16(internalPort))
+			binary.BigEndian.PutUint16(res[10:12], uint16(externalPortOffset+internalPort))
+			binary.BigEndian.PutUint32(res[12:16], lifetime)
+		default:
+			// unsupported opcode
+			res = make([]byte, 4)
+			binary.BigEndian.PutUint16(res[2:4], 5)
+		}
+		res[1] = buf[1] | 0x80
+		_, _ = gw.conn.WriteToUDP(res, addr)
+	}
+}
+
+func (gw *testNATPMPGateway) mapping(port int) (uint32, bool) {
+	gw.mtx.Lock()
+	defer gw.mtx.Unlock()
+	lifetime, ok := gw.mappings[port]
+	return lifetime, ok
+}
+
+func (gw *testNATPMPGateway) nat() *natPMP {
+	return &natPMP{gateway: gw.conn.LocalAddr().(*net.UDPAddr)}
+}
+
+func TestNATPMP(t *testing.T) {
+	gw := newTestNATPMPGateway(t)
+	nat := gw.nat()
+
+	ip, err := nat.GetExternalAddress()
+	require.NoError(t, err)
+	assert.True(t, gw.externalIP.Equal(ip))
+
+	port, err := nat.AddPortMapping("tcp", 26656, 26656, "test", 60)
+	require.NoError(t, err)
+	assert.Equal(t, externalPortOffset+26656, port)
+	lifetime, ok := gw.mapping(26656)
+	require.True(t, ok)
+	assert.EqualValues(t, 60, lifetime)
+
+	require.NoError(t, nat.DeletePortMapping("tcp", port, 26656))
+	_, ok = gw.mapping(26656)
+	assert.False(t, ok)
+
+	_, err = nat.AddPortMapping("sctp", 26656, 26656, "test", 60)
+	assert.Error(t, err)
+}
+
+func TestPortMapping(t *testing.T) {
+	gw := newTestNATPMPGateway(t)
+
+	m, err := NewPortMapping(gw.nat(), "udp", 26656)
+	require.NoError(t, err)
+	m.SetLogger(log.TestingLogger())
+	assert.Equal(t, "203.0.113.7:36656", m.ExternalAddress())
+	lifetime, ok := gw.mapping(26656)
+	require.True(t, ok)
+	assert.EqualValues(t, portMappingLifetime.Seconds(), lifetime)
+
+	require.NoError(t, m.Start())
+	require.NoError(t, m.Stop())
+	_, ok = gw.mapping(26656)
+	assert.False(t, ok)
+}
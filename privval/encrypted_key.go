@@ -0,0 +1,252 @@
+package privval
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/term"
+
+	"github.com/cometbft/cometbft/crypto"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// KeyPassphraseEnv is the environment variable holding the passphrase of
+	// an encrypted private validator key.
+	KeyPassphraseEnv = "CMT_PRIV_VALIDATOR_KEY_PASSPHRASE"
+	// KeyPassphraseCredential is the name of the systemd credential holding
+	// the passphrase of an encrypted private validator key, see
+	// systemd.exec(5) LoadCredential=.
+	KeyPassphraseCredential = "priv_validator_key_passphrase"
+
+	kdfArgon2id      = "argon2id"
+	cipherAES256GCM  = "aes-256-gcm"
+	argon2idTime     = 3
+	argon2idMemory   = 64 * 1024 // KiB
+	argon2idThreads  = 4
+	argon2idSaltSize = 16
+	aes256KeySize    = 32
+)
+
+// ErrWrongPassphrase is returned when the private validator key can't be
+// decrypted with the passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase for the private validator key")
+
+// PassphraseFunc returns the passphrase of an encrypted private validator key.
+// It is only called if the key is encrypted.
+type PassphraseFunc func() ([]byte, error)
+
+// encryptedPrivKey is a private key encrypted with AES-256-GCM, with a key
+// derived from a passphrase with argon2id.
+type encryptedPrivKey struct {
+	KDF        string            `json:"kdf"`
+	Salt       cmtbytes.HexBytes `json:"salt"`
+	Time       uint32            `json:"time"`
+	Memory     uint32            `json:"memory"`
+	Threads    uint8             `json:"threads"`
+	Cipher     string            `json:"cipher"`
+	Nonce      cmtbytes.HexBytes `json:"nonce"`
+	Ciphertext cmtbytes.HexBytes `json:"ciphertext"`
+}
+
+// encryptedFilePVKey is the content of an encrypted private validator key
+// file. The address and the public key are not encrypted.
+type encryptedFilePVKey struct {
+	Address          types.Address     `json:"address"`
+	PubKey           crypto.PubKey     `json:"pub_key"`
+	EncryptedPrivKey *encryptedPrivKey `json:"encrypted_priv_key"`
+}
+
+// Encrypt encrypts the private key with the passphrase, the key being saved
+// encrypted from then on.
+func (pvKey *FilePVKey) Encrypt(passphrase []byte) error {
+	if len(passphrase) == 0 {
+		return errors.New("the passphrase can't be empty")
+	}
+	plaintext, err := cmtjson.Marshal(pvKey.PrivKey)
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, argon2idSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	enc := &encryptedPrivKey{
+		KDF:     kdfArgon2id,
+		Salt:    salt,
+		Time:    argon2idTime,
+		Memory:  argon2idMemory,
+		Threads: argon2idThreads,
+		Cipher:  cipherAES256GCM,
+	}
+	aead, err := enc.aead(passphrase)
+	if err != nil {
+		return err
+	}
+	enc.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(enc.Nonce); err != nil {
+		return err
+	}
+	enc.Ciphertext = aead.Seal(nil, enc.Nonce, plaintext, pvKey.Address)
+	pvKey.encrypted = enc
+	return nil
+}
+
+// IsEncrypted reports whether the private key is saved encrypted.
+func (pvKey FilePVKey) IsEncrypted() bool {
+	return pvKey.encrypted != nil
+}
+
+// marshalJSON returns the content of the key file, encrypted if the key is.
+func (pvKey FilePVKey) marshalJSON() ([]byte, error) {
+	if pvKey.encrypted == nil {
+		return cmtjson.MarshalIndent(pvKey, "", "  ")
+	}
+	return cmtjson.MarshalIndent(encryptedFilePVKey{
+		Address:          pvKey.Address,
+		PubKey:           pvKey.PubKey,
+		EncryptedPrivKey: pvKey.encrypted,
+	}, "", "  ")
+}
+
+// unmarshalFilePVKey reads the content of a key file, decrypting the private
+// key with the passphrase if it is encrypted.
+func unmarshalFilePVKey(bz []byte, passphrase PassphraseFunc) (FilePVKey, error) {
+	var encKey encryptedFilePVKey
+	if err := cmtjson.Unmarshal(bz, &encKey); err != nil {
+		return FilePVKey{}, err
+	}
+	if encKey.EncryptedPrivKey == nil {
+		pvKey := FilePVKey{}
+		err := cmtjson.Unmarshal(bz, &pvKey)
+		return pvKey, err
+	}
+
+	if passphrase == nil {
+		return FilePVKey{}, errors.New("the private validator key is encrypted, but no passphrase is available")
+	}
+	pass, err := passphrase()
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("can't get the passphrase of the private validator key: %w", err)
+	}
+	enc := encKey.EncryptedPrivKey
+	aead, err := enc.aead(pass)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	if len(enc.Nonce) != aead.NonceSize() {
+		return FilePVKey{}, fmt.Errorf("invalid nonce size %d", len(enc.Nonce))
+	}
+	plaintext, err := aead.Open(nil, enc.Nonce, enc.Ciphertext, encKey.Address)
+	if err != nil {
+		return FilePVKey{}, ErrWrongPassphrase
+	}
+	var privKey crypto.PrivKey
+	if err := cmtjson.Unmarshal(plaintext, &privKey); err != nil {
+		return FilePVKey{}, err
+	}
+	return FilePVKey{
+		Address:   encKey.Address,
+		PubKey:    encKey.PubKey,
+		PrivKey:   privKey,
+		encrypted: enc,
+	}, nil
+}
+
+// aead derives the encryption key from the passphrase.
+func (enc *encryptedPrivKey) aead(passphrase []byte) (cipher.AEAD, error) {
+	if enc.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported key derivation function %q", enc.KDF)
+	}
+	if enc.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported cipher %q", enc.Cipher)
+	}
+	if enc.Time == 0 || enc.Threads == 0 {
+		return nil, errors.New("invalid argon2id parameters")
+	}
+	key := argon2.IDKey(passphrase, enc.Salt, enc.Time, enc.Memory, enc.Threads, aes256KeySize)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyPassphrase returns a PassphraseFunc reading the passphrase from, in this
+// order:
+//
+//   - the file, if not empty;
+//   - the CMT_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable;
+//   - the priv_validator_key_passphrase systemd credential;
+//   - a prompt, if the standard input is a terminal.
+func KeyPassphrase(file string) PassphraseFunc {
+	return func() ([]byte, error) {
+		return readPassphrase(file, false)
+	}
+}
+
+// NewKeyPassphrase is like KeyPassphrase, the passphrase being asked twice if
+// prompted, e.g. to encrypt a key.
+func NewKeyPassphrase(file string) PassphraseFunc {
+	return func() ([]byte, error) {
+		return readPassphrase(file, true)
+	}
+}
+
+func readPassphrase(file string, confirm bool) ([]byte, error) {
+	if file != "" {
+		return readPassphraseFile(file)
+	}
+	if pass, ok := os.LookupEnv(KeyPassphraseEnv); ok {
+		return []byte(pass), nil
+	}
+	if dir := os.Getenv("CREDENTIALS_DIRECTORY"); dir != "" {
+		path := filepath.Join(dir, KeyPassphraseCredential)
+		if _, err := os.Stat(path); err == nil {
+			return readPassphraseFile(path)
+		}
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("set %s, a passphrase file or the %s systemd credential, "+
+			"or run in a terminal", KeyPassphraseEnv, KeyPassphraseCredential)
+	}
+	fmt.Fprint(os.Stderr, "Passphrase of the private validator key: ")
+	pass, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat the passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(pass, again) {
+			return nil, errors.New("the passphrases do not match")
+		}
+	}
+	return pass, nil
+}
+
+// readPassphraseFile reads the passphrase from the file, without the trailing
+// newline.
+func readPassphraseFile(path string) ([]byte, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(bz, "\r\n"), nil
+}
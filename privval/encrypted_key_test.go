@@ -0,0 +1,82 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "priv_validator_key.json")
+	stateFile := filepath.Join(dir, "priv_validator_state.json")
+	passphrase := func() ([]byte, error) { return []byte("secret"), nil }
+
+	privVal := GenFilePV(keyFile, stateFile)
+	privVal.Save()
+	require.NoError(t, privVal.Key.Encrypt([]byte("secret")))
+	privVal.Key.Save()
+
+	bz, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), "encrypted_priv_key")
+	assert.NotContains(t, string(bz), `"priv_key"`)
+
+	loaded := LoadFilePVWithPassphrase(keyFile, stateFile, passphrase)
+	assert.True(t, loaded.Key.IsEncrypted())
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+	assert.Equal(t, privVal.Key.Address, loaded.Key.Address)
+
+	// saving again keeps the key encrypted
+	loaded.Save()
+	bz2, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, bz, bz2)
+
+	// a wrong or missing passphrase
+	_, err = unmarshalFilePVKey(bz, func() ([]byte, error) { return []byte("wrong"), nil })
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	_, err = unmarshalFilePVKey(bz, nil)
+	assert.Error(t, err)
+
+	// a plaintext key doesn't need a passphrase
+	plain := GenFilePV(keyFile, stateFile)
+	plain.Key.Save()
+	pvKey, err := unmarshalFilePVKey(mustReadFile(t, keyFile), nil)
+	require.NoError(t, err)
+	assert.False(t, pvKey.IsEncrypted())
+	assert.Equal(t, plain.Key.PrivKey, pvKey.PrivKey)
+
+	assert.Error(t, plain.Key.Encrypt(nil))
+}
+
+func TestKeyPassphrase(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(file, []byte("from file\n"), 0o600))
+	pass, err := KeyPassphrase(file)()
+	require.NoError(t, err)
+	assert.Equal(t, "from file", string(pass))
+
+	t.Setenv(KeyPassphraseEnv, "from env")
+	pass, err = KeyPassphrase("")()
+	require.NoError(t, err)
+	assert.Equal(t, "from env", string(pass))
+	os.Unsetenv(KeyPassphraseEnv)
+
+	credentials := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(credentials, KeyPassphraseCredential), []byte("from systemd"), 0o600))
+	t.Setenv("CREDENTIALS_DIRECTORY", credentials)
+	pass, err = KeyPassphrase("")()
+	require.NoError(t, err)
+	assert.Equal(t, "from systemd", string(pass))
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return bz
+}
@@ -50,6 +50,8 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// the encrypted private key, if saved encrypted, see Encrypt
+	encrypted *encryptedPrivKey
 }
 
 // Save persists the FilePVKey to its filePath.
@@ -59,7 +61,7 @@ func (pvKey FilePVKey) Save() {
 		panic("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := cmtjson.MarshalIndent(pvKey, "", "  ")
+	jsonBytes, err := pvKey.marshalJSON()
 	if err != nil {
 		panic(err)
 	}
@@ -175,25 +177,37 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
-// does not exist, the program will exit.
+// does not exist, the program will exit. The passphrase of an encrypted key is
+// read as with KeyPassphrase, without a file.
 func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, true, KeyPassphrase(""))
+}
+
+// LoadFilePVWithPassphrase is like LoadFilePV, the passphrase of an encrypted
+// key being returned by passphrase.
+func LoadFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase PassphraseFunc) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, passphrase)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, false, KeyPassphrase(""))
+}
+
+// LoadFilePVEmptyStateWithPassphrase is like LoadFilePVEmptyState, the
+// passphrase of an encrypted key being returned by passphrase.
+func LoadFilePVEmptyStateWithPassphrase(keyFilePath, stateFilePath string, passphrase PassphraseFunc) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, passphrase)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, passphrase PassphraseFunc) *FilePV {
 	keyJSONBytes, err := os.ReadFile(keyFilePath)
 	if err != nil {
 		cmtos.Exit(err.Error())
 	}
-	pvKey := FilePVKey{}
-	err = cmtjson.Unmarshal(keyJSONBytes, &pvKey)
+	pvKey, err := unmarshalFilePVKey(keyJSONBytes, passphrase)
 	if err != nil {
 		cmtos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
 	}
@@ -227,9 +241,16 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
+	return LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath, KeyPassphrase(""))
+}
+
+// LoadOrGenFilePVWithPassphrase is like LoadOrGenFilePV, the passphrase of an
+// encrypted key being returned by passphrase. The generated keys are not
+// encrypted.
+func LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase PassphraseFunc) *FilePV {
 	var pv *FilePV
 	if cmtos.FileExists(keyFilePath) {
-		pv = LoadFilePV(keyFilePath, stateFilePath)
+		pv = LoadFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase)
 	} else {
 		pv = GenFilePV(keyFilePath, stateFilePath)
 		pv.Save()
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
@@ -208,6 +209,33 @@ func TestSignVote(t *testing.T) {
 	assert.Equal(sig, vote.Signature)
 }
 
+func TestSignVoteSecp256k1(t *testing.T) {
+	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := os.CreateTemp("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := NewFilePV(secp256k1.GenPrivKey(), tempKeyFile.Name(), tempStateFile.Name())
+	privVal.Save()
+	// The key survives a round trip through the key file.
+	privVal = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	require.Equal(t, secp256k1.KeyType, pubKey.Type())
+	assert.Equal(t, pubKey.Address(), privVal.GetAddress())
+
+	randbytes := cmtrand.Bytes(tmhash.Size)
+	block := types.BlockID{Hash: randbytes,
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+
+	vote := newVote(privVal.Key.Address, 0, 10, 1, cmtproto.PrecommitType, block)
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", v))
+	vote.Signature = v.Signature
+	assert.NoError(t, vote.Verify("mychainid", pubKey))
+	assert.Error(t, vote.Verify("otherchainid", pubKey))
+}
+
 func TestSignProposal(t *testing.T) {
 	assert := assert.New(t)
 
@@ -23,7 +23,10 @@ func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration)
 	return &RetrySignerClient{sc, retries, timeout}
 }
 
-var _ types.PrivValidator = (*RetrySignerClient)(nil)
+var (
+	_ types.PrivValidator          = (*RetrySignerClient)(nil)
+	_ types.SignBytesVersionSetter = (*RetrySignerClient)(nil)
+)
 
 func (sc *RetrySignerClient) Close() error {
 	return sc.next.Close()
@@ -37,6 +40,10 @@ func (sc *RetrySignerClient) WaitForConnection(maxWait time.Duration) error {
 	return sc.next.WaitForConnection(maxWait)
 }
 
+func (sc *RetrySignerClient) SetSignBytesVersion(version uint32) {
+	sc.next.SetSignBytesVersion(version)
+}
+
 //--------------------------------------------------------
 // Implement PrivValidator
 
@@ -2,6 +2,7 @@ package privval
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
@@ -16,9 +17,14 @@ import (
 type SignerClient struct {
 	endpoint *SignerListenerEndpoint
 	chainID  string
+
+	signBytesVersion atomic.Uint32
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator          = (*SignerClient)(nil)
+	_ types.SignBytesVersionSetter = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -32,6 +38,12 @@ func NewSignerClient(endpoint *SignerListenerEndpoint, chainID string) (*SignerC
 	return &SignerClient{endpoint: endpoint, chainID: chainID}, nil
 }
 
+// SetSignBytesVersion sets the version of the sign bytes sent with the
+// signing requests.
+func (sc *SignerClient) SetSignBytesVersion(version uint32) {
+	sc.signBytesVersion.Store(version)
+}
+
 // Close closes the underlying connection
 func (sc *SignerClient) Close() error {
 	return sc.endpoint.Close()
@@ -92,7 +104,9 @@ func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
 
 // SignVote requests a remote signer to sign a vote
 func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
-	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{
+		Vote: vote, ChainId: chainID, SignBytesVersion: sc.signBytesVersion.Load(),
+	}))
 	if err != nil {
 		return err
 	}
@@ -113,7 +127,9 @@ func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote) error {
 // SignProposal requests a remote signer to sign a proposal
 func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(
-		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
+		&privvalproto.SignProposalRequest{
+			Proposal: proposal, ChainId: chainID, SignBytesVersion: sc.signBytesVersion.Load(),
+		},
 	))
 	if err != nil {
 		return err
@@ -389,6 +389,48 @@ func TestSignerSignVoteErrors(t *testing.T) {
 	}
 }
 
+func TestSignerSignBytesVersion(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		hash := cmtrand.Bytes(tmhash.Size)
+		blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}}
+		vote := &types.Vote{
+			Type:             cmtproto.PrecommitType,
+			Height:           1,
+			Round:            2,
+			BlockID:          blockID,
+			Timestamp:        time.Now(),
+			ValidatorAddress: cmtrand.Bytes(crypto.AddressSize),
+			ValidatorIndex:   1,
+		}
+		proposal := types.NewProposal(1, 2, -1, blockID)
+
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		tc.signerClient.SetSignBytesVersion(types.LatestSignBytesVersion)
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, vote.ToProto()))
+		require.NoError(t, tc.signerClient.SignProposal(tc.chainID, proposal.ToProto()))
+
+		// The signer refuses the versions it doesn't know.
+		tc.signerClient.SetSignBytesVersion(types.LatestSignBytesVersion + 1)
+		err := tc.signerClient.SignVote(tc.chainID, vote.ToProto())
+		require.IsType(t, &RemoteSignerError{}, err)
+		assert.Contains(t, err.Error(), "unsupported sign bytes version")
+		err = tc.signerClient.SignProposal(tc.chainID, proposal.ToProto())
+		require.IsType(t, &RemoteSignerError{}, err)
+		assert.Contains(t, err.Error(), "unsupported sign bytes version")
+	}
+}
+
 func brokenHandler(privVal types.PrivValidator, request privvalproto.Message,
 	chainID string) (privvalproto.Message, error) {
 	var res privvalproto.Message
@@ -55,6 +55,12 @@ func DefaultValidationRequestHandler(
 			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.SignVoteRequest.GetChainId(), chainID)
 		}
 
+		if err := types.ValidateSignBytesVersion(r.SignVoteRequest.SignBytesVersion); err != nil {
+			res = mustWrapMsg(&privvalproto.SignedVoteResponse{
+				Vote: cmtproto.Vote{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+			return res, err
+		}
+
 		vote := r.SignVoteRequest.Vote
 
 		err = privVal.SignVote(chainID, vote)
@@ -74,6 +80,12 @@ func DefaultValidationRequestHandler(
 			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.SignProposalRequest.GetChainId(), chainID)
 		}
 
+		if err := types.ValidateSignBytesVersion(r.SignProposalRequest.SignBytesVersion); err != nil {
+			res = mustWrapMsg(&privvalproto.SignedProposalResponse{
+				Proposal: cmtproto.Proposal{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+			return res, err
+		}
+
 		proposal := r.SignProposalRequest.Proposal
 
 		err = privVal.SignProposal(chainID, proposal)
@@ -11,12 +11,16 @@ import (
 var _ p2p.Wrapper = &VoteSetBits{}
 var _ p2p.Wrapper = &VoteSetMaj23{}
 var _ p2p.Wrapper = &Vote{}
+var _ p2p.Wrapper = &VoteBatch{}
 var _ p2p.Wrapper = &ProposalPOL{}
 var _ p2p.Wrapper = &Proposal{}
 var _ p2p.Wrapper = &NewValidBlock{}
 var _ p2p.Wrapper = &NewRoundStep{}
 var _ p2p.Wrapper = &HasVote{}
 var _ p2p.Wrapper = &BlockPart{}
+var _ p2p.Wrapper = &BlockParityPart{}
+var _ p2p.Wrapper = &CommitRequest{}
+var _ p2p.Wrapper = &CommitResponse{}
 
 func (m *VoteSetBits) Wrap() proto.Message {
 	cm := &Message{}
@@ -43,12 +47,24 @@ func (m *Vote) Wrap() proto.Message {
 	return cm
 }
 
+func (m *VoteBatch) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_VoteBatch{VoteBatch: m}
+	return cm
+}
+
 func (m *BlockPart) Wrap() proto.Message {
 	cm := &Message{}
 	cm.Sum = &Message_BlockPart{BlockPart: m}
 	return cm
 }
 
+func (m *BlockParityPart) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_BlockParityPart{BlockParityPart: m}
+	return cm
+}
+
 func (m *ProposalPOL) Wrap() proto.Message {
 	cm := &Message{}
 	cm.Sum = &Message_ProposalPol{ProposalPol: m}
@@ -73,6 +89,18 @@ func (m *NewRoundStep) Wrap() proto.Message {
 	return cm
 }
 
+func (m *CommitRequest) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CommitRequest{CommitRequest: m}
+	return cm
+}
+
+func (m *CommitResponse) Wrap() proto.Message {
+	cm := &Message{}
+	cm.Sum = &Message_CommitResponse{CommitResponse: m}
+	return cm
+}
+
 // Unwrap implements the p2p Wrapper interface and unwraps a wrapped consensus
 // proto message.
 func (m *Message) Unwrap() (proto.Message, error) {
@@ -92,9 +120,15 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_BlockPart:
 		return m.GetBlockPart(), nil
 
+	case *Message_BlockParityPart:
+		return m.GetBlockParityPart(), nil
+
 	case *Message_Vote:
 		return m.GetVote(), nil
 
+	case *Message_VoteBatch:
+		return m.GetVoteBatch(), nil
+
 	case *Message_HasVote:
 		return m.GetHasVote(), nil
 
@@ -104,6 +138,12 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_CommitRequest:
+		return m.GetCommitRequest(), nil
+
+	case *Message_CommitResponse:
+		return m.GetCommitResponse(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -0,0 +1,96 @@
+package consensus
+
+import (
+	"sync"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+// The consensus messages received from peers, and the votes and block parts,
+// received at the highest rate, are taken from pools rather than allocated.
+var (
+	messagePool = sync.Pool{
+		New: func() interface{} { return new(Message) },
+	}
+	votePool = sync.Pool{
+		New: func() interface{} { return &Message_Vote{Vote: &Vote{Vote: new(types.Vote)}} },
+	}
+	blockPartPool = sync.Pool{
+		New: func() interface{} { return &Message_BlockPart{BlockPart: new(BlockPart)} },
+	}
+)
+
+// AcquireMessage returns an empty Message from the pool.
+func AcquireMessage() *Message {
+	return messagePool.Get().(*Message)
+}
+
+// ReleaseMessage resets the message, and the vote or block part it holds, and
+// returns them to the pool. They must not be used afterwards.
+func ReleaseMessage(m *Message) {
+	switch sum := m.Sum.(type) {
+	case *Message_Vote:
+		if sum.Vote != nil {
+			// keep the inner vote, reset so that no byte slice is reused
+			vote := sum.Vote.Vote
+			if vote == nil {
+				vote = new(types.Vote)
+			}
+			*vote = types.Vote{}
+			*sum.Vote = Vote{Vote: vote}
+			votePool.Put(sum)
+		}
+	case *Message_BlockPart:
+		if sum.BlockPart != nil {
+			*sum.BlockPart = BlockPart{}
+			blockPartPool.Put(sum)
+		}
+	}
+	*m = Message{}
+	messagePool.Put(m)
+}
+
+// UnmarshalPooled implements p2p.PooledUnmarshaler, decoding the votes and
+// block parts into the pooled ones.
+func (*Message) UnmarshalPooled(bz []byte) (proto.Message, error) {
+	m := AcquireMessage()
+	if err := m.unmarshalPooled(bz); err != nil {
+		ReleaseMessage(m)
+		return nil, err
+	}
+	return m, nil
+}
+
+// Release implements p2p.PooledUnmarshaler.
+func (*Message) Release(msg proto.Message) {
+	ReleaseMessage(msg.(*Message))
+}
+
+// unmarshalPooled decodes a message made of a single vote or block part into
+// the pooled ones, and any other message with Unmarshal.
+func (m *Message) unmarshalPooled(bz []byte) error {
+	key, n := proto.DecodeVarint(bz)
+	if n == 0 || key&0x7 != proto.WireBytes {
+		return m.Unmarshal(bz)
+	}
+	length, l := proto.DecodeVarint(bz[n:])
+	if l == 0 || length != uint64(len(bz)-n-l) {
+		return m.Unmarshal(bz)
+	}
+	payload := bz[n+l:]
+
+	switch key >> 3 {
+	case 5:
+		part := blockPartPool.Get().(*Message_BlockPart)
+		m.Sum = part
+		return part.BlockPart.Unmarshal(payload)
+	case 6:
+		vote := votePool.Get().(*Message_Vote)
+		m.Sum = vote
+		return vote.Vote.Unmarshal(payload)
+	default:
+		return m.Unmarshal(bz)
+	}
+}
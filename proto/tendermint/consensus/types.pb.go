@@ -349,6 +349,86 @@ func (m *BlockPart) GetPart() types.Part {
 	return types.Part{}
 }
 
+// BlockParityPart is sent when gossipping a parity part of the erasure coding
+// of the parts of the proposed block. parity_header commits to the parity
+// parts, and last_part_size is the size of the last part of the block, which
+// is padded for the erasure coding.
+type BlockParityPart struct {
+	Height       int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round        int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	ParityHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=parity_header,json=parityHeader,proto3" json:"parity_header"`
+	LastPartSize uint32              `protobuf:"varint,4,opt,name=last_part_size,json=lastPartSize,proto3" json:"last_part_size,omitempty"`
+	Part         types.Part          `protobuf:"bytes,5,opt,name=part,proto3" json:"part"`
+}
+
+func (m *BlockParityPart) Reset()         { *m = BlockParityPart{} }
+func (m *BlockParityPart) String() string { return proto.CompactTextString(m) }
+func (*BlockParityPart) ProtoMessage()    {}
+func (*BlockParityPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{5}
+}
+func (m *BlockParityPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockParityPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockParityPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockParityPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockParityPart.Merge(m, src)
+}
+func (m *BlockParityPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockParityPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockParityPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockParityPart proto.InternalMessageInfo
+
+func (m *BlockParityPart) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetParityHeader() types.PartSetHeader {
+	if m != nil {
+		return m.ParityHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *BlockParityPart) GetLastPartSize() uint32 {
+	if m != nil {
+		return m.LastPartSize
+	}
+	return 0
+}
+
+func (m *BlockParityPart) GetPart() types.Part {
+	if m != nil {
+		return m.Part
+	}
+	return types.Part{}
+}
+
 // Vote is sent when voting for a proposal (or lack thereof).
 type Vote struct {
 	Vote *types.Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
@@ -358,7 +438,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{5}
+	return fileDescriptor_81a22d2efc008981, []int{6}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -394,6 +474,51 @@ func (m *Vote) GetVote() *types.Vote {
 	return nil
 }
 
+// VoteBatch is sent to gossip several votes in a single message.
+type VoteBatch struct {
+	Votes []*types.Vote `protobuf:"bytes,1,rep,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (m *VoteBatch) Reset()         { *m = VoteBatch{} }
+func (m *VoteBatch) String() string { return proto.CompactTextString(m) }
+func (*VoteBatch) ProtoMessage()    {}
+func (*VoteBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{7}
+}
+func (m *VoteBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VoteBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VoteBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VoteBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoteBatch.Merge(m, src)
+}
+func (m *VoteBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *VoteBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoteBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoteBatch proto.InternalMessageInfo
+
+func (m *VoteBatch) GetVotes() []*types.Vote {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
 // HasVote is sent to indicate that a particular vote has been received.
 type HasVote struct {
 	Height int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -406,7 +531,7 @@ func (m *HasVote) Reset()         { *m = HasVote{} }
 func (m *HasVote) String() string { return proto.CompactTextString(m) }
 func (*HasVote) ProtoMessage()    {}
 func (*HasVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{6}
+	return fileDescriptor_81a22d2efc008981, []int{8}
 }
 func (m *HasVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -475,7 +600,7 @@ func (m *VoteSetMaj23) Reset()         { *m = VoteSetMaj23{} }
 func (m *VoteSetMaj23) String() string { return proto.CompactTextString(m) }
 func (*VoteSetMaj23) ProtoMessage()    {}
 func (*VoteSetMaj23) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{7}
+	return fileDescriptor_81a22d2efc008981, []int{9}
 }
 func (m *VoteSetMaj23) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -545,7 +670,7 @@ func (m *VoteSetBits) Reset()         { *m = VoteSetBits{} }
 func (m *VoteSetBits) String() string { return proto.CompactTextString(m) }
 func (*VoteSetBits) ProtoMessage()    {}
 func (*VoteSetBits) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{8}
+	return fileDescriptor_81a22d2efc008981, []int{10}
 }
 func (m *VoteSetBits) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -609,6 +734,97 @@ func (m *VoteSetBits) GetVotes() bits.BitArray {
 	return bits.BitArray{}
 }
 
+// CommitRequest is sent by a node lagging behind a peer to get the commit of
+// its current height in a single message.
+type CommitRequest struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *CommitRequest) Reset()         { *m = CommitRequest{} }
+func (m *CommitRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitRequest) ProtoMessage()    {}
+func (*CommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{11}
+}
+func (m *CommitRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitRequest.Merge(m, src)
+}
+func (m *CommitRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitRequest proto.InternalMessageInfo
+
+func (m *CommitRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// CommitResponse is sent in response to a CommitRequest.
+type CommitResponse struct {
+	Commit *types.Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (m *CommitResponse) Reset()         { *m = CommitResponse{} }
+func (m *CommitResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitResponse) ProtoMessage()    {}
+func (*CommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{12}
+}
+func (m *CommitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitResponse.Merge(m, src)
+}
+func (m *CommitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitResponse proto.InternalMessageInfo
+
+func (m *CommitResponse) GetCommit() *types.Commit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_NewRoundStep
@@ -620,6 +836,10 @@ type Message struct {
 	//	*Message_HasVote
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
+	//	*Message_VoteBatch
+	//	*Message_CommitRequest
+	//	*Message_CommitResponse
+	//	*Message_BlockParityPart
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -627,7 +847,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{9}
+	return fileDescriptor_81a22d2efc008981, []int{13}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -689,16 +909,32 @@ type Message_VoteSetMaj23 struct {
 type Message_VoteSetBits struct {
 	VoteSetBits *VoteSetBits `protobuf:"bytes,9,opt,name=vote_set_bits,json=voteSetBits,proto3,oneof" json:"vote_set_bits,omitempty"`
 }
+type Message_VoteBatch struct {
+	VoteBatch *VoteBatch `protobuf:"bytes,10,opt,name=vote_batch,json=voteBatch,proto3,oneof" json:"vote_batch,omitempty"`
+}
+type Message_CommitRequest struct {
+	CommitRequest *CommitRequest `protobuf:"bytes,11,opt,name=commit_request,json=commitRequest,proto3,oneof" json:"commit_request,omitempty"`
+}
+type Message_CommitResponse struct {
+	CommitResponse *CommitResponse `protobuf:"bytes,12,opt,name=commit_response,json=commitResponse,proto3,oneof" json:"commit_response,omitempty"`
+}
+type Message_BlockParityPart struct {
+	BlockParityPart *BlockParityPart `protobuf:"bytes,13,opt,name=block_parity_part,json=blockParityPart,proto3,oneof" json:"block_parity_part,omitempty"`
+}
 
-func (*Message_NewRoundStep) isMessage_Sum()  {}
-func (*Message_NewValidBlock) isMessage_Sum() {}
-func (*Message_Proposal) isMessage_Sum()      {}
-func (*Message_ProposalPol) isMessage_Sum()   {}
-func (*Message_BlockPart) isMessage_Sum()     {}
-func (*Message_Vote) isMessage_Sum()          {}
-func (*Message_HasVote) isMessage_Sum()       {}
-func (*Message_VoteSetMaj23) isMessage_Sum()  {}
-func (*Message_VoteSetBits) isMessage_Sum()   {}
+func (*Message_NewRoundStep) isMessage_Sum()    {}
+func (*Message_NewValidBlock) isMessage_Sum()   {}
+func (*Message_Proposal) isMessage_Sum()        {}
+func (*Message_ProposalPol) isMessage_Sum()     {}
+func (*Message_BlockPart) isMessage_Sum()       {}
+func (*Message_Vote) isMessage_Sum()            {}
+func (*Message_HasVote) isMessage_Sum()         {}
+func (*Message_VoteSetMaj23) isMessage_Sum()    {}
+func (*Message_VoteSetBits) isMessage_Sum()     {}
+func (*Message_VoteBatch) isMessage_Sum()       {}
+func (*Message_CommitRequest) isMessage_Sum()   {}
+func (*Message_CommitResponse) isMessage_Sum()  {}
+func (*Message_BlockParityPart) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -770,6 +1006,34 @@ func (m *Message) GetVoteSetBits() *VoteSetBits {
 	return nil
 }
 
+func (m *Message) GetVoteBatch() *VoteBatch {
+	if x, ok := m.GetSum().(*Message_VoteBatch); ok {
+		return x.VoteBatch
+	}
+	return nil
+}
+
+func (m *Message) GetCommitRequest() *CommitRequest {
+	if x, ok := m.GetSum().(*Message_CommitRequest); ok {
+		return x.CommitRequest
+	}
+	return nil
+}
+
+func (m *Message) GetCommitResponse() *CommitResponse {
+	if x, ok := m.GetSum().(*Message_CommitResponse); ok {
+		return x.CommitResponse
+	}
+	return nil
+}
+
+func (m *Message) GetBlockParityPart() *BlockParityPart {
+	if x, ok := m.GetSum().(*Message_BlockParityPart); ok {
+		return x.BlockParityPart
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -782,6 +1046,10 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_HasVote)(nil),
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
+		(*Message_VoteBatch)(nil),
+		(*Message_CommitRequest)(nil),
+		(*Message_CommitResponse)(nil),
+		(*Message_BlockParityPart)(nil),
 	}
 }
 
@@ -791,71 +1059,86 @@ func init() {
 	proto.RegisterType((*Proposal)(nil), "tendermint.consensus.Proposal")
 	proto.RegisterType((*ProposalPOL)(nil), "tendermint.consensus.ProposalPOL")
 	proto.RegisterType((*BlockPart)(nil), "tendermint.consensus.BlockPart")
+	proto.RegisterType((*BlockParityPart)(nil), "tendermint.consensus.BlockParityPart")
 	proto.RegisterType((*Vote)(nil), "tendermint.consensus.Vote")
+	proto.RegisterType((*VoteBatch)(nil), "tendermint.consensus.VoteBatch")
 	proto.RegisterType((*HasVote)(nil), "tendermint.consensus.HasVote")
 	proto.RegisterType((*VoteSetMaj23)(nil), "tendermint.consensus.VoteSetMaj23")
 	proto.RegisterType((*VoteSetBits)(nil), "tendermint.consensus.VoteSetBits")
+	proto.RegisterType((*CommitRequest)(nil), "tendermint.consensus.CommitRequest")
+	proto.RegisterType((*CommitResponse)(nil), "tendermint.consensus.CommitResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.consensus.Message")
 }
 
 func init() { proto.RegisterFile("tendermint/consensus/types.proto", fileDescriptor_81a22d2efc008981) }
 
 var fileDescriptor_81a22d2efc008981 = []byte{
-	// 856 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xcd, 0x8e, 0xe3, 0x44,
-	0x10, 0xb6, 0x19, 0x67, 0x92, 0x94, 0x93, 0x19, 0x68, 0xcd, 0xae, 0x42, 0x80, 0x24, 0x98, 0xcb,
-	0x08, 0x21, 0x07, 0x65, 0x0e, 0x2b, 0xad, 0x90, 0x00, 0xf3, 0xb3, 0xde, 0xd5, 0x66, 0x37, 0x38,
-	0xab, 0x15, 0xe2, 0x62, 0x39, 0x71, 0x93, 0x34, 0x1b, 0xbb, 0x2d, 0x77, 0x27, 0xc3, 0x5c, 0x79,
-	0x02, 0x1e, 0x80, 0xd7, 0x40, 0xe2, 0x11, 0xe6, 0x38, 0x47, 0x4e, 0x23, 0x94, 0x79, 0x04, 0x04,
-	0x67, 0xd4, 0xed, 0x4e, 0xec, 0x30, 0x9e, 0x81, 0xb9, 0x20, 0x71, 0xeb, 0x4e, 0x55, 0x7d, 0x5d,
-	0xf5, 0x55, 0xd5, 0x17, 0x43, 0x8f, 0xe3, 0x38, 0xc4, 0x69, 0x44, 0x62, 0xde, 0x9f, 0xd2, 0x98,
-	0xe1, 0x98, 0x2d, 0x59, 0x9f, 0x9f, 0x25, 0x98, 0xd9, 0x49, 0x4a, 0x39, 0x45, 0x47, 0xb9, 0x87,
-	0xbd, 0xf5, 0x68, 0x1f, 0xcd, 0xe8, 0x8c, 0x4a, 0x87, 0xbe, 0x38, 0x65, 0xbe, 0xed, 0xb7, 0x0b,
-	0x68, 0x12, 0xa3, 0x88, 0xd4, 0x2e, 0xbe, 0xb5, 0x20, 0x13, 0xd6, 0x9f, 0x10, 0xbe, 0xe3, 0x61,
-	0xfd, 0xac, 0x43, 0xe3, 0x19, 0x3e, 0xf5, 0xe8, 0x32, 0x0e, 0xc7, 0x1c, 0x27, 0xe8, 0x3e, 0xec,
-	0xcf, 0x31, 0x99, 0xcd, 0x79, 0x4b, 0xef, 0xe9, 0xc7, 0x7b, 0x9e, 0xba, 0xa1, 0x23, 0xa8, 0xa4,
-	0xc2, 0xa9, 0xf5, 0x5a, 0x4f, 0x3f, 0xae, 0x78, 0xd9, 0x05, 0x21, 0x30, 0x18, 0xc7, 0x49, 0x6b,
-	0xaf, 0xa7, 0x1f, 0x37, 0x3d, 0x79, 0x46, 0x0f, 0xa0, 0xc5, 0xf0, 0x94, 0xc6, 0x21, 0xf3, 0x19,
-	0x89, 0xa7, 0xd8, 0x67, 0x3c, 0x48, 0xb9, 0xcf, 0x49, 0x84, 0x5b, 0x86, 0xc4, 0xbc, 0xa7, 0xec,
-	0x63, 0x61, 0x1e, 0x0b, 0xeb, 0x0b, 0x12, 0x61, 0xf4, 0x3e, 0xbc, 0xb1, 0x08, 0x18, 0xf7, 0xa7,
-	0x34, 0x8a, 0x08, 0xf7, 0xb3, 0xe7, 0x2a, 0xf2, 0xb9, 0x43, 0x61, 0xf8, 0x4c, 0xfe, 0x2e, 0x53,
-	0xb5, 0xfe, 0xd0, 0xa1, 0xf9, 0x0c, 0x9f, 0xbe, 0x0c, 0x16, 0x24, 0x74, 0x16, 0x74, 0xfa, 0xea,
-	0x8e, 0x89, 0x7f, 0x0d, 0xf7, 0x26, 0x22, 0xcc, 0x4f, 0x44, 0x6e, 0x0c, 0x73, 0x7f, 0x8e, 0x83,
-	0x10, 0xa7, 0xb2, 0x12, 0x73, 0xd0, 0xb5, 0x0b, 0x3d, 0xc8, 0xf8, 0x1a, 0x05, 0x29, 0x1f, 0x63,
-	0xee, 0x4a, 0x37, 0xc7, 0x38, 0xbf, 0xec, 0x6a, 0x1e, 0x92, 0x18, 0x3b, 0x16, 0xf4, 0x31, 0x98,
-	0x39, 0x32, 0x93, 0x15, 0x9b, 0x83, 0x4e, 0x11, 0x4f, 0x74, 0xc2, 0x16, 0x9d, 0xb0, 0x1d, 0xc2,
-	0x3f, 0x4d, 0xd3, 0xe0, 0xcc, 0x83, 0x2d, 0x10, 0x43, 0x6f, 0x41, 0x9d, 0x30, 0x45, 0x82, 0x2c,
-	0xbf, 0xe6, 0xd5, 0x08, 0xcb, 0x8a, 0xb7, 0x5c, 0xa8, 0x8d, 0x52, 0x9a, 0x50, 0x16, 0x2c, 0xd0,
-	0x47, 0x50, 0x4b, 0xd4, 0x59, 0xd6, 0x6c, 0x0e, 0xda, 0x25, 0x69, 0x2b, 0x0f, 0x95, 0xf1, 0x36,
-	0xc2, 0xfa, 0x49, 0x07, 0x73, 0x63, 0x1c, 0x3d, 0x7f, 0x7a, 0x23, 0x7f, 0x1f, 0x00, 0xda, 0xc4,
-	0xf8, 0x09, 0x5d, 0xf8, 0x45, 0x32, 0x5f, 0xdf, 0x58, 0x46, 0x74, 0x21, 0xfb, 0x82, 0x1e, 0x41,
-	0xa3, 0xe8, 0xad, 0xe8, 0xfc, 0x87, 0xf2, 0x55, 0x6e, 0x66, 0x01, 0xcd, 0x7a, 0x05, 0x75, 0x67,
-	0xc3, 0xc9, 0x1d, 0x7b, 0xfb, 0x21, 0x18, 0x82, 0x7b, 0xf5, 0xf6, 0xfd, 0xf2, 0x56, 0xaa, 0x37,
-	0xa5, 0xa7, 0x35, 0x00, 0xe3, 0x25, 0xe5, 0x62, 0x02, 0x8d, 0x15, 0xe5, 0x58, 0xb1, 0x59, 0x12,
-	0x29, 0xbc, 0x3c, 0xe9, 0x63, 0xfd, 0xa0, 0x43, 0xd5, 0x0d, 0x98, 0x8c, 0xbb, 0x5b, 0x7e, 0x27,
-	0x60, 0x08, 0x34, 0x99, 0xdf, 0x41, 0xd9, 0xa8, 0x8d, 0xc9, 0x2c, 0xc6, 0xe1, 0x90, 0xcd, 0x5e,
-	0x9c, 0x25, 0xd8, 0x93, 0xce, 0x02, 0x8a, 0xc4, 0x21, 0xfe, 0x5e, 0x0e, 0x54, 0xc5, 0xcb, 0x2e,
-	0xd6, 0x2f, 0x3a, 0x34, 0x44, 0x06, 0x63, 0xcc, 0x87, 0xc1, 0x77, 0x83, 0x93, 0xff, 0x22, 0x93,
-	0x2f, 0xa0, 0x96, 0x0d, 0x38, 0x09, 0xd5, 0x74, 0xbf, 0x79, 0x3d, 0x50, 0xf6, 0xee, 0xf1, 0xe7,
-	0xce, 0xa1, 0x60, 0x79, 0x7d, 0xd9, 0xad, 0xaa, 0x1f, 0xbc, 0xaa, 0x8c, 0x7d, 0x1c, 0x5a, 0xbf,
-	0xeb, 0x60, 0xaa, 0xd4, 0x1d, 0xc2, 0xd9, 0xff, 0x27, 0x73, 0xf4, 0x10, 0x2a, 0x62, 0x02, 0x98,
-	0x5c, 0xce, 0x7f, 0x3b, 0xdc, 0x59, 0x88, 0xf5, 0xa7, 0x01, 0xd5, 0x21, 0x66, 0x2c, 0x98, 0x61,
-	0xf4, 0x04, 0x0e, 0x62, 0x7c, 0x9a, 0x2d, 0x94, 0x2f, 0x65, 0x34, 0x9b, 0x3b, 0xcb, 0x2e, 0xfb,
-	0x03, 0xb0, 0x8b, 0x32, 0xed, 0x6a, 0x5e, 0x23, 0x2e, 0xca, 0xf6, 0x10, 0x0e, 0x05, 0xd6, 0x4a,
-	0xe8, 0xa1, 0x2f, 0x13, 0x95, 0x7c, 0x99, 0x83, 0xf7, 0x6e, 0x04, 0xcb, 0xb5, 0xd3, 0xd5, 0xbc,
-	0x66, 0xbc, 0x23, 0xa6, 0x45, 0x69, 0x29, 0x59, 0xe1, 0x1c, 0x67, 0xa3, 0x20, 0x6e, 0x41, 0x5a,
-	0xd0, 0x97, 0x7f, 0x13, 0x81, 0x8c, 0xeb, 0x77, 0x6f, 0x47, 0x18, 0x3d, 0x7f, 0xea, 0xee, 0x6a,
-	0x00, 0xfa, 0x04, 0x20, 0x97, 0x52, 0xc5, 0x76, 0xb7, 0x1c, 0x65, 0xab, 0x15, 0xae, 0xe6, 0xd5,
-	0xb7, 0x62, 0x2a, 0xa4, 0x40, 0x2e, 0xf4, 0xfe, 0x75, 0x79, 0xcc, 0x63, 0xc5, 0x14, 0xba, 0x5a,
-	0xb6, 0xd6, 0xe8, 0x21, 0xd4, 0xe6, 0x01, 0xf3, 0x65, 0x54, 0x55, 0x46, 0xbd, 0x53, 0x1e, 0xa5,
-	0x76, 0xdf, 0xd5, 0xbc, 0xea, 0x5c, 0xc9, 0xc0, 0x13, 0x38, 0x10, 0x71, 0xf2, 0xef, 0x24, 0x12,
-	0xeb, 0xd8, 0xaa, 0xdd, 0xd6, 0xd0, 0xe2, 0xe2, 0x8a, 0x86, 0xae, 0x8a, 0x8b, 0xfc, 0x08, 0x9a,
-	0x5b, 0x2c, 0x31, 0x4f, 0xad, 0xfa, 0x6d, 0x24, 0x16, 0x16, 0x49, 0x90, 0xb8, 0xca, 0xaf, 0x4e,
-	0x05, 0xf6, 0xd8, 0x32, 0x72, 0xbe, 0x3a, 0x5f, 0x77, 0xf4, 0x8b, 0x75, 0x47, 0xff, 0x6d, 0xdd,
-	0xd1, 0x7f, 0xbc, 0xea, 0x68, 0x17, 0x57, 0x1d, 0xed, 0xd7, 0xab, 0x8e, 0xf6, 0xcd, 0x83, 0x19,
-	0xe1, 0xf3, 0xe5, 0xc4, 0x9e, 0xd2, 0xa8, 0x3f, 0xa5, 0x11, 0xe6, 0x93, 0x6f, 0x79, 0x7e, 0xc8,
-	0xbe, 0x38, 0xca, 0xbe, 0x59, 0x26, 0xfb, 0xd2, 0x76, 0xf2, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xfe, 0x06, 0x66, 0x65, 0xd2, 0x08, 0x00, 0x00,
+	// 1037 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0xb6, 0xd9, 0x64, 0x93, 0x3d, 0xf9, 0xa3, 0xa3, 0xb6, 0x32, 0x0b, 0x64, 0x83, 0x29, 0x62,
+	0x85, 0xaa, 0x04, 0x65, 0x2f, 0x2a, 0x2a, 0x24, 0xc0, 0xfc, 0xd4, 0xad, 0x76, 0xbb, 0xc1, 0xa9,
+	0x2a, 0xc4, 0x8d, 0xe5, 0x38, 0x43, 0x32, 0x34, 0xb1, 0x4d, 0x66, 0x92, 0x65, 0x7b, 0xc9, 0x13,
+	0xf0, 0x00, 0x3c, 0x02, 0xb7, 0x48, 0x3c, 0x42, 0x2f, 0x7b, 0xc9, 0x55, 0x85, 0x76, 0x1f, 0x01,
+	0x71, 0x8f, 0xe6, 0xcc, 0xd8, 0x71, 0xd8, 0x6c, 0xd4, 0xed, 0x05, 0x12, 0x77, 0x9e, 0x99, 0xef,
+	0x7c, 0x73, 0xe6, 0xfc, 0x7c, 0xc7, 0xd0, 0x12, 0x34, 0x1a, 0xd2, 0xd9, 0x94, 0x45, 0xa2, 0x13,
+	0xc6, 0x11, 0xa7, 0x11, 0x9f, 0xf3, 0x8e, 0x38, 0x4d, 0x28, 0x6f, 0x27, 0xb3, 0x58, 0xc4, 0xe4,
+	0xfa, 0x12, 0xd1, 0xce, 0x10, 0xbb, 0xd7, 0x47, 0xf1, 0x28, 0x46, 0x40, 0x47, 0x7e, 0x29, 0xec,
+	0xee, 0x5b, 0x39, 0x36, 0xe4, 0xc8, 0x33, 0xed, 0xe6, 0xef, 0x9a, 0xb0, 0x01, 0xef, 0x0c, 0x98,
+	0x58, 0x41, 0xd8, 0xbf, 0x99, 0x50, 0x7d, 0x48, 0x4f, 0xbc, 0x78, 0x1e, 0x0d, 0xfb, 0x82, 0x26,
+	0xe4, 0x26, 0x6c, 0x8f, 0x29, 0x1b, 0x8d, 0x85, 0x65, 0xb6, 0xcc, 0xfd, 0x2d, 0x4f, 0xaf, 0xc8,
+	0x75, 0x28, 0xce, 0x24, 0xc8, 0x7a, 0xad, 0x65, 0xee, 0x17, 0x3d, 0xb5, 0x20, 0x04, 0x0a, 0x5c,
+	0xd0, 0xc4, 0xda, 0x6a, 0x99, 0xfb, 0x35, 0x0f, 0xbf, 0xc9, 0x1d, 0xb0, 0x38, 0x0d, 0xe3, 0x68,
+	0xc8, 0x7d, 0xce, 0xa2, 0x90, 0xfa, 0x5c, 0x04, 0x33, 0xe1, 0x0b, 0x36, 0xa5, 0x56, 0x01, 0x39,
+	0x6f, 0xe8, 0xf3, 0xbe, 0x3c, 0xee, 0xcb, 0xd3, 0x47, 0x6c, 0x4a, 0xc9, 0x07, 0x70, 0x6d, 0x12,
+	0x70, 0xe1, 0x87, 0xf1, 0x74, 0xca, 0x84, 0xaf, 0xae, 0x2b, 0xe2, 0x75, 0x0d, 0x79, 0xf0, 0x39,
+	0xee, 0xa3, 0xab, 0xf6, 0xdf, 0x26, 0xd4, 0x1e, 0xd2, 0x93, 0xc7, 0xc1, 0x84, 0x0d, 0x9d, 0x49,
+	0x1c, 0x3e, 0xb9, 0xa2, 0xe3, 0xdf, 0xc0, 0x8d, 0x81, 0x34, 0xf3, 0x13, 0xe9, 0x1b, 0xa7, 0xc2,
+	0x1f, 0xd3, 0x60, 0x48, 0x67, 0xf8, 0x92, 0x4a, 0x77, 0xaf, 0x9d, 0xcb, 0x81, 0x8a, 0x57, 0x2f,
+	0x98, 0x89, 0x3e, 0x15, 0x2e, 0xc2, 0x9c, 0xc2, 0xb3, 0x17, 0x7b, 0x86, 0x47, 0x90, 0x63, 0xe5,
+	0x84, 0x7c, 0x02, 0x95, 0x25, 0x33, 0xc7, 0x17, 0x57, 0xba, 0xcd, 0x3c, 0x9f, 0xcc, 0x44, 0x5b,
+	0x66, 0xa2, 0xed, 0x30, 0xf1, 0xd9, 0x6c, 0x16, 0x9c, 0x7a, 0x90, 0x11, 0x71, 0xf2, 0x26, 0xec,
+	0x30, 0xae, 0x83, 0x80, 0xcf, 0x2f, 0x7b, 0x65, 0xc6, 0xd5, 0xe3, 0x6d, 0x17, 0xca, 0xbd, 0x59,
+	0x9c, 0xc4, 0x3c, 0x98, 0x90, 0x8f, 0xa1, 0x9c, 0xe8, 0x6f, 0x7c, 0x73, 0xa5, 0xbb, 0xbb, 0xc6,
+	0x6d, 0x8d, 0xd0, 0x1e, 0x67, 0x16, 0xf6, 0x2f, 0x26, 0x54, 0xd2, 0xc3, 0xde, 0xf1, 0xe1, 0xa5,
+	0xf1, 0xbb, 0x0d, 0x24, 0xb5, 0xf1, 0x93, 0x78, 0xe2, 0xe7, 0x83, 0xf9, 0x7a, 0x7a, 0xd2, 0x8b,
+	0x27, 0x98, 0x17, 0x72, 0x0f, 0xaa, 0x79, 0xb4, 0xb5, 0xf5, 0x32, 0xcf, 0xd7, 0xbe, 0x55, 0x72,
+	0x6c, 0xf6, 0x13, 0xd8, 0x71, 0xd2, 0x98, 0x5c, 0x31, 0xb7, 0x1f, 0x42, 0x41, 0xc6, 0x5e, 0xdf,
+	0x7d, 0x73, 0x7d, 0x2a, 0xf5, 0x9d, 0x88, 0xb4, 0xcf, 0x4d, 0x68, 0xa4, 0xb7, 0x31, 0x71, 0xfa,
+	0x0a, 0x77, 0x3e, 0x80, 0x5a, 0x82, 0xb6, 0xaf, 0x54, 0x47, 0x55, 0x65, 0xab, 0x2b, 0xe8, 0x16,
+	0xd4, 0xb1, 0x0f, 0x54, 0x69, 0xb2, 0xa7, 0xaa, 0x6d, 0x6a, 0x5e, 0x55, 0xee, 0xa2, 0x39, 0x7b,
+	0x4a, 0xb3, 0x57, 0x16, 0x5f, 0xfa, 0x95, 0x5d, 0x28, 0x3c, 0x8e, 0x85, 0xec, 0xb3, 0xc2, 0x22,
+	0x16, 0xd4, 0x32, 0x2f, 0xb3, 0x94, 0x28, 0x0f, 0x31, 0xf6, 0x47, 0xb0, 0x23, 0x57, 0x4e, 0x20,
+	0xc2, 0x31, 0xb9, 0x0d, 0x45, 0xb9, 0xc9, 0x2d, 0xb3, 0xb5, 0xb5, 0xc1, 0x52, 0x81, 0xec, 0x9f,
+	0x4c, 0x28, 0xb9, 0x01, 0xc7, 0x2b, 0xaf, 0x16, 0xcc, 0x03, 0x28, 0x48, 0x3a, 0x8c, 0x61, 0x7d,
+	0x5d, 0x0c, 0xfb, 0x6c, 0x14, 0xd1, 0xe1, 0x11, 0x1f, 0x3d, 0x3a, 0x4d, 0xa8, 0x87, 0x60, 0x49,
+	0xc5, 0xa2, 0x21, 0xfd, 0x11, 0x83, 0x55, 0xf4, 0xd4, 0xc2, 0xfe, 0xdd, 0x84, 0xaa, 0xf4, 0xa0,
+	0x4f, 0xc5, 0x51, 0xf0, 0x7d, 0xf7, 0xe0, 0xbf, 0xf0, 0xe4, 0x4b, 0x28, 0x2b, 0x05, 0x60, 0x43,
+	0xdd, 0xfe, 0x6f, 0x5c, 0x34, 0xc4, 0x72, 0xbb, 0xff, 0x85, 0xd3, 0x90, 0x09, 0x3a, 0x7b, 0xb1,
+	0x57, 0xd2, 0x1b, 0x5e, 0x09, 0x6d, 0xef, 0x0f, 0xed, 0xbf, 0x4c, 0xa8, 0x68, 0xd7, 0x1d, 0x26,
+	0xf8, 0xff, 0xc7, 0x73, 0x72, 0x37, 0xad, 0x93, 0xe2, 0x15, 0xba, 0x5f, 0x57, 0xcd, 0xfb, 0x50,
+	0xd3, 0x3a, 0x4f, 0x7f, 0x98, 0x53, 0x7e, 0x69, 0x1f, 0xda, 0x0e, 0xd4, 0x53, 0x20, 0x4f, 0xe2,
+	0x88, 0xcb, 0x8e, 0xd8, 0xd6, 0xaa, 0xa9, 0x2a, 0xdb, 0xba, 0xe8, 0xbb, 0xb6, 0xd0, 0x38, 0xfb,
+	0xd7, 0x12, 0x94, 0x8e, 0x28, 0xe7, 0xc1, 0x88, 0x92, 0x07, 0x50, 0x8f, 0xe8, 0x89, 0x92, 0x37,
+	0x1f, 0x87, 0x9a, 0x62, 0xb1, 0xdb, 0xeb, 0xc6, 0x71, 0x3b, 0x3f, 0x34, 0x5d, 0xc3, 0xab, 0x46,
+	0xb9, 0x35, 0x39, 0x82, 0x86, 0xe4, 0x5a, 0xc8, 0xe9, 0xe4, 0x63, 0x54, 0x30, 0x39, 0x95, 0xee,
+	0xbb, 0x97, 0x92, 0x2d, 0x27, 0x99, 0x6b, 0x78, 0xb5, 0x28, 0xbf, 0xb1, 0x22, 0xf4, 0x6b, 0x04,
+	0x75, 0xc9, 0x93, 0xea, 0xb9, 0x9b, 0x13, 0x7a, 0xf2, 0xd5, 0xbf, 0x24, 0x59, 0x25, 0xf6, 0x9d,
+	0xcd, 0x0c, 0xbd, 0xe3, 0x43, 0x77, 0x55, 0x91, 0xc9, 0xa7, 0x00, 0xcb, 0xc1, 0xa6, 0x53, 0xbb,
+	0xb7, 0x9e, 0x25, 0x53, 0x6e, 0xd7, 0xf0, 0x76, 0xb2, 0xd1, 0x26, 0x25, 0x0b, 0x85, 0x67, 0xfb,
+	0xe2, 0xb0, 0x5a, 0xda, 0xca, 0x92, 0x77, 0x0d, 0x25, 0x3f, 0xe4, 0x2e, 0x94, 0xc7, 0x01, 0xf7,
+	0xd1, 0xaa, 0x84, 0x56, 0x6f, 0xaf, 0xb7, 0xd2, 0x42, 0xe3, 0x1a, 0x5e, 0x69, 0xac, 0x3e, 0x65,
+	0x42, 0xa5, 0x1d, 0x0e, 0xf7, 0xa9, 0xec, 0x7d, 0xab, 0xbc, 0x29, 0xa1, 0x79, 0x95, 0x90, 0x09,
+	0x5d, 0xe4, 0xd6, 0xe4, 0x1e, 0xd4, 0x32, 0x2e, 0x59, 0xbc, 0xd6, 0xce, 0xa6, 0x20, 0xe6, 0xba,
+	0x56, 0x06, 0x71, 0xb1, 0x5c, 0xca, 0x20, 0x22, 0xd1, 0x40, 0x0a, 0xaa, 0x05, 0x9b, 0x82, 0x98,
+	0xe9, 0xae, 0x0c, 0xe2, 0x22, 0x5d, 0x90, 0x43, 0xa8, 0xa7, 0x3f, 0x48, 0xaa, 0x43, 0xac, 0xca,
+	0xa6, 0xd2, 0x5a, 0x69, 0x26, 0x59, 0x5a, 0xe1, 0x4a, 0x77, 0x1d, 0x43, 0x23, 0x63, 0x53, 0x6d,
+	0x64, 0x55, 0x91, 0xee, 0xd6, 0x66, 0x3a, 0x85, 0x75, 0x0d, 0xaf, 0x1e, 0xae, 0x36, 0x61, 0x1f,
+	0xae, 0x65, 0x55, 0x22, 0xc7, 0x21, 0x16, 0x4b, 0x0d, 0x29, 0xdf, 0xdb, 0x5c, 0x2c, 0x7a, 0xf0,
+	0xba, 0x86, 0xd7, 0x18, 0xac, 0x6e, 0x39, 0x45, 0xd8, 0xe2, 0xf3, 0xa9, 0xf3, 0xf5, 0xb3, 0xb3,
+	0xa6, 0xf9, 0xfc, 0xac, 0x69, 0xfe, 0x79, 0xd6, 0x34, 0x7f, 0x3e, 0x6f, 0x1a, 0xcf, 0xcf, 0x9b,
+	0xc6, 0x1f, 0xe7, 0x4d, 0xe3, 0xdb, 0x3b, 0x23, 0x26, 0xc6, 0xf3, 0x41, 0x3b, 0x8c, 0xa7, 0x9d,
+	0x30, 0x9e, 0x52, 0x31, 0xf8, 0x4e, 0x2c, 0x3f, 0xd4, 0x5f, 0xf3, 0xba, 0xff, 0xee, 0xc1, 0x36,
+	0x9e, 0x1d, 0xfc, 0x33, 0x00, 0xcd, 0xcb, 0x83, 0x0e, 0x96, 0x0b, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1090,6 +1373,64 @@ func (m *BlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BlockParityPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Part.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if m.LastPartSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastPartSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	{
+		size, err := m.ParityHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Vote) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1125,6 +1466,43 @@ func (m *Vote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *VoteBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VoteBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VoteBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *HasVote) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1274,7 +1652,7 @@ func (m *VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
+func (m *CommitRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1284,31 +1662,94 @@ func (m *Message) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+func (m *CommitRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Sum != nil {
-		{
-			size := m.Sum.Size()
-			i -= size
-			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Message_NewRoundStep) MarshalTo(dAtA []byte) (int, error) {
+func (m *CommitResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Commit != nil {
+		{
+			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_NewRoundStep) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *Message_NewRoundStep) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -1495,6 +1936,90 @@ func (m *Message_VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_VoteBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_VoteBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.VoteBatch != nil {
+		{
+			size, err := m.VoteBatch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CommitRequest != nil {
+		{
+			size, err := m.CommitRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CommitResponse != nil {
+		{
+			size, err := m.CommitResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockParityPart != nil {
+		{
+			size, err := m.BlockParityPart.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1599,6 +2124,28 @@ func (m *BlockPart) Size() (n int) {
 	return n
 }
 
+func (m *BlockParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.ParityHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.LastPartSize != 0 {
+		n += 1 + sovTypes(uint64(m.LastPartSize))
+	}
+	l = m.Part.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func (m *Vote) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1612,6 +2159,21 @@ func (m *Vote) Size() (n int) {
 	return n
 }
 
+func (m *VoteBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *HasVote) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1675,6 +2237,31 @@ func (m *VoteSetBits) Size() (n int) {
 	return n
 }
 
+func (m *CommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *CommitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1795,6 +2382,54 @@ func (m *Message_VoteSetBits) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_VoteBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VoteBatch != nil {
+		l = m.VoteBatch.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CommitRequest != nil {
+		l = m.CommitRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CommitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CommitResponse != nil {
+		l = m.CommitResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockParityPart != nil {
+		l = m.BlockParityPart.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -2449,7 +3084,7 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Vote) Unmarshal(dAtA []byte) error {
+func (m *BlockParityPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2472,15 +3107,53 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockParityPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockParityPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ParityHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2507,16 +3180,235 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &types.Vote{}
-			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ParityHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastPartSize", wireType)
+			}
+			m.LastPartSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastPartSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Vote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &types.Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VoteBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VoteBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VoteBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, &types.Vote{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
@@ -2974,6 +3866,161 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &types.Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3318,6 +4365,146 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_VoteSetBits{v}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &VoteBatch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_VoteBatch{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CommitRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CommitRequest{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CommitResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CommitResponse{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParityPart", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockParityPart{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_BlockParityPart{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9,6 +9,9 @@ import (
 )
 
 var _ p2p.Wrapper = &Txs{}
+var _ p2p.Wrapper = &HaveTx{}
+var _ p2p.Wrapper = &WantTx{}
+var _ p2p.Wrapper = &GossipFilter{}
 var _ p2p.Unwrapper = &Message{}
 
 // Wrap implements the p2p Wrapper interface and wraps a mempool message.
@@ -18,6 +21,27 @@ func (m *Txs) Wrap() proto.Message {
 	return mm
 }
 
+// Wrap implements the p2p Wrapper interface and wraps a mempool message.
+func (m *HaveTx) Wrap() proto.Message {
+	mm := &Message{}
+	mm.Sum = &Message_HaveTx{HaveTx: m}
+	return mm
+}
+
+// Wrap implements the p2p Wrapper interface and wraps a mempool message.
+func (m *WantTx) Wrap() proto.Message {
+	mm := &Message{}
+	mm.Sum = &Message_WantTx{WantTx: m}
+	return mm
+}
+
+// Wrap implements the p2p Wrapper interface and wraps a mempool message.
+func (m *GossipFilter) Wrap() proto.Message {
+	mm := &Message{}
+	mm.Sum = &Message_GossipFilter{GossipFilter: m}
+	return mm
+}
+
 // Unwrap implements the p2p Wrapper interface and unwraps a wrapped mempool
 // message.
 func (m *Message) Unwrap() (proto.Message, error) {
@@ -25,6 +49,15 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_Txs:
 		return m.GetTxs(), nil
 
+	case *Message_HaveTx:
+		return m.GetHaveTx(), nil
+
+	case *Message_WantTx:
+		return m.GetWantTx(), nil
+
+	case *Message_GossipFilter:
+		return m.GetGossipFilter(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -152,6 +152,9 @@ type DefaultNodeInfo struct {
 	Channels        []byte               `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	// channel_versions are the highest versions of the messages of the channels
+	// supported by the node, for the channels with a version above 1.
+	ChannelVersions []ChannelVersion `protobuf:"bytes,9,rep,name=channel_versions,json=channelVersions,proto3" json:"channel_versions"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -243,6 +246,65 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 	return DefaultNodeInfoOther{}
 }
 
+func (m *DefaultNodeInfo) GetChannelVersions() []ChannelVersion {
+	if m != nil {
+		return m.ChannelVersions
+	}
+	return nil
+}
+
+type ChannelVersion struct {
+	ChannelID uint32 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Version   uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *ChannelVersion) Reset()         { *m = ChannelVersion{} }
+func (m *ChannelVersion) String() string { return proto.CompactTextString(m) }
+func (*ChannelVersion) ProtoMessage()    {}
+func (*ChannelVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{3}
+}
+func (m *ChannelVersion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelVersion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChannelVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelVersion.Merge(m, src)
+}
+func (m *ChannelVersion) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelVersion proto.InternalMessageInfo
+
+func (m *ChannelVersion) GetChannelID() uint32 {
+	if m != nil {
+		return m.ChannelID
+	}
+	return 0
+}
+
+func (m *ChannelVersion) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -252,7 +314,7 @@ func (m *DefaultNodeInfoOther) Reset()         { *m = DefaultNodeInfoOther{} }
 func (m *DefaultNodeInfoOther) String() string { return proto.CompactTextString(m) }
 func (*DefaultNodeInfoOther) ProtoMessage()    {}
 func (*DefaultNodeInfoOther) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{3}
+	return fileDescriptor_c8a29e659aeca578, []int{4}
 }
 func (m *DefaultNodeInfoOther) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -299,44 +361,48 @@ func init() {
 	proto.RegisterType((*NetAddress)(nil), "tendermint.p2p.NetAddress")
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*DefaultNodeInfo)(nil), "tendermint.p2p.DefaultNodeInfo")
+	proto.RegisterType((*ChannelVersion)(nil), "tendermint.p2p.ChannelVersion")
 	proto.RegisterType((*DefaultNodeInfoOther)(nil), "tendermint.p2p.DefaultNodeInfoOther")
 }
 
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 483 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x3d, 0x8f, 0xda, 0x40,
-	0x10, 0xc5, 0xc6, 0x7c, 0xdc, 0x10, 0x8e, 0xcb, 0x0a, 0x45, 0x3e, 0x0a, 0x1b, 0xa1, 0x14, 0x54,
-	0xa0, 0x90, 0x2a, 0x5d, 0x42, 0x68, 0x50, 0xa4, 0x8b, 0xb5, 0x8a, 0x52, 0xa4, 0x41, 0xe0, 0x5d,
-	0x60, 0x85, 0xd9, 0x5d, 0xad, 0xf7, 0x12, 0xf2, 0x2f, 0xf2, 0xb3, 0xae, 0xbc, 0x32, 0x95, 0x15,
-	0x99, 0x32, 0x7f, 0x22, 0xf2, 0xae, 0x2f, 0xc7, 0xa1, 0xeb, 0xe6, 0xcd, 0x9b, 0x99, 0x37, 0xf3,
-	0x34, 0xd0, 0xd3, 0x94, 0x13, 0xaa, 0xf6, 0x8c, 0xeb, 0xb1, 0x9c, 0xc8, 0xb1, 0xfe, 0x29, 0x69,
-	0x3a, 0x92, 0x4a, 0x68, 0x81, 0x2e, 0x1f, 0xb9, 0x91, 0x9c, 0xc8, 0x5e, 0x77, 0x23, 0x36, 0xc2,
-	0x50, 0xe3, 0x22, 0xb2, 0x55, 0x83, 0x08, 0xe0, 0x86, 0xea, 0x0f, 0x84, 0x28, 0x9a, 0xa6, 0xe8,
-	0x15, 0xb8, 0x8c, 0xf8, 0x4e, 0xdf, 0x19, 0x5e, 0x4c, 0xeb, 0x79, 0x16, 0xba, 0xf3, 0x19, 0x76,
-	0x19, 0x31, 0x79, 0xe9, 0xbb, 0x27, 0xf9, 0x08, 0xbb, 0x4c, 0x22, 0x04, 0x9e, 0x14, 0x4a, 0xfb,
-	0xd5, 0xbe, 0x33, 0x6c, 0x63, 0x13, 0x0f, 0xbe, 0x40, 0x27, 0x2a, 0x46, 0xc7, 0x22, 0xf9, 0x4a,
-	0x55, 0xca, 0x04, 0x47, 0xd7, 0x50, 0x95, 0x13, 0x69, 0xe6, 0x7a, 0xd3, 0x46, 0x9e, 0x85, 0xd5,
-	0x68, 0x12, 0xe1, 0x22, 0x87, 0xba, 0x50, 0x5b, 0x25, 0x22, 0xde, 0x99, 0xe1, 0x1e, 0xb6, 0x00,
-	0x5d, 0x41, 0x75, 0x29, 0xa5, 0x19, 0xeb, 0xe1, 0x22, 0x1c, 0xfc, 0x75, 0xa1, 0x33, 0xa3, 0xeb,
-	0xe5, 0x6d, 0xa2, 0x6f, 0x04, 0xa1, 0x73, 0xbe, 0x16, 0x28, 0x82, 0x2b, 0x59, 0x2a, 0x2d, 0xbe,
-	0x5b, 0x29, 0xa3, 0xd1, 0x9a, 0x84, 0xa3, 0xa7, 0xc7, 0x8f, 0xce, 0x36, 0x9a, 0x7a, 0x77, 0x59,
-	0x58, 0xc1, 0x1d, 0x79, 0xb6, 0xe8, 0x3b, 0xe8, 0x10, 0x2b, 0xb2, 0xe0, 0x82, 0xd0, 0x05, 0x23,
-	0xe5, 0xd1, 0x2f, 0xf3, 0x2c, 0x6c, 0x9f, 0xea, 0xcf, 0x70, 0x9b, 0x9c, 0x40, 0x82, 0x42, 0x68,
-	0x25, 0x2c, 0xd5, 0x94, 0x2f, 0x96, 0x84, 0x28, 0xb3, 0xfa, 0x05, 0x06, 0x9b, 0x2a, 0xec, 0x45,
-	0x3e, 0x34, 0x38, 0xd5, 0x3f, 0x84, 0xda, 0xf9, 0x9e, 0x21, 0x1f, 0x60, 0xc1, 0x3c, 0xac, 0x5f,
-	0xb3, 0x4c, 0x09, 0x51, 0x0f, 0x9a, 0xf1, 0x76, 0xc9, 0x39, 0x4d, 0x52, 0xbf, 0xde, 0x77, 0x86,
-	0x2f, 0xf0, 0x7f, 0x5c, 0x74, 0xed, 0x05, 0x67, 0x3b, 0xaa, 0xfc, 0x86, 0xed, 0x2a, 0x21, 0x7a,
-	0x0f, 0x35, 0xa1, 0xb7, 0x54, 0xf9, 0x4d, 0x63, 0xc6, 0xeb, 0x73, 0x33, 0xce, 0x7c, 0xfc, 0x5c,
-	0xd4, 0x96, 0x8e, 0xd8, 0xc6, 0xc1, 0x0a, 0xba, 0xcf, 0x15, 0xa1, 0x6b, 0x68, 0xea, 0xc3, 0x82,
-	0x71, 0x42, 0x0f, 0xf6, 0x4b, 0x70, 0x43, 0x1f, 0xe6, 0x05, 0x44, 0x63, 0x68, 0x29, 0x19, 0x9b,
-	0xe3, 0x69, 0x9a, 0x96, 0xb6, 0x5d, 0xe6, 0x59, 0x08, 0x38, 0xfa, 0x58, 0xfe, 0x17, 0x06, 0x25,
-	0xe3, 0x32, 0x9e, 0x7e, 0xba, 0xcb, 0x03, 0xe7, 0x3e, 0x0f, 0x9c, 0x3f, 0x79, 0xe0, 0xfc, 0x3a,
-	0x06, 0x95, 0xfb, 0x63, 0x50, 0xf9, 0x7d, 0x0c, 0x2a, 0xdf, 0xde, 0x6c, 0x98, 0xde, 0xde, 0xae,
-	0x46, 0xb1, 0xd8, 0x8f, 0x63, 0xb1, 0xa7, 0x7a, 0xb5, 0xd6, 0x8f, 0x81, 0x7d, 0xe1, 0xa7, 0x8f,
-	0xbf, 0xaa, 0x9b, 0xec, 0xdb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xae, 0xdb, 0x56, 0x6d, 0x11,
-	0x03, 0x00, 0x00,
+	// 542 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xcd, 0x8e, 0xda, 0x3c,
+	0x14, 0x25, 0x3f, 0x33, 0xc0, 0xe5, 0xe3, 0xe7, 0xb3, 0x50, 0x95, 0x61, 0x91, 0x20, 0xd4, 0x05,
+	0x8b, 0x0a, 0xd4, 0x74, 0xd5, 0x5d, 0xcb, 0xb0, 0x41, 0x95, 0x66, 0x22, 0xab, 0xaa, 0xaa, 0x6e,
+	0x10, 0xc4, 0x06, 0x22, 0x20, 0xb6, 0x12, 0x4f, 0x4b, 0xdf, 0xa2, 0x0f, 0xd4, 0x07, 0x98, 0xe5,
+	0x2c, 0xbb, 0x8a, 0xaa, 0xf0, 0x22, 0x55, 0x6c, 0xd3, 0x81, 0xa8, 0xbb, 0x7b, 0xee, 0x3d, 0xbe,
+	0xe7, 0xfa, 0xd8, 0x17, 0x7a, 0x82, 0xc6, 0x84, 0x26, 0xfb, 0x28, 0x16, 0x63, 0xee, 0xf3, 0xb1,
+	0xf8, 0xce, 0x69, 0x3a, 0xe2, 0x09, 0x13, 0x0c, 0xb5, 0x9e, 0x6b, 0x23, 0xee, 0xf3, 0x5e, 0x77,
+	0xcd, 0xd6, 0x4c, 0x96, 0xc6, 0x45, 0xa4, 0x58, 0x83, 0x00, 0xe0, 0x8e, 0x8a, 0xf7, 0x84, 0x24,
+	0x34, 0x4d, 0xd1, 0x0b, 0x30, 0x23, 0xe2, 0x18, 0x7d, 0x63, 0x58, 0x9f, 0x5c, 0xe7, 0x99, 0x67,
+	0xce, 0xa6, 0xd8, 0x8c, 0x88, 0xcc, 0x73, 0xc7, 0x3c, 0xcb, 0x07, 0xd8, 0x8c, 0x38, 0x42, 0x60,
+	0x73, 0x96, 0x08, 0xc7, 0xea, 0x1b, 0xc3, 0x26, 0x96, 0xf1, 0xe0, 0x23, 0xb4, 0x83, 0xa2, 0x75,
+	0xc8, 0x76, 0x9f, 0x68, 0x92, 0x46, 0x2c, 0x46, 0x37, 0x60, 0x71, 0x9f, 0xcb, 0xbe, 0xf6, 0xa4,
+	0x9a, 0x67, 0x9e, 0x15, 0xf8, 0x01, 0x2e, 0x72, 0xa8, 0x0b, 0x57, 0xcb, 0x1d, 0x0b, 0xb7, 0xb2,
+	0xb9, 0x8d, 0x15, 0x40, 0x1d, 0xb0, 0x16, 0x9c, 0xcb, 0xb6, 0x36, 0x2e, 0xc2, 0xc1, 0x4f, 0x0b,
+	0xda, 0x53, 0xba, 0x5a, 0x3c, 0xec, 0xc4, 0x1d, 0x23, 0x74, 0x16, 0xaf, 0x18, 0x0a, 0xa0, 0xc3,
+	0xb5, 0xd2, 0xfc, 0xab, 0x92, 0x92, 0x1a, 0x0d, 0xdf, 0x1b, 0x5d, 0x5e, 0x7e, 0x54, 0x9a, 0x68,
+	0x62, 0x3f, 0x66, 0x5e, 0x05, 0xb7, 0x79, 0x69, 0xd0, 0xb7, 0xd0, 0x26, 0x4a, 0x64, 0x1e, 0x33,
+	0x42, 0xe7, 0x11, 0xd1, 0x97, 0xfe, 0x3f, 0xcf, 0xbc, 0xe6, 0xb9, 0xfe, 0x14, 0x37, 0xc9, 0x19,
+	0x24, 0xc8, 0x83, 0xc6, 0x2e, 0x4a, 0x05, 0x8d, 0xe7, 0x0b, 0x42, 0x12, 0x39, 0x7a, 0x1d, 0x83,
+	0x4a, 0x15, 0xf6, 0x22, 0x07, 0xaa, 0x31, 0x15, 0xdf, 0x58, 0xb2, 0x75, 0x6c, 0x59, 0x3c, 0xc1,
+	0xa2, 0x72, 0x1a, 0xff, 0x4a, 0x55, 0x34, 0x44, 0x3d, 0xa8, 0x85, 0x9b, 0x45, 0x1c, 0xd3, 0x5d,
+	0xea, 0x5c, 0xf7, 0x8d, 0xe1, 0x7f, 0xf8, 0x2f, 0x2e, 0x4e, 0xed, 0x59, 0x1c, 0x6d, 0x69, 0xe2,
+	0x54, 0xd5, 0x29, 0x0d, 0xd1, 0x3b, 0xb8, 0x62, 0x62, 0x43, 0x13, 0xa7, 0x26, 0xcd, 0x78, 0x59,
+	0x36, 0xa3, 0xe4, 0xe3, 0x7d, 0xc1, 0xd5, 0x8e, 0xa8, 0x83, 0xe8, 0x1e, 0x3a, 0x5a, 0xe7, 0x64,
+	0x6c, 0xea, 0xd4, 0xfb, 0xd6, 0xb0, 0xe1, 0xbb, 0xe5, 0x66, 0xb7, 0x8a, 0x57, 0x32, 0x36, 0xbc,
+	0xc8, 0xa6, 0x83, 0xcf, 0xd0, 0xba, 0x24, 0xa2, 0x57, 0x00, 0x27, 0x09, 0xfd, 0xe5, 0x9a, 0x93,
+	0x66, 0x9e, 0x79, 0x75, 0xcd, 0x9b, 0x4d, 0x71, 0x5d, 0x13, 0x66, 0xe4, 0xdc, 0x22, 0x53, 0xfe,
+	0xb5, 0x13, 0x1c, 0x2c, 0xa1, 0xfb, 0xaf, 0xfb, 0xa0, 0x1b, 0xa8, 0x89, 0xc3, 0x3c, 0x8a, 0x09,
+	0x3d, 0xa8, 0x0f, 0x8d, 0xab, 0xe2, 0x30, 0x2b, 0x20, 0x1a, 0x43, 0x23, 0xe1, 0xa1, 0x7c, 0x27,
+	0x9a, 0xa6, 0xfa, 0x85, 0x5b, 0x79, 0xe6, 0x01, 0x0e, 0x6e, 0xf5, 0x2a, 0x60, 0x48, 0x78, 0xa8,
+	0xe3, 0xc9, 0x87, 0xc7, 0xdc, 0x35, 0x9e, 0x72, 0xd7, 0xf8, 0x9d, 0xbb, 0xc6, 0x8f, 0xa3, 0x5b,
+	0x79, 0x3a, 0xba, 0x95, 0x5f, 0x47, 0xb7, 0xf2, 0xe5, 0xf5, 0x3a, 0x12, 0x9b, 0x87, 0xe5, 0x28,
+	0x64, 0xfb, 0x71, 0xc8, 0xf6, 0x54, 0x2c, 0x57, 0xe2, 0x39, 0x50, 0xdb, 0x76, 0xb9, 0xa3, 0xcb,
+	0x6b, 0x99, 0x7d, 0xf3, 0x67, 0x00, 0xae, 0xf8, 0x3c, 0xfe, 0xbc, 0x03, 0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -439,6 +505,20 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ChannelVersions) > 0 {
+		for iNdEx := len(m.ChannelVersions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ChannelVersions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -504,6 +584,39 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ChannelVersion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelVersion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelVersion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChannelID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChannelID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *DefaultNodeInfoOther) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -624,6 +737,27 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ChannelVersions) > 0 {
+		for _, e := range m.ChannelVersions {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ChannelVersion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChannelID != 0 {
+		n += 1 + sovTypes(uint64(m.ChannelID))
+	}
+	if m.Version != 0 {
+		n += 1 + sovTypes(uint64(m.Version))
+	}
 	return n
 }
 
@@ -1179,6 +1313,128 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelVersions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelVersions = append(m.ChannelVersions, ChannelVersion{})
+			if err := m.ChannelVersions[len(m.ChannelVersions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelVersion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelVersion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			m.ChannelID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChannelID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -216,6 +216,9 @@ func (m *PubKeyResponse) GetError() *RemoteSignerError {
 type SignVoteRequest struct {
 	Vote    *types.Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
 	ChainId string      `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Version of the sign bytes of the vote, zero meaning the first version.
+	// Signers must refuse the versions they don't support.
+	SignBytesVersion uint32 `protobuf:"varint,3,opt,name=sign_bytes_version,json=signBytesVersion,proto3" json:"sign_bytes_version,omitempty"`
 }
 
 func (m *SignVoteRequest) Reset()         { *m = SignVoteRequest{} }
@@ -265,6 +268,13 @@ func (m *SignVoteRequest) GetChainId() string {
 	return ""
 }
 
+func (m *SignVoteRequest) GetSignBytesVersion() uint32 {
+	if m != nil {
+		return m.SignBytesVersion
+	}
+	return 0
+}
+
 // SignedVoteResponse is a response containing a signed vote or an error
 type SignedVoteResponse struct {
 	Vote  types.Vote         `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote"`
@@ -322,6 +332,9 @@ func (m *SignedVoteResponse) GetError() *RemoteSignerError {
 type SignProposalRequest struct {
 	Proposal *types.Proposal `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
 	ChainId  string          `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Version of the sign bytes of the proposal, zero meaning the first
+	// version. Signers must refuse the versions they don't support.
+	SignBytesVersion uint32 `protobuf:"varint,3,opt,name=sign_bytes_version,json=signBytesVersion,proto3" json:"sign_bytes_version,omitempty"`
 }
 
 func (m *SignProposalRequest) Reset()         { *m = SignProposalRequest{} }
@@ -371,6 +384,13 @@ func (m *SignProposalRequest) GetChainId() string {
 	return ""
 }
 
+func (m *SignProposalRequest) GetSignBytesVersion() uint32 {
+	if m != nil {
+		return m.SignBytesVersion
+	}
+	return 0
+}
+
 // SignedProposalResponse is response containing a signed proposal or an error
 type SignedProposalResponse struct {
 	Proposal types.Proposal     `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal"`
@@ -678,55 +698,57 @@ func init() {
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 756 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4d, 0x4f, 0xe3, 0x46,
-	0x18, 0xb6, 0x21, 0x1f, 0xf0, 0x86, 0x84, 0x30, 0x50, 0x1a, 0x22, 0x6a, 0xd2, 0x54, 0x6d, 0x51,
-	0x0e, 0x49, 0x45, 0xd5, 0x5e, 0xe8, 0xa5, 0x80, 0xd5, 0x44, 0x11, 0x76, 0x3a, 0x09, 0x05, 0x21,
-	0x55, 0x56, 0x3e, 0x06, 0x63, 0x41, 0x3c, 0x5e, 0x8f, 0x83, 0x94, 0xf3, 0xde, 0xf6, 0xb4, 0xd2,
-	0xfe, 0x89, 0x3d, 0xef, 0xaf, 0xe0, 0xc8, 0x71, 0x4f, 0xab, 0x15, 0xfc, 0x91, 0x55, 0xc6, 0x13,
-	0xdb, 0xf9, 0x42, 0xbb, 0xe2, 0x36, 0xf3, 0xbe, 0xef, 0x3c, 0x1f, 0x33, 0x8f, 0x65, 0x50, 0x3c,
-	0x62, 0xf7, 0x88, 0xdb, 0xb7, 0x6c, 0xaf, 0xe2, 0xb8, 0xd6, 0xdd, 0x5d, 0xfb, 0xb6, 0xe2, 0x0d,
-	0x1d, 0xc2, 0xca, 0x8e, 0x4b, 0x3d, 0x8a, 0x50, 0xd8, 0x2f, 0x8b, 0x7e, 0x7e, 0x37, 0x72, 0xa6,
-	0xeb, 0x0e, 0x1d, 0x8f, 0x56, 0x6e, 0xc8, 0x50, 0x9c, 0x98, 0xe8, 0x72, 0xa4, 0x28, 0x5e, 0x7e,
-	0xcb, 0xa4, 0x26, 0xe5, 0xcb, 0xca, 0x68, 0xe5, 0x57, 0x8b, 0x35, 0xd8, 0xc0, 0xa4, 0x4f, 0x3d,
-	0xd2, 0xb4, 0x4c, 0x9b, 0xb8, 0xaa, 0xeb, 0x52, 0x17, 0x21, 0x88, 0x75, 0x69, 0x8f, 0xe4, 0xe4,
-	0x82, 0xbc, 0x1f, 0xc7, 0x7c, 0x8d, 0x0a, 0x90, 0xea, 0x11, 0xd6, 0x75, 0x2d, 0xc7, 0xb3, 0xa8,
-	0x9d, 0x5b, 0x2a, 0xc8, 0xfb, 0xab, 0x38, 0x5a, 0x2a, 0x96, 0x20, 0xdd, 0x18, 0x74, 0xea, 0x64,
-	0x88, 0xc9, 0xab, 0x01, 0x61, 0x1e, 0xda, 0x81, 0x95, 0xee, 0x75, 0xdb, 0xb2, 0x0d, 0xab, 0xc7,
-	0xa1, 0x56, 0x71, 0x92, 0xef, 0x6b, 0xbd, 0xe2, 0x1b, 0x19, 0x32, 0xe3, 0x61, 0xe6, 0x50, 0x9b,
-	0x11, 0x74, 0x08, 0x49, 0x67, 0xd0, 0x31, 0x6e, 0xc8, 0x90, 0x0f, 0xa7, 0x0e, 0x76, 0xcb, 0x91,
-	0x1b, 0xf0, 0xdd, 0x96, 0x1b, 0x83, 0xce, 0xad, 0xd5, 0xad, 0x93, 0xe1, 0x51, 0xec, 0xfe, 0xd3,
-	0x9e, 0x84, 0x13, 0x0e, 0x07, 0x41, 0x87, 0x10, 0x27, 0x23, 0xe9, 0x5c, 0x57, 0xea, 0xe0, 0xe7,
-	0xf2, 0xec, 0xe5, 0x95, 0x67, 0x7c, 0x62, 0xff, 0x4c, 0xf1, 0x02, 0xd6, 0x47, 0xd5, 0xff, 0xa8,
-	0x47, 0xc6, 0xd2, 0x4b, 0x10, 0xbb, 0xa3, 0x1e, 0x11, 0x4a, 0xb6, 0xa3, 0x70, 0xfe, 0x9d, 0xf2,
-	0x61, 0x3e, 0x33, 0x61, 0x73, 0x69, 0xd2, 0xe6, 0x6b, 0x19, 0x10, 0x27, 0xec, 0xf9, 0xe0, 0xc2,
-	0xea, 0x6f, 0x5f, 0x83, 0x2e, 0x1c, 0xfa, 0x1c, 0x2f, 0xf2, 0x77, 0x0d, 0x9b, 0xa3, 0x6a, 0xc3,
-	0xa5, 0x0e, 0x65, 0xed, 0xdb, 0xb1, 0xc7, 0x3f, 0x61, 0xc5, 0x11, 0x25, 0xa1, 0x24, 0x3f, 0xab,
-	0x24, 0x38, 0x14, 0xcc, 0x3e, 0xe7, 0xf7, 0x9d, 0x0c, 0xdb, 0xbe, 0xdf, 0x90, 0x4c, 0x78, 0xfe,
-	0xeb, 0x5b, 0xd8, 0x84, 0xf7, 0x90, 0xf3, 0x45, 0xfe, 0xd3, 0x90, 0x6a, 0x58, 0xb6, 0x29, 0x7c,
-	0x17, 0x33, 0xb0, 0xe6, 0x6f, 0x7d, 0x65, 0xc5, 0x0f, 0x71, 0x48, 0x9e, 0x12, 0xc6, 0xda, 0x26,
-	0x41, 0x75, 0x58, 0x17, 0x21, 0x34, 0x5c, 0x7f, 0x5c, 0x88, 0xfd, 0x71, 0x1e, 0xe3, 0x44, 0xdc,
-	0xab, 0x12, 0x4e, 0x3b, 0x13, 0xf9, 0xd7, 0x20, 0x1b, 0x82, 0xf9, 0x64, 0x42, 0x7f, 0xf1, 0x39,
-	0x34, 0x7f, 0xb2, 0x2a, 0xe1, 0x8c, 0x33, 0xf9, 0x85, 0xfc, 0x0b, 0x1b, 0xcc, 0x32, 0x6d, 0x63,
-	0x94, 0x88, 0x40, 0xde, 0x32, 0x07, 0xfc, 0x69, 0x1e, 0xe0, 0x54, 0xa8, 0xab, 0x12, 0x5e, 0x67,
-	0x53, 0x39, 0xbf, 0x84, 0x2d, 0xc6, 0xdf, 0x6b, 0x0c, 0x2a, 0x64, 0xc6, 0x38, 0xea, 0x2f, 0x8b,
-	0x50, 0x27, 0xf3, 0x5c, 0x95, 0x30, 0x62, 0xb3, 0x29, 0xff, 0x1f, 0xbe, 0xe3, 0x72, 0xc7, 0x8f,
-	0x18, 0x48, 0x8e, 0x73, 0xf0, 0x5f, 0x17, 0x81, 0x4f, 0xe5, 0xb4, 0x2a, 0xe1, 0x4d, 0x36, 0x27,
-	0xbe, 0x57, 0x90, 0x13, 0xd2, 0x23, 0x04, 0x42, 0x7e, 0x82, 0x33, 0x94, 0x16, 0xcb, 0x9f, 0x8e,
-	0x67, 0x55, 0xc2, 0xdb, 0x6c, 0x7e, 0x70, 0x4f, 0x60, 0xcd, 0xb1, 0x6c, 0x33, 0x50, 0x9f, 0xe4,
-	0xd8, 0x7b, 0x73, 0x5f, 0x30, 0x4c, 0x59, 0x55, 0xc2, 0x29, 0x27, 0xdc, 0xa2, 0x7f, 0x20, 0x2d,
-	0x50, 0x84, 0xc4, 0x15, 0x0e, 0x53, 0x58, 0x0c, 0x13, 0x08, 0x5b, 0x73, 0x22, 0xfb, 0xa3, 0x38,
-	0x2c, 0xb3, 0x41, 0xbf, 0xf4, 0x5e, 0x86, 0x04, 0x0f, 0x39, 0x43, 0x08, 0x32, 0x2a, 0xc6, 0x3a,
-	0x6e, 0x1a, 0x67, 0x5a, 0x5d, 0xd3, 0xcf, 0xb5, 0xac, 0x84, 0x14, 0xc8, 0x07, 0x35, 0xf5, 0xa2,
-	0xa1, 0x1e, 0xb7, 0xd4, 0x13, 0x03, 0xab, 0xcd, 0x86, 0xae, 0x35, 0xd5, 0xac, 0x8c, 0x72, 0xb0,
-	0x25, 0xfa, 0x9a, 0x6e, 0x1c, 0xeb, 0x9a, 0xa6, 0x1e, 0xb7, 0x6a, 0xba, 0x96, 0x5d, 0x42, 0x3f,
-	0xc0, 0x8e, 0xe8, 0x84, 0x65, 0xa3, 0x55, 0x3b, 0x55, 0xf5, 0xb3, 0x56, 0x76, 0x19, 0x7d, 0x0f,
-	0x9b, 0xa2, 0x8d, 0xd5, 0xbf, 0x4f, 0x82, 0x46, 0x2c, 0x82, 0x78, 0x8e, 0x6b, 0x2d, 0x35, 0xe8,
-	0xc4, 0x8f, 0xf4, 0xfb, 0x47, 0x45, 0x7e, 0x78, 0x54, 0xe4, 0xcf, 0x8f, 0x8a, 0xfc, 0xf6, 0x49,
-	0x91, 0x1e, 0x9e, 0x14, 0xe9, 0xe3, 0x93, 0x22, 0x5d, 0xfe, 0x61, 0x5a, 0xde, 0xf5, 0xa0, 0x53,
-	0xee, 0xd2, 0x7e, 0xa5, 0x4b, 0xfb, 0xc4, 0xeb, 0x5c, 0x79, 0xe1, 0xc2, 0xff, 0x57, 0xcd, 0xfe,
-	0x25, 0x3b, 0x09, 0xde, 0xf9, 0xfd, 0x4b, 0x00, 0x00, 0x00, 0xff, 0xff, 0xda, 0x9f, 0x99, 0x3e,
-	0x42, 0x07, 0x00, 0x00,
+	// 790 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0xcd, 0x6e, 0xeb, 0x44,
+	0x14, 0xc7, 0xed, 0xe6, 0xab, 0xf7, 0xa4, 0x49, 0x73, 0xa7, 0xa5, 0xe4, 0x46, 0x17, 0xdf, 0x60,
+	0x04, 0x54, 0x11, 0x4a, 0xd0, 0x45, 0xb0, 0xb9, 0x6c, 0x48, 0x6b, 0x91, 0x28, 0xba, 0x76, 0x98,
+	0xa4, 0x2d, 0xaa, 0x84, 0xac, 0x7c, 0x4c, 0x5d, 0xab, 0x8d, 0xc7, 0x78, 0x9c, 0x48, 0x59, 0x23,
+	0xb1, 0x60, 0x85, 0x84, 0xc4, 0x33, 0xb0, 0xe6, 0x29, 0xba, 0xec, 0x92, 0x15, 0x42, 0xed, 0x8b,
+	0x20, 0x8f, 0x27, 0xb6, 0xf3, 0x85, 0x40, 0x65, 0xe7, 0x39, 0xe7, 0xcc, 0x7f, 0x7e, 0xf3, 0x9f,
+	0x73, 0x64, 0x50, 0x7c, 0xe2, 0x8c, 0x89, 0x37, 0xb1, 0x1d, 0xbf, 0xe1, 0x7a, 0xf6, 0x6c, 0x36,
+	0xb8, 0x6d, 0xf8, 0x73, 0x97, 0xb0, 0xba, 0xeb, 0x51, 0x9f, 0x22, 0x14, 0xe7, 0xeb, 0x22, 0x5f,
+	0x79, 0x99, 0xd8, 0x33, 0xf2, 0xe6, 0xae, 0x4f, 0x1b, 0x37, 0x64, 0x2e, 0x76, 0x2c, 0x65, 0xb9,
+	0x52, 0x52, 0xaf, 0x72, 0x68, 0x51, 0x8b, 0xf2, 0xcf, 0x46, 0xf0, 0x15, 0x46, 0xd5, 0x36, 0x3c,
+	0xc7, 0x64, 0x42, 0x7d, 0xd2, 0xb3, 0x2d, 0x87, 0x78, 0x9a, 0xe7, 0x51, 0x0f, 0x21, 0x48, 0x8f,
+	0xe8, 0x98, 0x94, 0xe5, 0xaa, 0x7c, 0x9c, 0xc1, 0xfc, 0x1b, 0x55, 0x21, 0x3f, 0x26, 0x6c, 0xe4,
+	0xd9, 0xae, 0x6f, 0x53, 0xa7, 0xbc, 0x53, 0x95, 0x8f, 0x9f, 0xe1, 0x64, 0x48, 0xad, 0x41, 0xa1,
+	0x3b, 0x1d, 0x76, 0xc8, 0x1c, 0x93, 0xef, 0xa7, 0x84, 0xf9, 0xe8, 0x05, 0xec, 0x8e, 0xae, 0x07,
+	0xb6, 0x63, 0xda, 0x63, 0x2e, 0xf5, 0x0c, 0xe7, 0xf8, 0xba, 0x3d, 0x56, 0x7f, 0x92, 0xa1, 0xb8,
+	0x28, 0x66, 0x2e, 0x75, 0x18, 0x41, 0x6f, 0x20, 0xe7, 0x4e, 0x87, 0xe6, 0x0d, 0x99, 0xf3, 0xe2,
+	0xfc, 0xeb, 0x97, 0xf5, 0x84, 0x03, 0xe1, 0x6d, 0xeb, 0xdd, 0xe9, 0xf0, 0xd6, 0x1e, 0x75, 0xc8,
+	0xbc, 0x99, 0xbe, 0xfb, 0xf3, 0x95, 0x84, 0xb3, 0x2e, 0x17, 0x41, 0x6f, 0x20, 0x43, 0x02, 0x74,
+	0xce, 0x95, 0x7f, 0xfd, 0x61, 0x7d, 0xdd, 0xbc, 0xfa, 0xda, 0x3d, 0x71, 0xb8, 0x47, 0xfd, 0x51,
+	0x86, 0xfd, 0x20, 0x7c, 0x4e, 0x7d, 0xb2, 0x60, 0xaf, 0x41, 0x7a, 0x46, 0x7d, 0x22, 0x50, 0x8e,
+	0x92, 0x7a, 0xa1, 0xa9, 0xbc, 0x98, 0xd7, 0x2c, 0xdd, 0x73, 0x67, 0xe9, 0x9e, 0xe8, 0x13, 0x40,
+	0xcc, 0xb6, 0x1c, 0x73, 0x38, 0xf7, 0x09, 0x33, 0x67, 0xc4, 0x63, 0x81, 0x79, 0xa9, 0xaa, 0x7c,
+	0x5c, 0xc0, 0xa5, 0x20, 0xd3, 0x0c, 0x12, 0xe7, 0x61, 0x5c, 0xfd, 0x41, 0x06, 0xc4, 0xf9, 0xc6,
+	0x21, 0x8a, 0x70, 0xe6, 0xd3, 0x7f, 0xc3, 0x22, 0x0c, 0x09, 0x89, 0x9e, 0x64, 0xc7, 0xaf, 0x32,
+	0x1c, 0x04, 0xe1, 0xae, 0x47, 0x5d, 0xca, 0x06, 0xb7, 0x0b, 0x4b, 0xbe, 0x80, 0x5d, 0x57, 0x84,
+	0x04, 0x4a, 0x65, 0x1d, 0x25, 0xda, 0x14, 0xd5, 0xfe, 0x7f, 0xf6, 0xfc, 0x22, 0xc3, 0x51, 0x68,
+	0x4f, 0x8c, 0x26, 0x2c, 0xfa, 0xf2, 0xbf, 0xb0, 0x09, 0xab, 0x62, 0xc2, 0x27, 0xd9, 0x55, 0x80,
+	0x7c, 0xd7, 0x76, 0x2c, 0xe1, 0x92, 0x5a, 0x84, 0xbd, 0x70, 0x19, 0x92, 0xa9, 0xbf, 0x67, 0x20,
+	0xf7, 0x96, 0x30, 0x36, 0xb0, 0x08, 0xea, 0xc0, 0xbe, 0x68, 0x71, 0xd3, 0x0b, 0xcb, 0x05, 0xec,
+	0xfb, 0x9b, 0x4e, 0x5c, 0x1a, 0xa6, 0x96, 0x84, 0x0b, 0xee, 0xd2, 0x74, 0xe9, 0x50, 0x8a, 0xc5,
+	0xc2, 0xc3, 0x04, 0xbf, 0xfa, 0x4f, 0x6a, 0x61, 0x65, 0x4b, 0xc2, 0x45, 0x77, 0x79, 0xfe, 0xbe,
+	0x81, 0xe7, 0xfc, 0x2d, 0x82, 0x06, 0x8a, 0xf0, 0x52, 0x5c, 0xf0, 0x83, 0x4d, 0x82, 0x2b, 0x13,
+	0xd3, 0x92, 0xf0, 0x3e, 0x5b, 0x19, 0xa2, 0x4b, 0x38, 0x64, 0xfc, 0xbd, 0x16, 0xa2, 0x02, 0x33,
+	0xcd, 0x55, 0x3f, 0xda, 0xa6, 0xba, 0xdc, 0xfe, 0x2d, 0x09, 0x23, 0xb6, 0x3e, 0x14, 0xdf, 0xc1,
+	0x3b, 0x1c, 0x77, 0xf1, 0x88, 0x11, 0x72, 0x86, 0x8b, 0x7f, 0xbc, 0x4d, 0x7c, 0xa5, 0xab, 0x5b,
+	0x12, 0x3e, 0x60, 0xeb, 0x61, 0x74, 0x05, 0x65, 0x81, 0x9e, 0x38, 0x40, 0xe0, 0x67, 0xf9, 0x09,
+	0xb5, 0xed, 0xf8, 0xab, 0xed, 0xd9, 0x92, 0xf0, 0x11, 0xdb, 0xdc, 0xb8, 0xa7, 0xb0, 0xe7, 0xda,
+	0x8e, 0x15, 0xd1, 0xe7, 0xb8, 0xf6, 0xab, 0x8d, 0x2f, 0x18, 0x77, 0x59, 0x4b, 0xc2, 0x79, 0x37,
+	0x5e, 0xa2, 0xaf, 0xa1, 0x20, 0x54, 0x04, 0xe2, 0x2e, 0x97, 0xa9, 0x6e, 0x97, 0x89, 0xc0, 0xf6,
+	0xdc, 0xc4, 0xba, 0x99, 0x81, 0x14, 0x9b, 0x4e, 0x6a, 0xbf, 0xc9, 0x90, 0xe5, 0x4d, 0xce, 0x10,
+	0x82, 0xa2, 0x86, 0xb1, 0x81, 0x7b, 0xe6, 0x99, 0xde, 0xd1, 0x8d, 0x0b, 0xbd, 0x24, 0x21, 0x05,
+	0x2a, 0x51, 0x4c, 0xfb, 0xb6, 0xab, 0x9d, 0xf4, 0xb5, 0x53, 0x13, 0x6b, 0xbd, 0xae, 0xa1, 0xf7,
+	0xb4, 0x92, 0x8c, 0xca, 0x70, 0x28, 0xf2, 0xba, 0x61, 0x9e, 0x18, 0xba, 0xae, 0x9d, 0xf4, 0xdb,
+	0x86, 0x5e, 0xda, 0x41, 0xef, 0xc1, 0x0b, 0x91, 0x89, 0xc3, 0x66, 0xbf, 0xfd, 0x56, 0x33, 0xce,
+	0xfa, 0xa5, 0x14, 0x7a, 0x17, 0x0e, 0x44, 0x1a, 0x6b, 0x5f, 0x9d, 0x46, 0x89, 0x74, 0x42, 0xf1,
+	0x02, 0xb7, 0xfb, 0x5a, 0x94, 0xc9, 0x34, 0x8d, 0xbb, 0x07, 0x45, 0xbe, 0x7f, 0x50, 0xe4, 0xbf,
+	0x1e, 0x14, 0xf9, 0xe7, 0x47, 0x45, 0xba, 0x7f, 0x54, 0xa4, 0x3f, 0x1e, 0x15, 0xe9, 0xf2, 0x73,
+	0xcb, 0xf6, 0xaf, 0xa7, 0xc3, 0xfa, 0x88, 0x4e, 0x1a, 0x23, 0x3a, 0x21, 0xfe, 0xf0, 0xca, 0x8f,
+	0x3f, 0xc2, 0x3f, 0xe1, 0xfa, 0x3f, 0x78, 0x98, 0xe5, 0x99, 0xcf, 0xfe, 0x1e, 0x00, 0xb0, 0xfe,
+	0x31, 0x9c, 0xa0, 0x07, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -859,6 +881,11 @@ func (m *SignVoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SignBytesVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SignBytesVersion))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.ChainId) > 0 {
 		i -= len(m.ChainId)
 		copy(dAtA[i:], m.ChainId)
@@ -946,6 +973,11 @@ func (m *SignProposalRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SignBytesVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SignBytesVersion))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.ChainId) > 0 {
 		i -= len(m.ChainId)
 		copy(dAtA[i:], m.ChainId)
@@ -1328,6 +1360,9 @@ func (m *SignVoteRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.SignBytesVersion != 0 {
+		n += 1 + sovTypes(uint64(m.SignBytesVersion))
+	}
 	return n
 }
 
@@ -1360,6 +1395,9 @@ func (m *SignProposalRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.SignBytesVersion != 0 {
+		n += 1 + sovTypes(uint64(m.SignBytesVersion))
+	}
 	return n
 }
 
@@ -1910,6 +1948,25 @@ func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ChainId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignBytesVersion", wireType)
+			}
+			m.SignBytesVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignBytesVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2147,6 +2204,25 @@ func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ChainId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignBytesVersion", wireType)
+			}
+			m.SignBytesVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignBytesVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -34,6 +34,9 @@ type ConsensusParams struct {
 	Evidence  *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
 	Validator *ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Timeout   *TimeoutParams   `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Synchrony *SynchronyParams `protobuf:"bytes,6,opt,name=synchrony,proto3" json:"synchrony,omitempty"`
+	Feature   *FeatureParams   `protobuf:"bytes,7,opt,name=feature,proto3" json:"feature,omitempty"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
@@ -97,6 +100,27 @@ func (m *ConsensusParams) GetVersion() *VersionParams {
 	return nil
 }
 
+func (m *ConsensusParams) GetTimeout() *TimeoutParams {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetSynchrony() *SynchronyParams {
+	if m != nil {
+		return m.Synchrony
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetFeature() *FeatureParams {
+	if m != nil {
+		return m.Feature
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Max block size, in bytes.
@@ -273,9 +297,14 @@ func (m *ValidatorParams) GetPubKeyTypes() []string {
 	return nil
 }
 
-// VersionParams contains the ABCI application version.
+// VersionParams contains the ABCI application version, and the version of the
+// format of the bytes signed for votes and proposals.
 type VersionParams struct {
 	App uint64 `protobuf:"varint,1,opt,name=app,proto3" json:"app,omitempty"`
+	// Version of the canonical sign bytes of votes and proposals. Zero means
+	// the first version. It can only be raised, to a version supported by the
+	// nodes and their remote signers.
+	SignBytes uint32 `protobuf:"varint,2,opt,name=sign_bytes,json=signBytes,proto3" json:"sign_bytes,omitempty"`
 }
 
 func (m *VersionParams) Reset()         { *m = VersionParams{} }
@@ -318,6 +347,257 @@ func (m *VersionParams) GetApp() uint64 {
 	return 0
 }
 
+func (m *VersionParams) GetSignBytes() uint32 {
+	if m != nil {
+		return m.SignBytes
+	}
+	return 0
+}
+
+// TimeoutParams configure the timeouts of the consensus algorithm.
+//
+// A zero timeout means that the value from the local configuration of each
+// node is used instead. A delta is only taken into account if the timeout it
+// applies to is set.
+type TimeoutParams struct {
+	// Time to wait for a proposal, and increase per round.
+	Propose      time.Duration `protobuf:"bytes,1,opt,name=propose,proto3,stdduration" json:"propose"`
+	ProposeDelta time.Duration `protobuf:"bytes,2,opt,name=propose_delta,json=proposeDelta,proto3,stdduration" json:"propose_delta"`
+	// Time to wait after receiving +2/3 prevotes for anything, and increase per
+	// round.
+	Prevote      time.Duration `protobuf:"bytes,3,opt,name=prevote,proto3,stdduration" json:"prevote"`
+	PrevoteDelta time.Duration `protobuf:"bytes,4,opt,name=prevote_delta,json=prevoteDelta,proto3,stdduration" json:"prevote_delta"`
+	// Time to wait after receiving +2/3 precommits for anything, and increase
+	// per round.
+	Precommit      time.Duration `protobuf:"bytes,5,opt,name=precommit,proto3,stdduration" json:"precommit"`
+	PrecommitDelta time.Duration `protobuf:"bytes,6,opt,name=precommit_delta,json=precommitDelta,proto3,stdduration" json:"precommit_delta"`
+	// Time to wait after committing a block, before starting the next height.
+	Commit time.Duration `protobuf:"bytes,7,opt,name=commit,proto3,stdduration" json:"commit"`
+	// Maximum durations of the PrepareProposal and ProcessProposal calls to the
+	// application, after which the proposer proposes a block without
+	// transactions, and the validators reject the proposal. Zero means no
+	// limit.
+	PrepareProposal time.Duration `protobuf:"bytes,8,opt,name=prepare_proposal,json=prepareProposal,proto3,stdduration" json:"prepare_proposal"`
+	ProcessProposal time.Duration `protobuf:"bytes,9,opt,name=process_proposal,json=processProposal,proto3,stdduration" json:"process_proposal"`
+}
+
+func (m *TimeoutParams) Reset()         { *m = TimeoutParams{} }
+func (m *TimeoutParams) String() string { return proto.CompactTextString(m) }
+func (*TimeoutParams) ProtoMessage()    {}
+func (*TimeoutParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{5}
+}
+func (m *TimeoutParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeoutParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeoutParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimeoutParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutParams.Merge(m, src)
+}
+func (m *TimeoutParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeoutParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutParams proto.InternalMessageInfo
+
+func (m *TimeoutParams) GetPropose() time.Duration {
+	if m != nil {
+		return m.Propose
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetProposeDelta() time.Duration {
+	if m != nil {
+		return m.ProposeDelta
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrevote() time.Duration {
+	if m != nil {
+		return m.Prevote
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrevoteDelta() time.Duration {
+	if m != nil {
+		return m.PrevoteDelta
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrecommit() time.Duration {
+	if m != nil {
+		return m.Precommit
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrecommitDelta() time.Duration {
+	if m != nil {
+		return m.PrecommitDelta
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetCommit() time.Duration {
+	if m != nil {
+		return m.Commit
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetPrepareProposal() time.Duration {
+	if m != nil {
+		return m.PrepareProposal
+	}
+	return 0
+}
+
+func (m *TimeoutParams) GetProcessProposal() time.Duration {
+	if m != nil {
+		return m.ProcessProposal
+	}
+	return 0
+}
+
+// SynchronyParams configure the bounds under which a proposal is considered
+// timely, when proposer-based timestamps (PBTS) are enabled.
+type SynchronyParams struct {
+	// Bound on the clock drift between any two correct validators.
+	Precision time.Duration `protobuf:"bytes,1,opt,name=precision,proto3,stdduration" json:"precision"`
+	// Bound on the time it takes for a proposal to reach all correct
+	// validators, at round 0. It is increased by 10% every round, so that the
+	// network can recover if it was set too low.
+	MessageDelay time.Duration `protobuf:"bytes,2,opt,name=message_delay,json=messageDelay,proto3,stdduration" json:"message_delay"`
+}
+
+func (m *SynchronyParams) Reset()         { *m = SynchronyParams{} }
+func (m *SynchronyParams) String() string { return proto.CompactTextString(m) }
+func (*SynchronyParams) ProtoMessage()    {}
+func (*SynchronyParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{6}
+}
+func (m *SynchronyParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SynchronyParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SynchronyParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SynchronyParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronyParams.Merge(m, src)
+}
+func (m *SynchronyParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *SynchronyParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronyParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronyParams proto.InternalMessageInfo
+
+func (m *SynchronyParams) GetPrecision() time.Duration {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func (m *SynchronyParams) GetMessageDelay() time.Duration {
+	if m != nil {
+		return m.MessageDelay
+	}
+	return 0
+}
+
+// FeatureParams configure the heights at which optional consensus features
+// are enabled.
+type FeatureParams struct {
+	// First height at which proposer-based timestamps (PBTS) are used instead of
+	// BFT time. Zero means that PBTS is disabled. Once PBTS is enabled, this
+	// value cannot be changed; before that, it can only be set to a future
+	// height.
+	PbtsEnableHeight int64 `protobuf:"varint,1,opt,name=pbts_enable_height,json=pbtsEnableHeight,proto3" json:"pbts_enable_height,omitempty"`
+	// First height from which the last commit of the blocks aggregates the
+	// BLS12-381 signatures of the validators into one, if all the validators of
+	// the last commit have BLS12-381 keys. Zero means that aggregated commits are
+	// disabled. Once enabled, this value cannot be changed; before that, it can
+	// only be set to a future height.
+	BlsAggregatedCommitEnableHeight int64 `protobuf:"varint,2,opt,name=bls_aggregated_commit_enable_height,json=blsAggregatedCommitEnableHeight,proto3" json:"bls_aggregated_commit_enable_height,omitempty"`
+}
+
+func (m *FeatureParams) Reset()         { *m = FeatureParams{} }
+func (m *FeatureParams) String() string { return proto.CompactTextString(m) }
+func (*FeatureParams) ProtoMessage()    {}
+func (*FeatureParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{7}
+}
+func (m *FeatureParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeatureParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeatureParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FeatureParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureParams.Merge(m, src)
+}
+func (m *FeatureParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeatureParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeatureParams proto.InternalMessageInfo
+
+func (m *FeatureParams) GetPbtsEnableHeight() int64 {
+	if m != nil {
+		return m.PbtsEnableHeight
+	}
+	return 0
+}
+
+func (m *FeatureParams) GetBlsAggregatedCommitEnableHeight() int64 {
+	if m != nil {
+		return m.BlsAggregatedCommitEnableHeight
+	}
+	return 0
+}
+
 // HashedParams is a subset of ConsensusParams.
 //
 // It is hashed into the Header.ConsensusHash.
@@ -330,7 +610,7 @@ func (m *HashedParams) Reset()         { *m = HashedParams{} }
 func (m *HashedParams) String() string { return proto.CompactTextString(m) }
 func (*HashedParams) ProtoMessage()    {}
 func (*HashedParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e12598271a686f57, []int{5}
+	return fileDescriptor_e12598271a686f57, []int{8}
 }
 func (m *HashedParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -379,46 +659,68 @@ func init() {
 	proto.RegisterType((*EvidenceParams)(nil), "tendermint.types.EvidenceParams")
 	proto.RegisterType((*ValidatorParams)(nil), "tendermint.types.ValidatorParams")
 	proto.RegisterType((*VersionParams)(nil), "tendermint.types.VersionParams")
+	proto.RegisterType((*TimeoutParams)(nil), "tendermint.types.TimeoutParams")
+	proto.RegisterType((*SynchronyParams)(nil), "tendermint.types.SynchronyParams")
+	proto.RegisterType((*FeatureParams)(nil), "tendermint.types.FeatureParams")
 	proto.RegisterType((*HashedParams)(nil), "tendermint.types.HashedParams")
 }
 
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 515 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0xeb, 0xa5, 0x6c, 0xed, 0x5b, 0xba, 0x56, 0x16, 0x12, 0x61, 0x68, 0x49, 0xc9, 0x01,
-	0x4d, 0x9a, 0x94, 0x48, 0xf4, 0x04, 0x42, 0x9a, 0x28, 0xa0, 0xf1, 0x47, 0x43, 0x10, 0x21, 0x0e,
-	0xbb, 0x44, 0x4e, 0xe3, 0x65, 0xd1, 0xea, 0x38, 0x8a, 0x9d, 0xaa, 0xbd, 0xf1, 0x11, 0x38, 0x72,
-	0xdc, 0x11, 0xbe, 0x01, 0x1f, 0x61, 0xc7, 0x1d, 0x39, 0x01, 0x6a, 0x2f, 0x7c, 0x0c, 0x14, 0x27,
-	0x21, 0x6b, 0xb7, 0x9b, 0xe3, 0xe7, 0xf7, 0xd8, 0x79, 0x9e, 0x57, 0x86, 0x5d, 0x49, 0xe3, 0x80,
-	0xa6, 0x2c, 0x8a, 0xa5, 0x23, 0xe7, 0x09, 0x15, 0x4e, 0x42, 0x52, 0xc2, 0x84, 0x9d, 0xa4, 0x5c,
-	0x72, 0xdc, 0xaf, 0x65, 0x5b, 0xc9, 0x3b, 0x77, 0x42, 0x1e, 0x72, 0x25, 0x3a, 0xf9, 0xaa, 0xe0,
-	0x76, 0x8c, 0x90, 0xf3, 0x70, 0x42, 0x1d, 0xf5, 0xe5, 0x67, 0x27, 0x4e, 0x90, 0xa5, 0x44, 0x46,
-	0x3c, 0x2e, 0x74, 0xeb, 0xf3, 0x06, 0xf4, 0x9e, 0xf3, 0x58, 0xd0, 0x58, 0x64, 0xe2, 0xbd, 0xba,
-	0x01, 0x0f, 0xe1, 0x96, 0x3f, 0xe1, 0xe3, 0x33, 0x1d, 0x0d, 0xd0, 0x5e, 0xe7, 0xd1, 0xae, 0xbd,
-	0x7e, 0x97, 0x3d, 0xca, 0xe5, 0x82, 0x76, 0x0b, 0x16, 0x3f, 0x85, 0x16, 0x9d, 0x46, 0x01, 0x8d,
-	0xc7, 0x54, 0xdf, 0x50, 0xbe, 0xc1, 0x75, 0xdf, 0xcb, 0x92, 0x28, 0xad, 0xff, 0x1d, 0xf8, 0x00,
-	0xda, 0x53, 0x32, 0x89, 0x02, 0x22, 0x79, 0xaa, 0x6b, 0xca, 0xfe, 0xe0, 0xba, 0xfd, 0x53, 0x85,
-	0x94, 0xfe, 0xda, 0x83, 0x1f, 0xc3, 0xd6, 0x94, 0xa6, 0x22, 0xe2, 0xb1, 0xde, 0x54, 0x76, 0xf3,
-	0x06, 0x7b, 0x01, 0x94, 0xe6, 0x8a, 0xb7, 0x5e, 0x43, 0xe7, 0x4a, 0x1e, 0x7c, 0x1f, 0xda, 0x8c,
-	0xcc, 0x3c, 0x7f, 0x2e, 0xa9, 0x50, 0x0d, 0x68, 0x6e, 0x8b, 0x91, 0xd9, 0x28, 0xff, 0xc6, 0x77,
-	0x61, 0x2b, 0x17, 0x43, 0x22, 0x54, 0x48, 0xcd, 0xdd, 0x64, 0x64, 0x76, 0x48, 0xc4, 0x9b, 0x66,
-	0x4b, 0xeb, 0x37, 0xad, 0xef, 0x08, 0xb6, 0x57, 0x33, 0xe2, 0x7d, 0xc0, 0xb9, 0x83, 0x84, 0xd4,
-	0x8b, 0x33, 0xe6, 0xa9, 0xb2, 0xaa, 0x73, 0x7b, 0x8c, 0xcc, 0x9e, 0x85, 0xf4, 0x5d, 0xc6, 0xd4,
-	0x0f, 0x08, 0x7c, 0x04, 0xfd, 0x0a, 0xae, 0xe6, 0x54, 0x96, 0x79, 0xcf, 0x2e, 0x06, 0x69, 0x57,
-	0x83, 0xb4, 0x5f, 0x94, 0xc0, 0xa8, 0x75, 0xf1, 0xcb, 0x6c, 0x7c, 0xfd, 0x6d, 0x22, 0x77, 0xbb,
-	0x38, 0xaf, 0x52, 0x56, 0xa3, 0x68, 0xab, 0x51, 0xac, 0x03, 0xe8, 0xad, 0xf5, 0x89, 0x2d, 0xe8,
-	0x26, 0x99, 0xef, 0x9d, 0xd1, 0xb9, 0xa7, 0x1a, 0xd3, 0xd1, 0x40, 0xdb, 0x6b, 0xbb, 0x9d, 0x24,
-	0xf3, 0xdf, 0xd2, 0xf9, 0xc7, 0x7c, 0xeb, 0x49, 0xeb, 0xc7, 0xb9, 0x89, 0xfe, 0x9e, 0x9b, 0xc8,
-	0xda, 0x87, 0xee, 0x4a, 0xa3, 0xb8, 0x0f, 0x1a, 0x49, 0x12, 0x95, 0xad, 0xe9, 0xe6, 0xcb, 0x2b,
-	0xf0, 0x31, 0xdc, 0x7e, 0x45, 0xc4, 0x29, 0x0d, 0x4a, 0xf6, 0x21, 0xf4, 0x54, 0x15, 0xde, 0x7a,
-	0xd7, 0x5d, 0xb5, 0x7d, 0x54, 0x15, 0x6e, 0x41, 0xb7, 0xe6, 0xea, 0xda, 0x3b, 0x15, 0x75, 0x48,
-	0xc4, 0xe8, 0xc3, 0xb7, 0x85, 0x81, 0x2e, 0x16, 0x06, 0xba, 0x5c, 0x18, 0xe8, 0xcf, 0xc2, 0x40,
-	0x5f, 0x96, 0x46, 0xe3, 0x72, 0x69, 0x34, 0x7e, 0x2e, 0x8d, 0xc6, 0xf1, 0x30, 0x8c, 0xe4, 0x69,
-	0xe6, 0xdb, 0x63, 0xce, 0x9c, 0x31, 0x67, 0x54, 0xfa, 0x27, 0xb2, 0x5e, 0x14, 0x0f, 0x66, 0xfd,
-	0xad, 0xf9, 0x9b, 0x6a, 0x7f, 0xf8, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x3c, 0xfc, 0x4b, 0xf8, 0x86,
-	0x03, 0x00, 0x00,
+	// 827 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0xc7, 0xcd, 0x52, 0xd6, 0xc7, 0x38, 0x8a, 0x84, 0x45, 0x81, 0xb2, 0x29, 0x4c, 0xb9, 0x2c,
+	0x50, 0x04, 0x68, 0x41, 0x01, 0xf5, 0xa9, 0x5f, 0x08, 0xec, 0x38, 0x8d, 0x9b, 0x26, 0x41, 0xaa,
+	0x06, 0x3d, 0xe4, 0x42, 0x2c, 0xa5, 0x31, 0x45, 0x84, 0xe4, 0x12, 0xdc, 0xa5, 0x61, 0x3d, 0x43,
+	0x0f, 0xed, 0xb1, 0xa7, 0x22, 0xc7, 0xf6, 0x09, 0xda, 0x47, 0xc8, 0x31, 0xc7, 0x9e, 0xda, 0x42,
+	0xbe, 0xf4, 0x31, 0x8a, 0xfd, 0x92, 0x2c, 0xb9, 0x06, 0xa4, 0xdb, 0x72, 0xe7, 0xff, 0x9b, 0xfd,
+	0xef, 0xce, 0x68, 0x6c, 0xd8, 0x17, 0x58, 0x4c, 0xb0, 0xca, 0xd3, 0x42, 0x0c, 0xc5, 0xac, 0x44,
+	0x3e, 0x2c, 0x69, 0x45, 0x73, 0x1e, 0x96, 0x15, 0x13, 0x8c, 0xf4, 0x97, 0xe1, 0x50, 0x85, 0xef,
+	0xbc, 0x9d, 0xb0, 0x84, 0xa9, 0xe0, 0x50, 0xae, 0xb4, 0xee, 0x8e, 0x9f, 0x30, 0x96, 0x64, 0x38,
+	0x54, 0x5f, 0x71, 0x7d, 0x36, 0x9c, 0xd4, 0x15, 0x15, 0x29, 0x2b, 0x74, 0x3c, 0xf8, 0xdd, 0x85,
+	0xde, 0x7d, 0x56, 0x70, 0x2c, 0x78, 0xcd, 0x9f, 0xa9, 0x13, 0xc8, 0x21, 0xec, 0xc6, 0x19, 0x1b,
+	0xbf, 0xf4, 0x9c, 0x03, 0xe7, 0xee, 0xde, 0x27, 0xfb, 0xe1, 0xfa, 0x59, 0xe1, 0xb1, 0x0c, 0x6b,
+	0xf5, 0x48, 0x6b, 0xc9, 0x17, 0xd0, 0xc6, 0xf3, 0x74, 0x82, 0xc5, 0x18, 0xbd, 0xb7, 0x14, 0x77,
+	0x70, 0x9d, 0x7b, 0x60, 0x14, 0x06, 0x5d, 0x10, 0xe4, 0x1e, 0x74, 0xce, 0x69, 0x96, 0x4e, 0xa8,
+	0x60, 0x95, 0xe7, 0x2a, 0xfc, 0xfd, 0xeb, 0xf8, 0xf7, 0x56, 0x62, 0xf8, 0x25, 0x43, 0x3e, 0x85,
+	0xd6, 0x39, 0x56, 0x3c, 0x65, 0x85, 0xd7, 0x50, 0xf8, 0xe0, 0x7f, 0x70, 0x2d, 0x30, 0xb0, 0xd5,
+	0x4b, 0x54, 0xa4, 0x39, 0xb2, 0x5a, 0x78, 0xbb, 0x37, 0xa1, 0xcf, 0xb5, 0xc0, 0xa2, 0x46, 0x2f,
+	0x6d, 0xf3, 0x59, 0x31, 0x9e, 0x56, 0xac, 0x98, 0x79, 0xcd, 0x9b, 0x6c, 0x7f, 0x67, 0x25, 0xd6,
+	0xf6, 0x82, 0x91, 0x67, 0x9f, 0x21, 0x15, 0x75, 0x85, 0x5e, 0xeb, 0xa6, 0xb3, 0xbf, 0xd2, 0x02,
+	0x7b, 0xb6, 0xd1, 0x07, 0x5f, 0xc3, 0xde, 0x95, 0x32, 0x90, 0xf7, 0xa0, 0x93, 0xd3, 0x8b, 0x28,
+	0x9e, 0x09, 0xe4, 0xaa, 0x70, 0xee, 0xa8, 0x9d, 0xd3, 0x8b, 0x63, 0xf9, 0x4d, 0xde, 0x81, 0x96,
+	0x0c, 0x26, 0x94, 0xab, 0xda, 0xb8, 0xa3, 0x66, 0x4e, 0x2f, 0x1e, 0x52, 0xfe, 0xa8, 0xd1, 0x76,
+	0xfb, 0x8d, 0xe0, 0x37, 0x07, 0x6e, 0xaf, 0x96, 0x86, 0x7c, 0x04, 0x44, 0x12, 0x34, 0xc1, 0xa8,
+	0xa8, 0xf3, 0x48, 0xd5, 0xd8, 0xe6, 0xed, 0xe5, 0xf4, 0xe2, 0x28, 0xc1, 0xa7, 0x75, 0xae, 0x0c,
+	0x70, 0xf2, 0x04, 0xfa, 0x56, 0x6c, 0xdb, 0xcb, 0xf4, 0xc0, 0xbb, 0xa1, 0xee, 0xbf, 0xd0, 0xf6,
+	0x5f, 0x78, 0x62, 0x04, 0xc7, 0xed, 0xd7, 0x7f, 0x0d, 0x76, 0x7e, 0xfe, 0x7b, 0xe0, 0x8c, 0x6e,
+	0xeb, 0x7c, 0x36, 0xb2, 0x7a, 0x15, 0x77, 0xf5, 0x2a, 0xc1, 0x3d, 0xe8, 0xad, 0xb5, 0x01, 0x09,
+	0xa0, 0x5b, 0xd6, 0x71, 0xf4, 0x12, 0x67, 0x91, 0x7a, 0x31, 0xcf, 0x39, 0x70, 0xef, 0x76, 0x46,
+	0x7b, 0x65, 0x1d, 0x7f, 0x83, 0xb3, 0xe7, 0x72, 0xeb, 0xb3, 0xf6, 0x1f, 0xaf, 0x06, 0xce, 0xbf,
+	0xaf, 0x06, 0x4e, 0xf0, 0x08, 0xba, 0x2b, 0x8d, 0x40, 0xfa, 0xe0, 0xd2, 0xb2, 0x54, 0x77, 0x6b,
+	0x8c, 0xe4, 0x92, 0xec, 0x03, 0xf0, 0x34, 0x29, 0x8c, 0x03, 0x79, 0x93, 0xee, 0xa8, 0x23, 0x77,
+	0x94, 0x85, 0x2b, 0xb9, 0x7e, 0xdc, 0x85, 0xee, 0x4a, 0x6b, 0x90, 0x2f, 0xa1, 0x55, 0x56, 0xac,
+	0x64, 0x1c, 0x3d, 0x67, 0xf3, 0x17, 0xb0, 0x0c, 0x39, 0x85, 0xae, 0x59, 0x46, 0x13, 0xcc, 0x04,
+	0xdd, 0xe6, 0x19, 0x6f, 0x19, 0xf2, 0x44, 0x82, 0xda, 0x08, 0x9e, 0x33, 0x81, 0x9e, 0xbb, 0x79,
+	0x0e, 0xcb, 0x68, 0x23, 0x6a, 0x69, 0x8c, 0x34, 0xb6, 0x32, 0xa2, 0x48, 0x6d, 0xe4, 0x08, 0x3a,
+	0x65, 0x85, 0x63, 0x96, 0xe7, 0xa9, 0xfd, 0x81, 0x6d, 0x94, 0x65, 0x49, 0x91, 0xc7, 0xd0, 0x5b,
+	0x7c, 0x18, 0x3b, 0xcd, 0x2d, 0xda, 0x6b, 0xc1, 0x6a, 0x43, 0x9f, 0x43, 0xd3, 0xb8, 0x69, 0x6d,
+	0x9e, 0xc4, 0x20, 0xe4, 0x29, 0xf4, 0xcb, 0x0a, 0x4b, 0x5a, 0x61, 0xa4, 0x9f, 0x9b, 0x66, 0x5e,
+	0x7b, 0xf3, 0x34, 0x3d, 0x03, 0x3f, 0x33, 0xac, 0xce, 0xc7, 0xc6, 0xc8, 0xf9, 0x32, 0x5f, 0x67,
+	0xab, 0x7c, 0x0a, 0xb6, 0xf9, 0x82, 0x5f, 0x1c, 0xe8, 0xad, 0xcd, 0x1b, 0x5b, 0x81, 0x54, 0x4d,
+	0x47, 0x67, 0xcb, 0x0a, 0x28, 0x4a, 0xb6, 0x43, 0x8e, 0x9c, 0xab, 0x5f, 0x38, 0x66, 0x74, 0xb6,
+	0x55, 0x5f, 0x1a, 0xf2, 0x44, 0x82, 0xc1, 0x0f, 0x0e, 0x74, 0x57, 0x26, 0x1a, 0xf9, 0x18, 0x48,
+	0x19, 0x0b, 0x1e, 0x61, 0x41, 0xe3, 0x0c, 0xa3, 0x29, 0xa6, 0xc9, 0x54, 0x98, 0x51, 0xd3, 0x97,
+	0x91, 0x07, 0x2a, 0x70, 0xaa, 0xf6, 0xc9, 0x63, 0xf8, 0x20, 0xce, 0x78, 0x44, 0x93, 0xa4, 0xc2,
+	0x84, 0x0a, 0x9c, 0x44, 0xa6, 0x2f, 0x56, 0x71, 0x3d, 0xe6, 0x06, 0x71, 0xc6, 0x8f, 0x16, 0xca,
+	0xfb, 0x4a, 0x78, 0x35, 0x5b, 0xf0, 0x02, 0x6e, 0x9d, 0x52, 0x3e, 0xc5, 0x89, 0xf1, 0xf2, 0x21,
+	0xf4, 0xd4, 0xa8, 0x8b, 0xd6, 0x67, 0x69, 0x57, 0x6d, 0x3f, 0xb1, 0x03, 0x35, 0x80, 0xee, 0x52,
+	0xb7, 0x1c, 0xab, 0x7b, 0x56, 0xf5, 0x90, 0xf2, 0xe3, 0x6f, 0x7f, 0x9d, 0xfb, 0xce, 0xeb, 0xb9,
+	0xef, 0xbc, 0x99, 0xfb, 0xce, 0x3f, 0x73, 0xdf, 0xf9, 0xe9, 0xd2, 0xdf, 0x79, 0x73, 0xe9, 0xef,
+	0xfc, 0x79, 0xe9, 0xef, 0xbc, 0x38, 0x4c, 0x52, 0x31, 0xad, 0xe3, 0x70, 0xcc, 0xf2, 0xe1, 0x98,
+	0xe5, 0x28, 0xe2, 0x33, 0xb1, 0x5c, 0xe8, 0xbf, 0xe3, 0xeb, 0xff, 0x02, 0xc4, 0x4d, 0xb5, 0x7f,
+	0xf8, 0xdf, 0x00, 0x82, 0xb1, 0xae, 0xff, 0x1d, 0x08, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -452,6 +754,15 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	if !this.Version.Equal(that1.Version) {
 		return false
 	}
+	if !this.Timeout.Equal(that1.Timeout) {
+		return false
+	}
+	if !this.Synchrony.Equal(that1.Synchrony) {
+		return false
+	}
+	if !this.Feature.Equal(that1.Feature) {
+		return false
+	}
 	return true
 }
 func (this *BlockParams) Equal(that interface{}) bool {
@@ -562,6 +873,111 @@ func (this *VersionParams) Equal(that interface{}) bool {
 	if this.App != that1.App {
 		return false
 	}
+	if this.SignBytes != that1.SignBytes {
+		return false
+	}
+	return true
+}
+func (this *TimeoutParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TimeoutParams)
+	if !ok {
+		that2, ok := that.(TimeoutParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Propose != that1.Propose {
+		return false
+	}
+	if this.ProposeDelta != that1.ProposeDelta {
+		return false
+	}
+	if this.Prevote != that1.Prevote {
+		return false
+	}
+	if this.PrevoteDelta != that1.PrevoteDelta {
+		return false
+	}
+	if this.Precommit != that1.Precommit {
+		return false
+	}
+	if this.PrecommitDelta != that1.PrecommitDelta {
+		return false
+	}
+	if this.Commit != that1.Commit {
+		return false
+	}
+	if this.PrepareProposal != that1.PrepareProposal {
+		return false
+	}
+	if this.ProcessProposal != that1.ProcessProposal {
+		return false
+	}
+	return true
+}
+func (this *SynchronyParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SynchronyParams)
+	if !ok {
+		that2, ok := that.(SynchronyParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Precision != that1.Precision {
+		return false
+	}
+	if this.MessageDelay != that1.MessageDelay {
+		return false
+	}
+	return true
+}
+func (this *FeatureParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FeatureParams)
+	if !ok {
+		that2, ok := that.(FeatureParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.PbtsEnableHeight != that1.PbtsEnableHeight {
+		return false
+	}
+	if this.BlsAggregatedCommitEnableHeight != that1.BlsAggregatedCommitEnableHeight {
+		return false
+	}
 	return true
 }
 func (this *HashedParams) Equal(that interface{}) bool {
@@ -611,9 +1027,9 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if m.Version != nil {
+	if m.Feature != nil {
 		{
-			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -621,11 +1037,11 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintParams(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x3a
 	}
-	if m.Validator != nil {
+	if m.Synchrony != nil {
 		{
-			size, err := m.Validator.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Synchrony.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -633,11 +1049,11 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintParams(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x32
 	}
-	if m.Evidence != nil {
+	if m.Timeout != nil {
 		{
-			size, err := m.Evidence.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Timeout.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -645,11 +1061,11 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintParams(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x2a
 	}
-	if m.Block != nil {
+	if m.Version != nil {
 		{
-			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -657,15 +1073,51 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintParams(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x22
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *BlockParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if m.Validator != nil {
+		{
+			size, err := m.Validator.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Evidence != nil {
+		{
+			size, err := m.Evidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -720,12 +1172,12 @@ func (m *EvidenceParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x18
 	}
-	n5, err5 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MaxAgeDuration):])
-	if err5 != nil {
-		return 0, err5
+	n8, err8 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MaxAgeDuration):])
+	if err8 != nil {
+		return 0, err8
 	}
-	i -= n5
-	i = encodeVarintParams(dAtA, i, uint64(n5))
+	i -= n8
+	i = encodeVarintParams(dAtA, i, uint64(n8))
 	i--
 	dAtA[i] = 0x12
 	if m.MaxAgeNumBlocks != 0 {
@@ -788,6 +1240,11 @@ func (m *VersionParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SignBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.SignBytes))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.App != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.App))
 		i--
@@ -796,6 +1253,173 @@ func (m *VersionParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TimeoutParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeoutParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeoutParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n9, err9 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.ProcessProposal, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ProcessProposal):])
+	if err9 != nil {
+		return 0, err9
+	}
+	i -= n9
+	i = encodeVarintParams(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0x4a
+	n10, err10 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.PrepareProposal, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrepareProposal):])
+	if err10 != nil {
+		return 0, err10
+	}
+	i -= n10
+	i = encodeVarintParams(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0x42
+	n11, err11 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Commit, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Commit):])
+	if err11 != nil {
+		return 0, err11
+	}
+	i -= n11
+	i = encodeVarintParams(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0x3a
+	n12, err12 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.PrecommitDelta, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrecommitDelta):])
+	if err12 != nil {
+		return 0, err12
+	}
+	i -= n12
+	i = encodeVarintParams(dAtA, i, uint64(n12))
+	i--
+	dAtA[i] = 0x32
+	n13, err13 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Precommit, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precommit):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintParams(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x2a
+	n14, err14 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.PrevoteDelta, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrevoteDelta):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintParams(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0x22
+	n15, err15 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Prevote, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Prevote):])
+	if err15 != nil {
+		return 0, err15
+	}
+	i -= n15
+	i = encodeVarintParams(dAtA, i, uint64(n15))
+	i--
+	dAtA[i] = 0x1a
+	n16, err16 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.ProposeDelta, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ProposeDelta):])
+	if err16 != nil {
+		return 0, err16
+	}
+	i -= n16
+	i = encodeVarintParams(dAtA, i, uint64(n16))
+	i--
+	dAtA[i] = 0x12
+	n17, err17 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Propose, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Propose):])
+	if err17 != nil {
+		return 0, err17
+	}
+	i -= n17
+	i = encodeVarintParams(dAtA, i, uint64(n17))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SynchronyParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SynchronyParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SynchronyParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n18, err18 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.MessageDelay, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MessageDelay):])
+	if err18 != nil {
+		return 0, err18
+	}
+	i -= n18
+	i = encodeVarintParams(dAtA, i, uint64(n18))
+	i--
+	dAtA[i] = 0x12
+	n19, err19 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Precision, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precision):])
+	if err19 != nil {
+		return 0, err19
+	}
+	i -= n19
+	i = encodeVarintParams(dAtA, i, uint64(n19))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BlsAggregatedCommitEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BlsAggregatedCommitEnableHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.PbtsEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PbtsEnableHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *HashedParams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -855,6 +1479,7 @@ func NewPopulatedValidatorParams(r randyParams, easy bool) *ValidatorParams {
 func NewPopulatedVersionParams(r randyParams, easy bool) *VersionParams {
 	this := &VersionParams{}
 	this.App = uint64(uint64(r.Uint32()))
+	this.SignBytes = uint32(r.Uint32())
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -954,6 +1579,18 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Version.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.Timeout != nil {
+		l = m.Timeout.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.Synchrony != nil {
+		l = m.Synchrony.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -1013,6 +1650,64 @@ func (m *VersionParams) Size() (n int) {
 	if m.App != 0 {
 		n += 1 + sovParams(uint64(m.App))
 	}
+	if m.SignBytes != 0 {
+		n += 1 + sovParams(uint64(m.SignBytes))
+	}
+	return n
+}
+
+func (m *TimeoutParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Propose)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ProposeDelta)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Prevote)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrevoteDelta)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precommit)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrecommitDelta)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Commit)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.PrepareProposal)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ProcessProposal)
+	n += 1 + l + sovParams(uint64(l))
+	return n
+}
+
+func (m *SynchronyParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Precision)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.MessageDelay)
+	n += 1 + l + sovParams(uint64(l))
+	return n
+}
+
+func (m *FeatureParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PbtsEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.PbtsEnableHeight))
+	}
+	if m.BlsAggregatedCommitEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.BlsAggregatedCommitEnableHeight))
+	}
 	return n
 }
 
@@ -1210,61 +1905,47 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipParams(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthParams
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthParams
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *BlockParams) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowParams
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Timeout == nil {
+				m.Timeout = &TimeoutParams{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			if err := m.Timeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.MaxBytes = 0
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Synchrony", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowParams
@@ -1274,7 +1955,129 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxBytes |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Synchrony == nil {
+				m.Synchrony = &SynchronyParams{}
+			}
+			if err := m.Synchrony.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Feature == nil {
+				m.Feature = &FeatureParams{}
+			}
+			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1570,6 +2373,576 @@ func (m *VersionParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignBytes", wireType)
+			}
+			m.SignBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimeoutParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimeoutParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimeoutParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Propose", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Propose, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposeDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.ProposeDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prevote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Prevote, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevoteDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.PrevoteDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Precommit, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecommitDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.PrecommitDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Commit, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.PrepareProposal, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.ProcessProposal, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SynchronyParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SynchronyParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SynchronyParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precision", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Precision, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageDelay", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.MessageDelay, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PbtsEnableHeight", wireType)
+			}
+			m.PbtsEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PbtsEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlsAggregatedCommitEnableHeight", wireType)
+			}
+			m.BlsAggregatedCommitEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlsAggregatedCommitEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
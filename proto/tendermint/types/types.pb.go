@@ -571,6 +571,10 @@ type Commit struct {
 	Round      int32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID    BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	Signatures []CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures"`
+	// Aggregate of the BLS12-381 signatures of the commit, which are then left
+	// empty. Set from the height at which aggregated commits are enabled by the
+	// feature consensus params, if all the validators have BLS12-381 keys.
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
@@ -634,6 +638,13 @@ func (m *Commit) GetSignatures() []CommitSig {
 	return nil
 }
 
+func (m *Commit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // CommitSig is a part of the Vote included in a Commit.
 type CommitSig struct {
 	BlockIdFlag      BlockIDFlag `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=tendermint.types.BlockIDFlag" json:"block_id_flag,omitempty"`
@@ -1049,90 +1060,91 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1316 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xda, 0x9b, 0xd8, 0x7e, 0xb6, 0x13, 0x67, 0x95, 0xb6, 0xae, 0xdb, 0x38, 0x2b, 0x23,
-	0x20, 0x2d, 0x68, 0x53, 0x52, 0x84, 0xe0, 0xc0, 0xc1, 0x76, 0xd2, 0xd6, 0x6a, 0xec, 0x58, 0x6b,
-	0xb7, 0x08, 0x2e, 0xab, 0xb5, 0x77, 0x62, 0x2f, 0x5d, 0xef, 0xac, 0x76, 0xc6, 0x21, 0xe9, 0x27,
-	0x40, 0x39, 0xf5, 0xc4, 0x2d, 0x27, 0x38, 0x70, 0xe7, 0x0b, 0x20, 0x4e, 0x3d, 0xf6, 0x06, 0x17,
-	0x0a, 0x4a, 0x25, 0xc4, 0xc7, 0x40, 0xf3, 0xc7, 0xeb, 0x75, 0x9c, 0x40, 0x55, 0x55, 0x5c, 0xac,
-	0x9d, 0xf7, 0x7e, 0x6f, 0xe6, 0xbd, 0xdf, 0xfb, 0xcd, 0x1f, 0xc3, 0x4d, 0x8a, 0x7c, 0x07, 0x85,
-	0x23, 0xd7, 0xa7, 0x5b, 0xf4, 0x38, 0x40, 0x44, 0xfc, 0x1a, 0x41, 0x88, 0x29, 0xd6, 0x0a, 0x53,
-	0xaf, 0xc1, 0xed, 0xa5, 0xb5, 0x01, 0x1e, 0x60, 0xee, 0xdc, 0x62, 0x5f, 0x02, 0x57, 0xda, 0x18,
-	0x60, 0x3c, 0xf0, 0xd0, 0x16, 0x1f, 0xf5, 0xc6, 0x07, 0x5b, 0xd4, 0x1d, 0x21, 0x42, 0xed, 0x51,
-	0x20, 0x01, 0xeb, 0xb1, 0x65, 0xfa, 0xe1, 0x71, 0x40, 0x31, 0xc3, 0xe2, 0x03, 0xe9, 0x2e, 0xc7,
-	0xdc, 0x87, 0x28, 0x24, 0x2e, 0xf6, 0xe3, 0x79, 0x94, 0xf4, 0xb9, 0x2c, 0x0f, 0x6d, 0xcf, 0x75,
-	0x6c, 0x8a, 0x43, 0x81, 0xa8, 0x7c, 0x06, 0xf9, 0xb6, 0x1d, 0xd2, 0x0e, 0xa2, 0x0f, 0x90, 0xed,
-	0xa0, 0x50, 0x5b, 0x83, 0x45, 0x8a, 0xa9, 0xed, 0x15, 0x15, 0x5d, 0xd9, 0xcc, 0x9b, 0x62, 0xa0,
-	0x69, 0xa0, 0x0e, 0x6d, 0x32, 0x2c, 0x26, 0x74, 0x65, 0x33, 0x67, 0xf2, 0xef, 0xca, 0x10, 0x54,
-	0x16, 0xca, 0x22, 0x5c, 0xdf, 0x41, 0x47, 0x93, 0x08, 0x3e, 0x60, 0xd6, 0xde, 0x31, 0x45, 0x44,
-	0x86, 0x88, 0x81, 0xf6, 0x31, 0x2c, 0xf2, 0xfc, 0x8b, 0x49, 0x5d, 0xd9, 0xcc, 0x6e, 0x17, 0x8d,
-	0x18, 0x51, 0xa2, 0x3e, 0xa3, 0xcd, 0xfc, 0x35, 0xf5, 0xf9, 0xcb, 0x8d, 0x05, 0x53, 0x80, 0x2b,
-	0x1e, 0xa4, 0x6a, 0x1e, 0xee, 0x3f, 0x69, 0xec, 0x44, 0x89, 0x28, 0xd3, 0x44, 0xb4, 0x26, 0xac,
-	0x04, 0x76, 0x48, 0x2d, 0x82, 0xa8, 0x35, 0xe4, 0x55, 0xf0, 0x45, 0xb3, 0xdb, 0x1b, 0xc6, 0xf9,
-	0x3e, 0x18, 0x33, 0xc5, 0xca, 0x55, 0xf2, 0x41, 0xdc, 0x58, 0xf9, 0x4b, 0x85, 0x25, 0x49, 0xc6,
-	0xe7, 0x90, 0x92, 0xb4, 0xf2, 0x05, 0xb3, 0xdb, 0xeb, 0xf1, 0x19, 0xa5, 0xcb, 0xa8, 0x63, 0x9f,
-	0x20, 0x9f, 0x8c, 0x89, 0x9c, 0x6f, 0x12, 0xa3, 0xbd, 0x07, 0xe9, 0xfe, 0xd0, 0x76, 0x7d, 0xcb,
-	0x75, 0x78, 0x46, 0x99, 0x5a, 0xf6, 0xec, 0xe5, 0x46, 0xaa, 0xce, 0x6c, 0x8d, 0x1d, 0x33, 0xc5,
-	0x9d, 0x0d, 0x47, 0xbb, 0x0a, 0x4b, 0x43, 0xe4, 0x0e, 0x86, 0x94, 0xd3, 0x92, 0x34, 0xe5, 0x48,
-	0xfb, 0x14, 0x54, 0x26, 0x88, 0xa2, 0xca, 0xd7, 0x2e, 0x19, 0x42, 0x2d, 0xc6, 0x44, 0x2d, 0x46,
-	0x77, 0xa2, 0x96, 0x5a, 0x9a, 0x2d, 0xfc, 0xec, 0x8f, 0x0d, 0xc5, 0xe4, 0x11, 0x5a, 0x1d, 0xf2,
-	0x9e, 0x4d, 0xa8, 0xd5, 0x63, 0xb4, 0xb1, 0xe5, 0x17, 0xf9, 0x14, 0xd7, 0xe7, 0x09, 0x91, 0xc4,
-	0xca, 0xd4, 0xb3, 0x2c, 0x4a, 0x98, 0x1c, 0x6d, 0x13, 0x0a, 0x7c, 0x92, 0x3e, 0x1e, 0x8d, 0x5c,
-	0x6a, 0x71, 0xde, 0x97, 0x38, 0xef, 0xcb, 0xcc, 0x5e, 0xe7, 0xe6, 0x07, 0xac, 0x03, 0x37, 0x20,
-	0xe3, 0xd8, 0xd4, 0x16, 0x90, 0x14, 0x87, 0xa4, 0x99, 0x81, 0x3b, 0xdf, 0x87, 0x95, 0x48, 0x75,
-	0x44, 0x40, 0xd2, 0x62, 0x96, 0xa9, 0x99, 0x03, 0xef, 0xc0, 0x9a, 0x8f, 0x8e, 0xa8, 0x75, 0x1e,
-	0x9d, 0xe1, 0x68, 0x8d, 0xf9, 0x1e, 0xcf, 0x46, 0xbc, 0x0b, 0xcb, 0xfd, 0x09, 0xf9, 0x02, 0x0b,
-	0x1c, 0x9b, 0x8f, 0xac, 0x1c, 0x76, 0x1d, 0xd2, 0x76, 0x10, 0x08, 0x40, 0x96, 0x03, 0x52, 0x76,
-	0x10, 0x70, 0xd7, 0x6d, 0x58, 0xe5, 0x35, 0x86, 0x88, 0x8c, 0x3d, 0x2a, 0x27, 0xc9, 0x71, 0xcc,
-	0x0a, 0x73, 0x98, 0xc2, 0xce, 0xb1, 0xef, 0x40, 0x1e, 0x1d, 0xba, 0x0e, 0xf2, 0xfb, 0x48, 0xe0,
-	0xf2, 0x1c, 0x97, 0x9b, 0x18, 0x39, 0xe8, 0x16, 0x14, 0x82, 0x10, 0x07, 0x98, 0xa0, 0xd0, 0xb2,
-	0x1d, 0x27, 0x44, 0x84, 0x14, 0x97, 0xc5, 0x7c, 0x13, 0x7b, 0x55, 0x98, 0x2b, 0x45, 0x50, 0x77,
-	0x6c, 0x6a, 0x6b, 0x05, 0x48, 0xd2, 0x23, 0x52, 0x54, 0xf4, 0xe4, 0x66, 0xce, 0x64, 0x9f, 0x95,
-	0xbf, 0x13, 0xa0, 0x3e, 0xc6, 0x14, 0x69, 0x77, 0x41, 0x65, 0x6d, 0xe2, 0xea, 0x5b, 0xbe, 0x48,
-	0xcf, 0x1d, 0x77, 0xe0, 0x23, 0xa7, 0x49, 0x06, 0xdd, 0xe3, 0x00, 0x99, 0x1c, 0x1c, 0x93, 0x53,
-	0x62, 0x46, 0x4e, 0x6b, 0xb0, 0x18, 0xe2, 0xb1, 0xef, 0x70, 0x95, 0x2d, 0x9a, 0x62, 0xa0, 0xed,
-	0x42, 0x3a, 0x52, 0x89, 0xfa, 0x5f, 0x2a, 0x59, 0x61, 0x2a, 0x61, 0x1a, 0x96, 0x06, 0x33, 0xd5,
-	0x93, 0x62, 0xa9, 0x41, 0x26, 0x3a, 0xbc, 0xa4, 0xda, 0x5e, 0x4f, 0xb0, 0xd3, 0x30, 0xed, 0x03,
-	0x58, 0x8d, 0x7a, 0x1f, 0x91, 0x27, 0x14, 0x57, 0x88, 0x1c, 0x92, 0xbd, 0x19, 0x59, 0x59, 0xe2,
-	0x00, 0x4a, 0xf1, 0xba, 0xa6, 0xb2, 0x6a, 0xf0, 0x93, 0xe8, 0x26, 0x64, 0x88, 0x3b, 0xf0, 0x6d,
-	0x3a, 0x0e, 0x91, 0x54, 0xde, 0xd4, 0x50, 0xf9, 0x59, 0x81, 0x25, 0xa1, 0xe4, 0x18, 0x6f, 0xca,
-	0xc5, 0xbc, 0x25, 0x2e, 0xe3, 0x2d, 0xf9, 0xe6, 0xbc, 0x55, 0x01, 0xa2, 0x64, 0x48, 0x51, 0xd5,
-	0x93, 0x9b, 0xd9, 0xed, 0x1b, 0xf3, 0x13, 0x89, 0x14, 0x3b, 0xee, 0x40, 0x6e, 0xd4, 0x58, 0x50,
-	0xe5, 0x77, 0x05, 0x32, 0x91, 0x5f, 0xab, 0x42, 0x7e, 0x92, 0x97, 0x75, 0xe0, 0xd9, 0x03, 0xa9,
-	0x9d, 0xf5, 0x4b, 0x93, 0xbb, 0xe7, 0xd9, 0x03, 0x33, 0x2b, 0xf3, 0x61, 0x83, 0x8b, 0xfb, 0x90,
-	0xb8, 0xa4, 0x0f, 0x33, 0x8d, 0x4f, 0xbe, 0x59, 0xe3, 0x67, 0x5a, 0xa4, 0x9e, 0x6f, 0xd1, 0x4f,
-	0x09, 0x48, 0xb7, 0xf9, 0xde, 0xb1, 0xbd, 0xff, 0x63, 0x47, 0xdc, 0x80, 0x4c, 0x80, 0x3d, 0x4b,
-	0x78, 0x54, 0xee, 0x49, 0x07, 0xd8, 0x33, 0xe7, 0xda, 0xbe, 0xf8, 0x96, 0xb6, 0xcb, 0xd2, 0x5b,
-	0x60, 0x2d, 0x75, 0x9e, 0xb5, 0x10, 0x72, 0x82, 0x0a, 0x79, 0x97, 0xdd, 0x61, 0x1c, 0xf0, 0xcb,
-	0x51, 0x99, 0xbf, 0x7b, 0x45, 0xda, 0x02, 0x69, 0x4a, 0x1c, 0x8b, 0x10, 0x47, 0xbf, 0xbc, 0x4e,
-	0x8b, 0x97, 0xc9, 0xd2, 0x94, 0xb8, 0xca, 0x77, 0x0a, 0xc0, 0x1e, 0x63, 0x96, 0xd7, 0xcb, 0x6e,
-	0x21, 0xc2, 0x53, 0xb0, 0x66, 0x56, 0x2e, 0x5f, 0xd6, 0x34, 0xb9, 0x7e, 0x8e, 0xc4, 0xf3, 0xae,
-	0x43, 0x7e, 0x2a, 0x46, 0x82, 0x26, 0xc9, 0x5c, 0x30, 0x49, 0x74, 0x39, 0x74, 0x10, 0x35, 0x73,
-	0x87, 0xb1, 0x51, 0xe5, 0x17, 0x05, 0x32, 0x3c, 0xa7, 0x26, 0xa2, 0xf6, 0x4c, 0x0f, 0x95, 0x37,
-	0xef, 0xe1, 0x3a, 0x80, 0x98, 0x86, 0xb8, 0x4f, 0x91, 0x54, 0x56, 0x86, 0x5b, 0x3a, 0xee, 0x53,
-	0xa4, 0x7d, 0x12, 0x11, 0x9e, 0xfc, 0x77, 0xc2, 0xe5, 0x96, 0x9e, 0xd0, 0x7e, 0x0d, 0x52, 0xfe,
-	0x78, 0x64, 0xb1, 0x2b, 0x41, 0x15, 0x6a, 0xf5, 0xc7, 0xa3, 0xee, 0x11, 0xa9, 0x7c, 0x0d, 0xa9,
-	0xee, 0x11, 0x7f, 0x1e, 0x31, 0x89, 0x86, 0x18, 0xcb, 0x3b, 0x59, 0xbc, 0x85, 0xd2, 0xcc, 0xc0,
-	0xaf, 0x20, 0x0d, 0x54, 0x76, 0xf9, 0x4e, 0x1e, 0x6b, 0xec, 0x5b, 0x33, 0x5e, 0xf3, 0xe1, 0x25,
-	0x9f, 0x5c, 0xb7, 0x7f, 0x55, 0x20, 0x1b, 0x3b, 0x1f, 0xb4, 0x8f, 0xe0, 0x4a, 0x6d, 0x6f, 0xbf,
-	0xfe, 0xd0, 0x6a, 0xec, 0x58, 0xf7, 0xf6, 0xaa, 0xf7, 0xad, 0x47, 0xad, 0x87, 0xad, 0xfd, 0x2f,
-	0x5a, 0x85, 0x85, 0xd2, 0xd5, 0x93, 0x53, 0x5d, 0x8b, 0x61, 0x1f, 0xf9, 0x4f, 0x7c, 0xfc, 0x8d,
-	0xaf, 0x6d, 0xc1, 0xda, 0x6c, 0x48, 0xb5, 0xd6, 0xd9, 0x6d, 0x75, 0x0b, 0x4a, 0xe9, 0xca, 0xc9,
-	0xa9, 0xbe, 0x1a, 0x8b, 0xa8, 0xf6, 0x08, 0xf2, 0xe9, 0x7c, 0x40, 0x7d, 0xbf, 0xd9, 0x6c, 0x74,
-	0x0b, 0x89, 0xb9, 0x00, 0x79, 0x60, 0xdf, 0x82, 0xd5, 0xd9, 0x80, 0x56, 0x63, 0xaf, 0x90, 0x2c,
-	0x69, 0x27, 0xa7, 0xfa, 0x72, 0x0c, 0xdd, 0x72, 0xbd, 0x52, 0xfa, 0xdb, 0xef, 0xcb, 0x0b, 0x3f,
-	0xfe, 0x50, 0x56, 0x58, 0x65, 0xf9, 0x99, 0x33, 0x42, 0xfb, 0x10, 0xae, 0x75, 0x1a, 0xf7, 0x5b,
-	0xbb, 0x3b, 0x56, 0xb3, 0x73, 0xdf, 0xea, 0x7e, 0xd9, 0xde, 0x8d, 0x55, 0xb7, 0x72, 0x72, 0xaa,
-	0x67, 0x65, 0x49, 0x97, 0xa1, 0xdb, 0xe6, 0xee, 0xe3, 0xfd, 0xee, 0x6e, 0x41, 0x11, 0xe8, 0x76,
-	0x88, 0x0e, 0x31, 0x45, 0x1c, 0x7d, 0x07, 0xae, 0x5f, 0x80, 0x8e, 0x0a, 0x5b, 0x3d, 0x39, 0xd5,
-	0xf3, 0xed, 0x10, 0x89, 0xfd, 0xc3, 0x23, 0x0c, 0x28, 0xce, 0x47, 0xec, 0xb7, 0xf7, 0x3b, 0xd5,
-	0xbd, 0x82, 0x5e, 0x2a, 0x9c, 0x9c, 0xea, 0xb9, 0xc9, 0x61, 0xc8, 0xf0, 0xd3, 0xca, 0x6a, 0xcd,
-	0xe7, 0x67, 0x65, 0xe5, 0xc5, 0x59, 0x59, 0xf9, 0xf3, 0xac, 0xac, 0x3c, 0x7b, 0x55, 0x5e, 0x78,
-	0xf1, 0xaa, 0xbc, 0xf0, 0xdb, 0xab, 0xf2, 0xc2, 0x57, 0x77, 0x07, 0x2e, 0x1d, 0x8e, 0x7b, 0x46,
-	0x1f, 0x8f, 0xb6, 0xfa, 0x78, 0x84, 0x68, 0xef, 0x80, 0x4e, 0x3f, 0xc4, 0xdf, 0x92, 0xf3, 0x7f,
-	0x15, 0x7a, 0x4b, 0xdc, 0x7e, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x9c, 0x41, 0x46, 0x23,
-	0xeb, 0x0c, 0x00, 0x00,
+	// 1337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4d, 0x6f, 0xdb, 0x46,
+	0x13, 0x36, 0x25, 0xea, 0x6b, 0x24, 0xd9, 0xf2, 0xbe, 0x4e, 0xa2, 0x28, 0xb1, 0x2c, 0xe8, 0xc5,
+	0xfb, 0xd6, 0x49, 0x0b, 0x39, 0x71, 0x8a, 0xa2, 0x3d, 0xf4, 0x20, 0xc9, 0x4e, 0x22, 0xc4, 0x92,
+	0x05, 0x4a, 0x49, 0xd1, 0x5e, 0x08, 0x4a, 0x5c, 0x53, 0x6c, 0x28, 0x2e, 0x41, 0xae, 0x5c, 0x3b,
+	0xbf, 0xa0, 0xf0, 0x29, 0xa7, 0xde, 0x7c, 0x6a, 0x0f, 0xbd, 0xf7, 0x1f, 0xf4, 0x94, 0x63, 0x6e,
+	0xed, 0xa5, 0x69, 0xe1, 0x00, 0x45, 0xef, 0xfd, 0x03, 0xc5, 0x7e, 0x88, 0xa2, 0x2c, 0xbb, 0x1f,
+	0x41, 0xd0, 0x8b, 0xc0, 0x9d, 0x79, 0x66, 0x76, 0xe6, 0x99, 0x87, 0xbb, 0x14, 0xdc, 0xa4, 0xd8,
+	0x35, 0xb1, 0x3f, 0xb6, 0x5d, 0xba, 0x45, 0x8f, 0x3d, 0x1c, 0x88, 0xdf, 0x9a, 0xe7, 0x13, 0x4a,
+	0x50, 0x61, 0xe6, 0xad, 0x71, 0x7b, 0x69, 0xcd, 0x22, 0x16, 0xe1, 0xce, 0x2d, 0xf6, 0x24, 0x70,
+	0xa5, 0x0d, 0x8b, 0x10, 0xcb, 0xc1, 0x5b, 0x7c, 0x35, 0x98, 0x1c, 0x6c, 0x51, 0x7b, 0x8c, 0x03,
+	0x6a, 0x8c, 0x3d, 0x09, 0x58, 0x8f, 0x6c, 0x33, 0xf4, 0x8f, 0x3d, 0x4a, 0x18, 0x96, 0x1c, 0x48,
+	0x77, 0x39, 0xe2, 0x3e, 0xc4, 0x7e, 0x60, 0x13, 0x37, 0x5a, 0x47, 0xa9, 0xb2, 0x50, 0xe5, 0xa1,
+	0xe1, 0xd8, 0xa6, 0x41, 0x89, 0x2f, 0x10, 0xd5, 0x8f, 0x20, 0xdf, 0x35, 0x7c, 0xda, 0xc3, 0xf4,
+	0x21, 0x36, 0x4c, 0xec, 0xa3, 0x35, 0x48, 0x50, 0x42, 0x0d, 0xa7, 0xa8, 0x54, 0x94, 0xcd, 0xbc,
+	0x26, 0x16, 0x08, 0x81, 0x3a, 0x32, 0x82, 0x51, 0x31, 0x56, 0x51, 0x36, 0x73, 0x1a, 0x7f, 0xae,
+	0x8e, 0x40, 0x65, 0xa1, 0x2c, 0xc2, 0x76, 0x4d, 0x7c, 0x34, 0x8d, 0xe0, 0x0b, 0x66, 0x1d, 0x1c,
+	0x53, 0x1c, 0xc8, 0x10, 0xb1, 0x40, 0xef, 0x43, 0x82, 0xd7, 0x5f, 0x8c, 0x57, 0x94, 0xcd, 0xec,
+	0x76, 0xb1, 0x16, 0x21, 0x4a, 0xf4, 0x57, 0xeb, 0x32, 0x7f, 0x43, 0x7d, 0xf1, 0x6a, 0x63, 0x49,
+	0x13, 0xe0, 0xaa, 0x03, 0xa9, 0x86, 0x43, 0x86, 0x4f, 0x5b, 0x3b, 0x61, 0x21, 0xca, 0xac, 0x10,
+	0xd4, 0x86, 0x15, 0xcf, 0xf0, 0xa9, 0x1e, 0x60, 0xaa, 0x8f, 0x78, 0x17, 0x7c, 0xd3, 0xec, 0xf6,
+	0x46, 0xed, 0xfc, 0x1c, 0x6a, 0x73, 0xcd, 0xca, 0x5d, 0xf2, 0x5e, 0xd4, 0x58, 0xfd, 0x55, 0x85,
+	0xa4, 0x24, 0xe3, 0x63, 0x48, 0x49, 0x5a, 0xf9, 0x86, 0xd9, 0xed, 0xf5, 0x68, 0x46, 0xe9, 0xaa,
+	0x35, 0x89, 0x1b, 0x60, 0x37, 0x98, 0x04, 0x32, 0xdf, 0x34, 0x06, 0xfd, 0x1f, 0xd2, 0xc3, 0x91,
+	0x61, 0xbb, 0xba, 0x6d, 0xf2, 0x8a, 0x32, 0x8d, 0xec, 0xd9, 0xab, 0x8d, 0x54, 0x93, 0xd9, 0x5a,
+	0x3b, 0x5a, 0x8a, 0x3b, 0x5b, 0x26, 0xba, 0x0a, 0xc9, 0x11, 0xb6, 0xad, 0x11, 0xe5, 0xb4, 0xc4,
+	0x35, 0xb9, 0x42, 0x1f, 0x82, 0xca, 0x04, 0x51, 0x54, 0xf9, 0xde, 0xa5, 0x9a, 0x50, 0x4b, 0x6d,
+	0xaa, 0x96, 0x5a, 0x7f, 0xaa, 0x96, 0x46, 0x9a, 0x6d, 0xfc, 0xfc, 0xe7, 0x0d, 0x45, 0xe3, 0x11,
+	0xa8, 0x09, 0x79, 0xc7, 0x08, 0xa8, 0x3e, 0x60, 0xb4, 0xb1, 0xed, 0x13, 0x3c, 0xc5, 0xf5, 0x45,
+	0x42, 0x24, 0xb1, 0xb2, 0xf4, 0x2c, 0x8b, 0x12, 0x26, 0x13, 0x6d, 0x42, 0x81, 0x27, 0x19, 0x92,
+	0xf1, 0xd8, 0xa6, 0x3a, 0xe7, 0x3d, 0xc9, 0x79, 0x5f, 0x66, 0xf6, 0x26, 0x37, 0x3f, 0x64, 0x13,
+	0xb8, 0x01, 0x19, 0xd3, 0xa0, 0x86, 0x80, 0xa4, 0x38, 0x24, 0xcd, 0x0c, 0xdc, 0xf9, 0x0e, 0xac,
+	0x84, 0xaa, 0x0b, 0x04, 0x24, 0x2d, 0xb2, 0xcc, 0xcc, 0x1c, 0x78, 0x07, 0xd6, 0x5c, 0x7c, 0x44,
+	0xf5, 0xf3, 0xe8, 0x0c, 0x47, 0x23, 0xe6, 0x7b, 0x32, 0x1f, 0xf1, 0x3f, 0x58, 0x1e, 0x4e, 0xc9,
+	0x17, 0x58, 0xe0, 0xd8, 0x7c, 0x68, 0xe5, 0xb0, 0xeb, 0x90, 0x36, 0x3c, 0x4f, 0x00, 0xb2, 0x1c,
+	0x90, 0x32, 0x3c, 0x8f, 0xbb, 0x6e, 0xc3, 0x2a, 0xef, 0xd1, 0xc7, 0xc1, 0xc4, 0xa1, 0x32, 0x49,
+	0x8e, 0x63, 0x56, 0x98, 0x43, 0x13, 0x76, 0x8e, 0xfd, 0x2f, 0xe4, 0xf1, 0xa1, 0x6d, 0x62, 0x77,
+	0x88, 0x05, 0x2e, 0xcf, 0x71, 0xb9, 0xa9, 0x91, 0x83, 0x6e, 0x41, 0xc1, 0xf3, 0x89, 0x47, 0x02,
+	0xec, 0xeb, 0x86, 0x69, 0xfa, 0x38, 0x08, 0x8a, 0xcb, 0x22, 0xdf, 0xd4, 0x5e, 0x17, 0xe6, 0x6a,
+	0x11, 0xd4, 0x1d, 0x83, 0x1a, 0xa8, 0x00, 0x71, 0x7a, 0x14, 0x14, 0x95, 0x4a, 0x7c, 0x33, 0xa7,
+	0xb1, 0xc7, 0xea, 0x6f, 0x31, 0x50, 0x9f, 0x10, 0x8a, 0xd1, 0x3d, 0x50, 0xd9, 0x98, 0xb8, 0xfa,
+	0x96, 0x2f, 0xd2, 0x73, 0xcf, 0xb6, 0x5c, 0x6c, 0xb6, 0x03, 0xab, 0x7f, 0xec, 0x61, 0x8d, 0x83,
+	0x23, 0x72, 0x8a, 0xcd, 0xc9, 0x69, 0x0d, 0x12, 0x3e, 0x99, 0xb8, 0x26, 0x57, 0x59, 0x42, 0x13,
+	0x0b, 0xb4, 0x0b, 0xe9, 0x50, 0x25, 0xea, 0x5f, 0xa9, 0x64, 0x85, 0xa9, 0x84, 0x69, 0x58, 0x1a,
+	0xb4, 0xd4, 0x40, 0x8a, 0xa5, 0x01, 0x99, 0xf0, 0xf0, 0x2a, 0x26, 0xfe, 0x81, 0x60, 0x67, 0x61,
+	0xe8, 0x5d, 0x58, 0x0d, 0x67, 0x1f, 0x92, 0x27, 0x14, 0x57, 0x08, 0x1d, 0x92, 0xbd, 0x39, 0x59,
+	0xe9, 0xe2, 0x00, 0x4a, 0xf1, 0xbe, 0x66, 0xb2, 0x6a, 0x31, 0x2b, 0xba, 0x09, 0x99, 0xc0, 0xb6,
+	0x5c, 0x83, 0x4e, 0x7c, 0x2c, 0x95, 0x37, 0x33, 0x54, 0x7f, 0x57, 0x20, 0x29, 0x94, 0x1c, 0xe1,
+	0x4d, 0xb9, 0x98, 0xb7, 0xd8, 0x65, 0xbc, 0xc5, 0xdf, 0x9c, 0xb7, 0x3a, 0x40, 0x58, 0x4c, 0x50,
+	0x54, 0x2b, 0xf1, 0xcd, 0xec, 0xf6, 0x8d, 0xc5, 0x44, 0xa2, 0xc4, 0x9e, 0x6d, 0xc9, 0x17, 0x35,
+	0x12, 0x84, 0xee, 0xc2, 0x9a, 0x61, 0x59, 0x3e, 0xb6, 0x0c, 0x8a, 0x4d, 0x7d, 0xd6, 0x6b, 0x82,
+	0xf7, 0xfa, 0x9f, 0x99, 0xaf, 0x17, 0x76, 0xfd, 0x93, 0x02, 0x99, 0x30, 0x25, 0xaa, 0x43, 0x7e,
+	0xda, 0x8a, 0x7e, 0xe0, 0x18, 0x96, 0x94, 0xdb, 0xfa, 0xa5, 0xfd, 0xdc, 0x77, 0x0c, 0x4b, 0xcb,
+	0xca, 0x16, 0xd8, 0xe2, 0xe2, 0xd1, 0xc5, 0x2e, 0x19, 0xdd, 0x9c, 0x56, 0xe2, 0x6f, 0xa6, 0x95,
+	0xb9, 0xa9, 0xaa, 0xe7, 0xa7, 0xfa, 0x5d, 0x0c, 0xd2, 0x5d, 0xfe, 0xba, 0x19, 0xce, 0xbf, 0xf1,
+	0x12, 0xdd, 0x80, 0x8c, 0x47, 0x1c, 0x5d, 0x78, 0x54, 0xee, 0x49, 0x7b, 0xc4, 0xd1, 0x16, 0x94,
+	0x92, 0x78, 0x4b, 0x6f, 0x58, 0xf2, 0x2d, 0xb0, 0x96, 0x3a, 0xcf, 0x9a, 0x0f, 0x39, 0x41, 0x85,
+	0xbc, 0xfe, 0xee, 0x30, 0x0e, 0xd8, 0x53, 0x51, 0x59, 0xbc, 0xae, 0x45, 0xd9, 0x02, 0xa9, 0x25,
+	0x47, 0x61, 0x84, 0xb8, 0x2d, 0x8a, 0xb1, 0xcb, 0x22, 0x84, 0xec, 0x34, 0x89, 0xab, 0x7e, 0xa5,
+	0x00, 0xec, 0x31, 0x66, 0x79, 0xbf, 0xec, 0xe2, 0x0a, 0x78, 0x09, 0xfa, 0xdc, 0xce, 0xe5, 0xcb,
+	0x86, 0x26, 0xf7, 0xcf, 0x05, 0xd1, 0xba, 0x9b, 0x90, 0x9f, 0x89, 0x31, 0xc0, 0xd3, 0x62, 0x2e,
+	0x48, 0x12, 0xde, 0x27, 0x3d, 0x4c, 0xb5, 0xdc, 0x61, 0x64, 0x55, 0xfd, 0x5e, 0x81, 0x0c, 0xaf,
+	0xa9, 0x8d, 0xa9, 0x31, 0x37, 0x43, 0xe5, 0xcd, 0x67, 0xb8, 0x0e, 0x20, 0xd2, 0x04, 0xf6, 0x33,
+	0x2c, 0x95, 0x95, 0xe1, 0x96, 0x9e, 0xfd, 0x0c, 0xa3, 0x0f, 0x42, 0xc2, 0xe3, 0x7f, 0x4e, 0xb8,
+	0x3c, 0x05, 0xa6, 0xb4, 0x5f, 0x83, 0x94, 0x3b, 0x19, 0xeb, 0xec, 0x16, 0x51, 0x85, 0x5a, 0xdd,
+	0xc9, 0xb8, 0x7f, 0x14, 0x54, 0x3f, 0x87, 0x54, 0xff, 0x88, 0x7f, 0x51, 0x31, 0x89, 0xfa, 0x84,
+	0xc8, 0x6b, 0x5c, 0x7c, 0x3e, 0xa5, 0x99, 0x81, 0xdf, 0x5a, 0x08, 0x54, 0x76, 0x5f, 0x4f, 0xbf,
+	0xef, 0xd8, 0x33, 0xaa, 0xfd, 0xcd, 0x6f, 0x35, 0xf9, 0x95, 0x76, 0xfb, 0x07, 0x05, 0xb2, 0x91,
+	0xf3, 0x01, 0xdd, 0x85, 0x2b, 0x8d, 0xbd, 0xfd, 0xe6, 0x23, 0xbd, 0xb5, 0xa3, 0xdf, 0xdf, 0xab,
+	0x3f, 0xd0, 0x1f, 0x77, 0x1e, 0x75, 0xf6, 0x3f, 0xe9, 0x14, 0x96, 0x4a, 0x57, 0x4f, 0x4e, 0x2b,
+	0x28, 0x82, 0x7d, 0xec, 0x3e, 0x75, 0xc9, 0x17, 0x2e, 0xda, 0x82, 0xb5, 0xf9, 0x90, 0x7a, 0xa3,
+	0xb7, 0xdb, 0xe9, 0x17, 0x94, 0xd2, 0x95, 0x93, 0xd3, 0xca, 0x6a, 0x24, 0xa2, 0x3e, 0x08, 0xb0,
+	0x4b, 0x17, 0x03, 0x9a, 0xfb, 0xed, 0x76, 0xab, 0x5f, 0x88, 0x2d, 0x04, 0xc8, 0x33, 0xfe, 0x16,
+	0xac, 0xce, 0x07, 0x74, 0x5a, 0x7b, 0x85, 0x78, 0x09, 0x9d, 0x9c, 0x56, 0x96, 0x23, 0xe8, 0x8e,
+	0xed, 0x94, 0xd2, 0x5f, 0x7e, 0x5d, 0x5e, 0xfa, 0xf6, 0x9b, 0xb2, 0xc2, 0x3a, 0xcb, 0xcf, 0x9d,
+	0x11, 0xe8, 0x3d, 0xb8, 0xd6, 0x6b, 0x3d, 0xe8, 0xec, 0xee, 0xe8, 0xed, 0xde, 0x03, 0xbd, 0xff,
+	0x69, 0x77, 0x37, 0xd2, 0xdd, 0xca, 0xc9, 0x69, 0x25, 0x2b, 0x5b, 0xba, 0x0c, 0xdd, 0xd5, 0x76,
+	0x9f, 0xec, 0xf7, 0x77, 0x0b, 0x8a, 0x40, 0x77, 0x7d, 0x7c, 0x48, 0x28, 0xe6, 0xe8, 0x3b, 0x70,
+	0xfd, 0x02, 0x74, 0xd8, 0xd8, 0xea, 0xc9, 0x69, 0x25, 0xdf, 0xf5, 0xb1, 0x78, 0x7f, 0x78, 0x44,
+	0x0d, 0x8a, 0x8b, 0x11, 0xfb, 0xdd, 0xfd, 0x5e, 0x7d, 0xaf, 0x50, 0x29, 0x15, 0x4e, 0x4e, 0x2b,
+	0xb9, 0xe9, 0x61, 0xc8, 0xf0, 0xb3, 0xce, 0x1a, 0xed, 0x17, 0x67, 0x65, 0xe5, 0xe5, 0x59, 0x59,
+	0xf9, 0xe5, 0xac, 0xac, 0x3c, 0x7f, 0x5d, 0x5e, 0x7a, 0xf9, 0xba, 0xbc, 0xf4, 0xe3, 0xeb, 0xf2,
+	0xd2, 0x67, 0xf7, 0x2c, 0x9b, 0x8e, 0x26, 0x83, 0xda, 0x90, 0x8c, 0xb7, 0x86, 0x64, 0x8c, 0xe9,
+	0xe0, 0x80, 0xce, 0x1e, 0xc4, 0x3f, 0x99, 0xf3, 0xff, 0x2e, 0x06, 0x49, 0x6e, 0xbf, 0xf7, 0xc7,
+	0x00, 0xfa, 0x43, 0x2f, 0x7c, 0x1e, 0x0d, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1508,6 +1520,13 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Signatures) > 0 {
 		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2045,6 +2064,10 @@ func (m *Commit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3517,6 +3540,40 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -59,8 +59,12 @@ type AppConnSnapshot interface {
 // Implements AppConnConsensus (subset of abcicli.Client)
 
 type appConnConsensus struct {
-	metrics *Metrics
-	appConn abcicli.Client
+	metrics  *Metrics
+	appConn  abcicli.Client
+	timeouts Timeouts
+	breaker  *circuitBreaker
+	tracer   *Tracer
+	recorder *Recorder
 }
 
 var _ AppConnConsensus = (*appConnConsensus)(nil)
@@ -82,46 +86,121 @@ func (app *appConnConsensus) Error() error {
 
 func (app *appConnConsensus) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "init_chain", "type", "sync"))()
-	return app.appConn.InitChainSync(req)
+	span := app.tracer.startSpan(connConsensus, "init_chain", req.InitialHeight)
+	defer span.End()
+	res, err := app.appConn.InitChainSync(req)
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	app.recorder.record(req.InitialHeight, types.ToRequestInitChain(req), types.ToResponseInitChain(*res))
+	return res, nil
 }
 
 func (app *appConnConsensus) PrepareProposalSync(
 	req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "prepare_proposal", "type", "sync"))()
-	return app.appConn.PrepareProposalSync(req)
+	span := app.tracer.startSpan(connConsensus, "prepare_proposal", req.Height)
+	defer span.End()
+	var res *types.ResponsePrepareProposal
+	err := app.breaker.call("prepare_proposal", app.timeouts.PrepareProposal, func() (err error) {
+		res, err = app.appConn.PrepareProposalSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 func (app *appConnConsensus) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "process_proposal", "type", "sync"))()
-	return app.appConn.ProcessProposalSync(req)
+	span := app.tracer.startSpan(connConsensus, "process_proposal", req.Height)
+	defer span.End()
+	var res *types.ResponseProcessProposal
+	err := app.breaker.call("process_proposal", app.timeouts.ProcessProposal, func() (err error) {
+		res, err = app.appConn.ProcessProposalSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	app.recorder.record(req.Height, types.ToRequestProcessProposal(req), types.ToResponseProcessProposal(*res))
+	return res, nil
 }
 
 func (app *appConnConsensus) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "begin_block", "type", "sync"))()
-	return app.appConn.BeginBlockSync(req)
+	app.tracer.setHeight(req.Header.Height)
+	span := app.tracer.startSpan(connConsensus, "begin_block", req.Header.Height)
+	defer span.End()
+	var res *types.ResponseBeginBlock
+	err := app.breaker.call("begin_block", app.timeouts.BeginBlock, func() (err error) {
+		res, err = app.appConn.BeginBlockSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	app.recorder.record(req.Header.Height, types.ToRequestBeginBlock(req), types.ToResponseBeginBlock(*res))
+	return res, nil
 }
 
 func (app *appConnConsensus) DeliverTxAsync(req types.RequestDeliverTx) *abcicli.ReqRes {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "deliver_tx", "type", "async"))()
-	return app.appConn.DeliverTxAsync(req)
+	reqRes := app.tracer.traceAsync(connConsensus, "deliver_tx", 0, func() *abcicli.ReqRes {
+		return app.appConn.DeliverTxAsync(req)
+	})
+	app.recorder.recordAsync(reqRes)
+	return reqRes
 }
 
 func (app *appConnConsensus) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "end_block", "type", "sync"))()
-	return app.appConn.EndBlockSync(req)
+	span := app.tracer.startSpan(connConsensus, "end_block", req.Height)
+	defer span.End()
+	var res *types.ResponseEndBlock
+	err := app.breaker.call("end_block", app.timeouts.EndBlock, func() (err error) {
+		res, err = app.appConn.EndBlockSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	app.recorder.record(req.Height, types.ToRequestEndBlock(req), types.ToResponseEndBlock(*res))
+	return res, nil
 }
 
 func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "commit", "type", "sync"))()
-	return app.appConn.CommitSync()
+	span := app.tracer.startSpan(connConsensus, "commit", 0)
+	defer span.End()
+	var res *types.ResponseCommit
+	err := app.breaker.call("commit", app.timeouts.Commit, func() (err error) {
+		res, err = app.appConn.CommitSync()
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	app.recorder.record(0, types.ToRequestCommit(), types.ToResponseCommit(*res))
+	return res, nil
 }
 
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
 type appConnMempool struct {
-	metrics *Metrics
-	appConn abcicli.Client
+	metrics  *Metrics
+	appConn  abcicli.Client
+	timeouts Timeouts
+	breaker  *circuitBreaker
+	tracer   *Tracer
 }
 
 func NewAppConnMempool(appConn abcicli.Client, metrics *Metrics) AppConnMempool {
@@ -151,20 +230,38 @@ func (app *appConnMempool) FlushSync() error {
 
 func (app *appConnMempool) CheckTxAsync(req types.RequestCheckTx) *abcicli.ReqRes {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "check_tx", "type", "async"))()
-	return app.appConn.CheckTxAsync(req)
+	reqRes := app.tracer.traceAsync(connMempool, "check_tx", -1, func() *abcicli.ReqRes {
+		return app.appConn.CheckTxAsync(req)
+	})
+	app.breaker.watch("check_tx", app.timeouts.CheckTx, reqRes)
+	return reqRes
 }
 
 func (app *appConnMempool) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "check_tx", "type", "sync"))()
-	return app.appConn.CheckTxSync(req)
+	span := app.tracer.startSpan(connMempool, "check_tx", -1)
+	defer span.End()
+	var res *types.ResponseCheckTx
+	err := app.breaker.call("check_tx", app.timeouts.CheckTx, func() (err error) {
+		res, err = app.appConn.CheckTxSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 //------------------------------------------------
 // Implements AppConnQuery (subset of abcicli.Client)
 
 type appConnQuery struct {
-	metrics *Metrics
-	appConn abcicli.Client
+	metrics  *Metrics
+	appConn  abcicli.Client
+	timeouts Timeouts
+	breaker  *circuitBreaker
+	tracer   *Tracer
 }
 
 func NewAppConnQuery(appConn abcicli.Client, metrics *Metrics) AppConnQuery {
@@ -180,25 +277,61 @@ func (app *appConnQuery) Error() error {
 
 func (app *appConnQuery) EchoSync(msg string) (*types.ResponseEcho, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "echo", "type", "sync"))()
-	return app.appConn.EchoSync(msg)
+	span := app.tracer.startSpan(connQuery, "echo", -1)
+	defer span.End()
+	var res *types.ResponseEcho
+	err := app.breaker.call("echo", app.timeouts.Query, func() (err error) {
+		res, err = app.appConn.EchoSync(msg)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 func (app *appConnQuery) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "info", "type", "sync"))()
-	return app.appConn.InfoSync(req)
+	span := app.tracer.startSpan(connQuery, "info", -1)
+	defer span.End()
+	var res *types.ResponseInfo
+	err := app.breaker.call("info", app.timeouts.Query, func() (err error) {
+		res, err = app.appConn.InfoSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 func (app *appConnQuery) QuerySync(reqQuery types.RequestQuery) (*types.ResponseQuery, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "query", "type", "sync"))()
-	return app.appConn.QuerySync(reqQuery)
+	span := app.tracer.startSpan(connQuery, "query", reqQuery.Height)
+	defer span.End()
+	var res *types.ResponseQuery
+	err := app.breaker.call("query", app.timeouts.Query, func() (err error) {
+		res, err = app.appConn.QuerySync(reqQuery)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 //------------------------------------------------
 // Implements AppConnSnapshot (subset of abcicli.Client)
 
 type appConnSnapshot struct {
-	metrics *Metrics
-	appConn abcicli.Client
+	metrics  *Metrics
+	appConn  abcicli.Client
+	timeouts Timeouts
+	breaker  *circuitBreaker
+	tracer   *Tracer
 }
 
 func NewAppConnSnapshot(appConn abcicli.Client, metrics *Metrics) AppConnSnapshot {
@@ -214,24 +347,68 @@ func (app *appConnSnapshot) Error() error {
 
 func (app *appConnSnapshot) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "list_snapshots", "type", "sync"))()
-	return app.appConn.ListSnapshotsSync(req)
+	span := app.tracer.startSpan(connSnapshot, "list_snapshots", -1)
+	defer span.End()
+	var res *types.ResponseListSnapshots
+	err := app.breaker.call("list_snapshots", app.timeouts.Snapshot, func() (err error) {
+		res, err = app.appConn.ListSnapshotsSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 func (app *appConnSnapshot) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "offer_snapshot", "type", "sync"))()
-	return app.appConn.OfferSnapshotSync(req)
+	span := app.tracer.startSpan(connSnapshot, "offer_snapshot", -1)
+	defer span.End()
+	var res *types.ResponseOfferSnapshot
+	err := app.breaker.call("offer_snapshot", app.timeouts.Snapshot, func() (err error) {
+		res, err = app.appConn.OfferSnapshotSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 func (app *appConnSnapshot) LoadSnapshotChunkSync(
 	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "load_snapshot_chunk", "type", "sync"))()
-	return app.appConn.LoadSnapshotChunkSync(req)
+	span := app.tracer.startSpan(connSnapshot, "load_snapshot_chunk", -1)
+	defer span.End()
+	var res *types.ResponseLoadSnapshotChunk
+	err := app.breaker.call("load_snapshot_chunk", app.timeouts.Snapshot, func() (err error) {
+		res, err = app.appConn.LoadSnapshotChunkSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
 	defer addTimeSample(app.metrics.MethodTimingSeconds.With("method", "apply_snapshot_chunk", "type", "sync"))()
-	return app.appConn.ApplySnapshotChunkSync(req)
+	span := app.tracer.startSpan(connSnapshot, "apply_snapshot_chunk", -1)
+	defer span.End()
+	var res *types.ResponseApplySnapshotChunk
+	err := app.breaker.call("apply_snapshot_chunk", app.timeouts.Snapshot, func() (err error) {
+		res, err = app.appConn.ApplySnapshotChunkSync(req)
+		return err
+	})
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	return res, nil
 }
 
 // addTimeSample returns a function that, when called, adds an observation to m.
@@ -94,6 +94,22 @@ func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
 	return remoteApp, nil
 }
 
+// NewReconnectingABCIClient returns a new ABCI client which reconnects to the
+// application when the connection is lost, and replays the requests in
+// flight. See abcicli.NewReconnectingClient.
+func (r *remoteClientCreator) NewReconnectingABCIClient(options ...abcicli.ReconnectOption) (abcicli.Client, error) {
+	newClient := func() (abcicli.Client, error) {
+		return abcicli.NewClient(r.addr, r.transport, true)
+	}
+	return abcicli.NewReconnectingClient(newClient, r.mustConnect, options...), nil
+}
+
+// reconnectingClientCreator is implemented by the ClientCreators of remote
+// applications, whose connections can be lost.
+type reconnectingClientCreator interface {
+	NewReconnectingABCIClient(options ...abcicli.ReconnectOption) (abcicli.Client, error)
+}
+
 // DefaultClientCreator returns a default [ClientCreator], which will create a
 // local client if addr is one of "kvstore", "persistent_kvstore", "e2e",
 // "noop".
@@ -22,11 +22,25 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: []float64{.0001, .0004, .002, .009, .02, .1, .65, 2, 6, 25},
 		}, append(labels, "method", "type")).With(labelsAndValues...),
+		MethodTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "method_timeouts",
+			Help:      "Number of ABCI calls which exceeded their deadline, for each ABCI method.",
+		}, append(labels, "method")).With(labelsAndValues...),
+		Reconnects: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reconnects",
+			Help:      "Number of attempts to reconnect to the application, for each connection, with status success or failure.",
+		}, append(labels, "connection", "status")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
 		MethodTimingSeconds: discard.NewHistogram(),
+		MethodTimeouts:      discard.NewCounter(),
+		Reconnects:          discard.NewCounter(),
 	}
 }
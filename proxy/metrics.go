@@ -16,4 +16,9 @@ const (
 type Metrics struct {
 	// Timing for each ABCI method.
 	MethodTimingSeconds metrics.Histogram `metrics_bucketsizes:".0001,.0004,.002,.009,.02,.1,.65,2,6,25" metrics_labels:"method, type"`
+	// Number of ABCI calls which exceeded their deadline, for each ABCI method.
+	MethodTimeouts metrics.Counter `metrics_labels:"method"`
+	// Number of attempts to reconnect to the application, for each connection,
+	// with status success or failure.
+	Reconnects metrics.Counter `metrics_labels:"connection, status"`
 }
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"fmt"
+	"time"
 
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	cmtlog "github.com/cometbft/cometbft/libs/log"
@@ -14,6 +15,9 @@ const (
 	connMempool   = "mempool"
 	connQuery     = "query"
 	connSnapshot  = "snapshot"
+
+	connMempoolRecheck = "mempool-recheck"
+	connMempoolCheckTx = "mempool-checktx"
 )
 
 // AppConns is the CometBFT's interface to the application that consists of
@@ -29,11 +33,70 @@ type AppConns interface {
 	Query() AppConnQuery
 	// Snapshot connection
 	Snapshot() AppConnSnapshot
+	// Additional mempool connections, used to recheck transactions in
+	// parallel. Empty unless requested with WithMempoolRecheckConns.
+	MempoolRecheck() []AppConnMempool
+	// Additional mempool connections, used to check new transactions in
+	// parallel. Empty unless requested with WithMempoolCheckTxConns.
+	MempoolCheckTx() []AppConnMempool
+}
+
+// MultiAppConnOption sets an optional parameter on the multiAppConn.
+type MultiAppConnOption func(*multiAppConn)
+
+// WithMempoolRecheckConns sets the number of additional mempool connections
+// to open for rechecking transactions in parallel.
+func WithMempoolRecheckConns(n int) MultiAppConnOption {
+	return func(app *multiAppConn) { app.numRecheckConns = n }
+}
+
+// WithMempoolCheckTxConns sets the number of additional mempool connections
+// to open for checking new transactions in parallel.
+func WithMempoolCheckTxConns(n int) MultiAppConnOption {
+	return func(app *multiAppConn) { app.numCheckTxConns = n }
+}
+
+// WithTimeouts sets the deadlines of the calls to the application. The node is
+// stopped once maxTimeouts consecutive calls of a connection exceeded their
+// deadline, or once a call of the consensus connection did, since the block
+// being executed can't be committed anymore.
+func WithTimeouts(timeouts Timeouts, maxTimeouts int) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.timeouts = &timeouts
+		app.maxTimeouts = maxTimeouts
+	}
+}
+
+// WithReconnect reconnects the query and mempool connections to a remote
+// application when they are lost, with a backoff doubled from minBackoff up to
+// maxBackoff, and replays their requests in flight. The node is stopped after
+// maxAttempts consecutive failed attempts, or never if 0. The consensus and
+// snapshot connections, whose requests can't be safely replayed, still stop
+// the node when lost.
+func WithReconnect(minBackoff, maxBackoff time.Duration, maxAttempts int) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.reconnectOptions = []abcicli.ReconnectOption{
+			abcicli.ReconnectWithBackoff(minBackoff, maxBackoff),
+			abcicli.ReconnectWithMaxAttempts(maxAttempts),
+		}
+	}
+}
+
+// WithTracer records an OpenTelemetry span for every call to the
+// application with the given tracer.
+func WithTracer(tracer *Tracer) MultiAppConnOption {
+	return func(app *multiAppConn) { app.tracer = tracer }
+}
+
+// WithRecorder records the calls of the consensus connection to the
+// application, and their responses, with the given recorder.
+func WithRecorder(recorder *Recorder) MultiAppConnOption {
+	return func(app *multiAppConn) { app.recorder = recorder }
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator, metrics *Metrics) AppConns {
-	return NewMultiAppConn(clientCreator, metrics)
+func NewAppConns(clientCreator ClientCreator, metrics *Metrics, options ...MultiAppConnOption) AppConns {
+	return NewMultiAppConn(clientCreator, metrics, options...)
 }
 
 // multiAppConn implements AppConns.
@@ -55,16 +118,40 @@ type multiAppConn struct {
 	queryConnClient     abcicli.Client
 	snapshotConnClient  abcicli.Client
 
+	numRecheckConns     int
+	recheckConns        []AppConnMempool
+	recheckConnsClients []abcicli.Client
+
+	numCheckTxConns     int
+	checkTxConns        []AppConnMempool
+	checkTxConnsClients []abcicli.Client
+
+	// the deadlines of the calls, nil if none
+	timeouts    *Timeouts
+	maxTimeouts int
+
+	// the options of the reconnecting clients, nil if the connections are not
+	// reconnected
+	reconnectOptions []abcicli.ReconnectOption
+
+	// records the spans of the calls, nil if none
+	tracer *Tracer
+	// records the calls of the consensus connection, nil if none
+	recorder *Recorder
+
 	clientCreator ClientCreator
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator, metrics *Metrics) AppConns {
+func NewMultiAppConn(clientCreator ClientCreator, metrics *Metrics, options ...MultiAppConnOption) AppConns {
 	multiAppConn := &multiAppConn{
 		metrics:       metrics,
 		clientCreator: clientCreator,
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	return multiAppConn
 }
 
@@ -84,13 +171,27 @@ func (app *multiAppConn) Snapshot() AppConnSnapshot {
 	return app.snapshotConn
 }
 
+func (app *multiAppConn) MempoolRecheck() []AppConnMempool {
+	return app.recheckConns
+}
+
+func (app *multiAppConn) MempoolCheckTx() []AppConnMempool {
+	return app.checkTxConns
+}
+
 func (app *multiAppConn) OnStart() error {
 	c, err := app.abciClientFor(connQuery)
 	if err != nil {
 		return err
 	}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c, app.metrics)
+	app.queryConn = &appConnQuery{
+		metrics:  app.metrics,
+		appConn:  c,
+		timeouts: app.connTimeouts(),
+		breaker:  app.circuitBreakerFor(connQuery),
+		tracer:   app.tracer,
+	}
 
 	c, err = app.abciClientFor(connSnapshot)
 	if err != nil {
@@ -98,7 +199,13 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c, app.metrics)
+	app.snapshotConn = &appConnSnapshot{
+		metrics:  app.metrics,
+		appConn:  c,
+		timeouts: app.connTimeouts(),
+		breaker:  app.circuitBreakerFor(connSnapshot),
+		tracer:   app.tracer,
+	}
 
 	c, err = app.abciClientFor(connMempool)
 	if err != nil {
@@ -106,7 +213,13 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c, app.metrics)
+	app.mempoolConn = &appConnMempool{
+		metrics:  app.metrics,
+		appConn:  c,
+		timeouts: app.connTimeouts(),
+		breaker:  app.circuitBreakerFor(connMempool),
+		tracer:   app.tracer,
+	}
 
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
@@ -114,7 +227,46 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c, app.metrics)
+	app.consensusConn = &appConnConsensus{
+		metrics:  app.metrics,
+		appConn:  c,
+		timeouts: app.connTimeouts(),
+		breaker:  app.circuitBreakerFor(connConsensus),
+		tracer:   app.tracer,
+		recorder: app.recorder,
+	}
+
+	for i := 0; i < app.numRecheckConns; i++ {
+		c, err = app.abciClientFor(connMempoolRecheck)
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.recheckConnsClients = append(app.recheckConnsClients, c)
+		app.recheckConns = append(app.recheckConns, &appConnMempool{
+			metrics:  app.metrics,
+			appConn:  c,
+			timeouts: app.connTimeouts(),
+			breaker:  app.circuitBreakerFor(connMempoolRecheck),
+			tracer:   app.tracer,
+		})
+	}
+
+	for i := 0; i < app.numCheckTxConns; i++ {
+		c, err = app.abciClientFor(connMempoolCheckTx)
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.checkTxConnsClients = append(app.checkTxConnsClients, c)
+		app.checkTxConns = append(app.checkTxConns, &appConnMempool{
+			metrics:  app.metrics,
+			appConn:  c,
+			timeouts: app.connTimeouts(),
+			breaker:  app.circuitBreakerFor(connMempoolCheckTx),
+			tracer:   app.tracer,
+		})
+	}
 
 	// Kill CometBFT if the ABCI application crashes.
 	go app.killTMOnClientError()
@@ -124,6 +276,9 @@ func (app *multiAppConn) OnStart() error {
 
 func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
+	if err := app.recorder.Close(); err != nil {
+		app.Logger.Error("error while closing the ABCI recorder", "error", err)
+	}
 }
 
 func (app *multiAppConn) killTMOnClientError() {
@@ -154,7 +309,57 @@ func (app *multiAppConn) killTMOnClientError() {
 		if err := app.snapshotConnClient.Error(); err != nil {
 			killFn(connSnapshot, err, app.Logger)
 		}
+	case err := <-clientsError(app.recheckConnsClients):
+		killFn(connMempoolRecheck, err, app.Logger)
+	case err := <-clientsError(app.checkTxConnsClients):
+		killFn(connMempoolCheckTx, err, app.Logger)
+	}
+}
+
+func (app *multiAppConn) connTimeouts() Timeouts {
+	if app.timeouts == nil {
+		return Timeouts{}
+	}
+	return *app.timeouts
+}
+
+// circuitBreakerFor returns the circuit breaker of a connection, or nil if the
+// calls have no deadline.
+func (app *multiAppConn) circuitBreakerFor(conn string) *circuitBreaker {
+	if app.timeouts == nil {
+		return nil
+	}
+	maxTimeouts := app.maxTimeouts
+	if conn == connConsensus {
+		maxTimeouts = 1
 	}
+	return newCircuitBreaker(conn, maxTimeouts, app.metrics, app.stopOnTimeouts)
+}
+
+// stopOnTimeouts kills CometBFT, which stops gracefully, once the calls of a
+// connection repeatedly exceeded their deadline.
+func (app *multiAppConn) stopOnTimeouts(conn string, err error) {
+	app.Logger.Error(
+		fmt.Sprintf("%s connection timed out. Is the application wedged? Stopping CometBFT", conn),
+		"err", err)
+	if killErr := cmtos.Kill(); killErr != nil {
+		app.Logger.Error("Failed to kill this process - please do so manually", "err", killErr)
+	}
+}
+
+// clientsError returns a channel that receives the error of the first of the
+// given clients to quit with an error.
+func clientsError(clients []abcicli.Client) <-chan error {
+	errCh := make(chan error, len(clients))
+	for _, c := range clients {
+		go func(c abcicli.Client) {
+			<-c.Quit()
+			if err := c.Error(); err != nil {
+				errCh <- err
+			}
+		}(c)
+	}
+	return errCh
 }
 
 func (app *multiAppConn) stopAllClients() {
@@ -178,10 +383,20 @@ func (app *multiAppConn) stopAllClients() {
 			app.Logger.Error("error while stopping snapshot client", "error", err)
 		}
 	}
+	for _, c := range app.recheckConnsClients {
+		if err := c.Stop(); err != nil {
+			app.Logger.Error("error while stopping mempool recheck client", "error", err)
+		}
+	}
+	for _, c := range app.checkTxConnsClients {
+		if err := c.Stop(); err != nil {
+			app.Logger.Error("error while stopping mempool checktx client", "error", err)
+		}
+	}
 }
 
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
-	c, err := app.clientCreator.NewABCIClient()
+	c, err := app.newABCIClient(conn)
 	if err != nil {
 		return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", conn, err)
 	}
@@ -191,3 +406,26 @@ func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	}
 	return c, nil
 }
+
+// newABCIClient returns a reconnecting client for the connections whose
+// requests are safe to replay, if enabled and supported by the client creator.
+func (app *multiAppConn) newABCIClient(conn string) (abcicli.Client, error) {
+	creator, ok := app.clientCreator.(reconnectingClientCreator)
+	if !ok || app.reconnectOptions == nil {
+		return app.clientCreator.NewABCIClient()
+	}
+	switch conn {
+	case connQuery, connMempool, connMempoolRecheck, connMempoolCheckTx:
+		hook := func(err error) {
+			status := "success"
+			if err != nil {
+				status = "failure"
+			}
+			app.metrics.Reconnects.With("connection", conn, "status", status).Add(1)
+		}
+		options := append([]abcicli.ReconnectOption{abcicli.ReconnectWithHook(hook)}, app.reconnectOptions...)
+		return creator.NewReconnectingABCIClient(options...)
+	default:
+		return app.clientCreator.NewABCIClient()
+	}
+}
@@ -41,6 +41,61 @@ func TestAppConns_Start_Stop(t *testing.T) {
 	clientMock.AssertExpectations(t)
 }
 
+func TestAppConns_MempoolRecheck(t *testing.T) {
+	quitCh := make(<-chan struct{})
+
+	clientCreatorMock := &mocks.ClientCreator{}
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("SetLogger", mock.Anything).Return().Times(6)
+	clientMock.On("Start").Return(nil).Times(6)
+	clientMock.On("Stop").Return(nil).Times(6)
+	clientMock.On("Quit").Return(quitCh).Times(6)
+
+	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil).Times(6)
+
+	appConns := NewAppConns(clientCreatorMock, NopMetrics(), WithMempoolRecheckConns(2))
+
+	err := appConns.Start()
+	require.NoError(t, err)
+	require.Len(t, appConns.MempoolRecheck(), 2)
+
+	time.Sleep(100 * time.Millisecond)
+
+	err = appConns.Stop()
+	require.NoError(t, err)
+
+	clientMock.AssertExpectations(t)
+}
+
+func TestAppConns_MempoolCheckTx(t *testing.T) {
+	quitCh := make(<-chan struct{})
+
+	clientCreatorMock := &mocks.ClientCreator{}
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("SetLogger", mock.Anything).Return().Times(7)
+	clientMock.On("Start").Return(nil).Times(7)
+	clientMock.On("Stop").Return(nil).Times(7)
+	clientMock.On("Quit").Return(quitCh).Times(7)
+
+	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil).Times(7)
+
+	appConns := NewAppConns(clientCreatorMock, NopMetrics(), WithMempoolCheckTxConns(3))
+
+	err := appConns.Start()
+	require.NoError(t, err)
+	require.Len(t, appConns.MempoolCheckTx(), 3)
+	require.Empty(t, appConns.MempoolRecheck())
+
+	time.Sleep(100 * time.Millisecond)
+
+	err = appConns.Stop()
+	require.NoError(t, err)
+
+	clientMock.AssertExpectations(t)
+}
+
 // Upon failure, we call cmtos.Kill
 func TestAppConns_Failure(t *testing.T) {
 	ok := make(chan struct{})
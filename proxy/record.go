@@ -0,0 +1,299 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/protoio"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+const (
+	recordFileExt = ".abci"
+	// maximum size of a recorded message
+	maxRecordSize = 100 * 1024 * 1024
+)
+
+// Record is an ABCI request of the consensus connection, along with the
+// response of the application.
+type Record struct {
+	Request  *types.Request
+	Response *types.Response
+}
+
+// Recorder records the requests of the consensus connection to the
+// application, along with their responses, in a file per height, so that they
+// can be replayed against another version of the application with
+// ReplayRecords. PrepareProposal is not recorded, since its response may
+// legitimately differ between versions of the application. A nil Recorder
+// records nothing.
+//
+// Recording never fails the calls to the application: the errors are logged.
+type Recorder struct {
+	dir    string
+	logger log.Logger
+
+	mtx    cmtsync.Mutex
+	height int64
+	file   *os.File
+	writer protoio.WriteCloser
+	// the DeliverTx calls in flight, recorded once answered
+	pending []*abcicli.ReqRes
+}
+
+// NewRecorder returns a Recorder writing its files to dir.
+func NewRecorder(dir string, logger log.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("could not create the ABCI records directory: %w", err)
+	}
+	return &Recorder{
+		dir:    dir,
+		logger: logger,
+	}, nil
+}
+
+// RecordsFile returns the path of the file of the records of the given height.
+func RecordsFile(dir string, height int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", height, recordFileExt))
+}
+
+// record records a call of the given height. A height of 0 stands for the
+// height of the last recorded call.
+func (r *Recorder) record(height int64, req *types.Request, res *types.Response) {
+	if r == nil {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.recordPending()
+	if height > 0 && height != r.height {
+		if err := r.openFile(height); err != nil {
+			r.logger.Error("Failed to open the ABCI records file", "height", height, "err", err)
+			return
+		}
+	}
+	r.write(req, res)
+}
+
+// recordAsync records a call once reqRes is answered, before the next call.
+func (r *Recorder) recordAsync(reqRes *abcicli.ReqRes) {
+	if r == nil {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.pending = append(r.pending, reqRes)
+}
+
+func (r *Recorder) recordPending() {
+	for _, reqRes := range r.pending {
+		reqRes.Wait()
+		if reqRes.Response != nil {
+			r.write(reqRes.Request, reqRes.Response)
+		}
+	}
+	r.pending = nil
+}
+
+func (r *Recorder) write(req *types.Request, res *types.Response) {
+	if r.writer == nil {
+		return
+	}
+	if _, err := r.writer.WriteMsg(req); err != nil {
+		r.logger.Error("Failed to record the ABCI request", "height", r.height, "err", err)
+		return
+	}
+	if _, err := r.writer.WriteMsg(res); err != nil {
+		r.logger.Error("Failed to record the ABCI response", "height", r.height, "err", err)
+	}
+}
+
+// openFile closes the file of the current height, and opens the one of the
+// given height, replacing the records of a previous execution of the height.
+func (r *Recorder) openFile(height int64) error {
+	if err := r.closeFile(); err != nil {
+		r.logger.Error("Failed to close the ABCI records file", "height", r.height, "err", err)
+	}
+	r.height = height
+	file, err := os.OpenFile(RecordsFile(r.dir, height), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.writer = protoio.NewDelimitedWriter(file)
+	return nil
+}
+
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	r.writer = nil
+	return err
+}
+
+// Close records the calls in flight, and closes the file of the current
+// height.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.recordPending()
+	return r.closeFile()
+}
+
+// RecordedHeights returns the heights recorded in dir, in ascending order.
+func RecordedHeights(dir string) ([]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var heights []int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, recordFileExt) {
+			continue
+		}
+		height, err := strconv.ParseInt(strings.TrimSuffix(name, recordFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
+
+// ReadRecords reads the records of the given height from dir.
+func ReadRecords(dir string, height int64) ([]Record, error) {
+	file, err := os.Open(RecordsFile(dir, height))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+	reader := protoio.NewDelimitedReader(file, maxRecordSize)
+	for {
+		record := Record{Request: new(types.Request), Response: new(types.Response)}
+		if _, err := reader.ReadMsg(record.Request); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("reading record %d of height %d: %w", len(records), height, err)
+		}
+		// a request without response was interrupted by a crash
+		if _, err := reader.ReadMsg(record.Response); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("reading record %d of height %d: %w", len(records), height, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// Mismatch is a recorded call whose response differs from the one of the
+// replayed call.
+type Mismatch struct {
+	// Index of the record among the records of its height.
+	Index    int
+	Request  *types.Request
+	Recorded *types.Response
+	Replayed *types.Response
+}
+
+// ReplayRecords sends the requests of the records to the application of
+// client, in order, and returns the ones whose response differs from the
+// recorded one.
+func ReplayRecords(client abcicli.Client, records []Record) ([]Mismatch, error) {
+	var mismatches []Mismatch
+	for i, record := range records {
+		res, err := callSync(client, record.Request)
+		if err != nil {
+			return mismatches, fmt.Errorf("replaying record %d: %w", i, err)
+		}
+		if !equalResponses(record.Response, res) {
+			mismatches = append(mismatches, Mismatch{
+				Index:    i,
+				Request:  record.Request,
+				Recorded: record.Response,
+				Replayed: res,
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+func equalResponses(a, b *types.Response) bool {
+	abz, err := proto.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bbz, err := proto.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(abz, bbz)
+}
+
+// callSync sends a recorded request to the application.
+func callSync(client abcicli.Client, req *types.Request) (*types.Response, error) {
+	switch r := req.Value.(type) {
+	case *types.Request_InitChain:
+		res, err := client.InitChainSync(*r.InitChain)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseInitChain(*res), nil
+	case *types.Request_ProcessProposal:
+		res, err := client.ProcessProposalSync(*r.ProcessProposal)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseProcessProposal(*res), nil
+	case *types.Request_BeginBlock:
+		res, err := client.BeginBlockSync(*r.BeginBlock)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseBeginBlock(*res), nil
+	case *types.Request_DeliverTx:
+		res, err := client.DeliverTxSync(*r.DeliverTx)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseDeliverTx(*res), nil
+	case *types.Request_EndBlock:
+		res, err := client.EndBlockSync(*r.EndBlock)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseEndBlock(*res), nil
+	case *types.Request_Commit:
+		res, err := client.CommitSync()
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseCommit(*res), nil
+	default:
+		return nil, fmt.Errorf("unexpected recorded request %T", r)
+	}
+}
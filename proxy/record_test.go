@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, log.TestingLogger())
+	require.NoError(t, err)
+
+	conn := &appConnConsensus{
+		metrics:  NopMetrics(),
+		appConn:  abcicli.NewLocalClient(nil, kvstore.NewApplication()),
+		recorder: recorder,
+	}
+	conn.SetResponseCallback(func(*types.Request, *types.Response) {})
+	_, err = conn.InitChainSync(types.RequestInitChain{InitialHeight: 1})
+	require.NoError(t, err)
+	for h := int64(1); h <= 2; h++ {
+		txs := [][]byte{[]byte(fmt.Sprintf("a=%d", h)), []byte(fmt.Sprintf("b=%d", h))}
+		_, err = conn.ProcessProposalSync(types.RequestProcessProposal{Height: h, Txs: txs})
+		require.NoError(t, err)
+		_, err = conn.BeginBlockSync(types.RequestBeginBlock{Header: cmtproto.Header{Height: h}})
+		require.NoError(t, err)
+		for _, tx := range txs {
+			conn.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+		}
+		_, err = conn.EndBlockSync(types.RequestEndBlock{Height: h})
+		require.NoError(t, err)
+		_, err = conn.CommitSync()
+		require.NoError(t, err)
+	}
+	require.NoError(t, recorder.Close())
+
+	heights, err := RecordedHeights(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, heights)
+
+	records1, err := ReadRecords(dir, 1)
+	require.NoError(t, err)
+	require.Len(t, records1, 7)
+	assert.NotNil(t, records1[0].Request.GetInitChain())
+	assert.NotNil(t, records1[3].Response.GetDeliverTx())
+	assert.NotNil(t, records1[6].Response.GetCommit())
+	records2, err := ReadRecords(dir, 2)
+	require.NoError(t, err)
+	require.Len(t, records2, 6)
+
+	// the same application gives the same responses
+	client := abcicli.NewLocalClient(nil, kvstore.NewApplication())
+	for _, records := range [][]Record{records1, records2} {
+		mismatches, err := ReplayRecords(client, records)
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	}
+
+	// an application with another state commits another app hash
+	client = abcicli.NewLocalClient(nil, kvstore.NewApplication())
+	mismatches, err := ReplayRecords(client, records2)
+	require.NoError(t, err)
+	require.Len(t, mismatches, 1)
+	assert.Equal(t, 5, mismatches[0].Index)
+	assert.NotNil(t, mismatches[0].Replayed.GetCommit())
+}
@@ -0,0 +1,168 @@
+package proxy
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// Timeouts are the deadlines of the calls to the application, per ABCI
+// method. A zero timeout disables the deadline of its calls.
+type Timeouts struct {
+	PrepareProposal time.Duration
+	ProcessProposal time.Duration
+	BeginBlock      time.Duration
+	EndBlock        time.Duration
+	Commit          time.Duration
+	CheckTx         time.Duration
+	// Deadline of the Info, Echo and Query calls.
+	Query time.Duration
+	// Deadline of the state sync snapshot calls.
+	Snapshot time.Duration
+}
+
+// ErrTimeout is returned by the calls to the application which exceeded their
+// deadline.
+type ErrTimeout struct {
+	Method  string
+	Timeout time.Duration
+}
+
+func (e ErrTimeout) Error() string {
+	return fmt.Sprintf("ABCI %s call timed out after %v", e.Method, e.Timeout)
+}
+
+// circuitBreaker enforces the deadlines of the calls of a connection to the
+// application, and trips once maxTimeouts consecutive calls exceeded their
+// deadline. A nil circuitBreaker enforces no deadline.
+type circuitBreaker struct {
+	conn        string
+	maxTimeouts int
+	metrics     *Metrics
+	// trip is called, once, when the circuit breaker trips.
+	trip func(conn string, err error)
+
+	mtx      cmtsync.Mutex
+	timeouts int
+	tripped  bool
+}
+
+func newCircuitBreaker(
+	conn string,
+	maxTimeouts int,
+	metrics *Metrics,
+	trip func(conn string, err error),
+) *circuitBreaker {
+	return &circuitBreaker{
+		conn:        conn,
+		maxTimeouts: maxTimeouts,
+		metrics:     metrics,
+		trip:        trip,
+	}
+}
+
+// CallWithTimeout calls fn, the call of an ABCI method, and returns an
+// ErrTimeout if fn did not return within timeout, if greater than 0. fn keeps
+// running in the background after a timeout, so it must not write to variables
+// read by the caller after CallWithTimeout returned an ErrTimeout.
+func CallWithTimeout(method string, timeout time.Duration, fn func() error) error {
+	if timeout <= 0 {
+		return fn()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return ErrTimeout{Method: method, Timeout: timeout}
+	}
+}
+
+// TimeoutCall calls an ABCI method with a timeout, see CallWithTimeout,
+// leaving at most one call running in the background after a timeout: until
+// it returns, the next calls return an ErrTimeout right away, instead of
+// piling up behind it, e.g. on the mutex of a local client.
+type TimeoutCall struct {
+	running int32
+}
+
+// Call calls fn, and returns an ErrTimeout if fn did not return within
+// timeout, if greater than 0, or if the previous call timed out and is still
+// running.
+func (c *TimeoutCall) Call(method string, timeout time.Duration, fn func() error) error {
+	if timeout <= 0 {
+		return fn()
+	}
+	if !atomic.CompareAndSwapInt32(&c.running, 0, 1) {
+		return ErrTimeout{Method: method, Timeout: timeout}
+	}
+	return CallWithTimeout(method, timeout, func() error {
+		defer atomic.StoreInt32(&c.running, 0)
+		return fn()
+	})
+}
+
+// call calls fn, and returns an ErrTimeout if fn did not return within
+// timeout, see CallWithTimeout.
+func (cb *circuitBreaker) call(method string, timeout time.Duration, fn func() error) error {
+	if cb == nil || timeout <= 0 {
+		return fn()
+	}
+
+	err := CallWithTimeout(method, timeout, fn)
+	if _, ok := err.(ErrTimeout); ok {
+		cb.metrics.MethodTimeouts.With("method", method).Add(1)
+		cb.timedOut(err)
+		return err
+	}
+	cb.succeeded()
+	return err
+}
+
+// watch counts the asynchronous call of reqRes as timed out if its response
+// did not arrive within timeout, if greater than 0. The call is not abandoned:
+// its callback is still invoked once the response arrives.
+func (cb *circuitBreaker) watch(method string, timeout time.Duration, reqRes *abcicli.ReqRes) {
+	if cb == nil || timeout <= 0 || reqRes == nil {
+		return
+	}
+
+	timer := time.AfterFunc(timeout, func() {
+		cb.metrics.MethodTimeouts.With("method", method).Add(1)
+		cb.timedOut(ErrTimeout{Method: method, Timeout: timeout})
+	})
+	go func() {
+		reqRes.Wait()
+		if timer.Stop() {
+			cb.succeeded()
+		}
+	}()
+}
+
+func (cb *circuitBreaker) succeeded() {
+	cb.mtx.Lock()
+	cb.timeouts = 0
+	cb.mtx.Unlock()
+}
+
+func (cb *circuitBreaker) timedOut(err error) {
+	cb.mtx.Lock()
+	cb.timeouts++
+	trip := !cb.tripped && cb.timeouts >= cb.maxTimeouts
+	if trip {
+		cb.tripped = true
+	}
+	cb.mtx.Unlock()
+
+	if trip {
+		cb.trip(cb.conn, err)
+	}
+}
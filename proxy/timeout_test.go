@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abcimocks "github.com/cometbft/cometbft/abci/client/mocks"
+	"github.com/cometbft/cometbft/abci/types"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var tripped []string
+	cb := newCircuitBreaker(connQuery, 2, NopMetrics(), func(conn string, err error) {
+		tripped = append(tripped, conn)
+		assert.ErrorAs(t, err, &ErrTimeout{})
+	})
+
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+	wedged := func() error {
+		<-block
+		return nil
+	}
+
+	// without deadline, the calls are not timed
+	require.NoError(t, cb.call("info", 0, func() error { return nil }))
+
+	err := cb.call("info", 10*time.Millisecond, wedged)
+	require.Equal(t, ErrTimeout{Method: "info", Timeout: 10 * time.Millisecond}, err)
+	assert.Empty(t, tripped)
+
+	// a call within its deadline resets the consecutive timeouts
+	require.NoError(t, cb.call("info", time.Second, func() error { return nil }))
+	require.Error(t, cb.call("info", 10*time.Millisecond, wedged))
+	assert.Empty(t, tripped)
+
+	require.Error(t, cb.call("info", 10*time.Millisecond, wedged))
+	assert.Equal(t, []string{connQuery}, tripped)
+
+	// the circuit breaker only trips once
+	require.Error(t, cb.call("info", 10*time.Millisecond, wedged))
+	assert.Len(t, tripped, 1)
+}
+
+func TestTimeoutCall(t *testing.T) {
+	var c TimeoutCall
+	block := make(chan struct{})
+	returned := make(chan struct{})
+	wedged := func() error {
+		<-block
+		close(returned)
+		return nil
+	}
+
+	err := c.Call("prepare_proposal", 10*time.Millisecond, wedged)
+	require.Equal(t, ErrTimeout{Method: "prepare_proposal", Timeout: 10 * time.Millisecond}, err)
+
+	// the next calls time out right away while the previous one runs
+	called := false
+	err = c.Call("prepare_proposal", time.Second, func() error {
+		called = true
+		return nil
+	})
+	require.ErrorAs(t, err, &ErrTimeout{})
+	assert.False(t, called)
+
+	close(block)
+	<-returned
+	require.Eventually(t, func() bool {
+		return c.Call("prepare_proposal", time.Second, func() error { return nil }) == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCircuitBreakerWatch(t *testing.T) {
+	tripped := make(chan string, 1)
+	cb := newCircuitBreaker(connMempool, 1, NopMetrics(), func(conn string, err error) {
+		tripped <- conn
+	})
+
+	// a response within the deadline is not a timeout
+	reqRes := abcicli.NewReqRes(types.ToRequestCheckTx(types.RequestCheckTx{}))
+	cb.watch("check_tx", time.Second, reqRes)
+	reqRes.Done()
+	select {
+	case <-tripped:
+		t.Fatal("tripped on a response within the deadline")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// a response which does not arrive within the deadline is
+	reqRes = abcicli.NewReqRes(types.ToRequestCheckTx(types.RequestCheckTx{}))
+	cb.watch("check_tx", 10*time.Millisecond, reqRes)
+	assert.Equal(t, connMempool, <-tripped)
+	reqRes.Done()
+}
+
+func TestAppConnTimeouts(t *testing.T) {
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("InfoSync", mock.Anything).Return(&types.ResponseInfo{Data: "info"}, nil).Once()
+	clientMock.On("InfoSync", mock.Anything).Run(func(mock.Arguments) { <-block }).
+		Return(&types.ResponseInfo{}, nil)
+
+	tripped := make(chan string, 1)
+	conn := &appConnQuery{
+		metrics:  NopMetrics(),
+		appConn:  clientMock,
+		timeouts: Timeouts{Query: 50 * time.Millisecond},
+		breaker: newCircuitBreaker(connQuery, 1, NopMetrics(), func(conn string, err error) {
+			tripped <- conn
+		}),
+	}
+
+	res, err := conn.InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "info", res.Data)
+
+	res, err = conn.InfoSync(types.RequestInfo{})
+	require.ErrorAs(t, err, &ErrTimeout{})
+	assert.Nil(t, res)
+	assert.Equal(t, connQuery, <-tripped)
+}
@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the calls to the
+// application.
+const TracerName = "github.com/cometbft/cometbft/proxy"
+
+// Tracer records an OpenTelemetry span for every call to the application,
+// with the height, and round, of the block being decided or executed. A nil
+// Tracer records nothing.
+type Tracer struct {
+	tracer trace.Tracer
+
+	mtx cmtsync.Mutex
+	// the height of the last block being decided or executed, and its round if
+	// being decided
+	height int64
+	round  int32
+}
+
+// NewTracer returns a Tracer recording the spans with the given provider.
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	return &Tracer{
+		tracer: provider.Tracer(TracerName),
+		round:  -1,
+	}
+}
+
+// SetRound sets the height and round being decided by consensus, recorded in
+// the spans of the calls which follow.
+func (t *Tracer) SetRound(height int64, round int32) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.height = height
+	t.round = round
+}
+
+// setHeight sets the height of the block being executed, if not already.
+func (t *Tracer) setHeight(height int64) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.height != height {
+		t.height = height
+		t.round = -1
+	}
+}
+
+// startSpan starts the span of a call of the given connection to the
+// application. A height of 0 stands for the height of the last block being
+// decided or executed, and a negative one for none. The span must be ended by
+// the caller.
+func (t *Tracer) startSpan(conn, method string, height int64) trace.Span {
+	if t == nil {
+		return trace.SpanFromContext(context.Background())
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("abci.connection", conn),
+		attribute.String("abci.method", method),
+	}
+	t.mtx.Lock()
+	if height == 0 {
+		height = t.height
+	}
+	if height > 0 && height == t.height && t.round >= 0 {
+		attrs = append(attrs, attribute.Int64("round", int64(t.round)))
+	}
+	t.mtx.Unlock()
+	if height > 0 {
+		attrs = append(attrs, attribute.Int64("height", height))
+	}
+
+	_, span := t.tracer.Start(context.Background(), "abci."+method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return span
+}
+
+// recordError marks the span of a call as failed with err.
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// traceAsync records a span of the given method, which ends once the response
+// of reqRes is received.
+func (t *Tracer) traceAsync(conn, method string, height int64, send func() *abcicli.ReqRes) *abcicli.ReqRes {
+	if t == nil {
+		return send()
+	}
+	span := t.startSpan(conn, method, height)
+	reqRes := send()
+	go func() {
+		defer span.End()
+		reqRes.Wait()
+		if code := responseCode(reqRes.Response); code != types.CodeTypeOK {
+			span.SetStatus(codes.Error, fmt.Sprintf("code %d", code))
+		}
+	}()
+	return reqRes
+}
+
+func responseCode(res *types.Response) uint32 {
+	switch r := res.GetValue().(type) {
+	case *types.Response_CheckTx:
+		return r.CheckTx.GetCode()
+	case *types.Response_DeliverTx:
+		return r.DeliverTx.GetCode()
+	default:
+		return types.CodeTypeOK
+	}
+}
@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	abcimocks "github.com/cometbft/cometbft/abci/client/mocks"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+func TestAppConnTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("PrepareProposalSync", mock.Anything).Return(&types.ResponsePrepareProposal{}, nil)
+	clientMock.On("BeginBlockSync", mock.Anything).Return(&types.ResponseBeginBlock{}, nil)
+	clientMock.On("CommitSync").Return(nil, errors.New("commit failed"))
+	conn := &appConnConsensus{
+		metrics: NopMetrics(),
+		appConn: clientMock,
+		tracer:  tracer,
+	}
+
+	tracer.SetRound(5, 2)
+	_, err := conn.PrepareProposalSync(types.RequestPrepareProposal{Height: 5})
+	require.NoError(t, err)
+	// blocksync executes the blocks without consensus round
+	_, err = conn.BeginBlockSync(types.RequestBeginBlock{Header: cmtproto.Header{Height: 6}})
+	require.NoError(t, err)
+	_, err = conn.CommitSync()
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "abci.prepare_proposal", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64("height", 5))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64("round", 2))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("abci.connection", connConsensus))
+
+	assert.Equal(t, "abci.begin_block", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.Int64("height", 6))
+	assert.NotContains(t, spans[1].Attributes(), attribute.Int64("round", 2))
+
+	// the spans of the calls without height are attributed to the last block
+	assert.Equal(t, "abci.commit", spans[2].Name())
+	assert.Contains(t, spans[2].Attributes(), attribute.Int64("height", 6))
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/evidence"
 	"github.com/cometbft/cometbft/internal/test"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	"github.com/cometbft/cometbft/privval"
@@ -165,3 +166,19 @@ func TestBroadcastEmptyEvidence(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestEvidence(t *testing.T) {
+	for i, c := range GetClients() {
+		t.Logf("client %d", i)
+
+		result, err := c.Evidence(context.Background(), 1, 0, nil, evidence.DuplicateVoteType, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, len(result.Evidence), result.TotalCount)
+
+		_, err = c.Evidence(context.Background(), 0, 0, nil, "unknown", nil, nil)
+		assert.Error(t, err)
+
+		_, err = c.Evidence(context.Background(), 10, 5, nil, "", nil, nil)
+		assert.Error(t, err)
+	}
+}
@@ -320,6 +320,15 @@ func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.Resul
 	return result, nil
 }
 
+func (c *baseRPCClient) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	result := new(ctypes.ResultTxStatus)
+	_, err := c.caller.Call(ctx, "tx_status", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	result := new(ctypes.ResultNetInfo)
 	_, err := c.caller.Call(ctx, "net_info", map[string]interface{}{}, result)
@@ -347,6 +356,75 @@ func (c *baseRPCClient) ConsensusState(ctx context.Context) (*ctypes.ResultConse
 	return result, nil
 }
 
+func (c *baseRPCClient) Events(
+	ctx context.Context,
+	after uint64,
+	query string,
+	limit *int,
+) (*ctypes.ResultEvents, error) {
+	result := new(ctypes.ResultEvents)
+	params := map[string]interface{}{
+		"after": after,
+		"query": query,
+	}
+	if limit != nil {
+		params["limit"] = limit
+	}
+	_, err := c.caller.Call(ctx, "events", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) EventLogOffset(ctx context.Context, consumer string) (*ctypes.ResultEventLogOffset, error) {
+	result := new(ctypes.ResultEventLogOffset)
+	_, err := c.caller.Call(ctx, "event_log_offset", map[string]interface{}{"consumer": consumer}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) CommitEventLogOffset(
+	ctx context.Context,
+	consumer string,
+	sequence uint64,
+) (*ctypes.ResultEventLogOffset, error) {
+	result := new(ctypes.ResultEventLogOffset)
+	params := map[string]interface{}{"consumer": consumer, "sequence": sequence}
+	_, err := c.caller.Call(ctx, "commit_event_log_offset", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) AppHashDivergence(ctx context.Context) (*ctypes.ResultAppHashDivergence, error) {
+	result := new(ctypes.ResultAppHashDivergence)
+	_, err := c.caller.Call(ctx, "app_hash_divergence", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ConsensusRoundHistory(
+	ctx context.Context,
+	limit *int,
+) (*ctypes.ResultConsensusRoundHistory, error) {
+	result := new(ctypes.ResultConsensusRoundHistory)
+	params := make(map[string]interface{})
+	if limit != nil {
+		params["limit"] = limit
+	}
+	_, err := c.caller.Call(ctx, "consensus_round_history", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusParams(
 	ctx context.Context,
 	height *int64,
@@ -446,6 +524,21 @@ func (c *baseRPCClient) BlockResults(
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockResultsByHash(
+	ctx context.Context,
+	hash bytes.HexBytes,
+) (*ctypes.ResultBlockResults, error) {
+	result := new(ctypes.ResultBlockResults)
+	params := map[string]interface{}{
+		"hash": hash,
+	}
+	_, err := c.caller.Call(ctx, "block_results_by_hash", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
 	result := new(ctypes.ResultHeader)
 	params := make(map[string]interface{})
@@ -580,6 +673,29 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorsByTime(
+	ctx context.Context,
+	t time.Time,
+	page,
+	perPage *int,
+) (*ctypes.ResultValidators, error) {
+	result := new(ctypes.ResultValidators)
+	params := map[string]interface{}{
+		"time": t.Unix(),
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "validators", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -592,6 +708,35 @@ func (c *baseRPCClient) BroadcastEvidence(
 	return result, nil
 }
 
+func (c *baseRPCClient) Evidence(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	validatorAddress []byte,
+	evType string,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvidence, error) {
+	result := new(ctypes.ResultEvidence)
+	params := map[string]interface{}{
+		"min_height":        minHeight,
+		"max_height":        maxHeight,
+		"validator_address": validatorAddress,
+		"type":              evType,
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "evidence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 //-----------------------------------------------------------------------------
 // WSEvents
 
@@ -22,6 +22,7 @@ implementation.
 
 import (
 	"context"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/service"
@@ -35,6 +36,7 @@ type Client interface {
 	service.Service
 	ABCIClient
 	EventsClient
+	EventLogClient
 	HistoryClient
 	NetworkClient
 	SignClient
@@ -67,10 +69,12 @@ type SignClient interface {
 	Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+	BlockResultsByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultBlockResults, error)
 	Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultHeader, error)
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
+	ValidatorsByTime(ctx context.Context, t time.Time, page, perPage *int) (*ctypes.ResultValidators, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
@@ -111,7 +115,9 @@ type NetworkClient interface {
 	NetInfo(context.Context) (*ctypes.ResultNetInfo, error)
 	DumpConsensusState(context.Context) (*ctypes.ResultDumpConsensusState, error)
 	ConsensusState(context.Context) (*ctypes.ResultConsensusState, error)
+	ConsensusRoundHistory(ctx context.Context, limit *int) (*ctypes.ResultConsensusRoundHistory, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
+	AppHashDivergence(context.Context) (*ctypes.ResultAppHashDivergence, error)
 	Health(context.Context) (*ctypes.ResultHealth, error)
 }
 
@@ -132,17 +138,28 @@ type EventsClient interface {
 	UnsubscribeAll(ctx context.Context, subscriber string) error
 }
 
+// EventLogClient reads the events recorded in the event log of the node, and
+// stores the offsets of its consumers.
+type EventLogClient interface {
+	Events(ctx context.Context, after uint64, query string, limit *int) (*ctypes.ResultEvents, error)
+	EventLogOffset(ctx context.Context, consumer string) (*ctypes.ResultEventLogOffset, error)
+	CommitEventLogOffset(ctx context.Context, consumer string, sequence uint64) (*ctypes.ResultEventLogOffset, error)
+}
+
 // MempoolClient shows us data about current mempool state.
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
+	TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
-// behavior.
+// behavior and listing the pending evidence.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+	Evidence(ctx context.Context, minHeight, maxHeight int64, validatorAddress []byte, evType string,
+		page, perPage *int) (*ctypes.ResultEvidence, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
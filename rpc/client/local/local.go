@@ -105,6 +105,10 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return c.env.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	return c.env.TxStatus(c.ctx, hash)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.env.CheckTx(c.ctx, tx)
 }
@@ -121,12 +125,36 @@ func (c *Local) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusStat
 	return c.env.GetConsensusState(c.ctx)
 }
 
+func (c *Local) Events(ctx context.Context, after uint64, query string, limit *int) (*ctypes.ResultEvents, error) {
+	return c.env.Events(c.ctx, after, query, limit)
+}
+
+func (c *Local) EventLogOffset(ctx context.Context, consumer string) (*ctypes.ResultEventLogOffset, error) {
+	return c.env.EventLogOffset(c.ctx, consumer)
+}
+
+func (c *Local) CommitEventLogOffset(
+	ctx context.Context,
+	consumer string,
+	sequence uint64,
+) (*ctypes.ResultEventLogOffset, error) {
+	return c.env.CommitEventLogOffset(c.ctx, consumer, sequence)
+}
+
+func (c *Local) ConsensusRoundHistory(ctx context.Context, limit *int) (*ctypes.ResultConsensusRoundHistory, error) {
+	return c.env.ConsensusRoundHistory(c.ctx, limit)
+}
+
+func (c *Local) AppHashDivergence(ctx context.Context) (*ctypes.ResultAppHashDivergence, error) {
+	return c.env.AppHashDivergence(c.ctx)
+}
+
 func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	return c.env.ConsensusParams(c.ctx, height)
 }
 
 func (c *Local) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
-	return c.env.Health(c.ctx)
+	return c.env.Health(c.ctx, nil)
 }
 
 func (c *Local) DialSeeds(ctx context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
@@ -167,6 +195,10 @@ func (c *Local) BlockResults(ctx context.Context, height *int64) (*ctypes.Result
 	return c.env.BlockResults(c.ctx, height)
 }
 
+func (c *Local) BlockResultsByHash(ctx context.Context, hash bytes.HexBytes) (*ctypes.ResultBlockResults, error) {
+	return c.env.BlockResultsByHash(c.ctx, hash)
+}
+
 func (c *Local) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
 	return c.env.Header(c.ctx, height)
 }
@@ -180,7 +212,12 @@ func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit
 }
 
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
-	return c.env.Validators(c.ctx, height, page, perPage)
+	return c.env.Validators(c.ctx, height, page, perPage, nil)
+}
+
+func (c *Local) ValidatorsByTime(ctx context.Context, t time.Time, page, perPage *int) (*ctypes.ResultValidators, error) {
+	unix := t.Unix()
+	return c.env.Validators(c.ctx, nil, page, perPage, &unix)
 }
 
 func (c *Local) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
@@ -195,7 +232,7 @@ func (c *Local) TxSearch(
 	perPage *int,
 	orderBy string,
 ) (*ctypes.ResultTxSearch, error) {
-	return c.env.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
+	return c.env.TxSearch(c.ctx, query, prove, page, perPage, orderBy, "")
 }
 
 func (c *Local) BlockSearch(
@@ -204,13 +241,23 @@ func (c *Local) BlockSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*ctypes.ResultBlockSearch, error) {
-	return c.env.BlockSearch(c.ctx, query, page, perPage, orderBy)
+	return c.env.BlockSearch(c.ctx, query, page, perPage, orderBy, "")
 }
 
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) Evidence(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+	validatorAddress []byte,
+	evType string,
+	page, perPage *int,
+) (*ctypes.ResultEvidence, error) {
+	return c.env.Evidence(c.ctx, minHeight, maxHeight, validatorAddress, evType, page, perPage)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
@@ -250,7 +297,12 @@ func (c *Local) eventsRoutine(
 	for {
 		select {
 		case msg := <-sub.Out():
-			result := ctypes.ResultEvent{Query: q.String(), Data: msg.Data(), Events: msg.Events()}
+			result := ctypes.ResultEvent{
+				Query:    q.String(),
+				Data:     msg.Data(),
+				Events:   msg.Events(),
+				Sequence: msg.Sequence(),
+			}
 			if cap(outc) == 0 {
 				outc <- result
 			} else {
@@ -17,6 +17,7 @@ want to directly call a CometBFT node in process, you can use the
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/libs/service"
@@ -119,6 +120,10 @@ func (c Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx
 	return c.env.CheckTx(&rpctypes.Context{}, tx)
 }
 
+func (c Client) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	return c.env.TxStatus(&rpctypes.Context{}, hash)
+}
+
 func (c Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return c.env.NetInfo(&rpctypes.Context{})
 }
@@ -127,6 +132,33 @@ func (c Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusStat
 	return c.env.GetConsensusState(&rpctypes.Context{})
 }
 
+func (c Client) Events(ctx context.Context, after uint64, query string, limit *int) (*ctypes.ResultEvents, error) {
+	return c.env.Events(&rpctypes.Context{}, after, query, limit)
+}
+
+func (c Client) EventLogOffset(ctx context.Context, consumer string) (*ctypes.ResultEventLogOffset, error) {
+	return c.env.EventLogOffset(&rpctypes.Context{}, consumer)
+}
+
+func (c Client) CommitEventLogOffset(
+	ctx context.Context,
+	consumer string,
+	sequence uint64,
+) (*ctypes.ResultEventLogOffset, error) {
+	return c.env.CommitEventLogOffset(&rpctypes.Context{}, consumer, sequence)
+}
+
+func (c Client) ConsensusRoundHistory(
+	ctx context.Context,
+	limit *int,
+) (*ctypes.ResultConsensusRoundHistory, error) {
+	return c.env.ConsensusRoundHistory(&rpctypes.Context{}, limit)
+}
+
+func (c Client) AppHashDivergence(ctx context.Context) (*ctypes.ResultAppHashDivergence, error) {
+	return c.env.AppHashDivergence(&rpctypes.Context{})
+}
+
 func (c Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	return c.env.DumpConsensusState(&rpctypes.Context{})
 }
@@ -136,7 +168,7 @@ func (c Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Res
 }
 
 func (c Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
-	return c.env.Health(&rpctypes.Context{})
+	return c.env.Health(&rpctypes.Context{}, nil)
 }
 
 func (c Client) DialSeeds(ctx context.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
@@ -174,9 +206,24 @@ func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit
 }
 
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
-	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
+	return c.env.Validators(&rpctypes.Context{}, height, page, perPage, nil)
+}
+
+func (c Client) ValidatorsByTime(ctx context.Context, t time.Time, page, perPage *int) (*ctypes.ResultValidators, error) {
+	unix := t.Unix()
+	return c.env.Validators(&rpctypes.Context{}, nil, page, perPage, &unix)
 }
 
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
+
+func (c Client) Evidence(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+	validatorAddress []byte,
+	evType string,
+	page, perPage *int,
+) (*ctypes.ResultEvidence, error) {
+	return c.env.Evidence(&rpctypes.Context{}, minHeight, maxHeight, validatorAddress, evType, page, perPage)
+}
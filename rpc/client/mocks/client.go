@@ -14,6 +14,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/cometbft/cometbft/types"
 )
 
@@ -91,6 +93,29 @@ func (_m *Client) ABCIQueryWithOptions(ctx context.Context, path string, data by
 	return r0, r1
 }
 
+// AppHashDivergence provides a mock function with given fields: _a0
+func (_m *Client) AppHashDivergence(_a0 context.Context) (*coretypes.ResultAppHashDivergence, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultAppHashDivergence
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultAppHashDivergence); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultAppHashDivergence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Block provides a mock function with given fields: ctx, height
 func (_m *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
 	ret := _m.Called(ctx, height)
@@ -160,6 +185,29 @@ func (_m *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.R
 	return r0, r1
 }
 
+// BlockResultsByHash provides a mock function with given fields: ctx, hash
+func (_m *Client) BlockResultsByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultBlockResults, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultBlockResults
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes) *coretypes.ResultBlockResults); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockResults)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockSearch provides a mock function with given fields: ctx, query, page, perPage, orderBy
 func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
 	ret := _m.Called(ctx, query, page, perPage, orderBy)
@@ -344,6 +392,29 @@ func (_m *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultC
 	return r0, r1
 }
 
+// CommitEventLogOffset provides a mock function with given fields: ctx, consumer, sequence
+func (_m *Client) CommitEventLogOffset(ctx context.Context, consumer string, sequence uint64) (*coretypes.ResultEventLogOffset, error) {
+	ret := _m.Called(ctx, consumer, sequence)
+
+	var r0 *coretypes.ResultEventLogOffset
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) *coretypes.ResultEventLogOffset); ok {
+		r0 = rf(ctx, consumer, sequence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEventLogOffset)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(ctx, consumer, sequence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusParams provides a mock function with given fields: ctx, height
 func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	ret := _m.Called(ctx, height)
@@ -367,6 +438,29 @@ func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretype
 	return r0, r1
 }
 
+// ConsensusRoundHistory provides a mock function with given fields: ctx, limit
+func (_m *Client) ConsensusRoundHistory(ctx context.Context, limit *int) (*coretypes.ResultConsensusRoundHistory, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 *coretypes.ResultConsensusRoundHistory
+	if rf, ok := ret.Get(0).(func(context.Context, *int) *coretypes.ResultConsensusRoundHistory); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultConsensusRoundHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusState provides a mock function with given fields: _a0
 func (_m *Client) ConsensusState(_a0 context.Context) (*coretypes.ResultConsensusState, error) {
 	ret := _m.Called(_a0)
@@ -413,6 +507,75 @@ func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDump
 	return r0, r1
 }
 
+// EventLogOffset provides a mock function with given fields: ctx, consumer
+func (_m *Client) EventLogOffset(ctx context.Context, consumer string) (*coretypes.ResultEventLogOffset, error) {
+	ret := _m.Called(ctx, consumer)
+
+	var r0 *coretypes.ResultEventLogOffset
+	if rf, ok := ret.Get(0).(func(context.Context, string) *coretypes.ResultEventLogOffset); ok {
+		r0 = rf(ctx, consumer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEventLogOffset)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, consumer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Events provides a mock function with given fields: ctx, after, query, limit
+func (_m *Client) Events(ctx context.Context, after uint64, query string, limit *int) (*coretypes.ResultEvents, error) {
+	ret := _m.Called(ctx, after, query, limit)
+
+	var r0 *coretypes.ResultEvents
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, *int) *coretypes.ResultEvents); ok {
+		r0 = rf(ctx, after, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string, *int) error); ok {
+		r1 = rf(ctx, after, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Evidence provides a mock function with given fields: ctx, minHeight, maxHeight, validatorAddress, evType, page, perPage
+func (_m *Client) Evidence(ctx context.Context, minHeight int64, maxHeight int64, validatorAddress []byte, evType string, page *int, perPage *int) (*coretypes.ResultEvidence, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight, validatorAddress, evType, page, perPage)
+
+	var r0 *coretypes.ResultEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, []byte, string, *int, *int) *coretypes.ResultEvidence); ok {
+		r0 = rf(ctx, minHeight, maxHeight, validatorAddress, evType, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, []byte, string, *int, *int) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight, validatorAddress, evType, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -797,6 +960,29 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	return r0, r1
 }
 
+// TxStatus provides a mock function with given fields: ctx, hash
+func (_m *Client) TxStatus(ctx context.Context, hash []byte) (*coretypes.ResultTxStatus, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxStatus
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultTxStatus); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnconfirmedTxs provides a mock function with given fields: ctx, limit
 func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(ctx, limit)
@@ -870,3 +1056,26 @@ func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perP
 
 	return r0, r1
 }
+
+// ValidatorsByTime provides a mock function with given fields: ctx, t, page, perPage
+func (_m *Client) ValidatorsByTime(ctx context.Context, t time.Time, page *int, perPage *int) (*coretypes.ResultValidators, error) {
+	ret := _m.Called(ctx, t, page, perPage)
+
+	var r0 *coretypes.ResultValidators
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *int, *int) *coretypes.ResultValidators); ok {
+		r0 = rf(ctx, t, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidators)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *int, *int) error); ok {
+		r1 = rf(ctx, t, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
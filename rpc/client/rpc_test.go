@@ -154,6 +154,17 @@ func TestConsensusState(t *testing.T) {
 	}
 }
 
+func TestConsensusRoundHistory(t *testing.T) {
+	for i, c := range GetClients() {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+		limit := 1
+		res, err := nc.ConsensusRoundHistory(context.Background(), &limit)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.NotEmpty(t, res.History)
+	}
+}
+
 func TestHealth(t *testing.T) {
 	for i, c := range GetClients() {
 		nc, ok := c.(client.NetworkClient)
@@ -303,6 +314,12 @@ func TestAppCalls(t *testing.T) {
 			assert.EqualValues(0, blockResults.TxsResults[0].Code)
 		}
 
+		txBlock, err := c.Block(context.Background(), &txh)
+		require.NoError(err)
+		blockResultsByHash, err := c.BlockResultsByHash(context.Background(), txBlock.BlockID.Hash)
+		require.NoError(err)
+		require.Equal(blockResults, blockResultsByHash)
+
 		// check blockchain info, now that we know there is info
 		info, err := c.BlockchainInfo(context.Background(), apph, apph)
 		require.NoError(err)
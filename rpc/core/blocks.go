@@ -52,6 +52,87 @@ func (env *Environment) BlockchainInfo(
 		BlockMetas: blockMetas}, nil
 }
 
+// BlockRetainHeight returns the base and latest heights of the block store,
+// the height below which the blocks are pruned, and the retain height set by
+// the operator.
+func (env *Environment) BlockRetainHeight(ctx *rpctypes.Context) (*ctypes.ResultBlockRetainHeight, error) {
+	if env.Pruner == nil {
+		return nil, errors.New("pruning is not available")
+	}
+	height := env.BlockStore.Height()
+	return &ctypes.ResultBlockRetainHeight{
+		Base:                 env.BlockStore.Base(),
+		Height:               height,
+		RetainHeight:         env.Pruner.PruningRetainHeight(height),
+		OperatorRetainHeight: env.Pruner.BlockRetainHeight(),
+	}, nil
+}
+
+// UnsafeSetBlockRetainHeight sets the height below which the blocks are
+// pruned in the background. 0 unsets it, only retaining the blocks required by
+// the application and by min_retain_blocks.
+func (env *Environment) UnsafeSetBlockRetainHeight(
+	ctx *rpctypes.Context,
+	height int64) (*ctypes.ResultBlockRetainHeight, error) {
+
+	if env.Pruner == nil {
+		return nil, errors.New("pruning is not available")
+	}
+	if err := env.Pruner.SetBlockRetainHeight(height); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetBlockRetainHeight", "height", height)
+	return env.BlockRetainHeight(ctx)
+}
+
+// BlockResultsRetainHeight returns the lowest height whose block results may
+// still be stored, the latest height, the height below which the block
+// results are pruned, and the results retain height set by the operator.
+func (env *Environment) BlockResultsRetainHeight(ctx *rpctypes.Context) (*ctypes.ResultBlockRetainHeight, error) {
+	if env.Pruner == nil {
+		return nil, errors.New("pruning is not available")
+	}
+	base, err := env.resultsBase()
+	if err != nil {
+		return nil, err
+	}
+	height := env.BlockStore.Height()
+	return &ctypes.ResultBlockRetainHeight{
+		Base:                 base,
+		Height:               height,
+		RetainHeight:         env.Pruner.ResultsPruningRetainHeight(height),
+		OperatorRetainHeight: env.Pruner.ResultsRetainHeight(),
+	}, nil
+}
+
+// UnsafeSetBlockResultsRetainHeight sets the height below which the block
+// results are pruned in the background, independently of the blocks. 0 unsets
+// it, pruning them along with the blocks unless min_retain_results is set.
+func (env *Environment) UnsafeSetBlockResultsRetainHeight(
+	ctx *rpctypes.Context,
+	height int64) (*ctypes.ResultBlockRetainHeight, error) {
+
+	if env.Pruner == nil {
+		return nil, errors.New("pruning is not available")
+	}
+	if err := env.Pruner.SetResultsRetainHeight(height); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetBlockResultsRetainHeight", "height", height)
+	return env.BlockResultsRetainHeight(ctx)
+}
+
+// resultsBase returns the lowest height whose block results may still be
+// stored: the one below which they were pruned, or the base of the block store
+// if they were only pruned along with the blocks.
+func (env *Environment) resultsBase() (int64, error) {
+	base, err := env.StateStore.LoadResultsBase()
+	if err != nil || base > 0 {
+		return base, err
+	}
+	return env.BlockStore.Base(), nil
+}
+
 // error if either min or max are negative or min > max
 // if 0, use blockstore base for min, latest block height for max
 // enforce limit.
@@ -186,8 +267,30 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		return nil, err
 	}
 
+	return env.blockResults(height)
+}
+
+// BlockResultsByHash gets ABCIResults of the block with the given hash.
+// More: https://docs.cometbft.com/main/rpc/#/Info/block_results_by_hash
+func (env *Environment) BlockResultsByHash(ctx *rpctypes.Context, hash bytes.HexBytes) (*ctypes.ResultBlockResults, error) {
+	// N.B. The hash parameter is HexBytes so that the reflective parameter
+	// decoding logic in the HTTP service will correctly translate from JSON.
+	// See https://github.com/tendermint/tendermint/issues/6802 for context.
+
+	height := env.BlockStore.LoadBlockHeightByHash(hash)
+	if height == 0 {
+		return nil, fmt.Errorf("block with hash %X not found", []byte(hash))
+	}
+
+	return env.blockResults(height)
+}
+
+func (env *Environment) blockResults(height int64) (*ctypes.ResultBlockResults, error) {
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
+		if base, baseErr := env.StateStore.LoadResultsBase(); baseErr == nil && height < base {
+			return nil, fmt.Errorf("results of height %d were pruned, lowest height is %d", height, base)
+		}
 		return nil, err
 	}
 
@@ -202,12 +305,14 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 }
 
 // BlockSearch searches for a paginated set of blocks matching BeginBlock and
-// EndBlock event search criteria.
+// EndBlock event search criteria, either on the ?page or after the ?cursor
+// returned with the previous page.
 func (env *Environment) BlockSearch(
 	ctx *rpctypes.Context,
 	query string,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	cursor string,
 ) (*ctypes.ResultBlockSearch, error) {
 
 	// skip if block indexing is disabled
@@ -220,18 +325,26 @@ func (env *Environment) BlockSearch(
 		return nil, err
 	}
 
-	results, err := env.BlockIndexer.Search(ctx.Context(), q)
+	searchCtx, limit := env.searchContext(ctx.Context())
+	defer limit.Release()
+	results, err := env.BlockIndexer.Search(searchCtx, q)
 	if err != nil {
 		return nil, err
 	}
+	if err := env.checkSearchLimits(limit, len(results)); err != nil {
+		return nil, err
+	}
 
 	// sort results (must be done before pagination)
+	var after func(height int64, c searchCursor) bool
 	switch orderBy {
 	case "desc", "":
 		sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
+		after = func(height int64, c searchCursor) bool { return height < c.Height }
 
 	case "asc":
 		sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+		after = func(height int64, c searchCursor) bool { return height > c.Height }
 
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
@@ -239,16 +352,14 @@ func (env *Environment) BlockSearch(
 
 	// paginate results
 	totalCount := len(results)
-	perPage := env.validatePerPage(perPagePtr)
-
-	page, err := validatePage(pagePtr, perPage, totalCount)
+	skipCount, pageSize, err := env.searchPage(pagePtr, perPagePtr, cursor, totalCount,
+		func(c searchCursor) int {
+			return sort.Search(totalCount, func(i int) bool { return after(results[i], c) })
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
-
 	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
 	for i := skipCount; i < skipCount+pageSize; i++ {
 		block := env.BlockStore.LoadBlock(results[i])
@@ -263,5 +374,10 @@ func (env *Environment) BlockSearch(
 		}
 	}
 
-	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
+	var nextCursor string
+	if end := skipCount + pageSize; end < totalCount && pageSize > 0 {
+		nextCursor = searchCursor{Height: results[end-1]}.String()
+	}
+
+	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount, NextCursor: nextCursor}, nil
 }
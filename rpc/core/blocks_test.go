@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/cometbft/cometbft-db"
@@ -117,4 +118,12 @@ func TestBlockResults(t *testing.T) {
 			assert.Equal(t, tc.wantRes, res)
 		}
 	}
+
+	mockstore.On("LoadBlockHeightByHash", []byte("h100")).Return(int64(100))
+	mockstore.On("LoadBlockHeightByHash", mock.Anything).Return(int64(0))
+	res, err := env.BlockResultsByHash(&rpctypes.Context{}, []byte("h100"))
+	require.NoError(t, err)
+	assert.Equal(t, testCases[3].wantRes, res)
+	_, err = env.BlockResultsByHash(&rpctypes.Context{}, []byte("unknown"))
+	assert.Error(t, err)
 }
@@ -1,6 +1,11 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
 	cm "github.com/cometbft/cometbft/consensus"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -14,11 +19,27 @@ import (
 // validators are sorted by their voting power - this is the canonical order
 // for the validators in the set as used in computing their Merkle root.
 //
+// Instead of a height, a time can be provided, as a UNIX time in seconds, to
+// fetch the validator set as of that time: the one of the last block whose
+// time is not after it.
+//
 // More: https://docs.cometbft.com/main/rpc/#/Info/validators
 func (env *Environment) Validators(
 	ctx *rpctypes.Context,
 	heightPtr *int64,
-	pagePtr, perPagePtr *int) (*ctypes.ResultValidators, error) {
+	pagePtr, perPagePtr *int,
+	timePtr *int64) (*ctypes.ResultValidators, error) {
+
+	if timePtr != nil {
+		if heightPtr != nil {
+			return nil, errors.New("only one of height and time can be provided")
+		}
+		height, err := env.heightByTime(time.Unix(*timePtr, 0))
+		if err != nil {
+			return nil, err
+		}
+		heightPtr = &height
+	}
 
 	// The latest validator that we know is the NextValidator of the last block.
 	height, err := env.getHeight(env.latestUncommittedHeight(), heightPtr)
@@ -49,6 +70,27 @@ func (env *Environment) Validators(
 		Total:       totalCount}, nil
 }
 
+// heightByTime returns the height of the last block whose time is not after
+// t, with a binary search of the block store, since the times of the blocks
+// increase with their height.
+func (env *Environment) heightByTime(t time.Time) (int64, error) {
+	base, height := env.BlockStore.Base(), env.BlockStore.Height()
+	if height == 0 {
+		return 0, errors.New("no blocks are available")
+	}
+	// the blocks pruned during the search count as before t
+	after := func(h int64) bool {
+		meta := env.BlockStore.LoadBlockMeta(h)
+		return meta != nil && meta.Header.Time.After(t)
+	}
+	if after(base) {
+		return 0, fmt.Errorf("time %v is before the lowest available block %d", t.UTC(), base)
+	}
+	// the number of blocks in (base, height] not after t
+	n := sort.Search(int(height-base), func(i int) bool { return after(base + 1 + int64(i)) })
+	return base + int64(n), nil
+}
+
 // DumpConsensusState dumps consensus state.
 // UNSTABLE
 // More: https://docs.cometbft.com/main/rpc/#/Info/dump_consensus_state
@@ -91,6 +133,47 @@ func (env *Environment) GetConsensusState(ctx *rpctypes.Context) (*ctypes.Result
 	return &ctypes.ResultConsensusState{RoundState: bz}, err
 }
 
+// ConsensusRoundHistory returns the timing of the rounds of the last committed
+// heights, the most recent first: the proposer, the time spent in each step,
+// the arrival times of the prevotes and precommits and which validators were
+// late. At most the last 100 heights are kept.
+// UNSTABLE
+// More: https://docs.cometbft.com/main/rpc/#/Info/consensus_round_history
+func (env *Environment) ConsensusRoundHistory(
+	ctx *rpctypes.Context,
+	limitPtr *int,
+) (*ctypes.ResultConsensusRoundHistory, error) {
+	// reuse per_page validator
+	limit := env.validatePerPage(limitPtr)
+
+	bz, err := env.ConsensusState.GetRoundHistoryJSON(limit)
+	return &ctypes.ResultConsensusRoundHistory{History: bz}, err
+}
+
+// AppHashDivergence returns the block committed by +2/3 of the validators
+// whose app hash disagrees with the one computed by the app of the node, upon
+// which the consensus halted, if it did. The node then keeps serving the RPC,
+// but no longer signs; the app state must be repaired, e.g. with the
+// diagnostics bundle written into base.diagnostics_dir, and the divergence
+// saved into data/app_hash_divergence.json removed, before restarting it.
+// More: https://docs.cometbft.com/main/rpc/#/Info/app_hash_divergence
+func (env *Environment) AppHashDivergence(ctx *rpctypes.Context) (*ctypes.ResultAppHashDivergence, error) {
+	divergence := env.ConsensusState.GetAppHashDivergence()
+	if divergence == nil {
+		return &ctypes.ResultAppHashDivergence{}, nil
+	}
+	return &ctypes.ResultAppHashDivergence{
+		Diverged:       true,
+		Height:         divergence.Height,
+		Round:          divergence.Round,
+		BlockID:        divergence.BlockID,
+		AppHash:        divergence.AppHash,
+		BlockAppHash:   divergence.BlockAppHash,
+		DiagnosticsDir: divergence.DiagnosticsDir,
+		Time:           divergence.Time,
+	}, nil
+}
+
 // ConsensusParams gets the consensus parameters at the given block height.
 // If no height is provided, it will fetch the latest consensus params.
 // More: https://docs.cometbft.com/main/rpc/#/Info/consensus_params
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestHeightByTime(t *testing.T) {
+	// the blocks 10 to 20, 10s apart
+	genesis := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	blockTime := func(h int64) time.Time { return genesis.Add(time.Duration(h) * 10 * time.Second) }
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Base").Return(int64(10))
+	mockstore.On("Height").Return(int64(20))
+	mockstore.On("LoadBlockMeta", mock.Anything).Return(func(h int64) *types.BlockMeta {
+		return &types.BlockMeta{Header: types.Header{Height: h, Time: blockTime(h)}}
+	})
+	env := &Environment{BlockStore: mockstore}
+
+	testCases := []struct {
+		time    time.Time
+		height  int64
+		wantErr bool
+	}{
+		{blockTime(9), 0, true},
+		{blockTime(10).Add(-time.Nanosecond), 0, true},
+		{blockTime(10), 10, false},
+		{blockTime(10).Add(5 * time.Second), 10, false},
+		{blockTime(15), 15, false},
+		{blockTime(16).Add(-time.Nanosecond), 15, false},
+		{blockTime(20), 20, false},
+		{blockTime(100), 20, false},
+	}
+	for _, tc := range testCases {
+		height, err := env.heightByTime(tc.time)
+		if tc.wantErr {
+			assert.Error(t, err, tc.time)
+			continue
+		}
+		require.NoError(t, err, tc.time)
+		assert.Equal(t, tc.height, height, tc.time)
+	}
+
+	height, unix := int64(15), blockTime(15).Unix()
+	_, err := env.Validators(&rpctypes.Context{}, &height, nil, nil, &unix)
+	assert.Error(t, err)
+}
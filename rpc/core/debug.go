@@ -0,0 +1,189 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const (
+	// maxDebugSnapshots is the number of debug snapshots kept, the oldest ones
+	// being removed.
+	maxDebugSnapshots = 10
+
+	debugSnapshotTimeFormat = "20060102T150405.000Z"
+)
+
+// debugProfiles are the pprof profiles captured in a debug snapshot, with
+// their debug level: 0 for the compressed protobuf format, read with
+// `go tool pprof`, and 2 for the stack traces of the goroutines in text.
+var debugProfiles = []struct {
+	name  string
+	file  string
+	debug int
+}{
+	{"heap", "heap.pb.gz", 0},
+	{"goroutine", "goroutine.txt", 2},
+	{"mutex", "mutex.pb.gz", 0},
+}
+
+// debugStats are the statistics of the runtime and stores of the node
+// captured in a debug snapshot.
+type debugStats struct {
+	Time         time.Time `json:"time"`
+	NumGoroutine int       `json:"num_goroutine"`
+	HeapAlloc    uint64    `json:"heap_alloc"`
+	HeapSys      uint64    `json:"heap_sys"`
+	NumGC        uint32    `json:"num_gc"`
+
+	BlockStore struct {
+		Base   int64 `json:"base"`
+		Height int64 `json:"height"`
+		Size   int64 `json:"size"`
+	} `json:"block_store"`
+
+	WAL struct {
+		Files     map[string]int64 `json:"files"` // name => size in bytes
+		TotalSize int64            `json:"total_size"`
+	} `json:"wal"`
+}
+
+// DebugSnapshot captures the heap, goroutine and mutex profiles of the node,
+// its consensus state and the statistics of its block store and consensus WAL
+// in a new directory of rpc.debug_snapshot_dir, named after the current time,
+// like the `cometbft debug dump` command but without access to the machine.
+// Only the clients with an admin API key may call it.
+//
+// The mutex profile is empty unless the mutex profiling is enabled, see
+// runtime.SetMutexProfileFraction.
+func (env *Environment) DebugSnapshot(ctx *rpctypes.Context) (*ctypes.ResultDebugSnapshot, error) {
+	if env.Config.DebugSnapshotDir == "" {
+		return nil, errors.New("debug snapshots are disabled")
+	}
+	root := env.Config.DebugSnapshotDirPath()
+	dir := filepath.Join(root, time.Now().UTC().Format(debugSnapshotTimeFormat))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the snapshot directory: %w", err)
+	}
+
+	files := make([]string, 0, len(debugProfiles)+2)
+	for _, p := range debugProfiles {
+		if err := writeProfile(filepath.Join(dir, p.file), p.name, p.debug); err != nil {
+			return nil, err
+		}
+		files = append(files, p.file)
+	}
+
+	if env.ConsensusState != nil {
+		state, err := env.ConsensusState.GetRoundStateJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the consensus state: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "consensus_state.json"), state, 0o600); err != nil {
+			return nil, err
+		}
+		files = append(files, "consensus_state.json")
+	}
+
+	stats, err := json.MarshalIndent(env.debugStats(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stats.json"), stats, 0o600); err != nil {
+		return nil, err
+	}
+	files = append(files, "stats.json")
+
+	if err := pruneDebugSnapshots(root); err != nil {
+		env.Logger.Error("Failed to remove the old debug snapshots", "err", err)
+	}
+	env.Logger.Info("Captured a debug snapshot", "dir", dir)
+	return &ctypes.ResultDebugSnapshot{Dir: dir, Files: files}, nil
+}
+
+func writeProfile(path, name string, debug int) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := pprof.Lookup(name).WriteTo(f, debug); err != nil {
+		return fmt.Errorf("failed to write the %s profile: %w", name, err)
+	}
+	return f.Close()
+}
+
+func (env *Environment) debugStats() *debugStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats := &debugStats{
+		Time:         time.Now().UTC(),
+		NumGoroutine: runtime.NumGoroutine(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapSys:      mem.HeapSys,
+		NumGC:        mem.NumGC,
+	}
+	if env.BlockStore != nil {
+		stats.BlockStore.Base = env.BlockStore.Base()
+		stats.BlockStore.Height = env.BlockStore.Height()
+		stats.BlockStore.Size = env.BlockStore.Size()
+	}
+
+	// The WAL is split in the head file and the rotated chunks, named after it.
+	stats.WAL.Files = make(map[string]int64)
+	if env.ConsensusWALFile != "" {
+		head := filepath.Base(env.ConsensusWALFile)
+		entries, err := os.ReadDir(filepath.Dir(env.ConsensusWALFile))
+		if err != nil {
+			env.Logger.Error("Failed to read the WAL directory", "err", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasPrefix(e.Name(), head) {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			stats.WAL.Files[e.Name()] = info.Size()
+			stats.WAL.TotalSize += info.Size()
+		}
+	}
+	return stats
+}
+
+// pruneDebugSnapshots removes the oldest snapshots in root, keeping the
+// latest maxDebugSnapshots of them.
+func pruneDebugSnapshots(root string) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	var snapshots []string
+	for _, e := range entries {
+		if _, err := time.Parse(debugSnapshotTimeFormat, e.Name()); e.IsDir() && err == nil {
+			snapshots = append(snapshots, e.Name())
+		}
+	}
+	if len(snapshots) <= maxDebugSnapshots {
+		return nil
+	}
+	// The names are sortable timestamps.
+	sort.Strings(snapshots)
+	for _, name := range snapshots[:len(snapshots)-maxDebugSnapshots] {
+		if err := os.RemoveAll(filepath.Join(root, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
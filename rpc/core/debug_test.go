@@ -0,0 +1,62 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestDebugSnapshot(t *testing.T) {
+	home := t.TempDir()
+	env := &Environment{Logger: log.TestingLogger()}
+	env.Config.RootDir = home
+	env.Config.DebugSnapshotDir = "debug"
+
+	walDir := filepath.Join(home, "cs.wal")
+	require.NoError(t, os.MkdirAll(walDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(walDir, "wal"), make([]byte, 10), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(walDir, "wal.000"), make([]byte, 20), 0o600))
+	env.ConsensusWALFile = filepath.Join(walDir, "wal")
+
+	res, err := env.DebugSnapshot(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "debug"), filepath.Dir(res.Dir))
+	assert.Equal(t, []string{"heap.pb.gz", "goroutine.txt", "mutex.pb.gz", "stats.json"}, res.Files)
+	for _, f := range res.Files {
+		assert.FileExists(t, filepath.Join(res.Dir, f))
+	}
+
+	bz, err := os.ReadFile(filepath.Join(res.Dir, "stats.json"))
+	require.NoError(t, err)
+	var stats debugStats
+	require.NoError(t, json.Unmarshal(bz, &stats))
+	assert.EqualValues(t, 30, stats.WAL.TotalSize)
+	assert.Len(t, stats.WAL.Files, 2)
+	assert.Positive(t, stats.NumGoroutine)
+
+	// Only the latest snapshots are kept.
+	root := filepath.Join(home, "debug")
+	for i := 0; i < maxDebugSnapshots; i++ {
+		name := time.Date(2020, 1, 1, 0, 0, i, 0, time.UTC).Format(debugSnapshotTimeFormat)
+		require.NoError(t, os.Mkdir(filepath.Join(root, name), 0o700))
+	}
+	_, err = env.DebugSnapshot(&rpctypes.Context{})
+	require.NoError(t, err)
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, maxDebugSnapshots)
+	assert.NoDirExists(t, filepath.Join(root, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Format(debugSnapshotTimeFormat)))
+	assert.DirExists(t, res.Dir)
+
+	env.Config.DebugSnapshotDir = ""
+	_, err = env.DebugSnapshot(&rpctypes.Context{})
+	assert.Error(t, err)
+}
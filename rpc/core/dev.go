@@ -1,6 +1,12 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	cm "github.com/cometbft/cometbft/consensus"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
@@ -10,3 +16,151 @@ func (env *Environment) UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.Resul
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// ReloadConfig reads the configuration file of the node again, and applies
+// the changes of the settings which can be changed without restarting the
+// node. Only the clients with an admin API key may call it.
+func (env *Environment) ReloadConfig(ctx *rpctypes.Context) (*ctypes.ResultReloadConfig, error) {
+	if env.ConfigReloader == nil {
+		return nil, errors.New("reloading the configuration is not available")
+	}
+	changed, err := env.ConfigReloader.ReloadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultReloadConfig{Changed: changed}, nil
+}
+
+// Backup starts a backup of the data directory of the node into a new
+// directory of rpc.backup_dir, consistent at a height boundary: the commits
+// of blocks are paused while the databases are copied. It returns once the
+// backup started, which BackupStatus then reports. Only the clients with an
+// admin API key may call it.
+func (env *Environment) Backup(ctx *rpctypes.Context) (*ctypes.ResultBackup, error) {
+	if env.Backuper == nil {
+		return nil, errors.New("backups are not available")
+	}
+	return env.Backuper.Backup()
+}
+
+// BackupStatus returns the last backup started with Backup. Only the clients
+// with an admin API key may call it.
+func (env *Environment) BackupStatus(ctx *rpctypes.Context) (*ctypes.ResultBackup, error) {
+	if env.Backuper == nil {
+		return nil, errors.New("backups are not available")
+	}
+	backup := env.Backuper.BackupStatus()
+	if backup == nil {
+		return nil, errors.New("no backup was started")
+	}
+	return backup, nil
+}
+
+// LogLevel returns the current log level of the node, a list of module:level
+// pairs. Only the clients with an admin API key may call it.
+func (env *Environment) LogLevel(ctx *rpctypes.Context) (*ctypes.ResultLogLevel, error) {
+	if env.LogLevelSetter == nil {
+		return nil, errors.New("changing the log level is not available")
+	}
+	return &ctypes.ResultLogLevel{LogLevel: env.LogLevelSetter.LogLevel()}, nil
+}
+
+// SetLogLevel changes the levels of the modules given in level, a list of
+// module:level pairs like the log_level setting, e.g. "consensus:debug", or
+// of all the other modules if level is a simple one word level, until the
+// node is restarted. Only the clients with an admin API key may call it.
+func (env *Environment) SetLogLevel(ctx *rpctypes.Context, level string) (*ctypes.ResultLogLevel, error) {
+	if env.LogLevelSetter == nil {
+		return nil, errors.New("changing the log level is not available")
+	}
+	logLevel, err := env.LogLevelSetter.SetLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultLogLevel{LogLevel: logLevel}, nil
+}
+
+// UnsafeSetHalt makes the node stop after committing the block of the given
+// height, if not 0, or the first block whose time is not before haltTime, a
+// UNIX time in seconds, if not 0, whichever comes first. 0 and 0 unset the
+// halt.
+func (env *Environment) UnsafeSetHalt(
+	ctx *rpctypes.Context,
+	height, haltTime int64) (*ctypes.ResultHalt, error) {
+
+	if env.Halter == nil {
+		return nil, errors.New("halting is not available")
+	}
+	if haltTime < 0 {
+		return nil, fmt.Errorf("halt time %d can't be negative", haltTime)
+	}
+	var t time.Time
+	if haltTime > 0 {
+		t = time.Unix(haltTime, 0).UTC()
+	}
+	if err := env.Halter.SetHalt(height, t); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetHalt", "height", height, "time", t)
+	height, t = env.Halter.Halt()
+	return &ctypes.ResultHalt{HaltHeight: height, HaltTime: t}, nil
+}
+
+// UnsafeConsensusTrace reads the consensus WAL and returns the step
+// transitions, timeouts, proposals and votes recorded for the heights in
+// [minHeight, maxHeight]. If maxHeight is not provided, the height currently
+// being decided is used.
+//
+// At most 20 heights will be traced.
+func (env *Environment) UnsafeConsensusTrace(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64) (*ctypes.ResultConsensusTrace, error) {
+
+	if env.ConsensusWALFile == "" {
+		return nil, errors.New("consensus WAL is not available")
+	}
+
+	const limit int64 = 20
+	minHeight, maxHeight, err := filterMinMax(
+		1,
+		env.latestUncommittedHeight(),
+		minHeight,
+		maxHeight,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, err := cm.TraceWAL(env.ConsensusWALFile, minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	traceJSON, err := cmtjson.Marshal(trace)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultConsensusTrace{Trace: traceJSON}, nil
+}
+
+// UnsafePropagationTrace returns when each block part and vote of the round
+// of the given height was first received, and from which peer, in the order
+// they were received. If height is not provided, the height currently being
+// decided is used. The propagation must be traced, see
+// consensus.trace_propagation.
+func (env *Environment) UnsafePropagationTrace(
+	ctx *rpctypes.Context,
+	heightPtr *int64, round int32) (*ctypes.ResultPropagationTrace, error) {
+
+	height := env.latestUncommittedHeight()
+	if heightPtr != nil {
+		height = *heightPtr
+	}
+	if round < 0 {
+		return nil, fmt.Errorf("round %d can't be negative", round)
+	}
+	trace, err := env.ConsensusState.GetPropagationTraceJSON(height, round)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultPropagationTrace{Trace: trace}, nil
+}
@@ -29,6 +29,7 @@ Available endpoints:
 
 Endpoints that require arguments:
 /abci_query?path=_&data=_&prove=_
+/consensus_round_history?limit=_
 /block?height=_
 /blockchain?minHeight=_&maxHeight=_
 /broadcast_tx_async?tx=_
@@ -37,8 +38,11 @@ Endpoints that require arguments:
 /commit?height=_
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
+/evidence?min_height=_&max_height=_&validator_address=_&type=_&page=_&per_page=_
 /subscribe?event=_
 /tx?hash=_&prove=_
+/unsafe_consensus_trace?minHeight=_&maxHeight=_
+/unsafe_propagation_trace?height=_&round=_
 /unsubscribe?event=_
 ```
 */
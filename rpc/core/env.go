@@ -6,13 +6,18 @@ import (
 	"time"
 
 	cfg "github.com/cometbft/cometbft/config"
+	cm "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/evidence"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/state/eventlog"
 	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
@@ -41,6 +46,9 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetRoundHistoryJSON(limit int) ([]byte, error)
+	GetPropagationTraceJSON(height int64, round int32) ([]byte, error)
+	GetAppHashDivergence() *cm.AppHashDivergence
 }
 
 type transport interface {
@@ -57,10 +65,39 @@ type peers interface {
 	Peers() p2p.IPeerSet
 }
 
+type addrBook interface {
+	Export() *pex.AddrBookExport
+	Import(*pex.AddrBookExport) (int, error)
+}
+
+type evidencePool interface {
+	sm.EvidencePool
+	ListPendingEvidence(filter evidence.Filter, skip, limit int) ([]types.Evidence, int, error)
+}
+
 type consensusReactor interface {
 	WaitSync() bool
 }
 
+type configReloader interface {
+	ReloadConfigFile() ([]string, error)
+}
+
+type logLevelSetter interface {
+	LogLevel() string
+	SetLogLevel(level string) (string, error)
+}
+
+type backuper interface {
+	Backup() (*ctypes.ResultBackup, error)
+	BackupStatus() *ctypes.ResultBackup
+}
+
+type halter interface {
+	SetHalt(height int64, haltTime time.Time) error
+	Halt() (int64, time.Time)
+}
+
 // ----------------------------------------------
 // Environment contains objects and interfaces used by the RPC. It is expected
 // to be setup once during startup.
@@ -72,11 +109,12 @@ type Environment struct {
 	// interfaces defined in types and above
 	StateStore       sm.Store
 	BlockStore       sm.BlockStore
-	EvidencePool     sm.EvidencePool
+	EvidencePool     evidencePool
 	ConsensusState   Consensus
 	ConsensusReactor consensusReactor
 	P2PPeers         peers
 	P2PTransport     transport
+	AddrBook         addrBook
 
 	// objects
 	PubKey       crypto.PubKey
@@ -84,7 +122,18 @@ type Environment struct {
 	TxIndexer    txindex.TxIndexer
 	BlockIndexer indexer.BlockIndexer
 	EventBus     *types.EventBus // thread safe
+	EventLog     *eventlog.Log   // nil unless the event log is enabled
 	Mempool      mempl.Mempool
+	Pruner       *sm.Pruner
+
+	// reloads the configuration file of the node
+	ConfigReloader configReloader
+	LogLevelSetter logLevelSetter
+	Halter         halter
+	Backuper       backuper
+
+	// path to the consensus WAL, used by the consensus trace endpoint
+	ConsensusWALFile string
 
 	Logger log.Logger
 
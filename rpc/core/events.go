@@ -10,6 +10,7 @@ import (
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
 )
 
 const (
@@ -18,9 +19,15 @@ const (
 	maxQueryLength = 512
 )
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. If resume is set, the events published
+// after the one with this sequence number are sent first, so that a client
+// that reconnects does not miss any event.
 // More: https://docs.cometbft.com/main/rpc/#/Websocket/subscribe
-func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func (env *Environment) Subscribe(
+	ctx *rpctypes.Context,
+	query string,
+	resume *uint64,
+) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -41,7 +48,12 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	var sub types.Subscription
+	if resume != nil {
+		sub, err = env.EventBus.SubscribeFrom(subCtx, addr, q, *resume, env.Config.SubscriptionBufferSize)
+	} else {
+		sub, err = env.EventBus.Subscribe(subCtx, addr, q, env.Config.SubscriptionBufferSize)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -55,8 +67,13 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 			select {
 			case msg := <-sub.Out():
 				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+					resultEvent = &ctypes.ResultEvent{
+						Query:    query,
+						Data:     msg.Data(),
+						Events:   msg.Events(),
+						Sequence: msg.Sequence(),
+					}
+					resp = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
 				)
 				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 				defer cancel()
@@ -128,3 +145,75 @@ func (env *Environment) UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUns
 	}
 	return &ctypes.ResultUnsubscribe{}, nil
 }
+
+// Events returns at most limit events recorded in the event log after the one
+// with the given sequence number and matching the query, if any, oldest first.
+// A client which stopped at sequence number N gets the events it missed with
+// after=N, as long as they are retained.
+// More: https://docs.cometbft.com/main/rpc/#/Info/events
+func (env *Environment) Events(
+	ctx *rpctypes.Context,
+	after uint64,
+	query string,
+	limitPtr *int,
+) (*ctypes.ResultEvents, error) {
+	if env.EventLog == nil {
+		return nil, errors.New("the event log is disabled")
+	}
+	if len(query) > maxQueryLength {
+		return nil, errors.New("maximum query length exceeded")
+	}
+	var q cmtpubsub.Query
+	if query != "" {
+		parsed, err := cmtquery.New(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse query: %w", err)
+		}
+		q = parsed
+	}
+	// reuse per_page validator
+	limit := env.validatePerPage(limitPtr)
+
+	first, last := env.EventLog.Range()
+	events, err := env.EventLog.Events(after, limit, q)
+	if err != nil {
+		return nil, err
+	}
+	res := &ctypes.ResultEvents{Events: make([]*ctypes.ResultEvent, len(events)), First: first, Last: last}
+	for i, ev := range events {
+		res.Events[i] = &ctypes.ResultEvent{Query: query, Data: ev.Data, Events: ev.Events, Sequence: ev.Sequence}
+	}
+	return res, nil
+}
+
+// EventLogOffset returns the sequence number of the last event of the event
+// log processed by the consumer, as stored with /commit_event_log_offset, or 0.
+// More: https://docs.cometbft.com/main/rpc/#/Info/event_log_offset
+func (env *Environment) EventLogOffset(ctx *rpctypes.Context, consumer string) (*ctypes.ResultEventLogOffset, error) {
+	if env.EventLog == nil {
+		return nil, errors.New("the event log is disabled")
+	}
+	seq, err := env.EventLog.Offset(consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultEventLogOffset{Consumer: consumer, Sequence: seq}, nil
+}
+
+// CommitEventLogOffset stores the sequence number of the last event of the
+// event log processed by the consumer, so that it can resume from it after a
+// restart. It is restricted to the clients with an admin API key.
+// More: https://docs.cometbft.com/main/rpc/#/Info/commit_event_log_offset
+func (env *Environment) CommitEventLogOffset(
+	ctx *rpctypes.Context,
+	consumer string,
+	sequence uint64,
+) (*ctypes.ResultEventLogOffset, error) {
+	if env.EventLog == nil {
+		return nil, errors.New("the event log is disabled")
+	}
+	if err := env.EventLog.SetOffset(consumer, sequence); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultEventLogOffset{Consumer: consumer, Sequence: sequence}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/cometbft/cometbft/evidence"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	"github.com/cometbft/cometbft/types"
@@ -28,3 +29,55 @@ func (env *Environment) BroadcastEvidence(
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// Evidence gets the pending evidence, optionally filtered by height range,
+// validator address and evidence type ("duplicate_vote" or
+// "light_client_attack"), from oldest to newest.
+// More: https://docs.cometbft.com/main/rpc/#/Evidence/evidence
+func (env *Environment) Evidence(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64,
+	validatorAddress []byte,
+	evType string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultEvidence, error) {
+	switch evType {
+	case "", evidence.DuplicateVoteType, evidence.LightClientAttackType:
+	default:
+		return nil, fmt.Errorf("expected type to be either %q or %q or empty",
+			evidence.DuplicateVoteType, evidence.LightClientAttackType)
+	}
+	if minHeight < 0 || maxHeight < 0 {
+		return nil, errors.New("heights must be non negative")
+	}
+	if maxHeight > 0 && minHeight > maxHeight {
+		return nil, fmt.Errorf("min height %d can't be greater than max height %d", minHeight, maxHeight)
+	}
+
+	filter := evidence.Filter{
+		MinHeight:        minHeight,
+		MaxHeight:        maxHeight,
+		ValidatorAddress: validatorAddress,
+		Type:             evType,
+	}
+	if len(validatorAddress) == 0 {
+		filter.ValidatorAddress = nil
+	}
+
+	perPage := env.validatePerPage(perPagePtr)
+	page := 1
+	if pagePtr != nil {
+		page = *pagePtr
+	}
+
+	// the total count is only known once the evidence has been listed
+	evList, totalCount, err := env.EvidencePool.ListPendingEvidence(filter, validateSkipCount(page, perPage), perPage)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validatePage(pagePtr, perPage, totalCount); err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultEvidence{Evidence: evList, TotalCount: totalCount}, nil
+}
@@ -1,13 +1,56 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
 // Health gets node health. Returns empty result (200 OK) on success, no
-// response - in case of an error.
+// response - in case of an error. The result reports whether the node is
+// catching up through block sync or state sync.
+//
+// If ready is set, it also reports whether the node is ready to serve
+// requests, that is, its consensus didn't halt upon an app hash divergence
+// (see AppHashDivergence), it is not catching up through block sync or state
+// sync and has at least rpc.readiness_min_peers peers. If not, an error is
+// returned, answered with 503 Service Unavailable on the URI endpoint, so
+// that load balancers can take the node out of rotation.
 // More: https://docs.cometbft.com/main/rpc/#/Info/health
-func (env *Environment) Health(ctx *rpctypes.Context) (*ctypes.ResultHealth, error) {
-	return &ctypes.ResultHealth{}, nil
+func (env *Environment) Health(ctx *rpctypes.Context, ready *bool) (*ctypes.ResultHealth, error) {
+	if ready == nil || !*ready {
+		res := &ctypes.ResultHealth{}
+		if env.ConsensusReactor != nil {
+			res.CatchingUp = env.ConsensusReactor.WaitSync()
+		}
+		return res, nil
+	}
+
+	var (
+		divergence = env.ConsensusState.GetAppHashDivergence()
+		catchingUp = env.ConsensusReactor.WaitSync()
+		numPeers   = env.P2PPeers.Peers().Size()
+	)
+	switch {
+	case divergence != nil:
+		return nil, rpctypes.HTTPStatusError{
+			Status: http.StatusServiceUnavailable,
+			Err:    fmt.Errorf("node is not ready: the app hash diverged at height %d", divergence.Height),
+		}
+	case catchingUp:
+		return nil, rpctypes.HTTPStatusError{
+			Status: http.StatusServiceUnavailable,
+			Err:    errors.New("node is not ready: catching up"),
+		}
+	case numPeers < env.Config.ReadinessMinPeers:
+		return nil, rpctypes.HTTPStatusError{
+			Status: http.StatusServiceUnavailable,
+			Err: fmt.Errorf("node is not ready: %d peers, at least %d required",
+				numPeers, env.Config.ReadinessMinPeers),
+		}
+	}
+	return &ctypes.ResultHealth{Ready: true, NumPeers: numPeers}, nil
 }
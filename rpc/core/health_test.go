@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cm "github.com/cometbft/cometbft/consensus"
+	"github.com/cometbft/cometbft/p2p"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+type syncingReactor bool
+
+func (r syncingReactor) WaitSync() bool { return bool(r) }
+
+type divergedConsensus struct {
+	Consensus
+	divergence *cm.AppHashDivergence
+}
+
+func (c divergedConsensus) GetAppHashDivergence() *cm.AppHashDivergence { return c.divergence }
+
+func TestHealthReadiness(t *testing.T) {
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1, "testing", "123.123.123",
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
+
+	env := &Environment{}
+	env.P2PPeers = sw
+	env.ConsensusState = divergedConsensus{}
+	env.ConsensusReactor = syncingReactor(true)
+	env.Config.ReadinessMinPeers = 1
+
+	ready := true
+	notReady := func(err error) {
+		var statusErr rpctypes.HTTPStatusError
+		if assert.True(t, errors.As(err, &statusErr), err) {
+			assert.Equal(t, http.StatusServiceUnavailable, statusErr.Status)
+		}
+	}
+
+	// the liveness check does not depend on the readiness
+	res, err := env.Health(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.False(t, res.Ready)
+	assert.True(t, res.CatchingUp)
+
+	_, err = env.Health(&rpctypes.Context{}, &ready)
+	notReady(err)
+
+	env.ConsensusReactor = syncingReactor(false)
+	_, err = env.Health(&rpctypes.Context{}, &ready)
+	notReady(err)
+
+	env.Config.ReadinessMinPeers = 0
+	res, err = env.Health(&rpctypes.Context{}, &ready)
+	require.NoError(t, err)
+	assert.True(t, res.Ready)
+
+	env.ConsensusState = divergedConsensus{divergence: &cm.AppHashDivergence{Height: 10}}
+	_, err = env.Health(&rpctypes.Context{}, &ready)
+	notReady(err)
+}
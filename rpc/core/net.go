@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
@@ -94,6 +95,33 @@ func (env *Environment) UnsafeDialPeers(
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeExportAddrBook exports the address book, with what the node knows
+// about each address, to be imported by another node with
+// UnsafeImportAddrBook.
+func (env *Environment) UnsafeExportAddrBook(ctx *rpctypes.Context) (*ctypes.ResultExportAddrBook, error) {
+	if env.AddrBook == nil {
+		return nil, errors.New("address book is not available")
+	}
+	return &ctypes.ResultExportAddrBook{AddrBook: env.AddrBook.Export()}, nil
+}
+
+// UnsafeImportAddrBook adds the addresses of an exported address book unknown
+// to the node's address book. Either all the addresses are imported or none.
+func (env *Environment) UnsafeImportAddrBook(
+	ctx *rpctypes.Context,
+	addrBook *pex.AddrBookExport) (*ctypes.ResultImportAddrBook, error) {
+
+	if env.AddrBook == nil {
+		return nil, errors.New("address book is not available")
+	}
+	imported, err := env.AddrBook.Import(addrBook)
+	if err != nil {
+		return nil, err
+	}
+	env.Logger.Info("ImportAddrBook", "imported", imported)
+	return &ctypes.ResultImportAddrBook{Imported: imported}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.cometbft.com/main/rpc/#/Info/genesis
 func (env *Environment) Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
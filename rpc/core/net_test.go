@@ -1,14 +1,18 @@
 package core
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	cfg "github.com/cometbft/cometbft/config"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
 
@@ -87,3 +91,45 @@ func TestUnsafeDialPeers(t *testing.T) {
 		}
 	}
 }
+
+func TestUnsafeExportImportAddrBook(t *testing.T) {
+	env := &Environment{}
+	env.Logger = log.TestingLogger()
+	_, err := env.UnsafeExportAddrBook(&rpctypes.Context{})
+	assert.Error(t, err)
+
+	newAddrBook := func() pex.AddrBook {
+		book := pex.NewAddrBook(filepath.Join(t.TempDir(), "addrbook.json"), true)
+		book.SetLogger(log.TestingLogger())
+		return book
+	}
+	book := newAddrBook()
+	for _, s := range []string{
+		"d51fb70907db1c6c2d5237e78379b25cf1a37ab4@8.8.8.8:26656",
+		"b0dd378c3fbc4c156cd6d302a799f0d2e4227201@159.89.121.174:26656",
+	} {
+		addr, err := p2p.NewNetAddressString(s)
+		require.NoError(t, err)
+		require.NoError(t, book.AddAddress(addr, addr))
+	}
+	book.MarkGood("d51fb70907db1c6c2d5237e78379b25cf1a37ab4")
+	env.AddrBook = book
+
+	exported, err := env.UnsafeExportAddrBook(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Len(t, exported.AddrBook.Addrs, 2)
+
+	// import the JSON export, as sent to the RPC
+	bz, err := cmtjson.Marshal(exported)
+	require.NoError(t, err)
+	var res ctypes.ResultExportAddrBook
+	require.NoError(t, cmtjson.Unmarshal(bz, &res))
+
+	env.AddrBook = newAddrBook()
+	imported, err := env.UnsafeImportAddrBook(&rpctypes.Context{}, res.AddrBook)
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported.Imported)
+	assert.Equal(t, 2, env.AddrBook.(pex.AddrBook).Size())
+	assert.Equal(t, exported.AddrBook.Addrs[0].Addr, env.AddrBook.Export().Addrs[0].Addr)
+	assert.True(t, env.AddrBook.Export().Addrs[0].Good)
+}
@@ -8,49 +8,76 @@ import (
 
 type RoutesMap map[string]*rpc.RPCFunc
 
+// The classes of the routes which are rate limited separately from the
+// others, of the rpc.DefaultRouteClass.
+const (
+	SearchRouteClass    = "search"
+	BroadcastRouteClass = "broadcast"
+)
+
 // Routes is a map of available routes.
 func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query,resume"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
 		// info AP
-		"health":               rpc.NewRPCFunc(env.Health, ""),
-		"status":               rpc.NewRPCFunc(env.Status, ""),
-		"net_info":             rpc.NewRPCFunc(env.NetInfo, ""),
-		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-		"genesis":              rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
-		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
-		"block":                rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
-		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
-		"commit":               rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
-		"header":               rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
-		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
-		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx"),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by"),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", rpc.Cacheable("height")),
-		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
-		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, ""),
-		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
-		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"health":                      rpc.NewRPCFunc(env.Health, "ready"),
+		"status":                      rpc.NewRPCFunc(env.Status, ""),
+		"net_info":                    rpc.NewRPCFunc(env.NetInfo, ""),
+		"blockchain":                  rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
+		"block_retain_height":         rpc.NewRPCFunc(env.BlockRetainHeight, ""),
+		"block_results_retain_height": rpc.NewRPCFunc(env.BlockResultsRetainHeight, ""),
+		"genesis":                     rpc.NewRPCFunc(env.Genesis, "", rpc.Cacheable()),
+		"genesis_chunked":             rpc.NewRPCFunc(env.GenesisChunked, "chunk", rpc.Cacheable()),
+		"block":                       rpc.NewRPCFunc(env.Block, "height", rpc.Cacheable("height")),
+		"block_by_hash":               rpc.NewRPCFunc(env.BlockByHash, "hash", rpc.Cacheable()),
+		"block_results":               rpc.NewRPCFunc(env.BlockResults, "height", rpc.Cacheable("height")),
+		"block_results_by_hash":       rpc.NewRPCFunc(env.BlockResultsByHash, "hash", rpc.Cacheable()),
+		"commit":                      rpc.NewRPCFunc(env.Commit, "height", rpc.Cacheable("height")),
+		"header":                      rpc.NewRPCFunc(env.Header, "height", rpc.Cacheable("height")),
+		"header_by_hash":              rpc.NewRPCFunc(env.HeaderByHash, "hash", rpc.Cacheable()),
+		"check_tx":                    rpc.NewRPCFunc(env.CheckTx, "tx"),
+		"tx":                          rpc.NewRPCFunc(env.Tx, "hash,prove", rpc.Cacheable()),
+		"tx_search":                   rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by,cursor", rpc.RouteClass(SearchRouteClass)),
+		"block_search":                rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,cursor", rpc.RouteClass(SearchRouteClass)),
+		"validators":                  rpc.NewRPCFunc(env.Validators, "height,page,per_page,time", rpc.Cacheable("height")),
+		"dump_consensus_state":        rpc.NewRPCFunc(env.DumpConsensusState, ""),
+		"consensus_state":             rpc.NewRPCFunc(env.GetConsensusState, ""),
+		"consensus_round_history":     rpc.NewRPCFunc(env.ConsensusRoundHistory, "limit"),
+		"consensus_params":            rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
+		"app_hash_divergence":         rpc.NewRPCFunc(env.AppHashDivergence, ""),
+		"unconfirmed_txs":             rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
+		"num_unconfirmed_txs":         rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"tx_status":                   rpc.NewRPCFunc(env.TxStatus, "hash"),
+
+		// event log API
+		"events":                  rpc.NewRPCFunc(env.Events, "after,query,limit"),
+		"event_log_offset":        rpc.NewRPCFunc(env.EventLogOffset, "consumer"),
+		"commit_event_log_offset": rpc.NewRPCFunc(env.CommitEventLogOffset, "consumer,sequence", rpc.AdminOnly()),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
-		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx"),
-		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx"),
+		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", rpc.RouteClass(BroadcastRouteClass)),
+		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx", rpc.RouteClass(BroadcastRouteClass)),
+		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx", rpc.RouteClass(BroadcastRouteClass)),
 
 		// abci API
 		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove"),
 		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", rpc.Cacheable()),
 
 		// evidence API
-		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence"),
+		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence", rpc.RouteClass(BroadcastRouteClass)),
+		"evidence":           rpc.NewRPCFunc(env.Evidence, "min_height,max_height,validator_address,type,page,per_page"),
+
+		// control API, restricted to the clients with an admin API key
+		"reload_config":  rpc.NewRPCFunc(env.ReloadConfig, "", rpc.AdminOnly()),
+		"debug_snapshot": rpc.NewRPCFunc(env.DebugSnapshot, "", rpc.AdminOnly()),
+		"backup":         rpc.NewRPCFunc(env.Backup, "", rpc.AdminOnly()),
+		"backup_status":  rpc.NewRPCFunc(env.BackupStatus, "", rpc.AdminOnly()),
+		"log_level":      rpc.NewRPCFunc(env.LogLevel, "", rpc.AdminOnly()),
+		"set_log_level":  rpc.NewRPCFunc(env.SetLogLevel, "level", rpc.AdminOnly()),
 	}
 }
 
@@ -60,4 +87,13 @@ func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
 	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
 	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
+	routes["unsafe_export_addr_book"] = rpc.NewRPCFunc(env.UnsafeExportAddrBook, "")
+	routes["unsafe_import_addr_book"] = rpc.NewRPCFunc(env.UnsafeImportAddrBook, "addr_book")
+	routes["set_block_retain_height"] = rpc.NewRPCFunc(env.UnsafeSetBlockRetainHeight, "height")
+	routes["set_block_results_retain_height"] = rpc.NewRPCFunc(env.UnsafeSetBlockResultsRetainHeight, "height")
+	routes["set_halt"] = rpc.NewRPCFunc(env.UnsafeSetHalt, "height,time")
+
+	// debug API
+	routes["unsafe_consensus_trace"] = rpc.NewRPCFunc(env.UnsafeConsensusTrace, "minHeight,maxHeight")
+	routes["unsafe_propagation_trace"] = rpc.NewRPCFunc(env.UnsafePropagationTrace, "height,round")
 }
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/state/indexer"
+)
+
+// searchContext returns the context to search the indexers with, limiting the
+// number of index entries scanned. The caller must release the limit once
+// done.
+func (env *Environment) searchContext(ctx context.Context) (context.Context, *indexer.ScanLimit) {
+	return indexer.WithScanLimit(ctx, int64(env.Config.MaxSearchScannedEntries))
+}
+
+// checkSearchLimits returns an error if the search exceeded the scan limit,
+// or matched more results than allowed.
+func (env *Environment) checkSearchLimits(limit *indexer.ScanLimit, numResults int) error {
+	if limit.Exceeded() {
+		return fmt.Errorf("query scanned more than %d index entries, narrow it down (e.g. with a height range)",
+			env.Config.MaxSearchScannedEntries)
+	}
+	if max := env.Config.MaxSearchResults; max > 0 && numResults > max {
+		return fmt.Errorf("query matched %d results, more than %d, narrow it down (e.g. with a height range)",
+			numResults, max)
+	}
+	return nil
+}
+
+// searchCursor is the position of the last result of a page of search
+// results, the next page starting with the result after it.
+type searchCursor struct {
+	Height int64
+	Index  uint32 // of the tx in the block, 0 for blocks
+}
+
+func (c searchCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%d", c.Height, c.Index)))
+}
+
+func parseSearchCursor(s string) (searchCursor, error) {
+	var c searchCursor
+	bz, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if _, err := fmt.Sscanf(string(bz), "%d/%d", &c.Height, &c.Index); err != nil {
+		return c, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return c, nil
+}
+
+// searchPage returns the range of the sorted search results to return, either
+// the page or, if the cursor is set, the results after the cursor, starting
+// at the index returned by after.
+func (env *Environment) searchPage(
+	pagePtr, perPagePtr *int,
+	cursor string,
+	totalCount int,
+	after func(searchCursor) int,
+) (skipCount, pageSize int, err error) {
+	perPage := env.validatePerPage(perPagePtr)
+
+	if cursor != "" {
+		if pagePtr != nil {
+			return 0, 0, errors.New("page and cursor can't be set together")
+		}
+		c, err := parseSearchCursor(cursor)
+		if err != nil {
+			return 0, 0, err
+		}
+		skipCount = after(c)
+	} else {
+		page, err := validatePage(pagePtr, perPage, totalCount)
+		if err != nil {
+			return 0, 0, err
+		}
+		skipCount = validateSkipCount(page, perPage)
+	}
+
+	return skipCount, cmtmath.MinInt(perPage, totalCount-skipCount), nil
+}
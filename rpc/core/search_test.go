@@ -0,0 +1,121 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
+	"github.com/cometbft/cometbft/state/mocks"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func newSearchTestEnv(t *testing.T) *Environment {
+	env := &Environment{
+		TxIndexer:    kv.NewTxIndex(dbm.NewMemDB()),
+		BlockIndexer: blockidxkv.New(dbm.NewMemDB()),
+		Config:       *config.TestRPCConfig(),
+	}
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("LoadBlock", mock.Anything).Return(nil)
+	env.BlockStore = mockstore
+
+	// 3 txs in each of the heights 1 to 4
+	events := []abci.Event{{Type: "app", Attributes: []abci.EventAttribute{{Key: "key", Value: "v", Index: true}}}}
+	for height := int64(1); height <= 4; height++ {
+		for index := uint32(0); index < 3; index++ {
+			require.NoError(t, env.TxIndexer.Index(&abci.TxResult{
+				Height: height,
+				Index:  index,
+				Tx:     types.Tx(fmt.Sprintf("tx-%d-%d", height, index)),
+				Result: abci.ResponseDeliverTx{Events: events},
+			}))
+		}
+		require.NoError(t, env.BlockIndexer.Index(types.EventDataNewBlockHeader{
+			Header:         types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{Events: events},
+		}))
+	}
+	return env
+}
+
+func TestTxSearchCursor(t *testing.T) {
+	env := newSearchTestEnv(t)
+	perPage := 5
+
+	for _, orderBy := range []string{"asc", "desc"} {
+		var (
+			txs    []*abci.TxResult
+			cursor string
+		)
+		for {
+			res, err := env.TxSearch(&rpctypes.Context{}, "app.key='v'", false, nil, &perPage, orderBy, cursor)
+			require.NoError(t, err)
+			assert.Equal(t, 12, res.TotalCount)
+			for _, tx := range res.Txs {
+				txs = append(txs, &abci.TxResult{Height: tx.Height, Index: tx.Index})
+			}
+			if res.NextCursor == "" {
+				break
+			}
+			cursor = res.NextCursor
+		}
+
+		require.Len(t, txs, 12, orderBy)
+		for i, tx := range txs {
+			expected := &abci.TxResult{Height: int64(i/3 + 1), Index: uint32(i % 3)}
+			if orderBy == "desc" {
+				expected = &abci.TxResult{Height: int64(4 - i/3), Index: uint32(2 - i%3)}
+			}
+			assert.Equal(t, expected, tx, "%s #%d", orderBy, i)
+		}
+	}
+
+	page := 1
+	_, err := env.TxSearch(&rpctypes.Context{}, "app.key='v'", false, &page, nil, "asc",
+		searchCursor{Height: 1}.String())
+	assert.Error(t, err, "page and cursor")
+	_, err = env.TxSearch(&rpctypes.Context{}, "app.key='v'", false, nil, nil, "asc", "invalid")
+	assert.Error(t, err)
+}
+
+func TestBlockSearchCursor(t *testing.T) {
+	env := newSearchTestEnv(t)
+	perPage := 3
+
+	res, err := env.BlockSearch(&rpctypes.Context{}, "app.key='v'", nil, &perPage, "desc", "")
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.TotalCount)
+	require.NotEmpty(t, res.NextCursor)
+	assert.Equal(t, searchCursor{Height: 2}.String(), res.NextCursor)
+
+	res, err = env.BlockSearch(&rpctypes.Context{}, "app.key='v'", nil, &perPage, "desc", res.NextCursor)
+	require.NoError(t, err)
+	assert.Empty(t, res.NextCursor)
+}
+
+func TestSearchLimits(t *testing.T) {
+	env := newSearchTestEnv(t)
+
+	env.Config.MaxSearchResults = 11
+	_, err := env.TxSearch(&rpctypes.Context{}, "app.key='v'", false, nil, nil, "asc", "")
+	assert.Error(t, err)
+	_, err = env.BlockSearch(&rpctypes.Context{}, "app.key='v'", nil, nil, "asc", "")
+	assert.NoError(t, err)
+
+	env.Config.MaxSearchResults = 0
+	env.Config.MaxSearchScannedEntries = 10
+	_, err = env.TxSearch(&rpctypes.Context{}, "app.key='v'", false, nil, nil, "asc", "")
+	assert.Error(t, err)
+	_, err = env.BlockSearch(&rpctypes.Context{}, "app.key='v'", nil, nil, "asc", "")
+	assert.NoError(t, err)
+}
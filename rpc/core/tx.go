@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"sort"
 
-	cmtmath "github.com/cometbft/cometbft/libs/math"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	mempl "github.com/cometbft/cometbft/mempool"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	"github.com/cometbft/cometbft/state/txindex/null"
@@ -48,8 +50,63 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*cty
 	}, nil
 }
 
+// mempoolWithTxStatus is implemented by the mempools which can tell whether a
+// transaction is pending, or was evicted.
+type mempoolWithTxStatus interface {
+	HasTx(txKey types.TxKey) bool
+	EvictedTx(txKey types.TxKey) (mempl.EvictedTx, bool)
+}
+
+// TxStatus returns the status of a transaction: committed, with its result,
+// pending in the mempool, evicted from it without being committed, or
+// unknown, e.g. if it failed CheckTx, or was evicted long ago. Unlike
+// broadcast_tx_commit, it holds no event subscription, so clients can
+// broadcast their transactions with broadcast_tx_sync and poll their status.
+// The committed transactions are only known if the transactions are indexed.
+// More: https://docs.cometbft.com/main/rpc/#/Info/tx_status
+func (env *Environment) TxStatus(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	if len(hash) != tmhash.Size {
+		return nil, fmt.Errorf("invalid tx hash length %d, expected %d", len(hash), tmhash.Size)
+	}
+
+	if _, ok := env.TxIndexer.(*null.TxIndex); !ok {
+		r, err := env.TxIndexer.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			return &ctypes.ResultTxStatus{
+				Hash:     hash,
+				Status:   ctypes.TxStatusCommitted,
+				Height:   r.Height,
+				Index:    r.Index,
+				TxResult: &r.Result,
+			}, nil
+		}
+	}
+
+	if mempool, ok := env.Mempool.(mempoolWithTxStatus); ok {
+		var key types.TxKey
+		copy(key[:], hash)
+		if mempool.HasTx(key) {
+			return &ctypes.ResultTxStatus{Hash: hash, Status: ctypes.TxStatusPending}, nil
+		}
+		if evicted, ok := mempool.EvictedTx(key); ok {
+			return &ctypes.ResultTxStatus{
+				Hash:           hash,
+				Status:         ctypes.TxStatusEvicted,
+				Height:         evicted.Height,
+				EvictionReason: string(evicted.Reason),
+			}, nil
+		}
+	}
+
+	return &ctypes.ResultTxStatus{Hash: hash, Status: ctypes.TxStatusUnknown}, nil
+}
+
 // TxSearch allows you to query for multiple transactions results. It returns a
-// list of transactions (maximum ?per_page entries) and the total count.
+// list of transactions (maximum ?per_page entries) and the total count, either
+// on the ?page or after the ?cursor returned with the previous page.
 // More: https://docs.cometbft.com/main/rpc/#/Info/tx_search
 func (env *Environment) TxSearch(
 	ctx *rpctypes.Context,
@@ -57,6 +114,7 @@ func (env *Environment) TxSearch(
 	prove bool,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	cursor string,
 ) (*ctypes.ResultTxSearch, error) {
 
 	// if index is disabled, return error
@@ -71,12 +129,18 @@ func (env *Environment) TxSearch(
 		return nil, err
 	}
 
-	results, err := env.TxIndexer.Search(ctx.Context(), q)
+	searchCtx, limit := env.searchContext(ctx.Context())
+	defer limit.Release()
+	results, err := env.TxIndexer.Search(searchCtx, q)
 	if err != nil {
 		return nil, err
 	}
+	if err := env.checkSearchLimits(limit, len(results)); err != nil {
+		return nil, err
+	}
 
 	// sort results (must be done before pagination)
+	var before func(r *abci.TxResult, c searchCursor) bool
 	switch orderBy {
 	case "desc":
 		sort.Slice(results, func(i, j int) bool {
@@ -85,6 +149,9 @@ func (env *Environment) TxSearch(
 			}
 			return results[i].Height > results[j].Height
 		})
+		before = func(r *abci.TxResult, c searchCursor) bool {
+			return r.Height > c.Height || (r.Height == c.Height && r.Index >= c.Index)
+		}
 	case "asc", "":
 		sort.Slice(results, func(i, j int) bool {
 			if results[i].Height == results[j].Height {
@@ -92,22 +159,23 @@ func (env *Environment) TxSearch(
 			}
 			return results[i].Height < results[j].Height
 		})
+		before = func(r *abci.TxResult, c searchCursor) bool {
+			return r.Height < c.Height || (r.Height == c.Height && r.Index <= c.Index)
+		}
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
 
 	// paginate results
 	totalCount := len(results)
-	perPage := env.validatePerPage(perPagePtr)
-
-	page, err := validatePage(pagePtr, perPage, totalCount)
+	skipCount, pageSize, err := env.searchPage(pagePtr, perPagePtr, cursor, totalCount,
+		func(c searchCursor) int {
+			return sort.Search(totalCount, func(i int) bool { return !before(results[i], c) })
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := cmtmath.MinInt(perPage, totalCount-skipCount)
-
 	apiResults := make([]*ctypes.ResultTx, 0, pageSize)
 	for i := skipCount; i < skipCount+pageSize; i++ {
 		r := results[i]
@@ -128,5 +196,11 @@ func (env *Environment) TxSearch(
 		})
 	}
 
-	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+	var nextCursor string
+	if end := skipCount + pageSize; end < totalCount && pageSize > 0 {
+		last := results[end-1]
+		nextCursor = searchCursor{Height: last.Height, Index: last.Index}.String()
+	}
+
+	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount, NextCursor: nextCursor}, nil
 }
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	mempl "github.com/cometbft/cometbft/mempool"
+	"github.com/cometbft/cometbft/proxy"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxStatus(t *testing.T) {
+	client := abcicli.NewLocalClient(nil, kvstore.NewApplication())
+	require.NoError(t, client.Start())
+	t.Cleanup(func() { _ = client.Stop() })
+	mempool := mempl.NewCListMempool(config.TestMempoolConfig(), proxy.NewAppConnMempool(client, proxy.NopMetrics()), 0)
+	env := &Environment{
+		TxIndexer: kv.NewTxIndex(dbm.NewMemDB()),
+		Mempool:   mempool,
+	}
+	ctx := &rpctypes.Context{}
+
+	committed := types.Tx("committed=1")
+	require.NoError(t, env.TxIndexer.Index(&abci.TxResult{
+		Height: 3,
+		Index:  1,
+		Tx:     committed,
+		Result: abci.ResponseDeliverTx{Code: 5},
+	}))
+	res, err := env.TxStatus(ctx, committed.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, ctypes.TxStatusCommitted, res.Status)
+	assert.EqualValues(t, 3, res.Height)
+	assert.EqualValues(t, 1, res.Index)
+	assert.EqualValues(t, 5, res.TxResult.Code)
+
+	pending := types.Tx("pending=1")
+	require.NoError(t, mempool.CheckTx(pending, nil, mempl.TxInfo{}))
+	res, err = env.TxStatus(ctx, pending.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, ctypes.TxStatusPending, res.Status)
+
+	require.NoError(t, mempool.RemoveTxByKey(pending.Key()))
+	res, err = env.TxStatus(ctx, pending.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, ctypes.TxStatusEvicted, res.Status)
+	assert.Equal(t, string(mempl.EvictionReasonRemoved), res.EvictionReason)
+
+	res, err = env.TxStatus(ctx, types.Tx("unknown=1").Hash())
+	require.NoError(t, err)
+	assert.Equal(t, ctypes.TxStatusUnknown, res.Status)
+
+	_, err = env.TxStatus(ctx, []byte("too short"))
+	assert.Error(t, err)
+}
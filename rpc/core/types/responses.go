@@ -8,6 +8,7 @@ import (
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
 )
@@ -18,6 +19,14 @@ type ResultBlockchainInfo struct {
 	BlockMetas []*types.BlockMeta `json:"block_metas"`
 }
 
+// Block retain heights
+type ResultBlockRetainHeight struct {
+	Base                 int64 `json:"base"`
+	Height               int64 `json:"height"`
+	RetainHeight         int64 `json:"retain_height"`
+	OperatorRetainHeight int64 `json:"operator_retain_height"`
+}
+
 // Genesis file
 type ResultGenesis struct {
 	Genesis *types.GenesisDoc `json:"genesis"`
@@ -129,6 +138,60 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Settings changed by a reload of the configuration
+type ResultReloadConfig struct {
+	Changed []string `json:"changed"`
+}
+
+// Log level of the node
+type ResultLogLevel struct {
+	LogLevel string `json:"log_level"`
+}
+
+// Directory and files of a debug snapshot
+type ResultDebugSnapshot struct {
+	Dir   string   `json:"dir"`
+	Files []string `json:"files"`
+}
+
+// A backup of the data directory, in progress unless Done
+type ResultBackup struct {
+	Height    int64     `json:"height"`
+	Dir       string    `json:"dir"`
+	StartTime time.Time `json:"start_time"`
+	Done      bool      `json:"done"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Halt height and time of the node
+type ResultHalt struct {
+	HaltHeight int64     `json:"halt_height"`
+	HaltTime   time.Time `json:"halt_time"`
+}
+
+// Block committed by +2/3 of the validators whose app hash disagrees with the
+// one of the node, upon which the consensus halted
+type ResultAppHashDivergence struct {
+	Diverged       bool           `json:"diverged"`
+	Height         int64          `json:"height"`
+	Round          int32          `json:"round"`
+	BlockID        types.BlockID  `json:"block_id"`
+	AppHash        bytes.HexBytes `json:"app_hash"`
+	BlockAppHash   bytes.HexBytes `json:"block_app_hash"`
+	DiagnosticsDir string         `json:"diagnostics_dir"`
+	Time           time.Time      `json:"time"`
+}
+
+// Exported address book
+type ResultExportAddrBook struct {
+	AddrBook *pex.AddrBookExport `json:"addr_book"`
+}
+
+// Number of addresses imported in the address book
+type ResultImportAddrBook struct {
+	Imported int `json:"imported"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -171,6 +234,21 @@ type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 }
 
+// Timing of the rounds of the last committed heights
+type ResultConsensusRoundHistory struct {
+	History json.RawMessage `json:"history"`
+}
+
+// Trace of the consensus WAL over a range of heights
+type ResultConsensusTrace struct {
+	Trace json.RawMessage `json:"trace"`
+}
+
+// Timeline of the propagation of the block parts and votes of a round
+type ResultPropagationTrace struct {
+	Trace json.RawMessage `json:"trace"`
+}
+
 // CheckTx result
 type ResultBroadcastTx struct {
 	Code      uint32         `json:"code"`
@@ -204,16 +282,39 @@ type ResultTx struct {
 	Proof    types.TxProof          `json:"proof,omitempty"`
 }
 
+// The statuses of a tx returned by tx_status.
+const (
+	TxStatusCommitted = "committed"
+	TxStatusPending   = "pending"
+	TxStatusEvicted   = "evicted"
+	TxStatusUnknown   = "unknown"
+)
+
+// Result of querying the status of a tx. Height is the height of the block
+// which committed the tx, or the last height committed when it was evicted.
+type ResultTxStatus struct {
+	Hash           bytes.HexBytes          `json:"hash"`
+	Status         string                  `json:"status"`
+	Height         int64                   `json:"height,omitempty"`
+	Index          uint32                  `json:"index,omitempty"`
+	TxResult       *abci.ResponseDeliverTx `json:"tx_result,omitempty"`
+	EvictionReason string                  `json:"eviction_reason,omitempty"`
+}
+
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
 	TotalCount int         `json:"total_count"`
+	// Cursor to get the next page with, if any
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
 type ResultBlockSearch struct {
 	Blocks     []*ResultBlock `json:"blocks"`
 	TotalCount int            `json:"total_count"`
+	// Cursor to get the next page with, if any
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // List of mempool txs
@@ -239,18 +340,48 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// List of pending evidence
+type ResultEvidence struct {
+	Evidence   []types.Evidence `json:"evidence"`
+	TotalCount int              `json:"total_count"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
 )
 
-// Event data from a subscription
+// Node health. The readiness of the node is only reported when requested.
+type ResultHealth struct {
+	Ready      bool `json:"ready,omitempty"`
+	CatchingUp bool `json:"catching_up,omitempty"`
+	NumPeers   int  `json:"n_peers,omitempty"`
+}
+
+// Event data from a subscription, or from the event log
 type ResultEvent struct {
 	Query  string              `json:"query"`
 	Data   types.TMEventData   `json:"data"`
 	Events map[string][]string `json:"events"`
+	// Sequence number of the event, to pass as the resume parameter of
+	// /subscribe when resubscribing. The events of the event log have their
+	// own sequence numbers, to pass as the after parameter of /events.
+	Sequence uint64 `json:"sequence"`
+}
+
+// Events recorded in the event log
+type ResultEvents struct {
+	Events []*ResultEvent `json:"events"`
+	// Sequence numbers of the oldest and newest events of the log
+	First uint64 `json:"first"`
+	Last  uint64 `json:"last"`
+}
+
+// Sequence number of the last event of the event log processed by a consumer
+type ResultEventLogOffset struct {
+	Consumer string `json:"consumer"`
+	Sequence uint64 `json:"sequence"`
 }
@@ -2,10 +2,16 @@ package coregrpc
 
 import (
 	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	core "github.com/cometbft/cometbft/rpc/core"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
 )
 
 type broadcastAPI struct {
@@ -38,3 +44,118 @@ func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcast
 		},
 	}, nil
 }
+
+func (bapi *broadcastAPI) BroadcastEvidence(
+	ctx context.Context,
+	req *RequestBroadcastEvidence,
+) (*ResponseBroadcastEvidence, error) {
+	if req.Evidence == nil {
+		return nil, status.Error(codes.InvalidArgument, "no evidence was provided")
+	}
+	ev, err := types.EvidenceFromProto(req.Evidence)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, err := bapi.env.BroadcastEvidence(&rpctypes.Context{}, ev)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseBroadcastEvidence{Hash: res.Hash}, nil
+}
+
+type blockAPI struct {
+	env *core.Environment
+}
+
+// LightBlock returns the light block at the requested height, or the latest
+// one if the height is 0. Heights above the latest block are reported with
+// codes.OutOfRange, and heights that are not available with codes.NotFound.
+func (bapi *blockAPI) LightBlock(ctx context.Context, req *RequestLightBlock) (*ResponseLightBlock, error) {
+	blockStore := bapi.env.BlockStore
+	height := req.Height
+	switch {
+	case height < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "expected height >= 0, got height %d", height)
+	case height == 0:
+		height = blockStore.Height()
+	case height > blockStore.Height():
+		return nil, status.Errorf(codes.OutOfRange,
+			"height %d must be less than or equal to the current blockchain height %d", height, blockStore.Height())
+	}
+	if height == 0 || height < blockStore.Base() {
+		return nil, status.Errorf(codes.NotFound, "height %d is not available, lowest height is %d",
+			height, blockStore.Base())
+	}
+
+	commit, err := bapi.env.Commit(&rpctypes.Context{}, &height)
+	if err != nil {
+		return nil, err
+	}
+	if commit == nil || commit.Commit == nil {
+		return nil, status.Errorf(codes.NotFound, "commit for height %d is not available", height)
+	}
+	vals, err := bapi.env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "validators for height %d are not available: %v", height, err)
+	}
+
+	lb := &types.LightBlock{SignedHeader: &commit.SignedHeader, ValidatorSet: vals}
+	pb, err := lb.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseLightBlock{LightBlock: pb}, nil
+}
+
+type blockResultsService struct {
+	env *core.Environment
+}
+
+// GetBlockResults returns the results of the block with the requested hash, or
+// at the requested height, or of the latest block if neither is set. Heights
+// above the latest block are reported with codes.OutOfRange, and results that
+// are not available, e.g. pruned, with codes.NotFound. The results discarded
+// from the state store (storage.discard_abci_responses) are reported with
+// codes.FailedPrecondition.
+func (s *blockResultsService) GetBlockResults(
+	ctx context.Context,
+	req *RequestBlockResults,
+) (*ResponseBlockResults, error) {
+	// the results of a block are saved along with the state
+	state, err := s.env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	height := req.Height
+	switch {
+	case len(req.Hash) > 0:
+		height = s.env.BlockStore.LoadBlockHeightByHash(req.Hash)
+		if height == 0 {
+			return nil, status.Errorf(codes.NotFound, "block with hash %X not found", req.Hash)
+		}
+	case height < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "expected height >= 0, got height %d", height)
+	case height == 0:
+		height = state.LastBlockHeight
+	}
+	if height > state.LastBlockHeight {
+		return nil, status.Errorf(codes.OutOfRange,
+			"height %d must be less than or equal to the last executed height %d", height, state.LastBlockHeight)
+	}
+
+	results, err := s.env.StateStore.LoadABCIResponses(height)
+	if errors.Is(err, sm.ErrABCIResponsesNotPersisted) {
+		return nil, status.Errorf(codes.FailedPrecondition, "results of block %d are not available: %v", height, err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.NotFound, "results of block %d are not available: %v", height, err)
+	}
+
+	return &ResponseBlockResults{
+		Height:                height,
+		TxsResults:            results.DeliverTxs,
+		BeginBlockEvents:      results.BeginBlock.GetEvents(),
+		EndBlockEvents:        results.EndBlock.GetEvents(),
+		ValidatorUpdates:      results.EndBlock.GetValidatorUpdates(),
+		ConsensusParamUpdates: results.EndBlock.GetConsensusParamUpdates(),
+	}, nil
+}
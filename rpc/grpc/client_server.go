@@ -15,12 +15,16 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
+// StartGRPCServer starts a new gRPC server serving the BroadcastAPI, the
+// BlockAPI, the StreamAPI and the BlockResultsService using the given
 // net.Listener.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func StartGRPCServer(env *core.Environment, ln net.Listener) error {
 	grpcServer := grpc.NewServer()
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{env: env})
+	RegisterBlockAPIServer(grpcServer, &blockAPI{env: env})
+	RegisterStreamAPIServer(grpcServer, &streamAPI{env: env})
+	RegisterBlockResultsServiceServer(grpcServer, &blockResultsService{env: env})
 	return grpcServer.Serve(ln)
 }
 
@@ -35,6 +39,39 @@ func StartGRPCClient(protoAddr string) BroadcastAPIClient {
 	return NewBroadcastAPIClient(conn)
 }
 
+// StartGRPCBlockClient dials the gRPC server using protoAddr and returns a new
+// BlockAPIClient.
+func StartGRPCBlockClient(protoAddr string) BlockAPIClient {
+	//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		panic(err)
+	}
+	return NewBlockAPIClient(conn)
+}
+
+// StartGRPCStreamClient dials the gRPC server using protoAddr and returns a new
+// StreamAPIClient.
+func StartGRPCStreamClient(protoAddr string) StreamAPIClient {
+	//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		panic(err)
+	}
+	return NewStreamAPIClient(conn)
+}
+
+// StartGRPCBlockResultsClient dials the gRPC server using protoAddr and
+// returns a new BlockResultsServiceClient.
+func StartGRPCBlockResultsClient(protoAddr string) BlockResultsServiceClient {
+	//nolint: staticcheck // SA1019 Existing use of deprecated but supported dial option.
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		panic(err)
+	}
+	return NewBlockResultsServiceClient(conn)
+}
+
 func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
 	return cmtnet.Connect(addr)
 }
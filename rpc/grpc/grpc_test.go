@@ -4,12 +4,16 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	core_grpc "github.com/cometbft/cometbft/rpc/grpc"
 	rpctest "github.com/cometbft/cometbft/rpc/test"
+	"github.com/cometbft/cometbft/types"
 )
 
 func TestMain(m *testing.M) {
@@ -33,3 +37,101 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestLightBlock(t *testing.T) {
+	client := rpctest.GetGRPCBlockClient()
+
+	res, err := client.LightBlock(context.Background(), &core_grpc.RequestLightBlock{Height: 1})
+	require.NoError(t, err)
+	lb, err := types.LightBlockFromProto(res.LightBlock)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, lb.Height)
+	require.NoError(t, lb.ValidateBasic(lb.ChainID))
+
+	res, err = client.LightBlock(context.Background(), &core_grpc.RequestLightBlock{})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, res.LightBlock.SignedHeader.Header.Height, int64(1))
+
+	_, err = client.LightBlock(context.Background(), &core_grpc.RequestLightBlock{Height: 1 << 40})
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestStreamBlocks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	stream, err := rpctest.GetGRPCStreamClient().StreamBlocks(ctx, &core_grpc.RequestStreamBlocks{FromHeight: 1})
+	require.NoError(t, err)
+
+	// the blocks already committed are replayed, followed by the new ones
+	for height := int64(1); height <= 5; height++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		block, err := types.BlockFromProto(res.Block)
+		require.NoError(t, err)
+		require.EqualValues(t, height, block.Height)
+		blockID, err := types.BlockIDFromProto(res.BlockId)
+		require.NoError(t, err)
+		require.Equal(t, block.Hash(), blockID.Hash)
+	}
+
+	stream, err = rpctest.GetGRPCStreamClient().StreamBlocks(ctx, &core_grpc.RequestStreamBlocks{FromHeight: -1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStreamTxEvents(t *testing.T) {
+	client := rpctest.GetGRPCClient()
+	_, err := client.BroadcastTx(context.Background(), &core_grpc.RequestBroadcastTx{Tx: []byte("stream=first")})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	stream, err := rpctest.GetGRPCStreamClient().StreamTxEvents(ctx, &core_grpc.RequestStreamTxEvents{
+		Query:      "app.key='stream'",
+		FromHeight: 1,
+	})
+	require.NoError(t, err)
+
+	// replayed
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("stream=first"), res.TxResult.Tx)
+
+	// committed after the replay
+	_, err = client.BroadcastTx(context.Background(), &core_grpc.RequestBroadcastTx{Tx: []byte("stream=second")})
+	require.NoError(t, err)
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("stream=second"), res.TxResult.Tx)
+	require.EqualValues(t, 0, res.TxResult.Result.Code)
+
+	stream, err = rpctest.GetGRPCStreamClient().StreamTxEvents(ctx, &core_grpc.RequestStreamTxEvents{Query: "app.key="})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetBlockResults(t *testing.T) {
+	client := rpctest.GetGRPCBlockResultsClient()
+	ctx := context.Background()
+
+	res, err := client.GetBlockResults(ctx, &core_grpc.RequestBlockResults{Height: 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.Height)
+
+	res, err = client.GetBlockResults(ctx, &core_grpc.RequestBlockResults{})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, res.Height, int64(1))
+
+	lb, err := rpctest.GetGRPCBlockClient().LightBlock(ctx, &core_grpc.RequestLightBlock{Height: 1})
+	require.NoError(t, err)
+	res, err = client.GetBlockResults(ctx, &core_grpc.RequestBlockResults{Hash: lb.LightBlock.SignedHeader.Commit.BlockID.Hash})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.Height)
+
+	_, err = client.GetBlockResults(ctx, &core_grpc.RequestBlockResults{Height: 1 << 40})
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	_, err = client.GetBlockResults(ctx, &core_grpc.RequestBlockResults{Hash: []byte("nonexistent")})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,197 @@
+package coregrpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	core "github.com/cometbft/cometbft/rpc/core"
+	"github.com/cometbft/cometbft/types"
+)
+
+// The streams subscribe to the new block headers only to be notified of the
+// new blocks, which they load from the stores, so few notifications need to
+// be buffered.
+const streamSubscriptionCapacity = 100
+
+// streamSubscribers numbers the subscribers of the streams to the event bus.
+var streamSubscribers uint64
+
+type streamAPI struct {
+	env *core.Environment
+}
+
+// StreamBlocks streams the blocks from the requested height, replaying the
+// ones already committed from the block store. Heights pruned from the store
+// are reported with codes.NotFound.
+func (sapi *streamAPI) StreamBlocks(req *RequestStreamBlocks, stream StreamAPI_StreamBlocksServer) error {
+	blockStore := sapi.env.BlockStore
+	lastHeight := func() (int64, error) {
+		return blockStore.Height(), nil
+	}
+	return sapi.stream(stream.Context(), req.FromHeight, lastHeight, func(height int64) error {
+		block := blockStore.LoadBlock(height)
+		blockMeta := blockStore.LoadBlockMeta(height)
+		if block == nil || blockMeta == nil {
+			return status.Errorf(codes.NotFound, "block %d is not available", height)
+		}
+		pb, err := block.ToProto()
+		if err != nil {
+			return err
+		}
+		blockID := blockMeta.BlockID.ToProto()
+		return stream.Send(&ResponseStreamBlocks{Block: pb, BlockId: &blockID})
+	})
+}
+
+// StreamTxEvents streams the results of the txs matching the query from the
+// requested height, replaying the ones already committed from the block and
+// state stores. The results discarded from the state store
+// (storage.discard_abci_responses) are reported with
+// codes.FailedPrecondition.
+func (sapi *streamAPI) StreamTxEvents(req *RequestStreamTxEvents, stream StreamAPI_StreamTxEventsServer) error {
+	q, err := cmtquery.New(req.Query)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse query: %v", err)
+	}
+
+	// the results of a block are saved along with the state
+	lastHeight := func() (int64, error) {
+		state, err := sapi.env.StateStore.Load()
+		if err != nil {
+			return 0, err
+		}
+		return state.LastBlockHeight, nil
+	}
+	return sapi.stream(stream.Context(), req.FromHeight, lastHeight, func(height int64) error {
+		block := sapi.env.BlockStore.LoadBlock(height)
+		if block == nil {
+			return status.Errorf(codes.NotFound, "block %d is not available", height)
+		}
+		responses, err := sapi.loadABCIResponses(height)
+		if err != nil {
+			return err
+		}
+		if len(responses.DeliverTxs) != len(block.Txs) {
+			return fmt.Errorf("block %d has %d txs but %d results", height, len(block.Txs), len(responses.DeliverTxs))
+		}
+
+		for i, tx := range block.Txs {
+			txResult := abci.TxResult{
+				Height: height,
+				Index:  uint32(i),
+				Tx:     tx,
+				Result: *responses.DeliverTxs[i],
+			}
+			match, err := q.Matches(types.TxEvents(types.EventDataTx{TxResult: txResult}))
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "failed to match query: %v", err)
+			}
+			if !match {
+				continue
+			}
+			if err := stream.Send(&ResponseStreamTxEvents{TxResult: &txResult}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// loadABCIResponses loads the results of the block at the height, which are
+// only kept for the last block if the results are discarded.
+func (sapi *streamAPI) loadABCIResponses(height int64) (*cmtstate.ABCIResponses, error) {
+	responses, err := sapi.env.StateStore.LoadABCIResponses(height)
+	if err == nil {
+		return responses, nil
+	}
+	responses, lastErr := sapi.env.StateStore.LoadLastABCIResponse(height)
+	if lastErr != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "results of block %d are not available: %v", height, err)
+	}
+	return responses, nil
+}
+
+// stream calls send with the heights from fromHeight, or the one after the
+// last height if 0, up to the last height returned by lastHeight, then with
+// the heights committed afterwards, until the context is done or send fails.
+// The new blocks are waited for with a subscription to the event bus.
+func (sapi *streamAPI) stream(
+	ctx context.Context,
+	fromHeight int64,
+	lastHeight func() (int64, error),
+	send func(height int64) error,
+) error {
+	env := sapi.env
+	if fromHeight < 0 {
+		return status.Errorf(codes.InvalidArgument, "expected from_height >= 0, got %d", fromHeight)
+	}
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return status.Errorf(codes.ResourceExhausted, "max_subscription_clients %d reached",
+			env.Config.MaxSubscriptionClients)
+	}
+
+	// subscribed before getting the last height not to miss any block
+	subscriber := fmt.Sprintf("grpc-stream-%d", atomic.AddUint64(&streamSubscribers, 1))
+	sub, err := env.EventBus.Subscribe(ctx, subscriber, types.EventQueryNewBlockHeader, streamSubscriptionCapacity)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := env.EventBus.UnsubscribeAll(context.Background(), subscriber); err != nil {
+			env.Logger.Debug("Failed to unsubscribe stream", "subscriber", subscriber, "err", err)
+		}
+	}()
+
+	last, err := lastHeight()
+	if err != nil {
+		return err
+	}
+	next := fromHeight
+	if next == 0 {
+		next = last + 1
+	}
+	if base := env.BlockStore.Base(); next <= last && next < base {
+		return status.Errorf(codes.NotFound, "height %d is not available, lowest height is %d", next, base)
+	}
+
+	for {
+		for ; next <= last; next++ {
+			// the blocks notified meanwhile are sent by this loop
+			drainNotifications(sub)
+			if err := send(next); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-sub.Out():
+		case <-sub.Canceled():
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return status.Errorf(codes.Unavailable, "subscription was canceled: %v", sub.Err())
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		if last, err = lastHeight(); err != nil {
+			return err
+		}
+	}
+}
+
+func drainNotifications(sub types.Subscription) {
+	for {
+		select {
+		case <-sub.Out():
+		default:
+			return
+		}
+	}
+}
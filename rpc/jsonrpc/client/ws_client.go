@@ -532,6 +532,14 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeFrom subscribes to a query, receiving first the events published
+// after the one with the given sequence number. Note the server must have a
+// "subscribe" route defined.
+func (c *WSClient) SubscribeFrom(ctx context.Context, query string, after uint64) error {
+	params := map[string]interface{}{"query": query, "resume": after}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
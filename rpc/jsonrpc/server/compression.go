@@ -0,0 +1,123 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The response compression algorithms, named after their Content-Encoding.
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} {
+		return gzip.NewWriter(nil)
+	}}
+	zstdWriters = sync.Pool{New: func() interface{} {
+		w, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			panic(err)
+		}
+		return w
+	}}
+)
+
+// compressionHandler compresses the responses of the handler with the first
+// of the algorithms, in order of preference, the client accepts. WebSocket
+// upgrades are never compressed.
+func compressionHandler(h http.Handler, algorithms []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		algorithm := negotiateCompression(r, algorithms)
+		if algorithm == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		var cw io.WriteCloser
+		switch algorithm {
+		case CompressionGzip:
+			gw := gzipWriters.Get().(*gzip.Writer)
+			gw.Reset(w)
+			defer gzipWriters.Put(gw)
+			cw = gw
+		case CompressionZstd:
+			zw := zstdWriters.Get().(*zstd.Encoder)
+			zw.Reset(w)
+			defer zstdWriters.Put(zw)
+			cw = zw
+		}
+
+		w.Header().Set("Content-Encoding", algorithm)
+		w.Header().Add("Vary", "Accept-Encoding")
+		crw := &compressedResponseWriter{ResponseWriter: w, w: cw}
+		h.ServeHTTP(crw, r)
+		// an error can no longer be reported to the client
+		_ = cw.Close()
+	})
+}
+
+// negotiateCompression returns the first of the algorithms the client
+// accepts, or "" if none.
+func negotiateCompression(r *http.Request, algorithms []string) string {
+	if len(algorithms) == 0 || r.Header.Get("Upgrade") != "" {
+		return ""
+	}
+	accepted := make(map[string]struct{})
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(enc, ";")
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		refused := false
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				refused = err != nil || q == 0
+			}
+		}
+		if name != "" && !refused {
+			accepted[name] = struct{}{}
+		}
+	}
+	for _, algorithm := range algorithms {
+		if _, ok := accepted[algorithm]; ok {
+			return algorithm
+		}
+	}
+	return ""
+}
+
+// compressedResponseWriter writes the body of the response through the
+// compressing writer.
+type compressedResponseWriter struct {
+	http.ResponseWriter
+	w io.Writer
+}
+
+func (w *compressedResponseWriter) WriteHeader(code int) {
+	// the length of the body set by the handler is the uncompressed one
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *compressedResponseWriter) Write(b []byte) (int, error) {
+	w.Header().Del("Content-Length")
+	return w.w.Write(b)
+}
+
+// Flush implements http.Flusher.
+func (w *compressedResponseWriter) Flush() {
+	if f, ok := w.w.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
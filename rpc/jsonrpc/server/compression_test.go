@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	algorithms := []string{CompressionZstd, CompressionGzip}
+	testCases := []struct {
+		acceptEncoding string
+		upgrade        string
+		want           string
+	}{
+		{"", "", ""},
+		{"gzip", "", CompressionGzip},
+		{"gzip, deflate, zstd", "", CompressionZstd},
+		{"zstd;q=0, gzip;q=0.5", "", CompressionGzip},
+		{"br", "", ""},
+		{"gzip", "websocket", ""},
+	}
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		r.Header.Set("Upgrade", tc.upgrade)
+		assert.Equal(t, tc.want, negotiateCompression(r, algorithms), tc.acceptEncoding)
+	}
+	assert.Equal(t, "", negotiateCompression(httptest.NewRequest(http.MethodGet, "/", nil), nil))
+}
+
+func TestCompressionHandler(t *testing.T) {
+	body := strings.Repeat(`{"jsonrpc":"2.0","id":-1,"result":{}}`, 100)
+	h := compressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "3700")
+		_, _ = w.Write([]byte(body))
+	}), []string{CompressionZstd, CompressionGzip})
+
+	for _, algorithm := range []string{CompressionGzip, CompressionZstd} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", algorithm)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		assert.Equal(t, algorithm, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Header().Get("Content-Length"))
+		assert.Less(t, rec.Body.Len(), len(body))
+
+		var dec io.Reader
+		if algorithm == CompressionGzip {
+			gr, err := gzip.NewReader(rec.Body)
+			require.NoError(t, err)
+			dec = gr
+		} else {
+			zr, err := zstd.NewReader(rec.Body)
+			require.NoError(t, err)
+			defer zr.Close()
+			dec = zr
+		}
+		bz, err := io.ReadAll(dec)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(bz))
+	}
+
+	// not compressed if not accepted
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, rec.Body.String())
+}
+
+func TestServeH2C(t *testing.T) {
+	listener, err := Listen("tcp://127.0.0.1:0", 0)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+	config := DefaultConfig()
+	config.H2C = true
+	go func() {
+		_ = Serve(listener, mux, log.TestingLogger(), config)
+	}()
+
+	// HTTP/2 with prior knowledge
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	res, err := client.Get("http://" + listener.Addr().String())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	bz, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", string(bz))
+
+	// HTTP/1.1 is still served
+	res, err = http.Get("http://" + listener.Addr().String())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	bz, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1", string(bz))
+}
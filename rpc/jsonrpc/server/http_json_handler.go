@@ -3,11 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"runtime/debug"
 	"sort"
+	"sync"
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/libs/log"
@@ -17,7 +20,7 @@ import (
 // HTTP + JSON handler
 
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger, config handlerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -39,8 +42,8 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 
 		// first try to unmarshal the incoming request as an array of RPC requests
 		var (
-			requests  []types.RPCRequest
-			responses []types.RPCResponse
+			requests []types.RPCRequest
+			batch    = true
 		)
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
@@ -53,6 +56,14 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				return
 			}
 			requests = []types.RPCRequest{request}
+			batch = false
+		}
+		if len(requests) == 0 {
+			res := types.RPCInvalidRequestError(nil, errors.New("empty batch"))
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusBadRequest, res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
+			}
+			return
 		}
 
 		// Set the default response cache to true unless
@@ -60,6 +71,9 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		// 2. Any RPC request doesn't allow to be cached.
 		// 3. Any RPC request has the height argument and the value is 0 (the default).
 		cache := true
+		responses := make([]types.RPCResponse, 0, len(requests))
+		// the calls of the functions, filling in their responses
+		calls := make([]func(), 0, len(requests))
 		for _, request := range requests {
 			request := request
 
@@ -86,6 +100,18 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				cache = false
 				continue
 			}
+			if err := config.rateLimiter.allowRequest(r, rpcFunc); err != nil {
+				if !batch {
+					res := types.RPCServerError(request.ID, err)
+					if wErr := WriteRPCResponseHTTPError(w, rateLimitStatus(err), res); wErr != nil {
+						logger.Error("failed to write response", "res", res, "err", wErr)
+					}
+					return
+				}
+				responses = append(responses, types.RPCServerError(request.ID, err))
+				cache = false
+				continue
+			}
 			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
@@ -105,21 +131,26 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				cache = false
 			}
 
-			returns := rpcFunc.f.Call(args)
-			result, err := unreflectResult(returns)
-			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
-				continue
-			}
-			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
+			i := len(responses)
+			responses = append(responses, types.RPCResponse{})
+			calls = append(calls, func() {
+				responses[i] = callRPCFunc(rpcFunc, args, request, logger)
+			})
 		}
 
+		// the responses are in the order of the requests regardless
+		runConcurrently(calls, config.batchConcurrency)
+
 		if len(responses) > 0 {
-			var wErr error
+			headers := []httpHeader{}
 			if cache {
-				wErr = WriteCacheableRPCResponseHTTP(w, responses...)
+				headers = append(headers, cacheControlHeader)
+			}
+			var wErr error
+			if batch {
+				wErr = writeJSONHTTP(w, headers, responses)
 			} else {
-				wErr = WriteRPCResponseHTTP(w, responses...)
+				wErr = writeRPCResponseHTTP(w, headers, responses...)
 			}
 			if wErr != nil {
 				logger.Error("failed to write responses", "res", responses, "err", wErr)
@@ -128,6 +159,54 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 	}
 }
 
+// callRPCFunc calls the function with the args, returning the response to the
+// request. A panic is returned as an internal error, the function
+// being possibly called from another goroutine than the handler's.
+func callRPCFunc(
+	rpcFunc *RPCFunc, args []reflect.Value, request types.RPCRequest, logger log.Logger,
+) (res types.RPCResponse) {
+	defer func() {
+		if e := recover(); e != nil {
+			logger.Error("Panic in RPC function", "err", e, "stack", string(debug.Stack()))
+			res = types.RPCInternalError(request.ID, fmt.Errorf("panic in RPC function: %v", e))
+		}
+	}()
+
+	returns := rpcFunc.f.Call(args)
+	result, err := unreflectResult(returns)
+	if err != nil {
+		return types.RPCInternalError(request.ID, err)
+	}
+	return types.NewRPCSuccessResponse(request.ID, result)
+}
+
+// runConcurrently runs the functions, up to concurrency of them at a time, and
+// returns once they are all done.
+func runConcurrently(fns []func(), concurrency int) {
+	if concurrency <= 1 || len(fns) <= 1 {
+		for _, fn := range fns {
+			fn()
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, fn := range fns {
+		fn := fn
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn()
+		}()
+	}
+	wg.Wait()
+}
+
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Since the pattern "/" matches all paths not matched by other registered patterns,
@@ -170,12 +249,15 @@ func arrayParamsToArgs(
 	params []json.RawMessage,
 	argsOffset int,
 ) ([]reflect.Value, error) {
-	if len(rpcFunc.argNames) != len(params) {
+	if len(rpcFunc.argNames) < len(params) || !optionalArgs(rpcFunc, len(params)+argsOffset) {
 		return nil, fmt.Errorf("expected %v parameters (%v), got %v (%v)",
 			len(rpcFunc.argNames), rpcFunc.argNames, len(params), params)
 	}
 
-	values := make([]reflect.Value, len(params))
+	values := make([]reflect.Value, len(rpcFunc.argNames))
+	for i := len(params); i < len(values); i++ {
+		values[i] = reflect.Zero(rpcFunc.args[i+argsOffset])
+	}
 	for i, p := range params {
 		argType := rpcFunc.args[i+argsOffset]
 		val := reflect.New(argType)
@@ -188,6 +270,18 @@ func arrayParamsToArgs(
 	return values, nil
 }
 
+// optionalArgs returns true if all the arguments of the function from the
+// given index are pointers, which can be omitted from array parameters so
+// that optional arguments can be appended to existing functions.
+func optionalArgs(rpcFunc *RPCFunc, from int) bool {
+	for _, arg := range rpcFunc.args[from:] {
+		if arg.Kind() != reflect.Ptr {
+			return false
+		}
+	}
+	return true
+}
+
 // raw is unparsed json (from json.RawMessage) encoding either a map or an
 // array.
 //
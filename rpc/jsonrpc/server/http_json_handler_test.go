@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -217,6 +219,75 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatch(t *testing.T) {
+	var running, maxRunning int32
+	funcMap := map[string]*RPCFunc{
+		"sleep": NewRPCFunc(func(ctx *types.Context, ms int) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(ms) * time.Millisecond)
+			return ms, nil
+		}, "ms"),
+		"panic": NewRPCFunc(func(ctx *types.Context) (string, error) { panic("oops") }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), BatchConcurrency(2))
+
+	post := func(payload string) (*http.Response, []byte) {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		blob, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res, blob
+	}
+
+	// the responses are in the order of the requests, the later ones
+	// finishing first
+	res, blob := post(`[
+		{"jsonrpc": "2.0","method":"sleep","id":0,"params":["40"]},
+		{"jsonrpc": "2.0","method":"sleep","id":1,"params":["30"]},
+		{"jsonrpc": "2.0","method":"panic","id":2},
+		{"jsonrpc": "2.0","method":"sleep","id":3,"params":["10"]},
+		{"jsonrpc": "2.0","method":"sleep","id":4,"params":["0"]}
+	]`)
+	require.True(t, statusOK(res.StatusCode))
+	var responses []types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &responses), string(blob))
+	require.Len(t, responses, 5)
+	for i, response := range responses {
+		assert.Equal(t, types.JSONRPCIntID(i), response.ID)
+		if i == 2 {
+			require.NotNil(t, response.Error)
+			assert.Contains(t, response.Error.Data, "oops")
+			continue
+		}
+		require.Nil(t, response.Error, "#%d", i)
+	}
+	assert.Equal(t, `"40"`, string(responses[0].Result))
+	assert.Equal(t, `"0"`, string(responses[4].Result))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&maxRunning))
+
+	// a batch of one request is responded to with an array
+	_, blob = post(`[{"jsonrpc": "2.0","method":"sleep","id":0,"params":["0"]}]`)
+	require.NoError(t, json.Unmarshal(blob, &responses), string(blob))
+	require.Len(t, responses, 1)
+
+	res, blob = post(`[]`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	var response types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &response), string(blob))
+	require.NotNil(t, response.Error)
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
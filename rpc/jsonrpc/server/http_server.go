@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/net/netutil"
 
 	"github.com/cometbft/cometbft/libs/log"
@@ -32,6 +34,13 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// Compression is the list of algorithms, in order of preference, the
+	// responses are compressed with if the client accepts one of them. See
+	// CompressionGzip and CompressionZstd.
+	Compression []string
+	// H2C enables HTTP/2 over cleartext TCP, by prior knowledge or upgrade.
+	// HTTP/2 is always enabled over TLS.
+	H2C bool
 }
 
 // DefaultConfig returns a default configuration.
@@ -46,14 +55,19 @@ func DefaultConfig() *Config {
 }
 
 // Serve creates a http.Server and calls Serve with the given listener. It
-// wraps handler with RecoverAndLogHandler and a handler, which limits the max
-// body size to config.MaxBodyBytes.
+// wraps handler with RecoverAndLogHandler, a handler, which limits the max
+// body size to config.MaxBodyBytes, and a handler compressing the responses
+// with config.Compression. If config.H2C is set, HTTP/2 is served as well.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func Serve(listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info("serve", "msg", log.NewLazySprintf("Starting RPC HTTP server on %s", listener.Addr()))
+	h := serverHandler(handler, logger, config)
+	if config.H2C {
+		h = h2c.NewHandler(h, &http2.Server{})
+	}
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           h,
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
@@ -79,7 +93,7 @@ func ServeTLS(
 	logger.Info("serve tls", "msg", log.NewLazySprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           serverHandler(handler, logger, config),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
@@ -91,6 +105,17 @@ func ServeTLS(
 	return err
 }
 
+// serverHandler wraps handler with RecoverAndLogHandler, a handler which
+// limits the max body size to config.MaxBodyBytes and, if enabled, a handler
+// compressing the responses.
+func serverHandler(handler http.Handler, logger log.Logger, config *Config) http.Handler {
+	h := http.Handler(maxBytesHandler{h: handler, n: config.MaxBodyBytes})
+	if len(config.Compression) > 0 {
+		h = compressionHandler(h, config.Compression)
+	}
+	return RecoverAndLogHandler(h, logger)
+}
+
 // WriteRPCResponseHTTPError marshals res as JSON (with indent) and writes it
 // to w.
 //
@@ -124,7 +149,7 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error
 // it to w. Adds cache-control to the response header and sets the expiry to
 // one day.
 func WriteCacheableRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error {
-	return writeRPCResponseHTTP(w, []httpHeader{{"Cache-Control", "public, max-age=86400"}}, res...)
+	return writeRPCResponseHTTP(w, []httpHeader{cacheControlHeader}, res...)
 }
 
 type httpHeader struct {
@@ -132,6 +157,10 @@ type httpHeader struct {
 	value string
 }
 
+var cacheControlHeader = httpHeader{"Cache-Control", "public, max-age=86400"}
+
+// writeRPCResponseHTTP writes a single response as an object, and several as
+// an array.
 func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...types.RPCResponse) error {
 	var v interface{}
 	if len(res) == 1 {
@@ -139,7 +168,10 @@ func writeRPCResponseHTTP(w http.ResponseWriter, headers []httpHeader, res ...ty
 	} else {
 		v = res
 	}
+	return writeJSONHTTP(w, headers, v)
+}
 
+func writeJSONHTTP(w http.ResponseWriter, headers []httpHeader, v interface{}) error {
 	jsonBytes, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("json marshal: %w", err)
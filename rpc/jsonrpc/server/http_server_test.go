@@ -153,3 +153,25 @@ func TestWriteRPCResponseHTTPError(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 	assert.Equal(t, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"foo"}}`, string(body))
 }
+
+func TestHTTPStatusError(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"ready": NewRPCFunc(func(ctx *types.Context) (*sampleResult, error) {
+			return nil, types.HTTPStatusError{Status: http.StatusServiceUnavailable, Err: errors.New("not ready")}
+		}, ""),
+		"fail": NewRPCFunc(func(ctx *types.Context) (*sampleResult, error) {
+			return nil, errors.New("failed")
+		}, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+
+	for path, status := range map[string]int{
+		"/ready": http.StatusServiceUnavailable,
+		"/fail":  http.StatusInternalServerError,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, status, rec.Code, path)
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -18,7 +19,11 @@ import (
 var reInt = regexp.MustCompile(`^-?[0-9]+$`)
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(
+	rpcFunc *RPCFunc,
+	logger log.Logger,
+	config handlerConfig,
+) func(http.ResponseWriter, *http.Request) {
 	// Always return -1 as there's no ID here.
 	dummyID := types.JSONRPCIntID(-1) // URIClientRequestID
 
@@ -36,6 +41,14 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", r)
 
+		if err := config.rateLimiter.allowRequest(r, rpcFunc); err != nil {
+			res := types.RPCServerError(dummyID, err)
+			if wErr := WriteRPCResponseHTTPError(w, rateLimitStatus(err), res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
+			}
+			return
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
@@ -56,7 +69,12 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
 		if err != nil {
-			if err := WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
+			status := http.StatusInternalServerError
+			var statusErr types.HTTPStatusError
+			if errors.As(err, &statusErr) {
+				status = statusErr.Status
+			}
+			if err := WriteRPCResponseHTTPError(w, status,
 				types.RPCInternalError(dummyID, err)); err != nil {
 				logger.Error("failed to write response", "res", result, "err", err)
 				return
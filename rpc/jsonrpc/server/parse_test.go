@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/libs/bytes"
 	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
@@ -170,6 +171,28 @@ func TestParseJSONRPC(t *testing.T) {
 	}
 }
 
+func TestParseJSONRPCOptionalArgs(t *testing.T) {
+	demo := func(ctx *types.Context, name string, height *int64) {}
+	call := NewRPCFunc(demo, "name,height")
+
+	// trailing pointer arguments can be omitted from arrays
+	vals, err := jsonParamsToArgs(call, []byte(`["flew"]`))
+	require.NoError(t, err)
+	if assert.Equal(t, 2, len(vals)) {
+		assert.Equal(t, "flew", vals[0].String())
+		assert.True(t, vals[1].IsNil())
+	}
+	vals, err = jsonParamsToArgs(call, []byte(`["flew", "7"]`))
+	require.NoError(t, err)
+	if assert.Equal(t, 2, len(vals)) {
+		assert.EqualValues(t, 7, vals[1].Elem().Int())
+	}
+
+	// but not the others
+	_, err = jsonParamsToArgs(call, []byte(`[]`))
+	assert.Error(t, err)
+}
+
 func TestParseURI(t *testing.T) {
 	demo := func(ctx *types.Context, height int, name string) {}
 	call := NewRPCFunc(demo, "height,name")
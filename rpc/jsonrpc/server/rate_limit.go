@@ -0,0 +1,263 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+const (
+	// DefaultRouteClass is the class of the routes without a RouteClass
+	// option.
+	DefaultRouteClass = "default"
+
+	// APIKeyHeader is the HTTP header carrying the API key of a client.
+	APIKeyHeader = "X-Api-Key"
+
+	// idle buckets are swept at most once per rateLimitSweepPeriod
+	rateLimitSweepPeriod = time.Minute
+)
+
+var (
+	// ErrRateLimited is returned when a client exceeds the rate limit of the
+	// class of the route it calls.
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	// ErrUnknownAPIKey is returned when a client presents an API key that is
+	// not in the API keys file.
+	ErrUnknownAPIKey = errors.New("unknown API key")
+
+	// ErrAdminOnly is returned when a client without an admin API key calls an
+	// AdminOnly route.
+	ErrAdminOnly = errors.New("the route requires an admin API key")
+)
+
+// RateLimit is the number of requests per second a client may make to the
+// routes of a class, with bursts of up to Burst requests.
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+// APIKey identifies a client, rate limited on its own rather than by its IP
+// address, or not at all if Unlimited is set. Only the clients with an Admin
+// key may call the AdminOnly routes.
+type APIKey struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Unlimited bool   `json:"unlimited"`
+	Admin     bool   `json:"admin"`
+}
+
+// LoadAPIKeys reads the API keys from a JSON file holding an array of APIKey.
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(bz, &keys); err != nil {
+		return nil, fmt.Errorf("error reading API keys file %s: %w", path, err)
+	}
+	for i, key := range keys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("API key #%d of %s is empty", i, path)
+		}
+	}
+	return keys, nil
+}
+
+// RateLimiter limits the rate of the requests of each client, identified by
+// its API key or else its IP address, per route class. The routes of a class
+// without a limit are not rate limited. It is safe for concurrent use.
+type RateLimiter struct {
+	mtx       cmtsync.Mutex
+	limits    map[string]RateLimit
+	keys      map[string]APIKey
+	buckets   map[bucketID]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucketID struct {
+	class  string
+	client string
+}
+
+// rateLimitedClient is the identity a client is rate limited by.
+type rateLimitedClient struct {
+	id        string
+	unlimited bool
+	admin     bool
+}
+
+// NewRateLimiter returns a RateLimiter enforcing the limits, by route class,
+// and recognizing the API keys.
+func NewRateLimiter(limits map[string]RateLimit, keys []APIKey) *RateLimiter {
+	rl := &RateLimiter{now: time.Now}
+	rl.SetLimits(limits, keys)
+	return rl
+}
+
+// SetLimits replaces the limits and the API keys of the RateLimiter, e.g. upon
+// a reload of the configuration. The rates of the clients are reset.
+func (rl *RateLimiter) SetLimits(limits map[string]RateLimit, keys []APIKey) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.limits = limits
+	rl.keys = make(map[string]APIKey, len(keys))
+	for _, key := range keys {
+		rl.keys[key.Key] = key
+	}
+	rl.buckets = make(map[bucketID]*tokenBucket)
+}
+
+// HasAPIKeys reports whether keys are the API keys of the RateLimiter.
+func (rl *RateLimiter) HasAPIKeys(keys []APIKey) bool {
+	byKey := make(map[string]APIKey, len(keys))
+	for _, key := range keys {
+		byKey[key.Key] = key
+	}
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	return maps.Equal(rl.keys, byKey)
+}
+
+// client returns the identity of the client making the request, or
+// ErrUnknownAPIKey. The API keys are ignored if none are configured.
+func (rl *RateLimiter) client(r *http.Request) (rateLimitedClient, error) {
+	if k := r.Header.Get(APIKeyHeader); k != "" {
+		rl.mtx.Lock()
+		key, ok := rl.keys[k]
+		noKeys := len(rl.keys) == 0
+		rl.mtx.Unlock()
+		switch {
+		case ok:
+			return rateLimitedClient{id: "key:" + key.Key, unlimited: key.Unlimited, admin: key.Admin}, nil
+		case !noKeys:
+			return rateLimitedClient{}, ErrUnknownAPIKey
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return rateLimitedClient{id: "ip:" + host}, nil
+}
+
+// allow consumes a request of the client to a route of the class, returning
+// false if the client exceeded the rate limit of the class.
+func (rl *RateLimiter) allow(c rateLimitedClient, class string) bool {
+	if c.unlimited {
+		return true
+	}
+
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	limit, ok := rl.limits[class]
+	if !ok {
+		return true
+	}
+
+	now := rl.now()
+	if now.Sub(rl.lastSweep) >= rateLimitSweepPeriod {
+		rl.sweep(now)
+	}
+
+	id := bucketID{class: class, client: c.id}
+	b, ok := rl.buckets[id]
+	if !ok {
+		b = newTokenBucket(limit, now)
+		rl.buckets[id] = b
+	}
+	return b.take(now)
+}
+
+// sweep removes the buckets which are full again, their clients having been
+// idle for long enough.
+func (rl *RateLimiter) sweep(now time.Time) {
+	for id, b := range rl.buckets {
+		if b.full(now) {
+			delete(rl.buckets, id)
+		}
+	}
+	rl.lastSweep = now
+}
+
+// allowRequest returns nil if the request to the function is allowed, and
+// otherwise an error to answer it with. The AdminOnly functions are never
+// allowed without a RateLimiter.
+func (rl *RateLimiter) allowRequest(r *http.Request, rpcFunc *RPCFunc) error {
+	if rl == nil {
+		if rpcFunc.adminOnly {
+			return ErrAdminOnly
+		}
+		return nil
+	}
+	c, err := rl.client(r)
+	if err != nil {
+		return err
+	}
+	if rpcFunc.adminOnly && !c.admin {
+		return ErrAdminOnly
+	}
+	if !rl.allow(c, rpcFunc.class()) {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// rateLimitStatus returns the HTTP status code of the error of allowRequest.
+func rateLimitStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUnknownAPIKey):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrAdminOnly):
+		return http.StatusForbidden
+	}
+	return http.StatusTooManyRequests
+}
+
+// tokenBucket holds up to burst tokens, refilled at rps tokens per second.
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.RPS
+	if b.tokens > float64(b.limit.Burst) {
+		b.tokens = float64(b.limit.Burst)
+	}
+	b.last = now
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	b.refill(now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= float64(b.limit.Burst)
+}
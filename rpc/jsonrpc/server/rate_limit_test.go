@@ -0,0 +1,169 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	types "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	rl := NewRateLimiter(
+		map[string]RateLimit{DefaultRouteClass: {RPS: 1, Burst: 2}},
+		[]APIKey{{Key: "internal", Unlimited: true}},
+	)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	alice := rateLimitedClient{id: "ip:1.2.3.4"}
+	bob := rateLimitedClient{id: "ip:5.6.7.8"}
+	internal := rateLimitedClient{id: "key:internal", unlimited: true}
+
+	// the burst is allowed, then one request per second
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.False(t, rl.allow(alice, DefaultRouteClass))
+	now = now.Add(time.Second)
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.False(t, rl.allow(alice, DefaultRouteClass))
+
+	// clients have their own buckets
+	assert.True(t, rl.allow(bob, DefaultRouteClass))
+
+	// classes without a limit, and unlimited clients, are not rate limited
+	for i := 0; i < 10; i++ {
+		assert.True(t, rl.allow(alice, "search"))
+		assert.True(t, rl.allow(internal, DefaultRouteClass))
+	}
+
+	// idle clients' buckets are swept
+	now = now.Add(rateLimitSweepPeriod)
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.Len(t, rl.buckets, 1)
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	rl := NewRateLimiter(nil, []APIKey{{Key: "partner"}})
+
+	r := httptest.NewRequest(http.MethodGet, "/status", nil)
+	r.RemoteAddr = "1.2.3.4:5678"
+	c, err := rl.client(r)
+	require.NoError(t, err)
+	assert.Equal(t, rateLimitedClient{id: "ip:1.2.3.4"}, c)
+
+	r.Header.Set(APIKeyHeader, "partner")
+	c, err = rl.client(r)
+	require.NoError(t, err)
+	assert.Equal(t, rateLimitedClient{id: "key:partner"}, c)
+
+	r.Header.Set(APIKeyHeader, "unknown")
+	_, err = rl.client(r)
+	assert.Equal(t, ErrUnknownAPIKey, err)
+
+	// the API keys are ignored if none are configured
+	rl.SetLimits(nil, nil)
+	c, err = rl.client(r)
+	require.NoError(t, err)
+	assert.Equal(t, rateLimitedClient{id: "ip:1.2.3.4"}, c)
+}
+
+func TestRateLimiterSetLimits(t *testing.T) {
+	rl := NewRateLimiter(map[string]RateLimit{DefaultRouteClass: {RPS: 1, Burst: 1}}, nil)
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	alice := rateLimitedClient{id: "ip:1.2.3.4"}
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.False(t, rl.allow(alice, DefaultRouteClass))
+
+	// the rates are reset along with the limits
+	rl.SetLimits(map[string]RateLimit{DefaultRouteClass: {RPS: 1, Burst: 2}}, nil)
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+	assert.False(t, rl.allow(alice, DefaultRouteClass))
+
+	rl.SetLimits(nil, nil)
+	assert.True(t, rl.allow(alice, DefaultRouteClass))
+}
+
+func TestRateLimitedHandlers(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"status": NewRPCFunc(func(ctx *types.Context) (string, error) { return "ok", nil }, ""),
+	}
+	rl := NewRateLimiter(map[string]RateLimit{DefaultRouteClass: {RPS: 0.001, Burst: 1}}, nil)
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)), RateLimited(rl))
+
+	get := func(ip string) int {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	post := func(ip string) int {
+		body := strings.NewReader(`{"jsonrpc": "2.0", "method": "status", "id": 0}`)
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("1.2.3.4"))
+	assert.Equal(t, http.StatusTooManyRequests, get("1.2.3.4"))
+	assert.Equal(t, http.StatusTooManyRequests, post("1.2.3.4"))
+	assert.Equal(t, http.StatusOK, post("5.6.7.8"))
+}
+
+func TestAdminOnlyHandlers(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"reload": NewRPCFunc(func(ctx *types.Context) (string, error) { return "ok", nil }, "", AdminOnly()),
+	}
+	get := func(mux *http.ServeMux, key string) int {
+		req := httptest.NewRequest(http.MethodGet, "/reload", nil)
+		if key != "" {
+			req.Header.Set(APIKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	rl := NewRateLimiter(nil, []APIKey{{Key: "operator", Admin: true}, {Key: "partner"}})
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)), RateLimited(rl))
+	assert.Equal(t, http.StatusOK, get(mux, "operator"))
+	assert.Equal(t, http.StatusForbidden, get(mux, "partner"))
+	assert.Equal(t, http.StatusForbidden, get(mux, ""))
+
+	// without a rate limiter, nobody is an admin
+	mux = http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewTMLogger(new(bytes.Buffer)))
+	assert.Equal(t, http.StatusForbidden, get(mux, "operator"))
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	err := os.WriteFile(path, []byte(`[{"key": "k1", "name": "indexer", "unlimited": true}, {"key": "k2"}]`), 0o600)
+	require.NoError(t, err)
+
+	keys, err := LoadAPIKeys(path)
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{{Key: "k1", Name: "indexer", Unlimited: true}, {Key: "k2"}}, keys)
+
+	err = os.WriteFile(path, []byte(`[{"name": "indexer"}]`), 0o600)
+	require.NoError(t, err)
+	_, err = LoadAPIKeys(path)
+	assert.Error(t, err)
+}
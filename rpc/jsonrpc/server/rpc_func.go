@@ -13,14 +13,44 @@ import (
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
 // every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger, opts ...HandlerOption) {
+	config := handlerConfig{batchConcurrency: 1}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger, config))
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger, config)))
+}
+
+// HandlerOption configures the handlers registered by RegisterRPCFuncs.
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	batchConcurrency int
+	rateLimiter      *RateLimiter
+}
+
+// BatchConcurrency sets the maximum number of requests of a JSON-RPC batch
+// executed concurrently, 1 (sequentially) by default. The responses are in the
+// order of the requests regardless.
+func BatchConcurrency(n int) HandlerOption {
+	return func(c *handlerConfig) {
+		c.batchConcurrency = n
+	}
+}
+
+// RateLimited rate limits the requests of the clients of the handlers with
+// the RateLimiter, which may be shared with a WebsocketManager.
+func RateLimited(rl *RateLimiter) HandlerOption {
+	return func(c *handlerConfig) {
+		c.rateLimiter = rl
+	}
 }
 
 type Option func(*RPCFunc)
@@ -41,6 +71,23 @@ func Cacheable(noCacheDefArgs ...string) Option {
 	}
 }
 
+// RouteClass sets the class of the route, whose requests are rate limited
+// together (see RateLimiter). Routes are of the DefaultRouteClass otherwise.
+func RouteClass(class string) Option {
+	return func(r *RPCFunc) {
+		r.routeClass = class
+	}
+}
+
+// AdminOnly restricts the route to the clients presenting an API key with
+// admin set (see RateLimiter). The route can't be called if the handlers are
+// not RateLimited.
+func AdminOnly() Option {
+	return func(r *RPCFunc) {
+		r.adminOnly = true
+	}
+}
+
 // Ws enables WebSocket communication.
 func Ws() Option {
 	return func(r *RPCFunc) {
@@ -56,6 +103,8 @@ type RPCFunc struct {
 	argNames       []string               // name of each argument
 	cacheable      bool                   // enable cache control
 	ws             bool                   // enable websocket communication
+	routeClass     string                 // class of the route for rate limiting
+	adminOnly      bool                   // only callable with an admin API key
 	noCacheDefArgs map[string]interface{} // a lookup table of args that, if not supplied or are set to default values, cause us to not cache
 }
 
@@ -71,6 +120,14 @@ func NewWSRPCFunc(f interface{}, args string, options ...Option) *RPCFunc {
 	return newRPCFunc(f, args, options...)
 }
 
+// class returns the class of the route for rate limiting.
+func (f *RPCFunc) class() string {
+	if f.routeClass == "" {
+		return DefaultRouteClass
+	}
+	return f.routeClass
+}
+
 // cacheableWithArgs returns whether or not a call to this function is cacheable,
 // given the specified arguments.
 func (f *RPCFunc) cacheableWithArgs(args []reflect.Value) bool {
@@ -143,6 +200,9 @@ func funcReturnTypes(f interface{}) []reflect.Type {
 func unreflectResult(returns []reflect.Value) (interface{}, error) {
 	errV := returns[1]
 	if errV.Interface() != nil {
+		if err, ok := errV.Interface().(error); ok {
+			return nil, err
+		}
 		return nil, fmt.Errorf("%v", errV.Interface())
 	}
 	rv := returns[0]
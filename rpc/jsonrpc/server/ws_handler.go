@@ -34,6 +34,7 @@ type WebsocketManager struct {
 
 	funcMap       map[string]*RPCFunc
 	logger        log.Logger
+	rateLimiter   *RateLimiter
 	wsConnOptions []func(*wsConnection)
 }
 
@@ -69,9 +70,26 @@ func (wm *WebsocketManager) SetLogger(l log.Logger) {
 	wm.logger = l
 }
 
+// SetRateLimiter rate limits the requests of the clients with the
+// RateLimiter, identifying them by the API key or the IP address of their
+// connection's upgrade request.
+func (wm *WebsocketManager) SetRateLimiter(rl *RateLimiter) {
+	wm.rateLimiter = rl
+}
+
 // WebsocketHandler upgrades the request/response (via http.Hijack) and starts
 // the wsConnection.
 func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	var client rateLimitedClient
+	if wm.rateLimiter != nil {
+		var err error
+		client, err = wm.rateLimiter.client(r)
+		if err != nil {
+			http.Error(w, err.Error(), rateLimitStatus(err))
+			return
+		}
+	}
+
 	wsConn, err := wm.Upgrade(w, r, nil)
 	if err != nil {
 		// TODO - return http error
@@ -86,6 +104,8 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.rateLimiter = wm.rateLimiter
+	con.client = client
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -118,6 +138,10 @@ type wsConnection struct {
 
 	funcMap map[string]*RPCFunc
 
+	// rate limiter of the client's requests, if any
+	rateLimiter *RateLimiter
+	client      rateLimitedClient
+
 	// write channel capacity
 	writeChanCapacity int
 
@@ -363,6 +387,19 @@ func (wsc *wsConnection) readRoutine() {
 				continue
 			}
 
+			if rpcFunc.adminOnly && !wsc.client.admin {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, ErrAdminOnly)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+			if wsc.rateLimiter != nil && !wsc.rateLimiter.allow(wsc.client, rpcFunc.class()) {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, ErrRateLimited)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
 			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
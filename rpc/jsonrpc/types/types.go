@@ -214,6 +214,22 @@ func (resp RPCResponse) String() string {
 	return fmt.Sprintf("RPCResponse{%s %v}", resp.ID, resp.Error)
 }
 
+// HTTPStatusError is an error returned by an RPC function which is answered
+// with the given HTTP status code, rather than 500, when the function is
+// called through its URI endpoint.
+type HTTPStatusError struct {
+	Status int
+	Err    error
+}
+
+func (e HTTPStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e HTTPStatusError) Unwrap() error {
+	return e.Err
+}
+
 // From the JSON-RPC 2.0 spec:
 //
 //	If there was an error in detecting the id in the Request object (e.g. Parse
@@ -116,6 +116,21 @@ func GetGRPCClient() core_grpc.BroadcastAPIClient {
 	return core_grpc.StartGRPCClient(grpcAddr)
 }
 
+func GetGRPCBlockClient() core_grpc.BlockAPIClient {
+	grpcAddr := globalConfig.RPC.GRPCListenAddress
+	return core_grpc.StartGRPCBlockClient(grpcAddr)
+}
+
+func GetGRPCStreamClient() core_grpc.StreamAPIClient {
+	grpcAddr := globalConfig.RPC.GRPCListenAddress
+	return core_grpc.StartGRPCStreamClient(grpcAddr)
+}
+
+func GetGRPCBlockResultsClient() core_grpc.BlockResultsServiceClient {
+	grpcAddr := globalConfig.RPC.GRPCListenAddress
+	return core_grpc.StartGRPCBlockResultsClient(grpcAddr)
+}
+
 // StartTendermint starts a test CometBFT server in a go routine and returns when it is initialized
 func StartTendermint(app abci.Application, opts ...func(*Options)) *nm.Node {
 	nodeOpts := defaultOptions
@@ -0,0 +1,318 @@
+/*
+Package seed runs a standalone seed node: a node which only crawls the network
+with the peer exchange protocol (PEX), saving the addresses of the peers it
+finds in its address book, and hands them out to the nodes connecting to it.
+
+Unlike a full node with p2p.seed_mode enabled, a Seed needs neither the state
+and block stores nor an ABCI application, just the chain ID of the network,
+the node key and the P2P configuration. It serves the crawl_status and health
+RPC endpoints on the address of the RPC configuration, if any.
+
+The lifecycle of a Seed is controlled by a context.Context
+
+	s, err := seed.New(cfg, nodeKey, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Run blocks until the context is canceled.
+	go s.Run(ctx)
+	...
+	cancel()
+*/
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	cmtstrings "github.com/cometbft/cometbft/libs/strings"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+const (
+	// DefaultMaxAddrsPerGroup is the default maximum number of addresses of a
+	// network group a seed hands out at once.
+	DefaultMaxAddrsPerGroup = 4
+
+	// A seed exchanges no blocks, so its peers can't become good by
+	// contributing them: it only keeps them long enough to get their
+	// addresses.
+	disconnectWaitPeriod = 10 * time.Minute
+)
+
+// Config is the configuration of a seed, a small subset of the configuration
+// of a node.
+type Config struct {
+	// Chain ID of the network to crawl
+	ChainID string
+	Moniker string
+	// Maximum number of addresses of a network group (the /16 for IPv4, the
+	// /32 for IPv6) handed out at once, 0 for no limit
+	MaxAddrsPerGroup int
+
+	// The seeds, persistent and bootstrap peers, address book, listen and
+	// external addresses, and connection settings are used, the seed mode is
+	// implied
+	P2P *config.P2PConfig
+	// The crawl status RPC is served on the listen address, if any
+	RPC *config.RPCConfig
+}
+
+// ConfigFromNodeConfig returns the configuration of a seed crawling the
+// network with the chain ID, taking the P2P and RPC settings of the node.
+func ConfigFromNodeConfig(cfg *config.Config, chainID string) *Config {
+	return &Config{
+		ChainID:          chainID,
+		Moniker:          cfg.Moniker,
+		MaxAddrsPerGroup: DefaultMaxAddrsPerGroup,
+		P2P:              cfg.P2P,
+		RPC:              cfg.RPC,
+	}
+}
+
+// ValidateBasic performs basic validation, checking the P2P and RPC
+// configurations as well.
+func (cfg *Config) ValidateBasic() error {
+	if cfg.ChainID == "" {
+		return errors.New("chain ID is required")
+	}
+	if cfg.MaxAddrsPerGroup < 0 {
+		return errors.New("max addresses per group can't be negative")
+	}
+	if cfg.P2P.Transport != config.P2PTransportTCP {
+		return fmt.Errorf("only the %q transport is supported", config.P2PTransportTCP)
+	}
+	if err := cfg.P2P.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [p2p] section: %w", err)
+	}
+	if err := cfg.RPC.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [rpc] section: %w", err)
+	}
+	return nil
+}
+
+// Seed crawls the network and hands out the addresses it finds.
+type Seed struct {
+	config  *Config
+	nodeKey *p2p.NodeKey
+	logger  log.Logger
+
+	transport  *p2p.MultiplexTransport
+	sw         *p2p.Switch
+	addrBook   pex.AddrBook
+	pexReactor *pex.Reactor
+}
+
+// New returns a seed with the configuration and node key. The caller is
+// responsible for running it.
+func New(cfg *Config, nodeKey *p2p.NodeKey, logger log.Logger) (*Seed, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := makeNodeInfo(cfg, nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := p2p.NewMultiplexTransport(nodeInfo, *nodeKey, p2p.MConnConfig(cfg.P2P))
+	if !cfg.P2P.AllowDuplicateIP {
+		p2p.MultiplexTransportConnFilters(p2p.ConnDuplicateIPFilter())(transport)
+	}
+	p2p.MultiplexTransportMaxIncomingConnections(cfg.P2P.MaxNumInboundPeers)(transport)
+
+	p2pLogger := logger.With("module", "p2p")
+	sw := p2p.NewSwitch(cfg.P2P, transport)
+	sw.SetLogger(p2pLogger)
+	sw.SetNodeInfo(nodeInfo)
+	sw.SetNodeKey(nodeKey)
+
+	if err := cmtos.EnsureDir(filepath.Dir(cfg.P2P.AddrBookFile()), 0o700); err != nil {
+		return nil, err
+	}
+	book := pex.NewAddrBook(cfg.P2P.AddrBookFile(), cfg.P2P.AddrBookStrict)
+	book.SetLogger(p2pLogger.With("book", cfg.P2P.AddrBookFile()))
+	// Add ourselves to the book to prevent dialing ourselves
+	for _, addr := range []string{cfg.P2P.ExternalAddress, cfg.P2P.ListenAddress} {
+		if addr == "" {
+			continue
+		}
+		netAddr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), addr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+		}
+		book.AddOurAddress(netAddr)
+	}
+	sw.SetAddrBook(book)
+
+	for _, addr := range cmtstrings.SplitAndTrimEmpty(cfg.P2P.BootstrapPeers, ",", " ") {
+		netAddr, err := p2p.NewNetAddressString(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bootstrap peer address: %w", err)
+		}
+		if err := book.AddAddress(netAddr, netAddr); err != nil {
+			return nil, fmt.Errorf("adding bootstrap address to addressbook: %w", err)
+		}
+	}
+
+	pexReactor := pex.NewReactor(book, &pex.ReactorConfig{
+		SeedMode:                     true,
+		Seeds:                        cmtstrings.SplitAndTrimEmpty(cfg.P2P.Seeds, ",", " "),
+		SeedDisconnectWaitPeriod:     disconnectWaitPeriod,
+		PersistentPeersMaxDialPeriod: cfg.P2P.PersistentPeersMaxDialPeriod,
+		MaxAddrsPerGroup:             cfg.MaxAddrsPerGroup,
+	})
+	pexReactor.SetLogger(logger.With("module", "pex"))
+	sw.AddReactor("PEX", pexReactor)
+
+	err = sw.AddPersistentPeers(cmtstrings.SplitAndTrimEmpty(cfg.P2P.PersistentPeers, ",", " "))
+	if err != nil {
+		return nil, fmt.Errorf("could not add peers from persistent_peers field: %w", err)
+	}
+
+	return &Seed{
+		config:     cfg,
+		nodeKey:    nodeKey,
+		logger:     logger,
+		transport:  transport,
+		sw:         sw,
+		addrBook:   book,
+		pexReactor: pexReactor,
+	}, nil
+}
+
+func makeNodeInfo(cfg *Config, nodeKey *p2p.NodeKey) (p2p.DefaultNodeInfo, error) {
+	listenAddr := cfg.P2P.ExternalAddress
+	if listenAddr == "" {
+		listenAddr = cfg.P2P.ListenAddress
+	}
+	nodeInfo := p2p.DefaultNodeInfo{
+		// the app version is not checked by the peers, only the block one
+		ProtocolVersion: p2p.NewProtocolVersion(version.P2PProtocol, version.BlockProtocol, 0),
+		DefaultNodeID:   nodeKey.ID(),
+		ListenAddr:      listenAddr,
+		Network:         cfg.ChainID,
+		Version:         version.TMCoreSemVer,
+		Channels:        []byte{pex.PexChannel},
+		Moniker:         cfg.Moniker,
+		Other: p2p.DefaultNodeInfoOther{
+			TxIndex:    "off",
+			RPCAddress: cfg.RPC.ListenAddress,
+		},
+	}
+	return nodeInfo, nodeInfo.Validate()
+}
+
+// CrawlStatus returns the progress of the crawling of the network.
+func (s *Seed) CrawlStatus() pex.CrawlStatus {
+	return s.pexReactor.CrawlStatus()
+}
+
+// Run starts the seed and blocks until the context is canceled, or the RPC
+// server fails.
+func (s *Seed) Run(ctx context.Context) error {
+	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(s.nodeKey.ID(), s.config.P2P.ListenAddress))
+	if err != nil {
+		return err
+	}
+	if err := s.transport.Listen(*addr); err != nil {
+		return err
+	}
+	defer s.transport.Close()
+
+	if err := s.sw.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := s.sw.Stop(); err != nil {
+			s.logger.Error("Error stopping switch", "err", err)
+		}
+		// Wait for the address book to be saved for the last time
+		s.addrBook.Wait()
+	}()
+	s.logger.Info("Seed started", "ID", s.nodeKey.ID(), "chain_id", s.config.ChainID,
+		"laddr", s.config.P2P.ListenAddress)
+
+	err = s.sw.DialPeersAsync(cmtstrings.SplitAndTrimEmpty(s.config.P2P.PersistentPeers, ",", " "))
+	if err != nil {
+		return fmt.Errorf("could not dial peers from persistent_peers field: %w", err)
+	}
+
+	return s.runRPCServers(ctx)
+}
+
+// runRPCServers serves the RPC on the listen addresses until the context is
+// canceled.
+func (s *Seed) runRPCServers(ctx context.Context) error {
+	mux := http.NewServeMux()
+	rpcLogger := s.logger.With("module", "rpc-server")
+	rpcserver.RegisterRPCFuncs(mux, s.routes(), rpcLogger)
+	rpcConfig := rpcserver.DefaultConfig()
+	rpcConfig.MaxBodyBytes = s.config.RPC.MaxBodyBytes
+	rpcConfig.MaxHeaderBytes = s.config.RPC.MaxHeaderBytes
+
+	listenAddrs := cmtstrings.SplitAndTrimEmpty(s.config.RPC.ListenAddress, ",", " ")
+	listeners := make([]net.Listener, 0, len(listenAddrs))
+	for _, listenAddr := range listenAddrs {
+		listener, err := rpcserver.Listen(listenAddr, s.config.RPC.MaxOpenConnections)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return err
+		}
+		listeners = append(listeners, listener)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		<-gctx.Done()
+		for _, l := range listeners {
+			l.Close()
+		}
+		return nil
+	})
+	for i, listener := range listeners {
+		listenAddr, listener := listenAddrs[i], listener
+		g.Go(func() error {
+			s.logger.Info("RPC HTTP server starting", "address", listenAddr)
+			err := rpcserver.Serve(listener, mux, rpcLogger, rpcConfig)
+			if !errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			s.logger.Info("RPC HTTP server stopped", "address", listenAddr)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func (s *Seed) routes() map[string]*rpcserver.RPCFunc {
+	return map[string]*rpcserver.RPCFunc{
+		"health":       rpcserver.NewRPCFunc(s.health, ""),
+		"crawl_status": rpcserver.NewRPCFunc(s.crawlStatus, ""),
+	}
+}
+
+func (s *Seed) health(*rpctypes.Context) (*ctypes.ResultHealth, error) {
+	return &ctypes.ResultHealth{}, nil
+}
+
+func (s *Seed) crawlStatus(*rpctypes.Context) (*pex.CrawlStatus, error) {
+	status := s.CrawlStatus()
+	return &status, nil
+}
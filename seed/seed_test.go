@@ -0,0 +1,103 @@
+package seed_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtnet "github.com/cometbft/cometbft/libs/net"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/pex"
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"github.com/cometbft/cometbft/seed"
+)
+
+type testSeed struct {
+	*seed.Seed
+	addr    string
+	rpcAddr string
+}
+
+func runTestSeed(t *testing.T, seeds string) *testSeed {
+	p2pPort, err := cmtnet.GetFreePort()
+	require.NoError(t, err)
+	rpcPort, err := cmtnet.GetFreePort()
+	require.NoError(t, err)
+
+	cfg := &seed.Config{
+		ChainID:          "test-chain",
+		Moniker:          "seed",
+		MaxAddrsPerGroup: seed.DefaultMaxAddrsPerGroup,
+		P2P:              config.TestP2PConfig(),
+		RPC:              config.TestRPCConfig(),
+	}
+	cfg.P2P.RootDir = t.TempDir()
+	cfg.P2P.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", p2pPort)
+	cfg.P2P.Seeds = seeds
+	cfg.P2P.AddrBookStrict = false
+	cfg.RPC.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", rpcPort)
+
+	nodeKey := &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	s, err := seed.New(cfg, nodeKey, log.TestingLogger())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	return &testSeed{
+		Seed:    s,
+		addr:    p2p.IDAddressString(nodeKey.ID(), fmt.Sprintf("127.0.0.1:%d", p2pPort)),
+		rpcAddr: cfg.RPC.ListenAddress,
+	}
+}
+
+func TestSeed(t *testing.T) {
+	// the first seed needs a seed to start, which it fails to dial
+	unreachable := p2p.IDAddressString(p2p.PubKeyToID(ed25519.GenPrivKey().PubKey()), "127.0.0.1:1")
+	first := runTestSeed(t, unreachable)
+	second := runTestSeed(t, first.addr)
+
+	// the first seed learns the address of the second one dialing it
+	assert.Eventually(t, func() bool {
+		return first.CrawlStatus().BookSize > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	client, err := rpcclient.New(second.rpcAddr)
+	require.NoError(t, err)
+	var status pex.CrawlStatus
+	_, err = client.Call(context.Background(), "crawl_status", map[string]interface{}{}, &status)
+	require.NoError(t, err)
+	assert.Equal(t, second.CrawlStatus().BookSize, status.BookSize)
+
+	var health map[string]interface{}
+	_, err = client.Call(context.Background(), "health", map[string]interface{}{}, &health)
+	require.NoError(t, err)
+}
+
+func TestConfigValidateBasic(t *testing.T) {
+	cfg := seed.ConfigFromNodeConfig(config.TestConfig(), "test-chain")
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.ChainID = ""
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = seed.ConfigFromNodeConfig(config.TestConfig(), "test-chain")
+	cfg.MaxAddrsPerGroup = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = seed.ConfigFromNodeConfig(config.TestConfig(), "test-chain")
+	cfg.P2P.Transport = config.P2PTransportQUIC
+	assert.Error(t, cfg.ValidateBasic())
+}
@@ -0,0 +1,125 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/protojson"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+const diagnosticsTimeFormat = "20060102T150405Z"
+
+// BlockExecutorWithDiagnosticsDir writes a diagnostics bundle into a new
+// directory of dir when ApplyBlock fails, e.g. upon an app hash mismatch, and
+// publishes an ApplyBlockFailure event. The error returned by ApplyBlock
+// gives the directory of the bundle, see ErrApplyBlockDiagnostics.
+func BlockExecutorWithDiagnosticsDir(dir string) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.diagnosticsDir = dir
+	}
+}
+
+// WriteDiagnostics writes, as ApplyBlock does, the diagnostics bundle of a
+// failure to apply the block to state with err, detected before calling
+// ApplyBlock, e.g. upon validating a block committed by +2/3 of the
+// validators. It returns err, as an ErrApplyBlockDiagnostics if the bundle was
+// written, and as is if no diagnostics directory is set.
+func (blockExec *BlockExecutor) WriteDiagnostics(state State, block *types.Block, err error) error {
+	if blockExec.diagnosticsDir == "" {
+		return err
+	}
+	return blockExec.writeDiagnostics(state, block, &applyDiagnostics{}, err)
+}
+
+// applyDiagnostics is what ApplyBlock computed before it failed.
+type applyDiagnostics struct {
+	abciResponses *cmtstate.ABCIResponses // nil if the block wasn't executed
+	nextState     *State                  // nil if the state wasn't updated
+}
+
+// writeDiagnostics writes the diagnostics bundle of the failure to apply the
+// block to state with err, publishes an ApplyBlockFailure event, and returns
+// err along with the directory of the bundle. If the bundle can't be written,
+// err is returned as is.
+//
+// The bundle holds the error, the block, the state before and, if it was
+// updated, after the block, the validator set, the ABCI responses of the block
+// if it was executed, and the ones of the previous block, which computed the
+// app hash the block is checked against.
+func (blockExec *BlockExecutor) writeDiagnostics(
+	state State, block *types.Block, diag *applyDiagnostics, err error,
+) error {
+	now := time.Now().UTC()
+	dir := filepath.Join(blockExec.diagnosticsDir,
+		fmt.Sprintf("%d-%s", block.Height, now.Format(diagnosticsTimeFormat)))
+	if werr := writeDiagnosticsBundle(dir, blockExec.store, state, block, diag, err); werr != nil {
+		blockExec.logger.Error("Failed to write the diagnostics bundle", "height", block.Height,
+			"dir", dir, "err", werr)
+		return err
+	}
+	blockExec.logger.Error("Failed to apply the block, wrote a diagnostics bundle", "height", block.Height,
+		"dir", dir, "err", err)
+
+	if perr := blockExec.eventBus.PublishEventApplyBlockFailure(types.EventDataApplyBlockFailure{
+		Height: block.Height,
+		Error:  err.Error(),
+		Dir:    dir,
+	}); perr != nil {
+		blockExec.logger.Error("Failed publishing the apply block failure", "err", perr)
+	}
+	return ErrApplyBlockDiagnostics{Err: err, Dir: dir}
+}
+
+func writeDiagnosticsBundle(
+	dir string, store Store, state State, block *types.Block, diag *applyDiagnostics, err error,
+) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	files := map[string]interface{}{
+		"block.json":      block,
+		"state.json":      state,
+		"validators.json": state.Validators,
+	}
+	if diag.nextState != nil {
+		files["next_state.json"] = diag.nextState
+	}
+	for name, v := range files {
+		bz, err := cmtjson.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), bz, 0o600); err != nil {
+			return err
+		}
+	}
+
+	responses := map[string]*cmtstate.ABCIResponses{
+		"abci_responses.json": diag.abciResponses,
+	}
+	if state.LastBlockHeight > 0 {
+		// the results may have been pruned, or not persisted
+		if last, err := store.LoadABCIResponses(state.LastBlockHeight); err == nil {
+			responses["last_abci_responses.json"] = last
+		}
+	}
+	for name, res := range responses {
+		if res == nil {
+			continue
+		}
+		bz, err := protojson.MarshalIndent(res, "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), bz, 0o600); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, "error.txt"), []byte(err.Error()+"\n"), 0o600)
+}
@@ -40,6 +40,15 @@ type (
 		Expected *State
 	}
 
+	// ErrAppHashMismatch is returned when validating a block whose app hash
+	// differs from the one of the state, that is, computed by the app after
+	// the previous block.
+	ErrAppHashMismatch struct {
+		Height   int64
+		Expected []byte
+		Got      []byte
+	}
+
 	ErrNoValSetForHeight struct {
 		Height int64
 	}
@@ -51,6 +60,13 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	// ErrApplyBlockDiagnostics is an error of ApplyBlock, whose diagnostics
+	// bundle was written into Dir.
+	ErrApplyBlockDiagnostics struct {
+		Err error
+		Dir string
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -91,6 +107,10 @@ func (e ErrStateMismatch) Error() string {
 	)
 }
 
+func (e ErrAppHashMismatch) Error() string {
+	return fmt.Sprintf("wrong Block.Header.AppHash.  Expected %X, got %X", e.Expected, e.Got)
+}
+
 func (e ErrNoValSetForHeight) Error() string {
 	return fmt.Sprintf("could not find validator set for height #%d", e.Height)
 }
@@ -103,4 +123,18 @@ func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
 
+func (e ErrApplyBlockDiagnostics) Error() string {
+	return fmt.Sprintf("%v (diagnostics bundle in %s)", e.Err, e.Dir)
+}
+
+func (e ErrApplyBlockDiagnostics) Unwrap() error {
+	return e.Err
+}
+
 var ErrABCIResponsesNotPersisted = errors.New("node is not persisting abci responses")
+
+var ErrCompactionNotSupported = errors.New("the database backend does not support compaction")
+
+// ErrHalted is returned by ApplyBlock once the node halted (see
+// BlockExecutor.SetHalt).
+var ErrHalted = errors.New("the node halted")
@@ -0,0 +1,306 @@
+// Package eventlog records the events published on the event bus in a log on
+// disk, so that the clients consuming them, e.g. external indexers, can read
+// the events published while they were down instead of reindexing.
+//
+// Each recorded event gets a sequence number, starting at 1 and increasing by
+// one with each event, which is kept across restarts of the node. A client
+// reads the events after the last one it processed, and can store this
+// sequence number in the log, as the offset of a named consumer, to resume
+// from it later. The delivery is best effort: the events are not synced to
+// disk as they are recorded, and those pruned before a consumer read them are
+// lost to it.
+package eventlog
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	subscriber = "EventLog"
+
+	// MaxConsumers is the maximum number of consumer offsets stored.
+	MaxConsumers = 100
+	// MaxConsumerNameLength is the maximum length of a consumer name.
+	MaxConsumerNameLength = 64
+)
+
+var (
+	eventKeyPrefix  = []byte("e:")
+	offsetKeyPrefix = []byte("o:")
+
+	// ErrTooManyConsumers is returned when storing the offset of a new
+	// consumer while MaxConsumers offsets are already stored.
+	ErrTooManyConsumers = fmt.Errorf("too many consumers, at most %d offsets are stored", MaxConsumers)
+)
+
+// Event is an event recorded in the log.
+type Event struct {
+	Sequence uint64              `json:"sequence"`
+	Data     types.TMEventData   `json:"data"`
+	Events   map[string][]string `json:"events"`
+}
+
+// Log records the events of the given types published on the event bus, and
+// keeps the latest ones. It must be started before the events to record are
+// published.
+type Log struct {
+	service.BaseService
+
+	db       dbm.DB
+	eventBus *types.EventBus
+	types    map[string]struct{}
+	retain   uint64
+
+	mtx         cmtsync.RWMutex
+	first, last uint64 // sequence numbers of the oldest and newest events
+}
+
+// NewLog returns a log of the events of the given types stored in db, which
+// keeps the latest retain events, or all of them if retain is 0.
+func NewLog(db dbm.DB, eventBus *types.EventBus, eventTypes []string, retain uint64) (*Log, error) {
+	l := &Log{
+		db:       db,
+		eventBus: eventBus,
+		types:    make(map[string]struct{}, len(eventTypes)),
+		retain:   retain,
+	}
+	for _, t := range eventTypes {
+		l.types[t] = struct{}{}
+	}
+	if err := l.loadRange(); err != nil {
+		return nil, err
+	}
+	l.BaseService = *service.NewBaseService(nil, "EventLog", l)
+	return l, nil
+}
+
+// loadRange loads the sequence numbers of the oldest and newest events stored.
+func (l *Log) loadRange() error {
+	it, err := dbm.IteratePrefix(l.db, eventKeyPrefix)
+	if err != nil {
+		return err
+	}
+	if it.Valid() {
+		l.first = seqFromKey(it.Key())
+	}
+	if err := it.Close(); err != nil {
+		return err
+	}
+
+	it, err = l.db.ReverseIterator(eventKey(0), eventKey(^uint64(0)))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	if it.Valid() {
+		l.last = seqFromKey(it.Key())
+	}
+	return it.Error()
+}
+
+// OnStart implements service.Service by subscribing to all the events.
+func (l *Log) OnStart() error {
+	// An unbuffered subscription is never canceled, so no event is missed.
+	sub, err := l.eventBus.SubscribeUnbuffered(context.Background(), subscriber, cmtquery.All)
+	if err != nil {
+		return err
+	}
+	go l.recordRoutine(sub)
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from the events.
+func (l *Log) OnStop() {
+	if l.eventBus.IsRunning() {
+		_ = l.eventBus.UnsubscribeAll(context.Background(), subscriber)
+	}
+}
+
+func (l *Log) recordRoutine(sub types.Subscription) {
+	for {
+		select {
+		case msg := <-sub.Out():
+			if err := l.record(msg); err != nil {
+				l.Logger.Error("Failed to record the event", "events", msg.Events(), "err", err)
+			}
+		case <-sub.Canceled():
+			return
+		case <-l.Quit():
+			return
+		}
+	}
+}
+
+func (l *Log) record(msg cmtpubsub.Message) error {
+	eventType := ""
+	if t := msg.Events()[types.EventTypeKey]; len(t) > 0 {
+		eventType = t[0]
+	}
+	if _, ok := l.types[eventType]; !ok {
+		return nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	seq := l.last + 1
+	bz, err := cmtjson.Marshal(Event{Sequence: seq, Data: msg.Data(), Events: msg.Events()})
+	if err != nil {
+		return err
+	}
+
+	batch := l.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(eventKey(seq), bz); err != nil {
+		return err
+	}
+	first := l.first
+	if first == 0 {
+		first = seq
+	}
+	for ; l.retain > 0 && seq-first >= l.retain; first++ {
+		if err := batch.Delete(eventKey(first)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	l.first, l.last = first, seq
+	return nil
+}
+
+// Range returns the sequence numbers of the oldest and newest events of the
+// log, which are 0 if it is empty.
+func (l *Log) Range() (first, last uint64) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.first, l.last
+}
+
+// Events returns at most limit events recorded after the one with the given
+// sequence number and matching the query, if not nil, oldest first.
+func (l *Log) Events(after uint64, limit int, q cmtpubsub.Query) ([]*Event, error) {
+	if after == ^uint64(0) {
+		return nil, nil
+	}
+	it, err := l.db.Iterator(eventKey(after+1), eventKey(^uint64(0)))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	events := make([]*Event, 0)
+	for ; it.Valid() && len(events) < limit; it.Next() {
+		ev := new(Event)
+		if err := cmtjson.Unmarshal(it.Value(), ev); err != nil {
+			return nil, fmt.Errorf("failed to decode event %d: %w", seqFromKey(it.Key()), err)
+		}
+		if q != nil {
+			match, err := q.Matches(ev.Events)
+			if err != nil {
+				return nil, fmt.Errorf("failed to match against query %s: %w", q.String(), err)
+			}
+			if !match {
+				continue
+			}
+		}
+		events = append(events, ev)
+	}
+	return events, it.Error()
+}
+
+// Offset returns the sequence number of the last event processed by the
+// consumer, or 0 if it didn't store any.
+func (l *Log) Offset(consumer string) (uint64, error) {
+	if err := validateConsumer(consumer); err != nil {
+		return 0, err
+	}
+	bz, err := l.db.Get(offsetKey(consumer))
+	if err != nil || len(bz) == 0 {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+// SetOffset stores the sequence number of the last event processed by the
+// consumer.
+func (l *Log) SetOffset(consumer string, seq uint64) error {
+	if err := validateConsumer(consumer); err != nil {
+		return err
+	}
+	if _, last := l.Range(); seq > last {
+		return fmt.Errorf("event %d isn't recorded yet, the last one is %d", seq, last)
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	key := offsetKey(consumer)
+	exists, err := l.db.Has(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		n, err := l.numConsumers()
+		if err != nil {
+			return err
+		}
+		if n >= MaxConsumers {
+			return ErrTooManyConsumers
+		}
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, seq)
+	return l.db.SetSync(key, bz)
+}
+
+func (l *Log) numConsumers() (int, error) {
+	it, err := dbm.IteratePrefix(l.db, offsetKeyPrefix)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+	n := 0
+	for ; it.Valid(); it.Next() {
+		n++
+	}
+	return n, it.Error()
+}
+
+func validateConsumer(consumer string) error {
+	if consumer == "" {
+		return errors.New("empty consumer name")
+	}
+	if len(consumer) > MaxConsumerNameLength {
+		return fmt.Errorf("consumer name is too long, max %d bytes", MaxConsumerNameLength)
+	}
+	return nil
+}
+
+func eventKey(seq uint64) []byte {
+	key := make([]byte, len(eventKeyPrefix)+8)
+	copy(key, eventKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(eventKeyPrefix):], seq)
+	return key
+}
+
+func seqFromKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(eventKeyPrefix):])
+}
+
+func offsetKey(consumer string) []byte {
+	return append(append([]byte{}, offsetKeyPrefix...), consumer...)
+}
@@ -0,0 +1,169 @@
+package eventlog_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/state/eventlog"
+	"github.com/cometbft/cometbft/types"
+)
+
+func startEventBus(t *testing.T) *types.EventBus {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return eventBus
+}
+
+func startLog(t *testing.T, store db.DB, eventBus *types.EventBus, retain uint64) *eventlog.Log {
+	l, err := eventlog.NewLog(store, eventBus, []string{types.EventNewBlockHeader, types.EventTx}, retain)
+	require.NoError(t, err)
+	l.SetLogger(log.TestingLogger())
+	require.NoError(t, l.Start())
+	t.Cleanup(func() {
+		if l.IsRunning() {
+			if err := l.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	return l
+}
+
+func publishBlock(t *testing.T, eventBus *types.EventBus, height int64, txs ...string) {
+	err := eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: height},
+		NumTxs: int64(len(txs)),
+	})
+	require.NoError(t, err)
+	for i, tx := range txs {
+		err = eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: height,
+			Index:  uint32(i),
+			Tx:     types.Tx(tx),
+		}})
+		require.NoError(t, err)
+	}
+	// Not recorded.
+	require.NoError(t, eventBus.PublishEventNewRound(types.EventDataNewRound{Height: height}))
+}
+
+func waitForLast(t *testing.T, l *eventlog.Log, last uint64) {
+	require.Eventually(t, func() bool {
+		_, l := l.Range()
+		return l == last
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLogRecordsEvents(t *testing.T) {
+	eventBus := startEventBus(t)
+	l := startLog(t, db.NewMemDB(), eventBus, 0)
+
+	publishBlock(t, eventBus, 1, "foo", "bar")
+	publishBlock(t, eventBus, 2, "baz")
+	waitForLast(t, l, 5)
+
+	first, _ := l.Range()
+	assert.EqualValues(t, 1, first)
+
+	events, err := l.Events(0, 100, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	for i, ev := range events {
+		assert.EqualValues(t, i+1, ev.Sequence)
+	}
+	header, ok := events[0].Data.(types.EventDataNewBlockHeader)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, header.Header.Height)
+	tx, ok := events[2].Data.(types.EventDataTx)
+	require.True(t, ok)
+	assert.Equal(t, types.Tx("bar"), types.Tx(tx.Tx))
+
+	events, err = l.Events(3, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 4, events[0].Sequence)
+
+	q := cmtquery.MustCompile("tm.event = 'Tx' AND tx.height = 2")
+	events, err = l.Events(0, 100, q)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 5, events[0].Sequence)
+
+	events, err = l.Events(5, 100, nil)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestLogRetainsLatestEvents(t *testing.T) {
+	eventBus := startEventBus(t)
+	store := db.NewMemDB()
+	l := startLog(t, store, eventBus, 3)
+
+	publishBlock(t, eventBus, 1, "foo", "bar")
+	publishBlock(t, eventBus, 2, "baz")
+	waitForLast(t, l, 5)
+
+	first, _ := l.Range()
+	assert.EqualValues(t, 3, first)
+	events, err := l.Events(0, 100, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.EqualValues(t, 3, events[0].Sequence)
+
+	// The sequence numbers carry on after a restart.
+	require.NoError(t, l.Stop())
+	l = startLog(t, store, eventBus, 3)
+	first, last := l.Range()
+	assert.EqualValues(t, 3, first)
+	assert.EqualValues(t, 5, last)
+
+	publishBlock(t, eventBus, 3)
+	waitForLast(t, l, 6)
+	first, _ = l.Range()
+	assert.EqualValues(t, 4, first)
+}
+
+func TestLogOffsets(t *testing.T) {
+	eventBus := startEventBus(t)
+	l := startLog(t, db.NewMemDB(), eventBus, 0)
+
+	publishBlock(t, eventBus, 1, "foo")
+	waitForLast(t, l, 2)
+
+	seq, err := l.Offset("indexer")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, seq)
+
+	require.NoError(t, l.SetOffset("indexer", 1))
+	seq, err = l.Offset("indexer")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, seq)
+
+	// The event isn't recorded yet.
+	assert.Error(t, l.SetOffset("indexer", 3))
+	assert.Error(t, l.SetOffset("", 1))
+	_, err = l.Offset(string(make([]byte, eventlog.MaxConsumerNameLength+1)))
+	assert.Error(t, err)
+
+	for i := 1; i < eventlog.MaxConsumers; i++ {
+		require.NoError(t, l.SetOffset(fmt.Sprintf("consumer%d", i), 2))
+	}
+	assert.ErrorIs(t, l.SetOffset("other", 2), eventlog.ErrTooManyConsumers)
+	// The existing consumers can still store their offset.
+	assert.NoError(t, l.SetOffset("indexer", 2))
+}
@@ -9,6 +9,7 @@ import (
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/libs/fail"
 	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
 	"github.com/cometbft/cometbft/mempool"
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
 	"github.com/cometbft/cometbft/proxy"
@@ -42,10 +43,54 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// report how PrepareProposal changes the reaped transactions
+	prepareProposalDiagnostics bool
+
+	// pending optimistic execution, see ExecuteBlockOptimistically
+	oeMtx cmtsync.Mutex
+	oe    *optimisticExecution
+
+	// the halt condition and the halted height, see SetHalt
+	haltMtx      cmtsync.Mutex
+	haltHeight   int64
+	haltTime     time.Time
+	haltedHeight int64
+	halted       chan struct{}
+
+	// held while a block is committed, see LockCommit
+	commitMtx cmtsync.Mutex
+
+	// directory of the diagnostics bundles, see
+	// BlockExecutorWithDiagnosticsDir
+	diagnosticsDir string
+
+	// calls of PrepareProposal and ProcessProposal, bounded by the timeout
+	// consensus params
+	prepareProposalCall proxy.TimeoutCall
+	processProposalCall proxy.TimeoutCall
+
+	// hash of the last block proposed without PrepareProposal, after it timed
+	// out, which this node accepts without ProcessProposal, see
+	// CreateProposalBlock
+	fallbackMtx  cmtsync.Mutex
+	fallbackHash []byte
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
 
+// mempoolWithAppVersion is implemented by the mempools which cache results
+// depending on the version of the application.
+type mempoolWithAppVersion interface {
+	SetAppVersion(version uint64)
+}
+
+// mempoolWithMaxBlockTxBytes is implemented by the mempools which bound the
+// size of the txs received from peers by the consensus params.
+type mempoolWithMaxBlockTxBytes interface {
+	SetMaxBlockTxBytes(maxBytes int64)
+}
+
 func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	return func(blockExec *BlockExecutor) {
 		blockExec.metrics = metrics
@@ -72,6 +117,7 @@ func NewBlockExecutor(
 		logger:     logger,
 		metrics:    NopMetrics(),
 		blockStore: blockStore,
+		halted:     make(chan struct{}),
 	}
 
 	for _, option := range options {
@@ -108,6 +154,14 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
 
+	if state.aggregatesLastCommit(height) {
+		aggregated, err := commit.Aggregate()
+		if err != nil {
+			return nil, err
+		}
+		commit = aggregated
+	}
+
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
 	// Fetch a limited amount of valid txs
@@ -117,18 +171,40 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	block := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
 
 	localLastCommit := buildLastCommitInfo(block, blockExec.store, state.InitialHeight)
-	rpp, err := blockExec.proxyApp.PrepareProposalSync(
-		abci.RequestPrepareProposal{
-			MaxTxBytes:         maxDataBytes,
-			Txs:                block.Txs.ToSliceOfBytes(),
-			LocalLastCommit:    extendedCommitInfo(localLastCommit, votes),
-			Misbehavior:        block.Evidence.Evidence.ToABCI(),
-			Height:             block.Height,
-			Time:               block.Time,
-			NextValidatorsHash: block.NextValidatorsHash,
-			ProposerAddress:    block.ProposerAddress,
-		},
-	)
+	blockExec.waitOptimisticExecution()
+	req := abci.RequestPrepareProposal{
+		MaxTxBytes:         maxDataBytes,
+		Txs:                block.Txs.ToSliceOfBytes(),
+		LocalLastCommit:    extendedCommitInfo(localLastCommit, votes),
+		Misbehavior:        block.Evidence.Evidence.ToABCI(),
+		Height:             block.Height,
+		Time:               block.Time,
+		NextValidatorsHash: block.NextValidatorsHash,
+		ProposerAddress:    block.ProposerAddress,
+	}
+	var rpp *abci.ResponsePrepareProposal
+	err := blockExec.prepareProposalCall.Call("prepare_proposal", state.ConsensusParams.Timeout.PrepareProposal,
+		func() (err error) {
+			rpp, err = blockExec.proxyApp.PrepareProposalSync(req)
+			return err
+		})
+	if errors.As(err, new(proxy.ErrTimeout)) {
+		// A slow app must not stall the round: propose a block without
+		// transactions, which the app doesn't need to prepare. The call isn't
+		// canceled, and may still hold the connection to the app, so the
+		// block is accepted without calling ProcessProposal.
+		//
+		// NOTE: this is a node-local liveness heuristic, not deterministic:
+		// only this node accepts the block without ProcessProposal, and the
+		// other validators may reject it, costing the round.
+		blockExec.logger.Error("PrepareProposal timed out; proposing a block without transactions",
+			"height", height, "err", err)
+		block := state.MakeBlock(height, nil, commit, evidence, proposerAddr)
+		blockExec.fallbackMtx.Lock()
+		blockExec.fallbackHash = block.Hash()
+		blockExec.fallbackMtx.Unlock()
+		return block, nil
+	}
 	if err != nil {
 		// The App MUST ensure that only valid (and hence 'processable') transactions
 		// enter the mempool. Hence, at this point, we can't have any non-processable
@@ -145,6 +221,9 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	if err := txl.Validate(maxDataBytes); err != nil {
 		return nil, err
 	}
+	if blockExec.prepareProposalDiagnostics {
+		blockExec.reportPrepareProposal(height, txs, txl)
+	}
 
 	return state.MakeBlock(height, txl, commit, evidence, proposerAddr), nil
 }
@@ -153,7 +232,17 @@ func (blockExec *BlockExecutor) ProcessProposal(
 	block *types.Block,
 	state State,
 ) (bool, error) {
-	resp, err := blockExec.proxyApp.ProcessProposalSync(abci.RequestProcessProposal{
+	blockExec.fallbackMtx.Lock()
+	fallback := blockExec.fallbackHash != nil && block.HashesTo(blockExec.fallbackHash)
+	blockExec.fallbackMtx.Unlock()
+	if fallback {
+		// our own block, proposed after PrepareProposal timed out, see
+		// CreateProposalBlock: accepted by this node only
+		return true, nil
+	}
+
+	blockExec.waitOptimisticExecution()
+	req := abci.RequestProcessProposal{
 		Hash:               block.Header.Hash(),
 		Height:             block.Header.Height,
 		Time:               block.Header.Time,
@@ -162,7 +251,23 @@ func (blockExec *BlockExecutor) ProcessProposal(
 		Misbehavior:        block.Evidence.Evidence.ToABCI(),
 		ProposerAddress:    block.ProposerAddress,
 		NextValidatorsHash: block.NextValidatorsHash,
-	})
+	}
+	var resp *abci.ResponseProcessProposal
+	err := blockExec.processProposalCall.Call("process_proposal", state.ConsensusParams.Timeout.ProcessProposal,
+		func() (err error) {
+			resp, err = blockExec.proxyApp.ProcessProposalSync(req)
+			return err
+		})
+	if errors.As(err, new(proxy.ErrTimeout)) {
+		// A slow app must not stall the round: reject the proposal.
+		//
+		// NOTE: this is a node-local liveness heuristic, not deterministic:
+		// the validators whose app answered in time may accept the proposal.
+		// This only costs the round, the safety doesn't depend on it.
+		blockExec.logger.Error("ProcessProposal timed out; rejecting the proposal",
+			"height", block.Height, "err", err)
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
@@ -191,23 +296,46 @@ func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) e
 // It's the only function that needs to be called
 // from outside this package to process and commit an entire block.
 // It takes a blockID to avoid recomputing the parts hash.
+// If the block fails to be applied, a diagnostics bundle is written, see
+// BlockExecutorWithDiagnosticsDir.
 func (blockExec *BlockExecutor) ApplyBlock(
 	state State, blockID types.BlockID, block *types.Block,
 ) (State, error) {
 
+	if blockExec.IsHalted() {
+		return state, ErrHalted
+	}
+	diag := new(applyDiagnostics)
+	newState, err := blockExec.applyBlock(state, blockID, block, diag)
+	if err != nil && blockExec.diagnosticsDir != "" {
+		err = blockExec.writeDiagnostics(state, block, diag, err)
+	}
+	return newState, err
+}
+
+// applyBlock applies the block, recording the results computed on the way in
+// diag.
+func (blockExec *BlockExecutor) applyBlock(
+	state State, blockID types.BlockID, block *types.Block, diag *applyDiagnostics,
+) (State, error) {
 	if err := validateBlock(state, block); err != nil {
 		return state, ErrInvalidBlock(err)
 	}
 
 	startTime := time.Now().UnixNano()
-	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
-	)
+	var err error
+	abciResponses := blockExec.optimisticExecutionResult(block)
+	if abciResponses == nil {
+		abciResponses, err = execBlockOnProxyApp(
+			blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		)
+	}
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
 		return state, ErrProxyAppConn(err)
 	}
+	diag.abciResponses = abciResponses
 
 	fail.Fail() // XXX
 
@@ -238,10 +366,17 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	// Update the state with the block and responses.
+	prevNextValidators := state.NextValidators
 	state, err = updateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 	if err != nil {
 		return state, fmt.Errorf("commit failed for application: %v", err)
 	}
+	diag.nextState = &state
+	var valSetChange types.EventDataValidatorSetChange
+	if len(validatorUpdates) > 0 {
+		// The validator updates of a block apply from two heights on.
+		valSetChange = types.NewEventDataValidatorSetChange(block.Height+2, prevNextValidators, state.NextValidators)
+	}
 
 	// Lock mempool, commit app state, update mempoool.
 	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
@@ -274,7 +409,9 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates)
+	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates, valSetChange)
+
+	blockExec.checkHalt(block)
 
 	return state, nil
 }
@@ -317,6 +454,12 @@ func (blockExec *BlockExecutor) Commit(
 	)
 
 	// Update mempool.
+	if mempool, ok := blockExec.mempool.(mempoolWithAppVersion); ok {
+		mempool.SetAppVersion(state.Version.Consensus.App)
+	}
+	if mempool, ok := blockExec.mempool.(mempoolWithMaxBlockTxBytes); ok {
+		mempool.SetMaxBlockTxBytes(MaxBlockTxBytes(state))
+	}
 	err = blockExec.mempool.Update(
 		block.Height,
 		block.Txs,
@@ -521,9 +664,14 @@ func updateState(
 	nextParams := state.ConsensusParams
 	lastHeightParamsChanged := state.LastHeightConsensusParamsChanged
 	if abciResponses.EndBlock.ConsensusParamUpdates != nil {
+		err := state.ConsensusParams.ValidateUpdate(abciResponses.EndBlock.ConsensusParamUpdates, header.Height)
+		if err != nil {
+			return state, fmt.Errorf("error updating consensus params: %v", err)
+		}
+
 		// NOTE: must not mutate s.ConsensusParams
 		nextParams = state.ConsensusParams.Update(abciResponses.EndBlock.ConsensusParamUpdates)
-		err := nextParams.ValidateBasic()
+		err = nextParams.ValidateBasic()
 		if err != nil {
 			return state, fmt.Errorf("error updating consensus params: %v", err)
 		}
@@ -558,6 +706,7 @@ func updateState(
 
 // Fire NewBlock, NewBlockHeader.
 // Fire TxEvent for every tx.
+// Fire ValidatorSetUpdates and ValidatorSetChange if the validator set changed.
 // NOTE: if CometBFT crashes before commit, some or all of these events may be published again.
 func fireEvents(
 	logger log.Logger,
@@ -565,6 +714,7 @@ func fireEvents(
 	block *types.Block,
 	abciResponses *cmtstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
+	valSetChange types.EventDataValidatorSetChange,
 ) {
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:            block,
@@ -611,6 +761,12 @@ func fireEvents(
 			logger.Error("failed publishing event", "err", err)
 		}
 	}
+
+	if !valSetChange.IsEmpty() {
+		if err := eventBus.PublishEventValidatorSetChange(valSetChange); err != nil {
+			logger.Error("failed publishing validator set change", "err", err)
+		}
+	}
 }
 
 //----------------------------------------------------------------------------------------------------
@@ -3,6 +3,8 @@ package state_test
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -78,6 +80,123 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// TestApplyBlockDiagnostics ensures that a diagnostics bundle is written and
+// an event published when a block fails to be applied.
+func TestApplyBlockDiagnostics(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	dir := t.TempDir()
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		&mpmocks.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithDiagnosticsDir(dir))
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	blockExec.SetEventBus(eventBus)
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryApplyBlockFailure)
+	require.NoError(t, err)
+
+	// the app hash mismatches
+	block := makeBlock(state, 1, new(types.Commit))
+	block.AppHash = []byte("wrong")
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	var diagErr sm.ErrApplyBlockDiagnostics
+	require.ErrorAs(t, err, &diagErr)
+	assert.Equal(t, dir, filepath.Dir(diagErr.Dir))
+	assert.Contains(t, err.Error(), diagErr.Dir)
+
+	for _, name := range []string{"error.txt", "block.json", "state.json", "validators.json"} {
+		assert.FileExists(t, filepath.Join(diagErr.Dir, name))
+	}
+	// the block wasn't executed
+	assert.NoFileExists(t, filepath.Join(diagErr.Dir, "abci_responses.json"))
+	bz, err := os.ReadFile(filepath.Join(diagErr.Dir, "error.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), "wrong Block.Header.AppHash")
+
+	select {
+	case msg := <-sub.Out():
+		data := msg.Data().(types.EventDataApplyBlockFailure)
+		assert.EqualValues(t, 1, data.Height)
+		assert.Equal(t, diagErr.Dir, data.Dir)
+	case <-time.After(time.Second):
+		t.Fatal("Did not receive EventApplyBlockFailure within 1 sec.")
+	}
+}
+
+// TestApplyBlockOptimisticExecution ensures that the results of an optimistic
+// execution are used if the block is committed, and discarded otherwise.
+func TestApplyBlockOptimisticExecution(t *testing.T) {
+	app := abcimocks.NewBaseMock()
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore)
+
+	proposerAddr := state.Validators.GetProposer().Address
+	block := state.MakeBlock(1, test.MakeNTxs(1, 10), new(types.Commit), nil, proposerAddr)
+	otherBlock := state.MakeBlock(1, test.MakeNTxs(1, 5), new(types.Commit), nil, proposerAddr)
+	require.NotEqual(t, block.Hash(), otherBlock.Hash())
+
+	// the block executed optimistically is not committed: it is executed again
+	blockExec.ExecuteBlockOptimistically(state, otherBlock)
+	blockExec.ExecuteBlockOptimistically(state, otherBlock)
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	state, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	app.AssertNumberOfCalls(t, "BeginBlock", 2)
+
+	// the block executed optimistically is committed: its results are reused
+	commit, err := makeValidCommit(1, blockID, state.LastValidators, privVals)
+	require.NoError(t, err)
+	block = makeBlock(state, 2, commit)
+	blockExec.ExecuteBlockOptimistically(state, block)
+	bps, err = block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	app.AssertNumberOfCalls(t, "BeginBlock", 3)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -338,6 +457,39 @@ func TestProcessProposal(t *testing.T) {
 	app.AssertCalled(t, "ProcessProposal", expectedRpp)
 }
 
+// TestProcessProposalTimeout tests that a proposal is rejected when
+// ProcessProposal exceeds the timeout of the consensus params.
+func TestProcessProposalTimeout(t *testing.T) {
+	app := abcimocks.NewBaseMock()
+	app.On("ProcessProposal", mock.Anything).After(time.Second).
+		Return(abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT})
+
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		new(mpmocks.Mempool),
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+	block := makeBlock(state, 1, new(types.Commit))
+
+	state.ConsensusParams.Timeout.ProcessProposal = 10 * time.Millisecond
+	acceptBlock, err := blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	assert.False(t, acceptBlock)
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	pubkey1 := ed25519.GenPrivKey().PubKey()
 	pubkey2 := ed25519.GenPrivKey().PubKey()
@@ -517,6 +669,12 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 		types.EventQueryValidatorSetUpdates,
 	)
 	require.NoError(t, err)
+	changeSub, err := eventBus.Subscribe(
+		context.Background(),
+		"TestEndBlockValidatorUpdatesChange",
+		types.EventQueryValidatorSetChange,
+	)
+	require.NoError(t, err)
 
 	block := makeBlock(state, 1, new(types.Commit))
 	bps, err := block.MakePartSet(testPartSize)
@@ -554,6 +712,22 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("Did not receive EventValidatorSetUpdates within 1 sec.")
 	}
+	select {
+	case msg := <-changeSub.Out():
+		event, ok := msg.Data().(types.EventDataValidatorSetChange)
+		require.True(t, ok, "Expected event of type EventDataValidatorSetChange, got %T", msg.Data())
+		assert.EqualValues(t, 3, event.Height)
+		if assert.Len(t, event.Added, 1) {
+			assert.Equal(t, pubkey, event.Added[0].PubKey)
+			assert.EqualValues(t, 10, event.Added[0].VotingPower)
+		}
+		assert.Empty(t, event.Removed)
+		assert.Empty(t, event.PowerChanged)
+	case <-changeSub.Canceled():
+		t.Fatalf("changeSub was canceled (reason: %v)", changeSub.Err())
+	case <-time.After(1 * time.Second):
+		t.Fatal("Did not receive EventValidatorSetChange within 1 sec.")
+	}
 }
 
 // TestEndBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
@@ -689,6 +863,69 @@ func TestPrepareProposalTxsAllIncluded(t *testing.T) {
 	mp.AssertExpectations(t)
 }
 
+// TestPrepareProposalTimeout tests that CreateProposalBlock produces a block
+// without transactions when PrepareProposal exceeds the timeout of the
+// consensus params.
+func TestPrepareProposalTimeout(t *testing.T) {
+	const height = 2
+
+	state, stateDB, privVals := makeState(1, height)
+	state.ConsensusParams.Timeout.PrepareProposal = 10 * time.Millisecond
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.Anything).Return([]types.Evidence{}, int64(0))
+
+	txs := test.MakeNTxs(height, 10)
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(types.Txs(txs))
+
+	app := abcimocks.NewBaseMock()
+	app.On("PrepareProposal", mock.Anything).After(time.Second).Return(abci.ResponsePrepareProposal{
+		Txs: types.Txs(txs).ToSliceOfBytes(),
+	})
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mp,
+		evpool,
+		blockStore,
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, err := makeValidCommit(height, types.BlockID{}, state.Validators, privVals)
+	require.NoError(t, err)
+	block, err := blockExec.CreateProposalBlock(height, state, commit, pa, nil)
+	require.NoError(t, err)
+	assert.Empty(t, block.Data.Txs)
+	assert.EqualValues(t, height, block.Height)
+
+	// the block is accepted without calling ProcessProposal, whose call would
+	// wait for the one of PrepareProposal
+	acceptBlock, err := blockExec.ProcessProposal(block, state)
+	require.NoError(t, err)
+	assert.True(t, acceptBlock)
+	app.AssertNotCalled(t, "ProcessProposal", mock.Anything)
+
+	// PrepareProposal isn't called again while the call timed out runs
+	start := time.Now()
+	block, err = blockExec.CreateProposalBlock(height, state, commit, pa, nil)
+	require.NoError(t, err)
+	assert.Empty(t, block.Data.Txs)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	mp.AssertExpectations(t)
+}
+
 // TestPrepareProposalReorderTxs tests that CreateBlock produces a block with transactions
 // in the order matching the order they are returned from PrepareProposal.
 func TestPrepareProposalReorderTxs(t *testing.T) {
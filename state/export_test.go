@@ -45,3 +45,11 @@ func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *type
 	stateStore := dbStore{db, StoreOptions{DiscardABCIResponses: false}}
 	return stateStore.saveValidatorsInfo(height, lastHeightChanged, valSet)
 }
+
+// SetHistoryMigrationChunkSize sets historyMigrationChunkSize, exclusively and
+// explicitly for testing, and returns its previous value.
+func SetHistoryMigrationChunkSize(size int) int {
+	prev := historyMigrationChunkSize
+	historyMigrationChunkSize = size
+	return prev
+}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// SetHalt halts the node after committing the block of the given height, if
+// not 0, or the first block whose time is not before haltTime, if not zero,
+// whichever comes first. Once such a block is committed, ApplyBlock returns
+// ErrHalted, and the channel of Halted is closed, for the consensus and the
+// block sync to stop, and the node to shut down.
+//
+// The halt height, or time, can't be reached already by the last block of the
+// state.
+func (blockExec *BlockExecutor) SetHalt(height int64, haltTime time.Time) error {
+	if height < 0 {
+		return fmt.Errorf("halt height %d can't be negative", height)
+	}
+	state, err := blockExec.store.Load()
+	if err != nil {
+		return err
+	}
+	if height > 0 && height <= state.LastBlockHeight {
+		return fmt.Errorf("halt height %d is not above the last height %d", height, state.LastBlockHeight)
+	}
+	if !haltTime.IsZero() && state.LastBlockHeight > 0 && !haltTime.After(state.LastBlockTime) {
+		return fmt.Errorf("halt time %v is not after the time %v of the last block", haltTime, state.LastBlockTime)
+	}
+
+	blockExec.haltMtx.Lock()
+	defer blockExec.haltMtx.Unlock()
+	if blockExec.haltedHeight > 0 {
+		return fmt.Errorf("%w at height %d", ErrHalted, blockExec.haltedHeight)
+	}
+	blockExec.haltHeight = height
+	blockExec.haltTime = haltTime
+	return nil
+}
+
+// Halt returns the halt height and time, 0 and zero if unset.
+func (blockExec *BlockExecutor) Halt() (int64, time.Time) {
+	blockExec.haltMtx.Lock()
+	defer blockExec.haltMtx.Unlock()
+	return blockExec.haltHeight, blockExec.haltTime
+}
+
+// Halted returns a channel closed once the node halted.
+func (blockExec *BlockExecutor) Halted() <-chan struct{} {
+	return blockExec.halted
+}
+
+// IsHalted reports whether the node halted.
+func (blockExec *BlockExecutor) IsHalted() bool {
+	select {
+	case <-blockExec.halted:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkHalt halts the node if the committed block reached the halt height or
+// time.
+func (blockExec *BlockExecutor) checkHalt(block *types.Block) {
+	blockExec.haltMtx.Lock()
+	defer blockExec.haltMtx.Unlock()
+	if blockExec.haltedHeight > 0 {
+		return
+	}
+	heightReached := blockExec.haltHeight > 0 && block.Height >= blockExec.haltHeight
+	timeReached := !blockExec.haltTime.IsZero() && !block.Time.Before(blockExec.haltTime)
+	if !heightReached && !timeReached {
+		return
+	}
+	blockExec.logger.Info("Halting the node",
+		"height", block.Height,
+		"halt_height", blockExec.haltHeight,
+		"halt_time", blockExec.haltTime)
+	blockExec.haltedHeight = block.Height
+	close(blockExec.halted)
+}
@@ -0,0 +1,78 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
+	"github.com/cometbft/cometbft/proxy"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestBlockExecutorHalt(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore)
+
+	require.Error(t, blockExec.SetHalt(-1, time.Time{}))
+	require.NoError(t, blockExec.SetHalt(1, time.Time{}))
+	height, haltTime := blockExec.Halt()
+	assert.EqualValues(t, 1, height)
+	assert.True(t, haltTime.IsZero())
+	assert.False(t, blockExec.IsHalted())
+
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	state, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.True(t, blockExec.IsHalted())
+	select {
+	case <-blockExec.Halted():
+	default:
+		t.Fatal("expected the halted channel to be closed")
+	}
+
+	// no block is applied once halted
+	block = makeBlock(state, 2, new(types.Commit))
+	bps, err = block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	assert.ErrorIs(t, err, sm.ErrHalted)
+	assert.ErrorIs(t, blockExec.SetHalt(0, time.Time{}), sm.ErrHalted)
+}
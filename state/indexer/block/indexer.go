@@ -39,6 +39,9 @@ func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID
 		if err != nil {
 			return nil, nil, fmt.Errorf("creating psql indexer: %w", err)
 		}
+		if err := es.Migrate(); err != nil {
+			return nil, nil, fmt.Errorf("creating psql indexer: %w", err)
+		}
 		return es.TxIndexer(), es.BlockIndexer(), nil
 
 	default:
@@ -94,6 +94,9 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	}
 
 	conditions := q.Syntax()
+	if err := indexer.CheckWildcards(conditions); err != nil {
+		return nil, err
+	}
 
 	// If there is an exact height query, return the result immediately
 	// (if it exists).
@@ -185,7 +188,9 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 
 	// fetch matching heights
 	results = make([]int64, 0, len(filteredHeights))
+RESULTS_LOOP:
 	for _, hBz := range filteredHeights {
+		indexer.Scanned(ctx)
 		h := int64FromBytes(hBz)
 
 		ok, err := idx.Has(h)
@@ -198,7 +203,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 
 		select {
 		case <-ctx.Done():
-			break
+			break RESULTS_LOOP
 
 		default:
 		}
@@ -240,6 +245,8 @@ func (idx *BlockerIndexer) matchRange(
 
 LOOP:
 	for ; it.Valid(); it.Next() {
+		indexer.Scanned(ctx)
+
 		var (
 			eventValue string
 			err        error
@@ -277,7 +284,7 @@ LOOP:
 
 		select {
 		case <-ctx.Done():
-			break
+			break LOOP
 
 		default:
 		}
@@ -346,6 +353,8 @@ func (idx *BlockerIndexer) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
 			tmpHeights[string(it.Value())] = it.Value()
 
 			if err := ctx.Err(); err != nil {
@@ -369,12 +378,15 @@ func (idx *BlockerIndexer) match(
 		}
 		defer it.Close()
 
+	EXISTS_LOOP:
 		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
 			tmpHeights[string(it.Value())] = it.Value()
 
 			select {
 			case <-ctx.Done():
-				break
+				break EXISTS_LOOP
 
 			default:
 			}
@@ -396,7 +408,10 @@ func (idx *BlockerIndexer) match(
 		}
 		defer it.Close()
 
+	CONTAINS_LOOP:
 		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
 			eventValue, err := parseValueFromEventKey(it.Key())
 			if err != nil {
 				continue
@@ -408,7 +423,43 @@ func (idx *BlockerIndexer) match(
 
 			select {
 			case <-ctx.Done():
-				break
+				break CONTAINS_LOOP
+
+			default:
+			}
+		}
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+
+	case c.Op == syntax.TStartsWith:
+		prefix, err := orderedcode.Append(nil, c.Tag)
+		if err != nil {
+			return nil, err
+		}
+
+		it, err := dbm.IteratePrefix(idx.store, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prefix iterator: %w", err)
+		}
+		defer it.Close()
+
+	STARTS_WITH_LOOP:
+		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
+			eventValue, err := parseValueFromEventKey(it.Key())
+			if err != nil {
+				continue
+			}
+
+			if strings.HasPrefix(eventValue, c.Arg.Value()) {
+				tmpHeights[string(it.Value())] = it.Value()
+			}
+
+			select {
+			case <-ctx.Done():
+				break STARTS_WITH_LOOP
 
 			default:
 			}
@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/state/indexer"
 	blockidxkv "github.com/cometbft/cometbft/state/indexer/block/kv"
 	"github.com/cometbft/cometbft/types"
 )
@@ -130,6 +131,14 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustCompile(`begin_event.proposer CONTAINS 'FCAA001'`),
 			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
 		},
+		"begin_event.proposer STARTS_WITH 'FCA'": {
+			q:       query.MustCompile(`begin_event.proposer STARTS_WITH 'FCA'`),
+			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"begin_event.proposer STARTS_WITH 'AA001'": {
+			q:       query.MustCompile(`begin_event.proposer STARTS_WITH 'AA001'`),
+			results: []int64{},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -141,3 +150,42 @@ func TestBlockIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockIndexerSearchWildcard(t *testing.T) {
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(db.NewMemDB(), []byte("block_events")))
+
+	_, err := blockIndexer.Search(context.Background(), query.MustCompile(`begin_event.* = 'FCAA001'`))
+	require.Error(t, err)
+}
+
+func TestBlockIndexerSearchWithScanLimit(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	blockIndexer := blockidxkv.New(store)
+
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: int64(i)},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{
+					{
+						Type:       "end_event",
+						Attributes: []abci.EventAttribute{{Key: "foo", Value: "100", Index: true}},
+					},
+				},
+			},
+		}))
+	}
+
+	ctx, limit := indexer.WithScanLimit(context.Background(), 5)
+	defer limit.Release()
+	_, err := blockIndexer.Search(ctx, query.MustCompile(`end_event.foo = 100`))
+	require.NoError(t, err)
+	require.True(t, limit.Exceeded())
+
+	ctx, limit = indexer.WithScanLimit(context.Background(), 20)
+	defer limit.Release()
+	results, err := blockIndexer.Search(ctx, query.MustCompile(`end_event.foo = 100`))
+	require.NoError(t, err)
+	require.False(t, limit.Exceeded())
+	require.Len(t, results, 10)
+}
@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/cometbft/cometbft/libs/pubsub/query/syntax"
@@ -135,3 +136,15 @@ func conditionArg(c syntax.Condition) interface{} {
 		return c.Arg.Value() // string
 	}
 }
+
+// CheckWildcards returns an error if the tag of one of the conditions has
+// wildcards, which the indexers don't support as they look the events up by
+// their names.
+func CheckWildcards(conditions []syntax.Condition) error {
+	for _, c := range conditions {
+		if c.HasWildcard() {
+			return fmt.Errorf("wildcard tags are not supported by the indexer: %s", c.Tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package indexer
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type scanLimitKey struct{}
+
+// ScanLimit limits the number of index entries a search scans, canceling its
+// context once the limit is exceeded.
+type ScanLimit struct {
+	remaining int64 // atomic
+	exceeded  int32 // atomic
+	cancel    context.CancelFunc
+}
+
+// WithScanLimit returns a context for the searches, limiting the number of
+// index entries they scan to limit, or not limiting them if it's 0. The
+// indexers count the entries they scan with Scanned, and the searches exit
+// early like when the context is canceled once the limit is exceeded, which
+// the caller checks with Exceeded. The caller must call Release once done.
+func WithScanLimit(ctx context.Context, limit int64) (context.Context, *ScanLimit) {
+	ctx, cancel := context.WithCancel(ctx)
+	l := &ScanLimit{remaining: limit, cancel: cancel}
+	if limit <= 0 {
+		return ctx, l
+	}
+	return context.WithValue(ctx, scanLimitKey{}, l), l
+}
+
+// Exceeded returns true if the searches scanned more entries than the limit.
+func (l *ScanLimit) Exceeded() bool {
+	return atomic.LoadInt32(&l.exceeded) == 1
+}
+
+// Release releases the resources of the context.
+func (l *ScanLimit) Release() {
+	l.cancel()
+}
+
+// Scanned counts an index entry scanned by a search with the context,
+// canceling the context if it exceeds the scan limit, if any.
+func Scanned(ctx context.Context) {
+	l, ok := ctx.Value(scanLimitKey{}).(*ScanLimit)
+	if !ok {
+		return
+	}
+	if atomic.AddInt64(&l.remaining, -1) < 0 && atomic.CompareAndSwapInt32(&l.exceeded, 0, 1) {
+		l.cancel()
+	}
+}
@@ -0,0 +1,130 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// HeightRange is an inclusive range of block heights.
+type HeightRange struct {
+	From int64
+	To   int64
+}
+
+// BlockStore is the subset of the block store the sink is backfilled from.
+type BlockStore interface {
+	Base() int64
+	Height() int64
+	LoadBlock(height int64) *types.Block
+}
+
+// ABCIResponsesStore is the subset of the state store the sink is backfilled
+// from.
+type ABCIResponsesStore interface {
+	LoadABCIResponses(height int64) (*cmtstate.ABCIResponses, error)
+}
+
+// MissingHeights returns the ranges of heights of the block store which are
+// below the lowest, or above the highest, height indexed in the sink. Gaps
+// between indexed heights are not reported.
+func (es *EventSink) MissingHeights(bs BlockStore) ([]HeightRange, error) {
+	var first, last sql.NullInt64
+	if err := es.store.QueryRow(`
+SELECT MIN(height), MAX(height) FROM `+tableBlocks+` WHERE chain_id = $1;
+`, es.chainID).Scan(&first, &last); err != nil {
+		return nil, fmt.Errorf("finding indexed heights: %w", err)
+	}
+	return missingHeights(bs.Base(), bs.Height(), first.Int64, last.Int64), nil
+}
+
+// missingHeights returns the ranges of [base, height] outside of
+// [first, last]. A zero last means no height is indexed.
+func missingHeights(base, height, first, last int64) []HeightRange {
+	if base == 0 || height < base {
+		return nil
+	}
+	if last == 0 {
+		return []HeightRange{{From: base, To: height}}
+	}
+
+	var ranges []HeightRange
+	if base < first {
+		to := first - 1
+		if to > height {
+			to = height
+		}
+		ranges = append(ranges, HeightRange{From: base, To: to})
+	}
+	if last < height {
+		from := last + 1
+		if from < base {
+			from = base
+		}
+		ranges = append(ranges, HeightRange{From: from, To: height})
+	}
+	return ranges
+}
+
+// Backfill indexes the blocks of the given height ranges, along with their
+// transaction results and events, from the block store and the ABCI responses
+// saved in the state store. It stops at the first height which cannot be
+// indexed, or when ctx is done.
+func (es *EventSink) Backfill(
+	ctx context.Context,
+	ranges []HeightRange,
+	bs BlockStore,
+	rs ABCIResponsesStore,
+) error {
+	for _, r := range ranges {
+		for height := r.From; height <= r.To; height++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := es.backfillHeight(height, bs, rs); err != nil {
+				return fmt.Errorf("backfilling height %d: %w", height, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (es *EventSink) backfillHeight(height int64, bs BlockStore, rs ABCIResponsesStore) error {
+	block := bs.LoadBlock(height)
+	if block == nil {
+		return errors.New("block not found in the block store")
+	}
+	res, err := rs.LoadABCIResponses(height)
+	if err != nil {
+		return fmt.Errorf("loading ABCI responses: %w", err)
+	}
+	if len(res.DeliverTxs) != len(block.Txs) {
+		return fmt.Errorf("found %d tx results for %d txs", len(res.DeliverTxs), len(block.Txs))
+	}
+
+	// The block must be indexed before its transactions.
+	if err := es.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header:           block.Header,
+		NumTxs:           int64(len(block.Txs)),
+		ResultBeginBlock: *res.BeginBlock,
+		ResultEndBlock:   *res.EndBlock,
+	}); err != nil {
+		return err
+	}
+
+	txrs := make([]*abci.TxResult, len(block.Txs))
+	for i, tx := range block.Txs {
+		txrs[i] = &abci.TxResult{
+			Height: height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *res.DeliverTxs[i],
+		}
+	}
+	return es.IndexTxEvents(txrs)
+}
@@ -69,6 +69,9 @@ func (es *EventSink) BlockIndexer() BackportBlockIndexer {
 // delegating indexing operations to an underlying PostgreSQL event sink.
 type BackportBlockIndexer struct{ psql *EventSink }
 
+// EventSink returns the Postgres event sink backing b.
+func (b BackportBlockIndexer) EventSink() *EventSink { return b.psql }
+
 // Has is implemented to satisfy the BlockIndexer interface, but it is not
 // supported by the psql event sink and reports an error for all inputs.
 func (BackportBlockIndexer) Has(height int64) (bool, error) {
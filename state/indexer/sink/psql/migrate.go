@@ -0,0 +1,36 @@
+package psql
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/adlio/schema"
+)
+
+// migrationsTable is the table recording the migrations applied to the
+// database.
+const migrationsTable = "cometbft_schema_migrations"
+
+//go:embed schema.sql
+var initialSchema string
+
+// migrations are the schema migrations of the sink, in the order they are
+// applied. A migration must never be changed once released: changes to the
+// schema are made by appending a new migration.
+var migrations = []*schema.Migration{
+	{ID: "0001 initial schema", Script: initialSchema},
+}
+
+// Migrate brings the schema of the database up to date by applying the
+// migrations it is missing. It is safe to call Migrate concurrently from
+// several nodes sharing the database.
+func (es *EventSink) Migrate() error {
+	migrator := schema.NewMigrator(
+		schema.WithDialect(schema.Postgres),
+		schema.WithTableName(migrationsTable),
+	)
+	if err := migrator.Apply(es.store, migrations); err != nil {
+		return fmt.Errorf("migrating schema: %w", err)
+	}
+	return nil
+}
@@ -11,7 +11,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/adlio/schema"
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
@@ -19,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
 
@@ -105,12 +105,8 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Flushing database: %v", err)
 	}
 
-	sm, err := readSchema()
-	if err != nil {
-		log.Fatalf("Reading schema: %v", err)
-	}
-	migrator := schema.NewMigrator()
-	if err := migrator.Apply(db, sm); err != nil {
+	sink := &EventSink{store: db, chainID: chainID}
+	if err := sink.Migrate(); err != nil {
 		log.Fatalf("Applying schema: %v", err)
 	}
 
@@ -248,6 +244,79 @@ func TestIndexing(t *testing.T) {
 	})
 }
 
+func TestMigrate(t *testing.T) {
+	indexer := &EventSink{store: testDB(), chainID: chainID}
+
+	// Migrating an up to date database is a no-op.
+	require.NoError(t, indexer.Migrate())
+
+	var n int
+	require.NoError(t, testDB().QueryRow(`SELECT COUNT(*) FROM `+migrationsTable+`;`).Scan(&n))
+	assert.Equal(t, len(migrations), n)
+}
+
+func TestMissingHeights(t *testing.T) {
+	testCases := []struct {
+		base, height, first, last int64
+		want                      []HeightRange
+	}{
+		{0, 0, 0, 0, nil},
+		{1, 10, 0, 0, []HeightRange{{1, 10}}},
+		{1, 10, 1, 10, nil},
+		{1, 10, 1, 7, []HeightRange{{8, 10}}},
+		{1, 10, 5, 10, []HeightRange{{1, 4}}},
+		{1, 10, 5, 7, []HeightRange{{1, 4}, {8, 10}}},
+		{5, 10, 1, 3, []HeightRange{{5, 10}}},
+		{1, 10, 12, 15, []HeightRange{{1, 10}}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, missingHeights(tc.base, tc.height, tc.first, tc.last), tc)
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	const backfillChainID = "test-backfill-chainID"
+	indexer := &EventSink{store: testDB(), chainID: backfillChainID}
+
+	bs := &testBlockStore{}
+	rs := testABCIResponsesStore{}
+	for height := int64(1); height <= 3; height++ {
+		bs.blocks = append(bs.blocks, &types.Block{
+			Header: types.Header{Height: height},
+			Data:   types.Data{Txs: types.Txs{types.Tx(fmt.Sprintf("tx%d", height))}},
+		})
+		rs[height] = &cmtstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Events: []abci.Event{
+				makeIndexedEvent("account.number", fmt.Sprint(height)),
+			}}},
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}
+	}
+
+	// Height 2 is indexed already: heights 1 and 3 are missing.
+	require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{Header: bs.blocks[1].Header}))
+	ranges, err := indexer.MissingHeights(bs)
+	require.NoError(t, err)
+	assert.Equal(t, []HeightRange{{1, 1}, {3, 3}}, ranges)
+
+	require.NoError(t, indexer.Backfill(context.Background(), ranges, bs, rs))
+
+	ranges, err = indexer.MissingHeights(bs)
+	require.NoError(t, err)
+	assert.Empty(t, ranges)
+
+	for _, height := range []int64{1, 3} {
+		txr, err := loadTxResult(types.Tx(fmt.Sprintf("tx%d", height)).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, height, txr.Height)
+	}
+
+	// A height missing from the block store can't be backfilled.
+	err = indexer.Backfill(context.Background(), []HeightRange{{4, 4}}, bs, rs)
+	assert.Error(t, err)
+}
+
 func TestStop(t *testing.T) {
 	indexer := &EventSink{store: testDB()}
 	require.NoError(t, indexer.Stop())
@@ -273,18 +342,30 @@ func newTestBlockHeader() types.EventDataNewBlockHeader {
 	}
 }
 
-// readSchema loads the indexing database schema file
-func readSchema() ([]*schema.Migration, error) {
-	const filename = "schema.sql"
-	contents, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read sql file from '%s': %w", filename, err)
+// testBlockStore is a BlockStore of the blocks from height 1.
+type testBlockStore struct {
+	blocks []*types.Block
+}
+
+func (bs *testBlockStore) Base() int64   { return 1 }
+func (bs *testBlockStore) Height() int64 { return int64(len(bs.blocks)) }
+
+func (bs *testBlockStore) LoadBlock(height int64) *types.Block {
+	if height < 1 || height > bs.Height() {
+		return nil
 	}
+	return bs.blocks[height-1]
+}
 
-	return []*schema.Migration{{
-		ID:     time.Now().Local().String() + " db schema",
-		Script: string(contents),
-	}}, nil
+// testABCIResponsesStore is an ABCIResponsesStore of the responses by height.
+type testABCIResponsesStore map[int64]*cmtstate.ABCIResponses
+
+func (rs testABCIResponsesStore) LoadABCIResponses(height int64) (*cmtstate.ABCIResponses, error) {
+	res, ok := rs[height]
+	if !ok {
+		return nil, fmt.Errorf("no ABCI responses for height %d", height)
+	}
+	return res, nil
 }
 
 // resetDB drops all the data from the test database.
@@ -297,6 +378,10 @@ func resetDatabase(db *sql.DB) error {
 	if err != nil {
 		return fmt.Errorf("dropping views: %v", err)
 	}
+	_, err = db.Exec(`DROP TABLE IF EXISTS ` + migrationsTable + `;`)
+	if err != nil {
+		return fmt.Errorf("dropping migrations: %v", err)
+	}
 	return nil
 }
 
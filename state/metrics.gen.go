@@ -34,13 +34,41 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "validator_set_updates",
 			Help:      "ValidatorSetUpdates is the total number of times the application has udated the validator set since process start.",
 		}, labels).With(labelsAndValues...),
+		OptimisticExecutions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "optimistic_executions",
+			Help:      "OptimisticExecutions is the number of blocks executed optimistically, by outcome: used if the block was committed, discarded if another block was committed and failed if the execution returned an error.",
+		}, append(labels, "outcome")).With(labelsAndValues...),
+		PrepareProposalTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prepare_proposal_txs",
+			Help:      "PrepareProposalTxs is the number of transactions reaped from the mempool which the application removed from, or reordered in, the proposals of the node, and of the transactions it added, by change. Only reported if enabled with prepare_proposal_diagnostics.",
+		}, append(labels, "change")).With(labelsAndValues...),
+		PrepareProposalTxBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prepare_proposal_tx_bytes",
+			Help:      "PrepareProposalTxBytes is the total size of the transactions removed from, and added to, the proposals of the node by the application, by change.",
+		}, append(labels, "change")).With(labelsAndValues...),
+		PrepareProposalTxGas: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prepare_proposal_tx_gas",
+			Help:      "PrepareProposalTxGas is the total gas wanted by the transactions removed from, and added to, the proposals of the node by the application, by change. The gas of the added transactions which are not in the mempool is unknown.",
+		}, append(labels, "change")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		BlockProcessingTime:   discard.NewHistogram(),
-		ConsensusParamUpdates: discard.NewCounter(),
-		ValidatorSetUpdates:   discard.NewCounter(),
+		BlockProcessingTime:    discard.NewHistogram(),
+		ConsensusParamUpdates:  discard.NewCounter(),
+		ValidatorSetUpdates:    discard.NewCounter(),
+		OptimisticExecutions:   discard.NewCounter(),
+		PrepareProposalTxs:     discard.NewCounter(),
+		PrepareProposalTxBytes: discard.NewCounter(),
+		PrepareProposalTxGas:   discard.NewCounter(),
 	}
 }
@@ -24,4 +24,26 @@ type Metrics struct {
 	// ValidatorSetUpdates is the total number of times the application has
 	// udated the validator set since process start.
 	ValidatorSetUpdates metrics.Counter
+
+	// OptimisticExecutions is the number of blocks executed optimistically,
+	// by outcome: used if the block was committed, discarded if another block
+	// was committed and failed if the execution returned an error.
+	OptimisticExecutions metrics.Counter `metrics_labels:"outcome"`
+
+	// PrepareProposalTxs is the number of transactions reaped from the
+	// mempool which the application removed from, or reordered in, the
+	// proposals of the node, and of the transactions it added, by change. Only
+	// reported if enabled with prepare_proposal_diagnostics.
+	PrepareProposalTxs metrics.Counter `metrics_labels:"change"`
+
+	// PrepareProposalTxBytes is the total size of the transactions removed
+	// from, and added to, the proposals of the node by the application, by
+	// change.
+	PrepareProposalTxBytes metrics.Counter `metrics_labels:"change"`
+
+	// PrepareProposalTxGas is the total gas wanted by the transactions removed
+	// from, and added to, the proposals of the node by the application, by
+	// change. The gas of the added transactions which are not in the mempool
+	// is unknown.
+	PrepareProposalTxGas metrics.Counter `metrics_labels:"change"`
 }
@@ -134,6 +134,20 @@ func (_m *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 	return r0
 }
 
+// LoadBlockHeightByHash provides a mock function with given fields: hash
+func (_m *BlockStore) LoadBlockHeightByHash(hash []byte) int64 {
+	ret := _m.Called(hash)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func([]byte) int64); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // LoadBlockMeta provides a mock function with given fields: height
 func (_m *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	ret := _m.Called(height)
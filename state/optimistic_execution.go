@@ -0,0 +1,94 @@
+package state
+
+import (
+	"bytes"
+
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// optimisticExecution is a block being executed, or already executed, against
+// the app before it is committed.
+type optimisticExecution struct {
+	blockHash []byte
+	done      chan struct{}
+
+	// set once done is closed
+	abciResponses *cmtstate.ABCIResponses
+	err           error
+}
+
+// ExecuteBlockOptimistically starts executing the block against the app in the
+// background, so that its results are available by the time the block is
+// committed and passed to ApplyBlock. If a different block is committed, the
+// results are discarded and the committed block is executed as usual.
+//
+// The application must support executing a height more than once: any state
+// left uncommitted by a previous execution must be discarded on BeginBlock.
+func (blockExec *BlockExecutor) ExecuteBlockOptimistically(state State, block *types.Block) {
+	blockExec.oeMtx.Lock()
+	defer blockExec.oeMtx.Unlock()
+
+	if oe := blockExec.oe; oe != nil {
+		if bytes.Equal(oe.blockHash, block.Hash()) {
+			return
+		}
+		// the app connection must not be used by two executions at once
+		<-oe.done
+	}
+
+	oe := &optimisticExecution{
+		blockHash: block.Hash(),
+		done:      make(chan struct{}),
+	}
+	blockExec.oe = oe
+
+	blockExec.logger.Debug("executing block optimistically", "height", block.Height, "hash", block.Hash())
+	go func() {
+		defer close(oe.done)
+		oe.abciResponses, oe.err = execBlockOnProxyApp(
+			blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		)
+	}()
+}
+
+// waitOptimisticExecution blocks until the pending optimistic execution, if
+// any, is done, so that the app connection can be used safely.
+func (blockExec *BlockExecutor) waitOptimisticExecution() {
+	blockExec.oeMtx.Lock()
+	oe := blockExec.oe
+	blockExec.oeMtx.Unlock()
+
+	if oe != nil {
+		<-oe.done
+	}
+}
+
+// optimisticExecutionResult returns the responses of the optimistic execution
+// of the block, if any, and resets the optimistic execution. It returns nil
+// if the block was not executed optimistically or its execution failed.
+func (blockExec *BlockExecutor) optimisticExecutionResult(block *types.Block) *cmtstate.ABCIResponses {
+	blockExec.oeMtx.Lock()
+	oe := blockExec.oe
+	blockExec.oe = nil
+	blockExec.oeMtx.Unlock()
+
+	if oe == nil {
+		return nil
+	}
+	<-oe.done
+
+	if !bytes.Equal(oe.blockHash, block.Hash()) {
+		blockExec.logger.Debug("discarding optimistic execution of a block that was not committed",
+			"height", block.Height, "hash", oe.blockHash)
+		blockExec.metrics.OptimisticExecutions.With("outcome", "discarded").Add(1)
+		return nil
+	}
+	if oe.err != nil {
+		blockExec.logger.Error("optimistic execution failed", "height", block.Height, "err", oe.err)
+		blockExec.metrics.OptimisticExecutions.With("outcome", "failed").Add(1)
+		return nil
+	}
+	blockExec.metrics.OptimisticExecutions.With("outcome", "used").Add(1)
+	return oe.abciResponses
+}
@@ -0,0 +1,22 @@
+package state
+
+import "sync"
+
+// LockCommit must be called before a block is saved to the block store, and
+// the returned unlock once it is applied, so that PauseCommits pauses the
+// commits at a height boundary: with the block store and the state store at
+// the same height. unlock can be called more than once, so that it can also be
+// deferred, to release the lock if the commit panics.
+func (blockExec *BlockExecutor) LockCommit() (unlock func()) {
+	blockExec.commitMtx.Lock()
+	var once sync.Once
+	return func() { once.Do(blockExec.commitMtx.Unlock) }
+}
+
+// PauseCommits waits for the block being committed, if any, to be saved and
+// applied, and pauses the commit of the next blocks until resume is called,
+// e.g. to take a consistent snapshot of the stores. The consensus and the
+// block sync stop meanwhile, at the first block they commit.
+func (blockExec *BlockExecutor) PauseCommits() (resume func()) {
+	return blockExec.LockCommit()
+}
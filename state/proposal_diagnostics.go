@@ -0,0 +1,134 @@
+package state
+
+import (
+	"sort"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// txGasProvider is implemented by the mempools which know the gas wanted by
+// their transactions.
+type txGasProvider interface {
+	TxGasWanted(txKey types.TxKey) (int64, bool)
+}
+
+// BlockExecutorWithPrepareProposalDiagnostics reports, for every proposal
+// created, how the application changed the transactions reaped from the
+// mempool in PrepareProposal, in the metrics and a debug log.
+func BlockExecutorWithPrepareProposalDiagnostics() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.prepareProposalDiagnostics = true
+	}
+}
+
+// prepareProposalDiff is how the application changed the transactions reaped
+// from the mempool in PrepareProposal.
+type prepareProposalDiff struct {
+	// number of transactions removed, added, and moved, i.e. kept but
+	// reordered: the minimum number of kept transactions to move to get the
+	// order of the proposal.
+	removed, added, reordered int
+	// total size of the transactions removed and added
+	removedBytes, addedBytes int64
+	// total gas wanted by the transactions removed and added, as returned by
+	// CheckTx. The gas of the added transactions which are not in the mempool
+	// is unknown.
+	removedGas, addedGas int64
+	unknownGasTxs        int
+}
+
+// diffPrepareProposal returns how prepared, the transactions returned by
+// PrepareProposal, differ from reaped, the transactions reaped from the
+// mempool.
+func diffPrepareProposal(reaped, prepared types.Txs, gas txGasProvider) prepareProposalDiff {
+	var diff prepareProposalDiff
+	txGas := func(tx types.Tx) (int64, bool) {
+		if gas == nil {
+			return 0, false
+		}
+		return gas.TxGasWanted(tx.Key())
+	}
+
+	reapedIndex := make(map[types.TxKey]int, len(reaped))
+	for i, tx := range reaped {
+		reapedIndex[tx.Key()] = i
+	}
+
+	// the reaped indexes of the kept transactions, in the order of the proposal
+	var kept []int
+	keptKeys := make(map[types.TxKey]struct{}, len(prepared))
+	for _, tx := range prepared {
+		key := tx.Key()
+		if i, ok := reapedIndex[key]; ok {
+			if _, dup := keptKeys[key]; !dup {
+				kept = append(kept, i)
+				keptKeys[key] = struct{}{}
+				continue
+			}
+		}
+		diff.added++
+		diff.addedBytes += int64(len(tx))
+		if g, ok := txGas(tx); ok {
+			diff.addedGas += g
+		} else {
+			diff.unknownGasTxs++
+		}
+	}
+	for _, tx := range reaped {
+		if _, ok := keptKeys[tx.Key()]; ok {
+			continue
+		}
+		diff.removed++
+		diff.removedBytes += int64(len(tx))
+		if g, ok := txGas(tx); ok {
+			diff.removedGas += g
+		}
+	}
+	diff.reordered = len(kept) - longestIncreasingSubsequence(kept)
+	return diff
+}
+
+// longestIncreasingSubsequence returns the length of the longest increasing
+// subsequence of s.
+func longestIncreasingSubsequence(s []int) int {
+	// tails[i] is the smallest tail of the increasing subsequences of length i+1
+	var tails []int
+	for _, v := range s {
+		i := sort.SearchInts(tails, v)
+		if i == len(tails) {
+			tails = append(tails, v)
+		} else {
+			tails[i] = v
+		}
+	}
+	return len(tails)
+}
+
+// reportPrepareProposal reports how the application changed the transactions
+// reaped from the mempool in the PrepareProposal of the given height.
+func (blockExec *BlockExecutor) reportPrepareProposal(height int64, reaped, prepared types.Txs) {
+	gas, _ := blockExec.mempool.(txGasProvider)
+	diff := diffPrepareProposal(reaped, prepared, gas)
+
+	blockExec.metrics.PrepareProposalTxs.With("change", "removed").Add(float64(diff.removed))
+	blockExec.metrics.PrepareProposalTxs.With("change", "added").Add(float64(diff.added))
+	blockExec.metrics.PrepareProposalTxs.With("change", "reordered").Add(float64(diff.reordered))
+	blockExec.metrics.PrepareProposalTxBytes.With("change", "removed").Add(float64(diff.removedBytes))
+	blockExec.metrics.PrepareProposalTxBytes.With("change", "added").Add(float64(diff.addedBytes))
+	blockExec.metrics.PrepareProposalTxGas.With("change", "removed").Add(float64(diff.removedGas))
+	blockExec.metrics.PrepareProposalTxGas.With("change", "added").Add(float64(diff.addedGas))
+
+	blockExec.logger.Debug("PrepareProposal changed the reaped transactions",
+		"height", height,
+		"reaped", len(reaped),
+		"proposed", len(prepared),
+		"removed", diff.removed,
+		"added", diff.added,
+		"reordered", diff.reordered,
+		"removed_bytes", diff.removedBytes,
+		"added_bytes", diff.addedBytes,
+		"removed_gas", diff.removedGas,
+		"added_gas", diff.addedGas,
+		"added_unknown_gas", diff.unknownGasTxs,
+	)
+}
@@ -0,0 +1,58 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+type testTxGas map[types.TxKey]int64
+
+func (g testTxGas) TxGasWanted(txKey types.TxKey) (int64, bool) {
+	gas, ok := g[txKey]
+	return gas, ok
+}
+
+func TestDiffPrepareProposal(t *testing.T) {
+	a, b, c, d, e := types.Tx("a"), types.Tx("bb"), types.Tx("ccc"), types.Tx("dddd"), types.Tx("eeeee")
+	gas := testTxGas{a.Key(): 1, b.Key(): 2, c.Key(): 3, d.Key(): 4}
+
+	testCases := []struct {
+		name     string
+		reaped   types.Txs
+		prepared types.Txs
+		diff     prepareProposalDiff
+	}{
+		{"unchanged", types.Txs{a, b, c}, types.Txs{a, b, c}, prepareProposalDiff{}},
+		{"empty", nil, nil, prepareProposalDiff{}},
+		{
+			"removed", types.Txs{a, b, c}, types.Txs{a, c},
+			prepareProposalDiff{removed: 1, removedBytes: 2, removedGas: 2},
+		},
+		{
+			"added", types.Txs{a}, types.Txs{a, e},
+			prepareProposalDiff{added: 1, addedBytes: 5, unknownGasTxs: 1},
+		},
+		{"swapped", types.Txs{a, b, c}, types.Txs{a, c, b}, prepareProposalDiff{reordered: 1}},
+		{"reversed", types.Txs{a, b, c, d}, types.Txs{d, c, b, a}, prepareProposalDiff{reordered: 3}},
+		{
+			"mixed", types.Txs{a, b, c, d}, types.Txs{c, e, a, b},
+			prepareProposalDiff{
+				removed: 1, added: 1, reordered: 1,
+				removedBytes: 4, addedBytes: 5, removedGas: 4, unknownGasTxs: 1,
+			},
+		},
+		{
+			"duplicated", types.Txs{a}, types.Txs{a, a},
+			prepareProposalDiff{added: 1, addedBytes: 1, addedGas: 1},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.diff, diffPrepareProposal(tc.reaped, tc.prepared, gas))
+		})
+	}
+}
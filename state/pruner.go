@@ -0,0 +1,436 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+const (
+	defaultPruningInterval  = 10 * time.Second
+	defaultPruningBatchSize = 1000
+)
+
+// Pruner is a service pruning, in the background, the blocks and the states
+// below the retain height: the highest of the retain height set by the
+// operator, and of the height retaining the last minRetainBlocks blocks.
+//
+// The history of the validator sets and consensus params can also be pruned
+// below the last minRetainHistory heights, keeping the ones of every
+// historyKeepEvery heights.
+//
+// The results of the blocks, i.e. their ABCIResponses, are pruned along with
+// the blocks, unless a results retain height is set by the operator, or
+// minRetainResults is set: they are then pruned independently of the blocks,
+// below the highest of the two.
+//
+// To limit the load of the pruning on the node, at most batchSize blocks, and
+// heights of history, are pruned every interval. Once the pruning caught up
+// with the retain heights, the stores are compacted to reclaim the disk space,
+// if at least compactionBlocks blocks, and heights of history, were pruned
+// since the last compaction.
+type Pruner struct {
+	service.BaseService
+
+	stateStore Store
+	blockStore BlockStore
+
+	mtx    cmtsync.Mutex
+	params pruningParams
+	// the retain height set by the operator
+	retainHeight int64
+	// the results retain height set by the operator
+	resultsRetainHeight int64
+
+	// the number of blocks, and of heights of history, pruned since the last
+	// compaction, only accessed by the pruning routine
+	prunedBlocks int64
+
+	// held while a batch is pruned, see Pause
+	pauseMtx cmtsync.Mutex
+}
+
+// pruningParams are the parameters of the Pruner set with PrunerOption.
+type pruningParams struct {
+	minRetainBlocks  int64
+	minRetainHistory int64
+	historyKeepEvery int64
+	minRetainResults int64
+	interval         time.Duration
+	batchSize        int64
+	compactionBlocks int64
+}
+
+// PrunerOption sets an optional parameter on the Pruner.
+type PrunerOption func(*Pruner)
+
+// PrunerWithMinRetainBlocks prunes the blocks below the last n blocks. 0, the
+// default, only prunes the blocks below the retain height set by the operator.
+func PrunerWithMinRetainBlocks(n int64) PrunerOption {
+	return func(p *Pruner) { p.params.minRetainBlocks = n }
+}
+
+// PrunerWithHistory prunes the validator sets and consensus params below the
+// last n heights, except the ones of the heights multiple of keepEvery, if not
+// 0, whose blocks may still be stored. 0, the default, only prunes them along
+// with the blocks.
+func PrunerWithHistory(n, keepEvery int64) PrunerOption {
+	return func(p *Pruner) {
+		p.params.minRetainHistory = n
+		p.params.historyKeepEvery = keepEvery
+	}
+}
+
+// PrunerWithMinRetainResults prunes the results of the blocks below the last
+// n blocks, independently of the blocks. 0, the default, prunes them along
+// with the blocks, unless a results retain height is set by the operator.
+func PrunerWithMinRetainResults(n int64) PrunerOption {
+	return func(p *Pruner) { p.params.minRetainResults = n }
+}
+
+// PrunerWithInterval sets how often a batch of blocks is pruned.
+func PrunerWithInterval(interval time.Duration) PrunerOption {
+	return func(p *Pruner) { p.params.interval = interval }
+}
+
+// PrunerWithBatchSize sets the maximum number of blocks pruned every
+// interval.
+func PrunerWithBatchSize(n int64) PrunerOption {
+	return func(p *Pruner) { p.params.batchSize = n }
+}
+
+// PrunerWithCompaction compacts the stores once at least n blocks were pruned
+// since the last compaction. 0, the default, disables the compaction.
+func PrunerWithCompaction(n int64) PrunerOption {
+	return func(p *Pruner) { p.params.compactionBlocks = n }
+}
+
+// NewPruner returns a new Pruner of the given stores.
+func NewPruner(stateStore Store, blockStore BlockStore, logger log.Logger, options ...PrunerOption) *Pruner {
+	p := &Pruner{
+		stateStore: stateStore,
+		blockStore: blockStore,
+		params: pruningParams{
+			interval:  defaultPruningInterval,
+			batchSize: defaultPruningBatchSize,
+		},
+	}
+	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// SetOptions changes the parameters of a running Pruner, e.g. upon a reload of
+// the configuration.
+func (p *Pruner) SetOptions(options ...PrunerOption) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, option := range options {
+		option(p)
+	}
+}
+
+func (p *Pruner) getParams() pruningParams {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.params
+}
+
+// OnStart implements service.Service by loading the retain heights set by the
+// operator and starting the pruning routine.
+func (p *Pruner) OnStart() error {
+	retainHeight, err := p.stateStore.LoadBlockRetainHeight()
+	if err != nil {
+		return fmt.Errorf("loading the block retain height: %w", err)
+	}
+	resultsRetainHeight, err := p.stateStore.LoadResultsRetainHeight()
+	if err != nil {
+		return fmt.Errorf("loading the results retain height: %w", err)
+	}
+	p.mtx.Lock()
+	p.retainHeight = retainHeight
+	p.resultsRetainHeight = resultsRetainHeight
+	p.mtx.Unlock()
+
+	go p.pruneRoutine()
+	return nil
+}
+
+// SetBlockRetainHeight sets the height below which the blocks are pruned. 0
+// unsets it. The retain height can't be above the latest height.
+func (p *Pruner) SetBlockRetainHeight(height int64) error {
+	if height < 0 {
+		return fmt.Errorf("retain height %d can't be negative", height)
+	}
+	if latest := p.blockStore.Height(); height > latest {
+		return fmt.Errorf("retain height %d is above the latest height %d", height, latest)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if err := p.stateStore.SaveBlockRetainHeight(height); err != nil {
+		return fmt.Errorf("saving the block retain height: %w", err)
+	}
+	p.retainHeight = height
+	return nil
+}
+
+// BlockRetainHeight returns the retain height set by the operator, or 0 if
+// none.
+func (p *Pruner) BlockRetainHeight() int64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.retainHeight
+}
+
+// PruningRetainHeight returns the height below which the blocks are pruned,
+// given the latest height, or 0 if none are.
+func (p *Pruner) PruningRetainHeight(latest int64) int64 {
+	p.mtx.Lock()
+	retainHeight, minRetainBlocks := p.retainHeight, p.params.minRetainBlocks
+	p.mtx.Unlock()
+	if minRetainBlocks > 0 && latest-minRetainBlocks+1 > retainHeight {
+		retainHeight = latest - minRetainBlocks + 1
+	}
+	if retainHeight > latest {
+		retainHeight = latest
+	}
+	return retainHeight
+}
+
+// SetResultsRetainHeight sets the height below which the results of the
+// blocks are pruned, independently of the blocks. 0 unsets it. The retain
+// height can't be above the latest height.
+func (p *Pruner) SetResultsRetainHeight(height int64) error {
+	if height < 0 {
+		return fmt.Errorf("results retain height %d can't be negative", height)
+	}
+	if latest := p.blockStore.Height(); height > latest {
+		return fmt.Errorf("results retain height %d is above the latest height %d", height, latest)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if err := p.stateStore.SaveResultsRetainHeight(height); err != nil {
+		return fmt.Errorf("saving the results retain height: %w", err)
+	}
+	p.resultsRetainHeight = height
+	return nil
+}
+
+// ResultsRetainHeight returns the results retain height set by the operator,
+// or 0 if none.
+func (p *Pruner) ResultsRetainHeight() int64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.resultsRetainHeight
+}
+
+// ResultsPruningRetainHeight returns the height below which the results of the
+// blocks are pruned, given the latest height, or 0 if none are. Unless they
+// are pruned independently, it's the base of the block store.
+func (p *Pruner) ResultsPruningRetainHeight(latest int64) int64 {
+	p.mtx.Lock()
+	retainHeight, minRetainResults := p.resultsRetainHeight, p.params.minRetainResults
+	p.mtx.Unlock()
+	if retainHeight == 0 && minRetainResults <= 0 {
+		retainHeight = p.blockStore.Base()
+	} else if minRetainResults > 0 && latest-minRetainResults+1 > retainHeight {
+		retainHeight = latest - minRetainResults + 1
+	}
+	if retainHeight > latest {
+		retainHeight = latest
+	}
+	return retainHeight
+}
+
+// Pause waits for the batch being pruned, if any, and pauses the pruning
+// until resume is called, e.g. to take a consistent backup of the stores.
+func (p *Pruner) Pause() (resume func()) {
+	p.pauseMtx.Lock()
+	return p.pauseMtx.Unlock
+}
+
+func (p *Pruner) pruneRoutine() {
+	interval := p.getParams().interval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.pauseMtx.Lock()
+			err := p.prune()
+			p.pauseMtx.Unlock()
+			if err != nil {
+				p.Logger.Error("Failed to prune blocks", "err", err)
+			}
+			if i := p.getParams().interval; i != interval {
+				interval = i
+				ticker.Reset(interval)
+			}
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// prune prunes a batch of blocks, a batch of the history of the validator
+// sets and consensus params, and a batch of the results of the blocks, and
+// compacts the stores once it caught up with the retain heights.
+func (p *Pruner) prune() error {
+	state, err := p.stateStore.Load()
+	if err != nil {
+		return err
+	}
+	params := p.getParams()
+	blocksDone, err := p.pruneBlocks(state, params)
+	if err != nil {
+		return err
+	}
+	historyDone, err := p.pruneHistory(state, params)
+	if err != nil {
+		return err
+	}
+	resultsDone, err := p.pruneResults(state, params)
+	if err != nil {
+		return err
+	}
+
+	if blocksDone && historyDone && resultsDone && params.compactionBlocks > 0 && p.prunedBlocks >= params.compactionBlocks {
+		p.compact()
+	}
+	return nil
+}
+
+// pruneBlocks prunes a batch of blocks, and reports whether it caught up with
+// the retain height.
+func (p *Pruner) pruneBlocks(state State, params pruningParams) (bool, error) {
+	// the blocks above the last height of the state, whose states are not
+	// saved yet, are never pruned
+	retainHeight := p.PruningRetainHeight(state.LastBlockHeight)
+	base := p.blockStore.Base()
+	if retainHeight <= base {
+		return true, nil
+	}
+
+	height := base + params.batchSize
+	if height > retainHeight {
+		height = retainHeight
+	}
+	pruned, evidenceRetainHeight, err := p.blockStore.PruneBlocks(height, state)
+	if err != nil {
+		return false, fmt.Errorf("pruning blocks up to %d: %w", height, err)
+	}
+	if err := p.stateStore.PruneStates(base, height, evidenceRetainHeight); err != nil {
+		return false, fmt.Errorf("pruning states from %d to %d: %w", base, height, err)
+	}
+	p.Logger.Debug("Pruned blocks", "pruned", pruned, "retain_height", height)
+	p.prunedBlocks += int64(pruned)
+	return height == retainHeight, nil
+}
+
+// HistoryRetainHeight returns the height below which the validator sets and
+// consensus params are pruned, given the state, or 0 if none are. The
+// validator sets needed to verify evidence are retained.
+func (p *Pruner) HistoryRetainHeight(state State) int64 {
+	minRetainHistory := p.getParams().minRetainHistory
+	if minRetainHistory <= 0 {
+		return 0
+	}
+	retainHeight := state.LastBlockHeight - minRetainHistory + 1
+	evidenceParams := state.ConsensusParams.Evidence
+	if h := state.LastBlockHeight - evidenceParams.MaxAgeNumBlocks; h < retainHeight {
+		retainHeight = h
+	}
+	if retainHeight <= 1 {
+		return 0
+	}
+	// the evidence of the last pruned height must also be expired by its age
+	if meta := p.blockStore.LoadBlockMeta(retainHeight - 1); meta != nil &&
+		state.LastBlockTime.Sub(meta.Header.Time) <= evidenceParams.MaxAgeDuration {
+		return 0
+	}
+	return retainHeight
+}
+
+// pruneHistory prunes a batch of the history of the validator sets and
+// consensus params, and reports whether it caught up with the retain height.
+// The first time, the history is pruned all at once.
+func (p *Pruner) pruneHistory(state State, params pruningParams) (bool, error) {
+	retainHeight := p.HistoryRetainHeight(state)
+	base, err := p.stateStore.LoadHistoryRetainHeight()
+	if err != nil {
+		return false, err
+	}
+	// the history below the base of the block store is pruned along with the
+	// blocks
+	if retainHeight <= base || retainHeight <= p.blockStore.Base() {
+		return true, nil
+	}
+
+	height := retainHeight
+	if base > 0 && base+params.batchSize < height {
+		height = base + params.batchSize
+	}
+	pruned, err := p.stateStore.PruneHistory(height, params.historyKeepEvery)
+	if err != nil {
+		return false, fmt.Errorf("pruning the history up to %d: %w", height, err)
+	}
+	p.Logger.Debug("Pruned the history", "pruned", pruned, "retain_height", height)
+	p.prunedBlocks += int64(pruned)
+	return height == retainHeight, nil
+}
+
+// pruneResults prunes a batch of the results of the blocks, and reports
+// whether it caught up with the retain height. The first time, the results are
+// pruned all at once.
+func (p *Pruner) pruneResults(state State, params pruningParams) (bool, error) {
+	retainHeight := p.ResultsPruningRetainHeight(state.LastBlockHeight)
+	base, err := p.stateStore.LoadResultsBase()
+	if err != nil {
+		return false, err
+	}
+	// no results were saved below the first height
+	if retainHeight <= base || retainHeight <= state.InitialHeight {
+		return true, nil
+	}
+
+	height := retainHeight
+	if base > 0 && base+params.batchSize < height {
+		height = base + params.batchSize
+	}
+	pruned, err := p.stateStore.PruneABCIResponses(height)
+	if err != nil {
+		return false, fmt.Errorf("pruning the results up to %d: %w", height, err)
+	}
+	p.Logger.Debug("Pruned the results", "pruned", pruned, "retain_height", height)
+	p.prunedBlocks += int64(pruned)
+	return height == retainHeight, nil
+}
+
+// compactor is a store whose database can be compacted.
+type compactor interface {
+	Compact() error
+}
+
+func (p *Pruner) compact() {
+	p.Logger.Info("Compacting the stores")
+	for _, store := range []interface{}{p.blockStore, p.stateStore} {
+		c, ok := store.(compactor)
+		if !ok {
+			continue
+		}
+		if err := c.Compact(); err == ErrCompactionNotSupported {
+			p.Logger.Debug("Not compacting a store", "err", err)
+		} else if err != nil {
+			p.Logger.Error("Failed to compact a store", "err", err)
+		}
+	}
+	p.prunedBlocks = 0
+}
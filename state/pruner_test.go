@@ -0,0 +1,188 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+)
+
+func makePrunerStores(t *testing.T, height int64) (sm.Store, *store.BlockStore) {
+	state, stateDB, _ := makeState(1, int(height)+1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	for h := int64(1); h <= height; h++ {
+		block := makeBlock(state, h, new(types.Commit))
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: h})
+	}
+	return stateStore, blockStore
+}
+
+func TestPrunerRetainHeight(t *testing.T) {
+	stateStore, blockStore := makePrunerStores(t, 20)
+	pruner := sm.NewPruner(stateStore, blockStore, log.TestingLogger(), sm.PrunerWithMinRetainBlocks(5))
+
+	assert.Zero(t, pruner.BlockRetainHeight())
+	assert.EqualValues(t, 16, pruner.PruningRetainHeight(20))
+	assert.EqualValues(t, 1, pruner.PruningRetainHeight(5))
+
+	require.Error(t, pruner.SetBlockRetainHeight(-1))
+	require.Error(t, pruner.SetBlockRetainHeight(21))
+
+	require.NoError(t, pruner.SetBlockRetainHeight(18))
+	assert.EqualValues(t, 18, pruner.BlockRetainHeight())
+	assert.EqualValues(t, 18, pruner.PruningRetainHeight(20))
+	assert.EqualValues(t, 16, pruner.PruningRetainHeight(16))
+
+	retainHeight, err := stateStore.LoadBlockRetainHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 18, retainHeight)
+
+	// the retain height is loaded back on start
+	pruner = sm.NewPruner(stateStore, blockStore, log.TestingLogger(), sm.PrunerWithInterval(time.Hour))
+	require.NoError(t, pruner.Start())
+	t.Cleanup(func() { _ = pruner.Stop() })
+	assert.EqualValues(t, 18, pruner.BlockRetainHeight())
+}
+
+func TestPrunerPrunesInBatches(t *testing.T) {
+	stateStore, blockStore := makePrunerStores(t, 20)
+	pruner := sm.NewPruner(
+		stateStore,
+		blockStore,
+		log.TestingLogger(),
+		sm.PrunerWithInterval(10*time.Millisecond),
+		sm.PrunerWithBatchSize(3),
+		sm.PrunerWithCompaction(1),
+	)
+	require.NoError(t, pruner.SetBlockRetainHeight(15))
+	require.NoError(t, pruner.Start())
+	t.Cleanup(func() { _ = pruner.Stop() })
+
+	require.Eventually(t, func() bool {
+		return blockStore.Base() == 15
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Nil(t, blockStore.LoadBlock(14))
+	assert.NotNil(t, blockStore.LoadBlock(15))
+	_, err := stateStore.LoadValidators(15)
+	require.NoError(t, err)
+
+	// the blocks above the retain height are kept
+	time.Sleep(50 * time.Millisecond)
+	assert.EqualValues(t, 15, blockStore.Base())
+}
+
+func TestPrunerPrunesHistory(t *testing.T) {
+	stateStore, blockStore := makePrunerStores(t, 20)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+
+	pruner := sm.NewPruner(stateStore, blockStore, log.TestingLogger())
+	assert.Zero(t, pruner.HistoryRetainHeight(state))
+
+	// the validator sets needed to verify evidence are retained
+	pruner = sm.NewPruner(
+		stateStore,
+		blockStore,
+		log.TestingLogger(),
+		sm.PrunerWithInterval(10*time.Millisecond),
+		sm.PrunerWithHistory(5, 4),
+	)
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 10
+	state.ConsensusParams.Evidence.MaxAgeDuration = time.Hour
+	state.LastBlockTime = blockStore.LoadBlockMeta(20).Header.Time
+	assert.Zero(t, pruner.HistoryRetainHeight(state))
+
+	state.LastBlockTime = state.LastBlockTime.Add(2 * time.Hour)
+	assert.EqualValues(t, 10, pruner.HistoryRetainHeight(state))
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 2
+	assert.EqualValues(t, 16, pruner.HistoryRetainHeight(state))
+
+	require.NoError(t, stateStore.Save(state))
+	require.NoError(t, pruner.Start())
+	t.Cleanup(func() { _ = pruner.Stop() })
+
+	require.Eventually(t, func() bool {
+		retainHeight, err := stateStore.LoadHistoryRetainHeight()
+		require.NoError(t, err)
+		return retainHeight == 16
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for h := int64(1); h <= 20; h++ {
+		_, err := stateStore.LoadValidators(h)
+		if h == 1 || h%4 == 0 || h >= 16 {
+			require.NoError(t, err, "validators height %v", h)
+		} else {
+			require.Error(t, err, "validators height %v", h)
+		}
+	}
+	// the blocks are retained
+	assert.EqualValues(t, 1, blockStore.Base())
+}
+
+func TestPrunerPrunesResults(t *testing.T) {
+	stateStore, blockStore := makePrunerStores(t, 20)
+	for h := int64(1); h <= 20; h++ {
+		require.NoError(t, stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}))
+	}
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	state.LastBlockHeight = 20
+	require.NoError(t, stateStore.Save(state))
+
+	// the results are pruned along with the blocks by default
+	pruner := sm.NewPruner(stateStore, blockStore, log.TestingLogger())
+	assert.EqualValues(t, 1, pruner.ResultsPruningRetainHeight(20))
+
+	pruner = sm.NewPruner(
+		stateStore,
+		blockStore,
+		log.TestingLogger(),
+		sm.PrunerWithInterval(10*time.Millisecond),
+		sm.PrunerWithBatchSize(3),
+		sm.PrunerWithMinRetainResults(10),
+	)
+	assert.EqualValues(t, 11, pruner.ResultsPruningRetainHeight(20))
+	require.Error(t, pruner.SetResultsRetainHeight(21))
+	require.NoError(t, pruner.SetResultsRetainHeight(14))
+	assert.EqualValues(t, 14, pruner.ResultsRetainHeight())
+	assert.EqualValues(t, 14, pruner.ResultsPruningRetainHeight(20))
+
+	require.NoError(t, pruner.Start())
+	t.Cleanup(func() { _ = pruner.Stop() })
+
+	require.Eventually(t, func() bool {
+		base, err := stateStore.LoadResultsBase()
+		require.NoError(t, err)
+		return base == 14
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for h := int64(1); h <= 20; h++ {
+		_, err := stateStore.LoadABCIResponses(h)
+		if h < 14 {
+			require.Error(t, err, "abci height %v", h)
+		} else {
+			require.NoError(t, err, "abci height %v", h)
+		}
+	}
+	// the blocks are retained
+	assert.EqualValues(t, 1, blockStore.Base())
+}
@@ -123,3 +123,52 @@ func Rollback(bs BlockStore, ss Store, removeBlock bool) (int64, []byte, error)
 
 	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
 }
+
+// RollbackTo rolls back the CometBFT state, the blocks and the ABCI responses
+// down to the given height, so that both the last state and last block height
+// are equal to it. The heights rolled back must be within the evidence window
+// of the consensus params, since the misbehavior of the validators at older
+// heights can no longer be punished, and their blocks must not be pruned.
+// Note that this function does not affect application state.
+func RollbackTo(bs BlockStore, ss Store, height int64) (int64, []byte, error) {
+	st, err := ss.Load()
+	if err != nil {
+		return -1, nil, err
+	}
+	if st.IsEmpty() {
+		return -1, nil, errors.New("no state found")
+	}
+	if height >= st.LastBlockHeight {
+		return -1, nil, fmt.Errorf("height %d is not below the last block height %d", height, st.LastBlockHeight)
+	}
+	if lowest := max(bs.Base(), st.InitialHeight); height < lowest {
+		return -1, nil, fmt.Errorf("can't roll back to height %d, the lowest height is %d", height, lowest)
+	}
+
+	evidenceParams := st.ConsensusParams.Evidence
+	if heights := st.LastBlockHeight - height; heights > evidenceParams.MaxAgeNumBlocks {
+		return -1, nil, fmt.Errorf("can't roll back %d heights, more than the evidence window of %d blocks",
+			heights, evidenceParams.MaxAgeNumBlocks)
+	}
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil {
+		return -1, nil, fmt.Errorf("block at height %d not found", height)
+	}
+	if age := st.LastBlockTime.Sub(meta.Header.Time); age > evidenceParams.MaxAgeDuration {
+		return -1, nil, fmt.Errorf("can't roll back to the block of %v ago, older than the evidence window of %v",
+			age, evidenceParams.MaxAgeDuration)
+	}
+
+	// Each rollback removes the last block and the state of its height.
+	lastHeight, appHash := st.LastBlockHeight, st.AppHash
+	for lastHeight > height || bs.Height() > height {
+		removed := bs.Height()
+		if lastHeight, appHash, err = Rollback(bs, ss, true); err != nil {
+			return -1, nil, err
+		}
+		if err := ss.DeleteABCIResponses(removed); err != nil {
+			return -1, nil, fmt.Errorf("failed to remove the ABCI responses of height %d: %w", removed, err)
+		}
+	}
+	return lastHeight, appHash, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
@@ -202,6 +203,102 @@ func TestRollbackHard(t *testing.T) {
 	require.Equal(t, rollbackHash, currState.AppHash)
 }
 
+func TestRollbackTo(t *testing.T) {
+	const (
+		first = int64(10)
+		last  = int64(14)
+	)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := state.NewStore(dbm.NewMemDB(), state.StoreOptions{DiscardABCIResponses: false})
+	valSet, _ := types.RandValidatorSet(5, 10)
+	params := types.DefaultConsensusParams()
+	params.Evidence.MaxAgeNumBlocks = 3
+	params.Evidence.MaxAgeDuration = 25 * time.Second
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var lastBlockID types.BlockID
+	appHashes := make(map[int64][]byte)
+	for height := first; height <= last; height++ {
+		appHashes[height] = crypto.CRandBytes(tmhash.Size)
+		block := &types.Block{
+			Header: types.Header{
+				Version:            cmtversion.Consensus{Block: version.BlockProtocol, App: 1},
+				ChainID:            "test-chain",
+				Time:               start.Add(time.Duration(height-first) * 10 * time.Second),
+				Height:             height,
+				AppHash:            appHashes[height-1],
+				LastBlockID:        lastBlockID,
+				ValidatorsHash:     valSet.Hash(),
+				NextValidatorsHash: valSet.Hash(),
+				ConsensusHash:      params.Hash(),
+				ProposerAddress:    valSet.Proposer.Address,
+			},
+			LastCommit: &types.Commit{Height: height - 1},
+		}
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+		lastBlockID = types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+
+		st := state.State{
+			Version: cmtstate.Version{
+				Consensus: block.Header.Version,
+				Software:  version.TMCoreSemVer,
+			},
+			ChainID:                          "test-chain",
+			InitialHeight:                    1,
+			LastBlockHeight:                  height,
+			LastBlockID:                      lastBlockID,
+			LastBlockTime:                    block.Time,
+			AppHash:                          appHashes[height],
+			LastValidators:                   valSet,
+			Validators:                       valSet,
+			NextValidators:                   valSet,
+			LastHeightValidatorsChanged:      first + 1,
+			ConsensusParams:                  *params,
+			LastHeightConsensusParamsChanged: first + 1,
+		}
+		if height == first {
+			require.NoError(t, stateStore.Bootstrap(st))
+		} else {
+			require.NoError(t, stateStore.Save(st))
+		}
+		require.NoError(t, stateStore.SaveABCIResponses(height, &cmtstate.ABCIResponses{EndBlock: &abci.ResponseEndBlock{}}))
+	}
+
+	// beyond the evidence window, in number of blocks or duration
+	_, _, err := state.RollbackTo(blockStore, stateStore, last-4)
+	require.ErrorContains(t, err, "evidence window of 3 blocks")
+	_, _, err = state.RollbackTo(blockStore, stateStore, last-3)
+	require.ErrorContains(t, err, "evidence window of 25s")
+	_, _, err = state.RollbackTo(blockStore, stateStore, last)
+	require.Error(t, err)
+
+	height, appHash, err := state.RollbackTo(blockStore, stateStore, last-2)
+	require.NoError(t, err)
+	require.Equal(t, last-2, height)
+	require.Equal(t, appHashes[last-2], appHash)
+	require.Equal(t, last-2, blockStore.Height())
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, last-2, loadedState.LastBlockHeight)
+	require.Equal(t, appHashes[last-2], loadedState.AppHash)
+
+	for height := first; height <= last; height++ {
+		_, err := stateStore.LoadABCIResponses(height)
+		if height <= last-2 {
+			require.NoError(t, err)
+		} else {
+			require.Equal(t, state.ErrNoABCIResponsesForHeight{Height: height}, err)
+		}
+	}
+
+	// below the base of the block store
+	_, _, err = state.RollbackTo(blockStore, stateStore, first-1)
+	require.ErrorContains(t, err, "the lowest height is 10")
+}
+
 func TestRollbackNoState(t *testing.T) {
 	stateStore := state.NewStore(dbm.NewMemDB(),
 		state.StoreOptions{
@@ -30,6 +30,7 @@ type BlockStore interface {
 
 	LoadBlockByHash(hash []byte) *types.Block
 	LoadBlockMetaByHash(hash []byte) *types.BlockMeta
+	LoadBlockHeightByHash(hash []byte) int64
 	LoadBlockPart(height int64, index int) *types.Part
 
 	LoadBlockCommit(height int64) *types.Commit
@@ -244,9 +244,12 @@ func (state State) MakeBlock(
 
 	// Set time.
 	var timestamp time.Time
-	if height == state.InitialHeight {
+	switch {
+	case state.ConsensusParams.Feature.PbtsEnabled(height):
+		timestamp = proposerTime(state.LastBlockTime)
+	case height == state.InitialHeight:
 		timestamp = state.LastBlockTime // genesis time
-	} else {
+	default:
 		timestamp = MedianTime(lastCommit, state.LastValidators)
 	}
 
@@ -262,6 +265,26 @@ func (state State) MakeBlock(
 	return block
 }
 
+// aggregatesLastCommit returns true if the last commit of the block at the
+// given height must be aggregated: aggregated commits are enabled at the height
+// and all the validators of the last commit have BLS12-381 keys.
+func (state State) aggregatesLastCommit(height int64) bool {
+	return height > state.InitialHeight &&
+		state.ConsensusParams.Feature.BlsAggregatedCommitEnabled(height) &&
+		state.LastValidators.AllKeysHaveType(types.ABCIPubKeyTypeBls12381)
+}
+
+// proposerTime returns the time of a block proposed with proposer-based
+// timestamps: the local time, unless it is not after the time of the last
+// block, which happens if the local clock is behind.
+func proposerTime(lastBlockTime time.Time) time.Time {
+	now := cmttime.Now()
+	if !now.After(lastBlockTime) {
+		return lastBlockTime.Add(time.Millisecond)
+	}
+	return now
+}
+
 // MedianTime computes a median time for a given Commit (based on Timestamp field of votes messages) and the
 // corresponding validator set. The computed time is always between timestamps of
 // the votes sent by honest processes, i.e., a faulty processes can not arbitrarily increase or decrease the
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +22,7 @@ import (
 	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
 	sm "github.com/cometbft/cometbft/state"
 	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
 )
 
 // setupTestCase does setup common to all test cases.
@@ -1031,6 +1033,25 @@ func TestStateMakeBlock(t *testing.T) {
 	assert.Equal(t, proposerAddress, block.ProposerAddress)
 }
 
+func TestStateMakeBlockPBTS(t *testing.T) {
+	tearDown, _, state := setupTestCase(t)
+	defer tearDown(t)
+
+	state.ConsensusParams.Feature.PbtsEnableHeight = 2
+	state.ConsensusParams.Synchrony = types.DefaultSynchronyParams()
+
+	// the proposer uses its local time
+	before := cmttime.Now()
+	block := makeBlock(state, 2, new(types.Commit))
+	assert.False(t, block.Time.Before(before))
+	assert.False(t, block.Time.After(cmttime.Now()))
+
+	// unless its clock is behind the last block time
+	state.LastBlockTime = cmttime.Now().Add(time.Hour)
+	block = makeBlock(state, 2, new(types.Commit))
+	assert.Equal(t, state.LastBlockTime.Add(time.Millisecond), block.Time)
+}
+
 // TestConsensusParamsChangesSaveLoad tests saving and loading consensus params
 // with changes.
 func TestConsensusParamsChangesSaveLoad(t *testing.T) {
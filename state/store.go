@@ -3,6 +3,7 @@ package state
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/gogoproto/proto"
 
@@ -40,7 +41,20 @@ func calcABCIResponsesKey(height int64) []byte {
 
 //----------------------
 
-var lastABCIResponseKey = []byte("lastABCIResponseKey")
+var (
+	lastABCIResponseKey      = []byte("lastABCIResponseKey")
+	blockRetainHeightKey     = []byte("blockRetainHeightKey")
+	historyRetainHeightKey   = []byte("historyRetainHeightKey")
+	resultsRetainHeightKey   = []byte("resultsRetainHeightKey")
+	resultsBaseKey           = []byte("resultsBaseKey")
+	validatorsKeyPrefix      = []byte("validatorsKey:")
+	consensusParamsKeyPrefix = []byte("consensusParamsKey:")
+	abciResponsesKeyPrefix   = []byte("abciResponsesKey:")
+)
+
+// historyMigrationChunkSize is the number of keys deleted at once when the
+// history of a store is pruned for the first time.
+var historyMigrationChunkSize = 10000
 
 //go:generate ../scripts/mockery_generate.sh Store
 
@@ -69,10 +83,28 @@ type Store interface {
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *cmtstate.ABCIResponses) error
+	// DeleteABCIResponses deletes the ABCIResponses of a given height, e.g. when its block is rolled back
+	DeleteABCIResponses(int64) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start pruning and which height stop at
 	PruneStates(int64, int64, int64) error
+	// SaveBlockRetainHeight saves the retain height set by the operator
+	SaveBlockRetainHeight(int64) error
+	// LoadBlockRetainHeight loads the retain height set by the operator, or 0 if none
+	LoadBlockRetainHeight() (int64, error)
+	// PruneHistory deletes the validator sets and consensus params below a height, except every keepEvery heights
+	PruneHistory(int64, int64) (uint64, error)
+	// LoadHistoryRetainHeight loads the height below which the history was pruned, or 0 if it never was
+	LoadHistoryRetainHeight() (int64, error)
+	// SaveResultsRetainHeight saves the results retain height set by the operator
+	SaveResultsRetainHeight(int64) error
+	// LoadResultsRetainHeight loads the results retain height set by the operator, or 0 if none
+	LoadResultsRetainHeight() (int64, error)
+	// PruneABCIResponses deletes the ABCIResponses below a height
+	PruneABCIResponses(int64) (uint64, error)
+	// LoadResultsBase loads the height below which the ABCIResponses were pruned, or 0 if they never were
+	LoadResultsBase() (int64, error)
 	// Close closes the connection with the database
 	Close() error
 }
@@ -233,6 +265,7 @@ func (store dbStore) Bootstrap(state State) error {
 // PruneStates deletes states between the given heights (including from, excluding to). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at to must also exist.
+// The ABCIResponses are not deleted, see PruneABCIResponses.
 //
 // The from parameter is necessary since we can't do a key scan in a performant way due to the key
 // encoding not preserving ordering: https://github.com/tendermint/tendermint/issues/4567
@@ -246,23 +279,35 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 		return fmt.Errorf("from height %v must be lower than to height %v", from, to)
 	}
 
-	valInfo, err := loadValidatorsInfo(store.db, min(to, evidenceThresholdHeight))
-	if err != nil {
-		return fmt.Errorf("validators at height %v not found: %w", to, err)
-	}
-	paramsInfo, err := store.loadConsensusParamsInfo(to)
+	// The validator sets and consensus params below the history retain height
+	// were already pruned by PruneHistory, except the ones it keeps, which are
+	// pruned along with the blocks.
+	historyRetainHeight, err := store.LoadHistoryRetainHeight()
 	if err != nil {
-		return fmt.Errorf("consensus params at height %v not found: %w", to, err)
+		return err
 	}
+	pruneVals := min(to, evidenceThresholdHeight) >= historyRetainHeight
 
 	keepVals := make(map[int64]bool)
-	if valInfo.ValidatorSet == nil {
-		keepVals[valInfo.LastHeightChanged] = true
-		keepVals[lastStoredHeightFor(to, valInfo.LastHeightChanged)] = true // keep last checkpoint too
+	if pruneVals {
+		valInfo, err := loadValidatorsInfo(store.db, min(to, evidenceThresholdHeight))
+		if err != nil {
+			return fmt.Errorf("validators at height %v not found: %w", to, err)
+		}
+		if valInfo.ValidatorSet == nil {
+			keepVals[valInfo.LastHeightChanged] = true
+			keepVals[lastStoredHeightFor(to, valInfo.LastHeightChanged)] = true // keep last checkpoint too
+		}
 	}
 	keepParams := make(map[int64]bool)
-	if paramsInfo.ConsensusParams.Equal(&cmtproto.ConsensusParams{}) {
-		keepParams[paramsInfo.LastHeightChanged] = true
+	if to >= historyRetainHeight {
+		paramsInfo, err := store.loadConsensusParamsInfo(to)
+		if err != nil {
+			return fmt.Errorf("consensus params at height %v not found: %w", to, err)
+		}
+		if paramsInfo.ConsensusParams.Equal(&cmtproto.ConsensusParams{}) {
+			keepParams[paramsInfo.LastHeightChanged] = true
+		}
 	}
 
 	batch := store.db.NewBatch()
@@ -340,10 +385,6 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 			}
 		}
 
-		err = batch.Delete(calcABCIResponsesKey(h))
-		if err != nil {
-			return err
-		}
 		pruned++
 
 		// avoid batches growing too large by flushing to database regularly
@@ -366,6 +407,250 @@ func (store dbStore) PruneStates(from int64, to int64, evidenceThresholdHeight i
 	return nil
 }
 
+// PruneHistory deletes the validator sets and consensus params of the heights
+// below to, whose blocks may still be stored. The ones of the heights multiple
+// of keepEvery, if not 0, are kept, so that they can still be loaded, along
+// with the ones the heights from to refer to. It returns the number of
+// validator sets deleted.
+//
+// The heights are pruned from the history retain height, the to of the
+// previous call. The first time, all the validator sets and consensus params
+// of the database are scanned instead, to prune the history saved before the
+// pruning was enabled.
+// Like with PruneStates, the heights kept by a previous call are left behind.
+//
+// The caller must make sure the validator sets needed to verify evidence are
+// not below to.
+func (store dbStore) PruneHistory(to int64, keepEvery int64) (uint64, error) {
+	if to <= 0 {
+		return 0, fmt.Errorf("to height %v must be greater than 0", to)
+	}
+	if keepEvery < 0 {
+		return 0, fmt.Errorf("keep every %v can't be negative", keepEvery)
+	}
+	retainHeight, err := store.LoadHistoryRetainHeight()
+	if err != nil {
+		return 0, err
+	}
+	if to <= retainHeight {
+		return 0, nil
+	}
+
+	keepVals, keepParams, err := store.keepHistory(retainHeight, to, keepEvery)
+	if err != nil {
+		return 0, err
+	}
+	// The retain height is saved before the history is deleted, so that
+	// PruneStates does not load the validator sets and consensus params being
+	// deleted.
+	if err := store.db.SetSync(historyRetainHeightKey, []byte(strconv.FormatInt(to, 10))); err != nil {
+		return 0, err
+	}
+
+	if retainHeight == 0 {
+		return store.migrateHistory(to, keepVals, keepParams)
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	pruned := uint64(0)
+	for h := retainHeight; h < to; h++ {
+		if !keepVals[h] {
+			if err := batch.Delete(calcValidatorsKey(h)); err != nil {
+				return 0, err
+			}
+			pruned++
+		}
+		if !keepParams[h] {
+			if err := batch.Delete(calcConsensusParamsKey(h)); err != nil {
+				return 0, err
+			}
+		}
+
+		// avoid batches growing too large by flushing to database regularly
+		if pruned%1000 == 0 && pruned > 0 {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Close()
+			batch = store.db.NewBatch()
+			defer batch.Close()
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// keepHistory returns the heights whose validator sets and consensus params
+// are kept when pruning the history from the given height to the given
+// height, after making sure they are saved in full rather than as references
+// to the heights being pruned.
+func (store dbStore) keepHistory(from, to, keepEvery int64) (keepVals, keepParams map[int64]bool, err error) {
+	valInfo, err := loadValidatorsInfo(store.db, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("validators at height %v not found: %w", to, err)
+	}
+	paramsInfo, err := store.loadConsensusParamsInfo(to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("consensus params at height %v not found: %w", to, err)
+	}
+
+	keepVals = make(map[int64]bool)
+	if valInfo.ValidatorSet == nil {
+		keepVals[valInfo.LastHeightChanged] = true
+		keepVals[lastStoredHeightFor(to, valInfo.LastHeightChanged)] = true
+	}
+	keepParams = make(map[int64]bool)
+	if paramsInfo.ConsensusParams.Equal(&cmtproto.ConsensusParams{}) {
+		keepParams[paramsInfo.LastHeightChanged] = true
+	}
+	if keepEvery > 0 {
+		for h := (from/keepEvery + 1) * keepEvery; h < to; h += keepEvery {
+			// the history of the heights which were never saved, or were
+			// pruned along with the blocks, can't be kept
+			if _, err := loadValidatorsInfo(store.db, h); err != nil {
+				continue
+			}
+			keepVals[h] = true
+			keepParams[h] = true
+		}
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for h := range keepVals {
+		v, err := loadValidatorsInfo(store.db, h)
+		if err == nil && v.ValidatorSet != nil {
+			continue
+		}
+		vals, err := store.LoadValidators(h)
+		if err != nil {
+			return nil, nil, err
+		}
+		pv, err := vals.ToProto()
+		if err != nil {
+			return nil, nil, err
+		}
+		bz, err := (&cmtstate.ValidatorsInfo{ValidatorSet: pv, LastHeightChanged: h}).Marshal()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := batch.Set(calcValidatorsKey(h), bz); err != nil {
+			return nil, nil, err
+		}
+	}
+	for h := range keepParams {
+		p, err := store.loadConsensusParamsInfo(h)
+		if err == nil && !p.ConsensusParams.Equal(&cmtproto.ConsensusParams{}) {
+			continue
+		}
+		params, err := store.LoadConsensusParams(h)
+		if err != nil {
+			return nil, nil, err
+		}
+		bz, err := (&cmtstate.ConsensusParamsInfo{
+			ConsensusParams:   params.ToProto(),
+			LastHeightChanged: h,
+		}).Marshal()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := batch.Set(calcConsensusParamsKey(h), bz); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return nil, nil, err
+	}
+	return keepVals, keepParams, nil
+}
+
+// migrateHistory deletes all the validator sets and consensus params below to
+// which are not kept. As their keys are not ordered by height, all of them are
+// scanned.
+func (store dbStore) migrateHistory(to int64, keepVals, keepParams map[int64]bool) (uint64, error) {
+	pruned, err := store.deleteHistory(validatorsKeyPrefix, to, keepVals)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := store.deleteHistory(consensusParamsKeyPrefix, to, keepParams); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// deleteHistory deletes the keys with the given prefix of the heights below
+// to which are not kept, and returns their number. The keys are deleted a
+// chunk at a time, since the database can't be written while it is iterated.
+func (store dbStore) deleteHistory(prefix []byte, to int64, keep map[int64]bool) (uint64, error) {
+	end := append([]byte{}, prefix...)
+	end[len(end)-1]++
+
+	deleted := uint64(0)
+	for start := prefix; start != nil; {
+		var (
+			keys [][]byte
+			err  error
+		)
+		keys, start, err = store.scanHistory(start, end, prefix, to, keep)
+		if err != nil {
+			return 0, err
+		}
+
+		batch := store.db.NewBatch()
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				batch.Close()
+				return 0, err
+			}
+		}
+		err = batch.WriteSync()
+		batch.Close()
+		if err != nil {
+			return 0, err
+		}
+		deleted += uint64(len(keys))
+	}
+	return deleted, nil
+}
+
+// scanHistory returns at most historyMigrationChunkSize keys of [start, end)
+// to delete, and the key to resume the scan from, or nil once done.
+func (store dbStore) scanHistory(
+	start, end, prefix []byte,
+	to int64,
+	keep map[int64]bool,
+) ([][]byte, []byte, error) {
+	it, err := store.db.Iterator(start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		if len(keys) == historyMigrationChunkSize {
+			return keys, append([]byte{}, it.Key()...), nil
+		}
+		h, err := strconv.ParseInt(string(it.Key()[len(prefix):]), 10, 64)
+		if err != nil {
+			continue
+		}
+		if h < to && !keep[h] {
+			keys = append(keys, append([]byte{}, it.Key()...))
+		}
+	}
+	return keys, nil, it.Error()
+}
+
+// LoadHistoryRetainHeight loads the height below which the validator sets and
+// consensus params were pruned by PruneHistory, or 0 if they never were.
+func (store dbStore) LoadHistoryRetainHeight() (int64, error) {
+	return store.loadHeight(historyRetainHeightKey)
+}
+
 //------------------------------------------------------------------------
 
 // ABCIResponsesResultsHash returns the root hash of a Merkle tree of
@@ -477,6 +762,112 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *cmtstate.ABC
 	return store.db.SetSync(lastABCIResponseKey, bz)
 }
 
+// DeleteABCIResponses deletes the ABCIResponses of the given height, along
+// with the last ABCI response if it is the one of this height.
+func (store dbStore) DeleteABCIResponses(height int64) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Delete(calcABCIResponsesKey(height)); err != nil {
+		return err
+	}
+	bz, err := store.db.Get(lastABCIResponseKey)
+	if err != nil {
+		return err
+	}
+	if len(bz) > 0 {
+		response := new(cmtstate.ABCIResponsesInfo)
+		if err := response.Unmarshal(bz); err != nil {
+			return err
+		}
+		if response.Height == height {
+			if err := batch.Delete(lastABCIResponseKey); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.WriteSync()
+}
+
+// PruneABCIResponses deletes the ABCIResponses of the heights below to,
+// independently of the states, and returns their number. The heights are
+// pruned from the results base, the to of the previous call. The first time,
+// all the ABCIResponses of the database are scanned instead, to prune the ones
+// saved before they were pruned independently.
+func (store dbStore) PruneABCIResponses(to int64) (uint64, error) {
+	if to <= 0 {
+		return 0, fmt.Errorf("to height %v must be greater than 0", to)
+	}
+	base, err := store.LoadResultsBase()
+	if err != nil {
+		return 0, err
+	}
+	if to <= base {
+		return 0, nil
+	}
+
+	var pruned uint64
+	if base == 0 {
+		pruned, err = store.deleteHistory(abciResponsesKeyPrefix, to, nil)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		batch := store.db.NewBatch()
+		defer batch.Close()
+		for h := base; h < to; h++ {
+			if err := batch.Delete(calcABCIResponsesKey(h)); err != nil {
+				return 0, err
+			}
+			pruned++
+
+			// avoid batches growing too large by flushing to database regularly
+			if pruned%1000 == 0 {
+				if err := batch.Write(); err != nil {
+					return 0, err
+				}
+				batch.Close()
+				batch = store.db.NewBatch()
+				defer batch.Close()
+			}
+		}
+		if err := batch.WriteSync(); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := store.db.SetSync(resultsBaseKey, []byte(strconv.FormatInt(to, 10))); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// LoadResultsBase loads the height below which the ABCIResponses were pruned
+// by PruneABCIResponses, or 0 if they never were.
+func (store dbStore) LoadResultsBase() (int64, error) {
+	return store.loadHeight(resultsBaseKey)
+}
+
+// SaveResultsRetainHeight saves the retain height set by the operator, below
+// which the ABCIResponses are pruned.
+func (store dbStore) SaveResultsRetainHeight(height int64) error {
+	return store.db.SetSync(resultsRetainHeightKey, []byte(strconv.FormatInt(height, 10)))
+}
+
+// LoadResultsRetainHeight loads the results retain height set by the
+// operator, or 0 if none was set.
+func (store dbStore) LoadResultsRetainHeight() (int64, error) {
+	return store.loadHeight(resultsRetainHeightKey)
+}
+
+func (store dbStore) loadHeight(key []byte) (int64, error) {
+	bz, err := store.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return 0, err
+	}
+	return strconv.ParseInt(string(bz), 10, 64)
+}
+
 //-----------------------------------------------------------------------------
 
 // LoadValidators loads the ValidatorSet for a given height.
@@ -661,10 +1052,38 @@ func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, par
 	return nil
 }
 
+// SaveBlockRetainHeight saves the retain height set by the operator, below
+// which the blocks are pruned.
+func (store dbStore) SaveBlockRetainHeight(height int64) error {
+	return store.db.SetSync(blockRetainHeightKey, []byte(strconv.FormatInt(height, 10)))
+}
+
+// LoadBlockRetainHeight loads the retain height set by the operator, or 0 if
+// none was set.
+func (store dbStore) LoadBlockRetainHeight() (int64, error) {
+	return store.loadHeight(blockRetainHeightKey)
+}
+
+// Compact compacts the database, to reclaim the disk space of the pruned
+// states.
+func (store dbStore) Compact() error {
+	return CompactDB(store.db)
+}
+
 func (store dbStore) Close() error {
 	return store.db.Close()
 }
 
+// CompactDB compacts the database, to reclaim the disk space of the deleted
+// entries. Only the goleveldb, pebbledb, cleveldb and rocksdb databases are
+// actually compacted, and the in-memory ones can't be.
+func CompactDB(db dbm.DB) error {
+	if _, ok := db.(*dbm.MemDB); ok {
+		return ErrCompactionNotSupported
+	}
+	return db.Compact(nil, nil)
+}
+
 func min(a int64, b int64) int64 {
 	if a < b {
 		return a
@@ -95,26 +95,23 @@ func TestPruneStates(t *testing.T) {
 		expectErr               bool
 		expectVals              []int64
 		expectParams            []int64
-		expectABCI              []int64
 	}{
-		"error on pruning from 0":      {100, 0, 5, 100, true, nil, nil, nil},
-		"error when from > to":         {100, 3, 2, 2, true, nil, nil, nil},
-		"error when from == to":        {100, 3, 3, 3, true, nil, nil, nil},
-		"error when to does not exist": {100, 1, 101, 101, true, nil, nil, nil},
-		"prune all":                    {100, 1, 100, 100, false, []int64{93, 100}, []int64{95, 100}, []int64{100}},
+		"error on pruning from 0":      {100, 0, 5, 100, true, nil, nil},
+		"error when from > to":         {100, 3, 2, 2, true, nil, nil},
+		"error when from == to":        {100, 3, 3, 3, true, nil, nil},
+		"error when to does not exist": {100, 1, 101, 101, true, nil, nil},
+		"prune all":                    {100, 1, 100, 100, false, []int64{93, 100}, []int64{95, 100}},
 		"prune some": {
 			10, 2, 8, 8, false,
 			[]int64{1, 3, 8, 9, 10},
 			[]int64{1, 5, 8, 9, 10},
-			[]int64{1, 8, 9, 10},
 		},
 		"prune across checkpoint": {
 			100001, 1, 100001, 100001, false,
 			[]int64{99993, 100000, 100001},
 			[]int64{99995, 100001},
-			[]int64{100001},
 		},
-		"prune when evidence height < height": {20, 1, 18, 17, false, []int64{13, 17, 18, 19, 20}, []int64{15, 18, 19, 20}, []int64{18, 19, 20}},
+		"prune when evidence height < height": {20, 1, 18, 17, false, []int64{13, 17, 18, 19, 20}, []int64{15, 18, 19, 20}},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -123,54 +120,7 @@ func TestPruneStates(t *testing.T) {
 			stateStore := sm.NewStore(db, sm.StoreOptions{
 				DiscardABCIResponses: false,
 			})
-			pk := ed25519.GenPrivKey().PubKey()
-
-			// Generate a bunch of state data. Validators change for heights ending with 3, and
-			// parameters when ending with 5.
-			validator := &types.Validator{Address: cmtrand.Bytes(crypto.AddressSize), VotingPower: 100, PubKey: pk}
-			validatorSet := &types.ValidatorSet{
-				Validators: []*types.Validator{validator},
-				Proposer:   validator,
-			}
-			valsChanged := int64(0)
-			paramsChanged := int64(0)
-
-			for h := int64(1); h <= tc.makeHeights; h++ {
-				if valsChanged == 0 || h%10 == 2 {
-					valsChanged = h + 1 // Have to add 1, since NextValidators is what's stored
-				}
-				if paramsChanged == 0 || h%10 == 5 {
-					paramsChanged = h
-				}
-
-				state := sm.State{
-					InitialHeight:   1,
-					LastBlockHeight: h - 1,
-					Validators:      validatorSet,
-					NextValidators:  validatorSet,
-					ConsensusParams: types.ConsensusParams{
-						Block: types.BlockParams{MaxBytes: 10e6},
-					},
-					LastHeightValidatorsChanged:      valsChanged,
-					LastHeightConsensusParamsChanged: paramsChanged,
-				}
-
-				if state.LastBlockHeight >= 1 {
-					state.LastValidators = state.Validators
-				}
-
-				err := stateStore.Save(state)
-				require.NoError(t, err)
-
-				err = stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
-					DeliverTxs: []*abci.ResponseDeliverTx{
-						{Data: []byte{1}},
-						{Data: []byte{2}},
-						{Data: []byte{3}},
-					},
-				})
-				require.NoError(t, err)
-			}
+			saveTestStates(t, stateStore, tc.makeHeights)
 
 			// Test assertions
 			err := stateStore.PruneStates(tc.pruneFrom, tc.pruneTo, tc.evidenceThresholdHeight)
@@ -182,7 +132,6 @@ func TestPruneStates(t *testing.T) {
 
 			expectVals := sliceToMap(tc.expectVals)
 			expectParams := sliceToMap(tc.expectParams)
-			expectABCI := sliceToMap(tc.expectABCI)
 
 			for h := int64(1); h <= tc.makeHeights; h++ {
 				vals, err := stateStore.LoadValidators(h)
@@ -203,15 +152,205 @@ func TestPruneStates(t *testing.T) {
 					require.Empty(t, params)
 				}
 
+				// the ABCI responses are pruned independently of the states
 				abci, err := stateStore.LoadABCIResponses(h)
-				if expectABCI[h] {
-					require.NoError(t, err, "abci height %v", h)
-					require.NotNil(t, abci)
+				require.NoError(t, err, "abci height %v", h)
+				require.NotNil(t, abci)
+			}
+		})
+	}
+}
+
+func TestPruneABCIResponses(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	saveTestStates(t, stateStore, 20)
+
+	_, err := stateStore.PruneABCIResponses(0)
+	require.Error(t, err)
+
+	// the first time, all the ABCI responses below the height are pruned
+	pruned, err := stateStore.PruneABCIResponses(5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, pruned)
+	base, err := stateStore.LoadResultsBase()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, base)
+
+	// then, from the previous height
+	pruned, err = stateStore.PruneABCIResponses(12)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, pruned)
+	pruned, err = stateStore.PruneABCIResponses(10)
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+
+	for h := int64(1); h <= 20; h++ {
+		_, err := stateStore.LoadABCIResponses(h)
+		if h < 12 {
+			require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err)
+		} else {
+			require.NoError(t, err, "abci height %v", h)
+		}
+		// the states are kept
+		_, err = stateStore.LoadValidators(h)
+		require.NoError(t, err, "validators height %v", h)
+	}
+}
+
+// saveTestStates saves the states, and the ABCI responses, of the heights
+// from 1 to makeHeights.
+func saveTestStates(t *testing.T, stateStore sm.Store, makeHeights int64) {
+	t.Helper()
+	pk := ed25519.GenPrivKey().PubKey()
+
+	// Generate a bunch of state data. Validators change for heights ending with 3, and
+	// parameters when ending with 5.
+	validator := &types.Validator{Address: cmtrand.Bytes(crypto.AddressSize), VotingPower: 100, PubKey: pk}
+	validatorSet := &types.ValidatorSet{
+		Validators: []*types.Validator{validator},
+		Proposer:   validator,
+	}
+	valsChanged := int64(0)
+	paramsChanged := int64(0)
+
+	for h := int64(1); h <= makeHeights; h++ {
+		if valsChanged == 0 || h%10 == 2 {
+			valsChanged = h + 1 // Have to add 1, since NextValidators is what's stored
+		}
+		if paramsChanged == 0 || h%10 == 5 {
+			paramsChanged = h
+		}
+
+		state := sm.State{
+			InitialHeight:   1,
+			LastBlockHeight: h - 1,
+			Validators:      validatorSet,
+			NextValidators:  validatorSet,
+			ConsensusParams: types.ConsensusParams{
+				Block: types.BlockParams{MaxBytes: 10e6},
+			},
+			LastHeightValidatorsChanged:      valsChanged,
+			LastHeightConsensusParamsChanged: paramsChanged,
+		}
+
+		if state.LastBlockHeight >= 1 {
+			state.LastValidators = state.Validators
+		}
+
+		err := stateStore.Save(state)
+		require.NoError(t, err)
+
+		err = stateStore.SaveABCIResponses(h, &cmtstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{
+				{Data: []byte{1}},
+				{Data: []byte{2}},
+				{Data: []byte{3}},
+			},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	defer sm.SetHistoryMigrationChunkSize(sm.SetHistoryMigrationChunkSize(3))
+
+	type prune struct {
+		to        int64
+		keepEvery int64
+	}
+	testcases := map[string]struct {
+		prunes       []prune
+		expectErr    bool
+		expectVals   []int64
+		expectParams []int64
+	}{
+		"error on pruning to 0":        {[]prune{{0, 0}}, true, nil, nil},
+		"error on negative keep every": {[]prune{{10, -1}}, true, nil, nil},
+		"error when to does not exist": {[]prune{{22, 0}}, true, nil, nil},
+		"migrate": {
+			[]prune{{18, 0}}, false,
+			[]int64{13, 18, 19, 20},
+			[]int64{15, 18, 19, 20},
+		},
+		"migrate keeping every 4": {
+			[]prune{{18, 4}}, false,
+			[]int64{4, 8, 12, 13, 16, 18, 19, 20},
+			[]int64{4, 8, 12, 15, 16, 18, 19, 20},
+		},
+		"prune after migrating": {
+			[]prune{{8, 0}, {18, 0}}, false,
+			[]int64{3, 13, 18, 19, 20},
+			[]int64{5, 15, 18, 19, 20},
+		},
+		"prune after migrating keeping every 5": {
+			[]prune{{8, 5}, {18, 5}}, false,
+			[]int64{3, 5, 10, 13, 15, 18, 19, 20},
+			[]int64{5, 10, 15, 18, 19, 20},
+		},
+		"prune below the retain height": {
+			[]prune{{18, 0}, {10, 0}}, false,
+			[]int64{13, 18, 19, 20},
+			[]int64{15, 18, 19, 20},
+		},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+				DiscardABCIResponses: false,
+			})
+			saveTestStates(t, stateStore, 20)
+
+			var err error
+			for _, p := range tc.prunes {
+				if _, err = stateStore.PruneHistory(p.to, p.keepEvery); err != nil {
+					break
+				}
+			}
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			retainHeight, err := stateStore.LoadHistoryRetainHeight()
+			require.NoError(t, err)
+			assert.EqualValues(t, 18, retainHeight)
+
+			expectVals := sliceToMap(tc.expectVals)
+			expectParams := sliceToMap(tc.expectParams)
+			for h := int64(1); h <= 20; h++ {
+				_, err := stateStore.LoadValidators(h)
+				if expectVals[h] {
+					require.NoError(t, err, "validators height %v", h)
 				} else {
-					require.Error(t, err, "abci height %v", h)
-					require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err)
+					require.Equal(t, sm.ErrNoValSetForHeight{Height: h}, err, "validators height %v", h)
 				}
+
+				_, err = stateStore.LoadConsensusParams(h)
+				if expectParams[h] {
+					require.NoError(t, err, "params height %v", h)
+				} else {
+					require.Error(t, err, "params height %v", h)
+				}
+
+				// the ABCI responses are pruned independently
+				_, err = stateStore.LoadABCIResponses(h)
+				require.NoError(t, err, "abci height %v", h)
 			}
+
+			// the states can still be pruned along with the blocks
+			require.NoError(t, stateStore.PruneStates(1, 19, 19))
+			for h := int64(19); h <= 20; h++ {
+				_, err := stateStore.LoadValidators(h)
+				require.NoError(t, err, "validators height %v", h)
+				_, err = stateStore.LoadConsensusParams(h)
+				require.NoError(t, err, "params height %v", h)
+			}
+			_, err = stateStore.LoadABCIResponses(18)
+			require.NoError(t, err)
 		})
 	}
 }
@@ -15,6 +15,21 @@ func TxPreCheck(state State) mempl.PreCheckFunc {
 	return mempl.PreCheckMaxBytes(maxDataBytes)
 }
 
+// MaxBlockTxBytes returns the size of the largest transaction a block holds
+// under the consensus params of the state, or 0, meaning no bound, if the
+// block can't hold the header and the commit of the validators. Unlike
+// types.MaxDataBytesNoEvidence, it doesn't panic.
+func MaxBlockTxBytes(state State) int64 {
+	maxDataBytes := state.ConsensusParams.Block.MaxBytes -
+		types.MaxOverheadForBlock -
+		types.MaxHeaderBytes -
+		types.MaxCommitBytes(state.Validators.Size())
+	if maxDataBytes <= 0 {
+		return 0
+	}
+	return maxDataBytes
+}
+
 // TxPostCheck returns a function to filter transactions after processing.
 // The function limits the gas wanted by a transaction to the block's maximum total gas.
 func TxPostCheck(state State) mempl.PostCheckFunc {
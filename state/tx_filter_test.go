@@ -25,8 +25,8 @@ func TestTxFilter(t *testing.T) {
 		tx    types.Tx
 		isErr bool
 	}{
-		{types.Tx(cmtrand.Bytes(2155)), false},
-		{types.Tx(cmtrand.Bytes(2156)), true},
+		{types.Tx(cmtrand.Bytes(2122)), false},
+		{types.Tx(cmtrand.Bytes(2123)), true},
 		{types.Tx(cmtrand.Bytes(3000)), true},
 	}
 
@@ -47,3 +47,16 @@ func TestTxFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestMaxBlockTxBytes(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.ConsensusParams.Block.MaxBytes = 3000
+	genDoc.ConsensusParams.Evidence.MaxBytes = 1500
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	assert.EqualValues(t, types.MaxDataBytesNoEvidence(3000, state.Validators.Size()), sm.MaxBlockTxBytes(state))
+
+	// no bound, rather than a panic, if the block can't hold the commit
+	state.ConsensusParams.Block.MaxBytes = types.MaxCommitBytes(state.Validators.Size())
+	assert.Zero(t, sm.MaxBlockTxBytes(state))
+}
@@ -23,6 +23,15 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+
+	pauseCh chan *indexerPause // see Pause
+}
+
+// indexerPause is a request to pause the indexing, see Pause.
+type indexerPause struct {
+	height int64
+	paused chan struct{} // closed once the blocks up to height are indexed
+	resume chan struct{} // closed to resume the indexing
 }
 
 // NewIndexerService returns a new service instance.
@@ -33,7 +42,13 @@ func NewIndexerService(
 	terminateOnError bool,
 ) *IndexerService {
 
-	is := &IndexerService{txIdxr: txIdxr, blockIdxr: blockIdxr, eventBus: eventBus, terminateOnError: terminateOnError}
+	is := &IndexerService{
+		txIdxr:           txIdxr,
+		blockIdxr:        blockIdxr,
+		eventBus:         eventBus,
+		terminateOnError: terminateOnError,
+		pauseCh:          make(chan *indexerPause),
+	}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
 	return is
 }
@@ -58,10 +73,20 @@ func (is *IndexerService) OnStart() error {
 	}
 
 	go func() {
+		var (
+			indexedHeight int64 // the last height indexed since the start
+			pause         *indexerPause
+		)
 		for {
 			select {
 			case <-blockHeadersSub.Canceled():
 				return
+			case p := <-is.pauseCh:
+				if ok, err := is.blockIdxr.Has(p.height); indexedHeight >= p.height || (err == nil && ok) {
+					is.pauseIndexing(p)
+				} else {
+					pause = p
+				}
 			case msg := <-blockHeadersSub.Out():
 
 				eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
@@ -112,12 +137,45 @@ func (is *IndexerService) OnStart() error {
 				} else {
 					is.Logger.Debug("indexed transactions", "height", height, "num_txs", eventDataHeader.NumTxs)
 				}
+
+				indexedHeight = height
+				if pause != nil && indexedHeight >= pause.height {
+					is.pauseIndexing(pause)
+					pause = nil
+				}
 			}
 		}
 	}()
 	return nil
 }
 
+// Pause waits for the blocks up to the given height to be indexed, and pauses
+// the indexing of the next blocks until resume is called, e.g. to take a
+// snapshot of the indexes consistent with the stores, whose commits are paused
+// at the height. The blocks committed meanwhile wait for the indexing to
+// resume, since their events are not buffered.
+func (is *IndexerService) Pause(height int64) (resume func()) {
+	p := &indexerPause{height: height, paused: make(chan struct{}), resume: make(chan struct{})}
+	select {
+	case is.pauseCh <- p:
+	case <-is.Quit():
+		return func() {}
+	}
+	select {
+	case <-p.paused:
+	case <-is.Quit():
+	}
+	return func() { close(p.resume) }
+}
+
+func (is *IndexerService) pauseIndexing(p *indexerPause) {
+	close(p.paused)
+	select {
+	case <-p.resume:
+	case <-is.Quit():
+	}
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/cosmos/gogoproto/proto"
 
@@ -22,6 +24,25 @@ import (
 
 const (
 	tagKeySeparator = "/"
+
+	// numericKeyPrefix prefixes the keys of the numeric index, in which the
+	// event values which are integers are encoded so that the keys sort in the
+	// order of the values, and ranges of values can be iterated over.
+	numericKeyPrefix = "num"
+)
+
+var (
+	// numericIndexedKey marks a store whose values have all been added to the
+	// numeric index.
+	numericIndexedKey = []byte("numeric_indexed")
+
+	// numericIndexProgressKey holds the key of the store to resume the
+	// indexing of the values of an older store from.
+	numericIndexProgressKey = []byte("numeric_index_progress")
+
+	// numericIndexChunkSize is the number of keys added at a time to the
+	// numeric index when indexing the values of an older store.
+	numericIndexChunkSize = 10000
 )
 
 var _ txindex.TxIndexer = (*TxIndex)(nil)
@@ -29,13 +50,152 @@ var _ txindex.TxIndexer = (*TxIndex)(nil)
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
 type TxIndex struct {
 	store dbm.DB
+
+	// numericIndexed is 1 once all the values of the store are in the numeric
+	// index. Until then, the range queries scan the events instead.
+	numericIndexed int32
 }
 
-// NewTxIndex creates new KV indexer.
+// readOnlyStore is implemented by the stores refusing the writes, e.g. those
+// opened by the inspect command.
+type readOnlyStore interface {
+	ReadOnly() bool
+}
+
+// NewTxIndex creates new KV indexer. The values of the events indexed by
+// older versions, which lack the numeric index, are added to it by
+// IndexNumericValues.
 func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
+	txi := &TxIndex{
 		store: store,
 	}
+	// The values added to an empty store are all in the numeric index.
+	if done, err := store.Has(numericIndexedKey); err == nil && (done || isEmpty(store)) {
+		txi.numericIndexed = 1
+	}
+	return txi
+}
+
+func isEmpty(store dbm.DB) bool {
+	it, err := store.Iterator(nil, nil)
+	if err != nil {
+		return false
+	}
+	defer it.Close()
+	return !it.Valid()
+}
+
+// IndexNumericValues adds the integer values of the events of the store to
+// the numeric index, unless it has been done already or the store is
+// read-only. The progress is saved along with each chunk of keys, so that an
+// interrupted indexing resumes where it stopped. It returns ctx.Err() if ctx
+// is done before all the values have been indexed.
+func (txi *TxIndex) IndexNumericValues(ctx context.Context) error {
+	if atomic.LoadInt32(&txi.numericIndexed) == 1 {
+		return nil
+	}
+	if ro, ok := txi.store.(readOnlyStore); ok && ro.ReadOnly() {
+		return nil
+	}
+	done, err := txi.store.Has(numericIndexedKey)
+	if err != nil {
+		return err
+	}
+	if done {
+		atomic.StoreInt32(&txi.numericIndexed, 1)
+		return nil
+	}
+	start, err := txi.store.Get(numericIndexProgressKey)
+	if err != nil {
+		return err
+	}
+
+	// The keys are added by chunks, without iterating over the store while
+	// writing to it, which not all the databases support.
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		next, err := txi.indexNumericValuesChunk(start)
+		if err != nil {
+			return fmt.Errorf("indexing the numeric values from %q: %w", start, err)
+		}
+		if next == nil {
+			break
+		}
+		start = next
+	}
+
+	b := txi.store.NewBatch()
+	defer b.Close()
+	if err := b.Set(numericIndexedKey, []byte{1}); err != nil {
+		return err
+	}
+	if err := b.Delete(numericIndexProgressKey); err != nil {
+		return err
+	}
+	if err := b.WriteSync(); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&txi.numericIndexed, 1)
+	return nil
+}
+
+// indexNumericValuesChunk adds up to numericIndexChunkSize keys to the numeric
+// index from the keys of the store from start, and returns the key to resume
+// from, or nil once the whole store has been indexed. The key to resume from
+// is saved in the same batch.
+func (txi *TxIndex) indexNumericValuesChunk(start []byte) ([]byte, error) {
+	b := txi.store.NewBatch()
+	defer b.Close()
+
+	it, err := txi.store.Iterator(start, nil)
+	if err != nil {
+		return nil, err
+	}
+	var next []byte
+	n := 0
+	for ; it.Valid(); it.Next() {
+		if n == numericIndexChunkSize {
+			next = append([]byte{}, it.Key()...)
+			break
+		}
+		key := it.Key()
+		if !isTagKey(key) {
+			continue
+		}
+		parts := strings.Split(string(key), tagKeySeparator)
+		value, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		height, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		index, err := strconv.ParseUint(parts[3], 10, 32)
+		if err != nil {
+			continue
+		}
+		if err := b.Set(keyForNumericEvent(parts[0], value, height, uint32(index)), it.Value()); err != nil {
+			it.Close()
+			return nil, err
+		}
+		n++
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return nil, err
+	}
+	if err := it.Close(); err != nil {
+		return nil, err
+	}
+	if next != nil {
+		if err := b.Set(numericIndexProgressKey, next); err != nil {
+			return nil, err
+		}
+	}
+	return next, b.Write()
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -84,6 +244,10 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 		if err != nil {
 			return err
 		}
+		err = storeBatch.Set(keyForNumericEvent(types.TxHeightKey, result.Height, result.Height, result.Index), hash)
+		if err != nil {
+			return err
+		}
 
 		rawBytes, err := proto.Marshal(result)
 		if err != nil {
@@ -138,6 +302,10 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	if err != nil {
 		return err
 	}
+	err = b.Set(keyForNumericEvent(types.TxHeightKey, result.Height, result.Height, result.Index), hash)
+	if err != nil {
+		return err
+	}
 
 	rawBytes, err := proto.Marshal(result)
 	if err != nil {
@@ -175,6 +343,14 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				if err != nil {
 					return err
 				}
+				// integer values are also added to the numeric index, for the
+				// range queries
+				if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+					err = store.Set(keyForNumericEvent(compositeTag, v, result.Height, result.Index), hash)
+					if err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -186,10 +362,10 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 //
 // It breaks the query into conditions (like "tx.height > 5"). For each
 // condition, it queries the DB index. One special use cases here: (1) if
-// "tx.hash" is found, it returns tx result for it (2) for range queries it is
-// better for the client to provide both lower and upper bounds, so we are not
-// performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// "tx.hash" is found, it returns tx result for it (2) the range queries on
+// integer values iterate over the numeric index, only over the keys within
+// the bounds. Results from querying indexes are then intersected and returned
+// to the caller, in no particular order.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
@@ -206,6 +382,9 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 
 	// get a list of conditions (like "tx.height > 5")
 	conditions := q.Syntax()
+	if err := indexer.CheckWildcards(conditions); err != nil {
+		return nil, err
+	}
 
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
@@ -235,7 +414,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 
 		for _, qr := range ranges {
 			if !hashesInitialized {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, true)
+				filteredHashes = txi.matchRange(ctx, qr, filteredHashes, true)
 				hashesInitialized = true
 
 				// Ignore any remaining conditions if the first condition resulted
@@ -244,7 +423,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 					break
 				}
 			} else {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, false)
+				filteredHashes = txi.matchRange(ctx, qr, filteredHashes, false)
 			}
 		}
 	}
@@ -275,6 +454,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	results := make([]*abci.TxResult, 0, len(filteredHashes))
 RESULTS_LOOP:
 	for _, h := range filteredHashes {
+		indexer.Scanned(ctx)
 		res, err := txi.Get(h)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
@@ -342,6 +522,8 @@ func (txi *TxIndex) match(
 
 	EQ_LOOP:
 		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
 			tmpHashes[string(it.Value())] = it.Value()
 
 			// Potentially exit early.
@@ -366,6 +548,8 @@ func (txi *TxIndex) match(
 
 	EXISTS_LOOP:
 		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
 			tmpHashes[string(it.Value())] = it.Value()
 
 			// Potentially exit early.
@@ -391,6 +575,8 @@ func (txi *TxIndex) match(
 
 	CONTAINS_LOOP:
 		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
 			if !isTagKey(it.Key()) {
 				continue
 			}
@@ -408,6 +594,36 @@ func (txi *TxIndex) match(
 		if err := it.Error(); err != nil {
 			panic(err)
 		}
+	case c.Op == syntax.TStartsWith:
+		// The values beginning with the prefix are contiguous in the index.
+		prefix := append(startKey(c.Tag), c.Arg.Value()...)
+		it, err := dbm.IteratePrefix(txi.store, prefix)
+		if err != nil {
+			panic(err)
+		}
+		defer it.Close()
+
+	STARTS_WITH_LOOP:
+		for ; it.Valid(); it.Next() {
+			indexer.Scanned(ctx)
+
+			if !isTagKey(it.Key()) {
+				continue
+			}
+			if strings.HasPrefix(extractValueFromKey(it.Key()), c.Arg.Value()) {
+				tmpHashes[string(it.Value())] = it.Value()
+			}
+
+			// Potentially exit early.
+			select {
+			case <-ctx.Done():
+				break STARTS_WITH_LOOP
+			default:
+			}
+		}
+		if err := it.Error(); err != nil {
+			panic(err)
+		}
 	default:
 		panic("other operators should be handled already")
 	}
@@ -442,29 +658,16 @@ REMOVE_LOOP:
 	return filteredHashes
 }
 
-// matchRange returns all matching txs by hash that meet a given queryRange and
-// start key. An already filtered result (filteredHashes) is provided such that
-// any non-intersecting matches are removed.
-//
-// NOTE: filteredHashes may be empty if no previous condition has matched.
-func (txi *TxIndex) matchRange(
-	ctx context.Context,
-	qr indexer.QueryRange,
-	startKey []byte,
-	filteredHashes map[string][]byte,
-	firstRun bool,
-) map[string][]byte {
-	// A previous match was attempted but resulted in no matches, so we return
-	// no matches (assuming AND operand).
-	if !firstRun && len(filteredHashes) == 0 {
-		return filteredHashes
-	}
-
+// scanRange returns all the txs by hash whose integer values of the event of
+// the queryRange are within its bounds, scanning all the values of the event.
+// It is used until the values of an older store have all been added to the
+// numeric index.
+func (txi *TxIndex) scanRange(ctx context.Context, qr indexer.QueryRange) map[string][]byte {
 	tmpHashes := make(map[string][]byte)
 	lowerBound := qr.LowerBoundValue()
 	upperBound := qr.UpperBoundValue()
 
-	it, err := dbm.IteratePrefix(txi.store, startKey)
+	it, err := dbm.IteratePrefix(txi.store, startKey(qr.Key))
 	if err != nil {
 		panic(err)
 	}
@@ -472,35 +675,17 @@ func (txi *TxIndex) matchRange(
 
 LOOP:
 	for ; it.Valid(); it.Next() {
+		indexer.Scanned(ctx)
+
 		if !isTagKey(it.Key()) {
 			continue
 		}
-
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			if err != nil {
-				continue LOOP
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHashes[string(it.Value())] = it.Value()
-			}
-
-			// XXX: passing time in a ABCI Events is not yet implemented
-			// case time.Time:
-			// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			// 	if v == r.upperBound {
-			// 		break
-			// 	}
+		v, err := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
+		if err != nil {
+			continue
+		}
+		if (lowerBound == nil || v >= lowerBound.(int64)) && (upperBound == nil || v <= upperBound.(int64)) {
+			tmpHashes[string(it.Value())] = it.Value()
 		}
 
 		// Potentially exit early.
@@ -513,6 +698,59 @@ LOOP:
 	if err := it.Error(); err != nil {
 		panic(err)
 	}
+	return tmpHashes
+}
+
+// matchRange returns all matching txs by hash that meet a given queryRange,
+// from the numeric index. An already filtered result (filteredHashes) is
+// provided such that any non-intersecting matches are removed.
+//
+// NOTE: filteredHashes may be empty if no previous condition has matched.
+func (txi *TxIndex) matchRange(
+	ctx context.Context,
+	qr indexer.QueryRange,
+	filteredHashes map[string][]byte,
+	firstRun bool,
+) map[string][]byte {
+	// A previous match was attempted but resulted in no matches, so we return
+	// no matches (assuming AND operand).
+	if !firstRun && len(filteredHashes) == 0 {
+		return filteredHashes
+	}
+
+	tmpHashes := make(map[string][]byte)
+
+	// XXX: passing time in a ABCI Events is not yet implemented, only the
+	// ranges of integers are matched.
+	if _, ok := qr.AnyBound().(int64); ok && atomic.LoadInt32(&txi.numericIndexed) == 0 {
+		tmpHashes = txi.scanRange(ctx, qr)
+	} else if ok {
+		start, end := numericRangeKeys(qr)
+		if bytes.Compare(start, end) < 0 {
+			it, err := txi.store.Iterator(start, end)
+			if err != nil {
+				panic(err)
+			}
+			defer it.Close()
+
+		LOOP:
+			for ; it.Valid(); it.Next() {
+				indexer.Scanned(ctx)
+
+				tmpHashes[string(it.Value())] = it.Value()
+
+				// Potentially exit early.
+				select {
+				case <-ctx.Done():
+					break LOOP
+				default:
+				}
+			}
+			if err := it.Error(); err != nil {
+				panic(err)
+			}
+		}
+	}
 
 	if len(tmpHashes) == 0 || firstRun {
 		// Either:
@@ -564,6 +802,38 @@ func keyForEvent(key string, value string, result *abci.TxResult) []byte {
 	))
 }
 
+func keyForNumericEvent(key string, value int64, height int64, index uint32) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s/%d/%d",
+		numericKeyPrefix,
+		key,
+		encodeNumber(value),
+		height,
+		index,
+	))
+}
+
+// encodeNumber encodes v as fixed-width hex, with the sign bit flipped, so
+// that the encodings sort in the order of the values.
+func encodeNumber(v int64) string {
+	return fmt.Sprintf("%016x", uint64(v)^(1<<63))
+}
+
+// numericRangeKeys returns the bounds of the keys of the numeric index within
+// the range, the end being exclusive.
+func numericRangeKeys(qr indexer.QueryRange) (start, end []byte) {
+	prefix := numericKeyPrefix + tagKeySeparator + qr.Key + tagKeySeparator
+	start = []byte(prefix)
+	if lower, ok := qr.LowerBoundValue().(int64); ok {
+		start = []byte(prefix + encodeNumber(lower) + tagKeySeparator)
+	}
+	// '0' follows the separator, so the keys of the tag all sort before it.
+	end = []byte(numericKeyPrefix + tagKeySeparator + qr.Key + "0")
+	if upper, ok := qr.UpperBoundValue().(int64); ok && upper < math.MaxInt64 {
+		end = []byte(prefix + encodeNumber(upper+1) + tagKeySeparator)
+	}
+	return start, end
+}
+
 func keyForHeight(result *abci.TxResult) []byte {
 	return []byte(fmt.Sprintf("%s/%d/%d/%d",
 		types.TxHeightKey,
@@ -15,6 +15,7 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/pubsub/query"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
+	"github.com/cometbft/cometbft/state/indexer"
 	"github.com/cometbft/cometbft/state/txindex"
 	"github.com/cometbft/cometbft/types"
 )
@@ -115,6 +116,10 @@ func TestTxSearch(t *testing.T) {
 		{"account.owner CONTAINS 'Vlad'", 0},
 		// search using the wrong key (of numeric type) using CONTAINS
 		{"account.number CONTAINS 'Iv'", 0},
+		// search using STARTS_WITH
+		{"account.owner STARTS_WITH 'Iv'", 1},
+		// search using a value which isn't a prefix using STARTS_WITH
+		{"account.owner STARTS_WITH 'an'", 0},
 		// search using EXISTS
 		{"account.number EXISTS", 1},
 		// search using EXISTS for non existing key
@@ -139,6 +144,13 @@ func TestTxSearch(t *testing.T) {
 	}
 }
 
+func TestTxSearchWildcard(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	_, err := indexer.Search(context.Background(), query.MustCompile(`account.* = 'Ivan'`))
+	assert.Error(t, err)
+}
+
 func TestTxSearchWithCancelation(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -157,6 +169,33 @@ func TestTxSearchWithCancelation(t *testing.T) {
 	assert.Empty(t, results)
 }
 
+func TestTxSearchWithScanLimit(t *testing.T) {
+	txIndexer := NewTxIndex(db.NewMemDB())
+
+	for i := 0; i < 10; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, txIndexer.Index(txResult))
+	}
+
+	ctx, limit := indexer.WithScanLimit(context.Background(), 5)
+	defer limit.Release()
+	_, err := txIndexer.Search(ctx, query.MustCompile(`account.number = 1`))
+	require.NoError(t, err)
+	assert.True(t, limit.Exceeded())
+
+	// the matching entries and the txs loaded are counted
+	ctx, limit = indexer.WithScanLimit(context.Background(), 20)
+	defer limit.Release()
+	results, err := txIndexer.Search(ctx, query.MustCompile(`account.number = 1`))
+	require.NoError(t, err)
+	assert.False(t, limit.Exceeded())
+	assert.Len(t, results, 10)
+}
+
 func TestTxSearchDeprecatedIndexing(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -409,6 +448,119 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchNumericRanges(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	for i, v := range []string{"-10", "-1", "0", "7", "42", "1000", "not a number", "3.5"} {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: v, Index: true}}},
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "fee", Value: fmt.Sprint(i), Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Height = int64(i + 1)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q   string
+		txs []string
+	}{
+		{"transfer.amount >= 0", []string{"tx2", "tx3", "tx4", "tx5"}},
+		{"transfer.amount > 0", []string{"tx3", "tx4", "tx5"}},
+		{"transfer.amount < 7", []string{"tx0", "tx1", "tx2"}},
+		{"transfer.amount <= 7", []string{"tx0", "tx1", "tx2", "tx3"}},
+		{"transfer.amount BETWEEN 7 AND 1000", []string{"tx3", "tx4", "tx5"}},
+		{"transfer.amount BETWEEN 8 AND 41", nil},
+		{"transfer.amount > 7 AND transfer.amount < 7", nil},
+		// ranges on different attributes are intersected
+		{"transfer.amount >= 0 AND transfer.fee <= 3", []string{"tx2", "tx3"}},
+		{"transfer.amount BETWEEN 0 AND 1000 AND tx.height BETWEEN 4 AND 5", []string{"tx3", "tx4"}},
+		{"tx.height > 6", []string{"tx6", "tx7"}},
+		// and with the other conditions
+		{"transfer.amount <= 42 AND transfer.fee = 4", []string{"tx4"}},
+		{"transfer.amount <= 42 AND transfer.amount EXISTS AND transfer.fee = 5", nil},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+		require.NoError(t, err, tc.q)
+
+		var txs []string
+		for _, txr := range results {
+			txs = append(txs, string(txr.Tx))
+		}
+		assert.ElementsMatch(t, tc.txs, txs, tc.q)
+	}
+}
+
+func TestTxIndexNumericValuesOfOlderStore(t *testing.T) {
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store)
+	for i := 0; i < 5; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: fmt.Sprint(i), Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	// drop the numeric index, as in a store of an older version
+	it, err := db.IteratePrefix(store, []byte(numericKeyPrefix+tagKeySeparator))
+	require.NoError(t, err)
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte{}, it.Key()...))
+	}
+	require.NoError(t, it.Close())
+	require.NotEmpty(t, keys)
+	for _, key := range keys {
+		require.NoError(t, store.Delete(key))
+	}
+	require.NoError(t, store.Delete(numericIndexedKey))
+
+	results, err := indexer.Search(context.Background(), query.MustCompile("account.number >= 2"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// until the values are indexed again, the events are scanned
+	indexer = NewTxIndex(store)
+	results, err = indexer.Search(context.Background(), query.MustCompile("account.number >= 2"))
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+
+	// the values are indexed a few keys at a time, and an interrupted
+	// indexing resumes where it stopped
+	defer func(size int) { numericIndexChunkSize = size }(numericIndexChunkSize)
+	numericIndexChunkSize = 2
+	next, err := indexer.indexNumericValuesChunk(nil)
+	require.NoError(t, err)
+	progress, err := store.Get(numericIndexProgressKey)
+	require.NoError(t, err)
+	assert.Equal(t, next, progress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, indexer.IndexNumericValues(ctx), context.Canceled)
+
+	indexer = NewTxIndex(store)
+	require.NoError(t, indexer.IndexNumericValues(context.Background()))
+	done, err := store.Has(numericIndexedKey)
+	require.NoError(t, err)
+	assert.True(t, done)
+	progress, err = store.Get(numericIndexProgressKey)
+	require.NoError(t, err)
+	assert.Nil(t, progress)
+
+	results, err = indexer.Search(context.Background(), query.MustCompile("account.number >= 2"))
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	results, err = indexer.Search(context.Background(), query.MustCompile("tx.height <= 1"))
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -0,0 +1,381 @@
+package kv
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	"github.com/cometbft/cometbft/libs/tempfile"
+)
+
+const (
+	// snapshotChunksDir is the directory of the chunks of the snapshots, in the
+	// snapshot directory. The chunks are named by their hash, so that the
+	// chunks of successive snapshots are only stored once when they are equal.
+	snapshotChunksDir = "chunks"
+
+	// snapshotManifestExt is the extension of the manifests of the snapshots,
+	// named by their height.
+	snapshotManifestExt = ".json"
+)
+
+// snapshotChunkSize is the size above which the records of a snapshot are
+// split into another chunk.
+var snapshotChunkSize = 10 << 20 // 10MB
+
+// Snapshot is the manifest of a snapshot of the database of the tx index,
+// indexed up to a height, which allows bootstrapping the tx index of a new
+// node without reindexing all the blocks.
+//
+// A snapshot is content-addressed: the database is exported, in the order of
+// its keys, into chunks of records of about 10MB, each
+// chunk is identified by its SHA-256 hash, and the hash of the snapshot is the
+// SHA-256 hash of its height and of the hashes of its chunks. So a snapshot
+// with a trusted hash can be fetched from untrusted sources, each chunk being
+// verified before it is restored.
+type Snapshot struct {
+	Height      int64               `json:"height"`
+	Hash        cmtbytes.HexBytes   `json:"hash"`
+	ChunkHashes []cmtbytes.HexBytes `json:"chunk_hashes"`
+}
+
+// snapshotHash returns the hash of a snapshot of the given height and chunks.
+func snapshotHash(height int64, chunkHashes []cmtbytes.HexBytes) []byte {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, height)
+	for _, chunkHash := range chunkHashes {
+		h.Write(chunkHash)
+	}
+	return h.Sum(nil)
+}
+
+// Metadata returns the hashes of the chunks of the snapshot, concatenated, to
+// advertise the snapshot to peers.
+func (s *Snapshot) Metadata() []byte {
+	metadata := make([]byte, 0, len(s.ChunkHashes)*sha256.Size)
+	for _, hash := range s.ChunkHashes {
+		metadata = append(metadata, hash...)
+	}
+	return metadata
+}
+
+// DecodeSnapshot decodes the snapshot of the given height advertised with the
+// given hash and metadata, and verifies its hash.
+func DecodeSnapshot(height int64, hash, metadata []byte) (*Snapshot, error) {
+	if len(metadata) == 0 || len(metadata)%sha256.Size != 0 {
+		return nil, fmt.Errorf("invalid metadata of %d bytes", len(metadata))
+	}
+	s := &Snapshot{Height: height, Hash: hash}
+	for i := 0; i < len(metadata); i += sha256.Size {
+		s.ChunkHashes = append(s.ChunkHashes, cmtbytes.HexBytes(metadata[i:i+sha256.Size]))
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Snapshot) validate() error {
+	if s.Height <= 0 {
+		return fmt.Errorf("invalid height %d", s.Height)
+	}
+	if len(s.ChunkHashes) == 0 {
+		return errors.New("snapshot has no chunks")
+	}
+	if !bytes.Equal(s.Hash, snapshotHash(s.Height, s.ChunkHashes)) {
+		return errors.New("snapshot hash does not match its chunks")
+	}
+	return nil
+}
+
+// VerifyChunk verifies the chunk of the given index against its hash.
+func (s *Snapshot) VerifyChunk(index uint32, chunk []byte) error {
+	if int(index) >= len(s.ChunkHashes) {
+		return fmt.Errorf("snapshot has no chunk %d", index)
+	}
+	hash := sha256.Sum256(chunk)
+	if !bytes.Equal(hash[:], s.ChunkHashes[index]) {
+		return fmt.Errorf("chunk %d does not match its hash", index)
+	}
+	return nil
+}
+
+// ExportSnapshot exports the database of the tx index, indexed up to the
+// given height, into a snapshot in the given directory, and returns it. The
+// database must not be written meanwhile, e.g. the node must be stopped.
+func (txi *TxIndex) ExportSnapshot(dir string, height int64) (*Snapshot, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("invalid height %d", height)
+	}
+	if err := cmtos.EnsureDir(filepath.Join(dir, snapshotChunksDir), 0o700); err != nil {
+		return nil, err
+	}
+
+	it, err := txi.store.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	s := &Snapshot{Height: height}
+	var chunk []byte
+	for ; it.Valid(); it.Next() {
+		chunk = appendSnapshotRecord(chunk, it.Key(), it.Value())
+		if len(chunk) >= snapshotChunkSize {
+			if err := s.saveChunk(dir, chunk); err != nil {
+				return nil, err
+			}
+			chunk = nil
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if len(chunk) > 0 {
+		if err := s.saveChunk(dir, chunk); err != nil {
+			return nil, err
+		}
+	}
+	if len(s.ChunkHashes) == 0 {
+		return nil, errors.New("the tx index is empty")
+	}
+	s.Hash = snapshotHash(s.Height, s.ChunkHashes)
+
+	// the manifest is written last, so that only complete snapshots are listed
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, strconv.FormatInt(height, 10)+snapshotManifestExt)
+	if err := tempfile.WriteFileAtomic(path, bz, 0o600); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Snapshot) saveChunk(dir string, chunk []byte) error {
+	hash := sha256.Sum256(chunk)
+	s.ChunkHashes = append(s.ChunkHashes, hash[:])
+	path := snapshotChunkPath(dir, hash[:])
+	if cmtos.FileExists(path) {
+		return nil
+	}
+	return tempfile.WriteFileAtomic(path, chunk, 0o600)
+}
+
+func snapshotChunkPath(dir string, hash []byte) string {
+	return filepath.Join(dir, snapshotChunksDir, fmt.Sprintf("%X", hash))
+}
+
+// appendSnapshotRecord appends a key and its value to a chunk, each prefixed
+// with its length.
+func appendSnapshotRecord(chunk, key, value []byte) []byte {
+	chunk = binary.AppendUvarint(chunk, uint64(len(key)))
+	chunk = append(chunk, key...)
+	chunk = binary.AppendUvarint(chunk, uint64(len(value)))
+	return append(chunk, value...)
+}
+
+// ListSnapshots returns the snapshots of the given directory, from the most
+// recent one.
+func ListSnapshots(dir string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var snapshots []*Snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, snapshotManifestExt) {
+			continue
+		}
+		height, err := strconv.ParseInt(strings.TrimSuffix(name, snapshotManifestExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		s, err := LoadSnapshot(dir, height)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Height > snapshots[j].Height })
+	return snapshots, nil
+}
+
+// LoadSnapshot loads the snapshot of the given height from the given
+// directory.
+func LoadSnapshot(dir string, height int64) (*Snapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, strconv.FormatInt(height, 10)+snapshotManifestExt))
+	if err != nil {
+		return nil, err
+	}
+	s := new(Snapshot)
+	if err := json.Unmarshal(bz, s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot manifest of height %d: %w", height, err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid snapshot manifest of height %d: %w", height, err)
+	}
+	return s, nil
+}
+
+// LoadChunk loads the chunk of the given index of the snapshot from the given
+// directory.
+func (s *Snapshot) LoadChunk(dir string, index uint32) ([]byte, error) {
+	if int(index) >= len(s.ChunkHashes) {
+		return nil, fmt.Errorf("snapshot has no chunk %d", index)
+	}
+	return os.ReadFile(snapshotChunkPath(dir, s.ChunkHashes[index]))
+}
+
+// IsEmpty tells whether nothing was indexed, and a snapshot can be restored.
+func (txi *TxIndex) IsEmpty() (bool, error) {
+	it, err := txi.store.Iterator(nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if !bytes.Equal(it.Key(), numericIndexedKey) {
+			return false, nil
+		}
+	}
+	return true, it.Error()
+}
+
+// RestoreSnapshotChunk writes the records of a verified chunk of a snapshot
+// to the database of the tx index.
+func (txi *TxIndex) RestoreSnapshotChunk(chunk []byte) error {
+	b := txi.store.NewBatch()
+	defer b.Close()
+
+	for len(chunk) > 0 {
+		var key, value []byte
+		var err error
+		if key, chunk, err = readSnapshotField(chunk); err != nil {
+			return err
+		}
+		if value, chunk, err = readSnapshotField(chunk); err != nil {
+			return err
+		}
+		if err := b.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return b.WriteSync()
+}
+
+// Clear deletes the records of the tx index, e.g. to undo the restore of a
+// snapshot which failed half-way, so that it can be restored again.
+func (txi *TxIndex) Clear() error {
+	for {
+		keys, err := txi.recordKeys(numericIndexChunkSize)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		b := txi.store.NewBatch()
+		for _, key := range keys {
+			if err := b.Delete(key); err != nil {
+				b.Close()
+				return err
+			}
+		}
+		err = b.WriteSync()
+		b.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// recordKeys returns up to limit keys of the records of the tx index.
+func (txi *TxIndex) recordKeys(limit int) ([][]byte, error) {
+	it, err := txi.store.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var keys [][]byte
+	for ; it.Valid() && len(keys) < limit; it.Next() {
+		if !bytes.Equal(it.Key(), numericIndexedKey) {
+			keys = append(keys, append([]byte(nil), it.Key()...))
+		}
+	}
+	return keys, it.Error()
+}
+
+func readSnapshotField(chunk []byte) ([]byte, []byte, error) {
+	length, n := binary.Uvarint(chunk)
+	if n <= 0 || uint64(len(chunk)-n) < length {
+		return nil, nil, errors.New("truncated snapshot record")
+	}
+	return chunk[n : n+int(length)], chunk[n+int(length):], nil
+}
+
+// ImportSnapshot restores the snapshot with the given hash from the given
+// directory into the database of the tx index, which must be empty. The
+// records restored are deleted if it fails.
+func (txi *TxIndex) ImportSnapshot(dir string, hash []byte) (*Snapshot, error) {
+	empty, err := txi.IsEmpty()
+	if err != nil {
+		return nil, err
+	}
+	if !empty {
+		return nil, errors.New("the tx index is not empty")
+	}
+
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		return nil, err
+	}
+	var s *Snapshot
+	for _, snapshot := range snapshots {
+		if bytes.Equal(snapshot.Hash, hash) {
+			s = snapshot
+			break
+		}
+	}
+	if s == nil {
+		return nil, fmt.Errorf("no snapshot with hash %X in %v", hash, dir)
+	}
+
+	if err := txi.importSnapshotChunks(dir, s); err != nil {
+		if cerr := txi.Clear(); cerr != nil {
+			return nil, fmt.Errorf("%w (failed to delete the restored records: %v)", err, cerr)
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (txi *TxIndex) importSnapshotChunks(dir string, s *Snapshot) error {
+	for i := range s.ChunkHashes {
+		chunk, err := s.LoadChunk(dir, uint32(i))
+		if err != nil {
+			return err
+		}
+		if err := s.VerifyChunk(uint32(i), chunk); err != nil {
+			return err
+		}
+		if err := txi.RestoreSnapshotChunk(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxIndexSnapshot(t *testing.T) {
+	defer func(size int) { snapshotChunkSize = size }(snapshotChunkSize)
+	snapshotChunkSize = 1024
+
+	indexer := NewTxIndex(db.NewMemDB())
+	batch := txindex.NewBatch(100)
+	for i := 0; i < 100; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: fmt.Sprint(i), Index: true}}},
+		})
+		txResult.Height = int64(i/10 + 1)
+		txResult.Index = uint32(i)
+		txResult.Tx = []byte(fmt.Sprintf("tx%d", i))
+		require.NoError(t, batch.Add(txResult))
+	}
+	require.NoError(t, indexer.AddBatch(batch))
+
+	dir := t.TempDir()
+	_, err := indexer.ExportSnapshot(dir, 0)
+	require.Error(t, err)
+	snapshot, err := indexer.ExportSnapshot(dir, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, snapshot.Height)
+	assert.Greater(t, len(snapshot.ChunkHashes), 1)
+
+	// the same content has the same hash, and the chunks are only stored once
+	other, err := indexer.ExportSnapshot(dir, 11)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.ChunkHashes, other.ChunkHashes)
+	assert.NotEqual(t, snapshot.Hash, other.Hash)
+	chunks, err := os.ReadDir(filepath.Join(dir, snapshotChunksDir))
+	require.NoError(t, err)
+	assert.Len(t, chunks, len(snapshot.ChunkHashes))
+
+	snapshots, err := ListSnapshots(dir)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, other, snapshots[0])
+	assert.Equal(t, snapshot, snapshots[1])
+
+	// the snapshot is advertised with the hashes of its chunks
+	decoded, err := DecodeSnapshot(snapshot.Height, snapshot.Hash, snapshot.Metadata())
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, decoded)
+	_, err = DecodeSnapshot(snapshot.Height+1, snapshot.Hash, snapshot.Metadata())
+	require.Error(t, err)
+	_, err = DecodeSnapshot(snapshot.Height, snapshot.Hash, snapshot.Metadata()[1:])
+	require.Error(t, err)
+
+	chunk, err := snapshot.LoadChunk(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, snapshot.VerifyChunk(0, chunk))
+	require.Error(t, snapshot.VerifyChunk(1, chunk))
+
+	// the snapshot is imported into an empty tx index only
+	_, err = indexer.ImportSnapshot(dir, snapshot.Hash)
+	require.Error(t, err)
+	restored := NewTxIndex(db.NewMemDB())
+	empty, err := restored.IsEmpty()
+	require.NoError(t, err)
+	assert.True(t, empty)
+	_, err = restored.ImportSnapshot(dir, []byte("unknown"))
+	require.Error(t, err)
+
+	// the records of a failed import are deleted
+	lastChunk := snapshotChunkPath(dir, snapshot.ChunkHashes[len(snapshot.ChunkHashes)-1])
+	lastChunkData, err := os.ReadFile(lastChunk)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lastChunk, []byte("corrupt"), 0o600))
+	_, err = restored.ImportSnapshot(dir, snapshot.Hash)
+	require.Error(t, err)
+	empty, err = restored.IsEmpty()
+	require.NoError(t, err)
+	assert.True(t, empty)
+	require.NoError(t, os.WriteFile(lastChunk, lastChunkData, 0o600))
+	_, err = restored.ImportSnapshot(dir, snapshot.Hash)
+	require.NoError(t, err)
+
+	results, err := restored.Search(context.Background(), query.MustCompile("account.number >= 95"))
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+	txResult, err := restored.Get(types.Tx("tx42").Hash())
+	require.NoError(t, err)
+	require.NotNil(t, txResult)
+	assert.EqualValues(t, 5, txResult.Height)
+
+	// a tampered chunk isn't restored
+	require.NoError(t, os.WriteFile(snapshotChunkPath(dir, snapshot.ChunkHashes[0]), chunk[1:], 0o600))
+	_, err = NewTxIndex(db.NewMemDB()).ImportSnapshot(dir, snapshot.Hash)
+	require.Error(t, err)
+}
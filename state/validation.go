@@ -52,10 +52,11 @@ func validateBlock(state State, block *types.Block) error {
 
 	// Validate app info
 	if !bytes.Equal(block.AppHash, state.AppHash) {
-		return fmt.Errorf("wrong Block.Header.AppHash.  Expected %X, got %v",
-			state.AppHash,
-			block.AppHash,
-		)
+		return ErrAppHashMismatch{
+			Height:   block.Height,
+			Expected: state.AppHash,
+			Got:      block.AppHash,
+		}
 	}
 	if !bytes.Equal(block.ConsensusHash, state.ConsensusParams.Hash()) {
 		return fmt.Errorf("wrong Block.Header.ConsensusHash.  Expected %X, got %v",
@@ -88,6 +89,10 @@ func validateBlock(state State, block *types.Block) error {
 			return errors.New("initial block can't have LastCommit signatures")
 		}
 	} else {
+		if aggregated := state.aggregatesLastCommit(block.Height); block.LastCommit.IsAggregated() != aggregated {
+			return fmt.Errorf("expected the LastCommit to be aggregated: %t, got %t",
+				aggregated, block.LastCommit.IsAggregated())
+		}
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
 			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
@@ -110,7 +115,9 @@ func validateBlock(state State, block *types.Block) error {
 		)
 	}
 
-	// Validate block Time
+	// Validate block Time. With proposer-based timestamps, the time is chosen
+	// by the proposer and its timeliness is checked by consensus instead.
+	pbtsEnabled := state.ConsensusParams.Feature.PbtsEnabled(block.Height)
 	switch {
 	case block.Height > state.InitialHeight:
 		if !block.Time.After(state.LastBlockTime) {
@@ -119,6 +126,9 @@ func validateBlock(state State, block *types.Block) error {
 				state.LastBlockTime,
 			)
 		}
+		if pbtsEnabled {
+			break
+		}
 		medianTime := MedianTime(block.LastCommit, state.LastValidators)
 		if !block.Time.Equal(medianTime) {
 			return fmt.Errorf("invalid block time. Expected %v, got %v",
@@ -127,6 +137,15 @@ func validateBlock(state State, block *types.Block) error {
 			)
 		}
 
+	case block.Height == state.InitialHeight && pbtsEnabled:
+		genesisTime := state.LastBlockTime
+		if block.Time.Before(genesisTime) {
+			return fmt.Errorf("block time %v is before genesis time %v",
+				block.Time,
+				genesisTime,
+			)
+		}
+
 	case block.Height == state.InitialHeight:
 		genesisTime := state.LastBlockTime
 		if !block.Time.Equal(genesisTime) {
@@ -11,6 +11,7 @@ import (
 	dbm "github.com/cometbft/cometbft-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/internal/test"
@@ -248,6 +249,78 @@ func TestValidateBlockCommit(t *testing.T) {
 	}
 }
 
+func TestValidateBlockAggregatedCommit(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	vals := make([]types.GenesisValidator, 2)
+	privVals := make(map[string]types.PrivValidator, len(vals))
+	for i := range vals {
+		pk := bls12381.GenPrivKey()
+		vals[i] = types.GenesisValidator{PubKey: pk.PubKey(), Power: 1000}
+		privVals[pk.PubKey().Address().String()] = types.NewMockPVWithParams(pk, false, false)
+	}
+	params := types.DefaultConsensusParams()
+	params.Validator.PubKeyTypes = []string{types.ABCIPubKeyTypeBls12381}
+	params.Feature.BlsAggregatedCommitEnableHeight = 2
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		Validators:      vals,
+		ConsensusParams: params,
+	})
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	require.NoError(t, stateStore.Save(state))
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(types.Txs{})
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mp,
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+	)
+
+	proposerAddr := state.Validators.GetProposer().Address
+	state, _, lastCommit, err := makeAndCommitGoodBlock(state, 1, types.NewCommit(0, 0, types.BlockID{}, nil),
+		proposerAddr, blockExec, privVals, nil)
+	require.NoError(t, err)
+
+	// The last commit must be aggregated from the enable height.
+	block := makeBlock(state, 2, lastCommit)
+	err = blockExec.ValidateBlock(state, block)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "aggregated")
+	}
+
+	aggregated, err := lastCommit.Aggregate()
+	require.NoError(t, err)
+	block = makeBlock(state, 2, aggregated)
+	require.NoError(t, blockExec.ValidateBlock(state, block))
+
+	// The proposer aggregates the last commit.
+	block, err = blockExec.CreateProposalBlock(2, state, lastCommit, proposerAddr, nil)
+	require.NoError(t, err)
+	assert.True(t, block.LastCommit.IsAggregated())
+	require.NoError(t, blockExec.ValidateBlock(state, block))
+}
+
 func TestValidateBlockEvidence(t *testing.T) {
 	proxyApp := newTestApp()
 	require.NoError(t, proxyApp.Start())
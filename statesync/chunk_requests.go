@@ -0,0 +1,170 @@
+package statesync
+
+import (
+	"math/rand"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+const (
+	// chunkStatsWeight is the weight of the latest chunk in the moving averages of the chunk
+	// durations and peer throughputs.
+	chunkStatsWeight = 0.2
+	// slowChunkFactor is how many times longer than the average a chunk must take to be
+	// requested from another peer as well.
+	slowChunkFactor = 3
+	// minSlowChunkTimeout is the minimum time to wait for a chunk before requesting it from
+	// another peer as well.
+	minSlowChunkTimeout = 500 * time.Millisecond
+)
+
+// chunkRequests tracks the chunk requests in flight to each peer and the throughput of the
+// peers, so that chunks are requested in parallel from the fastest peers with spare capacity,
+// and that slow chunks are requested again from other peers.
+type chunkRequests struct {
+	mtx         cmtsync.Mutex
+	maxInflight int
+	peers       map[p2p.ID]*peerChunkStats
+	avgDuration time.Duration // moving average of the chunk durations across peers
+}
+
+// peerChunkStats is the chunk accounting of a peer.
+type peerChunkStats struct {
+	inflight   map[uint32]time.Time // request time by chunk index
+	throughput float64              // moving average in bytes per second, 0 if unknown
+	received   int64                // total bytes received
+}
+
+// newChunkRequests creates a chunk request tracker, allowing up to maxInflight chunks in flight
+// per peer.
+func newChunkRequests(maxInflight int) *chunkRequests {
+	return &chunkRequests{
+		maxInflight: maxInflight,
+		peers:       make(map[p2p.ID]*peerChunkStats),
+	}
+}
+
+func (c *chunkRequests) peer(peerID p2p.ID) *peerChunkStats {
+	stats, ok := c.peers[peerID]
+	if !ok {
+		stats = &peerChunkStats{inflight: make(map[uint32]time.Time)}
+		c.peers[peerID] = stats
+	}
+	return stats
+}
+
+// Pick returns the peer to request the chunk from, and records the request. Peers the chunk is
+// already in flight to and peers with maxInflight chunks in flight are skipped. Peers whose
+// throughput is unknown are picked first so that it gets measured, then the fastest ones.
+// Returns nil if no peer is available.
+func (c *chunkRequests) Pick(peers []p2p.Peer, index uint32) p2p.Peer {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var (
+		best           p2p.Peer
+		bestThroughput float64
+	)
+	for _, i := range rand.Perm(len(peers)) { //nolint:gosec // G404: Use of weak random number generator
+		peer := peers[i]
+		stats := c.peer(peer.ID())
+		if _, ok := stats.inflight[index]; ok || len(stats.inflight) >= c.maxInflight {
+			continue
+		}
+		if stats.throughput == 0 {
+			best = peer
+			break
+		}
+		if best == nil || stats.throughput > bestThroughput {
+			best, bestThroughput = peer, stats.throughput
+		}
+	}
+	if best != nil {
+		c.peer(best.ID()).inflight[index] = time.Now()
+	}
+	return best
+}
+
+// Received records the arrival of the chunk from the peer. The chunk is no longer in flight to
+// any peer, since duplicates are ignored.
+func (c *chunkRequests) Received(peerID p2p.ID, index uint32, size int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	stats := c.peer(peerID)
+	if sentAt, ok := stats.inflight[index]; ok {
+		duration := time.Since(sentAt)
+		throughput := float64(size) / duration.Seconds()
+		if stats.throughput == 0 {
+			stats.throughput = throughput
+		} else {
+			stats.throughput += chunkStatsWeight * (throughput - stats.throughput)
+		}
+		if c.avgDuration == 0 {
+			c.avgDuration = duration
+		} else {
+			c.avgDuration += time.Duration(chunkStatsWeight * float64(duration-c.avgDuration))
+		}
+	}
+	stats.received += int64(size)
+	c.forget(index, nil)
+}
+
+// Expire records that the chunk was not received in time. Peers it was in flight to have their
+// throughput halved.
+func (c *chunkRequests) Expire(index uint32) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.forget(index, func(stats *peerChunkStats) { stats.throughput /= 2 })
+}
+
+// forget removes the chunk from the requests in flight, calling fn for the peers it was in
+// flight to. The caller must hold the mutex lock.
+func (c *chunkRequests) forget(index uint32, fn func(*peerChunkStats)) {
+	for _, stats := range c.peers {
+		if _, ok := stats.inflight[index]; ok {
+			delete(stats.inflight, index)
+			if fn != nil {
+				fn(stats)
+			}
+		}
+	}
+}
+
+// RemovePeer removes the peer and its requests in flight.
+func (c *chunkRequests) RemovePeer(peerID p2p.ID) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.peers, peerID)
+}
+
+// SlowTimeout returns how long to wait for a chunk before requesting it from another peer as
+// well, at most maxTimeout.
+func (c *chunkRequests) SlowTimeout(maxTimeout time.Duration) time.Duration {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.avgDuration == 0 {
+		return maxTimeout
+	}
+	timeout := slowChunkFactor * c.avgDuration
+	if timeout < minSlowChunkTimeout {
+		timeout = minSlowChunkTimeout
+	}
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	return timeout
+}
+
+// Throughput returns the throughput of the peer in bytes per second, and the total number of
+// bytes received from it.
+func (c *chunkRequests) Throughput(peerID p2p.ID) (float64, int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if stats, ok := c.peers[peerID]; ok {
+		return stats.throughput, stats.received
+	}
+	return 0, 0
+}
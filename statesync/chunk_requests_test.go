@@ -0,0 +1,57 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/p2p"
+	p2pmocks "github.com/cometbft/cometbft/p2p/mocks"
+)
+
+func TestChunkRequests(t *testing.T) {
+	peerA := &p2pmocks.Peer{}
+	peerA.On("ID").Return(p2p.ID("a"))
+	peerB := &p2pmocks.Peer{}
+	peerB.On("ID").Return(p2p.ID("b"))
+	peers := []p2p.Peer{peerA, peerB}
+
+	requests := newChunkRequests(2)
+	assert.Equal(t, 10*time.Second, requests.SlowTimeout(10*time.Second))
+
+	// Peers with unknown throughput are picked first, and a chunk is not requested twice from
+	// the same peer.
+	first := requests.Pick(peers, 0)
+	require.NotNil(t, first)
+	second := requests.Pick(peers, 0)
+	require.NotNil(t, second)
+	assert.NotEqual(t, first.ID(), second.ID())
+	assert.Nil(t, requests.Pick(peers, 0))
+
+	// The chunk is no longer in flight once received from either peer.
+	time.Sleep(10 * time.Millisecond)
+	requests.Received(first.ID(), 0, 1000)
+	throughput, received := requests.Throughput(first.ID())
+	assert.Greater(t, throughput, 0.0)
+	assert.EqualValues(t, 1000, received)
+	_, received = requests.Throughput(second.ID())
+	assert.Zero(t, received)
+	assert.Equal(t, minSlowChunkTimeout, requests.SlowTimeout(10*time.Second))
+
+	// The peer with unknown throughput is preferred, until it reaches the maximum chunks in
+	// flight.
+	assert.Equal(t, second.ID(), requests.Pick(peers, 1).ID())
+	assert.Equal(t, second.ID(), requests.Pick(peers, 2).ID())
+	assert.Equal(t, first.ID(), requests.Pick(peers, 3).ID())
+
+	// Expired chunks halve the throughput of the peers they were in flight to.
+	requests.Expire(3)
+	halved, _ := requests.Throughput(first.ID())
+	assert.Equal(t, throughput/2, halved)
+
+	// Removed peers are forgotten.
+	requests.RemovePeer(second.ID())
+	assert.Equal(t, second.ID(), requests.Pick(peers, 4).ID())
+}
@@ -35,6 +35,7 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]p2p.ID          // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkSkipped   map[uint32]bool            // chunks skipped via Skip()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
@@ -55,6 +56,7 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkSenders:   make(map[uint32]p2p.ID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkSkipped:   make(map[uint32]bool),
 		waiters:        make(map[uint32][]chan<- uint32),
 	}, nil
 }
@@ -155,6 +157,12 @@ func (q *chunkQueue) discard(index uint32) error {
 	if q.snapshot == nil {
 		return nil
 	}
+	if q.chunkSkipped[index] {
+		delete(q.chunkSkipped, index)
+		delete(q.chunkReturned, index)
+		delete(q.chunkAllocated, index)
+		return nil
+	}
 	path := q.chunkFiles[index]
 	if path == "" {
 		return nil
@@ -278,11 +286,34 @@ func (q *chunkQueue) Retry(index uint32) {
 	delete(q.chunkReturned, index)
 }
 
-// RetryAll schedules all chunks to be retried, without refetching them.
+// RetryAll schedules all chunks to be retried, without refetching them. Skipped chunks are
+// fetched.
 func (q *chunkQueue) RetryAll() {
 	q.Lock()
 	defer q.Unlock()
 	q.chunkReturned = make(map[uint32]bool)
+	for index := range q.chunkSkipped {
+		delete(q.chunkAllocated, index)
+	}
+	q.chunkSkipped = make(map[uint32]bool)
+}
+
+// Skip skips chunks that are already applied, e.g. when resuming an interrupted restoration, so
+// that they are neither fetched nor returned via Next(). Discard() and RetryAll() schedule them
+// to be fetched again.
+func (q *chunkQueue) Skip(indexes []uint32) {
+	q.Lock()
+	defer q.Unlock()
+	if q.snapshot == nil {
+		return
+	}
+	for _, index := range indexes {
+		if index < q.snapshot.Chunks && q.chunkFiles[index] == "" {
+			q.chunkAllocated[index] = true
+			q.chunkReturned[index] = true
+			q.chunkSkipped[index] = true
+		}
+	}
 }
 
 // Size returns the total number of chunks for the snapshot and queue, or 0 when closed.
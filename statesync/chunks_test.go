@@ -478,6 +478,43 @@ func TestChunkQueue_RetryAll(t *testing.T) {
 	assert.Equal(t, errDone, err)
 }
 
+func TestChunkQueue_Skip(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	// Skipped chunks are neither allocated nor returned
+	queue.Skip([]uint32{0, 2, 3, 4})
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+	_, err = queue.Allocate()
+	assert.Equal(t, errDone, err)
+
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{1}})
+	require.NoError(t, err)
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, c.Index)
+	_, err = queue.Next()
+	assert.Equal(t, errDone, err)
+
+	// Discarding a skipped chunk schedules it for fetching
+	require.NoError(t, queue.Discard(2))
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+
+	// Retrying all chunks fetches the skipped ones
+	queue.RetryAll()
+	for _, expect := range []uint32{0, 3, 4} {
+		index, err = queue.Allocate()
+		require.NoError(t, err)
+		assert.EqualValues(t, expect, index)
+	}
+	_, err = queue.Allocate()
+	assert.Equal(t, errDone, err)
+}
+
 func TestChunkQueue_Size(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
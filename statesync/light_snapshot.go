@@ -0,0 +1,219 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// LightSnapshotFormat is the snapshot format of light snapshots. Light
+// snapshots are built by CometBFT from its block store, without involving the
+// ABCI application, so applications must not use this format for their own
+// snapshots.
+//
+// A light snapshot at height H with N chunks contains the blocks H-N+1 to H,
+// chunk i being the protobuf encoding of block H-i. Its hash is the hash of
+// block H. Restoring it does not restore the application state: the
+// application must already be at height H, e.g. restored from a backup of its
+// data, and the snapshot brings the block store on par with it.
+const LightSnapshotFormat = uint32(math.MaxUint32)
+
+// lightSnapshots returns the n most recent light snapshots that can be served
+// from the block store, if enabled.
+func (r *Reactor) lightSnapshots(n uint32) []*snapshot {
+	interval, blocks := r.cfg.LightSnapshotInterval, r.cfg.LightSnapshotBlocks
+	if interval <= 0 || r.blockStore == nil {
+		return nil
+	}
+
+	// The block at the snapshot height is committed by the next one, which
+	// the restoring node's light client must be able to fetch.
+	height := (r.blockStore.Height() - 1) / interval * interval
+	base := r.blockStore.Base()
+	snapshots := make([]*snapshot, 0, n)
+	for ; height > 0 && uint32(len(snapshots)) < n; height -= interval {
+		chunks := blocks
+		if chunks > height {
+			chunks = height
+		}
+		if height-chunks+1 < base {
+			break
+		}
+		meta := r.blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			break
+		}
+		snapshots = append(snapshots, &snapshot{
+			Height: uint64(height),
+			Format: LightSnapshotFormat,
+			Chunks: uint32(chunks),
+			Hash:   meta.BlockID.Hash,
+		})
+	}
+	return snapshots
+}
+
+// loadLightSnapshotChunk returns the chunk of the light snapshot, or nil if it
+// is not available: the snapshot is not one of those lightSnapshots would
+// return for the current block store, or the block was pruned.
+func (r *Reactor) loadLightSnapshotChunk(height uint64, index uint32) ([]byte, error) {
+	interval := r.cfg.LightSnapshotInterval
+	if interval <= 0 || r.blockStore == nil || height%uint64(interval) != 0 ||
+		uint64(index) >= uint64(r.cfg.LightSnapshotBlocks) || uint64(index) >= height {
+		return nil, nil
+	}
+	// As in lightSnapshots, the block at the snapshot height must be committed
+	// by a block of the store, and all the blocks of the snapshot in it.
+	if height >= uint64(r.blockStore.Height()) ||
+		int64(height)-int64(index) < r.blockStore.Base() {
+		return nil, nil
+	}
+
+	block := r.blockStore.LoadBlock(int64(height) - int64(index))
+	if block == nil {
+		return nil, nil
+	}
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := proto.Marshal(pb)
+	if err != nil {
+		return nil, err
+	}
+	// Leave room for the other fields of the response.
+	if len(bz) > chunkMsgSize-1024 {
+		return nil, fmt.Errorf("block of %d bytes is too large to be sent as a chunk", len(bz))
+	}
+	return bz, nil
+}
+
+// checkLightSnapshot checks that the application is at the height and app hash
+// of the light snapshot, since restoring the snapshot does not involve it.
+func (s *syncer) checkLightSnapshot(snapshot *snapshot) error {
+	// The blocks can only be saved to an empty block store.
+	if s.blockStore == nil || s.blockStore.Height() > 0 {
+		return errRejectFormat
+	}
+	resp, err := s.connQuery.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("failed to query ABCI app for last block height: %w", err)
+	}
+	if uint64(resp.LastBlockHeight) != snapshot.Height ||
+		!bytes.Equal(resp.LastBlockAppHash, snapshot.trustedAppHash) {
+		s.logger.Info("Application is not at the light snapshot height",
+			"height", snapshot.Height, "appHeight", resp.LastBlockHeight)
+		return errRejectSnapshot
+	}
+	s.logger.Info("Restoring light snapshot", "height", snapshot.Height,
+		"hash", log.NewLazySprintf("%X", snapshot.Hash))
+	return nil
+}
+
+// restoreLightSnapshot verifies the blocks of a light snapshot, from the most
+// recent one whose hash is committed by commit down to the oldest one, then
+// saves them to the block store in ascending order. Chunks with an invalid
+// block are refetched from other peers.
+func (s *syncer) restoreLightSnapshot(snapshot *snapshot, chunks *chunkQueue, commit *types.Commit) error {
+	if !bytes.Equal(snapshot.Hash, commit.BlockID.Hash) {
+		return errRejectSnapshot
+	}
+
+	// Every block is verified against the previous hash of the block after
+	// it, which is verified first since chunks are returned in order.
+	hashes := make(map[uint32][]byte, chunks.Size())
+	hashes[0] = commit.BlockID.Hash
+	for {
+		chunk, err := chunks.Next()
+		if err == errDone {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to fetch chunk: %w", err)
+		}
+
+		block, err := decodeLightSnapshotChunk(chunk)
+		if err == nil && !bytes.Equal(block.Hash(), hashes[chunk.Index]) {
+			err = errors.New("block hash does not match the snapshot")
+		}
+		if err != nil {
+			s.logger.Error("Invalid light snapshot chunk, rejecting sender", "height", chunk.Height,
+				"chunk", chunk.Index, "peer", chunk.Sender, "err", err)
+			s.snapshots.RejectPeer(chunk.Sender)
+			if err := chunks.DiscardSender(chunk.Sender); err != nil {
+				return fmt.Errorf("failed to reject sender: %w", err)
+			}
+			if err := chunks.Discard(chunk.Index); err != nil {
+				return fmt.Errorf("failed to discard chunk %v: %w", chunk.Index, err)
+			}
+			continue
+		}
+		hashes[chunk.Index+1] = block.LastBlockID.Hash
+		s.logger.Info("Verified light snapshot block", "height", block.Height,
+			"chunk", chunk.Index, "total", chunks.Size())
+	}
+
+	// Each block is saved with the commit of the next one, or the verified
+	// commit for the last one.
+	block, err := chunks.loadBlock(chunks.Size() - 1)
+	if err != nil {
+		return err
+	}
+	for index := chunks.Size() - 1; ; index-- {
+		var next *types.Block
+		seenCommit := commit
+		if index > 0 {
+			if next, err = chunks.loadBlock(index - 1); err != nil {
+				return err
+			}
+			seenCommit = next.LastCommit
+		}
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return fmt.Errorf("failed to make part set of block %v: %w", block.Height, err)
+		}
+		s.blockStore.SaveBlock(block, parts, seenCommit)
+		if index == 0 {
+			return nil
+		}
+		block = next
+	}
+}
+
+// decodeLightSnapshotChunk decodes and validates the block of a light snapshot
+// chunk.
+func decodeLightSnapshotChunk(chunk *chunk) (*types.Block, error) {
+	pb := new(cmtproto.Block)
+	if err := proto.Unmarshal(chunk.Chunk, pb); err != nil {
+		return nil, fmt.Errorf("failed to decode block: %w", err)
+	}
+	block, err := types.BlockFromProto(pb)
+	if err != nil {
+		return nil, err
+	}
+	if block.Height != int64(chunk.Height)-int64(chunk.Index) {
+		return nil, fmt.Errorf("expected block %v, got %v", int64(chunk.Height)-int64(chunk.Index), block.Height)
+	}
+	return block, block.ValidateBasic()
+}
+
+// loadBlock loads and decodes the block of a light snapshot chunk.
+func (q *chunkQueue) loadBlock(index uint32) (*types.Block, error) {
+	q.Lock()
+	chunk, err := q.load(index)
+	q.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if chunk == nil {
+		return nil, fmt.Errorf("chunk %v is missing", index)
+	}
+	return decodeLightSnapshotChunk(chunk)
+}
@@ -0,0 +1,166 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// makeLightSnapshotChain saves a chain of n blocks to a new block store and
+// returns it, along with the commit of the last block.
+func makeLightSnapshotChain(t *testing.T, n int64) (*store.BlockStore, *types.Commit) {
+	valSet, privVals := test.ValidatorSet(context.Background(), t, 2, 10)
+	state := sm.State{
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{Block: version.BlockProtocol},
+		},
+		ChainID:         "test-chain",
+		InitialHeight:   1,
+		LastBlockTime:   time.Now(),
+		Validators:      valSet,
+		NextValidators:  valSet,
+		LastValidators:  valSet,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	lastCommit := &types.Commit{}
+	for height := int64(1); height <= n; height++ {
+		block := state.MakeBlock(height, test.MakeNTxs(height, 2), lastCommit, nil, valSet.Proposer.Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		lastCommit, err = test.MakeCommit(blockID, height, 0, valSet, privVals, state.ChainID, state.LastBlockTime)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, lastCommit)
+
+		state.LastBlockID = blockID
+		state.LastBlockHeight = height
+		state.LastBlockTime = state.LastBlockTime.Add(time.Second)
+	}
+	return blockStore, lastCommit
+}
+
+func TestReactor_LightSnapshots(t *testing.T) {
+	blockStore, _ := makeLightSnapshotChain(t, 25)
+
+	cfg := config.DefaultStateSyncConfig()
+	cfg.LightSnapshotInterval = 10
+	cfg.LightSnapshotBlocks = 5
+	r := NewReactor(*cfg, nil, nil, "", NopMetrics(), WithBlockStore(blockStore))
+
+	assert.Equal(t, []*snapshot{
+		{Height: 20, Format: LightSnapshotFormat, Chunks: 5, Hash: blockStore.LoadBlockMeta(20).BlockID.Hash},
+		{Height: 10, Format: LightSnapshotFormat, Chunks: 5, Hash: blockStore.LoadBlockMeta(10).BlockID.Hash},
+	}, r.lightSnapshots(recentSnapshots))
+	assert.Len(t, r.lightSnapshots(1), 1)
+
+	chunk, err := r.loadLightSnapshotChunk(20, 3)
+	require.NoError(t, err)
+	pb := new(cmtproto.Block)
+	require.NoError(t, proto.Unmarshal(chunk, pb))
+	assert.EqualValues(t, 17, pb.Header.Height)
+
+	// outside of the snapshot
+	chunk, err = r.loadLightSnapshotChunk(20, 5)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+	chunk, err = r.loadLightSnapshotChunk(15, 0)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+	chunk, err = r.loadLightSnapshotChunk(30, 0)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+
+	// the block at the snapshot height is not committed yet
+	blockStore, _ = makeLightSnapshotChain(t, 20)
+	r = NewReactor(*cfg, nil, nil, "", NopMetrics(), WithBlockStore(blockStore))
+	require.NotNil(t, blockStore.LoadBlock(20))
+	chunk, err = r.loadLightSnapshotChunk(20, 0)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+
+	// disabled
+	r = NewReactor(*config.DefaultStateSyncConfig(), nil, nil, "", NopMetrics(), WithBlockStore(blockStore))
+	assert.Empty(t, r.lightSnapshots(recentSnapshots))
+}
+
+func TestSyncer_RestoreLightSnapshot(t *testing.T) {
+	serving, _ := makeLightSnapshotChain(t, 25)
+	commit := serving.LoadBlockCommit(20)
+	snapshot := &snapshot{
+		Height: 20,
+		Format: LightSnapshotFormat,
+		Chunks: 5,
+		Hash:   commit.BlockID.Hash,
+	}
+
+	cfg := config.DefaultStateSyncConfig()
+	cfg.LightSnapshotInterval = 10
+	cfg.LightSnapshotBlocks = 5
+	r := NewReactor(*cfg, nil, nil, "", NopMetrics(), WithBlockStore(serving))
+
+	chunks, err := newChunkQueue(snapshot, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { chunks.Close() })
+
+	for index := uint32(0); index < snapshot.Chunks; index++ {
+		body, err := r.loadLightSnapshotChunk(snapshot.Height, index)
+		require.NoError(t, err)
+		sender := p2p.ID("good")
+		if index == 2 {
+			// a block of another height is rejected and refetched
+			body, err = r.loadLightSnapshotChunk(snapshot.Height, index+1)
+			require.NoError(t, err)
+			sender = "bad"
+		}
+		_, err = chunks.Add(&chunk{Height: 20, Format: LightSnapshotFormat, Index: index, Chunk: body, Sender: sender})
+		require.NoError(t, err)
+	}
+	go func() {
+		for chunks.Has(2) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		body, err := r.loadLightSnapshotChunk(snapshot.Height, 2)
+		if err == nil {
+			_, err = chunks.Add(&chunk{Height: 20, Format: LightSnapshotFormat, Index: 2, Chunk: body, Sender: "good"})
+		}
+		assert.NoError(t, err)
+	}()
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	syncer := newSyncer(*cfg, log.NewNopLogger(), nil, nil, nil, "", blockStore, "")
+	require.NoError(t, syncer.restoreLightSnapshot(snapshot, chunks, commit))
+
+	assert.EqualValues(t, 16, blockStore.Base())
+	assert.EqualValues(t, 20, blockStore.Height())
+	for height := int64(16); height <= 20; height++ {
+		assert.Equal(t, serving.LoadBlockMeta(height).BlockID, blockStore.LoadBlockMeta(height).BlockID)
+		assert.Equal(t, serving.LoadSeenCommit(height).Hash(), blockStore.LoadSeenCommit(height).Hash())
+	}
+	assert.Equal(t, commit, blockStore.LoadSeenCommit(20))
+	assert.Equal(t, serving.LoadBlockCommit(18), blockStore.LoadBlockCommit(18))
+
+	// a snapshot which is not committed by the commit is rejected
+	snapshot.Hash = []byte{1, 2, 3}
+	err = syncer.restoreLightSnapshot(snapshot, chunks, commit)
+	assert.ErrorIs(t, err, errRejectSnapshot)
+}
@@ -0,0 +1,146 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+)
+
+// maxManifestSize is the maximum size of a snapshot mirror manifest.
+const maxManifestSize = 16 << 20
+
+// mirrorManifest is the manifest of a snapshot mirror, served as JSON at <url>/manifest.json.
+// The chunks of a snapshot are served at <url>/<height>/<format>/<index>.
+type mirrorManifest struct {
+	Snapshots []mirrorSnapshot `json:"snapshots"`
+}
+
+// mirrorSnapshot is a snapshot listed in a mirror manifest, along with the SHA-256 hashes of its
+// chunks.
+type mirrorSnapshot struct {
+	Height      uint64              `json:"height"`
+	Format      uint32              `json:"format"`
+	Chunks      uint32              `json:"chunks"`
+	Hash        cmtbytes.HexBytes   `json:"hash"`
+	Metadata    cmtbytes.HexBytes   `json:"metadata"`
+	ChunkHashes []cmtbytes.HexBytes `json:"chunk_hashes"`
+}
+
+// mirror is a snapshot source serving snapshots over HTTP(S), e.g. from a bucket or a CDN, for
+// networks where few peers serve snapshots. The chunks are verified against the hashes listed in
+// the manifest, and the restored application against the app hash verified by the light client,
+// like any other snapshot.
+type mirror struct {
+	url    string
+	client *http.Client
+
+	mtx         cmtsync.Mutex
+	chunkHashes map[snapshotKey][]cmtbytes.HexBytes
+}
+
+// newMirror creates a snapshot mirror for the URL, with a timeout for each HTTP request.
+func newMirror(url string, timeout time.Duration) *mirror {
+	return &mirror{
+		url:         strings.TrimSuffix(url, "/"),
+		client:      &http.Client{Timeout: timeout},
+		chunkHashes: make(map[snapshotKey][]cmtbytes.HexBytes),
+	}
+}
+
+// ID returns the identifier of the mirror, used as the sender of its chunks.
+func (m *mirror) ID() p2p.ID {
+	return p2p.ID(m.url)
+}
+
+// Snapshots fetches the manifest of the mirror and returns the recentSnapshots most recent
+// snapshots it lists.
+func (m *mirror) Snapshots(ctx context.Context) ([]*snapshot, error) {
+	bz, err := m.get(ctx, m.url+"/manifest.json", maxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+	var manifest mirrorManifest
+	if err := json.Unmarshal(bz, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	sort.Slice(manifest.Snapshots, func(i, j int) bool {
+		return manifest.Snapshots[i].Height > manifest.Snapshots[j].Height
+	})
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	snapshots := make([]*snapshot, 0, recentSnapshots)
+	for _, ms := range manifest.Snapshots {
+		if len(snapshots) >= recentSnapshots {
+			break
+		}
+		if ms.Height == 0 || ms.Chunks == 0 || len(ms.ChunkHashes) != int(ms.Chunks) {
+			continue // invalid
+		}
+		s := &snapshot{
+			Height:   ms.Height,
+			Format:   ms.Format,
+			Chunks:   ms.Chunks,
+			Hash:     ms.Hash,
+			Metadata: ms.Metadata,
+		}
+		m.chunkHashes[s.Key()] = ms.ChunkHashes
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, nil
+}
+
+// LoadChunk fetches a chunk of the snapshot and verifies it against the manifest.
+func (m *mirror) LoadChunk(ctx context.Context, snapshot *snapshot, index uint32) ([]byte, error) {
+	m.mtx.Lock()
+	hashes := m.chunkHashes[snapshot.Key()]
+	m.mtx.Unlock()
+	if int(index) >= len(hashes) {
+		return nil, fmt.Errorf("chunk %v is not in the manifest", index)
+	}
+
+	bz, err := m.get(ctx, fmt.Sprintf("%s/%d/%d/%d", m.url, snapshot.Height, snapshot.Format, index), chunkMsgSize)
+	if err != nil {
+		return nil, err
+	}
+	if hash := sha256.Sum256(bz); !bytes.Equal(hash[:], hashes[index]) {
+		return nil, fmt.Errorf("chunk %v hash %X does not match the manifest", index, hash)
+	}
+	return bz, nil
+}
+
+// get fetches the URL, limiting the response to maxSize bytes.
+func (m *mirror) get(ctx context.Context, url string, maxSize int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+	bz, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > maxSize {
+		return nil, errors.New("response is too large")
+	}
+	return bz, nil
+}
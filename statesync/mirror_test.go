@@ -0,0 +1,154 @@
+package statesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	"github.com/cometbft/cometbft/proxy"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/statesync/mocks"
+	"github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// startMirror starts an HTTP server serving the chunks of a snapshot at height 1 and format 1 as
+// a snapshot mirror. Chunks in tampered are served with a different content than in the
+// manifest.
+func startMirror(t *testing.T, chunks [][]byte, tampered map[int]bool) *httptest.Server {
+	hashes := make([]cmtbytes.HexBytes, len(chunks))
+	for i, chunk := range chunks {
+		hash := sha256.Sum256(chunk)
+		hashes[i] = hash[:]
+	}
+	manifest, err := json.Marshal(mirrorManifest{Snapshots: []mirrorSnapshot{
+		{Height: 1, Format: 1, Chunks: uint32(len(chunks)), Hash: []byte{1, 2, 3}, ChunkHashes: hashes},
+		{Height: 2, Format: 1, Chunks: 2, Hash: []byte{4, 5, 6}}, // invalid, no chunk hashes
+	}})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(manifest)
+	})
+	for i, chunk := range chunks {
+		body := chunk
+		if tampered[i] {
+			body = []byte("tampered")
+		}
+		mux.HandleFunc(fmt.Sprintf("/1/1/%d", i), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(body)
+		})
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMirror(t *testing.T) {
+	srv := startMirror(t, [][]byte{{1, 1, 0}, {1, 1, 1}}, map[int]bool{1: true})
+	m := newMirror(srv.URL+"/", time.Second)
+
+	snapshots, err := m.Snapshots(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*snapshot{{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1, 2, 3}, Metadata: []byte{}}}, snapshots)
+
+	chunk, err := m.LoadChunk(context.Background(), snapshots[0], 0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 1, 0}, chunk)
+
+	// chunks must match the manifest
+	_, err = m.LoadChunk(context.Background(), snapshots[0], 1)
+	assert.Error(t, err)
+	_, err = m.LoadChunk(context.Background(), snapshots[0], 2)
+	assert.Error(t, err)
+	_, err = m.LoadChunk(context.Background(), &snapshot{Height: 3, Format: 1, Chunks: 1}, 0)
+	assert.Error(t, err)
+}
+
+// The chunks are fetched from the mirrors only when no peer can take the
+// request.
+func TestSyncer_requestChunk_mirrorFallback(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	cfg.MaxInflightChunksPerPeer = 1
+	syncer := newSyncer(*cfg, log.NewNopLogger(), &proxymocks.AppConnSnapshot{}, &proxymocks.AppConnQuery{},
+		&mocks.StateProvider{}, "", nil, "")
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1, 2, 3}}
+	peer := simplePeer("a")
+	peer.On("Send", mock.MatchedBy(func(e p2p.Envelope) bool {
+		return e.ChannelID == ChunkChannel
+	})).Once().Return(true)
+	_, err := syncer.snapshots.Add(peer, s)
+	require.NoError(t, err)
+	syncer.snapshots.AddMirror(newMirror("https://mirror.invalid", time.Millisecond), s)
+
+	assert.True(t, syncer.requestChunk(s, 0))
+	// the peer has no spare capacity left
+	assert.True(t, syncer.requestChunk(s, 1))
+	peer.AssertExpectations(t)
+}
+
+func TestSyncer_SyncAny_mirror(t *testing.T) {
+	state := sm.State{
+		Version: cmtstate.Version{
+			Consensus: cmtversion.Consensus{Block: version.BlockProtocol, App: testAppVersion},
+		},
+		LastBlockHeight: 1,
+		AppHash:         []byte("app_hash"),
+	}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	srv := startMirror(t, [][]byte{{1, 1, 0}, {1, 1, 1}}, nil)
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+
+	cfg := config.DefaultStateSyncConfig()
+	cfg.SnapshotMirrors = []string{srv.URL}
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "", nil, "")
+
+	connSnapshot.On("OfferSnapshotSync", abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1, 2, 3}, Metadata: []byte{}},
+		AppHash:  []byte("app_hash"),
+	}).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i := byte(0); i < 2; i++ {
+		connSnapshot.On("ApplySnapshotChunkSync", abci.RequestApplySnapshotChunk{
+			Index: uint32(i), Chunk: []byte{1, 1, i}, Sender: srv.URL,
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	connQuery.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  1,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	// No peer serves snapshots, the mirror does.
+	newState, lastCommit, err := syncer.SyncAny(0, func() {})
+	require.NoError(t, err)
+	assert.Equal(t, state, newState)
+	assert.Equal(t, commit, lastCommit)
+
+	connSnapshot.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/libs/tempfile"
+)
+
+// restoreProgress is the progress of a snapshot restoration, persisted to a file after each
+// applied chunk so that an interrupted restoration can be resumed where it stopped instead of
+// fetching and applying all the chunks again.
+type restoreProgress struct {
+	Height   uint64   `json:"height"`
+	Format   uint32   `json:"format"`
+	Chunks   uint32   `json:"chunks"`
+	Hash     []byte   `json:"hash"`
+	Metadata []byte   `json:"metadata"`
+	Applied  []uint32 `json:"applied"`
+}
+
+func newRestoreProgress(snapshot *snapshot) *restoreProgress {
+	return &restoreProgress{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+	}
+}
+
+// loadRestoreProgress loads the restore progress from the file, or returns nil if there is
+// none.
+func loadRestoreProgress(path string) (*restoreProgress, error) {
+	if path == "" {
+		return nil, nil
+	}
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	progress := new(restoreProgress)
+	if err := cmtjson.Unmarshal(bz, progress); err != nil {
+		return nil, fmt.Errorf("failed to decode %v: %w", path, err)
+	}
+	return progress, nil
+}
+
+// snapshot returns the snapshot being restored.
+func (p *restoreProgress) snapshot() *snapshot {
+	return &snapshot{
+		Height:   p.Height,
+		Format:   p.Format,
+		Chunks:   p.Chunks,
+		Hash:     p.Hash,
+		Metadata: p.Metadata,
+	}
+}
+
+// setApplied records whether the chunk is applied.
+func (p *restoreProgress) setApplied(index uint32, applied bool) {
+	i := sort.Search(len(p.Applied), func(i int) bool { return p.Applied[i] >= index })
+	found := i < len(p.Applied) && p.Applied[i] == index
+	switch {
+	case applied && !found:
+		p.Applied = append(p.Applied, 0)
+		copy(p.Applied[i+1:], p.Applied[i:])
+		p.Applied[i] = index
+	case !applied && found:
+		p.Applied = append(p.Applied[:i], p.Applied[i+1:]...)
+	}
+}
+
+// save persists the restore progress to the file, if any.
+func (p *restoreProgress) save(path string) error {
+	if path == "" {
+		return nil
+	}
+	bz, err := cmtjson.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(path, bz, 0o600)
+}
+
+// removeRestoreProgress removes the restore progress file, if any.
+func removeRestoreProgress(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -35,12 +35,25 @@ type Reactor struct {
 	tempDir   string
 	metrics   *Metrics
 
+	// blockStore serves and restores light snapshots, if set.
+	blockStore sm.BlockStore
+	// progressFile persists the restore progress, if set.
+	progressFile string
+	// txIndexSnapshotDir is the directory of the tx index snapshots served, if
+	// set.
+	txIndexSnapshotDir string
+
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
+	// This will only be set when a tx index snapshot is being restored.
+	txIndexRestorer *txIndexRestorer
 }
 
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
 // NewReactor creates a new state sync reactor.
 func NewReactor(
 	cfg config.StateSyncConfig,
@@ -48,6 +61,7 @@ func NewReactor(
 	connQuery proxy.AppConnQuery,
 	tempDir string,
 	metrics *Metrics,
+	options ...ReactorOption,
 ) *Reactor {
 
 	r := &Reactor{
@@ -58,9 +72,25 @@ func NewReactor(
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
 
+	for _, option := range options {
+		option(r)
+	}
+
 	return r
 }
 
+// WithBlockStore sets the block store used to serve light snapshots, and to
+// restore them into.
+func WithBlockStore(blockStore sm.BlockStore) ReactorOption {
+	return func(r *Reactor) { r.blockStore = blockStore }
+}
+
+// WithProgressFile sets the file where the progress of a snapshot restoration is persisted, so
+// that it can be resumed after a restart.
+func WithProgressFile(path string) ReactorOption {
+	return func(r *Reactor) { r.progressFile = path }
+}
+
 // GetChannels implements p2p.Reactor.
 func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -102,6 +132,9 @@ func (r *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	if r.syncer != nil {
 		r.syncer.RemovePeer(peer)
 	}
+	if r.txIndexRestorer != nil {
+		r.txIndexRestorer.removePeer(peer.ID())
+	}
 }
 
 // Receive implements p2p.Reactor.
@@ -144,6 +177,16 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 		case *ssproto.SnapshotsResponse:
 			r.mtx.RLock()
 			defer r.mtx.RUnlock()
+			if msg.Format == TxIndexSnapshotFormat {
+				// never offered to the application
+				if r.txIndexRestorer == nil {
+					return
+				}
+				if err := r.txIndexRestorer.addSnapshot(e.Src, msg); err != nil {
+					r.Logger.Error("Invalid tx index snapshot", "height", msg.Height, "peer", e.Src.ID(), "err", err)
+				}
+				return
+			}
 			if r.syncer == nil {
 				r.Logger.Debug("Received unexpected snapshot, no state sync in progress")
 				return
@@ -172,11 +215,22 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 		case *ssproto.ChunkRequest:
 			r.Logger.Debug("Received chunk request", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
-			resp, err := r.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
-				Height: msg.Height,
-				Format: msg.Format,
-				Chunk:  msg.Index,
-			})
+			var (
+				resp = &abci.ResponseLoadSnapshotChunk{}
+				err  error
+			)
+			switch msg.Format {
+			case LightSnapshotFormat:
+				resp.Chunk, err = r.loadLightSnapshotChunk(msg.Height, msg.Index)
+			case TxIndexSnapshotFormat:
+				resp.Chunk, err = r.loadTxIndexSnapshotChunk(msg.Height, msg.Index)
+			default:
+				resp, err = r.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+					Height: msg.Height,
+					Format: msg.Format,
+					Chunk:  msg.Index,
+				})
+			}
 			if err != nil {
 				r.Logger.Error("Failed to load chunk", "height", msg.Height, "format", msg.Format,
 					"chunk", msg.Index, "err", err)
@@ -198,6 +252,18 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 		case *ssproto.ChunkResponse:
 			r.mtx.RLock()
 			defer r.mtx.RUnlock()
+			if msg.Format == TxIndexSnapshotFormat {
+				if r.txIndexRestorer != nil {
+					r.txIndexRestorer.addChunk(&chunk{
+						Height: msg.Height,
+						Format: msg.Format,
+						Index:  msg.Index,
+						Chunk:  msg.Chunk,
+						Sender: e.Src.ID(),
+					})
+				}
+				return
+			}
 			if r.syncer == nil {
 				r.Logger.Debug("Received unexpected chunk, no state sync in progress", "peer", e.Src.ID())
 				return
@@ -226,15 +292,26 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 	}
 }
 
-// recentSnapshots fetches the n most recent snapshots from the app
+// recentSnapshots fetches the n most recent snapshots from the app, along with the light
+// snapshots served from the block store, and the most recent tx index snapshot.
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	resp, err := r.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(resp.Snapshots, func(i, j int) bool {
-		a := resp.Snapshots[i]
-		b := resp.Snapshots[j]
+	snapshots := r.lightSnapshots(n)
+	for _, s := range resp.Snapshots {
+		snapshots = append(snapshots, &snapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		a := snapshots[i]
+		b := snapshots[j]
 		switch {
 		case a.Height > b.Height:
 			return true
@@ -244,18 +321,11 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 			return false
 		}
 	})
-	snapshots := make([]*snapshot, 0, n)
-	for i, s := range resp.Snapshots {
-		if i >= recentSnapshots {
-			break
-		}
-		snapshots = append(snapshots, &snapshot{
-			Height:   s.Height,
-			Format:   s.Format,
-			Chunks:   s.Chunks,
-			Hash:     s.Hash,
-			Metadata: s.Metadata,
-		})
+	if uint32(len(snapshots)) > n {
+		snapshots = snapshots[:n]
+	}
+	if s := r.txIndexSnapshot(); s != nil {
+		snapshots = append(snapshots, s)
 	}
 	return snapshots, nil
 }
@@ -269,7 +339,7 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 	r.metrics.Syncing.Set(1)
-	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir, r.blockStore, r.progressFile)
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -0,0 +1,187 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+const schedulerSubscriber = "SnapshotScheduler"
+
+// SnapshotScheduler asks the application to take a snapshot of its state every
+// interval heights, once the block at that height is committed, and to delete
+// its snapshots but those of the keepRecent most recent heights, rather than
+// leaving the scheduling to the application.
+//
+// The requests are ABCI queries, which the application opts into by handling
+// their paths:
+//
+//   - /snapshot/create/<height> takes a snapshot of the state at the height,
+//     which the application then lists in ListSnapshots. The application
+//     should take it in the background and reply right away;
+//   - /snapshot/delete/<height>/<format> deletes a snapshot listed by
+//     ListSnapshots.
+//
+// A snapshot is skipped if the previous one is still being requested.
+type SnapshotScheduler struct {
+	service.BaseService
+
+	query      proxy.AppConnQuery
+	snapshot   proxy.AppConnSnapshot
+	eventBus   *types.EventBus
+	interval   int64
+	keepRecent int64
+
+	heights chan int64
+}
+
+// NewSnapshotScheduler creates a snapshot scheduler requesting a snapshot
+// every interval heights, and keeping the snapshots of the keepRecent most
+// recent heights, or all of them if keepRecent is 0.
+func NewSnapshotScheduler(
+	query proxy.AppConnQuery,
+	snapshot proxy.AppConnSnapshot,
+	eventBus *types.EventBus,
+	interval, keepRecent int64,
+) *SnapshotScheduler {
+	s := &SnapshotScheduler{
+		query:      query,
+		snapshot:   snapshot,
+		eventBus:   eventBus,
+		interval:   interval,
+		keepRecent: keepRecent,
+		heights:    make(chan int64, 1),
+	}
+	s.BaseService = *service.NewBaseService(nil, "SnapshotScheduler", s)
+	return s
+}
+
+// OnStart implements service.Service by subscribing to the new block headers.
+func (s *SnapshotScheduler) OnStart() error {
+	// The subscription is unbuffered not to be canceled: the headers are only
+	// read here, the snapshots being requested by routine.
+	sub, err := s.eventBus.SubscribeUnbuffered(context.Background(), schedulerSubscriber, types.EventQueryNewBlockHeader)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-s.Quit():
+				return
+			case <-sub.Canceled():
+				s.Logger.Error("Snapshot scheduler subscription was canceled", "err", sub.Err())
+				return
+			case msg := <-sub.Out():
+				height := msg.Data().(types.EventDataNewBlockHeader).Header.Height
+				if height%s.interval != 0 {
+					continue
+				}
+				select {
+				case s.heights <- height:
+				default:
+					s.Logger.Info("Skipping the snapshot; the previous one is still being requested",
+						"height", height)
+				}
+			}
+		}
+	}()
+	go s.routine()
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from the event bus.
+func (s *SnapshotScheduler) OnStop() {
+	if s.eventBus.IsRunning() {
+		_ = s.eventBus.UnsubscribeAll(context.Background(), schedulerSubscriber)
+	}
+}
+
+func (s *SnapshotScheduler) routine() {
+	for {
+		select {
+		case <-s.Quit():
+			return
+		case height := <-s.heights:
+			if err := s.createSnapshot(height); err != nil {
+				s.Logger.Error("Failed to request a snapshot", "height", height, "err", err)
+				continue
+			}
+			if err := s.pruneSnapshots(); err != nil {
+				s.Logger.Error("Failed to prune the snapshots", "err", err)
+			}
+		}
+	}
+}
+
+// createSnapshot asks the application to take a snapshot at the height.
+func (s *SnapshotScheduler) createSnapshot(height int64) error {
+	res, err := s.query.QuerySync(abci.RequestQuery{
+		Path: fmt.Sprintf("/snapshot/create/%d", height),
+	})
+	if err != nil {
+		return err
+	}
+	if res.IsErr() {
+		return fmt.Errorf("error querying abci app: %v", res)
+	}
+	s.Logger.Info("Requested a snapshot", "height", height)
+	return nil
+}
+
+// pruneSnapshots asks the application to delete its snapshots but those of
+// the keepRecent most recent heights.
+func (s *SnapshotScheduler) pruneSnapshots() error {
+	if s.keepRecent == 0 {
+		return nil
+	}
+	res, err := s.snapshot.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshotsToPrune(res.Snapshots, s.keepRecent) {
+		res, err := s.query.QuerySync(abci.RequestQuery{
+			Path: fmt.Sprintf("/snapshot/delete/%d/%d", snapshot.Height, snapshot.Format),
+		})
+		if err != nil {
+			return err
+		}
+		if res.IsErr() {
+			return fmt.Errorf("error querying abci app: %v", res)
+		}
+		s.Logger.Info("Deleted a snapshot", "height", snapshot.Height, "format", snapshot.Format)
+	}
+	return nil
+}
+
+// snapshotsToPrune returns the snapshots which are not at one of the
+// keepRecent most recent heights, all their formats being kept.
+func snapshotsToPrune(snapshots []*abci.Snapshot, keepRecent int64) []*abci.Snapshot {
+	heights := make([]uint64, 0, len(snapshots))
+	seen := make(map[uint64]bool, len(snapshots))
+	for _, snapshot := range snapshots {
+		if !seen[snapshot.Height] {
+			seen[snapshot.Height] = true
+			heights = append(heights, snapshot.Height)
+		}
+	}
+	if int64(len(heights)) <= keepRecent {
+		return nil
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	minHeight := heights[keepRecent-1]
+
+	var prune []*abci.Snapshot
+	for _, snapshot := range snapshots {
+		if snapshot.Height < minHeight {
+			prune = append(prune, snapshot)
+		}
+	}
+	return prune
+}
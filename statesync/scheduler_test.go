@@ -0,0 +1,87 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestSnapshotScheduler(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	connQuery := &proxymocks.AppConnQuery{}
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	deleted := make(chan string, 1)
+	connQuery.On("QuerySync", abci.RequestQuery{Path: "/snapshot/create/4"}).
+		Return(&abci.ResponseQuery{}, nil)
+	connQuery.On("QuerySync", abci.RequestQuery{Path: "/snapshot/delete/2/1"}).
+		Run(func(args mock.Arguments) { deleted <- args.Get(0).(abci.RequestQuery).Path }).
+		Return(&abci.ResponseQuery{}, nil)
+	connSnapshot.On("ListSnapshotsSync", abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 2, Format: 1},
+			{Height: 4, Format: 1},
+		},
+	}, nil)
+
+	s := NewSnapshotScheduler(connQuery, connSnapshot, eventBus, 4, 1)
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	for height := int64(1); height <= 5; height++ {
+		err := eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+		})
+		require.NoError(t, err)
+	}
+
+	select {
+	case path := <-deleted:
+		assert.Equal(t, "/snapshot/delete/2/1", path)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the snapshot to be deleted")
+	}
+	connQuery.AssertNumberOfCalls(t, "QuerySync", 2)
+}
+
+func TestSnapshotsToPrune(t *testing.T) {
+	snapshots := []*abci.Snapshot{
+		{Height: 30, Format: 1},
+		{Height: 10, Format: 1},
+		{Height: 20, Format: 1},
+		{Height: 20, Format: 2},
+		{Height: 10, Format: 2},
+	}
+
+	assert.Empty(t, snapshotsToPrune(snapshots, 3))
+	assert.Empty(t, snapshotsToPrune(snapshots, 4))
+	assert.Equal(t, []*abci.Snapshot{
+		{Height: 10, Format: 1},
+		{Height: 10, Format: 2},
+	}, snapshotsToPrune(snapshots, 2))
+	assert.Equal(t, []*abci.Snapshot{
+		{Height: 10, Format: 1},
+		{Height: 20, Format: 1},
+		{Height: 20, Format: 2},
+		{Height: 10, Format: 2},
+	}, snapshotsToPrune(snapshots, 1))
+}
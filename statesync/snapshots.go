@@ -41,8 +41,9 @@ func (s *snapshot) Key() snapshotKey {
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	cmtsync.Mutex
-	snapshots     map[snapshotKey]*snapshot
-	snapshotPeers map[snapshotKey]map[p2p.ID]p2p.Peer
+	snapshots       map[snapshotKey]*snapshot
+	snapshotPeers   map[snapshotKey]map[p2p.ID]p2p.Peer
+	snapshotMirrors map[snapshotKey]map[p2p.ID]*mirror
 
 	// indexes for fast searches
 	formatIndex map[uint32]map[snapshotKey]bool
@@ -60,6 +61,7 @@ func newSnapshotPool() *snapshotPool {
 	return &snapshotPool{
 		snapshots:         make(map[snapshotKey]*snapshot),
 		snapshotPeers:     make(map[snapshotKey]map[p2p.ID]p2p.Peer),
+		snapshotMirrors:   make(map[snapshotKey]map[p2p.ID]*mirror),
 		formatIndex:       make(map[uint32]map[snapshotKey]bool),
 		heightIndex:       make(map[uint64]map[snapshotKey]bool),
 		peerIndex:         make(map[p2p.ID]map[snapshotKey]bool),
@@ -99,8 +101,39 @@ func (p *snapshotPool) Add(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	}
 	p.peerIndex[peer.ID()][key] = true
 
+	return p.add(key, snapshot), nil
+}
+
+// AddMirror adds a snapshot served by a mirror to the pool. It returns true if this was a new,
+// non-blacklisted snapshot.
+func (p *snapshotPool) AddMirror(m *mirror, snapshot *snapshot) bool {
+	key := snapshot.Key()
+
+	p.Lock()
+	defer p.Unlock()
+
+	switch {
+	case p.formatBlacklist[snapshot.Format]:
+		return false
+	case p.peerBlacklist[m.ID()]:
+		return false
+	case p.snapshotBlacklist[key]:
+		return false
+	}
+
+	if p.snapshotMirrors[key] == nil {
+		p.snapshotMirrors[key] = make(map[p2p.ID]*mirror)
+	}
+	p.snapshotMirrors[key][m.ID()] = m
+
+	return p.add(key, snapshot)
+}
+
+// add adds a snapshot to the snapshots and indexes, returning false if it already exists. The
+// caller must hold the mutex lock.
+func (p *snapshotPool) add(key snapshotKey, snapshot *snapshot) bool {
 	if p.snapshots[key] != nil {
-		return false, nil
+		return false
 	}
 	p.snapshots[key] = snapshot
 
@@ -114,7 +147,7 @@ func (p *snapshotPool) Add(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	}
 	p.heightIndex[snapshot.Height][key] = true
 
-	return true, nil
+	return true
 }
 
 // Best returns the "best" currently known snapshot, if any.
@@ -152,6 +185,22 @@ func (p *snapshotPool) GetPeers(snapshot *snapshot) []p2p.Peer {
 	return peers
 }
 
+// GetMirrors returns the mirrors serving a snapshot.
+func (p *snapshotPool) GetMirrors(snapshot *snapshot) []*mirror {
+	key := snapshot.Key()
+	p.Lock()
+	defer p.Unlock()
+
+	mirrors := make([]*mirror, 0, len(p.snapshotMirrors[key]))
+	for _, m := range p.snapshotMirrors[key] {
+		mirrors = append(mirrors, m)
+	}
+	sort.Slice(mirrors, func(a int, b int) bool {
+		return mirrors[a].ID() < mirrors[b].ID()
+	})
+	return mirrors
+}
+
 // Ranked returns a list of snapshots ranked by preference. The current heuristic is very naïve,
 // preferring the snapshot with the greatest height, then greatest format, then greatest number of
 // peers. This can be improved quite a lot.
@@ -227,15 +276,24 @@ func (p *snapshotPool) RemovePeer(peerID p2p.ID) {
 	p.removePeer(peerID)
 }
 
-// removePeer removes a peer. The caller must hold the mutex lock.
+// removePeer removes a peer, or a mirror. The caller must hold the mutex lock.
 func (p *snapshotPool) removePeer(peerID p2p.ID) {
 	for key := range p.peerIndex[peerID] {
 		delete(p.snapshotPeers[key], peerID)
-		if len(p.snapshotPeers[key]) == 0 {
+		if len(p.snapshotPeers[key]) == 0 && len(p.snapshotMirrors[key]) == 0 {
 			p.removeSnapshot(key)
 		}
 	}
 	delete(p.peerIndex, peerID)
+
+	for key, mirrors := range p.snapshotMirrors {
+		if _, ok := mirrors[peerID]; ok {
+			delete(mirrors, peerID)
+			if len(mirrors) == 0 && len(p.snapshotPeers[key]) == 0 {
+				p.removeSnapshot(key)
+			}
+		}
+	}
 }
 
 // removeSnapshot removes a snapshot. The caller must hold the mutex lock.
@@ -252,4 +310,5 @@ func (p *snapshotPool) removeSnapshot(key snapshotKey) {
 		delete(p.peerIndex[peerID], key)
 	}
 	delete(p.snapshotPeers, key)
+	delete(p.snapshotMirrors, key)
 }
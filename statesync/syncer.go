@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -55,13 +56,21 @@ type syncer struct {
 	stateProvider StateProvider
 	conn          proxy.AppConnSnapshot
 	connQuery     proxy.AppConnQuery
+	blockStore    sm.BlockStore
 	snapshots     *snapshotPool
+	mirrors       []*mirror
+	requests      *chunkRequests
 	tempDir       string
+	progressFile  string
 	chunkFetchers int32
 	retryTimeout  time.Duration
 
 	mtx    cmtsync.RWMutex
 	chunks *chunkQueue
+
+	// progress of the restoration, persisted to progressFile. It holds an interrupted
+	// restoration until Sync() resumes it.
+	progress *restoreProgress
 }
 
 // newSyncer creates a new syncer.
@@ -72,15 +81,26 @@ func newSyncer(
 	connQuery proxy.AppConnQuery,
 	stateProvider StateProvider,
 	tempDir string,
+	blockStore sm.BlockStore,
+	progressFile string,
 ) *syncer {
 
+	mirrors := make([]*mirror, 0, len(cfg.SnapshotMirrors))
+	for _, url := range cfg.SnapshotMirrors {
+		mirrors = append(mirrors, newMirror(url, cfg.ChunkRequestTimeout))
+	}
+
 	return &syncer{
 		logger:        logger,
 		stateProvider: stateProvider,
 		conn:          conn,
 		connQuery:     connQuery,
+		blockStore:    blockStore,
 		snapshots:     newSnapshotPool(),
+		mirrors:       mirrors,
+		requests:      newChunkRequests(int(cfg.MaxInflightChunksPerPeer)),
 		tempDir:       tempDir,
+		progressFile:  progressFile,
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
 	}
@@ -99,8 +119,10 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, err
 	}
 	if added {
+		s.requests.Received(chunk.Sender, chunk.Index, len(chunk.Chunk))
+		throughput, received := s.requests.Throughput(chunk.Sender)
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
-			"chunk", chunk.Index)
+			"chunk", chunk.Index, "peer", chunk.Sender, "throughput", throughput, "received", received)
 	} else {
 		s.logger.Debug("Ignoring duplicate chunk in queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
@@ -122,6 +144,25 @@ func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	return added, nil
 }
 
+// discoverMirrors adds the snapshots listed by the snapshot mirrors to the pool.
+func (s *syncer) discoverMirrors() {
+	for _, m := range s.mirrors {
+		ctx, cancel := context.WithTimeout(context.Background(), s.retryTimeout)
+		snapshots, err := m.Snapshots(ctx)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to fetch snapshots from mirror", "mirror", m.ID(), "err", err)
+			continue
+		}
+		for _, snapshot := range snapshots {
+			if s.snapshots.AddMirror(m, snapshot) {
+				s.logger.Info("Discovered new snapshot", "height", snapshot.Height, "format", snapshot.Format,
+					"hash", log.NewLazySprintf("%X", snapshot.Hash), "mirror", m.ID())
+			}
+		}
+	}
+}
+
 // AddPeer adds a peer to the pool. For now we just keep it simple and send a single request
 // to discover snapshots, later we may want to do retries and stuff.
 func (s *syncer) AddPeer(peer p2p.Peer) {
@@ -137,6 +178,7 @@ func (s *syncer) AddPeer(peer p2p.Peer) {
 func (s *syncer) RemovePeer(peer p2p.Peer) {
 	s.logger.Debug("Removing peer from sync", "peer", peer.ID())
 	s.snapshots.RemovePeer(peer.ID())
+	s.requests.RemovePeer(peer.ID())
 }
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
@@ -151,25 +193,41 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 		s.logger.Info("Discovering snapshots", "discoverTime", discoveryTime)
 		time.Sleep(discoveryTime)
 	}
+	s.discoverMirrors()
+
+	// A restoration interrupted by a restart is resumed if its snapshot is still available.
+	progress, err := loadRestoreProgress(s.progressFile)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("failed to load restore progress: %w", err)
+	}
+	if progress != nil {
+		s.logger.Info("Found interrupted snapshot restoration", "height", progress.Height,
+			"format", progress.Format, "applied", len(progress.Applied), "total", progress.Chunks)
+	}
 
 	// The app may ask us to retry a snapshot restoration, in which case we need to reuse
 	// the snapshot and chunk queue from the previous loop iteration.
 	var (
 		snapshot *snapshot
 		chunks   *chunkQueue
-		err      error
 	)
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
 			snapshot = s.snapshots.Best()
 			chunks = nil
+			if progress != nil && len(s.snapshots.GetPeers(progress.snapshot())) > 0 {
+				snapshot = progress.snapshot()
+				s.progress = progress
+			}
+			progress = nil
 		}
 		if snapshot == nil {
 			if discoveryTime == 0 {
 				return sm.State{}, nil, errNoSnapshots
 			}
 			retryHook()
+			s.discoverMirrors()
 			s.logger.Info("sync any", "msg", log.NewLazySprintf("Discovering snapshots for %v", discoveryTime))
 			time.Sleep(discoveryTime)
 			continue
@@ -185,12 +243,15 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 		newState, commit, err := s.Sync(snapshot, chunks)
 		switch {
 		case err == nil:
+			s.clearProgress()
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.clearProgress()
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
+			s.clearProgress()
 			chunks.RetryAll()
 			s.logger.Info("Retrying snapshot", "height", snapshot.Height, "format", snapshot.Format,
 				"hash", log.NewLazySprintf("%X", snapshot.Hash))
@@ -217,16 +278,25 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 				s.snapshots.RejectPeer(peer.ID())
 				s.logger.Info("Snapshot sender rejected", "peer", peer.ID())
 			}
+			for _, m := range s.snapshots.GetMirrors(snapshot) {
+				s.snapshots.RejectPeer(m.ID())
+				s.logger.Info("Snapshot mirror rejected", "mirror", m.ID())
+			}
 
 		case errors.Is(err, context.DeadlineExceeded):
 			s.logger.Info("Timed out validating snapshot, rejecting", "height", snapshot.Height, "err", err)
 			s.snapshots.Reject(snapshot)
 
 		default:
+			// Keep the progress to resume the restoration, unless the restored app is invalid.
+			if errors.Is(err, errVerifyFailed) {
+				s.clearProgress()
+			}
 			return sm.State{}, nil, fmt.Errorf("snapshot restoration failed: %w", err)
 		}
 
 		// Discard snapshot and chunks for next iteration
+		s.clearProgress()
 		err = chunks.Close()
 		if err != nil {
 			s.logger.Error("Failed to clean up chunk queue", "err", err)
@@ -265,8 +335,21 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 	snapshot.trustedAppHash = appHash
 
-	// Offer snapshot to ABCI app.
-	err = s.offerSnapshot(snapshot)
+	// Offer snapshot to ABCI app, unless it is a light snapshot which does not involve it, or
+	// an interrupted restoration of it is resumed.
+	switch {
+	case snapshot.Format == LightSnapshotFormat:
+		err = s.checkLightSnapshot(snapshot)
+	case s.progress != nil && s.progress.snapshot().Key() == snapshot.Key() && len(s.progress.Applied) > 0:
+		s.logger.Info("Resuming snapshot restoration", "height", snapshot.Height,
+			"format", snapshot.Format, "applied", len(s.progress.Applied), "total", snapshot.Chunks)
+		chunks.Skip(s.progress.Applied)
+	default:
+		err = s.offerSnapshot(snapshot)
+		if err == nil {
+			s.progress = newRestoreProgress(snapshot)
+		}
+	}
 	if err != nil {
 		return sm.State{}, nil, err
 	}
@@ -300,7 +383,11 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Restore snapshot
-	err = s.applyChunks(chunks)
+	if snapshot.Format == LightSnapshotFormat {
+		err = s.restoreLightSnapshot(snapshot, chunks, commit)
+	} else {
+		err = s.applyChunks(chunks)
+	}
 	if err != nil {
 		return sm.State{}, nil, err
 	}
@@ -375,12 +462,17 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 		s.logger.Info("Applied snapshot chunk to ABCI app", "height", chunk.Height,
 			"format", chunk.Format, "chunk", chunk.Index, "total", chunks.Size())
 
+		if resp.Result == abci.ResponseApplySnapshotChunk_ACCEPT {
+			s.setApplied(chunk.Index, true)
+		}
+
 		// Discard and refetch any chunks as requested by the app
 		for _, index := range resp.RefetchChunks {
 			err := chunks.Discard(index)
 			if err != nil {
 				return fmt.Errorf("failed to discard chunk %v: %w", index, err)
 			}
+			s.setApplied(index, false)
 		}
 
 		// Reject any senders as requested by the app
@@ -410,6 +502,26 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 	}
 }
 
+// setApplied records whether the chunk is applied in the restore progress, and persists it.
+// Failing to persist it only prevents resuming the restoration.
+func (s *syncer) setApplied(index uint32, applied bool) {
+	if s.progress == nil {
+		return
+	}
+	s.progress.setApplied(index, applied)
+	if err := s.progress.save(s.progressFile); err != nil {
+		s.logger.Error("Failed to save restore progress", "err", err)
+	}
+}
+
+// clearProgress clears the restore progress once the restoration completed or was abandoned.
+func (s *syncer) clearProgress() {
+	s.progress = nil
+	if err := removeRestoreProgress(s.progressFile); err != nil {
+		s.logger.Error("Failed to remove restore progress", "err", err)
+	}
+}
+
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
 // will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
@@ -441,33 +553,74 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
-		ticker := time.NewTicker(s.retryTimeout)
-		defer ticker.Stop()
+		next = s.awaitChunk(ctx, snapshot, chunks, index)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// awaitChunk requests a chunk and waits for it, requesting it from another peer as well each
+// time it is slower than usual. It returns true if the chunk was received, or false if it timed
+// out or the context was canceled.
+func (s *syncer) awaitChunk(ctx context.Context, snapshot *snapshot, chunks *chunkQueue, index uint32) bool {
+	timeout := time.NewTimer(s.retryTimeout)
+	defer timeout.Stop()
 
-		s.requestChunk(snapshot, index)
+	for {
+		slowTimeout := s.requests.SlowTimeout(s.retryTimeout)
+		if !s.requestChunk(snapshot, index) {
+			// All the peers are busy, try again shortly.
+			slowTimeout = minSlowChunkTimeout
+		}
+		slow := time.NewTimer(slowTimeout)
 
 		select {
 		case <-chunks.WaitFor(index):
-			next = true
+			slow.Stop()
+			return true
 
-		case <-ticker.C:
-			next = false
+		case <-slow.C:
+			s.logger.Debug("Snapshot chunk is slow, requesting it from another peer",
+				"height", snapshot.Height, "format", snapshot.Format, "chunk", index)
+
+		case <-timeout.C:
+			slow.Stop()
+			s.requests.Expire(index)
+			return false
 
 		case <-ctx.Done():
-			return
+			slow.Stop()
+			return false
 		}
-
-		ticker.Stop()
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
+// requestChunk requests a chunk from the fastest peer with spare capacity it was not already
+// requested from, falling back to a random mirror serving the snapshot if there is no such peer.
+// It returns false if there is no such mirror either.
+func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) bool {
+	var peer p2p.Peer
+	peers := s.snapshots.GetPeers(snapshot)
+	if len(peers) > 0 {
+		peer = s.requests.Pick(peers, chunk)
+	}
 	if peer == nil {
+		if mirrors := s.snapshots.GetMirrors(snapshot); len(mirrors) > 0 {
+			m := mirrors[rand.Intn(len(mirrors))] //nolint:gosec // G404: Use of weak random number generator
+			go s.fetchMirrorChunk(m, snapshot, chunk)
+			return true
+		}
+	}
+	if len(peers) == 0 {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
-		return
+		return false
+	}
+	if peer == nil {
+		s.logger.Debug("No peer available to request snapshot chunk", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", chunk)
+		return false
 	}
 	s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height,
 		"format", snapshot.Format, "chunk", chunk, "peer", peer.ID())
@@ -479,6 +632,30 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 			Index:  chunk,
 		},
 	})
+	return true
+}
+
+// fetchMirrorChunk fetches a chunk from a mirror and adds it to the chunk queue.
+func (s *syncer) fetchMirrorChunk(m *mirror, snapshot *snapshot, index uint32) {
+	s.logger.Debug("Fetching snapshot chunk from mirror", "height", snapshot.Height,
+		"format", snapshot.Format, "chunk", index, "mirror", m.ID())
+	bz, err := m.LoadChunk(context.Background(), snapshot, index)
+	if err != nil {
+		s.logger.Error("Failed to fetch snapshot chunk from mirror", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "mirror", m.ID(), "err", err)
+		return
+	}
+	_, err = s.AddChunk(&chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  bz,
+		Sender: m.ID(),
+	})
+	if err != nil {
+		s.logger.Error("Failed to add chunk", "height", snapshot.Height, "format", snapshot.Format,
+			"chunk", index, "err", err)
+	}
 }
 
 // verifyApp verifies the sync, checking the app hash, last block height and app version
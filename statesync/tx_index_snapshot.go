@@ -0,0 +1,268 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	"github.com/cometbft/cometbft/p2p"
+	ssproto "github.com/cometbft/cometbft/proto/tendermint/statesync"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+)
+
+// TxIndexSnapshotFormat is the snapshot format of the snapshots of the kv tx
+// index. Like light snapshots, they are served by CometBFT instead of the ABCI
+// application, so applications must not use this format for their own
+// snapshots.
+//
+// A tx index snapshot is exported by the "tx-index-snapshot export" command.
+// Its metadata is the concatenation of the SHA-256 hashes of its chunks, and
+// its hash commits to its height and to these hashes (see kv.Snapshot). It is
+// never offered to the state sync of the application: it is only restored,
+// after a state sync, when its hash is trusted by the operator.
+const TxIndexSnapshotFormat = uint32(math.MaxUint32 - 1)
+
+// txIndexSnapshotDiscoveryAttempts is the number of times the peers are asked
+// for their snapshots before restoring the tx index snapshot is given up.
+const txIndexSnapshotDiscoveryAttempts = 3
+
+// WithTxIndexSnapshots sets the directory of the tx index snapshots served to
+// peers.
+func WithTxIndexSnapshots(dir string) ReactorOption {
+	return func(r *Reactor) { r.txIndexSnapshotDir = dir }
+}
+
+// txIndexSnapshot returns the most recent tx index snapshot, if any.
+func (r *Reactor) txIndexSnapshot() *snapshot {
+	if r.txIndexSnapshotDir == "" {
+		return nil
+	}
+	snapshots, err := kv.ListSnapshots(r.txIndexSnapshotDir)
+	if err != nil {
+		r.Logger.Error("Failed to list tx index snapshots", "err", err)
+		return nil
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	s := snapshots[0]
+	return &snapshot{
+		Height:   uint64(s.Height),
+		Format:   TxIndexSnapshotFormat,
+		Chunks:   uint32(len(s.ChunkHashes)),
+		Hash:     s.Hash,
+		Metadata: s.Metadata(),
+	}
+}
+
+// loadTxIndexSnapshotChunk returns the chunk of the tx index snapshot, or nil
+// if it is not available.
+func (r *Reactor) loadTxIndexSnapshotChunk(height uint64, index uint32) ([]byte, error) {
+	if r.txIndexSnapshotDir == "" || height > math.MaxInt64 {
+		return nil, nil
+	}
+	s, err := kv.LoadSnapshot(r.txIndexSnapshotDir, int64(height))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(s.ChunkHashes) {
+		return nil, nil
+	}
+	return s.LoadChunk(r.txIndexSnapshotDir, index)
+}
+
+// txIndexRestorer collects the peers advertising the tx index snapshot being
+// restored, and the chunks they send.
+type txIndexRestorer struct {
+	hash []byte
+
+	mtx      cmtsync.Mutex
+	snapshot *kv.Snapshot
+	peers    []p2p.Peer
+	chunks   chan *chunk
+}
+
+func (t *txIndexRestorer) addSnapshot(peer p2p.Peer, msg *ssproto.SnapshotsResponse) error {
+	if !bytes.Equal(msg.Hash, t.hash) {
+		return nil
+	}
+	s, err := kv.DecodeSnapshot(int64(msg.Height), msg.Hash, msg.Metadata)
+	if err != nil {
+		return err
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, p := range t.peers {
+		if p.ID() == peer.ID() {
+			return nil
+		}
+	}
+	t.snapshot = s
+	t.peers = append(t.peers, peer)
+	return nil
+}
+
+func (t *txIndexRestorer) removePeer(id p2p.ID) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for i, p := range t.peers {
+		if p.ID() == id {
+			t.peers = append(t.peers[:i], t.peers[i+1:]...)
+			return
+		}
+	}
+}
+
+// nextPeer returns the peer to request the i-th chunk from, or nil if there
+// are none left.
+func (t *txIndexRestorer) nextPeer(i int) p2p.Peer {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if len(t.peers) == 0 {
+		return nil
+	}
+	return t.peers[i%len(t.peers)]
+}
+
+func (t *txIndexRestorer) addChunk(c *chunk) {
+	select {
+	case t.chunks <- c:
+	default: // unexpected
+	}
+}
+
+// RestoreTxIndex fetches the tx index snapshot with the given hash from the
+// peers, and restores it into the tx index, which must be empty. The snapshot
+// must be at the given height, the one of the state restored by state sync, so
+// that block sync indexes the blocks after it. Each chunk is verified against
+// the hash before it is restored, and the peers sending invalid chunks are
+// dropped. The records restored are deleted if it fails, so that it can be
+// retried.
+func (r *Reactor) RestoreTxIndex(txIndex *kv.TxIndex, hash []byte, height int64) error {
+	empty, err := txIndex.IsEmpty()
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errors.New("the tx index is not empty")
+	}
+
+	restorer := &txIndexRestorer{hash: hash, chunks: make(chan *chunk, 1)}
+	r.mtx.Lock()
+	if r.txIndexRestorer != nil {
+		r.mtx.Unlock()
+		return errors.New("a tx index restore is already in progress")
+	}
+	r.txIndexRestorer = restorer
+	r.mtx.Unlock()
+	defer func() {
+		r.mtx.Lock()
+		r.txIndexRestorer = nil
+		r.mtx.Unlock()
+	}()
+
+	discoveryTime := r.cfg.DiscoveryTime
+	if discoveryTime == 0 {
+		discoveryTime = minimumDiscoveryTime
+	}
+	var s *kv.Snapshot
+	for attempt := 0; attempt < txIndexSnapshotDiscoveryAttempts && s == nil; attempt++ {
+		r.Logger.Info("Discovering the tx index snapshot", "hash", log.NewLazySprintf("%X", hash))
+		r.Switch.Broadcast(p2p.Envelope{
+			ChannelID: SnapshotChannel,
+			Message:   &ssproto.SnapshotsRequest{},
+		})
+		select {
+		case <-time.After(discoveryTime):
+		case <-r.Quit():
+			return errors.New("state sync reactor stopped")
+		}
+		restorer.mtx.Lock()
+		s = restorer.snapshot
+		restorer.mtx.Unlock()
+	}
+	if s == nil {
+		return fmt.Errorf("no peer serves the tx index snapshot %X", hash)
+	}
+	if s.Height != height {
+		return fmt.Errorf("the tx index snapshot %X is at height %d, but the state was synced at height %d",
+			hash, s.Height, height)
+	}
+
+	r.Logger.Info("Restoring the tx index snapshot", "height", s.Height,
+		"hash", log.NewLazySprintf("%X", hash), "chunks", len(s.ChunkHashes))
+	if err := r.restoreTxIndexChunks(restorer, txIndex, s); err != nil {
+		if cerr := txIndex.Clear(); cerr != nil {
+			return fmt.Errorf("%w (failed to delete the restored records: %v)", err, cerr)
+		}
+		return err
+	}
+	return nil
+}
+
+// restoreTxIndexChunks fetches the chunks of the tx index snapshot from the
+// peers serving it, and restores them.
+func (r *Reactor) restoreTxIndexChunks(restorer *txIndexRestorer, txIndex *kv.TxIndex, s *kv.Snapshot) error {
+	for index, attempt := uint32(0), 0; int(index) < len(s.ChunkHashes); attempt++ {
+		peer := restorer.nextPeer(attempt)
+		if peer == nil {
+			return fmt.Errorf("no peer left to fetch chunk %d of the tx index snapshot from", index)
+		}
+		peer.Send(p2p.Envelope{
+			ChannelID: ChunkChannel,
+			Message: &ssproto.ChunkRequest{
+				Height: uint64(s.Height),
+				Format: TxIndexSnapshotFormat,
+				Index:  index,
+			},
+		})
+
+		chunk, err := r.awaitTxIndexChunk(restorer, peer.ID(), index)
+		if err == nil {
+			err = s.VerifyChunk(index, chunk)
+		}
+		if err != nil {
+			r.Logger.Error("Failed to fetch tx index snapshot chunk, dropping peer", "chunk", index,
+				"peer", peer.ID(), "err", err)
+			restorer.removePeer(peer.ID())
+			continue
+		}
+		if err := txIndex.RestoreSnapshotChunk(chunk); err != nil {
+			return fmt.Errorf("failed to restore chunk %d of the tx index snapshot: %w", index, err)
+		}
+		r.Logger.Info("Restored tx index snapshot chunk", "chunk", index, "total", len(s.ChunkHashes))
+		index++
+	}
+	return nil
+}
+
+// awaitTxIndexChunk waits for the chunk of the given index from the given
+// peer.
+func (r *Reactor) awaitTxIndexChunk(restorer *txIndexRestorer, peer p2p.ID, index uint32) ([]byte, error) {
+	timer := time.NewTimer(r.cfg.ChunkRequestTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case c := <-restorer.chunks:
+			if c.Sender != peer || c.Index != index || c.Format != TxIndexSnapshotFormat {
+				continue // stale
+			}
+			if c.Chunk == nil {
+				return nil, errors.New("chunk is missing")
+			}
+			return c.Chunk, nil
+		case <-timer.C:
+			return nil, errors.New("timed out waiting for the chunk")
+		case <-r.Quit():
+			return nil, errors.New("state sync reactor stopped")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/pubsub/query"
+	"github.com/cometbft/cometbft/p2p"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	"github.com/cometbft/cometbft/state/txindex"
+	"github.com/cometbft/cometbft/state/txindex/kv"
+	"github.com/cometbft/cometbft/types"
+)
+
+// makeTxIndexSnapshot indexes n transactions and exports them into a tx
+// index snapshot in a new directory.
+func makeTxIndexSnapshot(t *testing.T, n int) (string, *kv.Snapshot) {
+	txIndex := kv.NewTxIndex(dbm.NewMemDB())
+	batch := txindex.NewBatch(int64(n))
+	for i := 0; i < n; i++ {
+		require.NoError(t, batch.Add(&abci.TxResult{
+			Height: 1,
+			Index:  uint32(i),
+			Tx:     types.Tx(fmt.Sprintf("tx%d", i)),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: fmt.Sprint(i), Index: true}}},
+			}},
+		}))
+	}
+	require.NoError(t, txIndex.AddBatch(batch))
+
+	dir := t.TempDir()
+	s, err := txIndex.ExportSnapshot(dir, 1)
+	require.NoError(t, err)
+	return dir, s
+}
+
+func TestReactor_TxIndexSnapshots(t *testing.T) {
+	dir, s := makeTxIndexSnapshot(t, 10)
+	r := NewReactor(*config.DefaultStateSyncConfig(), nil, nil, "", NopMetrics(), WithTxIndexSnapshots(dir))
+	r.SetLogger(log.TestingLogger())
+
+	assert.Equal(t, &snapshot{
+		Height:   1,
+		Format:   TxIndexSnapshotFormat,
+		Chunks:   1,
+		Hash:     s.Hash,
+		Metadata: s.Metadata(),
+	}, r.txIndexSnapshot())
+
+	chunk, err := r.loadTxIndexSnapshotChunk(1, 0)
+	require.NoError(t, err)
+	require.NoError(t, s.VerifyChunk(0, chunk))
+
+	// outside of the snapshot
+	chunk, err = r.loadTxIndexSnapshotChunk(1, 1)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+	chunk, err = r.loadTxIndexSnapshotChunk(2, 0)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+
+	// disabled
+	r = NewReactor(*config.DefaultStateSyncConfig(), nil, nil, "", NopMetrics())
+	assert.Nil(t, r.txIndexSnapshot())
+}
+
+func TestReactor_RestoreTxIndex(t *testing.T) {
+	dir, s := makeTxIndexSnapshot(t, 10)
+
+	cfg := config.DefaultStateSyncConfig()
+	cfg.DiscoveryTime = 100 * time.Millisecond
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything).Return(&abci.ResponseListSnapshots{}, nil)
+	reactors := []*Reactor{
+		NewReactor(*cfg, conn, nil, "", NopMetrics(), WithTxIndexSnapshots(dir)),
+		NewReactor(*cfg, conn, nil, "", NopMetrics()),
+	}
+	switches := p2p.MakeConnectedSwitches(config.TestP2PConfig(), 2, func(i int, sw *p2p.Switch) *p2p.Switch {
+		reactors[i].SetLogger(log.TestingLogger())
+		sw.AddReactor("STATESYNC", reactors[i])
+		return sw
+	}, p2p.Connect2Switches)
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	txIndex := kv.NewTxIndex(dbm.NewMemDB())
+	require.Error(t, reactors[1].RestoreTxIndex(txIndex, []byte("unknown"), s.Height))
+	// only at the height of the synced state
+	require.Error(t, reactors[1].RestoreTxIndex(txIndex, s.Hash, s.Height+1))
+	empty, err := txIndex.IsEmpty()
+	require.NoError(t, err)
+	require.True(t, empty)
+	require.NoError(t, reactors[1].RestoreTxIndex(txIndex, s.Hash, s.Height))
+	results, err := txIndex.Search(context.Background(), query.MustCompile("account.number >= 5"))
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+
+	// only into an empty tx index
+	require.Error(t, reactors[1].RestoreTxIndex(txIndex, s.Hash, s.Height))
+}
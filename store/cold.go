@@ -0,0 +1,195 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	defaultColdStorageInterval  = time.Second
+	defaultColdStorageBatchSize = 100
+)
+
+// coldHeightKey holds the height up to which (but not including) the blocks
+// were moved to the cold store.
+var coldHeightKey = []byte("coldStoreHeight")
+
+// ErrNoColdStore is returned when moving blocks to the cold store of a
+// BlockStore which has none.
+var ErrNoColdStore = errors.New("the block store has no cold store")
+
+// MoveToColdStore moves up to maxBlocks blocks, from the lowest height not
+// moved yet up to (but not including) height, from the database of the block
+// store to its cold store, see WithColdStore. It returns the number of blocks
+// moved, and whether all the blocks below height are now in the cold store.
+// The blocks stored with an entry per part are migrated to blobs as they are
+// moved. The progress is persisted, so the moves resume where they stopped
+// after a restart.
+//
+// The blocks are written to the cold store before they are deleted from the
+// database, so they remain readable throughout.
+func (bs *BlockStore) MoveToColdStore(height, maxBlocks int64) (int64, bool, error) {
+	if bs.cold == nil {
+		return 0, false, ErrNoColdStore
+	}
+	if maxBlocks <= 0 {
+		return 0, false, fmt.Errorf("max blocks must be greater than 0")
+	}
+	// the pruning must not delete a block while it is moved
+	bs.pruneMtx.Lock()
+	defer bs.pruneMtx.Unlock()
+
+	from := bs.coldHeight
+	if base := bs.Base(); from < base {
+		from = base
+	}
+	if latest := bs.Height(); height > latest {
+		height = latest
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	coldBatch := bs.cold.NewBatch()
+	defer coldBatch.Close()
+
+	move := func(key []byte) error {
+		bz, err := bs.db.Get(key)
+		if err != nil || len(bz) == 0 {
+			return err
+		}
+		if err := coldBatch.Set(key, bz); err != nil {
+			return err
+		}
+		return batch.Delete(key)
+	}
+
+	moved := int64(0)
+	h := from
+	for ; h < height && moved < maxBlocks; h++ {
+		meta := bs.LoadBlockMeta(h)
+		if meta != nil {
+			if err := move(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+				return 0, false, err
+			}
+			if err := bs.moveLegacyBlockParts(batch, coldBatch, h, meta); err != nil {
+				return 0, false, err
+			}
+		}
+		for _, key := range [][]byte{
+			calcBlockBlobKey(h),
+			calcBlockPartIndexKey(h),
+			calcBlockCommitKey(h),
+			calcSeenCommitKey(h),
+			calcBlockMetaKey(h),
+		} {
+			if err := move(key); err != nil {
+				return 0, false, err
+			}
+		}
+		moved++
+	}
+	if h == from {
+		return 0, true, nil
+	}
+
+	if err := coldBatch.WriteSync(); err != nil {
+		return 0, false, fmt.Errorf("failed to write the blocks up to height %d to the cold store: %w", h-1, err)
+	}
+	if err := batch.Set(coldHeightKey, []byte(strconv.FormatInt(h, 10))); err != nil {
+		return 0, false, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, false, fmt.Errorf("failed to move the blocks up to height %d to the cold store: %w", h-1, err)
+	}
+	bs.coldHeight = h
+	return moved, h >= height, nil
+}
+
+// moveLegacyBlockParts adds the blob of the block at the given height, if
+// stored with an entry per part, to coldBatch, and the deletion of its parts
+// to batch.
+func (bs *BlockStore) moveLegacyBlockParts(batch, coldBatch dbm.Batch, height int64, meta *types.BlockMeta) error {
+	isBlob, err := bs.db.Has(calcBlockBlobKey(height))
+	if err != nil || isBlob {
+		return err
+	}
+	total := meta.BlockID.PartSetHeader.Total
+	blockParts := types.NewPartSetFromHeader(meta.BlockID.PartSetHeader)
+	for i := 0; i < int(total); i++ {
+		part := bs.loadLegacyBlockPart(height, i)
+		if part == nil { // pruned, keeping the header and commit for evidence
+			return nil
+		}
+		if _, err := blockParts.AddPart(part); err != nil {
+			return fmt.Errorf("invalid part %d of the block at height %d: %w", i, height, err)
+		}
+	}
+	if err := setBlockBlob(coldBatch, height, blockParts); err != nil {
+		return err
+	}
+	return deleteBlockParts(bs.db, batch, height, total, false)
+}
+
+// ColdStorageMover is a service moving, in the background, the blocks older
+// than a number of heights to the cold store of a block store, with
+// MoveToColdStore, keeping the database of the store small. To limit the load
+// of the moves on the node, at most batchSize blocks are moved every interval.
+// It stops upon an error.
+type ColdStorageMover struct {
+	service.BaseService
+
+	blockStore *BlockStore
+	after      int64
+	interval   time.Duration
+	batchSize  int64
+}
+
+// NewColdStorageMover returns a new ColdStorageMover of the given block store,
+// which must have a cold store, moving the blocks older than after heights.
+func NewColdStorageMover(blockStore *BlockStore, after int64, logger log.Logger) *ColdStorageMover {
+	m := &ColdStorageMover{
+		blockStore: blockStore,
+		after:      after,
+		interval:   defaultColdStorageInterval,
+		batchSize:  defaultColdStorageBatchSize,
+	}
+	m.BaseService = *service.NewBaseService(logger, "ColdStorageMover", m)
+	return m
+}
+
+// OnStart implements service.Service.
+func (m *ColdStorageMover) OnStart() error {
+	go m.moveRoutine()
+	return nil
+}
+
+func (m *ColdStorageMover) moveRoutine() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		height := m.blockStore.Height() - m.after + 1
+		if height > 0 {
+			moved, _, err := m.blockStore.MoveToColdStore(height, m.batchSize)
+			if err != nil {
+				m.Logger.Error("Failed to move the blocks to the cold store", "err", err)
+				return
+			}
+			if moved > 0 {
+				m.Logger.Debug("Moved the blocks to the cold store", "blocks", moved, "height", height)
+			}
+		}
+		select {
+		case <-m.Quit():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/libs/service"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	defaultMigrationInterval  = time.Second
+	defaultMigrationBatchSize = 100
+)
+
+// blockPartsMigrationKey holds the height from which the blocks stored with an
+// entry per part remain to be migrated.
+var blockPartsMigrationKey = []byte("blockPartsMigration")
+
+// MigrateBlockParts migrates up to maxBlocks blocks stored with an entry per
+// part, as the previous versions did, to blobs, from the lowest height not
+// migrated yet. It returns the number of blocks migrated, and whether all the
+// blocks of the store are now stored as blobs. The progress is persisted, so
+// the migration resumes where it stopped after a restart.
+//
+// The blocks remain readable during the migration, which can thus be done
+// online, in batches.
+func (bs *BlockStore) MigrateBlockParts(maxBlocks int64) (int64, bool, error) {
+	if maxBlocks <= 0 {
+		return 0, false, fmt.Errorf("max blocks must be greater than 0")
+	}
+	// the pruning must not delete a block while it is migrated
+	bs.pruneMtx.Lock()
+	defer bs.pruneMtx.Unlock()
+
+	from, err := loadHeight(bs.db, blockPartsMigrationKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if base := bs.Base(); from < base {
+		from = base
+	}
+	// the blocks moved to the cold store were migrated as they were moved
+	if bs.cold != nil && from < bs.coldHeight {
+		from = bs.coldHeight
+	}
+	height := bs.Height()
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	migrated := int64(0)
+	h := from
+	for ; h <= height && migrated < maxBlocks; h++ {
+		isBlob, err := bs.db.Has(calcBlockBlobKey(h))
+		if err != nil {
+			return 0, false, err
+		}
+		if isBlob {
+			continue
+		}
+		meta := bs.LoadBlockMeta(h)
+		if meta == nil { // assume pruned
+			continue
+		}
+
+		blockParts := types.NewPartSetFromHeader(meta.BlockID.PartSetHeader)
+		for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+			part := bs.loadLegacyBlockPart(h, i)
+			if part == nil {
+				return 0, false, fmt.Errorf("part %d of the block at height %d not found", i, h)
+			}
+			if _, err := blockParts.AddPart(part); err != nil {
+				return 0, false, fmt.Errorf("invalid part %d of the block at height %d: %w", i, h, err)
+			}
+		}
+		if err := setBlockBlob(batch, h, blockParts); err != nil {
+			return 0, false, err
+		}
+		if err := deleteBlockParts(bs.db, batch, h, meta.BlockID.PartSetHeader.Total, false); err != nil {
+			return 0, false, err
+		}
+		migrated++
+	}
+
+	if err := batch.Set(blockPartsMigrationKey, []byte(strconv.FormatInt(h, 10))); err != nil {
+		return 0, false, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, false, fmt.Errorf("failed to migrate the block parts up to height %d: %w", h-1, err)
+	}
+	return migrated, h > height, nil
+}
+
+// loadHeight returns the height stored as a decimal string under key in db,
+// such as the progress of the migration, or 0 if none.
+func loadHeight(db dbm.DB, key []byte) (int64, error) {
+	bz, err := db.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to extract the height of %s from %s: %w", key, bz, err)
+	}
+	return height, nil
+}
+
+// BlockPartsMigrator is a service migrating, in the background, the blocks
+// stored with an entry per part to blobs, with MigrateBlockParts. To limit the
+// load of the migration on the node, at most batchSize blocks are migrated
+// every interval. It stops once all the blocks are migrated, or upon an error.
+type BlockPartsMigrator struct {
+	service.BaseService
+
+	blockStore *BlockStore
+	interval   time.Duration
+	batchSize  int64
+}
+
+// NewBlockPartsMigrator returns a new BlockPartsMigrator of the given block
+// store.
+func NewBlockPartsMigrator(blockStore *BlockStore, logger log.Logger) *BlockPartsMigrator {
+	m := &BlockPartsMigrator{
+		blockStore: blockStore,
+		interval:   defaultMigrationInterval,
+		batchSize:  defaultMigrationBatchSize,
+	}
+	m.BaseService = *service.NewBaseService(logger, "BlockPartsMigrator", m)
+	return m
+}
+
+// OnStart implements service.Service.
+func (m *BlockPartsMigrator) OnStart() error {
+	go m.migrateRoutine()
+	return nil
+}
+
+func (m *BlockPartsMigrator) migrateRoutine() {
+	total := int64(0)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		migrated, done, err := m.blockStore.MigrateBlockParts(m.batchSize)
+		if err != nil {
+			m.Logger.Error("Failed to migrate the block parts, the block store may need a repair", "err", err)
+			return
+		}
+		total += migrated
+		if done {
+			m.Logger.Info("Migrated the block parts", "blocks", total)
+			return
+		}
+		select {
+		case <-m.Quit():
+			return
+		case <-ticker.C:
+		}
+	}
+}
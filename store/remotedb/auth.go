@@ -0,0 +1,77 @@
+package remotedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// storeNames are the names of the databases of a node which can be served: the
+// state store, the block store and its cold store.
+var storeNames = map[string]bool{
+	"state":           true,
+	"blockstore":      true,
+	"blockstore_cold": true,
+}
+
+// namespaceRegexp matches the valid namespaces, which are used as directory
+// names by the servers.
+var namespaceRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidateNamespace returns an error if the namespace of a node isn't valid.
+func ValidateNamespace(namespace string) error {
+	if !namespaceRegexp.MatchString(namespace) {
+		return fmt.Errorf("invalid namespace %q, expected letters, digits, '_' and '-' only", namespace)
+	}
+	return nil
+}
+
+// DBName returns the name of the database with the given name of the node
+// namespace, as sent to the server.
+func DBName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// splitDBName returns the namespace and the name of the database, or an error
+// if it isn't a database of a node which can be served.
+func splitDBName(dbName string) (string, string, error) {
+	namespace, name, ok := strings.Cut(dbName, "/")
+	if !ok {
+		return "", "", fmt.Errorf("invalid database %q, expected <namespace>/<name>", dbName)
+	}
+	if err := ValidateNamespace(namespace); err != nil {
+		return "", "", err
+	}
+	if !storeNames[name] {
+		return "", "", fmt.Errorf("unknown database %q", name)
+	}
+	return namespace, name, nil
+}
+
+func validateDBName(dbName string) error {
+	_, _, err := splitDBName(dbName)
+	return err
+}
+
+// authorizeNamespace returns an error unless the client calling the server is
+// the node of the namespace, i.e. the common name of its verified certificate
+// is the namespace.
+func authorizeNamespace(ctx context.Context, namespace string) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return errors.New("unknown client")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return errors.New("the client isn't authenticated")
+	}
+	if cn := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName; cn != namespace {
+		return fmt.Errorf("the client %q can't access the namespace %q", cn, namespace)
+	}
+	return nil
+}
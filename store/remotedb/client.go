@@ -0,0 +1,396 @@
+package remotedb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	cmtnet "github.com/cometbft/cometbft/libs/net"
+)
+
+var (
+	// errBatchClosed is returned when a closed or written batch is used.
+	errBatchClosed = errors.New("batch has been written or closed")
+
+	// errKeyEmpty is returned when attempting to use an empty or nil key.
+	errKeyEmpty = errors.New("key cannot be empty")
+
+	// errValueNil is returned when attempting to set a nil value.
+	errValueNil = errors.New("value cannot be nil")
+)
+
+// DB is a database served by a remote storage service over gRPC, e.g. the
+// state store or the block store of a node without a disk.
+type DB struct {
+	conn    *grpc.ClientConn
+	client  RemoteDBClient
+	name    string
+	timeout time.Duration
+}
+
+var _ dbm.DB = (*DB)(nil)
+
+// NewDB returns the database with the given name of the node namespace served
+// at addr. The connection is authenticated with tlsConfig, which must hold the
+// certificate of the node, whose common name is the namespace, and the CA of
+// the server. Each call fails if the server doesn't respond within timeout.
+// The connection is established lazily, upon the first call.
+func NewDB(addr, namespace, name string, tlsConfig *tls.Config, timeout time.Duration) (*DB, error) {
+	dbName := DBName(namespace, name)
+	if err := validateDBName(dbName); err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return nil, errors.New("the connection to the remote database requires TLS")
+	}
+	if timeout <= 0 {
+		return nil, errors.New("the timeout of the remote database must be positive")
+	}
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		// addr isn't a gRPC target the server name can be taken from. The
+		// certificate of a server on a unix socket must be for localhost.
+		tlsConfig.ServerName = "localhost"
+		if protocol, address := cmtnet.ProtocolAndAddress(addr); protocol != "unix" {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %s of the remote database: %w", addr, err)
+			}
+			tlsConfig.ServerName = host
+		}
+	}
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial the remote database %s at %s: %w", dbName, addr, err)
+	}
+	return &DB{
+		conn:    conn,
+		client:  NewRemoteDBClient(conn),
+		name:    dbName,
+		timeout: timeout,
+	}, nil
+}
+
+// callContext returns the context of a call, canceled after the timeout.
+func (db *DB) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), db.timeout)
+}
+
+func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
+	return cmtnet.Connect(addr)
+}
+
+// Get implements dbm.DB.
+func (db *DB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	ctx, cancel := db.callContext()
+	defer cancel()
+	res, err := db.client.Get(ctx, &RequestGet{Db: db.name, Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if !res.Found {
+		return nil, nil
+	}
+	if res.Value == nil {
+		return []byte{}, nil
+	}
+	return res.Value, nil
+}
+
+// Has implements dbm.DB.
+func (db *DB) Has(key []byte) (bool, error) {
+	if len(key) == 0 {
+		return false, errKeyEmpty
+	}
+	ctx, cancel := db.callContext()
+	defer cancel()
+	res, err := db.client.Has(ctx, &RequestHas{Db: db.name, Key: key})
+	if err != nil {
+		return false, err
+	}
+	return res.Found, nil
+}
+
+// Set implements dbm.DB.
+func (db *DB) Set(key []byte, value []byte) error {
+	return db.set(key, value, false)
+}
+
+// SetSync implements dbm.DB.
+func (db *DB) SetSync(key []byte, value []byte) error {
+	return db.set(key, value, true)
+}
+
+func (db *DB) set(key []byte, value []byte, sync bool) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return db.write([]*Operation{{Type: Operation_SET, Key: key, Value: value}}, sync)
+}
+
+// Delete implements dbm.DB.
+func (db *DB) Delete(key []byte) error {
+	return db.delete(key, false)
+}
+
+// DeleteSync implements dbm.DB.
+func (db *DB) DeleteSync(key []byte) error {
+	return db.delete(key, true)
+}
+
+func (db *DB) delete(key []byte, sync bool) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.write([]*Operation{{Type: Operation_DELETE, Key: key}}, sync)
+}
+
+func (db *DB) write(ops []*Operation, sync bool) error {
+	ctx, cancel := db.callContext()
+	defer cancel()
+	_, err := db.client.Write(ctx, &RequestWrite{Db: db.name, Ops: ops, Sync: sync})
+	return err
+}
+
+// Iterator implements dbm.DB.
+func (db *DB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	return db.iterator(start, end, false)
+}
+
+// ReverseIterator implements dbm.DB.
+func (db *DB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	return db.iterator(start, end, true)
+}
+
+func (db *DB) iterator(start, end []byte, reverse bool) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := db.client.Iterate(ctx, &RequestIterate{
+		Db:      db.name,
+		Start:   start,
+		End:     end,
+		Reverse: reverse,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	itr := &iterator{
+		start:   start,
+		end:     end,
+		stream:  stream,
+		cancel:  cancel,
+		timeout: db.timeout,
+	}
+	itr.recv()
+	return itr, nil
+}
+
+// Close implements dbm.DB.
+func (db *DB) Close() error {
+	return db.conn.Close()
+}
+
+// NewBatch implements dbm.DB.
+func (db *DB) NewBatch() dbm.Batch {
+	return &batch{db: db, ops: []*Operation{}}
+}
+
+// Print implements dbm.DB.
+func (db *DB) Print() error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return itr.Error()
+}
+
+// Stats implements dbm.DB. It returns no stats if the remote database can't
+// be reached.
+func (db *DB) Stats() map[string]string {
+	ctx, cancel := db.callContext()
+	defer cancel()
+	res, err := db.client.Stats(ctx, &RequestStats{Db: db.name})
+	if err != nil {
+		return map[string]string{}
+	}
+	return res.Stats
+}
+
+// Compact implements dbm.DB.
+func (db *DB) Compact(start, end []byte) error {
+	ctx, cancel := db.callContext()
+	defer cancel()
+	_, err := db.client.Compact(ctx, &RequestCompact{Db: db.name, Start: start, End: end})
+	return err
+}
+
+// iterator iterates over the entries streamed by the remote database.
+type iterator struct {
+	start, end []byte
+
+	stream  RemoteDB_IterateClient
+	cancel  context.CancelFunc
+	timeout time.Duration // of the receipt of each batch
+	entries []*Entry      // the current batch of entries
+	err     error
+	done    bool
+}
+
+var _ dbm.Iterator = (*iterator)(nil)
+
+// recv receives the next batch of entries, until the stream ends. The stream
+// is canceled if a batch isn't received within the timeout.
+func (itr *iterator) recv() {
+	itr.entries = nil
+	for len(itr.entries) == 0 {
+		timer := time.AfterFunc(itr.timeout, itr.cancel)
+		res, err := itr.stream.Recv()
+		timer.Stop()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				itr.err = err
+			}
+			itr.done = true
+			itr.cancel()
+			return
+		}
+		itr.entries = res.Entries
+	}
+}
+
+// Domain implements dbm.Iterator.
+func (itr *iterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements dbm.Iterator.
+func (itr *iterator) Valid() bool {
+	return !itr.done
+}
+
+// Next implements dbm.Iterator.
+func (itr *iterator) Next() {
+	itr.assertIsValid()
+	itr.entries = itr.entries[1:]
+	if len(itr.entries) == 0 {
+		itr.recv()
+	}
+}
+
+// Key implements dbm.Iterator.
+func (itr *iterator) Key() []byte {
+	itr.assertIsValid()
+	return itr.entries[0].Key
+}
+
+// Value implements dbm.Iterator.
+func (itr *iterator) Value() []byte {
+	itr.assertIsValid()
+	if itr.entries[0].Value == nil {
+		return []byte{}
+	}
+	return itr.entries[0].Value
+}
+
+// Error implements dbm.Iterator.
+func (itr *iterator) Error() error {
+	return itr.err
+}
+
+// Close implements dbm.Iterator.
+func (itr *iterator) Close() error {
+	itr.cancel()
+	itr.done = true
+	itr.entries = nil
+	return nil
+}
+
+func (itr *iterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("iterator is invalid")
+	}
+}
+
+// batch collects the operations written atomically to the remote database.
+type batch struct {
+	db  *DB
+	ops []*Operation
+}
+
+var _ dbm.Batch = (*batch)(nil)
+
+// Set implements dbm.Batch.
+func (b *batch) Set(key, value []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	if b.ops == nil {
+		return errBatchClosed
+	}
+	b.ops = append(b.ops, &Operation{Type: Operation_SET, Key: key, Value: value})
+	return nil
+}
+
+// Delete implements dbm.Batch.
+func (b *batch) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if b.ops == nil {
+		return errBatchClosed
+	}
+	b.ops = append(b.ops, &Operation{Type: Operation_DELETE, Key: key})
+	return nil
+}
+
+// Write implements dbm.Batch.
+func (b *batch) Write() error {
+	return b.write(false)
+}
+
+// WriteSync implements dbm.Batch.
+func (b *batch) WriteSync() error {
+	return b.write(true)
+}
+
+func (b *batch) write(sync bool) error {
+	if b.ops == nil {
+		return errBatchClosed
+	}
+	err := b.db.write(b.ops, sync)
+	// Make sure batch cannot be used afterwards. Callers should still call Close(), for errors.
+	b.ops = nil
+	return err
+}
+
+// Close implements dbm.Batch.
+func (b *batch) Close() error {
+	b.ops = nil
+	return nil
+}
@@ -0,0 +1,247 @@
+package remotedb
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtnet "github.com/cometbft/cometbft/libs/net"
+	cmttest "github.com/cometbft/cometbft/libs/test"
+)
+
+// testTLSConfig issues a certificate of the CA with the common name, valid for
+// 127.0.0.1, and returns the TLS config with it.
+func testTLSConfig(t *testing.T, ca *cmttest.TLSCA, commonName string) *tls.Config {
+	certFile, keyFile := ca.IssueCert(t, commonName)
+	config, err := cmtnet.LoadTLSConfig(ca.File, certFile, keyFile)
+	require.NoError(t, err)
+	return config
+}
+
+// startServer starts a server of the databases of the provider, with a
+// certificate of the CA, and returns its address.
+func startServer(t *testing.T, ca *cmttest.TLSCA, provider DBProvider) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv, err := NewServer(provider, testTLSConfig(t, ca, "server"))
+	require.NoError(t, err)
+	go srv.Serve(ln) //nolint:errcheck // stopped by the cleanup
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop())
+	})
+	return ln.Addr().String()
+}
+
+// dialDB returns the database of the namespace served at addr, authenticated
+// with a certificate of the CA for the namespace.
+func dialDB(t *testing.T, ca *cmttest.TLSCA, addr, namespace, name string) *DB {
+	t.Helper()
+	db, err := NewDB(addr, namespace, name, testTLSConfig(t, ca, namespace), time.Second)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func newRemoteDB(t *testing.T, name string) *DB {
+	t.Helper()
+	ca := cmttest.NewTLSCA(t)
+	addr := startServer(t, ca, func(string, string) (dbm.DB, error) { return dbm.NewMemDB(), nil })
+	return dialDB(t, ca, addr, "node0", name)
+}
+
+func TestRemoteDB(t *testing.T) {
+	db := newRemoteDB(t, "state")
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, db.Set([]byte("a"), []byte("1")))
+	require.NoError(t, db.SetSync([]byte("b"), []byte{}))
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, value)
+	has, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	require.NoError(t, db.Delete([]byte("a")))
+	has, err = db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = db.Get(nil)
+	assert.Error(t, err)
+	assert.Error(t, db.Set([]byte("a"), nil))
+}
+
+func TestRemoteDBBatch(t *testing.T) {
+	db := newRemoteDB(t, "state")
+	require.NoError(t, db.Set([]byte("c"), []byte("3")))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("a"), []byte("1")))
+	require.NoError(t, batch.Set([]byte("b"), []byte("2")))
+	require.NoError(t, batch.Delete([]byte("c")))
+
+	// Nothing is written before the batch.
+	has, err := db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	require.NoError(t, batch.WriteSync())
+	assert.Error(t, batch.Set([]byte("d"), []byte("4")))
+	require.NoError(t, batch.Close())
+
+	for key, want := range map[string]bool{"a": true, "b": true, "c": false} {
+		has, err := db.Has([]byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, want, has, key)
+	}
+}
+
+func TestRemoteDBIterator(t *testing.T) {
+	db := newRemoteDB(t, "blockstore")
+
+	// More entries than a streamed batch.
+	numEntries := 3*iterateBatchSize + 1
+	for i := 0; i < numEntries; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("key%04d", i)), []byte(fmt.Sprintf("value%04d", i))))
+	}
+
+	keys := func(itr dbm.Iterator) []string {
+		defer itr.Close()
+		var keys []string
+		for ; itr.Valid(); itr.Next() {
+			keys = append(keys, string(itr.Key()))
+		}
+		require.NoError(t, itr.Error())
+		return keys
+	}
+
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	all := keys(itr)
+	require.Len(t, all, numEntries)
+	assert.Equal(t, "key0000", all[0])
+	assert.Equal(t, fmt.Sprintf("key%04d", numEntries-1), all[numEntries-1])
+
+	itr, err = db.Iterator([]byte("key0010"), []byte("key0013"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key0010", "key0011", "key0012"}, keys(itr))
+
+	itr, err = db.ReverseIterator([]byte("key0010"), []byte("key0013"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key0012", "key0011", "key0010"}, keys(itr))
+
+	itr, err = db.Iterator([]byte("key0010"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value0010"), itr.Value())
+	// Closing an iterator before its end stops the stream.
+	require.NoError(t, itr.Close())
+	assert.False(t, itr.Valid())
+
+	itr, err = db.Iterator([]byte("zzz"), nil)
+	require.NoError(t, err)
+	assert.Empty(t, keys(itr))
+
+	_, err = db.Iterator([]byte{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRemoteDBNames(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	addr := startServer(t, ca, NewDBProvider(dbm.GoLevelDBBackend, t.TempDir()))
+
+	state := dialDB(t, ca, addr, "node0", "state")
+	blocks := dialDB(t, ca, addr, "node0", "blockstore")
+	otherState := dialDB(t, ca, addr, "node1", "state")
+
+	// The databases are distinct, and so are the namespaces.
+	require.NoError(t, state.Set([]byte("a"), []byte("1")))
+	has, err := blocks.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = otherState.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = state.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// Only the databases of the stores can be opened.
+	for _, name := range []string{"../state", "evidence", ""} {
+		_, err := NewDB(addr, "node0", name, testTLSConfig(t, ca, "node0"), time.Second)
+		assert.Error(t, err, name)
+	}
+	for _, namespace := range []string{"..", "a/b", ""} {
+		_, err := NewDB(addr, namespace, "state", testTLSConfig(t, ca, "node0"), time.Second)
+		assert.Error(t, err, namespace)
+	}
+	forged := dialDB(t, ca, addr, "node0", "state")
+	forged.name = "../node1/state"
+	_, err = forged.Has([]byte("a"))
+	assert.Error(t, err)
+}
+
+func TestRemoteDBAuthentication(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	addr := startServer(t, ca, func(string, string) (dbm.DB, error) { return dbm.NewMemDB(), nil })
+
+	// A node can't access the namespace of another node.
+	db, err := NewDB(addr, "node1", "state", testTLSConfig(t, ca, "node0"), time.Second)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Has([]byte("a"))
+	assert.Error(t, err)
+
+	// Nor a node with a certificate of another CA.
+	db, err = NewDB(addr, "node0", "state", testTLSConfig(t, cmttest.NewTLSCA(t), "node0"), time.Second)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Has([]byte("a"))
+	assert.Error(t, err)
+
+	// TLS is required.
+	_, err = NewDB(addr, "node0", "state", nil, time.Second)
+	assert.Error(t, err)
+	_, err = NewServer(NewDBProvider(dbm.MemDBBackend, t.TempDir()), nil)
+	assert.Error(t, err)
+}
+
+func TestRemoteDBTimeout(t *testing.T) {
+	ca := cmttest.NewTLSCA(t)
+	// A server which never responds.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	db, err := NewDB(ln.Addr().String(), "node0", "state", testTLSConfig(t, ca, "node0"), 100*time.Millisecond)
+	require.NoError(t, err)
+	defer db.Close()
+	start := time.Now()
+	_, err = db.Get([]byte("a"))
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,240 @@
+package remotedb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	cmtos "github.com/cometbft/cometbft/libs/os"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// iterateBatchSize is the maximum number of entries streamed in a batch by
+// Iterate.
+const iterateBatchSize = 100
+
+// DBProvider returns the database with the given name of the node namespace,
+// opening it if needed.
+type DBProvider func(namespace, name string) (dbm.DB, error)
+
+// NewDBProvider returns a DBProvider opening the databases with the given
+// backend, in a directory of dir per namespace.
+func NewDBProvider(backend dbm.BackendType, dir string) DBProvider {
+	return func(namespace, name string) (dbm.DB, error) {
+		dir := filepath.Join(dir, namespace)
+		if err := cmtos.EnsureDir(dir, 0o700); err != nil {
+			return nil, err
+		}
+		return dbm.NewDB(name, backend, dir)
+	}
+}
+
+// Server serves the databases of a DBProvider over gRPC, so that the state
+// store and the block store of nodes can live on a remote storage service.
+// Each node has a namespace of its own, which only it can access: the clients
+// must authenticate with a certificate whose common name is their namespace.
+type Server struct {
+	grpcServer *grpc.Server
+	provider   DBProvider
+
+	mtx cmtsync.Mutex
+	dbs map[string]dbm.DB // the databases opened, by namespace and name
+}
+
+var _ RemoteDBServer = (*Server)(nil)
+
+// NewServer returns a server of the databases returned by provider, over TLS
+// with tlsConfig, which must hold the certificate of the server and the CA of
+// the clients.
+func NewServer(provider DBProvider, tlsConfig *tls.Config) (*Server, error) {
+	if tlsConfig == nil {
+		return nil, errors.New("the remote database server requires TLS")
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	s := &Server{
+		grpcServer: grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig))),
+		provider:   provider,
+		dbs:        make(map[string]dbm.DB),
+	}
+	RegisterRemoteDBServer(s.grpcServer, s)
+	return s, nil
+}
+
+// Serve serves the databases on the given listener.
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func (s *Server) Serve(ln net.Listener) error {
+	return s.grpcServer.Serve(ln)
+}
+
+// Stop stops serving the databases, and closes them.
+func (s *Server) Stop() error {
+	s.grpcServer.Stop()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var err error
+	for name, db := range s.dbs {
+		if closeErr := db.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(s.dbs, name)
+	}
+	return err
+}
+
+// db returns the database with the given name, opening it on first use, if
+// the client may access it.
+func (s *Server) db(ctx context.Context, dbName string) (dbm.DB, error) {
+	namespace, name, err := splitDBName(dbName)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := authorizeNamespace(ctx, namespace); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if db, ok := s.dbs[dbName]; ok {
+		return db, nil
+	}
+	db, err := s.provider(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	s.dbs[dbName] = db
+	return db, nil
+}
+
+// Get implements RemoteDBServer.
+func (s *Server) Get(ctx context.Context, req *RequestGet) (*ResponseGet, error) {
+	db, err := s.db(ctx, req.Db)
+	if err != nil {
+		return nil, err
+	}
+	value, err := db.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseGet{Value: value, Found: value != nil}, nil
+}
+
+// Has implements RemoteDBServer.
+func (s *Server) Has(ctx context.Context, req *RequestHas) (*ResponseHas, error) {
+	db, err := s.db(ctx, req.Db)
+	if err != nil {
+		return nil, err
+	}
+	found, err := db.Has(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseHas{Found: found}, nil
+}
+
+// Write implements RemoteDBServer.
+func (s *Server) Write(ctx context.Context, req *RequestWrite) (*ResponseWrite, error) {
+	db, err := s.db(ctx, req.Db)
+	if err != nil {
+		return nil, err
+	}
+	batch := db.NewBatch()
+	defer batch.Close()
+	for _, op := range req.Ops {
+		switch op.Type {
+		case Operation_SET:
+			value := op.Value
+			if value == nil {
+				value = []byte{}
+			}
+			err = batch.Set(op.Key, value)
+		case Operation_DELETE:
+			err = batch.Delete(op.Key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Sync {
+		err = batch.WriteSync()
+	} else {
+		err = batch.Write()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseWrite{}, nil
+}
+
+// Iterate implements RemoteDBServer.
+func (s *Server) Iterate(req *RequestIterate, stream RemoteDB_IterateServer) error {
+	db, err := s.db(stream.Context(), req.Db)
+	if err != nil {
+		return err
+	}
+	var itr dbm.Iterator
+	if req.Reverse {
+		itr, err = db.ReverseIterator(nilIfEmpty(req.Start), nilIfEmpty(req.End))
+	} else {
+		itr, err = db.Iterator(nilIfEmpty(req.Start), nilIfEmpty(req.End))
+	}
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	entries := make([]*Entry, 0, iterateBatchSize)
+	for ; itr.Valid(); itr.Next() {
+		entries = append(entries, &Entry{Key: itr.Key(), Value: itr.Value()})
+		if len(entries) == iterateBatchSize {
+			if err := stream.Send(&ResponseIterate{Entries: entries}); err != nil {
+				return err
+			}
+			entries = make([]*Entry, 0, iterateBatchSize)
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return stream.Send(&ResponseIterate{Entries: entries})
+	}
+	return nil
+}
+
+// Stats implements RemoteDBServer.
+func (s *Server) Stats(ctx context.Context, req *RequestStats) (*ResponseStats, error) {
+	db, err := s.db(ctx, req.Db)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseStats{Stats: db.Stats()}, nil
+}
+
+// Compact implements RemoteDBServer.
+func (s *Server) Compact(ctx context.Context, req *RequestCompact) (*ResponseCompact, error) {
+	db, err := s.db(ctx, req.Db)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Compact(nilIfEmpty(req.Start), nilIfEmpty(req.End)); err != nil {
+		return nil, err
+	}
+	return &ResponseCompact{}, nil
+}
+
+func nilIfEmpty(key []byte) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+	return key
+}
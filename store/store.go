@@ -5,11 +5,14 @@ import (
 	"strconv"
 
 	"github.com/cosmos/gogoproto/proto"
+	"github.com/klauspost/compress/zstd"
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/evidence"
 	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	cmtstore "github.com/cometbft/cometbft/proto/tendermint/store"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	sm "github.com/cometbft/cometbft/state"
@@ -21,9 +24,13 @@ BlockStore is a simple low level store for blocks.
 
 There are three types of information stored:
   - BlockMeta:   Meta information about each block
-  - Block part:  Parts of each block, aggregated w/ PartSet
+  - Block blob:  The parts of each block, concatenated and compressed in a single
+    entry, along with a part index holding the proofs of the parts
   - Commit:      The commit part of each block, for gossiping precommit votes
 
+Blocks used to be stored with an entry per part. Such blocks are still read,
+and can be migrated to blobs online with MigrateBlockParts.
+
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
@@ -44,17 +51,61 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+
+	// pruneMtx serializes the pruning, which both the block executor and the
+	// pruner do, the migration of the block parts, and the moves of blocks to
+	// the cold store.
+	pruneMtx cmtsync.Mutex
+
+	// cold, if not nil, holds the blocks below coldHeight, moved out of db by
+	// MoveToColdStore, which remain readable. coldHeight is guarded by
+	// pruneMtx.
+	cold       dbm.DB
+	coldHeight int64
+
+	// blobMtx guards the last block blob loaded by LoadBlockPart, since the
+	// parts of a block are typically loaded one after the other.
+	blobMtx  cmtsync.Mutex
+	lastBlob *blockBlob
+}
+
+var (
+	// zstd encoders and decoders are safe for concurrent use through
+	// EncodeAll and DecodeAll.
+	blobZstdEncoder, _ = zstd.NewWriter(nil)
+	blobZstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(types.MaxBlockSizeBytes)))
+)
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithColdStore sets the cold store of the BlockStore, a secondary database,
+// typically on cheaper and slower storage, to which MoveToColdStore moves the
+// old blocks. The blocks of the cold store are read transparently.
+func WithColdStore(cold dbm.DB) BlockStoreOption {
+	return func(bs *BlockStore) { bs.cold = cold }
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	bs := LoadBlockStoreState(db)
-	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bss := LoadBlockStoreState(db)
+	bs := &BlockStore{
+		base:   bss.Base,
+		height: bss.Height,
 		db:     db,
 	}
+	for _, option := range options {
+		option(bs)
+	}
+	if bs.cold != nil {
+		coldHeight, err := loadHeight(db, coldHeightKey)
+		if err != nil {
+			panic(err)
+		}
+		bs.coldHeight = coldHeight
+	}
+	return bs
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
@@ -100,15 +151,18 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 	}
 
 	pbb := new(cmtproto.Block)
-	buf := []byte{}
-	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
-		part := bs.LoadBlockPart(height, i)
-		// If the part is missing (e.g. since it has been deleted after we
+	var buf []byte
+	if blob := bs.loadBlockBlob(height); blob != nil {
+		buf = blob.data
+	} else if buf = bs.loadLegacyBlockData(height, blockMeta.BlockID.PartSetHeader.Total); buf == nil {
+		// The block may have been migrated after we looked for its blob.
+		blob := bs.loadBlockBlob(height)
+		// If the block is missing (e.g. since it has been deleted after we
 		// loaded the block meta) we consider the whole block to be missing.
-		if part == nil {
+		if blob == nil {
 			return nil
 		}
-		buf = append(buf, part.Bytes...)
+		buf = blob.data
 	}
 	err := proto.Unmarshal(buf, pbb)
 	if err != nil {
@@ -129,12 +183,24 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 // If no block is found for that hash, it returns nil.
 // Panics if it fails to parse height associated with the given hash.
 func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
-	bz, err := bs.db.Get(calcBlockHashKey(hash))
+	height := bs.LoadBlockHeightByHash(hash)
+	if height == 0 {
+		return nil
+	}
+	return bs.LoadBlock(height)
+}
+
+// LoadBlockHeightByHash returns the height of the block with the given hash,
+// read from the hash to height index. If no block is found for that hash, it
+// returns 0.
+// Panics if it fails to parse height associated with the given hash.
+func (bs *BlockStore) LoadBlockHeightByHash(hash []byte) int64 {
+	bz, err := bs.get(calcBlockHashKey(hash))
 	if err != nil {
 		panic(err)
 	}
 	if len(bz) == 0 {
-		return nil
+		return 0
 	}
 
 	s := string(bz)
@@ -142,16 +208,121 @@ func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	if err != nil {
 		panic(fmt.Sprintf("failed to extract height from %s: %v", s, err))
 	}
-	return bs.LoadBlock(height)
+	return height
 }
 
 // LoadBlockPart returns the Part at the given index
 // from the block at the given height.
 // If no part is found for the given height and index, it returns nil.
 func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
+	blob := bs.loadCachedBlockBlob(height)
+	if blob == nil {
+		if part := bs.loadLegacyBlockPart(height, index); part != nil {
+			return part
+		}
+		// The block may have been migrated after we looked for its blob.
+		if blob = bs.loadCachedBlockBlob(height); blob == nil {
+			return nil
+		}
+	}
+	return blob.part(index)
+}
+
+// get returns the value of key in the database, or in the cold store if it is
+// not found there.
+func (bs *BlockStore) get(key []byte) ([]byte, error) {
+	bz, err := bs.db.Get(key)
+	if err != nil || len(bz) > 0 || bs.cold == nil {
+		return bz, err
+	}
+	return bs.cold.Get(key)
+}
+
+// dbAt returns the database holding the block at the given height, the cold
+// store if the block was moved to it. It must be called with pruneMtx held.
+func (bs *BlockStore) dbAt(height int64) dbm.DB {
+	if bs.cold != nil && height < bs.coldHeight {
+		return bs.cold
+	}
+	return bs.db
+}
+
+// loadCachedBlockBlob returns the blob of the block at the given height, like
+// loadBlockBlob, keeping it for the next parts to be loaded.
+func (bs *BlockStore) loadCachedBlockBlob(height int64) *blockBlob {
+	bs.blobMtx.Lock()
+	defer bs.blobMtx.Unlock()
+	if bs.lastBlob != nil && bs.lastBlob.height == height {
+		return bs.lastBlob
+	}
+	blob := bs.loadBlockBlob(height)
+	if blob != nil {
+		bs.lastBlob = blob
+	}
+	return blob
+}
+
+// resetCachedBlockBlob forgets the blob kept by loadCachedBlockBlob, once
+// blocks are deleted.
+func (bs *BlockStore) resetCachedBlockBlob() {
+	bs.blobMtx.Lock()
+	bs.lastBlob = nil
+	bs.blobMtx.Unlock()
+}
+
+// loadBlockBlob returns the decompressed blob of the block at the given
+// height, along with its part index. It returns nil if the block is not
+// stored as a blob.
+func (bs *BlockStore) loadBlockBlob(height int64) *blockBlob {
+	bz, err := bs.get(calcBlockBlobKey(height))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return nil
+	}
+	data, err := blobZstdDecoder.DecodeAll(bz, nil)
+	if err != nil {
+		panic(fmt.Sprintf("Error decompressing block blob: %v", err))
+	}
+
+	bz, err = bs.get(calcBlockPartIndexKey(height))
+	if err != nil {
+		panic(err)
+	}
+	blob := &blockBlob{height: height, data: data}
+	if err := proto.Unmarshal(bz, &blob.index); err != nil {
+		panic(fmt.Errorf("unmarshal to cmtstore.BlockPartIndex failed: %w", err))
+	}
+	if len(blob.index.Ends) != len(blob.index.Proofs) {
+		panic(fmt.Sprintf("Error reading block part index: %d ends but %d proofs",
+			len(blob.index.Ends), len(blob.index.Proofs)))
+	}
+	return blob
+}
+
+// loadLegacyBlockData returns the concatenated parts of the block at the
+// given height, stored with an entry per part. It returns nil if a part is
+// missing.
+func (bs *BlockStore) loadLegacyBlockData(height int64, total uint32) []byte {
+	buf := []byte{}
+	for i := 0; i < int(total); i++ {
+		part := bs.loadLegacyBlockPart(height, i)
+		if part == nil {
+			return nil
+		}
+		buf = append(buf, part.Bytes...)
+	}
+	return buf
+}
+
+// loadLegacyBlockPart returns the part at the given index of the block at the
+// given height, stored with an entry per part. It returns nil if the part is
+// not found.
+func (bs *BlockStore) loadLegacyBlockPart(height int64, index int) *types.Part {
 	pbpart := new(cmtproto.Part)
 
-	bz, err := bs.db.Get(calcBlockPartKey(height, index))
+	bz, err := bs.get(calcBlockPartKey(height, index))
 	if err != nil {
 		panic(err)
 	}
@@ -175,7 +346,7 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 // If no block is found for the given height, it returns nil.
 func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	pbbm := new(cmtproto.BlockMeta)
-	bz, err := bs.db.Get(calcBlockMetaKey(height))
+	bz, err := bs.get(calcBlockMetaKey(height))
 	if err != nil {
 		panic(err)
 	}
@@ -200,19 +371,10 @@ func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 // LoadBlockMetaByHash returns the blockmeta who's header corresponds to the given
 // hash. If none is found, returns nil.
 func (bs *BlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta {
-	bz, err := bs.db.Get(calcBlockHashKey(hash))
-	if err != nil {
-		panic(err)
-	}
-	if len(bz) == 0 {
+	height := bs.LoadBlockHeightByHash(hash)
+	if height == 0 {
 		return nil
 	}
-
-	s := string(bz)
-	height, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		panic(fmt.Sprintf("failed to extract height from %s: %v", s, err))
-	}
 	return bs.LoadBlockMeta(height)
 }
 
@@ -222,7 +384,7 @@ func (bs *BlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta {
 // If no commit is found for the given height, it returns nil.
 func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 	pbc := new(cmtproto.Commit)
-	bz, err := bs.db.Get(calcBlockCommitKey(height))
+	bz, err := bs.get(calcBlockCommitKey(height))
 	if err != nil {
 		panic(err)
 	}
@@ -245,7 +407,7 @@ func (bs *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 // a new block at `height + 1` that includes this commit in its block.LastCommit.
 func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	pbc := new(cmtproto.Commit)
-	bz, err := bs.db.Get(calcSeenCommitKey(height))
+	bz, err := bs.get(calcSeenCommitKey(height))
 	if err != nil {
 		panic(err)
 	}
@@ -269,6 +431,9 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 	if height <= 0 {
 		return 0, -1, fmt.Errorf("height must be greater than 0")
 	}
+	bs.pruneMtx.Lock()
+	defer bs.pruneMtx.Unlock()
+
 	bs.mtx.RLock()
 	if height > bs.height {
 		bs.mtx.RUnlock()
@@ -284,7 +449,13 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 	pruned := uint64(0)
 	batch := bs.db.NewBatch()
 	defer batch.Close()
-	flush := func(batch dbm.Batch, base int64) error {
+	// the blocks moved to the cold store are deleted from it
+	var coldBatch dbm.Batch
+	if bs.cold != nil {
+		coldBatch = bs.cold.NewBatch()
+		defer coldBatch.Close()
+	}
+	flush := func(batch, coldBatch dbm.Batch, base int64) error {
 		// We can't trust batches to be atomic, so update base first to make sure noone
 		// tries to access missing blocks.
 		bs.mtx.Lock()
@@ -297,6 +468,12 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 			return fmt.Errorf("failed to prune up to height %v: %w", base, err)
 		}
 		batch.Close()
+		if coldBatch != nil {
+			if err := coldBatch.WriteSync(); err != nil {
+				return fmt.Errorf("failed to prune the cold store up to height %v: %w", base, err)
+			}
+			coldBatch.Close()
+		}
 		return nil
 	}
 
@@ -307,6 +484,10 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 		if meta == nil { // assume already deleted
 			continue
 		}
+		db, target := bs.dbAt(h), batch
+		if db == bs.cold {
+			target = coldBatch
+		}
 
 		// This logic is in place to protect data that proves malicious behavior.
 		// If the height is within the evidence age, we continue to persist the header and commit data.
@@ -317,44 +498,47 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 
 		// if height is beyond the evidence point we dont delete the header
 		if h < evidencePoint {
-			if err := batch.Delete(calcBlockMetaKey(h)); err != nil {
+			if err := target.Delete(calcBlockMetaKey(h)); err != nil {
 				return 0, -1, err
 			}
 		}
-		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+		if err := target.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
 			return 0, -1, err
 		}
 		// if height is beyond the evidence point we dont delete the commit data
 		if h < evidencePoint {
-			if err := batch.Delete(calcBlockCommitKey(h)); err != nil {
+			if err := target.Delete(calcBlockCommitKey(h)); err != nil {
 				return 0, -1, err
 			}
 		}
-		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
+		if err := target.Delete(calcSeenCommitKey(h)); err != nil {
 			return 0, -1, err
 		}
-		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
-			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
-				return 0, -1, err
-			}
+		if err := deleteBlockParts(db, target, h, meta.BlockID.PartSetHeader.Total, false); err != nil {
+			return 0, -1, err
 		}
 		pruned++
 
 		// flush every 1000 blocks to avoid batches becoming too large
 		if pruned%1000 == 0 && pruned > 0 {
-			err := flush(batch, h)
+			err := flush(batch, coldBatch, h)
 			if err != nil {
 				return 0, -1, err
 			}
 			batch = bs.db.NewBatch()
 			defer batch.Close()
+			if bs.cold != nil {
+				coldBatch = bs.cold.NewBatch()
+				defer coldBatch.Close()
+			}
 		}
 	}
 
-	err := flush(batch, height)
+	err := flush(batch, coldBatch, height)
 	if err != nil {
 		return 0, -1, err
 	}
+	bs.resetCachedBlockBlob()
 	return pruned, evidencePoint, nil
 }
 
@@ -380,13 +564,16 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic("BlockStore can only save complete block part sets")
 	}
 
-	// Save block parts. This must be done before the block meta, since callers
+	// Save block blob. This must be done before the block meta, since callers
 	// typically load the block meta first as an indication that the block exists
 	// and then go on to load block parts - we must make sure the block is
 	// complete as soon as the block meta is written.
-	for i := 0; i < int(blockParts.Total()); i++ {
-		part := blockParts.GetPart(i)
-		bs.saveBlockPart(height, i, part)
+	blob, index := encodeBlockBlob(blockParts)
+	if err := bs.db.Set(calcBlockBlobKey(height), blob); err != nil {
+		panic(err)
+	}
+	if err := bs.db.Set(calcBlockPartIndexKey(height), index); err != nil {
+		panic(err)
 	}
 
 	// Save block meta
@@ -430,15 +617,58 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.saveState()
 }
 
-func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
-	pbp, err := part.ToProto()
+// encodeBlockBlob returns the compressed blob of the complete part set of a
+// block, and its encoded part index.
+func encodeBlockBlob(blockParts *types.PartSet) (blob []byte, index []byte) {
+	total := int(blockParts.Total())
+	data := make([]byte, 0, blockParts.ByteSize())
+	pbi := cmtstore.BlockPartIndex{
+		Ends:   make([]uint32, 0, total),
+		Proofs: make([]cmtcrypto.Proof, 0, total),
+	}
+	for i := 0; i < total; i++ {
+		part := blockParts.GetPart(i)
+		data = append(data, part.Bytes...)
+		pbi.Ends = append(pbi.Ends, uint32(len(data)))
+		pbi.Proofs = append(pbi.Proofs, *part.Proof.ToProto())
+	}
+	return blobZstdEncoder.EncodeAll(data, nil), mustEncode(&pbi)
+}
+
+// setBlockBlob adds the blob and the part index of the given block parts to
+// batch.
+func setBlockBlob(batch dbm.Batch, height int64, blockParts *types.PartSet) error {
+	blob, index := encodeBlockBlob(blockParts)
+	if err := batch.Set(calcBlockBlobKey(height), blob); err != nil {
+		return err
+	}
+	return batch.Set(calcBlockPartIndexKey(height), index)
+}
+
+// deleteBlockParts adds the deletion of the parts of the block at the given
+// height to batch, whether the block is stored as a blob or with an entry per
+// part. If both is false, only the layout of the block in db is deleted.
+func deleteBlockParts(db dbm.DB, batch dbm.Batch, height int64, total uint32, both bool) error {
+	isBlob, err := db.Has(calcBlockBlobKey(height))
 	if err != nil {
-		panic(fmt.Errorf("unable to make part into proto: %w", err))
+		return err
 	}
-	partBytes := mustEncode(pbp)
-	if err := bs.db.Set(calcBlockPartKey(height, index), partBytes); err != nil {
-		panic(err)
+	if isBlob || both {
+		if err := batch.Delete(calcBlockBlobKey(height)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcBlockPartIndexKey(height)); err != nil {
+			return err
+		}
+	}
+	if !isBlob || both {
+		for p := 0; p < int(total); p++ {
+			if err := batch.Delete(calcBlockPartKey(height, p)); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
 }
 
 func (bs *BlockStore) saveState() {
@@ -461,7 +691,21 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 	return bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
 }
 
+// Compact compacts the database, and the cold store if any, to reclaim the
+// disk space of the pruned blocks.
+func (bs *BlockStore) Compact() error {
+	if err := sm.CompactDB(bs.db); err != nil || bs.cold == nil {
+		return err
+	}
+	return sm.CompactDB(bs.cold)
+}
+
 func (bs *BlockStore) Close() error {
+	if bs.cold != nil {
+		if err := bs.cold.Close(); err != nil {
+			return err
+		}
+	}
 	return bs.db.Close()
 }
 
@@ -475,6 +719,14 @@ func calcBlockPartKey(height int64, partIndex int) []byte {
 	return []byte(fmt.Sprintf("P:%v:%v", height, partIndex))
 }
 
+func calcBlockBlobKey(height int64) []byte {
+	return []byte(fmt.Sprintf("B:%v", height))
+}
+
+func calcBlockPartIndexKey(height int64) []byte {
+	return []byte(fmt.Sprintf("PI:%v", height))
+}
+
 func calcBlockCommitKey(height int64) []byte {
 	return []byte(fmt.Sprintf("C:%v", height))
 }
@@ -556,10 +808,8 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
 			return err
 		}
-		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
-			if err := batch.Delete(calcBlockPartKey(targetHeight, p)); err != nil {
-				return err
-			}
+		if err := deleteBlockParts(bs.db, batch, targetHeight, meta.BlockID.PartSetHeader.Total, true); err != nil {
+			return err
 		}
 	}
 	if err := batch.Delete(calcBlockCommitKey(targetHeight)); err != nil {
@@ -582,5 +832,42 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 	if err != nil {
 		return fmt.Errorf("failed to delete height %v: %w", targetHeight, err)
 	}
+	bs.resetCachedBlockBlob()
 	return nil
 }
+
+//-----------------------------------------------------------------------------
+
+// blockBlob is the decompressed blob of a block, along with its part index.
+type blockBlob struct {
+	height int64
+	data   []byte
+	index  cmtstore.BlockPartIndex
+}
+
+// part returns the part at the given index of the block, or nil if there is
+// no such part.
+func (blob *blockBlob) part(index int) *types.Part {
+	if index < 0 || index >= len(blob.index.Ends) {
+		return nil
+	}
+	start := uint32(0)
+	if index > 0 {
+		start = blob.index.Ends[index-1]
+	}
+	end := blob.index.Ends[index]
+	if start > end || int(end) > len(blob.data) {
+		panic(fmt.Sprintf("Error reading block part: invalid range %d to %d of %d bytes",
+			start, end, len(blob.data)))
+	}
+	proof, err := merkle.ProofFromProto(&blob.index.Proofs[index])
+	if err != nil {
+		panic(fmt.Sprintf("Error reading block part proof: %v", err))
+	}
+	// copy the bytes, since the blob is shared by the loads of its parts
+	return &types.Part{
+		Index: uint32(index),
+		Bytes: append([]byte(nil), blob.data[start:end]...),
+		Proof: *proof,
+	}
+}
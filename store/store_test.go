@@ -430,6 +430,199 @@ func TestLoadBlockPart(t *testing.T) {
 		"expecting successful retrieval of previously saved block")
 }
 
+func TestLoadBlockPartFromBlob(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	block := state.MakeBlock(1, test.MakeNTxs(1, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+	partSet, err := block.MakePartSet(64)
+	require.NoError(t, err)
+	require.Greater(t, partSet.Total(), uint32(2))
+	bs.SaveBlock(block, partSet, makeTestCommit(1, cmttime.Now()))
+
+	// the block is saved as a blob, without an entry per part
+	has, err := bs.db.Has(calcBlockPartKey(1, 0))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	for i := 0; i < int(partSet.Total()); i++ {
+		part := bs.LoadBlockPart(1, i)
+		require.NotNil(t, part)
+		assert.Equal(t, partSet.GetPart(i), part)
+		require.NoError(t, part.Proof.Verify(partSet.Hash(), part.Bytes))
+	}
+	assert.Nil(t, bs.LoadBlockPart(1, int(partSet.Total())))
+	assert.Nil(t, bs.LoadBlockPart(1, -1))
+	assert.Nil(t, bs.LoadBlockPart(2, 0))
+	assert.Equal(t, block.Hash(), bs.LoadBlock(1).Hash())
+
+	// the cached blob is not used once the block is deleted
+	require.NoError(t, bs.DeleteLatestBlock())
+	assert.Nil(t, bs.LoadBlockPart(1, 0))
+	assert.Nil(t, bs.LoadBlock(1))
+}
+
+// saveLegacyBlockParts replaces the blob of the block at the given height by
+// an entry per part, as the previous versions stored the blocks.
+func saveLegacyBlockParts(t *testing.T, bs *BlockStore, height int64, partSet *types.PartSet) {
+	t.Helper()
+	require.NoError(t, bs.db.Delete(calcBlockBlobKey(height)))
+	require.NoError(t, bs.db.Delete(calcBlockPartIndexKey(height)))
+	for i := 0; i < int(partSet.Total()); i++ {
+		pbp, err := partSet.GetPart(i).ToProto()
+		require.NoError(t, err)
+		require.NoError(t, bs.db.Set(calcBlockPartKey(height, i), mustEncode(pbp)))
+	}
+}
+
+func TestMigrateBlockParts(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	_, _, err := bs.MigrateBlockParts(0)
+	require.Error(t, err)
+	_, done, err := bs.MigrateBlockParts(10)
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	partSets := make(map[int64]*types.PartSet)
+	for h := int64(1); h <= 25; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(64)
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, makeTestCommit(h, cmttime.Now()))
+		partSets[h] = partSet
+		// the last blocks are saved by the current version
+		if h <= 20 {
+			saveLegacyBlockParts(t, bs, h, partSet)
+		}
+	}
+
+	// the legacy blocks are readable
+	assert.Equal(t, partSets[3].GetPart(1), bs.LoadBlockPart(3, 1))
+	require.NotNil(t, bs.LoadBlock(3))
+
+	_, _, err = bs.PruneBlocks(3, state)
+	require.NoError(t, err)
+
+	migrated, done, err := bs.MigrateBlockParts(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, migrated)
+	assert.False(t, done)
+
+	// the migration resumes where it stopped, with a new block store
+	bs = NewBlockStore(bs.db)
+	migrated, done, err = bs.MigrateBlockParts(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, migrated)
+	assert.True(t, done)
+
+	for h := int64(3); h <= 25; h++ {
+		has, err := bs.db.Has(calcBlockPartKey(h, 0))
+		require.NoError(t, err)
+		assert.False(t, has, h)
+		require.NotNil(t, bs.LoadBlock(h), h)
+		for i := 0; i < int(partSets[h].Total()); i++ {
+			assert.Equal(t, partSets[h].GetPart(i), bs.LoadBlockPart(h, i), h)
+		}
+	}
+	// the pruned blocks are not migrated
+	for h := int64(1); h < 3; h++ {
+		for _, key := range [][]byte{calcBlockBlobKey(h), calcBlockPartKey(h, 0)} {
+			has, err := bs.db.Has(key)
+			require.NoError(t, err)
+			assert.False(t, has, h)
+		}
+	}
+
+	migrated, done, err = bs.MigrateBlockParts(10)
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+	assert.True(t, done)
+}
+
+func TestMoveToColdStore(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	_, _, err := bs.MoveToColdStore(10, 10)
+	require.ErrorIs(t, err, ErrNoColdStore)
+
+	cold := dbm.NewMemDB()
+	bs = NewBlockStore(bs.db, WithColdStore(cold))
+	_, _, err = bs.MoveToColdStore(10, 0)
+	require.Error(t, err)
+
+	blocks := make(map[int64]*types.Block)
+	partSets := make(map[int64]*types.PartSet)
+	for h := int64(1); h <= 20; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(64)
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, makeTestCommit(h, cmttime.Now()))
+		blocks[h] = block
+		partSets[h] = partSet
+		// the first blocks are saved by a previous version
+		if h <= 5 {
+			saveLegacyBlockParts(t, bs, h, partSet)
+		}
+	}
+
+	moved, done, err := bs.MoveToColdStore(15, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, moved)
+	assert.False(t, done)
+
+	// the moves resume where they stopped, with a new block store
+	bs = NewBlockStore(bs.db, WithColdStore(cold))
+	moved, done, err = bs.MoveToColdStore(15, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, moved)
+	assert.True(t, done)
+
+	// the blocks are served from both stores
+	for h := int64(1); h <= 20; h++ {
+		inCold := h < 15
+		for _, key := range [][]byte{calcBlockMetaKey(h), calcBlockBlobKey(h), calcSeenCommitKey(h)} {
+			has, err := bs.db.Has(key)
+			require.NoError(t, err)
+			assert.Equal(t, !inCold, has, h)
+			has, err = cold.Has(key)
+			require.NoError(t, err)
+			assert.Equal(t, inCold, has, h)
+		}
+		has, err := cold.Has(calcBlockPartKey(h, 0))
+		require.NoError(t, err)
+		assert.False(t, has, h)
+
+		assert.Equal(t, blocks[h].Hash(), bs.LoadBlock(h).Hash(), h)
+		assert.Equal(t, blocks[h].Hash(), bs.LoadBlockByHash(blocks[h].Hash()).Hash(), h)
+		assert.Equal(t, partSets[h].GetPart(0), bs.LoadBlockPart(h, 0), h)
+		assert.NotNil(t, bs.LoadSeenCommit(h), h)
+		if h < 20 {
+			assert.NotNil(t, bs.LoadBlockCommit(h), h)
+		}
+	}
+
+	// the pruning deletes the blocks from both stores
+	_, _, err = bs.PruneBlocks(17, state)
+	require.NoError(t, err)
+	for h := int64(1); h < 17; h++ {
+		assert.Nil(t, bs.LoadBlock(h), h)
+		for _, db := range []dbm.DB{bs.db, cold} {
+			has, err := db.Has(calcBlockBlobKey(h))
+			require.NoError(t, err)
+			assert.False(t, has, h)
+		}
+	}
+	assert.NotNil(t, bs.LoadBlock(17))
+
+	moved, done, err = bs.MoveToColdStore(15, 10)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+	assert.True(t, done)
+}
+
 func TestPruneBlocks(t *testing.T) {
 	config := test.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
@@ -592,6 +785,9 @@ func TestLoadBlockMetaByHash(t *testing.T) {
 	assert.EqualValues(t, b1.Header.Height, baseBlock.Header.Height)
 	assert.EqualValues(t, b1.Header.LastBlockID, baseBlock.Header.LastBlockID)
 	assert.EqualValues(t, b1.Header.ChainID, baseBlock.Header.ChainID)
+
+	assert.EqualValues(t, b1.Header.Height, bs.LoadBlockHeightByHash(b1.Hash()))
+	assert.Zero(t, bs.LoadBlockHeightByHash([]byte("unknown")))
 }
 
 func TestBlockFetchAtHeight(t *testing.T) {
@@ -651,3 +847,73 @@ func newBlock(hdr types.Header, lastCommit *types.Commit) *types.Block {
 		LastCommit: lastCommit,
 	}
 }
+
+// benchmarkedBackends are the database backends the block store is benchmarked
+// with, if the binary is built with their tags.
+var benchmarkedBackends = []dbm.BackendType{
+	dbm.GoLevelDBBackend,
+	dbm.PebbleDBBackend,
+	dbm.CLevelDBBackend,
+	dbm.RocksDBBackend,
+	dbm.BadgerDBBackend,
+	dbm.BoltDBBackend,
+}
+
+func newBenchmarkBlockStore(b *testing.B, backend dbm.BackendType) *BlockStore {
+	db, err := dbm.NewDB("blockstore", backend, b.TempDir())
+	if err != nil {
+		b.Skipf("%s is not available: %v", backend, err)
+	}
+	b.Cleanup(func() { db.Close() })
+	return NewBlockStore(db)
+}
+
+func BenchmarkBlockStoreSaveBlock(b *testing.B) {
+	state, _, cleanup := makeStateAndBlockStore(log.NewNopLogger())
+	defer cleanup()
+
+	for _, backend := range benchmarkedBackends {
+		b.Run(string(backend), func(b *testing.B) {
+			bs := newBenchmarkBlockStore(b, backend)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				h := int64(i + 1)
+				block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+				partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+				require.NoError(b, err)
+				seenCommit := makeTestCommit(h, cmttime.Now())
+				b.StartTimer()
+
+				bs.SaveBlock(block, partSet, seenCommit)
+			}
+		})
+	}
+}
+
+func BenchmarkBlockStoreLoadBlock(b *testing.B) {
+	const height = 1000
+	state, _, cleanup := makeStateAndBlockStore(log.NewNopLogger())
+	defer cleanup()
+
+	for _, backend := range benchmarkedBackends {
+		b.Run(string(backend), func(b *testing.B) {
+			bs := newBenchmarkBlockStore(b, backend)
+			for h := int64(1); h <= height; h++ {
+				block := state.MakeBlock(h, test.MakeNTxs(h, 10), new(types.Commit), nil, state.Validators.GetProposer().Address)
+				partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+				require.NoError(b, err)
+				bs.SaveBlock(block, partSet, makeTestCommit(h, cmttime.Now()))
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bs.LoadBlock(int64(i%height)+1) == nil {
+					b.Fatal("block not found")
+				}
+			}
+		})
+	}
+}
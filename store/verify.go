@@ -0,0 +1,251 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// CorruptedRange is an inclusive range of heights whose blocks failed the
+// verification of the block store.
+type CorruptedRange struct {
+	From int64
+	To   int64
+	// Err is the verification error of the first height of the range.
+	Err error
+}
+
+// VerifyResult is the outcome of the verification of the block store.
+type VerifyResult struct {
+	// Verified is the number of heights whose block and commit were verified.
+	Verified int64
+	// Unverified is the number of heights whose block was checked, but whose
+	// commit could not be verified since their validator set is not stored.
+	Unverified int64
+	// Corrupted are the ranges of heights whose blocks are corrupted.
+	Corrupted []CorruptedRange
+}
+
+// Verify checks the integrity of the blocks from height from to height to,
+// inclusive: the stored blocks are decoded, their hashes and part set headers
+// recomputed and compared with their block metas, and their commits verified
+// against the validator sets of the state store. The heights which failed the
+// verification are reported as corrupted ranges. It stops when ctx is done.
+func (bs *BlockStore) Verify(
+	ctx context.Context,
+	stateStore sm.Store,
+	chainID string,
+	from, to int64,
+) (VerifyResult, error) {
+	var res VerifyResult
+	if base, height := bs.Base(), bs.Height(); from < base || to > height || from > to {
+		return res, fmt.Errorf("heights %d to %d are not within the stored heights %d to %d",
+			from, to, base, height)
+	}
+
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		verified, err := bs.VerifyBlock(stateStore, chainID, height)
+		switch {
+		case err != nil:
+			n := len(res.Corrupted)
+			if n > 0 && res.Corrupted[n-1].To == height-1 {
+				res.Corrupted[n-1].To = height
+			} else {
+				res.Corrupted = append(res.Corrupted, CorruptedRange{From: height, To: height, Err: err})
+			}
+		case verified:
+			res.Verified++
+		default:
+			res.Unverified++
+		}
+	}
+	return res, nil
+}
+
+// VerifyBlock checks the integrity of the block at the given height, see
+// Verify. It reports whether the commit of the block could be verified, which
+// it can't if the validator set of the height is not stored.
+func (bs *BlockStore) VerifyBlock(stateStore sm.Store, chainID string, height int64) (verified bool, err error) {
+	// the store panics when loading corrupted data
+	defer func() {
+		if r := recover(); r != nil {
+			verified, err = false, fmt.Errorf("loading the block: %v", r)
+		}
+	}()
+
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil {
+		return false, errors.New("block meta not found")
+	}
+	block := bs.LoadBlock(height)
+	if block == nil {
+		return false, errors.New("block parts not found")
+	}
+	if h := bs.LoadBlockHeightByHash(meta.BlockID.Hash); h != height {
+		return false, fmt.Errorf("block hash is indexed at height %d", h)
+	}
+	if !bytes.Equal(meta.Header.Hash(), meta.BlockID.Hash) {
+		return false, fmt.Errorf("block meta header hash %X does not match the block ID %v",
+			meta.Header.Hash(), meta.BlockID)
+	}
+
+	commit := bs.LoadBlockCommit(height)
+	if commit == nil {
+		commit = bs.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return false, errors.New("commit not found")
+	}
+	return verifyBlock(stateStore, chainID, height, block, meta.BlockID, commit)
+}
+
+// verifyBlock checks that block, of the given block ID, is a valid block of
+// the given height committed by commit.
+func verifyBlock(
+	stateStore sm.Store,
+	chainID string,
+	height int64,
+	block *types.Block,
+	blockID types.BlockID,
+	commit *types.Commit,
+) (bool, error) {
+	if block.Height != height {
+		return false, fmt.Errorf("block has height %d", block.Height)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return false, fmt.Errorf("invalid block: %w", err)
+	}
+	if hash := block.Hash(); !bytes.Equal(hash, blockID.Hash) {
+		return false, fmt.Errorf("block hash %X does not match the block ID %v", hash, blockID)
+	}
+	partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return false, fmt.Errorf("making the block parts: %w", err)
+	}
+	if header := partSet.Header(); !header.Equals(blockID.PartSetHeader) {
+		return false, fmt.Errorf("block parts %v do not match the block ID %v", header, blockID)
+	}
+
+	vals, err := stateStore.LoadValidators(height)
+	if errors.As(err, &sm.ErrNoValSetForHeight{}) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("loading the validator set: %w", err)
+	}
+	if !bytes.Equal(block.ValidatorsHash, vals.Hash()) {
+		return false, fmt.Errorf("block validators hash %X does not match the stored validator set %X",
+			block.ValidatorsHash, vals.Hash())
+	}
+	if err := vals.VerifyCommitLight(chainID, blockID, height, commit); err != nil {
+		return false, fmt.Errorf("invalid commit: %w", err)
+	}
+	return true, nil
+}
+
+// RepairBlock replaces a stored block, whose height must be between the base
+// and the height of the store, by the given block and its commit, typically
+// fetched from a peer. The block and its commit are first verified against
+// the validator set of the state store.
+func (bs *BlockStore) RepairBlock(stateStore sm.Store, chainID string, block *types.Block, commit *types.Commit) error {
+	if block == nil || commit == nil {
+		return errors.New("block and commit can't be nil")
+	}
+	height := block.Height
+	if base, latest := bs.Base(), bs.Height(); height < base || height > latest {
+		return fmt.Errorf("height %d is not within the stored heights %d to %d", height, base, latest)
+	}
+
+	blockParts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return fmt.Errorf("making the block parts: %w", err)
+	}
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
+	verified, err := verifyBlock(stateStore, chainID, height, block, blockID, commit)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return sm.ErrNoValSetForHeight{Height: height}
+	}
+
+	// the block may have been moved to the cold store, and be repaired there
+	bs.pruneMtx.Lock()
+	defer bs.pruneMtx.Unlock()
+	batches := make(map[dbm.DB]dbm.Batch)
+	batchAt := func(height int64) dbm.Batch {
+		db := bs.dbAt(height)
+		if _, ok := batches[db]; !ok {
+			batches[db] = db.NewBatch()
+		}
+		return batches[db]
+	}
+	defer func() {
+		for _, batch := range batches {
+			batch.Close()
+		}
+	}()
+	batch := batchAt(height)
+
+	// delete what can be loaded of the corrupted block, so that its hash is
+	// not indexed anymore
+	if meta := bs.loadCorruptedBlockMeta(height); meta != nil {
+		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+			return err
+		}
+		if err := deleteBlockParts(bs.dbAt(height), batch, height, meta.BlockID.PartSetHeader.Total, true); err != nil {
+			return err
+		}
+	}
+
+	if err := setBlockBlob(batch, height, blockParts); err != nil {
+		return err
+	}
+	meta := types.NewBlockMeta(block, blockParts)
+	if err := batch.Set(calcBlockMetaKey(height), mustEncode(meta.ToProto())); err != nil {
+		return err
+	}
+	if err := batch.Set(calcBlockHashKey(blockID.Hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+		return err
+	}
+	if height > bs.Base() {
+		if err := batchAt(height-1).Set(calcBlockCommitKey(height-1), mustEncode(block.LastCommit.ToProto())); err != nil {
+			return err
+		}
+	}
+	// the latest block is only committed by its seen commit
+	commitKey := calcBlockCommitKey(height)
+	if height == bs.Height() {
+		commitKey = calcSeenCommitKey(height)
+	}
+	if err := batch.Set(commitKey, mustEncode(commit.ToProto())); err != nil {
+		return err
+	}
+
+	for _, batch := range batches {
+		if err := batch.WriteSync(); err != nil {
+			return fmt.Errorf("failed to repair height %d: %w", height, err)
+		}
+	}
+	bs.resetCachedBlockBlob()
+	return nil
+}
+
+// loadCorruptedBlockMeta returns the block meta of the given height, or nil if
+// it is missing or can't be decoded.
+func (bs *BlockStore) loadCorruptedBlockMeta(height int64) (meta *types.BlockMeta) {
+	defer func() {
+		if r := recover(); r != nil {
+			meta = nil
+		}
+	}()
+	return bs.LoadBlockMeta(height)
+}
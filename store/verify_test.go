@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/internal/test"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/types"
+)
+
+// makeVerifiableChain saves a chain of the given height, committed by the
+// validators saved in the returned state store, and returns its blocks and
+// commits, indexed by height-1.
+func makeVerifiableChain(t *testing.T, height int64) (*BlockStore, sm.Store, []*types.Block, []*types.Commit) {
+	t.Helper()
+	vals, privVals := test.ValidatorSet(context.Background(), t, 4, 10)
+	genDoc := test.GenesisDoc(time.Now(), vals.Validators, types.DefaultConsensusParams(), test.DefaultTestChainID)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(state))
+	bs := NewBlockStore(dbm.NewMemDB())
+
+	var (
+		blocks     []*types.Block
+		commits    []*types.Commit
+		lastCommit = new(types.Commit)
+	)
+	for h := int64(1); h <= height; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 2), lastCommit, nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		commit, err := test.MakeCommit(blockID, h, 0, state.Validators, privVals, state.ChainID, block.Time.Add(time.Second))
+		require.NoError(t, err)
+		bs.SaveBlock(block, partSet, commit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, stateStore.Save(state))
+
+		blocks = append(blocks, block)
+		commits = append(commits, commit)
+		lastCommit = commit
+	}
+	return bs, stateStore, blocks, commits
+}
+
+func TestVerifyBlockStore(t *testing.T) {
+	bs, stateStore, blocks, commits := makeVerifiableChain(t, 8)
+	chainID := test.DefaultTestChainID
+
+	res, err := bs.Verify(context.Background(), stateStore, chainID, 1, 8)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, res.Verified)
+	assert.Zero(t, res.Unverified)
+	assert.Empty(t, res.Corrupted)
+
+	_, err = bs.Verify(context.Background(), stateStore, chainID, 0, 8)
+	require.Error(t, err)
+	_, err = bs.Verify(context.Background(), stateStore, chainID, 1, 9)
+	require.Error(t, err)
+
+	// the commits can't be verified without the validator sets
+	res, err = bs.Verify(context.Background(), sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{}), chainID, 1, 8)
+	require.NoError(t, err)
+	assert.Zero(t, res.Verified)
+	assert.EqualValues(t, 8, res.Unverified)
+	assert.Empty(t, res.Corrupted)
+
+	// a blob of another block, an undecodable block meta, and a commit of
+	// another block
+	require.NoError(t, bs.db.Set(calcBlockBlobKey(3), mustBytes(t, bs.db, calcBlockBlobKey(2))))
+	require.NoError(t, bs.db.Set(calcBlockMetaKey(4), []byte("corrupted")))
+	require.NoError(t, bs.db.Set(calcBlockCommitKey(7), mustBytes(t, bs.db, calcBlockCommitKey(6))))
+
+	res, err = bs.Verify(context.Background(), stateStore, chainID, 1, 8)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, res.Verified)
+	require.Len(t, res.Corrupted, 2)
+	assert.EqualValues(t, 3, res.Corrupted[0].From)
+	assert.EqualValues(t, 4, res.Corrupted[0].To)
+	assert.EqualValues(t, 7, res.Corrupted[1].From)
+	assert.EqualValues(t, 7, res.Corrupted[1].To)
+	require.Error(t, res.Corrupted[1].Err)
+
+	// a block is only repaired with its commit
+	require.Error(t, bs.RepairBlock(stateStore, chainID, blocks[2], commits[3]))
+	require.Error(t, bs.RepairBlock(stateStore, chainID, blocks[2], nil))
+
+	for _, h := range []int64{3, 4, 7} {
+		require.NoError(t, bs.RepairBlock(stateStore, chainID, blocks[h-1], commits[h-1]))
+	}
+	res, err = bs.Verify(context.Background(), stateStore, chainID, 1, 8)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, res.Verified)
+	assert.Empty(t, res.Corrupted)
+	assert.EqualValues(t, 3, bs.LoadBlockHeightByHash(blocks[2].Hash()))
+}
+
+func mustBytes(t *testing.T, db dbm.DB, key []byte) []byte {
+	t.Helper()
+	bz, err := db.Get(key)
+	require.NoError(t, err)
+	return bz
+}
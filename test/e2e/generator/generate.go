@@ -33,7 +33,7 @@ var (
 	}
 
 	// The following specify randomly chosen values for testnet nodes.
-	nodeDatabases = uniformChoice{"goleveldb", "cleveldb", "rocksdb", "boltdb", "badgerdb"}
+	nodeDatabases = uniformChoice{"goleveldb", "cleveldb", "rocksdb", "boltdb", "badgerdb", "pebbledb"}
 	ipv6          = uniformChoice{false, true}
 	// FIXME: grpc disabled due to https://github.com/tendermint/tendermint/issues/5439
 	nodeABCIProtocols     = uniformChoice{"unix", "tcp", "builtin", "builtin_unsync"} // "grpc"
@@ -48,6 +48,7 @@ var (
 		4 * int(e2e.EvidenceAgeHeight),
 	}
 	evidence          = uniformChoice{0, 1, 10}
+	keyTypes          = weightedChoice{"ed25519": 3, "secp256k1": 1}
 	abciDelays        = uniformChoice{"none", "small", "large"}
 	nodePerturbations = probSetChoice{
 		"disconnect": 0.1,
@@ -125,6 +126,7 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}, upgradeVersion st
 		Validators:       &map[string]int64{},
 		ValidatorUpdates: map[string]map[string]int64{},
 		Evidence:         evidence.Choose(r).(int),
+		KeyType:          keyTypes.Choose(r).(string),
 		Nodes:            map[string]*e2e.ManifestNode{},
 		UpgradeVersion:   upgradeVersion,
 	}
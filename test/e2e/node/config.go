@@ -24,6 +24,7 @@ type Config struct {
 	PrivValKey       string                      `toml:"privval_key"`
 	PrivValState     string                      `toml:"privval_state"`
 	KeyType          string                      `toml:"key_type"`
+	Misbehaviors     []string                    `toml:"misbehaviors"`
 }
 
 // App extracts out the application specific configuration parameters
@@ -14,6 +14,7 @@ import (
 
 	"github.com/cometbft/cometbft/abci/server"
 	"github.com/cometbft/cometbft/config"
+	cs "github.com/cometbft/cometbft/consensus"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmtflags "github.com/cometbft/cometbft/libs/cli/flags"
 	"github.com/cometbft/cometbft/libs/log"
@@ -31,6 +32,11 @@ import (
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
 )
 
+// proposalDelay is the delay of the proposals of the nodes with the
+// delay-proposals misbehavior, long enough for the other validators to prevote
+// nil with the default timeout_propose.
+const proposalDelay = 5 * time.Second
+
 var logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
 
 // main is the binary entrypoint.
@@ -144,9 +150,43 @@ func startNode(cfg *Config) error {
 	if err != nil {
 		return err
 	}
+	if err := setMisbehaviors(n, cfg.Misbehaviors); err != nil {
+		return err
+	}
 	return n.Start()
 }
 
+// setMisbehaviors makes the consensus reactor of the node byzantine, which
+// requires the node to be built with the byzantine tag.
+func setMisbehaviors(n *node.Node, misbehaviors []string) error {
+	if len(misbehaviors) == 0 {
+		return nil
+	}
+	if !cs.ByzantineHooksEnabled {
+		return errors.New("misbehaviors require a node built with the byzantine tag")
+	}
+
+	var behavior cs.ByzantineBehavior
+	for _, misbehavior := range misbehaviors {
+		switch e2e.Misbehavior(misbehavior) {
+		case e2e.MisbehaviorEquivocate:
+			behavior.EquivocateVotes = true
+		case e2e.MisbehaviorWithholdBlockParts:
+			behavior.WithholdBlockParts = true
+		case e2e.MisbehaviorDelayProposals:
+			behavior.ProposalDelay = proposalDelay
+		default:
+			return fmt.Errorf("invalid misbehavior %q", misbehavior)
+		}
+	}
+	conR, ok := n.Switch().Reactor("CONSENSUS").(*cs.Reactor)
+	if !ok {
+		return errors.New("the node has no consensus reactor")
+	}
+	logger.Info("Setting misbehaviors", "misbehaviors", misbehaviors)
+	return conR.SetByzantineBehavior(behavior)
+}
+
 func startLightClient(cfg *Config) error {
 	cmtcfg, nodeLogger, _, err := setupNode()
 	if err != nil {
@@ -49,7 +49,7 @@ type Manifest struct {
 	Nodes map[string]*ManifestNode `toml:"node"`
 
 	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// Options are ed25519, secp256k1 & bls12_381
 	KeyType string `toml:"key_type"`
 
 	// Evidence indicates the amount of evidence that will be injected into the
@@ -112,7 +112,7 @@ type ManifestNode struct {
 	PersistentPeers []string `toml:"persistent_peers"`
 
 	// Database specifies the database backend: "goleveldb", "cleveldb",
-	// "rocksdb", "boltdb", or "badgerdb". Defaults to goleveldb.
+	// "rocksdb", "boltdb", "badgerdb" or "pebbledb". Defaults to goleveldb.
 	Database string `toml:"database"`
 
 	// PrivvalProtocol specifies the protocol used to sign consensus messages:
@@ -158,6 +158,14 @@ type ManifestNode struct {
 	// restart:    restarts the node, shutting it down with SIGTERM
 	Perturb []string `toml:"perturb"`
 
+	// Misbehaviors lists the byzantine behaviors of a validator, which needs
+	// the node to be built with the byzantine tag, as the local e2e node is:
+	//
+	// equivocate:           signs a conflicting vote along with each vote
+	// withhold-block-parts: doesn't gossip the parts of the current blocks
+	// delay-proposals:      gossips its proposals late
+	Misbehaviors []string `toml:"misbehaviors"`
+
 	// SendNoLoad determines if the e2e test should send load to this node.
 	// It defaults to false so unless the configured, the node will
 	// receive load.
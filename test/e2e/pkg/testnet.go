@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
@@ -34,6 +35,7 @@ type (
 	Mode         string
 	Protocol     string
 	Perturbation string
+	Misbehavior  string
 )
 
 const (
@@ -55,6 +57,10 @@ const (
 	PerturbationRestart    Perturbation = "restart"
 	PerturbationUpgrade    Perturbation = "upgrade"
 
+	MisbehaviorEquivocate         Misbehavior = "equivocate"
+	MisbehaviorWithholdBlockParts Misbehavior = "withhold-block-parts"
+	MisbehaviorDelayProposals     Misbehavior = "delay-proposals"
+
 	EvidenceAgeHeight int64         = 7
 	EvidenceAgeTime   time.Duration = 500 * time.Millisecond
 )
@@ -105,6 +111,7 @@ type Node struct {
 	Seeds               []*Node
 	PersistentPeers     []*Node
 	Perturbations       []Perturbation
+	Misbehaviors        []Misbehavior
 	SendNoLoad          bool
 	Prometheus          bool
 	PrometheusProxyPort uint32
@@ -205,6 +212,7 @@ func LoadTestnet(manifest Manifest, fname string, ifd InfrastructureData) (*Test
 			SnapshotInterval: nodeManifest.SnapshotInterval,
 			RetainBlocks:     nodeManifest.RetainBlocks,
 			Perturbations:    []Perturbation{},
+			Misbehaviors:     []Misbehavior{},
 			SendNoLoad:       nodeManifest.SendNoLoad,
 			Prometheus:       testnet.Prometheus,
 		}
@@ -235,6 +243,9 @@ func LoadTestnet(manifest Manifest, fname string, ifd InfrastructureData) (*Test
 		for _, p := range nodeManifest.Perturb {
 			node.Perturbations = append(node.Perturbations, Perturbation(p))
 		}
+		for _, m := range nodeManifest.Misbehaviors {
+			node.Misbehaviors = append(node.Misbehaviors, Misbehavior(m))
+		}
 		testnet.Nodes = append(testnet.Nodes, node)
 	}
 
@@ -363,7 +374,7 @@ func (n Node) Validate(testnet Testnet) error {
 		return fmt.Errorf("invalid block sync setting %q", n.BlockSyncVersion)
 	}
 	switch n.Database {
-	case "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb":
+	case "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb", "pebbledb":
 	default:
 		return fmt.Errorf("invalid database setting %q", n.Database)
 	}
@@ -416,6 +427,24 @@ func (n Node) Validate(testnet Testnet) error {
 		}
 	}
 
+	for _, misbehavior := range n.Misbehaviors {
+		switch misbehavior {
+		case MisbehaviorEquivocate:
+			if n.PrivvalProtocol != ProtocolFile {
+				return errors.New("only a validator with a file private key can equivocate")
+			}
+		case MisbehaviorWithholdBlockParts, MisbehaviorDelayProposals:
+		default:
+			return fmt.Errorf("invalid misbehavior %q", misbehavior)
+		}
+		if n.Mode != ModeValidator {
+			return fmt.Errorf("misbehavior %q is only supported by validators", misbehavior)
+		}
+		if n.ABCIProtocol != ProtocolBuiltin && n.ABCIProtocol != ProtocolBuiltinUnsync {
+			return fmt.Errorf("misbehavior %q requires the builtin protocol", misbehavior)
+		}
+	}
+
 	return nil
 }
 
@@ -467,6 +496,16 @@ func (t Testnet) HasPerturbations() bool {
 	return false
 }
 
+// HasMisbehavior returns whether the node has the given misbehavior.
+func (n Node) HasMisbehavior(misbehavior Misbehavior) bool {
+	for _, m := range n.Misbehaviors {
+		if m == misbehavior {
+			return true
+		}
+	}
+	return false
+}
+
 // Address returns a P2P endpoint address for the node.
 func (n Node) AddressP2P(withID bool) string {
 	ip := n.IP.String()
@@ -522,6 +561,8 @@ func (g *keyGenerator) Generate(keyType string) crypto.PrivKey {
 	switch keyType {
 	case "secp256k1":
 		return secp256k1.GenPrivKeySecp256k1(seed)
+	case "bls12_381":
+		return bls12381.GenPrivKeyFromSecret(seed)
 	case "", "ed25519":
 		return ed25519.GenPrivKeyFromSecret(seed)
 	default:
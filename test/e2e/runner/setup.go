@@ -129,6 +129,9 @@ func MakeGenesis(testnet *e2e.Testnet) (types.GenesisDoc, error) {
 	}
 	// set the app version to 1
 	genesis.ConsensusParams.Version.App = 1
+	if testnet.KeyType != "" {
+		genesis.ConsensusParams.Validator.PubKeyTypes = []string{testnet.KeyType}
+	}
 	for validator, power := range testnet.Validators {
 		genesis.Validators = append(genesis.Validators, types.GenesisValidator{
 			Name:    validator.Name,
@@ -262,6 +265,7 @@ func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 		"prepare_proposal_delay": node.Testnet.PrepareProposalDelay,
 		"process_proposal_delay": node.Testnet.ProcessProposalDelay,
 		"check_tx_delay":         node.Testnet.CheckTxDelay,
+		"misbehaviors":           node.Misbehaviors,
 	}
 	switch node.ABCIProtocol {
 	case e2e.ProtocolUNIX:
@@ -1,9 +1,13 @@
 package e2e_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+	"github.com/cometbft/cometbft/types"
 )
 
 // assert that all nodes that have blocks at the height of a misbehavior has evidence
@@ -17,6 +21,47 @@ func TestEvidence_Misbehavior(t *testing.T) {
 			seenEvidence += len(block.Evidence.Evidence)
 		}
 	}
+	if hasMisbehavior(testnet, e2e.MisbehaviorEquivocate) {
+		// the equivocating validators produce evidence of their own
+		require.GreaterOrEqual(t, seenEvidence, testnet.Evidence,
+			"less evidence committed than produced")
+		return
+	}
 	require.Equal(t, testnet.Evidence, seenEvidence,
 		"difference between the amount of evidence produced and committed")
 }
+
+// assert that the equivocation of the byzantine validators is committed as
+// duplicate vote evidence
+func TestEvidence_Equivocation(t *testing.T) {
+	testnet := loadTestnet(t)
+	if !hasMisbehavior(testnet, e2e.MisbehaviorEquivocate) {
+		return
+	}
+	blocks := fetchBlockChain(t)
+	for _, node := range testnet.Nodes {
+		if !node.HasMisbehavior(e2e.MisbehaviorEquivocate) {
+			continue
+		}
+		address := node.PrivvalKey.PubKey().Address()
+		found := false
+		for _, block := range blocks {
+			for _, ev := range block.Evidence.Evidence {
+				if dve, ok := ev.(*types.DuplicateVoteEvidence); ok &&
+					bytes.Equal(dve.VoteA.ValidatorAddress, address) {
+					found = true
+				}
+			}
+		}
+		require.True(t, found, "no evidence of the equivocation of %v", node.Name)
+	}
+}
+
+func hasMisbehavior(testnet e2e.Testnet, misbehavior e2e.Misbehavior) bool {
+	for _, node := range testnet.Nodes {
+		if node.HasMisbehavior(misbehavior) {
+			return true
+		}
+	}
+	return false
+}
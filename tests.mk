@@ -69,6 +69,11 @@ test_race:
 	@go test -p 1 -v -race $(PACKAGES)
 .PHONY: test_race
 
+test_byzantine:
+	@echo "--> Running go test --byzantine"
+	@go test -p 1 -v ./consensus/... -tags byzantine -run Byzantine
+.PHONY: test_byzantine
+
 test_deadlock:
 	@echo "--> Running go test --deadlock"
 	@go test -p 1 -v  $(PACKAGES) -tags deadlock 
@@ -11,6 +11,7 @@ import (
 	gogotypes "github.com/cosmos/gogoproto/types"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/libs/bits"
@@ -587,9 +588,9 @@ const (
 const (
 	// Max size of commit without any commitSigs -> 82 for BlockID, 8 for Height, 4 for Round.
 	MaxCommitOverheadBytes int64 = 94
-	// Commit sig size is made up of 64 bytes for the signature, 20 bytes for the address,
+	// Commit sig size is made up of 96 bytes for the signature, 20 bytes for the address,
 	// 1 byte for the flag and 14 bytes for the timestamp
-	MaxCommitSigBytes int64 = 109
+	MaxCommitSigBytes int64 = 141
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -612,7 +613,7 @@ func NewCommitSigForBlock(signature []byte, valAddr Address, ts time.Time) Commi
 
 func MaxCommitBytes(valCount int) int64 {
 	// From the repeated commit sig field
-	var protoEncodingOverhead int64 = 2
+	var protoEncodingOverhead int64 = 3
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
@@ -667,6 +668,12 @@ func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
 
 // ValidateBasic performs basic validation.
 func (cs CommitSig) ValidateBasic() error {
+	return cs.validateBasic(false)
+}
+
+// validateBasic performs basic validation. The signature of a commit sig of an
+// aggregated commit is empty.
+func (cs CommitSig) validateBasic(aggregated bool) error {
 	switch cs.BlockIDFlag {
 	case BlockIDFlagAbsent:
 	case BlockIDFlagCommit:
@@ -694,6 +701,12 @@ func (cs CommitSig) ValidateBasic() error {
 			)
 		}
 		// NOTE: Timestamp validation is subtle and handled elsewhere.
+		if aggregated {
+			if len(cs.Signature) != 0 {
+				return errors.New("signature is present in an aggregated commit")
+			}
+			break
+		}
 		if len(cs.Signature) == 0 {
 			return errors.New("signature is missing")
 		}
@@ -722,13 +735,17 @@ func (cs *CommitSig) ToProto() *cmtproto.CommitSig {
 // FromProto sets a protobuf CommitSig to the given pointer.
 // It returns an error if the CommitSig is invalid.
 func (cs *CommitSig) FromProto(csp cmtproto.CommitSig) error {
+	return cs.fromProto(csp, false)
+}
+
+func (cs *CommitSig) fromProto(csp cmtproto.CommitSig, aggregated bool) error {
 
 	cs.BlockIDFlag = BlockIDFlag(csp.BlockIdFlag)
 	cs.ValidatorAddress = csp.ValidatorAddress
 	cs.Timestamp = csp.Timestamp
 	cs.Signature = csp.Signature
 
-	return cs.ValidateBasic()
+	return cs.validateBasic(aggregated)
 }
 
 //-------------------------------------
@@ -744,6 +761,9 @@ type Commit struct {
 	Round      int32       `json:"round"`
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
+	// AggregatedSignature is the aggregate of the BLS12-381 signatures of
+	// the commit sigs, whose own signatures are then empty.
+	AggregatedSignature []byte `json:"aggregated_signature,omitempty"`
 
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
@@ -762,6 +782,37 @@ func NewCommit(height int64, round int32, blockID BlockID, commitSigs []CommitSi
 	}
 }
 
+// IsAggregated returns true if the signatures of the commit are aggregated.
+func (commit *Commit) IsAggregated() bool {
+	return commit != nil && len(commit.AggregatedSignature) != 0
+}
+
+// Aggregate returns a copy of the commit whose BLS12-381 signatures are
+// aggregated into one. It returns an error if any of the signatures isn't a
+// BLS12-381 signature.
+func (commit *Commit) Aggregate() (*Commit, error) {
+	if commit.IsAggregated() {
+		return commit, nil
+	}
+	sigs := make([][]byte, 0, len(commit.Signatures))
+	commitSigs := make([]CommitSig, len(commit.Signatures))
+	for i, commitSig := range commit.Signatures {
+		commitSigs[i] = commitSig
+		if commitSig.Absent() {
+			continue
+		}
+		sigs = append(sigs, commitSig.Signature)
+		commitSigs[i].Signature = nil
+	}
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("can't aggregate the commit signatures: %w", err)
+	}
+	aggregated := NewCommit(commit.Height, commit.Round, commit.BlockID, commitSigs)
+	aggregated.AggregatedSignature = aggSig
+	return aggregated, nil
+}
+
 // CommitToVoteSet constructs a VoteSet from the Commit and validator set.
 // Panics if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
@@ -782,6 +833,7 @@ func CommitToVoteSet(chainID string, commit *Commit, vals *ValidatorSet) *VoteSe
 // GetVote converts the CommitSig for the given valIdx to a Vote.
 // Returns nil if the precommit at valIdx is nil.
 // Panics if valIdx >= commit.Size().
+// The signature of the vote is empty if the commit is aggregated.
 func (commit *Commit) GetVote(valIdx int32) *Vote {
 	commitSig := commit.Signatures[valIdx]
 	return &Vote{
@@ -882,11 +934,18 @@ func (commit *Commit) ValidateBasic() error {
 		if len(commit.Signatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		aggregated := commit.IsAggregated()
+		if aggregated && len(commit.AggregatedSignature) != bls12381.SignatureSize {
+			return fmt.Errorf("expected AggregatedSignature size to be %d bytes, got %d bytes",
+				bls12381.SignatureSize, len(commit.AggregatedSignature))
+		}
 		for i, commitSig := range commit.Signatures {
-			if err := commitSig.ValidateBasic(); err != nil {
+			if err := commitSig.validateBasic(aggregated); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %v", i, err)
 			}
 		}
+	} else if commit.IsAggregated() {
+		return errors.New("aggregated signature is present in an empty commit")
 	}
 	return nil
 }
@@ -907,6 +966,11 @@ func (commit *Commit) Hash() cmtbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		// The aggregated signature, replacing those of the commit sigs, is
+		// hashed last.
+		if commit.IsAggregated() {
+			bs = append(bs, commit.AggregatedSignature)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -927,12 +991,14 @@ func (commit *Commit) StringIndented(indent string) string {
 %s  BlockID:    %v
 %s  Signatures:
 %s    %v
+%s  AggregatedSignature: %X
 %s}#%v`,
 		indent, commit.Height,
 		indent, commit.Round,
 		indent, commit.BlockID,
 		indent,
 		indent, strings.Join(commitSigStrings, "\n"+indent+"    "),
+		indent, cmtbytes.Fingerprint(commit.AggregatedSignature),
 		indent, commit.hash)
 }
 
@@ -952,6 +1018,7 @@ func (commit *Commit) ToProto() *cmtproto.Commit {
 	c.Height = commit.Height
 	c.Round = commit.Round
 	c.BlockID = commit.BlockID.ToProto()
+	c.AggregatedSignature = commit.AggregatedSignature
 
 	return c
 }
@@ -972,9 +1039,10 @@ func CommitFromProto(cp *cmtproto.Commit) (*Commit, error) {
 		return nil, err
 	}
 
+	aggregated := len(cp.AggregatedSignature) != 0
 	sigs := make([]CommitSig, len(cp.Signatures))
 	for i := range cp.Signatures {
-		if err := sigs[i].FromProto(cp.Signatures[i]); err != nil {
+		if err := sigs[i].fromProto(cp.Signatures[i], aggregated); err != nil {
 			return nil, err
 		}
 	}
@@ -983,6 +1051,7 @@ func CommitFromProto(cp *cmtproto.Commit) (*Commit, error) {
 	commit.Height = cp.Height
 	commit.Round = cp.Round
 	commit.BlockID = *bi
+	commit.AggregatedSignature = cp.AggregatedSignature
 
 	return commit, commit.ValidateBasic()
 }
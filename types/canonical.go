@@ -1,6 +1,8 @@
 package types
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -12,6 +14,24 @@ import (
 // TimeFormat is used for generating the sigs
 const TimeFormat = time.RFC3339Nano
 
+// SignBytesVersion1 is the first version of the canonical sign bytes of votes
+// and proposals, the length-delimited protobuf encoding of CanonicalVote and
+// CanonicalProposal.
+const SignBytesVersion1 uint32 = 1
+
+// LatestSignBytesVersion is the latest version of the sign bytes supported.
+const LatestSignBytesVersion = SignBytesVersion1
+
+// ValidateSignBytesVersion returns an error if the given version of the sign
+// bytes isn't supported. Zero means SignBytesVersion1.
+func ValidateSignBytesVersion(version uint32) error {
+	if version > LatestSignBytesVersion {
+		return fmt.Errorf("unsupported sign bytes version %d, latest supported is %d",
+			version, LatestSignBytesVersion)
+	}
+	return nil
+}
+
 //-----------------------------------
 // Canonicalize the structs
 
@@ -33,12 +53,12 @@ func CanonicalizeBlockID(bid cmtproto.BlockID) *cmtproto.CanonicalBlockID {
 	return cbid
 }
 
-// CanonicalizeVote transforms the given PartSetHeader to a CanonicalPartSetHeader.
+// CanonicalizePartSetHeader transforms the given PartSetHeader to a CanonicalPartSetHeader.
 func CanonicalizePartSetHeader(psh cmtproto.PartSetHeader) cmtproto.CanonicalPartSetHeader {
 	return cmtproto.CanonicalPartSetHeader(psh)
 }
 
-// CanonicalizeVote transforms the given Proposal to a CanonicalProposal.
+// CanonicalizeProposal transforms the given Proposal to a CanonicalProposal.
 func CanonicalizeProposal(chainID string, proposal *cmtproto.Proposal) cmtproto.CanonicalProposal {
 	return cmtproto.CanonicalProposal{
 		Type:      cmtproto.ProposalType,
@@ -64,6 +84,34 @@ func CanonicalizeVote(chainID string, vote *cmtproto.Vote) cmtproto.CanonicalVot
 	}
 }
 
+// CanonicalVoteSignBytes returns the bytes signed for the given vote on the
+// given chain, the length-delimited protobuf encoding of its CanonicalVote, like
+// VoteSignBytes. External signers can test their encoding against it: unlike
+// VoteSignBytes, it returns an error instead of panicking on an invalid vote.
+func CanonicalVoteSignBytes(chainID string, vote *Vote) ([]byte, error) {
+	if vote == nil {
+		return nil, errors.New("nil vote")
+	}
+	if err := vote.BlockID.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("wrong BlockID: %w", err)
+	}
+	return VoteSignBytes(chainID, vote.ToProto()), nil
+}
+
+// CanonicalProposalSignBytes returns the bytes signed for the given proposal on
+// the given chain, the length-delimited protobuf encoding of its
+// CanonicalProposal, like ProposalSignBytes, but returns an error instead of
+// panicking on an invalid proposal.
+func CanonicalProposalSignBytes(chainID string, proposal *Proposal) ([]byte, error) {
+	if proposal == nil {
+		return nil, errors.New("nil proposal")
+	}
+	if err := proposal.BlockID.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("wrong BlockID: %w", err)
+	}
+	return ProposalSignBytes(chainID, proposal.ToProto()), nil
+}
+
 // CanonicalTime can be used to stringify time in a canonical way.
 func CanonicalTime(t time.Time) string {
 	// Note that sending time over amino resets it to
@@ -4,6 +4,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtrand "github.com/cometbft/cometbft/libs/rand"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -37,3 +40,29 @@ func TestCanonicalizeBlockID(t *testing.T) {
 		})
 	}
 }
+
+func TestCanonicalSignBytes(t *testing.T) {
+	const chainID = "test_chain_id"
+	vote := examplePrecommit()
+	proposal := NewProposal(4, 2, 2, makeBlockIDRandom())
+
+	bz, err := CanonicalVoteSignBytes(chainID, vote)
+	require.NoError(t, err)
+	assert.Equal(t, VoteSignBytes(chainID, vote.ToProto()), bz)
+	bz, err = CanonicalProposalSignBytes(chainID, proposal)
+	require.NoError(t, err)
+	assert.Equal(t, ProposalSignBytes(chainID, proposal.ToProto()), bz)
+
+	_, err = CanonicalVoteSignBytes(chainID, nil)
+	assert.Error(t, err)
+	_, err = CanonicalProposalSignBytes(chainID, nil)
+	assert.Error(t, err)
+
+	// VoteSignBytes and ProposalSignBytes panic on an invalid block ID.
+	vote.BlockID.Hash = []byte{0x01}
+	_, err = CanonicalVoteSignBytes(chainID, vote)
+	assert.Error(t, err)
+	proposal.BlockID.Hash = []byte{0x01}
+	_, err = CanonicalProposalSignBytes(chainID, proposal)
+	assert.Error(t, err)
+}
@@ -8,12 +8,20 @@ import (
 	"github.com/cometbft/cometbft/libs/log"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	"github.com/cometbft/cometbft/libs/service"
+	cmttime "github.com/cometbft/cometbft/types/time"
 )
 
 const defaultCapacity = 0
 
 type EventBusSubscriber interface {
 	Subscribe(ctx context.Context, subscriber string, query cmtpubsub.Query, outCapacity ...int) (Subscription, error)
+	SubscribeFrom(
+		ctx context.Context,
+		subscriber string,
+		query cmtpubsub.Query,
+		after uint64,
+		outCapacity ...int,
+	) (Subscription, error)
 	Unsubscribe(ctx context.Context, subscriber string, query cmtpubsub.Query) error
 	UnsubscribeAll(ctx context.Context, subscriber string) error
 
@@ -49,6 +57,23 @@ func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	return b
 }
 
+// NewEventBusWithReplayCapacity returns a new event bus keeping the given
+// number of the last published events, so that subscriptions can be resumed
+// with SubscribeFrom. The sequence numbers of the events start after the
+// current time in nanoseconds, so that they keep increasing across restarts,
+// and a client resuming from an event of a previous run gets
+// pubsub.ErrReplayUnavailable instead of the events of this run.
+func NewEventBusWithReplayCapacity(replayCap int) *EventBus {
+	pubsub := cmtpubsub.NewServer(
+		cmtpubsub.BufferCapacity(defaultCapacity),
+		cmtpubsub.ReplayCapacity(replayCap),
+		cmtpubsub.InitialSequence(uint64(cmttime.Now().UnixNano())),
+	)
+	b := &EventBus{pubsub: pubsub}
+	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
+	return b
+}
+
 func (b *EventBus) SetLogger(l log.Logger) {
 	b.BaseService.SetLogger(l)
 	b.pubsub.SetLogger(l.With("module", "pubsub"))
@@ -81,6 +106,19 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeFrom subscribes to the events matching the query, starting with
+// the ones published after the event with the given sequence number. See
+// pubsub.Server#SubscribeFrom.
+func (b *EventBus) SubscribeFrom(
+	ctx context.Context,
+	subscriber string,
+	query cmtpubsub.Query,
+	after uint64,
+	outCapacity ...int,
+) (Subscription, error) {
+	return b.pubsub.SubscribeFrom(ctx, subscriber, query, after, outCapacity...)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -109,7 +147,7 @@ func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 // map of stringified events where each key is composed of the event
 // type and each of the event's attributes keys in the form of
 // "{event.Type}.{attribute.Key}" and the value is each attribute's value.
-func (b *EventBus) validateAndStringifyEvents(events []types.Event, logger log.Logger) map[string][]string {
+func validateAndStringifyEvents(events []types.Event, logger log.Logger) map[string][]string {
 	result := make(map[string][]string)
 	for _, event := range events {
 		if len(event.Type) == 0 {
@@ -136,7 +174,7 @@ func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 	ctx := context.Background()
 
 	resultEvents := append(data.ResultBeginBlock.Events, data.ResultEndBlock.Events...)
-	events := b.validateAndStringifyEvents(resultEvents, b.Logger.With("block", data.Block.StringShort()))
+	events := validateAndStringifyEvents(resultEvents, b.Logger.With("block", data.Block.StringShort()))
 
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
@@ -150,7 +188,7 @@ func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) erro
 
 	resultTags := append(data.ResultBeginBlock.Events, data.ResultEndBlock.Events...)
 	// TODO: Create StringShort method for Header and use it in logger.
-	events := b.validateAndStringifyEvents(resultTags, b.Logger.With("header", data.Header))
+	events := validateAndStringifyEvents(resultTags, b.Logger.With("header", data.Header))
 
 	// add predefined new block header event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockHeader)
@@ -177,14 +215,26 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
 
-	events := b.validateAndStringifyEvents(data.Result.Events, b.Logger.With("tx", data.Tx))
+	events := txEvents(data, b.Logger.With("tx", data.Tx))
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// TxEvents returns the events a tx is published with, for matching queries
+// against the txs replayed from the stores like against the published ones.
+func TxEvents(data EventDataTx) map[string][]string {
+	return txEvents(data, log.NewNopLogger())
+}
+
+func txEvents(data EventDataTx, logger log.Logger) map[string][]string {
+	events := validateAndStringifyEvents(data.Result.Events, logger)
 
 	// add predefined compositeKeys
 	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
 	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
 	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return events
 }
 
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
@@ -227,6 +277,14 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventValidatorSetChange(data EventDataValidatorSetChange) error {
+	return b.Publish(EventValidatorSetChange, data)
+}
+
+func (b *EventBus) PublishEventApplyBlockFailure(data EventDataApplyBlockFailure) error {
+	return b.Publish(EventApplyBlockFailure, data)
+}
+
 // -----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -306,3 +364,11 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventValidatorSetChange(data EventDataValidatorSetChange) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventApplyBlockFailure(data EventDataApplyBlockFailure) error {
+	return nil
+}
@@ -20,7 +20,11 @@ const (
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventNewEvidence         = "NewEvidence"
 	EventTx                  = "Tx"
+	EventValidatorSetChange  = "ValidatorSetChange"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
+	// ApplyBlockFailure is fired when a block fails to be applied, e.g. upon
+	// an app hash mismatch, once its diagnostics bundle is written.
+	EventApplyBlockFailure = "ApplyBlockFailure"
 
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
@@ -55,6 +59,8 @@ func init() {
 	cmtjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
+	cmtjson.RegisterType(EventDataValidatorSetChange{}, "tendermint/event/ValidatorSetChange")
+	cmtjson.RegisterType(EventDataApplyBlockFailure{}, "tendermint/event/ApplyBlockFailure")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 }
 
@@ -125,6 +131,66 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataValidatorSetChange is the difference between the validator set
+// validating the blocks from Height on and the one validating the previous
+// block.
+type EventDataValidatorSetChange struct {
+	Height int64 `json:"height"`
+
+	Added        []*Validator           `json:"added"`
+	Removed      []*Validator           `json:"removed"`
+	PowerChanged []ValidatorPowerChange `json:"power_changed"`
+}
+
+// EventDataApplyBlockFailure is a block which failed to be applied, with the
+// directory of its diagnostics bundle.
+type EventDataApplyBlockFailure struct {
+	Height int64  `json:"height"`
+	Error  string `json:"error"`
+	Dir    string `json:"dir"`
+}
+
+// ValidatorPowerChange is a validator whose voting power changed.
+type ValidatorPowerChange struct {
+	Validator           *Validator `json:"validator"`
+	PreviousVotingPower int64      `json:"previous_voting_power"`
+}
+
+// NewEventDataValidatorSetChange returns the difference between the
+// validator sets prev and next, the latter validating the blocks from height
+// on. The validators are in the order of their set.
+func NewEventDataValidatorSetChange(height int64, prev, next *ValidatorSet) EventDataValidatorSetChange {
+	data := EventDataValidatorSetChange{Height: height}
+	prevVals := make(map[string]*Validator, prev.Size())
+	for _, val := range prev.Validators {
+		prevVals[string(val.Address)] = val
+	}
+	for _, val := range next.Validators {
+		prevVal, ok := prevVals[string(val.Address)]
+		switch {
+		case !ok:
+			data.Added = append(data.Added, val.Copy())
+		case prevVal.VotingPower != val.VotingPower:
+			data.PowerChanged = append(data.PowerChanged, ValidatorPowerChange{
+				Validator:           val.Copy(),
+				PreviousVotingPower: prevVal.VotingPower,
+			})
+		}
+		delete(prevVals, string(val.Address))
+	}
+	for _, val := range prev.Validators {
+		if _, ok := prevVals[string(val.Address)]; ok {
+			data.Removed = append(data.Removed, val.Copy())
+		}
+	}
+	return data
+}
+
+// IsEmpty returns true if the validator set did not change.
+func (data EventDataValidatorSetChange) IsEmpty() bool {
+	return len(data.Added) == 0 && len(data.Removed) == 0 && len(data.PowerChanged) == 0
+}
+
 // PUBSUB
 
 const (
@@ -143,6 +209,7 @@ const (
 )
 
 var (
+	EventQueryApplyBlockFailure   = QueryForEvent(EventApplyBlockFailure)
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
 	EventQueryLock                = QueryForEvent(EventLock)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
@@ -156,6 +223,7 @@ var (
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
 	EventQueryTx                  = QueryForEvent(EventTx)
 	EventQueryUnlock              = QueryForEvent(EventUnlock)
+	EventQueryValidatorSetChange  = QueryForEvent(EventValidatorSetChange)
 	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdates)
 	EventQueryValidBlock          = QueryForEvent(EventValidBlock)
 	EventQueryVote                = QueryForEvent(EventVote)
@@ -176,6 +244,8 @@ type BlockEventPublisher interface {
 	PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	PublishEventTx(EventDataTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
+	PublishEventValidatorSetChange(EventDataValidatorSetChange) error
+	PublishEventApplyBlockFailure(EventDataApplyBlockFailure) error
 }
 
 type TxEventPublisher interface {
@@ -25,3 +25,35 @@ func TestQueryForEvent(t *testing.T) {
 		QueryForEvent(EventNewEvidence).String(),
 	)
 }
+
+func TestNewEventDataValidatorSetChange(t *testing.T) {
+	prev := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 2),
+		newValidator([]byte("c"), 3),
+	})
+	next := prev.Copy()
+	err := next.UpdateWithChangeSet([]*Validator{
+		newValidator([]byte("a"), 0),
+		newValidator([]byte("b"), 5),
+		newValidator([]byte("d"), 4),
+	})
+	assert.NoError(t, err)
+
+	data := NewEventDataValidatorSetChange(3, prev, next)
+	assert.False(t, data.IsEmpty())
+	assert.EqualValues(t, 3, data.Height)
+	if assert.Len(t, data.Added, 1) {
+		assert.EqualValues(t, "d", data.Added[0].Address)
+	}
+	if assert.Len(t, data.Removed, 1) {
+		assert.EqualValues(t, "a", data.Removed[0].Address)
+	}
+	if assert.Len(t, data.PowerChanged, 1) {
+		assert.EqualValues(t, "b", data.PowerChanged[0].Validator.Address)
+		assert.EqualValues(t, 5, data.PowerChanged[0].Validator.VotingPower)
+		assert.EqualValues(t, 2, data.PowerChanged[0].PreviousVotingPower)
+	}
+
+	assert.True(t, NewEventDataValidatorSetChange(3, prev, prev.Copy()).IsEmpty())
+}
@@ -0,0 +1,52 @@
+package types_test
+
+import (
+	"fmt"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func ExampleCanonicalVoteSignBytes() {
+	hash := make([]byte, 32)
+	vote := &types.Vote{
+		Type:   cmtproto.PrecommitType,
+		Height: 12345,
+		Round:  2,
+		BlockID: types.BlockID{
+			Hash:          hash,
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: hash},
+		},
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+	signBytes, err := types.CanonicalVoteSignBytes("test-chain", vote)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%x\n", signBytes)
+	// Output:
+	// 74080211393000000000000019020000000000000022480a20000000000000000000000000000000000000000000000000000000000000000012240801122000000000000000000000000000000000000000000000000000000000000000002a0808a593c99d061006320a746573742d636861696e
+}
+
+func ExampleCanonicalProposalSignBytes() {
+	hash := make([]byte, 32)
+	proposal := &types.Proposal{
+		Type:     cmtproto.ProposalType,
+		Height:   12345,
+		Round:    2,
+		POLRound: -1,
+		BlockID: types.BlockID{
+			Hash:          hash,
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: hash},
+		},
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+	signBytes, err := types.CanonicalProposalSignBytes("test-chain", proposal)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%x\n", signBytes)
+	// Output:
+	// 7f082011393000000000000019020000000000000020ffffffffffffffffff012a480a2000000000000000000000000000000000000000000000000000000000000000001224080112200000000000000000000000000000000000000000000000000000000000000000320808a593c99d0610063a0a746573742d636861696e
+}
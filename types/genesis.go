@@ -1,14 +1,19 @@
 package types
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -34,6 +39,14 @@ type GenesisValidator struct {
 	Name    string        `json:"name"`
 }
 
+// GenesisChunk is a file holding a part of the app_state of a chunked genesis
+// document.
+type GenesisChunk struct {
+	// path of the file, relative to the directory of the genesis file
+	File string            `json:"file"`
+	Hash cmtbytes.HexBytes `json:"hash"`
+}
+
 // GenesisDoc defines the initial conditions for a CometBFT blockchain, in particular its validator set.
 type GenesisDoc struct {
 	GenesisTime     time.Time          `json:"genesis_time"`
@@ -43,6 +56,10 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator `json:"validators,omitempty"`
 	AppHash         cmtbytes.HexBytes  `json:"app_hash"`
 	AppState        json.RawMessage    `json:"app_state,omitempty"`
+	// Files holding the app_state, in order, instead of AppState, so that a
+	// large one is read into a single buffer instead of being decoded from
+	// JSON. GenesisDocFromFile reads them into AppState.
+	AppStateChunks []GenesisChunk `json:"app_state_chunks,omitempty"`
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -54,6 +71,73 @@ func (genDoc *GenesisDoc) SaveAs(file string) error {
 	return cmtos.WriteFile(file, genDocBytes, 0644)
 }
 
+// SaveAsChunks saves the GenesisDoc as a JSON file, with its app_state split
+// into files of at most chunkSize bytes next to it, listed in app_state_chunks.
+func (genDoc *GenesisDoc) SaveAsChunks(file string, chunkSize int) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("invalid chunk size %d", chunkSize)
+	}
+	if len(genDoc.AppStateChunks) > 0 {
+		return errors.New("the app_state is already chunked")
+	}
+
+	prefix := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) + ".app_state."
+	chunked := *genDoc
+	chunked.AppState = nil
+	for i := 0; i*chunkSize < len(genDoc.AppState); i++ {
+		chunk := genDoc.AppState[i*chunkSize : min((i+1)*chunkSize, len(genDoc.AppState))]
+		name := fmt.Sprintf("%s%03d", prefix, i)
+		if err := cmtos.WriteFile(filepath.Join(filepath.Dir(file), name), chunk, 0644); err != nil {
+			return err
+		}
+		chunked.AppStateChunks = append(chunked.AppStateChunks, GenesisChunk{File: name, Hash: tmhash.Sum(chunk)})
+	}
+	return chunked.SaveAs(file)
+}
+
+// Hash returns the hash of the GenesisDoc: the SHA-256 of its JSON encoding
+// without app_state, followed by the app_state without whitespace, which is
+// hashed without being copied. It doesn't depend on the formatting of the
+// genesis file, nor on its app_state being chunked once read with
+// GenesisDocFromFile.
+func (genDoc *GenesisDoc) Hash() ([]byte, error) {
+	doc := *genDoc
+	doc.AppState = nil
+	bz, err := cmtjson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	h := tmhash.New()
+	h.Write(bz)
+	writeCompactJSON(h, genDoc.AppState)
+	return h.Sum(nil), nil
+}
+
+// writeCompactJSON writes the valid JSON value bz to w without the whitespace
+// outside of its strings.
+func writeCompactJSON(w io.Writer, bz []byte) {
+	inString, escaped := false, false
+	start := 0
+	for i, c := range bz {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && (c == ' ' || c == '\t' || c == '\n' || c == '\r'):
+			if start < i {
+				w.Write(bz[start:i]) //nolint:errcheck // hashes don't return errors
+			}
+			start = i + 1
+		}
+	}
+	if start < len(bz) {
+		w.Write(bz[start:]) //nolint:errcheck
+	}
+}
+
 // ValidatorHash returns the hash of the validator set contained in the GenesisDoc
 func (genDoc *GenesisDoc) ValidatorHash() []byte {
 	vals := make([]*Validator, len(genDoc.Validators))
@@ -80,6 +164,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		genDoc.InitialHeight = 1
 	}
 
+	if len(genDoc.AppState) > 0 && len(genDoc.AppStateChunks) > 0 {
+		return errors.New("genesis doc can't include both app_state and app_state_chunks")
+	}
+
 	if genDoc.ConsensusParams == nil {
 		genDoc.ConsensusParams = DefaultConsensusParams()
 	} else if err := genDoc.ConsensusParams.ValidateBasic(); err != nil {
@@ -96,6 +184,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if len(v.Address) == 0 {
 			genDoc.Validators[i].Address = v.PubKey.Address()
 		}
+		if !IsValidPubkeyType(genDoc.ConsensusParams.Validator, v.PubKey.Type()) {
+			return fmt.Errorf("validator %v in the genesis file uses the key type %s, not allowed by the consensus params (%v)",
+				v, v.PubKey.Type(), genDoc.ConsensusParams.Validator.PubKeyTypes)
+		}
 	}
 
 	if genDoc.GenesisTime.IsZero() {
@@ -110,28 +202,131 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 
 // GenesisDocFromJSON unmarshalls JSON data into a GenesisDoc.
 func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
-	genDoc := GenesisDoc{}
-	err := cmtjson.Unmarshal(jsonBlob, &genDoc)
+	return GenesisDocFromReader(bytes.NewReader(jsonBlob))
+}
+
+// GenesisDocFromReader decodes a GenesisDoc from the JSON document read from
+// r, without holding the whole document in memory. The fields other than
+// app_state are decoded and validated as usual, while the app_state is only
+// checked to be valid JSON, and copied once from the decoder.
+func GenesisDocFromReader(r io.Reader) (*GenesisDoc, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, errors.New("genesis doc must be a JSON object")
+	}
+
+	fields := make(map[string]json.RawMessage)
+	var appState json.RawMessage
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %v in genesis doc", tok)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", key, err)
+		}
+		if key == "app_state" {
+			appState = value
+		} else {
+			fields[key] = value
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the genesis doc")
+	}
+
+	// the other fields are small
+	bz, err := json.Marshal(fields)
 	if err != nil {
 		return nil, err
 	}
+	genDoc := GenesisDoc{}
+	if err := cmtjson.Unmarshal(bz, &genDoc); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(appState, []byte("null")) {
+		genDoc.AppState = appState
+	}
 
 	if err := genDoc.ValidateAndComplete(); err != nil {
 		return nil, err
 	}
-
-	return &genDoc, err
+	return &genDoc, nil
 }
 
 // GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
-	jsonBlob, err := os.ReadFile(genDocFile)
+	f, err := os.Open(genDocFile)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
 	}
-	genDoc, err := GenesisDocFromJSON(jsonBlob)
+	defer f.Close()
+	genDoc, err := GenesisDocFromReader(bufio.NewReader(f))
 	if err != nil {
 		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
 	}
+	if len(genDoc.AppStateChunks) > 0 {
+		if err := genDoc.readAppStateChunks(filepath.Dir(genDocFile)); err != nil {
+			return nil, fmt.Errorf("error reading the app_state of GenesisDoc at %s: %w", genDocFile, err)
+		}
+	}
 	return genDoc, nil
 }
+
+// readAppStateChunks reads the app_state chunks in dir into AppState,
+// checking their hashes.
+func (genDoc *GenesisDoc) readAppStateChunks(dir string) error {
+	size := int64(0)
+	for _, chunk := range genDoc.AppStateChunks {
+		if !filepath.IsLocal(chunk.File) {
+			return fmt.Errorf("chunk %s is not in the directory of the genesis file", chunk.File)
+		}
+		fi, err := os.Stat(filepath.Join(dir, chunk.File))
+		if err != nil {
+			return err
+		}
+		size += fi.Size()
+	}
+
+	appState := make([]byte, size)
+	offset := int64(0)
+	for _, chunk := range genDoc.AppStateChunks {
+		f, err := os.Open(filepath.Join(dir, chunk.File))
+		if err != nil {
+			return err
+		}
+		fi, err := f.Stat()
+		if err == nil && offset+fi.Size() > size {
+			err = fmt.Errorf("chunk %s changed while being read", chunk.File)
+		}
+		if err == nil {
+			_, err = io.ReadFull(f, appState[offset:offset+fi.Size()])
+		}
+		f.Close()
+		if err != nil {
+			return err
+		}
+		if hash := tmhash.Sum(appState[offset : offset+fi.Size()]); !bytes.Equal(hash, chunk.Hash) {
+			return fmt.Errorf("wrong hash of chunk %s, expected %X, got %X", chunk.File, chunk.Hash, hash)
+		}
+		offset += fi.Size()
+	}
+	appState = appState[:offset]
+	if !json.Valid(appState) {
+		return errors.New("the app_state in the chunks is not valid JSON")
+	}
+
+	genDoc.AppState = appState
+	genDoc.AppStateChunks = nil
+	return nil
+}
@@ -2,12 +2,14 @@ package types
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmttime "github.com/cometbft/cometbft/types/time"
 )
@@ -48,6 +50,9 @@ func TestGenesisBad(t *testing.T) {
 				`},"power":"10","name":""}` +
 				`]}`,
 		),
+		[]byte(`{"chain_id":"mychain","app_state":{"a":}}`),   // invalid app_state
+		[]byte(`{"chain_id":"mychain","app_state":{}} {}`),    // trailing data
+		[]byte(`{"chain_id":"mychain","app_state":{"a":"b"}`), // truncated
 	}
 
 	for _, testCase := range testCases {
@@ -120,6 +125,21 @@ func TestGenesisGood(t *testing.T) {
 	}
 }
 
+func TestGenesisValidatorKeyType(t *testing.T) {
+	pubKey := secp256k1.GenPrivKey().PubKey()
+	genDoc := &GenesisDoc{
+		ChainID:    "abc",
+		Validators: []GenesisValidator{{PubKey: pubKey, Power: 10}},
+	}
+	// The default consensus params only allow ed25519 keys.
+	assert.Error(t, genDoc.ValidateAndComplete())
+
+	genDoc.ConsensusParams = DefaultConsensusParams()
+	genDoc.ConsensusParams.Validator.PubKeyTypes = []string{ABCIPubKeyTypeEd25519, ABCIPubKeyTypeSecp256k1}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	assert.Equal(t, pubKey.Address(), genDoc.Validators[0].Address)
+}
+
 func TestGenesisSaveAs(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "genesis")
 	require.NoError(t, err)
@@ -146,6 +166,63 @@ func TestGenesisSaveAs(t *testing.T) {
 	assert.Equal(t, genDoc2.Validators, genDoc.Validators)
 }
 
+func TestGenesisSaveAsChunks(t *testing.T) {
+	dir := t.TempDir()
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = []byte(`{"accounts": [{"address": "a", "balance": 100}, {"address": "b", "balance": 200}]}`)
+	require.NoError(t, genDoc.ValidateAndComplete())
+	hash, err := genDoc.Hash()
+	require.NoError(t, err)
+
+	file := filepath.Join(dir, "genesis.json")
+	require.NoError(t, genDoc.SaveAsChunks(file, 32))
+	chunked, err := GenesisDocFromFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, genDoc, chunked)
+	chunkedHash, err := chunked.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, chunkedHash)
+
+	// the hash doesn't depend on the formatting
+	require.NoError(t, genDoc.SaveAs(file))
+	merged, err := GenesisDocFromFile(file)
+	require.NoError(t, err)
+	mergedHash, err := merged.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, mergedHash)
+
+	// a modified chunk is rejected
+	require.NoError(t, genDoc.SaveAsChunks(file, 32))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "genesis.app_state.001"), make([]byte, 32), 0o644))
+	_, err = GenesisDocFromFile(file)
+	require.ErrorContains(t, err, "wrong hash of chunk genesis.app_state.001")
+
+	// chunks can't be outside of the directory of the genesis file
+	genDoc.AppState = nil
+	genDoc.AppStateChunks = []GenesisChunk{{File: "../app_state"}}
+	require.NoError(t, genDoc.SaveAs(file))
+	_, err = GenesisDocFromFile(file)
+	require.Error(t, err)
+}
+
+func TestGenesisHash(t *testing.T) {
+	hash := func(appState string) []byte {
+		genDoc, err := GenesisDocFromJSON([]byte(`{"chain_id":"mychain","genesis_time":"2020-01-01T00:00:00Z",` +
+			`"app_state":` + appState + `}`))
+		require.NoError(t, err)
+		h, err := genDoc.Hash()
+		require.NoError(t, err)
+		return h
+	}
+
+	h := hash(`{"accounts":[{"name":"a b","escaped":"\" \\"}]}`)
+	assert.Equal(t, h, hash(`{ "accounts": [
+		{"name": "a b", "escaped": "\" \\"}
+	] }`))
+	assert.NotEqual(t, h, hash(`{"accounts":[{"name":"ab","escaped":"\" \\"}]}`))
+	assert.NotEqual(t, h, hash(`{"accounts":[{"name":"a b","escaped":"\"\\"}]}`))
+}
+
 func TestGenesisValidatorHash(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	assert.NotEmpty(t, genDoc.ValidatorHash())
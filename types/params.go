@@ -3,8 +3,10 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	"github.com/cometbft/cometbft/crypto/tmhash"
@@ -23,11 +25,13 @@ const (
 
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
+	ABCIPubKeyTypeBls12381  = bls12381.KeyType
 )
 
 var ABCIPubKeyTypesToNames = map[string]string{
 	ABCIPubKeyTypeEd25519:   ed25519.PubKeyName,
 	ABCIPubKeyTypeSecp256k1: secp256k1.PubKeyName,
+	ABCIPubKeyTypeBls12381:  bls12381.PubKeyName,
 }
 
 // ConsensusParams contains consensus critical parameters that determine the
@@ -37,6 +41,9 @@ type ConsensusParams struct {
 	Evidence  EvidenceParams  `json:"evidence"`
 	Validator ValidatorParams `json:"validator"`
 	Version   VersionParams   `json:"version"`
+	Timeout   TimeoutParams   `json:"timeout"`
+	Synchrony SynchronyParams `json:"synchrony"`
+	Feature   FeatureParams   `json:"feature"`
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
@@ -59,8 +66,47 @@ type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
 }
 
+// VersionParams contain the ABCI application version, and the version of the
+// canonical sign bytes of votes and proposals, zero meaning SignBytesVersion1.
 type VersionParams struct {
-	App uint64 `json:"app"`
+	App       uint64 `json:"app"`
+	SignBytes uint32 `json:"sign_bytes"`
+}
+
+// TimeoutParams configure the timeouts of the consensus algorithm. A zero
+// timeout means that the node's local configuration is used instead, and a
+// delta is only taken into account if the timeout it applies to is set.
+//
+// PrepareProposal and ProcessProposal bound the durations of the calls to the
+// application: once exceeded, the proposer proposes a block without
+// transactions, and the validators reject the proposal. Zero means no limit.
+// They are node-local liveness heuristics, not deterministic: their outcome
+// depends on the speed of each node, so that the validators may disagree on a
+// proposal. They must be less than Propose, if set.
+type TimeoutParams struct {
+	Propose         time.Duration `json:"propose"`
+	ProposeDelta    time.Duration `json:"propose_delta"`
+	Prevote         time.Duration `json:"prevote"`
+	PrevoteDelta    time.Duration `json:"prevote_delta"`
+	Precommit       time.Duration `json:"precommit"`
+	PrecommitDelta  time.Duration `json:"precommit_delta"`
+	Commit          time.Duration `json:"commit"`
+	PrepareProposal time.Duration `json:"prepare_proposal"`
+	ProcessProposal time.Duration `json:"process_proposal"`
+}
+
+// SynchronyParams configure the bounds under which a proposal is considered
+// timely, when proposer-based timestamps (PBTS) are enabled.
+type SynchronyParams struct {
+	Precision    time.Duration `json:"precision"`
+	MessageDelay time.Duration `json:"message_delay"`
+}
+
+// FeatureParams configure the heights at which optional consensus features
+// are enabled. A zero height means that the feature is disabled.
+type FeatureParams struct {
+	PbtsEnableHeight                int64 `json:"pbts_enable_height"`
+	BlsAggregatedCommitEnableHeight int64 `json:"bls_aggregated_commit_enable_height"`
 }
 
 // DefaultConsensusParams returns a default ConsensusParams.
@@ -70,6 +116,9 @@ func DefaultConsensusParams() *ConsensusParams {
 		Evidence:  DefaultEvidenceParams(),
 		Validator: DefaultValidatorParams(),
 		Version:   DefaultVersionParams(),
+		Timeout:   DefaultTimeoutParams(),
+		Synchrony: DefaultSynchronyParams(),
+		Feature:   DefaultFeatureParams(),
 	}
 }
 
@@ -100,10 +149,70 @@ func DefaultValidatorParams() ValidatorParams {
 
 func DefaultVersionParams() VersionParams {
 	return VersionParams{
-		App: 0,
+		App:       0,
+		SignBytes: SignBytesVersion1,
 	}
 }
 
+// DefaultTimeoutParams returns a default TimeoutParams, which leaves all the
+// timeouts to the local configuration.
+func DefaultTimeoutParams() TimeoutParams {
+	return TimeoutParams{}
+}
+
+// DefaultSynchronyParams returns a default SynchronyParams.
+func DefaultSynchronyParams() SynchronyParams {
+	return SynchronyParams{
+		Precision:    505 * time.Millisecond,
+		MessageDelay: 15 * time.Second,
+	}
+}
+
+// DefaultFeatureParams returns a default FeatureParams, which disables all
+// optional features.
+func DefaultFeatureParams() FeatureParams {
+	return FeatureParams{}
+}
+
+// InRound returns the synchrony params to use in the given round. The message
+// delay is increased by 10% every round, so that a network whose message
+// delay is set too low can still make progress.
+func (sp SynchronyParams) InRound(round int32) SynchronyParams {
+	if round <= 0 {
+		return sp
+	}
+	delay := float64(sp.MessageDelay) * math.Pow(1.1, float64(round))
+	if delay > float64(math.MaxInt64) {
+		delay = float64(math.MaxInt64)
+	}
+	return SynchronyParams{
+		Precision:    sp.Precision,
+		MessageDelay: time.Duration(delay),
+	}
+}
+
+// PbtsEnabled returns true if proposer-based timestamps are used at the given
+// height.
+func (fp FeatureParams) PbtsEnabled(height int64) bool {
+	return fp.PbtsEnableHeight > 0 && height >= fp.PbtsEnableHeight
+}
+
+// BlsAggregatedCommitEnabled returns true if the last commit of the block at
+// the given height aggregates the signatures of the validators, provided that
+// they all have BLS12-381 keys.
+func (fp FeatureParams) BlsAggregatedCommitEnabled(height int64) bool {
+	return fp.BlsAggregatedCommitEnableHeight > 0 && height >= fp.BlsAggregatedCommitEnableHeight
+}
+
+// SignBytesVersion returns the version of the sign bytes of votes and
+// proposals in use.
+func (vp VersionParams) SignBytesVersion() uint32 {
+	if vp.SignBytes == 0 {
+		return SignBytesVersion1
+	}
+	return vp.SignBytes
+}
+
 func IsValidPubkeyType(params ValidatorParams, pubkeyType string) bool {
 	for i := 0; i < len(params.PubKeyTypes); i++ {
 		if params.PubKeyTypes[i] == pubkeyType {
@@ -163,6 +272,101 @@ func (params ConsensusParams) ValidateBasic() error {
 		}
 	}
 
+	if err := ValidateSignBytesVersion(params.Version.SignBytes); err != nil {
+		return fmt.Errorf("version.SignBytes: %w", err)
+	}
+
+	if params.Feature.PbtsEnableHeight < 0 {
+		return fmt.Errorf("feature.PbtsEnableHeight must be non negative. Got: %d",
+			params.Feature.PbtsEnableHeight)
+	}
+
+	if params.Feature.BlsAggregatedCommitEnableHeight < 0 {
+		return fmt.Errorf("feature.BlsAggregatedCommitEnableHeight must be non negative. Got: %d",
+			params.Feature.BlsAggregatedCommitEnableHeight)
+	}
+
+	if params.Feature.PbtsEnableHeight > 0 {
+		if params.Synchrony.Precision <= 0 {
+			return fmt.Errorf("synchrony.Precision must be greater than 0 when PBTS is enabled. Got: %v",
+				params.Synchrony.Precision)
+		}
+		if params.Synchrony.MessageDelay <= 0 {
+			return fmt.Errorf("synchrony.MessageDelay must be greater than 0 when PBTS is enabled. Got: %v",
+				params.Synchrony.MessageDelay)
+		}
+	}
+
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"Propose", params.Timeout.Propose},
+		{"ProposeDelta", params.Timeout.ProposeDelta},
+		{"Prevote", params.Timeout.Prevote},
+		{"PrevoteDelta", params.Timeout.PrevoteDelta},
+		{"Precommit", params.Timeout.Precommit},
+		{"PrecommitDelta", params.Timeout.PrecommitDelta},
+		{"Commit", params.Timeout.Commit},
+		{"PrepareProposal", params.Timeout.PrepareProposal},
+		{"ProcessProposal", params.Timeout.ProcessProposal},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value < 0 {
+			return fmt.Errorf("timeout.%s must be non negative. Got: %v",
+				timeout.name, timeout.value)
+		}
+	}
+	// the proposal must be made, and processed, before the validators give up
+	// on it
+	if params.Timeout.Propose > 0 {
+		for _, timeout := range timeouts[len(timeouts)-2:] {
+			if timeout.value >= params.Timeout.Propose {
+				return fmt.Errorf("timeout.%s must be less than timeout.Propose (%v). Got: %v",
+					timeout.name, params.Timeout.Propose, timeout.value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateUpdate validates the updates returned by the application at the
+// given height against the current params. The sign bytes version cannot be
+// lowered. PBTS and aggregated commits can only be scheduled for a future
+// height, and cannot be disabled or rescheduled once they are enabled.
+func (params ConsensusParams) ValidateUpdate(updated *cmtproto.ConsensusParams, height int64) error {
+	if updated == nil {
+		return nil
+	}
+	if updated.Version != nil && updated.Version.SignBytes != 0 &&
+		updated.Version.SignBytes < params.Version.SignBytesVersion() {
+		return fmt.Errorf("the sign bytes version cannot be lowered from %d to %d",
+			params.Version.SignBytesVersion(), updated.Version.SignBytes)
+	}
+	if updated.Feature == nil {
+		return nil
+	}
+
+	features := []struct {
+		name          string
+		current, next int64
+	}{
+		{"PBTS", params.Feature.PbtsEnableHeight, updated.Feature.PbtsEnableHeight},
+		{"BLS aggregated commits", params.Feature.BlsAggregatedCommitEnableHeight,
+			updated.Feature.BlsAggregatedCommitEnableHeight},
+	}
+	for _, f := range features {
+		if f.current == f.next {
+			continue
+		}
+		if f.current > 0 && height >= f.current {
+			return fmt.Errorf("%s was enabled at height %d and cannot be changed to %d", f.name, f.current, f.next)
+		}
+		if f.next > 0 && f.next <= height {
+			return fmt.Errorf("%s can only be enabled at a future height, got %d at height %d", f.name, f.next, height)
+		}
+	}
 	return nil
 }
 
@@ -216,6 +420,30 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 	}
 	if params2.Version != nil {
 		res.Version.App = params2.Version.App
+		// Zero leaves the version unchanged, for the applications which only
+		// update their own version.
+		if params2.Version.SignBytes != 0 {
+			res.Version.SignBytes = params2.Version.SignBytes
+		}
+	}
+	if params2.Timeout != nil {
+		res.Timeout.Propose = params2.Timeout.Propose
+		res.Timeout.ProposeDelta = params2.Timeout.ProposeDelta
+		res.Timeout.Prevote = params2.Timeout.Prevote
+		res.Timeout.PrevoteDelta = params2.Timeout.PrevoteDelta
+		res.Timeout.Precommit = params2.Timeout.Precommit
+		res.Timeout.PrecommitDelta = params2.Timeout.PrecommitDelta
+		res.Timeout.Commit = params2.Timeout.Commit
+		res.Timeout.PrepareProposal = params2.Timeout.PrepareProposal
+		res.Timeout.ProcessProposal = params2.Timeout.ProcessProposal
+	}
+	if params2.Synchrony != nil {
+		res.Synchrony.Precision = params2.Synchrony.Precision
+		res.Synchrony.MessageDelay = params2.Synchrony.MessageDelay
+	}
+	if params2.Feature != nil {
+		res.Feature.PbtsEnableHeight = params2.Feature.PbtsEnableHeight
+		res.Feature.BlsAggregatedCommitEnableHeight = params2.Feature.BlsAggregatedCommitEnableHeight
 	}
 	return res
 }
@@ -235,13 +463,33 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 			PubKeyTypes: params.Validator.PubKeyTypes,
 		},
 		Version: &cmtproto.VersionParams{
-			App: params.Version.App,
+			App:       params.Version.App,
+			SignBytes: params.Version.SignBytes,
+		},
+		Timeout: &cmtproto.TimeoutParams{
+			Propose:         params.Timeout.Propose,
+			ProposeDelta:    params.Timeout.ProposeDelta,
+			Prevote:         params.Timeout.Prevote,
+			PrevoteDelta:    params.Timeout.PrevoteDelta,
+			Precommit:       params.Timeout.Precommit,
+			PrecommitDelta:  params.Timeout.PrecommitDelta,
+			Commit:          params.Timeout.Commit,
+			PrepareProposal: params.Timeout.PrepareProposal,
+			ProcessProposal: params.Timeout.ProcessProposal,
+		},
+		Synchrony: &cmtproto.SynchronyParams{
+			Precision:    params.Synchrony.Precision,
+			MessageDelay: params.Synchrony.MessageDelay,
+		},
+		Feature: &cmtproto.FeatureParams{
+			PbtsEnableHeight:                params.Feature.PbtsEnableHeight,
+			BlsAggregatedCommitEnableHeight: params.Feature.BlsAggregatedCommitEnableHeight,
 		},
 	}
 }
 
 func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams {
-	return ConsensusParams{
+	c := ConsensusParams{
 		Block: BlockParams{
 			MaxBytes: pbParams.Block.MaxBytes,
 			MaxGas:   pbParams.Block.MaxGas,
@@ -255,7 +503,37 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 			PubKeyTypes: pbParams.Validator.PubKeyTypes,
 		},
 		Version: VersionParams{
-			App: pbParams.Version.App,
+			App:       pbParams.Version.App,
+			SignBytes: pbParams.Version.SignBytes,
 		},
 	}
+	// params stored before these were introduced do not have them
+	if pbParams.Timeout != nil {
+		c.Timeout = TimeoutParams{
+			Propose:         pbParams.Timeout.Propose,
+			ProposeDelta:    pbParams.Timeout.ProposeDelta,
+			Prevote:         pbParams.Timeout.Prevote,
+			PrevoteDelta:    pbParams.Timeout.PrevoteDelta,
+			Precommit:       pbParams.Timeout.Precommit,
+			PrecommitDelta:  pbParams.Timeout.PrecommitDelta,
+			Commit:          pbParams.Timeout.Commit,
+			PrepareProposal: pbParams.Timeout.PrepareProposal,
+			ProcessProposal: pbParams.Timeout.ProcessProposal,
+		}
+	}
+	if pbParams.Synchrony != nil {
+		c.Synchrony = SynchronyParams{
+			Precision:    pbParams.Synchrony.Precision,
+			MessageDelay: pbParams.Synchrony.MessageDelay,
+		}
+	} else {
+		c.Synchrony = DefaultSynchronyParams()
+	}
+	if pbParams.Feature != nil {
+		c.Feature = FeatureParams{
+			PbtsEnableHeight:                pbParams.Feature.PbtsEnableHeight,
+			BlsAggregatedCommitEnableHeight: pbParams.Feature.BlsAggregatedCommitEnableHeight,
+		}
+	}
+	return c
 }
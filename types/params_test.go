@@ -146,6 +146,67 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	assert.EqualValues(t, 1, updated.Version.App)
 }
 
+func TestConsensusParamsUpdate_SignBytesVersion(t *testing.T) {
+	params := DefaultConsensusParams()
+	assert.Equal(t, SignBytesVersion1, params.Version.SignBytesVersion())
+
+	// An update of the app version only leaves the sign bytes version as is.
+	updated := params.Update(&cmtproto.ConsensusParams{Version: &cmtproto.VersionParams{App: 1}})
+	assert.Equal(t, SignBytesVersion1, updated.Version.SignBytes)
+
+	updated = params.Update(&cmtproto.ConsensusParams{
+		Version: &cmtproto.VersionParams{SignBytes: LatestSignBytesVersion + 1}})
+	assert.Error(t, updated.ValidateBasic())
+
+	// params stored without a sign bytes version use the first one
+	params.Version.SignBytes = 0
+	assert.NoError(t, params.ValidateBasic())
+	assert.Equal(t, SignBytesVersion1, params.Version.SignBytesVersion())
+}
+
+func TestConsensusParamsUpdate_Timeout(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+	assert.Equal(t, DefaultTimeoutParams(), params.Timeout)
+
+	updated := params.Update(&cmtproto.ConsensusParams{
+		Timeout: &cmtproto.TimeoutParams{
+			Propose:      2 * time.Second,
+			ProposeDelta: 500 * time.Millisecond,
+			Commit:       time.Second,
+
+			PrepareProposal: 200 * time.Millisecond,
+			ProcessProposal: 300 * time.Millisecond,
+		},
+	})
+	assert.Equal(t, 2*time.Second, updated.Timeout.Propose)
+	assert.Equal(t, 500*time.Millisecond, updated.Timeout.ProposeDelta)
+	assert.Zero(t, updated.Timeout.Prevote)
+	assert.Equal(t, time.Second, updated.Timeout.Commit)
+	assert.Equal(t, 200*time.Millisecond, updated.Timeout.PrepareProposal)
+	assert.Equal(t, 300*time.Millisecond, updated.Timeout.ProcessProposal)
+	assert.NoError(t, updated.ValidateBasic())
+
+	updated.Timeout.Precommit = -time.Second
+	assert.Error(t, updated.ValidateBasic())
+	updated.Timeout.Precommit = 0
+	updated.Timeout.ProcessProposal = -time.Second
+	assert.Error(t, updated.ValidateBasic())
+
+	// the proposal calls are bounded by the propose timeout
+	updated.Timeout.ProcessProposal = 2 * time.Second
+	assert.Error(t, updated.ValidateBasic())
+	updated.Timeout.ProcessProposal = 0
+	updated.Timeout.PrepareProposal = 3 * time.Second
+	assert.Error(t, updated.ValidateBasic())
+	updated.Timeout.Propose = 0
+	assert.NoError(t, updated.ValidateBasic())
+
+	// params stored without timeouts decode to the default
+	pbParams := params.ToProto()
+	pbParams.Timeout = nil
+	assert.Equal(t, params, ConsensusParamsFromProto(pbParams))
+}
+
 func TestProto(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519),
@@ -167,3 +228,77 @@ func TestProto(t *testing.T) {
 
 	}
 }
+
+func TestSynchronyParamsInRound(t *testing.T) {
+	sp := SynchronyParams{Precision: time.Second, MessageDelay: 10 * time.Second}
+	assert.Equal(t, sp, sp.InRound(0))
+
+	r1 := sp.InRound(1)
+	assert.Equal(t, time.Second, r1.Precision)
+	assert.Equal(t, 11*time.Second, r1.MessageDelay)
+	assert.Greater(t, sp.InRound(10).MessageDelay, r1.MessageDelay)
+}
+
+func TestConsensusParamsValidateUpdate(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+	enabled := params
+	enabled.Feature.PbtsEnableHeight = 10
+	aggregated := params
+	aggregated.Feature.BlsAggregatedCommitEnableHeight = 10
+	signBytesV2 := params
+	signBytesV2.Version.SignBytes = 2
+
+	testCases := []struct {
+		name    string
+		params  ConsensusParams
+		updates *cmtproto.ConsensusParams
+		height  int64
+		expErr  bool
+	}{
+		{"no updates", params, nil, 5, false},
+		{"no feature updates", params, &cmtproto.ConsensusParams{}, 5, false},
+		{"enable at a future height", params,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{PbtsEnableHeight: 6}}, 5, false},
+		{"enable at the current height", params,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{PbtsEnableHeight: 5}}, 5, true},
+		{"reschedule before enabled", enabled,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{PbtsEnableHeight: 20}}, 5, false},
+		{"cancel before enabled", enabled,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{PbtsEnableHeight: 0}}, 5, false},
+		{"unchanged once enabled", enabled,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{PbtsEnableHeight: 10}}, 15, false},
+		{"disable once enabled", enabled,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{PbtsEnableHeight: 0}}, 15, true},
+		{"enable aggregated commits at a future height", params,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{BlsAggregatedCommitEnableHeight: 6}}, 5, false},
+		{"enable aggregated commits at the current height", params,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{BlsAggregatedCommitEnableHeight: 5}}, 5, true},
+		{"disable aggregated commits once enabled", aggregated,
+			&cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{}}, 15, true},
+		{"raise the sign bytes version", params,
+			&cmtproto.ConsensusParams{Version: &cmtproto.VersionParams{SignBytes: 2}}, 5, false},
+		{"keep the sign bytes version", signBytesV2,
+			&cmtproto.ConsensusParams{Version: &cmtproto.VersionParams{App: 1}}, 5, false},
+		{"lower the sign bytes version", signBytesV2,
+			&cmtproto.ConsensusParams{Version: &cmtproto.VersionParams{SignBytes: 1}}, 5, true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.params.ValidateUpdate(tc.updates, tc.height)
+		if tc.expErr {
+			assert.Error(t, err, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+	}
+
+	// PBTS requires synchrony params
+	assert.Error(t, enabled.ValidateBasic())
+	enabled.Synchrony = DefaultSynchronyParams()
+	assert.NoError(t, enabled.ValidateBasic())
+
+	assert.True(t, aggregated.Feature.BlsAggregatedCommitEnabled(10))
+	assert.False(t, aggregated.Feature.BlsAggregatedCommitEnabled(9))
+	aggregated.Feature.BlsAggregatedCommitEnableHeight = -1
+	assert.Error(t, aggregated.ValidateBasic())
+}
@@ -0,0 +1,333 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/libs/bits"
+	"github.com/cometbft/cometbft/libs/erasure"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// ErrParityPartSetInvalid is returned when the parity parts don't reconstruct
+// the parts of the part set.
+var ErrParityPartSetInvalid = errors.New("parity parts don't match the part set")
+
+// ParityPartSet holds the parity parts of the erasure coding of a part set:
+// its parts, padded to BlockPartSizeBytes, are extended with parity parts by
+// a Reed-Solomon code, so that any Total() of the parts and parity parts are
+// enough to reconstruct the missing parts. The parity parts are committed by
+// the merkle root of their header, like the parts, so each one is verified on
+// its own, and the reconstructed parts are verified against the header of the
+// part set.
+type ParityPartSet struct {
+	header       PartSetHeader // of the parity parts
+	dataTotal    uint32        // number of parts of the part set
+	lastPartSize uint32        // size of the last part of the part set, before padding
+
+	mtx           cmtsync.Mutex
+	parts         []*Part
+	partsBitArray *bits.BitArray
+	count         uint32
+	// dispersed marks the parts and parity parts already sent to a peer, when
+	// the parity parts were made from the complete part set.
+	dispersed *bits.BitArray
+	// dispersedAt is when the last of them was sent.
+	dispersedAt time.Time
+}
+
+// NumParityParts returns the number of parity parts of a part set of the
+// given total, for the given ratio of parity parts to parts, or 0 if the part
+// set is too large to be erasure coded.
+func NumParityParts(total uint32, ratio float64) uint32 {
+	if total == 0 || ratio <= 0 || total >= erasure.MaxShards {
+		return 0
+	}
+	parity := uint32(math.Ceil(float64(total) * ratio))
+	if parity > erasure.MaxShards-total {
+		parity = erasure.MaxShards - total
+	}
+	return parity
+}
+
+// NewParityPartSet computes the parity parts of a complete part set, made by
+// NewPartSetFromData with BlockPartSizeBytes.
+func NewParityPartSet(ps *PartSet, parityTotal uint32) (*ParityPartSet, error) {
+	if !ps.IsComplete() {
+		return nil, errors.New("part set is not complete")
+	}
+	code, err := erasure.New(int(ps.Total()), int(parityTotal))
+	if err != nil {
+		return nil, err
+	}
+
+	ps.mtx.Lock()
+	data := make([][]byte, ps.total)
+	for i, part := range ps.parts {
+		if i < len(ps.parts)-1 && len(part.Bytes) != int(BlockPartSizeBytes) {
+			ps.mtx.Unlock()
+			return nil, fmt.Errorf("part %d is not of %d bytes", i, BlockPartSizeBytes)
+		}
+		data[i] = padPart(part.Bytes)
+	}
+	lastPartSize := uint32(len(ps.parts[ps.total-1].Bytes))
+	ps.mtx.Unlock()
+
+	parity, err := code.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	root, proofs := merkle.ProofsFromByteSlices(parity)
+	pps := &ParityPartSet{
+		header:        PartSetHeader{Total: parityTotal, Hash: root},
+		dataTotal:     ps.Total(),
+		lastPartSize:  lastPartSize,
+		parts:         make([]*Part, parityTotal),
+		partsBitArray: bits.NewBitArray(int(parityTotal)),
+		count:         parityTotal,
+		dispersed:     bits.NewBitArray(int(ps.Total() + parityTotal)),
+	}
+	for i := range parity {
+		pps.parts[i] = &Part{Index: uint32(i), Bytes: parity[i], Proof: *proofs[i]}
+		pps.partsBitArray.SetIndex(i, true)
+	}
+	return pps, nil
+}
+
+// NewParityPartSetFromHeader returns an empty ParityPartSet, of the given
+// header, for a part set of dataTotal parts whose last part is of
+// lastPartSize bytes.
+func NewParityPartSetFromHeader(header PartSetHeader, dataTotal, lastPartSize uint32) (*ParityPartSet, error) {
+	if err := header.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if header.Total == 0 || dataTotal == 0 || header.Total+dataTotal > erasure.MaxShards {
+		return nil, fmt.Errorf("invalid number of parts %d+%d", dataTotal, header.Total)
+	}
+	if lastPartSize == 0 || lastPartSize > BlockPartSizeBytes {
+		return nil, fmt.Errorf("invalid last part size %d", lastPartSize)
+	}
+	return &ParityPartSet{
+		header:        header,
+		dataTotal:     dataTotal,
+		lastPartSize:  lastPartSize,
+		parts:         make([]*Part, header.Total),
+		partsBitArray: bits.NewBitArray(int(header.Total)),
+	}, nil
+}
+
+func padPart(bz []byte) []byte {
+	if len(bz) == int(BlockPartSizeBytes) {
+		return bz
+	}
+	padded := make([]byte, BlockPartSizeBytes)
+	copy(padded, bz)
+	return padded
+}
+
+// Header returns the header of the parity parts.
+func (pps *ParityPartSet) Header() PartSetHeader {
+	if pps == nil {
+		return PartSetHeader{}
+	}
+	return pps.header
+}
+
+// HasHeader tells whether the parity parts are of the given header.
+func (pps *ParityPartSet) HasHeader(header PartSetHeader) bool {
+	if pps == nil {
+		return false
+	}
+	return pps.header.Equals(header)
+}
+
+// DataTotal returns the number of parts of the part set.
+func (pps *ParityPartSet) DataTotal() uint32 {
+	return pps.dataTotal
+}
+
+// LastPartSize returns the size of the last part of the part set.
+func (pps *ParityPartSet) LastPartSize() uint32 {
+	return pps.lastPartSize
+}
+
+func (pps *ParityPartSet) BitArray() *bits.BitArray {
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	return pps.partsBitArray.Copy()
+}
+
+func (pps *ParityPartSet) Count() uint32 {
+	if pps == nil {
+		return 0
+	}
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	return pps.count
+}
+
+func (pps *ParityPartSet) Total() uint32 {
+	if pps == nil {
+		return 0
+	}
+	return pps.header.Total
+}
+
+// AddPart adds a parity part, verified against the header.
+func (pps *ParityPartSet) AddPart(part *Part) (bool, error) {
+	if pps == nil {
+		return false, nil
+	}
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+
+	if part.Index >= pps.header.Total {
+		return false, ErrPartSetUnexpectedIndex
+	}
+	if pps.parts[part.Index] != nil {
+		return false, nil
+	}
+	if len(part.Bytes) != int(BlockPartSizeBytes) || part.Proof.Verify(pps.header.Hash, part.Bytes) != nil {
+		return false, ErrPartSetInvalidProof
+	}
+	pps.parts[part.Index] = part
+	pps.partsBitArray.SetIndex(int(part.Index), true)
+	pps.count++
+	return true, nil
+}
+
+func (pps *ParityPartSet) GetPart(index int) *Part {
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	return pps.parts[index]
+}
+
+// ClaimDispersal returns the index of a part, below DataTotal(), or of a
+// parity part, above, that wasn't sent to a peer yet, and marks it as sent.
+// It returns false once all of them were sent, or if the parity parts weren't
+// made from the complete part set.
+func (pps *ParityPartSet) ClaimDispersal() (int, bool) {
+	if pps == nil || pps.dispersed == nil {
+		return 0, false
+	}
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	for i := 0; i < pps.dispersed.Size(); i++ {
+		if !pps.dispersed.GetIndex(i) {
+			pps.dispersed.SetIndex(i, true)
+			if i == pps.dispersed.Size()-1 {
+				pps.dispersedAt = cmttime.Now()
+			}
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// IsDispersing tells whether the parity parts were made from the complete
+// part set, and some of the parts or parity parts weren't sent to a peer yet.
+func (pps *ParityPartSet) IsDispersing() bool {
+	if pps == nil || pps.dispersed == nil {
+		return false
+	}
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	return !pps.dispersed.IsFull()
+}
+
+// DispersedAt returns when the last of the parts and parity parts was sent to
+// a peer, or the zero time if they weren't all sent.
+func (pps *ParityPartSet) DispersedAt() time.Time {
+	if pps == nil {
+		return time.Time{}
+	}
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	return pps.dispersedAt
+}
+
+// Reconstruct adds the missing parts of the part set, once it has enough parts
+// and parity parts to reconstruct them, and returns whether it did. It returns
+// ErrParityPartSetInvalid if the reconstructed parts don't match the header of
+// the part set.
+func (pps *ParityPartSet) Reconstruct(ps *PartSet) (bool, error) {
+	if pps == nil || ps == nil {
+		return false, nil
+	}
+	if ps.Total() != pps.dataTotal {
+		return false, fmt.Errorf("expected a part set of %d parts, got %d", pps.dataTotal, ps.Total())
+	}
+
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+	ps.mtx.Lock()
+	if ps.count == ps.total || ps.count+pps.count < ps.total {
+		ps.mtx.Unlock()
+		return false, nil
+	}
+	shards := make([][]byte, pps.dataTotal+pps.header.Total)
+	for i, part := range ps.parts {
+		if part != nil {
+			shards[i] = padPart(part.Bytes)
+		}
+	}
+	ps.mtx.Unlock()
+	for i, part := range pps.parts {
+		if part != nil {
+			shards[int(pps.dataTotal)+i] = part.Bytes
+		}
+	}
+
+	code, err := erasure.New(int(pps.dataTotal), int(pps.header.Total))
+	if err != nil {
+		return false, err
+	}
+	if err := code.ReconstructData(shards); err != nil {
+		return false, err
+	}
+	data := shards[:pps.dataTotal]
+	data[pps.dataTotal-1] = data[pps.dataTotal-1][:pps.lastPartSize]
+	root, proofs := merkle.ProofsFromByteSlices(data)
+	if !bytes.Equal(root, ps.Hash()) {
+		return false, ErrParityPartSetInvalid
+	}
+	for i, bz := range data {
+		if _, err := ps.AddPart(&Part{Index: uint32(i), Bytes: bz, Proof: *proofs[i]}); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// StringShort returns a short version of String.
+//
+// (Count of Total)
+func (pps *ParityPartSet) StringShort() string {
+	if pps == nil {
+		return "nil-ParityPartSet"
+	}
+	return fmt.Sprintf("(%v of %v)", pps.Count(), pps.Total())
+}
+
+func (pps *ParityPartSet) MarshalJSON() ([]byte, error) {
+	if pps == nil {
+		return []byte("{}"), nil
+	}
+
+	pps.mtx.Lock()
+	defer pps.mtx.Unlock()
+
+	return cmtjson.Marshal(struct {
+		CountTotal    string         `json:"count/total"`
+		PartsBitArray *bits.BitArray `json:"parts_bit_array"`
+	}{
+		fmt.Sprintf("%d/%d", pps.count, pps.header.Total),
+		pps.partsBitArray,
+	})
+}
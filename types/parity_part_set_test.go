@@ -0,0 +1,109 @@
+package types
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtrand "github.com/cometbft/cometbft/libs/rand"
+)
+
+func TestNumParityParts(t *testing.T) {
+	assert.EqualValues(t, 0, NumParityParts(10, 0))
+	assert.EqualValues(t, 0, NumParityParts(0, 0.5))
+	assert.EqualValues(t, 5, NumParityParts(10, 0.5))
+	assert.EqualValues(t, 1, NumParityParts(1, 0.1))
+	assert.EqualValues(t, 56, NumParityParts(200, 0.5))
+	assert.EqualValues(t, 0, NumParityParts(256, 0.5))
+}
+
+func TestParityPartSet(t *testing.T) {
+	data := cmtrand.Bytes(int(BlockPartSizeBytes)*4 + 100)
+	partSet := NewPartSetFromData(data, BlockPartSizeBytes)
+	require.EqualValues(t, 5, partSet.Total())
+
+	parity, err := NewParityPartSet(partSet, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, parity.Count())
+	assert.EqualValues(t, 100, parity.LastPartSize())
+	assert.True(t, parity.DispersedAt().IsZero())
+
+	// every part and parity part is dispersed once
+	for i := 0; i < 8; i++ {
+		assert.True(t, parity.IsDispersing())
+		index, ok := parity.ClaimDispersal()
+		require.True(t, ok)
+		assert.Equal(t, i, index)
+	}
+	assert.False(t, parity.IsDispersing())
+	assert.False(t, parity.DispersedAt().IsZero())
+	_, ok := parity.ClaimDispersal()
+	assert.False(t, ok)
+
+	// the parts are reconstructed from any 5 parts and parity parts
+	received := NewPartSetFromHeader(partSet.Header())
+	receivedParity, err := NewParityPartSetFromHeader(parity.Header(), partSet.Total(), parity.LastPartSize())
+	require.NoError(t, err)
+	assert.False(t, receivedParity.IsDispersing())
+	for _, i := range []int{1, 4} {
+		added, err := received.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	for _, i := range []int{0, 2} {
+		added, err := receivedParity.AddPart(parity.GetPart(i))
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	reconstructed, err := receivedParity.Reconstruct(received)
+	require.NoError(t, err)
+	assert.False(t, reconstructed)
+
+	added, err := receivedParity.AddPart(parity.GetPart(1))
+	require.NoError(t, err)
+	require.True(t, added)
+	reconstructed, err = receivedParity.Reconstruct(received)
+	require.NoError(t, err)
+	assert.True(t, reconstructed)
+	require.True(t, received.IsComplete())
+	bz, err := io.ReadAll(received.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, bz)
+
+	// invalid parity parts are rejected
+	part := *parity.GetPart(0)
+	part.Bytes = cmtrand.Bytes(int(BlockPartSizeBytes))
+	otherParity, err := NewParityPartSetFromHeader(parity.Header(), partSet.Total(), parity.LastPartSize())
+	require.NoError(t, err)
+	_, err = otherParity.AddPart(&part)
+	assert.ErrorIs(t, err, ErrPartSetInvalidProof)
+
+	// and so are the parity parts of another part set
+	otherPartSet := NewPartSetFromData(cmtrand.Bytes(len(data)), BlockPartSizeBytes)
+	otherParity, err = NewParityPartSet(otherPartSet, 3)
+	require.NoError(t, err)
+	received = NewPartSetFromHeader(partSet.Header())
+	_, err = received.AddPart(partSet.GetPart(0))
+	require.NoError(t, err)
+	_, err = received.AddPart(partSet.GetPart(1))
+	require.NoError(t, err)
+	_, err = otherParity.Reconstruct(received)
+	assert.ErrorIs(t, err, ErrParityPartSetInvalid)
+	assert.False(t, received.IsComplete())
+}
+
+func TestNewParityPartSetFromHeader(t *testing.T) {
+	header := PartSetHeader{Total: 2, Hash: cmtrand.Bytes(32)}
+	_, err := NewParityPartSetFromHeader(header, 255, 1)
+	assert.Error(t, err)
+	_, err = NewParityPartSetFromHeader(header, 2, 0)
+	assert.Error(t, err)
+	_, err = NewParityPartSetFromHeader(header, 2, BlockPartSizeBytes+1)
+	assert.Error(t, err)
+	_, err = NewParityPartSetFromHeader(PartSetHeader{Total: 2, Hash: []byte{1}}, 2, 1)
+	assert.Error(t, err)
+	_, err = NewParityPartSetFromHeader(header, 2, 1)
+	assert.NoError(t, err)
+}
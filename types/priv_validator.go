@@ -19,6 +19,13 @@ type PrivValidator interface {
 	SignProposal(chainID string, proposal *cmtproto.Proposal) error
 }
 
+// SignBytesVersionSetter is implemented by the PrivValidators which sign out of
+// process, to be told the version of the sign bytes in use, so that their
+// signers can refuse the versions they don't support.
+type SignBytesVersionSetter interface {
+	SetSignBytesVersion(version uint32)
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
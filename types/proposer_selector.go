@@ -0,0 +1,122 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+)
+
+// PriorityProposerSelectorName is the name of the default proposer selection
+// algorithm.
+const PriorityProposerSelectorName = "priority"
+
+// ProposerSelector selects the proposer of each round among a validator set.
+//
+// The selection must be deterministic: all the nodes of a network must use
+// the same algorithm, and select the same proposer from the same validator
+// set, since the proposer of a round is not agreed upon but computed by each
+// node. The ProposerPriority of the validators is the only state persisted
+// along with a validator set, so an algorithm that needs more, e.g. a seed
+// for a stake-weighted random selection, must derive it deterministically
+// from data the nodes agree on, such as the vote extensions of the last
+// block.
+type ProposerSelector interface {
+	// IncrementProposerPriority advances the validator set by times rounds,
+	// updating the ProposerPriority of its validators, and returns the
+	// proposer of the last round. vals is not empty and times is positive.
+	IncrementProposerPriority(vals *ValidatorSet, times int32) *Validator
+	// FindProposer returns the proposer of the current round of a validator
+	// set whose proposer is unknown, from the ProposerPriority of its
+	// validators. vals is not empty.
+	FindProposer(vals *ValidatorSet) *Validator
+}
+
+var (
+	proposerSelectorsMtx cmtsync.RWMutex
+	// proposerSelectors contains the registered proposer selection algorithms.
+	proposerSelectors = map[string]ProposerSelector{
+		PriorityProposerSelectorName: PriorityProposerSelector{},
+	}
+	// proposerSelector is the algorithm used by all the validator sets.
+	proposerSelector ProposerSelector = PriorityProposerSelector{}
+)
+
+// RegisterProposerSelector registers a proposer selection algorithm under the
+// given name, so it can be selected with SetProposerSelector.
+//
+// Should only be called in init() functions, as it panics if the name is
+// empty or already registered.
+func RegisterProposerSelector(name string, selector ProposerSelector) {
+	if name == "" {
+		panic("proposer selector name cannot be empty")
+	}
+	if selector == nil {
+		panic("cannot register nil proposer selector")
+	}
+	proposerSelectorsMtx.Lock()
+	defer proposerSelectorsMtx.Unlock()
+	if _, ok := proposerSelectors[name]; ok {
+		panic(fmt.Sprintf("proposer selector %q is already registered", name))
+	}
+	proposerSelectors[name] = selector
+}
+
+// SetProposerSelector sets the proposer selection algorithm of all the
+// validator sets to the one registered under the given name. It must be
+// called before any validator set is created or loaded, as changing the
+// algorithm of a running network forks it.
+func SetProposerSelector(name string) error {
+	proposerSelectorsMtx.Lock()
+	defer proposerSelectorsMtx.Unlock()
+	selector, ok := proposerSelectors[name]
+	if !ok {
+		return fmt.Errorf("unknown proposer selector %q", name)
+	}
+	proposerSelector = selector
+	return nil
+}
+
+// GetProposerSelector returns the proposer selection algorithm of the
+// validator sets.
+func GetProposerSelector() ProposerSelector {
+	proposerSelectorsMtx.RLock()
+	defer proposerSelectorsMtx.RUnlock()
+	return proposerSelector
+}
+
+// PriorityProposerSelector is the default ProposerSelector, a weighted round
+// robin: every round, the ProposerPriority of each validator grows by its
+// voting power, and the validator with the highest priority is the proposer,
+// whose priority then drops by the total voting power.
+type PriorityProposerSelector struct{}
+
+var _ ProposerSelector = PriorityProposerSelector{}
+
+// IncrementProposerPriority implements ProposerSelector.
+func (PriorityProposerSelector) IncrementProposerPriority(vals *ValidatorSet, times int32) *Validator {
+	// Cap the difference between priorities to be proportional to 2*totalPower by
+	// re-normalizing priorities, i.e., rescale all priorities by multiplying with:
+	//  2*totalVotingPower/(maxPriority - minPriority)
+	diffMax := PriorityWindowSizeFactor * vals.TotalVotingPower()
+	vals.RescalePriorities(diffMax)
+	vals.shiftByAvgProposerPriority()
+
+	var proposer *Validator
+	// Call IncrementProposerPriority(1) times times.
+	for i := int32(0); i < times; i++ {
+		proposer = vals.incrementProposerPriority()
+	}
+	return proposer
+}
+
+// FindProposer implements ProposerSelector.
+func (PriorityProposerSelector) FindProposer(vals *ValidatorSet) *Validator {
+	var proposer *Validator
+	for _, val := range vals.Validators {
+		if proposer == nil || !bytes.Equal(val.Address, proposer.Address) {
+			proposer = proposer.CompareProposerPriority(val)
+		}
+	}
+	return proposer
+}
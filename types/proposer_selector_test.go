@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lastProposerSelector always selects the last validator of the set.
+type lastProposerSelector struct{}
+
+func (lastProposerSelector) IncrementProposerPriority(vals *ValidatorSet, _ int32) *Validator {
+	return vals.Validators[len(vals.Validators)-1]
+}
+
+func (lastProposerSelector) FindProposer(vals *ValidatorSet) *Validator {
+	return vals.Validators[len(vals.Validators)-1]
+}
+
+func TestProposerSelector(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterProposerSelector(PriorityProposerSelectorName, lastProposerSelector{})
+	})
+	assert.Error(t, SetProposerSelector("unknown"))
+
+	RegisterProposerSelector("last", lastProposerSelector{})
+	require.NoError(t, SetProposerSelector("last"))
+	t.Cleanup(func() {
+		require.NoError(t, SetProposerSelector(PriorityProposerSelectorName))
+	})
+
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 3),
+		newValidator([]byte("b"), 2),
+		newValidator([]byte("c"), 1),
+	})
+	for i := 0; i < 3; i++ {
+		assert.EqualValues(t, []byte("c"), vals.GetProposer().Address)
+		vals.IncrementProposerPriority(1)
+	}
+
+	vals.Proposer = nil
+	assert.EqualValues(t, []byte("c"), vals.GetProposer().Address)
+}
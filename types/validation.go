@@ -5,14 +5,22 @@ import (
 	"fmt"
 
 	"github.com/cometbft/cometbft/crypto/batch"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 )
 
 const batchVerifyThreshold = 2
 
+// shouldBatchVerify returns true if the signatures of the commit can be batch
+// verified: there are enough of them, and all the validators have keys of the
+// same type, which supports batch verification.
 func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
-	return len(commit.Signatures) >= batchVerifyThreshold && batch.SupportsBatchVerifier(vals.GetProposer().PubKey)
+	if len(commit.Signatures) < batchVerifyThreshold {
+		return false
+	}
+	pubKey := vals.GetProposer().PubKey
+	return batch.SupportsBatchVerifier(pubKey) && vals.AllKeysHaveType(pubKey.Type())
 }
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
@@ -39,6 +47,11 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	// only count the signatures that are for the block
 	count := func(c CommitSig) bool { return c.ForBlock() }
 
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -72,6 +85,11 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -116,6 +134,15 @@ func VerifyCommitLightTrusting(chainID string, vals *ValidatorSet, commit *Commi
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	// the signers of an aggregated commit must all be in the validator set, as
+	// the aggregated signature can only be verified as a whole. Otherwise, no
+	// voting power is trusted, and the light client verifies the headers in
+	// between instead of skipping to this one.
+	if commit.IsAggregated() {
+		return verifyCommitAggregated(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, false)
+	}
+
 	// attempt to batch verify commit. As the validator set doesn't necessarily
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
@@ -145,7 +172,8 @@ func ValidateHash(h []byte) error {
 
 // verifyCommitBatch batch verifies commits.  This routine is equivalent
 // to verifyCommitSingle in behavior, just faster iff every signature in the
-// batch is valid.
+// batch is valid. If the batch fails, the signatures are verified one by one
+// with verifyCommitSingle, to find the invalid one.
 //
 // Note: The caller is responsible for checking to see if this routine is
 // usable via `shouldVerifyBatch(vals, commit)`.
@@ -163,15 +191,14 @@ func verifyCommitBatch(
 		val                *Validator
 		valIdx             int32
 		seenVals           = make(map[int32]int, len(commit.Signatures))
-		batchSigIdxs       = make([]int, 0, len(commit.Signatures))
 		talliedVotingPower int64
 	)
 	// attempt to create a batch verifier
 	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
 	// re-check if batch verification is supported
-	if !ok || len(commit.Signatures) < batchVerifyThreshold {
-		// This should *NEVER* happen.
-		return fmt.Errorf("unsupported signature algorithm or insufficient signatures for batch verification")
+	if !ok {
+		return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+			ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 	}
 
 	for idx, commitSig := range commit.Signatures {
@@ -205,11 +232,12 @@ func verifyCommitBatch(
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 
-		// add the key, sig and message to the verifier
+		// add the key, sig and message to the verifier, falling back to single
+		// verification if it can't be batched, e.g. if it is malformed
 		if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-			return err
+			return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+				ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 		}
-		batchSigIdxs = append(batchSigIdxs, idx)
 
 		// If this signature counts then add the voting power of the validator
 		// to the tally
@@ -231,28 +259,15 @@ func verifyCommitBatch(
 	}
 
 	// attempt to verify the batch.
-	ok, validSigs := bv.Verify()
-	if ok {
+	if ok, _ := bv.Verify(); ok {
 		// success
 		return nil
 	}
 
-	// one or more of the signatures is invalid, find and return the first
-	// invalid signature.
-	for i, ok := range validSigs {
-		if !ok {
-			// go back from the batch index to the commit.Signatures index
-			idx := batchSigIdxs[i]
-			sig := commit.Signatures[idx]
-			return fmt.Errorf("wrong signature (#%d): %X", idx, sig)
-		}
-	}
-
-	// execution reaching here is a bug, and one of the following has
-	// happened:
-	//  * non-zero tallied voting power, empty batch (impossible?)
-	//  * bv.Verify() returned `false, []bool{true, ..., true}` (BUG)
-	return fmt.Errorf("BUG: batch verification failed with no invalid signatures")
+	// one or more of the signatures is invalid: fall back to single
+	// verification, which returns the first invalid signature.
+	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+		ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 }
 
 // Single Verification
@@ -331,6 +346,73 @@ func verifyCommitSingle(
 	return nil
 }
 
+// Aggregated Verification
+
+// verifyCommitAggregated verifies the aggregated signature of a commit. As the
+// aggregated signature covers all the signatures of the commit, all of them
+// are verified, ignored or not, and the validator of each one must be known:
+// if one isn't, ErrNotEnoughVotingPowerSigned is returned, with no voting
+// power. The voting power is tallied as in verifyCommitSingle.
+func verifyCommitAggregated(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	lookUpByIndex bool,
+) error {
+	var (
+		val                *Validator
+		valIdx             int32
+		seenVals           = make(map[int32]int, len(commit.Signatures))
+		pubKeys            = make([]bls12381.PubKey, 0, len(commit.Signatures))
+		msgs               = make([][]byte, 0, len(commit.Signatures))
+		talliedVotingPower int64
+	)
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+
+		if lookUpByIndex {
+			val = vals.Validators[idx]
+		} else {
+			valIdx, val = vals.GetByAddress(commitSig.ValidatorAddress)
+			if val == nil {
+				return ErrNotEnoughVotingPowerSigned{Got: 0, Needed: votingPowerNeeded}
+			}
+			if firstIndex, ok := seenVals[valIdx]; ok {
+				secondIndex := idx
+				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+			}
+			seenVals[valIdx] = idx
+		}
+
+		pubKey, ok := val.PubKey.(bls12381.PubKey)
+		if !ok {
+			return fmt.Errorf("validator %v (#%d) of the aggregated commit doesn't have a %s key",
+				val, idx, bls12381.KeyType)
+		}
+		pubKeys = append(pubKeys, pubKey)
+		msgs = append(msgs, commit.VoteSignBytes(chainID, int32(idx)))
+
+		if !ignoreSig(commitSig) && countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	if !bls12381.VerifyAggregateSignature(pubKeys, msgs, commit.AggregatedSignature) {
+		return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+	}
+
+	return nil
+}
+
 func verifyBasicValsAndCommit(vals *ValidatorSet, commit *Commit, height int64, blockID BlockID) error {
 	if vals == nil {
 		return errors.New("nil validator set")
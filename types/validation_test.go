@@ -1,12 +1,15 @@
 package types
 
 import (
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 )
@@ -259,3 +262,125 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 		assert.Contains(t, err.Error(), "int64 overflow")
 	}
 }
+
+func TestValidatorSet_VerifyCommit_MixedKeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	// An ed25519 proposer doesn't make the BLS12-381 signature batched.
+	edVal, edPrivVal := RandValidator(false, 100)
+	blsPrivVal := NewMockPVWithParams(bls12381.GenPrivKey(), false, false)
+	valSet := NewValidatorSet([]*Validator{edVal, blsPrivVal.ExtractIntoValidator(10)})
+	require.Equal(t, ed25519.KeyType, valSet.GetProposer().PubKey.Type())
+	assert.False(t, shouldBatchVerify(valSet, &Commit{Signatures: make([]CommitSig, 2)}))
+
+	// The voting power orders the set, ed25519 validator first.
+	privVals := []PrivValidator{edPrivVal, blsPrivVal}
+	voteSet := NewVoteSet(chainID, h, 0, cmtproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	assert.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+	assert.NoError(t, valSet.VerifyCommitLightTrusting(chainID, commit,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+}
+
+func TestValidatorSet_VerifyCommit_BatchFallback(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, cmtproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	require.True(t, shouldBatchVerify(valSet, commit))
+
+	// A malformed signature can't be batched and is found by single
+	// verification.
+	commit.Signatures[1].Signature = commit.Signatures[1].Signature[:10]
+	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#1)")
+	}
+}
+
+func TestValidatorSet_VerifyCommit_Aggregated(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randBlsVoteSet(h, 0, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	// the last validator is absent
+	commit.Signatures[3] = NewCommitSigAbsent()
+
+	aggregated, err := commit.Aggregate()
+	require.NoError(t, err)
+	require.True(t, aggregated.IsAggregated())
+	require.NoError(t, aggregated.ValidateBasic())
+	for _, commitSig := range aggregated.Signatures {
+		assert.Empty(t, commitSig.Signature)
+	}
+	assert.NotEqual(t, commit.Hash(), aggregated.Hash())
+
+	pb := aggregated.ToProto()
+	fromProto, err := CommitFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, aggregated.AggregatedSignature, fromProto.AggregatedSignature)
+
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, fromProto))
+	assert.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, fromProto))
+	assert.NoError(t, valSet.VerifyCommitLightTrusting(chainID, fromProto,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// with a signer unknown to the trusted validator set, no voting power can
+	// be trusted, so that the light client doesn't skip to this commit
+	newValSet := NewValidatorSet(valSet.Validators[1:])
+	err = newValSet.VerifyCommitLightTrusting(chainID, fromProto,
+		cmtmath.Fraction{Numerator: 1, Denominator: 3})
+	assert.ErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+
+	// a tampered timestamp fails the verification
+	fromProto.Signatures[0].Timestamp = fromProto.Signatures[0].Timestamp.Add(time.Second)
+	err = valSet.VerifyCommit(chainID, blockID, h, fromProto)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong aggregated signature")
+	}
+
+	// the signatures must be left empty
+	aggregated.Signatures[0].Signature = commit.Signatures[0].Signature
+	assert.Error(t, aggregated.ValidateBasic())
+
+	// ed25519 signatures can't be aggregated
+	edVoteSet, _, edVals := randVoteSet(h, 0, cmtproto.PrecommitType, 4, 10)
+	edCommit, err := MakeCommit(blockID, h, 0, edVoteSet, edVals, time.Now())
+	require.NoError(t, err)
+	_, err = edCommit.Aggregate()
+	assert.Error(t, err)
+}
+
+// NOTE: privValidators are in order
+func randBlsVoteSet(height int64, round int32, numValidators int, votingPower int64,
+) (*VoteSet, *ValidatorSet, []PrivValidator) {
+	var (
+		valz           = make([]*Validator, numValidators)
+		privValidators = make([]PrivValidator, numValidators)
+	)
+	for i := 0; i < numValidators; i++ {
+		privVal := NewMockPVWithParams(bls12381.GenPrivKey(), false, false)
+		valz[i] = privVal.ExtractIntoValidator(votingPower)
+		privValidators[i] = privVal
+	}
+	sort.Sort(PrivValidatorsByAddress(privValidators))
+	valSet := NewValidatorSet(valz)
+	return NewVoteSet("test_chain_id", height, round, cmtproto.PrecommitType, valSet), valSet, privValidators
+}
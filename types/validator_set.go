@@ -111,7 +111,8 @@ func (vals *ValidatorSet) CopyIncrementProposerPriority(times int32) *ValidatorS
 }
 
 // IncrementProposerPriority increments ProposerPriority of each validator and
-// updates the proposer. Panics if validator set is empty.
+// updates the proposer, with the algorithm set by SetProposerSelector. Panics
+// if validator set is empty.
 // `times` must be positive.
 func (vals *ValidatorSet) IncrementProposerPriority(times int32) {
 	if vals.IsNilOrEmpty() {
@@ -121,20 +122,7 @@ func (vals *ValidatorSet) IncrementProposerPriority(times int32) {
 		panic("Cannot call IncrementProposerPriority with non-positive times")
 	}
 
-	// Cap the difference between priorities to be proportional to 2*totalPower by
-	// re-normalizing priorities, i.e., rescale all priorities by multiplying with:
-	//  2*totalVotingPower/(maxPriority - minPriority)
-	diffMax := PriorityWindowSizeFactor * vals.TotalVotingPower()
-	vals.RescalePriorities(diffMax)
-	vals.shiftByAvgProposerPriority()
-
-	var proposer *Validator
-	// Call IncrementProposerPriority(1) times times.
-	for i := int32(0); i < times; i++ {
-		proposer = vals.incrementProposerPriority()
-	}
-
-	vals.Proposer = proposer
+	vals.Proposer = GetProposerSelector().IncrementProposerPriority(vals, times)
 }
 
 // RescalePriorities rescales the priorities such that the distance between the
@@ -265,6 +253,20 @@ func (vals *ValidatorSet) HasAddress(address []byte) bool {
 	return false
 }
 
+// AllKeysHaveType returns true if the keys of all the validators are of the
+// given type, e.g. to aggregate their signatures.
+func (vals *ValidatorSet) AllKeysHaveType(keyType string) bool {
+	if vals.IsNilOrEmpty() {
+		return false
+	}
+	for _, val := range vals.Validators {
+		if val.PubKey == nil || val.PubKey.Type() != keyType {
+			return false
+		}
+	}
+	return true
+}
+
 // GetByAddress returns an index of the validator with address and validator
 // itself (copy) if found. Otherwise, -1 and nil are returned.
 func (vals *ValidatorSet) GetByAddress(address []byte) (index int32, val *Validator) {
@@ -327,21 +329,11 @@ func (vals *ValidatorSet) GetProposer() (proposer *Validator) {
 		return nil
 	}
 	if vals.Proposer == nil {
-		vals.Proposer = vals.findProposer()
+		vals.Proposer = GetProposerSelector().FindProposer(vals)
 	}
 	return vals.Proposer.Copy()
 }
 
-func (vals *ValidatorSet) findProposer() *Validator {
-	var proposer *Validator
-	for _, val := range vals.Validators {
-		if proposer == nil || !bytes.Equal(val.Address, proposer.Address) {
-			proposer = proposer.CompareProposerPriority(val)
-		}
-	}
-	return proposer
-}
-
 // Hash returns the Merkle root hash build using validators (as leaves) in the
 // set.
 func (vals *ValidatorSet) Hash() []byte {